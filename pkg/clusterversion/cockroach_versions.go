@@ -494,6 +494,11 @@ const (
 	// the process of upgrading from previous supported releases to 24.1.
 	V24_1Start
 
+	// V24_1_AddSystemPublicationsTable is the version at which Cockroach
+	// creates the system.publications table, which stores the publications
+	// used for logical replication.
+	V24_1_AddSystemPublicationsTable
+
 	// *************************************************
 	// Step (1) Add new versions here.
 	// Do not add new versions to a patch release.
@@ -830,6 +835,10 @@ var rawVersionsSingleton = keyedVersions{
 		Key:     V24_1Start,
 		Version: roachpb.Version{Major: 23, Minor: 2, Internal: 2},
 	},
+	{
+		Key:     V24_1_AddSystemPublicationsTable,
+		Version: roachpb.Version{Major: 23, Minor: 2, Internal: 4},
+	},
 
 	// *************************************************
 	// Step (2): Add new versions here.
@@ -0,0 +1,182 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package geometric
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/errors"
+)
+
+// EncodeBinary appends the Postgres binary representation of the shape to
+// buf. This is the format used by the pgwire protocol, and is also used to
+// store geometric values on disk.
+func EncodeBinary(buf []byte, s Shape) []byte {
+	return s.appendBinary(buf)
+}
+
+// DecodeBinary decodes the Postgres binary representation of a shape of the
+// given kind.
+func DecodeBinary(k Kind, b []byte) (Shape, error) {
+	d := decoder{b: b}
+	var res Shape
+	switch k {
+	case PointKind:
+		res = d.point()
+	case LSegKind:
+		res = LSeg{P: [2]Point{d.point(), d.point()}}
+	case LineKind:
+		l := Line{A: d.float(), B: d.float(), C: d.float()}
+		if d.err == nil && l.A == 0 && l.B == 0 {
+			return nil, pgerror.New(pgcode.InvalidParameterValue,
+				"invalid line specification: A and B cannot both be zero")
+		}
+		res = l
+	case BoxKind:
+		res = MakeBox(d.point(), d.point())
+	case PathKind:
+		closed := d.byte()
+		res = Path{Closed: closed != 0, Points: d.points()}
+	case PolygonKind:
+		res = MakePolygon(d.points())
+	case CircleKind:
+		c := Circle{Center: d.point(), Radius: d.float()}
+		if d.err == nil && c.Radius < 0 {
+			return nil, pgerror.New(pgcode.InvalidBinaryRepresentation,
+				"invalid radius in external \"circle\" value")
+		}
+		res = c
+	default:
+		return nil, errors.AssertionFailedf("unknown geometric kind %d", k)
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+	if len(d.b) != 0 {
+		return nil, pgerror.Newf(pgcode.InvalidBinaryRepresentation,
+			"incorrect binary data format for type %s", k)
+	}
+	return res, nil
+}
+
+// decoder reads big-endian values from a buffer. After the first error, all
+// reads return zero values and the error is kept in err.
+type decoder struct {
+	b   []byte
+	err error
+}
+
+func (d *decoder) insufficientData() {
+	if d.err == nil {
+		d.err = pgerror.New(pgcode.InvalidBinaryRepresentation, "insufficient data left in message")
+	}
+	d.b = nil
+}
+
+func (d *decoder) byte() byte {
+	if len(d.b) < 1 {
+		d.insufficientData()
+		return 0
+	}
+	res := d.b[0]
+	d.b = d.b[1:]
+	return res
+}
+
+func (d *decoder) float() float64 {
+	if len(d.b) < 8 {
+		d.insufficientData()
+		return 0
+	}
+	res := math.Float64frombits(binary.BigEndian.Uint64(d.b))
+	d.b = d.b[8:]
+	return res
+}
+
+func (d *decoder) point() Point {
+	x := d.float()
+	return Point{X: x, Y: d.float()}
+}
+
+func (d *decoder) points() []Point {
+	if len(d.b) < 4 {
+		d.insufficientData()
+		return nil
+	}
+	n := int32(binary.BigEndian.Uint32(d.b))
+	d.b = d.b[4:]
+	if n <= 0 || int(n) > len(d.b)/16 {
+		if d.err == nil {
+			d.err = pgerror.New(pgcode.InvalidBinaryRepresentation,
+				"invalid number of points in external value")
+		}
+		return nil
+	}
+	res := make([]Point, n)
+	for i := range res {
+		res[i] = d.point()
+	}
+	return res
+}
+
+func appendBinaryFloat(buf []byte, f float64) []byte {
+	return binary.BigEndian.AppendUint64(buf, math.Float64bits(f))
+}
+
+func appendBinaryPoints(buf []byte, points []Point) []byte {
+	buf = binary.BigEndian.AppendUint32(buf, uint32(len(points)))
+	for _, pt := range points {
+		buf = pt.appendBinary(buf)
+	}
+	return buf
+}
+
+func (p Point) appendBinary(buf []byte) []byte {
+	buf = appendBinaryFloat(buf, p.X)
+	return appendBinaryFloat(buf, p.Y)
+}
+
+func (s LSeg) appendBinary(buf []byte) []byte {
+	buf = s.P[0].appendBinary(buf)
+	return s.P[1].appendBinary(buf)
+}
+
+func (l Line) appendBinary(buf []byte) []byte {
+	buf = appendBinaryFloat(buf, l.A)
+	buf = appendBinaryFloat(buf, l.B)
+	return appendBinaryFloat(buf, l.C)
+}
+
+func (b Box) appendBinary(buf []byte) []byte {
+	buf = b.High.appendBinary(buf)
+	return b.Low.appendBinary(buf)
+}
+
+func (p Path) appendBinary(buf []byte) []byte {
+	if p.Closed {
+		buf = append(buf, 1)
+	} else {
+		buf = append(buf, 0)
+	}
+	return appendBinaryPoints(buf, p.Points)
+}
+
+func (p Polygon) appendBinary(buf []byte) []byte {
+	return appendBinaryPoints(buf, p.Points)
+}
+
+func (c Circle) appendBinary(buf []byte) []byte {
+	buf = c.Center.appendBinary(buf)
+	return appendBinaryFloat(buf, c.Radius)
+}
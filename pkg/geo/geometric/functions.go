@@ -0,0 +1,218 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package geometric
+
+import (
+	"math"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/errors"
+)
+
+// DefaultCirclePolygonPoints is the number of points of the polygon that
+// approximates a circle when a circle is cast to a polygon.
+const DefaultCirclePolygonPoints = 12
+
+// Length returns the length of the segment.
+func (s LSeg) Length() float64 {
+	return distPointPoint(s.P[0], s.P[1])
+}
+
+// Center returns the middle of the segment.
+func (s LSeg) Center() Point {
+	return Point{X: (s.P[0].X + s.P[1].X) / 2, Y: (s.P[0].Y + s.P[1].Y) / 2}
+}
+
+// Center returns the center of the box.
+func (b Box) Center() Point {
+	return Point{X: (b.High.X + b.Low.X) / 2, Y: (b.High.Y + b.Low.Y) / 2}
+}
+
+// Width returns the horizontal size of the box.
+func (b Box) Width() float64 {
+	return b.High.X - b.Low.X
+}
+
+// Height returns the vertical size of the box.
+func (b Box) Height() float64 {
+	return b.High.Y - b.Low.Y
+}
+
+// Area returns the area of the box.
+func (b Box) Area() float64 {
+	return b.Width() * b.Height()
+}
+
+// Length returns the total length of the segments of the path.
+func (p Path) Length() float64 {
+	var res float64
+	for _, s := range p.segments() {
+		res += s.Length()
+	}
+	return res
+}
+
+// Area returns the area enclosed by a closed path. ok is false if the path is
+// open. If the path intersects itself, the result may be meaningless.
+func (p Path) Area() (_ float64, ok bool) {
+	if !p.Closed {
+		return 0, false
+	}
+	return ringArea(p.Points), true
+}
+
+// Center returns the average of the vertices of the polygon.
+func (p Polygon) Center() Point {
+	var res Point
+	for _, pt := range p.Points {
+		res.X += pt.X
+		res.Y += pt.Y
+	}
+	n := float64(len(p.Points))
+	return Point{X: res.X / n, Y: res.Y / n}
+}
+
+// Area returns the area of the circle.
+func (c Circle) Area() float64 {
+	return math.Pi * c.Radius * c.Radius
+}
+
+// Diameter returns the diameter of the circle.
+func (c Circle) Diameter() float64 {
+	return 2 * c.Radius
+}
+
+// ringArea returns the area of the polygon formed by the given points, using
+// the shoelace formula.
+func ringArea(points []Point) float64 {
+	var res float64
+	for i := range points {
+		j := (i + 1) % len(points)
+		res += points[i].X*points[j].Y - points[i].Y*points[j].X
+	}
+	return math.Abs(res / 2)
+}
+
+// CircleToPolygon returns a polygon with n points that approximates the
+// circle.
+func CircleToPolygon(c Circle, n int) (Polygon, error) {
+	if c.Radius == 0 {
+		return Polygon{}, pgerror.New(pgcode.FeatureNotSupported,
+			"cannot convert circle with radius zero to polygon")
+	}
+	if n < 2 {
+		return Polygon{}, pgerror.New(pgcode.InvalidParameterValue,
+			"must request at least 2 points")
+	}
+	points := make([]Point, n)
+	step := 2 * math.Pi / float64(n)
+	for i := range points {
+		angle := float64(i) * step
+		points[i] = Point{
+			X: c.Center.X - c.Radius*math.Cos(angle),
+			Y: c.Center.Y + c.Radius*math.Sin(angle),
+		}
+	}
+	return MakePolygon(points), nil
+}
+
+type convertFn func(Shape) (Shape, error)
+
+// conversions lists the casts between different geometric types.
+var conversions = map[kindPair]convertFn{
+	{PointKind, BoxKind}: func(s Shape) (Shape, error) {
+		p := s.(Point)
+		return Box{High: p, Low: p}, nil
+	},
+	{LSegKind, PointKind}: func(s Shape) (Shape, error) {
+		return s.(LSeg).Center(), nil
+	},
+	{BoxKind, PointKind}: func(s Shape) (Shape, error) {
+		return s.(Box).Center(), nil
+	},
+	{BoxKind, LSegKind}: func(s Shape) (Shape, error) {
+		b := s.(Box)
+		return LSeg{P: [2]Point{b.High, b.Low}}, nil
+	},
+	{BoxKind, PolygonKind}: func(s Shape) (Shape, error) {
+		c := s.(Box).corners()
+		return MakePolygon(c[:]), nil
+	},
+	{BoxKind, CircleKind}: func(s Shape) (Shape, error) {
+		b := s.(Box)
+		center := b.Center()
+		return Circle{Center: center, Radius: distPointPoint(center, b.High)}, nil
+	},
+	{PathKind, PolygonKind}: func(s Shape) (Shape, error) {
+		p := s.(Path)
+		if !p.Closed {
+			return nil, pgerror.New(pgcode.InvalidParameterValue,
+				"open path cannot be converted to polygon")
+		}
+		return MakePolygon(append([]Point(nil), p.Points...)), nil
+	},
+	{PolygonKind, PointKind}: func(s Shape) (Shape, error) {
+		return s.(Polygon).Center(), nil
+	},
+	{PolygonKind, PathKind}: func(s Shape) (Shape, error) {
+		p := s.(Polygon)
+		return Path{Closed: true, Points: append([]Point(nil), p.Points...)}, nil
+	},
+	{PolygonKind, BoxKind}: func(s Shape) (Shape, error) {
+		return s.(Polygon).BoundBox, nil
+	},
+	{PolygonKind, CircleKind}: func(s Shape) (Shape, error) {
+		p := s.(Polygon)
+		center := p.Center()
+		var radius float64
+		for _, pt := range p.Points {
+			radius += distPointPoint(center, pt)
+		}
+		return Circle{Center: center, Radius: radius / float64(len(p.Points))}, nil
+	},
+	{CircleKind, PointKind}: func(s Shape) (Shape, error) {
+		return s.(Circle).Center, nil
+	},
+	{CircleKind, BoxKind}: func(s Shape) (Shape, error) {
+		c := s.(Circle)
+		delta := c.Radius / math.Sqrt2
+		return Box{
+			High: Point{X: c.Center.X + delta, Y: c.Center.Y + delta},
+			Low:  Point{X: c.Center.X - delta, Y: c.Center.Y - delta},
+		}, nil
+	},
+	{CircleKind, PolygonKind}: func(s Shape) (Shape, error) {
+		return CircleToPolygon(s.(Circle), DefaultCirclePolygonPoints)
+	},
+}
+
+// CanConvert returns whether shapes of kind from can be converted to kind to.
+func CanConvert(from, to Kind) bool {
+	if from == to {
+		return true
+	}
+	_, ok := conversions[kindPair{from, to}]
+	return ok
+}
+
+// Convert converts the shape to the given kind. This implements the casts
+// between the geometric types.
+func Convert(s Shape, to Kind) (Shape, error) {
+	if s.Kind() == to {
+		return s, nil
+	}
+	fn, ok := conversions[kindPair{s.Kind(), to}]
+	if !ok {
+		return nil, errors.AssertionFailedf("cannot convert %s to %s", s.Kind(), to)
+	}
+	return fn(s)
+}
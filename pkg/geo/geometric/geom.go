@@ -0,0 +1,88 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package geometric
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/twpayne/go-geom"
+)
+
+// CanConvertToGeomT returns whether shapes of the given kind can be converted
+// to and from geometries. As in PostGIS, only points, paths and polygons can.
+func CanConvertToGeomT(k Kind) bool {
+	switch k {
+	case PointKind, PathKind, PolygonKind:
+		return true
+	}
+	return false
+}
+
+// ToGeomT converts the shape to the equivalent geometry. A point becomes a
+// POINT, a path becomes a LINESTRING and a polygon becomes a POLYGON. This
+// matches the casts to geometry that PostGIS defines.
+func ToGeomT(s Shape) (geom.T, error) {
+	switch t := s.(type) {
+	case Point:
+		return geom.NewPointFlat(geom.XY, []float64{t.X, t.Y}), nil
+	case Path:
+		return geom.NewLineStringFlat(geom.XY, flatCoords(t.Points)), nil
+	case Polygon:
+		points := t.Points
+		if len(points) > 0 && points[0] != points[len(points)-1] {
+			// Geometry polygon rings must be closed explicitly.
+			points = append(points[:len(points):len(points)], points[0])
+		}
+		coords := flatCoords(points)
+		return geom.NewPolygonFlat(geom.XY, coords, []int{len(coords)}), nil
+	}
+	return nil, pgerror.Newf(pgcode.CannotCoerce, "cannot convert %s to geometry", s.Kind())
+}
+
+// FromGeomT converts a geometry to a shape of the given kind. A point can only
+// be made from a POINT, a path from a LINESTRING and a polygon from the outer
+// ring of a POLYGON. Any Z or M coordinates are dropped.
+func FromGeomT(g geom.T, k Kind) (Shape, error) {
+	switch k {
+	case PointKind:
+		if p, ok := g.(*geom.Point); ok && !p.Empty() {
+			return Point{X: p.X(), Y: p.Y()}, nil
+		}
+		return nil, pgerror.New(pgcode.InvalidParameterValue, "geometry_to_point only accepts Points")
+	case PathKind:
+		if l, ok := g.(*geom.LineString); ok && !l.Empty() {
+			return Path{Points: pointsFromCoords(l.Coords())}, nil
+		}
+		return nil, pgerror.New(pgcode.InvalidParameterValue, "geometry_to_path only accepts LineStrings")
+	case PolygonKind:
+		if p, ok := g.(*geom.Polygon); ok && p.NumLinearRings() > 0 {
+			return MakePolygon(pointsFromCoords(p.LinearRing(0).Coords())), nil
+		}
+		return nil, pgerror.New(pgcode.InvalidParameterValue, "geometry_to_polygon only accepts Polygons")
+	}
+	return nil, pgerror.Newf(pgcode.CannotCoerce, "cannot convert geometry to %s", k)
+}
+
+func flatCoords(points []Point) []float64 {
+	res := make([]float64, 0, 2*len(points))
+	for _, p := range points {
+		res = append(res, p.X, p.Y)
+	}
+	return res
+}
+
+func pointsFromCoords(coords []geom.Coord) []Point {
+	res := make([]Point, len(coords))
+	for i, c := range coords {
+		res[i] = Point{X: c.X(), Y: c.Y()}
+	}
+	return res
+}
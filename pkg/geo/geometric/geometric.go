@@ -0,0 +1,328 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package geometric implements the Postgres built-in geometric types: point,
+// lseg, line, box, path, polygon and circle. See
+// https://www.postgresql.org/docs/current/datatype-geometric.html.
+//
+// Unlike the types in the geo package, these are plain two-dimensional
+// Cartesian shapes without an SRID, and their text and binary formats match
+// the ones Postgres uses.
+package geometric
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+)
+
+// Kind identifies one of the geometric types.
+type Kind uint8
+
+const (
+	// PointKind is the kind of a Point.
+	PointKind Kind = iota + 1
+	// LSegKind is the kind of an LSeg.
+	LSegKind
+	// LineKind is the kind of a Line.
+	LineKind
+	// BoxKind is the kind of a Box.
+	BoxKind
+	// PathKind is the kind of a Path.
+	PathKind
+	// PolygonKind is the kind of a Polygon.
+	PolygonKind
+	// CircleKind is the kind of a Circle.
+	CircleKind
+)
+
+var kindNames = [...]string{
+	PointKind:   "point",
+	LSegKind:    "lseg",
+	LineKind:    "line",
+	BoxKind:     "box",
+	PathKind:    "path",
+	PolygonKind: "polygon",
+	CircleKind:  "circle",
+}
+
+// String returns the Postgres name of the type.
+func (k Kind) String() string {
+	if k == 0 || int(k) >= len(kindNames) {
+		return "Kind(" + strconv.Itoa(int(k)) + ")"
+	}
+	return kindNames[k]
+}
+
+// Shape is a value of one of the geometric types.
+type Shape interface {
+	// Kind returns the kind of the shape.
+	Kind() Kind
+	// String returns the Postgres text representation of the shape.
+	String() string
+
+	// appendText appends the Postgres text representation of the shape to buf.
+	appendText(buf []byte) []byte
+	// appendBinary appends the Postgres binary representation of the shape to
+	// buf.
+	appendBinary(buf []byte) []byte
+	// coords calls fn for every coordinate that defines the shape, in a fixed
+	// order. It is used to implement Compare.
+	coords(fn func(float64))
+}
+
+// Point is a point on a plane.
+type Point struct {
+	X, Y float64
+}
+
+// LSeg is a finite line segment between two points.
+type LSeg struct {
+	P [2]Point
+}
+
+// Line is an infinite line represented by the linear equation Ax + By + C = 0,
+// where A and B are not both zero.
+type Line struct {
+	A, B, C float64
+}
+
+// Box is a rectangular box. High is always the upper right corner and Low the
+// lower left corner.
+type Box struct {
+	High, Low Point
+}
+
+// Path is a list of connected points. A closed path has an implicit segment
+// from the last point back to the first.
+type Path struct {
+	Closed bool
+	Points []Point
+}
+
+// Polygon is a closed list of points. Polygon is similar to a closed Path, but
+// also covers the area inside its boundary.
+type Polygon struct {
+	Points []Point
+	// BoundBox is the bounding box of Points. It is computed when the polygon
+	// is constructed.
+	BoundBox Box
+}
+
+// Circle is a circle defined by its center and radius.
+type Circle struct {
+	Center Point
+	Radius float64
+}
+
+var _ Shape = Point{}
+var _ Shape = LSeg{}
+var _ Shape = Line{}
+var _ Shape = Box{}
+var _ Shape = Path{}
+var _ Shape = Polygon{}
+var _ Shape = Circle{}
+
+// Kind implements the Shape interface.
+func (Point) Kind() Kind { return PointKind }
+
+// Kind implements the Shape interface.
+func (LSeg) Kind() Kind { return LSegKind }
+
+// Kind implements the Shape interface.
+func (Line) Kind() Kind { return LineKind }
+
+// Kind implements the Shape interface.
+func (Box) Kind() Kind { return BoxKind }
+
+// Kind implements the Shape interface.
+func (Path) Kind() Kind { return PathKind }
+
+// Kind implements the Shape interface.
+func (Polygon) Kind() Kind { return PolygonKind }
+
+// Kind implements the Shape interface.
+func (Circle) Kind() Kind { return CircleKind }
+
+// MakeBox returns the box with the two given points as opposite corners.
+func MakeBox(p1, p2 Point) Box {
+	return Box{
+		High: Point{X: math.Max(p1.X, p2.X), Y: math.Max(p1.Y, p2.Y)},
+		Low:  Point{X: math.Min(p1.X, p2.X), Y: math.Min(p1.Y, p2.Y)},
+	}
+}
+
+// MakeLine returns the line that passes through the two given points.
+func MakeLine(p1, p2 Point) (Line, error) {
+	if p1 == p2 {
+		return Line{}, pgerror.New(pgcode.InvalidParameterValue,
+			"invalid line specification: must be two distinct points")
+	}
+	switch {
+	case p1.X == p2.X:
+		// Vertical line.
+		return Line{A: -1, B: 0, C: p1.X}, nil
+	case p1.Y == p2.Y:
+		// Horizontal line.
+		return Line{A: 0, B: -1, C: p1.Y}, nil
+	default:
+		m := (p2.Y - p1.Y) / (p2.X - p1.X)
+		return Line{A: m, B: -1, C: p1.Y - m*p1.X}, nil
+	}
+}
+
+// MakePolygon returns a polygon with the given points, and computes its
+// bounding box.
+func MakePolygon(points []Point) Polygon {
+	return Polygon{Points: points, BoundBox: boundBox(points)}
+}
+
+// boundBox returns the smallest box that contains all the given points.
+func boundBox(points []Point) Box {
+	if len(points) == 0 {
+		return Box{}
+	}
+	b := Box{High: points[0], Low: points[0]}
+	for _, p := range points[1:] {
+		b.High.X = math.Max(b.High.X, p.X)
+		b.High.Y = math.Max(b.High.Y, p.Y)
+		b.Low.X = math.Min(b.Low.X, p.X)
+		b.Low.Y = math.Min(b.Low.Y, p.Y)
+	}
+	return b
+}
+
+// String implements the Shape interface.
+func (p Point) String() string { return string(p.appendText(nil)) }
+
+// String implements the Shape interface.
+func (s LSeg) String() string { return string(s.appendText(nil)) }
+
+// String implements the Shape interface.
+func (l Line) String() string { return string(l.appendText(nil)) }
+
+// String implements the Shape interface.
+func (b Box) String() string { return string(b.appendText(nil)) }
+
+// String implements the Shape interface.
+func (p Path) String() string { return string(p.appendText(nil)) }
+
+// String implements the Shape interface.
+func (p Polygon) String() string { return string(p.appendText(nil)) }
+
+// String implements the Shape interface.
+func (c Circle) String() string { return string(c.appendText(nil)) }
+
+func (p Point) coords(fn func(float64)) {
+	fn(p.X)
+	fn(p.Y)
+}
+
+func (s LSeg) coords(fn func(float64)) {
+	s.P[0].coords(fn)
+	s.P[1].coords(fn)
+}
+
+func (l Line) coords(fn func(float64)) {
+	fn(l.A)
+	fn(l.B)
+	fn(l.C)
+}
+
+func (b Box) coords(fn func(float64)) {
+	b.High.coords(fn)
+	b.Low.coords(fn)
+}
+
+func (p Path) coords(fn func(float64)) {
+	if p.Closed {
+		fn(1)
+	} else {
+		fn(0)
+	}
+	fn(float64(len(p.Points)))
+	for _, pt := range p.Points {
+		pt.coords(fn)
+	}
+}
+
+func (p Polygon) coords(fn func(float64)) {
+	fn(float64(len(p.Points)))
+	for _, pt := range p.Points {
+		pt.coords(fn)
+	}
+}
+
+func (c Circle) coords(fn func(float64)) {
+	c.Center.coords(fn)
+	fn(c.Radius)
+}
+
+// Compare returns -1, 0 or 1 depending on whether a sorts before, equal to or
+// after b. The shapes are ordered by kind and then by their coordinates. This
+// ordering has no geometric meaning, and is only used to provide a total
+// order over values for operations like DISTINCT. NaN coordinates sort before
+// all other values.
+func Compare(a, b Shape) int {
+	if a.Kind() != b.Kind() {
+		if a.Kind() < b.Kind() {
+			return -1
+		}
+		return 1
+	}
+	var ac, bc []float64
+	a.coords(func(f float64) { ac = append(ac, f) })
+	b.coords(func(f float64) { bc = append(bc, f) })
+	for i := 0; i < len(ac) && i < len(bc); i++ {
+		if c := compareFloats(ac[i], bc[i]); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case len(ac) < len(bc):
+		return -1
+	case len(ac) > len(bc):
+		return 1
+	}
+	return 0
+}
+
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	case a == b:
+		return 0
+	case math.IsNaN(a):
+		if math.IsNaN(b) {
+			return 0
+		}
+		return -1
+	default:
+		return 1
+	}
+}
+
+// Size returns the approximate number of bytes used by the shape.
+func Size(s Shape) uintptr {
+	const pointSize = 16
+	switch t := s.(type) {
+	case Path:
+		return 32 + uintptr(len(t.Points))*pointSize
+	case Polygon:
+		return 56 + uintptr(len(t.Points))*pointSize
+	default:
+		return 32
+	}
+}
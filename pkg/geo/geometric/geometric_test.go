@@ -0,0 +1,314 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package geometric
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		kind     Kind
+		input    string
+		expected string
+		err      string
+	}{
+		{kind: PointKind, input: "(1,2)", expected: "(1,2)"},
+		{kind: PointKind, input: " 1.5 , -2 ", expected: "(1.5,-2)"},
+		{kind: PointKind, input: "(1e6,infinity)", expected: "(1e+06,Infinity)"},
+		{kind: PointKind, input: "(nan,0)", expected: "(NaN,0)"},
+		{kind: PointKind, input: "(1,2", err: `invalid input syntax for type point: "(1,2"`},
+		{kind: PointKind, input: "(1,2))", err: `invalid input syntax for type point: "(1,2))"`},
+		{kind: PointKind, input: "1", err: `invalid input syntax for type point: "1"`},
+		{kind: PointKind, input: "(a,b)", err: `invalid input syntax for type point: "(a,b)"`},
+		{kind: PointKind, input: "(1e400,0)", err: `"1e400" is out of range for type double precision`},
+
+		{kind: LSegKind, input: "[(1,2),(3,4)]", expected: "[(1,2),(3,4)]"},
+		{kind: LSegKind, input: "((1,2),(3,4))", expected: "[(1,2),(3,4)]"},
+		{kind: LSegKind, input: "(1,2),(3,4)", expected: "[(1,2),(3,4)]"},
+		{kind: LSegKind, input: "1,2,3,4", expected: "[(1,2),(3,4)]"},
+		{kind: LSegKind, input: "(1,2,3,4)", expected: "[(1,2),(3,4)]"},
+		{kind: LSegKind, input: "[(1,2),(3,4)", err: `invalid input syntax for type lseg`},
+
+		{kind: LineKind, input: "{1,-1,0}", expected: "{1,-1,0}"},
+		{kind: LineKind, input: "[(0,0),(1,1)]", expected: "{1,-1,0}"},
+		{kind: LineKind, input: "(1,0),(1,5)", expected: "{-1,0,1}"},
+		{kind: LineKind, input: "(0,3),(5,3)", expected: "{0,-1,3}"},
+		{kind: LineKind, input: "{0,0,1}", err: `invalid line specification: A and B cannot both be zero`},
+		{kind: LineKind, input: "(1,1),(1,1)", err: `invalid line specification: must be two distinct points`},
+
+		{kind: BoxKind, input: "(1,2),(3,4)", expected: "(3,4),(1,2)"},
+		{kind: BoxKind, input: "((3,0),(1,2))", expected: "(3,2),(1,0)"},
+		{kind: BoxKind, input: "1,2,3,4", expected: "(3,4),(1,2)"},
+		{kind: BoxKind, input: "[(1,2),(3,4)]", err: `invalid input syntax for type box`},
+
+		{kind: PathKind, input: "[(1,2),(3,4),(5,6)]", expected: "[(1,2),(3,4),(5,6)]"},
+		{kind: PathKind, input: "((1,2),(3,4),(5,6))", expected: "((1,2),(3,4),(5,6))"},
+		{kind: PathKind, input: "(1,2),(3,4)", expected: "((1,2),(3,4))"},
+		{kind: PathKind, input: "(1,2,3,4)", expected: "((1,2),(3,4))"},
+		{kind: PathKind, input: "(1,2)", expected: "((1,2))"},
+		{kind: PathKind, input: "[(1,2),(3,4)", err: `invalid input syntax for type path`},
+		{kind: PathKind, input: "[(1,2),(3)]", err: `invalid input syntax for type path`},
+
+		{kind: PolygonKind, input: "((0,0),(1,0),(1,1))", expected: "((0,0),(1,0),(1,1))"},
+		{kind: PolygonKind, input: "0,0,1,0,1,1", expected: "((0,0),(1,0),(1,1))"},
+		{kind: PolygonKind, input: "[(0,0),(1,0),(1,1)]", err: `invalid input syntax for type polygon`},
+
+		{kind: CircleKind, input: "<(1,2),3>", expected: "<(1,2),3>"},
+		{kind: CircleKind, input: "((1,2),3)", expected: "<(1,2),3>"},
+		{kind: CircleKind, input: "(1,2),3", expected: "<(1,2),3>"},
+		{kind: CircleKind, input: "1,2,3", expected: "<(1,2),3>"},
+		{kind: CircleKind, input: "<(1,2),-3>", err: `invalid input syntax for type circle`},
+		{kind: CircleKind, input: "<(1,2),3)", err: `invalid input syntax for type circle`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.kind.String()+"/"+tc.input, func(t *testing.T) {
+			s, err := Parse(tc.kind, tc.input)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.kind, s.Kind())
+			require.Equal(t, tc.expected, s.String())
+
+			// The output must parse back to the same shape.
+			s2, err := Parse(tc.kind, s.String())
+			require.NoError(t, err)
+			require.Equal(t, 0, Compare(s, s2))
+
+			// The binary representation must round trip.
+			s3, err := DecodeBinary(tc.kind, EncodeBinary(nil, s))
+			require.NoError(t, err)
+			require.Equal(t, 0, Compare(s, s3))
+		})
+	}
+}
+
+func TestDecodeBinaryErrors(t *testing.T) {
+	_, err := DecodeBinary(PointKind, []byte{1, 2, 3})
+	require.ErrorContains(t, err, "insufficient data left in message")
+
+	p := EncodeBinary(nil, Point{X: 1, Y: 2})
+	_, err = DecodeBinary(PointKind, append(p, 0))
+	require.ErrorContains(t, err, "incorrect binary data format for type point")
+
+	_, err = DecodeBinary(PolygonKind, []byte{0, 0, 0, 5, 0})
+	require.ErrorContains(t, err, "invalid number of points in external value")
+
+	_, err = DecodeBinary(CircleKind, EncodeBinary(nil, Circle{Radius: -1}))
+	require.ErrorContains(t, err, "invalid radius")
+}
+
+func mustParse(t *testing.T, k Kind, s string) Shape {
+	res, err := Parse(k, s)
+	require.NoError(t, err)
+	return res
+}
+
+func TestDistance(t *testing.T) {
+	testCases := []struct {
+		a, b     string
+		ak, bk   Kind
+		expected float64
+	}{
+		{ak: PointKind, a: "(0,0)", bk: PointKind, b: "(3,4)", expected: 5},
+		{ak: PointKind, a: "(0,0)", bk: LSegKind, b: "[(1,-1),(1,1)]", expected: 1},
+		{ak: PointKind, a: "(0,0)", bk: LSegKind, b: "[(3,4),(6,8)]", expected: 5},
+		{ak: PointKind, a: "(0,0)", bk: LineKind, b: "{1,-1,2}", expected: math.Sqrt2},
+		{ak: PointKind, a: "(0,0)", bk: BoxKind, b: "(1,1),(2,2)", expected: math.Sqrt2},
+		{ak: PointKind, a: "(1.5,1.5)", bk: BoxKind, b: "(1,1),(2,2)", expected: 0},
+		{ak: PointKind, a: "(0,0)", bk: PathKind, b: "[(2,0),(2,2)]", expected: 2},
+		{ak: PointKind, a: "(0.5,0.5)", bk: PolygonKind, b: "((0,0),(1,0),(1,1),(0,1))", expected: 0},
+		{ak: PointKind, a: "(3,0.5)", bk: PolygonKind, b: "((0,0),(1,0),(1,1),(0,1))", expected: 2},
+		{ak: PointKind, a: "(5,0)", bk: CircleKind, b: "<(0,0),2>", expected: 3},
+		{ak: CircleKind, a: "<(0,0),2>", bk: PointKind, b: "(5,0)", expected: 3},
+		{ak: LSegKind, a: "[(0,0),(1,1)]", bk: LSegKind, b: "[(0,1),(1,0)]", expected: 0},
+		{ak: LSegKind, a: "[(0,0),(1,0)]", bk: LSegKind, b: "[(0,2),(1,2)]", expected: 2},
+		{ak: LineKind, a: "{0,-1,0}", bk: LineKind, b: "{0,-1,3}", expected: 3},
+		{ak: LineKind, a: "{0,-1,0}", bk: LineKind, b: "{1,-1,3}", expected: 0},
+		{ak: BoxKind, a: "(0,0),(1,1)", bk: BoxKind, b: "(5,5),(6,6)", expected: 4 * math.Sqrt2},
+		{ak: CircleKind, a: "<(0,0),1>", bk: CircleKind, b: "<(5,0),1>", expected: 3},
+		{ak: PolygonKind, a: "((0,0),(1,0),(1,1))", bk: PolygonKind, b: "((3,0),(4,0),(4,1))", expected: 2},
+	}
+	for _, tc := range testCases {
+		a, b := mustParse(t, tc.ak, tc.a), mustParse(t, tc.bk, tc.b)
+		require.True(t, DistanceOp.Supports(tc.ak, tc.bk))
+		d, ok := Distance(a, b)
+		require.True(t, ok)
+		require.InDelta(t, tc.expected, d, 1e-9, "%s <-> %s", a, b)
+	}
+	require.False(t, DistanceOp.Supports(LineKind, CircleKind))
+}
+
+func TestPredicates(t *testing.T) {
+	testCases := []struct {
+		op       BinaryOp
+		a, b     string
+		ak, bk   Kind
+		expected bool
+	}{
+		{op: ContainsOp, ak: BoxKind, a: "(0,0),(2,2)", bk: PointKind, b: "(1,1)", expected: true},
+		{op: ContainsOp, ak: BoxKind, a: "(0,0),(2,2)", bk: PointKind, b: "(2,2)", expected: true},
+		{op: ContainsOp, ak: BoxKind, a: "(0,0),(2,2)", bk: PointKind, b: "(3,1)", expected: false},
+		{op: ContainsOp, ak: BoxKind, a: "(0,0),(2,2)", bk: BoxKind, b: "(1,1),(2,2)", expected: true},
+		{op: ContainsOp, ak: CircleKind, a: "<(0,0),2>", bk: PointKind, b: "(1,1)", expected: true},
+		{op: ContainsOp, ak: CircleKind, a: "<(0,0),2>", bk: CircleKind, b: "<(1,0),1>", expected: true},
+		{op: ContainsOp, ak: CircleKind, a: "<(0,0),2>", bk: CircleKind, b: "<(1,0),2>", expected: false},
+		{op: ContainsOp, ak: PolygonKind, a: "((0,0),(2,0),(2,2),(0,2))", bk: PointKind, b: "(1,1)", expected: true},
+		{op: ContainsOp, ak: PolygonKind, a: "((0,0),(2,0),(2,2),(0,2))", bk: PointKind, b: "(2,1)", expected: true},
+		{op: ContainsOp, ak: PolygonKind, a: "((0,0),(2,0),(2,2),(0,2))", bk: PointKind, b: "(3,1)", expected: false},
+		// A U-shaped polygon contains all the vertices of the triangle, but not
+		// its edge that crosses the gap.
+		{op: ContainsOp, ak: PolygonKind, a: "((0,0),(3,0),(3,3),(2,3),(2,1),(1,1),(1,3),(0,3))",
+			bk: PolygonKind, b: "((0.5,2),(2.5,2),(1.5,0.5))", expected: false},
+		{op: ContainsOp, ak: PolygonKind, a: "((0,0),(3,0),(3,3),(0,3))",
+			bk: PolygonKind, b: "((0.5,2),(2.5,2),(1.5,0.5))", expected: true},
+		{op: ContainsOp, ak: LSegKind, a: "[(0,0),(2,2)]", bk: PointKind, b: "(1,1)", expected: true},
+		{op: ContainsOp, ak: LineKind, a: "{1,-1,0}", bk: PointKind, b: "(5,5)", expected: true},
+		{op: ContainsOp, ak: PathKind, a: "[(0,0),(2,0),(2,2)]", bk: PointKind, b: "(2,1)", expected: true},
+		{op: ContainsOp, ak: PathKind, a: "[(0,0),(2,0),(2,2)]", bk: PointKind, b: "(1,1)", expected: false},
+		{op: ContainsOp, ak: PathKind, a: "((0,0),(2,0),(2,2))", bk: PointKind, b: "(1.5,1)", expected: true},
+
+		{op: OverlapsOp, ak: BoxKind, a: "(0,0),(2,2)", bk: BoxKind, b: "(2,2),(3,3)", expected: true},
+		{op: OverlapsOp, ak: BoxKind, a: "(0,0),(2,2)", bk: BoxKind, b: "(3,3),(4,4)", expected: false},
+		{op: OverlapsOp, ak: CircleKind, a: "<(0,0),1>", bk: CircleKind, b: "<(2,0),1>", expected: true},
+		{op: OverlapsOp, ak: CircleKind, a: "<(0,0),1>", bk: CircleKind, b: "<(3,0),1>", expected: false},
+		{op: OverlapsOp, ak: PolygonKind, a: "((0,0),(4,0),(4,4),(0,4))", bk: PolygonKind, b: "((1,1),(2,1),(2,2))", expected: true},
+		{op: OverlapsOp, ak: PolygonKind, a: "((0,0),(1,0),(1,1))", bk: PolygonKind, b: "((3,0),(4,0),(4,1))", expected: false},
+
+		{op: IntersectsOp, ak: LSegKind, a: "[(0,0),(2,2)]", bk: LSegKind, b: "[(0,2),(2,0)]", expected: true},
+		{op: IntersectsOp, ak: LSegKind, a: "[(0,0),(1,1)]", bk: LSegKind, b: "[(2,2),(3,3)]", expected: false},
+		{op: IntersectsOp, ak: LSegKind, a: "[(0,0),(2,0)]", bk: LSegKind, b: "[(1,0),(1,5)]", expected: true},
+		{op: IntersectsOp, ak: LSegKind, a: "[(0,0),(2,2)]", bk: LineKind, b: "{0,-1,1}", expected: true},
+		{op: IntersectsOp, ak: LineKind, a: "{0,-1,1}", bk: LSegKind, b: "[(0,2),(2,2)]", expected: false},
+		{op: IntersectsOp, ak: LSegKind, a: "[(-1,1),(5,1)]", bk: BoxKind, b: "(0,0),(2,2)", expected: true},
+		{op: IntersectsOp, ak: LSegKind, a: "[(-1,3),(5,3)]", bk: BoxKind, b: "(0,0),(2,2)", expected: false},
+		{op: IntersectsOp, ak: LineKind, a: "{0,-1,1}", bk: LineKind, b: "{0,-1,2}", expected: false},
+		{op: IntersectsOp, ak: LineKind, a: "{0,-1,1}", bk: BoxKind, b: "(0,0),(2,2)", expected: true},
+		{op: IntersectsOp, ak: PathKind, a: "[(0,0),(2,2)]", bk: PathKind, b: "[(0,2),(2,0)]", expected: true},
+		{op: IntersectsOp, ak: PathKind, a: "[(0,0),(1,0)]", bk: PathKind, b: "[(0,2),(2,2)]", expected: false},
+	}
+	for _, tc := range testCases {
+		a, b := mustParse(t, tc.ak, tc.a), mustParse(t, tc.bk, tc.b)
+		require.True(t, tc.op.Supports(tc.ak, tc.bk))
+		var res, ok bool
+		switch tc.op {
+		case ContainsOp:
+			res, ok = Contains(a, b)
+		case OverlapsOp:
+			res, ok = Overlaps(a, b)
+		case IntersectsOp:
+			res, ok = Intersects(a, b)
+		}
+		require.True(t, ok)
+		require.Equal(t, tc.expected, res, "op %d: %s, %s", tc.op, a, b)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	testCases := []struct {
+		from     Kind
+		input    string
+		to       Kind
+		expected string
+		err      string
+	}{
+		{from: PointKind, input: "(1,2)", to: BoxKind, expected: "(1,2),(1,2)"},
+		{from: LSegKind, input: "[(0,0),(2,4)]", to: PointKind, expected: "(1,2)"},
+		{from: BoxKind, input: "(0,0),(2,4)", to: PointKind, expected: "(1,2)"},
+		{from: BoxKind, input: "(0,0),(2,4)", to: LSegKind, expected: "[(2,4),(0,0)]"},
+		{from: BoxKind, input: "(0,0),(2,4)", to: PolygonKind, expected: "((0,0),(0,4),(2,4),(2,0))"},
+		{from: BoxKind, input: "(0,0),(6,8)", to: CircleKind, expected: "<(3,4),5>"},
+		{from: PathKind, input: "((0,0),(1,1),(2,0))", to: PolygonKind, expected: "((0,0),(1,1),(2,0))"},
+		{from: PathKind, input: "[(0,0),(1,1),(2,0)]", to: PolygonKind, err: "open path cannot be converted to polygon"},
+		{from: PolygonKind, input: "((0,0),(2,0),(2,2),(0,2))", to: PointKind, expected: "(1,1)"},
+		{from: PolygonKind, input: "((0,0),(2,0),(2,2))", to: PathKind, expected: "((0,0),(2,0),(2,2))"},
+		{from: PolygonKind, input: "((0,0),(2,0),(2,2))", to: BoxKind, expected: "(2,2),(0,0)"},
+		{from: PolygonKind, input: "((-1,0),(1,0),(0,1),(0,-1))", to: CircleKind, expected: "<(0,0),1>"},
+		{from: CircleKind, input: "<(1,2),3>", to: PointKind, expected: "(1,2)"},
+		{from: CircleKind, input: "<(0,0),1.4142135623730951>", to: BoxKind, expected: "(1,1),(-1,-1)"},
+		{from: CircleKind, input: "<(0,0),0>", to: PolygonKind, err: "cannot convert circle with radius zero to polygon"},
+	}
+	for _, tc := range testCases {
+		require.True(t, CanConvert(tc.from, tc.to))
+		res, err := Convert(mustParse(t, tc.from, tc.input), tc.to)
+		if tc.err != "" {
+			require.ErrorContains(t, err, tc.err)
+			continue
+		}
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, res.String())
+	}
+	require.False(t, CanConvert(LineKind, PointKind))
+
+	poly, err := Convert(mustParse(t, CircleKind, "<(0,0),1>"), PolygonKind)
+	require.NoError(t, err)
+	require.Len(t, poly.(Polygon).Points, DefaultCirclePolygonPoints)
+	require.InDelta(t, -1, poly.(Polygon).Points[0].X, 1e-9)
+}
+
+func TestGeomT(t *testing.T) {
+	for _, tc := range []struct {
+		kind  Kind
+		input string
+	}{
+		{kind: PointKind, input: "(1,2)"},
+		{kind: PathKind, input: "[(0,0),(1,1),(2,0)]"},
+		{kind: PolygonKind, input: "((0,0),(1,1),(2,0),(0,0))"},
+	} {
+		s := mustParse(t, tc.kind, tc.input)
+		g, err := ToGeomT(s)
+		require.NoError(t, err)
+		res, err := FromGeomT(g, tc.kind)
+		require.NoError(t, err)
+		require.Equal(t, 0, Compare(s, res))
+	}
+
+	// Polygon rings are closed when converting to a geometry.
+	g, err := ToGeomT(mustParse(t, PolygonKind, "((0,0),(1,1),(2,0))"))
+	require.NoError(t, err)
+	res, err := FromGeomT(g, PolygonKind)
+	require.NoError(t, err)
+	require.Equal(t, "((0,0),(1,1),(2,0),(0,0))", res.String())
+
+	_, err = FromGeomT(g, PointKind)
+	require.ErrorContains(t, err, "geometry_to_point only accepts Points")
+	_, err = ToGeomT(mustParse(t, CircleKind, "<(0,0),1>"))
+	require.ErrorContains(t, err, "cannot convert circle to geometry")
+}
+
+func TestCompare(t *testing.T) {
+	ordered := []Shape{
+		mustParse(t, PointKind, "(NaN,0)"),
+		mustParse(t, PointKind, "(1,2)"),
+		mustParse(t, PointKind, "(1,3)"),
+		mustParse(t, PointKind, "(2,0)"),
+		mustParse(t, LSegKind, "[(0,0),(1,1)]"),
+		mustParse(t, PathKind, "[(0,0),(1,1)]"),
+		mustParse(t, PathKind, "[(0,0),(1,1),(2,2)]"),
+		mustParse(t, PathKind, "((0,0),(1,1))"),
+	}
+	for i := range ordered {
+		for j := range ordered {
+			expected := 0
+			if i < j {
+				expected = -1
+			} else if i > j {
+				expected = 1
+			}
+			require.Equal(t, expected, Compare(ordered[i], ordered[j]), "%s vs %s", ordered[i], ordered[j])
+		}
+	}
+}
@@ -0,0 +1,615 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package geometric
+
+import "math"
+
+// epsilon is the tolerance used by the fuzzy comparisons below. Postgres uses
+// the same tolerance for its geometric operators.
+const epsilon = 1.0e-06
+
+func fpZero(a float64) bool  { return math.Abs(a) <= epsilon }
+func fpEq(a, b float64) bool { return a == b || math.Abs(a-b) <= epsilon }
+func fpLe(a, b float64) bool { return a <= b+epsilon }
+func fpGe(a, b float64) bool { return a+epsilon >= b }
+
+// BinaryOp is one of the operators that can be applied to a pair of shapes.
+type BinaryOp uint8
+
+const (
+	// DistanceOp is the <-> operator, which returns the distance between two
+	// shapes.
+	DistanceOp BinaryOp = iota
+	// ContainsOp is the @> operator, which returns whether the first shape
+	// contains the second.
+	ContainsOp
+	// OverlapsOp is the && operator, which returns whether two shapes overlap.
+	OverlapsOp
+	// IntersectsOp is the ?# operator, which returns whether two shapes
+	// intersect.
+	IntersectsOp
+)
+
+type kindPair [2]Kind
+
+type distanceFn func(a, b Shape) float64
+type predicateFn func(a, b Shape) bool
+
+var distanceFns = map[kindPair]distanceFn{
+	{PointKind, PointKind}: func(a, b Shape) float64 {
+		return distPointPoint(a.(Point), b.(Point))
+	},
+	{PointKind, LSegKind}: func(a, b Shape) float64 {
+		return distPointLSeg(a.(Point), b.(LSeg))
+	},
+	{PointKind, LineKind}: func(a, b Shape) float64 {
+		return distPointLine(a.(Point), b.(Line))
+	},
+	{PointKind, BoxKind}: func(a, b Shape) float64 {
+		return distPointBox(a.(Point), b.(Box))
+	},
+	{PointKind, PathKind}: func(a, b Shape) float64 {
+		return distPointPath(a.(Point), b.(Path))
+	},
+	{PointKind, PolygonKind}: func(a, b Shape) float64 {
+		return distPointPolygon(a.(Point), b.(Polygon))
+	},
+	{PointKind, CircleKind}: func(a, b Shape) float64 {
+		c := b.(Circle)
+		return math.Max(0, distPointPoint(a.(Point), c.Center)-c.Radius)
+	},
+	{LSegKind, LSegKind}: func(a, b Shape) float64 {
+		return distLSegLSeg(a.(LSeg), b.(LSeg))
+	},
+	{LSegKind, LineKind}: func(a, b Shape) float64 {
+		return distLSegLine(a.(LSeg), b.(Line))
+	},
+	{LSegKind, BoxKind}: func(a, b Shape) float64 {
+		return distLSegBox(a.(LSeg), b.(Box))
+	},
+	{LineKind, LineKind}: func(a, b Shape) float64 {
+		return distLineLine(a.(Line), b.(Line))
+	},
+	{BoxKind, BoxKind}: func(a, b Shape) float64 {
+		return distBoxBox(a.(Box), b.(Box))
+	},
+	{PathKind, PathKind}: func(a, b Shape) float64 {
+		return distSegments(a.(Path).segments(), b.(Path).segments())
+	},
+	{PolygonKind, PolygonKind}: func(a, b Shape) float64 {
+		p1, p2 := a.(Polygon), b.(Polygon)
+		if polygonOverlaps(p1, p2) {
+			return 0
+		}
+		return distSegments(p1.edges(), p2.edges())
+	},
+	{CircleKind, CircleKind}: func(a, b Shape) float64 {
+		c1, c2 := a.(Circle), b.(Circle)
+		return math.Max(0, distPointPoint(c1.Center, c2.Center)-c1.Radius-c2.Radius)
+	},
+	{CircleKind, PolygonKind}: func(a, b Shape) float64 {
+		c := a.(Circle)
+		return math.Max(0, distPointPolygon(c.Center, b.(Polygon))-c.Radius)
+	},
+}
+
+var containsFns = map[kindPair]predicateFn{
+	{BoxKind, PointKind}: func(a, b Shape) bool {
+		return boxContainsPoint(a.(Box), b.(Point))
+	},
+	{BoxKind, LSegKind}: func(a, b Shape) bool {
+		box, s := a.(Box), b.(LSeg)
+		return boxContainsPoint(box, s.P[0]) && boxContainsPoint(box, s.P[1])
+	},
+	{BoxKind, BoxKind}: func(a, b Shape) bool {
+		b1, b2 := a.(Box), b.(Box)
+		return fpGe(b1.High.X, b2.High.X) && fpLe(b1.Low.X, b2.Low.X) &&
+			fpGe(b1.High.Y, b2.High.Y) && fpLe(b1.Low.Y, b2.Low.Y)
+	},
+	{CircleKind, PointKind}: func(a, b Shape) bool {
+		c := a.(Circle)
+		return distPointPoint(c.Center, b.(Point)) <= c.Radius
+	},
+	{CircleKind, CircleKind}: func(a, b Shape) bool {
+		c1, c2 := a.(Circle), b.(Circle)
+		return fpLe(distPointPoint(c1.Center, c2.Center)+c2.Radius, c1.Radius)
+	},
+	{PolygonKind, PointKind}: func(a, b Shape) bool {
+		return polygonContainsPoint(a.(Polygon), b.(Point))
+	},
+	{PolygonKind, PolygonKind}: func(a, b Shape) bool {
+		return polygonContainsPolygon(a.(Polygon), b.(Polygon))
+	},
+	{PathKind, PointKind}: func(a, b Shape) bool {
+		p, pt := a.(Path), b.(Point)
+		if p.Closed {
+			return pointInRing(pt, p.Points)
+		}
+		for _, s := range p.segments() {
+			if lsegContainsPoint(s, pt) {
+				return true
+			}
+		}
+		return len(p.Points) == 1 && p.Points[0] == pt
+	},
+	{LSegKind, PointKind}: func(a, b Shape) bool {
+		return lsegContainsPoint(a.(LSeg), b.(Point))
+	},
+	{LineKind, PointKind}: func(a, b Shape) bool {
+		return lineContainsPoint(a.(Line), b.(Point))
+	},
+	{LineKind, LSegKind}: func(a, b Shape) bool {
+		l, s := a.(Line), b.(LSeg)
+		return lineContainsPoint(l, s.P[0]) && lineContainsPoint(l, s.P[1])
+	},
+}
+
+var overlapsFns = map[kindPair]predicateFn{
+	{BoxKind, BoxKind}: func(a, b Shape) bool {
+		return boxOverlaps(a.(Box), b.(Box))
+	},
+	{CircleKind, CircleKind}: func(a, b Shape) bool {
+		c1, c2 := a.(Circle), b.(Circle)
+		return fpLe(distPointPoint(c1.Center, c2.Center), c1.Radius+c2.Radius)
+	},
+	{PolygonKind, PolygonKind}: func(a, b Shape) bool {
+		return polygonOverlaps(a.(Polygon), b.(Polygon))
+	},
+}
+
+var intersectsFns = map[kindPair]predicateFn{
+	{LSegKind, LSegKind}: func(a, b Shape) bool {
+		return lsegIntersects(a.(LSeg), b.(LSeg))
+	},
+	{LSegKind, LineKind}: func(a, b Shape) bool {
+		return distLSegLine(a.(LSeg), b.(Line)) == 0
+	},
+	{LSegKind, BoxKind}: func(a, b Shape) bool {
+		return lsegIntersectsBox(a.(LSeg), b.(Box))
+	},
+	{LineKind, LineKind}: func(a, b Shape) bool {
+		return !linesParallel(a.(Line), b.(Line))
+	},
+	{LineKind, BoxKind}: func(a, b Shape) bool {
+		l, box := a.(Line), b.(Box)
+		// The line intersects the box if the corners of the box are not all on
+		// the same side of the line.
+		var pos, neg bool
+		for _, p := range box.corners() {
+			switch v := l.A*p.X + l.B*p.Y + l.C; {
+			case fpZero(v):
+				return true
+			case v > 0:
+				pos = true
+			default:
+				neg = true
+			}
+		}
+		return pos && neg
+	},
+	{BoxKind, BoxKind}: func(a, b Shape) bool {
+		return boxOverlaps(a.(Box), b.(Box))
+	},
+	{PathKind, PathKind}: func(a, b Shape) bool {
+		p1, p2 := a.(Path), b.(Path)
+		if !boxOverlaps(boundBox(p1.Points), boundBox(p2.Points)) {
+			return false
+		}
+		for _, s1 := range p1.segments() {
+			for _, s2 := range p2.segments() {
+				if lsegIntersects(s1, s2) {
+					return true
+				}
+			}
+		}
+		return false
+	},
+}
+
+// commute adds the commutator of every operation in fns, if it is not already
+// defined.
+func commute(fns map[kindPair]distanceFn) {
+	var missing []kindPair
+	for k := range fns {
+		if _, ok := fns[kindPair{k[1], k[0]}]; !ok {
+			missing = append(missing, k)
+		}
+	}
+	for _, k := range missing {
+		fn := fns[k]
+		fns[kindPair{k[1], k[0]}] = func(a, b Shape) float64 { return fn(b, a) }
+	}
+}
+
+// commutePredicates is like commute, for predicates.
+func commutePredicates(fns map[kindPair]predicateFn) {
+	var missing []kindPair
+	for k := range fns {
+		if _, ok := fns[kindPair{k[1], k[0]}]; !ok {
+			missing = append(missing, k)
+		}
+	}
+	for _, k := range missing {
+		fn := fns[k]
+		fns[kindPair{k[1], k[0]}] = func(a, b Shape) bool { return fn(b, a) }
+	}
+}
+
+func init() {
+	commute(distanceFns)
+	commutePredicates(intersectsFns)
+}
+
+// Supports returns whether the operator is defined for shapes of the given
+// kinds.
+func (op BinaryOp) Supports(left, right Kind) bool {
+	k := kindPair{left, right}
+	var ok bool
+	switch op {
+	case DistanceOp:
+		_, ok = distanceFns[k]
+	case ContainsOp:
+		_, ok = containsFns[k]
+	case OverlapsOp:
+		_, ok = overlapsFns[k]
+	case IntersectsOp:
+		_, ok = intersectsFns[k]
+	}
+	return ok
+}
+
+// Distance returns the distance between two shapes. ok is false if the
+// distance is not defined for shapes of the given kinds.
+func Distance(a, b Shape) (_ float64, ok bool) {
+	fn, ok := distanceFns[kindPair{a.Kind(), b.Kind()}]
+	if !ok {
+		return 0, false
+	}
+	return fn(a, b), true
+}
+
+// Contains returns whether a contains b, including the case where b is on the
+// boundary of a. ok is false if containment is not defined for shapes of the
+// given kinds.
+func Contains(a, b Shape) (_ bool, ok bool) {
+	return evalPredicate(containsFns, a, b)
+}
+
+// Overlaps returns whether a and b overlap, that is, whether they have any
+// point in common. ok is false if the operation is not defined for shapes of
+// the given kinds.
+func Overlaps(a, b Shape) (_ bool, ok bool) {
+	return evalPredicate(overlapsFns, a, b)
+}
+
+// Intersects returns whether a and b intersect. ok is false if the operation
+// is not defined for shapes of the given kinds.
+func Intersects(a, b Shape) (_ bool, ok bool) {
+	return evalPredicate(intersectsFns, a, b)
+}
+
+func evalPredicate(fns map[kindPair]predicateFn, a, b Shape) (_ bool, ok bool) {
+	fn, ok := fns[kindPair{a.Kind(), b.Kind()}]
+	if !ok {
+		return false, false
+	}
+	return fn(a, b), true
+}
+
+func (b Box) corners() [4]Point {
+	return [4]Point{
+		b.Low,
+		{X: b.Low.X, Y: b.High.Y},
+		b.High,
+		{X: b.High.X, Y: b.Low.Y},
+	}
+}
+
+func (b Box) edges() []LSeg {
+	c := b.corners()
+	return ringSegments(c[:])
+}
+
+// segments returns the segments that make up the path.
+func (p Path) segments() []LSeg {
+	if p.Closed {
+		return ringSegments(p.Points)
+	}
+	if len(p.Points) < 2 {
+		return nil
+	}
+	res := make([]LSeg, len(p.Points)-1)
+	for i := range res {
+		res[i] = LSeg{P: [2]Point{p.Points[i], p.Points[i+1]}}
+	}
+	return res
+}
+
+// edges returns the segments that make up the boundary of the polygon.
+func (p Polygon) edges() []LSeg {
+	return ringSegments(p.Points)
+}
+
+// ringSegments returns the segments between consecutive points, including
+// the segment from the last point back to the first.
+func ringSegments(points []Point) []LSeg {
+	if len(points) < 2 {
+		return nil
+	}
+	res := make([]LSeg, len(points))
+	for i := range points {
+		res[i] = LSeg{P: [2]Point{points[i], points[(i+1)%len(points)]}}
+	}
+	return res
+}
+
+func distPointPoint(p, q Point) float64 {
+	return math.Hypot(p.X-q.X, p.Y-q.Y)
+}
+
+// closestPoint returns the point on the segment that is closest to p.
+func (s LSeg) closestPoint(p Point) Point {
+	dx, dy := s.P[1].X-s.P[0].X, s.P[1].Y-s.P[0].Y
+	l2 := dx*dx + dy*dy
+	if l2 == 0 {
+		return s.P[0]
+	}
+	t := ((p.X-s.P[0].X)*dx + (p.Y-s.P[0].Y)*dy) / l2
+	t = math.Max(0, math.Min(1, t))
+	return Point{X: s.P[0].X + t*dx, Y: s.P[0].Y + t*dy}
+}
+
+func distPointLSeg(p Point, s LSeg) float64 {
+	return distPointPoint(p, s.closestPoint(p))
+}
+
+func distPointLine(p Point, l Line) float64 {
+	return math.Abs(l.A*p.X+l.B*p.Y+l.C) / math.Hypot(l.A, l.B)
+}
+
+func distPointBox(p Point, b Box) float64 {
+	closest := Point{
+		X: math.Max(b.Low.X, math.Min(b.High.X, p.X)),
+		Y: math.Max(b.Low.Y, math.Min(b.High.Y, p.Y)),
+	}
+	return distPointPoint(p, closest)
+}
+
+func distPointSegments(p Point, segs []LSeg) float64 {
+	res := math.Inf(1)
+	for _, s := range segs {
+		res = math.Min(res, distPointLSeg(p, s))
+	}
+	return res
+}
+
+func distPointPath(p Point, path Path) float64 {
+	if len(path.Points) == 1 {
+		return distPointPoint(p, path.Points[0])
+	}
+	return distPointSegments(p, path.segments())
+}
+
+func distPointPolygon(p Point, poly Polygon) float64 {
+	if pointInRing(p, poly.Points) {
+		return 0
+	}
+	if len(poly.Points) == 1 {
+		return distPointPoint(p, poly.Points[0])
+	}
+	return distPointSegments(p, poly.edges())
+}
+
+func distLSegLSeg(a, b LSeg) float64 {
+	if lsegIntersects(a, b) {
+		return 0
+	}
+	return math.Min(
+		math.Min(distPointLSeg(a.P[0], b), distPointLSeg(a.P[1], b)),
+		math.Min(distPointLSeg(b.P[0], a), distPointLSeg(b.P[1], a)),
+	)
+}
+
+func distLSegLine(s LSeg, l Line) float64 {
+	v0 := l.A*s.P[0].X + l.B*s.P[0].Y + l.C
+	v1 := l.A*s.P[1].X + l.B*s.P[1].Y + l.C
+	if v0 == 0 || v1 == 0 || (v0 > 0) != (v1 > 0) {
+		// The segment touches or crosses the line.
+		return 0
+	}
+	return math.Min(distPointLine(s.P[0], l), distPointLine(s.P[1], l))
+}
+
+func distLSegBox(s LSeg, b Box) float64 {
+	if lsegIntersectsBox(s, b) {
+		return 0
+	}
+	return distSegments([]LSeg{s}, b.edges())
+}
+
+func linesParallel(l1, l2 Line) bool {
+	return fpEq(l1.A*l2.B, l2.A*l1.B)
+}
+
+func distLineLine(l1, l2 Line) float64 {
+	if !linesParallel(l1, l2) {
+		return 0
+	}
+	// Pick any point on the first line and measure its distance to the second.
+	var p Point
+	if l1.B != 0 {
+		p = Point{X: 0, Y: -l1.C / l1.B}
+	} else {
+		p = Point{X: -l1.C / l1.A, Y: 0}
+	}
+	return distPointLine(p, l2)
+}
+
+func distBoxBox(a, b Box) float64 {
+	dx := math.Max(0, math.Max(a.Low.X-b.High.X, b.Low.X-a.High.X))
+	dy := math.Max(0, math.Max(a.Low.Y-b.High.Y, b.Low.Y-a.High.Y))
+	return math.Hypot(dx, dy)
+}
+
+// distSegments returns the minimum distance between any two segments of the
+// given lists.
+func distSegments(a, b []LSeg) float64 {
+	res := math.Inf(1)
+	for _, s1 := range a {
+		for _, s2 := range b {
+			res = math.Min(res, distLSegLSeg(s1, s2))
+		}
+	}
+	return res
+}
+
+func boxContainsPoint(b Box, p Point) bool {
+	return p.X <= b.High.X && p.X >= b.Low.X && p.Y <= b.High.Y && p.Y >= b.Low.Y
+}
+
+func boxOverlaps(a, b Box) bool {
+	return fpLe(a.Low.X, b.High.X) && fpLe(b.Low.X, a.High.X) &&
+		fpLe(a.Low.Y, b.High.Y) && fpLe(b.Low.Y, a.High.Y)
+}
+
+func lsegContainsPoint(s LSeg, p Point) bool {
+	return fpEq(distPointPoint(p, s.P[0])+distPointPoint(p, s.P[1]), s.Length())
+}
+
+func lineContainsPoint(l Line, p Point) bool {
+	return fpZero(l.A*p.X + l.B*p.Y + l.C)
+}
+
+// orientation returns the sign of the cross product of (q-p) and (r-p), which
+// is positive if p, q and r make a counter-clockwise turn, negative if they
+// make a clockwise turn and zero if they are collinear.
+func orientation(p, q, r Point) int {
+	v := (q.X-p.X)*(r.Y-p.Y) - (q.Y-p.Y)*(r.X-p.X)
+	switch {
+	case fpZero(v):
+		return 0
+	case v > 0:
+		return 1
+	default:
+		return -1
+	}
+}
+
+func lsegIntersects(a, b LSeg) bool {
+	o1 := orientation(a.P[0], a.P[1], b.P[0])
+	o2 := orientation(a.P[0], a.P[1], b.P[1])
+	o3 := orientation(b.P[0], b.P[1], a.P[0])
+	o4 := orientation(b.P[0], b.P[1], a.P[1])
+	if o1 != o2 && o3 != o4 {
+		return true
+	}
+	// Handle the cases where an endpoint of one segment lies on the other.
+	return (o1 == 0 && lsegContainsPoint(a, b.P[0])) ||
+		(o2 == 0 && lsegContainsPoint(a, b.P[1])) ||
+		(o3 == 0 && lsegContainsPoint(b, a.P[0])) ||
+		(o4 == 0 && lsegContainsPoint(b, a.P[1]))
+}
+
+func lsegIntersectsBox(s LSeg, b Box) bool {
+	if boxContainsPoint(b, s.P[0]) || boxContainsPoint(b, s.P[1]) {
+		return true
+	}
+	for _, e := range b.edges() {
+		if lsegIntersects(s, e) {
+			return true
+		}
+	}
+	return false
+}
+
+// pointInRing returns whether p is inside or on the boundary of the polygon
+// formed by the given points.
+func pointInRing(p Point, points []Point) bool {
+	if len(points) == 0 {
+		return false
+	}
+	if len(points) == 1 {
+		return points[0] == p
+	}
+	for _, e := range ringSegments(points) {
+		if lsegContainsPoint(e, p) {
+			return true
+		}
+	}
+	// Count the crossings of a ray going from p to the right.
+	inside := false
+	for i, j := 0, len(points)-1; i < len(points); j, i = i, i+1 {
+		pi, pj := points[i], points[j]
+		if (pi.Y > p.Y) != (pj.Y > p.Y) &&
+			p.X < (pj.X-pi.X)*(p.Y-pi.Y)/(pj.Y-pi.Y)+pi.X {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+func polygonContainsPoint(poly Polygon, p Point) bool {
+	if !boxContainsPoint(poly.BoundBox, p) {
+		return false
+	}
+	return pointInRing(p, poly.Points)
+}
+
+func polygonContainsPolygon(a, b Polygon) bool {
+	if len(b.Points) == 0 {
+		return false
+	}
+	for _, p := range b.Points {
+		if !polygonContainsPoint(a, p) {
+			return false
+		}
+	}
+	// All the vertices of b are in a, but an edge of b may still leave a, if
+	// a is not convex. Check that no edge of b properly crosses an edge of a,
+	// and that the middle of each edge of b is inside a.
+	for _, e := range b.edges() {
+		if !polygonContainsPoint(a, e.Center()) {
+			return false
+		}
+		for _, f := range a.edges() {
+			if lsegCrosses(e, f) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// lsegCrosses returns whether the two segments intersect at a single point
+// that is not an endpoint of either segment.
+func lsegCrosses(a, b LSeg) bool {
+	o1 := orientation(a.P[0], a.P[1], b.P[0])
+	o2 := orientation(a.P[0], a.P[1], b.P[1])
+	o3 := orientation(b.P[0], b.P[1], a.P[0])
+	o4 := orientation(b.P[0], b.P[1], a.P[1])
+	return o1*o2 < 0 && o3*o4 < 0
+}
+
+func polygonOverlaps(a, b Polygon) bool {
+	if len(a.Points) == 0 || len(b.Points) == 0 || !boxOverlaps(a.BoundBox, b.BoundBox) {
+		return false
+	}
+	for _, e := range a.edges() {
+		for _, f := range b.edges() {
+			if lsegIntersects(e, f) {
+				return true
+			}
+		}
+	}
+	// The boundaries don't intersect, so either one polygon is inside the
+	// other, or they are disjoint.
+	return polygonContainsPoint(a, b.Points[0]) || polygonContainsPoint(b, a.Points[0])
+}
@@ -0,0 +1,410 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package geometric
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/errors"
+)
+
+// Parse parses the Postgres text representation of a shape of the given kind.
+// The accepted formats are the same as the ones Postgres accepts, for example
+// "(1,2)", "1,2" for a point, "[(1,2),(3,4)]" for an open path and
+// "<(1,2),3>" for a circle.
+func Parse(k Kind, s string) (Shape, error) {
+	p := parser{kind: k, orig: s, s: s}
+	var res Shape
+	var err error
+	switch k {
+	case PointKind:
+		res, err = p.parsePoint()
+	case LSegKind:
+		res, err = p.parseLSeg()
+	case LineKind:
+		res, err = p.parseLine()
+	case BoxKind:
+		res, err = p.parseBox()
+	case PathKind:
+		res, err = p.parsePath()
+	case PolygonKind:
+		res, err = p.parsePolygon()
+	case CircleKind:
+		res, err = p.parseCircle()
+	default:
+		return nil, errors.AssertionFailedf("unknown geometric kind %d", k)
+	}
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.s != "" {
+		return nil, p.syntaxError()
+	}
+	return res, nil
+}
+
+// ParsePoint parses the text representation of a point.
+func ParsePoint(s string) (Point, error) {
+	res, err := Parse(PointKind, s)
+	if err != nil {
+		return Point{}, err
+	}
+	return res.(Point), nil
+}
+
+// parser holds the state of parsing the text representation of a shape. Its
+// methods mirror the decoding routines in Postgres' geo_ops.c.
+type parser struct {
+	kind Kind
+	// orig is the full input, used in error messages.
+	orig string
+	// s is the remaining input.
+	s string
+}
+
+func (p *parser) syntaxError() error {
+	return pgerror.Newf(pgcode.InvalidTextRepresentation,
+		"invalid input syntax for type %s: \"%s\"", p.kind, p.orig)
+}
+
+func (p *parser) skipSpace() {
+	p.s = strings.TrimLeft(p.s, " \t\n\r\f\v")
+}
+
+func (p *parser) peek() byte {
+	if p.s == "" {
+		return 0
+	}
+	return p.s[0]
+}
+
+// consume skips leading whitespace and then the given delimiter, and reports
+// whether the delimiter was found.
+func (p *parser) consume(c byte) bool {
+	p.skipSpace()
+	if p.peek() != c {
+		return false
+	}
+	p.s = p.s[1:]
+	return true
+}
+
+// float parses a floating point number, which may be surrounded by
+// whitespace.
+func (p *parser) float() (float64, error) {
+	p.skipSpace()
+	end := strings.IndexAny(p.s, ",()[]<>{} \t\n\r\f\v")
+	if end < 0 {
+		end = len(p.s)
+	}
+	f, err := strconv.ParseFloat(p.s[:end], 64)
+	if err != nil {
+		if errors.Is(err, strconv.ErrRange) {
+			return 0, pgerror.Newf(pgcode.NumericValueOutOfRange,
+				"\"%s\" is out of range for type double precision", p.s[:end])
+		}
+		return 0, p.syntaxError()
+	}
+	p.s = p.s[end:]
+	p.skipSpace()
+	return f, nil
+}
+
+// pair parses a point of the form "x,y" or "(x,y)".
+func (p *parser) pair() (Point, error) {
+	p.skipSpace()
+	hasDelim := p.consume('(')
+	x, err := p.float()
+	if err != nil {
+		return Point{}, err
+	}
+	if !p.consume(',') {
+		return Point{}, p.syntaxError()
+	}
+	y, err := p.float()
+	if err != nil {
+		return Point{}, err
+	}
+	if hasDelim && !p.consume(')') {
+		return Point{}, p.syntaxError()
+	}
+	p.skipSpace()
+	return Point{X: x, Y: y}, nil
+}
+
+// pairCount returns the number of points in the input, based on the number of
+// commas in it. It returns -1 if the number of commas is not odd.
+func (p *parser) pairCount() int {
+	n := strings.Count(p.s, ",")
+	if n%2 == 0 {
+		return -1
+	}
+	return (n + 1) / 2
+}
+
+// path parses a list of n points, optionally surrounded by "[...]" (if
+// allowOpen is true) or "(...)". It returns whether the list was surrounded by
+// square brackets, which denotes an open path.
+func (p *parser) path(allowOpen bool, n int) (points []Point, isOpen bool, _ error) {
+	p.skipSpace()
+	depth := 0
+	switch p.peek() {
+	case '[':
+		if !allowOpen {
+			return nil, false, p.syntaxError()
+		}
+		depth++
+		isOpen = true
+		p.s = p.s[1:]
+	case '(':
+		rest := strings.TrimLeft(p.s[1:], " \t\n\r\f\v")
+		if strings.HasPrefix(rest, "(") || strings.LastIndexByte(p.s, '(') == 0 {
+			// Either the list of points is surrounded by parentheses, or all the
+			// points are bare coordinates surrounded by a single pair of
+			// parentheses.
+			depth++
+			p.s = rest
+		}
+	}
+	points = make([]Point, n)
+	for i := range points {
+		pt, err := p.pair()
+		if err != nil {
+			return nil, false, err
+		}
+		points[i] = pt
+		if i < n-1 && !p.consume(',') {
+			return nil, false, p.syntaxError()
+		}
+	}
+	for ; depth > 0; depth-- {
+		p.skipSpace()
+		if c := p.peek(); c == ')' || (isOpen && c == ']') {
+			p.s = p.s[1:]
+		} else {
+			return nil, false, p.syntaxError()
+		}
+	}
+	return points, isOpen, nil
+}
+
+func (p *parser) parsePoint() (Point, error) {
+	return p.pair()
+}
+
+func (p *parser) parseLSeg() (LSeg, error) {
+	points, _, err := p.path(true /* allowOpen */, 2)
+	if err != nil {
+		return LSeg{}, err
+	}
+	return LSeg{P: [2]Point{points[0], points[1]}}, nil
+}
+
+func (p *parser) parseLine() (Line, error) {
+	p.skipSpace()
+	if !p.consume('{') {
+		// The line is specified by two points on it.
+		points, _, err := p.path(true /* allowOpen */, 2)
+		if err != nil {
+			return Line{}, err
+		}
+		return MakeLine(points[0], points[1])
+	}
+	var coeffs [3]float64
+	for i := range coeffs {
+		f, err := p.float()
+		if err != nil {
+			return Line{}, err
+		}
+		coeffs[i] = f
+		if i < len(coeffs)-1 && !p.consume(',') {
+			return Line{}, p.syntaxError()
+		}
+	}
+	if !p.consume('}') {
+		return Line{}, p.syntaxError()
+	}
+	if coeffs[0] == 0 && coeffs[1] == 0 {
+		return Line{}, pgerror.New(pgcode.InvalidParameterValue,
+			"invalid line specification: A and B cannot both be zero")
+	}
+	return Line{A: coeffs[0], B: coeffs[1], C: coeffs[2]}, nil
+}
+
+func (p *parser) parseBox() (Box, error) {
+	points, _, err := p.path(false /* allowOpen */, 2)
+	if err != nil {
+		return Box{}, err
+	}
+	return MakeBox(points[0], points[1]), nil
+}
+
+func (p *parser) parsePath() (Path, error) {
+	n := p.pairCount()
+	if n <= 0 {
+		return Path{}, p.syntaxError()
+	}
+	p.skipSpace()
+	// Skip a single leading parenthesis that surrounds bare coordinates.
+	depth := 0
+	if p.peek() == '(' && strings.LastIndexByte(p.s, '(') == 0 {
+		p.s = p.s[1:]
+		depth++
+	}
+	points, isOpen, err := p.path(true /* allowOpen */, n)
+	if err != nil {
+		return Path{}, err
+	}
+	if depth > 0 && !p.consume(')') {
+		return Path{}, p.syntaxError()
+	}
+	return Path{Closed: !isOpen, Points: points}, nil
+}
+
+func (p *parser) parsePolygon() (Polygon, error) {
+	n := p.pairCount()
+	if n <= 0 {
+		return Polygon{}, p.syntaxError()
+	}
+	points, _, err := p.path(false /* allowOpen */, n)
+	if err != nil {
+		return Polygon{}, err
+	}
+	return MakePolygon(points), nil
+}
+
+func (p *parser) parseCircle() (Circle, error) {
+	p.skipSpace()
+	var closers []byte
+	switch p.peek() {
+	case '<':
+		closers = append(closers, '>')
+		p.s = p.s[1:]
+	case '(':
+		rest := strings.TrimLeft(p.s[1:], " \t\n\r\f\v")
+		if strings.HasPrefix(rest, "(") {
+			closers = append(closers, ')')
+			p.s = rest
+		}
+	}
+	center, err := p.pair()
+	if err != nil {
+		return Circle{}, err
+	}
+	if !p.consume(',') {
+		return Circle{}, p.syntaxError()
+	}
+	radius, err := p.float()
+	if err != nil {
+		return Circle{}, err
+	}
+	// The radius must not be negative. NaN is allowed.
+	if radius < 0 {
+		return Circle{}, p.syntaxError()
+	}
+	for _, c := range closers {
+		if !p.consume(c) {
+			return Circle{}, p.syntaxError()
+		}
+	}
+	return Circle{Center: center, Radius: radius}, nil
+}
+
+// AppendText appends the Postgres text representation of the shape to buf.
+func AppendText(buf []byte, s Shape) []byte {
+	return s.appendText(buf)
+}
+
+// appendFloat appends the text representation of a coordinate, using the same
+// format as the float8 type.
+func appendFloat(buf []byte, f float64) []byte {
+	switch {
+	case math.IsInf(f, 1):
+		return append(buf, "Infinity"...)
+	case math.IsInf(f, -1):
+		return append(buf, "-Infinity"...)
+	case math.IsNaN(f):
+		return append(buf, "NaN"...)
+	}
+	return strconv.AppendFloat(buf, f, 'g', -1, 64)
+}
+
+func appendPoints(buf []byte, points []Point) []byte {
+	for i, pt := range points {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = pt.appendText(buf)
+	}
+	return buf
+}
+
+func (p Point) appendText(buf []byte) []byte {
+	buf = append(buf, '(')
+	buf = appendFloat(buf, p.X)
+	buf = append(buf, ',')
+	buf = appendFloat(buf, p.Y)
+	return append(buf, ')')
+}
+
+func (s LSeg) appendText(buf []byte) []byte {
+	buf = append(buf, '[')
+	buf = appendPoints(buf, s.P[:])
+	return append(buf, ']')
+}
+
+func (l Line) appendText(buf []byte) []byte {
+	buf = append(buf, '{')
+	buf = appendFloat(buf, l.A)
+	buf = append(buf, ',')
+	buf = appendFloat(buf, l.B)
+	buf = append(buf, ',')
+	buf = appendFloat(buf, l.C)
+	return append(buf, '}')
+}
+
+func (b Box) appendText(buf []byte) []byte {
+	buf = b.High.appendText(buf)
+	buf = append(buf, ',')
+	return b.Low.appendText(buf)
+}
+
+func (p Path) appendText(buf []byte) []byte {
+	if p.Closed {
+		buf = append(buf, '(')
+	} else {
+		buf = append(buf, '[')
+	}
+	buf = appendPoints(buf, p.Points)
+	if p.Closed {
+		return append(buf, ')')
+	}
+	return append(buf, ']')
+}
+
+func (p Polygon) appendText(buf []byte) []byte {
+	buf = append(buf, '(')
+	buf = appendPoints(buf, p.Points)
+	return append(buf, ')')
+}
+
+func (c Circle) appendText(buf []byte) []byte {
+	buf = append(buf, '<')
+	buf = c.Center.appendText(buf)
+	buf = append(buf, ',')
+	buf = appendFloat(buf, c.Radius)
+	return append(buf, '>')
+}
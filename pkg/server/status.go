@@ -3569,6 +3569,55 @@ func (s *statusServer) ListExecutionInsights(
 	return &response, nil
 }
 
+// Notify delivers asynchronous notifications to the sessions listening on
+// their channels. Notifications can be sent by any SQL user with NOTIFY, so no
+// privilege is required.
+func (s *statusServer) Notify(
+	ctx context.Context, req *serverpb.NotifyRequest,
+) (*serverpb.NotifyResponse, error) {
+	ctx = authserver.ForwardSQLIdentityThroughRPCCalls(ctx)
+	ctx = s.AnnotateCtx(ctx)
+
+	localRequest := serverpb.NotifyRequest{NodeID: "local", Notifications: req.Notifications}
+
+	if len(req.NodeID) > 0 {
+		requestedNodeID, local, err := s.parseNodeID(req.NodeID)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, err.Error())
+		}
+		if local {
+			s.sqlServer.pgServer.SQLServer.DeliverNotifications(ctx, req.Notifications)
+			return &serverpb.NotifyResponse{}, nil
+		}
+		statusClient, err := s.dialNode(ctx, requestedNodeID)
+		if err != nil {
+			return nil, srverrors.ServerError(ctx, err)
+		}
+		return statusClient.Notify(ctx, &localRequest)
+	}
+
+	var response serverpb.NotifyResponse
+
+	localNodeID := roachpb.NodeID(s.serverIterator.getID())
+	nodeFn := func(ctx context.Context, statusClient serverpb.StatusClient, nodeID roachpb.NodeID) (*serverpb.NotifyResponse, error) {
+		if req.SkipLocal && nodeID == localNodeID {
+			return &serverpb.NotifyResponse{}, nil
+		}
+		return statusClient.Notify(ctx, &localRequest)
+	}
+	responseFn := func(nodeID roachpb.NodeID, resp *serverpb.NotifyResponse) {}
+	errorFn := func(nodeID roachpb.NodeID, err error) {
+		response.Errors = append(response.Errors, errors.EncodeError(ctx, err))
+	}
+
+	if err := iterateNodes(ctx, s.serverIterator, s.stopper, "notify", noTimeout,
+		s.dialNode, nodeFn,
+		responseFn, errorFn); err != nil {
+		return nil, srverrors.ServerError(ctx, err)
+	}
+	return &response, nil
+}
+
 // SpanStats requests the total statistics stored on a node for a given key
 // span, which may include multiple ranges.
 func (s *statusServer) SpanStats(
@@ -197,6 +197,10 @@ func (p *planner) addColumnImpl(
 		return err
 	}
 
+	if err := params.p.updateTextSearchRefsForColumn(params.ctx, col); err != nil {
+		return err
+	}
+
 	// Zone configuration logic is only required for REGIONAL BY ROW tables
 	// with newly created indexes.
 	if n.tableDesc.IsLocalityRegionalByRow() && idx != nil {
@@ -0,0 +1,254 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/errors"
+)
+
+type alterDomainNode struct {
+	n    *tree.AlterDomain
+	desc *typedesc.Mutable
+}
+
+// alterDomainNode implements planNode. We set n here to satisfy the linter.
+var _ planNode = &alterDomainNode{n: nil}
+
+func (p *planner) AlterDomain(ctx context.Context, n *tree.AlterDomain) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"ALTER DOMAIN",
+	); err != nil {
+		return nil, err
+	}
+
+	_, desc, err := p.ResolveMutableTypeDescriptor(ctx, n.Domain, true /* required */)
+	if err != nil {
+		return nil, err
+	}
+	if desc.Kind != descpb.TypeDescriptor_DOMAIN {
+		return nil, pgerror.Newf(pgcode.WrongObjectType, "%q is not a domain",
+			tree.AsStringWithFQNames(n.Domain, &p.semaCtx.Annotations))
+	}
+
+	// The user needs ownership privilege to alter the domain.
+	if err := p.canModifyType(ctx, desc); err != nil {
+		return nil, err
+	}
+
+	return &alterDomainNode{n: n, desc: desc}, nil
+}
+
+func (n *alterDomainNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeAlterCounterWithExtra("domain", n.n.Cmd.TelemetryName()))
+
+	domain := n.desc.Domain
+	version := params.ExecCfg().Settings.Version.ActiveVersion(params.ctx)
+	switch t := n.n.Cmd.(type) {
+	case *tree.AlterDomainSetDefault:
+		if t.Default == nil {
+			domain.DefaultExpr = ""
+			break
+		}
+		expr, err := schemaexpr.ValidateDomainDefaultExpr(
+			params.ctx, t.Default, domain.BaseType, params.p.SemaCtx(), version,
+		)
+		if err != nil {
+			return err
+		}
+		domain.DefaultExpr = expr
+
+	case *tree.AlterDomainSetNotNull:
+		if t.NotNull && !domain.NotNull {
+			if err := params.p.validateDomainNotNull(params.ctx, n.desc); err != nil {
+				return err
+			}
+		}
+		domain.NotNull = t.NotNull
+
+	case *tree.AlterDomainAddConstraint:
+		if t.Constraint.Check == nil {
+			return errors.AssertionFailedf("unsupported domain constraint %s", &t.Constraint)
+		}
+		usedNames := make(map[string]struct{}, len(domain.Checks))
+		for _, c := range domain.Checks {
+			usedNames[c.Name] = struct{}{}
+		}
+		if _, ok := usedNames[string(t.Constraint.Name)]; ok {
+			return pgerror.Newf(pgcode.DuplicateObject,
+				"constraint %q for domain %q already exists", t.Constraint.Name, n.desc.Name)
+		}
+		check, err := makeDomainCheck(params, &t.Constraint, domain.BaseType, n.desc.Name, usedNames)
+		if err != nil {
+			return err
+		}
+		// The type schema change job validates the existing values once every
+		// node enforces the constraint on writes, and then publishes it.
+		check.Validity = descpb.ConstraintValidity_Validating
+		domain.Checks = append(domain.Checks, check)
+
+	case *tree.AlterDomainDropConstraint:
+		idx := -1
+		for i := range domain.Checks {
+			if domain.Checks[i].Name == string(t.Constraint) {
+				idx = i
+				break
+			}
+		}
+		if idx == -1 {
+			if t.IfExists {
+				params.p.BufferClientNotice(params.ctx, pgnotice.Newf(
+					"constraint %q of domain %q does not exist, skipping", t.Constraint, n.desc.Name))
+				return nil
+			}
+			return pgerror.Newf(pgcode.UndefinedObject,
+				"constraint %q of domain %q does not exist", t.Constraint, n.desc.Name)
+		}
+		domain.Checks = append(domain.Checks[:idx], domain.Checks[idx+1:]...)
+
+	default:
+		return errors.AssertionFailedf("unknown alter domain cmd %s", t)
+	}
+
+	if err := params.p.writeTypeSchemaChange(
+		params.ctx, n.desc, tree.AsStringWithFQNames(n.n, params.p.Ann()),
+	); err != nil {
+		return err
+	}
+	return params.p.logEvent(params.ctx,
+		n.desc.ID,
+		&eventpb.AlterType{
+			TypeName: tree.AsStringWithFQNames(n.n.Domain, params.p.Ann()),
+		})
+}
+
+// forEachDomainColumn calls fn for each public column of a table that has the
+// given domain type.
+func forEachDomainColumn(
+	ctx context.Context,
+	txn descs.Txn,
+	desc catalog.TypeDescriptor,
+	fn func(tbl catalog.TableDescriptor, col catalog.Column) error,
+) error {
+	typOID := catid.TypeIDToOID(desc.GetID())
+	for i := 0; i < desc.NumReferencingDescriptors(); i++ {
+		d, err := txn.Descriptors().ByIDWithLeased(txn.KV()).WithoutNonPublic().Get().Desc(
+			ctx, desc.GetReferencingDescriptorID(i),
+		)
+		if err != nil {
+			return err
+		}
+		// The referencing descriptor may also be, for example, a function.
+		tbl, ok := d.(catalog.TableDescriptor)
+		if !ok || !tbl.IsPhysicalTable() {
+			continue
+		}
+		for _, col := range tbl.PublicColumns() {
+			if col.GetType().Oid() != typOID {
+				continue
+			}
+			if err := fn(tbl, col); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateDomainNotNull returns an error if a column of the domain type
+// contains NULL values.
+func (p *planner) validateDomainNotNull(ctx context.Context, desc *typedesc.Mutable) error {
+	return forEachDomainColumn(ctx, p.InternalSQLTxn(), desc, func(tbl catalog.TableDescriptor, col catalog.Column) error {
+		colName := col.ColName()
+		query := fmt.Sprintf(`SELECT 1 FROM [%d AS t] WHERE %s IS NULL LIMIT 1`,
+			tbl.GetID(), colName.String())
+		row, err := p.QueryRowEx(ctx, "validate domain not null", sessiondata.NodeUserSessionDataOverride, query)
+		if err != nil {
+			return err
+		}
+		if row != nil {
+			return pgerror.Newf(pgcode.NotNullViolation,
+				"column %q of table %q contains null values", col.GetName(), tbl.GetName())
+		}
+		return nil
+	})
+}
+
+// validateDomainCheck returns an error if a column of the domain type contains
+// values that violate the given CHECK constraint.
+func validateDomainCheck(
+	ctx context.Context,
+	txn descs.Txn,
+	desc catalog.TypeDescriptor,
+	check descpb.TypeDescriptor_Domain_Check,
+) error {
+	baseType := desc.AsDomainTypeDescriptor().BaseType()
+	return forEachDomainColumn(ctx, txn, desc, func(tbl catalog.TableDescriptor, col catalog.Column) error {
+		expr, err := parser.ParseExpr(check.Expr)
+		if err != nil {
+			return err
+		}
+		colItem := &tree.ColumnItem{ColumnName: col.ColName()}
+		expr, err = tree.SimpleVisit(expr, func(e tree.Expr) (recurse bool, newExpr tree.Expr, err error) {
+			if n, ok := e.(*tree.UnresolvedName); ok && n.NumParts == 1 && tree.Name(n.Parts[0]) == schemaexpr.DomainValueName {
+				// Compare the stored values of the base type, so that the existing
+				// constraints of the domain are not evaluated.
+				return false, &tree.ParenExpr{Expr: &tree.CastExpr{
+					Expr:       colItem,
+					Type:       baseType,
+					SyntaxMode: tree.CastShort,
+				}}, nil
+			}
+			return true, e, nil
+		})
+		if err != nil {
+			return err
+		}
+		query := fmt.Sprintf(`SELECT 1 FROM [%d AS t] WHERE NOT (%s) LIMIT 1`,
+			tbl.GetID(), tree.AsStringWithFlags(expr, tree.FmtSerializable))
+		row, err := txn.QueryRowEx(
+			ctx, "validate domain check", txn.KV(), sessiondata.NodeUserSessionDataOverride, query,
+		)
+		if err != nil {
+			return err
+		}
+		if row != nil {
+			return pgerror.Newf(pgcode.CheckViolation,
+				"column %q of table %q contains values that violate the new constraint",
+				col.GetName(), tbl.GetName())
+		}
+		return nil
+	})
+}
+
+func (n *alterDomainNode) Next(params runParams) (bool, error) { return false, nil }
+func (n *alterDomainNode) Values() tree.Datums                 { return tree.Datums{} }
+func (n *alterDomainNode) Close(ctx context.Context)           {}
+func (n *alterDomainNode) ReadingOwnWrites()                   {}
@@ -16,7 +16,6 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
-	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/decodeusername"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
@@ -24,7 +23,6 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
-	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
 )
@@ -71,6 +69,10 @@ func (n *alterFunctionOptionsNode) startExec(params runParams) error {
 	if err != nil {
 		return err
 	}
+	if fnDesc.IsAggregate() {
+		// The options of an aggregate are derived from its support functions.
+		return pgerror.Newf(pgcode.WrongObjectType, "%q is an aggregate function", fnDesc.GetName())
+	}
 	// TODO(chengxiong): add validation that a function can not be altered if it's
 	// referenced by other objects. This is needed when want to allow function
 	// references. Need to think about in what condition a function can be altered
@@ -170,6 +172,9 @@ func (n *alterFunctionRenameNode) startExec(params runParams) error {
 			pgcode.UndefinedFunction, "could not find a procedure named %q", &n.n.Function.FuncName,
 		)
 	}
+	if err := checkAlterAggregateKind(fnDesc, n.n.Aggregate, &n.n.Function.FuncName); err != nil {
+		return err
+	}
 	oldFnName, err := params.p.getQualifiedFunctionName(params.ctx, fnDesc)
 	if err != nil {
 		return err
@@ -204,7 +209,7 @@ func (n *alterFunctionRenameNode) startExec(params runParams) error {
 
 	scDesc.RemoveFunction(fnDesc.GetName(), fnDesc.GetID())
 	fnDesc.SetName(string(n.n.NewName))
-	scDesc.AddFunction(fnDesc.GetName(), toSchemaOverloadSignature(fnDesc))
+	scDesc.AddFunction(fnDesc.GetName(), fnDesc.ToSignature())
 	if err := params.p.writeFuncSchemaChange(params.ctx, fnDesc); err != nil {
 		return err
 	}
@@ -259,6 +264,9 @@ func (n *alterFunctionSetOwnerNode) startExec(params runParams) error {
 			pgcode.UndefinedFunction, "could not find a procedure named %q", &n.n.Function.FuncName,
 		)
 	}
+	if err := checkAlterAggregateKind(fnDesc, n.n.Aggregate, &n.n.Function.FuncName); err != nil {
+		return err
+	}
 	newOwner, err := decodeusername.FromRoleSpec(
 		params.p.SessionData(), username.PurposeValidation, n.n.NewOwner,
 	)
@@ -333,6 +341,9 @@ func (n *alterFunctionSetSchemaNode) startExec(params runParams) error {
 			pgcode.UndefinedFunction, "could not find a procedure named %q", &n.n.Function.FuncName,
 		)
 	}
+	if err := checkAlterAggregateKind(fnDesc, n.n.Aggregate, &n.n.Function.FuncName); err != nil {
+		return err
+	}
 	oldFnName, err := params.p.getQualifiedFunctionName(params.ctx, fnDesc)
 	if err != nil {
 		return err
@@ -398,7 +409,7 @@ func (n *alterFunctionSetSchemaNode) startExec(params runParams) error {
 	if err := params.p.writeSchemaDesc(params.ctx, sourceSc); err != nil {
 		return err
 	}
-	targetSc.AddFunction(fnDesc.GetName(), toSchemaOverloadSignature(fnDesc))
+	targetSc.AddFunction(fnDesc.GetName(), fnDesc.ToSignature())
 	if err := params.p.writeSchemaDesc(params.ctx, targetSc); err != nil {
 		return err
 	}
@@ -438,6 +449,17 @@ func (n *alterFunctionDepExtensionNode) Next(params runParams) (bool, error) { r
 func (n *alterFunctionDepExtensionNode) Values() tree.Datums                 { return tree.Datums{} }
 func (n *alterFunctionDepExtensionNode) Close(ctx context.Context)           {}
 
+// checkAlterAggregateKind returns an error if an ALTER AGGREGATE statement
+// targets a function that is not an aggregate.
+func checkAlterAggregateKind(
+	fnDesc catalog.FunctionDescriptor, aggregate bool, name *tree.RoutineName,
+) error {
+	if aggregate && !fnDesc.IsAggregate() {
+		return pgerror.Newf(pgcode.UndefinedFunction, "could not find an aggregate named %q", name)
+	}
+	return nil
+}
+
 func (p *planner) mustGetMutableFunctionForAlter(
 	ctx context.Context, routineObj *tree.RoutineObj,
 ) (*funcdesc.Mutable, error) {
@@ -452,17 +474,3 @@ func (p *planner) mustGetMutableFunctionForAlter(
 	}
 	return mut, nil
 }
-
-func toSchemaOverloadSignature(fnDesc *funcdesc.Mutable) descpb.SchemaDescriptor_FunctionSignature {
-	ret := descpb.SchemaDescriptor_FunctionSignature{
-		ID:          fnDesc.GetID(),
-		ArgTypes:    make([]*types.T, len(fnDesc.GetParams())),
-		ReturnType:  fnDesc.ReturnType.Type,
-		ReturnSet:   fnDesc.ReturnType.ReturnSet,
-		IsProcedure: fnDesc.IsProcedure(),
-	}
-	for i := range fnDesc.Params {
-		ret.ArgTypes[i] = fnDesc.Params[i].Type
-	}
-	return ret
-}
@@ -226,6 +226,11 @@ func (p *planner) AlterPrimaryKey(
 		telemetry.Inc(sqltelemetry.HashShardedIndexCounter)
 	}
 
+	if err := checkIndexNullsOrders(
+		alterPKNode.Columns, p.ExecCfg().Settings.Version.ActiveVersion(ctx),
+	); err != nil {
+		return err
+	}
 	if err := newPrimaryIndexDesc.FillColumns(alterPKNode.Columns); err != nil {
 		return err
 	}
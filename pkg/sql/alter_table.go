@@ -308,6 +308,11 @@ func (n *alterTableNode) startExec(params runParams) error {
 					StoreColumnNames: d.Storing.ToStrings(),
 					CreatedAtNanos:   params.EvalContext().GetTxnTimestamp(time.Microsecond).UnixNano(),
 				}
+				if err := checkIndexNullsOrders(
+					d.Columns, params.ExecCfg().Settings.Version.ActiveVersion(params.ctx),
+				); err != nil {
+					return err
+				}
 				if err := idx.FillColumns(d.Columns); err != nil {
 					return err
 				}
@@ -366,6 +371,38 @@ func (n *alterTableNode) startExec(params runParams) error {
 						return err
 					}
 				}
+			case *tree.ExclusionConstraintTableDef:
+				name := exclusionConstraintName(n.tableDesc, d)
+				version := params.ExecCfg().Settings.Version.ActiveVersion(params.ctx)
+				idx, err := makeExclusionConstraintIndex(
+					params.ctx, params.ExecCfg().Settings, n.tableDesc, d, name, version,
+				)
+				if err != nil {
+					return err
+				}
+				if err := addExclusionConstraintTableDef(
+					params.ctx,
+					params.EvalContext(),
+					d,
+					name,
+					n.tableDesc,
+					*tn,
+					NonEmptyTable,
+					t.ValidationBehavior,
+					params.p.SemaCtx(),
+				); err != nil {
+					return err
+				}
+				idx.CreatedAtNanos = params.EvalContext().GetTxnTimestamp(time.Microsecond).UnixNano()
+				if err := n.tableDesc.AddIndexMutationMaybeWithTempIndex(
+					&idx, descpb.DescriptorMutation_ADD,
+				); err != nil {
+					return err
+				}
+				if err := n.tableDesc.AllocateIDs(params.ctx, version); err != nil {
+					return err
+				}
+
 			case *tree.CheckConstraintTableDef:
 				var err error
 				params.p.runWithOptions(resolveFlags{contextDatabaseID: n.tableDesc.ParentID}, func() {
@@ -545,6 +582,20 @@ func (n *alterTableNode) startExec(params runParams) error {
 			); err != nil {
 				return err
 			}
+			// The supporting index of an exclusion constraint, which has the same
+			// name as the constraint, is dropped with it.
+			if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil && uwoi.IsExclusionConstraint() {
+				jobDesc := fmt.Sprintf(
+					"removing index %q supporting exclusion constraint %q which is being dropped; full details: %s",
+					name, name, tree.AsStringWithFQNames(n.n, params.Ann()),
+				)
+				if err := params.p.dropIndexByName(
+					params.ctx, tn, tree.UnrestrictedName(name), n.tableDesc, true, /* ifExists */
+					t.DropBehavior, ignoreIdxConstraint, jobDesc,
+				); err != nil {
+					return err
+				}
+			}
 			descriptorChanged = true
 			if err := validateDescriptor(params.ctx, params.p, n.tableDesc); err != nil {
 				return err
@@ -873,6 +924,18 @@ func (n *alterTableNode) startExec(params runParams) error {
 		}
 	}
 
+	// Record the text search objects used by the check constraints added by
+	// this statement. The existing ones keep the references resolved when they
+	// were created.
+	for _, ck := range n.tableDesc.CheckConstraints() {
+		if ck.GetConstraintID() < n.tableDesc.ClusterVersion().NextConstraintID {
+			continue
+		}
+		if err := params.p.updateTextSearchRefsForCheck(params.ctx, ck.CheckDesc()); err != nil {
+			return err
+		}
+	}
+
 	// Record this table alteration in the event log. This is an auditable log
 	// event and is recorded in the same transaction as the table descriptor
 	// update.
@@ -1127,6 +1190,10 @@ func updateNonComputedColExpr(
 		return err
 	}
 
+	if err := params.p.updateTextSearchRefsForColumn(params.ctx, col.ColumnDesc()); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -1444,6 +1511,24 @@ func validateConstraintNameIsNotUsed(
 	case *tree.ForeignKeyConstraintTableDef:
 		name = d.Name
 		hasIfNotExists = d.IfNotExists
+	case *tree.ExclusionConstraintTableDef:
+		name = d.Name
+		hasIfNotExists = d.IfNotExists
+		// The supporting index of an exclusion constraint has the same name as
+		// the constraint.
+		if name == "" {
+			return false, nil
+		}
+		if idx := catalog.FindIndexByName(tableDesc, string(name)); idx != nil {
+			if d.IfNotExists {
+				return true, nil
+			}
+			if idx.Dropped() {
+				return false, pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+					"index %q being dropped, try again later", name)
+			}
+			return false, pgerror.Newf(pgcode.DuplicateRelation, "constraint with name %q already exists", name)
+		}
 	case *tree.UniqueConstraintTableDef:
 		name = d.Name
 		hasIfNotExists = d.IfNotExists
@@ -1627,6 +1712,20 @@ func dropColumnImpl(
 		return nil, err
 	}
 
+	// You can't drop a column that is referenced by a trigger.
+	for i := range tableDesc.Triggers {
+		trig := &tableDesc.Triggers[i]
+		referenced, err := triggerReferencesColumn(trig, colToDrop)
+		if err != nil {
+			return nil, err
+		}
+		if referenced {
+			return nil, pgerror.Newf(pgcode.DependentObjectsStillExist,
+				"cannot drop column %q because trigger %q on table %q depends on it",
+				colToDrop.GetName(), trig.Name, tableDesc.GetName())
+		}
+	}
+
 	// If the dropped column uses a sequence, remove references to it from that sequence.
 	if colToDrop.NumUsesSequences() > 0 {
 		if err := params.p.removeSequenceDependencies(params.ctx, tableDesc, colToDrop); err != nil {
@@ -1622,6 +1622,15 @@ func ValidateConstraint(
 			return txn.WithSyntheticDescriptors(
 				[]catalog.Descriptor{tableDesc},
 				func() error {
+					if uwi.IsExclusionConstraint() {
+						return validateExclusionConstraint(
+							ctx, tableDesc, uwi.UniqueWithoutIndexDesc(),
+							indexIDForValidation,
+							txn,
+							sessionData.User(),
+							false, /* preExisting */
+						)
+					}
 					return validateUniqueConstraint(
 						ctx, tableDesc, uwi.GetName(),
 						uwi.CollectKeyColumnIDs().Ordered(),
@@ -2824,6 +2833,17 @@ func validateUniqueWithoutIndexConstraintInTxn(
 	return txn.WithSyntheticDescriptors(
 		syntheticDescs,
 		func() error {
+			if uc.IsExclusion() {
+				return validateExclusionConstraint(
+					ctx,
+					tableDesc,
+					uc,
+					0, /* indexIDForValidation */
+					txn,
+					user,
+					false, /* preExisting */
+				)
+			}
 			return validateUniqueConstraint(
 				ctx,
 				tableDesc,
@@ -136,6 +136,24 @@ func IndexColumnEncodingDirection(dir catenumpb.IndexColumn_Direction) (encoding
 	}
 }
 
+// IndexColumnNullsEncodingDirection returns the encoding.Direction of the NULL
+// marker of an index column with the given direction and NULLs order. NULLs
+// that are not in their default position are encoded with the marker of the
+// opposite direction, so that they sort after all other values when ascending
+// and before them when descending.
+func IndexColumnNullsEncodingDirection(
+	dir catenumpb.IndexColumn_Direction, nullsOrder catenumpb.IndexColumn_NullsOrder,
+) (encoding.Direction, error) {
+	encDir, err := IndexColumnEncodingDirection(dir)
+	if err != nil || nullsOrder == catenumpb.IndexColumn_NULLS_DEFAULT {
+		return encDir, err
+	}
+	if encDir == encoding.Ascending {
+		return encoding.Descending, nil
+	}
+	return encoding.Ascending, nil
+}
+
 // IndexKeyValDirs returns the corresponding encoding.Directions for all the
 // encoded values in index's "fullest" possible index key, including directions
 // for table/index IDs and the index column values.
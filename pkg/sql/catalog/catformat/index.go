@@ -17,6 +17,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/geo/geoindex"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catenumpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
@@ -252,6 +253,13 @@ func FormatIndexElements(
 			f.WriteByte(' ')
 			f.WriteString(index.KeyColumnDirections[i].String())
 		}
+		if index.KeyColumnNullsOrder(i) == catenumpb.IndexColumn_NULLS_REVERSED {
+			if index.KeyColumnDirections[i] == catenumpb.IndexColumn_DESC {
+				f.WriteString(" NULLS FIRST")
+			} else {
+				f.WriteString(" NULLS LAST")
+			}
+		}
 	}
 	return nil
 }
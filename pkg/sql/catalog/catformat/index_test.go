@@ -105,6 +105,12 @@ func TestIndexForDisplay(t *testing.T) {
 		catenumpb.IndexColumn_ASC, catenumpb.IndexColumn_DESC, catenumpb.IndexColumn_ASC,
 	}
 
+	// INDEX baz (a ASC NULLS LAST, b DESC NULLS FIRST)
+	nullsOrderIndex := baseIndex
+	nullsOrderIndex.KeyColumnNullsOrders = []catenumpb.IndexColumn_NullsOrder{
+		catenumpb.IndexColumn_NULLS_REVERSED, catenumpb.IndexColumn_NULLS_REVERSED,
+	}
+
 	// Hash Sharded INDEX baz (a)
 	shardedIndex := baseIndex
 	shardedIndex.KeyColumnNames = []string{"bucket_col", "a"}
@@ -133,6 +139,14 @@ func TestIndexForDisplay(t *testing.T) {
 			expected:    "INDEX baz (a ASC, b DESC)",
 			pgExpected:  "INDEX baz USING btree (a ASC, b DESC)",
 		},
+		{
+			index:       nullsOrderIndex,
+			tableName:   descpb.AnonymousTable,
+			partition:   "",
+			displayMode: IndexDisplayDefOnly,
+			expected:    "INDEX baz (a ASC NULLS LAST, b DESC NULLS FIRST)",
+			pgExpected:  "INDEX baz USING btree (a ASC NULLS LAST, b DESC NULLS FIRST)",
+		},
 		{
 			index:       baseIndex,
 			tableName:   descpb.AnonymousTable,
@@ -18,6 +18,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/errors"
@@ -140,6 +141,30 @@ func ValidateColumnDefType(ctx context.Context, version clusterversion.Handle, t
 			)
 		}
 
+	case types.JsonpathFamily:
+		if !version.IsActive(ctx, clusterversion.V24_1) {
+			return pgerror.Newf(
+				pgcode.FeatureNotSupported,
+				"jsonpath not supported until version 24.1",
+			)
+		}
+
+	case types.GeometricFamily:
+		if !version.IsActive(ctx, clusterversion.V24_1) {
+			return pgerror.Newf(
+				pgcode.FeatureNotSupported,
+				"geometric types not supported until version 24.1",
+			)
+		}
+
+	case types.RangeFamily, types.MultirangeFamily:
+		if !version.IsActive(ctx, clusterversion.V24_1) {
+			return pgerror.Newf(
+				pgcode.FeatureNotSupported,
+				"range types not supported until version 24.1",
+			)
+		}
+
 	default:
 		return pgerror.Newf(pgcode.InvalidTableDefinition,
 			"value type %s cannot be used for table columns", t.String())
@@ -148,6 +173,35 @@ func ValidateColumnDefType(ctx context.Context, version clusterversion.Handle, t
 	return nil
 }
 
+// ValidateColumnValue returns an error if the given value cannot be written to
+// a column at the active cluster version. Arrays whose dimensions differ from
+// the default are encoded along with their dimensions, which nodes running
+// versions prior to 24.1 cannot decode.
+func ValidateColumnValue(ctx context.Context, version clusterversion.Handle, d tree.Datum) error {
+	switch t := d.(type) {
+	case *tree.DArray:
+		if t.Dims != nil && !version.IsActive(ctx, clusterversion.V24_1) {
+			return pgerror.New(pgcode.FeatureNotSupported,
+				"multidimensional arrays and arrays with non-default lower bounds "+
+					"not supported until version 24.1")
+		}
+		if t.ParamTyp.Family() == types.TupleFamily {
+			for _, elem := range t.Array {
+				if err := ValidateColumnValue(ctx, version, elem); err != nil {
+					return err
+				}
+			}
+		}
+	case *tree.DTuple:
+		for _, elem := range t.D {
+			if err := ValidateColumnValue(ctx, version, elem); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // ColumnTypeIsIndexable returns whether the type t is valid as an indexed column.
 func ColumnTypeIsIndexable(t *types.T) bool {
 	if t.IsAmbiguous() || t.Family() == types.TupleFamily || t.Family() == types.RefCursorFamily {
@@ -162,7 +216,7 @@ func ColumnTypeIsIndexable(t *types.T) bool {
 // using an inverted index.
 func ColumnTypeIsInvertedIndexable(t *types.T) bool {
 	switch t.Family() {
-	case types.JsonFamily, types.ArrayFamily, types.StringFamily:
+	case types.JsonFamily, types.ArrayFamily, types.StringFamily, types.RangeFamily, types.MultirangeFamily:
 		return true
 	}
 	return ColumnTypeIsOnlyInvertedIndexable(t)
@@ -200,7 +254,8 @@ func MustBeValueEncoded(semanticType *types.T) bool {
 		}
 	case types.TupleFamily, types.GeographyFamily, types.GeometryFamily:
 		return true
-	case types.TSVectorFamily, types.TSQueryFamily:
+	case types.TSVectorFamily, types.TSQueryFamily, types.JsonpathFamily, types.GeometricFamily,
+		types.MultirangeFamily:
 		return true
 	}
 	return false
@@ -228,6 +228,16 @@ func (desc *immutable) GetNonDroppedSchemaName(schemaID descpb.ID) string {
 	return ""
 }
 
+// GetForeignServer implements the DatabaseDescriptor interface.
+func (desc *immutable) GetForeignServer(name string) *descpb.DatabaseDescriptor_ForeignServer {
+	for i := range desc.ForeignServers {
+		if desc.ForeignServers[i].Name == name {
+			return &desc.ForeignServers[i]
+		}
+	}
+	return nil
+}
+
 // ValidateSelf validates that the database descriptor is well formed.
 // Checks include validate the database name, and verifying that there
 // is at least one read and write user.
@@ -256,6 +266,21 @@ func (desc *immutable) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 	}
 
 	desc.maybeValidateSystemDatabaseSchemaVersion(vea)
+	desc.validateForeignServers(vea)
+}
+
+// validateForeignServers checks that the foreign servers of the database have
+// unique, non-empty names.
+func (desc *immutable) validateForeignServers(vea catalog.ValidationErrorAccumulator) {
+	servers := make(map[string]struct{}, len(desc.ForeignServers))
+	for _, server := range desc.ForeignServers {
+		if server.Name == "" {
+			vea.Report(errors.AssertionFailedf("foreign server with empty name"))
+		} else if _, ok := servers[server.Name]; ok {
+			vea.Report(errors.AssertionFailedf("duplicate foreign server %q", server.Name))
+		}
+		servers[server.Name] = struct{}{}
+	}
 }
 
 // validateMultiRegion performs checks specific to multi-region DBs.
@@ -552,6 +577,16 @@ func (desc *Mutable) AddSchemaToDatabase(
 	desc.Schemas[schemaName] = schemaInfo
 }
 
+// RemoveForeignServer removes the foreign server with the given name, if any.
+func (desc *Mutable) RemoveForeignServer(name string) {
+	for i := range desc.ForeignServers {
+		if desc.ForeignServers[i].Name == name {
+			desc.ForeignServers = append(desc.ForeignServers[:i], desc.ForeignServers[i+1:]...)
+			return
+		}
+	}
+}
+
 // GetDeclarativeSchemaChangerState is part of the catalog.MutableDescriptor
 // interface.
 func (desc *immutable) GetDeclarativeSchemaChangerState() *scpb.DescriptorState {
@@ -41,13 +41,14 @@ func (desc *IndexDescriptor) ExplicitColumnStartIdx() int {
 	return start
 }
 
-// FillColumns sets the column names and directions in desc. Note that it does
-// no validation with regards to the existence of the listed columns. It also
-// delegates filling in any IDs until later.
+// FillColumns sets the column names, directions and NULLs orders in desc. Note
+// that it does no validation with regards to the existence of the listed
+// columns. It also delegates filling in any IDs until later.
 func (desc *IndexDescriptor) FillColumns(elems tree.IndexElemList) error {
 	desc.KeyColumnNames = make([]string, 0, len(elems))
 	desc.KeyColumnDirections = make([]catenumpb.IndexColumn_Direction, 0, len(elems))
-	for _, c := range elems {
+	desc.KeyColumnNullsOrders = nil
+	for i, c := range elems {
 		if c.Expr != nil {
 			return errors.AssertionFailedf("index elem expression should have been replaced with a column")
 		}
@@ -60,10 +61,38 @@ func (desc *IndexDescriptor) FillColumns(elems tree.IndexElemList) error {
 		default:
 			return fmt.Errorf("invalid direction %s for column %s", c.Direction, c.Column)
 		}
+		if nullsOrder := IndexColumnNullsOrder(c.Direction, c.NullsOrder); nullsOrder != catenumpb.IndexColumn_NULLS_DEFAULT {
+			for len(desc.KeyColumnNullsOrders) < i {
+				desc.KeyColumnNullsOrders = append(desc.KeyColumnNullsOrders, catenumpb.IndexColumn_NULLS_DEFAULT)
+			}
+			desc.KeyColumnNullsOrders = append(desc.KeyColumnNullsOrders, nullsOrder)
+		}
 	}
 	return nil
 }
 
+// KeyColumnNullsOrder returns the placement of NULLs in the columnOrdinal-th
+// key column of the index.
+func (desc *IndexDescriptor) KeyColumnNullsOrder(columnOrdinal int) catenumpb.IndexColumn_NullsOrder {
+	if columnOrdinal < len(desc.KeyColumnNullsOrders) {
+		return desc.KeyColumnNullsOrders[columnOrdinal]
+	}
+	return catenumpb.IndexColumn_NULLS_DEFAULT
+}
+
+// IndexColumnNullsOrder converts the direction and NULLs order of an index
+// element to the placement of NULLs in the index column. NULLs are placed
+// first in ascending columns and last in descending columns by default.
+func IndexColumnNullsOrder(
+	dir tree.Direction, nullsOrder tree.NullsOrder,
+) catenumpb.IndexColumn_NullsOrder {
+	if (dir == tree.Descending && nullsOrder == tree.NullsFirst) ||
+		(dir != tree.Descending && nullsOrder == tree.NullsLast) {
+		return catenumpb.IndexColumn_NULLS_REVERSED
+	}
+	return catenumpb.IndexColumn_NULLS_DEFAULT
+}
+
 // explicitColumnIDsWithoutShardColumn returns explicit column ids of the index
 // excluding the shard column.
 func (desc *IndexDescriptor) explicitColumnIDsWithoutShardColumn() ColumnIDs {
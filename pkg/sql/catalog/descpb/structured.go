@@ -74,6 +74,12 @@ type IndexID = catid.IndexID
 // ConstraintID is a custom type for TableDescriptor constraint IDs.
 type ConstraintID = catid.ConstraintID
 
+// TriggerID is a custom type for TableDescriptor trigger IDs.
+type TriggerID uint32
+
+// SafeValue implements the redact.SafeValue interface.
+func (TriggerID) SafeValue() {}
+
 // DescriptorVersion is a custom type for TableDescriptor Versions.
 type DescriptorVersion uint64
 
@@ -217,7 +223,7 @@ func (desc *TableDescriptor) Adding() bool {
 
 // IsTable implements the TableDescriptor interface.
 func (desc *TableDescriptor) IsTable() bool {
-	return !desc.IsView() && !desc.IsSequence()
+	return !desc.IsView() && !desc.IsSequence() && !desc.IsForeignTable()
 }
 
 // IsView implements the TableDescriptor interface.
@@ -245,6 +251,11 @@ func (desc *TableDescriptor) IsSequence() bool {
 	return desc.SequenceOpts != nil
 }
 
+// IsForeignTable implements the TableDescriptor interface.
+func (desc *TableDescriptor) IsForeignTable() bool {
+	return desc.ForeignTable != nil
+}
+
 // IsVirtualTable implements the TableDescriptor interface.
 func (desc *TableDescriptor) IsVirtualTable() bool {
 	return IsVirtualTable(desc.ID)
@@ -323,6 +334,21 @@ func (u *UniqueWithoutIndexConstraint) IsPartial() bool {
 	return u.Predicate != ""
 }
 
+// IsExclusion returns true if the constraint is an exclusion constraint.
+func (u *UniqueWithoutIndexConstraint) IsExclusion() bool {
+	return len(u.ExclusionElements) > 0
+}
+
+// The operators supported by the elements of an exclusion constraint.
+const (
+	// ExclusionOperatorEquals conflicts when the values of the element are
+	// equal.
+	ExclusionOperatorEquals = "="
+	// ExclusionOperatorOverlaps conflicts when the values of the element
+	// overlap.
+	ExclusionOperatorOverlaps = "&&"
+)
+
 // GetParentID implements the catalog.NameKeyHaver interface.
 func (ni NameInfo) GetParentID() ID {
 	return ni.ParentID
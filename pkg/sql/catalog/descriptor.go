@@ -295,6 +295,9 @@ type DatabaseDescriptor interface {
 	// HasPublicSchemaWithDescriptor returns true iff the database has a public
 	// schema which itself has a descriptor.
 	HasPublicSchemaWithDescriptor() bool
+	// GetForeignServer returns the foreign server with the given name, or nil
+	// if there is none.
+	GetForeignServer(name string) *descpb.DatabaseDescriptor_ForeignServer
 }
 
 // TableDescriptor is an interface around the table descriptor types.
@@ -317,6 +320,9 @@ type TableDescriptor interface {
 	// IsSequence returns true if the TableDescriptor actually describes a
 	// Sequence resource rather than a Table.
 	IsSequence() bool
+	// IsForeignTable returns true if the TableDescriptor describes a foreign
+	// table, whose rows are read from external storage rather than a Table.
+	IsForeignTable() bool
 	// IsTemporary returns true if this is a temporary table.
 	IsTemporary() bool
 	// IsVirtualTable returns true if the TableDescriptor describes a
@@ -339,6 +345,11 @@ type TableDescriptor interface {
 	// GetSequenceOpts returns the sequence options for this table. Only valid if
 	// IsSequence is true.
 	GetSequenceOpts() *descpb.TableDescriptor_SequenceOpts
+	// GetForeignTable returns the foreign table options for this table. Only
+	// valid if IsForeignTable is true.
+	GetForeignTable() *descpb.TableDescriptor_ForeignTable
+	// GetTriggers returns the triggers defined on this table.
+	GetTriggers() []descpb.TriggerDescriptor
 
 	// GetCreateQuery returns the full CREATE TABLE AS query that was used for
 	// table's creation. Only valid if IsAs is true.
@@ -815,6 +826,16 @@ type TypeDescriptor interface {
 	// nil otherwise.
 	AsCompositeTypeDescriptor() CompositeTypeDescriptor
 
+	// AsDomainTypeDescriptor returns this instance cast to
+	// DomainTypeDescriptor if this type is a domain type,
+	// nil otherwise.
+	AsDomainTypeDescriptor() DomainTypeDescriptor
+
+	// AsRangeTypeDescriptor returns this instance cast to
+	// RangeTypeDescriptor if this type is a range type,
+	// nil otherwise.
+	AsRangeTypeDescriptor() RangeTypeDescriptor
+
 	// AsTableImplicitRecordTypeDescriptor returns this instance cast to
 	// TableImplicitRecordTypeDescriptor if this type is an implicit table record
 	// type, nil otherwise.
@@ -902,6 +923,41 @@ type CompositeTypeDescriptor interface {
 	GetElementType(ordinal int) *types.T
 }
 
+// DomainTypeDescriptor is the TypeDescriptor subtype for domain types, which
+// are base types with constraints.
+type DomainTypeDescriptor interface {
+	NonAliasTypeDescriptor
+
+	// BaseType returns the type that the domain is defined over.
+	BaseType() *types.T
+
+	// IsNotNull returns true if the domain does not allow NULL values.
+	IsNotNull() bool
+
+	// GetDomainDefaultExpr returns the serialized default expression of the
+	// domain, or the empty string if it has none.
+	GetDomainDefaultExpr() string
+
+	// NumChecks returns the number of CHECK constraints of the domain.
+	NumChecks() int
+
+	// GetCheckName returns the name of the CHECK constraint at the given
+	// ordinal.
+	GetCheckName(ordinal int) string
+
+	// GetCheckExpr returns the serialized expression of the CHECK constraint at
+	// the given ordinal.
+	GetCheckExpr(ordinal int) string
+}
+
+// RangeTypeDescriptor is the TypeDescriptor subtype for range types.
+type RangeTypeDescriptor interface {
+	NonAliasTypeDescriptor
+
+	// RangeSubtype returns the type of the values in the range.
+	RangeSubtype() *types.T
+}
+
 // TableImplicitRecordTypeDescriptor is the TypeDescriptor subtype for the
 // record type implicitly defined by a table.
 type TableImplicitRecordTypeDescriptor interface {
@@ -971,9 +1027,21 @@ type FunctionDescriptor interface {
 	// can be used for execution.
 	ToOverload() (ret *tree.Overload, err error)
 
+	// ToSignature returns the signature of the function that is stored in its
+	// parent schema descriptor.
+	ToSignature() descpb.SchemaDescriptor_FunctionSignature
+
 	// GetLanguage returns the language of this function.
 	GetLanguage() catpb.Function_Language
 
+	// GetSecurity returns whether the function executes with the privileges of
+	// the invoker or of its owner.
+	GetSecurity() catpb.Function_Security
+
+	// GetConfig returns the session variables, in "name=value" form, which are
+	// set while the function executes.
+	GetConfig() []string
+
 	// ToCreateExpr converts a function descriptor back to a CREATE FUNCTION or
 	// CREATE PROCEDURE statement. This is mainly used for formatting, e.g.,
 	// SHOW CREATE FUNCTION and SHOW CREATE PROCEDURE.
@@ -982,6 +1050,10 @@ type FunctionDescriptor interface {
 	// IsProcedure returns true if the descriptor represents a procedure. It
 	// returns false if the descriptor represents a user-defined function.
 	IsProcedure() bool
+
+	// IsAggregate returns true if the descriptor represents a user-defined
+	// aggregate.
+	IsAggregate() bool
 }
 
 // FilterDroppedDescriptor returns an error if the descriptor state is DROP.
@@ -12,6 +12,7 @@ package funcdesc
 
 import (
 	"sort"
+	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
@@ -24,6 +25,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/iterutil"
@@ -175,6 +177,9 @@ func (desc *immutable) GetReferencedDescIDs() (catalog.DescriptorIDSet, error) {
 	for _, dep := range desc.DependedOnBy {
 		ret.Add(dep.ID)
 	}
+	for _, id := range desc.aggregateSupportFuncIDs() {
+		ret.Add(id)
+	}
 
 	return ret, nil
 }
@@ -228,6 +233,18 @@ func (desc *immutable) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 			vea.Report(errors.AssertionFailedf("invalid type id %d in depends-on-types references #%d", typeID, i))
 		}
 	}
+
+	if agg := desc.Aggregate; agg != nil {
+		if agg.StateFuncID == descpb.InvalidID {
+			vea.Report(errors.AssertionFailedf("state function not set for aggregate"))
+		}
+		if agg.StateType == nil {
+			vea.Report(errors.AssertionFailedf("state type not set for aggregate"))
+		}
+		if desc.IsProcedure() || desc.ReturnType.ReturnSet {
+			vea.Report(errors.AssertionFailedf("aggregate cannot be a procedure or return a set"))
+		}
+	}
 }
 
 // ValidateForwardReferences implements the catalog.Descriptor interface.
@@ -257,6 +274,16 @@ func (desc *immutable) ValidateForwardReferences(
 	for _, typeID := range desc.DependsOnTypes {
 		vea.Report(catalog.ValidateOutboundTypeRef(typeID, vdg))
 	}
+
+	for _, fnID := range desc.aggregateSupportFuncIDs() {
+		fn, err := vdg.GetFunctionDescriptor(fnID)
+		if err != nil {
+			vea.Report(errors.NewAssertionErrorWithWrappedErrf(err, "invalid aggregate support function reference"))
+		} else if fn.Dropped() {
+			vea.Report(errors.AssertionFailedf("aggregate support function %q (%d) is dropped",
+				fn.GetName(), fn.GetID()))
+		}
+	}
 }
 
 // ValidateBackReferences implements the catalog.Descriptor interface.
@@ -278,13 +305,65 @@ func (desc *immutable) ValidateBackReferences(
 		vea.Report(catalog.ValidateOutboundTypeRefBackReference(desc.GetID(), typ))
 	}
 
-	// Currently, we don't support cross function references yet.
-	// So here we assume that all inbound references are from tables.
+	for _, fnID := range desc.aggregateSupportFuncIDs() {
+		fn, err := vdg.GetFunctionDescriptor(fnID)
+		if err != nil {
+			continue
+		}
+		var found bool
+		for _, by := range fn.GetDependedOnBy() {
+			if by.ID == desc.GetID() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			vea.Report(errors.AssertionFailedf("aggregate support function %q (%d) has no corresponding depended-on-by back reference",
+				fn.GetName(), fn.GetID()))
+		}
+	}
+
+	// The only function references to functions are those of aggregates to
+	// their support functions. All other inbound references are from tables.
 	for _, by := range desc.DependedOnBy {
+		if d, err := vdg.GetDescriptor(by.ID); err == nil && d.DescriptorType() == catalog.Function {
+			vea.Report(desc.validateInboundFunctionRef(d.(catalog.FunctionDescriptor)))
+			continue
+		}
 		vea.Report(desc.validateInboundTableRef(by, vdg))
 	}
 }
 
+// validateInboundFunctionRef validates a back reference from an aggregate
+// which uses this function as a support function.
+func (desc *immutable) validateInboundFunctionRef(backRefFn catalog.FunctionDescriptor) error {
+	if backRefFn.Dropped() {
+		return errors.AssertionFailedf("depended-on-by function %q (%d) is dropped",
+			backRefFn.GetName(), backRefFn.GetID())
+	}
+	if agg := backRefFn.FuncDesc().Aggregate; agg != nil {
+		for _, id := range []descpb.ID{agg.StateFuncID, agg.FinalFuncID, agg.CombineFuncID} {
+			if id == desc.GetID() {
+				return nil
+			}
+		}
+	}
+	return errors.AssertionFailedf("depended-on-by function %q (%d) has no corresponding forward reference",
+		backRefFn.GetName(), backRefFn.GetID())
+}
+
+// aggregateSupportFuncIDs returns the IDs of the functions used to compute an
+// aggregate. It returns nil if the function is not an aggregate.
+func (desc *immutable) aggregateSupportFuncIDs() []descpb.ID {
+	agg := desc.Aggregate
+	if agg == nil {
+		return nil
+	}
+	ids := catalog.MakeDescriptorIDSet(agg.StateFuncID, agg.FinalFuncID, agg.CombineFuncID)
+	ids.Remove(descpb.InvalidID)
+	return ids.Ordered()
+}
+
 func (desc *immutable) validateFuncExistsInSchema(scDesc catalog.SchemaDescriptor) error {
 	// Check that parent Schema contains the matching function signature.
 	if _, ok := scDesc.GetFunction(desc.GetName()); !ok {
@@ -371,6 +450,23 @@ func (desc *immutable) validateInboundTableRef(
 			cstID, backRefTbl.GetName(), backRefTbl.GetID(), desc.GetName(), desc.GetID(),
 		)
 	}
+	for _, triggerID := range by.TriggerIDs {
+		var found bool
+		for _, trig := range backRefTbl.GetTriggers() {
+			if trig.ID == triggerID && trig.FuncID == desc.GetID() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return errors.AssertionFailedf(
+				"depended-on-by relation %q (%d) does not have a trigger with ID %d using function %q (%d)",
+				backRefTbl.GetName(), by.ID, triggerID, desc.GetName(), desc.GetID(),
+			)
+		}
+		foundInTable = true
+	}
+
 	if foundInTable {
 		return nil
 	}
@@ -425,6 +521,11 @@ func (desc *immutable) ForEachUDTDependentForHydration(fn func(t *types.T) error
 			return iterutil.Map(err)
 		}
 	}
+	if agg := desc.Aggregate; agg != nil && catid.IsOIDUserDefined(agg.StateType.Oid()) {
+		if err := fn(agg.StateType); err != nil {
+			return iterutil.Map(err)
+		}
+	}
 	if !catid.IsOIDUserDefined(desc.ReturnType.Type.Oid()) {
 		return nil
 	}
@@ -538,6 +639,40 @@ func (desc *Mutable) SetFuncBody(v string) {
 	desc.FunctionBody = v
 }
 
+// SetSecurity sets the security mode of the function.
+func (desc *Mutable) SetSecurity(v catpb.Function_Security) {
+	desc.Security = v
+}
+
+// SetConfigVar sets the value of the given session variable while the function
+// executes, replacing any existing value for that variable.
+func (desc *Mutable) SetConfigVar(name, value string) {
+	entry := name + "=" + value
+	for i, c := range desc.Config {
+		if strings.HasPrefix(c, name+"=") {
+			desc.Config[i] = entry
+			return
+		}
+	}
+	desc.Config = append(desc.Config, entry)
+}
+
+// ResetConfigVar removes the setting of the given session variable from the
+// function.
+func (desc *Mutable) ResetConfigVar(name string) {
+	for i, c := range desc.Config {
+		if strings.HasPrefix(c, name+"=") {
+			desc.Config = append(desc.Config[:i], desc.Config[i+1:]...)
+			return
+		}
+	}
+}
+
+// ResetConfig removes all session variable settings from the function.
+func (desc *Mutable) ResetConfig() {
+	desc.Config = nil
+}
+
 // SetName sets the function name.
 func (desc *Mutable) SetName(n string) {
 	desc.Name = n
@@ -635,14 +770,61 @@ func (desc *Mutable) RemoveColumnReference(id descpb.ID, colID descpb.ColumnID)
 	}
 }
 
+// AddTriggerReference adds back reference to a trigger to the function.
+func (desc *Mutable) AddTriggerReference(id descpb.ID, triggerID descpb.TriggerID) {
+	for i := range desc.DependedOnBy {
+		if desc.DependedOnBy[i].ID == id {
+			for _, existing := range desc.DependedOnBy[i].TriggerIDs {
+				if existing == triggerID {
+					return
+				}
+			}
+			desc.DependedOnBy[i].TriggerIDs = append(desc.DependedOnBy[i].TriggerIDs, triggerID)
+			sort.Slice(desc.DependedOnBy[i].TriggerIDs, func(a, b int) bool {
+				return desc.DependedOnBy[i].TriggerIDs[a] < desc.DependedOnBy[i].TriggerIDs[b]
+			})
+			return
+		}
+	}
+	desc.DependedOnBy = append(
+		desc.DependedOnBy,
+		descpb.FunctionDescriptor_Reference{
+			ID:         id,
+			TriggerIDs: []descpb.TriggerID{triggerID},
+		},
+	)
+	sort.Slice(desc.DependedOnBy, func(i, j int) bool {
+		return desc.DependedOnBy[i].ID < desc.DependedOnBy[j].ID
+	})
+}
+
+// RemoveTriggerReference removes back reference to a trigger from the
+// function.
+func (desc *Mutable) RemoveTriggerReference(id descpb.ID, triggerID descpb.TriggerID) {
+	for i := range desc.DependedOnBy {
+		if desc.DependedOnBy[i].ID == id {
+			ids := desc.DependedOnBy[i].TriggerIDs[:0]
+			for _, existing := range desc.DependedOnBy[i].TriggerIDs {
+				if existing != triggerID {
+					ids = append(ids, existing)
+				}
+			}
+			desc.DependedOnBy[i].TriggerIDs = ids
+			desc.maybeRemoveTableReference(id)
+			return
+		}
+	}
+}
+
 // maybeRemoveTableReference removes a table's references from the function if
-// the column, index and constraint references are all empty. This function is
-// only used internally when removing an individual column, index or constraint
-// reference.
+// the column, index, constraint and trigger references are all empty. This
+// function is only used internally when removing an individual column, index,
+// constraint or trigger reference.
 func (desc *Mutable) maybeRemoveTableReference(id descpb.ID) {
 	var ret []descpb.FunctionDescriptor_Reference
 	for _, ref := range desc.DependedOnBy {
-		if ref.ID == id && len(ref.ColumnIDs) == 0 && len(ref.IndexIDs) == 0 && len(ref.ConstraintIDs) == 0 {
+		if ref.ID == id && len(ref.ColumnIDs) == 0 && len(ref.IndexIDs) == 0 &&
+			len(ref.ConstraintIDs) == 0 && len(ref.TriggerIDs) == 0 {
 			continue
 		}
 		ret = append(ret, ref)
@@ -684,6 +866,9 @@ func (desc *immutable) GetObjectTypeString() string {
 	if desc.IsProcedure() {
 		return "procedure"
 	}
+	if desc.IsAggregate() {
+		return "aggregate"
+	}
 	return "function"
 }
 
@@ -713,13 +898,39 @@ func (desc *immutable) ToOverload() (ret *tree.Overload, err error) {
 	}
 
 	argTypes := make(tree.ParamTypes, 0, len(desc.Params))
+	var hasNonInParams bool
 	for _, param := range desc.Params {
-		argTypes = append(
-			argTypes,
-			tree.ParamType{Name: param.Name, Typ: param.Type},
-		)
+		if desc.isInputParam(param) {
+			argTypes = append(
+				argTypes,
+				tree.ParamType{Name: param.Name, Typ: param.Type},
+			)
+		}
+		if param.Class != catpb.Function_Param_IN {
+			hasNonInParams = true
+		}
 	}
 	ret.Types = argTypes
+	if desc.isVariadic() {
+		// The arguments passed for the variadic parameter are collected into an
+		// array.
+		fixedTypes := make([]*types.T, len(argTypes)-1)
+		for i := range fixedTypes {
+			fixedTypes[i] = argTypes[i].Typ
+		}
+		ret.Types = tree.VariadicType{
+			FixedTypes: fixedTypes,
+			VarType:    argTypes[len(argTypes)-1].Typ.ArrayContents(),
+		}
+	}
+	if hasNonInParams {
+		ret.ParamClasses = make([]tree.RoutineParamClass, len(desc.Params))
+		ret.RoutineParams = make(tree.ParamTypes, len(desc.Params))
+		for i := range desc.Params {
+			ret.ParamClasses[i] = toTreeNodeParamClass(desc.Params[i].Class)
+			ret.RoutineParams[i] = tree.ParamType{Name: desc.Params[i].Name, Typ: desc.Params[i].Type}
+		}
+	}
 	ret.Volatility, err = desc.getOverloadVolatility()
 	if err != nil {
 		return nil, err
@@ -731,10 +942,67 @@ func (desc *immutable) ToOverload() (ret *tree.Overload, err error) {
 	if desc.ReturnType.ReturnSet {
 		ret.Class = tree.GeneratorClass
 	}
+	if agg := desc.Aggregate; agg != nil {
+		ret.Class = tree.AggregateClass
+		ret.Aggregate = &tree.UDFAggregate{
+			StateFunc: catid.FuncIDToOID(agg.StateFuncID),
+			StateType: agg.StateType,
+			InitCond:  agg.InitCond,
+		}
+		if agg.FinalFuncID != descpb.InvalidID {
+			ret.Aggregate.FinalFunc = catid.FuncIDToOID(agg.FinalFuncID)
+		}
+		if agg.CombineFuncID != descpb.InvalidID {
+			ret.Aggregate.CombineFunc = catid.FuncIDToOID(agg.CombineFuncID)
+		}
+	}
+	if desc.Security == catpb.Function_DEFINER || len(desc.Config) > 0 {
+		ret.RoutineExecContext = &tree.RoutineExecContext{Config: desc.Config}
+		if desc.Security == catpb.Function_DEFINER {
+			ret.RoutineExecContext.Definer = desc.Privileges.Owner()
+		}
+	}
 
 	return ret, nil
 }
 
+// ToSignature implements the FunctionDescriptor interface.
+func (desc *immutable) ToSignature() descpb.SchemaDescriptor_FunctionSignature {
+	ret := descpb.SchemaDescriptor_FunctionSignature{
+		ID:          desc.GetID(),
+		ReturnType:  desc.ReturnType.Type,
+		ReturnSet:   desc.ReturnType.ReturnSet,
+		IsProcedure: desc.IsProcedure(),
+		IsVariadic:  desc.isVariadic(),
+		IsAggregate: desc.IsAggregate(),
+	}
+	for _, param := range desc.Params {
+		if desc.isInputParam(param) {
+			ret.ArgTypes = append(ret.ArgTypes, param.Type)
+		}
+	}
+	return ret
+}
+
+// isInputParam returns true if an argument is passed for the given parameter
+// when the routine is invoked. The OUT parameters of a function are not part
+// of its signature, but those of a procedure are.
+func (desc *immutable) isInputParam(param descpb.FunctionDescriptor_Parameter) bool {
+	return desc.IsProcedure() ||
+		(param.Class != catpb.Function_Param_OUT && param.Class != catpb.Function_Param_TABLE)
+}
+
+// isVariadic returns true if the last input parameter of the routine is a
+// VARIADIC parameter.
+func (desc *immutable) isVariadic() bool {
+	for i := len(desc.Params) - 1; i >= 0; i-- {
+		if desc.isInputParam(desc.Params[i]) {
+			return desc.Params[i].Class == catpb.Function_Param_VARIADIC
+		}
+	}
+	return false
+}
+
 func (desc *immutable) getOverloadVolatility() (volatility.V, error) {
 	var ret volatility.V
 	switch desc.Volatility {
@@ -794,12 +1062,31 @@ func (desc *immutable) ToCreateExpr() (ret *tree.CreateRoutine, err error) {
 			}
 		}
 	}
-	// We only store 5 function attributes at the moment. We may extend the
-	// pre-allocated capacity in the future.
-	ret.Options = make(tree.RoutineOptions, 0, 5)
+	// We only store 5 function attributes, plus the security mode and session
+	// variable settings, at the moment. We may extend the pre-allocated
+	// capacity in the future.
+	ret.Options = make(tree.RoutineOptions, 0, 6+len(desc.Config))
 	ret.Options = append(ret.Options, desc.getCreateExprVolatility())
 	ret.Options = append(ret.Options, tree.RoutineLeakproof(desc.LeakProof))
 	ret.Options = append(ret.Options, desc.getCreateExprNullInputBehavior())
+	if desc.Security == catpb.Function_DEFINER {
+		ret.Options = append(ret.Options, tree.RoutineDefiner)
+	}
+	for _, c := range desc.Config {
+		name, value, _ := strings.Cut(c, "=")
+		set := tree.RoutineSet{Name: name, Values: tree.Exprs{tree.NewStrVal(value)}}
+		if name == "search_path" {
+			// Each schema in the search path is a separate value, so that the
+			// statement recreates the same setting.
+			if paths, err := sessiondata.ParseSearchPath(value); err == nil {
+				set.Values = make(tree.Exprs, len(paths))
+				for i := range paths {
+					set.Values[i] = tree.NewStrVal(paths[i])
+				}
+			}
+		}
+		ret.Options = append(ret.Options, set)
+	}
 	ret.Options = append(ret.Options, tree.RoutineBodyStr(desc.FunctionBody))
 	ret.Options = append(ret.Options, desc.getCreateExprLang())
 	return ret, nil
@@ -810,6 +1097,11 @@ func (desc *immutable) IsProcedure() bool {
 	return desc.FunctionDescriptor.IsProcedure
 }
 
+// IsAggregate implements the FunctionDescriptor interface.
+func (desc *immutable) IsAggregate() bool {
+	return desc.FunctionDescriptor.Aggregate != nil
+}
+
 func (desc *immutable) getCreateExprLang() tree.RoutineLanguage {
 	switch desc.Lang {
 	case catpb.Function_SQL:
@@ -854,6 +1146,8 @@ func toTreeNodeParamClass(class catpb.Function_Param_Class) tree.RoutineParamCla
 		return tree.RoutineParamInOut
 	case catpb.Function_Param_VARIADIC:
 		return tree.RoutineParamVariadic
+	case catpb.Function_Param_TABLE:
+		return tree.RoutineParamTable
 	}
 	return 0
 }
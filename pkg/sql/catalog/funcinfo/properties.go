@@ -129,7 +129,22 @@ func ParamClassToProto(v tree.RoutineParamClass) (catpb.Function_Param_Class, er
 		return catpb.Function_Param_IN_OUT, nil
 	case tree.RoutineParamVariadic:
 		return catpb.Function_Param_VARIADIC, nil
+	case tree.RoutineParamTable:
+		return catpb.Function_Param_TABLE, nil
 	}
 
 	return -1, errors.AssertionFailedf("unknown function parameter class %q", v)
 }
+
+// SecurityToProto converts sql statement input security mode to protobuf
+// type.
+func SecurityToProto(v tree.RoutineSecurity) (catpb.Function_Security, error) {
+	switch v {
+	case tree.RoutineInvoker:
+		return catpb.Function_INVOKER, nil
+	case tree.RoutineDefiner:
+		return catpb.Function_DEFINER, nil
+	}
+
+	return -1, errors.AssertionFailedf("unknown function security mode %q", v)
+}
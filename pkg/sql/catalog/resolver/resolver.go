@@ -240,6 +240,8 @@ func ResolveExistingObject(
 			goodType = table.IsTable() || table.IsView()
 		case tree.ResolveRequireSequenceDesc:
 			goodType = table.IsSequence()
+		case tree.ResolveRequireForeignTableDesc:
+			goodType = table.IsForeignTable()
 		}
 		if !goodType {
 			return nil, prefix, sqlerrors.NewWrongObjectTypeError(getResolvedTn(), lookupFlags.DesiredTableDescKind.String())
@@ -626,6 +626,27 @@ func SchemaDescs(schemas []*schemadesc.Mutable, descriptorRewrites jobspb.DescRe
 		}
 		sc.Functions = newFns
 
+		// Rewrite the schemas of the dictionaries used by the text search
+		// configurations, dropping the ones that were not restored. Built-in
+		// dictionaries have no schema.
+		for i := range sc.TextSearchConfigurations {
+			mappings := sc.TextSearchConfigurations[i].Mappings
+			for j := range mappings {
+				dicts := mappings[j].Dictionaries[:0]
+				for _, ref := range mappings[j].Dictionaries {
+					if ref.SchemaID != descpb.InvalidID {
+						rewrite, ok := descriptorRewrites[ref.SchemaID]
+						if !ok {
+							continue
+						}
+						ref.SchemaID = rewrite.ID
+					}
+					dicts = append(dicts, ref)
+				}
+				mappings[j].Dictionaries = dicts
+			}
+		}
+
 		if err := rewriteSchemaChangerState(sc, descriptorRewrites); err != nil {
 			return err
 		}
@@ -45,6 +45,14 @@ type SchemaDescriptor interface {
 	// ForEachFunctionSignature iterates through all function signatures within
 	// the schema and calls fn on each signature.
 	ForEachFunctionSignature(fn func(sig descpb.SchemaDescriptor_FunctionSignature) error) error
+
+	// GetTextSearchDictionary returns the text search dictionary with the given
+	// name, or nil if there is none.
+	GetTextSearchDictionary(name string) *descpb.SchemaDescriptor_TextSearchDictionary
+
+	// GetTextSearchConfiguration returns the text search configuration with the
+	// given name, or nil if there is none.
+	GetTextSearchConfiguration(name string) *descpb.SchemaDescriptor_TextSearchConfiguration
 }
 
 // ResolvedSchemaKind is an enum that represents what kind of schema
@@ -67,6 +67,30 @@ func (desc *immutable) GetFunction(name string) (descpb.SchemaDescriptor_Functio
 	return fn, found
 }
 
+// GetTextSearchDictionary implements the SchemaDescriptor interface.
+func (desc *immutable) GetTextSearchDictionary(
+	name string,
+) *descpb.SchemaDescriptor_TextSearchDictionary {
+	for i := range desc.TextSearchDictionaries {
+		if desc.TextSearchDictionaries[i].Name == name {
+			return &desc.TextSearchDictionaries[i]
+		}
+	}
+	return nil
+}
+
+// GetTextSearchConfiguration implements the SchemaDescriptor interface.
+func (desc *immutable) GetTextSearchConfiguration(
+	name string,
+) *descpb.SchemaDescriptor_TextSearchConfiguration {
+	for i := range desc.TextSearchConfigurations {
+		if desc.TextSearchConfigurations[i].Name == name {
+			return &desc.TextSearchConfigurations[i]
+		}
+	}
+	return nil
+}
+
 // SkipNamespace implements the descriptor interface.
 func (desc *immutable) SkipNamespace() bool {
 	return false
@@ -238,6 +262,39 @@ func (desc *immutable) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 			}
 		}
 	}
+
+	desc.validateTextSearchObjects(vea)
+}
+
+// validateTextSearchObjects checks that the text search dictionaries and
+// configurations of the schema have unique, non-empty names.
+func (desc *immutable) validateTextSearchObjects(vea catalog.ValidationErrorAccumulator) {
+	dicts := make(map[string]struct{}, len(desc.TextSearchDictionaries))
+	for _, d := range desc.TextSearchDictionaries {
+		if d.Name == "" {
+			vea.Report(errors.AssertionFailedf("text search dictionary with empty name"))
+		} else if _, ok := dicts[d.Name]; ok {
+			vea.Report(errors.AssertionFailedf("duplicate text search dictionary %q", d.Name))
+		}
+		dicts[d.Name] = struct{}{}
+	}
+	configs := make(map[string]struct{}, len(desc.TextSearchConfigurations))
+	for _, c := range desc.TextSearchConfigurations {
+		if c.Name == "" {
+			vea.Report(errors.AssertionFailedf("text search configuration with empty name"))
+		} else if _, ok := configs[c.Name]; ok {
+			vea.Report(errors.AssertionFailedf("duplicate text search configuration %q", c.Name))
+		}
+		configs[c.Name] = struct{}{}
+		for _, m := range c.Mappings {
+			for _, ref := range m.Dictionaries {
+				if _, ok := dicts[ref.Name]; ref.SchemaID == desc.GetID() && !ok {
+					vea.Report(errors.AssertionFailedf(
+						"text search configuration %q refers to missing dictionary %q", c.Name, ref.Name))
+				}
+			}
+		}
+	}
 }
 
 // GetReferencedDescIDs returns the IDs of all descriptors referenced by
@@ -484,6 +541,28 @@ func (desc *Mutable) RemoveFunction(name string, id descpb.ID) {
 	}
 }
 
+// RemoveTextSearchDictionary removes the text search dictionary with the given
+// name, if any.
+func (desc *Mutable) RemoveTextSearchDictionary(name string) {
+	for i := range desc.TextSearchDictionaries {
+		if desc.TextSearchDictionaries[i].Name == name {
+			desc.TextSearchDictionaries = append(desc.TextSearchDictionaries[:i], desc.TextSearchDictionaries[i+1:]...)
+			return
+		}
+	}
+}
+
+// RemoveTextSearchConfiguration removes the text search configuration with the
+// given name, if any.
+func (desc *Mutable) RemoveTextSearchConfiguration(name string) {
+	for i := range desc.TextSearchConfigurations {
+		if desc.TextSearchConfigurations[i].Name == name {
+			desc.TextSearchConfigurations = append(desc.TextSearchConfigurations[:i], desc.TextSearchConfigurations[i+1:]...)
+			return
+		}
+	}
+}
+
 // GetObjectType implements the Object interface.
 func (desc *immutable) GetObjectType() privilege.ObjectType {
 	return privilege.Schema
@@ -526,6 +605,9 @@ func (desc *immutable) GetResolvedFuncDefinition(
 		if funcDescPb.Signatures[i].ReturnSet {
 			overload.Class = tree.GeneratorClass
 		}
+		if sig.IsAggregate {
+			overload.Class = tree.AggregateClass
+		}
 		paramTypes := make(tree.ParamTypes, 0, len(sig.ArgTypes))
 		for _, paramType := range sig.ArgTypes {
 			paramTypes = append(
@@ -534,6 +616,14 @@ func (desc *immutable) GetResolvedFuncDefinition(
 			)
 		}
 		overload.Types = paramTypes
+		if sig.IsVariadic {
+			fixedTypes := make([]*types.T, len(sig.ArgTypes)-1)
+			copy(fixedTypes, sig.ArgTypes)
+			overload.Types = tree.VariadicType{
+				FixedTypes: fixedTypes,
+				VarType:    sig.ArgTypes[len(sig.ArgTypes)-1].ArrayContents(),
+			}
+		}
 		prefixedOverload := tree.MakeQualifiedOverload(desc.GetName(), overload)
 		funcDef.Overloads = append(funcDef.Overloads, prefixedOverload)
 	}
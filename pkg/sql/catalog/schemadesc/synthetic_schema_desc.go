@@ -149,6 +149,20 @@ func (p synthetic) ForEachFunctionSignature(
 	return nil
 }
 
+// GetTextSearchDictionary implements the SchemaDescriptor interface.
+func (p synthetic) GetTextSearchDictionary(
+	name string,
+) *descpb.SchemaDescriptor_TextSearchDictionary {
+	return nil
+}
+
+// GetTextSearchConfiguration implements the SchemaDescriptor interface.
+func (p synthetic) GetTextSearchConfiguration(
+	name string,
+) *descpb.SchemaDescriptor_TextSearchConfiguration {
+	return nil
+}
+
 // ForEachUDTDependentForHydration implements the catalog.Descriptor interface.
 func (p synthetic) ForEachUDTDependentForHydration(fn func(t *types.T) error) error {
 	return nil
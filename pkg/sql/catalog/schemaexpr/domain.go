@@ -0,0 +1,83 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package schemaexpr
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// DomainValueName is the name by which the CHECK constraint expression of a
+// domain type refers to the value being checked.
+const DomainValueName = tree.Name("value")
+
+// ValidateDomainCheckExpr validates the CHECK constraint expression of a
+// domain with the given base type. The expression may only reference the
+// value being checked, using the VALUE keyword, and must evaluate to a
+// boolean. The serialized, type-checked expression is returned if valid.
+func ValidateDomainCheckExpr(
+	ctx context.Context,
+	expr tree.Expr,
+	baseType *types.T,
+	semaCtx *tree.SemaContext,
+	version clusterversion.ClusterVersion,
+) (string, error) {
+	lookupFn := func(columnName tree.Name) (exists bool, accessible bool, id catid.ColumnID, typ *types.T) {
+		if columnName != DomainValueName {
+			return false, false, 0, nil
+		}
+		return true, true, 0, baseType
+	}
+	replacedExpr, _, err := ReplaceColumnVars(expr, lookupFn)
+	if err != nil {
+		return "", err
+	}
+	typedExpr, err := SanitizeVarFreeExpr(
+		ctx, replacedExpr, types.Bool, tree.DomainCheckExpr, semaCtx, volatility.Immutable,
+		false, /* allowAssignmentCast */
+	)
+	if err != nil {
+		return "", err
+	}
+	if err := funcdesc.MaybeFailOnUDFUsage(typedExpr, tree.DomainCheckExpr, version); err != nil {
+		return "", err
+	}
+	return tree.Serialize(typedExpr), nil
+}
+
+// ValidateDomainDefaultExpr validates the DEFAULT expression of a domain with
+// the given base type. The serialized, type-checked expression is returned if
+// valid.
+func ValidateDomainDefaultExpr(
+	ctx context.Context,
+	expr tree.Expr,
+	baseType *types.T,
+	semaCtx *tree.SemaContext,
+	version clusterversion.ClusterVersion,
+) (string, error) {
+	typedExpr, err := SanitizeVarFreeExpr(
+		ctx, expr, baseType, tree.DomainDefaultExpr, semaCtx, volatility.Volatile,
+		true, /* allowAssignmentCast */
+	)
+	if err != nil {
+		return "", err
+	}
+	if err := funcdesc.MaybeFailOnUDFUsage(typedExpr, tree.DomainDefaultExpr, version); err != nil {
+		return "", err
+	}
+	return tree.Serialize(typedExpr), nil
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/cast"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catconstants"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -647,3 +648,46 @@ func GetUDFIDsFromExprStr(exprStr string) (catalog.DescriptorIDSet, error) {
 	}
 	return GetUDFIDs(expr)
 }
+
+// ForEachTextSearchObjectArg calls fn with the argument of each call in the
+// given expression to a text search builtin that names a text search
+// configuration or dictionary.
+func ForEachTextSearchObjectArg(
+	e tree.Expr, fn func(arg tree.Expr, dictionary bool) error,
+) error {
+	_, err := tree.SimpleVisit(e, func(expr tree.Expr) (recurse bool, newExpr tree.Expr, err error) {
+		f, ok := expr.(*tree.FuncExpr)
+		// Only the two-argument overloads take the name of an object.
+		if !ok || len(f.Exprs) != 2 {
+			return true, expr, nil
+		}
+		var name string
+		switch t := f.Func.FunctionReference.(type) {
+		case *tree.UnresolvedName:
+			fnName, err := t.ToRoutineName()
+			if err != nil {
+				return false, expr, err
+			}
+			if fnName.ExplicitSchema && fnName.Schema() != catconstants.PgCatalogName {
+				return true, expr, nil
+			}
+			name = fnName.Object()
+		case *tree.ResolvedFunctionDefinition:
+			name = t.Name
+		case *tree.FunctionDefinition:
+			name = t.Name
+		default:
+			return true, expr, nil
+		}
+		if def, ok := tree.FunDefs[name]; !ok || !def.TextSearchObjectArgument {
+			return true, expr, nil
+		}
+		// ts_lexize is the only builtin that takes a dictionary rather than a
+		// configuration.
+		if err := fn(f.Exprs[0], name == "ts_lexize"); err != nil {
+			return false, expr, err
+		}
+		return true, expr, nil
+	})
+	return err
+}
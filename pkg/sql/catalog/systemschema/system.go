@@ -1148,6 +1148,25 @@ CREATE TABLE system.mvcc_statistics (
 			created
 		)
 	);`
+
+	// SystemPublicationsTableSchema stores the publications used for logical
+	// replication. The published tables are the tables of the database listed
+	// in table_ids, or all of them if all_tables is set.
+	SystemPublicationsTableSchema = `
+CREATE TABLE system.publications (
+	database_id      INT8 NOT NULL,
+	name             STRING NOT NULL,
+	owner            STRING NOT NULL,
+	owner_id         OID NOT NULL,
+	all_tables       BOOL NOT NULL,
+	table_ids        INT8[] NOT NULL,
+	publish_insert   BOOL NOT NULL,
+	publish_update   BOOL NOT NULL,
+	publish_delete   BOOL NOT NULL,
+	publish_truncate BOOL NOT NULL,
+	CONSTRAINT "primary" PRIMARY KEY (database_id, name),
+	FAMILY "primary" (database_id, name, owner, owner_id, all_tables, table_ids, publish_insert, publish_update, publish_delete, publish_truncate)
+);`
 )
 
 func pk(name string) descpb.IndexDescriptor {
@@ -1197,7 +1216,7 @@ const SystemDatabaseName = catconstants.SystemDatabaseName
 // SystemDatabaseSchemaBootstrapVersion is the system database schema version
 // that should be used during bootstrap. It should be bumped up alongside any
 // upgrade that creates or modifies the schema of a system table.
-var SystemDatabaseSchemaBootstrapVersion = clusterversion.ByKey(clusterversion.V23_2_AddSystemExecInsightsTable)
+var SystemDatabaseSchemaBootstrapVersion = clusterversion.ByKey(clusterversion.V24_1_AddSystemPublicationsTable)
 
 // MakeSystemDatabaseDesc constructs a copy of the system database
 // descriptor.
@@ -1388,6 +1407,7 @@ func MakeSystemTables() []SystemTable {
 		SystemMVCCStatisticsTable,
 		StatementExecInsightsTable,
 		TransactionExecInsightsTable,
+		SystemPublicationsTable,
 	}
 }
 
@@ -4643,6 +4663,47 @@ var (
 			tbl.NextConstraintID++
 		},
 	)
+
+	SystemPublicationsTable = makeSystemTable(
+		SystemPublicationsTableSchema,
+		systemTable(
+			catconstants.PublicationsTableName,
+			descpb.InvalidID, // dynamically assigned
+			[]descpb.ColumnDescriptor{
+				{Name: "database_id", ID: 1, Type: types.Int},
+				{Name: "name", ID: 2, Type: types.String},
+				{Name: "owner", ID: 3, Type: types.String},
+				{Name: "owner_id", ID: 4, Type: types.Oid},
+				{Name: "all_tables", ID: 5, Type: types.Bool},
+				{Name: "table_ids", ID: 6, Type: types.IntArray},
+				{Name: "publish_insert", ID: 7, Type: types.Bool},
+				{Name: "publish_update", ID: 8, Type: types.Bool},
+				{Name: "publish_delete", ID: 9, Type: types.Bool},
+				{Name: "publish_truncate", ID: 10, Type: types.Bool},
+			},
+			[]descpb.ColumnFamilyDescriptor{
+				{
+					Name: "primary",
+					ID:   0,
+					ColumnNames: []string{
+						"database_id", "name", "owner", "owner_id", "all_tables", "table_ids",
+						"publish_insert", "publish_update", "publish_delete", "publish_truncate",
+					},
+					ColumnIDs: []descpb.ColumnID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+				},
+			},
+			descpb.IndexDescriptor{
+				Name:           "primary",
+				ID:             1,
+				Unique:         true,
+				KeyColumnNames: []string{"database_id", "name"},
+				KeyColumnDirections: []catenumpb.IndexColumn_Direction{
+					catenumpb.IndexColumn_ASC, catenumpb.IndexColumn_ASC,
+				},
+				KeyColumnIDs: []descpb.ColumnID{1, 2},
+			},
+		),
+	)
 )
 
 // SpanConfigurationsTableName represents system.span_configurations.
@@ -198,6 +198,7 @@ type Index interface {
 	GetKeyColumnID(columnOrdinal int) descpb.ColumnID
 	GetKeyColumnName(columnOrdinal int) string
 	GetKeyColumnDirection(columnOrdinal int) catenumpb.IndexColumn_Direction
+	GetKeyColumnNullsOrder(columnOrdinal int) catenumpb.IndexColumn_NullsOrder
 
 	CollectKeyColumnIDs() TableColSet
 	CollectKeySuffixColumnIDs() TableColSet
@@ -599,6 +600,11 @@ type UniqueWithoutIndexConstraint interface {
 
 	// ParentTableID returns the ID of the table this constraint applies to.
 	ParentTableID() descpb.ID
+
+	// IsExclusionConstraint returns true iff this is an EXCLUDE constraint, in
+	// which case two rows conflict if all of its elements conflict, rather
+	// than if all of its key columns are equal.
+	IsExclusionConstraint() bool
 }
 
 // PrimaryKeySwap is an interface around a primary key swap mutation.
@@ -460,6 +460,11 @@ func makeIndexColumnCache(idx *descpb.IndexDescriptor, all []catalog.Column) (ic
 		if colID != 0 && colID == invertedColumnID {
 			typ = idx.InvertedColumnKeyType()
 		}
+		// Key suffix columns always place NULLs in the default position.
+		nullsOrder := catenumpb.IndexColumn_NULLS_DEFAULT
+		if i < nKey {
+			nullsOrder = idx.KeyColumnNullsOrder(i)
+		}
 		ic.keyAndSuffix[i] = fetchpb.IndexFetchSpec_KeyColumn{
 			IndexFetchSpec_Column: fetchpb.IndexFetchSpec_Column{
 				Name:          col.GetName(),
@@ -470,6 +475,7 @@ func makeIndexColumnCache(idx *descpb.IndexDescriptor, all []catalog.Column) (ic
 			Direction:   ic.allDirs[i],
 			IsComposite: compositeIDs.Contains(colID),
 			IsInverted:  colID == invertedColumnID,
+			NullsOrder:  nullsOrder,
 		}
 	}
 	return ic
@@ -319,6 +319,12 @@ func (w index) GetKeyColumnDirection(columnOrdinal int) catenumpb.IndexColumn_Di
 	return w.desc.KeyColumnDirections[columnOrdinal]
 }
 
+// GetKeyColumnNullsOrder returns the placement of NULLs in the
+// columnOrdinal-th column in the index key.
+func (w index) GetKeyColumnNullsOrder(columnOrdinal int) catenumpb.IndexColumn_NullsOrder {
+	return w.desc.KeyColumnNullsOrder(columnOrdinal)
+}
+
 // NumPrimaryStoredColumns returns the number of columns which the index
 // stores in addition to the columns which are part of the primary key.
 // Returns 0 if the index isn't primary.
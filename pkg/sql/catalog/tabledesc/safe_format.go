@@ -12,6 +12,7 @@ package tabledesc
 
 import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catenumpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/redact"
 )
@@ -134,7 +135,11 @@ func formatSafeIndex(
 		if i > 0 {
 			w.Printf(", ")
 		}
-		w.Printf("{ID: %d, Dir: %s}", idx.KeyColumnIDs[i], idx.KeyColumnDirections[i])
+		if nullsOrder := idx.KeyColumnNullsOrder(i); nullsOrder != catenumpb.IndexColumn_NULLS_DEFAULT {
+			w.Printf("{ID: %d, Dir: %s, Nulls: %s}", idx.KeyColumnIDs[i], idx.KeyColumnDirections[i], nullsOrder)
+		} else {
+			w.Printf("{ID: %d, Dir: %s}", idx.KeyColumnIDs[i], idx.KeyColumnDirections[i])
+		}
 	}
 	w.Printf("]")
 	if len(idx.KeySuffixColumnIDs) > 0 {
@@ -314,6 +314,15 @@ func ForEachExprStringInTableDesc(descI catalog.TableDescriptor, f func(expr *st
 		}
 	}
 
+	// Process trigger WHEN conditions.
+	for i := range desc.Triggers {
+		if desc.Triggers[i].WhenExpr != "" {
+			if err := f(&desc.Triggers[i].WhenExpr); err != nil {
+				return err
+			}
+		}
+	}
+
 	// Process all non-index mutations.
 	for _, mut := range desc.Mutations {
 		if c := mut.GetColumn(); c != nil {
@@ -381,6 +390,9 @@ func (desc *wrapper) GetAllReferencedFunctionIDs() (catalog.DescriptorIDSet, err
 			ret.Add(id)
 		}
 	}
+	for i := range desc.Triggers {
+		ret.Add(desc.Triggers[i].FuncID)
+	}
 	// TODO(chengxiong): add logic to extract references from indexes when UDFs
 	// are allowed in them.
 	return ret.Union(catalog.MakeDescriptorIDSet(desc.DependsOnFunctions...)), nil
@@ -1019,8 +1031,17 @@ func checkColumnsValidForIndex(tableDesc *Mutable, indexColNames []string) error
 }
 
 func checkColumnsValidForInvertedIndex(
-	tableDesc *Mutable, indexColNames []string, colDirs []catenumpb.IndexColumn_Direction,
+	tableDesc *Mutable,
+	indexColNames []string,
+	colDirs []catenumpb.IndexColumn_Direction,
+	colNullsOrders []catenumpb.IndexColumn_NullsOrder,
 ) error {
+	for _, nullsOrder := range colNullsOrders {
+		if nullsOrder != catenumpb.IndexColumn_NULLS_DEFAULT {
+			return pgerror.New(pgcode.FeatureNotSupported,
+				"inverted indexes do not support non-default NULLS FIRST or NULLS LAST options")
+		}
+	}
 	lastCol := len(indexColNames) - 1
 	for i, indexCol := range indexColNames {
 		for _, col := range tableDesc.NonDropColumns() {
@@ -1124,7 +1145,7 @@ func (desc *Mutable) AddSecondaryIndex(idx descpb.IndexDescriptor) error {
 		}
 	} else {
 		if err := checkColumnsValidForInvertedIndex(
-			desc, idx.KeyColumnNames, idx.KeyColumnDirections,
+			desc, idx.KeyColumnNames, idx.KeyColumnDirections, idx.KeyColumnNullsOrders,
 		); err != nil {
 			return err
 		}
@@ -1962,7 +1983,7 @@ func (desc *Mutable) checkValidIndex(idx *descpb.IndexDescriptor) error {
 		}
 	case descpb.IndexDescriptor_INVERTED:
 		if err := checkColumnsValidForInvertedIndex(
-			desc, idx.KeyColumnNames, idx.KeyColumnDirections,
+			desc, idx.KeyColumnNames, idx.KeyColumnDirections, idx.KeyColumnNullsOrders,
 		); err != nil {
 			return err
 		}
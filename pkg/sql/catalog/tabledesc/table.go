@@ -438,6 +438,13 @@ func PrimaryKeyString(desc catalog.TableDescriptor) string {
 		f.FormatNameP(&name)
 		f.WriteByte(' ')
 		f.WriteString(primaryIdx.GetKeyColumnDirection(i).String())
+		if primaryIdx.GetKeyColumnNullsOrder(i) == catenumpb.IndexColumn_NULLS_REVERSED {
+			if primaryIdx.GetKeyColumnDirection(i) == catenumpb.IndexColumn_DESC {
+				f.WriteString(" NULLS FIRST")
+			} else {
+				f.WriteString(" NULLS LAST")
+			}
+		}
 	}
 	f.WriteByte(')')
 	if primaryIdx.IsSharded() {
@@ -521,6 +528,15 @@ func RenameColumnInTable(
 		}
 	}
 
+	// Rename the column in trigger WHEN conditions.
+	for i := range tableDesc.Triggers {
+		if trig := &tableDesc.Triggers[i]; trig.WhenExpr != "" {
+			if err := renameInExpr(&trig.WhenExpr); err != nil {
+				return err
+			}
+		}
+	}
+
 	// Do all of the above renames inside check constraints, computed expressions,
 	// and idx predicates that are in mutations.
 	for i := range tableDesc.Mutations {
@@ -249,6 +249,13 @@ func UpdateIndexPartitioning(
 	idx.KeyColumnIDs = append(newColumnIDs, idx.KeyColumnIDs[oldNumImplicitCols:]...)
 	idx.KeyColumnNames = append(newColumnNames, idx.KeyColumnNames[oldNumImplicitCols:]...)
 	idx.KeyColumnDirections = append(newColumnDirections, idx.KeyColumnDirections[oldNumImplicitCols:]...)
+	if len(idx.KeyColumnNullsOrders) > oldNumImplicitCols {
+		// Implicit partitioning columns place NULLs in the default position.
+		newColumnNullsOrders := make([]catenumpb.IndexColumn_NullsOrder, len(newImplicitCols), newCap)
+		idx.KeyColumnNullsOrders = append(newColumnNullsOrders, idx.KeyColumnNullsOrders[oldNumImplicitCols:]...)
+	} else {
+		idx.KeyColumnNullsOrders = nil
+	}
 	idx.Partitioning = newPartitioning
 	if !isIndexPrimary {
 		return true
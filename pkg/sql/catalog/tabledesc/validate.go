@@ -198,6 +198,11 @@ func (desc *wrapper) ValidateForwardReferences(
 		}
 	}
 
+	// Check all trigger functions exist.
+	for i := range desc.Triggers {
+		vea.Report(desc.validateOutboundFuncRef(desc.Triggers[i].FuncID, vdg))
+	}
+
 	// Check enforced outbound foreign keys.
 	for _, fk := range desc.EnforcedOutboundForeignKeys() {
 		vea.Report(desc.validateOutboundFK(fk.ForeignKeyDesc(), vdg))
@@ -262,6 +267,17 @@ func (desc *wrapper) ValidateBackReferences(
 		}
 	}
 
+	// Check back-references in trigger functions.
+	for i := range desc.Triggers {
+		trig := &desc.Triggers[i]
+		fn, err := vdg.GetFunctionDescriptor(trig.FuncID)
+		if err != nil {
+			vea.Report(err)
+			continue
+		}
+		vea.Report(desc.validateOutboundFuncRefBackReferenceForTrigger(fn, trig.ID))
+	}
+
 	// For views, check dependent relations.
 	if desc.IsView() {
 		for _, id := range desc.DependsOnTypes {
@@ -375,6 +391,23 @@ func (desc *wrapper) validateOutboundFuncRefBackReferenceForColumn(
 		ref.GetName(), ref.GetID())
 }
 
+func (desc *wrapper) validateOutboundFuncRefBackReferenceForTrigger(
+	ref catalog.FunctionDescriptor, triggerID descpb.TriggerID,
+) error {
+	for _, dep := range ref.GetDependedOnBy() {
+		if dep.ID != desc.GetID() {
+			continue
+		}
+		for _, id := range dep.TriggerIDs {
+			if id == triggerID {
+				return nil
+			}
+		}
+	}
+	return errors.AssertionFailedf("depends-on function %q (%d) has no corresponding depended-on-by back reference",
+		ref.GetName(), ref.GetID())
+}
+
 func (desc *wrapper) validateInboundFunctionRef(
 	by descpb.TableDescriptor_Reference, vdg catalog.ValidationDescGetter,
 ) error {
@@ -761,6 +794,14 @@ func (desc *wrapper) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 
 	desc.validateAutoStatsSettings(vea)
 
+	if desc.IsForeignTable() {
+		desc.validateForeignTable(vea)
+	}
+
+	if len(desc.Triggers) > 0 {
+		desc.validateTriggers(vea)
+	}
+
 	if desc.IsSequence() {
 		return
 	}
@@ -1059,6 +1100,65 @@ func ValidateOnUpdate(desc catalog.TableDescriptor, errReportFn func(err error))
 	}
 }
 
+// validateForeignTable performs checks specific to foreign tables, which have
+// no data of their own.
+func (desc *wrapper) validateForeignTable(vea catalog.ValidationErrorAccumulator) {
+	if desc.IsView() || desc.IsSequence() {
+		vea.Report(errors.AssertionFailedf("foreign table cannot be a view or a sequence"))
+	}
+	if desc.Temporary {
+		vea.Report(errors.AssertionFailedf("foreign table cannot be temporary"))
+	}
+	if desc.ForeignTable.ServerName == "" {
+		vea.Report(errors.AssertionFailedf("foreign table has no server"))
+	}
+	if desc.PrimaryIndex.ID != 0 || len(desc.Indexes) > 0 {
+		vea.Report(errors.AssertionFailedf("foreign table cannot have indexes"))
+	}
+}
+
+// validateTriggers checks that the triggers of the table have unique names
+// and IDs, and that they only refer to columns of the table.
+func (desc *wrapper) validateTriggers(vea catalog.ValidationErrorAccumulator) {
+	if !desc.IsTable() {
+		vea.Report(errors.AssertionFailedf("triggers are only supported on tables"))
+	}
+	names := make(map[string]struct{}, len(desc.Triggers))
+	ids := make(map[descpb.TriggerID]struct{}, len(desc.Triggers))
+	for i := range desc.Triggers {
+		trig := &desc.Triggers[i]
+		if trig.ID == 0 || trig.ID >= desc.NextTriggerID {
+			vea.Report(errors.AssertionFailedf(
+				"trigger %q has invalid ID %d", trig.Name, trig.ID))
+		}
+		if _, ok := ids[trig.ID]; ok {
+			vea.Report(errors.AssertionFailedf("duplicate trigger ID %d", trig.ID))
+		}
+		ids[trig.ID] = struct{}{}
+		if trig.Name == "" {
+			vea.Report(errors.AssertionFailedf("trigger %d has an empty name", trig.ID))
+		}
+		if _, ok := names[trig.Name]; ok {
+			vea.Report(errors.AssertionFailedf("duplicate trigger name %q", trig.Name))
+		}
+		names[trig.Name] = struct{}{}
+		if trig.FuncID == descpb.InvalidID {
+			vea.Report(errors.AssertionFailedf("trigger %q has no function", trig.Name))
+		}
+		if len(trig.Events) == 0 {
+			vea.Report(errors.AssertionFailedf("trigger %q has no events", trig.Name))
+		}
+		for _, ev := range trig.Events {
+			for _, colID := range ev.ColumnIDs {
+				if catalog.FindColumnByID(desc, colID) == nil {
+					vea.Report(errors.AssertionFailedf(
+						"trigger %q refers to unknown column ID %d", trig.Name, colID))
+				}
+			}
+		}
+	}
+}
+
 func (desc *wrapper) validateConstraintNamesAndIDs(vea catalog.ValidationErrorAccumulator) {
 	if !desc.IsTable() {
 		return
@@ -1362,6 +1462,30 @@ func (desc *wrapper) validateUniqueWithoutIndexConstraints(
 			seen.Add(int(colID))
 		}
 
+		// Verify that the elements of an exclusion constraint only refer to the
+		// constraint's columns.
+		for _, elem := range c.UniqueWithoutIndexDesc().ExclusionElements {
+			if n := len(elem.ColumnIDs); n != 1 && n != 2 {
+				return errors.Newf(
+					"exclusion constraint %q has an element with %d columns", c.GetName(), n,
+				)
+			}
+			for _, colID := range elem.ColumnIDs {
+				if !seen.Contains(int(colID)) {
+					return errors.Newf(
+						"exclusion constraint %q contains unknown element column \"%d\"", c.GetName(), colID,
+					)
+				}
+			}
+			switch elem.Operator {
+			case descpb.ExclusionOperatorEquals, descpb.ExclusionOperatorOverlaps:
+			default:
+				return errors.Newf(
+					"exclusion constraint %q contains unknown operator %q", c.GetName(), elem.Operator,
+				)
+			}
+		}
+
 		if c.IsPartial() {
 			expr, err := parser.ParseExpr(c.GetPredicate())
 			if err != nil {
@@ -1448,6 +1572,10 @@ func (desc *wrapper) validateTableIndexes(columnsByID map[descpb.ColumnID]catalo
 			return errors.Newf("mismatched column IDs (%d) and directions (%d)",
 				len(idx.IndexDesc().KeyColumnIDs), len(idx.IndexDesc().KeyColumnDirections))
 		}
+		if len(idx.IndexDesc().KeyColumnNullsOrders) > len(idx.IndexDesc().KeyColumnIDs) {
+			return errors.Newf("mismatched column IDs (%d) and NULLs orders (%d)",
+				len(idx.IndexDesc().KeyColumnIDs), len(idx.IndexDesc().KeyColumnNullsOrders))
+		}
 		// In the old STORING encoding, stored columns are in ExtraColumnIDs;
 		// tolerate a longer list of column names.
 		if len(idx.IndexDesc().StoreColumnIDs) > len(idx.IndexDesc().StoreColumnNames) {
@@ -138,6 +138,9 @@ var validationMap = []struct {
 			"HistogramBuckets":              {status: thisFieldReferencesNoObjects},
 			"HistogramSamples":              {status: thisFieldReferencesNoObjects},
 			"SchemaLocked":                  {status: thisFieldReferencesNoObjects},
+			"Triggers":                      {status: iSolemnlySwearThisFieldIsValidated},
+			"NextTriggerID":                 {status: iSolemnlySwearThisFieldIsValidated},
+			"ForeignTable":                  {status: iSolemnlySwearThisFieldIsValidated},
 		},
 	},
 	{
@@ -147,16 +150,17 @@ var validationMap = []struct {
 			"ID":     {status: thisFieldReferencesNoObjects},
 			"Unique": {status: thisFieldReferencesNoObjects},
 			// NotVisible is deprecated in favor of Invisibility.
-			"NotVisible":          {status: thisFieldReferencesNoObjects},
-			"Invisibility":        {status: iSolemnlySwearThisFieldIsValidated},
-			"Version":             {status: thisFieldReferencesNoObjects},
-			"KeyColumnNames":      {status: iSolemnlySwearThisFieldIsValidated},
-			"KeyColumnDirections": {status: iSolemnlySwearThisFieldIsValidated},
-			"StoreColumnNames":    {status: iSolemnlySwearThisFieldIsValidated},
-			"InvertedColumnKinds": {status: thisFieldReferencesNoObjects},
-			"KeyColumnIDs":        {status: iSolemnlySwearThisFieldIsValidated},
-			"KeySuffixColumnIDs":  {status: iSolemnlySwearThisFieldIsValidated},
-			"StoreColumnIDs":      {status: iSolemnlySwearThisFieldIsValidated},
+			"NotVisible":           {status: thisFieldReferencesNoObjects},
+			"Invisibility":         {status: iSolemnlySwearThisFieldIsValidated},
+			"Version":              {status: thisFieldReferencesNoObjects},
+			"KeyColumnNames":       {status: iSolemnlySwearThisFieldIsValidated},
+			"KeyColumnDirections":  {status: iSolemnlySwearThisFieldIsValidated},
+			"KeyColumnNullsOrders": {status: iSolemnlySwearThisFieldIsValidated},
+			"StoreColumnNames":     {status: iSolemnlySwearThisFieldIsValidated},
+			"InvertedColumnKinds":  {status: thisFieldReferencesNoObjects},
+			"KeyColumnIDs":         {status: iSolemnlySwearThisFieldIsValidated},
+			"KeySuffixColumnIDs":   {status: iSolemnlySwearThisFieldIsValidated},
+			"StoreColumnIDs":       {status: iSolemnlySwearThisFieldIsValidated},
 			"CompositeColumnIDs": {
 				status: todoIAmKnowinglyAddingTechDebt,
 				reason: "initial import: TODO(features): add validation"},
@@ -223,17 +227,23 @@ var validationMap = []struct {
 			"OnUpdate":            {status: thisFieldReferencesNoObjects},
 			"Match":               {status: thisFieldReferencesNoObjects},
 			"ConstraintID":        {status: iSolemnlySwearThisFieldIsValidated},
+			"Deferrable":          {status: thisFieldReferencesNoObjects},
+			"InitiallyDeferred":   {status: thisFieldReferencesNoObjects},
 		},
 	},
 	{
 		obj: descpb.UniqueWithoutIndexConstraint{},
 		fieldMap: map[string]validationStatusInfo{
-			"TableID":      {status: iSolemnlySwearThisFieldIsValidated},
-			"ColumnIDs":    {status: iSolemnlySwearThisFieldIsValidated},
-			"Name":         {status: thisFieldReferencesNoObjects},
-			"Validity":     {status: thisFieldReferencesNoObjects},
-			"Predicate":    {status: iSolemnlySwearThisFieldIsValidated},
-			"ConstraintID": {status: iSolemnlySwearThisFieldIsValidated},
+			"TableID":           {status: iSolemnlySwearThisFieldIsValidated},
+			"ColumnIDs":         {status: iSolemnlySwearThisFieldIsValidated},
+			"Name":              {status: thisFieldReferencesNoObjects},
+			"Validity":          {status: thisFieldReferencesNoObjects},
+			"Predicate":         {status: iSolemnlySwearThisFieldIsValidated},
+			"ConstraintID":      {status: iSolemnlySwearThisFieldIsValidated},
+			"Deferrable":        {status: thisFieldReferencesNoObjects},
+			"InitiallyDeferred": {status: thisFieldReferencesNoObjects},
+			"ExclusionElements": {status: iSolemnlySwearThisFieldIsValidated},
+			"ExclusionMethod":   {status: thisFieldReferencesNoObjects},
 		},
 	},
 	{
@@ -257,6 +267,8 @@ var validationMap = []struct {
 			"RegionConfig":                  {status: iSolemnlySwearThisFieldIsValidated},
 			"DeclarativeSchemaChangerState": {status: thisFieldReferencesNoObjects},
 			"Composite":                     {status: iSolemnlySwearThisFieldIsValidated},
+			"Domain":                        {status: iSolemnlySwearThisFieldIsValidated},
+			"Range":                         {status: iSolemnlySwearThisFieldIsValidated},
 		},
 	},
 	{
@@ -274,6 +286,7 @@ var validationMap = []struct {
 			"DefaultPrivileges":             {status: iSolemnlySwearThisFieldIsValidated},
 			"DeclarativeSchemaChangerState": {status: thisFieldReferencesNoObjects},
 			"SystemDatabaseSchemaVersion":   {status: iSolemnlySwearThisFieldIsValidated},
+			"ForeignServers":                {status: iSolemnlySwearThisFieldIsValidated},
 		},
 	},
 	{
@@ -291,6 +304,8 @@ var validationMap = []struct {
 			"DefaultPrivileges":             {status: iSolemnlySwearThisFieldIsValidated},
 			"DeclarativeSchemaChangerState": {status: thisFieldReferencesNoObjects},
 			"Functions":                     {status: iSolemnlySwearThisFieldIsValidated},
+			"TextSearchDictionaries":        {status: iSolemnlySwearThisFieldIsValidated},
+			"TextSearchConfigurations":      {status: iSolemnlySwearThisFieldIsValidated},
 		},
 	},
 	{
@@ -325,6 +340,9 @@ var validationMap = []struct {
 			"Version":                       {status: thisFieldReferencesNoObjects},
 			"DeclarativeSchemaChangerState": {status: thisFieldReferencesNoObjects},
 			"IsProcedure":                   {status: thisFieldReferencesNoObjects},
+			"Security":                      {status: thisFieldReferencesNoObjects},
+			"Config":                        {status: thisFieldReferencesNoObjects},
+			"Aggregate":                     {status: iSolemnlySwearThisFieldIsValidated},
 		},
 	},
 }
@@ -277,7 +277,7 @@ func nonMatchingRowQuery(
 func validateForeignKey(
 	ctx context.Context,
 	txn isql.Txn,
-	srcTable *tabledesc.Mutable,
+	srcTable catalog.TableDescriptor,
 	targetTable catalog.TableDescriptor,
 	fk *descpb.ForeignKeyConstraint,
 	indexIDForValidation descpb.IndexID,
@@ -302,7 +302,7 @@ func validateForeignKey(
 
 		log.Infof(ctx, "validating MATCH FULL FK %q (%q [%v] -> %q [%v]) with query %q",
 			fk.Name,
-			srcTable.Name, colNames,
+			srcTable.GetName(), colNames,
 			targetTable.GetName(), referencedColumnNames,
 			query,
 		)
@@ -327,7 +327,7 @@ func validateForeignKey(
 
 	log.Infof(ctx, "validating FK %q (%q [%v] -> %q [%v]) with query %q",
 		fk.Name,
-		srcTable.Name, colNames, targetTable.GetName(), referencedColumnNames,
+		srcTable.GetName(), colNames, targetTable.GetName(), referencedColumnNames,
 		query,
 	)
 
@@ -339,7 +339,7 @@ func validateForeignKey(
 	if values.Len() > 0 {
 		return pgerror.WithConstraintName(pgerror.Newf(pgcode.ForeignKeyViolation,
 			"foreign key violation: %q row %s has no match in %q",
-			srcTable.Name, formatValues(colNames, values), targetTable.GetName()), fk.Name)
+			srcTable.GetName(), formatValues(colNames, values), targetTable.GetName()), fk.Name)
 	}
 	return nil
 }
@@ -503,6 +503,17 @@ func (p *planner) RevalidateUniqueConstraint(
 	// Check UNIQUE WITHOUT INDEX constraints.
 	for _, uc := range tableDesc.EnforcedUniqueConstraintsWithoutIndex() {
 		if uc.GetName() == constraintName {
+			if uc.IsExclusionConstraint() {
+				return validateExclusionConstraint(
+					ctx,
+					tableDesc,
+					uc.UniqueWithoutIndexDesc(),
+					0, /* indexIDForValidation */
+					p.InternalSQLTxn(),
+					p.User(),
+					true, /* preExisting */
+				)
+			}
 			return validateUniqueConstraint(
 				ctx,
 				tableDesc,
@@ -583,17 +594,31 @@ func RevalidateUniqueConstraintsInTable(
 	// Check UNIQUE WITHOUT INDEX constraints.
 	for _, uc := range tableDesc.EnforcedUniqueConstraintsWithoutIndex() {
 		if uc.IsConstraintValidated() {
-			if err := validateUniqueConstraint(
-				ctx,
-				tableDesc,
-				uc.GetName(),
-				uc.CollectKeyColumnIDs().Ordered(),
-				uc.GetPredicate(),
-				0, /* indexIDForValidation */
-				txn,
-				user,
-				true, /* preExisting */
-			); err != nil {
+			var err error
+			if uc.IsExclusionConstraint() {
+				err = validateExclusionConstraint(
+					ctx,
+					tableDesc,
+					uc.UniqueWithoutIndexDesc(),
+					0, /* indexIDForValidation */
+					txn,
+					user,
+					true, /* preExisting */
+				)
+			} else {
+				err = validateUniqueConstraint(
+					ctx,
+					tableDesc,
+					uc.GetName(),
+					uc.CollectKeyColumnIDs().Ordered(),
+					uc.GetPredicate(),
+					0, /* indexIDForValidation */
+					txn,
+					user,
+					true, /* preExisting */
+				)
+			}
+			if err != nil {
 				log.Errorf(ctx, "validation of unique constraints failed for table %s: %s", tableDesc.GetName(), err)
 				return errors.Wrapf(err, "for table %s", tableDesc.GetName())
 			}
@@ -700,6 +725,164 @@ func validateUniqueConstraint(
 	return nil
 }
 
+// conflictingRowQuery generates and returns a query for two rows that
+// conflict on the specified exclusion constraint. The query joins the table
+// with itself on the operators of the constraint elements, excluding rows
+// that are matched with themselves.
+//
+// For example, an exclusion constraint on (room WITH =, (s, e) WITH &&) on the
+// table "tbl" with primary key k would require the following query:
+//
+// SELECT a.room, a.s, a.e, b.room, b.s, b.e
+// FROM (SELECT room, s, e, k FROM [tbl AS t]) AS a
+// JOIN (SELECT room, s, e, k FROM [tbl AS t]) AS b
+// ON a.room = b.room AND overlaps(a.s, a.e, b.s, b.e) AND (a.k) != (b.k)
+// LIMIT 1
+//
+// If the constraint is partial, both subqueries are filtered by its
+// predicate.
+func conflictingRowQuery(
+	srcTbl catalog.TableDescriptor,
+	uc *descpb.UniqueWithoutIndexConstraint,
+	indexIDForValidation descpb.IndexID,
+) (sql string, colNames []string, _ error) {
+	colNames, err := catalog.ColumnNamesForIDs(srcTbl, uc.ColumnIDs)
+	if err != nil {
+		return "", nil, err
+	}
+	pkColNames, err := catalog.ColumnNamesForIDs(
+		srcTbl, srcTbl.GetPrimaryIndex().IndexDesc().KeyColumnIDs,
+	)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// Project the constraint columns and the primary key columns that are not
+	// part of the constraint.
+	cols := make([]string, 0, len(colNames)+len(pkColNames))
+	seen := make(map[string]struct{}, len(colNames))
+	for _, n := range colNames {
+		seen[n] = struct{}{}
+		cols = append(cols, tree.NameString(n))
+	}
+	for _, n := range pkColNames {
+		if _, ok := seen[n]; !ok {
+			cols = append(cols, tree.NameString(n))
+		}
+	}
+	src := fmt.Sprintf("[%d AS t]", srcTbl.GetID())
+	if indexIDForValidation != 0 {
+		src = fmt.Sprintf("[%d AS t]@[%d]", srcTbl.GetID(), indexIDForValidation)
+	}
+	subquery := fmt.Sprintf("SELECT %s FROM %s", strings.Join(cols, ", "), src)
+	if uc.Predicate != "" {
+		subquery += fmt.Sprintf(" WHERE (%s)", uc.Predicate)
+	}
+
+	conds := make([]string, 0, len(uc.ExclusionElements)+1)
+	for _, elem := range uc.ExclusionElements {
+		names, err := catalog.ColumnNamesForIDs(srcTbl, elem.ColumnIDs)
+		if err != nil {
+			return "", nil, err
+		}
+		if len(names) == 2 {
+			start, end := tree.NameString(names[0]), tree.NameString(names[1])
+			conds = append(conds, fmt.Sprintf(
+				"overlaps(a.%[1]s, a.%[2]s, b.%[1]s, b.%[2]s)", start, end,
+			))
+		} else {
+			conds = append(conds, fmt.Sprintf(
+				"a.%[1]s %[2]s b.%[1]s", tree.NameString(names[0]), elem.Operator,
+			))
+		}
+	}
+	pkA := make([]string, len(pkColNames))
+	pkB := make([]string, len(pkColNames))
+	for i, n := range pkColNames {
+		pkA[i] = "a." + tree.NameString(n)
+		pkB[i] = "b." + tree.NameString(n)
+	}
+	conds = append(conds, fmt.Sprintf(
+		"(%s) != (%s)", strings.Join(pkA, ", "), strings.Join(pkB, ", "),
+	))
+
+	selectCols := make([]string, 0, 2*len(colNames))
+	for _, prefix := range []string{"a.", "b."} {
+		for _, n := range colNames {
+			selectCols = append(selectCols, prefix+tree.NameString(n))
+		}
+	}
+	query := fmt.Sprintf(
+		`SELECT %[1]s FROM (%[2]s) AS a JOIN (%[2]s) AS b ON %[3]s LIMIT 1`,
+		strings.Join(selectCols, ", "), // 1
+		subquery,                       // 2
+		strings.Join(conds, " AND "),   // 3
+	)
+	return query, colNames, nil
+}
+
+// validateExclusionConstraint verifies that no two rows in the srcTable
+// conflict on the given exclusion constraint. See validateUniqueConstraint
+// for the meaning of the other arguments.
+func validateExclusionConstraint(
+	ctx context.Context,
+	srcTable catalog.TableDescriptor,
+	uc *descpb.UniqueWithoutIndexConstraint,
+	indexIDForValidation descpb.IndexID,
+	txn isql.Txn,
+	user username.SQLUsername,
+	preExisting bool,
+) error {
+	query, colNames, err := conflictingRowQuery(srcTable, uc, indexIDForValidation)
+	if err != nil {
+		return err
+	}
+
+	log.Infof(ctx, "validating exclusion constraint %q (%q [%v]) with query %q",
+		uc.Name,
+		srcTable.GetName(),
+		colNames,
+		query,
+	)
+
+	sessionDataOverride := sessiondata.NoSessionDataOverride
+	sessionDataOverride.User = user
+	values, err := txn.QueryRowEx(
+		ctx, "validate exclusion constraint", txn.KV(), sessionDataOverride, query,
+	)
+	if err != nil {
+		return err
+	}
+	if values.Len() > 0 {
+		valuesStr := make([]string, len(values))
+		for i := range values {
+			valuesStr[i] = values[i].String()
+		}
+		n := len(colNames)
+		// Note: this error message mirrors the message produced by Postgres
+		// when it fails to add an exclusion constraint due to conflicting rows.
+		errMsg := "could not create exclusion constraint"
+		if preExisting {
+			errMsg = "failed to validate exclusion constraint"
+		}
+		return errors.WithDetail(
+			pgerror.WithConstraintName(
+				pgerror.Newf(
+					pgcode.ExclusionViolation, "%s %q", errMsg, uc.Name,
+				),
+				uc.Name,
+			),
+			fmt.Sprintf(
+				"Key (%[1]s)=(%[2]s) conflicts with key (%[1]s)=(%[3]s).",
+				strings.Join(colNames, ","),
+				strings.Join(valuesStr[:n], ","),
+				strings.Join(valuesStr[n:], ","),
+			),
+		)
+	}
+	return nil
+}
+
 // ValidateTTLScheduledJobsInCurrentDB is part of the EvalPlanner interface.
 func (p *planner) ValidateTTLScheduledJobsInCurrentDB(ctx context.Context) error {
 	dbName := p.CurrentDatabase()
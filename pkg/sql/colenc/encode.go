@@ -640,7 +640,7 @@ func encodeColumns[T []byte | roachpb.Key](
 				return nil, err
 			}
 		}
-		if err := encodeKeys(keys, typ, dir, vec, start, end); err != nil {
+		if err := encodeKeys(keys, typ, dir, dir, vec, start, end); err != nil {
 			return nil, err
 		}
 	}
@@ -27,13 +27,13 @@ import (
 // helper routine to simplify wordy code below, return true if value/row is null
 // and we should skip it.
 func partialIndexAndNullCheck[T []byte | roachpb.Key](
-	kys []T, r, start int, nulls *coldata.Nulls, dir encoding.Direction,
+	kys []T, r, start int, nulls *coldata.Nulls, nullsDir encoding.Direction,
 ) bool {
 	if kys[r] == nil {
 		return true
 	}
 	if nulls.NullAt(r + start) {
-		if dir == encoding.Ascending {
+		if nullsDir == encoding.Ascending {
 			kys[r] = encoding.EncodeNullAscending(kys[r])
 		} else {
 			kys[r] = encoding.EncodeNullDescending(kys[r])
@@ -43,10 +43,12 @@ func partialIndexAndNullCheck[T []byte | roachpb.Key](
 	return false
 }
 
-// encodeKeys is the columnar version of keyside.Encode.
+// encodeKeys is the columnar version of keyside.Encode. NULLs are encoded with
+// the marker of nullsDir, which differs from dir for index columns whose NULLs
+// are not in their default position.
 // Cases taken from decodeTableKeyToCol.
 func encodeKeys[T []byte | roachpb.Key](
-	kys []T, typ *types.T, dir encoding.Direction, vec coldata.Vec, start, end int,
+	kys []T, typ *types.T, dir, nullsDir encoding.Direction, vec coldata.Vec, start, end int,
 ) error {
 	count := end - start
 	if vec == nil {
@@ -55,7 +57,7 @@ func encodeKeys[T []byte | roachpb.Key](
 			if b == nil {
 				continue
 			}
-			if dir == encoding.Ascending {
+			if nullsDir == encoding.Ascending {
 				kys[r] = encoding.EncodeNullAscending(b)
 			} else {
 				kys[r] = encoding.EncodeNullDescending(b)
@@ -67,7 +69,7 @@ func encodeKeys[T []byte | roachpb.Key](
 	case types.BoolFamily:
 		bs := vec.Bool()
 		for r := 0; r < count; r++ {
-			if partialIndexAndNullCheck(kys, r, start, nulls, dir) {
+			if partialIndexAndNullCheck(kys, r, start, nulls, nullsDir) {
 				continue
 			}
 			var x int64
@@ -84,7 +86,7 @@ func encodeKeys[T []byte | roachpb.Key](
 	case types.IntFamily, types.DateFamily:
 		for r := 0; r < count; r++ {
 			b := kys[r]
-			if partialIndexAndNullCheck(kys, r, start, nulls, dir) {
+			if partialIndexAndNullCheck(kys, r, start, nulls, nullsDir) {
 				continue
 			}
 			var i int64
@@ -109,7 +111,7 @@ func encodeKeys[T []byte | roachpb.Key](
 		fs := vec.Float64()
 		for r := 0; r < count; r++ {
 			b := kys[r]
-			if partialIndexAndNullCheck(kys, r, start, nulls, dir) {
+			if partialIndexAndNullCheck(kys, r, start, nulls, nullsDir) {
 				continue
 			}
 			f := fs[r+start]
@@ -123,7 +125,7 @@ func encodeKeys[T []byte | roachpb.Key](
 		ds := vec.Decimal()
 		for r := 0; r < count; r++ {
 			b := kys[r]
-			if partialIndexAndNullCheck(kys, r, start, nulls, dir) {
+			if partialIndexAndNullCheck(kys, r, start, nulls, nullsDir) {
 				continue
 			}
 			d := &ds[r+start]
@@ -137,7 +139,7 @@ func encodeKeys[T []byte | roachpb.Key](
 		ss := vec.Bytes()
 		for r := 0; r < count; r++ {
 			b := kys[r]
-			if partialIndexAndNullCheck(kys, r, start, nulls, dir) {
+			if partialIndexAndNullCheck(kys, r, start, nulls, nullsDir) {
 				continue
 			}
 			s := ss.Get(r + start)
@@ -151,7 +153,7 @@ func encodeKeys[T []byte | roachpb.Key](
 		ts := vec.Timestamp()
 		for r := 0; r < count; r++ {
 			b := kys[r]
-			if partialIndexAndNullCheck(kys, r, start, nulls, dir) {
+			if partialIndexAndNullCheck(kys, r, start, nulls, nullsDir) {
 				continue
 			}
 			if dir == encoding.Ascending {
@@ -164,7 +166,7 @@ func encodeKeys[T []byte | roachpb.Key](
 		ds := vec.Interval()
 		for r := 0; r < count; r++ {
 			b := kys[r]
-			if partialIndexAndNullCheck(kys, r, start, nulls, dir) {
+			if partialIndexAndNullCheck(kys, r, start, nulls, nullsDir) {
 				continue
 			}
 			var err error
@@ -182,7 +184,7 @@ func encodeKeys[T []byte | roachpb.Key](
 		jsonVector := vec.JSON()
 		for r := 0; r < count; r++ {
 			b := kys[r]
-			if partialIndexAndNullCheck(kys, r, start, nulls, dir) {
+			if partialIndexAndNullCheck(kys, r, start, nulls, nullsDir) {
 				continue
 			}
 			var err error
@@ -201,7 +203,7 @@ func encodeKeys[T []byte | roachpb.Key](
 		}
 		for r := 0; r < count; r++ {
 			b := kys[r]
-			if partialIndexAndNullCheck(kys, r, start, nulls, dir) {
+			if partialIndexAndNullCheck(kys, r, start, nulls, nullsDir) {
 				continue
 			}
 			var err error
@@ -230,6 +232,10 @@ func (b *BatchEncoder) encodeIndexKey(
 		if err != nil {
 			return err
 		}
+		nullsDir, err := catalogkeys.IndexColumnNullsEncodingDirection(k.Direction, k.NullsOrder)
+		if err != nil {
+			return err
+		}
 		col, ok := b.colMap.Get(k.ColumnID)
 		var vec coldata.Vec
 		if ok {
@@ -237,7 +243,7 @@ func (b *BatchEncoder) encodeIndexKey(
 		} else {
 			nulls.SetNulls()
 		}
-		if err := encodeKeys(kys, k.Type, dir, vec, b.start, b.end); err != nil {
+		if err := encodeKeys(kys, k.Type, dir, nullsDir, vec, b.start, b.end); err != nil {
 			return err
 		}
 		if vec.Nulls().MaybeHasNulls() {
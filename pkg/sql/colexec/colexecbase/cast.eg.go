@@ -58,6 +58,15 @@ var (
 	_ = pgerror.Wrapf
 )
 
+// stripDomain returns the base type of t if t is a domain type, since values
+// of domain types are physically represented as values of their base types.
+func stripDomain(t *types.T) *types.T {
+	if t.IsDomain() {
+		return t.DomainBaseType()
+	}
+	return t
+}
+
 func isIdentityCast(fromType, toType *types.T) bool {
 	if fromType.Identical(toType) {
 		return true
@@ -85,6 +94,7 @@ func GetCastOperator(
 	toType *types.T,
 	evalCtx *eval.Context,
 ) (colexecop.Operator, error) {
+	fromType, toType = stripDomain(fromType), stripDomain(toType)
 	input = colexecutils.NewVectorTypeEnforcer(allocator, input, toType, resultIdx)
 	base := castOpBase{
 		OneInputInitCloserHelper: colexecop.MakeOneInputInitCloserHelper(input),
@@ -640,6 +650,7 @@ func GetCastOperator(
 }
 
 func IsCastSupported(fromType, toType *types.T) bool {
+	fromType, toType = stripDomain(fromType), stripDomain(toType)
 	if fromType.Family() == types.UnknownFamily {
 		return true
 	}
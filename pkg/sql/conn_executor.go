@@ -365,6 +365,14 @@ type Server struct {
 
 	idxRecommendationsCache *idxrecommendations.IndexRecCache
 
+	// notifications keeps track of the sessions on this node that are
+	// listening for asynchronous notifications.
+	notifications *notificationRegistry
+
+	// notificationSender sends the notifications committed on this node to the
+	// other nodes of the cluster.
+	notificationSender *notificationSender
+
 	mu struct {
 		syncutil.Mutex
 		connectionCount     int64
@@ -405,6 +413,10 @@ type ServerMetrics struct {
 
 	// InsightsMetrics contains metrics related to outlier detection.
 	InsightsMetrics insights.Metrics
+
+	// NotificationMetrics contains metrics related to asynchronous
+	// notifications.
+	NotificationMetrics NotificationMetrics
 }
 
 // NewServer creates a new Server. Start() needs to be called before the Server
@@ -459,7 +471,11 @@ func NewServer(
 			cfg.Settings,
 			&serverMetrics.ContentionSubsystemMetrics),
 		idxRecommendationsCache: idxrecommendations.NewIndexRecommendationsCache(cfg.Settings),
+		notifications:           newNotificationRegistry(),
 	}
+	s.notificationSender = newNotificationSender(
+		s.sendNotificationsToOtherNodes, serverMetrics.NotificationMetrics.DroppedCount,
+	)
 
 	telemetryLoggingMetrics := newTelemetryLoggingmetrics(cfg.TelemetryLoggingTestingKnobs, cfg.Settings)
 	telemetryLoggingMetrics.registerOnTelemetrySamplingModeChange(cfg.Settings)
@@ -594,6 +610,9 @@ func makeServerMetrics(cfg *ExecutorConfig) ServerMetrics {
 		},
 		ContentionSubsystemMetrics: txnidcache.NewMetrics(),
 		InsightsMetrics:            insights.NewMetrics(),
+		NotificationMetrics: NotificationMetrics{
+			DroppedCount: metric.NewCounter(MetaNotificationsDropped),
+		},
 	}
 }
 
@@ -617,6 +636,8 @@ func (s *Server) Start(ctx context.Context, stopper *stop.Stopper) {
 	s.insights.Start(ctx, stopper)
 
 	s.txnIDCache.Start(ctx, stopper)
+
+	s.notificationSender.start(ctx, stopper)
 }
 
 // GetSQLStatsController returns the persistedsqlstats.Controller for current
@@ -824,7 +845,15 @@ func (s *Server) SetupConn(
 		&s.Metrics,
 		s.sqlStats.GetApplicationStats(sd.ApplicationName, false /* internal */),
 		sessionID,
-		nil, /* postSetupFn */
+		func(ex *connExecutor) {
+			// Only client sessions can receive asynchronous notifications.
+			ex.extraTxnState.notifications.listener = s.notifications.newListener(
+				stmtBuf, ex.queryCancelKey.GetPGBackendPID(),
+			)
+			// Only client sessions own their transactions, so only they can
+			// defer constraint checks until COMMIT.
+			ex.extraTxnState.deferredConstraints = &txnDeferredConstraints{}
+		},
 	)
 	return ConnectionHandler{ex}, nil
 }
@@ -1134,6 +1163,8 @@ func (s *Server) newConnExecutor(
 		ctx, descs.WithDescriptorSessionDataProvider(dsdp), descs.WithMonitor(ex.sessionMon),
 	)
 	ex.extraTxnState.jobs = newTxnJobsCollection()
+	ex.extraTxnState.notifications = &txnNotifications{}
+	ex.extraTxnState.storedProcTxnState = &storedProcTxnState{}
 	ex.extraTxnState.txnRewindPos = -1
 	ex.extraTxnState.schemaChangerState = &SchemaChangerState{
 		mode:   ex.sessionData().NewSchemaChangerMode,
@@ -1219,7 +1250,7 @@ func (ex *connExecutor) close(ctx context.Context, closeType closeType) {
 	ex.extraTxnState.prepStmtsNamespaceAtTxnRewindPos.closeAllPortals(
 		ctx, &ex.extraTxnState.prepStmtsNamespaceMemAcc,
 	)
-	if err := ex.extraTxnState.sqlCursors.closeAll(false /* errorOnWithHold */); err != nil {
+	if err := ex.extraTxnState.sqlCursors.closeAll(false /* keepPersisted */); err != nil {
 		log.Warningf(ctx, "error closing cursors: %v", err)
 	}
 
@@ -1254,6 +1285,7 @@ func (ex *connExecutor) close(ctx context.Context, closeType closeType) {
 	}
 
 	ex.resetExtraTxnState(ctx, txnEvent{eventType: txnEvType}, payloadErr)
+	ex.extraTxnState.notifications.close()
 	if ex.hasCreatedTemporarySchema && !ex.server.cfg.TestingKnobs.DisableTempObjectsCleanupOnSessionExit {
 		err := cleanupSessionTempObjects(
 			ctx,
@@ -1407,6 +1439,20 @@ type connExecutor struct {
 
 		jobs *txnJobsCollection
 
+		// notifications collects the LISTEN, UNLISTEN and NOTIFY actions of the
+		// current transaction, which are applied when it commits.
+		notifications *txnNotifications
+
+		// deferredConstraints tracks the DEFERRABLE constraints whose checks
+		// are deferred until the current transaction commits. It is nil for
+		// internal executors.
+		deferredConstraints *txnDeferredConstraints
+
+		// storedProcTxnState tracks the COMMIT and ROLLBACK statements executed
+		// by a procedure. Unlike most of extraTxnState, it outlives the
+		// transaction, since it is used to resume the procedure in the next one.
+		storedProcTxnState *storedProcTxnState
+
 		// firstStmtExecuted indicates that the first statement inside this
 		// transaction has been executed.
 		firstStmtExecuted bool
@@ -1472,7 +1518,9 @@ type connExecutor struct {
 		// sqlCursors contains the list of SQL CURSORs the session currently has
 		// access to.
 		// Cursors are bound to an explicit transaction and they're all destroyed
-		// once the transaction finishes.
+		// once the transaction finishes, except for WITH HOLD cursors, which are
+		// persisted when the transaction commits and remain open until they are
+		// closed or the session ends.
 		sqlCursors cursorMap
 
 		// shouldExecuteOnTxnFinish indicates that ex.onTxnFinish will be called
@@ -1999,11 +2047,21 @@ func (ex *connExecutor) resetExtraTxnState(ctx context.Context, ev txnEvent, pay
 		ctx, &ex.extraTxnState.prepStmtsNamespaceMemAcc,
 	)
 
-	// Close all cursors.
-	if err := ex.extraTxnState.sqlCursors.closeAll(false /* errorOnWithHold */); err != nil {
+	// Close all cursors, except for persisted WITH HOLD cursors.
+	if err := ex.extraTxnState.sqlCursors.closeAll(true /* keepPersisted */); err != nil {
 		log.Warningf(ctx, "error closing cursors: %v", err)
 	}
 
+	// Apply the LISTEN, UNLISTEN and NOTIFY actions if the transaction
+	// committed, and discard them otherwise.
+	if ev.eventType == txnCommit {
+		ex.extraTxnState.notifications.commit(ctx, ex.server)
+	}
+	ex.extraTxnState.notifications.reset()
+	if ex.extraTxnState.deferredConstraints.enabled() {
+		ex.extraTxnState.deferredConstraints.reset()
+	}
+
 	switch ev.eventType {
 	case txnCommit, txnRollback:
 		ex.extraTxnState.prepStmtsNamespaceAtTxnRewindPos.closeAllPortals(
@@ -2200,9 +2258,15 @@ func (ex *connExecutor) execCmd() (retErr error) {
 			}
 			ex.curStmtAST = tcmd.AST
 
+			descOpt := NeedRowDesc
+			if ex.extraTxnState.storedProcTxnState.resumeProc != nil {
+				// A procedure is being resumed after it ended the transaction. The
+				// row description was sent by its first execution.
+				descOpt = DontNeedRowDesc
+			}
 			stmtRes := ex.clientComm.CreateStatementResult(
 				tcmd.AST,
-				NeedRowDesc,
+				descOpt,
 				pos,
 				nil, /* formatCodes */
 				ex.sessionData().DataConversionConfig,
@@ -2256,6 +2320,12 @@ func (ex *connExecutor) execCmd() (retErr error) {
 		err := func() error {
 			portalName := tcmd.Name
 			portal, ok := ex.extraTxnState.prepStmtsNamespace.portals[portalName]
+			if resumePortal := ex.extraTxnState.storedProcTxnState.resumePortal; !ok && resumePortal != nil {
+				// A procedure executed through the portal ended the transaction,
+				// which closed the portal. The portal is executed again to resume
+				// the procedure.
+				portal, ok = *resumePortal, true
+			}
 			if !ok {
 				err := pgerror.Newf(
 					pgcode.InvalidCursorName, "unknown portal %q", portalName)
@@ -2430,6 +2500,14 @@ func (ex *connExecutor) execCmd() (retErr error) {
 		//   was created when the statement started executing (via the
 		//   reset() method).
 		ex.statsCollector.PhaseTimes().SetSessionPhaseTime(sessionphase.SessionQueryServiced, timeutil.Now())
+	case StartReplication:
+		ex.phaseTimes.SetSessionPhaseTime(sessionphase.SessionQueryReceived, tcmd.TimeReceived)
+		ex.phaseTimes.SetSessionPhaseTime(sessionphase.SessionStartParse, tcmd.ParseStart)
+		ex.phaseTimes.SetSessionPhaseTime(sessionphase.SessionEndParse, tcmd.ParseEnd)
+		replRes := ex.clientComm.CreateStartReplicationResult(tcmd, pos)
+		res = replRes
+		stmtCtx := withStatement(ctx, tcmd.Stmt)
+		ev, payload = ex.execStartReplication(stmtCtx, tcmd)
 	case DrainRequest:
 		// We received a drain request. We terminate immediately if we're not in a
 		// transaction. If we are in a transaction, we'll finish as soon as a Sync
@@ -2443,6 +2521,10 @@ func (ex *connExecutor) execCmd() (retErr error) {
 	case Flush:
 		// Closing the res will flush the connection's buffer.
 		res = ex.clientComm.CreateFlushResult(pos)
+	case DeliverNotifications:
+		// The queued notifications are buffered on the result below if we're not
+		// inside a transaction. Closing the res will flush them to the client.
+		res = ex.clientComm.CreateFlushResult(pos)
 	default:
 		panic(errors.AssertionFailedf("unsupported command type: %T", cmd))
 	}
@@ -2468,7 +2550,7 @@ func (ex *connExecutor) execCmd() (retErr error) {
 			// txnState.finishSQLTxn() is being called, as the underlying resources of
 			// pausable portals hasn't been cleared yet.
 			ex.extraTxnState.prepStmtsNamespace.closeAllPausablePortals(ctx, &ex.extraTxnState.prepStmtsNamespaceMemAcc)
-			if err := ex.extraTxnState.sqlCursors.closeAll(false /* errorOnWithHold */); err != nil {
+			if err := ex.extraTxnState.sqlCursors.closeAll(true /* keepPersisted */); err != nil {
 				log.Warningf(ctx, "error closing cursors: %v", err)
 			}
 		}
@@ -2507,6 +2589,10 @@ func (ex *connExecutor) execCmd() (retErr error) {
 		advInfo = advanceInfo{code: advanceOne}
 	}
 
+	// A procedure that committed or rolled back the transaction is resumed by
+	// executing the CALL statement again in a new implicit transaction.
+	ex.maybeResumeStoredProc(&advInfo, payload, res)
+
 	// Decide if we need to close the result or not. We don't need to do it if
 	// we're staying in place or rewinding - the statement will be executed
 	// again.
@@ -2532,6 +2618,11 @@ func (ex *connExecutor) execCmd() (retErr error) {
 				}
 			}
 		}
+		// Asynchronous notifications are only sent to the client between
+		// transactions.
+		if notificationRes, ok := res.(NotificationResult); ok && ex.idleConn() {
+			ex.extraTxnState.notifications.bufferQueued(notificationRes)
+		}
 		res.Close(ctx, stateToTxnStatusIndicator(ex.machine.CurState()))
 	} else {
 		res.Discard()
@@ -2589,6 +2680,39 @@ func (ex *connExecutor) execCmd() (retErr error) {
 	return nil
 }
 
+// maybeResumeStoredProc updates the advance code of a CALL statement whose
+// procedure ended the transaction, so that the statement is executed again to
+// resume the procedure. It also discards the state of the procedure once the
+// CALL statement is done.
+func (ex *connExecutor) maybeResumeStoredProc(
+	advInfo *advanceInfo, payload fsm.EventPayload, res ResultBase,
+) {
+	state := ex.extraTxnState.storedProcTxnState
+	if state.resumeProc == nil && state.nextProc == nil {
+		return
+	}
+	switch advInfo.code {
+	case advanceOne:
+		txnEnded := advInfo.txnEvent.eventType == txnCommit ||
+			advInfo.txnEvent.eventType == txnRollback
+		if state.nextProc != nil && txnEnded && !payloadHasError(payload) && res.Err() == nil {
+			state.resumeProc = state.nextProc
+			state.txnOp = tree.StoredProcTxnNoOp
+			state.nextProc = nil
+			advInfo.code = stayInPlace
+			return
+		}
+		state.reset()
+	case stayInPlace, rewind:
+		// The statement will be executed again in the same state: either a new
+		// transaction is starting, or the current one is being retried.
+		state.txnOp = tree.StoredProcTxnNoOp
+		state.nextProc = nil
+	default:
+		state.reset()
+	}
+}
+
 func (ex *connExecutor) idleConn() bool {
 	switch ex.machine.CurState().(type) {
 	case stateNoTxn:
@@ -2687,10 +2811,14 @@ func (ex *connExecutor) updateTxnRewindPosMaybe(
 				// Can't advance.
 			case CopyOut:
 				// Can't advance.
+			case StartReplication:
+				// Can't advance.
 			case DrainRequest:
 				canAdvance = true
 			case Flush:
 				canAdvance = true
+			case DeliverNotifications:
+				canAdvance = true
 			default:
 				panic(errors.AssertionFailedf("unsupported cmd: %T", cmd))
 			}
@@ -2750,7 +2878,7 @@ func stateToTxnStatusIndicator(s fsm.State) TransactionStatusIndicator {
 // used by these commands.
 func isCopyToExternalStorage(cmd CopyIn) bool {
 	stmt := cmd.Stmt
-	return (stmt.Table.Table() == NodelocalFileUploadTable ||
+	return stmt.Stdin && (stmt.Table.Table() == NodelocalFileUploadTable ||
 		stmt.Table.Table() == UserFileUploadTable) && stmt.Table.SchemaName == CrdbInternalName
 }
 
@@ -2962,6 +3090,43 @@ func (ex *connExecutor) execCopyOut(
 	return nil, nil
 }
 
+// execStartReplication streams logical replication changes to the client
+// until the client ends the stream. It runs outside of any transaction.
+func (ex *connExecutor) execStartReplication(
+	ctx context.Context, cmd StartReplication,
+) (retEv fsm.Event, retPayload fsm.EventPayload) {
+	// When we're done, unblock the network connection.
+	defer cmd.ReplicationDone.Done()
+
+	if _, isNoTxn := ex.machine.CurState().(stateNoTxn); !isNoTxn {
+		return ex.makeErrEvent(pgerror.New(pgcode.ActiveSQLTransaction,
+			"START_REPLICATION cannot be executed inside a transaction"), cmd.Stmt)
+	}
+
+	ex.incrementStartedStmtCounter(cmd.Stmt)
+	var cancelQuery context.CancelFunc
+	ctx, cancelQuery = ctxlog.WithCancel(ctx)
+	queryID := ex.server.cfg.GenerateID()
+	ex.addActiveQuery(cmd.ParsedStmt, nil /* placeholders */, queryID, cancelQuery)
+	ex.metrics.EngineMetrics.SQLActiveStatements.Inc(1)
+
+	defer func() {
+		ex.removeActiveQuery(queryID, cmd.Stmt)
+		cancelQuery()
+		ex.metrics.EngineMetrics.SQLActiveStatements.Dec(1)
+		if !payloadHasError(retPayload) {
+			ex.incrementExecutedStmtCounter(cmd.Stmt)
+		}
+	}()
+
+	if err := runWalSender(ctx, &ex.planner, cmd); err != nil {
+		ev := eventNonRetriableErr{IsCommit: fsm.False}
+		payload := eventNonRetriableErrPayload{err: err}
+		return ev, payload
+	}
+	return nil, nil
+}
+
 func (ex *connExecutor) setCopyLoggingFields(stmt statements.Statement[tree.Statement]) {
 	// These fields need to be set for logging purposes.
 	ex.planner.stmt = Statement{
@@ -3230,8 +3395,13 @@ func (ex *connExecutor) execCopyIn(
 }
 
 // stmtHasNoData returns true if describing a result of the input statement
-// type should return NoData.
-func stmtHasNoData(stmt tree.Statement) bool {
+// type should return NoData. A CALL statement only returns data if the
+// procedure has OUT or INOUT parameters, so the result columns of the
+// statement are needed to describe it.
+func stmtHasNoData(stmt tree.Statement, cols colinfo.ResultColumns) bool {
+	if _, ok := stmt.(*tree.Call); ok {
+		return len(cols) == 0
+	}
 	return stmt == nil || stmt.StatementReturnType() != tree.Rows
 }
 
@@ -3583,6 +3753,9 @@ func (ex *connExecutor) initEvalCtx(ctx context.Context, evalCtx *extendedEvalCo
 		Descs:                ex.extraTxnState.descCollection,
 		TxnModesSetter:       ex,
 		jobs:                 ex.extraTxnState.jobs,
+		notifications:        ex.extraTxnState.notifications,
+		deferredConstraints:  ex.extraTxnState.deferredConstraints,
+		storedProcTxnState:   ex.extraTxnState.storedProcTxnState,
 		validateDbZoneConfig: &ex.extraTxnState.validateDbZoneConfig,
 		statsProvider:        ex.server.sqlStats,
 		indexUsageStats:      ex.indexUsageStats,
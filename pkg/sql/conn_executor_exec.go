@@ -734,6 +734,24 @@ func (ex *connExecutor) execStmtInOpenState(
 			})
 	}
 
+	// A procedure invoked with CALL can only commit or roll back the
+	// transaction if the CALL runs in an implicit transaction. When the CALL is
+	// executed through a portal, the implicit transaction must also end with
+	// the portal, which is the case when the Execute message is followed by
+	// Sync.
+	//
+	// The check is made against the statement sent by the client rather than
+	// ast, from which EXPLAIN ANALYZE and EXECUTE have been stripped, so that
+	// a procedure cannot end the transaction when it is called by EXPLAIN
+	// ANALYZE CALL or by the EXECUTE of a prepared CALL. Neither of them can be
+	// executed again to resume the procedure.
+	procTxnState := ex.extraTxnState.storedProcTxnState
+	_, isCall := parserStmt.AST.(*tree.Call)
+	if isCall && canAutoCommit && !isPausablePortal() {
+		procTxnState.canControlTxn = true
+		defer func() { procTxnState.canControlTxn = false }()
+	}
+
 	defer func(ctx context.Context) {
 		if filter := ex.server.cfg.TestingKnobs.StatementFilter; retErr == nil && filter != nil {
 			var execErr error
@@ -748,8 +766,20 @@ func (ex *connExecutor) execStmtInOpenState(
 		if retEv != nil || retErr != nil {
 			return
 		}
-		// As portals are from extended protocol, we don't auto commit for them.
-		if canAutoCommit && !isExtendedProtocol {
+		// As portals are from extended protocol, we don't auto commit for them,
+		// unless a procedure ended the transaction. In that case, the
+		// transaction is ended right away, and the portal is executed again in a
+		// new transaction to resume the procedure.
+		procEndedTxn := procTxnState.txnOp != tree.StoredProcTxnNoOp
+		if canAutoCommit && (!isExtendedProtocol || procEndedTxn) {
+			if isExtendedProtocol && procTxnState.resumePortal == nil {
+				procTxnState.saveResumePortal(portal)
+			}
+			if procTxnState.txnOp == tree.StoredProcTxnRollback {
+				// The procedure rolled back the transaction.
+				retEv, retPayload = ex.rollbackSQLTransaction(ctx, ast)
+				return
+			}
 			retEv, retPayload = ex.handleAutoCommit(ctx, ast)
 		}
 	}(ctx)
@@ -1395,11 +1425,31 @@ func (ex *connExecutor) reportSessionDataChanges(fn func() error) error {
 	return nil
 }
 
-func (ex *connExecutor) commitSQLTransactionInternal(ctx context.Context) error {
+func (ex *connExecutor) commitSQLTransactionInternal(ctx context.Context) (retErr error) {
 	ctx, sp := tracing.EnsureChildSpan(ctx, ex.server.cfg.AmbientCtx.Tracer, "commit sql txn")
 	defer sp.Finish()
 
-	if err := ex.extraTxnState.sqlCursors.closeAll(true /* errorOnWithHold */); err != nil {
+	// WITH HOLD cursors are materialized before committing, so that they can
+	// be used after the transaction finishes.
+	persistedCursors, err := ex.extraTxnState.sqlCursors.persistHeldCursors(
+		ctx, ex.planner.ExtendedEvalContext(), ex.sessionMon,
+	)
+	defer func() {
+		if retErr == nil {
+			return
+		}
+		// The persisted cursors must not outlive a transaction that failed to
+		// commit.
+		for _, name := range persistedCursors {
+			if err := ex.extraTxnState.sqlCursors.closeCursor(name); err != nil {
+				log.Warningf(ctx, "error closing cursor %s: %v", name, err)
+			}
+		}
+	}()
+	if err != nil {
+		return err
+	}
+	if err := ex.extraTxnState.sqlCursors.closeAll(true /* keepPersisted */); err != nil {
 		return err
 	}
 
@@ -1417,6 +1467,14 @@ func (ex *connExecutor) commitSQLTransactionInternal(ctx context.Context) error
 		ex.state.mu.txn.ConfigureStepping(ctx, prevSteppingMode)
 	}
 
+	// Validate the deferred constraints now that all the writes of the
+	// transaction are visible.
+	if deferredConstraints := ex.extraTxnState.deferredConstraints; deferredConstraints.enabled() {
+		if err := ex.planner.validateDeferredConstraints(ctx, deferredConstraints.takePending()); err != nil {
+			return err
+		}
+	}
+
 	if err := ex.createJobs(ctx); err != nil {
 		return err
 	}
@@ -1494,7 +1552,7 @@ func (ex *connExecutor) createJobs(ctx context.Context) error {
 func (ex *connExecutor) rollbackSQLTransaction(
 	ctx context.Context, stmt tree.Statement,
 ) (fsm.Event, fsm.EventPayload) {
-	if err := ex.extraTxnState.sqlCursors.closeAll(false /* errorOnWithHold */); err != nil {
+	if err := ex.extraTxnState.sqlCursors.closeAll(true /* keepPersisted */); err != nil {
 		return ex.makeErrEvent(err, stmt)
 	}
 
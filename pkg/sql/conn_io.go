@@ -18,8 +18,10 @@ import (
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser/statements"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgrepltree"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -424,6 +426,42 @@ func (c CopyOut) String() string {
 
 var _ Command = CopyOut{}
 
+// StartReplication is the command for streaming logical replication changes
+// with the Copy-both pgwire subprotocol.
+type StartReplication struct {
+	ParsedStmt statements.Statement[tree.Statement]
+	Stmt       *pgrepltree.StartReplication
+	// Conn is the network connection. Execution of START_REPLICATION takes
+	// control of the connection.
+	Conn pgwirebase.Conn
+	// ReplicationDone is decremented once streaming finishes, signaling that
+	// control of the connection is being handed back to the network routine.
+	ReplicationDone *sync.WaitGroup
+	// TimeReceived is the time at which the message was received
+	// from the client. Used to compute the service latency.
+	TimeReceived time.Time
+	// ParseStart/ParseEnd are the timing info for parsing of the query. Used for
+	// stats reporting.
+	ParseStart time.Time
+	ParseEnd   time.Time
+}
+
+// command implements the Command interface.
+func (StartReplication) command() string { return "start replication" }
+
+// isExtendedProtocolCmd implements the Command interface.
+func (StartReplication) isExtendedProtocolCmd() bool { return false }
+
+func (c StartReplication) String() string {
+	s := "(empty)"
+	if c.Stmt != nil {
+		s = c.Stmt.String()
+	}
+	return fmt.Sprintf("StartReplication: %s", s)
+}
+
+var _ Command = StartReplication{}
+
 // DrainRequest represents a notice that the server is draining and command
 // processing should stop soon.
 //
@@ -442,6 +480,27 @@ func (DrainRequest) String() string {
 
 var _ Command = DrainRequest{}
 
+// DeliverNotifications is pushed by the node's notification registry when
+// asynchronous notifications are queued for a session that is LISTENing on
+// their channel. If the session is not inside a transaction, the queued
+// notifications are sent to the client right away; otherwise, they are sent
+// when the transaction ends.
+//
+// DeliverNotifications commands produce a FlushResult.
+type DeliverNotifications struct{}
+
+// command implements the Command interface.
+func (DeliverNotifications) command() string { return "deliver notifications" }
+
+// isExtendedProtocolCmd implements the Command interface.
+func (DeliverNotifications) isExtendedProtocolCmd() bool { return false }
+
+func (DeliverNotifications) String() string {
+	return "DeliverNotifications"
+}
+
+var _ Command = DeliverNotifications{}
+
 // SendError is a command that, upon execution, send a specific error to the
 // client. This is used by pgwire to schedule errors to be sent at an
 // appropriate time.
@@ -736,6 +795,9 @@ type ClientComm interface {
 	CreateCopyInResult(cmd CopyIn, pos CmdPos) CopyInResult
 	// CreateCopyOutResult creates a result for a Copy-out command.
 	CreateCopyOutResult(cmd CopyOut, pos CmdPos) CopyOutResult
+	// CreateStartReplicationResult creates a result for a StartReplication
+	// command.
+	CreateStartReplicationResult(cmd StartReplication, pos CmdPos) StartReplicationResult
 	// CreateDrainResult creates a result for a Drain command.
 	CreateDrainResult(pos CmdPos) DrainResult
 
@@ -930,12 +992,23 @@ type DeleteResult interface {
 // flushed.
 type SyncResult interface {
 	ResultBase
+	NotificationResult
 }
 
 // FlushResult represents the result of a Flush command. When this result is
 // closed, all previously accumulated results are flushed to the client.
 type FlushResult interface {
 	ResultBase
+	NotificationResult
+}
+
+// NotificationResult is implemented by the results that can carry
+// asynchronous notifications to the client.
+type NotificationResult interface {
+	// BufferNotification appends an asynchronous notification sent with NOTIFY
+	// to the result. Buffered notifications are sent to the client before the
+	// result's completion message.
+	BufferNotification(notification serverpb.Notification)
 }
 
 // DrainResult represents the result of a Drain command. Closing this result
@@ -974,6 +1047,17 @@ type CopyOutResult interface {
 
 	// SendCopyDone sends the copy done response to the client.
 	SendCopyDone(ctx context.Context) error
+
+	// SetRowsAffected sets the number of rows affected by the COPY. It is only
+	// used when the rows are written to a file instead of being sent to the
+	// client.
+	SetRowsAffected(ctx context.Context, n int)
+}
+
+// StartReplicationResult represents the result of a StartReplication
+// command. Closing this result sends a CommandComplete message to the client.
+type StartReplicationResult interface {
+	ResultBase
 }
 
 // ClientLock is an interface returned by ClientComm.lockCommunication(). It
@@ -1089,6 +1173,11 @@ func (r *streamingCommandResult) BufferNotice(notice pgnotice.Notice) {
 	// Unimplemented: the internal executor does not support notices.
 }
 
+// BufferNotification is part of the NotificationResult interface.
+func (r *streamingCommandResult) BufferNotification(notification serverpb.Notification) {
+	// Unimplemented: the internal executor does not support notifications.
+}
+
 // SendNotice is part of the RestrictedCommandResult interface.
 func (r *streamingCommandResult) SendNotice(ctx context.Context, notice pgnotice.Notice) error {
 	// Unimplemented: the internal executor does not support notices.
@@ -327,44 +327,90 @@ func TestCopyFromBinary(t *testing.T) {
 			i8 INT8,
 			f FLOAT,
 			s STRING,
-			b BYTES
+			b BYTES,
+			d DECIMAL,
+			dt DATE,
+			ts TIMESTAMPTZ,
+			iv INTERVAL,
+			uid UUID,
+			j JSONB,
+			vc VARCHAR(3)
 		);
 	`); err != nil {
 		t.Fatal(err)
 	}
 
-	input := [][]interface{}{{
-		1,
-		nil,
-		true,
-		int16(1),
-		int32(1),
-		int64(1),
-		float64(1),
-		"s",
-		"b",
-	}}
-	if _, err = conn.CopyFrom(
-		ctx,
-		pgx.Identifier{"t"},
-		[]string{"id", "u", "o", "i2", "i4", "i8", "f", "s", "b"},
-		pgx.CopyFromRows(input),
-	); err != nil {
-		t.Fatal(err)
+	cols := []string{"id", "u", "o", "i2", "i4", "i8", "f", "s", "b", "d", "dt", "ts", "iv", "uid", "j", "vc"}
+	input := [][]interface{}{
+		{
+			1,
+			nil,
+			true,
+			int16(1),
+			int32(1),
+			int64(1),
+			float64(1),
+			"s",
+			"b",
+			float64(1.5),
+			time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC),
+			time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+			90 * time.Minute,
+			[16]byte{15: 1},
+			`{"a": 1}`,
+			"abc",
+		},
+		{2, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil},
+		{
+			3,
+			3,
+			false,
+			int16(-2),
+			int32(-4),
+			int64(-8),
+			float64(-1.25),
+			"é",
+			"",
+			float64(-0.001),
+			time.Date(1999, 12, 31, 0, 0, 0, 0, time.UTC),
+			time.Date(1999, 12, 31, 23, 59, 59, 0, time.UTC),
+			time.Second,
+			[16]byte{0: 0xff},
+			`[]`,
+			"",
+		},
 	}
-
-	expect := func() [][]string {
-		row := make([]string, len(input[0]))
-		for i, v := range input[0] {
-			if v == nil {
-				row[i] = "NULL"
-			} else {
-				row[i] = fmt.Sprintf("%v", v)
+	expect := [][]string{
+		{"1", "NULL", "true", "1", "1", "1", "1", "s", "b", "1.5", "2020-01-02",
+			"2020-01-02 03:04:05+00", "01:30:00", "00000000-0000-0000-0000-000000000001", `{"a": 1}`, "abc"},
+		{"2", "NULL", "NULL", "NULL", "NULL", "NULL", "NULL", "NULL", "NULL", "NULL", "NULL",
+			"NULL", "NULL", "NULL", "NULL", "NULL"},
+		{"3", "3", "false", "-2", "-4", "-8", "-1.25", "é", "", "-0.001", "1999-12-31",
+			"1999-12-31 23:59:59+00", "00:00:01", "ff000000-0000-0000-0000-000000000000", "[]", ""},
+	}
+
+	// Binary COPY is decoded straight into a columnar batch when the
+	// vectorized engine is on, and into datums otherwise.
+	for _, vectorize := range []string{"on", "off"} {
+		t.Run("vectorize="+vectorize, func(t *testing.T) {
+			if _, err := conn.Exec(ctx, "SET vectorize = "+vectorize); err != nil {
+				t.Fatal(err)
 			}
-		}
-		return [][]string{row}
-	}()
-	sqlDB.CheckQueryResults(t, "SELECT * FROM t ORDER BY id", expect)
+			sqlDB.Exec(t, "TRUNCATE t")
+			if _, err = conn.CopyFrom(
+				ctx,
+				pgx.Identifier{"t"},
+				cols,
+				pgx.CopyFromRows(input),
+			); err != nil {
+				t.Fatal(err)
+			}
+			sqlDB.CheckQueryResults(t, `
+				SELECT id, u, o, i2, i4, i8, f, s, b, d::STRING, dt::STRING, ts::STRING,
+					iv::STRING, uid::STRING, j::STRING, vc
+				FROM t ORDER BY id`, expect)
+		})
+	}
 }
 
 func TestCopyFromError(t *testing.T) {
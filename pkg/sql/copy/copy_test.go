@@ -28,8 +28,10 @@ import (
 	"github.com/cockroachdb/apd/v3"
 	"github.com/cockroachdb/cockroach/pkg/base"
 	"github.com/cockroachdb/cockroach/pkg/cli/clisqlclient"
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverbase"
 	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/server"
 	"github.com/cockroachdb/cockroach/pkg/sql"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/desctestutils"
@@ -649,6 +651,50 @@ func TestTinyRows(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// TestCopyFromArrayDimsMixedVersion verifies that COPY, including vectorized
+// COPY, rejects arrays with non-default dimensions until 24.1 is active.
+func TestCopyFromArrayDimsMixedVersion(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	ctx := context.Background()
+
+	s := serverutils.StartServerOnly(t, base.TestServerArgs{
+		Knobs: base.TestingKnobs{
+			Server: &server.TestingKnobs{
+				DisableAutomaticVersionUpgrade: make(chan struct{}),
+				BinaryVersionOverride:          clusterversion.ByKey(clusterversion.V23_2),
+			},
+		},
+	})
+	defer s.Stopper().Stop(ctx)
+
+	url, cleanup := sqlutils.PGUrl(t, s.AdvSQLAddr(), "copytest", url.User(username.RootUser))
+	defer cleanup()
+	var sqlConnCtx clisqlclient.Context
+	conn := sqlConnCtx.MakeSQLConn(io.Discard, io.Discard, url.String())
+
+	// Vectorized COPY requires the fast path, override metamorphic that might
+	// turn it off.
+	for _, stmt := range []string{
+		`SET COPY_FAST_PATH_ENABLED = 'true'`,
+		`SET VECTORIZE = 'on'`,
+		`CREATE TABLE t (a INT[])`,
+	} {
+		require.NoError(t, conn.Exec(ctx, stmt))
+	}
+
+	_, err := conn.GetDriverConn().CopyFrom(ctx, strings.NewReader("{1,2}\n"), "COPY t FROM STDIN")
+	require.NoError(t, err)
+	for _, data := range []string{"{{1,2},{3,4}}\n", "[0:1]={1,2}\n"} {
+		_, err = conn.GetDriverConn().CopyFrom(ctx, strings.NewReader(data), "COPY t FROM STDIN")
+		require.ErrorContains(t, err, "not supported until version 24.1")
+	}
+
+	require.NoError(t, conn.Exec(ctx, `SET CLUSTER SETTING version = crdb_internal.node_executable_version()`))
+	_, err = conn.GetDriverConn().CopyFrom(ctx, strings.NewReader("{{1,2},{3,4}}\n"), "COPY t FROM STDIN")
+	require.NoError(t, err)
+}
+
 // TODO(cucaroach): get the rand utilities and ParseAndRequire to be friends
 // STRINGS don't roundtrip well, need to figure out proper escaping
 // INET doesn't round trip: ERROR: could not parse "70e5:112:5114:7da5:1" as inet. invalid IP (SQLSTATE 22P02)
@@ -0,0 +1,86 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/cloud/cloudpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/syntheticprivilege"
+)
+
+// copyFileChunkSize is the size of the chunks in which COPY FROM reads an
+// external storage file.
+const copyFileChunkSize = 64 << 10
+
+// evalCopyFileURI evaluates the URI of the external storage file that is read
+// by COPY FROM or written by COPY TO, and checks that the current user is
+// allowed to access it.
+func evalCopyFileURI(ctx context.Context, p *planner, file tree.Expr) (string, error) {
+	uri, err := p.ExprEvaluator("COPY").String(ctx, file)
+	if err != nil {
+		return "", err
+	}
+	if err := checkExternalStorageURIPrivileges(ctx, p, uri); err != nil {
+		return "", err
+	}
+	return uri, nil
+}
+
+// checkExternalStorageURIPrivileges checks that the current user is allowed to
+// access the given external storage URI. These are the same checks that
+// IMPORT and EXPORT perform; see cloudprivilege.CheckDestinationPrivileges,
+// which cannot be used here because of a circular dependency with pkg/sql.
+func checkExternalStorageURIPrivileges(ctx context.Context, p *planner, uri string) error {
+	isAdmin, err := p.HasAdminRole(ctx)
+	if err != nil {
+		return err
+	}
+	if isAdmin {
+		return nil
+	}
+	conf, err := cloud.ExternalStorageConfFromURI(uri, p.User())
+	if err != nil {
+		return err
+	}
+	// Check if the destination requires the user to be an admin or have the
+	// EXTERNALIOIMPLICITACCESS privilege.
+	if !conf.AccessIsWithExplicitAuth() && !p.ExecCfg().ExternalIODirConfig.EnableNonAdminImplicitAndArbitraryOutbound {
+		hasImplicitAccess, err := p.HasPrivilege(
+			ctx, syntheticprivilege.GlobalPrivilegeObject, privilege.EXTERNALIOIMPLICITACCESS, p.User(),
+		)
+		if err != nil {
+			return err
+		}
+		if !hasImplicitAccess {
+			return pgerror.Newf(
+				pgcode.InsufficientPrivilege,
+				"only users with the admin role or the EXTERNALIOIMPLICITACCESS system privilege "+
+					"are allowed to access the specified %s URI", conf.Provider.String())
+		}
+	}
+	// If the resource is an External Connection, check that the user has
+	// adequate privileges on it.
+	if conf.Provider == cloudpb.ExternalStorageProvider_external {
+		ecPrivilege := &syntheticprivilege.ExternalConnectionPrivilege{
+			ConnectionName: conf.ExternalConnectionConfig.Name,
+		}
+		if err := p.CheckPrivilege(ctx, ecPrivilege, privilege.USAGE); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -15,12 +15,15 @@ import (
 	"context"
 	"encoding/binary"
 	"io"
+	"math"
 	"strings"
 	"time"
 	"unicode/utf8"
 	"unsafe"
 
 	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/col/coldata"
 	"github.com/cockroachdb/cockroach/pkg/col/coldataext"
 	"github.com/cockroachdb/cockroach/pkg/kv"
@@ -30,11 +33,13 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/resolver"
 	"github.com/cockroachdb/cockroach/pkg/sql/colexecerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/colmem"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowcontainer"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
@@ -45,12 +50,14 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
 	"github.com/cockroachdb/cockroach/pkg/util/encoding/csv"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/intsets"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/mon"
 	"github.com/cockroachdb/cockroach/pkg/util/retry"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
 	"github.com/cockroachdb/errors"
 	"github.com/dustin/go-humanize"
+	"github.com/lib/pq/oid"
 )
 
 // CopyBatchRowSizeDefault is the number of rows we insert in one insert
@@ -87,6 +94,12 @@ type copyMachineInterface interface {
 type copyOptions struct {
 	csvEscape       rune
 	csvExpectHeader bool
+	// csvForceQuote, csvForceNotNull and csvForceNull are the columns given to
+	// the FORCE_QUOTE, FORCE_NOT_NULL and FORCE_NULL options, respectively.
+	csvForceQuote    tree.NameList
+	csvForceQuoteAll bool
+	csvForceNotNull  tree.NameList
+	csvForceNull     tree.NameList
 
 	delimiter byte
 	format    tree.CopyFormat
@@ -99,8 +112,12 @@ func processCopyOptions(
 	ctx context.Context, p *planner, opts tree.CopyOptions,
 ) (copyOptions, error) {
 	c := copyOptions{
-		format:          opts.CopyFormat,
-		csvExpectHeader: opts.Header,
+		format:           opts.CopyFormat,
+		csvExpectHeader:  opts.Header,
+		csvForceQuote:    opts.ForceQuote,
+		csvForceQuoteAll: opts.ForceQuoteAll,
+		csvForceNotNull:  opts.ForceNotNull,
+		csvForceNull:     opts.ForceNull,
 	}
 
 	switch c.format {
@@ -125,6 +142,27 @@ func processCopyOptions(
 		}
 	}
 
+	if (len(opts.ForceQuote) > 0 || opts.ForceQuoteAll) && c.format != tree.CopyFormatCSV {
+		return c, pgerror.Newf(pgcode.FeatureNotSupported, "FORCE_QUOTE only supported with CSV format")
+	}
+	if len(opts.ForceNotNull) > 0 && c.format != tree.CopyFormatCSV {
+		return c, pgerror.Newf(pgcode.FeatureNotSupported, "FORCE_NOT_NULL only supported with CSV format")
+	}
+	if len(opts.ForceNull) > 0 && c.format != tree.CopyFormatCSV {
+		return c, pgerror.Newf(pgcode.FeatureNotSupported, "FORCE_NULL only supported with CSV format")
+	}
+
+	if opts.Encoding != nil {
+		// Like client_encoding, only UTF8 is supported.
+		switch encoding := builtins.CleanEncodingName(opts.Encoding.RawString()); encoding {
+		case "utf8", "unicode", "cp65001":
+		default:
+			return c, unimplemented.NewWithIssueDetailf(35882,
+				"copy encoding "+encoding,
+				"unimplemented encoding: %q", encoding)
+		}
+	}
+
 	exprEval := p.ExprEvaluator("COPY")
 	if opts.Delimiter != nil {
 		if c.format == tree.CopyFormatBinary {
@@ -187,6 +225,28 @@ func processCopyOptions(
 	return c, nil
 }
 
+// resolveCopyOptionColumns returns the ordinals of the given columns, which
+// are referenced by the given COPY option, among the columns being copied.
+func resolveCopyOptionColumns(
+	option string, names tree.NameList, cols colinfo.ResultColumns,
+) (intsets.Fast, error) {
+	var ords intsets.Fast
+	for _, name := range names {
+		found := false
+		for i := range cols {
+			if cols[i].Name == string(name) {
+				ords.Add(i)
+				found = true
+			}
+		}
+		if !found {
+			return intsets.Fast{}, pgerror.Newf(pgcode.InvalidColumnReference,
+				"%s column %q not referenced by COPY", option, string(name))
+		}
+	}
+	return ords, nil
+}
+
 // copyMachine supports the Copy-in pgwire subprotocol (COPY...FROM STDIN). The
 // machine is created by the Executor when that statement is executed; from that
 // moment on, the machine takes control of the pgwire connection until
@@ -195,6 +255,10 @@ func processCopyOptions(
 // associated with statement results). Errors however are not sent on the
 // connection by the machine; the higher layer is responsible for sending them.
 //
+// The machine also supports COPY...FROM 'uri', in which case the data is read
+// from an external storage file and no Copy-in protocol messages are
+// exchanged with the client.
+//
 // Incoming data is buffered and batched; batches are turned into insertNodes
 // that are executed. INSERT privileges are required on the destination table.
 //
@@ -210,12 +274,21 @@ type copyMachine struct {
 	// textDelim is delimiter converted to a []byte so that we don't have to do that per row.
 	textDelim   []byte
 	binaryState binaryState
+	// binaryFields is scratch space for the fields of a binary tuple.
+	binaryFields [][]byte
 	// forceNotNull disables converting values matching the null string to
 	// NULL. The spec says this is only supported for CSV, and also must specify
 	// which columns it applies to.
 	forceNotNull bool
 	csvInput     bytes.Buffer
 	csvReader    *csv.Reader
+	// csvForceNotNullCols and csvForceNullCols are the ordinals of the columns
+	// given to the FORCE_NOT_NULL and FORCE_NULL options, respectively.
+	csvForceNotNullCols intsets.Fast
+	csvForceNullCols    intsets.Fast
+	// where, if set, is the filter of the WHERE clause, evaluated against
+	// scratchRow.
+	where tree.TypedExpr
 	// buf is used to parse input data into rows. It also accumulates a partial
 	// row between protocol messages.
 	buf []byte
@@ -234,6 +307,9 @@ type copyMachine struct {
 
 	// conn is the pgwire connection from which data is to be read.
 	conn pgwirebase.Conn
+	// file, if set, is the URI of the external storage file from which data is
+	// to be read instead of conn.
+	file string
 
 	// execInsertPlan is a function to be used to execute the plan (stored in the
 	// planner) which performs an INSERT.
@@ -282,6 +358,9 @@ func newCopyMachine(
 	if err != nil {
 		return nil, err
 	}
+	if len(cOpts.csvForceQuote) > 0 || cOpts.csvForceQuoteAll {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported, "FORCE_QUOTE only supported with COPY TO")
+	}
 	c := &copyMachine{
 		conn:        conn,
 		copyFromAST: n,
@@ -315,6 +394,11 @@ func newCopyMachine(
 	if err := c.p.CheckPrivilege(ctx, tableDesc, privilege.INSERT); err != nil {
 		return nil, err
 	}
+	if n.File != nil {
+		if c.file, err = evalCopyFileURI(ctx, c.p, n.File); err != nil {
+			return nil, err
+		}
+	}
 	cols, err := colinfo.ProcessTargetColumns(tableDesc, n.Columns,
 		true /* ensureColumns */, false /* allowMutations */)
 	if err != nil {
@@ -332,6 +416,21 @@ func newCopyMachine(
 		typs[i] = col.GetType()
 	}
 	c.typs = typs
+	if c.csvForceNotNullCols, err = resolveCopyOptionColumns(
+		"FORCE_NOT_NULL", cOpts.csvForceNotNull, c.resultColumns,
+	); err != nil {
+		return nil, err
+	}
+	if c.csvForceNullCols, err = resolveCopyOptionColumns(
+		"FORCE_NULL", cOpts.csvForceNull, c.resultColumns,
+	); err != nil {
+		return nil, err
+	}
+	if n.Where != nil {
+		if err := c.initWhere(ctx, n); err != nil {
+			return nil, err
+		}
+	}
 	// If there are no column specifiers and we expect non-visible columns
 	// to have field data then we have to populate the expectedHiddenColumnIdxs
 	// field with the columns indexes we expect to be hidden.
@@ -353,7 +452,7 @@ func newCopyMachine(
 	// we still have all the encoder allocations to make.
 	c.maxRowMem = kvserverbase.MaxCommandSize.Get(c.p.execCfg.SV()) / 3
 
-	if c.canSupportVectorized(tableDesc) {
+	if c.canSupportVectorized(ctx, tableDesc) {
 		if err := c.initVectorizedCopy(ctx, typs); err != nil {
 			return nil, err
 		}
@@ -366,11 +465,57 @@ func newCopyMachine(
 	return c, nil
 }
 
-func (c *copyMachine) canSupportVectorized(table catalog.TableDescriptor) bool {
-	// TODO(cucaroach): support vectorized binary.
-	if c.format == tree.CopyFormatBinary {
+// initWhere resolves and type checks the WHERE clause of the COPY. Only the
+// columns being copied can be referenced.
+func (c *copyMachine) initWhere(ctx context.Context, n *tree.CopyFrom) (err error) {
+	source := colinfo.NewSourceInfoForSingleTable(n.Table, c.resultColumns)
+	ivarHelper := tree.MakeIndexedVarHelper(c, len(c.resultColumns))
+	defer c.p.semaCtx.Properties.Restore(c.p.semaCtx.Properties)
+	c.p.semaCtx.Properties.Require("COPY FROM WHERE", tree.RejectSpecial|tree.RejectSubqueries)
+	c.where, err = c.p.analyzeExpr(
+		ctx, n.Where.Expr, source, ivarHelper, types.Bool, true /* requireType */, "COPY FROM WHERE",
+	)
+	return err
+}
+
+var _ eval.IndexedVarContainer = &copyMachine{}
+
+// IndexedVarEval implements the eval.IndexedVarContainer interface.
+func (c *copyMachine) IndexedVarEval(
+	ctx context.Context, idx int, e tree.ExprEvaluator,
+) (tree.Datum, error) {
+	return c.scratchRow[idx], nil
+}
+
+// IndexedVarResolvedType implements the tree.IndexedVarContainer interface.
+func (c *copyMachine) IndexedVarResolvedType(idx int) *types.T {
+	return c.resultColumns[idx].Typ
+}
+
+// IndexedVarNodeFormatter implements the tree.IndexedVarContainer interface.
+func (c *copyMachine) IndexedVarNodeFormatter(idx int) tree.NodeFormatter {
+	return c.resultColumns.NodeFormatter(idx)
+}
+
+func (c *copyMachine) canSupportVectorized(
+	ctx context.Context, table catalog.TableDescriptor,
+) bool {
+	// The WHERE clause is evaluated on the datums of each row, which only the
+	// row-by-row path materializes.
+	if c.where != nil {
 		return false
 	}
+	// Vectorized COPY encodes the values without going through
+	// colinfo.ValidateColumnValue. Until 24.1 is active, arrays are copied
+	// row-by-row so that arrays with non-default dimensions, which older nodes
+	// cannot decode, are rejected.
+	if !c.p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V24_1) {
+		for _, col := range c.resultColumns {
+			if typeContainsArray(col.Typ) {
+				return false
+			}
+		}
+	}
 	// Vectorized requires avoiding materializing the rows for the optimizer.
 	if !c.copyFastPath {
 		return false
@@ -389,6 +534,22 @@ func (c *copyMachine) canSupportVectorized(table catalog.TableDescriptor) bool {
 	return len(table.EnforcedOutboundForeignKeys()) == 0
 }
 
+// typeContainsArray returns whether the values of the given type can contain
+// arrays.
+func typeContainsArray(t *types.T) bool {
+	switch t.Family() {
+	case types.ArrayFamily:
+		return true
+	case types.TupleFamily:
+		for _, typ := range t.TupleContents() {
+			if typeContainsArray(typ) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (c *copyMachine) initVectorizedCopy(ctx context.Context, typs []*types.T) error {
 	if buildutil.CrdbTestBuild {
 		// We have to honor metamorphic default in testing, the transaction
@@ -513,23 +674,9 @@ func (c *copyMachine) Close(ctx context.Context) {
 	c.copyMon.Stop(ctx)
 }
 
-// run consumes all the copy-in data from the network connection and inserts it
-// in the database.
+// run consumes all the copy-in data from the network connection, or from the
+// external storage file, and inserts it in the database.
 func (c *copyMachine) run(ctx context.Context) error {
-	format := pgwirebase.FormatText
-	if c.format == tree.CopyFormatBinary {
-		format = pgwirebase.FormatBinary
-	}
-	// Send the message describing the columns to the client.
-	if err := c.conn.BeginCopyIn(ctx, c.resultColumns, format); err != nil {
-		return err
-	}
-
-	// Read from the connection until we see an ClientMsgCopyDone.
-	readBuf := pgwirebase.MakeReadBuffer(
-		pgwirebase.ReadBufferOptionWithClusterSettings(&c.p.execCfg.Settings.SV),
-	)
-
 	switch c.format {
 	case tree.CopyFormatText:
 		c.textDelim = []byte{c.delimiter}
@@ -544,6 +691,24 @@ func (c *copyMachine) run(ctx context.Context) error {
 		}
 	}
 
+	if c.file != "" {
+		return c.readFile(ctx)
+	}
+
+	format := pgwirebase.FormatText
+	if c.format == tree.CopyFormatBinary {
+		format = pgwirebase.FormatBinary
+	}
+	// Send the message describing the columns to the client.
+	if err := c.conn.BeginCopyIn(ctx, c.resultColumns, format); err != nil {
+		return err
+	}
+
+	// Read from the connection until we see an ClientMsgCopyDone.
+	readBuf := pgwirebase.MakeReadBuffer(
+		pgwirebase.ReadBufferOptionWithClusterSettings(&c.p.execCfg.Settings.SV),
+	)
+
 Loop:
 	for {
 		typ, _, err := readBuf.ReadTypedMsg(c.conn.Rd())
@@ -603,6 +768,38 @@ Loop:
 	return nil
 }
 
+// readFile consumes all the copy-in data from the external storage file.
+func (c *copyMachine) readFile(ctx context.Context) error {
+	store, err := c.p.execCfg.DistSQLSrv.ExternalStorageFromURI(ctx, c.file, c.p.User())
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+	r, _, err := store.ReadFile(ctx, "", cloud.ReadOptions{NoFileSize: true})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = r.Close(ctx) }()
+
+	buf := make([]byte, copyFileChunkSize)
+	for {
+		n, err := r.Read(ctx, buf)
+		if n > 0 {
+			if err := c.processCopyData(
+				ctx, unsafeUint8ToString(buf[:n]), false, /* final */
+			); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+	}
+	return c.processCopyData(ctx, "" /* data */, true /* final */)
+}
+
 const (
 	lineDelim = '\n'
 	endOfData = `\.`
@@ -829,9 +1026,7 @@ func (c *copyMachine) readCSVTuple(ctx context.Context, record []csv.Record) err
 	if c.vectorized {
 		vh := c.valueHandlers
 		for i, s := range record {
-			// NB: When we implement FORCE_NULL, then quoted values also are allowed
-			// to be treated as NULL.
-			if !s.Quoted && s.Val == c.null {
+			if c.csvValueIsNull(i, s) {
 				vh[i].Null()
 				continue
 			}
@@ -843,9 +1038,7 @@ func (c *copyMachine) readCSVTuple(ctx context.Context, record []csv.Record) err
 	} else {
 		datums := c.scratchRow
 		for i, s := range record {
-			// NB: When we implement FORCE_NULL, then quoted values also are allowed
-			// to be treated as NULL.
-			if !s.Quoted && s.Val == c.null {
+			if c.csvValueIsNull(i, s) {
 				datums[i] = tree.DNull
 				continue
 			}
@@ -855,13 +1048,27 @@ func (c *copyMachine) readCSVTuple(ctx context.Context, record []csv.Record) err
 			}
 			datums[i] = d
 		}
-		if _, err := c.rows.AddRow(ctx, datums); err != nil {
+		if err := c.addRow(ctx); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+// csvValueIsNull returns whether the value of the i-th column in a CSV record
+// is NULL. Unquoted values that match the NULL string are NULL unless the
+// column was given to FORCE_NOT_NULL, and quoted values that match the NULL
+// string are NULL only if the column was given to FORCE_NULL.
+func (c *copyMachine) csvValueIsNull(i int, s csv.Record) bool {
+	if s.Val != c.null {
+		return false
+	}
+	if s.Quoted {
+		return c.csvForceNullCols.Contains(i)
+	}
+	return !c.csvForceNotNullCols.Contains(i)
+}
+
 func (c *copyMachine) readBinaryData(ctx context.Context, final bool) (brk bool, err error) {
 	if len(c.expectedHiddenColumnIdxs) > 0 {
 		return false, pgerror.Newf(
@@ -924,10 +1131,17 @@ func (c *copyMachine) readBinaryTuple(ctx context.Context) (bytesRead int, err e
 		return bytesRead, pgerror.Newf(pgcode.BadCopyFileFormat,
 			"unexpected field count: %d", fieldCount)
 	}
-	datums := make(tree.Datums, fieldCount)
+	if expected := len(c.resultColumns); int(fieldCount) != expected {
+		return bytesRead, pgerror.Newf(pgcode.BadCopyFileFormat,
+			"expected %d values, got %d", expected, fieldCount)
+	}
+	// Find all the fields of the tuple before decoding any of them, so that an
+	// incomplete tuple is left in the buffer untouched until more data arrives.
+	// A NULL field is represented by a nil slice.
+	fields := c.binaryFields[:0]
 	var byteCount int32
 	var byteCountBytes [4]byte
-	for i := range datums {
+	for i := 0; i < int(fieldCount); i++ {
 		n := copy(byteCountBytes[:], c.buf[bytesRead:])
 		bytesRead += n
 		if n < len(byteCountBytes) {
@@ -935,14 +1149,42 @@ func (c *copyMachine) readBinaryTuple(ctx context.Context) (bytesRead int, err e
 		}
 		byteCount = int32(binary.BigEndian.Uint32(byteCountBytes[:]))
 		if byteCount == -1 {
-			datums[i] = tree.DNull
+			fields = append(fields, nil)
 			continue
 		}
-		data := make([]byte, byteCount)
-		n = copy(data, c.buf[bytesRead:])
-		bytesRead += n
-		if n < len(data) {
-			return bytesRead, io.ErrUnexpectedEOF
+		if byteCount < 0 {
+			return bytesRead, pgerror.Newf(pgcode.BadCopyFileFormat,
+				"unexpected field length: %d", byteCount)
+		}
+		if len(c.buf)-bytesRead < int(byteCount) {
+			return len(c.buf), io.ErrUnexpectedEOF
+		}
+		fields = append(fields, c.buf[bytesRead:bytesRead+int(byteCount):bytesRead+int(byteCount)])
+		bytesRead += int(byteCount)
+	}
+	c.binaryFields = fields
+
+	if c.vectorized {
+		for i, data := range fields {
+			if data == nil {
+				c.valueHandlers[i].Null()
+				continue
+			}
+			if err := decodeBinaryValue(
+				ctx, c.parsingEvalCtx, c.resultColumns[i].Typ, data, c.valueHandlers[i],
+			); err != nil {
+				return bytesRead, pgerror.Wrapf(err, pgcode.BadCopyFileFormat,
+					"decode datum as %s: %s", c.resultColumns[i].Typ.SQLString(), data)
+			}
+		}
+		c.batch.SetLength(c.batch.Length() + 1)
+		return bytesRead, nil
+	}
+	datums := c.scratchRow
+	for i, data := range fields {
+		if data == nil {
+			datums[i] = tree.DNull
+			continue
 		}
 		d, err := pgwirebase.DecodeDatum(
 			ctx,
@@ -957,11 +1199,109 @@ func (c *copyMachine) readBinaryTuple(ctx context.Context) (bytesRead int, err e
 		}
 		datums[i] = d
 	}
-	_, err = c.rows.AddRow(ctx, datums)
+	return bytesRead, c.addRow(ctx)
+}
+
+// decodeBinaryValue decodes a value in the binary format and passes it to a
+// ValueHandler. Fixed-width numeric types, strings and bytes are written
+// straight into the handler, other types are decoded into a tree.Datum first.
+func decodeBinaryValue(
+	ctx context.Context, evalCtx *eval.Context, typ *types.T, data []byte, vh tree.ValueHandler,
+) error {
+	// Malformed values fall through to DecodeDatum, which returns the
+	// appropriate error.
+	switch typ.Oid() {
+	case oid.T_bool:
+		if len(data) > 0 && data[0] <= 1 {
+			vh.Bool(data[0] == 1)
+			return nil
+		}
+	case oid.T_int2:
+		if len(data) >= 2 {
+			vh.Int16(int16(binary.BigEndian.Uint16(data)))
+			return nil
+		}
+	case oid.T_int4:
+		if len(data) >= 4 {
+			vh.Int32(int32(binary.BigEndian.Uint32(data)))
+			return nil
+		}
+	case oid.T_int8:
+		if len(data) >= 8 {
+			vh.Int(int64(binary.BigEndian.Uint64(data)))
+			return nil
+		}
+	case oid.T_float4:
+		if len(data) >= 4 {
+			vh.Float(float64(math.Float32frombits(binary.BigEndian.Uint32(data))))
+			return nil
+		}
+	case oid.T_float8:
+		if len(data) >= 8 {
+			vh.Float(math.Float64frombits(binary.BigEndian.Uint64(data)))
+			return nil
+		}
+	case oid.T_text, oid.T_varchar:
+		// Strings with a width are truncated like in the text format, see
+		// tree.ParseAndRequireStringHandler.
+		if typ.Width() == 0 && utf8.Valid(data) {
+			vh.String(unsafeUint8ToString(data))
+			return nil
+		}
+	case oid.T_bytea:
+		vh.Bytes(data)
+		return nil
+	}
+	d, err := pgwirebase.DecodeDatum(ctx, evalCtx, typ, pgwirebase.FormatBinary, data)
 	if err != nil {
-		return bytesRead, err
+		return err
+	}
+	if typ.Family() == types.StringFamily {
+		// NAME values are wrapped in a DOidWrapper.
+		d = tree.UnwrapDOidWrapper(d)
+	}
+	switch t := d.(type) {
+	case *tree.DBool:
+		vh.Bool(bool(*t))
+	case *tree.DInt:
+		switch typ.Width() {
+		case 16:
+			vh.Int16(int16(*t))
+		case 32:
+			vh.Int32(int32(*t))
+		default:
+			vh.Int(int64(*t))
+		}
+	case *tree.DFloat:
+		vh.Float(float64(*t))
+	case *tree.DDecimal:
+		vh.Decimal().Set(&t.Decimal)
+	case *tree.DDate:
+		vh.Date(t.Date)
+	case *tree.DTimestamp:
+		vh.TimestampTZ(t.Time)
+	case *tree.DTimestampTZ:
+		vh.TimestampTZ(t.Time)
+	case *tree.DInterval:
+		vh.Duration(t.Duration)
+	case *tree.DString:
+		str := string(*t)
+		if typ.Width() > 0 {
+			str = util.TruncateString(str, int(typ.Width()))
+		}
+		vh.String(str)
+	case *tree.DBytes:
+		vh.Bytes(encoding.UnsafeConvertStringToBytes(string(*t)))
+	case *tree.DUuid:
+		vh.Bytes(t.GetBytes())
+	case *tree.DEnum:
+		vh.Bytes(t.PhysicalRep)
+	case *tree.DJSON:
+		vh.JSON(t.JSON)
+	default:
+		vh.Datum(d)
 	}
-	return bytesRead, nil
+	return nil
 }
 
 // This is the standard 11-byte binary signature with the flags and
@@ -1236,7 +1576,22 @@ func (c *copyMachine) readTextTupleDatum(ctx context.Context, parts [][]byte) er
 
 		datums[i] = d
 	}
-	_, err := c.rows.AddRow(ctx, datums)
+	return c.addRow(ctx)
+}
+
+// addRow adds scratchRow to the rows to be inserted, unless it is filtered out
+// by the WHERE clause.
+func (c *copyMachine) addRow(ctx context.Context) error {
+	if c.where != nil {
+		evalCtx := c.p.EvalContext()
+		evalCtx.PushIVarContainer(c)
+		pass, err := execinfrapb.RunFilter(ctx, c.where, evalCtx)
+		evalCtx.PopIVarContainer()
+		if err != nil || !pass {
+			return err
+		}
+	}
+	_, err := c.rows.AddRow(ctx, c.scratchRow)
 	return err
 }
 
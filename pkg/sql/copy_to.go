@@ -17,11 +17,14 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/util/encoding/csv"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/intsets"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 )
 
@@ -76,6 +79,8 @@ type csvCopyToTranslater struct {
 	b      bytes.Buffer
 	fmtCtx *tree.FmtCtx
 	w      *csv.Writer
+	// forceQuoteCols are the ordinals of the columns given to FORCE_QUOTE.
+	forceQuoteCols intsets.Fast
 }
 
 func (c *csvCopyToTranslater) translateRow(
@@ -83,7 +88,7 @@ func (c *csvCopyToTranslater) translateRow(
 ) ([]byte, error) {
 	c.b.Reset()
 	c.fmtCtx.Buffer.Reset()
-	for _, d := range datums {
+	for i, d := range datums {
 		if d == tree.DNull {
 			if err := c.w.WriteField(bytes.NewBufferString(c.null)); err != nil {
 				return nil, err
@@ -97,6 +102,10 @@ func (c *csvCopyToTranslater) translateRow(
 			if err := c.w.ForceEmptyField(); err != nil {
 				return nil, err
 			}
+		} else if c.csvForceQuoteAll || c.forceQuoteCols.Contains(i) {
+			if err := c.w.WriteQuotedField(bytes.NewBuffer(c.fmtCtx.Buffer.Bytes())); err != nil {
+				return nil, err
+			}
 		} else {
 			if err := c.w.WriteField(bytes.NewBuffer(c.fmtCtx.Buffer.Bytes())); err != nil {
 				return nil, err
@@ -135,9 +144,22 @@ func runCopyTo(
 	if err != nil {
 		return 0, err
 	}
+	if len(copyOptions.csvForceNotNull) > 0 {
+		return 0, pgerror.Newf(pgcode.FeatureNotSupported, "FORCE_NOT_NULL only supported with COPY FROM")
+	}
+	if len(copyOptions.csvForceNull) > 0 {
+		return 0, pgerror.Newf(pgcode.FeatureNotSupported, "FORCE_NULL only supported with COPY FROM")
+	}
+	var file string
+	if cmd.Stmt.File != nil {
+		if file, err = evalCopyFileURI(ctx, p, cmd.Stmt.File); err != nil {
+			return 0, err
+		}
+	}
 
 	wireFormat := pgwirebase.FormatText
 	var t copyToTranslater
+	var csvTranslater *csvCopyToTranslater
 	switch cmd.Stmt.Options.CopyFormat {
 	case tree.CopyFormatBinary:
 		// wireFormat = pgwirebase.FormatBinary
@@ -146,7 +168,7 @@ func runCopyTo(
 			"binary format for COPY TO not implemented",
 		)
 	case tree.CopyFormatCSV:
-		csvTranslater := &csvCopyToTranslater{
+		csvTranslater = &csvCopyToTranslater{
 			copyOptions: copyOptions,
 			fmtCtx:      p.EvalContext().FmtCtx(tree.FmtPgwireText),
 		}
@@ -202,9 +224,42 @@ func runCopyTo(
 		}
 	}()
 
-	// Send the message describing the columns to the client.
-	if err := res.SendCopyOut(ctx, it.Types(), wireFormat); err != nil {
-		return 0, err
+	if csvTranslater != nil {
+		if csvTranslater.forceQuoteCols, err = resolveCopyOptionColumns(
+			"FORCE_QUOTE", copyOptions.csvForceQuote, it.Types(),
+		); err != nil {
+			return 0, err
+		}
+	}
+
+	// When copying to a file, the rows are written to external storage and
+	// no Copy-out protocol messages are sent to the client.
+	var w io.WriteCloser
+	cancelWrite := func() {}
+	if file != "" {
+		store, err := p.execCfg.DistSQLSrv.ExternalStorageFromURI(ctx, file, p.User())
+		if err != nil {
+			return 0, err
+		}
+		defer store.Close()
+		var writeCtx context.Context
+		writeCtx, cancelWrite = context.WithCancel(ctx)
+		defer cancelWrite()
+		if w, err = store.Writer(writeCtx, ""); err != nil {
+			return 0, err
+		}
+	} else {
+		// Send the message describing the columns to the client.
+		if err := res.SendCopyOut(ctx, it.Types(), wireFormat); err != nil {
+			return 0, err
+		}
+	}
+	sendRow := func(row []byte, isHeader bool) error {
+		if w != nil {
+			_, err := w.Write(row)
+			return err
+		}
+		return res.SendCopyData(ctx, row, isHeader)
 	}
 
 	if err := func() error {
@@ -213,7 +268,7 @@ func runCopyTo(
 		if row, ok, err := t.headerRow(it.Types()); err != nil {
 			return err
 		} else if ok {
-			if err := res.SendCopyData(ctx, row, true /* isHeader */); err != nil {
+			if err := sendRow(row, true /* isHeader */); err != nil {
 				return err
 			}
 		}
@@ -231,14 +286,26 @@ func runCopyTo(
 			if err != nil {
 				return err
 			}
-			if err := res.SendCopyData(ctx, row, false /* isHeader */); err != nil {
+			if err := sendRow(row, false /* isHeader */); err != nil {
 				return err
 			}
 		}
 		return nil
 	}(); err != nil {
+		if w != nil {
+			// Abort the write so that the file is not left partially written.
+			cancelWrite()
+			_ = w.Close()
+		}
 		return 0, err
 	}
+	if w != nil {
+		if err := w.Close(); err != nil {
+			return 0, err
+		}
+		res.SetRowsAffected(ctx, numOutputRows)
+		return numOutputRows, nil
+	}
 	return numOutputRows, res.SendCopyDone(ctx)
 }
 
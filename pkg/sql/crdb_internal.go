@@ -3440,6 +3440,64 @@ func writeCreateTypeDescRow(
 			tree.DNull,                           // enum_members
 		)
 	}
+	if d := typeDesc.AsDomainTypeDescriptor(); d != nil {
+		name, err := tree.NewUnresolvedObjectName(2, [3]string{d.GetName(), sc.GetName()}, 0)
+		if err != nil {
+			return false, err
+		}
+		node := &tree.CreateType{
+			Variety:    tree.Domain,
+			TypeName:   name,
+			DomainType: d.BaseType(),
+		}
+		if def := d.GetDomainDefaultExpr(); def != "" {
+			if node.DomainDefault, err = parser.ParseExpr(def); err != nil {
+				return false, err
+			}
+		}
+		if d.IsNotNull() {
+			node.DomainConstraints = append(node.DomainConstraints, tree.DomainConstraint{NotNull: true})
+		}
+		for i := 0; i < d.NumChecks(); i++ {
+			check, err := parser.ParseExpr(d.GetCheckExpr(i))
+			if err != nil {
+				return false, err
+			}
+			node.DomainConstraints = append(node.DomainConstraints, tree.DomainConstraint{
+				Name:  tree.Name(d.GetCheckName(i)),
+				Check: check,
+			})
+		}
+		return true, addRow(
+			tree.NewDInt(tree.DInt(db.GetID())),  // database_id
+			tree.NewDString(db.GetName()),        // database_name
+			tree.NewDString(sc.GetName()),        // schema_name
+			tree.NewDInt(tree.DInt(d.GetID())),   // descriptor_id
+			tree.NewDString(d.GetName()),         // descriptor_name
+			tree.NewDString(tree.AsString(node)), // create_statement
+			tree.DNull,                           // enum_members
+		)
+	}
+	if r := typeDesc.AsRangeTypeDescriptor(); r != nil {
+		name, err := tree.NewUnresolvedObjectName(2, [3]string{r.GetName(), sc.GetName()}, 0)
+		if err != nil {
+			return false, err
+		}
+		node := &tree.CreateType{
+			Variety:      tree.Range,
+			TypeName:     name,
+			RangeSubtype: r.RangeSubtype(),
+		}
+		return true, addRow(
+			tree.NewDInt(tree.DInt(db.GetID())),  // database_id
+			tree.NewDString(db.GetName()),        // database_name
+			tree.NewDString(sc.GetName()),        // schema_name
+			tree.NewDInt(tree.DInt(r.GetID())),   // descriptor_id
+			tree.NewDString(r.GetName()),         // descriptor_name
+			tree.NewDString(tree.AsString(node)), // create_statement
+			tree.DNull,                           // enum_members
+		)
+	}
 	return false, errors.AssertionFailedf("unknown type descriptor kind %s", typeDesc.GetKind())
 }
 
@@ -3579,6 +3637,24 @@ func createRoutinePopulate(
 				// otherwise.
 				continue
 			}
+			if fnDesc.IsAggregate() {
+				aggNode, err := p.makeCreateAggregateExpr(ctx, fnDesc, fnIDToScName[fnDesc.GetID()])
+				if err != nil {
+					return err
+				}
+				if err := addRow(
+					tree.NewDInt(tree.DInt(fnIDToDBID[fnDesc.GetID()])), // database_id
+					tree.NewDString(fnIDToDBName[fnDesc.GetID()]),       // database_name
+					tree.NewDInt(tree.DInt(fnIDToScID[fnDesc.GetID()])), // schema_id
+					tree.NewDString(fnIDToScName[fnDesc.GetID()]),       // schema_name
+					tree.NewDInt(tree.DInt(fnDesc.GetID())),             // function_id
+					tree.NewDString(fnDesc.GetName()),                   // function_name
+					tree.NewDString(tree.AsString(aggNode)),             // create_statement
+				); err != nil {
+					return err
+				}
+				continue
+			}
 			treeNode, err := fnDesc.ToCreateExpr()
 			treeNode.Name.ObjectNamePrefix = tree.ObjectNamePrefix{
 				ExplicitSchema: true,
@@ -3678,6 +3754,7 @@ CREATE TABLE crdb_internal.create_procedure_statements (
 var typeView = tree.NewDString("view")
 var typeTable = tree.NewDString("table")
 var typeSequence = tree.NewDString("sequence")
+var typeForeignTable = tree.NewDString("foreign table")
 
 // crdbInternalCreateStmtsTable exposes the CREATE TABLE/CREATE VIEW
 // statements.
@@ -3739,6 +3816,17 @@ CREATE TABLE crdb_internal.create_statements (
 			descType = typeSequence
 			stmt, err = ShowCreateSequence(ctx, &name, table)
 			createRedactable = stmt
+		} else if table.IsForeignTable() {
+			descType = typeForeignTable
+			stmt, err = ShowCreateForeignTable(
+				ctx, &p.semaCtx, p.SessionData(), &name, table, false, /* redactableValues */
+			)
+			if err != nil {
+				return err
+			}
+			createRedactable, err = ShowCreateForeignTable(
+				ctx, &p.semaCtx, p.SessionData(), &name, table, true, /* redactableValues */
+			)
 		} else {
 			descType = typeTable
 			displayOptions := ShowCreateDisplayOptions{
@@ -4327,7 +4415,7 @@ CREATE TABLE crdb_internal.forward_dependencies (
 					)
 				}
 
-				if table.IsTable() || table.IsView() {
+				if table.IsTable() || table.IsView() || table.IsForeignTable() {
 					return table.ForeachDependedOnBy(func(dep *descpb.TableDescriptor_Reference) error {
 						return reportDependedOnBy(dep, viewDep)
 					})
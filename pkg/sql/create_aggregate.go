@@ -0,0 +1,580 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catprivilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemadesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/errors"
+)
+
+type createAggregateNode struct {
+	n      *tree.CreateAggregate
+	dbDesc catalog.DatabaseDescriptor
+	scDesc catalog.SchemaDescriptor
+}
+
+// aggregateDefinition contains the resolved options of a CREATE AGGREGATE
+// statement.
+type aggregateDefinition struct {
+	paramTypes  []*types.T
+	stateType   *types.T
+	stateFunc   *funcdesc.Mutable
+	finalFunc   *funcdesc.Mutable
+	combineFunc *funcdesc.Mutable
+	initCond    *string
+}
+
+// CreateAggregate creates a user-defined aggregate whose state transition,
+// final and combine functions are existing user-defined functions.
+func (p *planner) CreateAggregate(ctx context.Context, n *tree.CreateAggregate) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE AGGREGATE",
+	); err != nil {
+		return nil, err
+	}
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V24_1) {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"version %v must be finalized to create aggregates",
+			clusterversion.ByKey(clusterversion.V24_1))
+	}
+
+	un := n.Name.ToUnresolvedObjectName()
+	db, sc, prefix, err := p.ResolveTargetObject(ctx, un)
+	if err != nil {
+		return nil, err
+	}
+	if db.GetID() == keys.SystemDatabaseID {
+		return nil, errors.New("cannot create an aggregate in the system database")
+	}
+	if sc.SchemaKind() == catalog.SchemaTemporary {
+		return nil, unimplemented.NewWithIssue(104687, "cannot create UDFs under a temporary schema")
+	}
+	n.Name.ObjectNamePrefix = prefix
+	return &createAggregateNode{n: n, dbDesc: db, scDesc: sc}, nil
+}
+
+func (n *createAggregateNode) ReadingOwnWrites() {}
+
+func (n *createAggregateNode) startExec(params runParams) error {
+	if err := params.p.canCreateOnSchema(
+		params.ctx, n.scDesc.GetID(), n.dbDesc.GetID(), params.p.User(), skipCheckPublicSchema,
+	); err != nil {
+		return err
+	}
+
+	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("aggregate"))
+
+	mutScDesc, err := params.p.descCollection.MutableByName(params.p.Txn()).Schema(params.ctx, n.dbDesc, n.scDesc.GetName())
+	if err != nil {
+		return err
+	}
+
+	var retErr error
+	params.p.runWithOptions(resolveFlags{contextDatabaseID: n.dbDesc.GetID()}, func() {
+		retErr = func() error {
+			def, pbParams, err := n.resolveDefinition(params)
+			if err != nil {
+				return err
+			}
+			aggDesc, isNew, err := n.getMutableAggDesc(params, mutScDesc, def, pbParams)
+			if err != nil {
+				return err
+			}
+
+			aggName := tree.MakeQualifiedRoutineName(n.dbDesc.GetName(), n.scDesc.GetName(), n.n.Name.String())
+			event := eventpb.CreateFunction{
+				FunctionName: aggName.FQString(),
+				IsReplace:    !isNew,
+			}
+			if isNew {
+				err = n.createNewAggregate(params, aggDesc, mutScDesc, def)
+			} else {
+				err = n.replaceAggregate(params, aggDesc, def)
+			}
+			if err != nil {
+				return err
+			}
+			return params.p.logEvent(params.ctx, aggDesc.GetID(), &event)
+		}()
+	})
+	return retErr
+}
+
+func (*createAggregateNode) Next(params runParams) (bool, error) { return false, nil }
+func (*createAggregateNode) Values() tree.Datums                 { return tree.Datums{} }
+func (*createAggregateNode) Close(ctx context.Context)           {}
+
+// resolveDefinition resolves the parameters and the options of the aggregate.
+func (n *createAggregateNode) resolveDefinition(
+	params runParams,
+) (*aggregateDefinition, []descpb.FunctionDescriptor_Parameter, error) {
+	if len(n.n.Params) == 0 {
+		return nil, nil, unimplemented.New(
+			"zero-argument aggregates", "aggregates without arguments are not supported",
+		)
+	}
+	def := &aggregateDefinition{paramTypes: make([]*types.T, len(n.n.Params))}
+	pbParams := make([]descpb.FunctionDescriptor_Parameter, len(n.n.Params))
+	for i, param := range n.n.Params {
+		if param.Class.IsOutput() {
+			return nil, nil, pgerror.New(pgcode.InvalidFunctionDefinition, "aggregates cannot have output arguments")
+		}
+		if param.Class == tree.RoutineParamVariadic {
+			return nil, nil, unimplemented.New("variadic aggregates", "aggregates cannot have VARIADIC parameters")
+		}
+		pbParam, err := makeFunctionParam(params.ctx, param, params.p)
+		if err != nil {
+			return nil, nil, err
+		}
+		pbParams[i] = pbParam
+		def.paramTypes[i] = pbParam.Type
+	}
+
+	var stateFuncName, finalFuncName, combineFuncName *tree.RoutineName
+	seen := make(map[string]struct{}, len(n.n.Options))
+	for i := range n.n.Options {
+		opt := &n.n.Options[i]
+		name := string(opt.Name)
+		if _, ok := seen[name]; ok {
+			return nil, nil, tree.ErrConflictingRoutineOption
+		}
+		seen[name] = struct{}{}
+		var err error
+		switch name {
+		case "sfunc":
+			stateFuncName, err = aggregateOptionFuncName(opt)
+		case "finalfunc":
+			finalFuncName, err = aggregateOptionFuncName(opt)
+		case "combinefunc":
+			combineFuncName, err = aggregateOptionFuncName(opt)
+		case "stype":
+			if opt.Type == nil {
+				return nil, nil, pgerror.Newf(pgcode.Syntax, "aggregate attribute %q requires a type", name)
+			}
+			def.stateType, err = tree.ResolveType(params.ctx, opt.Type, params.p)
+		case "initcond":
+			var initCond string
+			switch v := opt.Value.(type) {
+			case *tree.StrVal:
+				initCond = v.RawString()
+			case *tree.NumVal:
+				initCond = v.String()
+			default:
+				return nil, nil, pgerror.Newf(pgcode.Syntax, "aggregate attribute %q requires a constant", name)
+			}
+			def.initCond = &initCond
+		default:
+			return nil, nil, pgerror.Newf(pgcode.InvalidFunctionDefinition,
+				"aggregate attribute %q not recognized", name)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	if stateFuncName == nil {
+		return nil, nil, pgerror.New(pgcode.InvalidFunctionDefinition, "aggregate sfunc must be specified")
+	}
+	if def.stateType == nil {
+		return nil, nil, pgerror.New(pgcode.InvalidFunctionDefinition, "aggregate stype must be specified")
+	}
+	if def.stateType.Family() == types.VoidFamily {
+		return nil, nil, pgerror.Newf(pgcode.InvalidFunctionDefinition,
+			"aggregate transition data type cannot be %s", def.stateType.SQLString())
+	}
+
+	var err error
+	stateFuncArgs := append([]*types.T{def.stateType}, def.paramTypes...)
+	if def.stateFunc, err = n.resolveSupportFunc(
+		params, "transition", stateFuncName, stateFuncArgs, def.stateType,
+	); err != nil {
+		return nil, nil, err
+	}
+	if finalFuncName != nil {
+		if def.finalFunc, err = n.resolveSupportFunc(
+			params, "final", finalFuncName, []*types.T{def.stateType}, nil, /* returnType */
+		); err != nil {
+			return nil, nil, err
+		}
+	}
+	if combineFuncName != nil {
+		if def.combineFunc, err = n.resolveSupportFunc(
+			params, "combine", combineFuncName, []*types.T{def.stateType, def.stateType}, def.stateType,
+		); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if def.initCond != nil {
+		// Make sure that the initial state can be converted to the state type.
+		if _, err := eval.PerformCast(
+			params.ctx, params.EvalContext(), tree.NewDString(*def.initCond), def.stateType,
+		); err != nil {
+			return nil, nil, errors.Wrapf(err, "invalid initial value for aggregate state")
+		}
+	} else if def.stateFunc.GetNullInputBehavior() != catpb.Function_CALLED_ON_NULL_INPUT &&
+		(len(def.paramTypes) != 1 || !def.paramTypes[0].Equivalent(def.stateType)) {
+		// The first input of a strict transition function becomes the initial
+		// state, so it must be of the state type.
+		return nil, nil, pgerror.New(pgcode.InvalidFunctionDefinition,
+			"must not omit initial value when transition function is strict and transition type is not compatible with input type")
+	}
+	return def, pbParams, nil
+}
+
+// aggregateOptionFuncName returns the name of the function specified by an
+// option like SFUNC.
+func aggregateOptionFuncName(opt *tree.AggregateOption) (*tree.RoutineName, error) {
+	un, ok := opt.Type.(*tree.UnresolvedObjectName)
+	if !ok {
+		return nil, pgerror.Newf(pgcode.Syntax,
+			"aggregate attribute %q requires a function name", string(opt.Name))
+	}
+	name := un.ToRoutineName()
+	return &name, nil
+}
+
+// resolveSupportFunc resolves a user-defined function which is used to compute
+// the aggregate. If returnType is non-nil, the function must return it.
+func (n *createAggregateNode) resolveSupportFunc(
+	params runParams,
+	kind string,
+	name *tree.RoutineName,
+	argTypes []*types.T,
+	returnType *types.T,
+) (*funcdesc.Mutable, error) {
+	p := params.p
+	path := p.CurrentSearchPath()
+	unresolvedName := name.ToUnresolvedObjectName().ToUnresolvedName()
+	fnDef, err := p.ResolveFunction(params.ctx, tree.MakeUnresolvedFunctionName(unresolvedName), &path)
+	if err != nil {
+		return nil, err
+	}
+	ol, err := fnDef.MatchOverload(argTypes, name.Schema(), &path, tree.UDFRoutine)
+	if err != nil {
+		return nil, err
+	}
+	if ol.Type == tree.BuiltinRoutine {
+		return nil, unimplemented.Newf("builtin aggregate support functions",
+			"%s function %s of an aggregate must be a user-defined function", kind, fnDef.Name)
+	}
+	fnDesc, err := p.Descriptors().MutableByID(p.Txn()).Function(params.ctx, funcdesc.UserDefinedFunctionOIDToID(ol.Oid))
+	if err != nil {
+		return nil, err
+	}
+	if fnDesc.GetParentID() != n.dbDesc.GetID() {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported, "the aggregate cannot refer to other databases")
+	}
+	if fnDesc.IsAggregate() || fnDesc.GetReturnType().ReturnSet {
+		return nil, pgerror.Newf(pgcode.InvalidFunctionDefinition,
+			"%s function %s of an aggregate must be a scalar function", kind, fnDesc.GetName())
+	}
+	if returnType != nil && !fnDesc.GetReturnType().Type.Equal(returnType) {
+		return nil, pgerror.Newf(pgcode.DatatypeMismatch,
+			"return type of %s function %s is not %s", kind, fnDesc.GetName(), returnType.SQLString())
+	}
+	if err := p.CheckPrivilege(params.ctx, fnDesc, privilege.EXECUTE); err != nil {
+		return nil, err
+	}
+	return fnDesc, nil
+}
+
+func (n *createAggregateNode) getMutableAggDesc(
+	params runParams,
+	scDesc catalog.SchemaDescriptor,
+	def *aggregateDefinition,
+	pbParams []descpb.FunctionDescriptor_Parameter,
+) (aggDesc *funcdesc.Mutable, isNew bool, err error) {
+	// Try to look up an existing routine.
+	routineObj := tree.RoutineObj{
+		FuncName: n.n.Name,
+		Params:   n.n.Params,
+	}
+	existing, err := params.p.matchRoutine(params.ctx, &routineObj,
+		false /* required */, tree.UDFRoutine|tree.ProcedureRoutine)
+	if err != nil {
+		return nil, false, err
+	}
+	if existing != nil {
+		if !n.n.Replace {
+			return nil, false, pgerror.Newf(
+				pgcode.DuplicateFunction,
+				"function %q already exists with same argument types",
+				n.n.Name.Object(),
+			)
+		}
+		aggDesc, err = params.p.checkPrivilegesForDropFunction(
+			params.ctx, funcdesc.UserDefinedFunctionOIDToID(existing.Oid),
+		)
+		if err != nil {
+			return nil, false, err
+		}
+		return aggDesc, false, nil
+	}
+
+	aggDescID, err := params.EvalContext().DescIDGenerator.GenerateUniqueDescID(params.ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	privileges, err := catprivilege.CreatePrivilegesFromDefaultPrivileges(
+		n.dbDesc.GetDefaultPrivilegeDescriptor(),
+		scDesc.GetDefaultPrivilegeDescriptor(),
+		n.dbDesc.GetID(),
+		params.SessionData().User(),
+		privilege.Routines,
+	)
+	if err != nil {
+		return nil, false, err
+	}
+	newAggDesc := funcdesc.NewMutableFunctionDescriptor(
+		aggDescID,
+		n.dbDesc.GetID(),
+		scDesc.GetID(),
+		string(n.n.Name.ObjectName),
+		pbParams,
+		def.resultType(),
+		false, /* returnSet */
+		false, /* isProcedure */
+		privileges,
+	)
+	return &newAggDesc, true, nil
+}
+
+func (n *createAggregateNode) createNewAggregate(
+	params runParams,
+	aggDesc *funcdesc.Mutable,
+	scDesc *schemadesc.Mutable,
+	def *aggregateDefinition,
+) error {
+	n.setAggregateDefinition(aggDesc, def)
+	if err := n.addAggregateReferences(params, aggDesc, def); err != nil {
+		return err
+	}
+	if err := params.p.createDescriptor(
+		params.ctx,
+		aggDesc,
+		tree.AsStringWithFQNames(&n.n.Name, params.Ann()),
+	); err != nil {
+		return err
+	}
+	scDesc.AddFunction(aggDesc.GetName(), aggDesc.ToSignature())
+	return params.p.writeSchemaDescChange(params.ctx, scDesc, "Create Aggregate")
+}
+
+func (n *createAggregateNode) replaceAggregate(
+	params runParams, aggDesc *funcdesc.Mutable, def *aggregateDefinition,
+) error {
+	if !aggDesc.IsAggregate() {
+		kind := "function"
+		if aggDesc.IsProcedure() {
+			kind = "procedure"
+		}
+		return errors.WithDetailf(
+			pgerror.Newf(pgcode.WrongObjectType, "cannot change routine kind"),
+			"%q is a %s", aggDesc.GetName(), kind,
+		)
+	}
+	if !def.resultType().Equal(aggDesc.GetReturnType().Type) {
+		return pgerror.Newf(pgcode.InvalidFunctionDefinition, "cannot change return type of existing function")
+	}
+
+	// Remove all existing references before adding the new ones.
+	if err := removeAggregateSupportFuncReferences(params.ctx, params.p, aggDesc); err != nil {
+		return err
+	}
+	jobDesc := fmt.Sprintf("updating type back reference %d for aggregate %d", aggDesc.DependsOnTypes, aggDesc.ID)
+	if err := params.p.removeTypeBackReferences(params.ctx, aggDesc.DependsOnTypes, aggDesc.ID, jobDesc); err != nil {
+		return err
+	}
+	n.setAggregateDefinition(aggDesc, def)
+	if err := n.addAggregateReferences(params, aggDesc, def); err != nil {
+		return err
+	}
+	return params.p.writeFuncSchemaChange(params.ctx, aggDesc)
+}
+
+// setAggregateDefinition sets the support functions, the state and the
+// properties derived from the support functions in the aggregate descriptor.
+func (n *createAggregateNode) setAggregateDefinition(
+	aggDesc *funcdesc.Mutable, def *aggregateDefinition,
+) {
+	agg := &descpb.FunctionDescriptor_Aggregate{
+		StateFuncID: def.stateFunc.GetID(),
+		StateType:   def.stateType,
+		InitCond:    def.initCond,
+	}
+	// The aggregate is as volatile as the most volatile of its support
+	// functions.
+	vol := catpb.Function_IMMUTABLE
+	for _, fn := range []*funcdesc.Mutable{def.stateFunc, def.finalFunc, def.combineFunc} {
+		if fn == nil {
+			continue
+		}
+		switch fn.GetVolatility() {
+		case catpb.Function_VOLATILE:
+			vol = catpb.Function_VOLATILE
+		case catpb.Function_STABLE:
+			if vol != catpb.Function_VOLATILE {
+				vol = catpb.Function_STABLE
+			}
+		}
+	}
+	if def.finalFunc != nil {
+		agg.FinalFuncID = def.finalFunc.GetID()
+	}
+	if def.combineFunc != nil {
+		agg.CombineFuncID = def.combineFunc.GetID()
+	}
+	resetFuncOption(aggDesc)
+	aggDesc.SetVolatility(vol)
+	aggDesc.SetLang(catpb.Function_SQL)
+	aggDesc.SetFuncBody("")
+	aggDesc.Aggregate = agg
+}
+
+// addAggregateReferences adds the references of the aggregate to the types it
+// uses, and the back references from its support functions.
+func (n *createAggregateNode) addAggregateReferences(
+	params runParams, aggDesc *funcdesc.Mutable, def *aggregateDefinition,
+) error {
+	typeDeps := make(typeDependencies)
+	for _, typ := range append([]*types.T{def.stateType, def.resultType()}, def.paramTypes...) {
+		typedesc.GetTypeDescriptorClosure(typ).ForEach(func(id descpb.ID) {
+			typeDeps[id] = struct{}{}
+		})
+	}
+	if err := addRoutineReferences(
+		params, aggDesc, n.n.Name.String(), nil /* planDeps */, typeDeps,
+	); err != nil {
+		return err
+	}
+
+	seen := make(map[descpb.ID]struct{}, 3)
+	for _, fn := range []*funcdesc.Mutable{def.stateFunc, def.finalFunc, def.combineFunc} {
+		if fn == nil {
+			continue
+		}
+		if _, ok := seen[fn.GetID()]; ok {
+			continue
+		}
+		seen[fn.GetID()] = struct{}{}
+		fn.DependedOnBy = append(fn.DependedOnBy, descpb.FunctionDescriptor_Reference{ID: aggDesc.GetID()})
+		if err := params.p.writeFuncSchemaChange(params.ctx, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeAggregateSupportFuncReferences removes the back references to the
+// aggregate from its support functions.
+func removeAggregateSupportFuncReferences(
+	ctx context.Context, p *planner, aggDesc *funcdesc.Mutable,
+) error {
+	agg := aggDesc.Aggregate
+	if agg == nil {
+		return nil
+	}
+	ids := catalog.MakeDescriptorIDSet(agg.StateFuncID, agg.FinalFuncID, agg.CombineFuncID)
+	ids.Remove(descpb.InvalidID)
+	for _, id := range ids.Ordered() {
+		fn, err := p.Descriptors().MutableByID(p.Txn()).Function(ctx, id)
+		if err != nil {
+			return err
+		}
+		if fn.Dropped() {
+			continue
+		}
+		fn.RemoveReference(aggDesc.GetID())
+		if err := p.writeFuncSchemaChange(ctx, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resultType returns the result type of the aggregate, which is the return
+// type of the final function, or the state type if there is none.
+func (def *aggregateDefinition) resultType() *types.T {
+	if def.finalFunc != nil {
+		return def.finalFunc.GetReturnType().Type
+	}
+	return def.stateType
+}
+
+// makeCreateAggregateExpr returns a CREATE AGGREGATE statement which defines
+// the given aggregate.
+func (p *planner) makeCreateAggregateExpr(
+	ctx context.Context, aggDesc catalog.FunctionDescriptor, scName string,
+) (*tree.CreateAggregate, error) {
+	agg := aggDesc.FuncDesc().Aggregate
+	ret := &tree.CreateAggregate{
+		Name: tree.MakeRoutineNameFromPrefix(
+			tree.ObjectNamePrefix{SchemaName: tree.Name(scName), ExplicitSchema: true},
+			tree.Name(aggDesc.GetName()),
+		),
+	}
+	for _, param := range aggDesc.GetParams() {
+		ret.Params = append(ret.Params, tree.RoutineParam{Name: tree.Name(param.Name), Type: param.Type})
+	}
+	addFuncOption := func(name tree.Name, id descpb.ID) error {
+		if id == descpb.InvalidID {
+			return nil
+		}
+		fnDesc, err := p.Descriptors().ByIDWithLeased(p.Txn()).WithoutNonPublic().Get().Function(ctx, id)
+		if err != nil {
+			return err
+		}
+		fnName, err := p.getQualifiedFunctionName(ctx, fnDesc)
+		if err != nil {
+			return err
+		}
+		fnName.ExplicitCatalog = false
+		ret.Options = append(ret.Options, tree.AggregateOption{Name: name, Type: fnName.ToUnresolvedObjectName()})
+		return nil
+	}
+	if err := addFuncOption("sfunc", agg.StateFuncID); err != nil {
+		return nil, err
+	}
+	ret.Options = append(ret.Options, tree.AggregateOption{Name: "stype", Type: agg.StateType})
+	if err := addFuncOption("finalfunc", agg.FinalFuncID); err != nil {
+		return nil, err
+	}
+	if err := addFuncOption("combinefunc", agg.CombineFuncID); err != nil {
+		return nil, err
+	}
+	if agg.InitCond != nil {
+		ret.Options = append(ret.Options, tree.AggregateOption{Name: "initcond", Value: tree.NewStrVal(*agg.InitCond)})
+	}
+	return ret, nil
+}
@@ -13,6 +13,7 @@ package sql
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
@@ -24,9 +25,12 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemadesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/paramparse"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
@@ -39,10 +43,11 @@ import (
 type createFunctionNode struct {
 	cf *tree.CreateRoutine
 
-	dbDesc   catalog.DatabaseDescriptor
-	scDesc   catalog.SchemaDescriptor
-	planDeps planDependencies
-	typeDeps typeDependencies
+	dbDesc         catalog.DatabaseDescriptor
+	scDesc         catalog.SchemaDescriptor
+	planDeps       planDependencies
+	typeDeps       typeDependencies
+	textSearchDeps opt.SchemaTextSearchDeps
 }
 
 func (n *createFunctionNode) ReadingOwnWrites() {}
@@ -122,11 +127,16 @@ func (n *createFunctionNode) createNewFunction(
 		return err
 	}
 
-	if err := n.addUDFReferences(udfDesc, params); err != nil {
+	if err := addRoutineReferences(params, udfDesc, n.cf.Name.String(), n.planDeps, n.typeDeps); err != nil {
 		return err
 	}
+	textSearchRefs, err := params.p.resolveTextSearchDeps(params.ctx, n.textSearchDeps)
+	if err != nil {
+		return err
+	}
+	udfDesc.DependsOnTextSearchObjects = textSearchRefs
 
-	err := params.p.createDescriptor(
+	err = params.p.createDescriptor(
 		params.ctx,
 		udfDesc,
 		tree.AsStringWithFQNames(&n.cf.Name, params.Ann()),
@@ -135,24 +145,7 @@ func (n *createFunctionNode) createNewFunction(
 		return err
 	}
 
-	returnType, err := tree.ResolveType(params.ctx, n.cf.ReturnType.Type, params.p)
-	if err != nil {
-		return err
-	}
-	paramTypes := make([]*types.T, len(udfDesc.Params))
-	for i, param := range udfDesc.Params {
-		paramTypes[i] = param.Type
-	}
-	scDesc.AddFunction(
-		udfDesc.GetName(),
-		descpb.SchemaDescriptor_FunctionSignature{
-			ID:          udfDesc.GetID(),
-			ArgTypes:    paramTypes,
-			ReturnType:  returnType,
-			ReturnSet:   udfDesc.ReturnType.ReturnSet,
-			IsProcedure: udfDesc.IsProcedure(),
-		},
-	)
+	scDesc.AddFunction(udfDesc.GetName(), udfDesc.ToSignature())
 	if err := params.p.writeSchemaDescChange(params.ctx, scDesc, "Create Function"); err != nil {
 		return err
 	}
@@ -164,6 +157,14 @@ func (n *createFunctionNode) replaceFunction(udfDesc *funcdesc.Mutable, params r
 	// TODO(chengxiong): add validation that the function is not referenced. This
 	// is needed when we start allowing function references from other objects.
 
+	if udfDesc.IsAggregate() {
+		return errors.WithDetailf(
+			pgerror.Newf(pgcode.WrongObjectType, "cannot change routine kind"),
+			"%q is an aggregate function",
+			udfDesc.Name,
+		)
+	}
+
 	if n.cf.IsProcedure && !udfDesc.IsProcedure() {
 		return errors.WithDetailf(
 			pgerror.Newf(pgcode.WrongObjectType, "cannot change routine kind"),
@@ -180,12 +181,17 @@ func (n *createFunctionNode) replaceFunction(udfDesc *funcdesc.Mutable, params r
 		)
 	}
 
-	// Make sure parameter names are not changed.
-	for i := range n.cf.Params {
-		if string(n.cf.Params[i].Name) != udfDesc.Params[i].Name {
-			return pgerror.Newf(
-				pgcode.InvalidFunctionDefinition, "cannot change name of input parameter %q", udfDesc.Params[i].Name,
-			)
+	// Make sure parameter names are not changed. The OUT parameters of a
+	// function are not part of its signature, so the parameters may not line up
+	// if they are changed. In that case the return type is changed, which is
+	// disallowed below.
+	if len(n.cf.Params) == len(udfDesc.Params) {
+		for i := range n.cf.Params {
+			if string(n.cf.Params[i].Name) != udfDesc.Params[i].Name {
+				return pgerror.Newf(
+					pgcode.InvalidFunctionDefinition, "cannot change name of input parameter %q", udfDesc.Params[i].Name,
+				)
+			}
 		}
 	}
 
@@ -233,9 +239,14 @@ func (n *createFunctionNode) replaceFunction(udfDesc *funcdesc.Mutable, params r
 		return err
 	}
 	// Add all new references.
-	if err := n.addUDFReferences(udfDesc, params); err != nil {
+	if err := addRoutineReferences(params, udfDesc, n.cf.Name.String(), n.planDeps, n.typeDeps); err != nil {
 		return err
 	}
+	textSearchRefs, err := params.p.resolveTextSearchDeps(params.ctx, n.textSearchDeps)
+	if err != nil {
+		return err
+	}
+	udfDesc.DependsOnTextSearchObjects = textSearchRefs
 
 	return params.p.writeFuncSchemaChange(params.ctx, udfDesc)
 }
@@ -268,7 +279,7 @@ func (n *createFunctionNode) getMutableFuncDesc(
 	// Try to look up an existing function.
 	routineObj := tree.RoutineObj{
 		FuncName: n.cf.Name,
-		Params:   n.cf.Params,
+		Params:   n.cf.InputParams(),
 	}
 	existing, err := params.p.matchRoutine(params.ctx, &routineObj,
 		false /* required */, tree.UDFRoutine|tree.ProcedureRoutine)
@@ -329,15 +340,23 @@ func (n *createFunctionNode) getMutableFuncDesc(
 	return &newUdfDesc, true, nil
 }
 
-func (n *createFunctionNode) addUDFReferences(udfDesc *funcdesc.Mutable, params runParams) error {
+// addRoutineReferences adds the references of a routine to the relations and
+// types it depends on, along with the corresponding back references.
+func addRoutineReferences(
+	params runParams,
+	udfDesc *funcdesc.Mutable,
+	name string,
+	planDeps planDependencies,
+	typeDeps typeDependencies,
+) error {
 	// Get all table IDs for which we need to update back references, including
 	// tables used directly in function body or as implicit types.
 	backrefTblIDs := catalog.DescriptorIDSet{}
 	implicitTypeTblIDs := catalog.DescriptorIDSet{}
-	for id := range n.planDeps {
+	for id := range planDeps {
 		backrefTblIDs.Add(id)
 	}
-	for id := range n.typeDeps {
+	for id := range typeDeps {
 		if isTable, err := params.p.descIsTable(params.ctx, id); err != nil {
 			return err
 		} else if isTable {
@@ -361,7 +380,7 @@ func (n *createFunctionNode) addUDFReferences(udfDesc *funcdesc.Mutable, params
 		backRefMutables[id] = backRefMutable
 	}
 
-	for id, updated := range n.planDeps {
+	for id, updated := range planDeps {
 		backRefMutable := backRefMutables[id]
 		for _, dep := range updated.deps {
 			dep.ID = udfDesc.ID
@@ -374,7 +393,7 @@ func (n *createFunctionNode) addUDFReferences(udfDesc *funcdesc.Mutable, params
 			backRefMutable,
 			descpb.InvalidMutationID,
 			fmt.Sprintf("updating udf reference %q in table %s(%d)",
-				n.cf.Name.String(), updated.desc.GetName(), updated.desc.GetID(),
+				name, updated.desc.GetName(), updated.desc.GetID(),
 			),
 		); err != nil {
 			return err
@@ -388,7 +407,7 @@ func (n *createFunctionNode) addUDFReferences(udfDesc *funcdesc.Mutable, params
 			backRefMutable,
 			descpb.InvalidMutationID,
 			fmt.Sprintf("updating udf reference %q in table %s(%d)",
-				n.cf.Name.String(), backRefMutable.GetName(), backRefMutable.GetID(),
+				name, backRefMutable.GetName(), backRefMutable.GetID(),
 			),
 		); err != nil {
 			return err
@@ -397,7 +416,7 @@ func (n *createFunctionNode) addUDFReferences(udfDesc *funcdesc.Mutable, params
 
 	// Add type back references. Skip table implicit types (we update table back
 	// references above).
-	for id := range n.typeDeps {
+	for id := range typeDeps {
 		if implicitTypeTblIDs.Contains(id) {
 			continue
 		}
@@ -411,7 +430,7 @@ func (n *createFunctionNode) addUDFReferences(udfDesc *funcdesc.Mutable, params
 	udfDesc.DependsOn = backrefTblIDs.Ordered()
 
 	typeDepIDs := catalog.DescriptorIDSet{}
-	for id := range n.typeDeps {
+	for id := range typeDeps {
 		typeDepIDs.Add(id)
 	}
 	udfDesc.DependsOnTypes = typeDepIDs.Difference(implicitTypeTblIDs).Ordered()
@@ -446,6 +465,16 @@ func setFuncOptions(
 				return err
 			}
 			udfDesc.SetLang(lang)
+		case tree.RoutineSecurity:
+			sec, err := funcinfo.SecurityToProto(t)
+			if err != nil {
+				return err
+			}
+			udfDesc.SetSecurity(sec)
+		case tree.RoutineSet:
+			if err := params.p.setFuncConfigVar(params.ctx, udfDesc, t); err != nil {
+				return err
+			}
 		case tree.RoutineBodyStr:
 			// Handle the body after the loop, since we don't yet know what language
 			// it is.
@@ -478,11 +507,83 @@ func setFuncOptions(
 	return nil
 }
 
+// setFuncConfigVar applies a SET or RESET option of a function to the session
+// variables that are set while the function executes. The value is validated
+// and stored in the form that is shown by SHOW.
+func (p *planner) setFuncConfigVar(
+	ctx context.Context, udfDesc *funcdesc.Mutable, opt tree.RoutineSet,
+) error {
+	if opt.ResetAll {
+		udfDesc.ResetConfig()
+		return nil
+	}
+	name := strings.ToLower(opt.Name)
+	_, v, err := getSessionVar(name, false /* missingOk */)
+	if err != nil {
+		return err
+	}
+	if opt.Reset {
+		udfDesc.ResetConfigVar(name)
+		return nil
+	}
+	if len(opt.Values) == 1 {
+		if _, ok := opt.Values[0].(tree.DefaultVal); ok {
+			// "SET var = DEFAULT" means RESET.
+			udfDesc.ResetConfigVar(name)
+			return nil
+		}
+	}
+	// Only variables that can be set without access to the planner can be set
+	// while a function executes.
+	if v.Set == nil {
+		return newCannotChangeParameterError(name)
+	}
+
+	var strVal string
+	if opt.FromCurrent {
+		if strVal, err = v.Get(&p.extendedEvalCtx, p.Txn()); err != nil {
+			return err
+		}
+	} else {
+		values := make([]tree.TypedExpr, len(opt.Values))
+		for i, expr := range opt.Values {
+			expr = paramparse.UnresolvedNameToStrVal(expr)
+			var dummyHelper tree.IndexedVarHelper
+			typedValue, err := p.analyzeExpr(
+				ctx, expr, nil, dummyHelper, types.String, false, "SET "+name)
+			if err != nil {
+				return wrapSetVarError(err, name, expr.String())
+			}
+			if values[i], err = eval.Expr(ctx, p.EvalContext(), typedValue); err != nil {
+				return err
+			}
+		}
+		if v.GetStringVal != nil {
+			strVal, err = v.GetStringVal(ctx, &p.extendedEvalCtx, values, p.Txn())
+		} else {
+			strVal, err = getStringVal(ctx, p.EvalContext(), name, values)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	// Validate the value by applying it to a copy of the session data.
+	m := p.sessionDataMutatorIterator.mutator(false /* applyCallbacks */, p.SessionData().Clone())
+	if err := v.Set(ctx, m, strVal); err != nil {
+		return err
+	}
+	udfDesc.SetConfigVar(name, strVal)
+	return nil
+}
+
 // resetFuncOption sets all function options to default values.
 func resetFuncOption(udfDesc *funcdesc.Mutable) {
 	udfDesc.SetVolatility(catpb.Function_VOLATILE)
 	udfDesc.SetNullInputBehavior(catpb.Function_CALLED_ON_NULL_INPUT)
 	udfDesc.SetLeakProof(false)
+	udfDesc.SetSecurity(catpb.Function_INVOKER)
+	udfDesc.ResetConfig()
 }
 
 func makeFunctionParam(
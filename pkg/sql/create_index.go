@@ -20,6 +20,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catenumpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
@@ -180,6 +181,14 @@ func makeIndexDescriptor(
 	); err != nil {
 		return nil, err
 	}
+	for _, col := range tableDesc.AllColumns() {
+		if !col.Adding() || !col.IsExpressionIndexColumn() {
+			continue
+		}
+		if err := params.p.updateTextSearchRefsForColumn(params.ctx, col.ColumnDesc()); err != nil {
+			return nil, err
+		}
+	}
 
 	// Ensure that the columns we want to index exist before trying to create the
 	// index.
@@ -318,6 +327,22 @@ func makeIndexDescriptor(
 	return &indexDesc, nil
 }
 
+// checkIndexNullsOrders returns an error if an index element places NULLs
+// differently from the default before all nodes can encode the corresponding
+// index keys.
+func checkIndexNullsOrders(columns tree.IndexElemList, version clusterversion.ClusterVersion) error {
+	if version.IsActive(clusterversion.V24_1) {
+		return nil
+	}
+	for _, c := range columns {
+		if descpb.IndexColumnNullsOrder(c.Direction, c.NullsOrder) != catenumpb.IndexColumn_NULLS_DEFAULT {
+			return pgerror.New(pgcode.FeatureNotSupported,
+				"non-default NULLS FIRST or NULLS LAST options in indexes are not supported until version 24.1")
+		}
+	}
+	return nil
+}
+
 func checkIndexColumns(
 	desc catalog.TableDescriptor,
 	columns tree.IndexElemList,
@@ -325,6 +350,9 @@ func checkIndexColumns(
 	inverted bool,
 	version clusterversion.ClusterVersion,
 ) error {
+	if err := checkIndexNullsOrders(columns, version); err != nil {
+		return err
+	}
 	for i, colDef := range columns {
 		lastCol := i == len(columns)-1
 		col, err := catalog.MustFindColumnByTreeName(desc, colDef.Column)
@@ -437,6 +465,18 @@ func populateInvertedIndexDescriptor(
 		default:
 			return newUndefinedOpclassError(invCol.OpClass)
 		}
+	case types.RangeFamily:
+		switch invCol.OpClass {
+		case "range_ops", "":
+		default:
+			return newUndefinedOpclassError(invCol.OpClass)
+		}
+	case types.MultirangeFamily:
+		switch invCol.OpClass {
+		case "multirange_ops", "":
+		default:
+			return newUndefinedOpclassError(invCol.OpClass)
+		}
 	default:
 		return tabledesc.NewInvalidInvertedColumnError(column.GetName(), column.GetType().Name())
 	}
@@ -197,6 +197,8 @@ func getSchemaForCreateTable(
 					mismatchedType = !tableDescriptor.IsView()
 				case tree.ResolveRequireSequenceDesc:
 					mismatchedType = !tableDescriptor.IsSequence()
+				case tree.ResolveRequireForeignTableDesc:
+					mismatchedType = !tableDescriptor.IsForeignTable()
 				}
 				// If kind any is passed then there will never be a mismatch
 				// and we can return an exists error.
@@ -437,6 +439,20 @@ func (n *createTableNode) startExec(params runParams) error {
 		}
 	}
 
+	// Record the text search dictionaries and configurations used by check
+	// constraints and columns so that they cannot be dropped out from under
+	// the table.
+	for _, ck := range desc.CheckConstraints() {
+		if err := params.p.updateTextSearchRefsForCheck(params.ctx, ck.CheckDesc()); err != nil {
+			return err
+		}
+	}
+	for i := range desc.Columns {
+		if err := params.p.updateTextSearchRefsForColumn(params.ctx, &desc.Columns[i]); err != nil {
+			return err
+		}
+	}
+
 	// Descriptor written to store here.
 	if err := params.p.createDescriptor(
 		params.ctx,
@@ -672,6 +688,7 @@ func addUniqueWithoutIndexColumnTableDef(
 		string(d.Unique.ConstraintName),
 		[]string{string(d.Name)},
 		"", /* predicate */
+		tree.ConstraintDeferrability{},
 		ts,
 		validationBehavior,
 	); err != nil {
@@ -716,6 +733,9 @@ func addUniqueWithoutIndexTableDef(
 			"creating a unique constraint using UNIQUE WITH NOT VISIBLE INDEX is not supported",
 		)
 	}
+	if err := checkDeferrableConstraintSupported(ctx, evalCtx, d.Deferrability); err != nil {
+		return err
+	}
 
 	// If there is a predicate, validate it.
 	var predicate string
@@ -735,13 +755,26 @@ func addUniqueWithoutIndexTableDef(
 		colNames[i] = string(d.Columns[i].Column)
 	}
 	if err := ResolveUniqueWithoutIndexConstraint(
-		ctx, desc, string(d.Name), colNames, predicate, ts, validationBehavior,
+		ctx, desc, string(d.Name), colNames, predicate, d.Deferrability, ts, validationBehavior,
 	); err != nil {
 		return err
 	}
 	return nil
 }
 
+// checkDeferrableConstraintSupported returns an error if a deferrable
+// constraint is created before the cluster is upgraded to 24.1, since older
+// nodes would check it immediately.
+func checkDeferrableConstraintSupported(
+	ctx context.Context, evalCtx *eval.Context, d tree.ConstraintDeferrability,
+) error {
+	if d.Deferrable && !evalCtx.Settings.Version.IsActive(ctx, clusterversion.V24_1) {
+		return pgerror.New(pgcode.FeatureNotSupported,
+			"deferrable constraints are not supported until version 24.1")
+	}
+	return nil
+}
+
 // ResolveUniqueWithoutIndexConstraint looks up the columns mentioned in a
 // UNIQUE WITHOUT INDEX constraint and adds metadata representing that
 // constraint to the descriptor.
@@ -755,6 +788,7 @@ func ResolveUniqueWithoutIndexConstraint(
 	constraintName string,
 	colNames []string,
 	predicate string,
+	deferrability tree.ConstraintDeferrability,
 	ts TableState,
 	validationBehavior tree.ValidationBehavior,
 ) error {
@@ -803,12 +837,14 @@ func ResolveUniqueWithoutIndexConstraint(
 	}
 
 	uc := descpb.UniqueWithoutIndexConstraint{
-		Name:         constraintName,
-		TableID:      tbl.ID,
-		ColumnIDs:    columnIDs,
-		Predicate:    predicate,
-		Validity:     validity,
-		ConstraintID: tbl.NextConstraintID,
+		Name:              constraintName,
+		TableID:           tbl.ID,
+		ColumnIDs:         columnIDs,
+		Predicate:         predicate,
+		Validity:          validity,
+		ConstraintID:      tbl.NextConstraintID,
+		Deferrable:        deferrability.Deferrable,
+		InitiallyDeferred: deferrability.InitiallyDeferred,
 	}
 	tbl.NextConstraintID++
 	if ts == NewTable {
@@ -820,6 +856,216 @@ func ResolveUniqueWithoutIndexConstraint(
 	return nil
 }
 
+// exclusionConstraintName returns the name of the given exclusion constraint,
+// which is also the name of its supporting index. If the constraint is not
+// named, a name is generated following the Postgres convention.
+func exclusionConstraintName(desc *tabledesc.Mutable, d *tree.ExclusionConstraintTableDef) string {
+	if d.Name != "" {
+		return string(d.Name)
+	}
+	var colNames []string
+	for i := range d.Elems {
+		colNames = append(colNames, d.Elems[i].Columns.ToStrings()...)
+	}
+	return tabledesc.GenerateUniqueName(
+		fmt.Sprintf("%s_%s_excl", desc.GetName(), strings.Join(colNames, "_")),
+		func(p string) bool {
+			return catalog.FindConstraintByName(desc, p) != nil || catalog.FindIndexByName(desc, p) != nil
+		},
+	)
+}
+
+// addExclusionConstraintTableDef runs various checks on the given
+// ExclusionConstraintTableDef before adding it as an exclusion constraint
+// with the given name to the given table descriptor. Exclusion constraints
+// are represented as UNIQUE WITHOUT INDEX constraints with exclusion elements,
+// and are enforced by the same checks in the mutation path. The supporting
+// index must have been built by makeExclusionConstraintIndex beforehand,
+// which also validates the elements of the constraint.
+func addExclusionConstraintTableDef(
+	ctx context.Context,
+	evalCtx *eval.Context,
+	d *tree.ExclusionConstraintTableDef,
+	constraintName string,
+	desc *tabledesc.Mutable,
+	tn tree.TableName,
+	ts TableState,
+	validationBehavior tree.ValidationBehavior,
+	semaCtx *tree.SemaContext,
+) error {
+	// Like Postgres, existing rows are always validated.
+	if validationBehavior == tree.ValidationSkip {
+		return pgerror.New(pgcode.FeatureNotSupported,
+			"EXCLUDE constraints cannot be marked NOT VALID")
+	}
+
+	// If there is a predicate, validate it.
+	var predicate string
+	if d.Predicate != nil {
+		var err error
+		predicate, err = schemaexpr.ValidateUniqueWithoutIndexPredicate(
+			ctx, tn, desc, d.Predicate, semaCtx, evalCtx.Settings.Version.ActiveVersionOrEmpty(ctx),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	var colSet catalog.TableColSet
+	hasOverlaps := false
+	elems := make([]descpb.ExclusionElement, len(d.Elems))
+	for i := range d.Elems {
+		elem := &d.Elems[i]
+		for _, name := range elem.Columns {
+			col, err := desc.FindActiveOrNewColumnByName(name)
+			if err != nil {
+				return err
+			}
+			colSet.Add(col.GetID())
+			elems[i].ColumnIDs = append(elems[i].ColumnIDs, col.GetID())
+		}
+		elems[i].Operator = elem.Operator.String()
+		hasOverlaps = hasOverlaps || elem.Operator.Symbol == treecmp.Overlaps
+	}
+
+	// Verify we are not writing a constraint over the same name.
+	if c := catalog.FindConstraintByName(desc, constraintName); c != nil {
+		return pgerror.Newf(pgcode.DuplicateObject, "duplicate constraint name: %q", constraintName)
+	}
+
+	// Without an explicit access method, display the constraint with the one
+	// Postgres would need to support its operators.
+	method := d.Using
+	if method == "" {
+		method = "btree"
+		if hasOverlaps {
+			method = "gist"
+		}
+	}
+
+	validity := descpb.ConstraintValidity_Validated
+	if ts != NewTable {
+		validity = descpb.ConstraintValidity_Validating
+	}
+
+	uc := descpb.UniqueWithoutIndexConstraint{
+		Name:              constraintName,
+		TableID:           desc.ID,
+		ColumnIDs:         colSet.Ordered(),
+		Predicate:         predicate,
+		Validity:          validity,
+		ConstraintID:      desc.NextConstraintID,
+		ExclusionElements: elems,
+		ExclusionMethod:   method,
+	}
+	desc.NextConstraintID++
+	if ts == NewTable {
+		desc.UniqueWithoutIndexConstraints = append(desc.UniqueWithoutIndexConstraints, uc)
+	} else {
+		desc.AddUniqueWithoutIndexMutation(&uc, descpb.DescriptorMutation_ADD)
+	}
+	return nil
+}
+
+// checkExclusionElementType returns an error if the operator of the given
+// exclusion constraint element cannot compare values of the given type.
+// Periods are compared with overlaps(), which is only defined for date and
+// time types.
+func checkExclusionElementType(elem *tree.ExclusionElem, typ *types.T) error {
+	if len(elem.Columns) == 2 {
+		switch typ.Family() {
+		case types.TimestampFamily, types.TimestampTZFamily, types.DateFamily,
+			types.TimeFamily, types.TimeTZFamily:
+			return nil
+		}
+		return pgerror.Newf(pgcode.DatatypeMismatch,
+			"periods in exclusion constraints must have a date or time type, not %s", typ.SQLString())
+	}
+	if _, ok := tree.CmpOps[elem.Operator.Symbol].LookupImpl(typ, typ); !ok {
+		return pgerror.Newf(pgcode.UndefinedFunction,
+			"unsupported comparison operator in exclusion constraint: <%s> %s <%s>",
+			typ, elem.Operator, typ)
+	}
+	return nil
+}
+
+// makeExclusionConstraintIndex validates the elements of the given exclusion
+// constraint, and returns the descriptor of the secondary index that supports
+// its checks, which has the same name as the constraint. Its key columns are
+// the columns of the constraint elements, in order. If the last element
+// compares spatial values with &&, the index is inverted on that column.
+func makeExclusionConstraintIndex(
+	ctx context.Context,
+	st *cluster.Settings,
+	desc *tabledesc.Mutable,
+	d *tree.ExclusionConstraintTableDef,
+	name string,
+	version clusterversion.ClusterVersion,
+) (descpb.IndexDescriptor, error) {
+	idx := descpb.IndexDescriptor{Name: name}
+	if !version.IsActive(clusterversion.V24_1) {
+		return idx, pgerror.New(pgcode.FeatureNotSupported,
+			"exclusion constraints are not supported until version 24.1")
+	}
+	if desc.PartitionAllBy {
+		return idx, unimplemented.NewWithIssuef(46657,
+			"exclusion constraints on implicitly partitioned tables are not supported")
+	}
+	var columns tree.IndexElemList
+	var invertedCol catalog.Column
+	for i := range d.Elems {
+		elem := &d.Elems[i]
+		var typ *types.T
+		for _, name := range elem.Columns {
+			col, err := desc.FindActiveOrNewColumnByName(name)
+			if err != nil {
+				return idx, err
+			}
+			// Ensure that the columns don't have duplicates. Column IDs may not
+			// be allocated yet, so the columns are compared by name.
+			for j := range columns {
+				if columns[j].Column == name {
+					return idx, pgerror.Newf(pgcode.DuplicateColumn,
+						"column %q appears twice in exclusion constraint", col.GetName())
+				}
+			}
+			if typ != nil && !typ.Identical(col.GetType()) {
+				return idx, pgerror.Newf(pgcode.DatatypeMismatch,
+					"start and end columns of a period in an exclusion constraint must have the same type")
+			}
+			typ = col.GetType()
+			family := typ.Family()
+			if elem.Operator.Symbol == treecmp.Overlaps &&
+				(family == types.GeometryFamily || family == types.GeographyFamily) {
+				if i != len(d.Elems)-1 {
+					return idx, unimplemented.NewWithIssuef(46657,
+						"exclusion constraints with a spatial element that is not the last element are not supported")
+				}
+				invertedCol = col
+			}
+			columns = append(columns, tree.IndexElem{Column: name, Direction: tree.Ascending})
+		}
+		if err := checkExclusionElementType(elem, typ); err != nil {
+			return idx, err
+		}
+	}
+	if err := checkIndexColumns(desc, columns, nil /* storing */, invertedCol != nil, version); err != nil {
+		return idx, err
+	}
+	if err := idx.FillColumns(columns); err != nil {
+		return idx, err
+	}
+	if invertedCol != nil {
+		idx.Type = descpb.IndexDescriptor_INVERTED
+		if err := populateInvertedIndexDescriptor(
+			ctx, st, invertedCol, &idx, columns[len(columns)-1],
+		); err != nil {
+			return idx, err
+		}
+	}
+	return idx, nil
+}
+
 // ResolveFK looks up the tables and columns mentioned in a `REFERENCES`
 // constraint and adds metadata representing that constraint to the descriptor.
 // It may, in doing so, add to or alter descriptors in the passed in `backrefs`
@@ -877,6 +1123,10 @@ func ResolveFK(
 		originCols[i] = col
 	}
 
+	if err := checkDeferrableConstraintSupported(ctx, evalCtx, d.Deferrability); err != nil {
+		return err
+	}
+
 	_, target, err := resolver.ResolveMutableExistingTableObject(ctx, sc, &d.Table, true /*required*/, tree.ResolveRequireTableDesc)
 	if err != nil {
 		return err
@@ -1053,6 +1303,8 @@ func ResolveFK(
 		OnUpdate:            tree.ForeignKeyReferenceActionValue[d.Actions.Update],
 		Match:               tree.CompositeKeyMatchMethodValue[d.Match],
 		ConstraintID:        tbl.NextConstraintID,
+		Deferrable:          d.Deferrability.Deferrable,
+		InitiallyDeferred:   d.Deferrability.InitiallyDeferred,
 	}
 	tbl.NextConstraintID++
 	if ts == NewTable {
@@ -1797,6 +2049,9 @@ func NewTableDesc(
 		}
 	}
 
+	// exclusionConstraintNames maps each exclusion constraint to the name of
+	// its supporting index, which the constraint shares.
+	exclusionConstraintNames := make(map[*tree.ExclusionConstraintTableDef]string)
 	for _, def := range n.Defs {
 		switch d := def.(type) {
 		case *tree.ColumnTableDef, *tree.LikeTableDef:
@@ -2040,6 +2295,22 @@ func NewTableDesc(
 					return nil, err
 				}
 			}
+		case *tree.ExclusionConstraintTableDef:
+			// The exclusion constraint is added below, but its supporting index
+			// must be added before IDs are allocated.
+			name := exclusionConstraintName(&desc, d)
+			if idx := catalog.FindIndexByName(&desc, name); idx != nil {
+				return nil, pgerror.Newf(pgcode.DuplicateRelation, "duplicate index name: %q", name)
+			}
+			exclusionConstraintNames[d] = name
+			idx, err := makeExclusionConstraintIndex(ctx, st, &desc, d, name, version)
+			if err != nil {
+				return nil, err
+			}
+			idx.Version = indexEncodingVersion
+			if err := desc.AddSecondaryIndex(idx); err != nil {
+				return nil, err
+			}
 		case *tree.CheckConstraintTableDef, *tree.ForeignKeyConstraintTableDef, *tree.FamilyTableDef:
 			// pass, handled below.
 
@@ -2208,6 +2479,14 @@ func NewTableDesc(
 				}
 			}
 
+		case *tree.ExclusionConstraintTableDef:
+			if err := addExclusionConstraintTableDef(
+				ctx, evalCtx, d, exclusionConstraintNames[d], &desc, n.Table, NewTable,
+				tree.ValidationDefault, semaCtx,
+			); err != nil {
+				return nil, err
+			}
+
 		case *tree.IndexTableDef, *tree.FamilyTableDef, *tree.LikeTableDef:
 			// Pass, handled above.
 
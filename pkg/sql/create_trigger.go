@@ -0,0 +1,339 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/errors"
+)
+
+type createTriggerNode struct {
+	n         *tree.CreateTrigger
+	tableDesc *tabledesc.Mutable
+	funcDesc  *funcdesc.Mutable
+}
+
+// CreateTrigger creates a row-level trigger on a table.
+// Privileges: CREATE on table and EXECUTE on the trigger function.
+func (p *planner) CreateTrigger(ctx context.Context, n *tree.CreateTrigger) (planNode, error) {
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V24_1) {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"triggers are not supported until version 24.1")
+	}
+	if err := checkSchemaChangeEnabled(ctx, p.ExecCfg(), "CREATE TRIGGER"); err != nil {
+		return nil, err
+	}
+	switch {
+	case n.ActionTime == tree.TriggerActionTimeInsteadOf:
+		return nil, unimplemented.NewWithIssue(28296, "INSTEAD OF triggers")
+	case n.ForEach == tree.TriggerForEachStatement:
+		return nil, unimplemented.NewWithIssue(28296, "statement-level triggers")
+	case len(n.Transitions) > 0:
+		return nil, unimplemented.NewWithIssue(28296, "trigger transition tables")
+	}
+	for _, ev := range n.Events {
+		if ev.EventType == tree.TriggerEventTruncate {
+			return nil, unimplemented.NewWithIssue(28296, "TRUNCATE triggers")
+		}
+	}
+
+	tn := n.TableName.ToTableName()
+	_, tableDesc, err := p.ResolveMutableTableDescriptor(
+		ctx, &tn, true /* required */, tree.ResolveRequireTableDesc,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.CheckPrivilege(ctx, tableDesc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+	if tableDesc.IsVirtualTable() || tableDesc.IsForeignTable() {
+		return nil, pgerror.Newf(pgcode.WrongObjectType,
+			"%q is not a table that supports triggers", tableDesc.GetName())
+	}
+
+	// Trigger functions are declared without parameters.
+	fnName, err := n.FuncName.ToRoutineName()
+	if err != nil {
+		return nil, err
+	}
+	ol, err := p.matchRoutine(
+		ctx, &tree.RoutineObj{FuncName: fnName, Params: tree.RoutineParams{}},
+		true /* required */, tree.UDFRoutine,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if ol.FixedReturnType().Family() != types.TriggerFamily {
+		return nil, pgerror.Newf(pgcode.InvalidObjectDefinition,
+			"function %s must return type trigger", fnName.Object())
+	}
+	funcDesc, err := p.Descriptors().MutableByID(p.txn).Function(
+		ctx, funcdesc.UserDefinedFunctionOIDToID(ol.Oid),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.CheckPrivilege(ctx, funcDesc, privilege.EXECUTE); err != nil {
+		return nil, err
+	}
+
+	return &createTriggerNode{n: n, tableDesc: tableDesc, funcDesc: funcDesc}, nil
+}
+
+func (n *createTriggerNode) startExec(params runParams) error {
+	p := params.p
+	trig := descpb.TriggerDescriptor{
+		Name:       string(n.n.Name),
+		ForEachRow: true,
+		FuncID:     n.funcDesc.GetID(),
+		FuncArgs:   n.n.FuncArgs,
+	}
+	switch n.n.ActionTime {
+	case tree.TriggerActionTimeBefore:
+		trig.ActionTime = descpb.TriggerDescriptor_BEFORE
+	case tree.TriggerActionTimeAfter:
+		trig.ActionTime = descpb.TriggerDescriptor_AFTER
+	default:
+		return errors.AssertionFailedf("unexpected trigger action time %s", n.n.ActionTime)
+	}
+
+	var hasInsert, hasDelete bool
+	for _, ev := range n.n.Events {
+		var event descpb.TriggerDescriptor_Event
+		switch ev.EventType {
+		case tree.TriggerEventInsert:
+			event.Type = descpb.TriggerDescriptor_Event_INSERT
+			hasInsert = true
+		case tree.TriggerEventUpdate:
+			event.Type = descpb.TriggerDescriptor_Event_UPDATE
+			for _, colName := range ev.Columns {
+				col, err := catalog.MustFindColumnByTreeName(n.tableDesc, colName)
+				if err != nil {
+					return err
+				}
+				event.ColumnIDs = append(event.ColumnIDs, col.GetID())
+			}
+		case tree.TriggerEventDelete:
+			event.Type = descpb.TriggerDescriptor_Event_DELETE
+			hasDelete = true
+		default:
+			return errors.AssertionFailedf("unexpected trigger event %s", ev.EventType)
+		}
+		trig.Events = append(trig.Events, event)
+	}
+
+	if n.n.When != nil {
+		if err := validateTriggerWhenExpr(n.tableDesc, n.n.When, hasInsert, hasDelete); err != nil {
+			return err
+		}
+		trig.WhenExpr = tree.Serialize(n.n.When)
+	}
+
+	// Replace an existing trigger with the same name if OR REPLACE was
+	// specified.
+	for i := range n.tableDesc.Triggers {
+		existing := &n.tableDesc.Triggers[i]
+		if existing.Name != trig.Name {
+			continue
+		}
+		if !n.n.Replace {
+			return pgerror.Newf(pgcode.DuplicateObject,
+				"trigger %q for relation %q already exists", trig.Name, n.tableDesc.GetName())
+		}
+		if err := removeTriggerBackReference(params.ctx, p, n.tableDesc, existing); err != nil {
+			return err
+		}
+		n.tableDesc.Triggers = append(n.tableDesc.Triggers[:i], n.tableDesc.Triggers[i+1:]...)
+		break
+	}
+
+	if n.tableDesc.NextTriggerID == 0 {
+		n.tableDesc.NextTriggerID = 1
+	}
+	trig.ID = n.tableDesc.NextTriggerID
+	n.tableDesc.NextTriggerID++
+	n.tableDesc.Triggers = append(n.tableDesc.Triggers, trig)
+
+	// The function may have been modified when an existing trigger was
+	// replaced, so look it up again.
+	funcDesc, err := p.Descriptors().MutableByID(p.txn).Function(params.ctx, trig.FuncID)
+	if err != nil {
+		return err
+	}
+	funcDesc.AddTriggerReference(n.tableDesc.GetID(), trig.ID)
+	if err := p.writeFuncSchemaChange(params.ctx, funcDesc); err != nil {
+		return err
+	}
+	return p.writeSchemaChange(
+		params.ctx, n.tableDesc, descpb.InvalidMutationID, tree.AsStringWithFQNames(n.n, params.Ann()),
+	)
+}
+
+func (n *createTriggerNode) Next(params runParams) (bool, error) { return false, nil }
+func (n *createTriggerNode) Values() tree.Datums                 { return tree.Datums{} }
+func (n *createTriggerNode) Close(ctx context.Context)           {}
+func (n *createTriggerNode) ReadingOwnWrites()                   {}
+
+// validateTriggerWhenExpr checks that the WHEN condition of a trigger only
+// refers to existing columns of the NEW and OLD rows, and that it does not
+// contain subqueries. The condition is type-checked when the trigger is
+// invoked.
+func validateTriggerWhenExpr(
+	tableDesc catalog.TableDescriptor, expr tree.Expr, hasInsert, hasDelete bool,
+) error {
+	v := triggerWhenVisitor{tableDesc: tableDesc, hasInsert: hasInsert, hasDelete: hasDelete}
+	tree.WalkExprConst(&v, expr)
+	return v.err
+}
+
+type triggerWhenVisitor struct {
+	tableDesc            catalog.TableDescriptor
+	hasInsert, hasDelete bool
+	err                  error
+}
+
+var _ tree.Visitor = &triggerWhenVisitor{}
+
+// VisitPre is part of the tree.Visitor interface.
+func (v *triggerWhenVisitor) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
+	if v.err != nil {
+		return false, expr
+	}
+	switch t := expr.(type) {
+	case *tree.Subquery:
+		v.err = pgerror.New(pgcode.FeatureNotSupported,
+			"cannot use subquery in trigger WHEN condition")
+		return false, expr
+
+	case *tree.ColumnAccessExpr:
+		// (NEW).a or (OLD).a.
+		if name, ok := tree.StripParens(t.Expr).(*tree.UnresolvedName); ok && name.NumParts == 1 {
+			v.err = v.checkRowRef(name.Parts[0], string(t.ColName))
+			return false, expr
+		}
+
+	case *tree.UnresolvedName:
+		switch t.NumParts {
+		case 1:
+			v.err = v.checkRowRef(t.Parts[0], "" /* colName */)
+		case 2:
+			v.err = v.checkRowRef(t.Parts[1], t.Parts[0])
+		default:
+			v.err = pgerror.Newf(pgcode.InvalidColumnReference,
+				"trigger WHEN condition cannot refer to %s", tree.ErrString(t))
+		}
+		return false, expr
+	}
+	return true, expr
+}
+
+// VisitPost is part of the tree.Visitor interface.
+func (*triggerWhenVisitor) VisitPost(expr tree.Expr) tree.Expr { return expr }
+
+// checkRowRef checks a reference to the NEW or OLD row, or to one of its
+// columns if colName is not empty.
+func (v *triggerWhenVisitor) checkRowRef(rowName, colName string) error {
+	switch rowName {
+	case "new":
+		if v.hasDelete {
+			return pgerror.New(pgcode.InvalidObjectDefinition,
+				"DELETE trigger's WHEN condition cannot reference NEW values")
+		}
+	case "old":
+		if v.hasInsert {
+			return pgerror.New(pgcode.InvalidObjectDefinition,
+				"INSERT trigger's WHEN condition cannot reference OLD values")
+		}
+	default:
+		name := rowName
+		if colName != "" {
+			name = fmt.Sprintf("%s.%s", rowName, colName)
+		}
+		return pgerror.Newf(pgcode.UndefinedColumn,
+			"column %q does not exist in trigger WHEN condition", name)
+	}
+	if colName == "" {
+		return nil
+	}
+	col, err := catalog.MustFindColumnByName(v.tableDesc, colName)
+	if err != nil {
+		return err
+	}
+	if !col.Public() || col.IsHidden() {
+		return pgerror.Newf(pgcode.UndefinedColumn,
+			"record %q has no field %q", rowName, colName)
+	}
+	return nil
+}
+
+// triggerReferencesColumn returns true if the given column is referenced by
+// the UPDATE OF column list or the WHEN condition of the trigger.
+func triggerReferencesColumn(trig *descpb.TriggerDescriptor, col catalog.Column) (bool, error) {
+	for _, ev := range trig.Events {
+		for _, colID := range ev.ColumnIDs {
+			if colID == col.GetID() {
+				return true, nil
+			}
+		}
+	}
+	if trig.WhenExpr == "" {
+		return false, nil
+	}
+	expr, err := parser.ParseExpr(trig.WhenExpr)
+	if err != nil {
+		return false, err
+	}
+	var found bool
+	if _, err := tree.SimpleVisit(expr, func(expr tree.Expr) (recurse bool, newExpr tree.Expr, err error) {
+		switch t := expr.(type) {
+		case *tree.ColumnAccessExpr:
+			if string(t.ColName) == col.GetName() {
+				found = true
+			}
+		case *tree.UnresolvedName:
+			if t.NumParts == 2 && t.Parts[0] == col.GetName() {
+				found = true
+			}
+		}
+		return !found, expr, nil
+	}); err != nil {
+		return false, err
+	}
+	return found, nil
+}
+
+// removeTriggerBackReference removes the reference from the function of the
+// given trigger back to the trigger.
+func removeTriggerBackReference(
+	ctx context.Context, p *planner, tableDesc *tabledesc.Mutable, trig *descpb.TriggerDescriptor,
+) error {
+	funcDesc, err := p.Descriptors().MutableByID(p.txn).Function(ctx, trig.FuncID)
+	if err != nil {
+		return err
+	}
+	funcDesc.RemoveTriggerReference(tableDesc.GetID(), trig.ID)
+	return p.writeFuncSchemaChange(ctx, funcDesc)
+}
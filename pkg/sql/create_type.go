@@ -20,8 +20,10 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catprivilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/enum"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
@@ -237,6 +239,10 @@ func CreateUserDefinedArrayTypeDesc(
 			labels[i] = e.ElementLabel
 		}
 		elemTyp = types.NewCompositeType(catid.TypeIDToOID(typDesc.GetID()), catid.TypeIDToOID(id), contents, labels)
+	case descpb.TypeDescriptor_DOMAIN:
+		elemTyp = types.MakeDomain(catid.TypeIDToOID(typDesc.GetID()), catid.TypeIDToOID(id), typDesc.Domain.BaseType)
+	case descpb.TypeDescriptor_RANGE:
+		elemTyp = types.MakeRange(catid.TypeIDToOID(typDesc.GetID()), catid.TypeIDToOID(id), typDesc.Range.Subtype)
 	default:
 		return nil, errors.AssertionFailedf("cannot make array type for kind %s", t.String())
 	}
@@ -323,6 +329,20 @@ func (p *planner) createUserDefinedType(params runParams, n *createTypeNode) err
 		return params.p.createCompositeWithID(
 			params, id, n.n.CompositeTypeList, n.dbDesc, n.typeName,
 		)
+	case tree.Domain:
+		if !p.execCfg.Settings.Version.IsActive(params.ctx, clusterversion.V24_1) {
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"version %v must be finalized to create domains",
+				clusterversion.ByKey(clusterversion.V24_1))
+		}
+		return params.p.createDomainWithID(params, id, n.n, n.dbDesc, n.typeName)
+	case tree.Range:
+		if !p.execCfg.Settings.Version.IsActive(params.ctx, clusterversion.V24_1) {
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"version %v must be finalized to create range types",
+				clusterversion.ByKey(clusterversion.V24_1))
+		}
+		return params.p.createRangeWithID(params, id, n.n, n.dbDesc, n.typeName)
 	}
 	return unimplemented.NewWithIssue(25123, "CREATE TYPE")
 }
@@ -464,6 +484,190 @@ func CreateCompositeTypeDesc(
 	}).BuildCreatedMutableType(), nil
 }
 
+// CreateDomainTypeDesc creates a new domain type descriptor.
+func CreateDomainTypeDesc(
+	params runParams,
+	id descpb.ID,
+	n *tree.CreateType,
+	dbDesc catalog.DatabaseDescriptor,
+	schema catalog.SchemaDescriptor,
+	typeName *tree.TypeName,
+) (*typedesc.Mutable, error) {
+	baseType, err := tree.ResolveType(params.ctx, n.DomainType, params.p.semaCtx.TypeResolver)
+	if err != nil {
+		return nil, err
+	}
+	if err := tree.CheckUnsupportedType(params.ctx, &params.p.semaCtx, baseType); err != nil {
+		return nil, err
+	}
+	if baseType.UserDefined() {
+		return nil, unimplemented.NewWithIssue(27796,
+			"domains over user-defined types not yet supported")
+	}
+	switch baseType.Family() {
+	case types.ArrayFamily, types.TupleFamily, types.AnyFamily, types.VoidFamily,
+		types.TriggerFamily:
+		return nil, pgerror.Newf(pgcode.DatatypeMismatch,
+			"%q is not a valid base type for a domain", baseType.SQLString())
+	}
+
+	domain := &descpb.TypeDescriptor_Domain{BaseType: baseType}
+	var sawNull, sawNotNull bool
+	usedNames := make(map[string]struct{})
+	for i := range n.DomainConstraints {
+		c := &n.DomainConstraints[i]
+		if c.Name != "" {
+			if _, ok := usedNames[string(c.Name)]; ok {
+				return nil, pgerror.Newf(pgcode.DuplicateObject,
+					"constraint %q for domain %q already exists", c.Name, typeName.Type())
+			}
+			usedNames[string(c.Name)] = struct{}{}
+		}
+		switch {
+		case c.NotNull:
+			if sawNull {
+				return nil, pgerror.New(pgcode.Syntax, "conflicting NULL/NOT NULL constraints")
+			}
+			sawNotNull = true
+			domain.NotNull = true
+		case c.Null:
+			if sawNotNull {
+				return nil, pgerror.New(pgcode.Syntax, "conflicting NULL/NOT NULL constraints")
+			}
+			sawNull = true
+		}
+	}
+	for i := range n.DomainConstraints {
+		c := &n.DomainConstraints[i]
+		if c.Check == nil {
+			continue
+		}
+		check, err := makeDomainCheck(params, c, baseType, typeName.Type(), usedNames)
+		if err != nil {
+			return nil, err
+		}
+		domain.Checks = append(domain.Checks, check)
+	}
+	if n.DomainDefault != nil {
+		domain.DefaultExpr, err = schemaexpr.ValidateDomainDefaultExpr(
+			params.ctx, n.DomainDefault, baseType, params.p.SemaCtx(), params.ExecCfg().Settings.Version.ActiveVersion(params.ctx),
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	privs, err := catprivilege.CreatePrivilegesFromDefaultPrivileges(
+		dbDesc.GetDefaultPrivilegeDescriptor(),
+		schema.GetDefaultPrivilegeDescriptor(),
+		dbDesc.GetID(),
+		params.SessionData().User(),
+		privilege.Types,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return typedesc.NewBuilder(&descpb.TypeDescriptor{
+		Name:           typeName.Type(),
+		ID:             id,
+		ParentID:       dbDesc.GetID(),
+		ParentSchemaID: schema.GetID(),
+		Kind:           descpb.TypeDescriptor_DOMAIN,
+		Domain:         domain,
+		Version:        1,
+		Privileges:     privs,
+	}).BuildCreatedMutableType(), nil
+}
+
+// CreateRangeTypeDesc creates a new range type descriptor.
+func CreateRangeTypeDesc(
+	params runParams,
+	id descpb.ID,
+	n *tree.CreateType,
+	dbDesc catalog.DatabaseDescriptor,
+	schema catalog.SchemaDescriptor,
+	typeName *tree.TypeName,
+) (*typedesc.Mutable, error) {
+	subtype, err := tree.ResolveType(params.ctx, n.RangeSubtype, params.p.semaCtx.TypeResolver)
+	if err != nil {
+		return nil, err
+	}
+	if err := tree.CheckUnsupportedType(params.ctx, &params.p.semaCtx, subtype); err != nil {
+		return nil, err
+	}
+	if subtype.UserDefined() {
+		return nil, unimplemented.NewWithIssue(27791,
+			"range types over user-defined types not yet supported")
+	}
+	switch subtype.Family() {
+	case types.TupleFamily, types.AnyFamily, types.VoidFamily, types.TriggerFamily,
+		types.UnknownFamily, types.RangeFamily, types.MultirangeFamily:
+		return nil, pgerror.Newf(pgcode.DatatypeMismatch,
+			"%q is not a valid subtype for a range type", subtype.SQLString())
+	}
+	// The bounds of a range are compared and key-encoded as values of the
+	// subtype, so the subtype must have a total order and a key encoding.
+	if colinfo.MustBeValueEncoded(subtype) {
+		return nil, pgerror.Newf(pgcode.UndefinedObject,
+			"data type %s has no default operator class for access method \"btree\"",
+			subtype.SQLString())
+	}
+
+	privs, err := catprivilege.CreatePrivilegesFromDefaultPrivileges(
+		dbDesc.GetDefaultPrivilegeDescriptor(),
+		schema.GetDefaultPrivilegeDescriptor(),
+		dbDesc.GetID(),
+		params.SessionData().User(),
+		privilege.Types,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return typedesc.NewBuilder(&descpb.TypeDescriptor{
+		Name:           typeName.Type(),
+		ID:             id,
+		ParentID:       dbDesc.GetID(),
+		ParentSchemaID: schema.GetID(),
+		Kind:           descpb.TypeDescriptor_RANGE,
+		Range:          &descpb.TypeDescriptor_Range{Subtype: subtype},
+		Version:        1,
+		Privileges:     privs,
+	}).BuildCreatedMutableType(), nil
+}
+
+// makeDomainCheck validates the CHECK constraint c of the domain domainName and
+// returns its descriptor representation. Unnamed constraints are given a name
+// of the form <domain>_check, which is not already in usedNames. The name of
+// the constraint is added to usedNames.
+func makeDomainCheck(
+	params runParams,
+	c *tree.DomainConstraint,
+	baseType *types.T,
+	domainName string,
+	usedNames map[string]struct{},
+) (descpb.TypeDescriptor_Domain_Check, error) {
+	expr, err := schemaexpr.ValidateDomainCheckExpr(
+		params.ctx, c.Check, baseType, params.p.SemaCtx(), params.ExecCfg().Settings.Version.ActiveVersion(params.ctx),
+	)
+	if err != nil {
+		return descpb.TypeDescriptor_Domain_Check{}, err
+	}
+	name := string(c.Name)
+	if name == "" {
+		name = domainName + "_check"
+		for i := 1; ; i++ {
+			if _, ok := usedNames[name]; !ok {
+				break
+			}
+			name = fmt.Sprintf("%s_check%d", domainName, i)
+		}
+		usedNames[name] = struct{}{}
+	}
+	return descpb.TypeDescriptor_Domain_Check{Name: name, Expr: expr}, nil
+}
+
 func (p *planner) createEnumWithID(
 	params runParams,
 	id descpb.ID,
@@ -516,6 +720,52 @@ func (p *planner) createCompositeWithID(
 	return nil
 }
 
+func (p *planner) createDomainWithID(
+	params runParams,
+	id descpb.ID,
+	n *tree.CreateType,
+	dbDesc catalog.DatabaseDescriptor,
+	typeName *tree.TypeName,
+) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("domain"))
+
+	// Generate a key in the namespace table and a new id for this type.
+	schema, err := getCreateTypeParams(params, typeName, dbDesc)
+	if err != nil {
+		return err
+	}
+
+	typeDesc, err := CreateDomainTypeDesc(params, id, n, dbDesc, schema, typeName)
+	if err != nil {
+		return err
+	}
+
+	return p.finishCreateType(params, id, typeName, typeDesc, dbDesc, schema)
+}
+
+func (p *planner) createRangeWithID(
+	params runParams,
+	id descpb.ID,
+	n *tree.CreateType,
+	dbDesc catalog.DatabaseDescriptor,
+	typeName *tree.TypeName,
+) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("range"))
+
+	// Generate a key in the namespace table and a new id for this type.
+	schema, err := getCreateTypeParams(params, typeName, dbDesc)
+	if err != nil {
+		return err
+	}
+
+	typeDesc, err := CreateRangeTypeDesc(params, id, n, dbDesc, schema, typeName)
+	if err != nil {
+		return err
+	}
+
+	return p.finishCreateType(params, id, typeName, typeDesc, dbDesc, schema)
+}
+
 func (p *planner) finishCreateType(
 	params runParams,
 	id descpb.ID,
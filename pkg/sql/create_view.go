@@ -26,6 +26,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/resolver"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/seqexpr"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
@@ -60,6 +61,10 @@ type createViewNode struct {
 	// depends on. This is collected during the construction of
 	// the view query's logical plan.
 	typeDeps typeDependencies
+
+	// textSearchDeps tracks which text search configurations and dictionaries
+	// the view being created passes to text search builtins.
+	textSearchDeps opt.SchemaTextSearchDeps
 }
 
 // ReadingOwnWrites implements the planNodeReadingOwnWrites interface.
@@ -273,6 +278,12 @@ func (n *createViewNode) startExec(params runParams) error {
 					orderedTypeDeps.Add(backrefID)
 				}
 				desc.DependsOnTypes = append(desc.DependsOnTypes, orderedTypeDeps.Ordered()...)
+
+				// Collect all text search objects this view depends on.
+				desc.DependsOnTextSearchObjects, err = params.p.resolveTextSearchDeps(params.ctx, n.textSearchDeps)
+				if err != nil {
+					return err
+				}
 				newDesc = &desc
 
 				if err = params.p.createDescriptor(
@@ -678,6 +689,10 @@ func (p *planner) replaceViewDesc(
 	for backrefID := range n.typeDeps {
 		toReplace.DependsOnTypes = append(toReplace.DependsOnTypes, backrefID)
 	}
+	toReplace.DependsOnTextSearchObjects, err = p.resolveTextSearchDeps(ctx, n.textSearchDeps)
+	if err != nil {
+		return nil, err
+	}
 
 	// Since we are replacing an existing view here, we need to write the new
 	// descriptor into place.
@@ -68,6 +68,7 @@ SELECT ns.nspname AS schema_name,
        WHEN pc.relkind = 'v' THEN 'view'
        WHEN pc.relkind = 'm' THEN 'materialized view'
        WHEN pc.relkind = 'S' THEN 'sequence'
+       WHEN pc.relkind = 'f' THEN 'foreign table'
        ELSE 'table'
        END AS type,
        rl.rolname AS owner,
@@ -80,7 +81,7 @@ JOIN %[1]s.pg_catalog.pg_namespace AS ns ON (ns.oid = pc.relnamespace)
 %[4]s
 %[6]s
 LEFT JOIN crdb_internal.tables AS ct ON (pc.oid::int8 = ct.table_id AND ct.database_name = %[7]s AND ct.drop_time IS NULL)
-WHERE pc.relkind IN ('r', 'v', 'S', 'm') %[2]s
+WHERE pc.relkind IN ('r', 'v', 'S', 'm', 'f') %[2]s
 ORDER BY schema_name, table_name
 `
 	var estimatedRowCount string
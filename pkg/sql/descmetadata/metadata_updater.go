@@ -14,6 +14,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
@@ -34,6 +35,7 @@ type metadataUpdater struct {
 	sessionData  *sessiondata.SessionData
 	descriptors  *descs.Collection
 	cacheEnabled bool
+	version      clusterversion.Handle
 }
 
 // NewMetadataUpdater creates a new comment updater, which can be used to
@@ -52,11 +54,17 @@ func NewMetadataUpdater(
 		sessionData:  sessionData,
 		descriptors:  descriptors,
 		cacheEnabled: sessioninit.CacheEnabled.Get(settings),
+		version:      settings.Opaque().(clusterversion.Handle),
 	}
 }
 
-// DeleteDatabaseRoleSettings implement scexec.DescriptorMetaDataUpdater.
+// DeleteDatabaseRoleSettings implement scexec.DescriptorMetaDataUpdater. The
+// publications of the database, which are also keyed by its ID, are deleted
+// along with its role settings.
 func (mu metadataUpdater) DeleteDatabaseRoleSettings(ctx context.Context, dbID descpb.ID) error {
+	if err := mu.deleteDatabasePublications(ctx, dbID); err != nil {
+		return err
+	}
 	rowsDeleted, err := mu.txn.ExecEx(ctx,
 		"delete-db-role-setting",
 		mu.txn.KV(),
@@ -84,6 +92,22 @@ func (mu metadataUpdater) DeleteDatabaseRoleSettings(ctx context.Context, dbID d
 	return mu.descriptors.WriteDesc(ctx, false /*kvTrace*/, desc, mu.txn.KV())
 }
 
+// deleteDatabasePublications deletes the publications of a database.
+func (mu metadataUpdater) deleteDatabasePublications(ctx context.Context, dbID descpb.ID) error {
+	// The system.publications table only exists once the cluster is upgraded.
+	if !mu.version.IsActive(ctx, clusterversion.V24_1_AddSystemPublicationsTable) {
+		return nil
+	}
+	_, err := mu.txn.ExecEx(ctx,
+		"delete-db-publications",
+		mu.txn.KV(),
+		sessiondata.RootUserSessionDataOverride,
+		`DELETE FROM system.publications WHERE database_id = $1`,
+		dbID,
+	)
+	return err
+}
+
 // DeleteSchedule implement scexec.DescriptorMetadataUpdater.
 func (mu metadataUpdater) DeleteSchedule(ctx context.Context, scheduleID int64) error {
 	_, err := mu.txn.ExecEx(
@@ -67,6 +67,11 @@ func (n *discardNode) startExec(params runParams) error {
 			return err
 		}
 
+		// UNLISTEN *
+		if notifications := params.p.extendedEvalCtx.notifications; notifications.enabled() {
+			notifications.actions = append(notifications.actions, listenAction{})
+		}
+
 	case tree.DiscardModeSequences:
 		params.p.sessionDataMutatorIterator.applyOnEachMutator(func(m sessionDataMutator) {
 			m.data.SequenceState = sessiondata.NewSequenceState()
@@ -62,6 +62,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/quotapool"
 	"github.com/cockroachdb/cockroach/pkg/util/randutil"
 	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/redact"
@@ -306,6 +307,14 @@ func (v *distSQLExprCheckVisitor) VisitPre(expr tree.Expr) (recurse bool, newExp
 			v.err = newQueryNotSupportedErrorf("function %s cannot be executed with distsql", t)
 			return false, expr
 		}
+		if mayUseUserDefinedTextSearchObject(t) {
+			// Remote nodes have no planner with which to resolve user-defined
+			// text search configurations and dictionaries.
+			v.err = newQueryNotSupportedErrorf(
+				"function %s may use a user-defined text search object and cannot be executed with distsql", t,
+			)
+			return false, expr
+		}
 	case *tree.RoutineExpr:
 		// TODO(#86310): enable UDFs in DistSQL.
 		v.err = newQueryNotSupportedErrorf("user-defined routine %s cannot be executed with distsql", t)
@@ -352,6 +361,19 @@ func (v *distSQLExprCheckVisitor) VisitPre(expr tree.Expr) (recurse bool, newExp
 
 func (v *distSQLExprCheckVisitor) VisitPost(expr tree.Expr) tree.Expr { return expr }
 
+// mayUseUserDefinedTextSearchObject returns whether f is a text search builtin
+// whose configuration or dictionary argument is not a constant naming a
+// built-in object. The one-argument overloads use default_text_search_config,
+// which can only be set to a built-in configuration.
+func mayUseUserDefinedTextSearchObject(f *tree.FuncExpr) bool {
+	overload := f.ResolvedOverload()
+	if overload == nil || !overload.TextSearchObjectArgument || len(f.Exprs) != 2 {
+		return false
+	}
+	name, ok := f.Exprs[0].(*tree.DString)
+	return !ok || !tsearch.IsBuiltinObject(string(*name))
+}
+
 // hasOidType returns whether t or its contents include an OID type.
 func hasOidType(t *types.T) bool {
 	switch t.Family() {
@@ -524,6 +546,15 @@ func checkSupportForPlanNode(node planNode) (distRecommendation, error) {
 		return checkSupportForPlanNode(n.source.plan)
 
 	case *groupNode:
+		for _, f := range n.funcs {
+			if f.userDefined != nil {
+				// The support routines of user-defined aggregates can only be
+				// evaluated on the gateway.
+				return cannotDistribute, newQueryNotSupportedErrorf(
+					"user-defined aggregate %s cannot be executed with distsql", f.funcName,
+				)
+			}
+		}
 		rec, err := checkSupportForPlanNode(n.plan)
 		if err != nil {
 			return cannotDistribute, err
@@ -2178,6 +2209,11 @@ type aggregatorPlanningInfo struct {
 	inputMergeOrdering       execinfrapb.Ordering
 	reqOrdering              ReqOrdering
 	allowPartialDistribution bool
+	// userDefined, if set, contains the definitions of the user-defined
+	// aggregates, in the same positions as the corresponding USER_DEFINED
+	// aggregations. It allows user-defined aggregates with a combine function
+	// to be planned in local and final stages.
+	userDefined []*tree.UserDefinedAggregate
 }
 
 // addAggregators adds aggregators corresponding to a groupNode and updates the plan to
@@ -2187,7 +2223,20 @@ func (dsp *DistSQLPlanner) addAggregators(
 ) error {
 	aggregations := make([]execinfrapb.AggregatorSpec_Aggregation, len(n.funcs))
 	argumentsColumnTypes := make([][]*types.T, len(n.funcs))
+	var userDefined []*tree.UserDefinedAggregate
 	for i, fholder := range n.funcs {
+		if fholder.userDefined != nil {
+			agg, err := dsp.makeUserDefinedAggregation(ctx, planCtx, p, fholder)
+			if err != nil {
+				return err
+			}
+			aggregations[i] = agg
+			if userDefined == nil {
+				userDefined = make([]*tree.UserDefinedAggregate, len(n.funcs))
+			}
+			userDefined[i] = fholder.userDefined
+			continue
+		}
 		funcIdx, err := execinfrapb.GetAggregateFuncIdx(fholder.funcName)
 		if err != nil {
 			return err
@@ -2221,9 +2270,55 @@ func (dsp *DistSQLPlanner) addAggregators(
 		groupColOrdering:     n.groupColOrdering,
 		inputMergeOrdering:   dsp.convertOrdering(planReqOrdering(n.plan), p.PlanToStreamColMap),
 		reqOrdering:          n.reqOrdering,
+		userDefined:          userDefined,
 	})
 }
 
+// makeUserDefinedAggregation returns the specification of an aggregation which
+// evaluates a user-defined aggregate with its state transition and final
+// functions.
+func (dsp *DistSQLPlanner) makeUserDefinedAggregation(
+	ctx context.Context, planCtx *PlanningCtx, p *PhysicalPlan, fholder *aggregateFuncHolder,
+) (execinfrapb.AggregatorSpec_Aggregation, error) {
+	uda := fholder.userDefined
+	agg := execinfrapb.AggregatorSpec_Aggregation{
+		Func:     execinfrapb.UserDefined,
+		Distinct: fholder.isDistinct,
+	}
+	for _, renderIdx := range fholder.argRenderIdxs {
+		agg.ColIdx = append(agg.ColIdx, uint32(p.PlanToStreamColMap[renderIdx]))
+	}
+	if fholder.hasFilter() {
+		col := uint32(p.PlanToStreamColMap[fholder.filterRenderIdx])
+		agg.FilterColIdx = &col
+	}
+	var err error
+	agg.Arguments, err = dsp.makeUserDefinedAggregationArguments(
+		ctx, planCtx, uda.StateFunc, uda.FinalFunc, uda.InitCond,
+	)
+	return agg, err
+}
+
+// makeUserDefinedAggregationArguments returns the arguments of a USER_DEFINED
+// aggregation (see execagg.MakeUserDefinedAggregateArguments).
+func (dsp *DistSQLPlanner) makeUserDefinedAggregationArguments(
+	ctx context.Context,
+	planCtx *PlanningCtx,
+	transitionFunc, finalFunc *tree.RoutineExpr,
+	initCond tree.Datum,
+) ([]execinfrapb.Expression, error) {
+	args := execagg.MakeUserDefinedAggregateArguments(transitionFunc, finalFunc, initCond)
+	res := make([]execinfrapb.Expression, len(args))
+	for i := range args {
+		var err error
+		res[i], err = physicalplan.MakeExpression(ctx, args[i], planCtx, nil /* indexVarMap */)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
 // planAggregators plans the aggregator processors. An evaluator stage is added
 // if necessary.
 // Invariants assumed:
@@ -2415,11 +2510,20 @@ func (dsp *DistSQLPlanner) planAggregators(
 	//  different paths and joining on the results.
 	multiStage := prevStageNode == 0
 	if multiStage {
-		for _, e := range info.aggregations {
+		for i, e := range info.aggregations {
 			if e.Distinct {
 				multiStage = false
 				break
 			}
+			if e.Func == execinfrapb.UserDefined {
+				// A user-defined aggregate supports a local stage only if it
+				// has a combine function.
+				if info.userDefined == nil || info.userDefined[i].CombineFunc == nil {
+					multiStage = false
+					break
+				}
+				continue
+			}
 			// Check that the function supports a local stage.
 			if _, ok := physicalplan.DistAggregationTable[e.Func]; !ok {
 				multiStage = false
@@ -2461,6 +2565,11 @@ func (dsp *DistSQLPlanner) planAggregators(
 		nFinalAgg := 0
 		needRender := false
 		for _, e := range info.aggregations {
+			if e.Func == execinfrapb.UserDefined {
+				nLocalAgg++
+				nFinalAgg++
+				continue
+			}
 			info := physicalplan.DistAggregationTable[e.Func]
 			nLocalAgg += len(info.LocalStage)
 			nFinalAgg += len(info.FinalStage)
@@ -2495,7 +2604,49 @@ func (dsp *DistSQLPlanner) planAggregators(
 		// finalIdx is the index of the final aggregation with respect
 		// to all final aggregations.
 		finalIdx := 0
-		for _, e := range info.aggregations {
+		for i, e := range info.aggregations {
+			if e.Func == execinfrapb.UserDefined {
+				// The local stage accumulates the state of the user-defined
+				// aggregate using its state transition function, and the final
+				// stage merges the states using the combine function before
+				// applying the final function.
+				uda := info.userDefined[i]
+				localAgg := execinfrapb.AggregatorSpec_Aggregation{
+					Func:         execinfrapb.UserDefined,
+					ColIdx:       e.ColIdx,
+					FilterColIdx: e.FilterColIdx,
+				}
+				var err error
+				localAgg.Arguments, err = dsp.makeUserDefinedAggregationArguments(
+					ctx, planCtx, uda.StateFunc, nil /* finalFunc */, uda.InitCond,
+				)
+				if err != nil {
+					return err
+				}
+				finalAgg := execinfrapb.AggregatorSpec_Aggregation{
+					Func:   execinfrapb.UserDefined,
+					ColIdx: []uint32{uint32(len(localAggs))},
+				}
+				finalAgg.Arguments, err = dsp.makeUserDefinedAggregationArguments(
+					ctx, planCtx, uda.CombineFunc, uda.FinalFunc, uda.InitCond,
+				)
+				if err != nil {
+					return err
+				}
+				localAggs = append(localAggs, localAgg)
+				intermediateTypes = append(intermediateTypes, uda.StateType)
+				finalIdxMap[finalIdx] = uint32(len(finalAggs))
+				finalAggs = append(finalAggs, finalAgg)
+				if needRender {
+					outputType, err := execagg.UserDefinedAggregateResultType(&finalAgg)
+					if err != nil {
+						return err
+					}
+					finalPreRenderTypes = append(finalPreRenderTypes, outputType)
+				}
+				finalIdx++
+				continue
+			}
 			info := physicalplan.DistAggregationTable[e.Func]
 
 			// relToAbsLocalIdx maps each local stage for the given
@@ -2715,6 +2866,10 @@ func (dsp *DistSQLPlanner) planAggregators(
 			finalIdx := 0
 			for i, e := range info.aggregations {
 				info := physicalplan.DistAggregationTable[e.Func]
+				if e.Func == execinfrapb.UserDefined {
+					// User-defined aggregates have a single final aggregation.
+					info.FinalStage = []physicalplan.FinalStageInfo{{Fn: e.Func}}
+				}
 				if info.FinalRendering == nil {
 					// mappedIdx corresponds to the index
 					// location of the result for this
@@ -2766,6 +2921,14 @@ func (dsp *DistSQLPlanner) planAggregators(
 
 	finalOutTypes := make([]*types.T, len(info.aggregations))
 	for i, agg := range info.aggregations {
+		if agg.Func == execinfrapb.UserDefined {
+			returnTyp, err := execagg.UserDefinedAggregateResultType(&info.aggregations[i])
+			if err != nil {
+				return err
+			}
+			finalOutTypes[i] = returnTyp
+			continue
+		}
 		argTypes := make([]*types.T, len(agg.ColIdx)+len(agg.Arguments))
 		for j, c := range agg.ColIdx {
 			argTypes[j] = inputTypes[c]
@@ -4685,6 +4848,12 @@ func (c *localScanParallelizationChecker) enterNode(
 		return false, nil
 	case *groupNode:
 		for _, f := range n.funcs {
+			if f.userDefined != nil {
+				// The support routines of user-defined aggregates are evaluated
+				// using the planner, which cannot be used concurrently.
+				c.prohibitParallelization = true
+				return false, nil
+			}
 			c.prohibitParallelization = f.hasFilter()
 		}
 		return true, nil
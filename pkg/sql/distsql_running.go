@@ -1082,9 +1082,13 @@ func NewMetadataOnlyMetadataCallbackWriter() *MetadataCallbackWriter {
 
 // errOnlyResultWriter is a rowResultWriter and batchResultWriter that only
 // supports receiving an error. All other functions that deal with producing
-// results panic.
+// results panic, unless rows are discarded.
 type errOnlyResultWriter struct {
 	err error
+	// discardRows, if set, causes any rows to be ignored rather than panic. It
+	// is used for cascades that run trigger functions, which produce a row for
+	// each modified row.
+	discardRows bool
 }
 
 var _ rowResultWriter = &errOnlyResultWriter{}
@@ -1098,10 +1102,16 @@ func (w *errOnlyResultWriter) Err() error {
 }
 
 func (w *errOnlyResultWriter) AddRow(ctx context.Context, row tree.Datums) error {
+	if w.discardRows {
+		return nil
+	}
 	panic("AddRow not supported by errOnlyResultWriter")
 }
 
 func (w *errOnlyResultWriter) AddBatch(ctx context.Context, batch coldata.Batch) error {
+	if w.discardRows {
+		return nil
+	}
 	panic("AddBatch not supported by errOnlyResultWriter")
 }
 
@@ -2135,6 +2145,7 @@ func (dsp *DistSQLPlanner) PlanAndRunCascadesAndChecks(
 			evalCtx,
 			recv,
 			false, /* parallelCheck */
+			true,  /* discardRows */
 			defaultGetSaveFlowsFunc,
 			planner.instrumentation.getAssociateNodeWithComponentsFn(),
 			recv.stats.add,
@@ -2144,7 +2155,13 @@ func (dsp *DistSQLPlanner) PlanAndRunCascadesAndChecks(
 		}
 	}
 
-	if len(plan.checkPlans) == 0 {
+	checkPlans := plan.checkPlans
+	if deferredConstraints := planner.extendedEvalCtx.deferredConstraints; deferredConstraints.enabled() {
+		// The checks of deferred constraints are skipped; the constraints are
+		// validated when the transaction commits instead.
+		checkPlans = deferredConstraints.filterChecks(checkPlans)
+	}
+	if len(checkPlans) == 0 {
 		return true
 	}
 
@@ -2159,7 +2176,7 @@ func (dsp *DistSQLPlanner) PlanAndRunCascadesAndChecks(
 	// multiple checks to run, none of the checks have non-default locking, and
 	// we're likely to have quota to do so.
 	runParallelChecks := parallelizeChecks.Get(&dsp.st.SV) &&
-		len(plan.checkPlans) > 1 &&
+		len(checkPlans) > 1 &&
 		!planner.curPlan.flags.IsSet(planFlagCheckContainsNonDefaultLocking) &&
 		dsp.parallelChecksSem.ApproximateQuota() > 0
 	if runParallelChecks {
@@ -2168,31 +2185,32 @@ func (dsp *DistSQLPlanner) PlanAndRunCascadesAndChecks(
 		// TODO(yuzefovich): the planObserver logic in
 		// planAndRunChecksInParallel will need to be adjusted when we switch to
 		// using the DistSQL spec factory.
-		for i := range plan.checkPlans {
-			if plan.checkPlans[i].plan.isPhysicalPlan() {
+		for i := range checkPlans {
+			if checkPlans[i].plan.isPhysicalPlan() {
 				runParallelChecks = false
 				break
 			}
 		}
 	}
 	if runParallelChecks {
-		if err := dsp.planAndRunChecksInParallel(ctx, plan.checkPlans, planner, evalCtxFactory, recv); err != nil {
+		if err := dsp.planAndRunChecksInParallel(ctx, checkPlans, planner, evalCtxFactory, recv); err != nil {
 			recv.SetError(err)
 			return false
 		}
 	} else {
-		if len(plan.checkPlans) > 1 {
-			log.VEventf(ctx, 2, "executing %d checks serially", len(plan.checkPlans))
+		if len(checkPlans) > 1 {
+			log.VEventf(ctx, 2, "executing %d checks serially", len(checkPlans))
 		}
-		for i := range plan.checkPlans {
-			log.VEventf(ctx, 2, "executing check query %d out of %d", i+1, len(plan.checkPlans))
+		for i := range checkPlans {
+			log.VEventf(ctx, 2, "executing check query %d out of %d", i+1, len(checkPlans))
 			if err := dsp.planAndRunPostquery(
 				ctx,
-				plan.checkPlans[i].plan,
+				checkPlans[i].plan,
 				planner,
 				evalCtxFactory(false /* usedConcurrently */),
 				recv,
 				false, /* parallelCheck */
+				false, /* discardRows */
 				defaultGetSaveFlowsFunc,
 				planner.instrumentation.getAssociateNodeWithComponentsFn(),
 				recv.stats.add,
@@ -2234,6 +2252,9 @@ var parallelChecksConcurrencyLimit = settings.RegisterIntSetting(
 // with other check queries. If parallelCheck is true, then getSaveFlowsFunc,
 // associateNodeWithComponents, and addTopLevelQueryStats must be
 // concurrency-safe (if non-nil).
+// - discardRows indicates whether any rows produced by the postquery are
+// ignored. It is set for cascades, which include the invocations of AFTER
+// triggers.
 // - getSaveFlowsFunc will only be called if
 // planner.instrumentation.ShouldSaveFlows() returns true.
 func (dsp *DistSQLPlanner) planAndRunPostquery(
@@ -2243,6 +2264,7 @@ func (dsp *DistSQLPlanner) planAndRunPostquery(
 	evalCtx *extendedEvalContext,
 	recv *DistSQLReceiver,
 	parallelCheck bool,
+	discardRows bool,
 	getSaveFlowsFunc func() func(map[base.SQLInstanceID]*execinfrapb.FlowSpec, execopnode.OpChains, []execinfra.LocalProcessor, bool) error,
 	associateNodeWithComponents func(exec.Node, execComponents),
 	addTopLevelQueryStats func(stats *topLevelQueryStats),
@@ -2278,7 +2300,7 @@ func (dsp *DistSQLPlanner) planAndRunPostquery(
 	postqueryRecv := recv.clone()
 	defer postqueryRecv.Release()
 	defer addTopLevelQueryStats(&postqueryRecv.stats)
-	postqueryResultWriter := &errOnlyResultWriter{}
+	postqueryResultWriter := &errOnlyResultWriter{discardRows: discardRows}
 	postqueryRecv.resultWriter = postqueryResultWriter
 	postqueryRecv.batchWriter = postqueryResultWriter
 	finishedSetupFn, cleanup := getFinishedSetupFn(planner)
@@ -2373,7 +2395,8 @@ func (dsp *DistSQLPlanner) planAndRunChecksInParallel(
 			planner,
 			evalCtxFactory(true /* usedConcurrently */),
 			recv,
-			true, /* parallelCheck */
+			true,  /* parallelCheck */
+			false, /* discardRows */
 			getSaveFlowsFunc,
 			associateNodeWithComponents,
 			addTopLevelQueryStats,
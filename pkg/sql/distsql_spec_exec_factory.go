@@ -501,6 +501,11 @@ func (e *distSQLSpecExecFactory) constructAggregators(
 	reqOrdering exec.OutputOrdering,
 	isScalar bool,
 ) (exec.Node, error) {
+	for i := range aggregations {
+		if aggregations[i].UserDefined != nil {
+			return nil, unimplemented.NewWithIssue(47473, "experimental opt-driven distsql planning: user-defined aggregate")
+		}
+	}
 	physPlan, plan := getPhysPlan(input)
 	// planAggregators() itself decides whether to distribute the aggregation.
 	planCtx := e.getPlanCtx(shouldDistribute)
@@ -1051,12 +1056,17 @@ func (e *distSQLSpecExecFactory) ConstructCreateView(
 	columns colinfo.ResultColumns,
 	deps opt.SchemaDeps,
 	typeDeps opt.SchemaTypeDeps,
+	textSearchDeps opt.SchemaTextSearchDeps,
 ) (exec.Node, error) {
 	return nil, unimplemented.NewWithIssue(47473, "experimental opt-driven distsql planning: create view")
 }
 
 func (e *distSQLSpecExecFactory) ConstructCreateFunction(
-	schema cat.Schema, cf *tree.CreateRoutine, deps opt.SchemaDeps, typeDeps opt.SchemaTypeDeps,
+	schema cat.Schema,
+	cf *tree.CreateRoutine,
+	deps opt.SchemaDeps,
+	typeDeps opt.SchemaTypeDeps,
+	textSearchDeps opt.SchemaTextSearchDeps,
 ) (exec.Node, error) {
 	return nil, unimplemented.NewWithIssue(47473, "experimental opt-driven distsql planning: create function")
 }
@@ -1072,7 +1082,7 @@ func (e *distSQLSpecExecFactory) ConstructSaveTable(
 }
 
 func (e *distSQLSpecExecFactory) ConstructErrorIfRows(
-	input exec.Node, mkErr exec.MkErrFn,
+	input exec.Node, mkErr exec.MkErrFn, deferral *exec.CheckDeferral,
 ) (exec.Node, error) {
 	return nil, unimplemented.NewWithIssue(47473, "experimental opt-driven distsql planning: error if rows")
 }
@@ -77,6 +77,9 @@ func (p *planner) DropFunction(ctx context.Context, n *tree.DropRoutine) (ret pl
 		if err != nil {
 			return nil, err
 		}
+		if err := checkDropRoutineKind(n, mut); err != nil {
+			return nil, err
+		}
 		if n.DropBehavior != tree.DropCascade && len(mut.DependedOnBy) > 0 {
 			dependedOnByIDs := make([]descpb.ID, 0, len(mut.DependedOnBy))
 			for _, ref := range mut.DependedOnBy {
@@ -93,6 +96,28 @@ func (p *planner) DropFunction(ctx context.Context, n *tree.DropRoutine) (ret pl
 			)
 		}
 		dropNode.toDrop = append(dropNode.toDrop, mut)
+		if n.DropBehavior == tree.DropCascade {
+			// Aggregates which use the function as a support function are dropped
+			// along with it.
+			for _, ref := range mut.DependedOnBy {
+				if fnResolved.Contains(int(ref.ID)) {
+					continue
+				}
+				desc, err := p.Descriptors().ByIDWithLeased(p.txn).Get().Desc(ctx, ref.ID)
+				if err != nil {
+					return nil, err
+				}
+				if desc.DescriptorType() != catalog.Function {
+					continue
+				}
+				fnResolved.Add(int(ref.ID))
+				aggMut, err := p.checkPrivilegesForDropFunction(ctx, ref.ID)
+				if err != nil {
+					return nil, err
+				}
+				dropNode.toDrop = append(dropNode.toDrop, aggMut)
+			}
+		}
 	}
 
 	if len(dropNode.toDrop) == 0 {
@@ -104,6 +129,22 @@ func (p *planner) DropFunction(ctx context.Context, n *tree.DropRoutine) (ret pl
 	return dropNode, nil
 }
 
+// checkDropRoutineKind returns an error if a DROP AGGREGATE statement targets
+// a function that is not an aggregate, or if a DROP FUNCTION statement targets
+// an aggregate.
+func checkDropRoutineKind(n *tree.DropRoutine, fnDesc catalog.FunctionDescriptor) error {
+	if n.Aggregate && !fnDesc.IsAggregate() {
+		return pgerror.Newf(pgcode.WrongObjectType, "function %s is not an aggregate", fnDesc.GetName())
+	}
+	if !n.Aggregate && !n.Procedure && fnDesc.IsAggregate() {
+		return errors.WithHint(
+			pgerror.Newf(pgcode.WrongObjectType, "%q is an aggregate function", fnDesc.GetName()),
+			"Use DROP AGGREGATE to drop aggregate functions.",
+		)
+	}
+	return nil
+}
+
 func (n *dropFunctionNode) startExec(params runParams) error {
 	for _, fnMutable := range n.toDrop {
 		if err := params.p.dropFunctionImpl(params.ctx, fnMutable); err != nil {
@@ -141,7 +182,7 @@ func (p *planner) matchRoutine(
 		return nil, err
 	}
 
-	paramTypes, err := routineObj.ParamTypes(ctx, p)
+	paramTypes, err := routineObj.ParamTypes(ctx, p, routineType)
 	if err != nil {
 		return nil, err
 	}
@@ -237,6 +278,11 @@ func (p *planner) dropFunctionImpl(ctx context.Context, fnMutable *funcdesc.Muta
 		return err
 	}
 
+	// Remove backreferences from the support functions of an aggregate.
+	if err := removeAggregateSupportFuncReferences(ctx, p, fnMutable); err != nil {
+		return err
+	}
+
 	// Remove function signature from schema.
 	scDesc, err := p.Descriptors().MutableByID(p.Txn()).Schema(ctx, fnMutable.ParentSchemaID)
 	if err != nil {
@@ -60,10 +60,14 @@ func (p *planner) DropTable(ctx context.Context, n *tree.DropTable) (planNode, e
 		return nil, err
 	}
 
+	requiredKind := tree.ResolveRequireTableDesc
+	if n.IsForeign {
+		requiredKind = tree.ResolveRequireForeignTableDesc
+	}
 	td := make(map[descpb.ID]toDelete, len(n.Names))
 	for i := range n.Names {
 		tn := &n.Names[i]
-		droppedDesc, err := p.prepareDrop(ctx, tn, !n.IfExists, tree.ResolveRequireTableDesc)
+		droppedDesc, err := p.prepareDrop(ctx, tn, !n.IfExists, requiredKind)
 		if err != nil {
 			return nil, err
 		}
@@ -108,7 +112,11 @@ func (p *planner) DropTable(ctx context.Context, n *tree.DropTable) (planNode, e
 func (n *dropTableNode) ReadingOwnWrites() {}
 
 func (n *dropTableNode) startExec(params runParams) error {
-	telemetry.Inc(sqltelemetry.SchemaChangeDropCounter("table"))
+	if n.n.IsForeign {
+		telemetry.Inc(sqltelemetry.SchemaChangeDropCounter("foreign_table"))
+	} else {
+		telemetry.Inc(sqltelemetry.SchemaChangeDropCounter("table"))
+	}
 
 	ctx := params.ctx
 	for _, toDel := range n.td {
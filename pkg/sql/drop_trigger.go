@@ -0,0 +1,79 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+type dropTriggerNode struct {
+	n         *tree.DropTrigger
+	tableDesc *tabledesc.Mutable
+}
+
+// DropTrigger drops a trigger from a table.
+// Privileges: CREATE on table.
+func (p *planner) DropTrigger(ctx context.Context, n *tree.DropTrigger) (planNode, error) {
+	if err := checkSchemaChangeEnabled(ctx, p.ExecCfg(), "DROP TRIGGER"); err != nil {
+		return nil, err
+	}
+	tn := n.Table.ToTableName()
+	_, tableDesc, err := p.ResolveMutableTableDescriptor(
+		ctx, &tn, !n.IfExists, tree.ResolveRequireTableDesc,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if tableDesc == nil {
+		return newZeroNode(nil /* columns */), nil
+	}
+	if err := p.CheckPrivilege(ctx, tableDesc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+	return &dropTriggerNode{n: n, tableDesc: tableDesc}, nil
+}
+
+func (n *dropTriggerNode) startExec(params runParams) error {
+	for i := range n.tableDesc.Triggers {
+		trig := &n.tableDesc.Triggers[i]
+		if trig.Name != string(n.n.Trigger) {
+			continue
+		}
+		if err := removeTriggerBackReference(params.ctx, params.p, n.tableDesc, trig); err != nil {
+			return err
+		}
+		n.tableDesc.Triggers = append(n.tableDesc.Triggers[:i], n.tableDesc.Triggers[i+1:]...)
+		return params.p.writeSchemaChange(
+			params.ctx, n.tableDesc, descpb.InvalidMutationID, tree.AsStringWithFQNames(n.n, params.Ann()),
+		)
+	}
+	if n.n.IfExists {
+		params.p.BufferClientNotice(params.ctx, pgnotice.Newf(
+			"trigger %q for relation %q does not exist, skipping", n.n.Trigger, n.tableDesc.GetName(),
+		))
+		return nil
+	}
+	return pgerror.Newf(pgcode.UndefinedObject,
+		"trigger %q for table %q does not exist", n.n.Trigger, n.tableDesc.GetName())
+}
+
+func (n *dropTriggerNode) Next(params runParams) (bool, error) { return false, nil }
+func (n *dropTriggerNode) Values() tree.Datums                 { return tree.Datums{} }
+func (n *dropTriggerNode) Close(ctx context.Context)           {}
+func (n *dropTriggerNode) ReadingOwnWrites()                   {}
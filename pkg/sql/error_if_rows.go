@@ -26,6 +26,9 @@ type errorIfRowsNode struct {
 	// produced.
 	mkErr exec.MkErrFn
 
+	// deferral is set if the check enforces a DEFERRABLE constraint.
+	deferral *exec.CheckDeferral
+
 	nexted bool
 }
 
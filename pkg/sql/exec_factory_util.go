@@ -79,6 +79,9 @@ func constructPlan(
 		res.checkPlans = make([]checkPlan, len(checks))
 		for i := range checks {
 			assignPlan(&res.checkPlans[i].plan, checks[i])
+			if n, ok := checks[i].(*errorIfRowsNode); ok {
+				res.checkPlans[i].deferral = n.deferral
+			}
 		}
 	}
 
@@ -230,25 +233,38 @@ func constructVirtualScan(
 	delayedNodeCallback func(*delayedNode) (exec.Node, error),
 ) (exec.Node, error) {
 	tn := &table.(*optVirtualTable).name
-	virtual, err := p.getVirtualTabler().getVirtualTableEntry(tn, p)
-	if err != nil {
-		return nil, err
-	}
-	if !canQueryVirtualTable(p.EvalContext(), virtual) {
-		return nil, newUnimplementedVirtualTableError(tn.Schema(), tn.Table())
+	desc := table.(*optVirtualTable).desc
+	var columns colinfo.ResultColumns
+	var constructor nodeConstructor
+	if desc.IsForeignTable() {
+		// Foreign tables are planned as virtual tables whose rows are read from
+		// their external files.
+		columns = colinfo.ResultColumnsFromColumns(desc.GetID(), desc.PublicColumns())
+		constructor = func(ctx context.Context, p *planner) (planNode, error) {
+			return p.newForeignScanNode(ctx, desc)
+		}
+	} else {
+		virtual, err := p.getVirtualTabler().getVirtualTableEntry(tn, p)
+		if err != nil {
+			return nil, err
+		}
+		if !canQueryVirtualTable(p.EvalContext(), virtual) {
+			return nil, newUnimplementedVirtualTableError(tn.Schema(), tn.Table())
+		}
+		idx := index.(*optVirtualIndex).idx
+		var virtualConstructor virtualTableConstructor
+		columns, virtualConstructor = virtual.getPlanInfo(
+			desc, idx, params.IndexConstraint, p.execCfg.DistSQLPlanner.stopper)
+		constructor = func(ctx context.Context, p *planner) (planNode, error) {
+			return virtualConstructor(ctx, p, tn.Catalog())
+		}
 	}
-	idx := index.(*optVirtualIndex).idx
-	columns, constructor := virtual.getPlanInfo(
-		table.(*optVirtualTable).desc,
-		idx, params.IndexConstraint, p.execCfg.DistSQLPlanner.stopper)
 
 	n, err := delayedNodeCallback(&delayedNode{
 		name:            fmt.Sprintf("%s@%s", table.Name(), index.Name()),
 		columns:         columns,
 		indexConstraint: params.IndexConstraint,
-		constructor: func(ctx context.Context, p *planner) (planNode, error) {
-			return constructor(ctx, p, tn.Catalog())
-		},
+		constructor:     constructor,
 	})
 	if err != nil {
 		return nil, err
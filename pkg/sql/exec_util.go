@@ -1137,6 +1137,12 @@ var (
 		Measurement: "SQL Transaction Stats Collection Overhead",
 		Unit:        metric.Unit_NANOSECONDS,
 	}
+	MetaNotificationsDropped = metric.Metadata{
+		Name:        "sql.notifications.dropped",
+		Help:        "Number of notifications that were not sent to other nodes because too many were waiting to be sent",
+		Measurement: "Notifications",
+		Unit:        metric.Unit_COUNT,
+	}
 	MetaTxnRowsWrittenLog = metric.Metadata{
 		Name:        "sql.guardrails.transaction_rows_written_log.count",
 		Help:        "Number of transactions logged because of transaction_rows_written_log guardrail",
@@ -2006,6 +2012,10 @@ func checkResultType(typ *types.T) error {
 	case types.TimestampTZFamily:
 	case types.TSQueryFamily:
 	case types.TSVectorFamily:
+	case types.JsonpathFamily:
+	case types.GeometricFamily:
+	case types.RangeFamily:
+	case types.MultirangeFamily:
 	case types.IntervalFamily:
 	case types.JsonFamily:
 	case types.UuidFamily:
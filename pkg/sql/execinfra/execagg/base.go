@@ -81,6 +81,12 @@ func GetAggregateConstructor(
 	aggInfo *execinfrapb.AggregatorSpec_Aggregation,
 	inputTypes []*types.T,
 ) (constructor AggregateConstructor, arguments tree.Datums, outputType *types.T, err error) {
+	if aggInfo.Func == execinfrapb.UserDefined {
+		// The arguments of a user-defined aggregate are its support routines,
+		// which must not be evaluated here.
+		constructor, outputType, err = getUserDefinedAggregateConstructor(ctx, aggInfo)
+		return constructor, nil /* arguments */, outputType, err
+	}
 	argTypes := make([]*types.T, len(aggInfo.ColIdx)+len(aggInfo.Arguments))
 	for j, c := range aggInfo.ColIdx {
 		if c >= uint32(len(inputTypes)) {
@@ -0,0 +1,202 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package execagg
+
+import (
+	"context"
+	"unsafe"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// The arguments of a USER_DEFINED aggregation are the routine that computes
+// the next state, the routine that computes the result from the final state
+// (or NULL if the final state is the result), and the initial state.
+const (
+	userDefinedTransitionFuncArgIdx = iota
+	userDefinedFinalFuncArgIdx
+	userDefinedInitCondArgIdx
+	numUserDefinedArgs
+)
+
+// MakeUserDefinedAggregateArguments returns the arguments of a USER_DEFINED
+// aggregation. transitionFunc computes the next state from the current state
+// and the input of a row. finalFunc, if non-nil, computes the result from the
+// final state. initCond is the initial state.
+func MakeUserDefinedAggregateArguments(
+	transitionFunc, finalFunc *tree.RoutineExpr, initCond tree.Datum,
+) []tree.TypedExpr {
+	args := make([]tree.TypedExpr, numUserDefinedArgs)
+	args[userDefinedTransitionFuncArgIdx] = transitionFunc
+	args[userDefinedFinalFuncArgIdx] = tree.DNull
+	if finalFunc != nil {
+		args[userDefinedFinalFuncArgIdx] = finalFunc
+	}
+	args[userDefinedInitCondArgIdx] = initCond
+	return args
+}
+
+// unpackUserDefinedAggregate returns the routines and the initial state of a
+// USER_DEFINED aggregation. The routines cannot be serialized, so the
+// aggregation must be planned on the gateway.
+func unpackUserDefinedAggregate(
+	aggInfo *execinfrapb.AggregatorSpec_Aggregation,
+) (transitionFunc, finalFunc *tree.RoutineExpr, initCond tree.Datum, err error) {
+	if len(aggInfo.Arguments) != numUserDefinedArgs {
+		return nil, nil, nil, errors.AssertionFailedf(
+			"expected %d arguments for user-defined aggregate, found %d",
+			numUserDefinedArgs, len(aggInfo.Arguments),
+		)
+	}
+	var ok bool
+	transitionFunc, ok = aggInfo.Arguments[userDefinedTransitionFuncArgIdx].LocalExpr.(*tree.RoutineExpr)
+	if !ok {
+		return nil, nil, nil, errors.AssertionFailedf(
+			"user-defined aggregate can only be evaluated on the gateway",
+		)
+	}
+	finalFunc, _ = aggInfo.Arguments[userDefinedFinalFuncArgIdx].LocalExpr.(*tree.RoutineExpr)
+	initCond, ok = aggInfo.Arguments[userDefinedInitCondArgIdx].LocalExpr.(tree.Datum)
+	if !ok {
+		return nil, nil, nil, errors.AssertionFailedf(
+			"expected constant initial state for user-defined aggregate",
+		)
+	}
+	return transitionFunc, finalFunc, initCond, nil
+}
+
+// UserDefinedAggregateStateType returns the type of the state of the given
+// USER_DEFINED aggregation.
+func UserDefinedAggregateStateType(
+	aggInfo *execinfrapb.AggregatorSpec_Aggregation,
+) (*types.T, error) {
+	transitionFunc, _, _, err := unpackUserDefinedAggregate(aggInfo)
+	if err != nil {
+		return nil, err
+	}
+	return transitionFunc.ResolvedType(), nil
+}
+
+// UserDefinedAggregateResultType returns the result type of the given
+// USER_DEFINED aggregation.
+func UserDefinedAggregateResultType(
+	aggInfo *execinfrapb.AggregatorSpec_Aggregation,
+) (*types.T, error) {
+	transitionFunc, finalFunc, _, err := unpackUserDefinedAggregate(aggInfo)
+	if err != nil {
+		return nil, err
+	}
+	if finalFunc != nil {
+		return finalFunc.ResolvedType(), nil
+	}
+	return transitionFunc.ResolvedType(), nil
+}
+
+// getUserDefinedAggregateConstructor returns the constructor and the result
+// type of a USER_DEFINED aggregation.
+func getUserDefinedAggregateConstructor(
+	ctx context.Context, aggInfo *execinfrapb.AggregatorSpec_Aggregation,
+) (AggregateConstructor, *types.T, error) {
+	transitionFunc, finalFunc, initCond, err := unpackUserDefinedAggregate(aggInfo)
+	if err != nil {
+		return nil, nil, err
+	}
+	outputType := transitionFunc.ResolvedType()
+	if finalFunc != nil {
+		outputType = finalFunc.ResolvedType()
+	}
+	constructor := func(evalCtx *eval.Context, _ tree.Datums) eval.AggregateFunc {
+		return &userDefinedAggregate{
+			ctx:            ctx,
+			evalCtx:        evalCtx,
+			transitionFunc: transitionFunc,
+			finalFunc:      finalFunc,
+			initCond:       initCond,
+			state:          initCond,
+		}
+	}
+	return constructor, outputType, nil
+}
+
+// userDefinedAggregate evaluates an aggregate created with CREATE AGGREGATE by
+// invoking its support routines. It follows the semantics of Postgres: if the
+// transition function is strict, rows with NULL inputs are skipped, and, while
+// the state is NULL, the first non-NULL input becomes the state.
+type userDefinedAggregate struct {
+	// ctx is the context of the most recent call to Add. It is used to evaluate
+	// the final function in Result.
+	ctx            context.Context
+	evalCtx        *eval.Context
+	transitionFunc *tree.RoutineExpr
+	finalFunc      *tree.RoutineExpr
+	initCond       tree.Datum
+	state          tree.Datum
+}
+
+var _ eval.AggregateFunc = &userDefinedAggregate{}
+
+const sizeOfUserDefinedAggregate = int64(unsafe.Sizeof(userDefinedAggregate{}))
+
+// Add is part of the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Add(
+	ctx context.Context, firstArg tree.Datum, otherArgs ...tree.Datum,
+) error {
+	a.ctx = ctx
+	if !a.transitionFunc.CalledOnNullInput {
+		if firstArg == tree.DNull {
+			return nil
+		}
+		for _, arg := range otherArgs {
+			if arg == tree.DNull {
+				return nil
+			}
+		}
+		if a.state == tree.DNull {
+			a.state = firstArg
+			return nil
+		}
+	}
+	args := make(tree.Datums, 0, len(otherArgs)+2)
+	args = append(args, a.state, firstArg)
+	args = append(args, otherArgs...)
+	state, err := a.evalCtx.Planner.EvalRoutineExpr(ctx, a.transitionFunc, args)
+	if err != nil {
+		return err
+	}
+	a.state = state
+	return nil
+}
+
+// Result is part of the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Result() (tree.Datum, error) {
+	if a.finalFunc == nil {
+		return a.state, nil
+	}
+	return a.evalCtx.Planner.EvalRoutineExpr(a.ctx, a.finalFunc, tree.Datums{a.state})
+}
+
+// Reset is part of the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Reset(ctx context.Context) {
+	a.ctx = ctx
+	a.state = a.initCond
+}
+
+// Close is part of the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Close(context.Context) {}
+
+// Size is part of the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Size() int64 {
+	return sizeOfUserDefinedAggregate
+}
@@ -77,4 +77,5 @@ const (
 	MergeStatsMetadata      = AggregatorSpec_MERGE_STATS_METADATA
 	MergeStatementStats     = AggregatorSpec_MERGE_STATEMENT_STATS
 	MergeTransactionStats   = AggregatorSpec_MERGE_TRANSACTION_STATS
+	UserDefined             = AggregatorSpec_USER_DEFINED
 )
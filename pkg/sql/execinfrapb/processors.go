@@ -59,6 +59,18 @@ func (a AggregatorSpec_Aggregation) Equals(b AggregatorSpec_Aggregation) bool {
 			return false
 		}
 	}
+	if a.Func == UserDefined {
+		// User-defined aggregates are identified by the support routines that
+		// are passed as their arguments.
+		if len(a.Arguments) != len(b.Arguments) {
+			return false
+		}
+		for i := range a.Arguments {
+			if a.Arguments[i].LocalExpr != b.Arguments[i].LocalExpr {
+				return false
+			}
+		}
+	}
 	return true
 }
 
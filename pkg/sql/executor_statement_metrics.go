@@ -89,6 +89,17 @@ var _ metric.Struct = StatsMetrics{}
 // MetricStruct is part of the metric.Struct interface.
 func (StatsMetrics) MetricStruct() {}
 
+// NotificationMetrics groups metrics related to asynchronous notifications.
+type NotificationMetrics struct {
+	DroppedCount *metric.Counter
+}
+
+// NotificationMetrics is part of the metric.Struct interface.
+var _ metric.Struct = NotificationMetrics{}
+
+// MetricStruct is part of the metric.Struct interface.
+func (NotificationMetrics) MetricStruct() {}
+
 // GuardrailMetrics groups metrics related to different guardrails in the SQL
 // layer.
 type GuardrailMetrics struct {
@@ -34,6 +34,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/mon"
 	"github.com/cockroachdb/cockroach/pkg/util/rangedesc"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/cockroachdb/errors"
 	"github.com/lib/pq/oid"
 )
@@ -350,6 +351,18 @@ func (*DummyEvalPlanner) PLpgSQLFetchCursor(
 	return nil, errors.WithStack(errEvalPlanner)
 }
 
+// PLpgSQLOpenDynamicCursor is part of the eval.Planner interface.
+func (*DummyEvalPlanner) PLpgSQLOpenDynamicCursor(
+	context.Context, tree.Name, bool, string, tree.Datums,
+) error {
+	return errors.WithStack(errEvalPlanner)
+}
+
+// PLpgSQLReturnNext is part of the eval.Planner interface.
+func (*DummyEvalPlanner) PLpgSQLReturnNext(context.Context, tree.Datum) error {
+	return errors.WithStack(errEvalPlanner)
+}
+
 var _ eval.Planner = &DummyEvalPlanner{}
 
 var errEvalPlanner = pgerror.New(pgcode.ScalarOperationCannotRunWithoutFullSessionContext,
@@ -537,6 +550,23 @@ func (ep *DummyEvalPlanner) GetDetailsForSpanStats(
 func (ep *DummyEvalPlanner) MaybeReallocateAnnotations(numAnnotations tree.AnnotationIdx) {
 }
 
+// QueueNotification is part of the eval.Planner interface.
+func (*DummyEvalPlanner) QueueNotification(context.Context, string, string) error {
+	return errors.WithStack(errEvalPlanner)
+}
+
+// ResolveTextSearchConfig is part of the eval.Planner interface.
+func (*DummyEvalPlanner) ResolveTextSearchConfig(context.Context, string) (*tsearch.Config, error) {
+	return nil, errors.WithStack(errEvalPlanner)
+}
+
+// ResolveTextSearchDictionary is part of the eval.Planner interface.
+func (*DummyEvalPlanner) ResolveTextSearchDictionary(
+	context.Context, string,
+) (*tsearch.Dictionary, error) {
+	return nil, errors.WithStack(errEvalPlanner)
+}
+
 // AutoCommit is part of the eval.Planner interface.
 func (ep *DummyEvalPlanner) AutoCommit() bool {
 	return false
@@ -0,0 +1,298 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	// foreignOptionURI is the option holding the external storage URI of the
+	// files of a foreign table. A URI ending in '/' refers to all the files
+	// under that prefix.
+	foreignOptionURI = "uri"
+	// foreignOptionFormat is the option holding the name of the format of the
+	// files of a foreign table.
+	foreignOptionFormat = "format"
+)
+
+// ForeignTableFormat describes a file format which the rows of foreign tables
+// can be read from.
+type ForeignTableFormat struct {
+	// Options are the names of the format-specific options accepted in the
+	// OPTIONS clauses of foreign servers and tables.
+	Options []string
+	// ValidateOptions, if set, checks the values of the options of a foreign
+	// table using the format.
+	ValidateOptions func(options map[string]string) error
+	// NewReader opens a reader over the rows of a single file.
+	NewReader func(ctx context.Context, evalCtx *eval.Context, file ForeignTableFile) (ForeignTableReader, error)
+}
+
+// ForeignTableFile is a file containing rows of a foreign table.
+type ForeignTableFile struct {
+	// Store is the external storage holding the file.
+	Store cloud.ExternalStorage
+	// Name is the name of the file relative to Store, as passed to
+	// Store.ReadFile.
+	Name string
+	// Columns are the columns of the foreign table. Readers return rows with
+	// one datum of the column type per column.
+	Columns []catalog.Column
+	// Options are the options of the foreign table merged with those of its
+	// server.
+	Options map[string]string
+}
+
+// ForeignTableReader reads the rows of a file of a foreign table.
+type ForeignTableReader interface {
+	// Next returns the next row of the file, or nil once all the rows have been
+	// read.
+	Next(ctx context.Context) (tree.Datums, error)
+	// Close releases the resources held by the reader.
+	Close(ctx context.Context) error
+}
+
+var foreignTableFormats = map[string]ForeignTableFormat{}
+
+// RegisterForeignTableFormat registers a file format of foreign tables. It
+// must be called from an init function.
+func RegisterForeignTableFormat(name string, format ForeignTableFormat) {
+	if _, ok := foreignTableFormats[name]; ok {
+		panic(errors.AssertionFailedf("foreign table format %q already registered", name))
+	}
+	foreignTableFormats[name] = format
+}
+
+// isForeignOption returns whether key is the name of an option of foreign
+// servers and tables, either generic or specific to the given format. An empty
+// format accepts the options of all formats.
+func isForeignOption(key, format string) bool {
+	if key == foreignOptionURI || key == foreignOptionFormat {
+		return true
+	}
+	for name, f := range foreignTableFormats {
+		if format != "" && name != format {
+			continue
+		}
+		for _, opt := range f.Options {
+			if key == opt {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// lookupForeignTableFormat returns the registered format with the given name.
+func lookupForeignTableFormat(name string) (ForeignTableFormat, error) {
+	format, ok := foreignTableFormats[name]
+	if !ok {
+		return ForeignTableFormat{}, pgerror.Newf(pgcode.FdwInvalidAttributeValue,
+			"unsupported foreign table format %q", name)
+	}
+	return format, nil
+}
+
+// validateForeignServerOptions checks the options of a foreign server, which
+// may be shared by foreign tables of different formats.
+func validateForeignServerOptions(options []descpb.ForeignOption) error {
+	for _, opt := range options {
+		if !isForeignOption(opt.Key, "" /* format */) {
+			return pgerror.Newf(pgcode.FdwInvalidOptionName, "invalid option %q", opt.Key)
+		}
+		if opt.Key == foreignOptionFormat {
+			if _, err := lookupForeignTableFormat(opt.Value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateForeignTableOptions checks the options of a foreign table merged
+// with those of its server, and returns the format of its files.
+func validateForeignTableOptions(options map[string]string) (ForeignTableFormat, error) {
+	for _, key := range []string{foreignOptionURI, foreignOptionFormat} {
+		if _, ok := options[key]; !ok {
+			return ForeignTableFormat{}, pgerror.Newf(pgcode.FdwOptionNameNotFound,
+				"option %q is required", key)
+		}
+	}
+	format, err := lookupForeignTableFormat(options[foreignOptionFormat])
+	if err != nil {
+		return ForeignTableFormat{}, err
+	}
+	// The options of other formats are allowed, since they may be inherited
+	// from a server shared by foreign tables of different formats.
+	for key := range options {
+		if !isForeignOption(key, "" /* format */) {
+			return ForeignTableFormat{}, pgerror.Newf(pgcode.FdwInvalidOptionName,
+				"invalid option %q", key)
+		}
+	}
+	if format.ValidateOptions != nil {
+		if err := format.ValidateOptions(options); err != nil {
+			return ForeignTableFormat{}, err
+		}
+	}
+	return format, nil
+}
+
+// foreignTableOptions returns the options of a foreign table merged with
+// those of its server.
+func (p *planner) foreignTableOptions(
+	ctx context.Context, desc catalog.TableDescriptor,
+) (map[string]string, error) {
+	dbDesc, err := p.Descriptors().ByIDWithLeased(p.txn).Get().Database(ctx, desc.GetParentID())
+	if err != nil {
+		return nil, err
+	}
+	foreign := desc.GetForeignTable()
+	server := dbDesc.GetForeignServer(foreign.ServerName)
+	if server == nil {
+		return nil, pgerror.Newf(pgcode.UndefinedObject,
+			"server %q does not exist", foreign.ServerName)
+	}
+	return mergeForeignOptions(server.Options, foreign.Options), nil
+}
+
+// foreignScanNode is a planNode that reads the rows of a foreign table from
+// its files in external storage.
+type foreignScanNode struct {
+	columns colinfo.ResultColumns
+	desc    catalog.TableDescriptor
+	format  ForeignTableFormat
+	options map[string]string
+
+	// store is the external storage holding the files of the table, which are
+	// read one after the other.
+	store    cloud.ExternalStorage
+	files    []string
+	nextFile int
+	reader   ForeignTableReader
+
+	currentRow tree.Datums
+}
+
+func (p *planner) newForeignScanNode(
+	ctx context.Context, desc catalog.TableDescriptor,
+) (planNode, error) {
+	options, err := p.foreignTableOptions(ctx, desc)
+	if err != nil {
+		return nil, err
+	}
+	format, err := validateForeignTableOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	return &foreignScanNode{
+		columns: colinfo.ResultColumnsFromColumns(desc.GetID(), desc.PublicColumns()),
+		desc:    desc,
+		format:  format,
+		options: options,
+	}, nil
+}
+
+func (n *foreignScanNode) startExec(params runParams) error {
+	uri := n.options[foreignOptionURI]
+	store, err := params.p.execCfg.DistSQLSrv.ExternalStorageFromURI(params.ctx, uri, params.p.User())
+	if err != nil {
+		return err
+	}
+	n.store = store
+	if !strings.HasSuffix(uri, "/") {
+		n.files = []string{""}
+		return nil
+	}
+	if err := store.List(params.ctx, "", "", func(name string) error {
+		name = strings.TrimPrefix(name, "/")
+		if name != "" && !strings.HasSuffix(name, "/") {
+			n.files = append(n.files, name)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	sort.Strings(n.files)
+	return nil
+}
+
+func (n *foreignScanNode) Next(params runParams) (bool, error) {
+	for {
+		if err := params.p.cancelChecker.Check(); err != nil {
+			return false, err
+		}
+		if n.reader == nil {
+			if n.nextFile >= len(n.files) {
+				return false, nil
+			}
+			reader, err := n.format.NewReader(params.ctx, params.EvalContext(), ForeignTableFile{
+				Store:   n.store,
+				Name:    n.files[n.nextFile],
+				Columns: n.desc.PublicColumns(),
+				Options: n.options,
+			})
+			if err != nil {
+				return false, errors.Wrapf(err, "reading foreign table %q", n.desc.GetName())
+			}
+			n.nextFile++
+			n.reader = reader
+		}
+		row, err := n.reader.Next(params.ctx)
+		if err != nil {
+			return false, errors.Wrapf(err, "reading foreign table %q", n.desc.GetName())
+		}
+		if row == nil {
+			err := n.reader.Close(params.ctx)
+			n.reader = nil
+			if err != nil {
+				return false, err
+			}
+			continue
+		}
+		for i, col := range n.desc.PublicColumns() {
+			if row[i] == tree.DNull && !col.IsNullable() {
+				return false, sqlerrors.NewNonNullViolationError(col.GetName())
+			}
+		}
+		n.currentRow = row
+		return true, nil
+	}
+}
+
+func (n *foreignScanNode) Values() tree.Datums {
+	return n.currentRow
+}
+
+func (n *foreignScanNode) Close(ctx context.Context) {
+	if n.reader != nil {
+		_ = n.reader.Close(ctx)
+		n.reader = nil
+	}
+	if n.store != nil {
+		_ = n.store.Close()
+		n.store = nil
+	}
+}
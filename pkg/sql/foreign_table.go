@@ -0,0 +1,540 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catprivilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/dbdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/errors"
+)
+
+// externalStorageForeignDataWrapper is the name of the only foreign data
+// wrapper, which reads the rows of foreign tables from files in external
+// storage.
+const externalStorageForeignDataWrapper = "external_storage"
+
+type createForeignServerNode struct {
+	n      *tree.CreateForeignServer
+	dbDesc *dbdesc.Mutable
+	server descpb.DatabaseDescriptor_ForeignServer
+}
+
+// CreateForeignServer creates a foreign server in the current database.
+// Privileges: admin role.
+//
+//	notes: postgres requires USAGE on the foreign data wrapper, which only
+//	       superusers have by default.
+func (p *planner) CreateForeignServer(
+	ctx context.Context, n *tree.CreateForeignServer,
+) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE SERVER",
+	); err != nil {
+		return nil, err
+	}
+	if hasAdmin, err := p.HasAdminRole(ctx); err != nil {
+		return nil, err
+	} else if !hasAdmin {
+		return nil, pgerror.Newf(pgcode.InsufficientPrivilege,
+			"only users with the admin role are allowed to CREATE SERVER")
+	}
+	if n.Wrapper != externalStorageForeignDataWrapper {
+		return nil, pgerror.Newf(pgcode.UndefinedObject,
+			"foreign-data wrapper %q does not exist", n.Wrapper)
+	}
+
+	dbDesc, err := p.Descriptors().MutableByName(p.txn).Database(ctx, p.CurrentDatabase())
+	if err != nil {
+		return nil, err
+	}
+	options, err := makeForeignOptions(n.Options)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateForeignServerOptions(options); err != nil {
+		return nil, err
+	}
+	return &createForeignServerNode{
+		n:      n,
+		dbDesc: dbDesc,
+		server: descpb.DatabaseDescriptor_ForeignServer{
+			Name:       string(n.Name),
+			OwnerProto: p.User().EncodeProto(),
+			Wrapper:    string(n.Wrapper),
+			Options:    options,
+		},
+	}, nil
+}
+
+func (n *createForeignServerNode) startExec(params runParams) error {
+	if n.dbDesc.GetForeignServer(n.server.Name) != nil {
+		if n.n.IfNotExists {
+			params.p.BufferClientNotice(
+				params.ctx,
+				pgnotice.Newf("server %q already exists, skipping", n.server.Name),
+			)
+			return nil
+		}
+		return pgerror.Newf(pgcode.DuplicateObject,
+			"server %q already exists", n.server.Name)
+	}
+	n.dbDesc.ForeignServers = append(n.dbDesc.ForeignServers, n.server)
+	return params.p.writeNonDropDatabaseChange(
+		params.ctx,
+		n.dbDesc,
+		redactedForeignStatementString(n.n, params.Ann()),
+	)
+}
+
+func (n *createForeignServerNode) Next(runParams) (bool, error) { return false, nil }
+func (n *createForeignServerNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *createForeignServerNode) Close(context.Context)        {}
+
+type dropForeignServerNode struct {
+	n      *tree.DropForeignServer
+	dbDesc *dbdesc.Mutable
+	names  []string
+	// tables are the foreign tables which use the dropped servers; they are
+	// only dropped with CASCADE.
+	tables []*tabledesc.Mutable
+}
+
+// DropForeignServer drops foreign servers of the current database.
+// Privileges: ownership of the servers. Dropping the foreign tables which use
+// the servers with CASCADE additionally requires the privileges of DROP
+// FOREIGN TABLE.
+//
+//	notes: postgres requires ownership of the servers.
+func (p *planner) DropForeignServer(
+	ctx context.Context, n *tree.DropForeignServer,
+) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"DROP SERVER",
+	); err != nil {
+		return nil, err
+	}
+
+	dbDesc, err := p.Descriptors().MutableByName(p.txn).Database(ctx, p.CurrentDatabase())
+	if err != nil {
+		return nil, err
+	}
+	hasAdmin, err := p.HasAdminRole(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, name := range n.Names {
+		server := dbDesc.GetForeignServer(string(name))
+		if server == nil {
+			if n.IfExists {
+				continue
+			}
+			return nil, pgerror.Newf(pgcode.UndefinedObject,
+				"server %q does not exist", name)
+		}
+		if owner := server.OwnerProto.Decode(); !hasAdmin && owner != p.User() {
+			memberOf, err := p.MemberOfWithAdminOption(ctx, p.User())
+			if err != nil {
+				return nil, err
+			}
+			if _, found := memberOf[owner]; !found {
+				return nil, pgerror.Newf(pgcode.InsufficientPrivilege,
+					"must be owner of server %s", name)
+			}
+		}
+		names = append(names, string(name))
+	}
+	if len(names) == 0 {
+		return newZeroNode(nil /* columns */), nil
+	}
+
+	tables, err := p.foreignTablesUsingServers(ctx, dbDesc, names)
+	if err != nil {
+		return nil, err
+	}
+	for _, table := range tables {
+		if n.DropBehavior != tree.DropCascade {
+			return nil, errors.WithHint(
+				errors.WithDetailf(
+					pgerror.Newf(pgcode.DependentObjectsStillExist,
+						"cannot drop server %s because other objects depend on it",
+						tree.Name(table.ForeignTable.ServerName)),
+					"foreign table %s depends on server %s",
+					tree.Name(table.GetName()), tree.Name(table.ForeignTable.ServerName)),
+				"use DROP ... CASCADE to drop the dependent objects too")
+		}
+		if err := p.canDropTable(ctx, table, true /* checkOwnership */); err != nil {
+			return nil, err
+		}
+	}
+
+	return &dropForeignServerNode{n: n, dbDesc: dbDesc, names: names, tables: tables}, nil
+}
+
+// foreignTablesUsingServers returns the foreign tables of the database which
+// use one of the given servers.
+func (p *planner) foreignTablesUsingServers(
+	ctx context.Context, dbDesc catalog.DatabaseDescriptor, servers []string,
+) ([]*tabledesc.Mutable, error) {
+	all, err := p.Descriptors().GetAllTablesInDatabase(ctx, p.txn, dbDesc)
+	if err != nil {
+		return nil, err
+	}
+	var tables []*tabledesc.Mutable
+	if err := all.ForEachDescriptor(func(desc catalog.Descriptor) error {
+		table, ok := desc.(catalog.TableDescriptor)
+		if !ok || !table.IsForeignTable() || table.Dropped() {
+			return nil
+		}
+		for _, server := range servers {
+			if table.GetForeignTable().ServerName != server {
+				continue
+			}
+			mut, err := p.Descriptors().MutableByID(p.txn).Table(ctx, table.GetID())
+			if err != nil {
+				return err
+			}
+			tables = append(tables, mut)
+			break
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return tables, nil
+}
+
+// ReadingOwnWrites implements the planNodeReadingOwnWrites interface.
+// This is because DROP SERVER CASCADE drops the foreign tables which use the
+// servers and expects to see its own writes.
+func (n *dropForeignServerNode) ReadingOwnWrites() {}
+
+func (n *dropForeignServerNode) startExec(params runParams) error {
+	for _, table := range n.tables {
+		if table.Dropped() {
+			continue
+		}
+		tn, err := params.p.getQualifiedTableName(params.ctx, table)
+		if err != nil {
+			return err
+		}
+		if _, err := params.p.dropTableImpl(
+			params.ctx,
+			table,
+			false, /* droppingParent */
+			tree.AsStringWithFQNames(n.n, params.Ann()),
+			tree.DropCascade,
+		); err != nil {
+			return err
+		}
+		if err := params.p.logEvent(params.ctx,
+			table.ID,
+			&eventpb.DropTable{
+				TableName: tn.FQString(),
+			}); err != nil {
+			return err
+		}
+	}
+	for _, name := range n.names {
+		n.dbDesc.RemoveForeignServer(name)
+	}
+	return params.p.writeNonDropDatabaseChange(
+		params.ctx,
+		n.dbDesc,
+		tree.AsStringWithFQNames(n.n, params.Ann()),
+	)
+}
+
+func (n *dropForeignServerNode) Next(runParams) (bool, error) { return false, nil }
+func (n *dropForeignServerNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *dropForeignServerNode) Close(context.Context)        {}
+
+type createForeignTableNode struct {
+	n      *tree.CreateForeignTable
+	dbDesc catalog.DatabaseDescriptor
+}
+
+// CreateForeignTable creates a foreign table, whose rows are read from files
+// in external storage whenever it is scanned.
+// Privileges: CREATE on the schema, and access to the external storage URI of
+// the table.
+//
+//	notes: postgres requires CREATE on the schema and USAGE on the server.
+func (p *planner) CreateForeignTable(
+	ctx context.Context, n *tree.CreateForeignTable,
+) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE FOREIGN TABLE",
+	); err != nil {
+		return nil, err
+	}
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V24_1) {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"version %v must be finalized to create foreign tables",
+			clusterversion.ByKey(clusterversion.V24_1))
+	}
+
+	un := n.Table.ToUnresolvedObjectName()
+	dbDesc, _, prefix, err := p.ResolveTargetObject(ctx, un)
+	if err != nil {
+		return nil, err
+	}
+	n.Table.ObjectNamePrefix = prefix
+	return &createForeignTableNode{n: n, dbDesc: dbDesc}, nil
+}
+
+// ReadingOwnWrites implements the planNodeReadingOwnWrites interface.
+// This is because CREATE FOREIGN TABLE performs multiple KV operations on
+// descriptors and expects to see its own writes.
+func (n *createForeignTableNode) ReadingOwnWrites() {}
+
+func (n *createForeignTableNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("foreign_table"))
+	p := params.p
+
+	schema, err := getSchemaForCreateTable(params, n.dbDesc, tree.PersistencePermanent, &n.n.Table,
+		tree.ResolveRequireForeignTableDesc, n.n.IfNotExists)
+	if err != nil {
+		if sqlerrors.IsRelationAlreadyExistsError(err) && n.n.IfNotExists {
+			p.BufferClientNotice(
+				params.ctx,
+				pgnotice.Newf("relation %q already exists, skipping", n.n.Table.Table()),
+			)
+			return nil
+		}
+		return err
+	}
+	if schema.SchemaKind() == catalog.SchemaTemporary {
+		return pgerror.New(pgcode.FeatureNotSupported,
+			"cannot create temporary foreign tables")
+	}
+	if err := p.canCreateOnSchema(
+		params.ctx, schema.GetID(), n.dbDesc.GetID(), p.User(), checkPublicSchema,
+	); err != nil {
+		return err
+	}
+
+	server := n.dbDesc.GetForeignServer(string(n.n.Server))
+	if server == nil {
+		return pgerror.Newf(pgcode.UndefinedObject,
+			"server %q does not exist", n.n.Server)
+	}
+	tableOptions, err := makeForeignOptions(n.n.Options)
+	if err != nil {
+		return err
+	}
+	options := mergeForeignOptions(server.Options, tableOptions)
+	if _, err := validateForeignTableOptions(options); err != nil {
+		return err
+	}
+	for _, opt := range tableOptions {
+		if !isForeignOption(opt.Key, options[foreignOptionFormat]) {
+			return pgerror.Newf(pgcode.FdwInvalidOptionName,
+				"invalid option %q for format %s", opt.Key, options[foreignOptionFormat])
+		}
+	}
+	if err := checkExternalStorageURIPrivileges(params.ctx, p, options[foreignOptionURI]); err != nil {
+		return err
+	}
+
+	id, err := params.EvalContext().DescIDGenerator.GenerateUniqueDescID(params.ctx)
+	if err != nil {
+		return err
+	}
+	privs, err := catprivilege.CreatePrivilegesFromDefaultPrivileges(
+		n.dbDesc.GetDefaultPrivilegeDescriptor(),
+		schema.GetDefaultPrivilegeDescriptor(),
+		n.dbDesc.GetID(),
+		params.SessionData().User(),
+		privilege.Tables,
+	)
+	if err != nil {
+		return err
+	}
+	// creationTime is initialized to a zero value and populated at read time.
+	// See the comment in desc.MaybeIncrementVersion.
+	var creationTime hlc.Timestamp
+	desc := tabledesc.InitTableDescriptor(
+		id,
+		n.dbDesc.GetID(),
+		schema.GetID(),
+		n.n.Table.Table(),
+		creationTime,
+		privs,
+		tree.PersistencePermanent,
+	)
+	desc.ForeignTable = &descpb.TableDescriptor_ForeignTable{
+		ServerName: server.Name,
+		Options:    tableOptions,
+	}
+	if n.dbDesc.IsMultiRegion() {
+		desc.SetTableLocalityRegionalByTable(tree.PrimaryRegionNotSpecifiedName)
+	}
+	for _, def := range n.n.Defs {
+		d, ok := def.(*tree.ColumnTableDef)
+		if !ok {
+			return pgerror.New(pgcode.FeatureNotSupported,
+				"foreign tables can only contain column definitions")
+		}
+		if d.HasDefaultExpr() || d.HasOnUpdateExpr() || d.IsComputed() || d.IsSerial ||
+			d.GeneratedIdentity.IsGeneratedAsIdentity || d.PrimaryKey.IsPrimaryKey ||
+			d.Unique.IsUnique || len(d.CheckExprs) > 0 || d.HasFKConstraint() ||
+			d.HasColumnFamily() || d.Hidden {
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"column %q of a foreign table can only have NULL or NOT NULL constraints", d.Name)
+		}
+		cdd, err := tabledesc.MakeColumnDefDescs(
+			params.ctx, d, &p.semaCtx, p.EvalContext(), tree.ColumnDefaultExprInNewTable,
+		)
+		if err != nil {
+			return err
+		}
+		desc.AddColumn(cdd.ColumnDescriptor)
+	}
+	if len(desc.Columns) == 0 {
+		return pgerror.New(pgcode.InvalidTableDefinition,
+			"foreign tables must contain at least one column")
+	}
+	version := params.ExecCfg().Settings.Version.ActiveVersion(params.ctx)
+	if err := desc.AllocateIDs(params.ctx, version); err != nil {
+		return err
+	}
+
+	if err := p.createDescriptor(
+		params.ctx,
+		&desc,
+		redactedForeignStatementString(n.n, params.Ann()),
+	); err != nil {
+		return err
+	}
+	if err := p.addBackRefsFromAllTypesInTable(params.ctx, &desc); err != nil {
+		return err
+	}
+	if err := validateDescriptor(params.ctx, p, &desc); err != nil {
+		return err
+	}
+
+	// Log Create Table event. This is an auditable log event and is recorded in
+	// the same transaction as the table descriptor update.
+	return p.logEvent(params.ctx,
+		desc.ID,
+		&eventpb.CreateTable{
+			TableName: n.n.Table.FQString(),
+		})
+}
+
+func (*createForeignTableNode) Next(runParams) (bool, error) { return false, nil }
+func (*createForeignTableNode) Values() tree.Datums          { return tree.Datums{} }
+func (*createForeignTableNode) Close(context.Context)        {}
+
+// makeForeignOptions converts the options of an OPTIONS clause into their
+// descriptor representation.
+func makeForeignOptions(options tree.ForeignOptions) ([]descpb.ForeignOption, error) {
+	if len(options) == 0 {
+		return nil, nil
+	}
+	ret := make([]descpb.ForeignOption, 0, len(options))
+	seen := make(map[string]struct{}, len(options))
+	for _, opt := range options {
+		key := string(opt.Key)
+		if _, ok := seen[key]; ok {
+			return nil, pgerror.Newf(pgcode.DuplicateObject,
+				"option %q provided more than once", key)
+		}
+		seen[key] = struct{}{}
+		ret = append(ret, descpb.ForeignOption{Key: key, Value: opt.Value})
+	}
+	return ret, nil
+}
+
+// mergeForeignOptions returns the options of a foreign table, which are the
+// options of its server overridden by its own options.
+func mergeForeignOptions(serverOptions, tableOptions []descpb.ForeignOption) map[string]string {
+	options := make(map[string]string, len(serverOptions)+len(tableOptions))
+	for _, opt := range serverOptions {
+		options[opt.Key] = opt.Value
+	}
+	for _, opt := range tableOptions {
+		options[opt.Key] = opt.Value
+	}
+	return options
+}
+
+// redactForeignOptions returns the options of a foreign server or table with
+// the secrets in their external storage URI redacted, for display purposes.
+func redactForeignOptions(options []descpb.ForeignOption) ([]descpb.ForeignOption, error) {
+	ret := make([]descpb.ForeignOption, len(options))
+	for i, opt := range options {
+		ret[i] = opt
+		if opt.Key != foreignOptionURI {
+			continue
+		}
+		uri, err := cloud.SanitizeExternalStorageURI(opt.Value, nil /* extraParams */)
+		if err != nil {
+			return nil, err
+		}
+		ret[i].Value = uri
+	}
+	return ret, nil
+}
+
+// redactedForeignStatementString formats a CREATE SERVER or CREATE FOREIGN
+// TABLE statement with the secrets in its external storage URI redacted, for
+// use in job descriptions.
+func redactedForeignStatementString(stmt tree.Statement, ann *tree.Annotations) string {
+	redact := func(options tree.ForeignOptions) tree.ForeignOptions {
+		ret := make(tree.ForeignOptions, len(options))
+		for i, opt := range options {
+			ret[i] = opt
+			if string(opt.Key) != foreignOptionURI {
+				continue
+			}
+			if uri, err := cloud.SanitizeExternalStorageURI(opt.Value, nil /* extraParams */); err == nil {
+				ret[i].Value = uri
+			}
+		}
+		return ret
+	}
+	switch n := stmt.(type) {
+	case *tree.CreateForeignServer:
+		redacted := *n
+		redacted.Options = redact(n.Options)
+		stmt = &redacted
+	case *tree.CreateForeignTable:
+		redacted := *n
+		redacted.Options = redact(n.Options)
+		stmt = &redacted
+	}
+	return tree.AsStringWithFQNames(stmt, ann)
+}
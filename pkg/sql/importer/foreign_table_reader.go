@@ -0,0 +1,349 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package importer
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding/csv"
+	"github.com/cockroachdb/cockroach/pkg/util/ioctx"
+	"github.com/cockroachdb/cockroach/pkg/util/parquet"
+	"github.com/cockroachdb/errors"
+	"github.com/linkedin/goavro/v2"
+)
+
+// Options of foreign tables in the csv format.
+const (
+	foreignCSVDelimiter = "delimiter"
+	foreignCSVHeader    = "header"
+	foreignCSVNull      = "null"
+)
+
+func init() {
+	sql.RegisterForeignTableFormat("csv", sql.ForeignTableFormat{
+		Options:         []string{foreignCSVDelimiter, foreignCSVHeader, foreignCSVNull},
+		ValidateOptions: validateForeignCSVOptions,
+		NewReader:       newCSVForeignTableReader,
+	})
+	sql.RegisterForeignTableFormat("avro", sql.ForeignTableFormat{
+		NewReader: newAvroForeignTableReader,
+	})
+	sql.RegisterForeignTableFormat("parquet", sql.ForeignTableFormat{
+		NewReader: newParquetForeignTableReader,
+	})
+}
+
+// openForeignTableFile opens the given file of a foreign table for reading.
+func openForeignTableFile(
+	ctx context.Context, file sql.ForeignTableFile,
+) (ioctx.ReadCloserCtx, error) {
+	r, _, err := file.Store.ReadFile(ctx, file.Name, cloud.ReadOptions{NoFileSize: true})
+	return r, err
+}
+
+// foreignTableFileReaderAt provides random access to a file of a foreign
+// table, which parquet files require as they are read from their footer.
+// Every read is a ranged read of the external storage, so that the file is
+// not buffered in memory.
+type foreignTableFileReaderAt struct {
+	// ctx is captured at construction time and used for the reads.
+	ctx  context.Context
+	file sql.ForeignTableFile
+	size int64
+	// pos is the position of the reader for io.Seeker.
+	pos int64
+}
+
+var _ io.ReaderAt = (*foreignTableFileReaderAt)(nil)
+var _ io.Seeker = (*foreignTableFileReaderAt)(nil)
+
+func newForeignTableFileReaderAt(
+	ctx context.Context, file sql.ForeignTableFile,
+) (*foreignTableFileReaderAt, error) {
+	size, err := file.Store.Size(ctx, file.Name)
+	if err != nil {
+		return nil, err
+	}
+	return &foreignTableFileReaderAt{ctx: ctx, file: file, size: size}, nil
+}
+
+// ReadAt implements the io.ReaderAt interface.
+func (r *foreignTableFileReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	if off >= r.size {
+		return 0, io.EOF
+	}
+	want := len(p)
+	if remaining := r.size - off; int64(want) > remaining {
+		p = p[:remaining]
+	}
+	reader, _, err := r.file.Store.ReadFile(r.ctx, r.file.Name, cloud.ReadOptions{
+		Offset:     off,
+		LengthHint: int64(len(p)),
+		NoFileSize: true,
+	})
+	if err != nil {
+		return 0, err
+	}
+	defer reader.Close(r.ctx)
+	n, err := io.ReadFull(ioctx.ReaderCtxAdapter(r.ctx, reader), p)
+	if err == nil && n < want {
+		err = io.EOF
+	}
+	return n, err
+}
+
+// Seek implements the io.Seeker interface.
+func (r *foreignTableFileReaderAt) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += r.pos
+	case io.SeekEnd:
+		offset += r.size
+	default:
+		return 0, errors.AssertionFailedf("invalid whence %d", whence)
+	}
+	if offset < 0 {
+		return 0, errors.Newf("invalid negative position %d", offset)
+	}
+	r.pos = offset
+	return offset, nil
+}
+
+func validateForeignCSVOptions(options map[string]string) error {
+	if delimiter, ok := options[foreignCSVDelimiter]; ok {
+		if utf8.RuneCountInString(delimiter) != 1 {
+			return pgerror.Newf(pgcode.FdwInvalidAttributeValue,
+				"%s must be a single character", foreignCSVDelimiter)
+		}
+	}
+	if header, ok := options[foreignCSVHeader]; ok {
+		if _, err := strconv.ParseBool(header); err != nil {
+			return pgerror.Newf(pgcode.FdwInvalidAttributeValue,
+				"%s requires a Boolean value", foreignCSVHeader)
+		}
+	}
+	return nil
+}
+
+// csvForeignTableReader reads the rows of a foreign table from a csv file,
+// whose fields are the columns of the table in order.
+type csvForeignTableReader struct {
+	input   ioctx.ReadCloserCtx
+	csv     *csv.Reader
+	columns []catalog.Column
+	evalCtx *eval.Context
+	null    string
+	header  bool
+	// rowNum is the number of the last record read, for error reporting.
+	rowNum int
+}
+
+func newCSVForeignTableReader(
+	ctx context.Context, evalCtx *eval.Context, file sql.ForeignTableFile,
+) (sql.ForeignTableReader, error) {
+	input, err := openForeignTableFile(ctx, file)
+	if err != nil {
+		return nil, err
+	}
+	r := &csvForeignTableReader{
+		input:   input,
+		csv:     csv.NewReader(bufio.NewReader(ioctx.ReaderCtxAdapter(ctx, input))),
+		columns: file.Columns,
+		evalCtx: evalCtx,
+		null:    file.Options[foreignCSVNull],
+	}
+	if delimiter, ok := file.Options[foreignCSVDelimiter]; ok {
+		r.csv.Comma, _ = utf8.DecodeRuneInString(delimiter)
+	}
+	r.csv.FieldsPerRecord = -1
+	r.csv.LazyQuotes = true
+	if header, ok := file.Options[foreignCSVHeader]; ok {
+		r.header, _ = strconv.ParseBool(header)
+	}
+	return r, nil
+}
+
+// Next implements the sql.ForeignTableReader interface.
+func (r *csvForeignTableReader) Next(ctx context.Context) (tree.Datums, error) {
+	if r.header {
+		r.header = false
+		r.rowNum++
+		if _, err := r.csv.Read(); err == io.EOF {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	record, err := r.csv.Read()
+	if err == io.EOF {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	r.rowNum++
+	if len(record) != len(r.columns) {
+		return nil, pgerror.Newf(pgcode.BadCopyFileFormat,
+			"row %d: expected %d fields, got %d", r.rowNum, len(r.columns), len(record))
+	}
+	row := make(tree.Datums, len(r.columns))
+	for i, field := range record {
+		// To match COPY, only unquoted fields are treated as NULL.
+		if !field.Quoted && field.Val == r.null {
+			row[i] = tree.DNull
+			continue
+		}
+		typ := r.columns[i].GetType()
+		row[i], err = rowenc.ParseDatumStringAs(ctx, typ, field.Val, r.evalCtx)
+		if err != nil {
+			// Fallback to parsing as a string literal, like IMPORT does, to support
+			// both array expressions and literals.
+			var err2 error
+			row[i], _, err2 = tree.ParseAndRequireString(typ, field.Val, r.evalCtx)
+			if err2 != nil {
+				return nil, errors.Wrapf(errors.CombineErrors(err, err2), "row %d: parse %q as %s",
+					r.rowNum, r.columns[i].GetName(), typ.SQLString())
+			}
+		}
+	}
+	return row, nil
+}
+
+// Close implements the sql.ForeignTableReader interface.
+func (r *csvForeignTableReader) Close(ctx context.Context) error {
+	return r.input.Close(ctx)
+}
+
+// avroForeignTableReader reads the rows of a foreign table from an avro OCF
+// file. The fields of the records are matched to the columns by name; missing
+// fields are NULL and extra fields are ignored.
+type avroForeignTableReader struct {
+	input          ioctx.ReadCloserCtx
+	ocf            *goavro.OCFReader
+	columns        []catalog.Column
+	evalCtx        *eval.Context
+	fieldNameToIdx map[string]int
+}
+
+func newAvroForeignTableReader(
+	ctx context.Context, evalCtx *eval.Context, file sql.ForeignTableFile,
+) (sql.ForeignTableReader, error) {
+	input, err := openForeignTableFile(ctx, file)
+	if err != nil {
+		return nil, err
+	}
+	ocf, err := goavro.NewOCFReader(bufio.NewReaderSize(ioctx.ReaderCtxAdapter(ctx, input), 64<<10))
+	if err != nil {
+		return nil, errors.CombineErrors(err, input.Close(ctx))
+	}
+	r := &avroForeignTableReader{
+		input:          input,
+		ocf:            ocf,
+		columns:        file.Columns,
+		evalCtx:        evalCtx,
+		fieldNameToIdx: make(map[string]int, len(file.Columns)),
+	}
+	for i, col := range file.Columns {
+		r.fieldNameToIdx[col.GetName()] = i
+	}
+	return r, nil
+}
+
+// Next implements the sql.ForeignTableReader interface.
+func (r *avroForeignTableReader) Next(ctx context.Context) (tree.Datums, error) {
+	if !r.ocf.Scan() {
+		return nil, r.ocf.Err()
+	}
+	native, err := r.ocf.Read()
+	if err != nil {
+		return nil, err
+	}
+	record, ok := native.(map[string]interface{})
+	if !ok {
+		return nil, errors.Errorf(
+			"unexpected native type; expected map[string]interface{} found %T instead", native)
+	}
+	row := make(tree.Datums, len(r.columns))
+	for i := range row {
+		row[i] = tree.DNull
+	}
+	for f, v := range record {
+		idx, ok := r.fieldNameToIdx[lexbase.NormalizeName(f)]
+		if !ok {
+			continue
+		}
+		typ := r.columns[idx].GetType()
+		avroT, ok := familyToAvroT[typ.Family()]
+		if !ok {
+			return nil, errors.Errorf("cannot convert avro value %v to col %s", v, typ.Name())
+		}
+		if row[idx], err = nativeToDatum(ctx, v, typ, avroT, r.evalCtx); err != nil {
+			return nil, err
+		}
+	}
+	return row, nil
+}
+
+// Close implements the sql.ForeignTableReader interface.
+func (r *avroForeignTableReader) Close(ctx context.Context) error {
+	return r.input.Close(ctx)
+}
+
+// parquetForeignTableReader reads the rows of a foreign table from a parquet
+// file. The columns of the file are matched to the columns of the table by
+// name.
+type parquetForeignTableReader struct {
+	reader *parquet.Reader
+}
+
+func newParquetForeignTableReader(
+	ctx context.Context, _ *eval.Context, file sql.ForeignTableFile,
+) (sql.ForeignTableReader, error) {
+	input, err := newForeignTableFileReaderAt(ctx, file)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(file.Columns))
+	typs := make([]*types.T, len(file.Columns))
+	for i, col := range file.Columns {
+		names[i] = col.GetName()
+		typs[i] = col.GetType()
+	}
+	reader, err := parquet.NewReader(input, names, typs)
+	if err != nil {
+		return nil, err
+	}
+	return &parquetForeignTableReader{reader: reader}, nil
+}
+
+// Next implements the sql.ForeignTableReader interface.
+func (r *parquetForeignTableReader) Next(context.Context) (tree.Datums, error) {
+	return r.reader.Next()
+}
+
+// Close implements the sql.ForeignTableReader interface.
+func (r *parquetForeignTableReader) Close(context.Context) error {
+	return r.reader.Close()
+}
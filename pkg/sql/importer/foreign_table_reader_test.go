@@ -0,0 +1,157 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package importer
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/ioctx"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+// bytesExternalStorage is an external storage whose only file holds the given
+// data. Ranged reads only return the requested bytes.
+type bytesExternalStorage struct {
+	cloud.ExternalStorage
+	data []byte
+}
+
+func (s *bytesExternalStorage) ReadFile(
+	_ context.Context, _ string, opts cloud.ReadOptions,
+) (ioctx.ReadCloserCtx, int64, error) {
+	data := s.data[opts.Offset:]
+	if opts.LengthHint > 0 && opts.LengthHint < int64(len(data)) {
+		data = data[:opts.LengthHint]
+	}
+	return ioctx.NopCloser(ioctx.ReaderAdapter(bytes.NewReader(data))), int64(len(s.data)), nil
+}
+
+func (s *bytesExternalStorage) Size(context.Context, string) (int64, error) {
+	return int64(len(s.data)), nil
+}
+
+func readForeignTableFile(
+	ctx context.Context,
+	t *testing.T,
+	evalCtx *eval.Context,
+	format string,
+	file sql.ForeignTableFile,
+) []tree.Datums {
+	newReader := map[string]func(context.Context, *eval.Context, sql.ForeignTableFile) (sql.ForeignTableReader, error){
+		"csv":  newCSVForeignTableReader,
+		"avro": newAvroForeignTableReader,
+	}[format]
+	r, err := newReader(ctx, evalCtx, file)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, r.Close(ctx)) }()
+	var rows []tree.Datums
+	for {
+		row, err := r.Next(ctx)
+		require.NoError(t, err)
+		if row == nil {
+			return rows
+		}
+		require.Len(t, row, len(file.Columns))
+		rows = append(rows, row)
+	}
+}
+
+func TestCSVForeignTableReader(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	ctx := context.Background()
+
+	st := cluster.MakeTestingClusterSettings()
+	evalCtx := eval.MakeTestingEvalContext(st)
+	desc := descForTable(ctx, t, "CREATE TABLE t (a INT8, b STRING)", 100, 150, 200, NoFKs)
+	file := sql.ForeignTableFile{
+		Store:   &bytesExternalStorage{data: []byte("a|b\n1|foo\n2|\n3|\"\"\n")},
+		Columns: desc.PublicColumns(),
+		Options: map[string]string{foreignCSVDelimiter: "|", foreignCSVHeader: "true"},
+	}
+	rows := readForeignTableFile(ctx, t, &evalCtx, "csv", file)
+	require.Equal(t, []tree.Datums{
+		{tree.NewDInt(1), tree.NewDString("foo")},
+		{tree.NewDInt(2), tree.DNull},
+		{tree.NewDInt(3), tree.NewDString("")},
+	}, rows)
+
+	// Records must have one field per column.
+	file.Store = &bytesExternalStorage{data: []byte("1|foo|bar\n")}
+	file.Options = nil
+	r, err := newCSVForeignTableReader(ctx, &evalCtx, file)
+	require.NoError(t, err)
+	_, err = r.Next(ctx)
+	require.ErrorContains(t, err, "row 1: expected 2 fields, got 3")
+	require.NoError(t, r.Close(ctx))
+}
+
+func TestAvroForeignTableReader(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	ctx := context.Background()
+
+	th := newTestHelper(ctx, t)
+	var data bytes.Buffer
+	th.genOcfData(t, 10, &data)
+	rows := readForeignTableFile(ctx, t, &th.evalCtx, "avro", sql.ForeignTableFile{
+		Store:   &bytesExternalStorage{data: data.Bytes()},
+		Columns: th.schemaTable.PublicColumns(),
+	})
+	require.Len(t, rows, 10)
+	for i, row := range rows {
+		// The first column is generated by a sequence.
+		require.Equal(t, tree.NewDInt(tree.DInt(i+1)), row[0])
+	}
+}
+
+func TestForeignTableFileReaderAt(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	ctx := context.Background()
+
+	r, err := newForeignTableFileReaderAt(ctx, sql.ForeignTableFile{
+		Store: &bytesExternalStorage{data: []byte("0123456789")},
+	})
+	require.NoError(t, err)
+
+	buf := make([]byte, 4)
+	n, err := r.ReadAt(buf, 3)
+	require.NoError(t, err)
+	require.Equal(t, "3456", string(buf[:n]))
+
+	// Reads past the end of the file are short.
+	n, err = r.ReadAt(buf, 8)
+	require.Equal(t, io.EOF, err)
+	require.Equal(t, "89", string(buf[:n]))
+	n, err = r.ReadAt(buf, 10)
+	require.Equal(t, io.EOF, err)
+	require.Zero(t, n)
+
+	pos, err := r.Seek(-2, io.SeekEnd)
+	require.NoError(t, err)
+	require.Equal(t, int64(8), pos)
+	pos, err = r.Seek(1, io.SeekCurrent)
+	require.NoError(t, err)
+	require.Equal(t, int64(9), pos)
+	_, err = r.Seek(-1, io.SeekStart)
+	require.Error(t, err)
+}
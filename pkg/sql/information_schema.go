@@ -485,10 +485,19 @@ https://www.postgresql.org/docs/9.5/infoschema-columns.html`,
 				// type is a user defined type, then we should fill this value based on
 				// the schema it is under.
 				udtSchema := pgCatalogNameDString
+				udtName := tree.NewDString(column.GetType().PGName())
 				typeMetaName := column.GetType().TypeMeta.Name
 				if typeMetaName != nil {
 					udtSchema = tree.NewDString(typeMetaName.Schema)
 				}
+				// Like in Postgres, the udt_* columns describe the base type of a
+				// domain, and the domain itself is described by the domain_* columns.
+				domainCatalog, domainSchema, domainName := tree.DNull, tree.DNull, tree.DNull
+				if column.GetType().IsDomain() {
+					domainCatalog, domainSchema, domainName = dbNameStr, udtSchema, udtName
+					udtSchema = pgCatalogNameDString
+					udtName = tree.NewDString(column.GetType().DomainBaseType().PGName())
+				}
 
 				// Get the sequence option if it's an identity column.
 				identityStart := tree.DNull
@@ -530,18 +539,18 @@ https://www.postgresql.org/docs/9.5/infoschema-columns.html`,
 					collationCatalog,                                          // collation_catalog
 					collationSchema,                                           // collation_schema
 					collationName,                                             // collation_name
-					tree.DNull,                                                // domain_catalog
-					tree.DNull,                                                // domain_schema
-					tree.DNull,                                                // domain_name
+					domainCatalog,                                             // domain_catalog
+					domainSchema,                                              // domain_schema
+					domainName,                                                // domain_name
 					dbNameStr,                                                 // udt_catalog
 					udtSchema,                                                 // udt_schema
-					tree.NewDString(column.GetType().PGName()), // udt_name
-					tree.DNull, // scope_catalog
-					tree.DNull, // scope_schema
-					tree.DNull, // scope_name
-					tree.DNull, // maximum_cardinality
-					tree.DNull, // dtd_identifier
-					tree.DNull, // is_self_referencing
+					udtName,                                                   // udt_name
+					tree.DNull,                                                // scope_catalog
+					tree.DNull,                                                // scope_schema
+					tree.DNull,                                                // scope_name
+					tree.DNull,                                                // maximum_cardinality
+					tree.DNull,                                                // dtd_identifier
+					tree.DNull,                                                // is_self_referencing
 					yesOrNoDatum(column.IsGeneratedAsIdentity()), // is_identity
 					colGeneratedAsIdentity,                       // identity_generation
 					identityStart,                                // identity_start
@@ -780,7 +789,7 @@ https://www.postgresql.org/docs/9.5/infoschema-constraint-column-usage.html`,
 					cols = refTable.ForeignKeyReferencedColumns(fk)
 				} else if uwi := c.AsUniqueWithIndex(); uwi != nil {
 					cols = table.IndexKeyColumns(uwi)
-				} else if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil {
+				} else if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil && !uwoi.IsExclusionConstraint() {
 					cols = table.UniqueWithoutIndexColumns(uwoi)
 				}
 				for _, col := range cols {
@@ -826,7 +835,7 @@ https://www.postgresql.org/docs/9.5/infoschema-key-column-usage.html`,
 					cols = table.ForeignKeyOriginColumns(fk)
 				} else if uwi := c.AsUniqueWithIndex(); uwi != nil {
 					cols = table.IndexKeyColumns(uwi)
-				} else if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil {
+				} else if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil && !uwoi.IsExclusionConstraint() {
 					cols = table.UniqueWithoutIndexColumns(uwoi)
 				}
 				for pos, col := range cols {
@@ -1423,6 +1432,10 @@ https://www.postgresql.org/docs/9.5/infoschema-table-constraints.html`,
 				tbNameStr := tree.NewDString(table.GetName())
 
 				for _, c := range table.AllConstraints() {
+					// Like Postgres, exclusion constraints are not included.
+					if u := c.AsUniqueWithoutIndex(); u != nil && u.IsExclusionConstraint() {
+						continue
+					}
 					kind := catconstants.ConstraintTypeUnique
 					if c.AsCheck() != nil {
 						kind = catconstants.ConstraintTypeCheck
@@ -1431,16 +1444,17 @@ https://www.postgresql.org/docs/9.5/infoschema-table-constraints.html`,
 					} else if u := c.AsUniqueWithIndex(); u != nil && u.Primary() {
 						kind = catconstants.ConstraintTypePK
 					}
+					deferrable, initiallyDeferred := constraintDeferrability(c)
 					if err := addRow(
-						dbNameStr,                     // constraint_catalog
-						scNameStr,                     // constraint_schema
-						tree.NewDString(c.GetName()),  // constraint_name
-						dbNameStr,                     // table_catalog
-						scNameStr,                     // table_schema
-						tbNameStr,                     // table_name
-						tree.NewDString(string(kind)), // constraint_type
-						yesOrNoDatum(false),           // is_deferrable
-						yesOrNoDatum(false),           // initially_deferred
+						dbNameStr,                       // constraint_catalog
+						scNameStr,                       // constraint_schema
+						tree.NewDString(c.GetName()),    // constraint_name
+						dbNameStr,                       // table_catalog
+						scNameStr,                       // table_schema
+						tbNameStr,                       // table_name
+						tree.NewDString(string(kind)),   // constraint_type
+						yesOrNoDatum(deferrable),        // is_deferrable
+						yesOrNoDatum(initiallyDeferred), // initially_deferred
 					); err != nil {
 						return err
 					}
@@ -1580,6 +1594,7 @@ var (
 	tableTypeBaseTable  = tree.NewDString("BASE TABLE")
 	tableTypeView       = tree.NewDString("VIEW")
 	tableTypeTemporary  = tree.NewDString("LOCAL TEMPORARY")
+	tableTypeForeign    = tree.NewDString("FOREIGN")
 )
 
 var informationSchemaTablesTable = virtualSchemaTable{
@@ -1611,6 +1626,9 @@ func addTablesTableRow(
 		} else if table.IsView() {
 			tableType = tableTypeView
 			insertable = noString
+		} else if table.IsForeignTable() {
+			tableType = tableTypeForeign
+			insertable = noString
 		} else if table.IsTemporary() {
 			tableType = tableTypeTemporary
 		}
@@ -1979,13 +1997,36 @@ var informationSchemaAttributesTable = virtualSchemaTable{
 	unimplemented: true,
 }
 
+// Postgres: https://www.postgresql.org/docs/current/infoschema-domain-constraints.html
 var informationSchemaDomainConstraintsTable = virtualSchemaTable{
-	comment: "domain_constraints was created for compatibility and is currently unimplemented",
+	comment: "domain constraints",
 	schema:  vtable.InformationSchemaDomainConstraints,
-	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		return forEachTypeDesc(ctx, p, dbContext, func(db catalog.DatabaseDescriptor, sc catalog.SchemaDescriptor, typeDesc catalog.TypeDescriptor) error {
+			d := typeDesc.AsDomainTypeDescriptor()
+			if d == nil {
+				return nil
+			}
+			dbNameStr := tree.NewDString(db.GetName())
+			scNameStr := tree.NewDString(sc.GetName())
+			domainNameStr := tree.NewDString(d.GetName())
+			for i := 0; i < d.NumChecks(); i++ {
+				if err := addRow(
+					dbNameStr,                          // constraint_catalog
+					scNameStr,                          // constraint_schema
+					tree.NewDString(d.GetCheckName(i)), // constraint_name
+					dbNameStr,                          // domain_catalog
+					scNameStr,                          // domain_schema
+					domainNameStr,                      // domain_name
+					noString,                           // is_deferrable
+					noString,                           // initially_deferred
+				); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
 	},
-	unimplemented: true,
 }
 
 var informationSchemaUserMappingsTable = virtualSchemaTable{
@@ -2051,13 +2092,61 @@ var informationSchemaUsagePrivilegesTable = virtualSchemaTable{
 	unimplemented: true,
 }
 
+// Postgres: https://www.postgresql.org/docs/current/infoschema-domains.html
 var informationSchemaDomainsTable = virtualSchemaTable{
-	comment: "domains was created for compatibility and is currently unimplemented",
+	comment: "domains",
 	schema:  vtable.InformationSchemaDomains,
-	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		return forEachTypeDesc(ctx, p, dbContext, func(db catalog.DatabaseDescriptor, sc catalog.SchemaDescriptor, typeDesc catalog.TypeDescriptor) error {
+			d := typeDesc.AsDomainTypeDescriptor()
+			if d == nil {
+				return nil
+			}
+			dbNameStr := tree.NewDString(db.GetName())
+			baseType := d.BaseType()
+			collationCatalog := tree.DNull
+			collationSchema := tree.DNull
+			collationName := tree.DNull
+			if locale := baseType.Locale(); locale != "" {
+				collationCatalog = dbNameStr
+				collationSchema = pgCatalogNameDString
+				collationName = tree.NewDString(locale)
+			}
+			domainDefault := tree.DNull
+			if def := d.GetDomainDefaultExpr(); def != "" {
+				domainDefault = tree.NewDString(def)
+			}
+			return addRow(
+				dbNameStr,                                         // domain_catalog
+				tree.NewDString(sc.GetName()),                     // domain_schema
+				tree.NewDString(d.GetName()),                      // domain_name
+				tree.NewDString(baseType.InformationSchemaName()), // data_type
+				characterMaximumLength(baseType),                  // character_maximum_length
+				characterOctetLength(baseType),                    // character_octet_length
+				tree.DNull,                                        // character_set_catalog
+				tree.DNull,                                        // character_set_schema
+				tree.DNull,                                        // character_set_name
+				collationCatalog,                                  // collation_catalog
+				collationSchema,                                   // collation_schema
+				collationName,                                     // collation_name
+				numericPrecision(baseType),                        // numeric_precision
+				numericPrecisionRadix(baseType),                   // numeric_precision_radix
+				numericScale(baseType),                            // numeric_scale
+				datetimePrecision(baseType),                       // datetime_precision
+				tree.DNull,                                        // interval_type
+				tree.DNull,                                        // interval_precision
+				domainDefault,                                     // domain_default
+				dbNameStr,                                         // udt_catalog
+				pgCatalogNameDString,                              // udt_schema
+				tree.NewDString(baseType.PGName()),                // udt_name
+				tree.DNull,                                        // scope_catalog
+				tree.DNull,                                        // scope_schema
+				tree.DNull,                                        // scope_name
+				tree.DNull,                                        // maximum_cardinality
+				tree.DNull,                                        // dtd_identifier
+			)
+		})
 	},
-	unimplemented: true,
 }
 
 var informationSchemaSQLImplementationInfoTable = virtualSchemaTable{
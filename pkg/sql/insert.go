@@ -199,6 +199,9 @@ func (r *insertRun) processSourceRow(params runParams, rowVals tree.Datums) erro
 	if err := enforceLocalColumnConstraints(rowVals, r.insertCols); err != nil {
 		return err
 	}
+	if err := validateColumnValues(params, rowVals[:len(r.insertCols)]); err != nil {
+		return err
+	}
 
 	// Create a set of partial index IDs to not write to. Indexes should not be
 	// written to when they are partial indexes and the row does not satisfy the
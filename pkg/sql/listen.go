@@ -0,0 +1,42 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// Listen implements the LISTEN statement.
+// See https://www.postgresql.org/docs/current/sql-listen.html for details.
+func (p *planner) Listen(ctx context.Context, n *tree.Listen) (planNode, error) {
+	if !p.extendedEvalCtx.notifications.enabled() {
+		return nil, errNotificationsNotSupported
+	}
+	return &listenNode{action: listenAction{channel: string(n.ChannelName), listen: true}}, nil
+}
+
+// listenNode implements the LISTEN and UNLISTEN statements. The action is
+// applied when the transaction commits.
+type listenNode struct {
+	action listenAction
+}
+
+func (n *listenNode) startExec(params runParams) error {
+	notifications := params.p.extendedEvalCtx.notifications
+	notifications.actions = append(notifications.actions, n.action)
+	return nil
+}
+
+func (n *listenNode) Next(_ runParams) (bool, error) { return false, nil }
+func (n *listenNode) Values() tree.Datums            { return nil }
+func (n *listenNode) Close(_ context.Context)        {}
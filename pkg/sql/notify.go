@@ -0,0 +1,471 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/metric"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+)
+
+// maxNotificationChannelLen is the maximum length in bytes of a channel name
+// passed to pg_notify. It matches the identifier length limit of Postgres.
+const maxNotificationChannelLen = 63
+
+// maxNotificationPayloadLen is the maximum length in bytes of a notification
+// payload, exclusive. It matches the limit used by Postgres.
+const maxNotificationPayloadLen = 8000
+
+// notificationSendTimeout is the maximum amount of time spent sending the
+// notifications of a committed transaction to the other nodes of the cluster.
+const notificationSendTimeout = 10 * time.Second
+
+// maxQueuedNotifications is the maximum number of notifications that can be
+// queued for a session before they are sent to the client. Notifications that
+// arrive when the queue is full are dropped.
+const maxQueuedNotifications = 1 << 16
+
+// maxPendingNotificationSends is the maximum number of notifications that can
+// be waiting to be sent to the other nodes of the cluster. Notifications that
+// are committed when the limit is reached are dropped.
+const maxPendingNotificationSends = 1 << 16
+
+var errNotificationsNotSupported = pgerror.New(pgcode.FeatureNotSupported,
+	"LISTEN, UNLISTEN and NOTIFY are only supported in client sessions")
+
+// Notify implements the NOTIFY statement.
+// See https://www.postgresql.org/docs/current/sql-notify.html for details.
+func (p *planner) Notify(ctx context.Context, n *tree.Notify) (planNode, error) {
+	if !p.extendedEvalCtx.notifications.enabled() {
+		return nil, errNotificationsNotSupported
+	}
+	return &notifyNode{channel: string(n.ChannelName), payload: n.Payload}, nil
+}
+
+// QueueNotification is part of the eval.Planner interface.
+func (p *planner) QueueNotification(ctx context.Context, channel, payload string) error {
+	if !p.extendedEvalCtx.notifications.enabled() {
+		return errNotificationsNotSupported
+	}
+	if channel == "" {
+		return pgerror.New(pgcode.InvalidParameterValue, "channel name cannot be empty")
+	}
+	if len(channel) > maxNotificationChannelLen {
+		return pgerror.New(pgcode.InvalidParameterValue, "channel name too long")
+	}
+	if len(payload) >= maxNotificationPayloadLen {
+		return pgerror.New(pgcode.InvalidParameterValue, "payload string too long")
+	}
+	p.extendedEvalCtx.notifications.notify(channel, payload)
+	return nil
+}
+
+type notifyNode struct {
+	channel string
+	payload string
+}
+
+func (n *notifyNode) startExec(params runParams) error {
+	return params.p.QueueNotification(params.ctx, n.channel, n.payload)
+}
+
+func (n *notifyNode) Next(_ runParams) (bool, error) { return false, nil }
+func (n *notifyNode) Values() tree.Datums            { return nil }
+func (n *notifyNode) Close(_ context.Context)        {}
+
+// txnNotifications collects the effects of the LISTEN, UNLISTEN and NOTIFY
+// statements executed in the current transaction. As in Postgres, they only
+// take effect when the transaction commits.
+type txnNotifications struct {
+	// listener receives the notifications sent to the session. It is nil for
+	// sessions that cannot receive notifications, such as the ones of the
+	// internal executor.
+	listener *notificationListener
+
+	// actions are the LISTEN and UNLISTEN actions to apply at commit, in the
+	// order in which they were executed.
+	actions []listenAction
+
+	// pending are the notifications to send at commit, in the order in which
+	// they were sent. As in Postgres, duplicate notifications are only sent
+	// once; seen is used to detect them.
+	pending []serverpb.Notification
+	seen    map[serverpb.Notification]struct{}
+}
+
+// listenAction is a LISTEN or UNLISTEN action. An UNLISTEN action without a
+// channel stops listening on all channels.
+type listenAction struct {
+	channel string
+	listen  bool
+}
+
+// enabled returns whether the session can use LISTEN, UNLISTEN and NOTIFY.
+func (n *txnNotifications) enabled() bool {
+	return n != nil && n.listener != nil
+}
+
+func (n *txnNotifications) notify(channel, payload string) {
+	notification := serverpb.Notification{
+		Channel: channel,
+		Payload: payload,
+		PID:     n.listener.pid,
+	}
+	if _, ok := n.seen[notification]; ok {
+		return
+	}
+	if n.seen == nil {
+		n.seen = make(map[serverpb.Notification]struct{})
+	}
+	n.seen[notification] = struct{}{}
+	n.pending = append(n.pending, notification)
+}
+
+// commit applies the LISTEN and UNLISTEN actions of the transaction and sends
+// its notifications to the listening sessions on all nodes. It must be called
+// once the transaction has committed.
+func (n *txnNotifications) commit(ctx context.Context, s *Server) {
+	if !n.enabled() {
+		return
+	}
+	for _, a := range n.actions {
+		switch {
+		case a.listen:
+			n.listener.listen(a.channel)
+		case a.channel == "":
+			n.listener.unlistenAll()
+		default:
+			n.listener.unlisten(a.channel)
+		}
+	}
+	if len(n.pending) > 0 {
+		s.sendNotifications(ctx, n.pending)
+	}
+}
+
+// reset discards the effects of the LISTEN, UNLISTEN and NOTIFY statements
+// executed in the transaction.
+func (n *txnNotifications) reset() {
+	n.actions = nil
+	n.pending = nil
+	n.seen = nil
+}
+
+// close stops listening on all channels. It is called when the session ends.
+func (n *txnNotifications) close() {
+	if n.enabled() {
+		n.listener.unlistenAll()
+	}
+}
+
+// bufferQueued buffers the notifications queued for the session on the given
+// result.
+func (n *txnNotifications) bufferQueued(res NotificationResult) {
+	if !n.enabled() {
+		return
+	}
+	for _, notification := range n.listener.takeQueued() {
+		res.BufferNotification(notification)
+	}
+}
+
+// sendNotifications delivers the given notifications to the sessions
+// listening on their channels on every node of the cluster. They are delivered
+// on the local node right away, so that a session receives its own
+// notifications before the transaction's COMMIT completes. The other nodes are
+// notified by the notificationSender, so that a slow or unavailable node does
+// not delay the session.
+func (s *Server) sendNotifications(ctx context.Context, notifications []serverpb.Notification) {
+	s.DeliverNotifications(ctx, notifications)
+	if s.cfg.SQLStatusServer == nil {
+		return
+	}
+	s.notificationSender.enqueue(ctx, notifications)
+}
+
+// notificationSender sends the notifications committed on this node to the
+// other nodes of the cluster. The notifications are queued in commit order and
+// sent by a single worker, one batch at a time, so that the notifications of a
+// session arrive on the other nodes in the order in which they were committed.
+// Notifications that are committed while the queue is full are dropped.
+type notificationSender struct {
+	// send sends a batch of notifications to the other nodes. It is bounded by
+	// notificationSendTimeout.
+	send    func(ctx context.Context, notifications []serverpb.Notification) error
+	dropped *metric.Counter
+
+	// wakeup is signaled when notifications are added to an empty queue.
+	wakeup   chan struct{}
+	logEvery log.EveryN
+
+	mu struct {
+		syncutil.Mutex
+		// queue contains the notifications that haven't been sent yet, in
+		// commit order.
+		queue []serverpb.Notification
+	}
+}
+
+func newNotificationSender(
+	send func(ctx context.Context, notifications []serverpb.Notification) error,
+	dropped *metric.Counter,
+) *notificationSender {
+	return &notificationSender{
+		send:     send,
+		dropped:  dropped,
+		wakeup:   make(chan struct{}, 1),
+		logEvery: log.Every(10 * time.Second),
+	}
+}
+
+// enqueue queues the notifications of a committed transaction to be sent to
+// the other nodes. They are dropped if the queue cannot hold all of them.
+func (ns *notificationSender) enqueue(
+	ctx context.Context, notifications []serverpb.Notification,
+) {
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+	if len(ns.mu.queue)+len(notifications) > maxPendingNotificationSends {
+		ns.dropped.Inc(int64(len(notifications)))
+		if ns.logEvery.ShouldLog() {
+			log.Warningf(ctx, "dropping %d notifications: too many notifications "+
+				"waiting to be sent to other nodes", len(notifications))
+		}
+		return
+	}
+	ns.mu.queue = append(ns.mu.queue, notifications...)
+	select {
+	case ns.wakeup <- struct{}{}:
+	default:
+	}
+}
+
+// takeQueued returns the queued notifications and empties the queue.
+func (ns *notificationSender) takeQueued() []serverpb.Notification {
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+	queue := ns.mu.queue
+	ns.mu.queue = nil
+	return queue
+}
+
+// start starts the worker that sends the queued notifications.
+func (ns *notificationSender) start(ctx context.Context, stopper *stop.Stopper) {
+	_ = stopper.RunAsyncTask(ctx, "sql-notification-sender", func(ctx context.Context) {
+		ctx, cancel := stopper.WithCancelOnQuiesce(ctx)
+		defer cancel()
+		for {
+			select {
+			case <-ns.wakeup:
+				ns.sendQueued(ctx)
+			case <-stopper.ShouldQuiesce():
+				return
+			}
+		}
+	})
+}
+
+// sendQueued sends the queued notifications until the queue is empty. Errors
+// are logged rather than returned, since the transactions that sent the
+// notifications have already committed.
+func (ns *notificationSender) sendQueued(ctx context.Context) {
+	for {
+		notifications := ns.takeQueued()
+		if len(notifications) == 0 {
+			return
+		}
+		if err := timeutil.RunWithTimeout(ctx, "send notifications", notificationSendTimeout,
+			func(ctx context.Context) error {
+				return ns.send(ctx, notifications)
+			},
+		); err != nil {
+			log.Warningf(ctx, "error sending notifications: %v", err)
+		}
+	}
+}
+
+// sendNotificationsToOtherNodes sends the given notifications to the sessions
+// listening on their channels on the other nodes of the cluster.
+func (s *Server) sendNotificationsToOtherNodes(
+	ctx context.Context, notifications []serverpb.Notification,
+) error {
+	resp, err := s.cfg.SQLStatusServer.Notify(ctx, &serverpb.NotifyRequest{
+		Notifications: notifications,
+		SkipLocal:     true,
+	})
+	if err != nil {
+		return err
+	}
+	for i := range resp.Errors {
+		log.Warningf(ctx, "error sending notifications: %v", errors.DecodeError(ctx, resp.Errors[i]))
+	}
+	return nil
+}
+
+// DeliverNotifications delivers asynchronous notifications sent with NOTIFY
+// to the sessions on this node that are listening on their channels.
+func (s *Server) DeliverNotifications(
+	ctx context.Context, notifications []serverpb.Notification,
+) {
+	s.notifications.deliver(ctx, notifications)
+}
+
+// notificationRegistry keeps track of the sessions on this node that are
+// listening on notification channels.
+type notificationRegistry struct {
+	mu struct {
+		syncutil.Mutex
+		// listeners maps each channel to the sessions listening on it.
+		listeners map[string]map[*notificationListener]struct{}
+	}
+}
+
+func newNotificationRegistry() *notificationRegistry {
+	r := &notificationRegistry{}
+	r.mu.listeners = make(map[string]map[*notificationListener]struct{})
+	return r
+}
+
+// newListener creates a notificationListener for a client session. Queued
+// notifications are signaled to the session by pushing a DeliverNotifications
+// command to its StmtBuf.
+func (r *notificationRegistry) newListener(stmtBuf *StmtBuf, pid uint32) *notificationListener {
+	return &notificationListener{
+		registry: r,
+		stmtBuf:  stmtBuf,
+		pid:      int32(pid),
+		channels: make(map[string]struct{}),
+		logEvery: log.Every(10 * time.Second),
+	}
+}
+
+func (r *notificationRegistry) deliver(
+	ctx context.Context, notifications []serverpb.Notification,
+) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, n := range notifications {
+		for l := range r.mu.listeners[n.Channel] {
+			l.enqueue(ctx, n)
+		}
+	}
+}
+
+func (r *notificationRegistry) add(l *notificationListener, channel string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	listeners, ok := r.mu.listeners[channel]
+	if !ok {
+		listeners = make(map[*notificationListener]struct{})
+		r.mu.listeners[channel] = listeners
+	}
+	listeners[l] = struct{}{}
+}
+
+func (r *notificationRegistry) remove(l *notificationListener, channel string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	listeners := r.mu.listeners[channel]
+	delete(listeners, l)
+	if len(listeners) == 0 {
+		delete(r.mu.listeners, channel)
+	}
+}
+
+// notificationListener receives the notifications sent on the channels a
+// client session is listening on.
+type notificationListener struct {
+	registry *notificationRegistry
+	stmtBuf  *StmtBuf
+
+	// pid is the backend process ID of the session, reported as the sender of
+	// the notifications it sends.
+	pid int32
+
+	// channels is the set of channels the session is listening on. It is only
+	// accessed by the session's connExecutor.
+	channels map[string]struct{}
+
+	logEvery log.EveryN
+
+	mu struct {
+		syncutil.Mutex
+		// queue contains the notifications that haven't been sent to the
+		// client yet.
+		queue []serverpb.Notification
+		// wakeupPending is set when a DeliverNotifications command has been
+		// pushed to the session's StmtBuf and the queued notifications haven't
+		// been sent yet.
+		wakeupPending bool
+	}
+}
+
+func (l *notificationListener) listen(channel string) {
+	if _, ok := l.channels[channel]; ok {
+		return
+	}
+	l.channels[channel] = struct{}{}
+	l.registry.add(l, channel)
+}
+
+func (l *notificationListener) unlisten(channel string) {
+	if _, ok := l.channels[channel]; !ok {
+		return
+	}
+	delete(l.channels, channel)
+	l.registry.remove(l, channel)
+}
+
+func (l *notificationListener) unlistenAll() {
+	for channel := range l.channels {
+		l.unlisten(channel)
+	}
+}
+
+func (l *notificationListener) enqueue(ctx context.Context, n serverpb.Notification) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.mu.queue) >= maxQueuedNotifications {
+		if l.logEvery.ShouldLog() {
+			log.Warningf(ctx, "dropping notification on channel %q: too many notifications "+
+				"queued for session with backend PID %d", n.Channel, l.pid)
+		}
+		return
+	}
+	l.mu.queue = append(l.mu.queue, n)
+	if !l.mu.wakeupPending {
+		l.mu.wakeupPending = true
+		// An error is only returned if the StmtBuf is closed, in which case the
+		// session is going away and there is nobody to notify.
+		_ = l.stmtBuf.Push(ctx, DeliverNotifications{})
+	}
+}
+
+// takeQueued returns the notifications queued for the session and empties
+// the queue.
+func (l *notificationListener) takeQueued() []serverpb.Notification {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	queue := l.mu.queue
+	l.mu.queue = nil
+	l.mu.wakeupPending = false
+	return queue
+}
@@ -0,0 +1,90 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/metric"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNotificationSender verifies that the notifications committed on a node
+// are sent to the other nodes in commit order, and that they are dropped
+// rather than queued without bound when they cannot be sent.
+func TestNotificationSender(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	stopper := stop.NewStopper()
+	defer stopper.Stop(ctx)
+
+	var mu struct {
+		syncutil.Mutex
+		sent []string
+	}
+	unblock := make(chan struct{})
+	send := func(ctx context.Context, notifications []serverpb.Notification) error {
+		<-unblock
+		mu.Lock()
+		defer mu.Unlock()
+		for _, n := range notifications {
+			mu.sent = append(mu.sent, n.Payload)
+		}
+		// Errors are logged and must not prevent the next batches from
+		// being sent.
+		return errors.New("boom")
+	}
+	dropped := metric.NewCounter(MetaNotificationsDropped)
+	ns := newNotificationSender(send, dropped)
+	ns.start(ctx, stopper)
+
+	const numTxns = 100
+	var expected []string
+	for i := 0; i < numTxns; i++ {
+		payloads := []string{fmt.Sprintf("%d-a", i), fmt.Sprintf("%d-b", i)}
+		ns.enqueue(ctx, []serverpb.Notification{
+			{Channel: "c", Payload: payloads[0]},
+			{Channel: "c", Payload: payloads[1]},
+		})
+		expected = append(expected, payloads...)
+	}
+	close(unblock)
+	testutils.SucceedsSoon(t, func() error {
+		mu.Lock()
+		defer mu.Unlock()
+		if len(mu.sent) < len(expected) {
+			return errors.Newf("sent %d notifications, expected %d", len(mu.sent), len(expected))
+		}
+		return nil
+	})
+	mu.Lock()
+	require.Equal(t, expected, mu.sent)
+	mu.Unlock()
+	require.Zero(t, dropped.Count())
+
+	// Once the queue is full, notifications are dropped and counted.
+	full := newNotificationSender(send, dropped)
+	full.enqueue(ctx, make([]serverpb.Notification, maxPendingNotificationSends))
+	full.enqueue(ctx, make([]serverpb.Notification, 3))
+	require.Equal(t, int64(3), dropped.Count())
+	require.Len(t, full.takeQueued(), maxPendingNotificationSends)
+}
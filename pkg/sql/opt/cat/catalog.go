@@ -178,6 +178,13 @@ type Catalog interface {
 	// returns an error.
 	CheckExecutionPrivilege(ctx context.Context, oid oid.Oid) error
 
+	// WithRoutineExecContext calls fn with the session changed as described by
+	// the given execution context of a SECURITY DEFINER routine or a routine
+	// with SET options. Privilege checks and name resolution performed by fn
+	// use the definer of the routine and its session settings. The session is
+	// restored once fn returns.
+	WithRoutineExecContext(ctx context.Context, execCtx *tree.RoutineExecContext, fn func() error) error
+
 	// HasAdminRole checks that the current user has admin privileges. If yes,
 	// returns true. Returns an error if query on the `system.users` table failed
 	HasAdminRole(ctx context.Context) (bool, error)
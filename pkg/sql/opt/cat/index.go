@@ -215,6 +215,12 @@ type IndexColumn struct {
 	// Descending is true if the index is ordered from greatest to least on
 	// this column, rather than least to greatest.
 	Descending bool
+
+	// NullsReversed is true if NULLs are stored after all other values of this
+	// column when it is ascending, or before them when it is descending, rather
+	// than in the default position. The index does not provide an ordering on
+	// such a column unless it contains no NULLs.
+	NullsReversed bool
 }
 
 // IsMutationIndex is a convenience function that returns true if the index at
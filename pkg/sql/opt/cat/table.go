@@ -106,6 +106,13 @@ type Table interface {
 	// Check returns the ith check constraint, where i < CheckCount.
 	Check(i int) CheckConstraint
 
+	// TriggerCount returns the number of triggers defined on the table.
+	TriggerCount() int
+
+	// Trigger returns the ith trigger, where i < TriggerCount. Triggers are
+	// returned in the order in which they fire.
+	Trigger(i int) Trigger
+
 	// FamilyCount returns the number of column families present on the table.
 	// There is always at least one primary family (always family 0) where columns
 	// go if they are not explicitly assigned to another family. The primary
@@ -193,6 +200,41 @@ type CheckConstraint struct {
 	Validated  bool
 }
 
+// Trigger describes a row-level trigger on a table, which invokes a trigger
+// function for each row that is inserted, updated or deleted. For example:
+//
+//	CREATE TRIGGER tr BEFORE INSERT ON a FOR EACH ROW EXECUTE FUNCTION f()
+type Trigger struct {
+	Name tree.Name
+
+	// ActionTime is either tree.TriggerActionTimeBefore or
+	// tree.TriggerActionTimeAfter.
+	ActionTime tree.TriggerActionTime
+
+	// Events are the events that cause the trigger to fire.
+	Events []TriggerEvent
+
+	// WhenExpr is the SQL text of the WHEN condition of the trigger, or empty
+	// if the trigger fires unconditionally.
+	WhenExpr string
+
+	// FuncID is the ID of the trigger function.
+	FuncID descpb.ID
+
+	// FuncArgs are the arguments passed to the trigger function in TG_ARGV.
+	FuncArgs []string
+}
+
+// TriggerEvent is one of the events that cause a trigger to fire.
+type TriggerEvent struct {
+	Type tree.TriggerEventType
+
+	// ColumnOrdinals, if set for an UPDATE event, are the table ordinals of the
+	// columns in the UPDATE OF list. The trigger only fires for an UPDATE that
+	// targets one of them.
+	ColumnOrdinals []int
+}
+
 // TableStatistic is an interface to a table statistic. Each statistic is
 // associated with a set of columns.
 type TableStatistic interface {
@@ -318,6 +360,14 @@ type ForeignKeyConstraint interface {
 	// UpdateReferenceAction returns the action to be performed if the foreign key
 	// constraint would be violated by an update.
 	UpdateReferenceAction() tree.ReferenceAction
+
+	// Deferrable is true if the checks of this constraint may be deferred until
+	// the end of the transaction with SET CONSTRAINTS.
+	Deferrable() bool
+
+	// InitiallyDeferred is true if the checks of this constraint are deferred
+	// until the end of the transaction unless SET CONSTRAINTS says otherwise.
+	InitiallyDeferred() bool
 }
 
 // UniqueConstraint represents a uniqueness constraint. UniqueConstraints may
@@ -365,6 +415,38 @@ type UniqueConstraint interface {
 	// satisfied when building functional dependencies for the table. This enables
 	// additional optimizations, such as omission of uniqueness checks.
 	UniquenessGuaranteedByAnotherIndex() bool
+
+	// ExclusionElementCount returns the number of elements of an exclusion
+	// constraint, or zero if this is a regular unique constraint. Two rows
+	// violate an exclusion constraint if all of its elements conflict, rather
+	// than if all of its columns are equal. Exclusion constraints do not imply
+	// a key, so they cannot be used to build functional dependencies or as
+	// arbiters.
+	ExclusionElementCount() int
+
+	// ExclusionElement returns the ith element of an exclusion constraint.
+	ExclusionElement(i int) ExclusionElement
+
+	// Deferrable is true if the checks of this constraint may be deferred until
+	// the end of the transaction with SET CONSTRAINTS.
+	Deferrable() bool
+
+	// InitiallyDeferred is true if the checks of this constraint are deferred
+	// until the end of the transaction unless SET CONSTRAINTS says otherwise.
+	InitiallyDeferred() bool
+}
+
+// ExclusionElement is an element of an exclusion constraint.
+type ExclusionElement struct {
+	// Columns contains the position within the constraint columns (see
+	// UniqueConstraint.ColumnOrdinal) of a single column, or the positions of
+	// the start and end columns of a period.
+	Columns []int
+
+	// Overlaps is true if the element conflicts when the values of two rows
+	// overlap (&&), and false if it conflicts when they are equal (=). Periods
+	// always use Overlaps.
+	Overlaps bool
 }
 
 // UniqueOrdinal identifies a unique constraint (in the context of a Table).
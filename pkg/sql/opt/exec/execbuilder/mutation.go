@@ -126,6 +126,11 @@ func (b *Builder) buildInsert(ins *memo.InsertExpr) (execPlan, error) {
 		return execPlan{}, err
 	}
 
+	// Inserts only have cascades that run AFTER triggers.
+	if err := b.buildFKCascades(ins.WithID, ins.FKCascades); err != nil {
+		return execPlan{}, err
+	}
+
 	return ep, nil
 }
 
@@ -147,6 +152,25 @@ func (b *Builder) tryBuildFastPathInsert(ins *memo.InsertExpr) (_ execPlan, ok b
 	if len(ins.UniqueChecks) != len(ins.FastPathUniqueChecks) {
 		return execPlan{}, false, nil
 	}
+	// Cascades, which run AFTER triggers, are not supported by the fast path.
+	if len(ins.FKCascades) > 0 {
+		return execPlan{}, false, nil
+	}
+
+	md := b.mem.Metadata()
+
+	// Checks of deferrable constraints may have to wait until the transaction
+	// commits, so they cannot be performed by the fast path.
+	for i := range ins.UniqueChecks {
+		if uniqueCheckDeferral(md, &ins.UniqueChecks[i]) != nil {
+			return execPlan{}, false, nil
+		}
+	}
+	for i := range ins.FKChecks {
+		if fkCheckDeferral(md, &ins.FKChecks[i]) != nil {
+			return execPlan{}, false, nil
+		}
+	}
 
 	insInput := ins.Input
 	values, ok := insInput.(*memo.ValuesExpr)
@@ -156,7 +180,6 @@ func (b *Builder) tryBuildFastPathInsert(ins *memo.InsertExpr) (_ execPlan, ok b
 		return execPlan{}, false, nil
 	}
 
-	md := b.mem.Metadata()
 	tab := md.Table(ins.Table)
 
 	uniqChecks := make([]exec.InsertFastPathCheck, len(ins.UniqueChecks))
@@ -783,7 +806,7 @@ func (b *Builder) buildUniqueChecks(checks memo.UniqueChecksExpr) error {
 			}
 			return mkUniqueCheckErr(md, c, keyVals)
 		}
-		node, err := b.factory.ConstructErrorIfRows(query.root, mkErr)
+		node, err := b.factory.ConstructErrorIfRows(query.root, mkErr, uniqueCheckDeferral(md, c))
 		if err != nil {
 			return err
 		}
@@ -815,7 +838,7 @@ func (b *Builder) buildFKChecks(checks memo.FKChecksExpr) error {
 			}
 			return mkFKCheckErr(md, c, keyVals)
 		}
-		node, err := b.factory.ConstructErrorIfRows(query.root, mkErr)
+		node, err := b.factory.ConstructErrorIfRows(query.root, mkErr, fkCheckDeferral(md, c))
 		if err != nil {
 			return err
 		}
@@ -824,6 +847,39 @@ func (b *Builder) buildFKChecks(checks memo.FKChecksExpr) error {
 	return nil
 }
 
+// uniqueCheckDeferral returns the deferral of the given uniqueness check, or
+// nil if the unique constraint is not deferrable.
+func uniqueCheckDeferral(md *opt.Metadata, c *memo.UniqueChecksItem) *exec.CheckDeferral {
+	uc := md.Table(c.Table).Unique(c.CheckOrdinal)
+	if !uc.Deferrable() {
+		return nil
+	}
+	return &exec.CheckDeferral{
+		TableID:           uc.TableID(),
+		ConstraintName:    uc.Name(),
+		InitiallyDeferred: uc.InitiallyDeferred(),
+	}
+}
+
+// fkCheckDeferral returns the deferral of the given foreign key check, or nil
+// if the foreign key is not deferrable.
+func fkCheckDeferral(md *opt.Metadata, c *memo.FKChecksItem) *exec.CheckDeferral {
+	var fk cat.ForeignKeyConstraint
+	if c.FKOutbound {
+		fk = md.Table(c.OriginTable).OutboundForeignKey(c.FKOrdinal)
+	} else {
+		fk = md.Table(c.ReferencedTable).InboundForeignKey(c.FKOrdinal)
+	}
+	if !fk.Deferrable() {
+		return nil
+	}
+	return &exec.CheckDeferral{
+		TableID:           fk.OriginTableID(),
+		ConstraintName:    fk.Name(),
+		InitiallyDeferred: fk.InitiallyDeferred(),
+	}
+}
+
 // mkUniqueCheckErr generates a user-friendly error describing a uniqueness
 // violation. The keyVals are the values that correspond to the
 // cat.UniqueConstraint columns.
@@ -831,12 +887,20 @@ func mkUniqueCheckErr(md *opt.Metadata, c *memo.UniqueChecksItem, keyVals tree.D
 	tabMeta := md.TableMeta(c.Table)
 	uc := tabMeta.Table.Unique(c.CheckOrdinal)
 	constraintName := uc.Name()
+	isExclusion := uc.ExclusionElementCount() > 0
 	var msg, details bytes.Buffer
 
 	// Generate an error of the form:
 	//   ERROR:  duplicate key value violates unique constraint "foo"
 	//   DETAIL: Key (k)=(2) already exists.
-	msg.WriteString("duplicate key value violates unique constraint ")
+	// or, for exclusion constraints:
+	//   ERROR:  conflicting key value violates exclusion constraint "foo"
+	//   DETAIL: Key (k)=(2) conflicts with an existing key.
+	if isExclusion {
+		msg.WriteString("conflicting key value violates exclusion constraint ")
+	} else {
+		msg.WriteString("duplicate key value violates unique constraint ")
+	}
 	lexbase.EncodeEscapedSQLIdent(&msg, constraintName)
 
 	details.WriteString("Key (")
@@ -855,11 +919,17 @@ func mkUniqueCheckErr(md *opt.Metadata, c *memo.UniqueChecksItem, keyVals tree.D
 		details.WriteString(d.String())
 	}
 
-	details.WriteString(") already exists.")
+	code := pgcode.UniqueViolation
+	if isExclusion {
+		details.WriteString(") conflicts with an existing key.")
+		code = pgcode.ExclusionViolation
+	} else {
+		details.WriteString(") already exists.")
+	}
 
 	return errors.WithDetail(
 		pgerror.WithConstraintName(
-			pgerror.Newf(pgcode.UniqueViolation, "%s", msg.String()),
+			pgerror.Newf(code, "%s", msg.String()),
 			constraintName,
 		),
 		details.String(),
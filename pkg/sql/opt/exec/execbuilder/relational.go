@@ -1580,6 +1580,30 @@ func (b *Builder) buildGroupBy(groupBy memo.RelExpr) (execPlan, error) {
 			agg = aggDistinct.Input
 		}
 
+		if uda, ok := agg.(*memo.UserDefinedAggExpr); ok {
+			argCols := make([]exec.NodeColumnOrdinal, len(uda.Args))
+			for j := range uda.Args {
+				variable, ok := uda.Args[j].(*memo.VariableExpr)
+				if !ok {
+					return execPlan{}, errors.AssertionFailedf("only VariableOp args supported")
+				}
+				argCols[j], err = input.getNodeColumnOrdinal(variable.Col)
+				if err != nil {
+					return execPlan{}, err
+				}
+			}
+			aggInfos[i] = exec.AggInfo{
+				FuncName:    uda.Def.Name,
+				Distinct:    distinct,
+				ResultType:  item.Agg.DataType(),
+				ArgCols:     argCols,
+				Filter:      filterOrd,
+				UserDefined: b.buildUserDefinedAggregate(uda.Def),
+			}
+			ep.outputCols.Set(int(item.Col), len(groupingColIdx)+i)
+			continue
+		}
+
 		name, _ := memo.FindAggregateOverload(agg)
 
 		// Accumulate variable arguments in argCols and constant arguments in
@@ -3183,8 +3207,12 @@ func (b *Builder) buildCall(c *memo.CallExpr) (execPlan, error) {
 		udf.Def.SetReturning,
 		udf.TailCall,
 		true, /* procedure */
-		nil,  /* blockState */
-		nil,  /* cursorDeclaration */
+		tree.StoredProcTxnNoOp,
+		nil,   /* blockState */
+		nil,   /* cursorDeclaration */
+		false, /* returnNext */
+		false, /* bufferedResult */
+		udf.Def.ExecContext,
 	)
 
 	var ep execPlan
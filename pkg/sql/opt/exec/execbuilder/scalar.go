@@ -69,6 +69,7 @@ func init() {
 		opt.AnyOp:            (*Builder).buildAny,
 		opt.AnyScalarOp:      (*Builder).buildAnyScalar,
 		opt.IndirectionOp:    (*Builder).buildIndirection,
+		opt.ArraySubscriptOp: (*Builder).buildArraySubscript,
 		opt.CollateOp:        (*Builder).buildCollate,
 		opt.ArrayFlattenOp:   (*Builder).buildArrayFlatten,
 		opt.IfErrOp:          (*Builder).buildIfErr,
@@ -487,6 +488,35 @@ func (b *Builder) buildIndirection(
 	return tree.NewTypedIndirectionExpr(expr, index, scalar.DataType()), nil
 }
 
+func (b *Builder) buildArraySubscript(
+	ctx *buildScalarCtx, scalar opt.ScalarExpr,
+) (tree.TypedExpr, error) {
+	arraySubscript := scalar.(*memo.ArraySubscriptExpr)
+	expr, err := b.buildScalar(ctx, arraySubscript.Input)
+	if err != nil {
+		return nil, err
+	}
+
+	subscripts := make(tree.ArraySubscripts, len(arraySubscript.Kinds))
+	bounds := arraySubscript.Bounds
+	for i, kind := range arraySubscript.Kinds {
+		subscripts[i] = &tree.ArraySubscript{Slice: kind.IsSlice()}
+		if kind.HasBegin() {
+			if subscripts[i].Begin, err = b.buildScalar(ctx, bounds[0]); err != nil {
+				return nil, err
+			}
+			bounds = bounds[1:]
+		}
+		if kind.HasEnd() {
+			if subscripts[i].End, err = b.buildScalar(ctx, bounds[0]); err != nil {
+				return nil, err
+			}
+			bounds = bounds[1:]
+		}
+	}
+	return tree.NewTypedArraySubscriptExpr(expr, subscripts, scalar.DataType()), nil
+}
+
 func (b *Builder) buildCollate(ctx *buildScalarCtx, scalar opt.ScalarExpr) (tree.TypedExpr, error) {
 	expr, err := b.buildScalar(ctx, scalar.Child(0).(opt.ScalarExpr))
 	if err != nil {
@@ -702,8 +732,12 @@ func (b *Builder) buildExistsSubquery(
 				false, /* generator */
 				false, /* tailCall */
 				false, /* procedure */
+				tree.StoredProcTxnNoOp,
 				nil,   /* blockState */
 				nil,   /* cursorDeclaration */
+				false, /* returnNext */
+				false, /* bufferedResult */
+				nil,   /* execContext */
 			),
 			tree.DBoolFalse,
 		}, types.Bool), nil
@@ -821,8 +855,12 @@ func (b *Builder) buildSubquery(
 			false, /* generator */
 			false, /* tailCall */
 			false, /* procedure */
+			tree.StoredProcTxnNoOp,
 			nil,   /* blockState */
 			nil,   /* cursorDeclaration */
+			false, /* returnNext */
+			false, /* bufferedResult */
+			nil,   /* execContext */
 		), nil
 	}
 
@@ -879,8 +917,12 @@ func (b *Builder) buildSubquery(
 			false, /* generator */
 			false, /* tailCall */
 			false, /* procedure */
+			tree.StoredProcTxnNoOp,
 			nil,   /* blockState */
 			nil,   /* cursorDeclaration */
+			false, /* returnNext */
+			false, /* bufferedResult */
+			nil,   /* execContext */
 		), nil
 	}
 
@@ -1000,8 +1042,12 @@ func (b *Builder) buildUDF(ctx *buildScalarCtx, scalar opt.ScalarExpr) (tree.Typ
 				action.SetReturning,
 				false, /* tailCall */
 				false, /* procedure */
+				tree.StoredProcTxnNoOp,
 				nil,   /* blockState */
 				nil,   /* cursorDeclaration */
+				false, /* returnNext */
+				false, /* bufferedResult */
+				nil,   /* execContext */
 			)
 		}
 	}
@@ -1017,11 +1063,71 @@ func (b *Builder) buildUDF(ctx *buildScalarCtx, scalar opt.ScalarExpr) (tree.Typ
 		udf.Def.SetReturning,
 		udf.TailCall,
 		false, /* procedure */
+		udf.Def.TxnOp,
 		udf.Def.BlockState,
 		udf.Def.CursorDeclaration,
+		udf.Def.ReturnNext,
+		udf.Def.BufferedResult,
+		udf.Def.ExecContext,
 	), nil
 }
 
+// buildUserDefinedAggregate builds the support routines of a user-defined
+// aggregate. The routines are built without arguments; the aggregator supplies
+// the current state and the input row when it invokes them.
+func (b *Builder) buildUserDefinedAggregate(def *memo.UDAggDefinition) *tree.UserDefinedAggregate {
+	return &tree.UserDefinedAggregate{
+		Name:        def.Name,
+		StateType:   def.StateType,
+		InitCond:    def.InitCond,
+		StateFunc:   b.buildAggregateSupportRoutine(def.StateFunc),
+		FinalFunc:   b.buildAggregateSupportRoutine(def.FinalFunc),
+		CombineFunc: b.buildAggregateSupportRoutine(def.CombineFunc),
+	}
+}
+
+// buildAggregateSupportRoutine builds a routine with no arguments for a
+// support function of a user-defined aggregate. It returns nil if def is nil.
+func (b *Builder) buildAggregateSupportRoutine(def *memo.UDFDefinition) *tree.RoutineExpr {
+	if def == nil {
+		return nil
+	}
+	for _, s := range def.Body {
+		if s.Relational().CanMutate {
+			b.ContainsMutation = true
+			break
+		}
+	}
+	if def.BlockState != nil {
+		b.initRoutineExceptionHandler(def.BlockState, def.ExceptionBlock)
+	}
+	planGen := b.buildRoutinePlanGenerator(
+		def.Params,
+		def.Body,
+		def.BodyProps,
+		false, /* allowOuterWithRefs */
+		nil,   /* wrapRootExpr */
+	)
+	return tree.NewTypedRoutineExpr(
+		def.Name,
+		nil, /* args */
+		planGen,
+		def.Typ,
+		def.Volatility == volatility.Volatile, /* enableStepping */
+		def.CalledOnNullInput,
+		def.MultiColDataSource,
+		def.SetReturning,
+		false, /* tailCall */
+		false, /* procedure */
+		def.TxnOp,
+		def.BlockState,
+		def.CursorDeclaration,
+		def.ReturnNext,
+		def.BufferedResult,
+		def.ExecContext,
+	)
+}
+
 // initRoutineExceptionHandler initializes the exception handler (if any) for
 // the shared BlockState of a group of sub-routines within a PLpgSQL block.
 func (b *Builder) initRoutineExceptionHandler(
@@ -1057,8 +1163,12 @@ func (b *Builder) initRoutineExceptionHandler(
 			action.SetReturning,
 			false, /* tailCall */
 			false, /* procedure */
+			tree.StoredProcTxnNoOp,
 			nil,   /* blockState */
 			nil,   /* cursorDeclaration */
+			false, /* returnNext */
+			false, /* bufferedResult */
+			nil,   /* execContext */
 		)
 	}
 	blockState.ExceptionHandler = exceptionHandler
@@ -66,6 +66,7 @@ func (b *Builder) buildCreateView(cv *memo.CreateViewExpr) (execPlan, error) {
 		cols,
 		cv.Deps,
 		cv.TypeDeps,
+		cv.TextSearchDeps,
 	)
 	return execPlan{root: root}, err
 }
@@ -78,6 +79,7 @@ func (b *Builder) buildCreateFunction(cf *memo.CreateFunctionExpr) (execPlan, er
 		cf.Syntax,
 		cf.Deps,
 		cf.TypeDeps,
+		cf.TextSearchDeps,
 	)
 	return execPlan{root: root}, err
 }
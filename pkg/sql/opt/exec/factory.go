@@ -154,6 +154,10 @@ type AggInfo struct {
 	// Filter is the index of the column, if any, which should be used as the
 	// FILTER condition for the aggregate. If there is no filter, Filter is -1.
 	Filter NodeColumnOrdinal
+
+	// UserDefined is set if the aggregate was created with CREATE AGGREGATE.
+	// In that case FuncName is the name of the aggregate.
+	UserDefined *tree.UserDefinedAggregate
 }
 
 // WindowInfo represents the information about a window function that must be
@@ -289,6 +293,22 @@ type InsertFastPathCheck struct {
 // relevant row.
 type MkErrFn func(tree.Datums) error
 
+// CheckDeferral identifies the DEFERRABLE constraint enforced by a check
+// query. Depending on SET CONSTRAINTS, the check may be skipped and the
+// constraint instead re-validated when the transaction commits.
+type CheckDeferral struct {
+	// TableID is the table on which the constraint is defined. For foreign
+	// keys, this is the origin (referencing) table.
+	TableID cat.StableID
+
+	// ConstraintName is the name of the constraint.
+	ConstraintName string
+
+	// InitiallyDeferred is true if the constraint is deferred unless SET
+	// CONSTRAINTS says otherwise.
+	InitiallyDeferred bool
+}
+
 // ExplainFactory is an extension of Factory used when constructing a plan that
 // can be explained. It allows annotation of nodes with extra information.
 type ExplainFactory interface {
@@ -28,6 +28,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/jsonpath"
 	"github.com/cockroachdb/errors"
 )
 
@@ -400,6 +401,13 @@ func (j *jsonOrArrayFilterPlanner) extractInvertedFilterConditionFromLeaf(
 		}
 	case *memo.OverlapsExpr:
 		invertedExpr = j.extractArrayOverlapsCondition(ctx, evalCtx, t.Left, t.Right)
+	case *memo.TSMatchesExpr:
+		invertedExpr = j.extractJSONPathCondition(ctx, evalCtx, t.Left, t.Right, false /* exists */)
+	case *memo.FunctionExpr:
+		// The @? operator is implemented by the jsonb_path_exists_opr function.
+		if t.Name == "jsonb_path_exists_opr" && len(t.Args) == 2 {
+			invertedExpr = j.extractJSONPathCondition(ctx, evalCtx, t.Args[0], t.Args[1], true /* exists */)
+		}
 	}
 
 	if invertedExpr == nil {
@@ -600,6 +608,54 @@ func (j *jsonOrArrayFilterPlanner) extractJSONEqCondition(
 	return invertedExpr
 }
 
+// extractJSONPathCondition extracts an InvertedExpression representing an
+// inverted filter over the planner's inverted index, based on a jsonpath match
+// (@@) or, if exists is true, a jsonpath exists (@?) expression. If an
+// InvertedExpression cannot be generated from the expression, an
+// inverted.NonInvertedColExpression is returned.
+//
+// In order to generate an InvertedExpression, left must be a variable or
+// expression referencing the inverted column in the inverted index, and right
+// must be a constant jsonpath of one of the forms supported by
+// jsonpath.RequiredContainments, such as $.a.b == 1.
+func (j *jsonOrArrayFilterPlanner) extractJSONPathCondition(
+	ctx context.Context, evalCtx *eval.Context, left, right opt.ScalarExpr, exists bool,
+) inverted.Expression {
+	if !isIndexColumn(j.tabID, j.index, left, j.computedColumns) {
+		return inverted.NonInvertedColExpression{}
+	}
+	if !memo.CanExtractConstDatum(right) {
+		return inverted.NonInvertedColExpression{}
+	}
+	path, ok := memo.ExtractConstDatum(right).(*tree.DJsonpath)
+	if !ok {
+		return inverted.NonInvertedColExpression{}
+	}
+	vals, ok := jsonpath.RequiredContainments(path.Jsonpath, exists)
+	if !ok {
+		return inverted.NonInvertedColExpression{}
+	}
+
+	// Any row for which the expression is true contains at least one of the
+	// values, so we generate the disjunction of the inverted expressions for
+	// containment of each value.
+	var invertedExpr inverted.Expression
+	for _, val := range vals {
+		expr := getInvertedExprForJSONOrArrayIndexForContaining(ctx, evalCtx, tree.NewDJSON(val))
+		if invertedExpr == nil {
+			invertedExpr = expr
+		} else {
+			invertedExpr = inverted.Or(invertedExpr, expr)
+		}
+	}
+
+	// The values only describe the forms that matching rows can take, so the
+	// generated inverted expression is not tight and the original expression
+	// must be applied after the inverted index scan.
+	invertedExpr.SetNotTight()
+	return invertedExpr
+}
+
 // extractJSONFetchValEqCondition extracts an InvertedExpression representing an
 // inverted filter over the planner's inverted index, based on equality between
 // a chain of fetch val expressions and a scalar expression. If an
@@ -1173,6 +1173,54 @@ func TestTryFilterJsonOrArrayIndex(t *testing.T) {
 			indexOrd: jsonOrd,
 			ok:       false,
 		},
+		{
+			filters:          `j @@ 'strict $.a == 1'`,
+			indexOrd:         jsonOrd,
+			ok:               true,
+			tight:            false,
+			unique:           true,
+			remainingFilters: `j @@ 'strict $.a == 1'`,
+		},
+		{
+			filters:          `j @@ '$.a.b == "x"'`,
+			indexOrd:         jsonOrd,
+			ok:               true,
+			tight:            false,
+			unique:           false,
+			remainingFilters: `j @@ '$.a.b == "x"'`,
+		},
+		{
+			filters:          `j @? 'strict $.a ? (@.b == 1)'`,
+			indexOrd:         jsonOrd,
+			ok:               true,
+			tight:            false,
+			unique:           true,
+			remainingFilters: `j @? 'strict $.a ? (@.b == 1)'`,
+		},
+		{
+			filters:          `j @? '$.a ? (@ == true)'`,
+			indexOrd:         jsonOrd,
+			ok:               true,
+			tight:            false,
+			unique:           false,
+			remainingFilters: `j @? '$.a ? (@ == true)'`,
+		},
+		{
+			// Only equality with a constant can be index-accelerated.
+			filters:  `j @@ '$.a > 1'`,
+			indexOrd: jsonOrd,
+			ok:       false,
+		},
+		{
+			filters:  `j @? '$.a'`,
+			indexOrd: jsonOrd,
+			ok:       false,
+		},
+		{
+			filters:  `j2 @@ '$.a == 1'`,
+			indexOrd: jsonOrd,
+			ok:       false,
+		},
 	}
 
 	for _, tc := range testCases {
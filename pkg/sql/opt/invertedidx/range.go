@@ -0,0 +1,108 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package invertedidx
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/inverted"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/invertedexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+type rangeFilterPlanner struct {
+	tabID           opt.TableID
+	index           cat.Index
+	computedColumns map[opt.ColumnID]opt.ScalarExpr
+}
+
+var _ invertedFilterPlanner = &rangeFilterPlanner{}
+
+// extractInvertedFilterConditionFromLeaf implements the invertedFilterPlanner
+// interface.
+func (r *rangeFilterPlanner) extractInvertedFilterConditionFromLeaf(
+	ctx context.Context, evalCtx *eval.Context, expr opt.ScalarExpr,
+) (
+	invertedExpr inverted.Expression,
+	remainingFilters opt.ScalarExpr,
+	_ *invertedexpr.PreFiltererStateForInvertedFilterer,
+) {
+	var left, right opt.ScalarExpr
+	var containedBy, overlaps bool
+	switch e := expr.(type) {
+	case *memo.ContainsExpr:
+		left, right = e.Left, e.Right
+	case *memo.ContainedByExpr:
+		left, right = e.Left, e.Right
+		containedBy = true
+	case *memo.OverlapsExpr:
+		left, right = e.Left, e.Right
+		overlaps = true
+	default:
+		// Only the above types are supported.
+		return inverted.NonInvertedColExpression{}, expr, nil
+	}
+	var constantVal opt.ScalarExpr
+	if isIndexColumn(r.tabID, r.index, left, r.computedColumns) && memo.CanExtractConstDatum(right) {
+		constantVal = right
+	} else if isIndexColumn(r.tabID, r.index, right, r.computedColumns) && memo.CanExtractConstDatum(left) {
+		// The expression is equivalent to one with the index column on the left
+		// and the inverse operator.
+		constantVal = left
+		containedBy = !containedBy
+	} else {
+		// Can only accelerate with a single constant value.
+		return inverted.NonInvertedColExpression{}, expr, nil
+	}
+	d := eval.UnwrapDatum(ctx, evalCtx, memo.ExtractConstDatum(constantVal))
+	if d == tree.DNull {
+		return inverted.NonInvertedColExpression{}, expr, nil
+	}
+
+	// Every non-empty range or multirange in the index which contains, is
+	// contained by or overlaps with the constant value must overlap with it.
+	// Empty values are contained by every range or multirange but overlap with
+	// none, so they are added to the spans for contained by, and the index
+	// cannot be used to find the values which contain an empty constant.
+	if !overlaps && !containedBy && rangeOrMultirangeIsEmpty(d) {
+		return inverted.NonInvertedColExpression{}, expr, nil
+	}
+	invertedExpr, err := rowenc.EncodeRangeOverlapsInvertedIndexSpans(d, containedBy && !overlaps)
+	if err != nil || invertedExpr == nil {
+		// An inverted expression could not be extracted, or the constant value is
+		// empty and no values can overlap with it.
+		return inverted.NonInvertedColExpression{}, expr, nil
+	}
+
+	// The inverted expression is never tight, so the original filter must be
+	// applied after the inverted index scan.
+	//
+	// We do not currently support pre-filtering for range indexes, so the
+	// returned pre-filter state is nil.
+	return invertedExpr, expr, nil
+}
+
+// rangeOrMultirangeIsEmpty returns true if d is an empty range or an empty
+// multirange.
+func rangeOrMultirangeIsEmpty(d tree.Datum) bool {
+	switch t := d.(type) {
+	case *tree.DRange:
+		return t.Empty
+	case *tree.DMultirange:
+		return len(t.Ranges) == 0
+	}
+	return false
+}
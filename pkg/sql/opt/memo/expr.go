@@ -271,6 +271,13 @@ func (n FiltersExpr) Difference(other FiltersExpr) FiltersExpr {
 	return newFilters
 }
 
+// HasSubArrays returns true if the ARRAY constructor builds a multidimensional
+// array out of sub-arrays, as in ARRAY[ARRAY[1,2],ARRAY[3,4]], rather than a
+// one-dimensional array out of its elements.
+func (e *ArrayExpr) HasSubArrays() bool {
+	return len(e.Elems) > 0 && tree.IsSubArrayConstructor(e.Elems[0].DataType())
+}
+
 // NoOpDistribution returns true if a DistributeExpr has the same distribution
 // as its input.
 func (e *DistributeExpr) NoOpDistribution() bool {
@@ -362,6 +369,53 @@ func (n ZipExpr) OutputCols() opt.ColSet {
 // used by the ColumnAccess scalar expression.
 type TupleOrdinal uint32
 
+// ArraySubscriptKind describes one subscript of an ArraySubscript scalar
+// expression, which determines which of its bounds are present.
+type ArraySubscriptKind uint8
+
+const (
+	// ArraySubscriptIndex is a subscript of the form [i].
+	ArraySubscriptIndex ArraySubscriptKind = iota
+	// ArraySubscriptSlice is a slice of the form [i:j].
+	ArraySubscriptSlice
+	// ArraySubscriptSliceFrom is a slice of the form [i:].
+	ArraySubscriptSliceFrom
+	// ArraySubscriptSliceTo is a slice of the form [:j].
+	ArraySubscriptSliceTo
+	// ArraySubscriptSliceAll is a slice of the form [:].
+	ArraySubscriptSliceAll
+)
+
+// HasBegin returns true if the subscript has a lower bound, or an index.
+func (k ArraySubscriptKind) HasBegin() bool {
+	return k == ArraySubscriptIndex || k == ArraySubscriptSlice || k == ArraySubscriptSliceFrom
+}
+
+// HasEnd returns true if the subscript is a slice with an upper bound.
+func (k ArraySubscriptKind) HasEnd() bool {
+	return k == ArraySubscriptSlice || k == ArraySubscriptSliceTo
+}
+
+// IsSlice returns true if the subscript is a slice.
+func (k ArraySubscriptKind) IsSlice() bool {
+	return k != ArraySubscriptIndex
+}
+
+// ArraySubscriptKinds describes the subscripts of an ArraySubscript scalar
+// expression, in order.
+type ArraySubscriptKinds []ArraySubscriptKind
+
+// IsSlice returns true if any of the subscripts is a slice, in which case
+// the expression produces an array rather than an element.
+func (k ArraySubscriptKinds) IsSlice() bool {
+	for _, kind := range k {
+		if kind.IsSlice() {
+			return true
+		}
+	}
+	return false
+}
+
 // ScanLimit is used for a limited table or index scan and stores the limit as
 // well as the desired scan direction. A value of 0 means that there is no
 // limit.
@@ -737,6 +791,29 @@ type UDFDefinition struct {
 	// result of the routine. This invariant is enforced when the PLpgSQL routine
 	// is built. CursorDeclaration may be unset.
 	CursorDeclaration *tree.RoutineOpenCursor
+
+	// TxnOp indicates that the routine commits or rolls back the current
+	// transaction before it is evaluated. It is used to implement COMMIT and
+	// ROLLBACK statements in PLpgSQL procedures.
+	TxnOp tree.StoredProcTxnOp
+
+	// ReturnNext is true if the rows returned by the first body statement are
+	// added to the result of the enclosing set-returning PLpgSQL function. It
+	// is used to implement the RETURN NEXT and RETURN QUERY statements. The
+	// first body statement returns a single column with the function's return
+	// type.
+	ReturnNext bool
+
+	// BufferedResult is true if the rows returned by the routine are collected
+	// by the PLpgSQL RETURN NEXT and RETURN QUERY statements during execution,
+	// rather than being the result of the last body statement. It is only set
+	// for set-returning PLpgSQL functions.
+	BufferedResult bool
+
+	// ExecContext, if set, describes the changes to the session that are in
+	// effect while the routine executes. It is only set for SECURITY DEFINER
+	// routines and routines with SET options.
+	ExecContext *tree.RoutineExecContext
 }
 
 // ExceptionBlock contains the information needed to match and handle errors in
@@ -753,6 +830,37 @@ type ExceptionBlock struct {
 	Actions []*UDFDefinition
 }
 
+// UDAggDefinition stores details about an aggregate function created
+// with CREATE AGGREGATE. It is stored separately from the call-site so that
+// different invocations of the same aggregate can point to the same definition.
+type UDAggDefinition struct {
+	// Name is the name of the aggregate.
+	Name string
+
+	// Typ is the return type of the aggregate.
+	Typ *types.T
+
+	// StateType is the type of the aggregate's transition state.
+	StateType *types.T
+
+	// InitCond is the initial value of the transition state. It is NULL if the
+	// aggregate was created without an INITCOND.
+	InitCond tree.Datum
+
+	// StateFunc is the state transition function, which is called with the
+	// current state and the arguments of each input row, and returns the next
+	// state.
+	StateFunc *UDFDefinition
+
+	// FinalFunc, if set, is called with the final state to compute the result of
+	// the aggregate. If it is unset, the final state is the result.
+	FinalFunc *UDFDefinition
+
+	// CombineFunc, if set, combines two partial states into one. It allows the
+	// aggregate to be computed in multiple stages.
+	CombineFunc *UDFDefinition
+}
+
 // WindowFrame denotes the definition of a window frame for an individual
 // window function, excluding the OFFSET expressions, if present.
 type WindowFrame struct {
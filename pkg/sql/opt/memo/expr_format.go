@@ -965,6 +965,15 @@ func (f *ExprFmtCtx) formatScalarWithLabel(
 			if len(udf.Def.Params) > 0 {
 				f.formatColList(tp, "params:", udf.Def.Params, opt.ColSet{} /* notNullCols */)
 			}
+			switch udf.Def.TxnOp {
+			case tree.StoredProcTxnCommit:
+				tp.Child("commit")
+			case tree.StoredProcTxnRollback:
+				tp.Child("rollback")
+			}
+			if udf.Def.BufferedResult {
+				tp.Child("buffered-result")
+			}
 			n = tp.Child("body")
 			for i := range udf.Def.Body {
 				if i == 0 && udf.Def.CursorDeclaration != nil {
@@ -973,6 +982,13 @@ func (f *ExprFmtCtx) formatScalarWithLabel(
 					f.formatExpr(udf.Def.Body[i], cur)
 					continue
 				}
+				if i == 0 && udf.Def.ReturnNext {
+					// The result of the first statement is added to the result of
+					// the set-returning function.
+					next := n.Child("return-next")
+					f.formatExpr(udf.Def.Body[i], next)
+					continue
+				}
 				f.formatExpr(udf.Def.Body[i], n)
 			}
 			delete(f.seenUDFs, udf.Def)
@@ -1693,6 +1709,23 @@ func FormatPrivate(f *ExprFmtCtx, private interface{}, physProps *physical.Requi
 	case *TupleOrdinal:
 		fmt.Fprintf(f.Buffer, " %d", *t)
 
+	case *ArraySubscriptKinds:
+		f.space()
+		for _, kind := range *t {
+			switch kind {
+			case ArraySubscriptIndex:
+				f.Buffer.WriteString("[i]")
+			case ArraySubscriptSlice:
+				f.Buffer.WriteString("[i:j]")
+			case ArraySubscriptSliceFrom:
+				f.Buffer.WriteString("[i:]")
+			case ArraySubscriptSliceTo:
+				f.Buffer.WriteString("[:j]")
+			case ArraySubscriptSliceAll:
+				f.Buffer.WriteString("[:]")
+			}
+		}
+
 	case *ScanPrivate:
 		f.formatIndex(t.Table, t.Index, ScanIsReverseFn(f.Memo.Metadata(), t, &physProps.Ordering))
 
@@ -1749,6 +1782,9 @@ func FormatPrivate(f *ExprFmtCtx, private interface{}, physProps *physical.Requi
 	case *FunctionPrivate:
 		fmt.Fprintf(f.Buffer, " %s", t.Name)
 
+	case *UserDefinedAggPrivate:
+		fmt.Fprintf(f.Buffer, " %s", t.Def.Name)
+
 	case *WindowsItemPrivate:
 		fmt.Fprintf(f.Buffer, " frame=%q", &t.Frame)
 
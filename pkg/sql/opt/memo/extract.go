@@ -46,6 +46,12 @@ func CanExtractConstDatum(e opt.Expr) bool {
 	}
 
 	if arr, ok := e.(*ArrayExpr); ok {
+		if arr.HasSubArrays() {
+			// Constructing a multidimensional array can fail if its sub-arrays
+			// have different dimensions. Constant multidimensional arrays are
+			// instead folded into Const expressions by the FoldArray rule.
+			return false
+		}
 		for _, elem := range arr.Elems {
 			if !CanExtractConstDatum(elem) {
 				return false
@@ -133,6 +139,16 @@ func ExtractAggInputColumns(e opt.ScalarExpr) opt.ColSet {
 		panic(errors.AssertionFailedf("not an Aggregate"))
 	}
 
+	if uda, ok := e.(*UserDefinedAggExpr); ok {
+		// The arguments of a user-defined aggregate are stored in a list.
+		for i := range uda.Args {
+			if variable, ok := uda.Args[i].(*VariableExpr); ok {
+				res.Add(variable.Col)
+			}
+		}
+		return res
+	}
+
 	for i, n := 0, e.ChildCount(); i < n; i++ {
 		if variable, ok := e.Child(i).(*VariableExpr); ok {
 			res.Add(variable.Col)
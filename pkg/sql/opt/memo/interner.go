@@ -484,6 +484,7 @@ func (h *hasher) HashOrderingChoice(val props.OrderingChoice) {
 		choice := &val.Columns[i]
 		h.HashColSet(choice.Group)
 		h.HashBool(choice.Descending)
+		h.HashBool(choice.NullsReversed)
 	}
 }
 
@@ -608,6 +609,14 @@ func (h *hasher) HashSchemaTypeDeps(val opt.SchemaTypeDeps) {
 	h.hash = hash
 }
 
+func (h *hasher) HashSchemaTextSearchDeps(val opt.SchemaTextSearchDeps) {
+	// Hash the length and address of the first element.
+	h.HashInt(len(val))
+	if len(val) > 0 {
+		h.HashPointer(unsafe.Pointer(&val[0]))
+	}
+}
+
 func (h *hasher) HashWindowFrame(val WindowFrame) {
 	h.HashInt(int(val.StartBoundType))
 	h.HashInt(int(val.EndBoundType))
@@ -619,6 +628,15 @@ func (h *hasher) HashTupleOrdinal(val TupleOrdinal) {
 	h.HashUint64(uint64(val))
 }
 
+func (h *hasher) HashArraySubscriptKinds(val ArraySubscriptKinds) {
+	hash := h.hash
+	for _, kind := range val {
+		hash ^= internHash(kind)
+		hash *= prime64
+	}
+	h.hash = hash
+}
+
 func (h *hasher) HashPhysProps(val *physical.Required) {
 	// Note: the Any presentation is not the same as the 0-column presentation.
 	if !val.Presentation.Any() {
@@ -777,6 +795,10 @@ func (h *hasher) HashUDFDefinition(val *UDFDefinition) {
 	h.HashUint64(uint64(reflect.ValueOf(val).Pointer()))
 }
 
+func (h *hasher) HashUDAggDefinition(val *UDAggDefinition) {
+	h.HashUint64(uint64(reflect.ValueOf(val).Pointer()))
+}
+
 // ----------------------------------------------------------------------
 //
 // Equality functions
@@ -1053,6 +1075,13 @@ func (h *hasher) IsSchemaTypeDepsEqual(l, r opt.SchemaTypeDeps) bool {
 	return l.Equals(r)
 }
 
+func (h *hasher) IsSchemaTextSearchDepsEqual(l, r opt.SchemaTextSearchDeps) bool {
+	if len(l) != len(r) {
+		return false
+	}
+	return len(l) == 0 || &l[0] == &r[0]
+}
+
 func (h *hasher) IsWindowFrameEqual(l, r WindowFrame) bool {
 	return l.StartBoundType == r.StartBoundType &&
 		l.EndBoundType == r.EndBoundType &&
@@ -1064,6 +1093,18 @@ func (h *hasher) IsTupleOrdinalEqual(l, r TupleOrdinal) bool {
 	return l == r
 }
 
+func (h *hasher) IsArraySubscriptKindsEqual(l, r ArraySubscriptKinds) bool {
+	if len(l) != len(r) {
+		return false
+	}
+	for i := range l {
+		if l[i] != r[i] {
+			return false
+		}
+	}
+	return true
+}
+
 func (h *hasher) IsPhysPropsEqual(l, r *physical.Required) bool {
 	return l.Equals(r)
 }
@@ -1308,7 +1349,13 @@ func (h *hasher) IsUDFDefinitionEqual(l, r *UDFDefinition) bool {
 	} else if r.CursorDeclaration != nil {
 		return false
 	}
-	return h.IsColListEqual(l.Params, r.Params) && l.IsRecursive == r.IsRecursive
+	return h.IsColListEqual(l.Params, r.Params) && l.IsRecursive == r.IsRecursive &&
+		l.TxnOp == r.TxnOp && l.ReturnNext == r.ReturnNext &&
+		l.BufferedResult == r.BufferedResult
+}
+
+func (h *hasher) IsUDAggDefinitionEqual(l, r *UDAggDefinition) bool {
+	return l == r
 }
 
 // encodeDatum turns the given datum into an encoded string of bytes. If two
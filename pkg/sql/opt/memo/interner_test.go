@@ -513,6 +513,25 @@ func TestInterner(t *testing.T) {
 			{val1: TupleOrdinal(0), val2: TupleOrdinal(1), equal: false},
 		}},
 
+		{hashFn: in.hasher.HashArraySubscriptKinds, eqFn: in.hasher.IsArraySubscriptKindsEqual, variations: []testVariation{
+			{val1: ArraySubscriptKinds{}, val2: ArraySubscriptKinds{}, equal: true},
+			{
+				val1:  ArraySubscriptKinds{ArraySubscriptIndex, ArraySubscriptSlice},
+				val2:  ArraySubscriptKinds{ArraySubscriptIndex, ArraySubscriptSlice},
+				equal: true,
+			},
+			{
+				val1:  ArraySubscriptKinds{ArraySubscriptIndex, ArraySubscriptSlice},
+				val2:  ArraySubscriptKinds{ArraySubscriptSlice, ArraySubscriptIndex},
+				equal: false,
+			},
+			{
+				val1:  ArraySubscriptKinds{ArraySubscriptSliceFrom},
+				val2:  ArraySubscriptKinds{ArraySubscriptSliceFrom, ArraySubscriptSliceTo},
+				equal: false,
+			},
+		}},
+
 		// PhysProps hash/isEqual methods are tested in TestInternerPhysProps.
 
 		{hashFn: in.hasher.HashLocking, eqFn: in.hasher.IsLockingEqual, variations: []testVariation{
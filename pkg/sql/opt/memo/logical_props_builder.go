@@ -1690,6 +1690,14 @@ func BuildSharedProps(e opt.Expr, shared *props.Shared, evalCtx *eval.Context) {
 		shared.HasUDF = true
 		shared.VolatilitySet.Add(t.Def.Volatility)
 
+	case *UserDefinedAggExpr:
+		shared.HasUDF = true
+		for _, def := range []*UDFDefinition{t.Def.StateFunc, t.Def.FinalFunc, t.Def.CombineFunc} {
+			if def != nil {
+				shared.VolatilitySet.Add(def.Volatility)
+			}
+		}
+
 	default:
 		if opt.IsUnaryOp(e) {
 			inputType := e.Child(0).(opt.ScalarExpr).DataType()
@@ -1888,6 +1896,12 @@ func MakeTableFuncDep(md *opt.Metadata, tabID opt.TableID) *props.FuncDepSet {
 			continue
 		}
 
+		if unique.ExclusionElementCount() > 0 {
+			// Exclusion constraints do not guarantee that their columns are
+			// unique, so they cannot be used as keys.
+			continue
+		}
+
 		// If any of the columns are nullable, add a lax key FD. Otherwise, add a
 		// strict key.
 		var keyCols opt.ColSet
@@ -188,10 +188,12 @@ func init() {
 	typingFuncMap[opt.SubqueryOp] = typeSubquery
 	typingFuncMap[opt.ColumnAccessOp] = typeColumnAccess
 	typingFuncMap[opt.IndirectionOp] = typeIndirection
+	typingFuncMap[opt.ArraySubscriptOp] = typeArraySubscript
 	typingFuncMap[opt.CollateOp] = typeCollate
 	typingFuncMap[opt.ArrayFlattenOp] = typeArrayFlatten
 	typingFuncMap[opt.IfErrOp] = typeIfErr
 	typingFuncMap[opt.UDFCallOp] = typeUDFCall
+	typingFuncMap[opt.UserDefinedAggOp] = typeUserDefinedAgg
 
 	// Override default typeAsAggregate behavior for aggregate functions with
 	// a large number of possible overloads or where ReturnType depends on
@@ -274,6 +276,17 @@ func typeIndirection(e opt.ScalarExpr) *types.T {
 	}
 }
 
+// typeArraySubscript returns the type of the element after the subscripts are
+// applied, or an array of that type if any of them is a slice.
+func typeArraySubscript(e opt.ScalarExpr) *types.T {
+	subscript := e.(*ArraySubscriptExpr)
+	elemType := subscript.Input.DataType().ArrayContents()
+	if subscript.Kinds.IsSlice() {
+		return types.MakeArray(elemType)
+	}
+	return elemType
+}
+
 // typeCollate returns the collated string typed with the given locale.
 func typeCollate(e opt.ScalarExpr) *types.T {
 	locale := e.(*CollateExpr).Locale
@@ -391,6 +404,11 @@ func typeUDFCall(e opt.ScalarExpr) *types.T {
 	return e.(*UDFCallExpr).Def.Typ
 }
 
+// typeUserDefinedAgg returns the type of a user-defined aggregate operator.
+func typeUserDefinedAgg(e opt.ScalarExpr) *types.T {
+	return e.(*UserDefinedAggExpr).Def.Typ
+}
+
 // typeSubquery returns the type of a subquery, which is equal to the type of
 // its first (and only) column.
 func typeSubquery(e opt.ScalarExpr) *types.T {
@@ -183,9 +183,24 @@ func (c *CustomFuncs) IsListOfConstants(elems memo.ScalarListExpr) bool {
 }
 
 // FoldArray evaluates an Array expression with constant inputs. It returns the
-// array as a Const datum with type TArray.
-func (c *CustomFuncs) FoldArray(elems memo.ScalarListExpr, typ *types.T) opt.ScalarExpr {
+// array as a Const datum with type TArray, or ok=false if the evaluation
+// results in an error.
+func (c *CustomFuncs) FoldArray(
+	elems memo.ScalarListExpr, typ *types.T,
+) (_ opt.ScalarExpr, ok bool) {
 	elemType := typ.ArrayContents()
+	if len(elems) > 0 && tree.IsSubArrayConstructor(elems[0].DataType()) {
+		// The elements are the sub-arrays of a multidimensional array.
+		subArrays := make(tree.Datums, len(elems))
+		for i := range elems {
+			subArrays[i] = memo.ExtractConstDatum(elems[i])
+		}
+		a, err := tree.NewMultiDimDArray(elemType, subArrays)
+		if err != nil {
+			return nil, false
+		}
+		return c.f.ConstructConst(a, typ), true
+	}
 	a := tree.NewDArray(elemType)
 	a.Array = make(tree.Datums, len(elems))
 	for i := range a.Array {
@@ -196,7 +211,7 @@ func (c *CustomFuncs) FoldArray(elems memo.ScalarListExpr, typ *types.T) opt.Sca
 			a.HasNonNulls = true
 		}
 	}
-	return c.f.ConstructConst(a, typ)
+	return c.f.ConstructConst(a, typ), true
 }
 
 // IsConstValueOrGroupOfConstValues returns true if the input is a constant,
@@ -547,8 +562,10 @@ func (c *CustomFuncs) FoldIndirection(input, index opt.ScalarExpr) (_ opt.Scalar
 	// Index is 1-based, so convert to 0-based.
 	indexD := memo.ExtractConstDatum(index)
 
-	// Case 1: The input is a static array constructor.
-	if arr, ok := input.(*memo.ArrayExpr); ok {
+	// Case 1: The input is a static array constructor. Indexing into a
+	// multidimensional array with a single subscript produces NULL, which is
+	// left to evaluation.
+	if arr, ok := input.(*memo.ArrayExpr); ok && !arr.HasSubArrays() {
 		if indexInt, ok := indexD.(*tree.DInt); ok {
 			indexI := int(*indexInt) - 1
 			if indexI >= 0 && indexI < len(arr.Elems) {
@@ -183,8 +183,10 @@ func (c *CustomFuncs) ConstructValuesFromZips(zip memo.ZipExpr) memo.RelExpr {
 //
 // Here, the length of the array is only known at run-time.
 func (c *CustomFuncs) IsStaticArray(scalar opt.ScalarExpr) bool {
-	if _, ok := scalar.(*memo.ArrayExpr); ok {
-		return true
+	if arr, ok := scalar.(*memo.ArrayExpr); ok {
+		// The elements of a multidimensional array are not the elements of its
+		// sub-array constructors.
+		return !arr.HasSubArrays()
 	}
 	return c.IsConstArray(scalar)
 }
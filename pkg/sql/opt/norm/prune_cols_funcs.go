@@ -79,6 +79,13 @@ func (c *CustomFuncs) neededMutationCols(
 		cols.Add(private.CanaryCol)
 	}
 
+	// Cascades that run AFTER triggers refer to input columns that may not be
+	// otherwise needed by the mutation.
+	for i := range private.FKCascades {
+		addCols(opt.OptionalColList(private.FKCascades[i].OldValues))
+		addCols(opt.OptionalColList(private.FKCascades[i].NewValues))
+	}
+
 	if private.WithID != 0 {
 		for i := range uniqueChecks {
 			withUses := memo.WithUses(uniqueChecks[i].Check)
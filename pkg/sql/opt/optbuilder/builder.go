@@ -145,6 +145,13 @@ type Builder struct {
 	// insideDataSource is true when we are processing a data source.
 	insideDataSource bool
 
+	// resolveRecordFields is true when a reference of the form x.y that does
+	// not match a data source may refer to the field y of the composite-typed
+	// column x. It is set while building PL/pgSQL routines, so that fields of
+	// variables can be referenced (e.g. NEW.a in a trigger function), and while
+	// building the WHEN condition of a trigger.
+	resolveRecordFields bool
+
 	// If set, we are collecting view dependencies in schemaDeps. This can only
 	// happen inside view/function definitions.
 	//
@@ -154,8 +161,9 @@ type Builder struct {
 	// inner view/function).
 	trackSchemaDeps bool
 
-	schemaDeps     opt.SchemaDeps
-	schemaTypeDeps opt.SchemaTypeDeps
+	schemaDeps           opt.SchemaDeps
+	schemaTypeDeps       opt.SchemaTypeDeps
+	schemaTextSearchDeps opt.SchemaTextSearchDeps
 
 	// If set, the data source names in the AST are rewritten to the fully
 	// qualified version (after resolution). Used to construct the strings for
@@ -171,6 +179,11 @@ type Builder struct {
 	// subqueryNameIdx helps generate unique subquery names during star
 	// expansion.
 	subqueryNameIdx int
+
+	// groupingSetItems maps each SELECT clause built as a branch of a grouping
+	// sets query to the GROUP BY items of all the grouping sets of the query.
+	// See buildGroupingSets.
+	groupingSetItems map[*tree.SelectClause]tree.Exprs
 }
 
 // New creates a new Builder structure initialized with the given
@@ -312,7 +325,7 @@ func (b *Builder) buildStmt(
 	if b.insideViewDef {
 		// A blocklist of statements that can't be used from inside a view.
 		switch stmt := stmt.(type) {
-		case *tree.Delete, *tree.Insert, *tree.Update, *tree.CreateTable, *tree.CreateView,
+		case *tree.Delete, *tree.Insert, *tree.Update, *tree.Merge, *tree.CreateTable, *tree.CreateView,
 			*tree.Split, *tree.Unsplit, *tree.Relocate, *tree.RelocateRange,
 			*tree.ControlJobs, *tree.ControlSchedules, *tree.CancelQueries, *tree.CancelSessions,
 			*tree.CreateRoutine:
@@ -358,6 +371,11 @@ func (b *Builder) buildStmt(
 			return b.buildUpdate(stmt, inScope)
 		})
 
+	case *tree.Merge:
+		return b.processWiths(stmt.With, inScope, func(inScope *scope) *scope {
+			return b.buildMerge(stmt, inScope)
+		})
+
 	case *tree.CreateTable:
 		return b.buildCreateTable(stmt, inScope)
 
@@ -65,6 +65,7 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 		b.trackSchemaDeps = false
 		b.schemaDeps = nil
 		b.schemaTypeDeps = intsets.Fast{}
+		b.schemaTextSearchDeps = nil
 		b.qualifyDataSourceNamesInAST = false
 		b.evalCtx.Annotations = oldEvalCtxAnn
 		b.semaCtx.Annotations = oldSemaCtxAnn
@@ -132,13 +133,16 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 	// the function body.
 	var deps opt.SchemaDeps
 	var typeDeps opt.SchemaTypeDeps
+	var textSearchDeps opt.SchemaTextSearchDeps
 
 	afterBuildStmt := func() {
 		deps = append(deps, b.schemaDeps...)
 		typeDeps.UnionWith(b.schemaTypeDeps)
+		textSearchDeps = append(textSearchDeps, b.schemaTextSearchDeps...)
 		// Reset the tracked dependencies for next statement.
 		b.schemaDeps = nil
 		b.schemaTypeDeps = intsets.Fast{}
+		b.schemaTextSearchDeps = nil
 
 		// Reset the annotations to the original values
 		b.evalCtx.Annotations = oldEvalCtxAnn
@@ -150,12 +154,56 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 	// be resolved.
 	bodyScope := b.allocScope()
 	var paramTypes tree.ParamTypes
+	var paramClasses []tree.RoutineParamClass
+	var outParamTypes []*types.T
+	var outParamLabels []string
+	var hasOutParams, hasVariadicParam bool
+	argOrd := 0
 	for i := range cf.Params {
 		param := &cf.Params[i]
 		typ, err := tree.ResolveType(b.ctx, param.Type, b.semaCtx.TypeResolver)
 		if err != nil {
 			panic(err)
 		}
+		if typ.Family() == types.TriggerFamily {
+			panic(pgerror.Newf(pgcode.InvalidFunctionDefinition,
+				"PL/pgSQL functions cannot accept type trigger"))
+		}
+		switch param.Class {
+		case tree.RoutineParamOut, tree.RoutineParamInOut:
+			hasOutParams = true
+		case tree.RoutineParamTable:
+			if hasOutParams {
+				panic(pgerror.New(pgcode.Syntax, "OUT and INOUT arguments aren't allowed in TABLE functions"))
+			}
+		}
+		if param.Class.IsOutput() {
+			// The OUT and INOUT parameters of a routine make up the columns of the
+			// row that it returns. As in Postgres, unnamed parameters are named
+			// after their position among the OUT parameters.
+			label := string(param.Name)
+			if label == "" {
+				label = fmt.Sprintf("column%d", len(outParamTypes)+1)
+			}
+			outParamTypes = append(outParamTypes, typ)
+			outParamLabels = append(outParamLabels, label)
+		}
+		if param.Class.IsInput(cf.IsProcedure) {
+			if hasVariadicParam {
+				panic(pgerror.New(pgcode.InvalidFunctionDefinition,
+					"VARIADIC parameter must be the last input parameter",
+				))
+			}
+			if param.Class == tree.RoutineParamVariadic {
+				hasVariadicParam = true
+				if typ.Family() != types.ArrayFamily {
+					panic(pgerror.New(pgcode.InvalidFunctionDefinition,
+						"VARIADIC parameter must be an array",
+					))
+				}
+			}
+		}
+		paramClasses = append(paramClasses, param.Class)
 		// The parameter type must be supported by the current cluster version.
 		checkUnsupportedType(b.ctx, b.semaCtx, typ)
 		if types.IsRecordType(typ) {
@@ -170,10 +218,14 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 			}
 		}
 
-		// Add the parameter to the base scope of the body.
-		paramColName := funcParamColName(param.Name, i)
-		col := b.synthesizeColumn(bodyScope, paramColName, typ, nil /* expr */, nil /* scalar */)
-		col.setParamOrd(i)
+		// Add the parameter to the base scope of the body. The OUT parameters of
+		// a function are not passed as arguments, so they are not added.
+		if param.Class.IsInput(cf.IsProcedure) {
+			paramColName := funcParamColName(param.Name, argOrd)
+			col := b.synthesizeColumn(bodyScope, paramColName, typ, nil /* expr */, nil /* scalar */)
+			col.setParamOrd(argOrd)
+			argOrd++
+		}
 
 		// Collect the user defined type dependencies.
 		typedesc.GetTypeDescriptorClosure(typ).ForEach(func(id descpb.ID) {
@@ -189,6 +241,16 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 		}
 	}
 
+	if cf.IsProcedure {
+		// A procedure with OUT or INOUT parameters returns a row with their
+		// values.
+		if len(outParamTypes) > 0 {
+			cf.ReturnType.Type = types.MakeLabeledTuple(outParamTypes, outParamLabels)
+		}
+	} else {
+		cf.ReturnType.Type = b.resolveFunctionReturnType(cf, outParamTypes, outParamLabels)
+	}
+
 	// Collect the user defined type dependency of the return type.
 	funcReturnType, err := tree.ResolveType(b.ctx, cf.ReturnType.Type, b.semaCtx.TypeResolver)
 	if err != nil {
@@ -198,6 +260,26 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 		typeDeps.Add(int(id))
 	})
 
+	// A trigger function can only be invoked by a trigger, which supplies the
+	// NEW and OLD rows and the other special variables of the function.
+	isTriggerFunc := funcReturnType.Family() == types.TriggerFamily
+	if isTriggerFunc {
+		switch {
+		case cf.IsProcedure:
+			panic(pgerror.New(pgcode.InvalidFunctionDefinition,
+				"procedures cannot return type trigger"))
+		case language != tree.RoutineLangPLpgSQL:
+			panic(pgerror.Newf(pgcode.InvalidFunctionDefinition,
+				"%s functions cannot return type trigger", language))
+		case cf.ReturnType.SetOf:
+			panic(pgerror.New(pgcode.InvalidFunctionDefinition,
+				"trigger functions cannot return a set"))
+		case len(cf.Params) > 0:
+			panic(pgerror.New(pgcode.InvalidFunctionDefinition,
+				"trigger functions cannot have declared arguments"))
+		}
+	}
+
 	targetVolatility := tree.GetRoutineVolatility(cf.Options)
 	fmtCtx := tree.NewFmtCtx(tree.FmtSerializable)
 
@@ -234,28 +316,28 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 			afterBuildStmt()
 		}
 	case tree.RoutineLangPLpgSQL:
-		if cf.ReturnType.SetOf {
-			panic(unimplemented.NewWithIssueDetail(105240,
-				"set-returning PL/pgSQL functions",
-				"set-returning PL/pgSQL functions are not yet supported",
-			))
-		}
-
 		// Parse the function body.
 		stmt, err := plpgsql.Parse(funcBodyStr)
 		if err != nil {
 			panic(err)
 		}
 
-		// We need to disable stable function folding because we want to catch the
-		// volatility of stable functions. If folded, we only get a scalar and lose
-		// the volatility.
-		b.factory.FoldingControl().TemporarilyDisallowStableFolds(func() {
-			var plBuilder plpgsqlBuilder
-			plBuilder.init(b, nil /* colRefs */, paramTypes, stmt.AST, funcReturnType)
-			stmtScope = plBuilder.build(stmt.AST, bodyScope)
-		})
-		checkStmtVolatility(targetVolatility, stmtScope, stmt)
+		// The body of a trigger function depends on the table of the trigger, so
+		// it is only built when the trigger is invoked.
+		if !isTriggerFunc {
+			// We need to disable stable function folding because we want to catch
+			// the volatility of stable functions. If folded, we only get a scalar
+			// and lose the volatility.
+			b.factory.FoldingControl().TemporarilyDisallowStableFolds(func() {
+				var plBuilder plpgsqlBuilder
+				plBuilder.init(
+					b, nil /* colRefs */, paramTypes, paramClasses, stmt.AST, funcReturnType,
+					cf.ReturnType.SetOf, cf.IsProcedure,
+				)
+				stmtScope = plBuilder.build(stmt.AST, bodyScope)
+			})
+			checkStmtVolatility(targetVolatility, stmtScope, stmt)
+		}
 
 		// Format the statements with qualified datasource names.
 		formatFuncBodyStmt(fmtCtx, stmt.AST, false /* newLine */)
@@ -270,7 +352,14 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 		// TODO(mgartner): stmtScope.cols does not describe the result
 		// columns of the statement. We should use physical.Presentation
 		// instead.
-		err = validateReturnType(b.ctx, b.semaCtx, funcReturnType, stmtScope.cols)
+		expectedType := funcReturnType
+		if cf.IsProcedure && len(outParamTypes) == 1 && len(stmtScope.cols) == 1 &&
+			stmtScope.cols[0].typ.Family() != types.TupleFamily {
+			// The last statement of a procedure with a single OUT parameter can
+			// return the value of the parameter rather than a row.
+			expectedType = outParamTypes[0]
+		}
+		err = validateReturnType(b.ctx, b.semaCtx, expectedType, stmtScope.cols)
 		if err != nil {
 			panic(err)
 		}
@@ -296,15 +385,53 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 	outScope = b.allocScope()
 	outScope.expr = b.factory.ConstructCreateFunction(
 		&memo.CreateFunctionPrivate{
-			Schema:   schID,
-			Syntax:   cf,
-			Deps:     deps,
-			TypeDeps: typeDeps,
+			Schema:         schID,
+			Syntax:         cf,
+			Deps:           deps,
+			TypeDeps:       typeDeps,
+			TextSearchDeps: textSearchDeps,
 		},
 	)
 	return outScope
 }
 
+// resolveFunctionReturnType returns the return type of a function. A function
+// with OUT or INOUT parameters, including the columns of RETURNS TABLE, returns
+// their values: either the value of its single OUT parameter, or a record with
+// the values of all of them. The declared return type of such a function, if
+// any, must match.
+func (b *Builder) resolveFunctionReturnType(
+	cf *tree.CreateRoutine, outParamTypes []*types.T, outParamLabels []string,
+) tree.ResolvableTypeReference {
+	if len(outParamTypes) == 0 {
+		if cf.ReturnType.Type == nil {
+			panic(pgerror.New(pgcode.InvalidFunctionDefinition, "function result type must be specified"))
+		}
+		return cf.ReturnType.Type
+	}
+	outType := types.MakeLabeledTuple(outParamTypes, outParamLabels)
+	if len(outParamTypes) == 1 {
+		outType = outParamTypes[0]
+	}
+	if cf.ReturnType.Type != nil {
+		typ, err := tree.ResolveType(b.ctx, cf.ReturnType.Type, b.semaCtx.TypeResolver)
+		if err != nil {
+			panic(err)
+		}
+		if len(outParamTypes) == 1 && !typ.Identical(outType) {
+			panic(pgerror.Newf(pgcode.InvalidFunctionDefinition,
+				"function result type must be %s because of OUT parameters", outType.SQLStringForError(),
+			))
+		}
+		if len(outParamTypes) > 1 && !types.IsRecordType(typ) {
+			panic(pgerror.New(pgcode.InvalidFunctionDefinition,
+				"function result type must be record because of OUT parameters",
+			))
+		}
+	}
+	return outType
+}
+
 func formatFuncBodyStmt(fmtCtx *tree.FmtCtx, ast tree.NodeFormatter, newLine bool) {
 	if newLine {
 		fmtCtx.WriteString("\n")
@@ -337,8 +464,10 @@ func validateReturnType(
 		)
 	}
 
-	// If return type is RECORD, any column types are valid.
-	if types.IsRecordType(expected) {
+	// If return type is RECORD, any column types are valid. This does not apply
+	// to the record returned by a routine with OUT parameters, which has the
+	// types of the parameters.
+	if types.IsWildcardTupleType(expected) {
 		return nil
 	}
 
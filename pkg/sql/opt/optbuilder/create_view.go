@@ -48,6 +48,7 @@ func (b *Builder) buildCreateView(cv *tree.CreateView, inScope *scope) (outScope
 		b.trackSchemaDeps = false
 		b.schemaDeps = nil
 		b.schemaTypeDeps = intsets.Fast{}
+		b.schemaTextSearchDeps = nil
 		b.qualifyDataSourceNamesInAST = false
 		delete(b.sourceViews, viewFQString)
 
@@ -109,12 +110,13 @@ func (b *Builder) buildCreateView(cv *tree.CreateView, inScope *scope) (outScope
 	outScope = b.allocScope()
 	outScope.expr = b.factory.ConstructCreateView(
 		&memo.CreateViewPrivate{
-			Syntax:    cv,
-			Schema:    schID,
-			ViewQuery: tree.AsStringWithFlags(cv.AsSource, tree.FmtParsable),
-			Columns:   p,
-			Deps:      b.schemaDeps,
-			TypeDeps:  b.schemaTypeDeps,
+			Syntax:         cv,
+			Schema:         schID,
+			ViewQuery:      tree.AsStringWithFlags(cv.AsSource, tree.FmtParsable),
+			Columns:        p,
+			Deps:           b.schemaDeps,
+			TypeDeps:       b.schemaTypeDeps,
+			TextSearchDeps: b.schemaTextSearchDeps,
 		},
 	)
 	return outScope
@@ -0,0 +1,150 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinsregistry"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// checkDomainValueFnName is the builtin used to raise an error when a value
+// violates a constraint of a domain type.
+const checkDomainValueFnName = "crdb_internal.check_domain_value"
+
+// domainValue represents the VALUE keyword in the CHECK constraint expression
+// of a domain type. It refers to an already built scalar expression of the
+// domain's base type.
+type domainValue struct {
+	typ    *types.T
+	scalar opt.ScalarExpr
+}
+
+var _ tree.TypedExpr = &domainValue{}
+
+func (v *domainValue) String() string {
+	return tree.AsString(v)
+}
+
+// Format implements the NodeFormatter interface.
+func (v *domainValue) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString("VALUE")
+}
+
+// Walk is part of the tree.Expr interface.
+func (v *domainValue) Walk(_ tree.Visitor) tree.Expr {
+	return v
+}
+
+// TypeCheck is part of the tree.Expr interface.
+func (v *domainValue) TypeCheck(
+	_ context.Context, _ *tree.SemaContext, _ *types.T,
+) (tree.TypedExpr, error) {
+	return v, nil
+}
+
+// ResolvedType is part of the tree.TypedExpr interface.
+func (v *domainValue) ResolvedType() *types.T {
+	return v.typ
+}
+
+// Eval is part of the tree.TypedExpr interface.
+func (v *domainValue) Eval(_ context.Context, _ tree.ExprEvaluator) (tree.Datum, error) {
+	panic(errors.AssertionFailedf("domainValue must be replaced before evaluation"))
+}
+
+// buildDomainChecks wraps value, which is an expression of the domain type typ,
+// with checks of the domain's NOT NULL and CHECK constraints. The returned
+// expression evaluates to value if it satisfies all constraints and raises an
+// error otherwise. Values of types other than domains are returned unchanged.
+func (b *Builder) buildDomainChecks(
+	value opt.ScalarExpr, typ *types.T, inScope *scope,
+) opt.ScalarExpr {
+	if !typ.IsDomain() || typ.TypeMeta.DomainData == nil {
+		return value
+	}
+	// The constraints and default of the domain are inlined into the query, so
+	// it must be invalidated when the domain is altered.
+	b.factory.Metadata().AddUserDefinedType(typ, nil /* name */)
+	dom := typ.TypeMeta.DomainData
+	if dom.NotNull {
+		ok := b.factory.ConstructIsNot(value, memo.NullSingleton)
+		value = b.constructDomainCheck(value, typ, ok, pgcode.NotNullViolation,
+			fmt.Sprintf("domain %s does not allow null values", typ.Name()),
+		)
+	}
+	if len(dom.Checks) == 0 {
+		return value
+	}
+	// The CHECK expressions refer to the value being checked as VALUE, which
+	// has the base type of the domain.
+	baseType := typ.DomainBaseType()
+	baseValue := &domainValue{typ: baseType, scalar: b.factory.ConstructCast(value, baseType)}
+	for i := range dom.Checks {
+		check := &dom.Checks[i]
+		expr, err := parser.ParseExpr(check.Expr)
+		if err != nil {
+			panic(err)
+		}
+		expr, err = tree.SimpleVisit(expr, func(e tree.Expr) (recurse bool, newExpr tree.Expr, err error) {
+			if n, ok := e.(*tree.UnresolvedName); ok && n.NumParts == 1 && n.Parts[0] == "value" {
+				return false, baseValue, nil
+			}
+			return true, e, nil
+		})
+		if err != nil {
+			panic(err)
+		}
+		texpr, err := tree.TypeCheckAndRequire(b.ctx, expr, b.semaCtx, types.Bool, "CHECK")
+		if err != nil {
+			panic(err)
+		}
+		ok := b.buildScalar(texpr, inScope, nil, nil, nil)
+		value = b.constructDomainCheck(value, typ, ok, pgcode.CheckViolation,
+			fmt.Sprintf("value for domain %s violates check constraint %q", typ.Name(), check.Name),
+		)
+	}
+	return value
+}
+
+// constructDomainCheck constructs a call to the builtin that returns value if
+// ok is not false, and raises an error with the given code and message
+// otherwise.
+func (b *Builder) constructDomainCheck(
+	value opt.ScalarExpr, typ *types.T, ok opt.ScalarExpr, code pgcode.Code, msg string,
+) opt.ScalarExpr {
+	props, overloads := builtinsregistry.GetBuiltinProperties(checkDomainValueFnName)
+	if len(overloads) != 1 {
+		panic(errors.AssertionFailedf("expected one overload for %s", checkDomainValueFnName))
+	}
+	return b.factory.ConstructFunction(
+		memo.ScalarListExpr{
+			value,
+			ok,
+			b.factory.ConstructConstVal(tree.NewDString(code.String()), types.String),
+			b.factory.ConstructConstVal(tree.NewDString(msg), types.String),
+		},
+		&memo.FunctionPrivate{
+			Name:       checkDomainValueFnName,
+			Typ:        typ,
+			Properties: props,
+			Overload:   &overloads[0],
+		},
+	)
+}
@@ -43,9 +43,12 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
 )
 
 // groupby information stored in scopes.
@@ -96,6 +99,13 @@ type groupby struct {
 	// projects that expression.
 	groupStrs groupByStrSet
 
+	// nullGroupStrs contains a string representation of each grouping
+	// expression that appears in a ROLLUP, CUBE or GROUPING SETS clause but is
+	// not part of the grouping set currently being built, mapped to the type of
+	// the expression. References to these expressions in the SELECT list and
+	// HAVING clause are built as NULL constants. See buildGroupingSets.
+	nullGroupStrs map[string]*types.T
+
 	// buildingGroupingCols is true while the grouping columns are being built.
 	// It is used to ensure that the builder does not throw a grouping error
 	// prematurely.
@@ -232,6 +242,12 @@ func (a *aggregateInfo) TypeCheck(
 	return a, nil
 }
 
+// isUserDefined returns true if the aggregate was created with CREATE
+// AGGREGATE.
+func (a aggregateInfo) isUserDefined() bool {
+	return a.def.Overload != nil && a.def.Overload.Aggregate != nil
+}
+
 // isOrderedSetAggregate returns true if the given aggregate operator is an
 // ordered-set aggregate.
 func (a aggregateInfo) isOrderedSetAggregate() bool {
@@ -247,6 +263,10 @@ func (a aggregateInfo) isOrderedSetAggregate() bool {
 // ordering sensitive. That is, it can give different results based on the order
 // values are fed to it.
 func (a aggregateInfo) isOrderingSensitive() bool {
+	if a.isUserDefined() {
+		// ORDER BY is not supported in calls to user-defined aggregates.
+		return false
+	}
 	if a.isOrderedSetAggregate() {
 		return true
 	}
@@ -334,6 +354,21 @@ func (b *Builder) buildGroupingColumns(sel *tree.SelectClause, projectionsScope,
 	// The "from" columns are visible to any grouping expressions.
 	b.buildGroupingList(sel.GroupBy, sel.Exprs, projectionsScope, fromScope)
 
+	// If this SELECT is one of the branches of a grouping sets query, the
+	// grouping expressions of the other grouping sets produce NULLs.
+	if allItems, ok := b.groupingSetItems[sel]; ok {
+		g.nullGroupStrs = make(map[string]*types.T)
+		for _, e := range allItems {
+			exprs, _ := b.resolveGrouping(e, sel.Exprs, projectionsScope, fromScope)
+			for _, texpr := range exprs {
+				exprStr := symbolicExprStr(texpr)
+				if _, ok := g.groupStrs[exprStr]; !ok {
+					g.nullGroupStrs[exprStr] = texpr.ResolvedType()
+				}
+			}
+		}
+	}
+
 	// Copy the grouping columns to the aggOutScope.
 	g.aggOutScope.appendColumns(g.groupingCols())
 }
@@ -354,6 +389,12 @@ func (b *Builder) buildAggregation(having opt.ScalarExpr, fromScope *scope) (out
 	// If there are any aggregates that are ordering sensitive, build the
 	// aggregations as window functions over each group.
 	if g.hasNonCommutativeAggregates() {
+		for i := range g.aggs {
+			if g.aggs[i].isUserDefined() {
+				panic(unimplemented.New("user-defined aggregates",
+					"user-defined aggregates cannot be combined with ordered aggregates"))
+			}
+		}
 		return b.buildAggregationAsWindow(groupingColSet, having, fromScope)
 	}
 
@@ -385,7 +426,11 @@ func (b *Builder) buildAggregation(having opt.ScalarExpr, fromScope *scope) (out
 
 		// Construct the aggregate function from its name and arguments and store
 		// it in the corresponding scope column.
-		aggCols[i].scalar = b.constructAggregate(agg.def.Name, args)
+		if agg.isUserDefined() {
+			aggCols[i].scalar = b.constructUserDefinedAgg(&aggInfos[i], args, fromScope)
+		} else {
+			aggCols[i].scalar = b.constructAggregate(agg.def.Name, args)
+		}
 
 		// Wrap the aggregate function with an AggDistinct operator if DISTINCT
 		// was specified in the query.
@@ -539,9 +584,35 @@ func (b *Builder) buildGroupingList(
 func (b *Builder) buildGrouping(
 	groupBy tree.Expr, selects tree.SelectExprs, projectionsScope, fromScope, aggInScope *scope,
 ) {
+	exprs, alias := b.resolveGrouping(groupBy, selects, projectionsScope, fromScope)
+
+	// Finally, build each of the GROUP BY columns.
+	for _, e := range exprs {
+		// If a grouping column has already been added, don't add it again.
+		// GROUP BY a, a is semantically equivalent to GROUP BY a.
+		exprStr := symbolicExprStr(e)
+		if _, ok := fromScope.groupby.groupStrs[exprStr]; ok {
+			continue
+		}
+
+		// Save a representation of the GROUP BY expression for validation of the
+		// SELECT and HAVING expressions. This enables queries such as:
+		//   SELECT x+y FROM t GROUP BY x+y
+		col := aggInScope.addColumn(scopeColName(tree.Name(alias)), e)
+		b.buildScalar(e, fromScope, aggInScope, col, nil)
+		fromScope.groupby.groupStrs[exprStr] = col
+	}
+}
+
+// resolveGrouping resolves a GROUP BY expression, which may refer to a target
+// in the SELECT list by index or alias, to the list of typed expressions it
+// groups on. Stars are expanded and tuples are flattened. If the expression
+// refers to an aliased SELECT target, the alias is also returned.
+func (b *Builder) resolveGrouping(
+	groupBy tree.Expr, selects tree.SelectExprs, projectionsScope, fromScope *scope,
+) (exprs []tree.TypedExpr, alias string) {
 	// Unwrap parenthesized expressions like "((a))" to "a".
 	groupBy = tree.StripParens(groupBy)
-	alias := ""
 
 	// Comment below pasted from PostgreSQL (findTargetListEntrySQL92 in
 	// src/backend/parser/parse_clause.c).
@@ -628,25 +699,8 @@ func (b *Builder) buildGrouping(
 	fromScope.context = exprKindGroupBy
 
 	// Resolve types, expand stars, and flatten tuples.
-	exprs := b.expandStarAndResolveType(groupBy, fromScope)
-	exprs = flattenTuples(exprs)
-
-	// Finally, build each of the GROUP BY columns.
-	for _, e := range exprs {
-		// If a grouping column has already been added, don't add it again.
-		// GROUP BY a, a is semantically equivalent to GROUP BY a.
-		exprStr := symbolicExprStr(e)
-		if _, ok := fromScope.groupby.groupStrs[exprStr]; ok {
-			continue
-		}
-
-		// Save a representation of the GROUP BY expression for validation of the
-		// SELECT and HAVING expressions. This enables queries such as:
-		//   SELECT x+y FROM t GROUP BY x+y
-		col := aggInScope.addColumn(scopeColName(tree.Name(alias)), e)
-		b.buildScalar(e, fromScope, aggInScope, col, nil)
-		fromScope.groupby.groupStrs[exprStr] = col
-	}
+	exprs = b.expandStarAndResolveType(groupBy, fromScope)
+	return flattenTuples(exprs), alias
 }
 
 // buildAggArg builds a scalar expression which is used as an input in some form
@@ -903,6 +957,84 @@ func (b *Builder) constructAggregate(name string, args []opt.ScalarExpr) opt.Sca
 	panic(errors.AssertionFailedf("unhandled aggregate: %s", name))
 }
 
+// constructUserDefinedAgg constructs a UserDefinedAgg expression for a call to
+// an aggregate created with CREATE AGGREGATE. The support functions of the
+// aggregate are built as routines, which are invoked by the aggregator for
+// each input row and for each group.
+func (b *Builder) constructUserDefinedAgg(
+	agg *aggregateInfo, args []opt.ScalarExpr, inScope *scope,
+) opt.ScalarExpr {
+	o := agg.def.Overload
+	b.factory.Metadata().AddUserDefinedFunction(o, agg.Func.ReferenceByName)
+	if err := b.catalog.CheckExecutionPrivilege(b.ctx, o.Oid); err != nil {
+		panic(err)
+	}
+
+	uda := o.Aggregate
+	stateType := uda.StateType
+	def := &memo.UDAggDefinition{
+		Name:      agg.def.Name,
+		Typ:       agg.FuncExpr.ResolvedType(),
+		StateType: stateType,
+		InitCond:  tree.DNull,
+	}
+	if uda.InitCond != nil {
+		initCond, err := eval.PerformCast(b.ctx, b.evalCtx, tree.NewDString(*uda.InitCond), stateType)
+		if err != nil {
+			panic(err)
+		}
+		def.InitCond = initCond
+	}
+
+	// The state transition function takes the state followed by the arguments
+	// of the aggregate.
+	stateArgTypes := make([]*types.T, 0, len(args)+1)
+	stateArgTypes = append(stateArgTypes, stateType)
+	for i := range args {
+		stateArgTypes = append(stateArgTypes, args[i].DataType())
+	}
+	def.StateFunc = b.buildAggregateSupportFunc(uda.StateFunc, stateArgTypes, inScope)
+	if uda.FinalFunc != 0 {
+		def.FinalFunc = b.buildAggregateSupportFunc(
+			uda.FinalFunc, []*types.T{stateType}, inScope,
+		)
+	}
+	if uda.CombineFunc != 0 {
+		def.CombineFunc = b.buildAggregateSupportFunc(
+			uda.CombineFunc, []*types.T{stateType, stateType}, inScope,
+		)
+	}
+	return b.factory.ConstructUserDefinedAgg(args, &memo.UserDefinedAggPrivate{Def: def})
+}
+
+// buildAggregateSupportFunc builds the definition of a support function of a
+// user-defined aggregate, which is referenced by its OID. The function is
+// built as if it were called with NULL arguments of the given types; only the
+// definition of the routine is used.
+func (b *Builder) buildAggregateSupportFunc(
+	funcOID oid.Oid, argTypes []*types.T, inScope *scope,
+) *memo.UDFDefinition {
+	exprs := make(tree.Exprs, len(argTypes))
+	for i := range argTypes {
+		exprs[i] = tree.NewTypedCastExpr(tree.DNull, argTypes[i])
+	}
+	f := &tree.FuncExpr{
+		Func:  tree.ResolvableFunctionReference{FunctionReference: &tree.FunctionOID{OID: funcOID}},
+		Exprs: exprs,
+	}
+	typedFunc, err := tree.TypeCheck(b.ctx, f, b.semaCtx, types.Any)
+	if err != nil {
+		panic(err)
+	}
+	f = typedFunc.(*tree.FuncExpr)
+	def, err := f.Func.Resolve(b.ctx, b.semaCtx.SearchPath, b.semaCtx.FunctionResolver)
+	if err != nil {
+		panic(err)
+	}
+	routine, _, _ := b.buildRoutine(f, def, inScope, nil /* colRefs */)
+	return routine.(*memo.UDFCallExpr).Def
+}
+
 func isAggregate(def *tree.ResolvedFunctionDefinition) bool {
 	return isClass(def, tree.AggregateClass)
 }
@@ -966,6 +1098,10 @@ func (b *Builder) allowImplicitGroupingColumn(colID opt.ColumnID, g *groupby) bo
 	// Check UNIQUE WITHOUT INDEX constraints.
 	for i := 0; i < tab.UniqueCount(); i++ {
 		uniqueConstraint := tab.Unique(i)
+		if uniqueConstraint.ExclusionElementCount() > 0 {
+			// Exclusion constraints do not guarantee uniqueness.
+			continue
+		}
 		var uniqueCols opt.ColSet
 		nullable := false
 		for j := 0; j < uniqueConstraint.ColumnCount(); j++ {
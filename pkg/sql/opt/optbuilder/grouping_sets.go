@@ -0,0 +1,366 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+)
+
+// maxGroupingSets is the maximum number of grouping sets a GROUP BY clause
+// can expand to. It matches the limit used by Postgres.
+const maxGroupingSets = 4096
+
+// maxGroupingArgs is the maximum number of arguments to the GROUPING function,
+// so that the result fits in the bits of a 32-bit integer like in Postgres.
+const maxGroupingArgs = 31
+
+// hasGroupingSets returns true if the given GROUP BY clause contains a ROLLUP,
+// CUBE or GROUPING SETS item.
+func hasGroupingSets(groupBy tree.GroupBy) bool {
+	for _, e := range groupBy {
+		if _, ok := e.(*tree.GroupingSet); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// buildGroupingSets builds a SELECT clause whose GROUP BY contains ROLLUP, CUBE
+// or GROUPING SETS items. The GROUP BY clause is expanded into a list of
+// grouping sets, and the query is built as the UNION ALL of one aggregation
+// per grouping set. For example:
+//
+//	SELECT a, b, sum(c) FROM t GROUP BY ROLLUP (a, b)
+//	=>
+//	SELECT a, b, sum(c) FROM t GROUP BY a, b
+//	UNION ALL
+//	SELECT a, NULL, sum(c) FROM t GROUP BY a
+//	UNION ALL
+//	SELECT NULL, NULL, sum(c) FROM t GROUP BY ()
+//
+// Within each branch, the grouping expressions that are not part of the
+// branch's grouping set are replaced with NULL (see groupby.nullGroupStrs),
+// and GROUPING() calls are replaced with constants.
+//
+// If the SELECT has a DISTINCT clause, the branches are combined with UNION
+// instead.
+//
+// The ORDER BY clause is applied to the result of the union. Its expressions
+// that do not refer to an output column by alias or ordinal, such as
+// aggregates or GROUPING() calls, are computed by every branch as extra
+// columns, which are then projected away.
+//
+// See Builder.buildStmt for a description of the remaining input and
+// return values.
+func (b *Builder) buildGroupingSets(
+	sel *tree.SelectClause,
+	orderBy tree.OrderBy,
+	lockCtx lockingContext,
+	desiredTypes []*types.T,
+	inScope *scope,
+) (outScope *scope) {
+	if sel.DistinctOn != nil {
+		panic(unimplemented.NewWithIssue(46280, "DISTINCT ON with grouping sets"))
+	}
+	if len(sel.Window) > 0 || containsWindowFunction(sel) {
+		panic(unimplemented.NewWithIssue(46280, "window functions with grouping sets"))
+	}
+
+	sets := expandGroupingSets(sel.GroupBy)
+
+	// Collect every item appearing in any of the grouping sets; each branch
+	// produces NULL for the items that are not in its grouping set.
+	var allItems tree.Exprs
+	for _, set := range sets {
+		allItems = append(allItems, set...)
+	}
+
+	// extraOrds maps each ORDER BY item that must be computed by the branches to
+	// the position of its expression in extraExprs, or -1 if the item refers to
+	// an output column.
+	var extraExprs tree.SelectExprs
+	extraOrds := make([]int, len(orderBy))
+	for i, order := range orderBy {
+		extraOrds[i] = -1
+		if order.OrderType == tree.OrderByIndex {
+			panic(unimplemented.NewWithIssue(46280, "ORDER BY INDEX with grouping sets"))
+		}
+		if refersToSelectOutput(sel, order.Expr) {
+			continue
+		}
+		if sel.Distinct {
+			panic(pgerror.New(pgcode.InvalidColumnReference,
+				"for SELECT DISTINCT, ORDER BY expressions must appear in select list"))
+		}
+		extraOrds[i] = len(extraExprs)
+		extraExprs = append(extraExprs, tree.SelectExpr{Expr: order.Expr})
+	}
+
+	if b.groupingSetItems == nil {
+		b.groupingSetItems = make(map[*tree.SelectClause]tree.Exprs)
+	}
+	for i, set := range sets {
+		branch := *sel
+		branch.Exprs = append(sel.Exprs[:len(sel.Exprs):len(sel.Exprs)], extraExprs...)
+		branch.Distinct = sel.Distinct && len(sets) == 1
+		branch.GroupBy = tree.GroupBy(set)
+		if len(set) == 0 {
+			// The empty grouping set aggregates all rows into a single group.
+			branch.GroupBy = tree.GroupBy{&tree.Tuple{}}
+		}
+		b.groupingSetItems[&branch] = allItems
+		branchScope := b.buildSelectClause(&branch, nil /* orderBy */, lockCtx, desiredTypes, inScope)
+		delete(b.groupingSetItems, &branch)
+
+		if i == 0 {
+			// Star expansion of the SELECT list must be reflected in the original
+			// statement when building a view or function definition.
+			sel.Exprs = branch.Exprs[:len(branch.Exprs)-len(extraExprs)]
+			outScope = branchScope
+			// Propagate types from the first branch to the remaining ones, if we
+			// didn't already have desired types.
+			if len(desiredTypes) == 0 {
+				desiredTypes = outScope.makeColumnTypes()
+			}
+			continue
+		}
+		outScope = b.buildSetOp(tree.UnionOp, !sel.Distinct, inScope, outScope, branchScope)
+	}
+
+	if orderBy != nil {
+		outScope = b.buildGroupingSetsOrderBy(orderBy, extraOrds, len(extraExprs), outScope)
+	}
+	return outScope
+}
+
+// buildGroupingSetsOrderBy sorts the result of the union built by
+// buildGroupingSets, and projects away the numExtra columns that were added
+// at the end of the SELECT list to compute the ORDER BY expressions. See
+// buildGroupingSets for the meaning of extraOrds.
+func (b *Builder) buildGroupingSetsOrderBy(
+	orderBy tree.OrderBy, extraOrds []int, numExtra int, inScope *scope,
+) (outScope *scope) {
+	numCols := len(inScope.cols) - numExtra
+	for i := numCols; i < len(inScope.cols); i++ {
+		// The extra columns must not be referenced by name.
+		inScope.cols[i].visibility = inaccessible
+	}
+	outScope = inScope.replace()
+	outScope.cols = make([]scopeColumn, 0, numCols)
+	for i := 0; i < numCols; i++ {
+		expr := &inScope.cols[i]
+		col := outScope.addColumn(scopeColName(""), expr)
+		b.buildScalar(expr, inScope, outScope, col, nil)
+	}
+
+	orderByScope := inScope.push()
+	defer b.semaCtx.Properties.Restore(b.semaCtx.Properties)
+	b.semaCtx.Properties.Require(exprKindOrderBy.String(),
+		tree.RejectGenerators|tree.RejectAggregates|tree.RejectWindowApplications)
+	inScope.context = exprKindOrderBy
+	for i, order := range orderBy {
+		if extraOrds[i] == -1 {
+			b.analyzeOrderByArg(order, inScope, outScope, orderByScope)
+			continue
+		}
+		col := &inScope.cols[numCols+extraOrds[i]]
+		start := len(orderByScope.cols)
+		if !b.hasDefaultNullsOrder(order) {
+			orderByScope.addColumn(
+				scopeColName("").WithMetadataName(fmt.Sprintf("nulls_ordering_%s", col.String())),
+				tree.NewTypedIsNullExpr(col),
+			)
+		}
+		orderByScope.addColumn(scopeColName(""), col)
+		for j := start; j < len(orderByScope.cols); j++ {
+			orderByScope.cols[j].descending = order.Direction == tree.Descending
+		}
+	}
+	b.buildOrderBy(inScope, outScope, orderByScope)
+	b.constructProjectForScope(inScope, outScope)
+	return outScope
+}
+
+// refersToSelectOutput returns true if the given ORDER BY expression refers to
+// an output column of the SELECT by its alias or ordinal, in which case it
+// cannot be computed by the branches built by buildGroupingSets.
+func refersToSelectOutput(sel *tree.SelectClause, expr tree.Expr) bool {
+	expr = tree.StripParens(expr)
+	switch t := expr.(type) {
+	case *tree.NumVal:
+		return true
+	case *tree.UnresolvedName:
+		if t.NumParts != 1 || t.Star {
+			return false
+		}
+		for i := range sel.Exprs {
+			if sel.Exprs[i].As != "" && string(sel.Exprs[i].As) == t.Parts[0] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// expandGroupingSets expands a GROUP BY clause containing ROLLUP, CUBE or
+// GROUPING SETS items into the list of grouping sets it represents. Each
+// grouping set is a list of GROUP BY items. Multiple items in the GROUP BY
+// clause are combined by taking the cross product of their grouping sets, as
+// in Postgres.
+func expandGroupingSets(groupBy tree.GroupBy) [][]tree.Expr {
+	sets := [][]tree.Expr{nil}
+	for _, e := range groupBy {
+		itemSets := expandGroupingSetItem(e)
+		checkGroupingSetCount(len(sets) * len(itemSets))
+		product := make([][]tree.Expr, 0, len(sets)*len(itemSets))
+		for _, set := range sets {
+			for _, itemSet := range itemSets {
+				newSet := make([]tree.Expr, 0, len(set)+len(itemSet))
+				newSet = append(newSet, set...)
+				product = append(product, append(newSet, itemSet...))
+			}
+		}
+		sets = product
+	}
+	return sets
+}
+
+// expandGroupingSetItem returns the list of grouping sets represented by a
+// single GROUP BY item.
+func expandGroupingSetItem(e tree.Expr) [][]tree.Expr {
+	gs, ok := e.(*tree.GroupingSet)
+	if !ok {
+		return [][]tree.Expr{{e}}
+	}
+	switch gs.Type {
+	case tree.RollupGroupingSet:
+		// ROLLUP (a, b, c) is equivalent to
+		// GROUPING SETS ((a, b, c), (a, b), (a), ()).
+		sets := make([][]tree.Expr, 0, len(gs.Exprs)+1)
+		for i := len(gs.Exprs); i >= 0; i-- {
+			sets = append(sets, gs.Exprs[:i:i])
+		}
+		return sets
+
+	case tree.CubeGroupingSet:
+		// CUBE (a, b) is equivalent to GROUPING SETS ((a, b), (a), (b), ()).
+		n := 1
+		for range gs.Exprs {
+			n *= 2
+			checkGroupingSetCount(n)
+		}
+		sets := make([][]tree.Expr, 0, n)
+		for mask := n - 1; mask >= 0; mask-- {
+			var set []tree.Expr
+			for i := range gs.Exprs {
+				if mask&(1<<(len(gs.Exprs)-1-i)) != 0 {
+					set = append(set, gs.Exprs[i])
+				}
+			}
+			sets = append(sets, set)
+		}
+		return sets
+
+	default:
+		var sets [][]tree.Expr
+		for _, item := range gs.Exprs {
+			if _, ok := item.(*tree.GroupingSet); ok {
+				sets = append(sets, expandGroupingSetItem(item)...)
+			} else {
+				sets = append(sets, []tree.Expr{item})
+			}
+			checkGroupingSetCount(len(sets))
+		}
+		return sets
+	}
+}
+
+func checkGroupingSetCount(n int) {
+	if n > maxGroupingSets {
+		panic(pgerror.Newf(pgcode.StatementTooComplex,
+			"too many grouping sets present (maximum %d)", maxGroupingSets))
+	}
+}
+
+// containsWindowFunction returns true if the SELECT list or the HAVING clause
+// of the given SELECT contains a window function.
+func containsWindowFunction(sel *tree.SelectClause) bool {
+	var v windowFuncFinder
+	for _, e := range sel.Exprs {
+		tree.WalkExprConst(&v, e.Expr)
+	}
+	if sel.Having != nil {
+		tree.WalkExprConst(&v, sel.Having.Expr)
+	}
+	return v.found
+}
+
+type windowFuncFinder struct {
+	found bool
+}
+
+// VisitPre is part of the tree.Visitor interface.
+func (v *windowFuncFinder) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
+	if f, ok := expr.(*tree.FuncExpr); ok && f.WindowDef != nil {
+		v.found = true
+	}
+	if _, ok := expr.(*tree.Subquery); ok {
+		return false, expr
+	}
+	return !v.found, expr
+}
+
+// VisitPost is part of the tree.Visitor interface.
+func (*windowFuncFinder) VisitPost(expr tree.Expr) tree.Expr { return expr }
+
+// buildGroupingFunction builds a GROUPING(args...) call. The result is an
+// integer bit mask in which the rightmost argument corresponds to the
+// least-significant bit, and each bit is set if the corresponding argument is
+// not part of the grouping set of the current aggregation. Since each
+// aggregation built by buildGroupingSets corresponds to exactly one grouping
+// set, the result is always a constant.
+func (b *Builder) buildGroupingFunction(
+	f *tree.FuncExpr, inScope, outScope *scope, outCol *scopeColumn,
+) (out opt.ScalarExpr) {
+	if !inScope.inGroupingContext() || inScope.inAgg || inScope.groupby.buildingGroupingCols {
+		panic(errGroupingArgs)
+	}
+	if len(f.Exprs) > maxGroupingArgs {
+		panic(pgerror.Newf(pgcode.TooManyArguments,
+			"GROUPING must have fewer than %d arguments", maxGroupingArgs+1))
+	}
+	g := inScope.groupby
+	var mask tree.DInt
+	for _, arg := range f.Exprs {
+		mask <<= 1
+		exprStr := symbolicExprStr(arg)
+		if _, ok := g.groupStrs[exprStr]; ok {
+			continue
+		}
+		if _, ok := g.nullGroupStrs[exprStr]; ok {
+			mask |= 1
+			continue
+		}
+		panic(errGroupingArgs)
+	}
+	out = b.factory.ConstructConstVal(tree.NewDInt(mask), types.Int)
+	return b.finishBuildScalar(f, out, inScope, outScope, outCol)
+}
+
+var errGroupingArgs = pgerror.New(pgcode.Grouping,
+	"arguments to GROUPING must be grouping expressions of the associated query level")
@@ -222,6 +222,13 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 
 	var mb mutationBuilder
 	if ins.OnConflict != nil && ins.OnConflict.IsUpsertAlias() {
+		if ins.ColumnIndirections != nil {
+			// UPSERT overwrites its target columns when a row conflicts, so a
+			// column that is only assigned in part would lose the rest of its
+			// existing value.
+			panic(unimplemented.New("upsert target indirection",
+				"UPSERT cannot assign to a field or element of a column; use INSERT ... ON CONFLICT DO UPDATE"))
+		}
 		mb.init(b, "upsert", tab, alias)
 	} else {
 		mb.init(b, "insert", tab, alias)
@@ -243,7 +250,7 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 	// replaced and named target columns are known. So this step must come first.
 	if len(ins.Columns) != 0 {
 		// Target columns are explicitly specified by name.
-		mb.addTargetNamedColsForInsert(ins.Columns)
+		mb.addTargetNamedColsForInsert(ins.Columns, ins.ColumnIndirections)
 	} else {
 		values := mb.extractValuesInput(ins.Rows)
 		if values != nil && len(values.Rows) > 0 {
@@ -276,6 +283,12 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 		mb.buildInputForInsert(inScope, nil /* rows */)
 	}
 
+	// Triggers are not yet supported for UPSERT and INSERT..ON CONFLICT DO
+	// UPDATE statements.
+	if ins.OnConflict != nil && !ins.OnConflict.DoNothing {
+		mb.checkNoTriggersForUpsert()
+	}
+
 	// Add default columns that were not explicitly specified by name or
 	// implicitly targeted by input columns. Also add any computed columns. In
 	// both cases, include columns undergoing mutations in the write-only state.
@@ -417,14 +430,17 @@ func (mb *mutationBuilder) needExistingRows() bool {
 }
 
 // addTargetNamedColsForInsert adds a list of user-specified column names to the
-// list of table columns that are the target of the Insert operation.
-func (mb *mutationBuilder) addTargetNamedColsForInsert(names tree.NameList) {
+// list of table columns that are the target of the Insert operation, along with
+// the field and subscript paths of the columns that are only assigned in part.
+func (mb *mutationBuilder) addTargetNamedColsForInsert(
+	names tree.NameList, indirections []tree.TargetIndirection,
+) {
 	if len(mb.targetColList) != 0 {
 		panic(errors.AssertionFailedf("addTargetNamedColsForInsert cannot be called more than once"))
 	}
 
 	// Add target table columns by the names specified in the Insert statement.
-	mb.addTargetColsByName(names)
+	mb.addTargetColsByName(names, indirections)
 
 	// Ensure that primary key columns are in the target column list, or that
 	// they have default values.
@@ -589,8 +605,8 @@ func (mb *mutationBuilder) buildInputForInsert(inScope *scope, inputRows *tree.S
 	var desiredTypes []*types.T
 	if len(mb.targetColList) != 0 {
 		desiredTypes = make([]*types.T, len(mb.targetColList))
-		for i, colID := range mb.targetColList {
-			desiredTypes[i] = mb.md.ColumnMeta(colID).Type
+		for i := range mb.targetColList {
+			desiredTypes[i] = mb.targetValueType(i)
 		}
 	} else {
 		desiredTypes = make([]*types.T, 0, mb.tab.ColumnCount())
@@ -618,6 +634,8 @@ func (mb *mutationBuilder) buildInputForInsert(inScope *scope, inputRows *tree.S
 	//   2. Check if the INSERT violates a GENERATED ALWAYS AS IDENTITY column.
 	//   2. Assign name to each column
 	//   3. Add column ID to the insertColIDs list.
+	var partialOrds []int
+	var partial map[int][]partialAssignment
 	for i := range mb.outScope.cols {
 		inCol := &mb.outScope.cols[i]
 		ord := mb.tabID.ColumnOrdinal(mb.targetColList[i])
@@ -638,11 +656,49 @@ func (mb *mutationBuilder) buildInputForInsert(inScope *scope, inputRows *tree.S
 		// Assign name of input column.
 		inCol.name = scopeColName(tree.Name(mb.md.ColumnMeta(mb.targetColList[i]).Alias))
 
+		// Input columns that are assigned to part of a target column are
+		// combined into the value of that column below.
+		if ind := mb.targetIndirection(i); ind != nil {
+			if partial == nil {
+				partial = make(map[int][]partialAssignment)
+			}
+			if _, ok := partial[ord]; !ok {
+				partialOrds = append(partialOrds, ord)
+			}
+			partial[ord] = append(partial[ord], partialAssignment{
+				indirection: ind,
+				value:       mb.b.factory.ConstructVariable(inCol.id),
+			})
+			continue
+		}
+
 		// Record the ID of the column that contains the value to be inserted
 		// into the corresponding target table column.
 		mb.insertColIDs[ord] = inCol.id
 	}
 
+	// Build the values of columns that are assigned in part, starting from a
+	// NULL value:
+	//
+	//   INSERT INTO t (c.a, c.b) VALUES (1, 2)
+	//
+	if len(partialOrds) != 0 {
+		projectionsScope := mb.outScope.replace()
+		projectionsScope.appendColumnsFromScope(mb.outScope)
+		for _, ord := range partialOrds {
+			tabCol := mb.tab.Column(ord)
+			colType := tabCol.DatumType()
+			var value opt.ScalarExpr = mb.b.factory.ConstructNull(colType)
+			for _, a := range partial[ord] {
+				value = mb.buildPartialAssignment(value, colType, a.indirection, a.value, tabCol.ColName(), inScope)
+			}
+			scopeCol := mb.b.synthesizeColumn(projectionsScope, scopeColName(tabCol.ColName()), colType, nil /* expr */, value)
+			mb.insertColIDs[ord] = scopeCol.id
+		}
+		projectionsScope.expr = mb.b.constructProject(mb.outScope.expr, projectionsScope.cols)
+		mb.outScope = projectionsScope
+	}
+
 	// Add assignment casts for insert columns.
 	mb.addAssignmentCasts(mb.insertColIDs)
 	mb.inputForInsertExpr = mb.outScope.expr
@@ -666,6 +722,10 @@ func (mb *mutationBuilder) addSynthesizedColsForInsert() {
 	// Add assignment casts for default column values.
 	mb.addAssignmentCasts(mb.insertColIDs)
 
+	// Invoke BEFORE triggers, which may modify the new rows. Computed columns
+	// are added afterwards, since they may depend on the modified values.
+	mb.buildRowLevelBeforeTriggers(tree.TriggerEventInsert)
+
 	// Now add all computed columns.
 	mb.addSynthesizedComputedCols(mb.insertColIDs, false /* restrict */)
 
@@ -690,6 +750,8 @@ func (mb *mutationBuilder) buildInsert(returning *tree.ReturningExprs) {
 
 	mb.buildFKChecksForInsert()
 
+	mb.buildRowLevelAfterTriggers(tree.TriggerEventInsert)
+
 	private := mb.makeMutationPrivate(returning != nil)
 	mb.outScope.expr = mb.b.factory.ConstructInsert(
 		mb.outScope.expr, mb.uniqueChecks, mb.fastPathUniqueChecks, mb.fkChecks, private,
@@ -741,6 +803,8 @@ func (mb *mutationBuilder) buildInputForDoNothing(inScope *scope, onConflict *tr
 
 	mb.targetColList = make(opt.ColList, 0, mb.tab.ColumnCount())
 	mb.targetColSet = opt.ColSet{}
+	mb.targetIndirections = nil
+	mb.partialTargetColSet = opt.ColSet{}
 }
 
 // buildInputForUpsert assumes that the output scope already contains the insert
@@ -831,6 +895,8 @@ func (mb *mutationBuilder) buildInputForUpsert(
 
 	mb.targetColList = make(opt.ColList, 0, mb.tab.ColumnCount())
 	mb.targetColSet = opt.ColSet{}
+	mb.targetIndirections = nil
+	mb.partialTargetColSet = opt.ColSet{}
 }
 
 // setUpsertCols sets the list of columns to be updated in case of conflict.
@@ -919,6 +985,8 @@ func (mb *mutationBuilder) buildUpsert(returning *tree.ReturningExprs) {
 
 	mb.buildFKChecksForUpsert()
 
+	mb.buildMergeDeleteCascade()
+
 	private := mb.makeMutationPrivate(returning != nil)
 	mb.outScope.expr = mb.b.factory.ConstructUpsert(
 		mb.outScope.expr, mb.uniqueChecks, mb.fkChecks, private,
@@ -0,0 +1,736 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/errors"
+)
+
+// duplicateMergeErrText is the error text used when a row of the target table
+// of a MERGE statement is matched by more than one source row.
+const duplicateMergeErrText = "MERGE command cannot affect row a second time"
+
+// buildMerge builds a memo group for a MERGE statement:
+//
+//	MERGE INTO t USING s ON <on>
+//	  WHEN MATCHED [AND <cond>] THEN UPDATE SET ... | DELETE | DO NOTHING
+//	  WHEN NOT MATCHED [AND <cond>] THEN INSERT ... | DO NOTHING
+//	  ...
+//
+// The source is joined with the target table on the ON condition. This is a
+// left join if there are any WHEN NOT MATCHED clauses, and an inner join
+// otherwise. The first WHEN clause that applies to a joined row determines the
+// action taken for that row, so the action is computed by a CASE expression
+// over the WHEN clauses, in order:
+//
+//	SELECT *, CASE
+//	  WHEN t.pk IS NOT NULL AND <cond1> THEN 1
+//	  WHEN t.pk IS NULL AND <cond2> THEN 2
+//	  ...
+//	  ELSE 0
+//	END AS merge_action
+//	FROM s LEFT JOIN t ON <on>
+//
+// DO NOTHING clauses produce action 0. Rows with action 0 are filtered out,
+// and the remaining rows must be distinct on the primary key of the target
+// table, so that no target row is affected more than once. The new value of
+// each column is then chosen with a CASE expression over the action:
+//
+//	CASE merge_action WHEN 1 THEN <expr1> WHEN 3 THEN <expr3> ELSE t.b END
+//
+// If the statement only has actions of one kind, the rows are passed to an
+// Update, Delete or Insert operator. Otherwise they are passed to an Upsert
+// operator, which uses the primary key of the target table as the canary
+// column (it is NULL for rows that are not matched). Matched rows with a
+// DELETE action are left unchanged by the Update or Upsert operator, and are
+// deleted by a cascade that runs after it; see mergeDeleteBuilder.
+func (b *Builder) buildMerge(merge *tree.Merge, inScope *scope) (outScope *scope) {
+	var hasNotMatched, hasInsert, hasUpdate, hasDelete bool
+	var numInserts, numUpdates int
+	for _, when := range merge.Whens {
+		if !when.Matched {
+			hasNotMatched = true
+		}
+		switch when.Kind {
+		case tree.MergeActionInsert:
+			hasInsert = true
+			numInserts++
+			if when.ColumnIndirections != nil {
+				panic(unimplemented.New("merge target indirection",
+					"MERGE cannot assign to a field or element of a column"))
+			}
+		case tree.MergeActionUpdate:
+			hasUpdate = true
+			numUpdates++
+			for _, set := range when.Exprs {
+				if set.Indirections != nil {
+					panic(unimplemented.New("merge target indirection",
+						"MERGE cannot assign to a field or element of a column"))
+				}
+			}
+		case tree.MergeActionDelete:
+			hasDelete = true
+		}
+	}
+
+	// Find which table we're working on, check the permissions. Rows of the
+	// target table are always read in order to find the matching rows.
+	tab, depName, alias, refColumns := b.resolveTableForMutation(merge.Table, privilege.SELECT)
+	if refColumns != nil {
+		panic(pgerror.Newf(pgcode.Syntax,
+			"cannot specify a list of column IDs with MERGE"))
+	}
+	if hasInsert {
+		b.checkPrivilege(depName, tab, privilege.INSERT)
+	}
+	if hasUpdate {
+		b.checkPrivilege(depName, tab, privilege.UPDATE)
+	}
+	if hasDelete {
+		b.checkPrivilege(depName, tab, privilege.DELETE)
+	}
+
+	// Check if this table has already been mutated in another subquery.
+	b.checkMultipleMutations(tab, generalMutation)
+
+	var mb mutationBuilder
+	mb.init(b, "merge", tab, alias)
+
+	// Join the source with the target table and compute the action of each
+	// joined row.
+	sourceScope, actionCol := mb.buildInputForMerge(
+		inScope, merge, hasNotMatched, hasUpdate || hasDelete,
+	)
+
+	// Compute the new values of the inserted and updated columns.
+	var insertCols, updateCols opt.ColList
+	if hasInsert {
+		insertCols = mb.addMergeInsertCols(merge.Whens, sourceScope, actionCol, numInserts == 1)
+	}
+	if hasUpdate {
+		updateCols = mb.addMergeUpdateCols(merge.Whens, actionCol, numUpdates == 1 && !hasDelete)
+	}
+
+	switch {
+	case !hasInsert && !hasUpdate:
+		// Only DELETE actions. If there are only DO NOTHING actions, all rows
+		// have been filtered out, and the Delete is a no-op.
+		mb.buildDelete(nil /* returning */)
+
+	case !hasUpdate && !hasDelete:
+		// Only INSERT actions. The fetched columns only serve to find the rows
+		// that are not matched.
+		for i := range mb.fetchColIDs {
+			mb.fetchColIDs[i] = 0
+		}
+		mb.setMergeTargetCols(insertCols)
+		mb.addSynthesizedColsForInsert()
+		mb.insertExpr = mb.outScope.expr
+		mb.buildInsert(nil /* returning */)
+
+	case !hasInsert:
+		// UPDATE and possibly DELETE actions.
+		if hasDelete {
+			mb.checkNoTriggersForUpsert()
+			mb.mergeDeleteCascade = newMergeDeleteBuilder(mb.tab, merge.Whens)
+			mb.mergeActionColID = actionCol
+		}
+		mb.setMergeTargetCols(updateCols)
+		mb.buildRowLevelBeforeTriggers(tree.TriggerEventUpdate)
+		mb.addSynthesizedColsForUpdate()
+		mb.buildUpdate(nil /* returning */)
+
+	default:
+		// INSERT actions mixed with UPDATE or DELETE actions. The first primary
+		// key column of the target table is NULL if, and only if, a source row
+		// is not matched, so it is used as the canary column.
+		mb.checkNoTriggersForUpsert()
+		primaryIndex := mb.tab.Index(cat.PrimaryIndex)
+		mb.canaryColID = mb.fetchColIDs[primaryIndex.Column(0).Ordinal()]
+		if hasDelete {
+			mb.mergeDeleteCascade = newMergeDeleteBuilder(mb.tab, merge.Whens)
+			mb.mergeActionColID = actionCol
+		}
+		mb.setMergeTargetCols(insertCols)
+		mb.addSynthesizedColsForInsert()
+		mb.insertExpr = mb.outScope.expr
+		mb.setMergeTargetCols(updateCols)
+		mb.addSynthesizedColsForUpdate()
+		mb.buildUpsert(nil /* returning */)
+	}
+
+	return mb.outScope
+}
+
+// buildInputForMerge joins the source of a MERGE statement with its target
+// table, and projects a column with the action of each joined row. Rows
+// without an action are filtered out. If hasMatchedAction is true, an error
+// is raised if a row of the target table is matched by more than one of the
+// remaining rows. The returned scope contains the source columns, which are
+// the only columns that can be referenced by WHEN NOT MATCHED clauses.
+func (mb *mutationBuilder) buildInputForMerge(
+	inScope *scope, merge *tree.Merge, hasNotMatched, hasMatchedAction bool,
+) (sourceScope *scope, actionCol opt.ColumnID) {
+	var indexFlags *tree.IndexFlags
+	if source, ok := merge.Table.(*tree.AliasedTableExpr); ok && source.IndexFlags != nil {
+		indexFlags = source.IndexFlags
+	}
+
+	// NOTE: Include mutation columns, but be careful to never use them for any
+	// reason other than as "fetch columns". See buildScan comment.
+	mb.fetchScope = mb.b.buildScan(
+		mb.b.addTable(mb.tab, &mb.alias),
+		tableOrdinals(mb.tab, columnKinds{
+			includeMutations: true,
+			includeSystem:    true,
+			includeInverted:  false,
+		}),
+		indexFlags,
+		noRowLocking,
+		inScope,
+		false, /* disableNotVisibleIndex */
+	)
+	mb.setFetchColIDs(mb.fetchScope.cols)
+
+	sourceScope = mb.b.buildFromTables(tree.TableExprs{merge.Source}, noLocking, inScope)
+
+	// Check that the same table name is not used multiple times.
+	mb.b.validateJoinTableNames(mb.fetchScope, sourceScope)
+
+	// The first primary key column of the target table is NULL if, and only
+	// if, a source row is not matched.
+	primaryIndex := mb.tab.Index(cat.PrimaryIndex)
+	canaryCol := mb.fetchColIDs[primaryIndex.Column(0).Ordinal()]
+
+	// Create a new scope so that fetchScope is not modified. It will be used
+	// later to build partial index predicate expressions, and we do not want
+	// ambiguities with column names in the source.
+	mb.outScope = mb.fetchScope.replace()
+	mb.outScope.appendColumnsFromScope(sourceScope)
+	mb.outScope.appendColumnsFromScope(mb.fetchScope)
+	on := memo.FiltersExpr{mb.b.factory.ConstructFiltersItem(mb.b.resolveAndBuildScalar(
+		merge.On,
+		types.Bool,
+		exprKindOn,
+		tree.RejectGenerators|tree.RejectWindowApplications|tree.RejectProcedures,
+		mb.outScope,
+	))}
+	if hasNotMatched {
+		mb.outScope.expr = mb.b.factory.ConstructLeftJoin(
+			sourceScope.expr, mb.fetchScope.expr, on, memo.EmptyJoinPrivate,
+		)
+	} else {
+		mb.outScope.expr = mb.b.factory.ConstructInnerJoin(
+			sourceScope.expr, mb.fetchScope.expr, on, memo.EmptyJoinPrivate,
+		)
+	}
+
+	// WHEN NOT MATCHED clauses can only reference the source columns.
+	notMatchedScope := mb.outScope.replace()
+	notMatchedScope.appendColumnsFromScope(sourceScope)
+
+	// Project the action of each row:
+	//
+	//   CASE
+	//     WHEN <canary> IS NOT NULL AND <cond1> THEN 1
+	//     WHEN <canary> IS NULL AND <cond2> THEN 2
+	//     ...
+	//     ELSE 0
+	//   END
+	//
+	whens := make(memo.ScalarListExpr, len(merge.Whens))
+	for i, when := range merge.Whens {
+		var cond opt.ScalarExpr
+		if when.Matched {
+			if hasNotMatched {
+				cond = mb.b.factory.ConstructIsNot(
+					mb.b.factory.ConstructVariable(canaryCol), memo.NullSingleton,
+				)
+			}
+		} else {
+			cond = mb.b.factory.ConstructIs(
+				mb.b.factory.ConstructVariable(canaryCol), memo.NullSingleton,
+			)
+		}
+		if when.Cond != nil {
+			condScope := mb.outScope
+			if !when.Matched {
+				condScope = notMatchedScope
+			}
+			whenCond := mb.b.resolveAndBuildScalar(
+				when.Cond,
+				types.Bool,
+				exprKindWhere,
+				tree.RejectGenerators|tree.RejectWindowApplications|tree.RejectProcedures,
+				condScope,
+			)
+			if cond == nil {
+				cond = whenCond
+			} else {
+				cond = mb.b.factory.ConstructAnd(cond, whenCond)
+			}
+		}
+		if cond == nil {
+			cond = memo.TrueSingleton
+		}
+		action := 0
+		if when.Kind != tree.MergeActionDoNothing {
+			action = i + 1
+		}
+		whens[i] = mb.b.factory.ConstructWhen(cond, mergeActionConst(mb.b, action))
+	}
+	projectionsScope := mb.outScope.replace()
+	projectionsScope.appendColumnsFromScope(mb.outScope)
+	actionScopeCol := mb.b.synthesizeColumn(
+		projectionsScope,
+		scopeColName("").WithMetadataName("merge_action"),
+		types.Int,
+		nil, /* expr */
+		mb.b.factory.ConstructCase(memo.TrueSingleton, whens, mergeActionConst(mb.b, 0)),
+	)
+	actionCol = actionScopeCol.id
+	mb.b.constructProjectForScope(mb.outScope, projectionsScope)
+	mb.outScope = projectionsScope
+
+	// Filter out the rows without an action.
+	mb.outScope.expr = mb.b.factory.ConstructSelect(
+		mb.outScope.expr,
+		memo.FiltersExpr{mb.b.factory.ConstructFiltersItem(mb.b.factory.ConstructNe(
+			mb.b.factory.ConstructVariable(actionCol), mergeActionConst(mb.b, 0),
+		))},
+	)
+
+	// Ensure that there is at most one row for every row in the target table.
+	// Rows that are not matched have NULL primary key columns, and are always
+	// distinct.
+	if hasMatchedAction {
+		var pkCols opt.ColSet
+		for i := 0; i < primaryIndex.KeyColumnCount(); i++ {
+			pkCols.Add(mb.fetchColIDs[primaryIndex.Column(i).Ordinal()])
+		}
+		mb.outScope = mb.b.buildDistinctOn(
+			pkCols, mb.outScope, true /* nullsAreDistinct */, duplicateMergeErrText,
+		)
+	}
+
+	return notMatchedScope, actionCol
+}
+
+// addMergeUpdateCols builds the values of the columns that are updated by the
+// WHEN MATCHED THEN UPDATE clauses of a MERGE statement, and sets updateColIDs
+// accordingly. If singleAction is true, all rows are updated by the same
+// clause, and the value of each column is taken from it directly. Otherwise
+// the value is chosen by a CASE expression over the action column, and
+// columns that are not updated by the clause of a row keep their existing
+// value. It returns the updated columns.
+func (mb *mutationBuilder) addMergeUpdateCols(
+	whens []*tree.MergeWhen, actionCol opt.ColumnID, singleAction bool,
+) (targetCols opt.ColList) {
+	// SET expressions should reject aggregates, generators, etc.
+	scalarProps := &mb.b.semaCtx.Properties
+	defer scalarProps.Restore(*scalarProps)
+	mb.b.semaCtx.Properties.Require("MERGE UPDATE SET", tree.RejectSpecial)
+
+	inScope := mb.outScope
+	projectionsScope := mb.outScope.replace()
+	projectionsScope.appendColumnsFromScope(mb.outScope)
+
+	var actions []int
+	var values []opt.OptionalColList
+	var targetColSet opt.ColSet
+	for i, when := range whens {
+		if when.Kind != tree.MergeActionUpdate {
+			continue
+		}
+		vals := make(opt.OptionalColList, mb.tab.ColumnCount())
+		mb.resetMergeTargetCols()
+
+		addCol := func(name tree.Name, expr tree.Expr) {
+			mb.addTargetColsByName(tree.NameList{name}, nil /* indirections */)
+			targetColID := mb.targetColList[len(mb.targetColList)-1]
+			ord := mb.tabID.ColumnOrdinal(targetColID)
+			targetCol := mb.tab.Column(ord)
+
+			// Allow right side of SET to be DEFAULT.
+			if _, ok := expr.(tree.DefaultVal); ok {
+				expr = mb.parseDefaultExpr(targetColID)
+			} else if targetCol.IsGeneratedAlwaysAsIdentity() {
+				panic(sqlerrors.NewGeneratedAlwaysAsIdentityColumnUpdateError(string(targetCol.ColName())))
+			}
+
+			texpr := inScope.resolveType(expr, targetCol.DatumType())
+			targetColName := targetCol.ColName()
+			colName := scopeColName(targetColName).WithMetadataName(string(targetColName) + "_new")
+			scopeCol := projectionsScope.addColumn(colName, texpr)
+			mb.b.buildScalar(texpr, inScope, projectionsScope, scopeCol, nil)
+			vals[ord] = scopeCol.id
+			if !targetColSet.Contains(targetColID) {
+				targetColSet.Add(targetColID)
+				targetCols = append(targetCols, targetColID)
+			}
+		}
+
+		for _, set := range when.Exprs {
+			if !set.Tuple {
+				addCol(set.Names[0], set.Expr)
+				continue
+			}
+			t, ok := set.Expr.(*tree.Tuple)
+			if !ok {
+				panic(unimplemented.Newf("merge update subquery",
+					"source for a multiple-column MERGE UPDATE item must be a ROW() expression; not supported: %T", set.Expr))
+			}
+			if len(set.Names) != len(t.Exprs) {
+				panic(pgerror.Newf(pgcode.Syntax,
+					"number of columns (%d) does not match number of values (%d)",
+					len(set.Names), len(t.Exprs)))
+			}
+			for j, expr := range t.Exprs {
+				addCol(set.Names[j], expr)
+			}
+		}
+		actions = append(actions, i+1)
+		values = append(values, vals)
+	}
+
+	mb.b.constructProjectForScope(mb.outScope, projectionsScope)
+	mb.outScope = projectionsScope
+	for _, vals := range values {
+		mb.addAssignmentCasts(vals)
+	}
+
+	mb.combineMergeValues(mb.updateColIDs, targetCols, actionCol, actions, values, singleAction, "_new", true /* useFetchVal */)
+	return targetCols
+}
+
+// addMergeInsertCols builds the values of the columns that are inserted by the
+// WHEN NOT MATCHED THEN INSERT clauses of a MERGE statement, and sets
+// insertColIDs accordingly. If singleAction is true, all rows are inserted by
+// the same clause, and the value of each column is taken from it directly.
+// Otherwise the value is chosen by a CASE expression over the action column,
+// and columns that are not given a value by the clause of a row are set to
+// their default value. Columns that are not given a value by any clause are
+// left for addSynthesizedColsForInsert. It returns the inserted columns.
+func (mb *mutationBuilder) addMergeInsertCols(
+	whens []*tree.MergeWhen, inScope *scope, actionCol opt.ColumnID, singleAction bool,
+) (targetCols opt.ColList) {
+	// VALUES expressions should reject aggregates, generators, etc.
+	scalarProps := &mb.b.semaCtx.Properties
+	defer scalarProps.Restore(*scalarProps)
+	mb.b.semaCtx.Properties.Require("MERGE INSERT VALUES", tree.RejectSpecial)
+
+	// Determine the target columns of each clause, and of the statement as a
+	// whole.
+	var targetColSet opt.ColSet
+	clauseCols := make([]opt.ColList, len(whens))
+	for i, when := range whens {
+		if when.Kind != tree.MergeActionInsert {
+			continue
+		}
+		mb.resetMergeTargetCols()
+		if len(when.Columns) != 0 {
+			mb.addTargetColsByName(when.Columns, nil /* indirections */)
+			if when.Values != nil {
+				mb.checkNumCols(len(mb.targetColList), len(when.Values))
+			}
+		} else if when.Values != nil {
+			mb.addTargetTableColsForInsert(len(when.Values))
+		}
+		if when.Values == nil {
+			// DEFAULT VALUES.
+			continue
+		}
+		clauseCols[i] = append(opt.ColList(nil), mb.targetColList...)
+		for _, colID := range mb.targetColList {
+			if !targetColSet.Contains(colID) {
+				targetColSet.Add(colID)
+				targetCols = append(targetCols, colID)
+			}
+		}
+	}
+
+	projectionsScope := mb.outScope.replace()
+	projectionsScope.appendColumnsFromScope(mb.outScope)
+
+	var actions []int
+	var values []opt.OptionalColList
+	for i, when := range whens {
+		if when.Kind != tree.MergeActionInsert {
+			continue
+		}
+		vals := make(opt.OptionalColList, mb.tab.ColumnCount())
+		exprs := make(map[opt.ColumnID]tree.Expr, len(clauseCols[i]))
+		for j, colID := range clauseCols[i] {
+			exprs[colID] = when.Values[j]
+		}
+		for _, targetColID := range targetCols {
+			ord := mb.tabID.ColumnOrdinal(targetColID)
+			targetCol := mb.tab.Column(ord)
+			expr, ok := exprs[targetColID]
+			if _, isDefault := expr.(tree.DefaultVal); !ok || isDefault {
+				expr = mb.parseDefaultExpr(targetColID)
+			} else if targetCol.IsGeneratedAlwaysAsIdentity() {
+				panic(sqlerrors.NewGeneratedAlwaysAsIdentityColumnOverrideError(string(targetCol.ColName())))
+			}
+			texpr := inScope.resolveType(expr, targetCol.DatumType())
+			scopeCol := projectionsScope.addColumn(scopeColName(targetCol.ColName()), texpr)
+			mb.b.buildScalar(texpr, inScope, projectionsScope, scopeCol, nil)
+			vals[ord] = scopeCol.id
+		}
+		actions = append(actions, i+1)
+		values = append(values, vals)
+	}
+
+	mb.b.constructProjectForScope(mb.outScope, projectionsScope)
+	mb.outScope = projectionsScope
+	for _, vals := range values {
+		mb.addAssignmentCasts(vals)
+	}
+
+	mb.combineMergeValues(mb.insertColIDs, targetCols, actionCol, actions, values, singleAction, "_ins", false /* useFetchVal */)
+	return targetCols
+}
+
+// combineMergeValues sets the given target columns in colIDs to the values
+// built for the WHEN clauses with the given actions. If singleAction is true,
+// the values of the only clause are used directly. Otherwise, a CASE
+// expression over the action column is projected for each target column,
+// which falls back to the fetched value of the column if useFetchVal is true,
+// and to NULL otherwise.
+func (mb *mutationBuilder) combineMergeValues(
+	colIDs opt.OptionalColList,
+	targetCols opt.ColList,
+	actionCol opt.ColumnID,
+	actions []int,
+	values []opt.OptionalColList,
+	singleAction bool,
+	suffix string,
+	useFetchVal bool,
+) {
+	if singleAction {
+		for _, colID := range targetCols {
+			ord := mb.tabID.ColumnOrdinal(colID)
+			colIDs[ord] = values[0][ord]
+		}
+		return
+	}
+
+	projectionsScope := mb.outScope.replace()
+	projectionsScope.appendColumnsFromScope(mb.outScope)
+	for _, colID := range targetCols {
+		ord := mb.tabID.ColumnOrdinal(colID)
+		col := mb.tab.Column(ord)
+		typ := col.DatumType()
+		whens := make(memo.ScalarListExpr, 0, len(actions))
+		for i, action := range actions {
+			if values[i][ord] == 0 {
+				continue
+			}
+			whens = append(whens, mb.b.factory.ConstructWhen(
+				mergeActionConst(mb.b, action), mb.b.factory.ConstructVariable(values[i][ord]),
+			))
+		}
+		var orElse opt.ScalarExpr
+		if useFetchVal {
+			orElse = mb.b.factory.ConstructVariable(mb.fetchColIDs[ord])
+		} else {
+			orElse = mb.b.factory.ConstructNull(typ)
+		}
+		name := scopeColName(col.ColName()).WithMetadataName(string(col.ColName()) + suffix)
+		scopeCol := mb.b.synthesizeColumn(
+			projectionsScope, name, typ, nil, /* expr */
+			mb.b.factory.ConstructCase(mb.b.factory.ConstructVariable(actionCol), whens, orElse),
+		)
+		colIDs[ord] = scopeCol.id
+	}
+	mb.b.constructProjectForScope(mb.outScope, projectionsScope)
+	mb.outScope = projectionsScope
+}
+
+// resetMergeTargetCols clears the target columns of the mutation builder, so
+// that the target columns of the next WHEN clause or of the mutation operator
+// can be added.
+func (mb *mutationBuilder) resetMergeTargetCols() {
+	mb.targetColList = make(opt.ColList, 0, mb.tab.ColumnCount())
+	mb.targetColSet = opt.ColSet{}
+	mb.targetIndirections = nil
+	mb.partialTargetColSet = opt.ColSet{}
+}
+
+// setMergeTargetCols sets the target columns of the mutation builder to the
+// given columns.
+func (mb *mutationBuilder) setMergeTargetCols(cols opt.ColList) {
+	mb.resetMergeTargetCols()
+	for _, colID := range cols {
+		mb.targetColList = append(mb.targetColList, colID)
+		mb.targetColSet.Add(colID)
+	}
+}
+
+// buildMergeDeleteCascade adds a cascade that deletes the rows of a MERGE
+// statement with a DELETE action, if the mutation operator of the statement
+// is an Update or Upsert. See mergeDeleteBuilder.
+func (mb *mutationBuilder) buildMergeDeleteCascade() {
+	if mb.mergeDeleteCascade == nil {
+		return
+	}
+	primaryIndex := mb.tab.Index(cat.PrimaryIndex)
+	oldValues := make(opt.ColList, 0, primaryIndex.KeyColumnCount()+1)
+	oldValues = append(oldValues, mb.mergeActionColID)
+	for i := 0; i < primaryIndex.KeyColumnCount(); i++ {
+		oldValues = append(oldValues, mb.fetchColIDs[primaryIndex.Column(i).Ordinal()])
+	}
+	mb.ensureWithID()
+	mb.cascades = append(mb.cascades, memo.FKCascade{
+		FKName:    "merge delete",
+		Builder:   mb.mergeDeleteCascade,
+		WithID:    mb.withID,
+		OldValues: oldValues,
+	})
+}
+
+// mergeActionConst returns a constant with the given MERGE action.
+func mergeActionConst(b *Builder, action int) opt.ScalarExpr {
+	return b.factory.ConstructConstVal(tree.NewDInt(tree.DInt(action)), types.Int)
+}
+
+// mergeDeleteBuilder is a memo.CascadeBuilder implementation that deletes the
+// rows of the target table of a MERGE statement which are matched by a WHEN
+// MATCHED THEN DELETE clause, when the statement also has INSERT or UPDATE
+// actions. It builds a query equivalent to:
+//
+//	DELETE FROM t WHERE pk IN (
+//	  SELECT pk FROM original_mutation_input WHERE merge_action IN (<actions>)
+//	)
+//
+// The first column of the mutation input is the action column, and the
+// remaining columns are the primary key columns of the table.
+type mergeDeleteBuilder struct {
+	table   cat.Table
+	actions []int
+}
+
+var _ memo.CascadeBuilder = &mergeDeleteBuilder{}
+
+func newMergeDeleteBuilder(table cat.Table, whens []*tree.MergeWhen) *mergeDeleteBuilder {
+	cb := &mergeDeleteBuilder{table: table}
+	for i, when := range whens {
+		if when.Kind == tree.MergeActionDelete {
+			cb.actions = append(cb.actions, i+1)
+		}
+	}
+	return cb
+}
+
+// Build is part of the memo.CascadeBuilder interface.
+func (cb *mergeDeleteBuilder) Build(
+	ctx context.Context,
+	semaCtx *tree.SemaContext,
+	evalCtx *eval.Context,
+	catalog cat.Catalog,
+	factoryI interface{},
+	binding opt.WithID,
+	bindingProps *props.Relational,
+	oldValues, newValues opt.ColList,
+) (_ memo.RelExpr, err error) {
+	return buildCascadeHelper(ctx, semaCtx, evalCtx, catalog, factoryI, func(b *Builder) memo.RelExpr {
+		opt.MaybeInjectOptimizerTestingPanic(ctx, evalCtx)
+
+		var mb mutationBuilder
+		mb.init(b, "delete", cb.table, tree.MakeUnqualifiedTableName(cb.table.Name()))
+
+		// Build a semi join of the table with the deleted rows of the mutation
+		// input.
+		mb.fetchScope = b.buildScan(
+			b.addTable(cb.table, &mb.alias),
+			tableOrdinals(cb.table, columnKinds{
+				includeMutations: false,
+				includeSystem:    false,
+				includeInverted:  false,
+			}),
+			nil, /* indexFlags */
+			noRowLocking,
+			b.allocScope(),
+			true, /* disableNotVisibleIndex */
+		)
+
+		primaryIndex := cb.table.Index(cat.PrimaryIndex)
+		if len(oldValues) != primaryIndex.KeyColumnCount()+1 {
+			panic(errors.AssertionFailedf(
+				"expected %d oldValues columns, got %d", primaryIndex.KeyColumnCount()+1, len(oldValues),
+			))
+		}
+
+		md := b.factory.Metadata()
+		outCols := make(opt.ColList, len(oldValues))
+		for i := range outCols {
+			c := md.ColumnMeta(oldValues[i])
+			outCols[i] = md.AddColumn(c.Alias, c.Type)
+		}
+
+		// Construct a dummy operator as the binding.
+		md.AddWithBinding(binding, b.factory.ConstructFakeRel(&memo.FakeRelPrivate{
+			Props: bindingProps,
+		}))
+		var deleted opt.ScalarExpr
+		for _, action := range cb.actions {
+			eq := b.factory.ConstructEq(
+				b.factory.ConstructVariable(outCols[0]), mergeActionConst(b, action),
+			)
+			if deleted == nil {
+				deleted = eq
+			} else {
+				deleted = b.factory.ConstructOr(deleted, eq)
+			}
+		}
+		mutationInput := b.factory.ConstructSelect(
+			b.factory.ConstructWithScan(&memo.WithScanPrivate{
+				With:    binding,
+				InCols:  oldValues,
+				OutCols: outCols,
+				ID:      md.NextUniqueID(),
+			}),
+			memo.FiltersExpr{b.factory.ConstructFiltersItem(deleted)},
+		)
+
+		on := make(memo.FiltersExpr, primaryIndex.KeyColumnCount())
+		for i := range on {
+			col := mb.fetchScope.getColumnForTableOrdinal(primaryIndex.Column(i).Ordinal())
+			on[i] = b.factory.ConstructFiltersItem(b.factory.ConstructEq(
+				b.factory.ConstructVariable(col.id),
+				b.factory.ConstructVariable(outCols[i+1]),
+			))
+		}
+		mb.fetchScope.expr = b.factory.ConstructSemiJoin(
+			mb.fetchScope.expr, mutationInput, on, memo.EmptyJoinPrivate,
+		)
+		mb.outScope = mb.fetchScope
+
+		// Set list of columns that will be fetched by the input expression.
+		mb.setFetchColIDs(mb.outScope.cols)
+		mb.buildDelete(nil /* returning */)
+		return mb.outScope.expr
+	})
+}
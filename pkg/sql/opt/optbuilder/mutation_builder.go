@@ -74,6 +74,18 @@ type mutationBuilder struct {
 	// targetColSet contains the same column IDs as targetColList, but as a set.
 	targetColSet opt.ColSet
 
+	// targetIndirections lists the field and subscript paths of target columns
+	// that are only assigned in part, as in UPDATE t SET c.a = 1. Its i-th entry
+	// belongs to the i-th column in targetColList, and is nil if the whole column
+	// is targeted. It is nil or shorter than targetColList if the remaining
+	// target columns are targeted whole. A column that is assigned in part can
+	// appear in targetColList several times.
+	targetIndirections []tree.TargetIndirection
+
+	// partialTargetColSet contains the IDs of target columns that are only
+	// assigned in part.
+	partialTargetColSet opt.ColSet
+
 	// insertColIDs lists the input column IDs providing values to insert. Its
 	// length is always equal to the number of columns in the target table,
 	// including mutation columns. Table columns which will not have values
@@ -206,6 +218,13 @@ type mutationBuilder struct {
 	// inputForInsertExpr stores the result of outscope.expr from the most
 	// recent call to buildInputForInsert.
 	inputForInsertExpr memo.RelExpr
+
+	// mergeDeleteCascade, if set, deletes the rows of a MERGE statement that
+	// have a DELETE action, after the Update or Upsert operator of the
+	// statement; see buildMerge. mergeActionColID is the input column with the
+	// action of each row.
+	mergeDeleteCascade *mergeDeleteBuilder
+	mergeActionColID   opt.ColumnID
 }
 
 func (mb *mutationBuilder) init(b *Builder, opName string, tab cat.Table, alias tree.TableName) {
@@ -489,9 +508,13 @@ func (mb *mutationBuilder) buildInputForDelete(
 }
 
 // addTargetColsByName adds one target column for each of the names in the given
-// list.
-func (mb *mutationBuilder) addTargetColsByName(names tree.NameList) {
-	for _, name := range names {
+// list. If indirections is not nil, its i-th entry is the field and subscript
+// path of the part of the i-th column that is targeted, or nil if the whole
+// column is targeted.
+func (mb *mutationBuilder) addTargetColsByName(
+	names tree.NameList, indirections []tree.TargetIndirection,
+) {
+	for i, name := range names {
 		// Determine the ordinal position of the named column in the table and
 		// add it as a target column.
 		if ord := findPublicTableColumnByName(mb.tab, name); ord != -1 {
@@ -499,7 +522,11 @@ func (mb *mutationBuilder) addTargetColsByName(names tree.NameList) {
 			if mb.tab.Column(ord).Kind() == cat.System {
 				panic(pgerror.Newf(pgcode.InvalidColumnReference, "cannot modify system column %q", name))
 			}
-			mb.addTargetCol(ord)
+			if indirections != nil && indirections[i] != nil {
+				mb.addPartialTargetCol(ord, indirections[i])
+			} else {
+				mb.addTargetCol(ord)
+			}
 			continue
 		}
 		panic(colinfo.NewUndefinedColumnError(string(name)))
@@ -602,6 +629,9 @@ func (mb *mutationBuilder) replaceDefaultExprs(inRows *tree.Select) (outRows *tr
 					copy(newTuple, tuple[:itup])
 				}
 
+				if ind := mb.targetIndirection(itup); ind != nil {
+					panic(partialDefaultError(ind))
+				}
 				val = mb.parseDefaultExpr(mb.targetColList[itup])
 			}
 			if newTuple != nil {
@@ -1150,6 +1180,12 @@ func (mb *mutationBuilder) parseDefaultExpr(colID opt.ColumnID) tree.Expr {
 	col := mb.tab.Column(ord)
 	exprStr := col.DefaultExprStr()
 
+	// Columns of domain types without a default expression of their own use the
+	// default expression of the domain, if any.
+	if typ := col.DatumType(); exprStr == "" && typ.IsDomain() && typ.TypeMeta.DomainData != nil {
+		exprStr = typ.TypeMeta.DomainData.DefaultExpr
+	}
+
 	// If no default expression, return NULL or a default value.
 	if exprStr == "" {
 		if col.IsMutation() && !col.IsNullable() {
@@ -1370,6 +1406,10 @@ func (mb *mutationBuilder) addAssignmentCasts(srcCols opt.OptionalColList) {
 			projectionScope.appendColumnsFromScope(mb.outScope)
 		}
 
+		// Values assigned to columns of domain types must satisfy the
+		// constraints of the domain.
+		cast = mb.b.buildDomainChecks(cast, targetType, projectionScope)
+
 		// Update the scope column to be casted.
 		//
 		// When building an UPDATE..FROM expression the projectionScope may have
@@ -17,10 +17,12 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinsregistry"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/intsets"
+	"github.com/cockroachdb/errors"
 )
 
 // UniquenessChecksForGenRandomUUIDClusterMode controls the cluster setting for
@@ -34,7 +36,8 @@ var UniquenessChecksForGenRandomUUIDClusterMode = settings.RegisterBoolSetting(
 	settings.WithPublic)
 
 // buildUniqueChecksForInsert builds uniqueness check queries for an insert.
-// These check queries are used to enforce UNIQUE WITHOUT INDEX constraints.
+// These check queries are used to enforce UNIQUE WITHOUT INDEX constraints,
+// including exclusion constraints.
 func (mb *mutationBuilder) buildUniqueChecksForInsert() {
 	// We only need to build unique checks if there is at least one unique
 	// constraint without an index.
@@ -237,6 +240,7 @@ func (h *uniqueCheckHelper) init(mb *mutationBuilder, uniqueOrdinal int) bool {
 	for i, n := 0, h.unique.ColumnCount(); i < n; i++ {
 		uniqueOrds.Add(h.unique.ColumnOrdinal(mb.tab, i))
 	}
+	isExclusion := h.unique.ExclusionElementCount() > 0
 
 	// Find the primary key columns that are not part of the unique constraint.
 	// If there aren't any, we don't need a check.
@@ -245,12 +249,18 @@ func (h *uniqueCheckHelper) init(mb *mutationBuilder, uniqueOrdinal int) bool {
 	// Similarly, we don't need a check for a partial unique constraint if there
 	// exists a non-partial unique constraint with columns that are a subset of
 	// the partial unique constraint columns.
+	//
+	// Rows that conflict on an exclusion constraint do not necessarily have
+	// equal values, so all primary key columns are needed to prevent rows from
+	// matching themselves, and a check is always needed.
 	primaryOrds := getIndexLaxKeyOrdinals(mb.tab.Index(cat.PrimaryIndex))
-	primaryOrds.DifferenceWith(uniqueOrds)
-	if primaryOrds.Empty() {
-		// The primary key columns are a subset of the unique columns; unique check
-		// not needed.
-		return false
+	if !isExclusion {
+		primaryOrds.DifferenceWith(uniqueOrds)
+		if primaryOrds.Empty() {
+			// The primary key columns are a subset of the unique columns; unique
+			// check not needed.
+			return false
+		}
 	}
 
 	h.uniqueOrdinals = uniqueOrds
@@ -284,6 +294,12 @@ func (h *uniqueCheckHelper) init(mb *mutationBuilder, uniqueOrdinal int) bool {
 	// FDs below.
 	h.scanScope, h.scanOrdinals = h.buildTableScan()
 
+	if isExclusion {
+		// The columns of an exclusion constraint being a key does not prevent
+		// rows from conflicting.
+		return true
+	}
+
 	// Check that the columns in the unique constraint aren't already known to
 	// form a lax key. This can happen if there is a unique index on a superset of
 	// these columns, where all other columns are computed columns that depend
@@ -456,13 +472,18 @@ func (h *uniqueCheckHelper) buildInsertionCheck(
 		numFilters += 2
 	}
 	semiJoinFilters := make(memo.FiltersExpr, 0, numFilters)
-	for i, ok := h.uniqueOrdinals.Next(0); ok; i, ok = h.uniqueOrdinals.Next(i + 1) {
-		semiJoinFilters = append(semiJoinFilters, f.ConstructFiltersItem(
-			f.ConstructEq(
-				f.ConstructVariable(uniqueCheckScope.cols[i].id),
-				f.ConstructVariable(h.scanScope.cols[i].id),
-			),
-		))
+	isExclusion := h.unique.ExclusionElementCount() > 0
+	if isExclusion {
+		semiJoinFilters = h.appendExclusionFilters(semiJoinFilters, uniqueCheckScope)
+	} else {
+		for i, ok := h.uniqueOrdinals.Next(0); ok; i, ok = h.uniqueOrdinals.Next(i + 1) {
+			semiJoinFilters = append(semiJoinFilters, f.ConstructFiltersItem(
+				f.ConstructEq(
+					f.ConstructVariable(uniqueCheckScope.cols[i].id),
+					f.ConstructVariable(h.scanScope.cols[i].id),
+				),
+			))
+		}
 	}
 	// Find the ScanExpr which reads from the table this unique check applies to.
 	var uniqueFastPathCheck memo.RelExpr
@@ -481,8 +502,8 @@ func (h *uniqueCheckHelper) buildInsertionCheck(
 		scanExpr, foundScan = possibleScan.(*memo.ScanExpr)
 
 		// Fast path is disabled if this check is for a UNIQUE WITHOUT INDEX with a
-		// partial index predicate.
-		if foundScan && !isPartial {
+		// partial index predicate, or for an exclusion constraint.
+		if foundScan && !isPartial && !isExclusion {
 			scanFilters = h.buildFiltersForFastPathCheck(uniqueCheckExpr, uniqueCheckCols, scanExpr)
 		}
 	}
@@ -585,6 +606,66 @@ func (h *uniqueCheckHelper) buildInsertionCheck(
 	return uniqueChecks, &fastPathChecks
 }
 
+// appendExclusionFilters appends to filters the join filters of an exclusion
+// constraint check, which match existing rows that conflict with the new rows
+// on all the elements of the constraint:
+//
+//	(new_a = existing_a) AND (new_b && existing_b) AND
+//	overlaps(new_start, new_end, existing_start, existing_end) AND ...
+func (h *uniqueCheckHelper) appendExclusionFilters(
+	filters memo.FiltersExpr, newScope *scope,
+) memo.FiltersExpr {
+	f := h.mb.b.factory
+	for i, n := 0, h.unique.ExclusionElementCount(); i < n; i++ {
+		elem := h.unique.ExclusionElement(i)
+		ord := h.unique.ColumnOrdinal(h.mb.tab, elem.Columns[0])
+		newVal := f.ConstructVariable(newScope.cols[ord].id)
+		existingVal := f.ConstructVariable(h.scanScope.cols[ord].id)
+		var cond opt.ScalarExpr
+		switch {
+		case len(elem.Columns) == 2:
+			endOrd := h.unique.ColumnOrdinal(h.mb.tab, elem.Columns[1])
+			cond = h.constructPeriodOverlaps(
+				memo.ScalarListExpr{
+					newVal,
+					f.ConstructVariable(newScope.cols[endOrd].id),
+					existingVal,
+					f.ConstructVariable(h.scanScope.cols[endOrd].id),
+				},
+				h.mb.tab.Column(ord).DatumType(),
+			)
+		case elem.Overlaps:
+			cond = f.ConstructOverlaps(newVal, existingVal)
+		default:
+			cond = f.ConstructEq(newVal, existingVal)
+		}
+		filters = append(filters, f.ConstructFiltersItem(cond))
+	}
+	return filters
+}
+
+// constructPeriodOverlaps constructs a call to the overlaps builtin with the
+// given start and end values of two periods of the given type.
+func (h *uniqueCheckHelper) constructPeriodOverlaps(
+	args memo.ScalarListExpr, typ *types.T,
+) opt.ScalarExpr {
+	const fnName = "overlaps"
+	props, overloads := builtinsregistry.GetBuiltinProperties(fnName)
+	for i := range overloads {
+		o := &overloads[i]
+		if o.Types.MatchAt(typ, 0) && o.Types.MatchAt(typ, 1) &&
+			o.Types.MatchAt(typ, 2) && o.Types.MatchAt(typ, 3) {
+			return h.mb.b.factory.ConstructFunction(args, &memo.FunctionPrivate{
+				Name:       fnName,
+				Typ:        types.Bool,
+				Properties: props,
+				Overload:   o,
+			})
+		}
+	}
+	panic(errors.AssertionFailedf("no overlaps overload for periods of type %s", typ))
+}
+
 // buildTableScan builds a Scan of the table. The ordinals of the columns
 // scanned are also returned.
 func (h *uniqueCheckHelper) buildTableScan() (outScope *scope, ordinals []int) {
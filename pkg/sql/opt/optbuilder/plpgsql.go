@@ -12,6 +12,7 @@ package optbuilder
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
@@ -19,8 +20,11 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinsregistry"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/cast"
 	ast "github.com/cockroachdb/cockroach/pkg/sql/sem/plpgsqltree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treebin"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
@@ -114,6 +118,10 @@ type plpgsqlBuilder struct {
 	// params tracks the names and types for the original function parameters.
 	params []tree.ParamType
 
+	// paramClasses tracks the class of each of the original function
+	// parameters. It is nil if all parameters are IN parameters.
+	paramClasses []tree.RoutineParamClass
+
 	// decls is the set of variable declarations for a PL/pgSQL function.
 	decls []ast.Declaration
 
@@ -128,9 +136,25 @@ type plpgsqlBuilder struct {
 	// cursor before it is opened.
 	cursors map[tree.Name]ast.CursorDeclaration
 
-	// returnType is the return type of the PL/pgSQL function.
+	// returnType is the return type of the PL/pgSQL function. For a
+	// set-returning function, it is the type of each returned row.
 	returnType *types.T
 
+	// setReturning is true if the PL/pgSQL function returns a set of rows.
+	setReturning bool
+
+	// isProcedure is true if the PL/pgSQL routine is a procedure.
+	isProcedure bool
+
+	// paramAliases contains the ordinals of unnamed parameters that were given
+	// a name by an ALIAS declaration.
+	paramAliases []int
+
+	// loopVars tracks the hidden variables that are used to implement each FOR
+	// and FOREACH loop. They are declared along with the user's variables. See
+	// declareLoopVars.
+	loopVars map[ast.Statement][]tree.Name
+
 	// continuations is used to model the control flow of a PL/pgSQL function.
 	// The head of the continuations stack is used upon reaching the end of a
 	// statement block to call a function that models the statements that come
@@ -152,13 +176,29 @@ type plpgsqlBuilder struct {
 }
 
 func (b *plpgsqlBuilder) init(
-	ob *Builder, colRefs *opt.ColSet, params []tree.ParamType, block *ast.Block, returnType *types.T,
+	ob *Builder,
+	colRefs *opt.ColSet,
+	params []tree.ParamType,
+	paramClasses []tree.RoutineParamClass,
+	block *ast.Block,
+	returnType *types.T,
+	setReturning bool,
+	isProcedure bool,
 ) {
 	b.ob = ob
 	b.colRefs = colRefs
 	b.params = params
+	b.paramClasses = paramClasses
 	b.returnType = returnType
+	b.setReturning = setReturning
+	b.isProcedure = isProcedure
 	b.varTypes = make(map[tree.Name]*types.T)
+	for i := range params {
+		if b.isOutParam(i) && params[i].Name != "" {
+			// OUT and INOUT parameters can be assigned like variables.
+			b.varTypes[tree.Name(params[i].Name)] = params[i].Typ
+		}
+	}
 	b.cursors = make(map[tree.Name]ast.CursorDeclaration)
 	for i := range block.Decls {
 		switch dec := block.Decls[i].(type) {
@@ -168,6 +208,8 @@ func (b *plpgsqlBuilder) init(
 			// Declaration of a bound cursor declares a variable of type refcursor.
 			b.decls = append(b.decls, ast.Declaration{Var: dec.Name, Typ: types.RefCursor})
 			b.cursors[dec.Name] = *dec
+		case *ast.AliasDeclaration:
+			b.addParamAlias(dec)
 		}
 	}
 	for _, dec := range b.decls {
@@ -189,14 +231,160 @@ func (b *plpgsqlBuilder) init(
 			))
 		}
 	}
+	b.declareLoopVars(block)
+}
+
+// addParamAlias handles an ALIAS declaration, which gives another name to a
+// parameter. Only unnamed parameters can currently be aliased, in which case
+// the alias becomes the name of the parameter.
+func (b *plpgsqlBuilder) addParamAlias(alias *ast.AliasDeclaration) {
+	if !strings.HasPrefix(alias.Target, "$") {
+		panic(unimplemented.New(
+			"ALIAS FOR variable",
+			"ALIAS declarations are only supported for unnamed parameters",
+		))
+	}
+	ord, err := strconv.Atoi(alias.Target[1:])
+	if err != nil || ord < 1 || ord > len(b.params) {
+		panic(pgerror.Newf(pgcode.Syntax, "\"%s\" is not a known variable", alias.Target))
+	}
+	ord--
+	if b.params[ord].Name != "" {
+		panic(unimplemented.New(
+			"ALIAS FOR named parameter",
+			"ALIAS declarations are only supported for unnamed parameters",
+		))
+	}
+	// Copy the parameters before modifying them, since they may belong to the
+	// function overload.
+	b.params = append([]tree.ParamType(nil), b.params...)
+	b.params[ord].Name = string(alias.Name)
+	if b.isOutParam(ord) {
+		b.varTypes[alias.Name] = b.params[ord].Typ
+	}
+	if !b.isOutOnlyParam(ord) {
+		b.paramAliases = append(b.paramAliases, ord)
+	}
+}
+
+// declareLoopVars declares the hidden variables that are used to implement the
+// FOR and FOREACH loops in the given block:
+//
+//   - An integer FOR loop uses variables for the counter, the upper bound and
+//     the step of the loop. If the loop variable shadows an existing variable,
+//     another variable is used to save the value of the existing variable.
+//   - A FOREACH loop uses variables for the array and the current index.
+//   - A FOR loop over the rows of a query uses a variable for the name of the
+//     cursor that returns the rows. A FOR loop over a bound cursor uses the
+//     cursor variable.
+//
+// The loop variable of an integer FOR loop is also declared if it is not an
+// existing variable.
+func (b *plpgsqlBuilder) declareLoopVars(block *ast.Block) {
+	b.loopVars = make(map[ast.Statement][]tree.Name)
+	declare := func(stmt ast.Statement, name string, typ *types.T) {
+		varName := tree.Name(b.makeIdentifier(name))
+		b.decls = append(b.decls, ast.Declaration{Var: varName, Typ: typ})
+		b.varTypes[varName] = typ
+		b.loopVars[stmt] = append(b.loopVars[stmt], varName)
+	}
+	v := loopVisitor{fn: func(stmt ast.Statement) {
+		switch t := stmt.(type) {
+		case *ast.ForInt:
+			declare(t, "_for_counter", types.Int)
+			declare(t, "_for_upper", types.Int)
+			declare(t, "_for_step", types.Int)
+			if typ, ok := b.varTypes[t.Var]; ok {
+				if typ.Family() != types.IntFamily {
+					panic(unimplemented.New(
+						"FOR loop variable shadowing",
+						"the variable of an integer FOR loop cannot shadow a non-integer variable",
+					))
+				}
+				declare(t, "_for_saved", typ)
+			} else {
+				for i := range b.params {
+					if b.params[i].Name == string(t.Var) {
+						panic(unimplemented.New(
+							"FOR loop variable shadowing",
+							"the variable of an integer FOR loop cannot shadow a parameter",
+						))
+					}
+				}
+				b.decls = append(b.decls, ast.Declaration{Var: t.Var, Typ: types.Int})
+				b.varTypes[t.Var] = types.Int
+			}
+		case *ast.ForEachArray:
+			if t.Slice != 0 {
+				panic(unimplemented.New(
+					"FOREACH SLICE",
+					"the SLICE option for FOREACH loops is not yet supported",
+				))
+			}
+			typ, ok := b.varTypes[t.Var]
+			if !ok {
+				panic(pgerror.Newf(pgcode.Syntax, "\"%s\" is not a known variable", t.Var))
+			}
+			declare(t, "_foreach_array", types.MakeArray(typ))
+			declare(t, "_foreach_index", types.Int)
+		case *ast.ForSelect, *ast.ForDynamic:
+			declare(t, "_for_cursor", types.RefCursor)
+		}
+	}}
+	for _, stmt := range block.Body {
+		ast.Walk(&v, stmt)
+	}
+	for i := range block.Exceptions {
+		ast.Walk(&v, &block.Exceptions[i])
+	}
+}
+
+// loopVisitor calls a function for each statement in a PL/pgSQL statement
+// walk.
+type loopVisitor struct {
+	fn func(stmt ast.Statement)
+}
+
+var _ ast.StatementVisitor = &loopVisitor{}
+
+// Visit implements the ast.StatementVisitor interface.
+func (v *loopVisitor) Visit(stmt ast.Statement) {
+	v.fn(stmt)
 }
 
 // build constructs an expression that returns the result of executing a
 // PL/pgSQL function. See buildPLpgSQLStatements for more details.
 func (b *plpgsqlBuilder) build(block *ast.Block, s *scope) *scope {
+	// The fields of composite-typed variables can be referenced as var.field.
+	defer func(old bool) { b.ob.resolveRecordFields = old }(b.ob.resolveRecordFields)
+	b.ob.resolveRecordFields = true
+
 	s = s.push()
 	b.ensureScopeHasExpr(s)
 
+	for i, param := range b.params {
+		if param.Name != "" && b.isOutOnlyParam(i) {
+			// OUT parameters are initially null, regardless of the value passed
+			// for them.
+			s = b.addPLpgSQLAssign(
+				s, tree.Name(param.Name), &tree.CastExpr{Expr: tree.DNull, Type: param.Typ},
+			)
+		}
+	}
+	for _, ord := range b.paramAliases {
+		// Project the aliased parameter with the name given by the alias, so
+		// that it can be referenced and passed to continuations by that name.
+		aliasScope := s.push()
+		aliasScope.appendColumnsFromScope(s)
+		param := b.params[ord]
+		paramCol := s.findFuncArgCol(tree.PlaceholderIdx(b.argOrd(ord)))
+		b.ob.synthesizeColumn(
+			aliasScope, scopeColName(tree.Name(param.Name)), param.Typ, nil, /* expr */
+			b.ob.factory.ConstructVariable(paramCol.id),
+		)
+		b.ob.constructProjectForScope(s, aliasScope)
+		s = aliasScope
+	}
 	b.constants = make(map[tree.Name]struct{})
 	for _, dec := range b.decls {
 		if dec.Expr != nil {
@@ -245,7 +433,7 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 		case *ast.Return:
 			// RETURN is handled by projecting a single column with the expression
 			// that is being returned.
-			returnScalar := b.buildPLpgSQLExpr(t.Expr, b.returnType, s)
+			returnScalar := b.buildReturnExpr(t, s)
 			returnColName := scopeColName("").WithMetadataName(b.makeIdentifier("stmt_return"))
 			returnScope := s.push()
 			b.ensureScopeHasExpr(returnScope)
@@ -255,8 +443,13 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 
 		case *ast.Assignment:
 			// Assignment (:=) is handled by projecting a new column with the same
-			// name as the variable being assigned.
-			s = b.addPLpgSQLAssign(s, t.Var, t.Value)
+			// name as the variable being assigned. Assignment to a field of a
+			// composite-typed variable assigns a new value to the whole variable.
+			val := t.Value
+			if t.Field != "" {
+				val = b.makeFieldAssignExpr(t.Var, t.Field, t.Value)
+			}
+			s = b.addPLpgSQLAssign(s, t.Var, val)
 			if b.hasExceptionBlock {
 				// If exception handling is required, we have to start a new
 				// continuation after each variable assignment. This ensures that in the
@@ -526,9 +719,6 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 			// This is handled by calling the plpgsql_open_cursor internal builtin
 			// function in a separate body statement that returns no results, similar
 			// to the RAISE implementation.
-			if t.Scroll == tree.Scroll {
-				panic(unimplemented.NewWithIssue(77102, "DECLARE SCROLL CURSOR"))
-			}
 			openCon := b.makeContinuation("_stmt_open")
 			openCon.def.Volatility = volatility.Volatile
 			_, source, _, err := openCon.s.FindSourceProvidingColumn(b.ob.ctx, t.CurVar)
@@ -543,24 +733,44 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 					"variable \"%s\" must be of type cursor or refcursor", t.CurVar,
 				))
 			}
-			// Initialize the routine with the information needed to pipe the first
-			// body statement into a cursor.
-			query := b.resolveOpenQuery(t)
-			fmtCtx := b.ob.evalCtx.FmtCtx(tree.FmtSimple)
-			fmtCtx.FormatNode(query)
-			openCon.def.CursorDeclaration = &tree.RoutineOpenCursor{
-				NameArgIdx: source.(*scopeColumn).getParamOrd(),
-				Scroll:     t.Scroll,
-				CursorSQL:  fmtCtx.CloseAndGetString(),
-			}
-			openScope := b.ob.buildStmtAtRootWithScope(query, nil /* desiredTypes */, openCon.s)
-			if openScope.expr.Relational().CanMutate {
-				// Cursors with mutations are invalid.
-				panic(pgerror.Newf(pgcode.FeatureNotSupported,
-					"DECLARE CURSOR must not contain data-modifying statements in WITH",
-				))
+			if t.DynamicQuery != nil {
+				// The query for an OPEN ... FOR EXECUTE statement is only known at
+				// execution time, so the cursor is opened by the
+				// crdb_internal.plpgsql_open_dynamic_cursor builtin function.
+				if _, ok := b.cursors[t.CurVar]; ok {
+					panic(errors.WithHintf(
+						pgerror.New(pgcode.Syntax, "syntax error at or near \"FOR\""),
+						"cannot specify a query during OPEN for bound cursor \"%s\"", t.CurVar,
+					))
+				}
+				query, params := b.buildDynamicQueryArgs(openCon.s, t.DynamicQuery, t.Params)
+				openCall := b.makeBuiltinCall(openDynamicCursorFnName, types.Int,
+					b.ob.factory.ConstructVariable(source.(*scopeColumn).id),
+					b.ob.factory.ConstructConstVal(tree.MakeDBool(t.Scroll == tree.Scroll), types.Bool),
+					query,
+					params,
+				)
+				b.appendBodyStmt(&openCon, b.projectScalar(openCon.s, "stmt_open", types.Int, openCall))
+			} else {
+				// Initialize the routine with the information needed to pipe the first
+				// body statement into a cursor.
+				query := b.resolveOpenQuery(t)
+				fmtCtx := b.ob.evalCtx.FmtCtx(tree.FmtSimple)
+				fmtCtx.FormatNode(query)
+				openCon.def.CursorDeclaration = &tree.RoutineOpenCursor{
+					NameArgIdx: source.(*scopeColumn).getParamOrd(),
+					Scroll:     t.Scroll,
+					CursorSQL:  fmtCtx.CloseAndGetString(),
+				}
+				openScope := b.ob.buildStmtAtRootWithScope(query, nil /* desiredTypes */, openCon.s)
+				if openScope.expr.Relational().CanMutate {
+					// Cursors with mutations are invalid.
+					panic(pgerror.Newf(pgcode.FeatureNotSupported,
+						"DECLARE CURSOR must not contain data-modifying statements in WITH",
+					))
+				}
+				b.appendBodyStmt(&openCon, openScope)
 			}
-			b.appendBodyStmt(&openCon, openScope)
 			b.appendPlpgSQLStmts(&openCon, stmts[i+1:])
 
 			// Build a statement to generate a unique name for the cursor if one
@@ -645,18 +855,7 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 			// elements returned is equal to the length of the target list
 			// (padded with NULLs), so we can assume each target variable has a
 			// corresponding element.
-			fetchCol := fetchScope.cols[0].id
-			intoScope := fetchScope.push()
-			for j := range t.Target {
-				typ := b.resolveVariableForAssign(t.Target[j])
-				colName := scopeColName(t.Target[j])
-				scalar := b.ob.factory.ConstructColumnAccess(
-					b.ob.factory.ConstructVariable(fetchCol),
-					memo.TupleOrdinal(j),
-				)
-				b.ob.synthesizeColumn(intoScope, colName, typ, nil /* expr */, scalar)
-			}
-			b.ob.constructProjectForScope(fetchScope, intoScope)
+			intoScope := b.projectTargets(fetchScope, fetchScope.cols[0].id, t.Target)
 
 			// Call a continuation for the remaining PLpgSQL statements from the newly
 			// built statement that has updated variables. Then, call the fetch
@@ -667,6 +866,249 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 			b.appendBodyStmt(&fetchCon, intoScope)
 			return b.callContinuation(&fetchCon, s)
 
+		case *ast.ForInt:
+			// An integer FOR loop is handled by rewriting it into a LOOP that
+			// steps a hidden counter variable:
+			//
+			//   FOR i IN [lower]..[upper] BY [step] LOOP
+			//     [body];
+			//   END LOOP;
+			//   =>
+			//   _for_counter := [lower];
+			//   _for_upper := [upper];
+			//   _for_step := [step];
+			//   [raise an error if a bound is NULL or the step is not positive]
+			//   _for_counter := _for_counter - _for_step;
+			//   LOOP
+			//     _for_counter := _for_counter + _for_step;
+			//     IF _for_counter > _for_upper THEN
+			//       EXIT;
+			//     END IF;
+			//     i := _for_counter;
+			//     [body];
+			//   END LOOP;
+			//
+			// A REVERSE loop decrements the counter instead, and exits once the
+			// counter is less than the upper bound. The bounds and the step are
+			// evaluated only once, before entering the loop. Assignments to the loop
+			// variable within the body do not affect the iteration.
+			newStmts := b.rewriteForIntLoop(t)
+			newStmts = append(newStmts, stmts[i+1:]...)
+			return b.buildPLpgSQLStatements(newStmts, s)
+
+		case *ast.ForEachArray:
+			// A FOREACH loop is handled by rewriting it into a LOOP that iterates
+			// over the indexes of the array:
+			//
+			//   FOREACH x IN ARRAY [expr] LOOP
+			//     [body];
+			//   END LOOP;
+			//   =>
+			//   _foreach_array := [expr];
+			//   [raise an error if the array is NULL]
+			//   _foreach_index := 0;
+			//   LOOP
+			//     _foreach_index := _foreach_index + 1;
+			//     IF _foreach_index > cardinality(_foreach_array) THEN
+			//       EXIT;
+			//     END IF;
+			//     x := _foreach_array[_foreach_index];
+			//     [body];
+			//   END LOOP;
+			//
+			newStmts := b.rewriteForEachLoop(t)
+			newStmts = append(newStmts, stmts[i+1:]...)
+			return b.buildPLpgSQLStatements(newStmts, s)
+
+		case *ast.ForSelect, *ast.ForCursor, *ast.ForDynamic:
+			// A FOR loop over the rows of a query is handled by opening a cursor for
+			// the query, and then fetching from the cursor on each iteration. See
+			// the forQueryLoop case for details.
+			newStmts := b.rewriteForQueryLoop(t)
+			newStmts = append(newStmts, stmts[i+1:]...)
+			return b.buildPLpgSQLStatements(newStmts, s)
+
+		case *forQueryLoop:
+			// The rows of a FOR query loop are retrieved from a cursor, which has
+			// already been opened. The loop is modeled with a recursive continuation
+			// that fetches the next row from the cursor. If there is a row, it is
+			// assigned to the target variables, and the continuation for the loop
+			// body is called. Otherwise, the exit continuation is called, which
+			// closes the cursor and executes the statements following the loop.
+			// Since the body continuation calls the fetch continuation upon reaching
+			// the end of the body, CONTINUE and EXIT behave just as they do for LOOP
+			// statements.
+			exitCon := b.makeContinuation("loop_exit")
+			exitStmts := make([]ast.Statement, 0, len(stmts)-i)
+			exitStmts = append(exitStmts, &ast.Close{CurVar: t.CurVar})
+			exitStmts = append(exitStmts, stmts[i+1:]...)
+			b.appendPlpgSQLStmts(&exitCon, exitStmts)
+			b.pushExitContinuation(exitCon)
+			loopCon := b.makeRecursiveContinuation("stmt_for")
+			loopCon.def.Volatility = volatility.Volatile
+			b.pushContinuation(loopCon)
+			bodyCon := b.makeContinuation("_stmt_for_body")
+			b.appendPlpgSQLStmts(&bodyCon, t.Body)
+
+			// Fetch the next row. The result of the fetch is NULL if there are no
+			// rows left.
+			fetchScope := b.buildFetch(loopCon.s, &ast.Fetch{
+				Target: t.Target,
+				Cursor: tree.CursorStmt{Name: t.CurVar, FetchType: tree.FetchNormal, Count: 1},
+			})
+			fetchCol := fetchScope.cols[0].id
+			thenScope := fetchScope.push()
+			b.ensureScopeHasExpr(thenScope)
+			thenScope = b.callContinuation(&bodyCon, b.projectTargets(thenScope, fetchCol, t.Target))
+			elseScope := fetchScope.push()
+			b.ensureScopeHasExpr(elseScope)
+			elseScope = b.callContinuation(&exitCon, elseScope)
+			cond := b.ob.factory.ConstructIsNot(
+				b.ob.factory.ConstructVariable(fetchCol), memo.NullSingleton,
+			)
+			scalar := b.ob.factory.ConstructCase(
+				memo.TrueSingleton,
+				memo.ScalarListExpr{b.ob.factory.ConstructWhen(
+					cond, b.ob.factory.ConstructSubquery(thenScope.expr, &memo.SubqueryPrivate{}),
+				)},
+				b.ob.factory.ConstructSubquery(elseScope.expr, &memo.SubqueryPrivate{}),
+			)
+			b.appendBodyStmt(&loopCon, b.projectScalar(fetchScope, "stmt_for", b.returnType, scalar))
+			b.popContinuation()
+			b.popExitContinuation()
+			return b.callContinuation(&loopCon, s)
+
+		case *ast.Perform:
+			// PERFORM executes a query and discards its results. Similar to an
+			// EXECUTE statement with no INTO target, the query is built into a body
+			// statement that is only executed for its side effects.
+			performCon := b.makeContinuation("_stmt_perform")
+			performCon.def.Volatility = volatility.Volatile
+			performScope := b.ob.buildStmtAtRootWithScope(t.Query, nil /* desiredTypes */, performCon.s)
+			b.appendBodyStmt(&performCon, performScope)
+			b.appendPlpgSQLStmts(&performCon, stmts[i+1:])
+			return b.callContinuation(&performCon, s)
+
+		case *ast.DynamicExecute:
+			// A dynamic EXECUTE statement executes a query string that is only known
+			// at execution time. This is handled by the crdb_internal.plpgsql_execute
+			// builtin function, which returns the first row of the result as a tuple
+			// (or NULL if there are no rows). If there is an INTO target, the target
+			// variables are assigned from the tuple in the same way as for a FETCH
+			// statement.
+			execCon := b.makeContinuation("_stmt_exec")
+			execCon.def.Volatility = volatility.Volatile
+			execScope := b.buildDynamicExecute(execCon.s, t)
+			if t.Target == nil {
+				b.appendBodyStmt(&execCon, execScope)
+				b.appendPlpgSQLStmts(&execCon, stmts[i+1:])
+				return b.callContinuation(&execCon, s)
+			}
+			intoScope := b.projectTargets(execScope, execScope.cols[0].id, t.Target)
+			retCon := b.makeContinuation("_stmt_exec_ret")
+			b.appendPlpgSQLStmts(&retCon, stmts[i+1:])
+			intoScope = b.callContinuation(&retCon, intoScope)
+			b.appendBodyStmt(&execCon, intoScope)
+			return b.callContinuation(&execCon, s)
+
+		case *ast.ReturnNext:
+			// RETURN NEXT adds a row to the result of a set-returning function, after
+			// which execution continues with the next statement. It is handled by a
+			// continuation routine with the ReturnNext flag set, which indicates that
+			// the result of its first body statement is added to the rows returned
+			// by the function. The remaining statements are built into the last body
+			// statement.
+			if !b.setReturning {
+				panic(pgerror.New(pgcode.DatatypeMismatch,
+					"cannot use RETURN NEXT in a non-SETOF function",
+				))
+			}
+			if b.hasOutParams() {
+				// A function with OUT parameters returns the current values of its
+				// OUT parameters as the next row.
+				if t.Expr != nil {
+					panic(pgerror.New(pgcode.DatatypeMismatch,
+						"RETURN NEXT cannot have a parameter in function with OUT parameters",
+					))
+				}
+			} else if t.Expr == nil {
+				panic(pgerror.New(pgcode.Syntax, "RETURN NEXT must have a parameter"))
+			}
+			nextCon := b.makeContinuation("_stmt_return_next")
+			nextCon.def.ReturnNext = true
+			nextCon.def.Volatility = volatility.Volatile
+			var val opt.ScalarExpr
+			if t.Expr == nil {
+				val = b.buildOutParamsExpr(nextCon.s)
+			} else {
+				val = b.addReturnCast(b.buildPLpgSQLExpr(t.Expr, b.returnType, nextCon.s))
+			}
+			b.appendBodyStmt(&nextCon, b.projectScalar(nextCon.s, "stmt_return_next", b.returnType, val))
+			b.appendPlpgSQLStmts(&nextCon, stmts[i+1:])
+			return b.callContinuation(&nextCon, s)
+
+		case *ast.ReturnQuery:
+			// RETURN QUERY adds the rows of a query to the result of a set-returning
+			// function. A static query is handled like RETURN NEXT, except that the
+			// first body statement of the continuation returns all the rows of the
+			// query. The rows of a dynamic query are added to the result by the
+			// crdb_internal.plpgsql_return_query_execute builtin function.
+			if !b.setReturning {
+				panic(pgerror.New(pgcode.DatatypeMismatch,
+					"cannot use RETURN QUERY in a non-SETOF function",
+				))
+			}
+			queryCon := b.makeContinuation("_stmt_return_query")
+			queryCon.def.Volatility = volatility.Volatile
+			if t.DynamicQuery != nil {
+				query, params := b.buildDynamicQueryArgs(queryCon.s, t.DynamicQuery, t.Params)
+				resultType := types.MakeTuple([]*types.T{b.returnType})
+				queryCall := b.makeBuiltinCall(returnQueryExecuteFnName, types.Int,
+					query,
+					params,
+					b.ob.factory.ConstructTuple(
+						memo.ScalarListExpr{b.ob.factory.ConstructConstVal(tree.DNull, b.returnType)},
+						resultType,
+					),
+				)
+				b.appendBodyStmt(&queryCon, b.projectScalar(queryCon.s, "stmt_return_query", types.Int, queryCall))
+			} else {
+				queryCon.def.ReturnNext = true
+				b.appendBodyStmt(&queryCon, b.buildReturnQuery(queryCon.s, t.Query))
+			}
+			b.appendPlpgSQLStmts(&queryCon, stmts[i+1:])
+			return b.callContinuation(&queryCon, s)
+
+		case *ast.Commit, *ast.Rollback:
+			// COMMIT and ROLLBACK statements end the current transaction and start
+			// a new one. They are handled by building the statements that follow
+			// them into a continuation routine that is marked with the transaction
+			// operation. Instead of being evaluated, the continuation records the
+			// operation; the connExecutor then ends the transaction and evaluates
+			// the continuation in a new transaction. Since the continuation is in
+			// tail-call position, this resumes execution of the procedure. Whether
+			// the transaction can be ended is checked at execution time.
+			var name string
+			var txnOp tree.StoredProcTxnOp
+			var chain bool
+			switch t := t.(type) {
+			case *ast.Commit:
+				name, txnOp, chain = "_stmt_commit", tree.StoredProcTxnCommit, t.Chain
+			case *ast.Rollback:
+				name, txnOp, chain = "_stmt_rollback", tree.StoredProcTxnRollback, t.Chain
+			}
+			if chain {
+				panic(unimplemented.New(
+					"COMMIT AND CHAIN",
+					"AND CHAIN is not yet supported for PL/pgSQL transaction control",
+				))
+			}
+			txnCon := b.makeContinuation(name)
+			txnCon.def.TxnOp = txnOp
+			txnCon.def.Volatility = volatility.Volatile
+			b.appendPlpgSQLStmts(&txnCon, stmts[i+1:])
+			return b.callContinuation(&txnCon, s)
+
 		default:
 			panic(unimplemented.New(
 				"unimplemented PL/pgSQL statement",
@@ -678,6 +1120,522 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 	return b.callContinuation(b.getContinuation(), s)
 }
 
+// buildReturnExpr builds the expression returned by the given RETURN
+// statement. A procedure or a function with OUT parameters returns the values
+// of its OUT and INOUT parameters.
+func (b *plpgsqlBuilder) buildReturnExpr(ret *ast.Return, s *scope) opt.ScalarExpr {
+	if b.isProcedure {
+		if ret.Expr != nil {
+			panic(pgerror.New(pgcode.DatatypeMismatch,
+				"RETURN cannot have a parameter in a procedure",
+			))
+		}
+		if b.returnType.Family() != types.TupleFamily {
+			return b.ob.factory.ConstructNull(b.returnType)
+		}
+		return b.buildOutParamsExpr(s)
+	}
+	if b.hasOutParams() {
+		if ret.Expr != nil {
+			panic(pgerror.New(pgcode.DatatypeMismatch,
+				"RETURN cannot have a parameter in function with OUT parameters",
+			))
+		}
+		if b.setReturning {
+			// The rows of a set-returning function were already added by RETURN
+			// NEXT statements.
+			return b.ob.factory.ConstructNull(b.returnType)
+		}
+		return b.buildOutParamsExpr(s)
+	}
+	if b.setReturning {
+		// The rows of a set-returning function are added by RETURN NEXT and
+		// RETURN QUERY statements, so RETURN only ends execution. The returned
+		// value is ignored.
+		if ret.Expr != nil {
+			panic(errors.WithHint(
+				pgerror.New(pgcode.DatatypeMismatch, "RETURN cannot have a parameter in function returning set"),
+				"Use RETURN NEXT or RETURN QUERY.",
+			))
+		}
+		return b.ob.factory.ConstructNull(b.returnType)
+	}
+	if ret.Expr == nil {
+		if b.returnType.Family() == types.VoidFamily {
+			return b.ob.factory.ConstructNull(b.returnType)
+		}
+		panic(pgerror.New(pgcode.Syntax, "missing expression at or near \";\""))
+	}
+	return b.buildPLpgSQLExpr(ret.Expr, b.returnType, s)
+}
+
+// buildOutParamsExpr builds an expression for the current values of the OUT
+// and INOUT parameters. It is a tuple, unless the routine is a function with a
+// single OUT parameter, in which case it is the value of that parameter.
+func (b *plpgsqlBuilder) buildOutParamsExpr(s *scope) opt.ScalarExpr {
+	var elems memo.ScalarListExpr
+	for i, param := range b.params {
+		if !b.isOutParam(i) {
+			continue
+		}
+		if param.Name == "" {
+			// Unnamed OUT parameters cannot be assigned, so they are always null.
+			elems = append(elems, b.ob.factory.ConstructConstVal(tree.DNull, param.Typ))
+			continue
+		}
+		_, source, _, err := s.FindSourceProvidingColumn(b.ob.ctx, tree.Name(param.Name))
+		if err != nil {
+			panic(err)
+		}
+		elems = append(elems, b.ob.factory.ConstructVariable(source.(*scopeColumn).id))
+	}
+	if !b.isProcedure && len(elems) == 1 {
+		return elems[0]
+	}
+	return b.ob.factory.ConstructTuple(elems, b.returnType)
+}
+
+// isOutParam returns true if the parameter with the given ordinal is an OUT,
+// INOUT or TABLE parameter.
+func (b *plpgsqlBuilder) isOutParam(ord int) bool {
+	if b.paramClasses == nil {
+		return false
+	}
+	return b.paramClasses[ord].IsOutput()
+}
+
+// isOutOnlyParam returns true if the parameter with the given ordinal is an
+// OUT or TABLE parameter, which is initially null.
+func (b *plpgsqlBuilder) isOutOnlyParam(ord int) bool {
+	return b.isOutParam(ord) && b.paramClasses[ord] != tree.RoutineParamInOut
+}
+
+// hasOutParams returns true if the routine is a function with OUT, INOUT or
+// TABLE parameters.
+func (b *plpgsqlBuilder) hasOutParams() bool {
+	if b.isProcedure {
+		return false
+	}
+	for i := range b.params {
+		if b.isOutParam(i) {
+			return true
+		}
+	}
+	return false
+}
+
+// argOrd returns the ordinal of the routine argument for the parameter with
+// the given ordinal. The OUT parameters of a function are not arguments.
+func (b *plpgsqlBuilder) argOrd(ord int) int {
+	if b.isProcedure || b.paramClasses == nil {
+		return ord
+	}
+	argOrd := 0
+	for i := 0; i < ord; i++ {
+		if b.paramClasses[i].IsInput(false /* isProcedure */) {
+			argOrd++
+		}
+	}
+	return argOrd
+}
+
+// rewriteForIntLoop rewrites an integer FOR loop into a LOOP statement, preceded
+// by statements that initialize the hidden loop variables. See the ForInt case
+// in buildPLpgSQLStatements for details.
+func (b *plpgsqlBuilder) rewriteForIntLoop(loop *ast.ForInt) []ast.Statement {
+	vars := b.loopVars[loop]
+	counter, upper, step := vars[0], vars[1], vars[2]
+	ref := func(name tree.Name) tree.Expr {
+		return tree.NewUnresolvedName(string(name))
+	}
+	toInt := func(expr tree.Expr) tree.Expr {
+		return &tree.CastExpr{Expr: expr, Type: types.Int, SyntaxMode: tree.CastShort}
+	}
+	raise := func(msg string, code pgcode.Code) []ast.Statement {
+		return []ast.Statement{&ast.Raise{LogLevel: "EXCEPTION", Message: msg, Code: code.String()}}
+	}
+	next, prev, past := treebin.Plus, treebin.Minus, treecmp.GT
+	if loop.Reverse {
+		next, prev, past = treebin.Minus, treebin.Plus, treecmp.LT
+	}
+	stepExpr := loop.Step
+	if stepExpr == nil {
+		stepExpr = tree.NewDInt(1)
+	}
+	stmts := []ast.Statement{
+		&ast.Assignment{Var: counter, Value: toInt(loop.Lower)},
+		&ast.Assignment{Var: upper, Value: toInt(loop.Upper)},
+		&ast.Assignment{Var: step, Value: toInt(stepExpr)},
+		&ast.If{
+			Condition: &tree.IsNullExpr{Expr: ref(counter)},
+			ThenBody:  raise("lower bound of FOR loop cannot be null", pgcode.NullValueNotAllowed),
+			ElseIfList: []ast.ElseIf{
+				{
+					Condition: &tree.IsNullExpr{Expr: ref(upper)},
+					Stmts:     raise("upper bound of FOR loop cannot be null", pgcode.NullValueNotAllowed),
+				},
+				{
+					Condition: &tree.IsNullExpr{Expr: ref(step)},
+					Stmts:     raise("BY value of FOR loop cannot be null", pgcode.NullValueNotAllowed),
+				},
+				{
+					Condition: &tree.ComparisonExpr{
+						Operator: treecmp.MakeComparisonOperator(treecmp.LE),
+						Left:     ref(step),
+						Right:    tree.NewDInt(0),
+					},
+					Stmts: raise("BY value of FOR loop must be greater than zero", pgcode.InvalidParameterValue),
+				},
+			},
+		},
+	}
+	var saved tree.Name
+	if len(vars) > 3 {
+		// The loop variable shadows an existing variable, which is restored after
+		// the loop.
+		saved = vars[3]
+		stmts = append(stmts, &ast.Assignment{Var: saved, Value: ref(loop.Var)})
+	}
+	stmts = append(stmts,
+		&ast.Assignment{Var: counter, Value: &tree.BinaryExpr{
+			Operator: treebin.MakeBinaryOperator(prev), Left: ref(counter), Right: ref(step),
+		}},
+	)
+	body := make([]ast.Statement, 0, len(loop.Body)+3)
+	body = append(body,
+		&ast.Assignment{Var: counter, Value: &tree.BinaryExpr{
+			Operator: treebin.MakeBinaryOperator(next), Left: ref(counter), Right: ref(step),
+		}},
+		&ast.If{
+			Condition: &tree.ComparisonExpr{
+				Operator: treecmp.MakeComparisonOperator(past), Left: ref(counter), Right: ref(upper),
+			},
+			ThenBody: []ast.Statement{&ast.Exit{}},
+		},
+		&ast.Assignment{Var: loop.Var, Value: ref(counter)},
+	)
+	body = append(body, loop.Body...)
+	stmts = append(stmts, &ast.Loop{Label: loop.Label, Body: body})
+	if saved != "" {
+		stmts = append(stmts, &ast.Assignment{Var: loop.Var, Value: ref(saved)})
+	}
+	return stmts
+}
+
+// rewriteForEachLoop rewrites a FOREACH loop into a LOOP statement, preceded by
+// statements that initialize the hidden loop variables. See the ForEachArray
+// case in buildPLpgSQLStatements for details.
+func (b *plpgsqlBuilder) rewriteForEachLoop(loop *ast.ForEachArray) []ast.Statement {
+	vars := b.loopVars[loop]
+	arr, idx := vars[0], vars[1]
+	ref := func(name tree.Name) tree.Expr {
+		return tree.NewUnresolvedName(string(name))
+	}
+	body := make([]ast.Statement, 0, len(loop.Body)+3)
+	body = append(body,
+		&ast.Assignment{Var: idx, Value: &tree.BinaryExpr{
+			Operator: treebin.MakeBinaryOperator(treebin.Plus), Left: ref(idx), Right: tree.NewDInt(1),
+		}},
+		&ast.If{
+			Condition: &tree.ComparisonExpr{
+				Operator: treecmp.MakeComparisonOperator(treecmp.GT),
+				Left:     ref(idx),
+				Right: &tree.FuncExpr{
+					Func:  tree.WrapFunction("cardinality"),
+					Exprs: tree.Exprs{ref(arr)},
+				},
+			},
+			ThenBody: []ast.Statement{&ast.Exit{}},
+		},
+		&ast.Assignment{Var: loop.Var, Value: &tree.IndirectionExpr{
+			Expr:        ref(arr),
+			Indirection: tree.ArraySubscripts{&tree.ArraySubscript{Begin: ref(idx)}},
+		}},
+	)
+	body = append(body, loop.Body...)
+	return []ast.Statement{
+		&ast.Assignment{Var: arr, Value: &tree.CastExpr{
+			Expr: loop.Expr, Type: b.varTypes[arr], SyntaxMode: tree.CastShort,
+		}},
+		&ast.If{
+			Condition: &tree.IsNullExpr{Expr: ref(arr)},
+			ThenBody: []ast.Statement{&ast.Raise{
+				LogLevel: "EXCEPTION",
+				Message:  "FOREACH expression must not be null",
+				Code:     pgcode.NullValueNotAllowed.String(),
+			}},
+		},
+		&ast.Assignment{Var: idx, Value: tree.NewDInt(0)},
+		&ast.Loop{Label: loop.Label, Body: body},
+	}
+}
+
+// rewriteForQueryLoop rewrites a FOR loop over the rows of a query into an OPEN
+// statement for the cursor that returns the rows, followed by a forQueryLoop
+// statement that iterates over the cursor. A FOR loop over a bound cursor
+// opens the cursor itself; otherwise, a hidden cursor variable is used.
+func (b *plpgsqlBuilder) rewriteForQueryLoop(stmt ast.Statement) []ast.Statement {
+	var forQuery *ast.ForQuery
+	var open *ast.Open
+	switch t := stmt.(type) {
+	case *ast.ForSelect:
+		forQuery = &t.ForQuery
+		open = &ast.Open{CurVar: b.loopVars[t][0], Query: t.Query}
+	case *ast.ForDynamic:
+		forQuery = &t.ForQuery
+		open = &ast.Open{CurVar: b.loopVars[t][0], DynamicQuery: t.Query, Params: t.Params}
+	case *ast.ForCursor:
+		forQuery = &t.ForQuery
+		open = &ast.Open{CurVar: t.CurVar}
+	default:
+		panic(errors.AssertionFailedf("unexpected FOR loop statement %T", t))
+	}
+	if forQuery.Label != "" {
+		panic(unimplemented.New(
+			"LOOP label",
+			"LOOP statement labels are not yet supported",
+		))
+	}
+	stmts := make([]ast.Statement, 0, 3)
+	if _, ok := stmt.(*ast.ForCursor); !ok {
+		// Reset the hidden cursor variable, so that a new name is generated for
+		// the cursor each time the loop is entered.
+		stmts = append(stmts, &ast.Assignment{
+			Var:   open.CurVar,
+			Value: &tree.CastExpr{Expr: tree.DNull, Type: types.RefCursor},
+		})
+	}
+	return append(stmts, open, &forQueryLoop{
+		CurVar: open.CurVar,
+		Target: forQuery.Target,
+		Body:   forQuery.Body,
+	})
+}
+
+// forQueryLoop is a statement that is only used internally by the
+// plpgsqlBuilder. It iterates over the rows of an open cursor, assigning each
+// row to the target variables and then executing the loop body. It is produced
+// by rewriteForQueryLoop.
+type forQueryLoop struct {
+	ast.StatementImpl
+	CurVar ast.Variable
+	Target []ast.Variable
+	Body   []ast.Statement
+}
+
+var _ ast.Statement = &forQueryLoop{}
+
+// Format implements the tree.NodeFormatter interface.
+func (s *forQueryLoop) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString("FOR ")
+	for i := range s.Target {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		s.Target[i].Format(ctx)
+	}
+	ctx.WriteString(" IN ")
+	s.CurVar.Format(ctx)
+	ctx.WriteString(" LOOP\n")
+	for _, stmt := range s.Body {
+		stmt.Format(ctx)
+	}
+	ctx.WriteString("END LOOP;\n")
+}
+
+// WalkStmt implements the ast.Statement interface.
+func (s *forQueryLoop) WalkStmt(visitor ast.StatementVisitor) {
+	visitor.Visit(s)
+	for _, stmt := range s.Body {
+		stmt.WalkStmt(visitor)
+	}
+}
+
+const (
+	executeFnName            = "crdb_internal.plpgsql_execute"
+	openDynamicCursorFnName  = "crdb_internal.plpgsql_open_dynamic_cursor"
+	returnQueryExecuteFnName = "crdb_internal.plpgsql_return_query_execute"
+)
+
+// buildDynamicExecute projects a call to the crdb_internal.plpgsql_execute
+// builtin function, which executes the query string of a dynamic EXECUTE
+// statement. The result is a tuple with an element for each target variable,
+// or NULL if the query returned no rows.
+func (b *plpgsqlBuilder) buildDynamicExecute(s *scope, execute *ast.DynamicExecute) *scope {
+	typs := make([]*types.T, len(execute.Target))
+	elems := make(memo.ScalarListExpr, len(execute.Target))
+	for i := range execute.Target {
+		for j := 0; j < i; j++ {
+			if execute.Target[i] == execute.Target[j] {
+				panic(unimplemented.New(
+					"duplicate INTO target",
+					"assigning to a variable more than once in the same INTO statement is not supported",
+				))
+			}
+		}
+		typs[i] = b.resolveVariableForAssign(execute.Target[i])
+		elems[i] = b.ob.factory.ConstructConstVal(tree.DNull, typs[i])
+	}
+	resultType := types.MakeTuple(typs)
+	query, params := b.buildDynamicQueryArgs(s, execute.Query, execute.Params)
+
+	// The arguments are:
+	//   1. The query string.
+	//   2. A tuple with the values of the query parameters.
+	//   3. Whether the query must return exactly one row (INTO STRICT).
+	//   4. The types of the columns to return (can be empty).
+	execCall := b.makeBuiltinCall(executeFnName, resultType,
+		query,
+		params,
+		b.ob.factory.ConstructConstVal(tree.MakeDBool(tree.DBool(execute.Strict)), types.Bool),
+		b.ob.factory.ConstructTuple(elems, resultType),
+	)
+	return b.projectScalar(s, "stmt_exec", resultType, execCall)
+}
+
+// buildDynamicQueryArgs builds the query string and the USING parameters of a
+// dynamic query. The parameters are combined into a single tuple.
+func (b *plpgsqlBuilder) buildDynamicQueryArgs(
+	s *scope, query ast.Expr, params []ast.Expr,
+) (queryArg, paramsArg opt.ScalarExpr) {
+	queryArg = b.buildPLpgSQLExpr(query, types.String, s)
+	elems := make(memo.ScalarListExpr, len(params))
+	typs := make([]*types.T, len(params))
+	for i := range params {
+		elems[i] = b.buildPLpgSQLExpr(params[i], types.Any, s)
+		typs[i] = elems[i].DataType()
+	}
+	return queryArg, b.ob.factory.ConstructTuple(elems, types.MakeTuple(typs))
+}
+
+// buildReturnQuery builds the query of a RETURN QUERY statement. The result has
+// a single column with the row type of the set-returning function, and
+// preserves the ordering of the query.
+func (b *plpgsqlBuilder) buildReturnQuery(inScope *scope, query tree.Statement) *scope {
+	queryScope := b.ob.buildStmtAtRootWithScope(query, nil /* desiredTypes */, inScope)
+	cols := queryScope.makePresentation()
+	isRecord := types.IsRecordType(b.returnType)
+	isTuple := b.returnType.Family() == types.TupleFamily
+	resultTypes := []*types.T{b.returnType}
+	if isTuple {
+		resultTypes = b.returnType.TupleContents()
+	}
+	mismatchErr := func(detail string) error {
+		return errors.WithDetail(pgerror.New(pgcode.DatatypeMismatch,
+			"structure of query does not match function result type",
+		), detail)
+	}
+	if !isRecord && len(cols) != len(resultTypes) {
+		panic(mismatchErr("Number of returned columns does not match expected column count."))
+	}
+	elems := make(memo.ScalarListExpr, len(cols))
+	typs := make([]*types.T, len(cols))
+	for i := range cols {
+		elem := opt.ScalarExpr(b.ob.factory.ConstructVariable(cols[i].ID))
+		typ := b.ob.factory.Metadata().ColumnMeta(cols[i].ID).Type
+		if !isRecord && !typ.Identical(resultTypes[i]) {
+			if !cast.ValidCast(typ, resultTypes[i], cast.ContextAssignment) {
+				panic(mismatchErr(fmt.Sprintf(
+					"Returned type %s does not match expected type %s in column %d.",
+					typ.SQLStringForError(), resultTypes[i].SQLStringForError(), i+1,
+				)))
+			}
+			elem = b.ob.factory.ConstructAssignmentCast(elem, resultTypes[i])
+			typ = resultTypes[i]
+		}
+		elems[i], typs[i] = elem, typ
+	}
+	var val opt.ScalarExpr
+	if isTuple {
+		typ := b.returnType
+		if isRecord {
+			typ = types.MakeTuple(typs)
+		}
+		val = b.ob.factory.ConstructTuple(elems, typ)
+	} else {
+		val = elems[0]
+	}
+	returnColName := scopeColName("").WithMetadataName(b.makeIdentifier("stmt_return_query"))
+	returnScope := queryScope.push()
+	b.ob.synthesizeColumn(returnScope, returnColName, b.returnType, nil /* expr */, val)
+	returnScope.copyOrdering(queryScope)
+	b.ob.constructProjectForScope(queryScope, returnScope)
+	return returnScope
+}
+
+// addReturnCast adds an assignment cast of the given value to the return type
+// of the function, if necessary.
+func (b *plpgsqlBuilder) addReturnCast(val opt.ScalarExpr) opt.ScalarExpr {
+	typ := val.DataType()
+	if typ.Identical(b.returnType) || types.IsRecordType(b.returnType) ||
+		typ.Family() == types.UnknownFamily {
+		return val
+	}
+	if typ.Family() == types.TupleFamily && typ.Equivalent(b.returnType) {
+		return val
+	}
+	if !cast.ValidCast(typ, b.returnType, cast.ContextAssignment) {
+		panic(pgerror.Newf(pgcode.DatatypeMismatch,
+			"returned value of type %s does not match function result type %s",
+			typ.SQLStringForError(), b.returnType.SQLStringForError(),
+		))
+	}
+	return b.ob.factory.ConstructAssignmentCast(val, b.returnType)
+}
+
+// projectTargets projects each of the given target variables from the
+// corresponding element of the tuple in the given column. If the tuple is NULL,
+// all the targets are assigned NULL.
+func (b *plpgsqlBuilder) projectTargets(
+	inScope *scope, tupleCol opt.ColumnID, targets []ast.Variable,
+) *scope {
+	intoScope := inScope.push()
+	for i := range targets {
+		typ := b.resolveVariableForAssign(targets[i])
+		colName := scopeColName(targets[i])
+		scalar := b.ob.factory.ConstructColumnAccess(
+			b.ob.factory.ConstructVariable(tupleCol),
+			memo.TupleOrdinal(i),
+		)
+		b.ob.synthesizeColumn(intoScope, colName, typ, nil /* expr */, scalar)
+	}
+	b.ob.constructProjectForScope(inScope, intoScope)
+	return intoScope
+}
+
+// projectScalar projects a single column with the given scalar expression.
+// The name is only used for the column's metadata.
+func (b *plpgsqlBuilder) projectScalar(
+	inScope *scope, name string, typ *types.T, scalar opt.ScalarExpr,
+) *scope {
+	colName := scopeColName("").WithMetadataName(b.makeIdentifier(name))
+	outScope := inScope.push()
+	b.ensureScopeHasExpr(outScope)
+	b.ob.synthesizeColumn(outScope, colName, typ, nil /* expr */, scalar)
+	b.ob.constructProjectForScope(inScope, outScope)
+	return outScope
+}
+
+// makeBuiltinCall builds a call to the builtin function with the given name,
+// which must have exactly one overload.
+func (b *plpgsqlBuilder) makeBuiltinCall(
+	name string, typ *types.T, args ...opt.ScalarExpr,
+) opt.ScalarExpr {
+	props, overloads := builtinsregistry.GetBuiltinProperties(name)
+	if len(overloads) != 1 {
+		panic(errors.AssertionFailedf("expected one overload for %s", name))
+	}
+	return b.ob.factory.ConstructFunction(
+		args,
+		&memo.FunctionPrivate{
+			Name:       name,
+			Typ:        typ,
+			Properties: props,
+			Overload:   &overloads[0],
+		},
+	)
+}
+
 // resolveOpenQuery finds and validates the query that is bound to cursor for
 // the given OPEN statement.
 func (b *plpgsqlBuilder) resolveOpenQuery(open *ast.Open) tree.Statement {
@@ -746,6 +1704,36 @@ func (b *plpgsqlBuilder) buildCursorNameGen(nameCon *continuation, nameVar ast.V
 // new column with the variable name that projects the assigned expression.
 // If there is a column with the same name in the previous scope, it will be
 // replaced. This allows the plpgsqlBuilder to model variable mutations.
+// makeFieldAssignExpr returns an expression for the value of the
+// composite-typed variable ident after its given field is assigned val. The
+// other fields retain their current values.
+func (b *plpgsqlBuilder) makeFieldAssignExpr(
+	ident ast.Variable, field tree.Name, val ast.Expr,
+) ast.Expr {
+	typ := b.resolveVariableForAssign(ident)
+	if typ.Family() != types.TupleFamily || len(typ.TupleLabels()) == 0 {
+		panic(pgerror.Newf(pgcode.Syntax, "\"%s.%s\" is not a known variable", ident, field))
+	}
+	labels := typ.TupleLabels()
+	tuple := &tree.Tuple{Exprs: make(tree.Exprs, len(labels)), Labels: labels}
+	found := false
+	for i, label := range labels {
+		if label == string(field) {
+			tuple.Exprs[i] = val
+			found = true
+			continue
+		}
+		tuple.Exprs[i] = &tree.ColumnAccessExpr{
+			Expr:    &tree.ParenExpr{Expr: tree.NewUnresolvedName(string(ident))},
+			ColName: tree.Name(label),
+		}
+	}
+	if !found {
+		panic(pgerror.Newf(pgcode.UndefinedColumn, "record \"%s\" has no field \"%s\"", ident, field))
+	}
+	return tuple
+}
+
 func (b *plpgsqlBuilder) addPLpgSQLAssign(inScope *scope, ident ast.Variable, val ast.Expr) *scope {
 	typ := b.resolveVariableForAssign(ident)
 	assignScope := inScope.push()
@@ -25,7 +25,6 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
-	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/errors"
 )
 
@@ -42,6 +41,7 @@ func (b *Builder) buildUDF(
 	colRefs *opt.ColSet,
 ) opt.ScalarExpr {
 	o := f.ResolvedOverload()
+	checkTriggerFunctionReturnType(o.FixedReturnType())
 	b.factory.Metadata().AddUserDefinedFunction(o, f.Func.ReferenceByName)
 
 	if o.Type == tree.ProcedureRoutine {
@@ -169,7 +169,7 @@ func (b *Builder) buildRoutine(
 	// be concrete in order to decode them correctly. We can determine the types
 	// from the result columns or tuple of the last statement.
 	finishResolveType := func(lastStmtScope *scope) *types.T {
-		if types.IsRecordType(rtyp) {
+		if types.IsWildcardTupleType(rtyp) {
 			if len(lastStmtScope.cols) == 1 &&
 				lastStmtScope.cols[0].typ.Family() == types.TupleFamily {
 				// When the final statement returns a single tuple, we can use
@@ -205,6 +205,15 @@ func (b *Builder) buildRoutine(
 			)
 		}
 	}
+	if v, ok := o.Types.(tree.VariadicType); ok {
+		// The trailing arguments of a variadic routine are passed to its
+		// variadic parameter as an array.
+		numFixed := len(v.FixedTypes)
+		varArgs := make(memo.ScalarListExpr, len(args)-numFixed)
+		copy(varArgs, args[numFixed:])
+		varArray := b.factory.ConstructArray(varArgs, types.MakeArray(v.VarType))
+		args = append(args[:numFixed:numFixed], varArray)
+	}
 
 	// Create a new scope for building the statements in the function body. We
 	// start with an empty scope because a statement in the function body cannot
@@ -216,12 +225,7 @@ func (b *Builder) buildRoutine(
 	// CTEs that mutate and are not at the top-level.
 	bodyScope := b.allocScope()
 	var params opt.ColList
-	if o.Types.Length() > 0 {
-		paramTypes, ok := o.Types.(tree.ParamTypes)
-		if !ok {
-			panic(unimplemented.NewWithIssue(88947,
-				"variadiac user-defined functions are not yet supported"))
-		}
+	if paramTypes := o.InputParamTypes(); len(paramTypes) > 0 {
 		params = make(opt.ColList, len(paramTypes))
 		for i := range paramTypes {
 			paramType := &paramTypes[i]
@@ -243,69 +247,101 @@ func (b *Builder) buildRoutine(
 	// Build an expression for each statement in the function body.
 	var body []memo.RelExpr
 	var bodyProps []*physical.Required
-	switch o.Language {
-	case tree.RoutineLangSQL:
-		// Parse the function body.
-		stmts, err := parser.Parse(o.Body)
-		if err != nil {
-			panic(err)
-		}
-		// Add a VALUES (NULL) statement if the return type of the function is
-		// VOID. We cannot simply project NULL from the last statement because
-		// all column would be pruned and the contents of last statement would
-		// not be executed.
-		// TODO(mgartner): This will add some planning overhead for every
-		// invocation of the function. Is there a more efficient way to do this?
-		if rtyp.Family() == types.VoidFamily {
-			stmts = append(stmts, statements.Statement[tree.Statement]{
-				AST: &tree.Select{
-					Select: &tree.ValuesClause{
-						Rows: []tree.Exprs{{tree.DNull}},
+	buildBody := func() error {
+		switch o.Language {
+		case tree.RoutineLangSQL:
+			// Parse the function body.
+			stmts, err := parser.Parse(o.Body)
+			if err != nil {
+				panic(err)
+			}
+			// Add a VALUES (NULL) statement if the return type of the function is
+			// VOID. We cannot simply project NULL from the last statement because
+			// all column would be pruned and the contents of last statement would
+			// not be executed.
+			// TODO(mgartner): This will add some planning overhead for every
+			// invocation of the function. Is there a more efficient way to do this?
+			if rtyp.Family() == types.VoidFamily {
+				stmts = append(stmts, statements.Statement[tree.Statement]{
+					AST: &tree.Select{
+						Select: &tree.ValuesClause{
+							Rows: []tree.Exprs{{tree.DNull}},
+						},
 					},
-				},
-			})
-		}
-		body = make([]memo.RelExpr, len(stmts))
-		bodyProps = make([]*physical.Required, len(stmts))
+				})
+			}
+			body = make([]memo.RelExpr, len(stmts))
+			bodyProps = make([]*physical.Required, len(stmts))
 
-		for i := range stmts {
-			stmtScope := b.buildStmtAtRootWithScope(stmts[i].AST, nil /* desiredTypes */, bodyScope)
-			expr, physProps := stmtScope.expr, stmtScope.makePhysicalProps()
+			for i := range stmts {
+				stmtScope := b.buildStmtAtRootWithScope(stmts[i].AST, nil /* desiredTypes */, bodyScope)
+				expr, physProps := stmtScope.expr, stmtScope.makePhysicalProps()
 
-			// The last statement produces the output of the UDF.
-			if i == len(stmts)-1 {
-				rtyp = finishResolveType(stmtScope)
-				expr, physProps, isMultiColDataSource =
-					b.finishBuildLastStmt(stmtScope, bodyScope, isSetReturning, f)
+				// The last statement produces the output of the UDF.
+				if i == len(stmts)-1 {
+					rtyp = finishResolveType(stmtScope)
+					expr, physProps, isMultiColDataSource =
+						b.finishBuildLastStmt(stmtScope, bodyScope, isSetReturning, f)
+				}
+				body[i] = expr
+				bodyProps[i] = physProps
 			}
-			body[i] = expr
-			bodyProps[i] = physProps
-		}
-	case tree.RoutineLangPLpgSQL:
-		// Parse the function body.
-		stmt, err := plpgsql.Parse(o.Body)
-		if err != nil {
-			panic(err)
-		}
-		// Add a RETURN NULL statement if the return type of the function is
-		// VOID and the last statement is not already a RETURN statement. This
-		// ensures that all possible code paths lead to a RETURN statement.
-		// TODO(#108298): There is a parsing bug that affects some PLpgSQL
-		// functions with VOID return types.
-		if rtyp.Family() == types.VoidFamily {
-			lastStmt := stmt.AST.Body[len(stmt.AST.Body)-1]
-			if _, ok := lastStmt.(*plpgsqltree.Return); !ok {
-				stmt.AST.Body = append(stmt.AST.Body, &plpgsqltree.Return{Expr: tree.DNull})
+		case tree.RoutineLangPLpgSQL:
+			// Parse the function body.
+			stmt, err := plpgsql.Parse(o.Body)
+			if err != nil {
+				panic(err)
+			}
+			// Add a RETURN statement if the return type of the function is VOID, the
+			// function is set-returning, or the routine is a procedure, and the last
+			// statement is not already a RETURN statement. This ensures that all
+			// possible code paths lead to a RETURN statement.
+			// TODO(#108298): There is a parsing bug that affects some PLpgSQL
+			// functions with VOID return types.
+			isProcedure := o.Type == tree.ProcedureRoutine
+			var plBuilder plpgsqlBuilder
+			params, paramClasses := o.InputParamTypes(), o.ParamClasses
+			if paramClasses != nil {
+				params = o.RoutineParams
+			}
+			plBuilder.init(
+				b, colRefs, params, paramClasses, stmt.AST, rtyp, isSetReturning, isProcedure,
+			)
+			if rtyp.Family() == types.VoidFamily || isSetReturning || isProcedure ||
+				plBuilder.hasOutParams() {
+				lastStmt := stmt.AST.Body[len(stmt.AST.Body)-1]
+				if _, ok := lastStmt.(*plpgsqltree.Return); !ok {
+					stmt.AST.Body = append(stmt.AST.Body, &plpgsqltree.Return{})
+				}
 			}
+			stmtScope := plBuilder.build(stmt.AST, bodyScope)
+			if isSetReturning {
+				// The rows returned by a set-returning PLpgSQL function are collected
+				// by its RETURN NEXT and RETURN QUERY statements, so the result of the
+				// last statement is only used to determine the output columns.
+				_, _, isMultiColDataSource = b.finishBuildLastStmt(stmtScope, bodyScope, isSetReturning, f)
+			} else {
+				b.finishBuildLastStmt(stmtScope, bodyScope, isSetReturning, f)
+			}
+			body = []memo.RelExpr{stmtScope.expr}
+			bodyProps = []*physical.Required{stmtScope.makePhysicalProps()}
+		default:
+			panic(errors.AssertionFailedf("unexpected language: %v", o.Language))
 		}
-		var plBuilder plpgsqlBuilder
-		plBuilder.init(b, colRefs, o.Types.(tree.ParamTypes), stmt.AST, rtyp)
-		stmtScope := plBuilder.build(stmt.AST, bodyScope)
-		b.finishBuildLastStmt(stmtScope, bodyScope, isSetReturning, f)
-		body = []memo.RelExpr{stmtScope.expr}
-		bodyProps = []*physical.Required{stmtScope.makePhysicalProps()}
-	default:
-		panic(errors.AssertionFailedf("unexpected language: %v", o.Language))
+		return nil
+	}
+	// The body of a SECURITY DEFINER routine or a routine with SET options is
+	// built with the session changes that are in effect while it executes, so
+	// that privileges are checked for the definer and names are resolved with
+	// the search path of the routine.
+	if o.RoutineExecContext != nil {
+		// TODO(mgartner): Enable memo reuse with these routines. The privileges
+		// of the dependencies in the metadata are re-checked for the current
+		// user, rather than the definer of the routine, when the memo is reused.
+		b.DisableMemoReuse = true
+	}
+	if err := b.catalog.WithRoutineExecContext(b.ctx, o.RoutineExecContext, buildBody); err != nil {
+		panic(err)
 	}
 
 	b.insideUDF = false
@@ -323,6 +359,8 @@ func (b *Builder) buildRoutine(
 				Body:               body,
 				BodyProps:          bodyProps,
 				Params:             params,
+				BufferedResult:     isSetReturning && o.Language == tree.RoutineLangPLpgSQL,
+				ExecContext:        o.RoutineExecContext,
 			},
 		},
 	)
@@ -362,6 +400,11 @@ func (b *Builder) finishBuildLastStmt(
 	cols := physProps.Presentation
 	isSingleTupleResult := len(stmtScope.cols) == 1 &&
 		stmtScope.cols[0].typ.Family() == types.TupleFamily
+	// A procedure with OUT or INOUT parameters returns a tuple with their
+	// values. The last statement of a procedure with a single OUT parameter may
+	// return the value of the parameter instead.
+	isProcedureWithOutParams := f.ResolvedOverload().Type == tree.ProcedureRoutine &&
+		rtyp.Family() == types.TupleFamily
 	if b.insideDataSource && rtyp.Family() == types.TupleFamily {
 		// When the UDF is used as a data source and expects to output a tuple
 		// type, its output needs to be a row of columns instead of the usual
@@ -379,7 +422,8 @@ func (b *Builder) finishBuildLastStmt(
 			expr = b.constructProject(expr, elems)
 			physProps = stmtScope.makePhysicalProps()
 		}
-	} else if len(cols) > 1 || (types.IsRecordType(rtyp) && !isSingleTupleResult) {
+	} else if len(cols) > 1 || (types.IsRecordType(rtyp) && !isSingleTupleResult) ||
+		(len(cols) == 1 && isProcedureWithOutParams && !isSingleTupleResult) {
 		// Only a single column can be returned from a UDF, unless it is used as a
 		// data source (see comment above). If there are multiple columns, combine
 		// them into a tuple. If the last statement is already returning a tuple
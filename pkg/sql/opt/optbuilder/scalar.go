@@ -34,6 +34,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/redact"
 )
@@ -73,6 +74,11 @@ func (b *Builder) buildScalar(
 			// necessary.
 			return b.finishBuildScalarRef(col, inScope.groupby.aggOutScope, outScope, outCol, colRefs)
 		}
+		// Grouping expressions that are not part of the current grouping set
+		// are NULL.
+		if typ, ok := inScope.groupby.nullGroupStrs[symbolicExprStr(scalar)]; ok {
+			return b.finishBuildScalar(scalar, b.factory.ConstructNull(typ), inScope, outScope, outCol)
+		}
 	}
 
 	switch t := scalar.(type) {
@@ -172,17 +178,15 @@ func (b *Builder) buildScalar(
 		out = b.factory.ConstructArrayFlatten(s.node, &subqueryPrivate)
 
 	case *tree.IndirectionExpr:
-		if len(t.Indirection) != 1 && t.Expr.(tree.TypedExpr).ResolvedType().Family() == types.ArrayFamily {
-			panic(unimplementedWithIssueDetailf(32552, "ind", "multidimensional indexing is not supported"))
-		}
-
 		out = b.buildScalar(t.Expr.(tree.TypedExpr), inScope, nil, nil, colRefs)
 
-		for _, subscript := range t.Indirection {
-			if subscript.Slice {
-				panic(unimplementedWithIssueDetailf(32551, "", "array slicing is not supported"))
-			}
+		if t.Expr.(tree.TypedExpr).ResolvedType().Family() == types.ArrayFamily &&
+			(len(t.Indirection) != 1 || t.Indirection[0].Slice) {
+			out = b.buildArraySubscript(out, t.Indirection, inScope, colRefs)
+			break
+		}
 
+		for _, subscript := range t.Indirection {
 			out = b.factory.ConstructIndirection(
 				out,
 				b.buildScalar(subscript.Begin.(tree.TypedExpr), inScope, nil, nil, colRefs),
@@ -281,6 +285,7 @@ func (b *Builder) buildScalar(
 		texpr := t.Expr.(tree.TypedExpr)
 		arg := b.buildScalar(texpr, inScope, nil, nil, colRefs)
 		out = b.factory.ConstructCast(arg, t.ResolvedType())
+		out = b.buildDomainChecks(out, t.ResolvedType(), inScope)
 
 	case *tree.CoalesceExpr:
 		args := make(memo.ScalarListExpr, len(t.Exprs))
@@ -448,6 +453,9 @@ func (b *Builder) buildScalar(
 		}
 		out = b.factory.ConstructTuple(els, t.ResolvedType())
 
+	case *domainValue:
+		out = t.scalar
+
 	case *subquery:
 		out, _ = b.buildSingleRowSubquery(t, inScope)
 		// Perform correctness checks on the outer cols, update colRefs and
@@ -520,6 +528,37 @@ func (b *Builder) buildAnyScalar(
 	return out
 }
 
+// buildArraySubscript builds an ArraySubscript expression that applies the
+// given subscripts to an array, for subscripts that index into several
+// dimensions of the array or take a slice of it.
+func (b *Builder) buildArraySubscript(
+	input opt.ScalarExpr, subscripts tree.ArraySubscripts, inScope *scope, colRefs *opt.ColSet,
+) opt.ScalarExpr {
+	kinds := make(memo.ArraySubscriptKinds, len(subscripts))
+	bounds := make(memo.ScalarListExpr, 0, 2*len(subscripts))
+	for i, subscript := range subscripts {
+		if subscript.Begin != nil {
+			bounds = append(bounds, b.buildScalar(subscript.Begin.(tree.TypedExpr), inScope, nil, nil, colRefs))
+		}
+		if subscript.End != nil {
+			bounds = append(bounds, b.buildScalar(subscript.End.(tree.TypedExpr), inScope, nil, nil, colRefs))
+		}
+		switch {
+		case !subscript.Slice:
+			kinds[i] = memo.ArraySubscriptIndex
+		case subscript.Begin != nil && subscript.End != nil:
+			kinds[i] = memo.ArraySubscriptSlice
+		case subscript.Begin != nil:
+			kinds[i] = memo.ArraySubscriptSliceFrom
+		case subscript.End != nil:
+			kinds[i] = memo.ArraySubscriptSliceTo
+		default:
+			kinds[i] = memo.ArraySubscriptSliceAll
+		}
+	}
+	return b.factory.ConstructArraySubscript(input, bounds, kinds)
+}
+
 // buildFunction builds a set of memo groups that represent a function
 // expression.
 //
@@ -552,6 +591,10 @@ func (b *Builder) buildFunction(
 	}
 	b.factory.Metadata().AddBuiltin(f.Func.ReferenceByName)
 
+	if def.Name == "grouping" {
+		return b.buildGroupingFunction(f, inScope, outScope, outCol)
+	}
+
 	if overload.Class == tree.AggregateClass {
 		panic(errors.AssertionFailedf("aggregate function should have been replaced"))
 	}
@@ -565,6 +608,30 @@ func (b *Builder) buildFunction(
 		args[i] = b.buildScalar(pexpr.(tree.TypedExpr), inScope, nil, nil, colRefs)
 	}
 
+	// The text search builtins resolve user-defined configurations and
+	// dictionaries without adding them to the metadata, so a memo that may
+	// refer to one can't be reused after it changes. Views and routines record
+	// the objects they may use, so that they can't be dropped.
+	if overload.TextSearchObjectArgument && len(args) == 2 {
+		var name string
+		if memo.CanExtractConstDatum(args[0]) {
+			if d, ok := memo.ExtractConstDatum(args[0]).(*tree.DString); ok {
+				name = string(*d)
+			}
+		}
+		if name == "" || !tsearch.IsBuiltinObject(name) {
+			b.DisableMemoReuse = true
+			if b.trackSchemaDeps {
+				// ts_lexize is the only builtin that takes a dictionary rather
+				// than a configuration.
+				b.schemaTextSearchDeps = append(b.schemaTextSearchDeps, opt.TextSearchDep{
+					Name:       name,
+					Dictionary: def.Name == "ts_lexize",
+				})
+			}
+		}
+	}
+
 	// Construct a private FuncOpDef that refers to a resolved function overload.
 	out = b.factory.ConstructFunction(args, &memo.FunctionPrivate{
 		Name:       def.Name,
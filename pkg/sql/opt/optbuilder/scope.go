@@ -31,6 +31,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treewindow"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/redact"
 )
@@ -996,6 +997,29 @@ func makeUntypedTuple(labels []string, texprs []tree.TypedExpr) *tree.Tuple {
 	return &tree.Tuple{Exprs: exprs, Labels: labels}
 }
 
+// resolveRecordField attempts to resolve a column item of the form x.y, which
+// does not match any data source, as the field y of the composite-typed column
+// x. It returns nil if there is no such column or field.
+func (s *scope) resolveRecordField(t *tree.ColumnItem) tree.Expr {
+	if t.TableName == nil || t.TableName.NumParts != 1 {
+		return nil
+	}
+	_, source, _, err := s.FindSourceProvidingColumn(s.builder.ctx, tree.Name(t.TableName.Parts[0]))
+	if err != nil {
+		return nil
+	}
+	col, ok := source.(*scopeColumn)
+	if !ok || col.typ.Family() != types.TupleFamily {
+		return nil
+	}
+	for _, label := range col.typ.TupleLabels() {
+		if label == string(t.ColumnName) {
+			return &tree.ColumnAccessExpr{Expr: col, ColName: t.ColumnName}
+		}
+	}
+	return nil
+}
+
 // VisitPre is part of the Visitor interface.
 //
 // NB: This code is adapted from sql/select_name_resolution.go and
@@ -1031,6 +1055,11 @@ func (s *scope) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
 	case *tree.ColumnItem:
 		colI, resolveErr := colinfo.ResolveColumnItem(s.builder.ctx, s, t)
 		if resolveErr != nil {
+			if s.builder.resolveRecordFields {
+				if field := s.resolveRecordField(t); field != nil {
+					return false, field
+				}
+			}
 			// It may be a reference to a table, e.g. SELECT tbl FROM tbl.
 			// Attempt to resolve as a TupleStar.
 			if sqlerrors.IsUndefinedColumnError(resolveErr) {
@@ -1284,6 +1313,10 @@ func (s *scope) replaceAggregate(f *tree.FuncExpr, def *tree.ResolvedFunctionDef
 	}
 
 	f = typedFunc.(*tree.FuncExpr)
+	if f.ResolvedOverload().Aggregate != nil && f.OrderBy != nil {
+		panic(unimplemented.New("user-defined aggregates",
+			"ORDER BY is not supported in calls to user-defined aggregates"))
+	}
 
 	private := memo.FunctionPrivate{
 		Name:       def.Name,
@@ -1356,6 +1389,10 @@ func (s *scope) replaceWindowFn(f *tree.FuncExpr, def *tree.ResolvedFunctionDefi
 	}
 
 	f = typedFunc.(*tree.FuncExpr)
+	if f.ResolvedOverload().Aggregate != nil {
+		panic(unimplemented.New("user-defined aggregates",
+			"user-defined aggregates cannot be used as window functions"))
+	}
 
 	// We will be performing type checking on expressions from PARTITION BY and
 	// ORDER BY clauses below, and we need the semantic context to know that we
@@ -615,6 +615,12 @@ func (b *Builder) buildScan(
 		b.addPartialIndexPredicatesForTable(tabMeta, outScope.expr)
 
 		// Note: virtual tables should not be collected as view dependencies.
+		// Foreign tables, which are planned like virtual tables but are backed
+		// by a descriptor with a regular ID, are collected as a dependency on the
+		// whole relation.
+		if b.trackSchemaDeps && !descpb.IsVirtualTable(descpb.ID(tab.ID())) {
+			b.schemaDeps = append(b.schemaDeps, opt.SchemaDep{DataSource: tab})
+		}
 		return outScope
 	}
 
@@ -1139,6 +1145,11 @@ func (b *Builder) buildSelectStmtWithoutParens(
 
 	case *tree.SelectClause:
 		outScope = b.buildSelectClause(t, orderBy, lockCtx, desiredTypes, inScope)
+		if hasGroupingSets(t.GroupBy) {
+			// The ORDER BY was applied to the result of the union built by
+			// buildGroupingSets.
+			orderBy = nil
+		}
 
 	case *tree.UnionClause:
 		b.rejectIfLocking(lockCtx.locking, "UNION/INTERSECT/EXCEPT")
@@ -1202,6 +1213,10 @@ func (b *Builder) buildSelectClause(
 	desiredTypes []*types.T,
 	inScope *scope,
 ) (outScope *scope) {
+	if hasGroupingSets(sel.GroupBy) {
+		return b.buildGroupingSets(sel, orderBy, lockCtx, desiredTypes, inScope)
+	}
+
 	fromScope := b.buildFrom(sel.From, lockCtx, inScope)
 
 	b.processWindowDefs(sel, fromScope)
@@ -0,0 +1,261 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinsregistry"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/cast"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/errors"
+)
+
+// arraySetFnName is the builtin used to assign to an element of an array.
+const arraySetFnName = "crdb_internal.array_set"
+
+// partialAssignment is a value assigned to part of a table column, such as a
+// field of a composite type or an element of an array:
+//
+//	UPDATE t SET c.a = 1, arr[2] = 3
+type partialAssignment struct {
+	// indirection is the path of fields and subscripts within the column.
+	indirection tree.TargetIndirection
+
+	// value is the scalar expression providing the assigned value.
+	value opt.ScalarExpr
+}
+
+// targetIndirection returns the field and subscript path of the i-th target
+// column, or nil if the whole column is targeted.
+func (mb *mutationBuilder) targetIndirection(i int) tree.TargetIndirection {
+	if i < len(mb.targetIndirections) {
+		return mb.targetIndirections[i]
+	}
+	return nil
+}
+
+// targetValueType returns the type of the values assigned to the i-th target
+// column. This is the type of the column, unless only part of the column is
+// targeted, in which case it is the type of that part.
+func (mb *mutationBuilder) targetValueType(i int) *types.T {
+	colID := mb.targetColList[i]
+	if ind := mb.targetIndirection(i); ind != nil {
+		return mb.targetIndirectionType(mb.tabID.ColumnOrdinal(colID), ind)
+	}
+	return mb.md.ColumnMeta(colID).Type
+}
+
+// addPartialTargetCol adds a target column by its ordinal position in the
+// target table, of which only the part described by the given indirection is
+// assigned. Unlike addTargetCol, the same column can be targeted several times,
+// as long as every target assigns to only a part of it.
+func (mb *mutationBuilder) addPartialTargetCol(ord int, ind tree.TargetIndirection) {
+	// Validate the indirection against the type of the column.
+	mb.targetIndirectionType(ord, ind)
+
+	colID := mb.tabID.ColumnID(ord)
+	if mb.partialTargetColSet.Contains(colID) {
+		mb.targetColList = append(mb.targetColList, colID)
+	} else {
+		mb.addTargetCol(ord)
+		mb.partialTargetColSet.Add(colID)
+	}
+
+	for len(mb.targetIndirections) < len(mb.targetColList)-1 {
+		mb.targetIndirections = append(mb.targetIndirections, nil)
+	}
+	mb.targetIndirections = append(mb.targetIndirections, ind)
+}
+
+// targetIndirectionType returns the type of the part of the table column with
+// the given ordinal that is described by the given indirection. It raises an
+// error if the indirection cannot be applied to the type of the column.
+func (mb *mutationBuilder) targetIndirectionType(ord int, ind tree.TargetIndirection) *types.T {
+	tabCol := mb.tab.Column(ord)
+	typ := tabCol.DatumType()
+	for i := 0; i < len(ind); {
+		if ind[i].Subscript == nil {
+			field := ind[i].Field
+			if typ.Family() != types.TupleFamily {
+				panic(pgerror.Newf(pgcode.DatatypeMismatch,
+					"cannot assign to field %q of column %q because its type %s is not a composite type",
+					field, tabCol.ColName(), typ))
+			}
+			idx := tupleFieldOrdinal(typ, field)
+			if idx == -1 {
+				panic(pgerror.Newf(pgcode.UndefinedColumn,
+					"cannot assign to field %q of column %q because there is no such column in data type %s",
+					field, tabCol.ColName(), typ))
+			}
+			typ = typ.TupleContents()[idx]
+			i++
+			continue
+		}
+
+		switch typ.Family() {
+		case types.ArrayFamily:
+		case types.JsonFamily:
+			panic(unimplemented.New("json subscript assignment",
+				"assigning to an element of a JSON value is not supported"))
+		default:
+			panic(pgerror.Newf(pgcode.DatatypeMismatch,
+				"cannot subscript type %s because it is not an array", typ))
+		}
+
+		// Consecutive subscripts index into the dimensions of the same array.
+		n := subscriptCount(ind[i:])
+		for _, elem := range ind[i : i+n] {
+			if elem.Subscript.Slice {
+				panic(unimplemented.New("array slice assignment",
+					"assigning to a slice of an array is not supported"))
+			}
+		}
+		typ = typ.ArrayContents()
+		i += n
+	}
+	return typ
+}
+
+// buildPartialAssignment builds a scalar expression that computes a new value
+// of the given type from base, which is replaced in the part described by the
+// given indirection with value. Values of composite types are rebuilt from their
+// fields, and array elements are assigned with the crdb_internal.array_set
+// builtin. For example, the new value of column c in:
+//
+//	UPDATE t SET c.a[2] = 1
+//
+// is built as:
+//
+//	ROW(crdb_internal.array_set(COALESCE((c).a, ARRAY[]), ARRAY[2], 1), (c).b)
+//
+// Subscripts of the indirection are built in inScope.
+func (mb *mutationBuilder) buildPartialAssignment(
+	base opt.ScalarExpr,
+	typ *types.T,
+	ind tree.TargetIndirection,
+	value opt.ScalarExpr,
+	colName tree.Name,
+	inScope *scope,
+) opt.ScalarExpr {
+	f := mb.b.factory
+	if len(ind) == 0 {
+		return mb.buildPartialAssignmentCast(value, typ, colName, inScope)
+	}
+
+	if ind[0].Subscript == nil {
+		idx := tupleFieldOrdinal(typ, ind[0].Field)
+		contents := typ.TupleContents()
+		elems := make(memo.ScalarListExpr, len(contents))
+		for i := range elems {
+			elems[i] = f.ConstructColumnAccess(base, memo.TupleOrdinal(i))
+		}
+		elems[idx] = mb.buildPartialAssignment(elems[idx], contents[idx], ind[1:], value, colName, inScope)
+		return f.ConstructTuple(elems, typ)
+	}
+
+	n := subscriptCount(ind)
+	subscripts := make(memo.ScalarListExpr, n)
+	for i := range subscripts {
+		texpr := inScope.resolveAndRequireType(ind[i].Subscript.Begin, types.Int)
+		subscripts[i] = mb.b.buildScalar(texpr, inScope, nil, nil, nil)
+	}
+
+	// Assigning to an element of a NULL array produces an array containing only
+	// that element.
+	base = f.ConstructCoalesce(memo.ScalarListExpr{
+		base, f.ConstructConstVal(tree.NewDArray(typ.ArrayContents()), typ),
+	})
+
+	var elem opt.ScalarExpr
+	if len(ind) > n {
+		// The assignment continues into the element, so start from its current
+		// value.
+		var cur opt.ScalarExpr
+		if n == 1 {
+			cur = f.ConstructIndirection(base, subscripts[0])
+		} else {
+			kinds := make(memo.ArraySubscriptKinds, n)
+			for i := range kinds {
+				kinds[i] = memo.ArraySubscriptIndex
+			}
+			cur = f.ConstructArraySubscript(base, subscripts, kinds)
+		}
+		elem = mb.buildPartialAssignment(cur, typ.ArrayContents(), ind[n:], value, colName, inScope)
+	} else {
+		elem = mb.buildPartialAssignmentCast(value, typ.ArrayContents(), colName, inScope)
+	}
+
+	props, overloads := builtinsregistry.GetBuiltinProperties(arraySetFnName)
+	if len(overloads) != 1 {
+		panic(errors.AssertionFailedf("expected one overload for %s", arraySetFnName))
+	}
+	return f.ConstructFunction(
+		memo.ScalarListExpr{base, f.ConstructArray(subscripts, types.IntArray), elem},
+		&memo.FunctionPrivate{
+			Name:       arraySetFnName,
+			Typ:        typ,
+			Properties: props,
+			Overload:   &overloads[0],
+		},
+	)
+}
+
+// buildPartialAssignmentCast adds an assignment cast of a value assigned to
+// part of the given column to the type of that part, if the types differ.
+func (mb *mutationBuilder) buildPartialAssignmentCast(
+	value opt.ScalarExpr, typ *types.T, colName tree.Name, inScope *scope,
+) opt.ScalarExpr {
+	srcType := value.DataType()
+	if srcType.Identical(typ) {
+		return value
+	}
+	if !cast.ValidCast(srcType, typ, cast.ContextAssignment) {
+		panic(sqlerrors.NewInvalidAssignmentCastError(srcType, typ, string(colName)))
+	}
+	value = mb.b.factory.ConstructAssignmentCast(value, typ)
+	return mb.b.buildDomainChecks(value, typ, inScope)
+}
+
+// partialDefaultError returns the error raised when DEFAULT is assigned to the
+// part of a column described by the given indirection.
+func partialDefaultError(ind tree.TargetIndirection) error {
+	if ind[len(ind)-1].Subscript != nil {
+		return pgerror.New(pgcode.FeatureNotSupported, "cannot set an array element to DEFAULT")
+	}
+	return pgerror.New(pgcode.FeatureNotSupported, "cannot set a subfield to DEFAULT")
+}
+
+// tupleFieldOrdinal returns the ordinal of the field with the given name in
+// the given tuple type, or -1 if there is no such field.
+func tupleFieldOrdinal(typ *types.T, field tree.Name) int {
+	for i, label := range typ.TupleLabels() {
+		if label == string(field) {
+			return i
+		}
+	}
+	return -1
+}
+
+// subscriptCount returns the number of consecutive array subscripts at the
+// start of the given indirection.
+func subscriptCount(ind tree.TargetIndirection) int {
+	n := 0
+	for n < len(ind) && ind[n].Subscript != nil {
+		n++
+	}
+	return n
+}
@@ -0,0 +1,545 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/props/physical"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	plpgsql "github.com/cockroachdb/cockroach/pkg/sql/plpgsql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
+)
+
+// Row-level triggers are built as calls to the trigger function, which is
+// invoked with the NEW and OLD rows and the other special variables of a
+// PL/pgSQL trigger function as its arguments.
+//
+// BEFORE triggers are built into the input of the mutation. The row returned by
+// the trigger function replaces the NEW row, and rows for which the function
+// returns NULL are skipped:
+//
+//	SELECT (r).a, (r).b FROM (
+//	  SELECT f(ROW(a, b), NULL, ...) AS r FROM <input>
+//	) WHERE r IS DISTINCT FROM NULL
+//
+// AFTER triggers are built as post-queries, in the same way as foreign key
+// cascades, which invoke the trigger function for each row of the buffered
+// mutation input once the mutation has been performed. Their results are
+// ignored.
+
+// triggersForEvent returns the row-level triggers of the target table that
+// fire at the given time for the given event.
+func (mb *mutationBuilder) triggersForEvent(
+	actionTime tree.TriggerActionTime, event tree.TriggerEventType,
+) []*cat.Trigger {
+	var res []*cat.Trigger
+	for i, n := 0, mb.tab.TriggerCount(); i < n; i++ {
+		trig := mb.tab.Trigger(i)
+		if trig.ActionTime != actionTime {
+			continue
+		}
+		for j := range trig.Events {
+			ev := &trig.Events[j]
+			if ev.Type != event {
+				continue
+			}
+			if len(ev.ColumnOrdinals) > 0 && !mb.targetsAnyColumn(ev.ColumnOrdinals) {
+				// An UPDATE OF trigger only fires if one of its columns is a target
+				// of the UPDATE.
+				continue
+			}
+			res = append(res, &trig)
+			break
+		}
+	}
+	return res
+}
+
+// targetsAnyColumn returns true if any of the columns with the given table
+// ordinals is a target of the mutation.
+func (mb *mutationBuilder) targetsAnyColumn(ords []int) bool {
+	for _, ord := range ords {
+		if mb.targetColSet.Contains(mb.tabID.ColumnID(ord)) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkNoTriggersForUpsert raises an error if the target table of an UPSERT,
+// INSERT ... ON CONFLICT or MERGE statement has any triggers that fire on
+// INSERT or UPDATE, which are not yet supported for such statements.
+func (mb *mutationBuilder) checkNoTriggersForUpsert() {
+	for i, n := 0, mb.tab.TriggerCount(); i < n; i++ {
+		trig := mb.tab.Trigger(i)
+		for j := range trig.Events {
+			if typ := trig.Events[j].Type; typ == tree.TriggerEventInsert || typ == tree.TriggerEventUpdate {
+				stmt := "INSERT ... ON CONFLICT DO UPDATE"
+				switch mb.opName {
+				case "upsert":
+					stmt = "UPSERT"
+				case "merge":
+					stmt = "MERGE with several kinds of actions"
+				}
+				panic(unimplemented.NewWithIssuef(28296,
+					"%s is not supported on tables with triggers", stmt))
+			}
+		}
+	}
+}
+
+// triggerRowType returns the type of the NEW and OLD rows that are passed to
+// the trigger functions of the given table, along with the table ordinals of
+// the columns of the row. The row includes all visible columns of the table.
+func triggerRowType(tab cat.Table) (_ *types.T, ords []int) {
+	var contents []*types.T
+	var labels []string
+	for i, n := 0, tab.ColumnCount(); i < n; i++ {
+		col := tab.Column(i)
+		if col.Kind() != cat.Ordinary || col.Visibility() != cat.Visible {
+			continue
+		}
+		contents = append(contents, col.DatumType())
+		labels = append(labels, string(col.ColName()))
+		ords = append(ords, i)
+	}
+	return types.MakeLabeledTuple(contents, labels), ords
+}
+
+// buildTriggerRow returns a tuple expression for a NEW or OLD row, with the
+// values of the given columns of the mutation input. Computed columns are NULL
+// if skipComputed is true, because their values are not yet known when BEFORE
+// triggers fire.
+func (mb *mutationBuilder) buildTriggerRow(
+	rowType *types.T, ords []int, colIDs func(ord int) opt.ColumnID, skipComputed bool,
+) opt.ScalarExpr {
+	f := mb.b.factory
+	elems := make(memo.ScalarListExpr, len(ords))
+	for i, ord := range ords {
+		if skipComputed && mb.tab.Column(ord).IsComputed() {
+			elems[i] = f.ConstructNull(rowType.TupleContents()[i])
+			continue
+		}
+		elems[i] = f.ConstructVariable(colIDs(ord))
+	}
+	return f.ConstructTuple(elems, rowType)
+}
+
+// buildRowLevelBeforeTriggers invokes the BEFORE triggers of the target table
+// that fire for the given event, in order, for each row of the mutation input.
+// For INSERT and UPDATE, the new values of each row are replaced by the row
+// returned by the trigger function. Rows for which a trigger function returns
+// NULL are not modified.
+func (mb *mutationBuilder) buildRowLevelBeforeTriggers(event tree.TriggerEventType) {
+	triggers := mb.triggersForEvent(tree.TriggerActionTimeBefore, event)
+	if len(triggers) == 0 {
+		return
+	}
+	rowType, ords := triggerRowType(mb.tab)
+	f := mb.b.factory
+	for _, trig := range triggers {
+		var newRow, oldRow opt.ScalarExpr
+		switch event {
+		case tree.TriggerEventInsert:
+			newRow = mb.buildTriggerRow(rowType, ords, func(ord int) opt.ColumnID {
+				return mb.insertColIDs[ord]
+			}, true /* skipComputed */)
+			oldRow = f.ConstructNull(rowType)
+		case tree.TriggerEventUpdate:
+			newRow = mb.buildTriggerRow(rowType, ords, func(ord int) opt.ColumnID {
+				if mb.updateColIDs[ord] != 0 {
+					return mb.updateColIDs[ord]
+				}
+				return mb.fetchColIDs[ord]
+			}, true /* skipComputed */)
+			oldRow = mb.buildTriggerRow(rowType, ords, func(ord int) opt.ColumnID {
+				return mb.fetchColIDs[ord]
+			}, false /* skipComputed */)
+		case tree.TriggerEventDelete:
+			newRow = f.ConstructNull(rowType)
+			oldRow = mb.buildTriggerRow(rowType, ords, func(ord int) opt.ColumnID {
+				return mb.fetchColIDs[ord]
+			}, false /* skipComputed */)
+		}
+		var resultCol opt.ColumnID
+		mb.outScope, resultCol = mb.b.buildTriggerCall(
+			mb.outScope, mb.tab, trig, event, rowType, newRow, oldRow,
+		)
+
+		// Skip the rows for which the trigger function returned NULL. Note that
+		// IS NOT NULL cannot be used, because it is false for a row with NULL
+		// fields.
+		result := f.ConstructVariable(resultCol)
+		mb.outScope.expr = f.ConstructSelect(mb.outScope.expr, memo.FiltersExpr{
+			f.ConstructFiltersItem(f.ConstructIsNot(result, f.ConstructNull(rowType))),
+		})
+		if event == tree.TriggerEventDelete {
+			continue
+		}
+
+		// Replace the new values of the row with the fields of the row returned
+		// by the trigger function. Changes to computed columns are ignored.
+		projectionsScope := mb.outScope.replace()
+		projectionsScope.appendColumnsFromScope(mb.outScope)
+		for i, ord := range ords {
+			tabCol := mb.tab.Column(ord)
+			if tabCol.IsComputed() {
+				continue
+			}
+			colName := scopeColName(tabCol.ColName()).WithMetadataName(
+				string(tabCol.ColName()) + "_" + string(trig.Name),
+			)
+			newCol := mb.b.synthesizeColumn(
+				projectionsScope, colName, tabCol.DatumType(), nil, /* expr */
+				f.ConstructColumnAccess(result, memo.TupleOrdinal(i)),
+			)
+			if event == tree.TriggerEventInsert {
+				mb.insertColIDs[ord] = newCol.id
+				continue
+			}
+			if mb.updateColIDs[ord] == 0 {
+				tabColID := mb.tabID.ColumnID(ord)
+				mb.targetColList = append(mb.targetColList, tabColID)
+				mb.targetColSet.Add(tabColID)
+			}
+			mb.updateColIDs[ord] = newCol.id
+		}
+		mb.b.constructProjectForScope(mb.outScope, projectionsScope)
+		mb.outScope = projectionsScope
+	}
+}
+
+// buildRowLevelAfterTriggers adds a post-query for each of the AFTER triggers
+// of the target table that fire for the given event. It must be called once
+// mb.outScope is the final input to the mutation.
+func (mb *mutationBuilder) buildRowLevelAfterTriggers(event tree.TriggerEventType) {
+	triggers := mb.triggersForEvent(tree.TriggerActionTimeAfter, event)
+	if len(triggers) == 0 {
+		return
+	}
+	_, ords := triggerRowType(mb.tab)
+	var oldValues, newValues opt.ColList
+	for _, ord := range ords {
+		switch event {
+		case tree.TriggerEventInsert:
+			newValues = append(newValues, mb.insertColIDs[ord])
+		case tree.TriggerEventUpdate:
+			oldValues = append(oldValues, mb.fetchColIDs[ord])
+			if mb.updateColIDs[ord] != 0 {
+				newValues = append(newValues, mb.updateColIDs[ord])
+			} else {
+				newValues = append(newValues, mb.fetchColIDs[ord])
+			}
+		case tree.TriggerEventDelete:
+			oldValues = append(oldValues, mb.fetchColIDs[ord])
+		}
+	}
+	mb.ensureWithID()
+	for _, trig := range triggers {
+		mb.cascades = append(mb.cascades, memo.FKCascade{
+			FKName:    string(trig.Name),
+			Builder:   newAfterTriggerBuilder(mb.tab, trig, event),
+			WithID:    mb.withID,
+			OldValues: oldValues,
+			NewValues: newValues,
+		})
+	}
+}
+
+// afterTriggerBuilder is a memo.CascadeBuilder implementation for AFTER
+// triggers. It builds a query that invokes the trigger function for each row
+// that was modified by the mutation:
+//
+//	SELECT f(ROW(new_a, new_b), ROW(old_a, old_b), ...)
+//	FROM (SELECT new_a, new_b, old_a, old_b FROM original_mutation_input)
+//
+// The rows produced by the query are ignored.
+type afterTriggerBuilder struct {
+	table   cat.Table
+	trigger cat.Trigger
+	event   tree.TriggerEventType
+}
+
+var _ memo.CascadeBuilder = &afterTriggerBuilder{}
+
+func newAfterTriggerBuilder(
+	table cat.Table, trigger *cat.Trigger, event tree.TriggerEventType,
+) *afterTriggerBuilder {
+	return &afterTriggerBuilder{
+		table:   table,
+		trigger: *trigger,
+		event:   event,
+	}
+}
+
+// Build is part of the memo.CascadeBuilder interface.
+func (tb *afterTriggerBuilder) Build(
+	ctx context.Context,
+	semaCtx *tree.SemaContext,
+	evalCtx *eval.Context,
+	catalog cat.Catalog,
+	factoryI interface{},
+	binding opt.WithID,
+	bindingProps *props.Relational,
+	oldValues, newValues opt.ColList,
+) (_ memo.RelExpr, err error) {
+	return buildCascadeHelper(ctx, semaCtx, evalCtx, catalog, factoryI, func(b *Builder) memo.RelExpr {
+		f := b.factory
+		md := f.Metadata()
+
+		// Scan the buffered mutation input.
+		inCols := make(opt.ColList, 0, len(oldValues)+len(newValues))
+		inCols = append(inCols, oldValues...)
+		inCols = append(inCols, newValues...)
+		outScope := b.allocScope()
+		outCols := make(opt.ColList, len(inCols))
+		for i := range inCols {
+			c := md.ColumnMeta(inCols[i])
+			outCols[i] = md.AddColumn(c.Alias, c.Type)
+			outScope.cols = append(outScope.cols, scopeColumn{
+				name: scopeColName(""),
+				id:   outCols[i],
+				typ:  c.Type,
+			})
+		}
+		md.AddWithBinding(binding, f.ConstructFakeRel(&memo.FakeRelPrivate{
+			Props: bindingProps,
+		}))
+		outScope.expr = f.ConstructWithScan(&memo.WithScanPrivate{
+			With:    binding,
+			InCols:  inCols,
+			OutCols: outCols,
+			ID:      md.NextUniqueID(),
+		})
+
+		rowType, _ := triggerRowType(tb.table)
+		makeRow := func(cols opt.ColList) opt.ScalarExpr {
+			if len(cols) == 0 {
+				return f.ConstructNull(rowType)
+			}
+			elems := make(memo.ScalarListExpr, len(cols))
+			for i := range cols {
+				elems[i] = f.ConstructVariable(cols[i])
+			}
+			return f.ConstructTuple(elems, rowType)
+		}
+		oldRow := makeRow(outCols[:len(oldValues)])
+		newRow := makeRow(outCols[len(oldValues):])
+		outScope, _ = b.buildTriggerCall(outScope, tb.table, &tb.trigger, tb.event, rowType, newRow, oldRow)
+		return outScope.expr
+	})
+}
+
+// buildTriggerCall projects the result of invoking the trigger function of the
+// given trigger for each row of inScope, with the given NEW and OLD rows. If the
+// trigger has a WHEN condition and it is not true for a row, the function is
+// not invoked, and the result is the NEW row, or the OLD row for a DELETE.
+func (b *Builder) buildTriggerCall(
+	inScope *scope,
+	tab cat.Table,
+	trig *cat.Trigger,
+	event tree.TriggerEventType,
+	rowType *types.T,
+	newRow, oldRow opt.ScalarExpr,
+) (outScope *scope, resultCol opt.ColumnID) {
+	f := b.factory
+
+	// Project the NEW and OLD rows, so that they can be referenced by name in
+	// the WHEN condition.
+	rowsScope := inScope.replace()
+	rowsScope.appendColumnsFromScope(inScope)
+	newCol := b.synthesizeColumn(rowsScope, scopeColName("new"), rowType, nil /* expr */, newRow)
+	oldCol := b.synthesizeColumn(rowsScope, scopeColName("old"), rowType, nil /* expr */, oldRow)
+	b.constructProjectForScope(inScope, rowsScope)
+	newRow, oldRow = f.ConstructVariable(newCol.id), f.ConstructVariable(oldCol.id)
+
+	var call opt.ScalarExpr = b.buildTriggerFunction(tab, trig, event, rowType, newRow, oldRow)
+	if trig.WhenExpr != "" {
+		expr, err := parser.ParseExpr(trig.WhenExpr)
+		if err != nil {
+			panic(err)
+		}
+		defer func(old bool) { b.resolveRecordFields = old }(b.resolveRecordFields)
+		b.resolveRecordFields = true
+		texpr := rowsScope.resolveAndRequireType(expr, types.Bool)
+		cond := b.buildScalar(texpr, rowsScope, nil /* outScope */, nil /* outCol */, nil /* colRefs */)
+		orElse := newRow
+		if event == tree.TriggerEventDelete {
+			orElse = oldRow
+		}
+		call = f.ConstructCase(
+			memo.TrueSingleton,
+			memo.ScalarListExpr{f.ConstructWhen(cond, call)},
+			orElse,
+		)
+	}
+
+	// Project the result along with the columns of inScope. The NEW and OLD
+	// columns are not projected, so that they cannot be referenced later on.
+	outScope = inScope.replace()
+	outScope.appendColumnsFromScope(inScope)
+	colName := scopeColName("").WithMetadataName(string(trig.Name))
+	col := b.synthesizeColumn(outScope, colName, rowType, nil /* expr */, call)
+	b.constructProjectForScope(rowsScope, outScope)
+	return outScope, col.id
+}
+
+// triggerParams returns the parameters of the routine that is built for an
+// invocation of a trigger function. Trigger functions are declared without
+// parameters, and instead refer to these special variables.
+func triggerParams(rowType *types.T) tree.ParamTypes {
+	return tree.ParamTypes{
+		{Name: "new", Typ: rowType},
+		{Name: "old", Typ: rowType},
+		{Name: "tg_name", Typ: types.Name},
+		{Name: "tg_when", Typ: types.String},
+		{Name: "tg_level", Typ: types.String},
+		{Name: "tg_op", Typ: types.String},
+		{Name: "tg_relid", Typ: types.Oid},
+		{Name: "tg_relname", Typ: types.Name},
+		{Name: "tg_table_name", Typ: types.Name},
+		{Name: "tg_table_schema", Typ: types.Name},
+		{Name: "tg_nargs", Typ: types.Int},
+		{Name: "tg_argv", Typ: types.StringArray},
+	}
+}
+
+// buildTriggerFunction builds an invocation of the trigger function of the
+// given trigger with the given NEW and OLD rows. The function returns a row of
+// the given type.
+func (b *Builder) buildTriggerFunction(
+	tab cat.Table,
+	trig *cat.Trigger,
+	event tree.TriggerEventType,
+	rowType *types.T,
+	newRow, oldRow opt.ScalarExpr,
+) opt.ScalarExpr {
+	f := b.factory
+	name, o, err := b.catalog.ResolveFunctionByOID(b.ctx, catid.FuncIDToOID(trig.FuncID))
+	if err != nil {
+		panic(err)
+	}
+	if o.Language != tree.RoutineLangPLpgSQL || o.FixedReturnType().Family() != types.TriggerFamily {
+		panic(errors.AssertionFailedf("function %s is not a trigger function", name.Object()))
+	}
+	f.Metadata().AddUserDefinedFunction(o, nil /* name */)
+
+	tabName, err := b.catalog.FullyQualifiedName(b.ctx, tab)
+	if err != nil {
+		panic(err)
+	}
+	argv := tree.NewDArray(types.String)
+	for _, arg := range trig.FuncArgs {
+		if err := argv.Append(tree.NewDString(arg)); err != nil {
+			panic(err)
+		}
+	}
+	constVal := func(d tree.Datum, typ *types.T) opt.ScalarExpr {
+		return f.ConstructConstVal(d, typ)
+	}
+	args := memo.ScalarListExpr{
+		newRow,
+		oldRow,
+		constVal(tree.NewDName(string(trig.Name)), types.Name),
+		constVal(tree.NewDString(trig.ActionTime.String()), types.String),
+		constVal(tree.NewDString("ROW"), types.String),
+		constVal(tree.NewDString(event.String()), types.String),
+		constVal(tree.NewDOid(oid.Oid(tab.ID())), types.Oid),
+		constVal(tree.NewDName(string(tab.Name())), types.Name),
+		constVal(tree.NewDName(string(tab.Name())), types.Name),
+		constVal(tree.NewDName(tabName.Schema()), types.Name),
+		constVal(tree.NewDInt(tree.DInt(len(trig.FuncArgs))), types.Int),
+		constVal(argv, types.StringArray),
+	}
+
+	// Build the body of the function with the special variables as its
+	// parameters. NEW and OLD can be assigned like variables.
+	paramTypes := triggerParams(rowType)
+	bodyScope := b.allocScope()
+	params := make(opt.ColList, len(paramTypes))
+	for i := range paramTypes {
+		param := &paramTypes[i]
+		col := b.synthesizeColumn(
+			bodyScope, funcParamColName(tree.Name(param.Name), i), param.Typ, nil /* expr */, nil, /* scalar */
+		)
+		col.setParamOrd(i)
+		params[i] = col.id
+	}
+	var body []memo.RelExpr
+	var bodyProps []*physical.Required
+	buildBody := func() error {
+		stmt, err := plpgsql.Parse(o.Body)
+		if err != nil {
+			return err
+		}
+		var plBuilder plpgsqlBuilder
+		plBuilder.init(
+			b, nil /* colRefs */, paramTypes, nil /* paramClasses */, stmt.AST, rowType,
+			false /* setReturning */, false, /* isProcedure */
+		)
+		plBuilder.varTypes["new"] = rowType
+		plBuilder.varTypes["old"] = rowType
+		stmtScope := plBuilder.build(stmt.AST, bodyScope)
+		b.buildLimit(&tree.Limit{Count: tree.NewDInt(1)}, b.allocScope(), stmtScope)
+		body = []memo.RelExpr{stmtScope.expr}
+		bodyProps = []*physical.Required{stmtScope.makePhysicalProps()}
+		return nil
+	}
+	if o.RoutineExecContext != nil {
+		// See buildRoutine.
+		b.DisableMemoReuse = true
+	}
+	defer func(old bool) { b.insideUDF = old }(b.insideUDF)
+	b.insideUDF = true
+	if err := b.catalog.WithRoutineExecContext(b.ctx, o.RoutineExecContext, buildBody); err != nil {
+		panic(err)
+	}
+
+	return f.ConstructUDFCall(
+		args,
+		&memo.UDFCallPrivate{
+			Def: &memo.UDFDefinition{
+				Name:       name.Object(),
+				Typ:        rowType,
+				Volatility: o.Volatility,
+				// The function must be invoked even though either the NEW or OLD row
+				// is NULL.
+				CalledOnNullInput: true,
+				Body:              body,
+				BodyProps:         bodyProps,
+				Params:            params,
+				ExecContext:       o.RoutineExecContext,
+			},
+		},
+	)
+}
+
+// checkTriggerFunctionReturnType raises an error if a function with the
+// trigger return type is invoked directly.
+func checkTriggerFunctionReturnType(typ *types.T) {
+	if typ.Family() == types.TriggerFamily {
+		panic(pgerror.New(pgcode.FeatureNotSupported,
+			"trigger functions can only be called as triggers"))
+	}
+}
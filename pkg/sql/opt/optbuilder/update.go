@@ -126,7 +126,7 @@ func (mb *mutationBuilder) addTargetColsForUpdate(exprs tree.UpdateExprs) {
 	}
 
 	for _, expr := range exprs {
-		mb.addTargetColsByName(expr.Names)
+		mb.addTargetColsByName(expr.Names, expr.Indirections)
 
 		if expr.Tuple {
 			n := -1
@@ -140,7 +140,7 @@ func (mb *mutationBuilder) addTargetColsForUpdate(exprs tree.UpdateExprs) {
 				desiredTypes := make([]*types.T, len(expr.Names))
 				targetIdx := len(mb.targetColList) - len(expr.Names)
 				for i := range desiredTypes {
-					desiredTypes[i] = mb.md.ColumnMeta(mb.targetColList[targetIdx+i]).Type
+					desiredTypes[i] = mb.targetValueType(targetIdx + i)
 				}
 				outScope := mb.b.buildSelectStmt(t.Select, noLocking, desiredTypes, mb.outScope)
 				mb.subqueries = append(mb.subqueries, outScope)
@@ -194,10 +194,35 @@ func (mb *mutationBuilder) addUpdateCols(exprs tree.UpdateExprs) {
 	projectionsScope := mb.outScope.replace()
 	projectionsScope.appendColumnsFromScope(mb.outScope)
 
-	addCol := func(expr tree.Expr, targetColID opt.ColumnID) {
+	// Values assigned to part of a column are collected per column, and
+	// combined with the existing value of the column once all SET expressions
+	// have been built.
+	var partialOrds []int
+	var partial map[int][]partialAssignment
+	addPartial := func(ord int, ind tree.TargetIndirection, value opt.ScalarExpr) {
+		if partial == nil {
+			partial = make(map[int][]partialAssignment)
+		}
+		if _, ok := partial[ord]; !ok {
+			partialOrds = append(partialOrds, ord)
+		}
+		partial[ord] = append(partial[ord], partialAssignment{indirection: ind, value: value})
+	}
+
+	addCol := func(expr tree.Expr, n int) {
+		targetColID := mb.targetColList[n]
 		ord := mb.tabID.ColumnOrdinal(targetColID)
 		targetCol := mb.tab.Column(ord)
 
+		if ind := mb.targetIndirection(n); ind != nil {
+			if _, ok := expr.(tree.DefaultVal); ok {
+				panic(partialDefaultError(ind))
+			}
+			texpr := inScope.resolveType(expr, mb.targetValueType(n))
+			addPartial(ord, ind, mb.b.buildScalar(texpr, inScope, nil, nil, nil))
+			return
+		}
+
 		// Allow right side of SET to be DEFAULT.
 		if _, ok := expr.(tree.DefaultVal); ok {
 			expr = mb.parseDefaultExpr(targetColID)
@@ -241,8 +266,12 @@ func (mb *mutationBuilder) addUpdateCols(exprs tree.UpdateExprs) {
 					targetCol := mb.tab.Column(ord)
 					subqueryScope.cols[i].name = scopeColName(targetCol.ColName())
 
-					// Add the column ID to the list of columns to update.
-					mb.updateColIDs[ord] = subqueryScope.cols[i].id
+					if ind := mb.targetIndirection(n); ind != nil {
+						addPartial(ord, ind, mb.b.factory.ConstructVariable(subqueryScope.cols[i].id))
+					} else {
+						// Add the column ID to the list of columns to update.
+						mb.updateColIDs[ord] = subqueryScope.cols[i].id
+					}
 					n++
 				}
 
@@ -267,22 +296,40 @@ func (mb *mutationBuilder) addUpdateCols(exprs tree.UpdateExprs) {
 
 			case *tree.Tuple:
 				for _, expr := range t.Exprs {
-					addCol(expr, mb.targetColList[n])
+					addCol(expr, n)
 					n++
 				}
 			}
 		} else {
-			addCol(set.Expr, mb.targetColList[n])
+			addCol(set.Expr, n)
 			n++
 		}
 	}
 
+	// Build the new values of columns that are assigned in part from their
+	// existing values.
+	for _, ord := range partialOrds {
+		targetCol := mb.tab.Column(ord)
+		colType := targetCol.DatumType()
+		var value opt.ScalarExpr = mb.b.factory.ConstructVariable(mb.fetchColIDs[ord])
+		for _, a := range partial[ord] {
+			value = mb.buildPartialAssignment(value, colType, a.indirection, a.value, targetCol.ColName(), inScope)
+		}
+		targetColName := targetCol.ColName()
+		colName := scopeColName(targetColName).WithMetadataName(string(targetColName) + "_new")
+		scopeCol := mb.b.synthesizeColumn(projectionsScope, colName, colType, nil /* expr */, value)
+		mb.updateColIDs[ord] = scopeCol.id
+	}
+
 	mb.b.constructProjectForScope(mb.outScope, projectionsScope)
 	mb.outScope = projectionsScope
 
 	// Add assignment casts for update columns.
 	mb.addAssignmentCasts(mb.updateColIDs)
 
+	// Invoke BEFORE triggers, which may modify the new values of the rows.
+	mb.buildRowLevelBeforeTriggers(tree.TriggerEventUpdate)
+
 	// Add additional columns for computed expressions that may depend on the
 	// updated columns.
 	mb.addSynthesizedColsForUpdate()
@@ -346,6 +393,10 @@ func (mb *mutationBuilder) buildUpdate(returning *tree.ReturningExprs) {
 
 	mb.buildFKChecksForUpdate()
 
+	mb.buildMergeDeleteCascade()
+
+	mb.buildRowLevelAfterTriggers(tree.TriggerEventUpdate)
+
 	private := mb.makeMutationPrivate(returning != nil)
 	for _, col := range mb.extraAccessibleCols {
 		if col.id != 0 {
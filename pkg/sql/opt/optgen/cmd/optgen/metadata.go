@@ -196,10 +196,12 @@ func newMetadata(compiled *lang.CompiledExpr, pkg string) *metadata {
 		"UniqueID":             {fullName: "opt.UniqueID", passByVal: true},
 		"WithID":               {fullName: "opt.WithID", passByVal: true},
 		"UDFDefinition":        {fullName: "memo.UDFDefinition", isPointer: true},
+		"UDAggDefinition":      {fullName: "memo.UDAggDefinition", isPointer: true},
 		"Ordering":             {fullName: "opt.Ordering", passByVal: true},
 		"OrderingChoice":       {fullName: "props.OrderingChoice", passByVal: true},
 		"GroupingOrder":        {fullName: "memo.GroupingOrder", passByVal: true},
 		"TupleOrdinal":         {fullName: "memo.TupleOrdinal", passByVal: true},
+		"ArraySubscriptKinds":  {fullName: "memo.ArraySubscriptKinds", passByVal: true},
 		"ScanLimit":            {fullName: "memo.ScanLimit", passByVal: true},
 		"ScanFlags":            {fullName: "memo.ScanFlags", passByVal: true},
 		"JoinFlags":            {fullName: "memo.JoinFlags", passByVal: true},
@@ -242,6 +244,7 @@ func newMetadata(compiled *lang.CompiledExpr, pkg string) *metadata {
 		"UniqueOrdinals":       {fullName: "cat.UniqueOrdinals", passByVal: true},
 		"SchemaDeps":           {fullName: "opt.SchemaDeps", passByVal: true},
 		"SchemaTypeDeps":       {fullName: "opt.SchemaTypeDeps", passByVal: true},
+		"SchemaTextSearchDeps": {fullName: "opt.SchemaTextSearchDeps", passByVal: true},
 		"Locking":              {fullName: "opt.Locking", passByVal: true},
 		"CTEMaterializeClause": {fullName: "tree.CTEMaterializeClause", passByVal: true},
 		"SpanExpression":       {fullName: "inverted.SpanExpression", isPointer: true, usePointerIntern: true},
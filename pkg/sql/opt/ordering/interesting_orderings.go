@@ -121,9 +121,14 @@ func interestingOrderingsForScan(scan *memo.ScanExpr) props.OrderingSet {
 			colID := scan.Table.ColumnID(indexCol.Ordinal())
 			if j < exactPrefix {
 				o.Optional.Add(colID)
-			} else {
-				o.AppendCol(colID, indexCol.Descending)
+				continue
 			}
+			if indexCol.NullsReversed && indexCol.IsNullable() {
+				// The NULLs of this column are not where an ordering on the column
+				// expects them.
+				break
+			}
+			o.AppendCol(colID, indexCol.Descending)
 		}
 		if o.CanSimplify(fds) {
 			o.Simplify(fds)
@@ -272,6 +272,11 @@ func getLookupOrdCols(
 			// satisfy the required ordering, so break instead of returning.
 			break
 		}
+		if idxCol := idx.Column(i); idxCol.NullsReversed && idxCol.IsNullable() {
+			// The NULLs of this index column are not where an ordering on the
+			// column expects them.
+			break
+		}
 		indexOrder = append(indexOrder, opt.MakeOrderingColumn(idxColID, idx.Column(i).Descending))
 	}
 	// Check if the index ordering satisfies the postfix of the required
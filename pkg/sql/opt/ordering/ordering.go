@@ -29,15 +29,34 @@ func CanProvide(expr memo.RelExpr, required *props.OrderingChoice) bool {
 	if buildutil.CrdbTestBuild {
 		checkRequired(expr, required)
 	}
+	if required.HasNullsReversed() && !canProvideNullsReversed(expr) {
+		return false
+	}
 	return funcMap[expr.Op()].canProvideOrdering(expr, required)
 }
 
+// canProvideNullsReversed returns true if the given operator can provide an
+// ordering in which the NULLs of some columns are reversed. Only scans can
+// produce such orderings, and only operators that pass them through unchanged
+// can provide them from their input.
+func canProvideNullsReversed(expr memo.RelExpr) bool {
+	switch expr.Op() {
+	case opt.ScanOp, opt.SelectOp, opt.ProjectOp, opt.IndexJoinOp, opt.DistributeOp:
+		return true
+	}
+	return false
+}
+
 // CanEnforce returns true if the output of the given operator can be sorted
 // in order to satisfy the given required ordering.
 func CanEnforce(expr memo.RelExpr, required *props.OrderingChoice) bool {
 	if required.Any() {
 		return false
 	}
+	if required.HasNullsReversed() {
+		// Sorts always order NULLs before all other values in ascending order.
+		return false
+	}
 	if buildutil.CrdbTestBuild {
 		checkRequired(expr, required)
 	}
@@ -92,7 +111,20 @@ func BuildProvided(
 	}
 	provided := funcMap[expr.Op()].buildProvidedOrdering(expr, required)
 	if evalCtx.SessionData().OptimizerUseProvidedOrderingFix {
-		provided = finalizeProvided(provided, required, expr.Relational().OutputCols)
+		finalRequired := required
+		if required.HasNullsReversed() {
+			// The provided ordering can only represent the columns before the first
+			// column with reversed NULLs.
+			truncated := *required
+			for i := range truncated.Columns {
+				if truncated.Columns[i].NullsReversed {
+					truncated.Truncate(i)
+					break
+				}
+			}
+			finalRequired = &truncated
+		}
+		provided = finalizeProvided(provided, finalRequired, expr.Relational().OutputCols)
 	}
 
 	if buildutil.CrdbTestBuild {
@@ -31,7 +31,11 @@ func projectCanProvideOrdering(expr memo.RelExpr, required *props.OrderingChoice
 		simplified = required.Copy()
 		simplified.Simplify(fdSet)
 	}
-	return simplified.CanProjectCols(inputCols)
+	if simplified.CanProjectCols(inputCols) {
+		return true
+	}
+	_, ok := projectNullsReversedOrdering(proj, &simplified)
+	return ok
 }
 
 func projectBuildChildReqOrdering(
@@ -50,6 +54,11 @@ func projectBuildChildReqOrdering(
 		simplified = simplified.Copy()
 		simplified.Simplify(fdSet)
 	}
+	if !simplified.CanProjectCols(proj.Input.Relational().OutputCols) {
+		// The ordering can only be provided by reversing the NULLs of some input
+		// columns.
+		simplified, _ = projectNullsReversedOrdering(proj, &simplified)
+	}
 
 	// We may need to remove ordering columns that are not output by the input
 	// expression.
@@ -74,6 +83,64 @@ func projectOrderingToInput(
 	return result
 }
 
+// projectNullsReversedOrdering expresses an ordering in terms of the input
+// columns of a Project by reversing the NULLs of some input columns. The
+// optbuilder orders NULLs last in ascending order (or first in descending
+// order) by ordering on a synthesized "col IS NULL" column, followed by the
+// column itself in the same direction; for example +4,+1 where column 4 is
+// "col1 IS NULL". Each such pair is replaced with the input column with its
+// NULLs reversed, which can be provided by a scan of an index that stores the
+// NULLs of the column in the same position.
+//
+// Returns ok=false if the resulting ordering still refers to columns that are
+// not in the input.
+func projectNullsReversedOrdering(
+	proj *memo.ProjectExpr, ordering *props.OrderingChoice,
+) (_ props.OrderingChoice, ok bool) {
+	inputCols := proj.Input.Relational().OutputCols
+	result := props.OrderingChoice{
+		Optional: ordering.Optional,
+		Columns:  make([]props.OrderingColumnChoice, 0, len(ordering.Columns)),
+	}
+	for i := 0; i < len(ordering.Columns); i++ {
+		col := ordering.Columns[i]
+		if i+1 < len(ordering.Columns) && !col.Group.Intersects(inputCols) {
+			next := ordering.Columns[i+1]
+			if next.Descending == col.Descending && !next.NullsReversed &&
+				isNullsOrderingCol(proj, col.Group, next.Group) {
+				next.NullsReversed = true
+				result.Columns = append(result.Columns, next)
+				i++
+				continue
+			}
+		}
+		result.Columns = append(result.Columns, col)
+	}
+	if len(result.Columns) == len(ordering.Columns) || !result.CanProjectCols(inputCols) {
+		return props.OrderingChoice{}, false
+	}
+	return result, true
+}
+
+// isNullsOrderingCol returns true if the Project synthesizes one of the given
+// columns as "col IS NULL", where col is one of the given input columns.
+func isNullsOrderingCol(proj *memo.ProjectExpr, nullsCols, cols opt.ColSet) bool {
+	for i := range proj.Projections {
+		item := &proj.Projections[i]
+		if !nullsCols.Contains(item.Col) {
+			continue
+		}
+		is, ok := item.Element.(*memo.IsExpr)
+		if !ok || is.Right.Op() != opt.NullOp {
+			continue
+		}
+		if v, ok := is.Left.(*memo.VariableExpr); ok && cols.Contains(v.Col) {
+			return true
+		}
+	}
+	return false
+}
+
 func projectBuildProvided(expr memo.RelExpr, required *props.OrderingChoice) opt.Ordering {
 	// Ensure that the child provided ordering only refers to columns from the
 	// required ordering choice. This is necessary because there may be cases
@@ -90,6 +157,18 @@ func projectBuildProvided(expr memo.RelExpr, required *props.OrderingChoice) opt
 	// columns; it should always be possible to remap the columns in the input's
 	// provided ordering.
 	p := expr.(*memo.ProjectExpr)
+	simplified := *required
+	if fdSet := p.InternalFDs(); simplified.CanSimplify(fdSet) {
+		simplified = required.Copy()
+		simplified.Simplify(fdSet)
+	}
+	if !simplified.CanProjectCols(p.Input.Relational().OutputCols) {
+		// The input provides the ordering with the NULLs of some columns reversed
+		// (see projectNullsReversedOrdering), which its provided ordering cannot
+		// represent. The "col IS NULL" columns put the NULLs in place, so build
+		// the provided ordering from the required columns.
+		return finalizeProvided(nil /* provided */, &simplified, expr.Relational().OutputCols)
+	}
 	return remapProvided(
 		p.Input.ProvidedPhysical().Ordering,
 		p.InternalFDs(),
@@ -12,6 +12,8 @@ package ordering
 
 import (
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/constraint"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -99,6 +101,12 @@ func ScanPrivateCanProvide(
 			}
 			return false, false
 		}
+		if indexCol.NullsReversed != reqCol.NullsReversed && left >= s.ExactPrefix &&
+			!scanExcludesNulls(s, indexCol, left) {
+			// The NULLs of the index column are not where the required ordering
+			// expects them, in either scan direction.
+			return false, false
+		}
 		// The directions of the index column and the required column impose either
 		// a forward or a reverse scan.
 		required := fwd
@@ -152,6 +160,12 @@ func scanBuildProvided(expr memo.RelExpr, required *props.OrderingChoice) opt.Or
 			// Column not in output; we are done.
 			break
 		}
+		if indexCol.NullsReversed && i >= scan.ExactPrefix &&
+			!scanExcludesNulls(&scan.ScanPrivate, indexCol, i) {
+			// The NULLs of the column are not in the position that an ordering
+			// implies; we are done.
+			break
+		}
 		direction := indexCol.Descending != reverse // != is bool XOR
 		provided = append(provided, opt.MakeOrderingColumn(colID, direction))
 	}
@@ -159,6 +173,39 @@ func scanBuildProvided(expr memo.RelExpr, required *props.OrderingChoice) opt.Or
 	return trimProvided(provided, required, fds)
 }
 
+// scanExcludesNulls returns true if the index column at the given position
+// contains no NULLs in the rows returned by the scan, either because it is not
+// nullable or because the scan constraint excludes NULLs. The constraint is only
+// taken into account if the column immediately follows its exact prefix, in
+// which case the column has a single range of values within each span.
+func scanExcludesNulls(s *memo.ScanPrivate, indexCol cat.IndexColumn, ord int) bool {
+	if !indexCol.IsNullable() {
+		return true
+	}
+	c := s.Constraint
+	if c == nil || ord != s.ExactPrefix || ord >= c.Columns.Count() {
+		return false
+	}
+	for i, n := 0, c.Spans.Count(); i < n; i++ {
+		// NULLs sort before all other values of ascending columns and after all
+		// other values of descending columns, so only one boundary of each span
+		// needs to be checked.
+		sp := c.Spans.Get(i)
+		key, boundary := sp.StartKey(), sp.StartBoundary()
+		if indexCol.Descending {
+			key, boundary = sp.EndKey(), sp.EndBoundary()
+		}
+		if key.Length() <= ord {
+			return false
+		}
+		if key.Value(ord) == tree.DNull &&
+			(boundary == constraint.IncludeBoundary || key.Length() > ord+1) {
+			return false
+		}
+	}
+	return true
+}
+
 func init() {
 	memo.ScanIsReverseFn = func(
 		md *opt.Metadata, s *memo.ScanPrivate, required *props.OrderingChoice,
@@ -30,7 +30,7 @@ import (
 func TestScan(t *testing.T) {
 	tc := testcat.New()
 	if _, err := tc.ExecuteDDL(
-		"CREATE TABLE t (c1 INT, c2 INT, c3 INT, c4 INT, PRIMARY KEY(c1, c2), INDEX(c3 DESC, c4))",
+		"CREATE TABLE t (c1 INT, c2 INT, c3 INT, c4 INT, PRIMARY KEY(c1, c2), INDEX(c3 DESC, c4), INDEX(c4 NULLS LAST, c3))",
 	); err != nil {
 		t.Fatal(err)
 	}
@@ -60,6 +60,23 @@ func TestScan(t *testing.T) {
 	)
 	c.InitSingleSpan(&keyCtx, &span)
 
+	// Make constraints for the index with reversed NULLs, one of which excludes
+	// NULLs and one of which does not.
+	var nullsColumns constraint.Columns
+	nullsColumns.Init([]opt.OrderingColumn{+4, +3, +1, +2})
+	nullsKeyCtx := constraint.MakeKeyContext(&nullsColumns, evalCtx)
+	var notNullC, nullC constraint.Constraint
+	span.Init(
+		constraint.MakeKey(tree.NewDInt(1)), constraint.IncludeBoundary,
+		constraint.MakeKey(tree.NewDInt(5)), constraint.IncludeBoundary,
+	)
+	notNullC.InitSingleSpan(&nullsKeyCtx, &span)
+	span.Init(
+		constraint.MakeKey(tree.DNull), constraint.IncludeBoundary,
+		constraint.MakeKey(tree.NewDInt(5)), constraint.IncludeBoundary,
+	)
+	nullC.InitSingleSpan(&nullsKeyCtx, &span)
+
 	// We have groups of test cases for various ScanPrivates.
 	type testCase struct {
 		req  string // required ordering
@@ -167,6 +184,43 @@ func TestScan(t *testing.T) {
 				{req: "-(1|2) opt(3,4)", exp: "rev", prov: "-4,-1"}, // case 5
 			},
 		},
+		{ // group 6: index scan with reversed NULLs.
+			p: memo.ScanPrivate{
+				Table: tab,
+				Index: 2,
+				Cols:  opt.MakeColSet(1, 2, 3, 4),
+			},
+			cases: []testCase{
+				{req: "", exp: "fwd", prov: ""}, // case 1
+				{req: "+4", exp: "no"},          // case 2
+				{req: "-4", exp: "no"},          // case 3
+			},
+		},
+		{ // group 7: index scan with reversed NULLs and constraint excluding NULLs.
+			p: memo.ScanPrivate{
+				Table: tab,
+				Index: 2,
+				Cols:  opt.MakeColSet(1, 2, 3, 4),
+			},
+			c: &notNullC,
+			cases: []testCase{
+				{req: "+4", exp: "fwd", prov: "+4"},       // case 1
+				{req: "-4,-3", exp: "rev", prov: "-4,-3"}, // case 2
+				{req: "+4,-3", exp: "no"},                 // case 3
+			},
+		},
+		{ // group 8: index scan with reversed NULLs and constraint including NULLs.
+			p: memo.ScanPrivate{
+				Table: tab,
+				Index: 2,
+				Cols:  opt.MakeColSet(1, 2, 3, 4),
+			},
+			c: &nullC,
+			cases: []testCase{
+				{req: "", exp: "fwd", prov: ""}, // case 1
+				{req: "+4", exp: "no"},          // case 2
+			},
+		},
 	}
 
 	for gIdx, g := range tests {
@@ -85,6 +85,15 @@ type OrderingColumnChoice struct {
 	// Descending is true if the sort key column is ordered from highest to
 	// lowest. Otherwise, it's ordered from lowest to highest.
 	Descending bool
+
+	// NullsReversed is true if NULLs are ordered after all other values of an
+	// ascending column, or before all other values of a descending column. Such
+	// a column cannot be represented by an opt.Ordering or provided by a Sort;
+	// it is only required of the input of a Project that orders on a "col IS
+	// NULL" projection followed by the column itself, and it can only be
+	// provided by a scan of an index that places the column's NULLs the same
+	// way.
+	NullsReversed bool
 }
 
 const (
@@ -303,7 +312,7 @@ func (oc *OrderingChoice) Implies(other *OrderingChoice) bool {
 		leftCol, rightCol := &oc.Columns[left], &other.Columns[right]
 
 		switch {
-		case leftCol.Descending == rightCol.Descending && leftCol.Group.SubsetOf(rightCol.Group):
+		case leftCol.matchesDirection(rightCol) && leftCol.Group.SubsetOf(rightCol.Group):
 			// The columns match.
 			optional.unionWith(rightCol.Group)
 			left, right = left+1, right+1
@@ -330,7 +339,7 @@ func (oc *OrderingChoice) Intersects(other *OrderingChoice) bool {
 	for left, right := 0, 0; left < len(oc.Columns) && right < len(other.Columns); {
 		leftCol, rightCol := &oc.Columns[left], &other.Columns[right]
 		switch {
-		case leftCol.Descending == rightCol.Descending && leftCol.Group.Intersects(rightCol.Group):
+		case leftCol.matchesDirection(rightCol) && leftCol.Group.Intersects(rightCol.Group):
 			// The columns match.
 			leftOptional.unionWith(leftCol.Group)
 			rightOptional.unionWith(rightCol.Group)
@@ -395,11 +404,12 @@ func (oc *OrderingChoice) Intersection(other *OrderingChoice) OrderingChoice {
 		leftCol, rightCol := &oc.Columns[left], &other.Columns[right]
 
 		switch {
-		case leftCol.Descending == rightCol.Descending && leftCol.Group.Intersects(rightCol.Group):
+		case leftCol.matchesDirection(rightCol) && leftCol.Group.Intersects(rightCol.Group):
 			// The columns match.
 			result = append(result, OrderingColumnChoice{
-				Group:      leftCol.Group.Intersection(rightCol.Group),
-				Descending: leftCol.Descending,
+				Group:         leftCol.Group.Intersection(rightCol.Group),
+				Descending:    leftCol.Descending,
+				NullsReversed: leftCol.NullsReversed,
 			})
 			leftOptional.unionWith(leftCol.Group)
 			rightOptional.unionWith(rightCol.Group)
@@ -408,8 +418,9 @@ func (oc *OrderingChoice) Intersection(other *OrderingChoice) OrderingChoice {
 		case rightOptional.intersects(leftCol.Group):
 			// Left column is optional in the right set.
 			result = append(result, OrderingColumnChoice{
-				Group:      rightOptional.intersection(leftCol.Group),
-				Descending: leftCol.Descending,
+				Group:         rightOptional.intersection(leftCol.Group),
+				Descending:    leftCol.Descending,
+				NullsReversed: leftCol.NullsReversed,
 			})
 			leftOptional.unionWith(leftCol.Group)
 			left++
@@ -417,8 +428,9 @@ func (oc *OrderingChoice) Intersection(other *OrderingChoice) OrderingChoice {
 		case leftOptional.intersects(rightCol.Group):
 			// Right column is optional in the left set.
 			result = append(result, OrderingColumnChoice{
-				Group:      leftOptional.intersection(rightCol.Group),
-				Descending: rightCol.Descending,
+				Group:         leftOptional.intersection(rightCol.Group),
+				Descending:    rightCol.Descending,
+				NullsReversed: rightCol.NullsReversed,
 			})
 			rightOptional.unionWith(rightCol.Group)
 			right++
@@ -475,11 +487,12 @@ func (oc *OrderingChoice) CommonPrefix(other *OrderingChoice) OrderingChoice {
 		leftCol, rightCol := &oc.Columns[left], &other.Columns[right]
 
 		switch {
-		case leftCol.Descending == rightCol.Descending && leftCol.Group.Intersects(rightCol.Group):
+		case leftCol.matchesDirection(rightCol) && leftCol.Group.Intersects(rightCol.Group):
 			// The columns match.
 			result = append(result, OrderingColumnChoice{
-				Group:      leftCol.Group.Intersection(rightCol.Group),
-				Descending: leftCol.Descending,
+				Group:         leftCol.Group.Intersection(rightCol.Group),
+				Descending:    leftCol.Descending,
+				NullsReversed: leftCol.NullsReversed,
 			})
 			leftOptional.unionWith(leftCol.Group)
 			rightOptional.unionWith(rightCol.Group)
@@ -488,8 +501,9 @@ func (oc *OrderingChoice) CommonPrefix(other *OrderingChoice) OrderingChoice {
 		case rightOptional.intersects(leftCol.Group):
 			// Left column is optional in the right set.
 			result = append(result, OrderingColumnChoice{
-				Group:      rightOptional.intersection(leftCol.Group),
-				Descending: leftCol.Descending,
+				Group:         rightOptional.intersection(leftCol.Group),
+				Descending:    leftCol.Descending,
+				NullsReversed: leftCol.NullsReversed,
 			})
 			leftOptional.unionWith(leftCol.Group)
 			left++
@@ -497,8 +511,9 @@ func (oc *OrderingChoice) CommonPrefix(other *OrderingChoice) OrderingChoice {
 		case leftOptional.intersects(rightCol.Group):
 			// Right column is optional in the left set.
 			result = append(result, OrderingColumnChoice{
-				Group:      leftOptional.intersection(rightCol.Group),
-				Descending: rightCol.Descending,
+				Group:         leftOptional.intersection(rightCol.Group),
+				Descending:    rightCol.Descending,
+				NullsReversed: rightCol.NullsReversed,
 			})
 			rightOptional.unionWith(rightCol.Group)
 			right++
@@ -523,8 +538,9 @@ func (oc *OrderingChoice) CommonPrefix(other *OrderingChoice) OrderingChoice {
 			break
 		}
 		result = append(result, OrderingColumnChoice{
-			Group:      leftOptional.intersection(rightCol.Group),
-			Descending: rightCol.Descending,
+			Group:         leftOptional.intersection(rightCol.Group),
+			Descending:    rightCol.Descending,
+			NullsReversed: rightCol.NullsReversed,
 		})
 	}
 	var optional opt.ColSet
@@ -554,7 +570,7 @@ func (oc *OrderingChoice) commonPrefixLength(other *OrderingChoice) (length int,
 		leftCol, rightCol := &oc.Columns[left], &other.Columns[right]
 
 		switch {
-		case leftCol.Descending == rightCol.Descending && leftCol.Group.Intersects(rightCol.Group):
+		case leftCol.matchesDirection(rightCol) && leftCol.Group.Intersects(rightCol.Group):
 			// The columns match.
 			length++
 			leftOptional.unionWith(leftCol.Group)
@@ -637,7 +653,7 @@ func (oc *OrderingChoice) MatchesAt(index int, col opt.OrderingColumn) bool {
 		return true
 	}
 	choice := &oc.Columns[index]
-	if choice.Descending != col.Descending() {
+	if choice.Descending != col.Descending() || choice.NullsReversed {
 		return false
 	}
 	if !choice.Group.Contains(col.ID()) {
@@ -874,7 +890,7 @@ func (oc OrderingChoice) PrefixIntersection(
 			return result, true
 		case prefixHelper.empty() && len(oc.Columns) > 0 && len(suffix) > 0 &&
 			oc.Group(0).Intersects(suffix[0].Group) &&
-			oc.Columns[0].Descending == suffix[0].Descending:
+			oc.Columns[0].matchesDirection(&suffix[0]):
 			// <prefix> is empty, and <suffix> and <oc> agree on the first column, so
 			// emit that column, remove it from both, and loop.
 			newCol := oc.Columns[0]
@@ -911,7 +927,7 @@ func (oc *OrderingChoice) Equals(rhs *OrderingChoice) bool {
 		left := &oc.Columns[i]
 		y := &rhs.Columns[i]
 
-		if left.Descending != y.Descending {
+		if !left.matchesDirection(y) {
 			return false
 		}
 		if !left.Group.Equals(y.Group) {
@@ -943,6 +959,9 @@ func (oc OrderingChoice) String() string {
 //	+(1|2)
 //	+(1|2),+3
 //	-(3|4),+5 opt(1,2)
+//
+// Columns whose NULLs are reversed are followed by "nulls last" or "nulls
+// first" (e.g. +1 nulls last,+2), which ParseOrderingChoice does not accept.
 func (oc OrderingChoice) Format(buf *bytes.Buffer) {
 	for g := range oc.Columns {
 		group := &oc.Columns[g]
@@ -971,6 +990,14 @@ func (oc OrderingChoice) Format(buf *bytes.Buffer) {
 			buf.WriteByte(')')
 		}
 
+		if group.NullsReversed {
+			if group.Descending {
+				buf.WriteString(" nulls first")
+			} else {
+				buf.WriteString(" nulls last")
+			}
+		}
+
 		if g+1 != len(oc.Columns) {
 			buf.WriteByte(',')
 		}
@@ -994,13 +1021,25 @@ func (oc *OrderingChoice) RemapColumns(from, to opt.ColList) OrderingChoice {
 	for i := range oc.Columns {
 		col := &oc.Columns[i]
 		other.Columns[i] = OrderingColumnChoice{
-			Group:      opt.TranslateColSetStrict(col.Group, from, to),
-			Descending: col.Descending,
+			Group:         opt.TranslateColSetStrict(col.Group, from, to),
+			Descending:    col.Descending,
+			NullsReversed: col.NullsReversed,
 		}
 	}
 	return other
 }
 
+// HasNullsReversed returns true if the NULLs of any of the ordering columns
+// are reversed. See OrderingColumnChoice.NullsReversed.
+func (oc *OrderingChoice) HasNullsReversed() bool {
+	for i := range oc.Columns {
+		if oc.Columns[i].NullsReversed {
+			return true
+		}
+	}
+	return false
+}
+
 // AnyID returns the ID of an arbitrary member of the group of equivalent
 // columns.
 func (oc *OrderingColumnChoice) AnyID() opt.ColumnID {
@@ -1011,6 +1050,12 @@ func (oc *OrderingColumnChoice) AnyID() opt.ColumnID {
 	return id
 }
 
+// matchesDirection returns true if the two column choices order their columns
+// in the same direction, with NULLs in the same position.
+func (oc *OrderingColumnChoice) matchesDirection(other *OrderingColumnChoice) bool {
+	return oc.Descending == other.Descending && oc.NullsReversed == other.NullsReversed
+}
+
 // OrderingSet is a set of orderings, with the restriction that no ordering
 // is a prefix of another ordering in the set.
 type OrderingSet []OrderingChoice
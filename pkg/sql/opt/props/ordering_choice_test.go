@@ -671,6 +671,35 @@ func TestOrderingChoice_Equals(t *testing.T) {
 	}
 }
 
+func TestOrderingChoice_NullsReversed(t *testing.T) {
+	plain := props.ParseOrderingChoice("+1,-2")
+	reversed := plain.Copy()
+	reversed.Columns[1].NullsReversed = true
+
+	if plain.HasNullsReversed() || !reversed.HasNullsReversed() {
+		t.Errorf("expected only %s to have reversed NULLs", reversed)
+	}
+	if actual := reversed.String(); actual != "+1,-2 nulls first" {
+		t.Errorf("unexpected format %s", actual)
+	}
+	if plain.Equals(&reversed) {
+		t.Errorf("expected %s to not equal %s", plain, reversed)
+	}
+	if plain.Implies(&reversed) || reversed.Implies(&plain) {
+		t.Errorf("expected %s and %s to not imply each other", plain, reversed)
+	}
+	if !reversed.Implies(&reversed) {
+		t.Errorf("expected %s to imply itself", reversed)
+	}
+	prefix := props.ParseOrderingChoice("+1")
+	if !reversed.Implies(&prefix) {
+		t.Errorf("expected %s to imply %s", reversed, prefix)
+	}
+	if actual := prefix.Intersection(&reversed); !actual.Equals(&reversed) {
+		t.Errorf("expected intersection %s, got %s", reversed, actual)
+	}
+}
+
 func TestOrderingChoice_PrefixIntersection(t *testing.T) {
 	testcases := []struct {
 		x        string
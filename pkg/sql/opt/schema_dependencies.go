@@ -46,6 +46,19 @@ type SchemaDep struct {
 // this object depends on.
 type SchemaTypeDeps = intsets.Fast
 
+// SchemaTextSearchDeps contains the text search configurations and
+// dictionaries that this object passes to text search builtins, other than
+// the built-in ones.
+type SchemaTextSearchDeps []TextSearchDep
+
+// TextSearchDep is a text search configuration or dictionary passed to a text
+// search builtin. Name is empty if the argument isn't a constant, in which case
+// the builtin may use any object of the kind.
+type TextSearchDep struct {
+	Name       string
+	Dictionary bool
+}
+
 // GetColumnNames returns a sorted list of the names of the column dependencies
 // and a boolean to determine if the dependency was a table.
 // We only track column dependencies on tables.
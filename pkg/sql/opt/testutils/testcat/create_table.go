@@ -361,7 +361,9 @@ func (tc *Catalog) CreateTable(stmt *tree.CreateTable) *Table {
 		switch def := def.(type) {
 		case *tree.UniqueConstraintTableDef:
 			if def.WithoutIndex {
-				tab.addUniqueConstraint(def.Name, def.Columns, def.Predicate, def.WithoutIndex)
+				tab.addUniqueConstraint(
+					def.Name, def.Columns, def.Predicate, def.WithoutIndex, def.Deferrability,
+				)
 			} else if !def.PrimaryKey {
 				tab.addIndex(&def.IndexTableDef, uniqueIndex)
 			}
@@ -369,6 +371,9 @@ func (tc *Catalog) CreateTable(stmt *tree.CreateTable) *Table {
 		case *tree.IndexTableDef:
 			tab.addIndex(def, nonUniqueIndex)
 
+		case *tree.ExclusionConstraintTableDef:
+			tab.addExclusionConstraint(def)
+
 		case *tree.FamilyTableDef:
 			tab.addFamily(def)
 
@@ -380,6 +385,7 @@ func (tc *Catalog) CreateTable(stmt *tree.CreateTable) *Table {
 						tree.IndexElemList{{Column: def.Name}},
 						nil, /* predicate */
 						def.Unique.WithoutIndex,
+						tree.ConstraintDeferrability{},
 					)
 				} else {
 					tab.addIndex(
@@ -681,13 +687,19 @@ func (tc *Catalog) resolveFK(tab *Table, d *tree.ForeignKeyConstraintTableDef) {
 		matchMethod:              d.Match,
 		deleteAction:             d.Actions.Delete,
 		updateAction:             d.Actions.Update,
+		deferrable:               d.Deferrability.Deferrable,
+		initiallyDeferred:        d.Deferrability.InitiallyDeferred,
 	}
 	tab.outboundFKs = append(tab.outboundFKs, fk)
 	targetTable.inboundFKs = append(targetTable.inboundFKs, fk)
 }
 
 func (tt *Table) addUniqueConstraint(
-	name tree.Name, columns tree.IndexElemList, predicate tree.Expr, withoutIndex bool,
+	name tree.Name,
+	columns tree.IndexElemList,
+	predicate tree.Expr,
+	withoutIndex bool,
+	deferrability tree.ConstraintDeferrability,
 ) {
 	// We don't currently use unique constraints with an index (those are already
 	// tracked with unique indexes), so don't bother adding them.
@@ -708,6 +720,9 @@ func (tt *Table) addUniqueConstraint(
 		columnOrdinals: cols,
 		withoutIndex:   withoutIndex,
 		validated:      true,
+
+		deferrable:        deferrability.Deferrable,
+		initiallyDeferred: deferrability.InitiallyDeferred,
 	}
 	// Add partial unique constraint predicate.
 	if predicate != nil {
@@ -726,6 +741,49 @@ func (tt *Table) addUniqueConstraint(
 	tt.uniqueConstraints = append(tt.uniqueConstraints, u)
 }
 
+// addExclusionConstraint adds an exclusion constraint, which is represented
+// as a unique constraint without an index with exclusion elements. Unlike
+// opt_catalog.go, the supporting index is not added.
+func (tt *Table) addExclusionConstraint(def *tree.ExclusionConstraintTableDef) {
+	var cols []int
+	for i := range def.Elems {
+		for _, name := range def.Elems[i].Columns {
+			cols = append(cols, tt.FindOrdinal(string(name)))
+		}
+	}
+	sort.Ints(cols)
+
+	name := string(def.Name)
+	if name == "" {
+		name = fmt.Sprintf("excl%d", len(tt.uniqueConstraints)+1)
+	}
+	u := UniqueConstraint{
+		name:              name,
+		tabID:             tt.TabID,
+		columnOrdinals:    cols,
+		withoutIndex:      true,
+		validated:         true,
+		exclusionElements: make([]cat.ExclusionElement, len(def.Elems)),
+	}
+	for i := range def.Elems {
+		elem := &u.exclusionElements[i]
+		elem.Overlaps = def.Elems[i].Operator.Symbol == treecmp.Overlaps
+		for _, colName := range def.Elems[i].Columns {
+			ord := tt.FindOrdinal(string(colName))
+			for j := range cols {
+				if cols[j] == ord {
+					elem.Columns = append(elem.Columns, j)
+					break
+				}
+			}
+		}
+	}
+	if def.Predicate != nil {
+		u.predicate = tree.Serialize(def.Predicate)
+	}
+	tt.uniqueConstraints = append(tt.uniqueConstraints, u)
+}
+
 func (tt *Table) addColumn(def *tree.ColumnTableDef) {
 	ordinal := len(tt.Columns)
 	nullable := !def.PrimaryKey.IsPrimaryKey && def.Nullable.Nullability != tree.NotNull
@@ -848,7 +906,9 @@ func (tt *Table) addIndexWithVersion(
 ) *Index {
 	// Add a unique constraint if this is a primary or unique index.
 	if typ != nonUniqueIndex {
-		tt.addUniqueConstraint(def.Name, def.Columns, def.Predicate, false /* withoutIndex */)
+		tt.addUniqueConstraint(
+			def.Name, def.Columns, def.Predicate, false /* withoutIndex */, tree.ConstraintDeferrability{},
+		)
 	}
 
 	// The test catalog does not support the hash-sharded index syntactic sugar.
@@ -1219,7 +1279,15 @@ func (ti *Index) addColumn(
 		ordinal = col.Ordinal()
 	}
 
-	return ti.addColumnByOrdinal(tt, ordinal, elem.Direction, colType)
+	col := ti.addColumnByOrdinal(tt, ordinal, elem.Direction, colType)
+	if colType == keyCol || colType == strictKeyCol {
+		// NULLs are first in ascending columns and last in descending columns,
+		// unless the index element specifies otherwise.
+		ti.Columns[len(ti.Columns)-1].NullsReversed =
+			(elem.Direction == tree.Descending && elem.NullsOrder == tree.NullsFirst) ||
+				(elem.Direction != tree.Descending && elem.NullsOrder == tree.NullsLast)
+	}
+	return col
 }
 
 // columnForIndexElemExpr returns a VirtualComputed table column that can be
@@ -304,6 +304,13 @@ func (tc *Catalog) CheckExecutionPrivilege(ctx context.Context, oid oid.Oid) err
 	return nil
 }
 
+// WithRoutineExecContext is part of the cat.Catalog interface.
+func (tc *Catalog) WithRoutineExecContext(
+	ctx context.Context, execCtx *tree.RoutineExecContext, fn func() error,
+) error {
+	return fn()
+}
+
 // HasAdminRole is part of the cat.Catalog interface.
 func (tc *Catalog) HasAdminRole(ctx context.Context) (bool, error) {
 	return true, nil
@@ -718,6 +725,7 @@ type Table struct {
 	Indexes    []*Index
 	Stats      TableStats
 	Checks     []cat.CheckConstraint
+	Triggers   []cat.Trigger
 	Families   []*Family
 	IsVirtual  bool
 	IsSystem   bool
@@ -854,6 +862,16 @@ func (tt *Table) Check(i int) cat.CheckConstraint {
 	return tt.Checks[i]
 }
 
+// TriggerCount is part of the cat.Table interface.
+func (tt *Table) TriggerCount() int {
+	return len(tt.Triggers)
+}
+
+// Trigger is part of the cat.Table interface.
+func (tt *Table) Trigger(i int) cat.Trigger {
+	return tt.Triggers[i]
+}
+
 // FamilyCount is part of the cat.Table interface.
 func (tt *Table) FamilyCount() int {
 	return len(tt.Families)
@@ -1401,6 +1419,9 @@ type ForeignKeyConstraint struct {
 	matchMethod  tree.CompositeKeyMatchMethod
 	deleteAction tree.ReferenceAction
 	updateAction tree.ReferenceAction
+
+	deferrable        bool
+	initiallyDeferred bool
 }
 
 var _ cat.ForeignKeyConstraint = &ForeignKeyConstraint{}
@@ -1450,7 +1471,7 @@ func (fk *ForeignKeyConstraint) ReferencedColumnOrdinal(referencedTable cat.Tabl
 
 // Validated is part of the cat.ForeignKeyConstraint interface.
 func (fk *ForeignKeyConstraint) Validated() bool {
-	return fk.validated
+	return fk.validated && !fk.deferrable
 }
 
 // MatchMethod is part of the cat.ForeignKeyConstraint interface.
@@ -1468,6 +1489,16 @@ func (fk *ForeignKeyConstraint) UpdateReferenceAction() tree.ReferenceAction {
 	return fk.updateAction
 }
 
+// Deferrable is part of the cat.ForeignKeyConstraint interface.
+func (fk *ForeignKeyConstraint) Deferrable() bool {
+	return fk.deferrable
+}
+
+// InitiallyDeferred is part of the cat.ForeignKeyConstraint interface.
+func (fk *ForeignKeyConstraint) InitiallyDeferred() bool {
+	return fk.initiallyDeferred
+}
+
 // UniqueConstraint implements cat.UniqueConstraint. See that interface
 // for more information on the fields.
 type UniqueConstraint struct {
@@ -1477,6 +1508,11 @@ type UniqueConstraint struct {
 	predicate      string
 	withoutIndex   bool
 	validated      bool
+
+	exclusionElements []cat.ExclusionElement
+
+	deferrable        bool
+	initiallyDeferred bool
 }
 
 var _ cat.UniqueConstraint = &UniqueConstraint{}
@@ -1519,7 +1555,7 @@ func (u *UniqueConstraint) WithoutIndex() bool {
 
 // Validated is part of the cat.UniqueConstraint interface.
 func (u *UniqueConstraint) Validated() bool {
-	return u.validated
+	return u.validated && !u.deferrable
 }
 
 // UniquenessGuaranteedByAnotherIndex is part of the cat.UniqueConstraint
@@ -1528,6 +1564,26 @@ func (u *UniqueConstraint) UniquenessGuaranteedByAnotherIndex() bool {
 	return false
 }
 
+// ExclusionElementCount is part of the cat.UniqueConstraint interface.
+func (u *UniqueConstraint) ExclusionElementCount() int {
+	return len(u.exclusionElements)
+}
+
+// ExclusionElement is part of the cat.UniqueConstraint interface.
+func (u *UniqueConstraint) ExclusionElement(i int) cat.ExclusionElement {
+	return u.exclusionElements[i]
+}
+
+// Deferrable is part of the cat.UniqueConstraint interface.
+func (u *UniqueConstraint) Deferrable() bool {
+	return u.deferrable
+}
+
+// InitiallyDeferred is part of the cat.UniqueConstraint interface.
+func (u *UniqueConstraint) InitiallyDeferred() bool {
+	return u.initiallyDeferred
+}
+
 // Sequence implements the cat.Sequence interface for testing purposes.
 type Sequence struct {
 	SeqID      cat.StableID
@@ -187,8 +187,19 @@ func (c *CustomFuncs) initIdxConstraintForIndex(
 	ps := tabMeta.IndexPartitionLocality(index.Ordinal())
 	columns := make([]opt.OrderingColumn, index.LaxKeyColumnCount())
 	var notNullCols opt.ColSet
+	nullsReversed := false
 	for i := range columns {
 		col := index.Column(i)
+		if col.NullsReversed && col.IsNullable() {
+			// Spans are only converted to index keys across a single column whose
+			// NULLs are not in their default position, so constrain no further
+			// than the column before the second such column.
+			if nullsReversed {
+				columns = columns[:i]
+				break
+			}
+			nullsReversed = true
+		}
 		ordinal := col.Ordinal()
 		nullable := col.IsNullable()
 		colID := tabID.ColumnID(ordinal)
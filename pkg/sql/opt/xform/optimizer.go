@@ -544,6 +544,13 @@ func (o *Optimizer) optimizeGroup(grp memo.RelExpr, required *physical.Required)
 		}
 	}
 
+	if state.best == nil {
+		// None of the group members can provide the required properties, and they
+		// cannot be enforced (see ordering.CanEnforce). Make sure that no parent
+		// expression that requires them is chosen.
+		state.cost = memo.MaxCost
+	}
+
 	return state
 }
 
@@ -13,6 +13,7 @@ package sql
 import (
 	"context"
 	"math"
+	"sort"
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/clusterversion"
@@ -438,6 +439,13 @@ func (oc *optCatalog) CheckExecutionPrivilege(ctx context.Context, oid oid.Oid)
 	return oc.planner.CheckPrivilege(ctx, desc, privilege.EXECUTE)
 }
 
+// WithRoutineExecContext is part of the cat.Catalog interface.
+func (oc *optCatalog) WithRoutineExecContext(
+	ctx context.Context, execCtx *tree.RoutineExecContext, fn func() error,
+) error {
+	return oc.planner.withRoutineExecContext(ctx, execCtx, fn)
+}
+
 // HasAdminRole is part of the cat.Catalog interface.
 func (oc *optCatalog) HasAdminRole(ctx context.Context) (bool, error) {
 	return oc.planner.HasAdminRole(ctx)
@@ -534,6 +542,14 @@ func (oc *optCatalog) dataSourceForDesc(
 	case desc.IsSequence():
 		ds = newOptSequence(desc)
 
+	case desc.IsForeignTable():
+		// Foreign tables have no indexes of their own; like virtual tables, their
+		// rows are produced by a generator when they are scanned.
+		var err error
+		if ds, err = newOptVirtualTable(ctx, oc, desc, name); err != nil {
+			return nil, err
+		}
+
 	default:
 		return nil, errors.AssertionFailedf("unexpected table descriptor: %+v", desc)
 	}
@@ -774,6 +790,10 @@ type optTable struct {
 	// constraints for user defined types.
 	checkConstraints []cat.CheckConstraint
 
+	// triggers is the set of triggers for this table, sorted by name, which is
+	// the order in which they fire.
+	triggers []cat.Trigger
+
 	// colMap is a mapping from unique ColumnID to column ordinal within the
 	// table. This is a common lookup that needs to be fast.
 	colMap catalog.TableColMap
@@ -913,6 +933,14 @@ func newOptTable(
 			predicate:    u.GetPredicate(),
 			withoutIndex: true,
 			validity:     u.GetConstraintValidity(),
+
+			deferrable:        u.UniqueWithoutIndexDesc().Deferrable,
+			initiallyDeferred: u.UniqueWithoutIndexDesc().InitiallyDeferred,
+		}
+		if u.IsExclusionConstraint() {
+			ot.uniqueConstraints[i].exclusionElements = makeOptExclusionElements(
+				u.UniqueWithoutIndexDesc().ExclusionElements, ot.uniqueConstraints[i].columns,
+			)
 		}
 	}
 
@@ -1017,6 +1045,8 @@ func newOptTable(
 			match:             tree.CompositeKeyMatchMethodType[fk.Match()],
 			deleteAction:      tree.ForeignKeyReferenceActionType[fk.OnDelete()],
 			updateAction:      tree.ForeignKeyReferenceActionType[fk.OnUpdate()],
+			deferrable:        fk.ForeignKeyDesc().Deferrable,
+			initiallyDeferred: fk.ForeignKeyDesc().InitiallyDeferred,
 		})
 	}
 	for _, fk := range ot.desc.InboundForeignKeys() {
@@ -1030,6 +1060,8 @@ func newOptTable(
 			match:             tree.CompositeKeyMatchMethodType[fk.Match()],
 			deleteAction:      tree.ForeignKeyReferenceActionType[fk.OnDelete()],
 			updateAction:      tree.ForeignKeyReferenceActionType[fk.OnUpdate()],
+			deferrable:        fk.ForeignKeyDesc().Deferrable,
+			initiallyDeferred: fk.ForeignKeyDesc().InitiallyDeferred,
 		})
 	}
 
@@ -1075,6 +1107,17 @@ func newOptTable(
 	}
 	ot.checkConstraints = append(ot.checkConstraints, synthesizedChecks...)
 
+	// Add the triggers in the order in which they fire.
+	if triggers := desc.GetTriggers(); len(triggers) > 0 {
+		ot.triggers = make([]cat.Trigger, len(triggers))
+		for i := range triggers {
+			ot.triggers[i] = ot.makeTrigger(&triggers[i])
+		}
+		sort.Slice(ot.triggers, func(i, j int) bool {
+			return ot.triggers[i].Name < ot.triggers[j].Name
+		})
+	}
+
 	// Add stats last, now that other metadata is initialized.
 	if stats != nil {
 		ot.stats = make([]optTableStat, len(stats))
@@ -1257,6 +1300,49 @@ func (ot *optTable) Check(i int) cat.CheckConstraint {
 	return ot.checkConstraints[i]
 }
 
+// makeTrigger converts a trigger descriptor into a cat.Trigger.
+func (ot *optTable) makeTrigger(trig *descpb.TriggerDescriptor) cat.Trigger {
+	res := cat.Trigger{
+		Name:     tree.Name(trig.Name),
+		WhenExpr: trig.WhenExpr,
+		FuncID:   trig.FuncID,
+		FuncArgs: trig.FuncArgs,
+	}
+	if trig.ActionTime == descpb.TriggerDescriptor_AFTER {
+		res.ActionTime = tree.TriggerActionTimeAfter
+	} else {
+		res.ActionTime = tree.TriggerActionTimeBefore
+	}
+	res.Events = make([]cat.TriggerEvent, len(trig.Events))
+	for i := range trig.Events {
+		ev := &trig.Events[i]
+		switch ev.Type {
+		case descpb.TriggerDescriptor_Event_INSERT:
+			res.Events[i].Type = tree.TriggerEventInsert
+		case descpb.TriggerDescriptor_Event_UPDATE:
+			res.Events[i].Type = tree.TriggerEventUpdate
+		case descpb.TriggerDescriptor_Event_DELETE:
+			res.Events[i].Type = tree.TriggerEventDelete
+		}
+		for _, colID := range ev.ColumnIDs {
+			if ord, ok := ot.colMap.Get(colID); ok {
+				res.Events[i].ColumnOrdinals = append(res.Events[i].ColumnOrdinals, ord)
+			}
+		}
+	}
+	return res
+}
+
+// TriggerCount is part of the cat.Table interface.
+func (ot *optTable) TriggerCount() int {
+	return len(ot.triggers)
+}
+
+// Trigger is part of the cat.Table interface.
+func (ot *optTable) Trigger(i int) cat.Trigger {
+	return ot.triggers[i]
+}
+
 // FamilyCount is part of the cat.Table interface.
 func (ot *optTable) FamilyCount() int {
 	return 1 + len(ot.families)
@@ -1632,11 +1718,14 @@ func (oi *optIndex) NonInvertedPrefixColumnCount() int {
 // Column is part of the cat.Index interface.
 func (oi *optIndex) Column(i int) cat.IndexColumn {
 	ord := oi.columnOrds[i]
-	// Only key columns have a direction.
-	descending := i < oi.idx.NumKeyColumns() && oi.idx.GetKeyColumnDirection(i) == catenumpb.IndexColumn_DESC
+	// Only key columns have a direction and a NULLs order.
+	isKey := i < oi.idx.NumKeyColumns()
+	descending := isKey && oi.idx.GetKeyColumnDirection(i) == catenumpb.IndexColumn_DESC
+	nullsReversed := isKey && oi.idx.GetKeyColumnNullsOrder(i) == catenumpb.IndexColumn_NULLS_REVERSED
 	return cat.IndexColumn{
-		Column:     oi.tab.Column(ord),
-		Descending: descending,
+		Column:        oi.tab.Column(ord),
+		Descending:    descending,
+		NullsReversed: nullsReversed,
 	}
 }
 
@@ -1893,10 +1982,37 @@ type optUniqueConstraint struct {
 	validity     descpb.ConstraintValidity
 
 	uniquenessGuaranteedByAnotherIndex bool
+
+	// exclusionElements is non-empty if this is an exclusion constraint.
+	exclusionElements []cat.ExclusionElement
+
+	deferrable        bool
+	initiallyDeferred bool
 }
 
 var _ cat.UniqueConstraint = &optUniqueConstraint{}
 
+// makeOptExclusionElements converts the elements of an exclusion constraint
+// into cat.ExclusionElements that refer to positions within the given
+// constraint columns.
+func makeOptExclusionElements(
+	elems []descpb.ExclusionElement, columns []descpb.ColumnID,
+) []cat.ExclusionElement {
+	res := make([]cat.ExclusionElement, len(elems))
+	for i := range elems {
+		res[i].Overlaps = elems[i].Operator == descpb.ExclusionOperatorOverlaps
+		for _, colID := range elems[i].ColumnIDs {
+			for j := range columns {
+				if columns[j] == colID {
+					res[i].Columns = append(res[i].Columns, j)
+					break
+				}
+			}
+		}
+	}
+	return res
+}
+
 // Name is part of the cat.UniqueConstraint interface.
 func (u *optUniqueConstraint) Name() string {
 	return u.name
@@ -1935,9 +2051,11 @@ func (u *optUniqueConstraint) WithoutIndex() bool {
 	return u.withoutIndex
 }
 
-// Validated is part of the cat.UniqueConstraint interface.
+// Validated is part of the cat.UniqueConstraint interface. Deferrable
+// constraints can be violated in the middle of a transaction, so the
+// optimizer must not rely on them.
 func (u *optUniqueConstraint) Validated() bool {
-	return u.validity == descpb.ConstraintValidity_Validated
+	return u.validity == descpb.ConstraintValidity_Validated && !u.deferrable
 }
 
 // UniquenessGuaranteedByAnotherIndex is part of the cat.UniqueConstraint
@@ -1948,6 +2066,26 @@ func (u *optUniqueConstraint) UniquenessGuaranteedByAnotherIndex() bool {
 	return u.uniquenessGuaranteedByAnotherIndex
 }
 
+// ExclusionElementCount is part of the cat.UniqueConstraint interface.
+func (u *optUniqueConstraint) ExclusionElementCount() int {
+	return len(u.exclusionElements)
+}
+
+// ExclusionElement is part of the cat.UniqueConstraint interface.
+func (u *optUniqueConstraint) ExclusionElement(i int) cat.ExclusionElement {
+	return u.exclusionElements[i]
+}
+
+// Deferrable is part of the cat.UniqueConstraint interface.
+func (u *optUniqueConstraint) Deferrable() bool {
+	return u.deferrable
+}
+
+// InitiallyDeferred is part of the cat.UniqueConstraint interface.
+func (u *optUniqueConstraint) InitiallyDeferred() bool {
+	return u.initiallyDeferred
+}
+
 // optForeignKeyConstraint implements cat.ForeignKeyConstraint and represents a
 // foreign key relationship. Both the origin and the referenced table store the
 // same optForeignKeyConstraint (as an outbound and inbound reference,
@@ -1965,6 +2103,9 @@ type optForeignKeyConstraint struct {
 	match        tree.CompositeKeyMatchMethod
 	deleteAction tree.ReferenceAction
 	updateAction tree.ReferenceAction
+
+	deferrable        bool
+	initiallyDeferred bool
 }
 
 var _ cat.ForeignKeyConstraint = &optForeignKeyConstraint{}
@@ -2016,9 +2157,11 @@ func (fk *optForeignKeyConstraint) ReferencedColumnOrdinal(referencedTable cat.T
 	return ord
 }
 
-// Validated is part of the cat.ForeignKeyConstraint interface.
+// Validated is part of the cat.ForeignKeyConstraint interface. Deferrable
+// constraints can be violated in the middle of a transaction, so the
+// optimizer must not rely on them.
 func (fk *optForeignKeyConstraint) Validated() bool {
-	return fk.validity == descpb.ConstraintValidity_Validated
+	return fk.validity == descpb.ConstraintValidity_Validated && !fk.deferrable
 }
 
 // MatchMethod is part of the cat.ForeignKeyConstraint interface.
@@ -2036,6 +2179,16 @@ func (fk *optForeignKeyConstraint) UpdateReferenceAction() tree.ReferenceAction
 	return fk.updateAction
 }
 
+// Deferrable is part of the cat.ForeignKeyConstraint interface.
+func (fk *optForeignKeyConstraint) Deferrable() bool {
+	return fk.deferrable
+}
+
+// InitiallyDeferred is part of the cat.ForeignKeyConstraint interface.
+func (fk *optForeignKeyConstraint) InitiallyDeferred() bool {
+	return fk.initiallyDeferred
+}
+
 // optVirtualTable is similar to optTable but is used with virtual tables.
 type optVirtualTable struct {
 	desc catalog.TableDescriptor
@@ -2055,6 +2208,9 @@ type optVirtualTable struct {
 	// Note that some virtual tables have a special instance with empty catalog,
 	// for example "".information_schema.tables contains info about tables in
 	// all databases. We treat the empty catalog as having database ID 0.
+	//
+	// Foreign tables only have a single instance, so their stable ID is their
+	// descriptor ID.
 	id cat.StableID
 
 	// name is the fully qualified, fully resolved, fully normalized name of the
@@ -2081,7 +2237,7 @@ func newOptVirtualTable(
 ) (*optVirtualTable, error) {
 	// Calculate the stable ID (see the comment for optVirtualTable.id).
 	id := cat.StableID(desc.GetID())
-	if name.Catalog() != "" {
+	if name.Catalog() != "" && !desc.IsForeignTable() {
 		// TODO(radu): it's unfortunate that we have to lookup the schema again.
 		found, prefix, err := oc.planner.LookupSchema(ctx, name.Catalog(), name.Schema())
 		if err != nil {
@@ -2294,6 +2450,16 @@ func (ot *optVirtualTable) Check(i int) cat.CheckConstraint {
 	}
 }
 
+// TriggerCount is part of the cat.Table interface.
+func (ot *optVirtualTable) TriggerCount() int {
+	return 0
+}
+
+// Trigger is part of the cat.Table interface.
+func (ot *optVirtualTable) Trigger(i int) cat.Trigger {
+	panic(errors.AssertionFailedf("no triggers"))
+}
+
 // FamilyCount is part of the cat.Table interface.
 func (ot *optVirtualTable) FamilyCount() int {
 	return 1
@@ -544,6 +544,7 @@ func (ef *execFactory) addAggregations(n *groupNode, aggregations []exec.AggInfo
 			agg.Distinct,
 		)
 		f.filterRenderIdx = int(agg.Filter)
+		f.userDefined = agg.UserDefined
 
 		n.funcs = append(n.funcs, f)
 	}
@@ -1852,6 +1853,7 @@ func (ef *execFactory) ConstructCreateView(
 	columns colinfo.ResultColumns,
 	deps opt.SchemaDeps,
 	typeDeps opt.SchemaTypeDeps,
+	textSearchDeps opt.SchemaTextSearchDeps,
 ) (exec.Node, error) {
 
 	if err := checkSchemaChangeEnabled(
@@ -1868,18 +1870,23 @@ func (ef *execFactory) ConstructCreateView(
 	}
 
 	return &createViewNode{
-		createView: createView,
-		viewQuery:  viewQuery,
-		dbDesc:     schema.(*optSchema).database,
-		columns:    columns,
-		planDeps:   planDeps,
-		typeDeps:   typeDepSet,
+		createView:     createView,
+		viewQuery:      viewQuery,
+		dbDesc:         schema.(*optSchema).database,
+		columns:        columns,
+		planDeps:       planDeps,
+		typeDeps:       typeDepSet,
+		textSearchDeps: textSearchDeps,
 	}, nil
 }
 
 // ConstructCreateFunction is part of the exec.Factory interface.
 func (ef *execFactory) ConstructCreateFunction(
-	schema cat.Schema, cf *tree.CreateRoutine, deps opt.SchemaDeps, typeDeps opt.SchemaTypeDeps,
+	schema cat.Schema,
+	cf *tree.CreateRoutine,
+	deps opt.SchemaDeps,
+	typeDeps opt.SchemaTypeDeps,
+	textSearchDeps opt.SchemaTextSearchDeps,
 ) (exec.Node, error) {
 
 	if err := checkSchemaChangeEnabled(
@@ -1904,11 +1911,12 @@ func (ef *execFactory) ConstructCreateFunction(
 	}
 
 	return &createFunctionNode{
-		cf:       cf,
-		dbDesc:   schema.(*optSchema).database,
-		scDesc:   schema.(*optSchema).schema,
-		planDeps: planDeps,
-		typeDeps: typeDepSet,
+		cf:             cf,
+		dbDesc:         schema.(*optSchema).database,
+		scDesc:         schema.(*optSchema).schema,
+		planDeps:       planDeps,
+		typeDeps:       typeDepSet,
+		textSearchDeps: textSearchDeps,
 	}, nil
 }
 
@@ -1960,11 +1968,12 @@ func (ef *execFactory) ConstructSaveTable(
 
 // ConstructErrorIfRows is part of the exec.Factory interface.
 func (ef *execFactory) ConstructErrorIfRows(
-	input exec.Node, mkErr exec.MkErrFn,
+	input exec.Node, mkErr exec.MkErrFn, deferral *exec.CheckDeferral,
 ) (exec.Node, error) {
 	return &errorIfRowsNode{
-		plan:  input.(planNode),
-		mkErr: mkErr,
+		plan:     input.(planNode),
+		mkErr:    mkErr,
+		deferral: deferral,
 	}, nil
 }
 
@@ -2189,7 +2198,7 @@ func (ef *execFactory) ConstructExplain(
 
 // ConstructCall is part of the exec.Factory interface.
 func (e *execFactory) ConstructCall(proc *tree.RoutineExpr) (exec.Node, error) {
-	return &callNode{proc: proc}, nil
+	return &callNode{proc: proc, columns: getCallResultColumns(proc.Typ)}, nil
 }
 
 // renderBuilder encapsulates the code to build a renderNode.
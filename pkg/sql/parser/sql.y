@@ -75,6 +75,22 @@ func unimplementedWithIssueDetail(sqllex sqlLexer, issue int, detail string) int
     return 1
 }
 
+// appendTargetColumns appends the target columns in r to l, and returns l.
+func appendTargetColumns(l, r *tree.TargetColumns) *tree.TargetColumns {
+    if r.Indirections != nil && l.Indirections == nil {
+        l.Indirections = make([]tree.TargetIndirection, len(l.Names), len(l.Names)+len(r.Names))
+    }
+    if l.Indirections != nil {
+        if r.Indirections != nil {
+            l.Indirections = append(l.Indirections, r.Indirections...)
+        } else {
+            l.Indirections = append(l.Indirections, make([]tree.TargetIndirection, len(r.Names))...)
+        }
+    }
+    l.Names = append(l.Names, r.Names...)
+    return l
+}
+
 func processBinaryQualOp(
   sqllex sqlLexer,
   op tree.Operator,
@@ -306,6 +322,27 @@ func (u *sqlSymUnion) enumValueList() tree.EnumValueList {
 func (u *sqlSymUnion) compositeTypeList() []tree.CompositeTypeElem {
     return u.val.([]tree.CompositeTypeElem)
 }
+func (u *sqlSymUnion) domainConstraint() tree.DomainConstraint {
+    return u.val.(tree.DomainConstraint)
+}
+func (u *sqlSymUnion) domainConstraints() []tree.DomainConstraint {
+    return u.val.([]tree.DomainConstraint)
+}
+func (u *sqlSymUnion) foreignOption() tree.ForeignOption {
+    return u.val.(tree.ForeignOption)
+}
+func (u *sqlSymUnion) foreignOptions() tree.ForeignOptions {
+    return u.val.(tree.ForeignOptions)
+}
+func (u *sqlSymUnion) createPublication() *tree.CreatePublication {
+    return u.val.(*tree.CreatePublication)
+}
+func (u *sqlSymUnion) aggregateOption() tree.AggregateOption {
+    return u.val.(tree.AggregateOption)
+}
+func (u *sqlSymUnion) aggregateOptions() tree.AggregateOptions {
+    return u.val.(tree.AggregateOptions)
+}
 func (u *sqlSymUnion) unresolvedName() *tree.UnresolvedName {
     return u.val.(*tree.UnresolvedName)
 }
@@ -327,6 +364,15 @@ func (u *sqlSymUnion) indexFlags() *tree.IndexFlags {
 func (u *sqlSymUnion) arraySubscript() *tree.ArraySubscript {
     return u.val.(*tree.ArraySubscript)
 }
+func (u *sqlSymUnion) targetColumns() *tree.TargetColumns {
+    return u.val.(*tree.TargetColumns)
+}
+func (u *sqlSymUnion) targetIndirection() tree.TargetIndirection {
+    return u.val.(tree.TargetIndirection)
+}
+func (u *sqlSymUnion) targetIndirectionElem() tree.TargetIndirectionElem {
+    return u.val.(tree.TargetIndirectionElem)
+}
 func (u *sqlSymUnion) arraySubscripts() tree.ArraySubscripts {
     if as, ok := u.val.(tree.ArraySubscripts); ok {
         return as
@@ -639,6 +685,12 @@ func (u *sqlSymUnion) idxElem() tree.IndexElem {
 func (u *sqlSymUnion) idxElems() tree.IndexElemList {
     return u.val.(tree.IndexElemList)
 }
+func (u *sqlSymUnion) exclusionElem() tree.ExclusionElem {
+    return u.val.(tree.ExclusionElem)
+}
+func (u *sqlSymUnion) exclusionElems() tree.ExclusionElemList {
+    return u.val.(tree.ExclusionElemList)
+}
 func (u *sqlSymUnion) indexInvisibility() tree.IndexInvisibility {
     return u.val.(tree.IndexInvisibility)
 }
@@ -732,6 +784,9 @@ func (u *sqlSymUnion) transactionModes() tree.TransactionModes {
 func (u *sqlSymUnion) compositeKeyMatchMethod() tree.CompositeKeyMatchMethod {
   return u.val.(tree.CompositeKeyMatchMethod)
 }
+func (u *sqlSymUnion) constraintDeferrability() tree.ConstraintDeferrability {
+  return u.val.(tree.ConstraintDeferrability)
+}
 func (u *sqlSymUnion) referenceAction() tree.ReferenceAction {
     return u.val.(tree.ReferenceAction)
 }
@@ -889,6 +944,30 @@ func (u *sqlSymUnion) beginTransaction() *tree.BeginTransaction {
 func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
     return u.val.(*tree.ShowFingerprintOptions)
 }
+func (u *sqlSymUnion) triggerActionTime() tree.TriggerActionTime {
+    return u.val.(tree.TriggerActionTime)
+}
+func (u *sqlSymUnion) triggerEvent() *tree.TriggerEvent {
+    return u.val.(*tree.TriggerEvent)
+}
+func (u *sqlSymUnion) triggerEvents() []*tree.TriggerEvent {
+    return u.val.([]*tree.TriggerEvent)
+}
+func (u *sqlSymUnion) triggerTransition() *tree.TriggerTransition {
+    return u.val.(*tree.TriggerTransition)
+}
+func (u *sqlSymUnion) triggerTransitions() []*tree.TriggerTransition {
+    return u.val.([]*tree.TriggerTransition)
+}
+func (u *sqlSymUnion) triggerForEach() tree.TriggerForEach {
+    return u.val.(tree.TriggerForEach)
+}
+func (u *sqlSymUnion) mergeWhen() *tree.MergeWhen {
+    return u.val.(*tree.MergeWhen)
+}
+func (u *sqlSymUnion) mergeWhens() []*tree.MergeWhen {
+    return u.val.([]*tree.MergeWhen)
+}
 %}
 
 // NB: the %token definitions must come before the %type definitions in this
@@ -927,10 +1006,10 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %token <str> CURRENT_USER CURSOR CYCLE
 
 %token <str> DATA DATABASE DATABASES DATE DAY DEBUG_IDS DEBUG_PAUSE_ON DEC DEBUG_DUMP_METADATA_SST DECIMAL DEFAULT DEFAULTS DEFINER
-%token <str> DEALLOCATE DECLARE DEFERRABLE DEFERRED DELETE DELIMITER DEPENDS DESC DESTINATION DETACHED DETAILS
+%token <str> DEALLOCATE DECLARE DEFERRABLE DEFERRED DELETE DELIMITER DEPENDS DESC DESTINATION DETACHED DETAILS DICTIONARY
 %token <str> DISCARD DISTINCT DO DOMAIN DOUBLE DROP
 
-%token <str> ELSE ENCODING ENCRYPTED ENCRYPTION_INFO_DIR ENCRYPTION_PASSPHRASE END ENUM ENUMS ESCAPE EXCEPT EXCLUDE EXCLUDING
+%token <str> EACH ELSE ENCODING ENCRYPTED ENCRYPTION_INFO_DIR ENCRYPTION_PASSPHRASE END ENUM ENUMS ESCAPE EXCEPT EXCLUDE EXCLUDING
 %token <str> EXISTS EXECUTE EXECUTION EXPERIMENTAL
 %token <str> EXPERIMENTAL_FINGERPRINTS EXPERIMENTAL_REPLICA
 %token <str> EXPERIMENTAL_AUDIT EXPERIMENTAL_RELOCATE
@@ -944,6 +1023,7 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 
 %token <str> GENERATED GEOGRAPHY GEOMETRY GEOMETRYM GEOMETRYZ GEOMETRYZM
 %token <str> GEOMETRYCOLLECTION GEOMETRYCOLLECTIONM GEOMETRYCOLLECTIONZ GEOMETRYCOLLECTIONZM
+%token <str> GEOMETRIC_DISTANCE GEOMETRIC_INTERSECTS
 %token <str> GLOBAL GOAL GRANT GRANTEE GRANTS GREATEST GROUP GROUPING GROUPS
 
 %token <str> HAVING HASH HEADER HIGH HISTOGRAM HOLD HOUR
@@ -954,31 +1034,31 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %token <str> INET INET_CONTAINED_BY_OR_EQUALS
 %token <str> INET_CONTAINS_OR_EQUALS INDEX INDEXES INHERITS INJECT INITIALLY
 %token <str> INDEX_BEFORE_PAREN INDEX_BEFORE_NAME_THEN_PAREN INDEX_AFTER_ORDER_BY_BEFORE_AT
-%token <str> INNER INOUT INPUT INSENSITIVE INSERT INT INTEGER
+%token <str> INNER INOUT INPUT INSENSITIVE INSERT INSTEAD INT INTEGER
 %token <str> INTERSECT INTERVAL INTO INTO_DB INVERTED INVOKER IS ISERROR ISNULL ISOLATION
 
-%token <str> JOB JOBS JOIN JSON JSONB JSON_SOME_EXISTS JSON_ALL_EXISTS
+%token <str> JOB JOBS JOIN JSON JSONB JSON_SOME_EXISTS JSON_ALL_EXISTS JSON_PATH_EXISTS
 
 %token <str> KEY KEYS KMS KV
 
 %token <str> LABEL LANGUAGE LAST LATERAL LATEST LC_CTYPE LC_COLLATE
 %token <str> LEADING LEASE LEAST LEAKPROOF LEFT LESS LEVEL LIKE LIMIT
 %token <str> LINESTRING LINESTRINGM LINESTRINGZ LINESTRINGZM
-%token <str> LIST LOCAL LOCALITY LOCALTIME LOCALTIMESTAMP LOCKED LOGIN LOOKUP LOW LSHIFT
+%token <str> LIST LISTEN LOCAL LOCALITY LOCALTIME LOCALTIMESTAMP LOCKED LOGIN LOOKUP LOW LSHIFT
 
-%token <str> MATCH MATERIALIZED MERGE MINVALUE MAXVALUE METHOD MINUTE MODIFYCLUSTERSETTING MODIFYSQLCLUSTERSETTING MONTH MOVE
+%token <str> MAPPING MATCH MATCHED MATERIALIZED MERGE MINVALUE MAXVALUE METHOD MINUTE MODIFYCLUSTERSETTING MODIFYSQLCLUSTERSETTING MONTH MOVE
 %token <str> MULTILINESTRING MULTILINESTRINGM MULTILINESTRINGZ MULTILINESTRINGZM
 %token <str> MULTIPOINT MULTIPOINTM MULTIPOINTZ MULTIPOINTZM
 %token <str> MULTIPOLYGON MULTIPOLYGONM MULTIPOLYGONZ MULTIPOLYGONZM
 
-%token <str> NAN NAME NAMES NATURAL NEVER NEW_DB_NAME NEW_KMS NEXT NO NOCANCELQUERY NOCONTROLCHANGEFEED
+%token <str> NAN NAME NAMES NATURAL NEVER NEW NEW_DB_NAME NEW_KMS NEXT NO NOCANCELQUERY NOCONTROLCHANGEFEED
 %token <str> NOCONTROLJOB NOCREATEDB NOCREATELOGIN NOCREATEROLE NOLOGIN NOMODIFYCLUSTERSETTING NOREPLICATION
 %token <str> NOSQLLOGIN NO_INDEX_JOIN NO_ZIGZAG_JOIN NO_FULL_SCAN NONE NONVOTERS NORMAL NOT
-%token <str> NOTHING NOTHING_AFTER_RETURNING
+%token <str> NOTHING NOTHING_AFTER_RETURNING NOTIFY
 %token <str> NOTNULL
 %token <str> NOVIEWACTIVITY NOVIEWACTIVITYREDACTED NOVIEWCLUSTERSETTING NOWAIT NULL NULLIF NULLS NUMERIC
 
-%token <str> OF OFF OFFSET OID OIDS OIDVECTOR OLD_KMS ON ONLY OPT OPTION OPTIONS OR
+%token <str> OF OFF OFFSET OID OIDS OIDVECTOR OLD OLD_KMS ON ONLY OPT OPTION OPTIONS OR
 %token <str> ORDER ORDINALITY OTHERS OUT OUTER OVER OVERLAPS OVERLAY OWNED OWNER OPERATOR
 
 %token <str> PARALLEL PARENT PARTIAL PARTITION PARTITIONS PASSWORD PAUSE PAUSED PHYSICAL PLACEMENT PLACING
@@ -988,7 +1068,7 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 
 %token <str> QUERIES QUERY QUOTE
 
-%token <str> RANGE RANGES READ REAL REASON REASSIGN RECURSIVE RECURRING REDACT REF REFERENCES REFRESH
+%token <str> RANGE RANGE_ADJACENT RANGES READ REAL REASON REASSIGN RECURSIVE RECURRING REDACT REF REFERENCES REFERENCING REFRESH
 %token <str> REGCLASS REGION REGIONAL REGIONS REGNAMESPACE REGPROC REGPROCEDURE REGROLE REGTYPE REINDEX
 %token <str> RELATIVE RELOCATE REMOVE_PATH REMOVE_REGIONS RENAME REPEATABLE REPLACE REPLICATION
 %token <str> RELEASE RESET RESTART RESTORE RESTRICT RESTRICTED RESUME RETENTION RETURNING RETURN RETURNS RETRY REVISION_HISTORY
@@ -1001,7 +1081,7 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %token <str> SKIP_MISSING_SEQUENCES SKIP_MISSING_SEQUENCE_OWNERS SKIP_MISSING_VIEWS SKIP_MISSING_UDFS SMALLINT SMALLSERIAL
 %token <str> SNAPSHOT SOME SPLIT SQL SQLLOGIN
 %token <str> STABLE START STATE STATISTICS STATUS STDIN STDOUT STOP STREAM STRICT STRING STORAGE STORE STORED STORING SUBSTRING SUPER
-%token <str> SUPPORT SURVIVE SURVIVAL SYMMETRIC SYNTAX SYSTEM SQRT SUBSCRIPTION STATEMENTS
+%token <str> SUPPORT SURVIVE SURVIVAL SYMMETRIC SYNTAX SYSTEM SQRT SUBSCRIPTION STATEMENT STATEMENTS
 
 %token <str> TABLE TABLES TABLESPACE TEMP TEMPLATE TEMPORARY TENANT TENANT_NAME TENANTS TESTING_RELOCATE TEXT THEN
 %token <str> TIES TIME TIMETZ TIMESTAMP TIMESTAMPTZ TO THROTTLING TRAILING TRACE
@@ -1016,7 +1096,7 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %token <str> VIEWCLUSTERMETADATA VIEWCLUSTERSETTING VIRTUAL VISIBLE INVISIBLE VISIBILITY VOLATILE VOTERS
 %token <str> VIRTUAL_CLUSTER_NAME VIRTUAL_CLUSTER
 
-%token <str> WHEN WHERE WINDOW WITH WITHIN WITHOUT WORK WRITE
+%token <str> WHEN WHERE WINDOW WITH WITHIN WITHOUT WORK WRAPPER WRITE
 
 %token <str> YEAR
 
@@ -1069,8 +1149,10 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %type <tree.Statement> alter_role_stmt
 %type <*tree.SetVar> set_or_reset_clause
 %type <tree.Statement> alter_type_stmt
+%type <tree.Statement> alter_domain_stmt
 %type <tree.Statement> alter_schema_stmt
-%type <tree.Statement> alter_unsupported_stmt
+%type <tree.Statement> alter_aggregate_stmt
+%type <tree.Statement> alter_text_search_config_stmt
 %type <tree.Statement> alter_func_stmt
 %type <tree.Statement> alter_proc_stmt
 
@@ -1163,6 +1245,9 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %type <tree.Statement> alter_proc_rename_stmt
 %type <tree.Statement> alter_proc_set_schema_stmt
 %type <tree.Statement> alter_proc_owner_stmt
+%type <tree.Statement> alter_aggregate_rename_stmt
+%type <tree.Statement> alter_aggregate_set_schema_stmt
+%type <tree.Statement> alter_aggregate_owner_stmt
 
 %type <tree.Statement> backup_stmt
 %type <tree.Statement> begin_stmt
@@ -1206,6 +1291,10 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %type <tree.Statement> create_sequence_stmt
 %type <tree.Statement> create_func_stmt
 %type <tree.Statement> create_proc_stmt
+%type <tree.Statement> create_aggregate_stmt
+%type <tree.AggregateOptions> aggregate_def_list
+%type <tree.AggregateOption> aggregate_def_elem
+%type <tree.Statement> create_trigger_stmt
 
 %type <*tree.LikeTenantSpec> opt_like_virtual_cluster
 
@@ -1215,6 +1304,18 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %type <*tree.CreateStatsOptions> create_stats_option
 
 %type <tree.Statement> create_type_stmt
+%type <tree.Statement> create_domain_stmt
+%type <tree.Statement> create_publication_stmt
+%type <tree.Statement> create_text_search_config_stmt
+%type <tree.Statement> create_text_search_dict_stmt
+%type <tree.Statement> create_server_stmt
+%type <tree.Statement> create_foreign_table_stmt
+%type <tree.ForeignOption> foreign_option
+%type <tree.ForeignOptions> foreign_option_list opt_foreign_options
+%type <*tree.CreatePublication> opt_publication_for_tables
+%type <tree.DomainConstraint> domain_constraint domain_constraint_elem
+%type <[]tree.DomainConstraint> opt_domain_constraint_list domain_constraint_list
+%type <tree.Expr> opt_domain_default
 %type <tree.Statement> delete_stmt
 %type <tree.Statement> discard_stmt
 
@@ -1227,10 +1328,18 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %type <tree.Statement> drop_schema_stmt
 %type <tree.Statement> drop_table_stmt
 %type <tree.Statement> drop_type_stmt
+%type <tree.Statement> drop_domain_stmt
+%type <tree.Statement> drop_publication_stmt
+%type <tree.Statement> drop_text_search_config_stmt
+%type <tree.Statement> drop_text_search_dict_stmt
+%type <tree.Statement> drop_server_stmt
+%type <tree.Statement> drop_foreign_table_stmt
 %type <tree.Statement> drop_view_stmt
 %type <tree.Statement> drop_sequence_stmt
 %type <tree.Statement> drop_func_stmt
 %type <tree.Statement> drop_proc_stmt
+%type <tree.Statement> drop_aggregate_stmt
+%type <tree.Statement> drop_trigger_stmt
 %type <tree.Statement> drop_virtual_cluster_stmt
 %type <bool>           opt_immediate
 
@@ -1247,6 +1356,7 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %type <tree.Statement> grant_stmt
 %type <tree.Statement> insert_stmt
 %type <tree.Statement> import_stmt
+%type <tree.Statement> merge_stmt
 %type <tree.Statement> pause_stmt pause_jobs_stmt pause_schedules_stmt pause_all_jobs_stmt
 %type <*tree.Select>   for_schedules_clause
 %type <tree.Statement> reassign_owned_by_stmt
@@ -1269,6 +1379,7 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %type <tree.Statement> set_local_stmt
 %type <tree.Statement> set_session_stmt
 %type <tree.Statement> set_csetting_stmt set_or_reset_csetting_stmt
+%type <tree.Statement> set_constraints_stmt
 %type <tree.Statement> set_transaction_stmt
 %type <tree.Statement> set_exprs_internal
 %type <tree.Statement> generic_set
@@ -1332,6 +1443,8 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 
 %type <tree.Statement> transaction_stmt legacy_transaction_stmt legacy_begin_stmt legacy_end_stmt
 %type <tree.Statement> truncate_stmt
+%type <tree.Statement> listen_stmt
+%type <tree.Statement> notify_stmt
 %type <tree.Statement> unlisten_stmt
 %type <tree.Statement> update_stmt
 %type <tree.Statement> upsert_stmt
@@ -1343,6 +1456,7 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %type <tree.Statement> move_cursor_stmt
 %type <tree.CursorStmt> cursor_movement_specifier
 %type <bool> opt_hold opt_binary
+%type <bool> constraints_set_mode
 %type <tree.CursorSensitivity> opt_sensitivity
 %type <tree.CursorScrollOption> opt_scroll
 %type <int64> opt_forward_backward forward_backward
@@ -1361,6 +1475,7 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %type <*tree.ShowBackupOptions> opt_with_show_backup_options show_backup_options show_backup_options_list show_backup_connection_options opt_with_show_backup_connection_options_list show_backup_connection_options_list
 %type <*tree.CopyOptions> opt_with_copy_options copy_options copy_options_list copy_generic_options copy_generic_options_list
 %type <str> import_format
+%type <str> opt_exclude_using
 %type <str> storage_parameter_key
 %type <tree.NameList> storage_parameter_key_list
 %type <tree.StorageParam> storage_parameter
@@ -1421,7 +1536,7 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %type <tree.ResolvableFunctionReference> func_application_name
 %type <str> opt_class opt_collate
 
-%type <str> cursor_name database_name index_name opt_index_name column_name insert_column_item statistics_name window_name opt_in_database
+%type <str> cursor_name database_name index_name opt_index_name column_name statistics_name window_name opt_in_database
 %type <str> family_name opt_family_name table_alias_name constraint_name target_name zone_name partition_name collation_name
 %type <str> db_object_name_component
 %type <*tree.UnresolvedObjectName> table_name db_name standalone_index_name sequence_name type_name
@@ -1460,13 +1575,18 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %type <empty> opt_privileges_clause
 %type <bool> distinct_clause opt_with_data
 %type <tree.DistinctOn> distinct_on_clause
-%type <tree.NameList> opt_column_list insert_column_list opt_stats_columns query_stats_cols
+%type <tree.NameList> opt_column_list opt_stats_columns query_stats_cols
+%type <*tree.TargetColumns> insert_column_list insert_column_item
+%type <tree.TargetIndirection> target_indirection
+%type <tree.TargetIndirectionElem> target_indirection_elem
 %type <tree.OrderBy> sort_clause single_sort_clause opt_sort_clause
 %type <[]*tree.Order> sortby_list
 %type <tree.IndexElemList> index_params create_as_params
+%type <tree.ExclusionElem> exclude_elem
+%type <tree.ExclusionElemList> exclude_elems
 %type <tree.IndexInvisibility> opt_index_visible alter_index_visible
 %type <tree.NameList> name_list privilege_list
-%type <[]int32> opt_array_bounds
+%type <[]int32> opt_array_bounds array_bounds
 %type <*tree.Batch> opt_batch_clause
 %type <tree.From> from_clause
 %type <tree.TableExprs> from_list rowsfrom_list opt_from_list
@@ -1612,6 +1732,7 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %type <str> col_name_keyword reserved_keyword cockroachdb_extra_reserved_keyword extra_var_value
 
 %type <tree.ResolvableTypeReference> complex_type_name
+%type <tree.ResolvableTypeReference> range_type_param_list range_type_param
 %type <str> general_type_name
 
 %type <tree.ConstraintTableDef> table_constraint constraint_elem create_as_constraint_def create_as_constraint_elem
@@ -1621,6 +1742,7 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %type <tree.NamedColumnQualification> col_qualification create_as_col_qualification
 %type <tree.ColumnQualification> col_qualification_elem create_as_col_qualification_elem
 %type <tree.CompositeKeyMatchMethod> key_match
+%type <tree.ConstraintDeferrability> opt_deferrable
 %type <tree.ReferenceActions> reference_actions
 %type <tree.ReferenceAction> reference_action reference_on_delete reference_on_update
 
@@ -1683,10 +1805,10 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %type <privilege.TargetObjectType> target_object_type
 
 // User defined function relevant components.
-%type <bool> opt_or_replace opt_return_table opt_return_set opt_no
+%type <bool> opt_or_replace opt_return_set opt_no
 %type <str> param_name routine_as
-%type <tree.RoutineParams> opt_routine_param_with_default_list routine_param_with_default_list func_params func_params_list
-%type <tree.RoutineParam> routine_param_with_default routine_param
+%type <tree.RoutineParams> opt_routine_param_with_default_list routine_param_with_default_list func_params func_params_list table_func_column_list
+%type <tree.RoutineParam> routine_param_with_default routine_param table_func_column
 %type <tree.ResolvableTypeReference> routine_return_type routine_param_type
 %type <tree.RoutineOptions> opt_create_routine_opt_list create_routine_opt_list alter_func_opt_list
 %type <tree.RoutineOption> create_routine_opt_item common_routine_opt_item
@@ -1699,6 +1821,24 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %type <tree.RoutineObjs> function_with_paramtypes_list
 %type <empty> opt_link_sym
 
+// Trigger relevant components.
+%type <tree.TriggerActionTime> trigger_action_time
+%type <[]*tree.TriggerEvent> trigger_event_list
+%type <*tree.TriggerEvent> trigger_event
+%type <[]*tree.TriggerTransition> opt_trigger_transition_list trigger_transition_list
+%type <*tree.TriggerTransition> trigger_transition
+%type <bool> transition_is_new
+%type <tree.TriggerForEach> trigger_for_each
+%type <tree.Expr> trigger_when
+%type <[]string> opt_trigger_func_args trigger_func_args
+%type <str> trigger_func_arg
+%type <empty> opt_each opt_as function_or_procedure
+
+// MERGE relevant components.
+%type <[]*tree.MergeWhen> merge_when_list
+%type <*tree.MergeWhen> merge_when merge_when_matched_action merge_when_not_matched_action
+%type <tree.Expr> opt_merge_cond
+
 %type <*tree.LabelSpec> label_spec
 
 %type <*tree.ShowRangesOptions> opt_show_ranges_options show_ranges_options
@@ -1743,7 +1883,7 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 // funny behavior of UNBOUNDED on the SQL standard, though.
 %nonassoc  UNBOUNDED         // ideally should have same precedence as IDENT
 %nonassoc  IDENT NULL PARTITION RANGE ROWS GROUPS PRECEDING FOLLOWING CUBE ROLLUP
-%left      CONCAT FETCHVAL FETCHTEXT FETCHVAL_PATH FETCHTEXT_PATH REMOVE_PATH AT_AT  // multi-character ops
+%left      CONCAT FETCHVAL FETCHTEXT FETCHVAL_PATH FETCHTEXT_PATH REMOVE_PATH AT_AT JSON_PATH_EXISTS GEOMETRIC_DISTANCE GEOMETRIC_INTERSECTS RANGE_ADJACENT  // multi-character ops
 %left      '|'
 %left      '#'
 %left      '&'
@@ -1811,8 +1951,10 @@ stmt_without_legacy_transaction:
 | declare_cursor_stmt        // EXTEND WITH HELP: DECLARE
 | fetch_cursor_stmt          // EXTEND WITH HELP: FETCH
 | move_cursor_stmt           // EXTEND WITH HELP: MOVE
-| reindex_stmt
-| unlisten_stmt
+| reindex_stmt               // EXTEND WITH HELP: REINDEX
+| listen_stmt                // EXTEND WITH HELP: LISTEN
+| notify_stmt                // EXTEND WITH HELP: NOTIFY
+| unlisten_stmt              // EXTEND WITH HELP: UNLISTEN
 | show_commit_timestamp_stmt // EXTEND WITH HELP: SHOW COMMIT TIMESTAMP
 
 // %Help: ALTER
@@ -1822,7 +1964,6 @@ alter_stmt:
   alter_ddl_stmt      // help texts in sub-rule
 | alter_role_stmt     // EXTEND WITH HELP: ALTER ROLE
 | alter_virtual_cluster_stmt   /* SKIP DOC */
-| alter_unsupported_stmt
 | ALTER error         // SHOW HELP: ALTER
 
 alter_ddl_stmt:
@@ -1835,11 +1976,14 @@ alter_ddl_stmt:
 | alter_partition_stmt          // EXTEND WITH HELP: ALTER PARTITION
 | alter_schema_stmt             // EXTEND WITH HELP: ALTER SCHEMA
 | alter_type_stmt               // EXTEND WITH HELP: ALTER TYPE
+| alter_domain_stmt             // EXTEND WITH HELP: ALTER DOMAIN
 | alter_default_privileges_stmt // EXTEND WITH HELP: ALTER DEFAULT PRIVILEGES
 | alter_changefeed_stmt         // EXTEND WITH HELP: ALTER CHANGEFEED
 | alter_backup_stmt             // EXTEND WITH HELP: ALTER BACKUP
 | alter_func_stmt               // EXTEND WITH HELP: ALTER FUNCTION
 | alter_proc_stmt               // EXTEND WITH HELP: ALTER PROCEDURE
+| alter_aggregate_stmt          // EXTEND WITH HELP: ALTER AGGREGATE
+| alter_text_search_config_stmt // EXTEND WITH HELP: ALTER TEXT SEARCH CONFIGURATION
 | alter_backup_schedule  // EXTEND WITH HELP: ALTER BACKUP SCHEDULE
 
 // %Help: ALTER TABLE - change the definition of a table
@@ -2025,6 +2169,11 @@ alter_database_stmt:
 //    CALLED ON NULL INPUT | RETURNS NULL ON NULL INPUT | STRICT
 //    IMMUTABLE | STABLE | VOLATILE
 //    [ NOT ] LEAKPROOF
+//    [ EXTERNAL ] SECURITY { INVOKER | DEFINER }
+//    SET var_name { TO | = } { value | DEFAULT }
+//    SET var_name FROM CURRENT
+//    RESET var_name
+//    RESET ALL
 // %SeeAlso: WEBDOCS/alter-function.html
 alter_func_stmt:
   alter_func_options_stmt
@@ -2051,6 +2200,74 @@ alter_proc_stmt:
 | alter_proc_set_schema_stmt
 | ALTER PROCEDURE error // SHOW HELP: ALTER PROCEDURE
 
+// %Help: ALTER AGGREGATE - change the definition of an aggregate function
+// %Category: DDL
+// %Text:
+// ALTER AGGREGATE name ( [ [ argmode ] [ argname ] argtype [, ...] ] )
+//    RENAME TO new_name
+// ALTER AGGREGATE name ( [ [ argmode ] [ argname ] argtype [, ...] ] )
+//    OWNER TO { new_owner | CURRENT_USER | SESSION_USER }
+// ALTER AGGREGATE name ( [ [ argmode ] [ argname ] argtype [, ...] ] )
+//    SET SCHEMA new_schema
+//
+// %SeeAlso: CREATE AGGREGATE, DROP AGGREGATE
+alter_aggregate_stmt:
+  alter_aggregate_rename_stmt
+| alter_aggregate_owner_stmt
+| alter_aggregate_set_schema_stmt
+| ALTER AGGREGATE error // SHOW HELP: ALTER AGGREGATE
+
+// %Help: ALTER TEXT SEARCH CONFIGURATION - change the mappings of a text search configuration
+// %Category: DDL
+// %Text:
+// ALTER TEXT SEARCH CONFIGURATION <name>
+//    ADD MAPPING FOR <token_type> [, ...] WITH <dictionary> [, ...]
+// ALTER TEXT SEARCH CONFIGURATION <name>
+//    ALTER MAPPING FOR <token_type> [, ...] WITH <dictionary> [, ...]
+// ALTER TEXT SEARCH CONFIGURATION <name>
+//    DROP MAPPING [IF EXISTS] FOR <token_type> [, ...]
+//
+// Token types:
+//    asciiword, word, numword, uint
+// %SeeAlso: CREATE TEXT SEARCH CONFIGURATION
+alter_text_search_config_stmt:
+  ALTER TEXT SEARCH CONFIGURATION db_object_name ADD MAPPING FOR name_list WITH type_name_list
+  {
+    $$.val = &tree.AlterTextSearchConfig{
+      Name: $5.unresolvedObjectName(),
+      Cmd: tree.AlterTextSearchAddMapping,
+      TokenTypes: $9.nameList(),
+      Dictionaries: $11.unresolvedObjectNames(),
+    }
+  }
+| ALTER TEXT SEARCH CONFIGURATION db_object_name ALTER MAPPING FOR name_list WITH type_name_list
+  {
+    $$.val = &tree.AlterTextSearchConfig{
+      Name: $5.unresolvedObjectName(),
+      Cmd: tree.AlterTextSearchAlterMapping,
+      TokenTypes: $9.nameList(),
+      Dictionaries: $11.unresolvedObjectNames(),
+    }
+  }
+| ALTER TEXT SEARCH CONFIGURATION db_object_name DROP MAPPING FOR name_list
+  {
+    $$.val = &tree.AlterTextSearchConfig{
+      Name: $5.unresolvedObjectName(),
+      Cmd: tree.AlterTextSearchDropMapping,
+      TokenTypes: $9.nameList(),
+    }
+  }
+| ALTER TEXT SEARCH CONFIGURATION db_object_name DROP MAPPING IF EXISTS FOR name_list
+  {
+    $$.val = &tree.AlterTextSearchConfig{
+      Name: $5.unresolvedObjectName(),
+      Cmd: tree.AlterTextSearchDropMapping,
+      IfExists: true,
+      TokenTypes: $11.nameList(),
+    }
+  }
+| ALTER TEXT SEARCH CONFIGURATION error // SHOW HELP: ALTER TEXT SEARCH CONFIGURATION
+
 // ALTER DATABASE has its error help token here because the ALTER DATABASE
 // prefix is spread over multiple non-terminals.
 | ALTER DATABASE error // SHOW HELP: ALTER DATABASE
@@ -3102,6 +3319,86 @@ opt_add_val_placement:
     $$.val = (*tree.AlterTypeAddValuePlacement)(nil)
   }
 
+// %Help: ALTER DOMAIN - change the definition of a domain
+// %Category: DDL
+// %Text: ALTER DOMAIN <domain_name> <command>
+//
+// Commands:
+//   ALTER DOMAIN ... { SET DEFAULT <expr> | DROP DEFAULT }
+//   ALTER DOMAIN ... { SET | DROP } NOT NULL
+//   ALTER DOMAIN ... ADD [CONSTRAINT <constraint_name>] CHECK (<expr>)
+//   ALTER DOMAIN ... DROP CONSTRAINT [IF EXISTS] <constraint_name> [CASCADE | RESTRICT]
+// %SeeAlso: CREATE DOMAIN, DROP DOMAIN
+alter_domain_stmt:
+  ALTER DOMAIN type_name SET DEFAULT a_expr
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainSetDefault{Default: $6.expr()},
+    }
+  }
+| ALTER DOMAIN type_name DROP DEFAULT
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainSetDefault{},
+    }
+  }
+| ALTER DOMAIN type_name SET NOT NULL
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainSetNotNull{NotNull: true},
+    }
+  }
+| ALTER DOMAIN type_name DROP NOT NULL
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainSetNotNull{NotNull: false},
+    }
+  }
+| ALTER DOMAIN type_name ADD CONSTRAINT constraint_name CHECK '(' a_expr ')'
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainAddConstraint{
+        Constraint: tree.DomainConstraint{Name: tree.Name($6), Check: $9.expr()},
+      },
+    }
+  }
+| ALTER DOMAIN type_name ADD CHECK '(' a_expr ')'
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainAddConstraint{
+        Constraint: tree.DomainConstraint{Check: $7.expr()},
+      },
+    }
+  }
+| ALTER DOMAIN type_name DROP CONSTRAINT constraint_name opt_drop_behavior
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainDropConstraint{
+        Constraint: tree.Name($6),
+        DropBehavior: $7.dropBehavior(),
+      },
+    }
+  }
+| ALTER DOMAIN type_name DROP CONSTRAINT IF EXISTS constraint_name opt_drop_behavior
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainDropConstraint{
+        IfExists: true,
+        Constraint: tree.Name($8),
+        DropBehavior: $9.dropBehavior(),
+      },
+    }
+  }
+| ALTER DOMAIN error // SHOW HELP: ALTER DOMAIN
+
 role_spec:
   IDENT
   {
@@ -3901,16 +4198,6 @@ import_format:
     $$ = strings.ToUpper($1)
   }
 
-alter_unsupported_stmt:
-  ALTER DOMAIN error
-  {
-    return unimplemented(sqllex, "alter domain")
-  }
-| ALTER AGGREGATE error
-  {
-    return unimplementedWithIssueDetail(sqllex, 74775, "alter aggregate")
-  }
-
 // %Help: IMPORT - load data from file in a distributed manner
 // %Category: CCL
 // %Text:
@@ -3955,8 +4242,12 @@ import_stmt:
   }
 | IMPORT INTO table_name '(' insert_column_list ')' import_format DATA '(' string_or_placeholder_list ')' opt_with_options
   {
+    cols := $5.targetColumns()
+    if cols.Indirections != nil {
+      return unimplemented(sqllex, "import into column fields or elements")
+    }
     name := $3.unresolvedObjectName().ToTableName()
-    $$.val = &tree.Import{Table: &name, Into: true, IntoCols: $5.nameList(), FileFormat: $7, Files: $10.exprs(), Options: $12.kvOptions()}
+    $$.val = &tree.Import{Table: &name, Into: true, IntoCols: cols.Names, FileFormat: $7, Files: $10.exprs(), Options: $12.kvOptions()}
   }
 | IMPORT INTO table_name import_format DATA '(' string_or_placeholder_list ')' opt_with_options
   {
@@ -4082,14 +4373,24 @@ copy_stmt:
   {
     /* FORCE DOC */
     name := $2.unresolvedObjectName().ToTableName()
-    if $7.expr() != nil {
-      return unimplementedWithIssue(sqllex, 54580)
-    }
     $$.val = &tree.CopyFrom{
        Table: name,
        Columns: $3.nameList(),
        Stdin: true,
        Options: *$6.copyOptions(),
+       Where: tree.NewWhere(tree.AstWhere, $7.expr()),
+    }
+  }
+| COPY table_name opt_column_list FROM SCONST opt_with_copy_options opt_where_clause
+  {
+    /* FORCE DOC */
+    name := $2.unresolvedObjectName().ToTableName()
+    $$.val = &tree.CopyFrom{
+       Table: name,
+       Columns: $3.nameList(),
+       File: tree.NewStrVal($5),
+       Options: *$6.copyOptions(),
+       Where: tree.NewWhere(tree.AstWhere, $7.expr()),
     }
   }
 | COPY table_name opt_column_list FROM error
@@ -4106,9 +4407,20 @@ copy_stmt:
        Options: *$6.copyOptions(),
     }
   }
+| COPY table_name opt_column_list TO SCONST opt_with_copy_options
+  {
+    /* FORCE DOC */
+    name := $2.unresolvedObjectName().ToTableName()
+    $$.val = &tree.CopyTo{
+       Table: name,
+       Columns: $3.nameList(),
+       File: tree.NewStrVal($5),
+       Options: *$6.copyOptions(),
+    }
+  }
 | COPY table_name opt_column_list TO error
   {
-    return unimplementedWithIssue(sqllex, 97181)
+    return unimplemented(sqllex, "copy to unsupported format")
   }
 | COPY '(' copy_to_stmt ')' TO STDOUT opt_with_copy_options
    {
@@ -4118,9 +4430,18 @@ copy_stmt:
         Options: *$7.copyOptions(),
      }
    }
+| COPY '(' copy_to_stmt ')' TO SCONST opt_with_copy_options
+   {
+     /* FORCE DOC */
+     $$.val = &tree.CopyTo{
+        Statement: $3.stmt(),
+        File: tree.NewStrVal($6),
+        Options: *$7.copyOptions(),
+     }
+   }
 | COPY '(' copy_to_stmt ')' TO error
    {
-     return unimplementedWithIssue(sqllex, 96590)
+     return unimplemented(sqllex, "copy to unsupported format")
    }
 
 opt_with_copy_options:
@@ -4202,21 +4523,25 @@ copy_options:
   {
     $$.val = &tree.CopyOptions{Escape: tree.NewStrVal($2)}
   }
-| FORCE QUOTE error
+| FORCE QUOTE '*'
+  {
+    $$.val = &tree.CopyOptions{ForceQuoteAll: true}
+  }
+| FORCE QUOTE name_list
   {
-    return unimplementedWithIssueDetail(sqllex, 41608, "force_quote")
+    $$.val = &tree.CopyOptions{ForceQuote: $3.nameList()}
   }
-| FORCE NOT NULL error
+| FORCE NOT NULL name_list
   {
-    return unimplementedWithIssueDetail(sqllex, 41608, "force_not_null")
+    $$.val = &tree.CopyOptions{ForceNotNull: $4.nameList()}
   }
-| FORCE NULL error
+| FORCE NULL name_list
   {
-    return unimplementedWithIssueDetail(sqllex, 41608, "force_null")
+    $$.val = &tree.CopyOptions{ForceNull: $3.nameList()}
   }
-| ENCODING SCONST error
+| ENCODING SCONST
   {
-    return unimplementedWithIssueDetail(sqllex, 41608, "encoding")
+    $$.val = &tree.CopyOptions{Encoding: tree.NewStrVal($2)}
   }
 
 copy_generic_options:
@@ -4287,21 +4612,25 @@ copy_generic_options:
   {
     $$.val = &tree.CopyOptions{Escape: tree.NewStrVal($2)}
   }
-| FORCE_QUOTE error
+| FORCE_QUOTE '*'
   {
-    return unimplementedWithIssueDetail(sqllex, 41608, "force_quote")
+    $$.val = &tree.CopyOptions{ForceQuoteAll: true}
   }
-| FORCE_NOT_NULL error
+| FORCE_QUOTE '(' name_list ')'
   {
-    return unimplementedWithIssueDetail(sqllex, 41608, "force_not_null")
+    $$.val = &tree.CopyOptions{ForceQuote: $3.nameList()}
   }
-| FORCE_NULL error
+| FORCE_NOT_NULL '(' name_list ')'
   {
-    return unimplementedWithIssueDetail(sqllex, 41608, "force_null")
+    $$.val = &tree.CopyOptions{ForceNotNull: $3.nameList()}
   }
-| ENCODING SCONST error
+| FORCE_NULL '(' name_list ')'
   {
-    return unimplementedWithIssueDetail(sqllex, 41608, "encoding")
+    $$.val = &tree.CopyOptions{ForceNull: $3.nameList()}
+  }
+| ENCODING SCONST
+  {
+    $$.val = &tree.CopyOptions{Encoding: tree.NewStrVal($2)}
   }
 
 // %Help: CANCEL
@@ -4634,17 +4963,20 @@ create_extension_stmt:
 // %Text:
 // CREATE [ OR REPLACE ] FUNCTION
 //    name ( [ [ argmode ] [ argname ] argtype [, ...] ] )
-//    [ RETURNS rettype ]
+//    [ RETURNS rettype
+//      | RETURNS TABLE ( column_name column_type [, ...] ) ]
 //  { LANGUAGE lang_name
 //    | { IMMUTABLE | STABLE | VOLATILE }
 //    | [ NOT ] LEAKPROOF
 //    | { CALLED ON NULL INPUT | RETURNS NULL ON NULL INPUT | STRICT }
+//    | [ EXTERNAL ] SECURITY { INVOKER | DEFINER }
+//    | SET var_name { TO | = | FROM CURRENT } ...
 //    | AS 'definition'
 //  } ...
 // %SeeAlso: WEBDOCS/create-function.html
 create_func_stmt:
   CREATE opt_or_replace FUNCTION routine_create_name '(' opt_routine_param_with_default_list ')'
-  RETURNS opt_return_table opt_return_set routine_return_type
+  RETURNS opt_return_set routine_return_type
   opt_create_routine_opt_list opt_routine_body
   {
     name := $4.unresolvedObjectName().ToRoutineName()
@@ -4654,11 +4986,43 @@ create_func_stmt:
       Name: name,
       Params: $6.routineParams(),
       ReturnType: tree.RoutineReturnType{
-        Type: $11.typeReference(),
-        SetOf: $10.bool(),
+        Type: $10.typeReference(),
+        SetOf: $9.bool(),
+      },
+      Options: $11.routineOptions(),
+      RoutineBody: $12.routineBody(),
+    }
+  }
+| CREATE opt_or_replace FUNCTION routine_create_name '(' opt_routine_param_with_default_list ')'
+  RETURNS TABLE '(' table_func_column_list ')'
+  opt_create_routine_opt_list opt_routine_body
+  {
+    // The columns of the returned table are OUT parameters of the function.
+    name := $4.unresolvedObjectName().ToRoutineName()
+    $$.val = &tree.CreateRoutine{
+      IsProcedure: false,
+      Replace: $2.bool(),
+      Name: name,
+      Params: append($6.routineParams(), $11.routineParams()...),
+      ReturnType: tree.RoutineReturnType{
+        SetOf: true,
       },
-      Options: $12.routineOptions(),
-      RoutineBody: $13.routineBody(),
+      Options: $13.routineOptions(),
+      RoutineBody: $14.routineBody(),
+    }
+  }
+| CREATE opt_or_replace FUNCTION routine_create_name '(' opt_routine_param_with_default_list ')'
+  opt_create_routine_opt_list opt_routine_body
+  {
+    // The return type is omitted, so it is determined by the OUT parameters.
+    name := $4.unresolvedObjectName().ToRoutineName()
+    $$.val = &tree.CreateRoutine{
+      IsProcedure: false,
+      Replace: $2.bool(),
+      Name: name,
+      Params: $6.routineParams(),
+      Options: $8.routineOptions(),
+      RoutineBody: $9.routineBody(),
     }
   }
 | CREATE opt_or_replace FUNCTION error // SHOW HELP: CREATE FUNCTION
@@ -4669,6 +5033,8 @@ create_func_stmt:
 // CREATE [ OR REPLACE ] PROCEDURE
 //    name ( [ [ argmode ] [ argname ] argtype [, ...] ] )
 //  { LANGUAGE lang_name
+//    | [ EXTERNAL ] SECURITY { INVOKER | DEFINER }
+//    | SET var_name { TO | = | FROM CURRENT } ...
 //    | AS 'definition'
 //  } ...
 // %SeeAlso: WEBDOCS/create-procedure.html
@@ -4691,14 +5057,233 @@ create_proc_stmt:
   }
 | CREATE opt_or_replace PROCEDURE error // SHOW HELP: CREATE PROCEDURE
 
+// %Help: CREATE AGGREGATE - define a new aggregate function
+// %Category: DDL
+// %Text:
+// CREATE [ OR REPLACE ] AGGREGATE
+//    name ( [ [ argmode ] [ argname ] argtype [, ...] ] ) (
+//    SFUNC = sfunc,
+//    STYPE = state_data_type
+//    [ , FINALFUNC = ffunc ]
+//    [ , COMBINEFUNC = combinefunc ]
+//    [ , INITCOND = initial_condition ]
+// )
+// %SeeAlso: DROP AGGREGATE, ALTER AGGREGATE
+create_aggregate_stmt:
+  CREATE opt_or_replace AGGREGATE routine_create_name func_params '(' aggregate_def_list ')'
+  {
+    $$.val = &tree.CreateAggregate{
+      Replace: $2.bool(),
+      Name: $4.unresolvedObjectName().ToRoutineName(),
+      Params: $5.routineParams(),
+      Options: $7.aggregateOptions(),
+    }
+  }
+| CREATE opt_or_replace AGGREGATE error // SHOW HELP: CREATE AGGREGATE
+
+aggregate_def_list:
+  aggregate_def_elem
+  {
+    $$.val = tree.AggregateOptions{$1.aggregateOption()}
+  }
+| aggregate_def_list ',' aggregate_def_elem
+  {
+    $$.val = append($1.aggregateOptions(), $3.aggregateOption())
+  }
+
+aggregate_def_elem:
+  name '=' typename
+  {
+    $$.val = tree.AggregateOption{Name: tree.Name($1), Type: $3.typeReference()}
+  }
+| name '=' SCONST
+  {
+    $$.val = tree.AggregateOption{Name: tree.Name($1), Value: tree.NewStrVal($3)}
+  }
+| name '=' signed_iconst
+  {
+    $$.val = tree.AggregateOption{Name: tree.Name($1), Value: $3.numVal()}
+  }
+
+// %Help: CREATE TRIGGER - define a new trigger
+// %Category: DDL
+// %Text:
+// CREATE [ OR REPLACE ] TRIGGER name { BEFORE | AFTER | INSTEAD OF } { event [ OR ... ] }
+//    ON table_name
+//    [ REFERENCING { { OLD | NEW } TABLE [ AS ] transition_relation_name } [ ... ] ]
+//    [ FOR [ EACH ] { ROW | STATEMENT } ]
+//    [ WHEN ( condition ) ]
+//    EXECUTE { FUNCTION | PROCEDURE } function_name ( arguments )
+//
+// Events:
+//    INSERT
+//    UPDATE [ OF column_name [, ... ] ]
+//    DELETE
+//    TRUNCATE
+// %SeeAlso: DROP TRIGGER, CREATE FUNCTION
+create_trigger_stmt:
+  CREATE opt_or_replace TRIGGER name trigger_action_time trigger_event_list
+  ON table_name opt_trigger_transition_list trigger_for_each trigger_when
+  EXECUTE function_or_procedure func_name '(' opt_trigger_func_args ')'
+  {
+    $$.val = &tree.CreateTrigger{
+      Replace: $2.bool(),
+      Name: tree.Name($4),
+      ActionTime: $5.triggerActionTime(),
+      Events: $6.triggerEvents(),
+      TableName: $8.unresolvedObjectName(),
+      Transitions: $9.triggerTransitions(),
+      ForEach: $10.triggerForEach(),
+      When: $11.expr(),
+      FuncName: $14.unresolvedName(),
+      FuncArgs: $16.strs(),
+    }
+  }
+| CREATE opt_or_replace TRIGGER error // SHOW HELP: CREATE TRIGGER
+
+trigger_action_time:
+  BEFORE { $$.val = tree.TriggerActionTimeBefore }
+| AFTER { $$.val = tree.TriggerActionTimeAfter }
+| INSTEAD OF { $$.val = tree.TriggerActionTimeInsteadOf }
+
+trigger_event_list:
+  trigger_event
+  {
+    $$.val = []*tree.TriggerEvent{$1.triggerEvent()}
+  }
+| trigger_event_list OR trigger_event
+  {
+    $$.val = append($1.triggerEvents(), $3.triggerEvent())
+  }
+
+trigger_event:
+  INSERT
+  {
+    $$.val = &tree.TriggerEvent{EventType: tree.TriggerEventInsert}
+  }
+| DELETE
+  {
+    $$.val = &tree.TriggerEvent{EventType: tree.TriggerEventDelete}
+  }
+| UPDATE
+  {
+    $$.val = &tree.TriggerEvent{EventType: tree.TriggerEventUpdate}
+  }
+| UPDATE OF name_list
+  {
+    $$.val = &tree.TriggerEvent{EventType: tree.TriggerEventUpdate, Columns: $3.nameList()}
+  }
+| TRUNCATE
+  {
+    $$.val = &tree.TriggerEvent{EventType: tree.TriggerEventTruncate}
+  }
+
+opt_trigger_transition_list:
+  REFERENCING trigger_transition_list
+  {
+    $$.val = $2.triggerTransitions()
+  }
+| /* EMPTY */
+  {
+    $$.val = []*tree.TriggerTransition(nil)
+  }
+
+trigger_transition_list:
+  trigger_transition
+  {
+    $$.val = []*tree.TriggerTransition{$1.triggerTransition()}
+  }
+| trigger_transition_list trigger_transition
+  {
+    $$.val = append($1.triggerTransitions(), $2.triggerTransition())
+  }
+
+trigger_transition:
+  transition_is_new TABLE opt_as table_alias_name
+  {
+    $$.val = &tree.TriggerTransition{
+      Name: tree.Name($4),
+      IsNew: $1.bool(),
+    }
+  }
+| transition_is_new ROW error
+  {
+    return unimplementedWithIssueDetail(sqllex, 28296, "trigger transition row")
+  }
+
+transition_is_new:
+  NEW { $$.val = true }
+| OLD { $$.val = false }
+
+opt_as:
+  AS {}
+| /* EMPTY */ {}
+
+trigger_for_each:
+  FOR opt_each ROW
+  {
+    $$.val = tree.TriggerForEachRow
+  }
+| FOR opt_each STATEMENT
+  {
+    $$.val = tree.TriggerForEachStatement
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.TriggerForEachStatement
+  }
+
+opt_each:
+  EACH {}
+| /* EMPTY */ {}
+
+trigger_when:
+  WHEN '(' a_expr ')'
+  {
+    $$.val = $3.expr()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.Expr(nil)
+  }
+
+function_or_procedure:
+  FUNCTION {}
+| PROCEDURE {}
+
+opt_trigger_func_args:
+  trigger_func_args
+| /* EMPTY */
+  {
+    $$.val = []string(nil)
+  }
+
+trigger_func_args:
+  trigger_func_arg
+  {
+    $$.val = []string{$1}
+  }
+| trigger_func_args ',' trigger_func_arg
+  {
+    $$.val = append($1.strs(), $3)
+  }
+
+trigger_func_arg:
+  ICONST
+  {
+    $$ = $1.numVal().OrigString()
+  }
+| FCONST
+  {
+    $$ = $1.numVal().OrigString()
+  }
+| SCONST
+| unrestricted_name
+
 opt_or_replace:
   OR REPLACE { $$.val = true }
 | /* EMPTY */ { $$.val = false }
 
-opt_return_table:
-  TABLE { return unimplementedWithIssueDetail(sqllex, 100226, "UDF returning TABLE") }
-| /* EMPTY */ { $$.val = false }
-
 opt_return_set:
   SETOF { $$.val = true}
 | /* EMPTY */ { $$.val = false }
@@ -4774,17 +5359,34 @@ routine_param:
 
 routine_param_class:
   IN { $$.val = tree.RoutineParamIn }
-| OUT { return unimplementedWithIssueDetail(sqllex, 100405, "create function with 'OUT' argument class") }
-| INOUT { return unimplementedWithIssueDetail(sqllex, 100405, "create function with 'INOUT' argument class") }
-| IN OUT { return unimplementedWithIssueDetail(sqllex, 100405, "create function with 'IN OUT' argument class") }
-| VARIADIC { return unimplementedWithIssueDetail(sqllex, 88947, "variadic user-defined functions") }
+| OUT { $$.val = tree.RoutineParamOut }
+| INOUT { $$.val = tree.RoutineParamInOut }
+| IN OUT { $$.val = tree.RoutineParamInOut }
+| VARIADIC { $$.val = tree.RoutineParamVariadic }
 
 routine_param_type:
   typename
 
-routine_return_type:
-  routine_param_type
-
+table_func_column_list:
+  table_func_column { $$.val = tree.RoutineParams{$1.routineParam()} }
+| table_func_column_list ',' table_func_column
+  {
+    $$.val = append($1.routineParams(), $3.routineParam())
+  }
+
+table_func_column:
+  param_name routine_param_type
+  {
+    $$.val = tree.RoutineParam{
+      Name: tree.Name($1),
+      Type: $2.typeReference(),
+      Class: tree.RoutineParamTable,
+    }
+  }
+
+routine_return_type:
+  routine_param_type
+
 opt_create_routine_opt_list:
   create_routine_opt_list { $$.val = $1.routineOptions() }
 | /* EMPTY */ { $$.val = tree.RoutineOptions{} }
@@ -4843,19 +5445,19 @@ common_routine_opt_item:
   }
 | EXTERNAL SECURITY DEFINER
   {
-    return unimplemented(sqllex, "create function...security")
+    $$.val = tree.RoutineDefiner
   }
 | EXTERNAL SECURITY INVOKER
   {
-    return unimplemented(sqllex, "create function...security")
+    $$.val = tree.RoutineInvoker
   }
 | SECURITY DEFINER
   {
-    return unimplemented(sqllex, "create function...security")
+    $$.val = tree.RoutineDefiner
   }
 | SECURITY INVOKER
   {
-    return unimplemented(sqllex, "create function...security")
+    $$.val = tree.RoutineInvoker
   }
 | LEAKPROOF
   {
@@ -4877,11 +5479,22 @@ common_routine_opt_item:
   {
     return unimplemented(sqllex, "create function/procedure ... support")
   }
-
-// In theory we should parse the a whole set/reset statement here. But it's fine
-// to just return fast on SET/RESET keyword for now since it's not supported
-// yet.
-| SET { return unimplemented(sqllex, "create function/procedure ... set") }
+| SET var_name to_or_eq var_list
+  {
+    $$.val = tree.RoutineSet{Name: strings.Join($2.strs(), "."), Values: $4.exprs()}
+  }
+| SET var_name FROM CURRENT
+  {
+    $$.val = tree.RoutineSet{Name: strings.Join($2.strs(), "."), FromCurrent: true}
+  }
+| RESET session_var
+  {
+    $$.val = tree.RoutineSet{Name: $2, Reset: true}
+  }
+| RESET_ALL ALL
+  {
+    $$.val = tree.RoutineSet{ResetAll: true}
+  }
 | PARALLEL { return unimplemented(sqllex, "create function/procedure ... parallel") }
 
 routine_as:
@@ -4985,6 +5598,62 @@ drop_proc_stmt:
   }
 | DROP PROCEDURE error // SHOW HELP: DROP PROCEDURE
 
+// %Help: DROP AGGREGATE - remove an aggregate function
+// %Category: DDL
+// %Text:
+// DROP AGGREGATE [ IF EXISTS ] name ( [ [ argmode ] [ argname ] argtype [, ...] ] ) [, ...]
+//    [ CASCADE | RESTRICT ]
+// %SeeAlso: CREATE AGGREGATE
+drop_aggregate_stmt:
+  DROP AGGREGATE function_with_paramtypes_list opt_drop_behavior
+  {
+    $$.val = &tree.DropRoutine{
+      Aggregate: true,
+      Routines: $3.routineObjs(),
+      DropBehavior: $4.dropBehavior(),
+    }
+  }
+| DROP AGGREGATE IF EXISTS function_with_paramtypes_list opt_drop_behavior
+  {
+    $$.val = &tree.DropRoutine{
+      IfExists: true,
+      Aggregate: true,
+      Routines: $5.routineObjs(),
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP AGGREGATE error // SHOW HELP: DROP AGGREGATE
+
+// %Help: DROP TRIGGER - remove a trigger
+// %Category: DDL
+// %Text:
+// DROP TRIGGER [ IF EXISTS ] name ON table_name [ CASCADE | RESTRICT ]
+// %SeeAlso: CREATE TRIGGER
+drop_trigger_stmt:
+  DROP TRIGGER name ON table_name opt_drop_behavior
+  {
+    $$.val = &tree.DropTrigger{
+      Trigger: tree.Name($3),
+      Table: $5.unresolvedObjectName(),
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP TRIGGER IF EXISTS name ON table_name opt_drop_behavior
+  {
+    $$.val = &tree.DropTrigger{
+      IfExists: true,
+      Trigger: tree.Name($5),
+      Table: $7.unresolvedObjectName(),
+      DropBehavior: $8.dropBehavior(),
+    }
+  }
+| DROP TRIGGER name
+  {
+    // MySQL and SQLite allow the table to be omitted.
+    return unimplementedWithIssueDetail(sqllex, 28296, "drop trigger without table")
+  }
+| DROP TRIGGER error // SHOW HELP: DROP TRIGGER
+
 function_with_paramtypes_list:
   function_with_paramtypes
   {
@@ -5120,6 +5789,36 @@ alter_proc_owner_stmt:
     }
   }
 
+alter_aggregate_rename_stmt:
+  ALTER AGGREGATE function_with_paramtypes RENAME TO name
+  {
+    $$.val = &tree.AlterRoutineRename{
+      Function: $3.functionObj(),
+      NewName: tree.Name($6),
+      Aggregate: true,
+    }
+  }
+
+alter_aggregate_set_schema_stmt:
+  ALTER AGGREGATE function_with_paramtypes SET SCHEMA schema_name
+  {
+    $$.val = &tree.AlterRoutineSetSchema{
+      Function: $3.functionObj(),
+      NewSchemaName: tree.Name($6),
+      Aggregate: true,
+    }
+  }
+
+alter_aggregate_owner_stmt:
+  ALTER AGGREGATE function_with_paramtypes OWNER TO role_spec
+  {
+    $$.val = &tree.AlterRoutineSetOwner{
+      Function: $3.functionObj(),
+      NewOwner: $6.roleSpec(),
+      Aggregate: true,
+    }
+  }
+
 opt_no:
   NO
   {
@@ -5132,22 +5831,18 @@ opt_no:
 
 create_unsupported:
   CREATE ACCESS METHOD error { return unimplemented(sqllex, "create access method") }
-| CREATE AGGREGATE error { return unimplementedWithIssueDetail(sqllex, 74775, "create aggregate") }
 | CREATE CAST error { return unimplemented(sqllex, "create cast") }
 | CREATE CONSTRAINT TRIGGER error { return unimplementedWithIssueDetail(sqllex, 28296, "create constraint") }
 | CREATE CONVERSION error { return unimplemented(sqllex, "create conversion") }
 | CREATE DEFAULT CONVERSION error { return unimplemented(sqllex, "create def conv") }
-| CREATE FOREIGN TABLE error { return unimplemented(sqllex, "create foreign table") }
 | CREATE FOREIGN DATA error { return unimplemented(sqllex, "create fdw") }
 | CREATE opt_or_replace opt_trusted opt_procedural LANGUAGE name error { return unimplementedWithIssueDetail(sqllex, 17511, "create language " + $6) }
 | CREATE OPERATOR error { return unimplementedWithIssue(sqllex, 65017) }
-| CREATE PUBLICATION error { return unimplemented(sqllex, "create publication") }
 | CREATE opt_or_replace RULE error { return unimplemented(sqllex, "create rule") }
-| CREATE SERVER error { return unimplemented(sqllex, "create server") }
 | CREATE SUBSCRIPTION error { return unimplemented(sqllex, "create subscription") }
 | CREATE TABLESPACE error { return unimplementedWithIssueDetail(sqllex, 54113, "create tablespace") }
-| CREATE TEXT error { return unimplementedWithIssueDetail(sqllex, 7821, "create text") }
-| CREATE TRIGGER error { return unimplementedWithIssueDetail(sqllex, 28296, "create trigger") }
+| CREATE TEXT SEARCH IDENT error { return unimplementedWithIssueDetail(sqllex, 7821, "create text search " + $4) }
+| CREATE TEXT SEARCH TEMPLATE error { return unimplementedWithIssueDetail(sqllex, 7821, "create text search template") }
 
 opt_trusted:
   TRUSTED {}
@@ -5159,23 +5854,18 @@ opt_procedural:
 
 drop_unsupported:
   DROP ACCESS METHOD error { return unimplemented(sqllex, "drop access method") }
-| DROP AGGREGATE error { return unimplementedWithIssueDetail(sqllex, 74775, "drop aggregate") }
 | DROP CAST error { return unimplemented(sqllex, "drop cast") }
 | DROP COLLATION error { return unimplemented(sqllex, "drop collation") }
 | DROP CONVERSION error { return unimplemented(sqllex, "drop conversion") }
-| DROP DOMAIN error { return unimplementedWithIssueDetail(sqllex, 27796, "drop") }
 | DROP EXTENSION IF EXISTS name error { return unimplementedWithIssueDetail(sqllex, 74777, "drop extension if exists") }
 | DROP EXTENSION name error { return unimplementedWithIssueDetail(sqllex, 74777, "drop extension") }
-| DROP FOREIGN TABLE error { return unimplemented(sqllex, "drop foreign table") }
 | DROP FOREIGN DATA error { return unimplemented(sqllex, "drop fdw") }
 | DROP opt_procedural LANGUAGE name error { return unimplementedWithIssueDetail(sqllex, 17511, "drop language " + $4) }
 | DROP OPERATOR error { return unimplemented(sqllex, "drop operator") }
-| DROP PUBLICATION error { return unimplemented(sqllex, "drop publication") }
 | DROP RULE error { return unimplemented(sqllex, "drop rule") }
-| DROP SERVER error { return unimplemented(sqllex, "drop server") }
 | DROP SUBSCRIPTION error { return unimplemented(sqllex, "drop subscription") }
-| DROP TEXT error { return unimplementedWithIssueDetail(sqllex, 7821, "drop text") }
-| DROP TRIGGER error { return unimplementedWithIssueDetail(sqllex, 28296, "drop") }
+| DROP TEXT SEARCH IDENT error { return unimplementedWithIssueDetail(sqllex, 7821, "drop text search " + $4) }
+| DROP TEXT SEARCH TEMPLATE error { return unimplementedWithIssueDetail(sqllex, 7821, "drop text search template") }
 
 create_ddl_stmt:
   create_database_stmt // EXTEND WITH HELP: CREATE DATABASE
@@ -5186,10 +5876,18 @@ create_ddl_stmt:
 // Error case for both CREATE TABLE and CREATE TABLE ... AS in one
 | CREATE opt_persistence_temp_table TABLE error   // SHOW HELP: CREATE TABLE
 | create_type_stmt     // EXTEND WITH HELP: CREATE TYPE
+| create_domain_stmt   // EXTEND WITH HELP: CREATE DOMAIN
 | create_view_stmt     // EXTEND WITH HELP: CREATE VIEW
 | create_sequence_stmt // EXTEND WITH HELP: CREATE SEQUENCE
 | create_func_stmt     // EXTEND WITH HELP: CREATE FUNCTION
 | create_proc_stmt     // EXTEND WITH HELP: CREATE PROCEDURE
+| create_aggregate_stmt // EXTEND WITH HELP: CREATE AGGREGATE
+| create_trigger_stmt  // EXTEND WITH HELP: CREATE TRIGGER
+| create_publication_stmt // EXTEND WITH HELP: CREATE PUBLICATION
+| create_text_search_config_stmt // EXTEND WITH HELP: CREATE TEXT SEARCH CONFIGURATION
+| create_text_search_dict_stmt // EXTEND WITH HELP: CREATE TEXT SEARCH DICTIONARY
+| create_server_stmt   // EXTEND WITH HELP: CREATE SERVER
+| create_foreign_table_stmt // EXTEND WITH HELP: CREATE FOREIGN TABLE
 
 // %Help: CREATE STATISTICS - create a new table statistic
 // %Category: Misc
@@ -5574,8 +6272,16 @@ drop_ddl_stmt:
 | drop_sequence_stmt // EXTEND WITH HELP: DROP SEQUENCE
 | drop_schema_stmt   // EXTEND WITH HELP: DROP SCHEMA
 | drop_type_stmt     // EXTEND WITH HELP: DROP TYPE
+| drop_domain_stmt   // EXTEND WITH HELP: DROP DOMAIN
 | drop_func_stmt     // EXTEND WITH HELP: DROP FUNCTION
 | drop_proc_stmt     // EXTEND WITH HELP: DROP FUNCTION
+| drop_aggregate_stmt // EXTEND WITH HELP: DROP AGGREGATE
+| drop_trigger_stmt  // EXTEND WITH HELP: DROP TRIGGER
+| drop_publication_stmt // EXTEND WITH HELP: DROP PUBLICATION
+| drop_text_search_config_stmt // EXTEND WITH HELP: DROP TEXT SEARCH CONFIGURATION
+| drop_text_search_dict_stmt // EXTEND WITH HELP: DROP TEXT SEARCH DICTIONARY
+| drop_server_stmt   // EXTEND WITH HELP: DROP SERVER
+| drop_foreign_table_stmt // EXTEND WITH HELP: DROP FOREIGN TABLE
 
 // %Help: DROP VIEW - remove a view
 // %Category: DDL
@@ -5710,6 +6416,150 @@ drop_type_stmt:
   }
 | DROP TYPE error // SHOW HELP: DROP TYPE
 
+// %Help: DROP DOMAIN - remove a domain
+// %Category: DDL
+// %Text: DROP DOMAIN [IF EXISTS] <domain_name> [, ...] [CASCADE | RESTRICT]
+// %SeeAlso: CREATE DOMAIN, ALTER DOMAIN
+drop_domain_stmt:
+  DROP DOMAIN type_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropType{
+      Names: $3.unresolvedObjectNames(),
+      IfExists: false,
+      DropBehavior: $4.dropBehavior(),
+      Domain: true,
+    }
+  }
+| DROP DOMAIN IF EXISTS type_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropType{
+      Names: $5.unresolvedObjectNames(),
+      IfExists: true,
+      DropBehavior: $6.dropBehavior(),
+      Domain: true,
+    }
+  }
+| DROP DOMAIN error // SHOW HELP: DROP DOMAIN
+
+// %Help: DROP PUBLICATION - remove a publication
+// %Category: DDL
+// %Text: DROP PUBLICATION [IF EXISTS] <name> [, ...] [CASCADE | RESTRICT]
+// %SeeAlso: CREATE PUBLICATION
+drop_publication_stmt:
+  DROP PUBLICATION name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropPublication{
+      Names: $3.nameList(),
+      IfExists: false,
+      DropBehavior: $4.dropBehavior(),
+    }
+  }
+| DROP PUBLICATION IF EXISTS name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropPublication{
+      Names: $5.nameList(),
+      IfExists: true,
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP PUBLICATION error // SHOW HELP: DROP PUBLICATION
+
+// %Help: DROP TEXT SEARCH CONFIGURATION - remove a text search configuration
+// %Category: DDL
+// %Text: DROP TEXT SEARCH CONFIGURATION [IF EXISTS] <name> [, ...] [CASCADE | RESTRICT]
+// %SeeAlso: CREATE TEXT SEARCH CONFIGURATION
+drop_text_search_config_stmt:
+  DROP TEXT SEARCH CONFIGURATION type_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropTextSearch{
+      Names: $5.unresolvedObjectNames(),
+      IfExists: false,
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP TEXT SEARCH CONFIGURATION IF EXISTS type_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropTextSearch{
+      Names: $7.unresolvedObjectNames(),
+      IfExists: true,
+      DropBehavior: $8.dropBehavior(),
+    }
+  }
+| DROP TEXT SEARCH CONFIGURATION error // SHOW HELP: DROP TEXT SEARCH CONFIGURATION
+
+// %Help: DROP TEXT SEARCH DICTIONARY - remove a text search dictionary
+// %Category: DDL
+// %Text: DROP TEXT SEARCH DICTIONARY [IF EXISTS] <name> [, ...] [CASCADE | RESTRICT]
+// %SeeAlso: CREATE TEXT SEARCH DICTIONARY
+drop_text_search_dict_stmt:
+  DROP TEXT SEARCH DICTIONARY type_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropTextSearch{
+      Names: $5.unresolvedObjectNames(),
+      IfExists: false,
+      DropBehavior: $6.dropBehavior(),
+      Dictionary: true,
+    }
+  }
+| DROP TEXT SEARCH DICTIONARY IF EXISTS type_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropTextSearch{
+      Names: $7.unresolvedObjectNames(),
+      IfExists: true,
+      DropBehavior: $8.dropBehavior(),
+      Dictionary: true,
+    }
+  }
+| DROP TEXT SEARCH DICTIONARY error // SHOW HELP: DROP TEXT SEARCH DICTIONARY
+
+// %Help: DROP SERVER - remove a foreign server
+// %Category: DDL
+// %Text: DROP SERVER [IF EXISTS] <name> [, ...] [CASCADE | RESTRICT]
+// %SeeAlso: CREATE SERVER, DROP FOREIGN TABLE
+drop_server_stmt:
+  DROP SERVER name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropForeignServer{
+      Names: $3.nameList(),
+      IfExists: false,
+      DropBehavior: $4.dropBehavior(),
+    }
+  }
+| DROP SERVER IF EXISTS name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropForeignServer{
+      Names: $5.nameList(),
+      IfExists: true,
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP SERVER error // SHOW HELP: DROP SERVER
+
+// %Help: DROP FOREIGN TABLE - remove a foreign table
+// %Category: DDL
+// %Text: DROP FOREIGN TABLE [IF EXISTS] <tablename> [, ...] [CASCADE | RESTRICT]
+// %SeeAlso: CREATE FOREIGN TABLE
+drop_foreign_table_stmt:
+  DROP FOREIGN TABLE table_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropTable{
+      Names: $4.tableNames(),
+      IfExists: false,
+      DropBehavior: $5.dropBehavior(),
+      IsForeign: true,
+    }
+  }
+| DROP FOREIGN TABLE IF EXISTS table_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropTable{
+      Names: $6.tableNames(),
+      IfExists: true,
+      DropBehavior: $7.dropBehavior(),
+      IsForeign: true,
+    }
+  }
+| DROP FOREIGN TABLE error // SHOW HELP: DROP FOREIGN TABLE
+
 // %Help: DROP VIRTUAL CLUSTER - remove a virtual cluster
 // %Category: Experimental
 // %Text: DROP VIRTUAL CLUSTER [IF EXISTS] <virtual_cluster_spec> [IMMEDIATE]
@@ -5930,6 +6780,7 @@ preparable_stmt:
 | explain_stmt   // EXTEND WITH HELP: EXPLAIN
 | import_stmt    // EXTEND WITH HELP: IMPORT
 | insert_stmt    // EXTEND WITH HELP: INSERT
+| merge_stmt     // EXTEND WITH HELP: MERGE
 | pause_stmt     // help texts in sub-rule
 | reset_stmt     // help texts in sub-rule
 | restore_stmt   // EXTEND WITH HELP: RESTORE
@@ -6644,7 +7495,7 @@ use_stmt:
 nonpreparable_set_stmt:
   set_transaction_stmt // EXTEND WITH HELP: SET TRANSACTION
 | set_exprs_internal   { /* SKIP DOC */ }
-| SET CONSTRAINTS error { return unimplemented(sqllex, "set constraints") }
+| set_constraints_stmt // EXTEND WITH HELP: SET CONSTRAINTS
 
 // SET SESSION / SET LOCAL / SET CLUSTER SETTING
 preparable_set_stmt:
@@ -7073,6 +7924,36 @@ set_local_stmt:
   }
 | SET LOCAL error  // SHOW HELP: SET LOCAL
 
+// %Help: SET CONSTRAINTS - set constraint check timing for the current transaction
+// %Category: Txn
+// %Text:
+// SET CONSTRAINTS { ALL | <name> [, ...] } { DEFERRED | IMMEDIATE }
+//
+// Only DEFERRABLE foreign key and UNIQUE WITHOUT INDEX constraints can be
+// deferred. The checks of deferred constraints run when the transaction
+// commits.
+// %SeeAlso: SET TRANSACTION
+set_constraints_stmt:
+  SET CONSTRAINTS ALL constraints_set_mode
+  {
+    $$.val = &tree.SetConstraints{Deferred: $4.bool()}
+  }
+| SET CONSTRAINTS name_list constraints_set_mode
+  {
+    $$.val = &tree.SetConstraints{Names: $3.nameList(), Deferred: $4.bool()}
+  }
+| SET CONSTRAINTS error // SHOW HELP: SET CONSTRAINTS
+
+constraints_set_mode:
+  DEFERRED
+  {
+    $$.val = true
+  }
+| IMMEDIATE
+  {
+    $$.val = false
+  }
+
 // %Help: SET TRANSACTION - configure the transaction settings
 // %Category: Txn
 // %Text:
@@ -7559,32 +8440,40 @@ from_or_in:
   FROM { }
 | IN { }
 
+// %Help: REINDEX - rebuild indexes
+// %Category: DDL
+// %Text:
+// REINDEX INDEX [CONCURRENTLY] <idxname>
+// REINDEX TABLE [CONCURRENTLY] <tablename>
+// REINDEX SCHEMA [CONCURRENTLY] <schemaname>
+// REINDEX DATABASE [CONCURRENTLY] <databasename>
+//
+// Indexes are rebuilt online: each index is backfilled into a replacement
+// index, which then takes its place. CONCURRENTLY is accepted for
+// compatibility and has no effect.
 reindex_stmt:
-  REINDEX TABLE error
+  REINDEX INDEX opt_concurrently table_index_name
   {
-    /* SKIP DOC */
-    return purposelyUnimplemented(sqllex, "reindex table", "CockroachDB does not require reindexing.")
+    $$.val = &tree.Reindex{Target: tree.ReindexIndex, Concurrently: $3.bool(), Index: *$4.newTableIndexName()}
   }
-| REINDEX INDEX error
+| REINDEX TABLE opt_concurrently table_name
   {
-    /* SKIP DOC */
-    return purposelyUnimplemented(sqllex, "reindex index", "CockroachDB does not require reindexing.")
+    $$.val = &tree.Reindex{Target: tree.ReindexTable, Concurrently: $3.bool(), Table: $4.unresolvedObjectName()}
   }
-| REINDEX SCHEMA error
+| REINDEX SCHEMA opt_concurrently qualifiable_schema_name
   {
-    /* SKIP DOC */
-    return purposelyUnimplemented(sqllex, "reindex schema", "CockroachDB does not require reindexing.")
+    $$.val = &tree.Reindex{Target: tree.ReindexSchema, Concurrently: $3.bool(), Schema: $4.objectNamePrefix()}
   }
-| REINDEX DATABASE error
+| REINDEX DATABASE opt_concurrently database_name
   {
-    /* SKIP DOC */
-    return purposelyUnimplemented(sqllex, "reindex database", "CockroachDB does not require reindexing.")
+    $$.val = &tree.Reindex{Target: tree.ReindexDatabase, Concurrently: $3.bool(), Database: tree.Name($4)}
   }
 | REINDEX SYSTEM error
   {
     /* SKIP DOC */
-    return purposelyUnimplemented(sqllex, "reindex system", "CockroachDB does not require reindexing.")
+    return purposelyUnimplemented(sqllex, "reindex system", "CockroachDB does not require reindexing system catalogs.")
   }
+| REINDEX error // SHOW HELP: REINDEX
 
 // %Help: SHOW SESSION - display session variables
 // %Category: Cfg
@@ -10104,7 +10993,7 @@ col_qualification_elem:
   {
     $$.val = &tree.ColumnOnUpdate{Expr: $3.expr()}
   }
-| REFERENCES table_name opt_name_parens key_match reference_actions
+| REFERENCES table_name opt_name_parens key_match reference_actions opt_deferrable
   {
     name := $2.unresolvedObjectName().ToTableName()
     $$.val = &tree.ColumnFKConstraint{
@@ -10112,6 +11001,7 @@ col_qualification_elem:
       Col: tree.Name($3),
       Actions: $5.referenceActions(),
       Match: $4.compositeKeyMatchMethod(),
+      Deferrability: $6.constraintDeferrability(),
     }
   }
 | generated_as '(' a_expr ')' STORED
@@ -10262,6 +11152,10 @@ table_constraint:
 constraint_elem:
   CHECK '(' a_expr ')' opt_deferrable
   {
+    if $5.constraintDeferrability().Deferrable {
+      return setErr(sqllex, pgerror.New(pgcode.FeatureNotSupported,
+        "CHECK constraints cannot be marked DEFERRABLE"))
+    }
     $$.val = &tree.CheckConstraintTableDef{
       Expr: $3.expr(),
     }
@@ -10269,6 +11163,9 @@ constraint_elem:
 | UNIQUE opt_without_index '(' index_params ')'
     opt_storing opt_partition_by_index opt_deferrable opt_where_clause
   {
+    if $8.constraintDeferrability().Deferrable && !$2.bool() {
+      return unimplementedWithIssueDetail(sqllex, 31632, "deferrable unique index")
+    }
     $$.val = &tree.UniqueConstraintTableDef{
       WithoutIndex: $2.bool(),
       IndexTableDef: tree.IndexTableDef{
@@ -10277,6 +11174,7 @@ constraint_elem:
         PartitionByIndex: $7.partitionByIndex(),
         Predicate: $9.expr(),
       },
+      Deferrability: $8.constraintDeferrability(),
     }
   }
 | PRIMARY KEY '(' index_params ')' opt_hash_sharded opt_with_storage_parameter_list
@@ -10300,11 +11198,67 @@ constraint_elem:
       ToCols: $8.nameList(),
       Match: $9.compositeKeyMatchMethod(),
       Actions: $10.referenceActions(),
+      Deferrability: $11.constraintDeferrability(),
+    }
+  }
+| EXCLUDE opt_exclude_using '(' exclude_elems ')' opt_where_clause
+  {
+    $$.val = &tree.ExclusionConstraintTableDef{
+      Using: $2,
+      Elems: $4.exclusionElems(),
+      Predicate: $6.expr(),
     }
   }
-| EXCLUDE USING error
+
+opt_exclude_using:
+  USING name
+  {
+    /* FORCE DOC */
+    switch $2 {
+      case "gist", "btree":
+        $$ = $2
+      case "gin", "hash", "spgist", "brin":
+        return unimplementedWithIssueDetail(sqllex, 46657, "exclude using " + $2)
+      default:
+        sqllex.Error("unrecognized access method: " + $2)
+        return 1
+    }
+  }
+| /* EMPTY */
+  {
+    $$ = ""
+  }
+
+exclude_elems:
+  exclude_elem
   {
-    return unimplementedWithIssueDetail(sqllex, 46657, "add constraint exclude using")
+    $$.val = tree.ExclusionElemList{$1.exclusionElem()}
+  }
+| exclude_elems ',' exclude_elem
+  {
+    $$.val = append($1.exclusionElems(), $3.exclusionElem())
+  }
+
+// An element of an EXCLUDE constraint is either a column, or a period made of
+// a start and an end column.
+exclude_elem:
+  name WITH all_op
+  {
+    /* FORCE DOC */
+    op, ok := $3.op().(treecmp.ComparisonOperator)
+    if !ok || (op.Symbol != treecmp.EQ && op.Symbol != treecmp.Overlaps) {
+      return unimplementedWithIssueDetail(sqllex, 46657, "exclude using operator")
+    }
+    $$.val = tree.ExclusionElem{Columns: tree.NameList{tree.Name($1)}, Operator: op}
+  }
+| '(' name ',' name ')' WITH all_op
+  {
+    /* FORCE DOC */
+    op, ok := $7.op().(treecmp.ComparisonOperator)
+    if !ok || op.Symbol != treecmp.Overlaps {
+      return unimplementedWithIssueDetail(sqllex, 46657, "exclude using operator")
+    }
+    $$.val = tree.ExclusionElem{Columns: tree.NameList{tree.Name($2), tree.Name($4)}, Operator: op}
   }
 
 
@@ -10412,13 +11366,32 @@ create_as_col_qualification_elem:
     }
   }
 
+// As in Postgres, INITIALLY DEFERRED implies DEFERRABLE.
 opt_deferrable:
-  /* EMPTY */ { /* no error */ }
-| DEFERRABLE { return unimplementedWithIssueDetail(sqllex, 31632, "deferrable") }
-| DEFERRABLE INITIALLY DEFERRED { return unimplementedWithIssueDetail(sqllex, 31632, "def initially deferred") }
-| DEFERRABLE INITIALLY IMMEDIATE { return unimplementedWithIssueDetail(sqllex, 31632, "def initially immediate") }
-| INITIALLY DEFERRED { return unimplementedWithIssueDetail(sqllex, 31632, "initially deferred") }
-| INITIALLY IMMEDIATE { return unimplementedWithIssueDetail(sqllex, 31632, "initially immediate") }
+  /* EMPTY */
+  {
+    $$.val = tree.ConstraintDeferrability{}
+  }
+| INITIALLY IMMEDIATE
+  {
+    $$.val = tree.ConstraintDeferrability{}
+  }
+| DEFERRABLE
+  {
+    $$.val = tree.ConstraintDeferrability{Deferrable: true}
+  }
+| DEFERRABLE INITIALLY IMMEDIATE
+  {
+    $$.val = tree.ConstraintDeferrability{Deferrable: true}
+  }
+| DEFERRABLE INITIALLY DEFERRED
+  {
+    $$.val = tree.ConstraintDeferrability{Deferrable: true, InitiallyDeferred: true}
+  }
+| INITIALLY DEFERRED
+  {
+    $$.val = tree.ConstraintDeferrability{Deferrable: true, InitiallyDeferred: true}
+  }
 
 storing:
   COVERING
@@ -11020,7 +11993,10 @@ opt_view_recursive:
 
 // %Help: CREATE TYPE - create a type
 // %Category: DDL
-// %Text: CREATE TYPE [IF NOT EXISTS] <type_name> AS ENUM (...)
+// %Text:
+// CREATE TYPE [IF NOT EXISTS] <type_name> AS ENUM (...)
+// CREATE TYPE [IF NOT EXISTS] <type_name> AS (<field> <type>, ...)
+// CREATE TYPE [IF NOT EXISTS] <type_name> AS RANGE (SUBTYPE = <type>)
 create_type_stmt:
   // Enum types.
   CREATE TYPE type_name AS ENUM '(' opt_enum_val_list ')'
@@ -11060,13 +12036,300 @@ create_type_stmt:
     }
   }
   // Range types.
-| CREATE TYPE type_name AS RANGE error    { return unimplementedWithIssue(sqllex, 27791) }
+| CREATE TYPE type_name AS RANGE '(' range_type_param_list ')'
+  {
+    $$.val = &tree.CreateType{
+      TypeName: $3.unresolvedObjectName(),
+      Variety: tree.Range,
+      RangeSubtype: $7.typeReference(),
+    }
+  }
+| CREATE TYPE IF NOT EXISTS type_name AS RANGE '(' range_type_param_list ')'
+  {
+    $$.val = &tree.CreateType{
+      TypeName: $6.unresolvedObjectName(),
+      Variety: tree.Range,
+      RangeSubtype: $10.typeReference(),
+      IfNotExists: true,
+    }
+  }
   // Base (primitive) types.
 | CREATE TYPE type_name '(' error         { return unimplementedWithIssueDetail(sqllex, 27793, "base") }
   // Shell types, gateway to define base types using the previous syntax.
 | CREATE TYPE type_name                   { return unimplementedWithIssueDetail(sqllex, 27793, "shell") }
-  // Domain types.
-| CREATE DOMAIN type_name error           { return unimplementedWithIssueDetail(sqllex, 27796, "create") }
+
+// range_type_param_list is the list of parameters of a CREATE TYPE ... AS
+// RANGE statement. The only supported parameter is SUBTYPE, which is
+// required, so the list evaluates to the subtype of the range.
+range_type_param_list:
+  range_type_param
+| range_type_param_list ',' range_type_param
+  {
+    sqllex.Error("conflicting or redundant options")
+    return 1
+  }
+
+range_type_param:
+  IDENT '=' typename
+  {
+    switch $1 {
+    case "subtype":
+      $$.val = $3.typeReference()
+    case "subtype_opclass", "canonical", "subtype_diff", "multirange_type_name":
+      return unimplementedWithIssueDetail(sqllex, 27791, $1)
+    default:
+      sqllex.Error("type attribute \"" + $1 + "\" not recognized")
+      return 1
+    }
+  }
+| COLLATION '=' typename
+  {
+    return unimplementedWithIssueDetail(sqllex, 27791, "collation")
+  }
+
+// %Help: CREATE DOMAIN - create a domain
+// %Category: DDL
+// %Text:
+// CREATE DOMAIN <domain_name> [AS] <type>
+//   [DEFAULT <expr>]
+//   [ [CONSTRAINT <constraint_name>] { NOT NULL | NULL | CHECK (<expr>) } ] [...]
+//
+// CHECK expressions refer to the value being checked as VALUE.
+// %SeeAlso: ALTER DOMAIN, DROP DOMAIN
+create_domain_stmt:
+  CREATE DOMAIN type_name opt_as typename opt_domain_default opt_domain_constraint_list
+  {
+    $$.val = &tree.CreateType{
+      TypeName: $3.unresolvedObjectName(),
+      Variety: tree.Domain,
+      DomainType: $5.typeReference(),
+      DomainDefault: $6.expr(),
+      DomainConstraints: $7.domainConstraints(),
+    }
+  }
+| CREATE DOMAIN error // SHOW HELP: CREATE DOMAIN
+
+// %Help: CREATE PUBLICATION - create a publication for logical replication
+// %Category: DDL
+// %Text:
+// CREATE PUBLICATION <name>
+//   [ FOR ALL TABLES | FOR TABLE <tablename> [, ...] ]
+//   [ WITH ( publish = '<operation> [, ...]' ) ]
+//
+// Operations:
+//    insert, update, delete, truncate
+// %SeeAlso: DROP PUBLICATION
+create_publication_stmt:
+  CREATE PUBLICATION name opt_publication_for_tables opt_with_storage_parameter_list
+  {
+    pub := $4.createPublication()
+    pub.Name = tree.Name($3)
+    pub.Options = $5.storageParams()
+    $$.val = pub
+  }
+| CREATE PUBLICATION error // SHOW HELP: CREATE PUBLICATION
+
+// %Help: CREATE TEXT SEARCH CONFIGURATION - create a text search configuration
+// %Category: DDL
+// %Text:
+// CREATE TEXT SEARCH CONFIGURATION <name> ( PARSER = default | COPY = <source_config> )
+//
+// A configuration created with PARSER = default has no mappings; use ALTER
+// TEXT SEARCH CONFIGURATION to choose the dictionaries used for each token type.
+// %SeeAlso: ALTER TEXT SEARCH CONFIGURATION, CREATE TEXT SEARCH DICTIONARY,
+// DROP TEXT SEARCH CONFIGURATION
+create_text_search_config_stmt:
+  CREATE TEXT SEARCH CONFIGURATION db_object_name '(' storage_parameter_list ')'
+  {
+    $$.val = &tree.CreateTextSearchConfig{
+      Name: $5.unresolvedObjectName(),
+      Options: $7.storageParams(),
+    }
+  }
+| CREATE TEXT SEARCH CONFIGURATION error // SHOW HELP: CREATE TEXT SEARCH CONFIGURATION
+
+// %Help: CREATE TEXT SEARCH DICTIONARY - create a text search dictionary
+// %Category: DDL
+// %Text:
+// CREATE TEXT SEARCH DICTIONARY <name> ( TEMPLATE = <template> [, <option> = <value> [, ...] ] )
+//
+// Templates and their options:
+//    simple:   stopwords, accept
+//    snowball: language, stopwords
+//    synonym:  synonyms (a string of '<word> <synonym>' entries separated by commas)
+// %SeeAlso: CREATE TEXT SEARCH CONFIGURATION, DROP TEXT SEARCH DICTIONARY
+create_text_search_dict_stmt:
+  CREATE TEXT SEARCH DICTIONARY db_object_name '(' storage_parameter_list ')'
+  {
+    $$.val = &tree.CreateTextSearchDictionary{
+      Name: $5.unresolvedObjectName(),
+      Options: $7.storageParams(),
+    }
+  }
+| CREATE TEXT SEARCH DICTIONARY error // SHOW HELP: CREATE TEXT SEARCH DICTIONARY
+
+// %Help: CREATE SERVER - create a foreign server
+// %Category: DDL
+// %Text:
+// CREATE SERVER [IF NOT EXISTS] <name> FOREIGN DATA WRAPPER external_storage
+//   [ OPTIONS ( <option> '<value>' [, ...] ) ]
+//
+// Options:
+//    uri, format, delimiter, header, null
+//
+// Options given to a server are inherited by the foreign tables that use it.
+// %SeeAlso: CREATE FOREIGN TABLE, DROP SERVER
+create_server_stmt:
+  CREATE SERVER name FOREIGN DATA WRAPPER name opt_foreign_options
+  {
+    $$.val = &tree.CreateForeignServer{
+      Name: tree.Name($3),
+      Wrapper: tree.Name($7),
+      Options: $8.foreignOptions(),
+    }
+  }
+| CREATE SERVER IF NOT EXISTS name FOREIGN DATA WRAPPER name opt_foreign_options
+  {
+    $$.val = &tree.CreateForeignServer{
+      Name: tree.Name($6),
+      IfNotExists: true,
+      Wrapper: tree.Name($10),
+      Options: $11.foreignOptions(),
+    }
+  }
+| CREATE SERVER error // SHOW HELP: CREATE SERVER
+
+// %Help: CREATE FOREIGN TABLE - create a table backed by external files
+// %Category: DDL
+// %Text:
+// CREATE FOREIGN TABLE [IF NOT EXISTS] <tablename> ( <colname> <type> [NULL | NOT NULL] [, ...] )
+//   SERVER <servername> [ OPTIONS ( <option> '<value>' [, ...] ) ]
+//
+// Options:
+//    uri, format, delimiter, header, null
+//
+// The files are read at query time; a uri ending with '/' refers to all
+// the files under that prefix.
+// %SeeAlso: CREATE SERVER, DROP FOREIGN TABLE
+create_foreign_table_stmt:
+  CREATE FOREIGN TABLE table_name '(' opt_table_elem_list ')' SERVER name opt_foreign_options
+  {
+    name := $4.unresolvedObjectName().ToTableName()
+    $$.val = &tree.CreateForeignTable{
+      Table: name,
+      Defs: $6.tblDefs(),
+      Server: tree.Name($9),
+      Options: $10.foreignOptions(),
+    }
+  }
+| CREATE FOREIGN TABLE IF NOT EXISTS table_name '(' opt_table_elem_list ')' SERVER name opt_foreign_options
+  {
+    name := $7.unresolvedObjectName().ToTableName()
+    $$.val = &tree.CreateForeignTable{
+      Table: name,
+      IfNotExists: true,
+      Defs: $9.tblDefs(),
+      Server: tree.Name($12),
+      Options: $13.foreignOptions(),
+    }
+  }
+| CREATE FOREIGN TABLE error // SHOW HELP: CREATE FOREIGN TABLE
+
+opt_foreign_options:
+  OPTIONS '(' foreign_option_list ')'
+  {
+    $$.val = $3.foreignOptions()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.ForeignOptions(nil)
+  }
+
+foreign_option_list:
+  foreign_option
+  {
+    $$.val = tree.ForeignOptions{$1.foreignOption()}
+  }
+| foreign_option_list ',' foreign_option
+  {
+    $$.val = append($1.foreignOptions(), $3.foreignOption())
+  }
+
+foreign_option:
+  name SCONST
+  {
+    $$.val = tree.ForeignOption{Key: tree.Name($1), Value: $2}
+  }
+
+opt_publication_for_tables:
+  FOR ALL TABLES
+  {
+    $$.val = &tree.CreatePublication{AllTables: true}
+  }
+| FOR TABLE table_name_list
+  {
+    $$.val = &tree.CreatePublication{Tables: $3.tableNames()}
+  }
+| /* EMPTY */
+  {
+    $$.val = &tree.CreatePublication{}
+  }
+
+opt_domain_default:
+  DEFAULT b_expr
+  {
+    $$.val = $2.expr()
+  }
+| /* EMPTY */
+  {
+    $$.val = nil
+  }
+
+opt_domain_constraint_list:
+  domain_constraint_list
+  {
+    $$.val = $1.domainConstraints()
+  }
+| /* EMPTY */
+  {
+    $$.val = []tree.DomainConstraint(nil)
+  }
+
+domain_constraint_list:
+  domain_constraint
+  {
+    $$.val = []tree.DomainConstraint{$1.domainConstraint()}
+  }
+| domain_constraint_list domain_constraint
+  {
+    $$.val = append($1.domainConstraints(), $2.domainConstraint())
+  }
+
+domain_constraint:
+  CONSTRAINT constraint_name domain_constraint_elem
+  {
+    c := $3.domainConstraint()
+    c.Name = tree.Name($2)
+    $$.val = c
+  }
+| domain_constraint_elem
+  {
+    $$.val = $1.domainConstraint()
+  }
+
+domain_constraint_elem:
+  NOT NULL
+  {
+    $$.val = tree.DomainConstraint{NotNull: true}
+  }
+| NULL
+  {
+    $$.val = tree.DomainConstraint{Null: true}
+  }
+| CHECK '(' a_expr ')'
+  {
+    $$.val = tree.DomainConstraint{Check: $3.expr()}
+  }
 
 opt_enum_val_list:
   enum_val_list
@@ -11285,15 +12548,6 @@ index_elem_options:
     opClass := $1
     dir := $2.dir()
     nullsOrder := $3.nullsOrder()
-    // We currently only support the opposite of Postgres defaults.
-    if nullsOrder != tree.DefaultNullsOrder {
-      if dir == tree.Descending && nullsOrder == tree.NullsFirst {
-        return unimplementedWithIssue(sqllex, 6224)
-      }
-      if dir != tree.Descending && nullsOrder == tree.NullsLast {
-        return unimplementedWithIssue(sqllex, 6224)
-      }
-    }
     $$.val = tree.IndexElem{Direction: dir, NullsOrder: nullsOrder, OpClass: tree.Name(opClass)}
   }
 
@@ -12382,7 +13636,8 @@ insert_rest:
   }
 | '(' insert_column_list ')' select_stmt
   {
-    $$.val = &tree.Insert{Columns: $2.nameList(), Rows: $4.slct()}
+    cols := $2.targetColumns()
+    $$.val = &tree.Insert{Columns: cols.Names, ColumnIndirections: cols.Indirections, Rows: $4.slct()}
   }
 | DEFAULT VALUES
   {
@@ -12391,12 +13646,9 @@ insert_rest:
 
 insert_column_list:
   insert_column_item
-  {
-    $$.val = tree.NameList{tree.Name($1)}
-  }
 | insert_column_list ',' insert_column_item
   {
-    $$.val = append($1.nameList(), tree.Name($3))
+    $$.val = appendTargetColumns($1.targetColumns(), $3.targetColumns())
   }
 
 // insert_column_item represents the target of an INSERT/UPSERT or one
@@ -12408,14 +13660,41 @@ insert_column_list:
 //    UPDATE foo SET x = 1+2, (y, z) = (4, 5)
 //                   ^^ here   ^^^^ here
 //
-// Currently CockroachDB only supports simple column names in this
-// position. The rule below can be extended to support a sequence of
-// field subscript or array indexing operators to designate a part of
-// a field, when partial updates are to be supported. This likely will
-// be needed together with support for composite types (#27792).
+// The column name can be followed by a sequence of field selections and
+// array subscripts to designate the part of the column that is assigned
+// to, as in (x.a, y[2]).
 insert_column_item:
   column_name
-| column_name '.' error { return unimplementedWithIssue(sqllex, 27792) }
+  {
+    $$.val = &tree.TargetColumns{Names: tree.NameList{tree.Name($1)}}
+  }
+| column_name target_indirection
+  {
+    $$.val = &tree.TargetColumns{
+      Names: tree.NameList{tree.Name($1)},
+      Indirections: []tree.TargetIndirection{$2.targetIndirection()},
+    }
+  }
+
+target_indirection:
+  target_indirection_elem
+  {
+    $$.val = tree.TargetIndirection{$1.targetIndirectionElem()}
+  }
+| target_indirection target_indirection_elem
+  {
+    $$.val = append($1.targetIndirection(), $2.targetIndirectionElem())
+  }
+
+target_indirection_elem:
+  '.' unrestricted_name
+  {
+    $$.val = tree.TargetIndirectionElem{Field: tree.Name($2)}
+  }
+| array_subscript
+  {
+    $$.val = tree.TargetIndirectionElem{Subscript: $1.arraySubscript()}
+  }
 
 on_conflict:
   ON CONFLICT DO NOTHING
@@ -12473,6 +13752,102 @@ returning_clause:
     $$.val = tree.AbsentReturningClause
   }
 
+// %Help: MERGE - conditionally insert, update or delete rows of a table
+// %Category: DML
+// %Text:
+// MERGE INTO <tablename> [[AS] <name>]
+//        USING <source> ON <join_condition>
+//        WHEN MATCHED [AND <expr>] THEN { UPDATE SET ... | DELETE | DO NOTHING }
+//        WHEN NOT MATCHED [AND <expr>] THEN
+//          { INSERT [( <colnames...> )] { VALUES ( <exprs...> ) | DEFAULT VALUES } | DO NOTHING }
+//        [WHEN ...]
+// %SeeAlso: INSERT, UPSERT, UPDATE, DELETE
+merge_stmt:
+  opt_with_clause MERGE INTO table_expr_opt_alias_idx USING table_ref ON a_expr merge_when_list
+  {
+    $$.val = &tree.Merge{
+      With: $1.with(),
+      Table: $4.tblExpr(),
+      Source: $6.tblExpr(),
+      On: $8.expr(),
+      Whens: $9.mergeWhens(),
+    }
+  }
+| opt_with_clause MERGE error // SHOW HELP: MERGE
+
+merge_when_list:
+  merge_when
+  {
+    $$.val = []*tree.MergeWhen{$1.mergeWhen()}
+  }
+| merge_when_list merge_when
+  {
+    $$.val = append($1.mergeWhens(), $2.mergeWhen())
+  }
+
+merge_when:
+  WHEN MATCHED opt_merge_cond THEN merge_when_matched_action
+  {
+    w := $5.mergeWhen()
+    w.Matched = true
+    w.Cond = $3.expr()
+    $$.val = w
+  }
+| WHEN NOT MATCHED opt_merge_cond THEN merge_when_not_matched_action
+  {
+    w := $6.mergeWhen()
+    w.Cond = $4.expr()
+    $$.val = w
+  }
+
+opt_merge_cond:
+  AND a_expr
+  {
+    $$.val = $2.expr()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.Expr(nil)
+  }
+
+merge_when_matched_action:
+  UPDATE SET set_clause_list
+  {
+    $$.val = &tree.MergeWhen{Kind: tree.MergeActionUpdate, Exprs: $3.updateExprs()}
+  }
+| DELETE
+  {
+    $$.val = &tree.MergeWhen{Kind: tree.MergeActionDelete}
+  }
+| DO NOTHING
+  {
+    $$.val = &tree.MergeWhen{Kind: tree.MergeActionDoNothing}
+  }
+
+merge_when_not_matched_action:
+  INSERT VALUES '(' expr_list ')'
+  {
+    $$.val = &tree.MergeWhen{Kind: tree.MergeActionInsert, Values: $4.exprs()}
+  }
+| INSERT '(' insert_column_list ')' VALUES '(' expr_list ')'
+  {
+    cols := $3.targetColumns()
+    $$.val = &tree.MergeWhen{
+      Kind: tree.MergeActionInsert,
+      Columns: cols.Names,
+      ColumnIndirections: cols.Indirections,
+      Values: $7.exprs(),
+    }
+  }
+| INSERT DEFAULT VALUES
+  {
+    $$.val = &tree.MergeWhen{Kind: tree.MergeActionInsert}
+  }
+| DO NOTHING
+  {
+    $$.val = &tree.MergeWhen{Kind: tree.MergeActionDoNothing}
+  }
+
 // %Help: UPDATE - update rows of a table
 // %Category: DML
 // %Text:
@@ -12519,9 +13894,6 @@ set_clause_list:
     $$.val = append($1.updateExprs(), $3.updateExpr())
   }
 
-// TODO(knz): The LHS in these can be extended to support
-// a path to a field member when compound types are supported.
-// Keep it simple for now.
 set_clause:
   single_set_clause
 | multiple_set_clause
@@ -12531,12 +13903,20 @@ single_set_clause:
   {
     $$.val = &tree.UpdateExpr{Names: tree.NameList{tree.Name($1)}, Expr: $3.expr()}
   }
-| column_name '.' error { return unimplementedWithIssue(sqllex, 27792) }
+| column_name target_indirection '=' a_expr
+  {
+    $$.val = &tree.UpdateExpr{
+      Names: tree.NameList{tree.Name($1)},
+      Indirections: []tree.TargetIndirection{$2.targetIndirection()},
+      Expr: $4.expr(),
+    }
+  }
 
 multiple_set_clause:
   '(' insert_column_list ')' '=' in_expr
   {
-    $$.val = &tree.UpdateExpr{Tuple: true, Names: $2.nameList(), Expr: $5.expr()}
+    cols := $2.targetColumns()
+    $$.val = &tree.UpdateExpr{Tuple: true, Names: cols.Names, Indirections: cols.Indirections, Expr: $5.expr()}
   }
 
 // %Help: REASSIGN OWNED BY - change ownership of all objects
@@ -13191,9 +14571,18 @@ group_by_list:
 // rather than reducing the conflicting unreserved_keyword rule.
 group_by_item:
   a_expr { $$.val = $1.expr() }
-| ROLLUP '(' error { return unimplementedWithIssueDetail(sqllex, 46280, "rollup") }
-| CUBE '(' error { return unimplementedWithIssueDetail(sqllex, 46280, "cube") }
-| GROUPING SETS error { return unimplementedWithIssueDetail(sqllex, 46280, "grouping sets") }
+| ROLLUP '(' expr_list ')'
+  {
+    $$.val = &tree.GroupingSet{Type: tree.RollupGroupingSet, Exprs: $3.exprs()}
+  }
+| CUBE '(' expr_list ')'
+  {
+    $$.val = &tree.GroupingSet{Type: tree.CubeGroupingSet, Exprs: $3.exprs()}
+  }
+| GROUPING SETS '(' group_by_list ')'
+  {
+    $$.val = &tree.GroupingSet{Type: tree.ExplicitGroupingSets, Exprs: $4.exprs()}
+  }
 
 having_clause:
   HAVING a_expr
@@ -13768,16 +15157,46 @@ relation_expr_list:
     $$.val = append($1.tableNames(), name)
   }
 
-// UNLISTEN
+// %Help: LISTEN - listen for notifications on a channel
+// %Category: Misc
+// %Text: LISTEN <channel>
+// %SeeAlso: NOTIFY, UNLISTEN
+listen_stmt:
+  LISTEN name
+  {
+    $$.val = &tree.Listen{ChannelName: tree.Name($2)}
+  }
+| LISTEN error // SHOW HELP: LISTEN
+
+// %Help: NOTIFY - send a notification on a channel
+// %Category: Misc
+// %Text: NOTIFY <channel> [, <payload>]
+// %SeeAlso: LISTEN, UNLISTEN
+notify_stmt:
+  NOTIFY name
+  {
+    $$.val = &tree.Notify{ChannelName: tree.Name($2)}
+  }
+| NOTIFY name ',' SCONST
+  {
+    $$.val = &tree.Notify{ChannelName: tree.Name($2), Payload: $4}
+  }
+| NOTIFY error // SHOW HELP: NOTIFY
+
+// %Help: UNLISTEN - stop listening for notifications on a channel
+// %Category: Misc
+// %Text: UNLISTEN { <channel> | * }
+// %SeeAlso: LISTEN, NOTIFY
 unlisten_stmt:
-   UNLISTEN type_name
-    {
-        $$.val = &tree.Unlisten{ ChannelName: $2.unresolvedObjectName(), Star: false}
-    }
-|  UNLISTEN '*'
-      {
-          $$.val = &tree.Unlisten{ ChannelName:nil, Star: true}
-      }
+  UNLISTEN name
+  {
+    $$.val = &tree.Unlisten{ChannelName: tree.Name($2)}
+  }
+| UNLISTEN '*'
+  {
+    $$.val = &tree.Unlisten{Star: true}
+  }
+| UNLISTEN error // SHOW HELP: UNLISTEN
 
 
 // Given "UPDATE foo set set ...", we have to decide without looking any
@@ -13885,7 +15304,6 @@ typename:
       return setErr(sqllex, err)
     }
   }
-| simple_typename ARRAY '[' ICONST ']' '[' error { return unimplementedWithIssue(sqllex, 32552) }
 | simple_typename ARRAY {
     var err error
     $$.val, err = arrayOf($1.typeReference(), nil)
@@ -13900,11 +15318,15 @@ cast_target:
     $$.val = $1.typeReference()
   }
 
+// Array bounds are accepted for Postgres compatibility, but, as in Postgres,
+// neither the number of dimensions nor their sizes are enforced: INT[],
+// INT[][] and INT[3][3] all denote the same type.
 opt_array_bounds:
-  // TODO(justin): reintroduce multiple array bounds
-  // opt_array_bounds '[' ']' { $$.val = append($1.int32s(), -1) }
+  array_bounds
+| /* EMPTY */ { $$.val = []int32(nil) }
+
+array_bounds:
   '[' ']' { $$.val = []int32{-1} }
-| '[' ']' '[' error { return unimplementedWithIssue(sqllex, 32552) }
 | '[' ICONST ']'
   {
     /* SKIP DOC */
@@ -13914,8 +15336,16 @@ opt_array_bounds:
     }
     $$.val = []int32{bound}
   }
-| '[' ICONST ']' '[' error { return unimplementedWithIssue(sqllex, 32552) }
-| /* EMPTY */ { $$.val = []int32(nil) }
+| array_bounds '[' ']' { $$.val = append($1.int32s(), -1) }
+| array_bounds '[' ICONST ']'
+  {
+    /* SKIP DOC */
+    bound, err := $3.numVal().AsInt32()
+    if err != nil {
+      return setErr(sqllex, err)
+    }
+    $$.val = append($1.int32s(), bound)
+  }
 
 // general_type_name is a variant of type_or_function_name but does not
 // include some extra keywords (like FAMILY) which cause ambiguity with
@@ -13993,8 +15423,6 @@ simple_typename:
   }
 | const_typename
 | interval_type
-| POINT error { return unimplementedWithIssueDetail(sqllex, 21286, "point") } // needed or else it generates a syntax error.
-| POLYGON error { return unimplementedWithIssueDetail(sqllex, 21286, "polygon") } // needed or else it generates a syntax error.
 
 geo_shape_type:
   POINT { $$.val = geopb.ShapeType_Point }
@@ -14034,6 +15462,8 @@ const_geo:
   GEOGRAPHY { $$.val = types.Geography }
 | GEOMETRY  { $$.val = types.Geometry }
 | BOX2D     { $$.val = types.Box2D }
+| POINT     { $$.val = types.Point }
+| POLYGON   { $$.val = types.Polygon }
 | GEOMETRY '(' geo_shape_type ')'
   {
     $$.val = types.MakeGeometry($3.geoShapeType(), 0)
@@ -14661,6 +16091,22 @@ a_expr:
   {
     $$.val = &tree.ComparisonExpr{Operator: treecmp.MakeComparisonOperator(treecmp.TSMatches), Left: $1.expr(), Right: $3.expr()}
   }
+| a_expr JSON_PATH_EXISTS a_expr
+  {
+    $$.val = &tree.FuncExpr{Func: tree.WrapFunction("jsonb_path_exists_opr"), Exprs: tree.Exprs{$1.expr(), $3.expr()}}
+  }
+| a_expr GEOMETRIC_DISTANCE a_expr
+  {
+    $$.val = &tree.FuncExpr{Func: tree.WrapFunction("geometric_distance"), Exprs: tree.Exprs{$1.expr(), $3.expr()}}
+  }
+| a_expr GEOMETRIC_INTERSECTS a_expr
+  {
+    $$.val = &tree.FuncExpr{Func: tree.WrapFunction("geometric_intersects"), Exprs: tree.Exprs{$1.expr(), $3.expr()}}
+  }
+| a_expr RANGE_ADJACENT a_expr
+  {
+    $$.val = &tree.FuncExpr{Func: tree.WrapFunction("range_adjacent"), Exprs: tree.Exprs{$1.expr(), $3.expr()}}
+  }
 | a_expr INET_CONTAINS_OR_EQUALS a_expr
   {
     $$.val = &tree.FuncExpr{Func: tree.WrapFunction("inet_contains_or_equals"), Exprs: tree.Exprs{$1.expr(), $3.expr()}}
@@ -15215,7 +16661,10 @@ d_expr:
   {
     $$.val = $2.expr()
   }
-| GROUPING '(' expr_list ')' { return unimplemented(sqllex, "d_expr grouping") }
+| GROUPING '(' expr_list ')'
+  {
+    $$.val = &tree.FuncExpr{Func: tree.WrapFunction("grouping"), Exprs: $3.exprs()}
+  }
 
 func_application:
   func_application_name '(' ')'
@@ -15558,6 +17007,14 @@ special_function:
     $$.val = &tree.FuncExpr{Func: tree.WrapFunction($1), Exprs: $3.exprs()}
   }
 | LEAST '(' error { return helpWithFunctionByName(sqllex, $1) }
+| POINT '(' expr_list ')'
+  {
+    $$.val = &tree.FuncExpr{Func: tree.WrapFunction($1), Exprs: $3.exprs()}
+  }
+| POLYGON '(' expr_list ')'
+  {
+    $$.val = &tree.FuncExpr{Func: tree.WrapFunction($1), Exprs: $3.exprs()}
+  }
 
 
 // Aggregate decoration clauses
@@ -16886,10 +18343,12 @@ unreserved_keyword:
 | DESTINATION
 | DETACHED
 | DETAILS
+| DICTIONARY
 | DISCARD
 | DOMAIN
 | DOUBLE
 | DROP
+| EACH
 | ENCODING
 | ENCRYPTED
 | ENCRYPTION_PASSPHRASE
@@ -16965,6 +18424,7 @@ unreserved_keyword:
 | INJECT
 | INPUT
 | INSERT
+| INSTEAD
 | INTO_DB
 | INVERTED
 | INVISIBLE
@@ -16992,13 +18452,16 @@ unreserved_keyword:
 | LINESTRINGZ
 | LINESTRINGZM
 | LIST
+| LISTEN
 | LOCAL
 | LOCKED
 | LOGIN
 | LOCALITY
 | LOOKUP
 | LOW
+| MAPPING
 | MATCH
+| MATCHED
 | MATERIALIZED
 | MAXVALUE
 | MERGE
@@ -17024,12 +18487,14 @@ unreserved_keyword:
 | NAMES
 | NAN
 | NEVER
+| NEW
 | NEW_DB_NAME
 | NEW_KMS
 | NEXT
 | NO
 | NORMAL
 | NOTHING
+| NOTIFY
 | NO_INDEX_JOIN
 | NO_ZIGZAG_JOIN
 | NO_FULL_SCAN
@@ -17054,6 +18519,7 @@ unreserved_keyword:
 | OF
 | OFF
 | OIDS
+| OLD
 | OLD_KMS
 | OPERATOR
 | OPT
@@ -17104,6 +18570,7 @@ unreserved_keyword:
 | RECURSIVE
 | REDACT
 | REF
+| REFERENCING
 | REFRESH
 | REGION
 | REGIONAL
@@ -17182,6 +18649,7 @@ unreserved_keyword:
 | STABLE
 | START
 | STATE
+| STATEMENT
 | STATEMENTS
 | STATISTICS
 | STDIN
@@ -17256,6 +18724,7 @@ unreserved_keyword:
 | VOTERS
 | WITHIN
 | WITHOUT
+| WRAPPER
 | WRITE
 | YEAR
 | ZONE
@@ -17389,12 +18858,14 @@ bare_label_keywords:
 | DESTINATION
 | DETACHED
 | DETAILS
+| DICTIONARY
 | DISCARD
 | DISTINCT
 | DO
 | DOMAIN
 | DOUBLE
 | DROP
+| EACH
 | ELSE
 | ENCODING
 | ENCRYPTED
@@ -17495,6 +18966,7 @@ bare_label_keywords:
 | INPUT
 | INSENSITIVE
 | INSERT
+| INSTEAD
 | INT
 | INTEGER
 | INTERVAL
@@ -17533,6 +19005,7 @@ bare_label_keywords:
 | LINESTRINGZ
 | LINESTRINGZM
 | LIST
+| LISTEN
 | LOCAL
 | LOCALITY
 | LOCALTIME
@@ -17541,7 +19014,9 @@ bare_label_keywords:
 | LOGIN
 | LOOKUP
 | LOW
+| MAPPING
 | MATCH
+| MATCHED
 | MATERIALIZED
 | MAXVALUE
 | MERGE
@@ -17566,6 +19041,7 @@ bare_label_keywords:
 | NAN
 | NATURAL
 | NEVER
+| NEW
 | NEW_DB_NAME
 | NEW_KMS
 | NEXT
@@ -17586,6 +19062,7 @@ bare_label_keywords:
 | NOT
 | NOTHING
 | NOTHING_AFTER_RETURNING
+| NOTIFY
 | NOVIEWACTIVITY
 | NOVIEWACTIVITYREDACTED
 | NOVIEWCLUSTERSETTING
@@ -17600,6 +19077,7 @@ bare_label_keywords:
 | OF
 | OFF
 | OIDS
+| OLD
 | OLD_KMS
 | ONLY
 | OPERATOR
@@ -17660,6 +19138,7 @@ bare_label_keywords:
 | RECURSIVE
 | REDACT
 | REF
+| REFERENCING
 | REFERENCES
 | REFRESH
 | REGION
@@ -17745,6 +19224,7 @@ bare_label_keywords:
 | STABLE
 | START
 | STATE
+| STATEMENT
 | STATEMENTS
 | STATISTICS
 | STATUS
@@ -17840,6 +19320,7 @@ bare_label_keywords:
 | VOTERS
 | WHEN
 | WORK
+| WRAPPER
 | WRITE
 | ZONE
 
@@ -21,6 +21,7 @@ import (
 	"time"
 	"unicode"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
@@ -37,6 +38,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/oidext"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsnutil"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
@@ -582,7 +584,9 @@ https://www.postgresql.org/docs/9.6/catalog-pg-cast.html`,
 				castCtx := cCtx.PGString()
 
 				castFunc := tree.DNull
-				if srcTyp, ok := types.OidToType[src]; ok {
+				if v, ok := builtins.CastBuiltinOIDsBySource[tgt][src]; ok {
+					castFunc = tree.NewDOid(v)
+				} else if srcTyp, ok := types.OidToType[src]; ok {
 					if v, ok := builtins.CastBuiltinOIDs[tgt][srcTyp.Family()]; ok {
 						castFunc = tree.NewDOid(v)
 					}
@@ -713,6 +717,7 @@ var (
 	relKindView             = tree.NewDString("v")
 	relKindMaterializedView = tree.NewDString("m")
 	relKindSequence         = tree.NewDString("S")
+	relKindForeignTable     = tree.NewDString("f")
 
 	relPersistencePermanent = tree.NewDString("p")
 	relPersistenceTemporary = tree.NewDString("t")
@@ -742,6 +747,10 @@ https://www.postgresql.org/docs/9.5/catalog-pg-class.html`,
 			relKind = relKindSequence
 			relAm = oidZero
 			replIdent = "n"
+		} else if table.IsForeignTable() {
+			relKind = relKindForeignTable
+			relAm = oidZero
+			replIdent = "n"
 		}
 		relPersistence := relPersistencePermanent
 		if table.IsTemporary() {
@@ -912,7 +921,6 @@ var (
 
 	// Avoid unused warning for constants.
 	_ = conTypeTrigger
-	_ = conTypeExclusion
 
 	fkActionNone       = tree.NewDString("a")
 	fkActionRestrict   = tree.NewDString("r")
@@ -1057,13 +1065,26 @@ func populateTableConstraints(
 			conoid = h.UniqueWithoutIndexConstraintOid(
 				db.GetID(), sc.GetID(), table.GetID(), uwoi,
 			)
-			f.WriteString("UNIQUE WITHOUT INDEX (")
-			colNames, err := catalog.ColumnNamesForIDs(table, uwoi.UniqueWithoutIndexDesc().ColumnIDs)
-			if err != nil {
-				return err
+			if uwoi.IsExclusionConstraint() {
+				contype = conTypeExclusion
+				if idx := catalog.FindIndexByName(table, uwoi.GetName()); idx != nil {
+					conindid = h.IndexOid(table.GetID(), idx.GetID())
+				}
+				if conkey, err = colIDArrayToDatum(uwoi.UniqueWithoutIndexDesc().ColumnIDs); err != nil {
+					return err
+				}
+				if err := showExclusionElements(table, uwoi.UniqueWithoutIndexDesc(), f); err != nil {
+					return err
+				}
+			} else {
+				f.WriteString("UNIQUE WITHOUT INDEX (")
+				colNames, err := catalog.ColumnNamesForIDs(table, uwoi.UniqueWithoutIndexDesc().ColumnIDs)
+				if err != nil {
+					return err
+				}
+				f.WriteString(strings.Join(colNames, ", "))
+				f.WriteByte(')')
 			}
-			f.WriteString(strings.Join(colNames, ", "))
-			f.WriteByte(')')
 			if !uwoi.IsConstraintValidated() {
 				f.WriteString(" NOT VALID")
 			}
@@ -1094,13 +1115,14 @@ func populateTableConstraints(
 			condef = tree.NewDString(fmt.Sprintf("CHECK ((%s))%s", displayExpr, validity))
 		}
 
+		deferrable, initiallyDeferred := constraintDeferrability(c)
 		if err := addRow(
-			conoid,                   // oid
-			dNameOrNull(c.GetName()), // conname
-			namespaceOid,             // connamespace
-			contype,                  // contype
-			tree.DBoolFalse,          // condeferrable
-			tree.DBoolFalse,          // condeferred
+			conoid,                                 // oid
+			dNameOrNull(c.GetName()),               // conname
+			namespaceOid,                           // connamespace
+			contype,                                // contype
+			tree.MakeDBool(tree.DBool(deferrable)), // condeferrable
+			tree.MakeDBool(tree.DBool(initiallyDeferred)),            // condeferred
 			tree.MakeDBool(tree.DBool(!c.IsConstraintUnvalidated())), // convalidated
 			tblOid,         // conrelid
 			oidZero,        // contypid
@@ -1672,7 +1694,7 @@ https://www.postgresql.org/docs/9.5/catalog-pg-depend.html`,
 				return nil
 			}
 
-			if table.IsTable() || table.IsView() {
+			if table.IsTable() || table.IsView() || table.IsForeignTable() {
 				if err := table.ForeachDependedOnBy(reportViewDependency); err != nil {
 					return err
 				}
@@ -1843,36 +1865,98 @@ https://www.postgresql.org/docs/9.5/catalog-pg-extension.html`,
 }
 
 var pgCatalogForeignDataWrapperTable = virtualSchemaTable{
-	comment: `foreign data wrappers (empty - feature does not exist)
+	comment: `foreign data wrappers
 https://www.postgresql.org/docs/9.5/catalog-pg-foreign-data-wrapper.html`,
 	schema: vtable.PGCatalogForeignDataWrapper,
 	populate: func(_ context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		// Foreign data wrappers are not supported.
-		return nil
+		// The external storage wrapper is built in and is the only one.
+		h := makeOidHasher()
+		return addRow(
+			h.ForeignDataWrapperOid(externalStorageForeignDataWrapper), // oid
+			tree.NewDName(externalStorageForeignDataWrapper),           // fdwname
+			h.UserOid(username.RootUserName()),                         // fdwowner
+			oidZero,                                                    // fdwhandler
+			oidZero,                                                    // fdwvalidator
+			tree.DNull,                                                 // fdwacl
+			tree.DNull,                                                 // fdwoptions
+		)
 	},
-	unimplemented: true,
 }
 
 var pgCatalogForeignServerTable = virtualSchemaTable{
-	comment: `foreign servers (empty - feature does not exist)
+	comment: `foreign servers
 https://www.postgresql.org/docs/9.5/catalog-pg-foreign-server.html`,
 	schema: vtable.PGCatalogForeignServer,
-	populate: func(_ context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		// Foreign servers are not supported.
-		return nil
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		h := makeOidHasher()
+		return forEachDatabaseDesc(ctx, p, dbContext, true, /* requiresPrivileges */
+			func(db catalog.DatabaseDescriptor) error {
+				for i := range db.DatabaseDesc().ForeignServers {
+					server := &db.DatabaseDesc().ForeignServers[i]
+					options, err := foreignOptionsArray(server.Options)
+					if err != nil {
+						return err
+					}
+					if err := addRow(
+						h.ForeignServerOid(db.GetID(), server.Name), // oid
+						tree.NewDName(server.Name),                  // srvname
+						h.UserOid(server.OwnerProto.Decode()),       // srvowner
+						h.ForeignDataWrapperOid(server.Wrapper),     // srvfdw
+						tree.DNull,                                  // srvtype
+						tree.DNull,                                  // srvversion
+						tree.DNull,                                  // srvacl
+						options,                                     // srvoptions
+					); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
 	},
-	unimplemented: true,
 }
 
 var pgCatalogForeignTableTable = virtualSchemaTable{
-	comment: `foreign tables (empty  - feature does not exist)
+	comment: `foreign tables
 https://www.postgresql.org/docs/9.5/catalog-pg-foreign-table.html`,
 	schema: vtable.PGCatalogForeignTable,
-	populate: func(_ context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		// Foreign tables are not supported.
-		return nil
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		h := makeOidHasher()
+		return forEachTableDesc(ctx, p, dbContext, hideVirtual, /* virtual tables are not foreign tables */
+			func(db catalog.DatabaseDescriptor, _ catalog.SchemaDescriptor, table catalog.TableDescriptor) error {
+				if !table.IsForeignTable() {
+					return nil
+				}
+				foreign := table.GetForeignTable()
+				options, err := foreignOptionsArray(foreign.Options)
+				if err != nil {
+					return err
+				}
+				return addRow(
+					tableOid(table.GetID()),                            // ftrelid
+					h.ForeignServerOid(db.GetID(), foreign.ServerName), // ftserver
+					options, // ftoptions
+				)
+			})
 	},
-	unimplemented: true,
+}
+
+// foreignOptionsArray returns the options of a foreign server or table as an
+// array of "key=value" strings, or NULL if there are none.
+func foreignOptionsArray(options []descpb.ForeignOption) (tree.Datum, error) {
+	if len(options) == 0 {
+		return tree.DNull, nil
+	}
+	options, err := redactForeignOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	arr := tree.NewDArray(types.String)
+	for _, opt := range options {
+		if err := arr.Append(tree.NewDString(opt.Key + "=" + opt.Value)); err != nil {
+			return nil, err
+		}
+	}
+	return arr, nil
 }
 
 func makeZeroedOidVector(size int) (tree.Datum, error) {
@@ -1932,13 +2016,19 @@ https://www.postgresql.org/docs/9.5/catalog-pg-index.html`,
 						if err := collationOids.Append(typColl(col.GetType(), h)); err != nil {
 							return err
 						}
-						// Currently, nulls always appear first if the order is ascending,
-						// and always appear last if the order is descending.
+						// By default, nulls appear first if the order is ascending, and last
+						// if the order is descending.
+						asc := index.GetKeyColumnDirection(i) == catenumpb.IndexColumn_ASC
+						nullsFirst := asc
+						if index.GetKeyColumnNullsOrder(i) == catenumpb.IndexColumn_NULLS_REVERSED {
+							nullsFirst = !asc
+						}
 						var thisIndOption tree.DInt
-						if index.GetKeyColumnDirection(i) == catenumpb.IndexColumn_ASC {
-							thisIndOption = indoptionNullsFirst
-						} else {
-							thisIndOption = indoptionDesc
+						if !asc {
+							thisIndOption |= indoptionDesc
+						}
+						if nullsFirst {
+							thisIndOption |= indoptionNullsFirst
 						}
 						if err := indoption.Append(tree.NewDInt(thisIndOption)); err != nil {
 							return err
@@ -2593,17 +2683,31 @@ func addPgProcUDFRow(
 	addRow func(...tree.Datum) error,
 ) error {
 	isStrict := fnDesc.GetNullInputBehavior() != catpb.Function_CALLED_ON_NULL_INPUT
+	sig := fnDesc.ToSignature()
 	argTypes := tree.NewDArray(types.Oid)
+	for _, typ := range sig.ArgTypes {
+		if err := argTypes.Append(tree.NewDOid(typ.Oid())); err != nil {
+			return err
+		}
+	}
+	variadic := oidZero
+	if sig.IsVariadic {
+		variadic = tree.NewDOid(sig.ArgTypes[len(sig.ArgTypes)-1].ArrayContents().Oid())
+	}
+	allArgTypes := tree.NewDArray(types.Oid)
+	foundNonInArgs := false
 	argModes := tree.NewDArray(types.String)
 	var argNames tree.Datum
 	argNamesArray := tree.NewDArray(types.String)
 	foundAnyArgNames := false
 	for _, param := range fnDesc.GetParams() {
-		if err := argTypes.Append(tree.NewDOid(param.Type.Oid())); err != nil {
+		if err := allArgTypes.Append(tree.NewDOid(param.Type.Oid())); err != nil {
 			return err
 		}
-		// We only support IN arguments at the moment.
-		if err := argModes.Append(tree.NewDString("i")); err != nil {
+		if param.Class != catpb.Function_Param_IN {
+			foundNonInArgs = true
+		}
+		if err := argModes.Append(tree.NewDString(funcParamMode(param.Class))); err != nil {
 			return err
 		}
 		if len(param.Name) > 0 {
@@ -2617,10 +2721,18 @@ func addPgProcUDFRow(
 	if foundAnyArgNames {
 		argNames = argNamesArray
 	}
+	// proallargtypes is only set if there are non-IN arguments, since
+	// proargtypes then does not describe all of them.
+	var allArgTypesDatum tree.Datum = tree.DNull
+	if foundNonInArgs {
+		allArgTypesDatum = allArgTypes
+	}
 
 	kind := tree.NewDString("f")
 	if fnDesc.IsProcedure() {
 		kind = tree.NewDString("p")
+	} else if fnDesc.IsAggregate() {
+		kind = tree.NewDString("a")
 	}
 
 	lang := languageInternalOid
@@ -2629,6 +2741,18 @@ func addPgProcUDFRow(
 	} else if fnDesc.GetLanguage() == catpb.Function_SQL {
 		lang = languageSqlOid
 	}
+
+	secDef := tree.MakeDBool(tree.DBool(fnDesc.GetSecurity() == catpb.Function_DEFINER))
+	var config tree.Datum = tree.DNull
+	if len(fnDesc.GetConfig()) > 0 {
+		configArray := tree.NewDArray(types.String)
+		for _, c := range fnDesc.GetConfig() {
+			if err := configArray.Append(tree.NewDString(c)); err != nil {
+				return err
+			}
+		}
+		config = configArray
+	}
 	return addRow(
 		tree.NewDOid(catid.FuncIDToOID(fnDesc.GetID())), // oid
 		tree.NewDName(fnDesc.GetName()),                 // proname
@@ -2637,30 +2761,30 @@ func addPgProcUDFRow(
 		lang,            // prolang
 		tree.DNull,      // procost
 		tree.DNull,      // prorows
-		oidZero,         // provariadic
+		variadic,        // provariadic
 		tree.DNull,      // protransform
 		tree.DBoolFalse, // proisagg
 		tree.DBoolFalse, // proiswindow
-		tree.DBoolFalse, // prosecdef
+		secDef,          // prosecdef
 		tree.MakeDBool(tree.DBool(fnDesc.GetLeakProof())),            // proleakproof
 		tree.MakeDBool(tree.DBool(isStrict)),                         // proisstrict
 		tree.MakeDBool(tree.DBool(fnDesc.GetReturnType().ReturnSet)), // proretset
 		tree.NewDString(funcVolatility(fnDesc.GetVolatility())),      // provolatile
 		tree.DNull, // proparallel
-		tree.NewDInt(tree.DInt(len(fnDesc.GetParams()))), // pronargs
-		tree.NewDInt(tree.DInt(0)),                       // pronargdefaults
-		tree.NewDOid(fnDesc.GetReturnType().Type.Oid()),  // prorettype
-		tree.NewDOidVectorFromDArray(argTypes),           // proargtypes
-		tree.DNull,                                       // proallargtypes
-		argModes,                                         // proargmodes
-		argNames,                                         // proargnames
-		tree.DNull,                                       // proargdefaults
-		tree.DNull,                                       // protrftypes
-		tree.NewDString(fnDesc.GetFunctionBody()),        // prosrc
-		tree.DNull,                                       // probin
-		tree.DNull,                                       // proconfig
-		tree.DNull,                                       // proacl
-		kind,                                             // prokind
+		tree.NewDInt(tree.DInt(len(sig.ArgTypes))),      // pronargs
+		tree.NewDInt(tree.DInt(0)),                      // pronargdefaults
+		tree.NewDOid(fnDesc.GetReturnType().Type.Oid()), // prorettype
+		tree.NewDOidVectorFromDArray(argTypes),          // proargtypes
+		allArgTypesDatum,                                // proallargtypes
+		argModes,                                        // proargmodes
+		argNames,                                        // proargnames
+		tree.DNull,                                      // proargdefaults
+		tree.DNull,                                      // protrftypes
+		tree.NewDString(fnDesc.GetFunctionBody()),       // prosrc
+		tree.DNull,                                      // probin
+		config,                                          // proconfig
+		tree.DNull,                                      // proacl
+		kind,                                            // prokind
 		// These columns were automatically created by pg_catalog_test's missing column generator.
 		tree.DNull, // prosupport
 	)
@@ -2794,7 +2918,7 @@ https://www.postgresql.org/docs/9.5/catalog-pg-rewrite.html`,
 			table catalog.TableDescriptor,
 			tableLookup tableLookupFn,
 		) error {
-			if !table.IsTable() && !table.IsView() {
+			if !table.IsTable() && !table.IsView() && !table.IsForeignTable() {
 				return nil
 			}
 
@@ -3170,17 +3294,16 @@ https://www.postgresql.org/docs/9.5/catalog-pg-trigger.html`,
 }
 
 var (
-	typTypeBase      = tree.NewDString("b")
-	typTypeComposite = tree.NewDString("c")
-	typTypeDomain    = tree.NewDString("d")
-	typTypeEnum      = tree.NewDString("e")
-	typTypePseudo    = tree.NewDString("p")
-	typTypeRange     = tree.NewDString("r")
+	typTypeBase       = tree.NewDString("b")
+	typTypeComposite  = tree.NewDString("c")
+	typTypeDomain     = tree.NewDString("d")
+	typTypeEnum       = tree.NewDString("e")
+	typTypePseudo     = tree.NewDString("p")
+	typTypeRange      = tree.NewDString("r")
+	typTypeMultirange = tree.NewDString("m")
 
 	// Avoid unused warning for constants.
-	_ = typTypeDomain
 	_ = typTypePseudo
-	_ = typTypeRange
 
 	// See https://www.postgresql.org/docs/9.6/static/catalog-pg-type.html#CATALOG-TYPCATEGORY-TABLE.
 	typCategoryArray       = tree.NewDString("A")
@@ -3201,8 +3324,6 @@ var (
 
 	// Avoid unused warning for constants.
 	_ = typCategoryEnum
-	_ = typCategoryGeometric
-	_ = typCategoryRange
 	_ = typCategoryBitString
 
 	commaTypDelim = tree.NewDString(",")
@@ -3308,14 +3429,39 @@ func addPGTypeRow(
 		if isUDT {
 			typrelid = tree.NewDOid(typ.Oid())
 		}
-	case types.VoidFamily:
-		// void does not have an array type.
+	case types.RangeFamily:
+		typType = typTypeRange
+		// anyrange does not have an array type.
+		if typ.Oid() != oid.T_anyrange {
+			typArray = tree.NewDOid(types.CalcArrayOid(typ))
+		}
+	case types.MultirangeFamily:
+		typType = typTypeMultirange
+		// anymultirange does not have an array type.
+		if typ.Oid() != oidext.T_anymultirange {
+			typArray = tree.NewDOid(types.CalcArrayOid(typ))
+		}
+	case types.VoidFamily, types.TriggerFamily:
+		// void and trigger do not have array types.
 	default:
 		typArray = tree.NewDOid(types.CalcArrayOid(typ))
 	}
 	if cat == typCategoryPseudo {
 		typType = typTypePseudo
 	}
+	typNotNull := tree.DBoolFalse
+	typBaseType := oidZero
+	typDefault := tree.DNull
+	if typ.IsDomain() {
+		typType = typTypeDomain
+		if dom := typ.TypeMeta.DomainData; dom != nil {
+			typNotNull = tree.MakeDBool(tree.DBool(dom.NotNull))
+			typBaseType = tree.NewDOid(dom.BaseType.Oid())
+			if dom.DefaultExpr != "" {
+				typDefault = tree.NewDString(dom.DefaultExpr)
+			}
+		}
+	}
 	typname := typ.PGName()
 	typDelim := tree.NewDString(typ.Delimiter())
 	return addRow(
@@ -3345,13 +3491,13 @@ func addPGTypeRow(
 
 		tree.DNull,      // typalign
 		tree.DNull,      // typstorage
-		tree.DBoolFalse, // typnotnull
-		oidZero,         // typbasetype
+		typNotNull,      // typnotnull
+		typBaseType,     // typbasetype
 		negOneVal,       // typtypmod
 		zeroVal,         // typndims
 		typColl(typ, h), // typcollation
 		tree.DNull,      // typdefaultbin
-		tree.DNull,      // typdefault
+		typDefault,      // typdefault
 		tree.DNull,      // typacl
 	)
 }
@@ -4138,12 +4284,35 @@ var pgCatalogStatUserFunctionsTable = virtualSchemaTable{
 }
 
 var pgCatalogPublicationTable = virtualSchemaTable{
-	comment: "pg_publication was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogPublication,
-	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+	comment: `publications for logical replication
+https://www.postgresql.org/docs/current/catalog-pg-publication.html`,
+	schema: vtable.PgCatalogPublication,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		h := makeOidHasher()
+		pubs, err := p.getPublicationsByDatabase(ctx)
+		if err != nil {
+			return err
+		}
+		return forEachDatabaseDesc(ctx, p, dbContext, true, /* requiresPrivileges */
+			func(db catalog.DatabaseDescriptor) error {
+				for _, pub := range pubs[db.GetID()] {
+					if err := addRow(
+						h.PublicationOid(db.GetID(), pub.name),          // oid
+						tree.NewDName(pub.name),                         // pubname
+						h.UserOid(pub.owner),                            // pubowner
+						tree.MakeDBool(tree.DBool(pub.allTables)),       // puballtables
+						tree.MakeDBool(tree.DBool(pub.publishInsert)),   // pubinsert
+						tree.MakeDBool(tree.DBool(pub.publishUpdate)),   // pubupdate
+						tree.MakeDBool(tree.DBool(pub.publishDelete)),   // pubdelete
+						tree.MakeDBool(tree.DBool(pub.publishTruncate)), // pubtruncate
+						tree.DBoolFalse,                                 // pubviaroot
+					); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
 	},
-	unimplemented: true,
 }
 
 var pgCatalogAmprocTable = virtualSchemaTable{
@@ -4193,12 +4362,12 @@ https://www.postgresql.org/docs/14/view-pg-cursors.html`,
 				return err
 			}
 			if err := addRow(
-				tree.NewDString(string(name)), /* name */
-				tree.NewDString(c.statement),  /* statement */
-				tree.DBoolFalse,               /* is_holdable */
-				tree.DBoolFalse,               /* is_binary */
-				tree.DBoolFalse,               /* is_scrollable */
-				tz,                            /* creation_date */
+				tree.NewDString(string(name)),          /* name */
+				tree.NewDString(c.statement),           /* statement */
+				tree.MakeDBool(tree.DBool(c.withHold)), /* is_holdable */
+				tree.DBoolFalse,                        /* is_binary */
+				tree.MakeDBool(tree.DBool(c.scroll)),   /* is_scrollable */
+				tz,                                     /* creation_date */
 			); err != nil {
 				return err
 			}
@@ -4298,12 +4467,32 @@ var pgCatalogStatXactUserTablesTable = virtualSchemaTable{
 }
 
 var pgCatalogPublicationTablesTable = virtualSchemaTable{
-	comment: "pg_publication_tables was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogPublicationTables,
-	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+	comment: `tables published by publications
+https://www.postgresql.org/docs/current/view-pg-publication-tables.html`,
+	schema: vtable.PgCatalogPublicationTables,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		pubs, err := p.getPublicationsByDatabase(ctx)
+		if err != nil {
+			return err
+		}
+		return forEachTableDesc(ctx, p, dbContext, hideVirtual, /* virtual tables cannot be published */
+			func(db catalog.DatabaseDescriptor, sc catalog.SchemaDescriptor, table catalog.TableDescriptor) error {
+				for i := range pubs[db.GetID()] {
+					pub := &pubs[db.GetID()][i]
+					if !publicationIncludesTable(pub, table) {
+						continue
+					}
+					if err := addRow(
+						tree.NewDName(pub.name),        // pubname
+						tree.NewDName(sc.GetName()),    // schemaname
+						tree.NewDName(table.GetName()), // tablename
+					); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
 	},
-	unimplemented: true,
 }
 
 var pgCatalogStatProgressClusterTable = virtualSchemaTable{
@@ -4334,12 +4523,50 @@ var pgCatalogLargeobjectMetadataTable = virtualSchemaTable{
 }
 
 var pgCatalogReplicationSlotsTable = virtualSchemaTable{
-	comment: "pg_replication_slots was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogReplicationSlots,
+	comment: `replication slots
+https://www.postgresql.org/docs/current/view-pg-replication-slots.html`,
+	schema: vtable.PgCatalogReplicationSlots,
 	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+		if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V24_1) {
+			return nil
+		}
+		slots, err := getReplicationSlots(ctx, p.InternalSQLTxn(), p.ExecCfg().JobRegistry)
+		if err != nil {
+			return err
+		}
+		slotsByDB := make(map[descpb.ID][]replicationSlot)
+		for _, slot := range slots {
+			slotsByDB[slot.details.DatabaseID] = append(slotsByDB[slot.details.DatabaseID], slot)
+		}
+		// Like in postgres, the replication slots of all databases are shown.
+		return forEachDatabaseDesc(ctx, p, nil /* all databases */, true, /* requiresPrivileges */
+			func(db catalog.DatabaseDescriptor) error {
+				for _, slot := range slotsByDB[db.GetID()] {
+					// The changes are retained from the last position confirmed by
+					// the client, which is where streaming restarts.
+					startLSN := tree.NewDString(lsnutil.HLCToLSN(slot.progress.ConfirmedFlush).String())
+					if err := addRow(
+						tree.NewDName(slot.details.SlotName), // slot_name
+						tree.NewDName(slot.details.Plugin),   // plugin
+						tree.NewDString("logical"),           // slot_type
+						dbOid(db.GetID()),                    // datoid
+						tree.NewDName(db.GetName()),          // database
+						tree.DBoolFalse,                      // temporary
+						tree.DBoolFalse,                      // active
+						tree.DNull,                           // active_pid
+						tree.DNull,                           // xmin
+						tree.DNull,                           // catalog_xmin
+						startLSN,                             // restart_lsn
+						startLSN,                             // confirmed_flush_lsn
+						tree.DNull,                           // wal_status
+						tree.DNull,                           // safe_wal_size
+					); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
 	},
-	unimplemented: true,
 }
 
 var pgCatalogSubscriptionRelTable = virtualSchemaTable{
@@ -4610,12 +4837,35 @@ var pgCatalogStatReplicationTable = virtualSchemaTable{
 }
 
 var pgCatalogPublicationRelTable = virtualSchemaTable{
-	comment: "pg_publication_rel was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogPublicationRel,
-	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+	comment: `tables explicitly added to publications
+https://www.postgresql.org/docs/current/catalog-pg-publication-rel.html`,
+	schema: vtable.PgCatalogPublicationRel,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		h := makeOidHasher()
+		pubs, err := p.getPublicationsByDatabase(ctx)
+		if err != nil {
+			return err
+		}
+		return forEachTableDesc(ctx, p, dbContext, hideVirtual, /* virtual tables cannot be published */
+			func(db catalog.DatabaseDescriptor, _ catalog.SchemaDescriptor, table catalog.TableDescriptor) error {
+				for i := range pubs[db.GetID()] {
+					pub := &pubs[db.GetID()][i]
+					// Like in postgres, FOR ALL TABLES publications have no rows.
+					if pub.allTables || !publicationIncludesTable(pub, table) {
+						continue
+					}
+					pubOid := h.PublicationOid(db.GetID(), pub.name)
+					if err := addRow(
+						h.PublicationRelOid(pubOid, table.GetID()), // oid
+						pubOid,                  // prpubid
+						tableOid(table.GetID()), // prrelid
+					); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
 	},
-	unimplemented: true,
 }
 
 var pgCatalogAvailableExtensionVersionsTable = virtualSchemaTable{
@@ -4679,6 +4929,8 @@ var datumToTypeCategory = map[types.Family]*tree.DString{
 	types.GeographyFamily:   typCategoryUserDefined,
 	types.GeometryFamily:    typCategoryUserDefined,
 	types.JsonFamily:        typCategoryUserDefined,
+	types.JsonpathFamily:    typCategoryUserDefined,
+	types.GeometricFamily:   typCategoryGeometric,
 	types.DecimalFamily:     typCategoryNumeric,
 	types.StringFamily:      typCategoryString,
 	types.TimestampFamily:   typCategoryDateTime,
@@ -4692,8 +4944,11 @@ var datumToTypeCategory = map[types.Family]*tree.DString{
 	types.RefCursorFamily:   typCategoryUserDefined,
 	types.UuidFamily:        typCategoryUserDefined,
 	types.INetFamily:        typCategoryNetworkAddr,
+	types.RangeFamily:       typCategoryRange,
+	types.MultirangeFamily:  typCategoryRange,
 	types.UnknownFamily:     typCategoryUnknown,
 	types.VoidFamily:        typCategoryPseudo,
+	types.TriggerFamily:     typCategoryPseudo,
 }
 
 func typCategory(typ *types.T) tree.Datum {
@@ -4704,6 +4959,10 @@ func typCategory(typ *types.T) tree.Datum {
 	if typ.UserDefined() && typ.Family() == types.TupleFamily {
 		return typCategoryComposite
 	}
+	// Special case the anyrange and anymultirange pseudo-types.
+	if typ.Oid() == oid.T_anyrange || typ.Oid() == oidext.T_anymultirange {
+		return typCategoryPseudo
+	}
 	return datumToTypeCategory[typ.Family()]
 }
 
@@ -4814,11 +5073,83 @@ https://www.postgresql.org/docs/9.6/catalog-pg-aggregate.html`,
 						}
 					}
 				}
-				return nil
+				return forEachSchema(ctx, p, db, true /* requiresPrivileges */, func(scDesc catalog.SchemaDescriptor) error {
+					return scDesc.ForEachFunctionSignature(func(sig descpb.SchemaDescriptor_FunctionSignature) error {
+						if !sig.IsAggregate {
+							return nil
+						}
+						fnDesc, err := p.Descriptors().ByID(p.Txn()).WithoutNonPublic().Get().Function(ctx, sig.ID)
+						if err != nil {
+							return err
+						}
+						return addPgAggregateUDARow(ctx, p, fnDesc, addRow)
+					})
+				})
 			})
 	},
 }
 
+// addPgAggregateUDARow adds the pg_aggregate row of a user-defined aggregate.
+func addPgAggregateUDARow(
+	ctx context.Context,
+	p *planner,
+	fnDesc catalog.FunctionDescriptor,
+	addRow func(...tree.Datum) error,
+) error {
+	agg := fnDesc.FuncDesc().Aggregate
+	supportFunc := func(id descpb.ID) (tree.Datum, error) {
+		if id == descpb.InvalidID {
+			return tree.NewDOidWithName(0, types.RegProc, "-"), nil
+		}
+		fn, err := p.Descriptors().ByID(p.Txn()).WithoutNonPublic().Get().Function(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		return tree.NewDOid(catid.FuncIDToOID(id)).AsRegProc(fn.GetName()), nil
+	}
+	transFn, err := supportFunc(agg.StateFuncID)
+	if err != nil {
+		return err
+	}
+	finalFn, err := supportFunc(agg.FinalFuncID)
+	if err != nil {
+		return err
+	}
+	combineFn, err := supportFunc(agg.CombineFuncID)
+	if err != nil {
+		return err
+	}
+	regprocForZeroOid := tree.NewDOidWithName(0, types.RegProc, "-")
+	initVal := tree.DNull
+	if agg.InitCond != nil {
+		initVal = tree.NewDString(*agg.InitCond)
+	}
+	return addRow(
+		tree.NewDOid(catid.FuncIDToOID(fnDesc.GetID())).AsRegProc(fnDesc.GetName()), // aggfnoid
+		tree.NewDString("n"),              // aggkind
+		zeroVal,                           // aggnumdirectargs
+		transFn,                           // aggtransfn
+		finalFn,                           // aggfinalfn
+		combineFn,                         // aggcombinefn
+		regprocForZeroOid,                 // aggserialfn
+		regprocForZeroOid,                 // aggdeserialfn
+		regprocForZeroOid,                 // aggmtransfn
+		regprocForZeroOid,                 // aggminvtransfn
+		regprocForZeroOid,                 // aggmfinalfn
+		tree.DBoolFalse,                   // aggfinalextra
+		tree.DBoolFalse,                   // aggmfinalextra
+		oidZero,                           // aggsortop
+		tree.NewDOid(agg.StateType.Oid()), // aggtranstype
+		zeroVal,                           // aggtransspace
+		oidZero,                           // aggmtranstype
+		zeroVal,                           // aggmtransspace
+		initVal,                           // agginitval
+		tree.DNull,                        // aggminitval
+		tree.NewDString("r"),              // aggfinalmodify
+		tree.NewDString("r"),              // aggmfinalmodify
+	)
+}
+
 // oidHasher provides a consistent hashing mechanism for object identifiers in
 // pg_catalog tables, allowing for reliable joins across tables.
 //
@@ -4907,6 +5238,10 @@ const (
 	rewriteTypeTag
 	dbSchemaRoleTypeTag
 	castTypeTag
+	publicationTypeTag
+	publicationRelTypeTag
+	foreignDataWrapperTypeTag
+	foreignServerTypeTag
 )
 
 func (h oidHasher) writeTypeTag(tag oidTypeTag) {
@@ -5081,6 +5416,41 @@ func (h oidHasher) DBSchemaRoleOid(
 	return h.getOid()
 }
 
+// PublicationOid creates an OID for the publication with the given name in
+// the given database.
+func (h oidHasher) PublicationOid(dbID descpb.ID, name string) *tree.DOid {
+	h.writeTypeTag(publicationTypeTag)
+	h.writeDB(dbID)
+	h.writeStr(name)
+	return h.getOid()
+}
+
+// PublicationRelOid creates an OID for the membership of a table in a
+// publication.
+func (h oidHasher) PublicationRelOid(pubOid *tree.DOid, tableID descpb.ID) *tree.DOid {
+	h.writeTypeTag(publicationRelTypeTag)
+	h.writeOID(pubOid)
+	h.writeTable(tableID)
+	return h.getOid()
+}
+
+// ForeignDataWrapperOid creates an OID for the foreign data wrapper with the
+// given name.
+func (h oidHasher) ForeignDataWrapperOid(name string) *tree.DOid {
+	h.writeTypeTag(foreignDataWrapperTypeTag)
+	h.writeStr(name)
+	return h.getOid()
+}
+
+// ForeignServerOid creates an OID for the foreign server with the given name
+// in the given database.
+func (h oidHasher) ForeignServerOid(dbID descpb.ID, name string) *tree.DOid {
+	h.writeTypeTag(foreignServerTypeTag)
+	h.writeDB(dbID)
+	h.writeStr(name)
+	return h.getOid()
+}
+
 func tableOid(id descpb.ID) *tree.DOid {
 	return tree.NewDOid(oid.Oid(id))
 }
@@ -5119,6 +5489,23 @@ func funcVolatility(v catpb.Function_Volatility) string {
 	}
 }
 
+func funcParamMode(c catpb.Function_Param_Class) string {
+	switch c {
+	case catpb.Function_Param_IN:
+		return "i"
+	case catpb.Function_Param_OUT:
+		return "o"
+	case catpb.Function_Param_IN_OUT:
+		return "b"
+	case catpb.Function_Param_VARIADIC:
+		return "v"
+	case catpb.Function_Param_TABLE:
+		return "t"
+	default:
+		return ""
+	}
+}
+
 // populateVirtualIndexForTable is used to populate the virtual index with context of the given table descriptor.
 func populateVirtualIndexForTable(
 	ctx context.Context,
@@ -5210,3 +5597,16 @@ func populateVirtualIndexForType(
 	}
 	return true, nil
 }
+
+// constraintDeferrability returns whether the checks of the given constraint
+// can be deferred until the end of the transaction, and whether they are
+// deferred by default.
+func constraintDeferrability(c catalog.Constraint) (deferrable, initiallyDeferred bool) {
+	if fk := c.AsForeignKey(); fk != nil {
+		return fk.ForeignKeyDesc().Deferrable, fk.ForeignKeyDesc().InitiallyDeferred
+	}
+	if uwi := c.AsUniqueWithoutIndex(); uwi != nil {
+		return uwi.UniqueWithoutIndexDesc().Deferrable, uwi.UniqueWithoutIndexDesc().InitiallyDeferred
+	}
+	return false, false
+}
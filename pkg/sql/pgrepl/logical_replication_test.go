@@ -0,0 +1,193 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgrepl
+
+import (
+	"context"
+	"encoding/binary"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgproto3"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLogicalReplication streams the changes of a published table with
+// START_REPLICATION and checks the pgoutput messages received.
+func TestLogicalReplication(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	srv, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer srv.Stopper().Stop(ctx)
+	s := srv.ApplicationLayer()
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, `SET CLUSTER SETTING kv.rangefeed.enabled = true`)
+	sqlDB.Exec(t, `SET CLUSTER SETTING kv.closed_timestamp.target_duration = '100ms'`)
+	sqlDB.Exec(t, `SET CLUSTER SETTING kv.rangefeed.closed_timestamp_refresh_interval = '100ms'`)
+	sqlDB.Exec(t, `CREATE TABLE t (k INT PRIMARY KEY, v STRING)`)
+	sqlDB.Exec(t, `CREATE TABLE unpublished (k INT PRIMARY KEY)`)
+	sqlDB.Exec(t, `CREATE PUBLICATION pub FOR TABLE t`)
+
+	pgURL, cleanup := sqlutils.PGUrl(t, s.AdvSQLAddr(), "pgrepl_logical_replication_test", url.User(username.RootUser))
+	defer cleanup()
+	cfg, err := pgconn.ParseConfig(pgURL.String())
+	require.NoError(t, err)
+	cfg.RuntimeParams["replication"] = "database"
+	conn, err := pgconn.ConnectConfig(ctx, cfg)
+	require.NoError(t, err)
+	defer func() { _ = conn.Close(ctx) }()
+
+	res, err := conn.Exec(ctx, `CREATE_REPLICATION_SLOT test_slot LOGICAL pgoutput`).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	require.Len(t, res[0].Rows, 1)
+	require.Equal(t, "test_slot", string(res[0].Rows[0][0]))
+	require.Equal(t, "pgoutput", string(res[0].Rows[0][3]))
+	sqlDB.CheckQueryResults(t,
+		`SELECT slot_name, plugin, database FROM pg_replication_slots`,
+		[][]string{{"test_slot", "pgoutput", "defaultdb"}},
+	)
+
+	sqlDB.Exec(t, `INSERT INTO t VALUES (1, 'a')`)
+	sqlDB.Exec(t, `INSERT INTO unpublished VALUES (1)`)
+	sqlDB.Exec(t, `UPDATE t SET v = NULL WHERE k = 1`)
+	sqlDB.Exec(t, `DELETE FROM t WHERE k = 1`)
+
+	fe := conn.Frontend()
+	fe.Send(&pgproto3.Query{
+		String: `START_REPLICATION SLOT test_slot LOGICAL 0/0 (proto_version '1', publication_names 'pub')`,
+	})
+	require.NoError(t, fe.Flush())
+	msg, err := conn.ReceiveMessage(ctx)
+	require.NoError(t, err)
+	require.IsType(t, &pgproto3.CopyBothResponse{}, msg)
+
+	// Collect the pgoutput messages until the three transactions are received,
+	// answering the keepalive messages along the way.
+	type change struct {
+		typ   byte
+		tuple []string
+	}
+	var changes []change
+	var commits int
+	var commitLSN, endLSN uint64
+	recvCtx, cancel := context.WithTimeout(ctx, 45*time.Second)
+	defer cancel()
+	for commits < 3 {
+		msg, err := conn.ReceiveMessage(recvCtx)
+		require.NoError(t, err)
+		cd, ok := msg.(*pgproto3.CopyData)
+		require.True(t, ok, "unexpected message %T", msg)
+		switch cd.Data[0] {
+		case 'k':
+			continue
+		case 'w':
+		default:
+			t.Fatalf("unexpected replication message %q", cd.Data[0])
+		}
+		// Skip the XLogData header.
+		data := cd.Data[1+8+8+8:]
+		c := change{typ: data[0]}
+		switch c.typ {
+		case 'C':
+			commits++
+			commitLSN = binary.BigEndian.Uint64(data[2:])
+			endLSN = binary.BigEndian.Uint64(data[10:])
+		case 'R':
+			// The relation ID is followed by the namespace and the name.
+			require.Contains(t, string(data[5:]), "public\x00t\x00")
+		case 'I', 'U', 'D':
+			c.tuple = decodeTuple(t, data[1+4+1:])
+		}
+		changes = append(changes, c)
+	}
+	require.Equal(t, []change{
+		{typ: 'B'}, {typ: 'R'}, {typ: 'I', tuple: []string{"1", "a"}}, {typ: 'C'},
+		{typ: 'B'}, {typ: 'U', tuple: []string{"1", "NULL"}}, {typ: 'C'},
+		{typ: 'B'}, {typ: 'D', tuple: []string{"1", "NULL"}}, {typ: 'C'},
+	}, changes)
+
+	// Confirm the received changes with a standby status update, then end the
+	// stream from the client side. The confirmed position is recorded in the
+	// slot.
+	status := []byte{'r'}
+	for _, pos := range []uint64{endLSN, endLSN, endLSN, 0 /* clientTime */} {
+		status = binary.BigEndian.AppendUint64(status, pos)
+	}
+	status = append(status, 0 /* replyRequested */)
+	fe.Send(&pgproto3.CopyData{Data: status})
+	fe.Send(&pgproto3.CopyDone{})
+	require.NoError(t, fe.Flush())
+	var copyDone, commandComplete bool
+	for {
+		msg, err := conn.ReceiveMessage(recvCtx)
+		require.NoError(t, err)
+		switch msg := msg.(type) {
+		case *pgproto3.CopyData:
+		case *pgproto3.CopyDone:
+			copyDone = true
+		case *pgproto3.CommandComplete:
+			require.Equal(t, "START_REPLICATION", string(msg.CommandTag))
+			commandComplete = true
+		case *pgproto3.ReadyForQuery:
+			require.True(t, copyDone)
+			require.True(t, commandComplete)
+			sqlDB.CheckQueryResults(t,
+				`SELECT confirmed_flush_lsn FROM pg_replication_slots`,
+				[][]string{{lsn.LSN(commitLSN).String()}},
+			)
+			sqlDB.CheckQueryResults(t, `SELECT count(*) FROM system.protected_ts_records`, [][]string{{"1"}})
+
+			_, err = conn.Exec(ctx, `DROP_REPLICATION_SLOT test_slot`).ReadAll()
+			require.NoError(t, err)
+			sqlDB.CheckQueryResults(t, `SELECT count(*) FROM pg_replication_slots`, [][]string{{"0"}})
+			// The protected timestamp record of the slot is released by its job.
+			sqlDB.CheckQueryResultsRetry(t, `SELECT count(*) FROM system.protected_ts_records`, [][]string{{"0"}})
+			return
+		default:
+			t.Fatalf("unexpected message %T", msg)
+		}
+	}
+}
+
+// decodeTuple decodes the text values of a TupleData of a pgoutput message,
+// using NULL for null and unchanged values.
+func decodeTuple(t *testing.T, data []byte) []string {
+	n := int(binary.BigEndian.Uint16(data))
+	data = data[2:]
+	vals := make([]string, n)
+	for i := range vals {
+		switch data[0] {
+		case 'n', 'u':
+			vals[i] = "NULL"
+			data = data[1:]
+		case 't':
+			l := int(binary.BigEndian.Uint32(data[1:]))
+			vals[i] = string(data[5 : 5+l])
+			data = data[5+l:]
+		default:
+			t.Fatalf("unexpected tuple value kind %q", data[0])
+		}
+	}
+	return vals
+}
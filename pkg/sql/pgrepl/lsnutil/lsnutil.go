@@ -17,9 +17,24 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 )
 
+// offsetBits is the number of low bits of a LSN which are not derived from the
+// wall time of a HLC. All the timestamps within a microsecond map to the same
+// LSN, and the low bits are left for positions within that microsecond.
+const offsetBits = 12
+
 // HLCToLSN converts a HLC to a LSN.
 // It is in a separate package to prevent the `lsn` package importing `log`.
 func HLCToLSN(h hlc.Timestamp) lsn.LSN {
-	// TODO(#105130): correctly populate this field.
-	return lsn.LSN(h.WallTime/int64(time.Millisecond)) << 32
+	return lsn.LSN(h.WallTime/int64(time.Microsecond)) << offsetBits
+}
+
+// LSNToHLC returns the earliest HLC whose LSN is at or after the given LSN.
+func LSNToHLC(l lsn.LSN) hlc.Timestamp {
+	micros := (l + (1 << offsetBits) - 1) >> offsetBits
+	return hlc.Timestamp{WallTime: int64(micros) * int64(time.Microsecond)}
+}
+
+// EndLSN returns the last LSN of the microsecond starting at the given LSN.
+func EndLSN(l lsn.LSN) lsn.LSN {
+	return l | ((1 << offsetBits) - 1)
 }
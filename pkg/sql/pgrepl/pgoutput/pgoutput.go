@@ -0,0 +1,241 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package pgoutput encodes the messages of the streaming replication protocol
+// and of the pgoutput logical decoding plugin (protocol version 1).
+//
+// See https://www.postgresql.org/docs/current/protocol-replication.html and
+// https://www.postgresql.org/docs/current/protocol-logicalrep-message-formats.html.
+package pgoutput
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
+)
+
+// pgEpoch is the epoch of the timestamps of the replication protocol.
+var pgEpoch = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Message types of the pgoutput plugin.
+const (
+	msgBegin    = 'B'
+	msgCommit   = 'C'
+	msgRelation = 'R'
+	msgInsert   = 'I'
+	msgUpdate   = 'U'
+	msgDelete   = 'D'
+)
+
+// Message types of the streaming replication protocol, which are sent inside
+// CopyData messages.
+const (
+	msgXLogData            = 'w'
+	msgPrimaryKeepalive    = 'k'
+	msgStandbyStatusUpdate = 'r'
+)
+
+// Tuple markers.
+const (
+	tupleNew  = 'N'
+	tupleKey  = 'K'
+	tupleNull = 'n'
+	tupleText = 't'
+)
+
+// replicaIdentityDefault is the replica identity of relations whose rows are
+// identified by their primary key.
+const replicaIdentityDefault = 'd'
+
+// columnFlagKey is set in the flags of the columns that are part of the
+// replica identity.
+const columnFlagKey = 1
+
+// Column describes a column of a Relation message.
+type Column struct {
+	Name    string
+	TypeOID oid.Oid
+	TypeMod int32
+	// Key is set if the column is part of the primary key.
+	Key bool
+}
+
+// Value is the text representation of a column value.
+type Value struct {
+	Null bool
+	Text []byte
+}
+
+// AppendBegin appends a Begin message for a transaction committing at the
+// given LSN.
+func AppendBegin(b []byte, finalLSN lsn.LSN, commitTime time.Time, xid uint32) []byte {
+	b = append(b, msgBegin)
+	b = binary.BigEndian.AppendUint64(b, uint64(finalLSN))
+	b = appendTime(b, commitTime)
+	return binary.BigEndian.AppendUint32(b, xid)
+}
+
+// AppendCommit appends a Commit message.
+func AppendCommit(b []byte, commitLSN, endLSN lsn.LSN, commitTime time.Time) []byte {
+	b = append(b, msgCommit)
+	b = append(b, 0 /* flags */)
+	b = binary.BigEndian.AppendUint64(b, uint64(commitLSN))
+	b = binary.BigEndian.AppendUint64(b, uint64(endLSN))
+	return appendTime(b, commitTime)
+}
+
+// AppendRelation appends a Relation message, which describes the columns of
+// the tuples of the following messages referencing the relation.
+func AppendRelation(b []byte, relID oid.Oid, namespace, name string, cols []Column) []byte {
+	b = append(b, msgRelation)
+	b = binary.BigEndian.AppendUint32(b, uint32(relID))
+	b = appendString(b, namespace)
+	b = appendString(b, name)
+	b = append(b, replicaIdentityDefault)
+	b = binary.BigEndian.AppendUint16(b, uint16(len(cols)))
+	for _, col := range cols {
+		var flags byte
+		if col.Key {
+			flags |= columnFlagKey
+		}
+		b = append(b, flags)
+		b = appendString(b, col.Name)
+		b = binary.BigEndian.AppendUint32(b, uint32(col.TypeOID))
+		b = binary.BigEndian.AppendUint32(b, uint32(col.TypeMod))
+	}
+	return b
+}
+
+// AppendInsert appends an Insert message with the values of the new row.
+func AppendInsert(b []byte, relID oid.Oid, row []Value) []byte {
+	b = append(b, msgInsert)
+	b = binary.BigEndian.AppendUint32(b, uint32(relID))
+	b = append(b, tupleNew)
+	return appendTuple(b, row)
+}
+
+// AppendUpdate appends an Update message with the values of the new row. The
+// old row is not included since the primary key of updated rows is unchanged.
+func AppendUpdate(b []byte, relID oid.Oid, row []Value) []byte {
+	b = append(b, msgUpdate)
+	b = binary.BigEndian.AppendUint32(b, uint32(relID))
+	b = append(b, tupleNew)
+	return appendTuple(b, row)
+}
+
+// AppendDelete appends a Delete message identifying the deleted row by its
+// key. The values of the columns that are not part of the key are sent as
+// NULL.
+func AppendDelete(b []byte, relID oid.Oid, cols []Column, row []Value) []byte {
+	b = append(b, msgDelete)
+	b = binary.BigEndian.AppendUint32(b, uint32(relID))
+	b = append(b, tupleKey)
+	b = binary.BigEndian.AppendUint16(b, uint16(len(row)))
+	for i := range row {
+		if !cols[i].Key {
+			b = append(b, tupleNull)
+			continue
+		}
+		b = appendValue(b, row[i])
+	}
+	return b
+}
+
+// AppendXLogData appends the header of a XLogData message. The WAL data, a
+// pgoutput message, must be appended after it.
+func AppendXLogData(b []byte, walStart, walEnd lsn.LSN, sendTime time.Time) []byte {
+	b = append(b, msgXLogData)
+	b = binary.BigEndian.AppendUint64(b, uint64(walStart))
+	b = binary.BigEndian.AppendUint64(b, uint64(walEnd))
+	return appendTime(b, sendTime)
+}
+
+// AppendPrimaryKeepalive appends a Primary keepalive message.
+func AppendPrimaryKeepalive(
+	b []byte, walEnd lsn.LSN, sendTime time.Time, replyRequested bool,
+) []byte {
+	b = append(b, msgPrimaryKeepalive)
+	b = binary.BigEndian.AppendUint64(b, uint64(walEnd))
+	b = appendTime(b, sendTime)
+	if replyRequested {
+		return append(b, 1)
+	}
+	return append(b, 0)
+}
+
+// StandbyStatusUpdate is the message sent by clients to report the position
+// up to which they have received and flushed the stream.
+type StandbyStatusUpdate struct {
+	WritePosition  lsn.LSN
+	FlushPosition  lsn.LSN
+	ApplyPosition  lsn.LSN
+	ClientTime     time.Time
+	ReplyRequested bool
+}
+
+// standbyStatusUpdateLen is the length of a Standby status update message.
+const standbyStatusUpdateLen = 1 + 8*4 + 1
+
+// IsStandbyStatusUpdate returns whether the given CopyData payload sent by a
+// client is a Standby status update message.
+func IsStandbyStatusUpdate(data []byte) bool {
+	return len(data) > 0 && data[0] == msgStandbyStatusUpdate
+}
+
+// ParseStandbyStatusUpdate parses a Standby status update message.
+func ParseStandbyStatusUpdate(data []byte) (StandbyStatusUpdate, error) {
+	if len(data) != standbyStatusUpdateLen || data[0] != msgStandbyStatusUpdate {
+		return StandbyStatusUpdate{}, errors.Newf("invalid standby status update message")
+	}
+	data = data[1:]
+	readUint64 := func() uint64 {
+		v := binary.BigEndian.Uint64(data)
+		data = data[8:]
+		return v
+	}
+	var u StandbyStatusUpdate
+	u.WritePosition = lsn.LSN(readUint64())
+	u.FlushPosition = lsn.LSN(readUint64())
+	u.ApplyPosition = lsn.LSN(readUint64())
+	u.ClientTime = pgEpoch.Add(time.Duration(readUint64()) * time.Microsecond)
+	u.ReplyRequested = data[0] != 0
+	return u, nil
+}
+
+func appendTuple(b []byte, row []Value) []byte {
+	b = binary.BigEndian.AppendUint16(b, uint16(len(row)))
+	for _, v := range row {
+		b = appendValue(b, v)
+	}
+	return b
+}
+
+func appendValue(b []byte, v Value) []byte {
+	if v.Null {
+		return append(b, tupleNull)
+	}
+	b = append(b, tupleText)
+	b = binary.BigEndian.AppendUint32(b, uint32(len(v.Text)))
+	return append(b, v.Text...)
+}
+
+func appendString(b []byte, s string) []byte {
+	b = append(b, s...)
+	return append(b, 0)
+}
+
+// appendTime appends the given time as the number of microseconds since the
+// pg epoch.
+func appendTime(b []byte, t time.Time) []byte {
+	return binary.BigEndian.AppendUint64(b, uint64(t.Sub(pgEpoch).Microseconds()))
+}
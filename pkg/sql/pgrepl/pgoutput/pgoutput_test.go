@@ -0,0 +1,127 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgoutput
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/lib/pq/oid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncoding(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	// One second after the pg epoch.
+	ts := time.Date(2000, 1, 1, 0, 0, 1, 0, time.UTC)
+	tsBytes := []byte{0, 0, 0, 0, 0, 0x0f, 0x42, 0x40}
+	lsnBytes := []byte{0, 0, 0, 1, 0, 0, 0x10, 0}
+	l := lsn.LSN(1<<32 | 1<<12)
+
+	cols := []Column{
+		{Name: "k", TypeOID: oid.T_int8, TypeMod: -1, Key: true},
+		{Name: "v", TypeOID: oid.T_text, TypeMod: -1},
+	}
+	row := []Value{{Text: []byte("1")}, {Null: true}}
+
+	concat := func(parts ...[]byte) []byte {
+		var res []byte
+		for _, p := range parts {
+			res = append(res, p...)
+		}
+		return res
+	}
+
+	for _, tc := range []struct {
+		name     string
+		actual   []byte
+		expected []byte
+	}{
+		{
+			name:     "begin",
+			actual:   AppendBegin(nil, l, ts, 7),
+			expected: concat([]byte{'B'}, lsnBytes, tsBytes, []byte{0, 0, 0, 7}),
+		},
+		{
+			name:     "commit",
+			actual:   AppendCommit(nil, l, l, ts),
+			expected: concat([]byte{'C', 0}, lsnBytes, lsnBytes, tsBytes),
+		},
+		{
+			name:   "relation",
+			actual: AppendRelation(nil, 104, "public", "t", cols),
+			expected: concat(
+				[]byte{'R', 0, 0, 0, 104},
+				[]byte("public\x00t\x00d"),
+				[]byte{0, 2},
+				[]byte{1}, []byte("k\x00"), []byte{0, 0, 0, 20}, []byte{0xff, 0xff, 0xff, 0xff},
+				[]byte{0}, []byte("v\x00"), []byte{0, 0, 0, 25}, []byte{0xff, 0xff, 0xff, 0xff},
+			),
+		},
+		{
+			name:     "insert",
+			actual:   AppendInsert(nil, 104, row),
+			expected: concat([]byte{'I', 0, 0, 0, 104, 'N', 0, 2, 't', 0, 0, 0, 1, '1', 'n'}),
+		},
+		{
+			name:     "update",
+			actual:   AppendUpdate(nil, 104, row),
+			expected: concat([]byte{'U', 0, 0, 0, 104, 'N', 0, 2, 't', 0, 0, 0, 1, '1', 'n'}),
+		},
+		{
+			name:     "delete",
+			actual:   AppendDelete(nil, 104, cols, []Value{{Text: []byte("1")}, {Text: []byte("a")}}),
+			expected: concat([]byte{'D', 0, 0, 0, 104, 'K', 0, 2, 't', 0, 0, 0, 1, '1', 'n'}),
+		},
+		{
+			name:     "xlogdata",
+			actual:   AppendXLogData(nil, l, l, ts),
+			expected: concat([]byte{'w'}, lsnBytes, lsnBytes, tsBytes),
+		},
+		{
+			name:     "keepalive",
+			actual:   AppendPrimaryKeepalive(nil, l, ts, true),
+			expected: concat([]byte{'k'}, lsnBytes, tsBytes, []byte{1}),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.actual)
+		})
+	}
+}
+
+func TestParseStandbyStatusUpdate(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	msg := []byte{'r'}
+	for _, v := range []uint64{3 << 12, 2 << 12, 1 << 12, 1_000_000} {
+		msg = binary.BigEndian.AppendUint64(msg, v)
+	}
+	msg = append(msg, 1)
+
+	require.True(t, IsStandbyStatusUpdate(msg))
+	u, err := ParseStandbyStatusUpdate(msg)
+	require.NoError(t, err)
+	require.Equal(t, StandbyStatusUpdate{
+		WritePosition:  3 << 12,
+		FlushPosition:  2 << 12,
+		ApplyPosition:  1 << 12,
+		ClientTime:     time.Date(2000, 1, 1, 0, 0, 1, 0, time.UTC),
+		ReplyRequested: true,
+	}, u)
+
+	_, err = ParseStandbyStatusUpdate(msg[:10])
+	require.Error(t, err)
+}
@@ -30,4 +30,16 @@ type Conn interface {
 	// subprotocol (COPY ... FROM STDIN). This message informs the client about
 	// the columns that are expected for the rows to be inserted.
 	BeginCopyIn(ctx context.Context, columns []colinfo.ResultColumn, format FormatCode) error
+
+	// BeginCopyBoth sends the server message initiating the Copy-both
+	// subprotocol, which is used by START_REPLICATION to stream changes to the
+	// client while receiving status updates from it.
+	BeginCopyBoth(ctx context.Context) error
+
+	// SendCopyData sends a CopyData message to the client and flushes it.
+	SendCopyData(ctx context.Context, data []byte) error
+
+	// SendCopyDone sends a CopyDone message to the client, ending the
+	// Copy-both subprotocol on the server side.
+	SendCopyDone(ctx context.Context) error
 }
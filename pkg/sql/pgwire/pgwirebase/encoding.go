@@ -24,6 +24,7 @@ import (
 	"unsafe"
 
 	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/cockroachdb/cockroach/pkg/geo/geometric"
 	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/sql/lex"
 	"github.com/cockroachdb/cockroach/pkg/sql/oidext"
@@ -36,7 +37,6 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/bitarray"
 	"github.com/cockroachdb/cockroach/pkg/util/duration"
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
-	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/ipaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
@@ -297,18 +297,13 @@ func NewInvalidBinaryRepresentationErrorf(format string, args ...interface{}) er
 // validateArrayDimensions takes the number of dimensions and elements and
 // returns an error if we don't support that combination.
 func validateArrayDimensions(nDimensions int, nElements int) error {
-	switch nDimensions {
-	case 1:
-		break
-	case 0:
-		// 0-dimensional array means 0-length array: validate that.
-		if nElements == 0 {
-			break
-		}
-		fallthrough
-	default:
-		return unimplemented.NewWithIssuef(32552,
-			"%d-dimension arrays not supported; only 1-dimension", nDimensions)
+	if nDimensions < 0 || nDimensions > tree.MaxArrayDims {
+		return pgerror.Newf(pgcode.ProgramLimitExceeded,
+			"number of array dimensions (%d) exceeds the maximum allowed (%d)",
+			nDimensions, tree.MaxArrayDims)
+	}
+	if nElements < 0 {
+		return NewInvalidBinaryRepresentationErrorf("invalid array dimension size %d", nElements)
 	}
 	return nil
 }
@@ -456,6 +451,20 @@ func DecodeDatum(
 				return nil, err
 			}
 			return tree.ParseDJSON(bs)
+		case oidext.T_jsonpath:
+			if err := validateStringBytes(b); err != nil {
+				return nil, err
+			}
+			d, err := tree.ParseDJsonpath(bs)
+			if err != nil {
+				return nil, tree.MakeParseError(bs, typ, err)
+			}
+			return d, nil
+		case oid.T_point, oid.T_lseg, oid.T_line, oid.T_box, oid.T_path, oid.T_polygon, oid.T_circle:
+			if err := validateStringBytes(b); err != nil {
+				return nil, err
+			}
+			return tree.ParseDGeometric(typ, bs)
 		case oid.T_tsquery:
 			ret, err := tsearch.ParseTSQuery(bs)
 			if err != nil {
@@ -469,6 +478,28 @@ func DecodeDatum(
 			}
 			return &tree.DTSVector{TSVector: ret}, nil
 		}
+		switch typ.Family() {
+		case types.RangeFamily:
+			if err := validateStringBytes(b); err != nil {
+				return nil, err
+			}
+			// Note: the bounds of the range may retain the input string, so it
+			// must be copied.
+			d, _, err := tree.ParseDRange(evalCtx, string(b), typ)
+			if err != nil {
+				return nil, err
+			}
+			return d, nil
+		case types.MultirangeFamily:
+			if err := validateStringBytes(b); err != nil {
+				return nil, err
+			}
+			d, _, err := tree.ParseDMultirange(evalCtx, string(b), typ)
+			if err != nil {
+				return nil, err
+			}
+			return d, nil
+		}
 		if typ.Family() == types.ArrayFamily {
 			// Arrays come in in their string form, so we parse them as such and later
 			// convert them to their actual datum form.
@@ -721,6 +752,25 @@ func DecodeDatum(
 				return nil, err
 			}
 			return tree.ParseDJSON(encoding.UnsafeConvertBytesToString(b))
+		case oidext.T_jsonpath:
+			if len(b) < 1 {
+				return nil, NewProtocolViolationErrorf("no data to decode")
+			}
+			if b[0] != 1 {
+				return nil, NewProtocolViolationErrorf("expected jsonpath version 1")
+			}
+			// Skip over the version number.
+			b = b[1:]
+			if err := validateStringBytes(b); err != nil {
+				return nil, err
+			}
+			return tree.ParseDJsonpath(string(b))
+		case oid.T_point, oid.T_lseg, oid.T_line, oid.T_box, oid.T_path, oid.T_polygon, oid.T_circle:
+			s, err := geometric.DecodeBinary(tree.GeometricKind(typ), b)
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDGeometric(s), nil
 		case oid.T_varbit, oid.T_bit:
 			if len(b) < 4 {
 				return nil, NewProtocolViolationErrorf("insufficient data: %d", len(b))
@@ -787,6 +837,20 @@ func DecodeDatum(
 			if typ.Family() == types.TupleFamily {
 				return decodeBinaryTuple(ctx, evalCtx, b)
 			}
+			if typ.Family() == types.RangeFamily {
+				d, err := decodeBinaryRange(ctx, evalCtx, typ, b)
+				if err != nil {
+					return nil, err
+				}
+				return d, nil
+			}
+			if typ.Family() == types.MultirangeFamily {
+				d, err := decodeBinaryMultirange(ctx, evalCtx, typ, b)
+				if err != nil {
+					return nil, err
+				}
+				return d, nil
+			}
 			if typ.Family() == types.OidFamily {
 				if len(b) < 4 {
 					return nil, pgerror.Newf(pgcode.ProtocolViolation, "oid requires 4 bytes for binary format")
@@ -972,14 +1036,6 @@ func decodeBinaryArray(
 		_       int32
 		ElemOid int32
 	}
-	var dim struct {
-		// The next two fields should be arrays of size Ndims. However, since
-		// we only support 1-dimensional arrays for now, for convenience we can
-		// leave them in this struct as such for `binary.Read` to parse for us.
-		DimSize int32
-		// Dim lower bound
-		_ int32
-	}
 	r := bytes.NewBuffer(b)
 	if err := binary.Read(r, binary.BigEndian, &hdr); err != nil {
 		return nil, err
@@ -991,14 +1047,31 @@ func decodeBinaryArray(
 	if hdr.Ndims == 0 {
 		return arr, nil
 	}
-	if err := binary.Read(r, binary.BigEndian, &dim); err != nil {
+	if err := validateArrayDimensions(int(hdr.Ndims), 0 /* nElements */); err != nil {
 		return nil, err
 	}
-	if err := validateArrayDimensions(int(hdr.Ndims), int(dim.DimSize)); err != nil {
-		return nil, err
+	var dim struct {
+		DimSize int32
+		LBound  int32
+	}
+	dims := make([]tree.ArrayDim, hdr.Ndims)
+	nElements := 1
+	for i := range dims {
+		if err := binary.Read(r, binary.BigEndian, &dim); err != nil {
+			return nil, err
+		}
+		if err := validateArrayDimensions(int(hdr.Ndims), int(dim.DimSize)); err != nil {
+			return nil, err
+		}
+		dims[i] = tree.ArrayDim{Length: int(dim.DimSize), LowerBound: int(dim.LBound)}
+		// Each element takes at least 4 bytes, which bounds the number of
+		// elements before it can overflow.
+		if nElements *= int(dim.DimSize); nElements > r.Len()/4 {
+			return nil, NewInvalidBinaryRepresentationErrorf("insufficient data left in message")
+		}
 	}
 	var vlen int32
-	for i := int32(0); i < dim.DimSize; i++ {
+	for i := 0; i < nElements; i++ {
 		if err := binary.Read(r, binary.BigEndian, &vlen); err != nil {
 			return nil, err
 		}
@@ -1017,6 +1090,9 @@ func decodeBinaryArray(
 			return nil, err
 		}
 	}
+	if err := arr.SetDims(dims); err != nil {
+		return nil, err
+	}
 	return arr, nil
 }
 
@@ -1109,6 +1185,106 @@ func decodeBinaryTuple(ctx context.Context, evalCtx *eval.Context, b []byte) (tr
 
 }
 
+// The flags of the binary format of ranges.
+const (
+	// PGBinaryRangeEmpty is set if the range is empty.
+	PGBinaryRangeEmpty byte = 0x01
+	// PGBinaryRangeLowerInclusive is set if the lower bound is inclusive.
+	PGBinaryRangeLowerInclusive byte = 0x02
+	// PGBinaryRangeUpperInclusive is set if the upper bound is inclusive.
+	PGBinaryRangeUpperInclusive byte = 0x04
+	// PGBinaryRangeLowerInfinite is set if the range has no lower bound.
+	PGBinaryRangeLowerInfinite byte = 0x08
+	// PGBinaryRangeUpperInfinite is set if the range has no upper bound.
+	PGBinaryRangeUpperInfinite byte = 0x10
+)
+
+// decodeBinaryRange decodes the binary format of a range, which is a flags
+// byte followed by the length-prefixed binary encodings of the finite bounds.
+func decodeBinaryRange(
+	ctx context.Context, evalCtx *eval.Context, typ *types.T, b []byte,
+) (*tree.DRange, error) {
+	if len(b) < 1 {
+		return nil, pgerror.New(pgcode.Syntax, "range requires a flags byte for binary format")
+	}
+	flags := b[0]
+	b = b[1:]
+	if flags&PGBinaryRangeEmpty != 0 {
+		if len(b) != 0 {
+			return nil, pgerror.New(pgcode.Syntax, "unexpected data after empty range for binary format")
+		}
+		return tree.NewEmptyDRange(typ), nil
+	}
+	decodeBound := func(infinite, inclusive byte) (tree.RangeBound, error) {
+		if flags&infinite != 0 {
+			return tree.RangeBound{}, nil
+		}
+		if len(b) < elementSize {
+			return tree.RangeBound{}, pgerror.New(pgcode.Syntax, "insufficient bytes reading range bound size for binary format")
+		}
+		n := int(int32(binary.BigEndian.Uint32(b)))
+		b = b[elementSize:]
+		if n < 0 || len(b) < n {
+			return tree.RangeBound{}, pgerror.New(pgcode.Syntax, "insufficient bytes reading range bound for binary format")
+		}
+		val, err := DecodeDatum(ctx, evalCtx, typ.RangeContents(), FormatBinary, b[:n])
+		if err != nil {
+			return tree.RangeBound{}, err
+		}
+		b = b[n:]
+		return tree.RangeBound{Val: val, Inclusive: flags&inclusive != 0}, nil
+	}
+	lower, err := decodeBound(PGBinaryRangeLowerInfinite, PGBinaryRangeLowerInclusive)
+	if err != nil {
+		return nil, err
+	}
+	upper, err := decodeBound(PGBinaryRangeUpperInfinite, PGBinaryRangeUpperInclusive)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != 0 {
+		return nil, pgerror.New(pgcode.Syntax, "unexpected data after range bounds for binary format")
+	}
+	return tree.NewDRange(evalCtx, typ, lower, upper)
+}
+
+// decodeBinaryMultirange decodes the binary format of a multirange, which is
+// the number of ranges followed by the length-prefixed binary encodings of the
+// ranges.
+func decodeBinaryMultirange(
+	ctx context.Context, evalCtx *eval.Context, typ *types.T, b []byte,
+) (*tree.DMultirange, error) {
+	if len(b) < elementSize {
+		return nil, pgerror.New(pgcode.Syntax, "multirange requires a 4 byte header for binary format")
+	}
+	n := int32(binary.BigEndian.Uint32(b))
+	b = b[elementSize:]
+	if n < 0 {
+		return nil, pgerror.New(pgcode.Syntax, "multirange must have non-negative number of ranges")
+	}
+	ranges := make([]*tree.DRange, 0, n)
+	for i := int32(0); i < n; i++ {
+		if len(b) < elementSize {
+			return nil, pgerror.New(pgcode.Syntax, "insufficient bytes reading range size for binary format")
+		}
+		size := int(int32(binary.BigEndian.Uint32(b)))
+		b = b[elementSize:]
+		if size < 0 || len(b) < size {
+			return nil, pgerror.New(pgcode.Syntax, "insufficient bytes reading range for binary format")
+		}
+		r, err := decodeBinaryRange(ctx, evalCtx, typ.RangeContents(), b[:size])
+		if err != nil {
+			return nil, err
+		}
+		ranges = append(ranges, r)
+		b = b[size:]
+	}
+	if len(b) != 0 {
+		return nil, pgerror.New(pgcode.Syntax, "unexpected data after ranges for binary format")
+	}
+	return tree.NewDMultirange(evalCtx, typ, ranges)
+}
+
 var invalidUTF8Error = pgerror.Newf(pgcode.CharacterNotInRepertoire, "invalid UTF-8 sequence")
 
 var (
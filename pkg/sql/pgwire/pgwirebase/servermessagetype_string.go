@@ -15,6 +15,7 @@ func _() {
 	_ = x[ServerMsgCloseComplete-51]
 	_ = x[ServerMsgCopyInResponse-71]
 	_ = x[ServerMsgCopyOutResponse-72]
+	_ = x[ServerMsgCopyBothResponse-87]
 	_ = x[ServerMsgCopyDataCommand-100]
 	_ = x[ServerMsgCopyDoneCommand-99]
 	_ = x[ServerMsgDataRow-68]
@@ -22,6 +23,7 @@ func _() {
 	_ = x[ServerMsgErrorResponse-69]
 	_ = x[ServerMsgNoticeResponse-78]
 	_ = x[ServerMsgNoData-110]
+	_ = x[ServerMsgNotificationResponse-65]
 	_ = x[ServerMsgParameterDescription-116]
 	_ = x[ServerMsgParameterStatus-83]
 	_ = x[ServerMsgParseComplete-49]
@@ -46,6 +48,8 @@ func (i ServerMessageType) String() string {
 		return "ServerMsgCopyInResponse"
 	case ServerMsgCopyOutResponse:
 		return "ServerMsgCopyOutResponse"
+	case ServerMsgCopyBothResponse:
+		return "ServerMsgCopyBothResponse"
 	case ServerMsgCopyDataCommand:
 		return "ServerMsgCopyDataCommand"
 	case ServerMsgCopyDoneCommand:
@@ -60,6 +64,8 @@ func (i ServerMessageType) String() string {
 		return "ServerMsgNoticeResponse"
 	case ServerMsgNoData:
 		return "ServerMsgNoData"
+	case ServerMsgNotificationResponse:
+		return "ServerMsgNotificationResponse"
 	case ServerMsgParameterDescription:
 		return "ServerMsgParameterDescription"
 	case ServerMsgParameterStatus:
@@ -22,6 +22,7 @@ import (
 
 	"github.com/cockroachdb/apd/v3"
 	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/geo/geometric"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/lex"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
@@ -30,7 +31,6 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/duration"
-	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/ipaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
@@ -58,6 +58,11 @@ type pgType struct {
 }
 
 func pgTypeForParserType(t *types.T) pgType {
+	// Like Postgres, describe values of a domain type using the domain's base
+	// type.
+	if t.IsDomain() {
+		t = t.DomainBaseType()
+	}
 	size := tree.PGWireTypeSize(t)
 	tOid := t.Oid()
 	if tOid == oid.T_text && t.Width() > 0 {
@@ -246,6 +251,21 @@ func writeTextDatumNotNull(
 	case *tree.DJSON:
 		b.writeLengthPrefixedString(v.JSON.String())
 
+	case *tree.DJsonpath:
+		b.writeLengthPrefixedString(v.Jsonpath.String())
+
+	case *tree.DGeometric:
+		b.textFormatter.FormatNode(v)
+		b.writeFromFmtCtx(b.textFormatter)
+
+	case *tree.DRange:
+		b.textFormatter.FormatNode(v)
+		b.writeFromFmtCtx(b.textFormatter)
+
+	case *tree.DMultirange:
+		b.textFormatter.FormatNode(v)
+		b.writeFromFmtCtx(b.textFormatter)
+
 	case *tree.DTSQuery:
 		b.textFormatter.FormatNode(v)
 		b.writeFromFmtCtx(b.textFormatter)
@@ -563,6 +583,34 @@ func writeBinaryJSON(b *writeBuffer, v json.JSON, t *types.T) {
 	b.writeString(s)
 }
 
+// writeBinaryRange writes the binary encoding of r to the buffer, without a
+// length prefix.
+func (b *writeBuffer) writeBinaryRange(
+	ctx context.Context, r *tree.DRange, sessionLoc *time.Location,
+) {
+	if r.Empty {
+		b.writeByte(pgwirebase.PGBinaryRangeEmpty)
+		return
+	}
+	var flags byte
+	if r.Lower.IsInfinite() {
+		flags |= pgwirebase.PGBinaryRangeLowerInfinite
+	} else if r.Lower.Inclusive {
+		flags |= pgwirebase.PGBinaryRangeLowerInclusive
+	}
+	if r.Upper.IsInfinite() {
+		flags |= pgwirebase.PGBinaryRangeUpperInfinite
+	} else if r.Upper.Inclusive {
+		flags |= pgwirebase.PGBinaryRangeUpperInclusive
+	}
+	b.writeByte(flags)
+	for _, bound := range []tree.RangeBound{r.Lower, r.Upper} {
+		if !bound.IsInfinite() {
+			b.writeBinaryDatum(ctx, bound.Val, sessionLoc, r.Typ.RangeContents())
+		}
+	}
+}
+
 // writeBinaryDatum writes d to the buffer. Type t must be specified for types
 // that have various width encodings (floats, ints, chars). It is ignored
 // (and can be nil) for types with a 1:1 datum:type mapping.
@@ -770,23 +818,13 @@ func writeBinaryDatumNotNull(
 		b.putInt32AtIndex(initialLen /* index to write at */, int32(lengthToWrite))
 
 	case *tree.DArray:
-		if v.ParamTyp.Family() == types.ArrayFamily {
-			b.setError(unimplemented.NewWithIssueDetail(32552,
-				"binenc", "unsupported binary serialization of multidimensional arrays"))
-			return
-		}
-
 		initialLen := b.Len()
 
 		// Reserve bytes for writing length later.
 		b.putInt32(int32(0))
 
-		// Put the number of dimensions. We currently support 1d arrays only.
-		var ndims int32 = 1
-		if v.Len() == 0 {
-			ndims = 0
-		}
-		b.putInt32(ndims)
+		dims := v.Dimensions()
+		b.putInt32(int32(len(dims)))
 		hasNulls := 0
 		if v.HasNulls {
 			hasNulls = 1
@@ -794,10 +832,11 @@ func writeBinaryDatumNotNull(
 		oid := v.ParamTyp.Oid()
 		b.putInt32(int32(hasNulls))
 		b.putInt32(int32(oid))
-		if v.Len() > 0 {
-			b.putInt32(int32(v.Len()))
-			// Lower bound, we only support a lower bound of 1.
-			b.putInt32(1)
+		for _, dim := range dims {
+			b.putInt32(int32(dim.Length))
+			b.putInt32(int32(dim.LowerBound))
+		}
+		if len(dims) > 0 {
 			for _, elem := range v.Array {
 				b.writeBinaryDatum(ctx, elem, sessionLoc, v.ParamTyp)
 			}
@@ -809,6 +848,46 @@ func writeBinaryDatumNotNull(
 	case *tree.DJSON:
 		writeBinaryJSON(b, v.JSON, t)
 
+	case *tree.DJsonpath:
+		s := v.Jsonpath.String()
+		b.putInt32(int32(len(s) + 1))
+		// Postgres version number, as of writing, `1` is the only valid value.
+		b.writeByte(1)
+		b.writeString(s)
+
+	case *tree.DGeometric:
+		enc := geometric.EncodeBinary(nil, v.Shape)
+		b.putInt32(int32(len(enc)))
+		b.write(enc)
+
+	case *tree.DRange:
+		initialLen := b.Len()
+
+		// Reserve bytes for writing length later.
+		b.putInt32(int32(0))
+		b.writeBinaryRange(ctx, v, sessionLoc)
+
+		lengthToWrite := b.Len() - (initialLen + 4)
+		b.putInt32AtIndex(initialLen /* index to write at */, int32(lengthToWrite))
+
+	case *tree.DMultirange:
+		initialLen := b.Len()
+
+		// Reserve bytes for writing length later.
+		b.putInt32(int32(0))
+
+		// Put the number of ranges, each of which is prefixed by its length.
+		b.putInt32(int32(len(v.Ranges)))
+		for _, r := range v.Ranges {
+			rangeLen := b.Len()
+			b.putInt32(int32(0))
+			b.writeBinaryRange(ctx, r, sessionLoc)
+			b.putInt32AtIndex(rangeLen /* index to write at */, int32(b.Len()-(rangeLen+4)))
+		}
+
+		lengthToWrite := b.Len() - (initialLen + 4)
+		b.putInt32AtIndex(initialLen /* index to write at */, int32(lengthToWrite))
+
 	case *tree.DOid:
 		b.putInt32(4)
 		b.putInt32(int32(v.Oid))
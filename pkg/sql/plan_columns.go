@@ -64,8 +64,12 @@ func getPlanColumns(plan planNode, mut bool) colinfo.ResultColumns {
 		return n.columns
 	case *virtualTableNode:
 		return n.columns
+	case *foreignScanNode:
+		return n.columns
 	case *windowNode:
 		return n.columns
+	case *callNode:
+		return n.columns
 	case *showTraceNode:
 		return n.columns
 	case *zeroNode:
@@ -176,6 +180,8 @@ func getPlanColumns(plan planNode, mut bool) colinfo.ResultColumns {
 
 	case *identifySystemNode:
 		return n.getColumns(mut, colinfo.IdentifySystemColumns)
+	case *createReplicationSlotNode:
+		return n.getColumns(mut, colinfo.CreateReplicationSlotColumns)
 	}
 
 	// Every other node has no columns in their results.
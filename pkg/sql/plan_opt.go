@@ -196,6 +196,12 @@ func (p *planner) prepareUsingOptimizer(ctx context.Context) (planFlags, error)
 		}
 	}
 
+	// The result columns of a CALL statement are the OUT and INOUT parameters of
+	// the procedure, which are not columns of the memo.
+	if _, ok := stmt.AST.(*tree.Call); ok {
+		resultCols = getCallResultColumnsFromMemo(memo)
+	}
+
 	// Fill blank placeholder types with the type hints.
 	p.semaCtx.Placeholders.MaybeExtendTypes()
 
@@ -234,6 +240,18 @@ func (p *planner) makeOptimizerPlan(ctx context.Context) error {
 	defer sp.Finish()
 	p.curPlan.init(&p.stmt, &p.instrumentation)
 
+	if txnState := p.extendedEvalCtx.storedProcTxnState; txnState != nil &&
+		txnState.resumeProc != nil {
+		// A procedure that committed or rolled back the previous transaction is
+		// being resumed. The routine that continues its execution has already
+		// been planned.
+		p.curPlan.main = planMaybePhysical{planNode: &callNode{
+			proc:    txnState.resumeProc,
+			columns: getCallResultColumns(txnState.resumeProc.Typ),
+		}}
+		return nil
+	}
+
 	opc := &p.optPlanningCtx
 	opc.reset(ctx)
 
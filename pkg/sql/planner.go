@@ -104,6 +104,18 @@ type extendedEvalContext struct {
 	// jobs refers to jobs in extraTxnState.
 	jobs *txnJobsCollection
 
+	// notifications refers to notifications in extraTxnState. It is nil for
+	// internal planners.
+	notifications *txnNotifications
+
+	// deferredConstraints refers to deferredConstraints in extraTxnState. It
+	// is nil for internal planners.
+	deferredConstraints *txnDeferredConstraints
+
+	// storedProcTxnState refers to storedProcTxnState in extraTxnState. It is
+	// nil for internal planners.
+	storedProcTxnState *storedProcTxnState
+
 	statsProvider *persistedsqlstats.PersistedSQLStats
 
 	indexUsageStats *idxusage.LocalIndexUsageStats
@@ -230,6 +242,10 @@ type planner struct {
 
 	createdSequences createdSequences
 
+	// routineResultBuffers is a stack with a buffer for the result of each
+	// set-returning PLpgSQL routine that is currently being evaluated.
+	routineResultBuffers []*routineResultBuffer
+
 	// autoCommit indicates whether the plan is allowed (but not required) to
 	// commit the transaction along with other KV operations. Committing the txn
 	// might be beneficial because it may enable the 1PC optimization. Note that
@@ -202,31 +202,31 @@ func (l *lexer) MakeExecSqlStmt() (*plpgsqltree.Execute, error) {
 	}, nil
 }
 
+// MakeDynamicExecuteStmt makes a DynamicExecute node. The INTO and USING
+// clauses may be specified in either order.
 func (l *lexer) MakeDynamicExecuteStmt() (*plpgsqltree.DynamicExecute, error) {
-	cmdStr, _, err := l.ReadSqlStatement(INTO, USING, ';')
+	cmdStr, _, err := l.ReadSqlExpr(INTO, USING, ';')
 	if err != nil {
 		return nil, err
 	}
-	ret := &plpgsqltree.DynamicExecute{
-		Query: cmdStr,
+	query, err := l.ParseExpr(cmdStr)
+	if err != nil {
+		return nil, err
 	}
+	ret := &plpgsqltree.DynamicExecute{Query: query}
 
 	var lval plpgsqlSymType
 	l.Lex(&lval)
 	for {
 		if lval.id == INTO {
-			if ret.Into {
+			if ret.Target != nil {
 				return nil, errors.New("multiple INTO keywords")
 			}
-			ret.Into = true
-			nextTok := l.Peek()
-			if nextTok.id == int32(STRICT) {
+			if l.Peek().id == STRICT {
 				l.Lex(&lval)
 				ret.Strict = true
 			}
-			// TODO we need to read each "INTO" variable name instead of just a
-			// string.
-			_, _, err = l.ReadSqlExpr(USING, ';')
+			ret.Target, err = l.readTargets(USING, INTO, ';')
 			if err != nil {
 				return nil, err
 			}
@@ -235,28 +235,268 @@ func (l *lexer) MakeDynamicExecuteStmt() (*plpgsqltree.DynamicExecute, error) {
 			if ret.Params != nil {
 				return nil, errors.New("multiple USING keywords")
 			}
-			ret.Params = make([]plpgsqltree.Expr, 0)
-			for {
-				_, _, err = l.ReadSqlExpr(',', ';', INTO)
-				if err != nil {
-					return nil, err
-				}
-				ret.Params = append(ret.Params, nil)
-				l.Lex(&lval)
-				if lval.id == ';' {
-					break
-				}
+			ret.Params, err = l.readExprList(INTO, ';')
+			if err != nil {
+				return nil, err
 			}
+			l.Lex(&lval)
 		} else if lval.id == ';' {
 			break
 		} else {
-			return nil, errors.Newf("unexpected token: %s", lval.id)
+			return nil, errors.Newf("unexpected token: %s", lval.str)
 		}
 	}
 
 	return ret, nil
 }
 
+// MakeOpenExecuteStmt makes an Open node for an OPEN ... FOR EXECUTE
+// statement. The EXECUTE keyword has already been consumed.
+func (l *lexer) MakeOpenExecuteStmt(
+	curVar plpgsqltree.Variable, scroll tree.CursorScrollOption,
+) (*plpgsqltree.Open, error) {
+	query, params, err := l.readDynamicQuery(';')
+	if err != nil {
+		return nil, err
+	}
+	// Move past the semicolon.
+	l.lastPos++
+	return &plpgsqltree.Open{
+		CurVar:       curVar,
+		Scroll:       scroll,
+		DynamicQuery: query,
+		Params:       params,
+	}, nil
+}
+
+// MakeReturnQueryStmt makes a ReturnQuery node. The RETURN QUERY keywords
+// have already been consumed.
+func (l *lexer) MakeReturnQueryStmt() (*plpgsqltree.ReturnQuery, error) {
+	if l.parser.Lookahead() != -1 {
+		// Push back the lookahead token so that it can be included.
+		l.PushBack(1)
+	}
+	ret := &plpgsqltree.ReturnQuery{}
+	if l.Peek().id == EXECUTE {
+		// Move past the EXECUTE keyword.
+		l.lastPos++
+		var err error
+		ret.DynamicQuery, ret.Params, err = l.readDynamicQuery(';')
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		sqlStr, _, err := l.ReadSqlStatement(';')
+		if err != nil {
+			return nil, err
+		}
+		sqlStmt, err := parser.ParseOne(sqlStr)
+		if err != nil {
+			return nil, err
+		}
+		if sqlStmt.AST.StatementReturnType() != tree.Rows {
+			return nil, pgerror.New(pgcode.Syntax,
+				"RETURN QUERY must specify a statement that returns rows")
+		}
+		ret.Query = sqlStmt.AST
+	}
+	// Move past the semicolon.
+	l.lastPos++
+	return ret, nil
+}
+
+// MakePerformStmt makes a Perform node. The PERFORM keyword has already been
+// consumed. The remainder of the statement is executed as a SELECT.
+func (l *lexer) MakePerformStmt() (*plpgsqltree.Perform, error) {
+	sqlStr, _, err := l.ReadSqlStatement(';')
+	if err != nil {
+		return nil, err
+	}
+	// Move past the semicolon.
+	l.lastPos++
+	sqlStmt, err := parser.ParseOne("SELECT " + sqlStr)
+	if err != nil {
+		return nil, err
+	}
+	return &plpgsqltree.Perform{Query: sqlStmt.AST}, nil
+}
+
+// MakeForLoopControl reads the control clause of a FOR loop, which follows the
+// IN keyword, and returns the corresponding loop statement without its label
+// and body. It stops before the LOOP keyword. The loop is one of the
+// following forms:
+//
+//	FOR target IN [ REVERSE ] lower .. upper [ BY step ] LOOP
+//	FOR target IN EXECUTE query [ USING expr [, ...] ] LOOP
+//	FOR target IN cursor_var LOOP
+//	FOR target IN query LOOP
+func (l *lexer) MakeForLoopControl(target []plpgsqltree.Variable) (plpgsqltree.Statement, error) {
+	if l.parser.Lookahead() != -1 {
+		// Push back the lookahead token so that it can be included.
+		l.PushBack(1)
+	}
+	if l.Peek().id == EXECUTE {
+		// Move past the EXECUTE keyword.
+		l.lastPos++
+		query, params, err := l.readDynamicQuery(LOOP)
+		if err != nil {
+			return nil, err
+		}
+		return &plpgsqltree.ForDynamic{
+			ForQuery: plpgsqltree.ForQuery{Target: target},
+			Query:    query,
+			Params:   params,
+		}, nil
+	}
+	reverse := false
+	if l.Peek().id == REVERSE {
+		reverse = true
+		l.lastPos++
+	}
+	startPos, endPos, _, err := l.readSQLConstruct(true /* isExpr */, LOOP)
+	if err != nil {
+		return nil, err
+	}
+	// Look for the ".." that indicates an integer FOR loop.
+	dotDotPos, byPos := -1, -1
+	parenLevel := 0
+	for pos := startPos; pos < endPos; pos++ {
+		switch l.tokens[pos].id {
+		case '(', '[':
+			parenLevel++
+		case ')', ']':
+			parenLevel--
+		case DOT_DOT:
+			if parenLevel == 0 && dotDotPos == -1 {
+				dotDotPos = pos
+			}
+		case BY:
+			if parenLevel == 0 && dotDotPos != -1 && byPos == -1 {
+				byPos = pos
+			}
+		}
+	}
+	if dotDotPos != -1 {
+		if len(target) != 1 {
+			return nil, pgerror.New(pgcode.Syntax,
+				"integer FOR loop must have only one target variable")
+		}
+		upperEndPos := endPos
+		if byPos != -1 {
+			upperEndPos = byPos
+		}
+		ret := &plpgsqltree.ForInt{Var: target[0], Reverse: reverse}
+		if ret.Lower, err = l.parseExprBetween(startPos, dotDotPos); err != nil {
+			return nil, err
+		}
+		if ret.Upper, err = l.parseExprBetween(dotDotPos+1, upperEndPos); err != nil {
+			return nil, err
+		}
+		if byPos != -1 {
+			if ret.Step, err = l.parseExprBetween(byPos+1, endPos); err != nil {
+				return nil, err
+			}
+		}
+		return ret, nil
+	}
+	if reverse {
+		return nil, pgerror.New(pgcode.Syntax, "cannot specify REVERSE in query FOR loop")
+	}
+	if endPos-startPos == 1 && l.tokens[startPos].id == IDENT {
+		// This is a loop over the rows of a bound cursor.
+		return &plpgsqltree.ForCursor{
+			ForQuery: plpgsqltree.ForQuery{Target: target},
+			CurVar:   plpgsqltree.Variable(strings.TrimSpace(l.getStr(startPos, endPos))),
+		}, nil
+	}
+	sqlStmt, err := parser.ParseOne(l.getStr(startPos, endPos))
+	if err != nil {
+		return nil, err
+	}
+	return &plpgsqltree.ForSelect{
+		ForQuery: plpgsqltree.ForQuery{Target: target},
+		Query:    sqlStmt.AST,
+	}, nil
+}
+
+// parseExprBetween parses the tokens in the range [startPos, endPos) as a
+// single expression.
+func (l *lexer) parseExprBetween(startPos, endPos int) (plpgsqltree.Expr, error) {
+	if endPos <= startPos {
+		return nil, errors.New("missing expression")
+	}
+	return l.ParseExpr(l.getStr(startPos, endPos))
+}
+
+// readDynamicQuery reads the query string expression of a dynamic SQL command
+// along with the parameters supplied by its optional USING clause. It stops
+// before the given terminator.
+func (l *lexer) readDynamicQuery(
+	terminator int,
+) (query plpgsqltree.Expr, params []plpgsqltree.Expr, err error) {
+	queryStr, terminatorMet, err := l.ReadSqlExpr(terminator, USING)
+	if err != nil {
+		return nil, nil, err
+	}
+	if query, err = l.ParseExpr(queryStr); err != nil {
+		return nil, nil, err
+	}
+	if terminatorMet == USING {
+		// Move past the USING keyword.
+		l.lastPos++
+		if params, err = l.readExprList(terminator); err != nil {
+			return nil, nil, err
+		}
+	}
+	return query, params, nil
+}
+
+// readExprList reads a comma-separated list of expressions, stopping before
+// one of the given terminators.
+func (l *lexer) readExprList(terminator1 int, terminators ...int) ([]plpgsqltree.Expr, error) {
+	var exprs []plpgsqltree.Expr
+	terminators = append(terminators, terminator1)
+	for {
+		exprStr, terminatorMet, err := l.ReadSqlExpr(',', terminators...)
+		if err != nil {
+			return nil, err
+		}
+		expr, err := l.ParseExpr(exprStr)
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, expr)
+		if terminatorMet != ',' {
+			return exprs, nil
+		}
+		// Move past the comma.
+		l.lastPos++
+	}
+}
+
+// readTargets reads a comma-separated list of INTO target variables, stopping
+// before one of the given terminators.
+func (l *lexer) readTargets(
+	terminator1 int, terminators ...int,
+) (target []plpgsqltree.Variable, err error) {
+	startPos, endPos, _, err := l.readSQLConstruct(true /* isExpr */, terminator1, terminators...)
+	if err != nil {
+		return nil, err
+	}
+	for pos := startPos; pos < endPos; pos += 2 {
+		tok := l.tokens[pos]
+		if tok.id != IDENT {
+			return nil, errors.Newf("\"%s\" is not a scalar variable", tok.str)
+		}
+		if pos+1 != endPos && l.tokens[pos+1].id != ',' {
+			return nil, errors.Newf("expected INTO target to be a comma-separated list")
+		}
+		variable := plpgsqltree.Variable(strings.TrimSpace(l.getStr(pos, pos+1)))
+		target = append(target, variable)
+	}
+	return target, nil
+}
+
 func (l *lexer) readSQLConstruct(
 	isExpr bool, terminator1 int, terminators ...int,
 ) (startPos, endPos, terminatorMet int, err error) {
@@ -341,21 +581,10 @@ func (l *lexer) MakeFetchOrMoveStmt(isMove bool) (plpgsqltree.Statement, error)
 		}
 		// Read past the INTO.
 		l.lastPos++
-		startPos, endPos, _, err := l.readSQLConstruct(true /* isExpr */, ';')
+		target, err = l.readTargets(';')
 		if err != nil {
 			return nil, err
 		}
-		for pos := startPos; pos < endPos; pos += 2 {
-			tok := l.tokens[pos]
-			if tok.id != IDENT {
-				return nil, errors.Newf("\"%s\" is not a scalar variable", tok.str)
-			}
-			if pos+1 != endPos && l.tokens[pos+1].id != ',' {
-				return nil, errors.Newf("expected INTO target to be a comma-separated list")
-			}
-			variable := plpgsqltree.Variable(strings.TrimSpace(l.getStr(pos, pos+1)))
-			target = append(target, variable)
-		}
 		if len(target) == 0 {
 			return nil, errors.Newf("expected INTO target")
 		}
@@ -379,6 +608,23 @@ func (l *lexer) ReadSqlExpr(
 	return l.getStr(startPos, endPos), terminatorMet, err
 }
 
+// ReadReturnExpr reads the optional expression of a RETURN statement. It
+// returns nil if the statement has no expression, as in "RETURN;".
+func (l *lexer) ReadReturnExpr() (plpgsqltree.Expr, error) {
+	if l.parser.Lookahead() != -1 {
+		// Push back the lookahead token so that it can be included.
+		l.PushBack(1)
+	}
+	if l.Peek().id == ';' {
+		return nil, nil
+	}
+	sqlStr, _, err := l.ReadSqlExpr(';')
+	if err != nil {
+		return nil, err
+	}
+	return l.ParseExpr(sqlStr)
+}
+
 func (l *lexer) ReadSqlStatement(
 	terminator1 int, terminators ...int,
 ) (sqlStr string, terminatorMet int, err error) {
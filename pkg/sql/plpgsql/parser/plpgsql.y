@@ -167,6 +167,10 @@ func (u *plpgsqlSymUnion) sqlStatement() tree.Statement {
     return u.val.(tree.Statement)
 }
 
+func (u *plpgsqlSymUnion) variables() []plpgsqltree.Variable {
+    return u.val.([]plpgsqltree.Variable)
+}
+
 %}
 /*
  * Basic non-keyword token types.  These are hard-wired into the core lexer.
@@ -311,7 +315,7 @@ func (u *plpgsqlSymUnion) sqlStatement() tree.Statement {
   union plpgsqlSymUnion
 }
 
-%type <str> decl_varname decl_defkey
+%type <str> decl_varname decl_defkey decl_aliasitem
 %type <bool>	decl_const decl_notnull
 %type <plpgsqltree.Expr>	decl_defval decl_cursor_query
 %type <tree.ResolvableTypeReference>	decl_datatype
@@ -321,12 +325,12 @@ func (u *plpgsqlSymUnion) sqlStatement() tree.Statement {
 %type <str>	expr_until_then expr_until_loop opt_expr_until_when
 %type <plpgsqltree.Expr>	opt_exitcond
 
-%type <forvariable>	for_variable
+%type <[]plpgsqltree.Variable>	for_variable
 %type <plpgsqltree.Expr>	return_variable
-%type <*tree.NumVal>	foreach_slice
+%type <int32>	foreach_slice
 %type <plpgsqltree.Statement>	for_control
 
-%type <str> any_identifier opt_block_label opt_loop_label opt_label query_options
+%type <str> any_identifier opt_block_label opt_loop_label opt_label
 %type <str> opt_error_level option_type
 
 %type <[]plpgsqltree.Statement> proc_sect
@@ -354,6 +358,7 @@ func (u *plpgsqlSymUnion) sqlStatement() tree.Statement {
 %type <[]plpgsqltree.Statement> opt_case_else
 
 %type <bool>	getdiag_area_opt
+%type <bool>	opt_transaction_chain
 %type <plpgsqltree.GetDiagnosticsItemList>	getdiag_list // TODO don't know what this is
 %type <*plpgsqltree.GetDiagnosticsItem> getdiag_list_item // TODO don't know what this is
 %type <int32> getdiag_item
@@ -365,7 +370,6 @@ func (u *plpgsqlSymUnion) sqlStatement() tree.Statement {
 
 %type <tree.CursorScrollOption>	opt_scrollable
 
-%type <*tree.NumVal>	opt_transaction_chain
 
 %type <str>	unreserved_keyword
 %%
@@ -455,7 +459,10 @@ decl_statement: decl_varname decl_const decl_datatype decl_collate decl_notnull
   }
 | decl_varname ALIAS FOR decl_aliasitem ';'
   {
-    return unimplemented(plpgsqllex, "alias for")
+    $$.val = &plpgsqltree.AliasDeclaration{
+      Name: plpgsqltree.Variable($1),
+      Target: $4,
+    }
   }
 | decl_varname opt_scrollable CURSOR decl_cursor_args decl_is_for decl_cursor_query
   {
@@ -521,10 +528,10 @@ decl_is_for:
 | FOR  /* SQL standard */
 
 decl_aliasitem: IDENT
-  {
-  }
 | unreserved_keyword
+| '$' ICONST
   {
+    $$ = "$" + $2.numVal().String()
   }
 ;
 
@@ -723,9 +730,13 @@ proc_stmt:pl_block ';'
   { }
 ;
 
-stmt_perform: PERFORM stmt_until_semi ';'
+stmt_perform: PERFORM
   {
-    return unimplemented(plpgsqllex, "perform")
+    stmt, err := plpgsqllex.(*lexer).MakePerformStmt()
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = stmt
   }
 ;
 
@@ -759,6 +770,18 @@ stmt_assign: IDENT assign_operator expr_until_semi ';'
       Value: expr,
     }
   }
+| IDENT '.' IDENT assign_operator expr_until_semi ';'
+  {
+    expr, err := plpgsqllex.(*lexer).ParseExpr($5)
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = &plpgsqltree.Assignment{
+      Var: plpgsqltree.Variable($1),
+      Field: tree.Name($3),
+      Value: expr,
+    }
+  }
 ;
 
 stmt_getdiag: GET getdiag_area_opt DIAGNOSTICS getdiag_list ';'
@@ -977,16 +1000,31 @@ stmt_while: opt_loop_label WHILE expr_until_loop LOOP loop_body opt_label ';'
   }
 ;
 
-stmt_for: opt_loop_label FOR for_control loop_body
+stmt_for: opt_loop_label FOR for_control LOOP loop_body opt_label ';'
   {
-    return unimplemented(plpgsqllex, "for loop")
+    // TODO(drewk): does the second usage of the label actually
+    // do anything?
+    switch t := $3.statement().(type) {
+    case *plpgsqltree.ForInt:
+      t.Label, t.Body = $1, $5.statements()
+    case *plpgsqltree.ForSelect:
+      t.Label, t.Body = $1, $5.statements()
+    case *plpgsqltree.ForCursor:
+      t.Label, t.Body = $1, $5.statements()
+    case *plpgsqltree.ForDynamic:
+      t.Label, t.Body = $1, $5.statements()
+    }
+    $$.val = $3.statement()
   }
 ;
 
 for_control: for_variable IN
-  // TODO need to parse the sql expression here.
   {
-    return unimplemented(plpgsqllex, "for loop")
+    stmt, err := plpgsqllex.(*lexer).MakeForLoopControl($1.variables())
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = stmt
   }
 ;
 
@@ -995,36 +1033,53 @@ for_control: for_variable IN
  * FOR is an integer FOR loop or a loop over query results.  In the former
  * case, the variable is just a name that we must instantiate as a loop
  * local variable, regardless of any other definition it might have.
- * Therefore, we always save the actual identifier into $$.name where it
- * can be used for that case.  We also save the outer-variable definition,
- * if any, because that's what we need for the loop-over-query case.  Note
- * that we must NOT apply check_assignable() or any other semantic check
- * until we know what's what.
+ * Therefore, we only record the names here; whether they refer to existing
+ * variables is determined when the routine body is built.
  *
- * However, if we see a comma-separated list of names, we know that it
- * can't be an integer FOR loop and so it's OK to check the variables
- * immediately.  In particular, for T_WORD followed by comma, we should
- * complain that the name is not known rather than say it's a syntax error.
- * Note that the non-error result of this case sets *both* $$.scalar and
- * $$.row; see the for_control production.
+ * If we see a comma-separated list of names, we know that it can't be an
+ * integer FOR loop; MakeForLoopControl reports an error in that case.
  */
 for_variable: any_identifier
   {
-    return unimplemented(plpgsqllex, "for loop")
+    $$.val = []plpgsqltree.Variable{plpgsqltree.Variable($1)}
+  }
+| for_variable ',' any_identifier
+  {
+    $$.val = append($1.variables(), plpgsqltree.Variable($3))
   }
 ;
 
-stmt_foreach_a: opt_loop_label FOREACH for_variable foreach_slice IN ARRAY expr_until_loop loop_body
+stmt_foreach_a: opt_loop_label FOREACH for_variable foreach_slice IN ARRAY expr_until_loop LOOP loop_body opt_label ';'
   {
-    return unimplemented(plpgsqllex, "for each loop")
+    vars := $3.variables()
+    if len(vars) != 1 {
+      return unimplemented(plpgsqllex, "foreach loop with multiple targets")
+    }
+    expr, err := plpgsqllex.(*lexer).ParseExpr($7)
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = &plpgsqltree.ForEachArray{
+      Label: $1,
+      Var: vars[0],
+      Slice: int($4.int32()),
+      Expr: expr,
+      Body: $9.statements(),
+    }
   }
 ;
 
 foreach_slice:
   {
+    $$.val = int32(0)
   }
 | SLICE ICONST
   {
+    slice, err := $2.numVal().AsInt32()
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = slice
   }
 ;
 
@@ -1058,37 +1113,25 @@ stmt_return: RETURN return_variable ';'
       Expr: $2.expr(),
     }
   }
-| RETURN_NEXT NEXT
+| RETURN_NEXT NEXT return_variable ';'
   {
-    return unimplemented(plpgsqllex, "return next")
+    $$.val = &plpgsqltree.ReturnNext{
+      Expr: $3.expr(),
+    }
   }
 | RETURN_QUERY QUERY
- {
-   return unimplemented (plpgsqllex, "return query")
- }
-;
-
-
-query_options:
   {
-    _, terminator, err := plpgsqllex.(*lexer).ReadSqlExpr(EXECUTE, ';')
-    if err != nil {
-      return setErr(plpgsqllex, err)
-    }
-    if terminator == EXECUTE {
-      return unimplemented (plpgsqllex, "return dynamic sql query")
-    }
-    _, _, err = plpgsqllex.(*lexer).ReadSqlExpr(';')
+    stmt, err := plpgsqllex.(*lexer).MakeReturnQueryStmt()
     if err != nil {
       return setErr(plpgsqllex, err)
     }
+    $$.val = stmt
   }
 ;
 
-
-return_variable: expr_until_semi
+return_variable:
   {
-    expr, err := plpgsqllex.(*lexer).ParseExpr($1)
+    expr, err := plpgsqllex.(*lexer).ReadReturnExpr()
     if err != nil {
       return setErr(plpgsqllex, err)
     }
@@ -1299,9 +1342,15 @@ stmt_open: OPEN IDENT ';'
   {
     $$.val = &plpgsqltree.Open{CurVar: plpgsqltree.Variable($2)}
   }
-| OPEN IDENT opt_scrollable FOR EXECUTE 
+| OPEN IDENT opt_scrollable FOR EXECUTE
   {
-    return unimplemented(plpgsqllex, "cursor for execute")
+    open, err := plpgsqllex.(*lexer).MakeOpenExecuteStmt(
+      plpgsqltree.Variable($2), $3.cursorScrollOption(),
+    )
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = open
   }
 | OPEN IDENT opt_scrollable FOR stmt_until_semi ';'
   {
@@ -1354,23 +1403,29 @@ stmt_null: NULL ';'
 
 stmt_commit: COMMIT opt_transaction_chain ';'
   {
-    return unimplemented(plpgsqllex, "commit")
+    $$.val = &plpgsqltree.Commit{Chain: $2.bool()}
   }
 ;
 
 stmt_rollback: ROLLBACK opt_transaction_chain ';'
   {
-    return unimplemented(plpgsqllex, "rollback")
+    $$.val = &plpgsqltree.Rollback{Chain: $2.bool()}
   }
 ;
 
 opt_transaction_chain:
 AND CHAIN
-  { }
+  {
+    $$.val = true
+  }
 | AND NO CHAIN
-  { }
+  {
+    $$.val = false
+  }
 | /* EMPTY */
-  { }
+  {
+    $$.val = false
+  }
 
 exception_sect: /* EMPTY */
   {
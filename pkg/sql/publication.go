@@ -0,0 +1,422 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/paramparse"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// publication is a publication of a database, stored in the
+// system.publications table.
+type publication struct {
+	dbID            descpb.ID
+	name            string
+	owner           username.SQLUsername
+	allTables       bool
+	tableIDs        []descpb.ID
+	publishInsert   bool
+	publishUpdate   bool
+	publishDelete   bool
+	publishTruncate bool
+}
+
+const publicationColumns = `database_id, name, owner, all_tables, table_ids,
+publish_insert, publish_update, publish_delete, publish_truncate`
+
+// makePublication decodes a row of system.publications selected with
+// publicationColumns.
+func makePublication(row tree.Datums) publication {
+	pub := publication{
+		dbID:            descpb.ID(tree.MustBeDInt(row[0])),
+		name:            string(tree.MustBeDString(row[1])),
+		owner:           username.MakeSQLUsernameFromPreNormalizedString(string(tree.MustBeDString(row[2]))),
+		allTables:       bool(tree.MustBeDBool(row[3])),
+		publishInsert:   bool(tree.MustBeDBool(row[5])),
+		publishUpdate:   bool(tree.MustBeDBool(row[6])),
+		publishDelete:   bool(tree.MustBeDBool(row[7])),
+		publishTruncate: bool(tree.MustBeDBool(row[8])),
+	}
+	for _, d := range tree.MustBeDArray(row[4]).Array {
+		pub.tableIDs = append(pub.tableIDs, descpb.ID(tree.MustBeDInt(d)))
+	}
+	return pub
+}
+
+// getPublications returns the publications of the given database, or of all
+// the databases if dbID is descpb.InvalidID, ordered by database and name.
+func getPublications(
+	ctx context.Context, txn isql.Txn, version clusterversion.Handle, dbID descpb.ID,
+) ([]publication, error) {
+	// The system.publications table only exists once the cluster is upgraded,
+	// before which there cannot be any publications.
+	if !version.IsActive(ctx, clusterversion.V24_1_AddSystemPublicationsTable) {
+		return nil, nil
+	}
+	query := `SELECT ` + publicationColumns + ` FROM system.publications`
+	var args []interface{}
+	if dbID != descpb.InvalidID {
+		query += ` WHERE database_id = $1`
+		args = append(args, dbID)
+	}
+	query += ` ORDER BY database_id, name`
+	rows, err := txn.QueryBufferedEx(
+		ctx, "get-publications", txn.KV(), sessiondata.NodeUserSessionDataOverride, query, args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	pubs := make([]publication, len(rows))
+	for i, row := range rows {
+		pubs[i] = makePublication(row)
+	}
+	return pubs, nil
+}
+
+// getPublicationsByDatabase returns the publications of all databases, keyed
+// by the ID of the database they belong to.
+func (p *planner) getPublicationsByDatabase(
+	ctx context.Context,
+) (map[descpb.ID][]publication, error) {
+	pubs, err := getPublications(ctx, p.InternalSQLTxn(), p.ExecCfg().Settings.Version, descpb.InvalidID)
+	if err != nil {
+		return nil, err
+	}
+	pubsByDB := make(map[descpb.ID][]publication)
+	for _, pub := range pubs {
+		pubsByDB[pub.dbID] = append(pubsByDB[pub.dbID], pub)
+	}
+	return pubsByDB, nil
+}
+
+// getPublication returns the publication with the given name in the given
+// database, or nil if there is none.
+func getPublication(
+	ctx context.Context, txn isql.Txn, version clusterversion.Handle, dbID descpb.ID, name string,
+) (*publication, error) {
+	if !version.IsActive(ctx, clusterversion.V24_1_AddSystemPublicationsTable) {
+		return nil, nil
+	}
+	row, err := txn.QueryRowEx(
+		ctx, "get-publication", txn.KV(), sessiondata.NodeUserSessionDataOverride,
+		`SELECT `+publicationColumns+` FROM system.publications WHERE database_id = $1 AND name = $2`,
+		dbID, name,
+	)
+	if err != nil || row == nil {
+		return nil, err
+	}
+	pub := makePublication(row)
+	return &pub, nil
+}
+
+// checkPublicationsSupported returns an error if the cluster is not upgraded
+// to a version supporting publications.
+func (p *planner) checkPublicationsSupported(ctx context.Context) error {
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V24_1_AddSystemPublicationsTable) {
+		return pgerror.New(pgcode.FeatureNotSupported,
+			"publications are not supported until version 24.1")
+	}
+	return nil
+}
+
+type createPublicationNode struct {
+	n   *tree.CreatePublication
+	pub publication
+}
+
+// CreatePublication creates a publication in the current database.
+// Privileges: CREATE on the database and ownership of the published tables.
+// Publications FOR ALL TABLES require the admin role.
+//
+//	notes: postgres requires CREATE on the database and ownership of the
+//	       tables, and superuser for FOR ALL TABLES.
+func (p *planner) CreatePublication(
+	ctx context.Context, n *tree.CreatePublication,
+) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE PUBLICATION",
+	); err != nil {
+		return nil, err
+	}
+	if err := p.checkPublicationsSupported(ctx); err != nil {
+		return nil, err
+	}
+
+	dbDesc, err := p.Descriptors().ByNameWithLeased(p.txn).Get().Database(ctx, p.CurrentDatabase())
+	if err != nil {
+		return nil, err
+	}
+	if err := p.CheckPrivilege(ctx, dbDesc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+	if existing, err := getPublication(
+		ctx, p.InternalSQLTxn(), p.ExecCfg().Settings.Version, dbDesc.GetID(), string(n.Name),
+	); err != nil {
+		return nil, err
+	} else if existing != nil {
+		return nil, pgerror.Newf(pgcode.DuplicateObject,
+			"publication %q already exists", n.Name)
+	}
+
+	pub := publication{
+		dbID:            dbDesc.GetID(),
+		name:            string(n.Name),
+		owner:           p.User(),
+		allTables:       n.AllTables,
+		publishInsert:   true,
+		publishUpdate:   true,
+		publishDelete:   true,
+		publishTruncate: true,
+	}
+	if err := p.setPublicationOptions(ctx, &pub, n.Options); err != nil {
+		return nil, err
+	}
+
+	if n.AllTables {
+		if hasAdmin, err := p.HasAdminRole(ctx); err != nil {
+			return nil, err
+		} else if !hasAdmin {
+			return nil, pgerror.Newf(pgcode.InsufficientPrivilege,
+				"only users with the admin role are allowed to CREATE PUBLICATION FOR ALL TABLES")
+		}
+	}
+	var tableIDs catalog.DescriptorIDSet
+	for i := range n.Tables {
+		tn := &n.Tables[i]
+		tableDesc, err := p.ResolveExistingObjectEx(
+			ctx, tn.ToUnresolvedObjectName(), true /* required */, tree.ResolveRequireTableDesc,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if !tableDesc.IsTable() || tableDesc.IsVirtualTable() {
+			return nil, pgerror.Newf(pgcode.WrongObjectType,
+				"%q is not a table", tn.Object())
+		}
+		if tableDesc.IsTemporary() {
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+				"cannot add temporary table %q to publication", tn.Object())
+		}
+		if tableDesc.GetParentID() != dbDesc.GetID() {
+			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"cannot add table %q from another database to publication %q", tn.Object(), n.Name)
+		}
+		if hasOwnership, err := p.HasOwnership(ctx, tableDesc); err != nil {
+			return nil, err
+		} else if !hasOwnership {
+			return nil, pgerror.Newf(pgcode.InsufficientPrivilege,
+				"must be owner of table %s", tree.Name(tableDesc.GetName()))
+		}
+		// Like postgres, tables listed more than once are only added once.
+		if !tableIDs.Contains(tableDesc.GetID()) {
+			tableIDs.Add(tableDesc.GetID())
+			pub.tableIDs = append(pub.tableIDs, tableDesc.GetID())
+		}
+	}
+
+	return &createPublicationNode{n: n, pub: pub}, nil
+}
+
+// setPublicationOptions applies the WITH options of CREATE PUBLICATION.
+func (p *planner) setPublicationOptions(
+	ctx context.Context, pub *publication, options tree.StorageParams,
+) error {
+	for _, option := range options {
+		key := string(option.Key)
+		if key != "publish" {
+			return pgerror.Newf(pgcode.Syntax,
+				"unrecognized publication parameter: %q", key)
+		}
+		if option.Value == nil {
+			return pgerror.Newf(pgcode.InvalidParameterValue,
+				"publication parameter %q requires a value", key)
+		}
+		typedExpr, err := tree.TypeCheckAndRequire(
+			ctx, paramparse.UnresolvedNameToStrVal(option.Value), &p.semaCtx, types.String, key,
+		)
+		if err != nil {
+			return err
+		}
+		value, err := paramparse.DatumAsString(ctx, p.EvalContext(), key, typedExpr)
+		if err != nil {
+			return err
+		}
+		pub.publishInsert, pub.publishUpdate = false, false
+		pub.publishDelete, pub.publishTruncate = false, false
+		for _, op := range strings.Split(value, ",") {
+			switch strings.ToLower(strings.TrimSpace(op)) {
+			case "insert":
+				pub.publishInsert = true
+			case "update":
+				pub.publishUpdate = true
+			case "delete":
+				pub.publishDelete = true
+			case "truncate":
+				pub.publishTruncate = true
+			case "":
+			default:
+				return pgerror.Newf(pgcode.InvalidParameterValue,
+					"unrecognized %q value: %q", key, strings.TrimSpace(op))
+			}
+		}
+	}
+	return nil
+}
+
+func (n *createPublicationNode) startExec(params runParams) error {
+	txn := params.p.InternalSQLTxn()
+	row, err := txn.QueryRowEx(params.ctx, "get-user-id", txn.KV(),
+		sessiondata.NodeUserSessionDataOverride,
+		`SELECT user_id FROM system.users WHERE username = $1`,
+		n.pub.owner,
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to get owner ID for publication")
+	}
+	if row == nil {
+		return errors.AssertionFailedf("user %s does not exist", n.pub.owner)
+	}
+	tableIDs := tree.NewDArray(types.Int)
+	for _, id := range n.pub.tableIDs {
+		if err := tableIDs.Append(tree.NewDInt(tree.DInt(id))); err != nil {
+			return err
+		}
+	}
+	_, err = txn.ExecEx(params.ctx, "create-publication", txn.KV(),
+		sessiondata.NodeUserSessionDataOverride,
+		`INSERT INTO system.publications (
+  database_id, name, owner, owner_id, all_tables, table_ids,
+  publish_insert, publish_update, publish_delete, publish_truncate
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		n.pub.dbID, n.pub.name, n.pub.owner.Normalized(), row[0], n.pub.allTables, tableIDs,
+		n.pub.publishInsert, n.pub.publishUpdate, n.pub.publishDelete, n.pub.publishTruncate,
+	)
+	return err
+}
+
+func (n *createPublicationNode) Next(runParams) (bool, error) { return false, nil }
+func (n *createPublicationNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *createPublicationNode) Close(context.Context)        {}
+
+// publicationIncludesTable returns whether the changes of the given table
+// are published by the publication.
+func publicationIncludesTable(pub *publication, table catalog.TableDescriptor) bool {
+	if !table.IsTable() || table.IsVirtualTable() || table.IsTemporary() || table.Dropped() {
+		return false
+	}
+	if table.GetParentID() != pub.dbID {
+		return false
+	}
+	if pub.allTables {
+		return true
+	}
+	for _, id := range pub.tableIDs {
+		if id == table.GetID() {
+			return true
+		}
+	}
+	return false
+}
+
+type dropPublicationNode struct {
+	n     *tree.DropPublication
+	dbID  descpb.ID
+	names []string
+}
+
+// DropPublication drops publications of the current database.
+// Privileges: ownership of the publications.
+//
+//	notes: postgres requires ownership of the publications.
+func (p *planner) DropPublication(ctx context.Context, n *tree.DropPublication) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"DROP PUBLICATION",
+	); err != nil {
+		return nil, err
+	}
+	if err := p.checkPublicationsSupported(ctx); err != nil {
+		return nil, err
+	}
+
+	dbDesc, err := p.Descriptors().ByNameWithLeased(p.txn).Get().Database(ctx, p.CurrentDatabase())
+	if err != nil {
+		return nil, err
+	}
+	hasAdmin, err := p.HasAdminRole(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, name := range n.Names {
+		pub, err := getPublication(
+			ctx, p.InternalSQLTxn(), p.ExecCfg().Settings.Version, dbDesc.GetID(), string(name),
+		)
+		if err != nil {
+			return nil, err
+		}
+		if pub == nil {
+			if n.IfExists {
+				continue
+			}
+			return nil, pgerror.Newf(pgcode.UndefinedObject,
+				"publication %q does not exist", name)
+		}
+		if !hasAdmin && pub.owner != p.User() {
+			memberOf, err := p.MemberOfWithAdminOption(ctx, p.User())
+			if err != nil {
+				return nil, err
+			}
+			if _, found := memberOf[pub.owner]; !found {
+				return nil, pgerror.Newf(pgcode.InsufficientPrivilege,
+					"must be owner of publication %s", name)
+			}
+		}
+		names = append(names, string(name))
+	}
+
+	return &dropPublicationNode{n: n, dbID: dbDesc.GetID(), names: names}, nil
+}
+
+func (n *dropPublicationNode) startExec(params runParams) error {
+	txn := params.p.InternalSQLTxn()
+	for _, name := range n.names {
+		if _, err := txn.ExecEx(params.ctx, "drop-publication", txn.KV(),
+			sessiondata.NodeUserSessionDataOverride,
+			`DELETE FROM system.publications WHERE database_id = $1 AND name = $2`,
+			n.dbID, name,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *dropPublicationNode) Next(runParams) (bool, error) { return false, nil }
+func (n *dropPublicationNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *dropPublicationNode) Close(context.Context)        {}
@@ -195,6 +195,18 @@ func RandDatumWithNullChance(
 			return nil
 		}
 		return &tree.DJSON{JSON: j}
+	case types.JsonpathFamily:
+		return randJsonpath(rng)
+	case types.GeometricFamily:
+		return randGeometric(rng, typ)
+	case types.RangeFamily:
+		return randRange(rng, typ, func(subtype *types.T) tree.Datum {
+			return RandDatumWithNullChance(rng, subtype, 0 /* nullChance */, favorCommonData, false /* targetColumnIsUnique */)
+		})
+	case types.MultirangeFamily:
+		return randMultirange(rng, typ, func(subtype *types.T) tree.Datum {
+			return RandDatumWithNullChance(rng, subtype, 0 /* nullChance */, favorCommonData, false /* targetColumnIsUnique */)
+		})
 	case types.TupleFamily:
 		tuple := tree.DTuple{D: make(tree.Datums, len(typ.TupleContents()))}
 		if nullChance == 0 {
@@ -467,6 +479,18 @@ func RandDatumSimple(rng *rand.Rand, typ *types.T) tree.Datum {
 		})
 	case types.JsonFamily:
 		datum = tree.NewDJSON(randJSONSimple(rng))
+	case types.JsonpathFamily:
+		datum = randJsonpath(rng)
+	case types.GeometricFamily:
+		datum = randGeometric(rng, typ)
+	case types.RangeFamily:
+		datum = randRange(rng, typ, func(subtype *types.T) tree.Datum {
+			return RandDatumSimple(rng, subtype)
+		})
+	case types.MultirangeFamily:
+		datum = randMultirange(rng, typ, func(subtype *types.T) tree.Datum {
+			return RandDatumSimple(rng, subtype)
+		})
 	case types.OidFamily:
 		datum = tree.NewDOid(oid.Oid(rng.Intn(simpleRange)))
 	case types.StringFamily:
@@ -493,6 +517,90 @@ func randStringSimple(rng *rand.Rand) string {
 	return string(rune('A' + rng.Intn(simpleRange)))
 }
 
+// randJsonpaths is a set of jsonpath expressions that randJsonpath picks
+// from.
+var randJsonpaths = []string{
+	`$`,
+	`strict $.a`,
+	`$.a[*].b`,
+	`$.** ? (@ > 1)`,
+	`$[last - 1]`,
+	`$.a.size() + 1`,
+	`$ ? (@.a like_regex "^b" flag "i")`,
+	`$.datetime()`,
+}
+
+func randJsonpath(rng *rand.Rand) tree.Datum {
+	d, err := tree.ParseDJsonpath(randJsonpaths[rng.Intn(len(randJsonpaths))])
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// randGeometrics contains, for each geometric type, a set of values that
+// randGeometric picks from.
+var randGeometrics = map[oid.Oid][]string{
+	oid.T_point:   {`(0,0)`, `(1,2)`, `(-3.5,1e+20)`, `(NaN,1)`},
+	oid.T_lseg:    {`[(0,0),(1,1)]`, `[(1,2),(1,2)]`, `[(-1,5),(3,-2)]`},
+	oid.T_line:    {`{1,-1,0}`, `{0,1,-3}`, `{2,0,1}`},
+	oid.T_box:     {`(1,1),(0,0)`, `(3,4),(3,4)`, `(0,0),(-2,-5)`},
+	oid.T_path:    {`[(0,0),(1,1)]`, `((0,0),(1,1),(2,0))`, `[(1,2),(3,4),(5,6),(7,8)]`},
+	oid.T_polygon: {`((0,0))`, `((0,0),(0,1),(1,1),(1,0))`, `((-1,0),(0,3),(2,-1))`},
+	oid.T_circle:  {`<(0,0),1>`, `<(1,2),0>`, `<(-5,3),2.5>`},
+}
+
+func randGeometric(rng *rand.Rand, typ *types.T) tree.Datum {
+	vals := randGeometrics[typ.Oid()]
+	d, err := tree.ParseDGeometric(typ, vals[rng.Intn(len(vals))])
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// randRange returns a random range of type typ, with bounds generated by
+// randBound. Invalid ranges, which can be generated when the bounds are out of
+// order or are not valid range bounds (such as NaN), are replaced with empty
+// ranges.
+func randRange(rng *rand.Rand, typ *types.T, randBound func(*types.T) tree.Datum) *tree.DRange {
+	if rng.Intn(10) == 0 {
+		return tree.NewEmptyDRange(typ)
+	}
+	var lower, upper tree.RangeBound
+	if rng.Intn(5) != 0 {
+		lower = tree.RangeBound{Val: randBound(typ.RangeContents()), Inclusive: rng.Intn(2) == 0}
+	}
+	if rng.Intn(5) != 0 {
+		upper = tree.RangeBound{Val: randBound(typ.RangeContents()), Inclusive: rng.Intn(2) == 0}
+	}
+	ctx := tree.RangeCompareContext()
+	if !lower.IsInfinite() && !upper.IsInfinite() && lower.Val.Compare(ctx, upper.Val) > 0 {
+		lower.Val, upper.Val = upper.Val, lower.Val
+	}
+	r, err := tree.NewDRange(ctx, typ, lower, upper)
+	if err != nil {
+		return tree.NewEmptyDRange(typ)
+	}
+	return r
+}
+
+// randMultirange returns a random multirange of type typ, made of up to three
+// random ranges.
+func randMultirange(
+	rng *rand.Rand, typ *types.T, randBound func(*types.T) tree.Datum,
+) *tree.DMultirange {
+	ranges := make([]*tree.DRange, rng.Intn(4))
+	for i := range ranges {
+		ranges[i] = randRange(rng, typ.RangeContents(), randBound)
+	}
+	d, err := tree.NewDMultirange(tree.RangeCompareContext(), typ, ranges)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
 func randJSONSimple(rng *rand.Rand) json.JSON {
 	return randJSONSimpleDepth(rng, 0)
 }
@@ -17,6 +17,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/norm"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/optbuilder"
@@ -32,6 +33,7 @@ type referenceProvider struct {
 	referencedSequences catalog.DescriptorIDSet
 	referencedTypes     catalog.DescriptorIDSet
 	allRelationIDs      catalog.DescriptorIDSet
+	textSearchDeps      opt.SchemaTextSearchDeps
 }
 
 func newReferenceProvider() *referenceProvider {
@@ -92,6 +94,11 @@ func (r *referenceProvider) ReferencedTypes() catalog.DescriptorIDSet {
 	return r.referencedTypes
 }
 
+// ReferencesTextSearchObjects implements scbuildstmt.ReferenceProvider
+func (r *referenceProvider) ReferencesTextSearchObjects() bool {
+	return len(r.textSearchDeps) > 0
+}
+
 type referenceProviderFactory struct {
 	p *planner
 }
@@ -116,6 +123,7 @@ func (f *referenceProviderFactory) NewReferenceProvider(
 	}
 
 	ret := newReferenceProvider()
+	ret.textSearchDeps = createFnExpr.TextSearchDeps
 
 	for descID, refs := range tableReferences {
 		ret.allRelationIDs.Add(descID)
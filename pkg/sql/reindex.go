@@ -0,0 +1,32 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/errors"
+)
+
+// Reindex is only implemented in the declarative schema changer, which
+// rebuilds the indexes online. This is reached when the statement could not be
+// planned by it.
+func (p *planner) Reindex(ctx context.Context, n *tree.Reindex) (planNode, error) {
+	return nil, errors.WithHint(
+		pgerror.Newf(pgcode.FeatureNotSupported,
+			"%s is only supported by the declarative schema changer", n.StatementTag()),
+		"REINDEX cannot be used in an explicit transaction or when "+
+			"use_declarative_schema_changer is set to off",
+	)
+}
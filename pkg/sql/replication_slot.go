@@ -0,0 +1,478 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobsprotectedts"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/protectedts"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/protectedts/ptpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsnutil"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgrepltree"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/cockroachdb/errors"
+)
+
+// pgoutputPlugin is the only logical decoding output plugin supported.
+const pgoutputPlugin = "pgoutput"
+
+// maxReplicationSlotNameLen is the maximum length of the name of a
+// replication slot, which is the maximum length of identifiers in postgres.
+const maxReplicationSlotNameLen = 63
+
+// replicationSlotCheckInterval is the interval at which the job of a
+// replication slot checks whether the database of the slot still exists.
+const replicationSlotCheckInterval = time.Minute
+
+// replicationSlot is a logical replication slot. Every slot is backed by a
+// job of type jobspb.TypeReplicationSlot, which exists until the slot is
+// dropped and owns a protected timestamp record on the database of the slot.
+// The record is advanced along with the position confirmed by the client, so
+// that streaming can resume from it after the client reconnects.
+type replicationSlot struct {
+	jobID    jobspb.JobID
+	details  jobspb.ReplicationSlotDetails
+	progress jobspb.ReplicationSlotProgress
+}
+
+// getReplicationSlots returns the replication slots of all the databases,
+// ordered by creation.
+func getReplicationSlots(
+	ctx context.Context, txn isql.Txn, registry *jobs.Registry,
+) ([]replicationSlot, error) {
+	// Slots whose cancellation was requested are being dropped.
+	rows, err := txn.QueryBufferedEx(
+		ctx, "get-replication-slots", txn.KV(), sessiondata.NodeUserSessionDataOverride,
+		fmt.Sprintf(`SELECT id FROM system.jobs WHERE job_type = $1 AND status IN ('%s', '%s', '%s', '%s') ORDER BY created`,
+			jobs.StatusPending, jobs.StatusRunning, jobs.StatusPauseRequested, jobs.StatusPaused),
+		jobspb.TypeReplicationSlot.String(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	slots := make([]replicationSlot, 0, len(rows))
+	for _, row := range rows {
+		j, err := registry.LoadJobWithTxn(ctx, jobspb.JobID(tree.MustBeDInt(row[0])), txn)
+		if err != nil {
+			return nil, err
+		}
+		slots = append(slots, replicationSlot{
+			jobID:    j.ID(),
+			details:  j.Details().(jobspb.ReplicationSlotDetails),
+			progress: *j.Progress().GetReplicationSlot(),
+		})
+	}
+	return slots, nil
+}
+
+// getReplicationSlot returns the replication slot with the given name, or nil
+// if there is none. Like in postgres, the names of the slots are unique
+// across databases.
+func getReplicationSlot(
+	ctx context.Context, txn isql.Txn, registry *jobs.Registry, name string,
+) (*replicationSlot, error) {
+	slots, err := getReplicationSlots(ctx, txn, registry)
+	if err != nil {
+		return nil, err
+	}
+	for i := range slots {
+		if slots[i].details.SlotName == name {
+			return &slots[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// checkReplicationSlotDatabase returns an error if the logical replication
+// slot was created in another database than the given one.
+func checkReplicationSlotDatabase(slot *replicationSlot, dbID descpb.ID) error {
+	if slot.details.DatabaseID != dbID {
+		return pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+			"replication slot %q was not created in this database", slot.details.SlotName)
+	}
+	return nil
+}
+
+// advanceReplicationSlot records that the client of a replication slot has
+// confirmed the changes up to the given time, and advances the protected
+// timestamp record of the slot to it. It returns an error if the slot was
+// dropped.
+func advanceReplicationSlot(
+	ctx context.Context, execCfg *ExecutorConfig, jobID jobspb.JobID, confirmedFlush hlc.Timestamp,
+) error {
+	return execCfg.InternalDB.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		j, err := execCfg.JobRegistry.LoadJobWithTxn(ctx, jobID, txn)
+		if err != nil {
+			return err
+		}
+		return j.WithTxn(txn).Update(ctx, func(
+			txn isql.Txn, md jobs.JobMetadata, ju *jobs.JobUpdater,
+		) error {
+			details := md.Payload.GetReplicationSlot()
+			if md.Status.Terminal() || md.Status == jobs.StatusCancelRequested ||
+				md.Status == jobs.StatusReverting {
+				return pgerror.Newf(pgcode.UndefinedObject,
+					"replication slot %q does not exist", details.SlotName)
+			}
+			progress := md.Progress.GetReplicationSlot()
+			if !progress.ConfirmedFlush.Less(confirmedFlush) {
+				return nil
+			}
+			progress.ConfirmedFlush = confirmedFlush
+			ju.UpdateProgress(md.Progress)
+			return execCfg.ProtectedTimestampProvider.WithTxn(txn).UpdateTimestamp(
+				ctx, details.ProtectedTimestampRecordID, confirmedFlush,
+			)
+		})
+	})
+}
+
+type createReplicationSlotNode struct {
+	optColumnsSlot
+	n     *pgrepltree.CreateReplicationSlot
+	slot  replicationSlot
+	shown bool
+}
+
+// CreateReplicationSlot creates a logical replication slot in the database of
+// the replication connection. Changes committed after the creation of the
+// slot can be streamed with START_REPLICATION.
+//
+// Unlike in postgres, the slot does not retain the changes in a log. Instead,
+// it protects the MVCC history of its database since the last position
+// confirmed by the client from garbage collection.
+func (p *planner) CreateReplicationSlot(
+	ctx context.Context, n *pgrepltree.CreateReplicationSlot,
+) (planNode, error) {
+	if err := p.checkLogicalReplicationConnection(); err != nil {
+		return nil, err
+	}
+	if err := p.checkReplicationSlotsSupported(ctx); err != nil {
+		return nil, err
+	}
+	if err := validateReplicationSlotName(string(n.Slot)); err != nil {
+		return nil, err
+	}
+	if n.Kind != pgrepltree.LogicalReplication {
+		return nil, unimplemented.NewWithIssueDetail(0, "physical replication slot",
+			"physical replication slots are not supported")
+	}
+	if n.Temporary {
+		return nil, unimplemented.NewWithIssueDetail(0, "temporary replication slot",
+			"temporary replication slots are not supported")
+	}
+	if n.Plugin != pgoutputPlugin {
+		return nil, pgerror.Newf(pgcode.UndefinedObject,
+			"output plugin %q is not supported; only %q is available", n.Plugin, pgoutputPlugin)
+	}
+	for _, o := range n.Options {
+		value := replicationOptionValue(o)
+		switch o.Key {
+		case "snapshot":
+			switch value {
+			case "export", "nothing":
+			case "use":
+				return nil, unimplemented.NewWithIssueDetail(0, "replication slot snapshot use",
+					"using the snapshot of a replication slot is not supported")
+			default:
+				return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+					"unrecognized value for CREATE_REPLICATION_SLOT option \"snapshot\": %q", value)
+			}
+		case "two_phase":
+			if value != "false" {
+				return nil, unimplemented.NewWithIssueDetail(0, "replication slot two_phase",
+					"two-phase decoding is not supported")
+			}
+		default:
+			return nil, pgerror.Newf(pgcode.Syntax,
+				"unrecognized option: %s", o.Key)
+		}
+	}
+
+	dbDesc, err := p.Descriptors().ByNameWithLeased(p.txn).Get().Database(ctx, p.CurrentDatabase())
+	if err != nil {
+		return nil, err
+	}
+	if existing, err := getReplicationSlot(
+		ctx, p.InternalSQLTxn(), p.ExecCfg().JobRegistry, string(n.Slot),
+	); err != nil {
+		return nil, err
+	} else if existing != nil {
+		return nil, pgerror.Newf(pgcode.DuplicateObject,
+			"replication slot %q already exists", n.Slot)
+	}
+	consistentPoint := p.Txn().ReadTimestamp()
+	return &createReplicationSlotNode{
+		n: n,
+		slot: replicationSlot{
+			jobID: p.ExecCfg().JobRegistry.MakeJobID(),
+			details: jobspb.ReplicationSlotDetails{
+				SlotName:                   string(n.Slot),
+				Plugin:                     string(n.Plugin),
+				DatabaseID:                 dbDesc.GetID(),
+				ConsistentPoint:            consistentPoint,
+				ProtectedTimestampRecordID: uuid.MakeV4(),
+			},
+			progress: jobspb.ReplicationSlotProgress{
+				ConfirmedFlush: consistentPoint,
+			},
+		},
+	}, nil
+}
+
+func (n *createReplicationSlotNode) startExec(params runParams) error {
+	p := params.p
+	record := jobs.Record{
+		JobID:         n.slot.jobID,
+		Description:   fmt.Sprintf("logical replication slot %q", n.slot.details.SlotName),
+		Username:      p.User(),
+		DescriptorIDs: descpb.IDs{n.slot.details.DatabaseID},
+		Details:       n.slot.details,
+		Progress:      n.slot.progress,
+	}
+	if _, err := p.ExecCfg().JobRegistry.CreateAdoptableJobWithTxn(
+		params.ctx, record, record.JobID, p.InternalSQLTxn(),
+	); err != nil {
+		return err
+	}
+	pts := jobsprotectedts.MakeRecord(
+		n.slot.details.ProtectedTimestampRecordID,
+		int64(n.slot.jobID),
+		n.slot.details.ConsistentPoint,
+		nil, /* deprecatedSpans */
+		jobsprotectedts.Jobs,
+		ptpb.MakeSchemaObjectsTarget(descpb.IDs{n.slot.details.DatabaseID}),
+	)
+	return p.ExecCfg().ProtectedTimestampProvider.WithTxn(p.InternalSQLTxn()).Protect(params.ctx, pts)
+}
+
+func (n *createReplicationSlotNode) Next(runParams) (bool, error) {
+	if n.shown {
+		return false, nil
+	}
+	n.shown = true
+	return true, nil
+}
+
+func (n *createReplicationSlotNode) Values() tree.Datums {
+	consistentPoint := n.slot.details.ConsistentPoint
+	return tree.Datums{
+		tree.NewDString(n.slot.details.SlotName),
+		tree.NewDString(lsnutil.HLCToLSN(consistentPoint).String()),
+		// The snapshot of the slot can be read using AS OF SYSTEM TIME.
+		tree.NewDString(consistentPoint.AsOfSystemTime()),
+		tree.NewDString(n.slot.details.Plugin),
+	}
+}
+
+func (n *createReplicationSlotNode) Close(context.Context) {}
+
+type dropReplicationSlotNode struct {
+	n     *pgrepltree.DropReplicationSlot
+	jobID jobspb.JobID
+}
+
+// DropReplicationSlot drops a replication slot of the database of the
+// replication connection. The job of the slot is canceled, which releases
+// the protected timestamp record of the slot.
+func (p *planner) DropReplicationSlot(
+	ctx context.Context, n *pgrepltree.DropReplicationSlot,
+) (planNode, error) {
+	if err := p.checkLogicalReplicationConnection(); err != nil {
+		return nil, err
+	}
+	if err := p.checkReplicationSlotsSupported(ctx); err != nil {
+		return nil, err
+	}
+	dbDesc, err := p.Descriptors().ByNameWithLeased(p.txn).Get().Database(ctx, p.CurrentDatabase())
+	if err != nil {
+		return nil, err
+	}
+	slot, err := getReplicationSlot(ctx, p.InternalSQLTxn(), p.ExecCfg().JobRegistry, string(n.Slot))
+	if err != nil {
+		return nil, err
+	}
+	if slot == nil {
+		return nil, pgerror.Newf(pgcode.UndefinedObject,
+			"replication slot %q does not exist", n.Slot)
+	}
+	if err := checkReplicationSlotDatabase(slot, dbDesc.GetID()); err != nil {
+		return nil, err
+	}
+	return &dropReplicationSlotNode{n: n, jobID: slot.jobID}, nil
+}
+
+func (n *dropReplicationSlotNode) startExec(params runParams) error {
+	txn := params.p.InternalSQLTxn()
+	j, err := params.p.ExecCfg().JobRegistry.LoadJobWithTxn(params.ctx, n.jobID, txn)
+	if err != nil {
+		return err
+	}
+	return j.WithTxn(txn).CancelRequested(params.ctx)
+}
+
+func (n *dropReplicationSlotNode) Next(runParams) (bool, error) { return false, nil }
+func (n *dropReplicationSlotNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *dropReplicationSlotNode) Close(context.Context)        {}
+
+// checkReplicationSlotsSupported returns an error if the cluster is not
+// upgraded to a version supporting replication slots.
+func (p *planner) checkReplicationSlotsSupported(ctx context.Context) error {
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V24_1) {
+		return pgerror.New(pgcode.FeatureNotSupported,
+			"replication slots are not supported until version 24.1")
+	}
+	return nil
+}
+
+// checkLogicalReplicationConnection returns an error if the session is not a
+// logical replication connection, i.e. a replication connection to a
+// database.
+func (p *planner) checkLogicalReplicationConnection() error {
+	if p.SessionData().ReplicationMode != sessiondatapb.ReplicationMode_REPLICATION_MODE_DATABASE {
+		return pgerror.New(pgcode.ObjectNotInPrerequisiteState,
+			"logical decoding requires a database connection")
+	}
+	return nil
+}
+
+// validateReplicationSlotName checks that the name of a replication slot only
+// contains lower case letters, numbers and underscores, like in postgres.
+func validateReplicationSlotName(name string) error {
+	if len(name) == 0 {
+		return pgerror.Newf(pgcode.InvalidName,
+			"replication slot name %q is too short", name)
+	}
+	if len(name) > maxReplicationSlotNameLen {
+		return pgerror.Newf(pgcode.NameTooLong,
+			"replication slot name %q is too long", name)
+	}
+	for _, c := range name {
+		if !(c >= 'a' && c <= 'z') && !(c >= '0' && c <= '9') && c != '_' {
+			return errors.WithHint(
+				pgerror.Newf(pgcode.InvalidName,
+					"replication slot name %q contains invalid character", name),
+				"Replication slot names may only contain lower case letters, numbers, and the underscore character.",
+			)
+		}
+	}
+	return nil
+}
+
+// replicationOptionValue returns the value of an option of a replication
+// protocol command, or the empty string if the option has no value.
+func replicationOptionValue(o pgrepltree.Option) string {
+	switch v := o.Value.(type) {
+	case nil:
+		return ""
+	case *tree.StrVal:
+		return strings.ToLower(v.RawString())
+	default:
+		return strings.ToLower(tree.AsStringWithFlags(v, tree.FmtBareStrings))
+	}
+}
+
+// replicationSlotResumer is the jobs.Resumer of the job backing a
+// replication slot. The job does not do any work: it exists for as long as
+// the slot does, and fails once the database of the slot is dropped.
+type replicationSlotResumer struct {
+	job *jobs.Job
+}
+
+var _ jobs.Resumer = (*replicationSlotResumer)(nil)
+
+// Resume is part of the jobs.Resumer interface.
+func (r *replicationSlotResumer) Resume(ctx context.Context, execCtx interface{}) error {
+	execCfg := execCtx.(JobExecContext).ExecCfg()
+	details := r.job.Details().(jobspb.ReplicationSlotDetails)
+	r.job.MarkIdle(true)
+
+	timer := timeutil.NewTimer()
+	defer timer.Stop()
+	for {
+		timer.Reset(replicationSlotCheckInterval)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+			timer.Read = true
+			var dropped bool
+			if err := execCfg.InternalDB.DescsTxn(ctx, func(ctx context.Context, txn descs.Txn) error {
+				_, err := txn.Descriptors().ByID(txn.KV()).Get().Database(ctx, details.DatabaseID)
+				dropped = errors.Is(err, catalog.ErrDescriptorNotFound) ||
+					errors.Is(err, catalog.ErrDescriptorDropped)
+				if dropped {
+					return nil
+				}
+				return err
+			}); err != nil {
+				log.Warningf(ctx, "failed to check the database of replication slot %q: %v",
+					details.SlotName, err)
+				continue
+			}
+			if dropped {
+				return jobs.MarkAsPermanentJobError(errors.Newf(
+					"the database of replication slot %q was dropped", details.SlotName))
+			}
+		}
+	}
+}
+
+// OnFailOrCancel is part of the jobs.Resumer interface.
+func (r *replicationSlotResumer) OnFailOrCancel(
+	ctx context.Context, execCtx interface{}, _ error,
+) error {
+	execCfg := execCtx.(JobExecContext).ExecCfg()
+	ptsID := r.job.Details().(jobspb.ReplicationSlotDetails).ProtectedTimestampRecordID
+	return execCfg.InternalDB.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		err := execCfg.ProtectedTimestampProvider.WithTxn(txn).Release(ctx, ptsID)
+		// In case that a retry happens, the record might have been released.
+		if errors.Is(err, protectedts.ErrNotExists) {
+			return nil
+		}
+		return err
+	})
+}
+
+// CollectProfile is part of the jobs.Resumer interface.
+func (r *replicationSlotResumer) CollectProfile(context.Context, interface{}) error {
+	return nil
+}
+
+func init() {
+	jobs.RegisterConstructor(jobspb.TypeReplicationSlot,
+		func(job *jobs.Job, _ *cluster.Settings) jobs.Resumer {
+			return &replicationSlotResumer{job: job}
+		},
+		jobs.UsesTenantCostControl,
+	)
+}
@@ -12,18 +12,23 @@ package sql
 
 import (
 	"context"
+	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util"
+	"github.com/cockroachdb/cockroach/pkg/util/duration"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
 	"github.com/cockroachdb/cockroach/pkg/util/tracing"
 	"github.com/cockroachdb/errors"
 )
@@ -31,27 +36,226 @@ import (
 // A callNode executes a procedure.
 type callNode struct {
 	proc *tree.RoutineExpr
+
+	// columns are the result columns of the CALL statement. They are the OUT
+	// and INOUT parameters of the procedure, if it has any.
+	columns colinfo.ResultColumns
+
+	// row is the row returned by the CALL statement. It is nil if the procedure
+	// has no OUT or INOUT parameters.
+	row  tree.Datums
+	done bool
 }
 
 var _ planNode = &callNode{}
 
 // startExec implements the planNode interface.
 func (d *callNode) startExec(params runParams) error {
-	// Until OUT and INOUT parameters are supported, all procedures return no
-	// results, so we can ignore the results of the routine.
-	_, err := eval.Expr(params.ctx, params.EvalContext(), d.proc)
-	return err
+	res, err := eval.Expr(params.ctx, params.EvalContext(), d.proc)
+	if err != nil {
+		return err
+	}
+	if txnState := params.p.extendedEvalCtx.storedProcTxnState; txnState != nil &&
+		txnState.nextProc != nil {
+		// The procedure ended the transaction. It will return its results once it
+		// is resumed in a new transaction.
+		return nil
+	}
+	if len(d.columns) == 0 {
+		return nil
+	}
+	d.row = make(tree.Datums, len(d.columns))
+	if tup, ok := tree.AsDTuple(res); ok {
+		copy(d.row, tup.D)
+	} else {
+		for i := range d.row {
+			d.row[i] = tree.DNull
+		}
+	}
+	return nil
 }
 
 // Next implements the planNode interface.
-func (d *callNode) Next(params runParams) (bool, error) { return false, nil }
+func (d *callNode) Next(params runParams) (bool, error) {
+	if d.row == nil || d.done {
+		return false, nil
+	}
+	d.done = true
+	return true, nil
+}
 
 // Values implements the planNode interface.
-func (d *callNode) Values() tree.Datums { return nil }
+func (d *callNode) Values() tree.Datums { return d.row }
 
 // Close implements the planNode interface.
 func (d *callNode) Close(ctx context.Context) {}
 
+// getCallResultColumns returns the result columns of a CALL statement for a
+// procedure with the given return type. A procedure with OUT or INOUT
+// parameters returns a tuple with their values, which is returned as a row.
+func getCallResultColumns(typ *types.T) colinfo.ResultColumns {
+	if typ.Family() != types.TupleFamily {
+		return nil
+	}
+	contents := typ.TupleContents()
+	labels := typ.TupleLabels()
+	cols := make(colinfo.ResultColumns, len(contents))
+	for i := range contents {
+		name := fmt.Sprintf("column%d", i+1)
+		if i < len(labels) {
+			name = labels[i]
+		}
+		cols[i] = colinfo.ResultColumn{Name: name, Typ: contents[i]}
+	}
+	return cols
+}
+
+// getCallResultColumnsFromMemo returns the result columns of the CALL
+// statement that was built into the given memo.
+func getCallResultColumnsFromMemo(mem *memo.Memo) colinfo.ResultColumns {
+	call, ok := mem.RootExpr().(*memo.CallExpr)
+	if !ok {
+		return nil
+	}
+	return getCallResultColumns(call.Proc.DataType())
+}
+
+// storedProcTxnState tracks the transaction control statements (COMMIT and
+// ROLLBACK) executed by a procedure invoked with CALL.
+//
+// A procedure ends the current transaction by recording the operation and the
+// sub-routine that resumes its execution, then returning early. The
+// connExecutor then commits or rolls back the transaction, and executes the
+// CALL statement again in a new implicit transaction. The second execution
+// evaluates resumeProc instead of planning the statement.
+type storedProcTxnState struct {
+	// canControlTxn is true while a CALL statement that is allowed to end the
+	// transaction is executing. As in Postgres, this is only the case for a
+	// CALL that runs in an implicit transaction.
+	canControlTxn bool
+
+	// txnOp is the operation to perform on the current transaction, as
+	// requested by the procedure.
+	txnOp tree.StoredProcTxnOp
+
+	// nextProc is the routine that continues the execution of the procedure
+	// once the transaction has been committed or rolled back. It is set along
+	// with txnOp.
+	nextProc *tree.RoutineExpr
+
+	// resumeProc, if set, is the routine that is evaluated when the CALL
+	// statement is executed, instead of the procedure itself. It is kept until
+	// the CALL statement finishes, so that it can be evaluated again if the
+	// transaction is retried.
+	resumeProc *tree.RoutineExpr
+
+	// resumePortal, if set, is a copy of the portal through which the CALL
+	// statement was executed. The portal is closed when the procedure ends the
+	// transaction, so the copy is used to execute the CALL statement again in
+	// the new transaction.
+	resumePortal *PreparedPortal
+}
+
+func (s *storedProcTxnState) reset() {
+	*s = storedProcTxnState{}
+}
+
+// saveResumePortal records the portal through which a CALL statement whose
+// procedure ended the transaction was executed. Only the statement of the
+// portal is used once the procedure is resumed, since resumeProc is evaluated
+// instead of the statement's plan.
+func (s *storedProcTxnState) saveResumePortal(portal *PreparedPortal) {
+	resumePortal := *portal
+	resumePortal.exhausted = false
+	resumePortal.pauseInfo = nil
+	s.resumePortal = &resumePortal
+}
+
+// setTxnOp records that a procedure ended the current transaction with the
+// given operation. The procedure will be resumed by evaluating expr with the
+// given arguments in the next transaction.
+func (s *storedProcTxnState) setTxnOp(expr *tree.RoutineExpr, args tree.Datums) error {
+	if s == nil || !s.canControlTxn {
+		return pgerror.New(pgcode.InvalidTransactionTermination, "invalid transaction termination")
+	}
+	if expr.BlockState != nil {
+		op := "commit"
+		if expr.TxnOp == tree.StoredProcTxnRollback {
+			op = "roll back"
+		}
+		return pgerror.Newf(pgcode.InvalidTransactionTermination,
+			"cannot %s while a subtransaction is active", op,
+		)
+	}
+	nextProc := *expr
+	nextProc.TxnOp = tree.StoredProcTxnNoOp
+	nextProc.TailCall = false
+	nextProc.Args = make(tree.TypedExprs, len(args))
+	for i := range args {
+		nextProc.Args[i] = args[i]
+	}
+	s.txnOp = expr.TxnOp
+	s.nextProc = &nextProc
+	return nil
+}
+
+// withRoutineExecContext calls fn with the session changed as described by the
+// given routine execution context. While fn runs, the current user is the
+// definer of a SECURITY DEFINER routine, the session variables set by the
+// routine's SET options are in effect, and the transaction cannot be ended by
+// a procedure. The session is restored once fn returns. It is used both while
+// the body of the routine is planned and while it is executed.
+func (p *planner) withRoutineExecContext(
+	ctx context.Context, execCtx *tree.RoutineExecContext, fn func() error,
+) error {
+	if execCtx == nil {
+		return fn()
+	}
+	stack := p.EvalContext().SessionDataStack
+	stack.PushTopClone()
+	defer func(searchPath tree.SearchPath, dateStyle pgdate.DateStyle, intervalStyle duration.IntervalStyle) {
+		if err := stack.Pop(); err != nil {
+			panic(errors.WithAssertionFailure(err))
+		}
+		p.semaCtx.SearchPath = searchPath
+		p.semaCtx.DateStyle = dateStyle
+		p.semaCtx.IntervalStyle = intervalStyle
+	}(p.semaCtx.SearchPath, p.semaCtx.DateStyle, p.semaCtx.IntervalStyle)
+
+	sd := stack.Top()
+	if !execCtx.Definer.Undefined() {
+		sd.SessionUserProto = sd.SessionUser().EncodeProto()
+		sd.UserProto = execCtx.Definer.EncodeProto()
+	}
+	m := p.sessionDataMutatorIterator.mutator(false /* applyCallbacks */, sd)
+	for _, c := range execCtx.Config {
+		name, val, _ := strings.Cut(c, "=")
+		_, v, err := getSessionVar(name, false /* missingOk */)
+		if err != nil {
+			return err
+		}
+		if v.Set == nil {
+			return newCannotChangeParameterError(name)
+		}
+		if err := v.Set(ctx, m, val); err != nil {
+			return err
+		}
+	}
+	p.semaCtx.SearchPath = &sd.SearchPath
+	p.semaCtx.DateStyle = sd.GetDateStyle()
+	p.semaCtx.IntervalStyle = sd.GetIntervalStyle()
+
+	// As in Postgres, a procedure cannot end the transaction while a routine
+	// that changes the session is executing.
+	if txnState := p.extendedEvalCtx.storedProcTxnState; txnState != nil {
+		defer func(canControlTxn bool) {
+			txnState.canControlTxn = canControlTxn
+		}(txnState.canControlTxn)
+		txnState.canControlTxn = false
+	}
+	return fn()
+}
+
 // EvalRoutineExpr returns the result of evaluating the routine. It calls the
 // routine's ForEachPlan closure to generate a plan for each statement in the
 // routine, then runs the plans. The resulting value of the last statement in
@@ -59,6 +263,17 @@ func (d *callNode) Close(ctx context.Context) {}
 func (p *planner) EvalRoutineExpr(
 	ctx context.Context, expr *tree.RoutineExpr, args tree.Datums,
 ) (result tree.Datum, err error) {
+	if expr.TxnOp != tree.StoredProcTxnNoOp {
+		// The routine commits or rolls back the transaction before it is
+		// evaluated. Record the operation and return early; the routine will be
+		// evaluated once the connExecutor has ended the transaction. It is safe
+		// to return NULL here because the routine is in tail-call position.
+		if err := p.extendedEvalCtx.storedProcTxnState.setTxnOp(expr, args); err != nil {
+			return nil, err
+		}
+		return tree.DNull, nil
+	}
+
 	// Strict routines (CalledOnNullInput=false) should not be invoked and they
 	// should immediately return NULL if any of their arguments are NULL.
 	if !expr.CalledOnNullInput {
@@ -176,17 +391,60 @@ func (g *routineGenerator) ResolvedType() *types.T {
 
 // Start is part of the ValueGenerator interface.
 func (g *routineGenerator) Start(ctx context.Context, txn *kv.Txn) (err error) {
-	for {
-		err = g.startInternal(ctx, txn)
-		if err != nil || g.deferredRoutine.expr == nil {
-			// No tail-call optimization.
+	var buf *routineResultBuffer
+	if g.expr.BufferedResult {
+		// The rows returned by a set-returning PLpgSQL routine are added to a
+		// buffer by its RETURN NEXT and RETURN QUERY statements while it executes.
+		retTypes, err := g.retTypes()
+		if err != nil {
+			return err
+		}
+		buf = g.p.pushRoutineResultBuffer(ctx, retTypes, g.expr.MultiColOutput)
+		defer g.p.popRoutineResultBuffer()
+	}
+	// The session changes of a SECURITY DEFINER routine or a routine with SET
+	// options remain in effect for the nested routines in tail-call position,
+	// which are part of the same invocation.
+	err = g.p.withRoutineExecContext(ctx, g.expr.ExecContext, func() (err error) {
+		for {
+			err = g.startInternal(ctx, txn)
+			if err != nil || g.deferredRoutine.expr == nil {
+				// No tail-call optimization.
+				return err
+			}
+			// A nested routine in tail-call position deferred its execution until
+			// now. Since it's in tail-call position, evaluating it will give the
+			// result of this routine as well.
+			g.reset(ctx, g.p, g.deferredRoutine.expr, g.deferredRoutine.args)
+		}
+	})
+	if buf != nil {
+		if err != nil {
+			buf.rch.Close(ctx)
 			return err
 		}
-		// A nested routine in tail-call position deferred its execution until now.
-		// Since it's in tail-call position, evaluating it will give the result of
-		// this routine as well.
-		g.reset(ctx, g.p, g.deferredRoutine.expr, g.deferredRoutine.args)
+		// The result of the last statement is ignored in favor of the buffered
+		// rows.
+		g.rci.Close()
+		g.rch.Close(ctx)
+		g.rch = buf.rch
+		g.rci = newRowContainerIterator(ctx, g.rch)
+	}
+	return err
+}
+
+// retTypes returns the types of the columns in each row returned by the
+// routine.
+func (g *routineGenerator) retTypes() ([]*types.T, error) {
+	rt := g.expr.ResolvedType()
+	if g.expr.MultiColOutput {
+		// A routine with multiple output column should have its types in a tuple.
+		if rt.Family() != types.TupleFamily {
+			return nil, errors.AssertionFailedf("routine expected to return multiple columns")
+		}
+		return rt.TupleContents(), nil
 	}
+	return []*types.T{rt}, nil
 }
 
 // startInternal implements logic for a single execution of a routine.
@@ -195,16 +453,9 @@ func (g *routineGenerator) Start(ctx context.Context, txn *kv.Txn) (err error) {
 // is cache-able (i.e., there are no arguments to the routine and stepping is
 // disabled).
 func (g *routineGenerator) startInternal(ctx context.Context, txn *kv.Txn) (err error) {
-	rt := g.expr.ResolvedType()
-	var retTypes []*types.T
-	if g.expr.MultiColOutput {
-		// A routine with multiple output column should have its types in a tuple.
-		if rt.Family() != types.TupleFamily {
-			return errors.AssertionFailedf("routine expected to return multiple columns")
-		}
-		retTypes = rt.TupleContents()
-	} else {
-		retTypes = []*types.T{g.expr.ResolvedType()}
+	retTypes, err := g.retTypes()
+	if err != nil {
+		return err
 	}
 	g.rch.Init(ctx, retTypes, g.p.ExtendedEvalContext(), "routine" /* opName */)
 
@@ -244,10 +495,11 @@ func (g *routineGenerator) startInternal(ctx context.Context, txn *kv.Txn) (err
 
 		var w rowResultWriter
 		openCursor := stmtIdx == 1 && g.expr.CursorDeclaration != nil
-		if isFinalPlan && !g.expr.Procedure {
-			// The result of this statement is the routine's output. This is never the
-			// case for a procedure, which does not output any rows (since we do not
-			// yet support OUT or INOUT parameters).
+		returnNext := stmtIdx == 1 && g.expr.ReturnNext
+		if isFinalPlan && (!g.expr.Procedure || g.expr.Typ.Family() != types.VoidFamily) {
+			// The result of this statement is the routine's output. This is only
+			// the case for a procedure with OUT or INOUT parameters; other
+			// procedures do not output any rows.
 			w = rrw
 		} else if openCursor {
 			// The result of the first statement will be used to open a SQL cursor.
@@ -256,6 +508,12 @@ func (g *routineGenerator) startInternal(ctx context.Context, txn *kv.Txn) (err
 				return err
 			}
 			w = NewRowResultWriter(&cursorHelper.container)
+		} else if returnNext {
+			// The result of the first statement is added to the result of the
+			// enclosing set-returning routine.
+			w = NewCallbackResultWriter(func(ctx context.Context, row tree.Datums) error {
+				return g.p.PLpgSQLReturnNext(ctx, row[0])
+			})
 		} else {
 			// The result of this statement is not needed. Use a rowResultWriter that
 			// drops all rows added to it.
@@ -424,6 +682,60 @@ func (g *routineGenerator) SendDeferredRoutine(routine *tree.RoutineExpr, args t
 	g.deferredRoutine.args = args
 }
 
+// routineResultBuffer accumulates the rows returned by a set-returning PLpgSQL
+// routine. The rows are added by the RETURN NEXT and RETURN QUERY statements of
+// the routine, and are returned once the routine has finished executing.
+type routineResultBuffer struct {
+	rch rowContainerHelper
+	// numCols is the number of columns in each row of the result.
+	numCols int
+	// multiColOutput is true if the routine returns multiple columns. In this
+	// case, each returned value is a tuple that is expanded into a row.
+	multiColOutput bool
+}
+
+// pushRoutineResultBuffer adds a buffer for the result of a set-returning
+// PLpgSQL routine that is about to be evaluated. Set-returning routines can be
+// nested, so the buffers form a stack.
+func (p *planner) pushRoutineResultBuffer(
+	ctx context.Context, typs []*types.T, multiColOutput bool,
+) *routineResultBuffer {
+	buf := &routineResultBuffer{numCols: len(typs), multiColOutput: multiColOutput}
+	buf.rch.Init(ctx, typs, p.ExtendedEvalContext(), "routine_result" /* opName */)
+	p.routineResultBuffers = append(p.routineResultBuffers, buf)
+	return buf
+}
+
+// popRoutineResultBuffer removes the buffer that was added by the last call to
+// pushRoutineResultBuffer. The buffer is not closed.
+func (p *planner) popRoutineResultBuffer() {
+	p.routineResultBuffers = p.routineResultBuffers[:len(p.routineResultBuffers)-1]
+}
+
+// PLpgSQLReturnNext is part of the eval.Planner interface.
+func (p *planner) PLpgSQLReturnNext(ctx context.Context, val tree.Datum) error {
+	if len(p.routineResultBuffers) == 0 {
+		return errors.AssertionFailedf("RETURN NEXT used outside of a set-returning function")
+	}
+	buf := p.routineResultBuffers[len(p.routineResultBuffers)-1]
+	if !buf.multiColOutput {
+		return buf.rch.AddRow(ctx, tree.Datums{val})
+	}
+	row := make(tree.Datums, buf.numCols)
+	if val == tree.DNull {
+		for i := range row {
+			row[i] = tree.DNull
+		}
+	} else {
+		tup := tree.MustBeDTuple(val)
+		if len(tup.D) != len(row) {
+			return pgerror.New(pgcode.DatatypeMismatch, "wrong record type supplied in RETURN NEXT")
+		}
+		copy(row, tup.D)
+	}
+	return buf.rch.AddRow(ctx, row)
+}
+
 // droppingResultWriter drops all rows that are added to it. It only tracks
 // errors with the SetError and Err functions.
 type droppingResultWriter struct {
@@ -468,6 +780,7 @@ func (g *routineGenerator) newCursorHelper(plan *planComponents) (*plpgsqlCursor
 	cursorHelper := &plpgsqlCursorHelper{
 		ctx:        context.Background(),
 		cursorName: cursorName,
+		scroll:     open.Scroll == tree.Scroll,
 		resultCols: make(colinfo.ResultColumns, len(planCols)),
 	}
 	copy(cursorHelper.resultCols, planCols)
@@ -489,6 +802,7 @@ type plpgsqlCursorHelper struct {
 	ctx         context.Context
 	cursorName  tree.Name
 	cursorSql   string
+	scroll      bool
 	addedCursor bool
 
 	// Fields related to implementing the isql.Rows interface.
@@ -507,6 +821,7 @@ func (h *plpgsqlCursorHelper) createCursor(p *planner, blockState *tree.BlockSta
 		txn:            p.txn,
 		statement:      h.cursorSql,
 		created:        timeutil.Now(),
+		scroll:         h.scroll,
 		eagerExecution: true,
 	}
 	if err := p.checkIfCursorExists(h.cursorName); err != nil {
@@ -515,6 +830,13 @@ func (h *plpgsqlCursorHelper) createCursor(p *planner, blockState *tree.BlockSta
 	if err := p.sqlCursors.addCursor(h.cursorName, cursor); err != nil {
 		return err
 	}
+	if cursor.scroll {
+		// Scrollable cursors spool the rows they read so that they can move
+		// backward.
+		cursor.spool = newCursorSpool(
+			h.ctx, p.ExtendedEvalContext(), p.Mon(), h.resultCols, 0, /* offset */
+		)
+	}
 	if blockState != nil {
 		// Add the cursor name to the block's state. This allows the exception handler
 		// to close it, if necessary.
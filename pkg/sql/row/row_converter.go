@@ -178,6 +178,9 @@ func GenerateInsertRow(
 		if err != nil {
 			return nil, err
 		}
+		if err := colinfo.ValidateColumnValue(ctx, evalCtx.Settings.Version, outVal); err != nil {
+			return nil, err
+		}
 		rowVals[i] = outVal
 	}
 
@@ -119,18 +119,30 @@ func EncodePartialIndexKey(
 			containsNull = true
 		}
 
-		dir, err := catalogkeys.IndexColumnEncodingDirection(keyCol.Direction)
-		if err != nil {
-			return nil, false, err
-		}
-
-		if key, err = keyside.Encode(key, val, dir); err != nil {
+		var err error
+		if key, err = EncodeKeyColumn(key, val, keyCol); err != nil {
 			return nil, false, err
 		}
 	}
 	return key, containsNull, nil
 }
 
+// EncodeKeyColumn appends the key encoding of val, a value of the given index
+// key column, to key, taking into account the direction and the NULLs order of
+// the column.
+func EncodeKeyColumn(
+	key []byte, val tree.Datum, keyCol *fetchpb.IndexFetchSpec_KeyColumn,
+) ([]byte, error) {
+	dir, err := catalogkeys.IndexColumnEncodingDirection(keyCol.Direction)
+	if err != nil {
+		return nil, err
+	}
+	if keyCol.NullsOrder == catenumpb.IndexColumn_NULLS_REVERSED {
+		return keyside.EncodeNullsReversed(key, val, dir)
+	}
+	return keyside.Encode(key, val, dir)
+}
+
 type Directions []catenumpb.IndexColumn_Direction
 
 func (d Directions) Get(i int) (encoding.Direction, error) {
@@ -365,6 +377,14 @@ func MakeKeyFromEncDatums(
 		}
 		if val.IsNull() {
 			containsNull = true
+			if keyCols[i].NullsOrder == catenumpb.IndexColumn_NULLS_REVERSED {
+				var err error
+				key, err = EncodeKeyColumn(key, tree.DNull, &keyCols[i])
+				if err != nil {
+					return nil, false, err
+				}
+				continue
+			}
 		}
 		var err error
 		key, err = val.Encode(keyCols[i].Type, alloc, encoding, key)
@@ -623,6 +643,8 @@ func EncodeInvertedIndexTableKeys(
 		return encodeTrigramInvertedIndexTableKeys(string(*datum.(*tree.DString)), inKey, version, true /* pad */)
 	case types.TSVectorFamily:
 		return tsearch.EncodeInvertedIndexKeys(inKey, val.(*tree.DTSVector).TSVector)
+	case types.RangeFamily, types.MultirangeFamily:
+		return encodeRangeInvertedIndexTableKeys(val, inKey)
 	}
 	return nil, errors.AssertionFailedf("trying to apply inverted index to unsupported type %s", datum.ResolvedType().SQLStringForError())
 }
@@ -26,9 +26,21 @@ import (
 // differently, because the standard NULL encoding conflicts with the
 // terminator byte. This NULL value is chosen to be larger than the
 // terminator but less than all existing encoded values.
+//
+// Arrays whose dimensions are not the default, such as multidimensional
+// arrays, have their dimensions encoded after the arrayMarker, before their
+// elements in row-major order.
 func encodeArrayKey(b []byte, array *tree.DArray, dir encoding.Direction) ([]byte, error) {
 	var err error
 	b = encoding.EncodeArrayKeyMarker(b, dir)
+	if array.Dims != nil {
+		lengths := make([]int64, len(array.Dims))
+		lowerBounds := make([]int64, len(array.Dims))
+		for i, dim := range array.Dims {
+			lengths[i], lowerBounds[i] = int64(dim.Length), int64(dim.LowerBound)
+		}
+		b = encoding.EncodeArrayKeyDims(b, dir, lengths, lowerBounds)
+	}
 	for _, elem := range array.Array {
 		if elem == tree.DNull {
 			b = encoding.EncodeNullWithinArrayKey(b, dir)
@@ -57,6 +69,19 @@ func decodeArrayKey(
 		return nil, nil, err
 	}
 
+	var dims []tree.ArrayDim
+	if encoding.HasArrayKeyDims(buf, dir) {
+		var lengths, lowerBounds []int64
+		buf, lengths, lowerBounds, err = encoding.DecodeArrayKeyDims(buf, dir)
+		if err != nil {
+			return nil, nil, err
+		}
+		dims = make([]tree.ArrayDim, len(lengths))
+		for i := range dims {
+			dims[i] = tree.ArrayDim{Length: int(lengths[i]), LowerBound: int(lowerBounds[i])}
+		}
+	}
+
 	for {
 		if len(buf) == 0 {
 			return nil, nil, errors.AssertionFailedf("invalid array encoding (unterminated)")
@@ -79,5 +104,8 @@ func decodeArrayKey(
 			return nil, nil, err
 		}
 	}
+	if err := result.SetDims(dims); err != nil {
+		return nil, nil, err
+	}
 	return result, buf, nil
 }
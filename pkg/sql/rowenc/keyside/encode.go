@@ -154,6 +154,8 @@ func Encode(b []byte, val tree.Datum, dir encoding.Direction) ([]byte, error) {
 		return b, nil
 	case *tree.DArray:
 		return encodeArrayKey(b, t, dir)
+	case *tree.DRange:
+		return encodeRangeKey(b, t, dir)
 	case *tree.DCollatedString:
 		if dir == encoding.Ascending {
 			return encoding.EncodeBytesAscending(b, t.Key), nil
@@ -182,3 +184,21 @@ func Encode(b []byte, val tree.Datum, dir encoding.Direction) ([]byte, error) {
 	}
 	return nil, errors.Errorf("unable to encode table key: %T", val)
 }
+
+// EncodeNullsReversed is like Encode, but places NULLs in the opposite position
+// to the one they have with Encode: after all other values when dir is
+// encoding.Ascending, and before all other values when dir is
+// encoding.Descending. Decode handles both encodings of NULLs.
+func EncodeNullsReversed(b []byte, val tree.Datum, dir encoding.Direction) ([]byte, error) {
+	if val == tree.DNull {
+		switch dir {
+		case encoding.Ascending:
+			return encoding.EncodeNullDescending(b), nil
+		case encoding.Descending:
+			return encoding.EncodeNullAscending(b), nil
+		default:
+			return nil, errors.Errorf("invalid direction: %d", dir)
+		}
+	}
+	return Encode(b, val, dir)
+}
@@ -149,6 +149,46 @@ func TestEncodeDecode(t *testing.T) {
 		genEncodingDirection(),
 	))
 
+	// NULLs encoded with EncodeNullsReversed sort after all other keys in
+	// ascending order and before them in descending order, and decode as NULL.
+	properties.Property("nulls-reversed", prop.ForAll(
+		func(d tree.Datum, dir encoding.Direction) string {
+			bNull, err := keyside.EncodeNullsReversed(nil, tree.DNull, dir)
+			if err != nil {
+				return "error: " + err.Error()
+			}
+			b, err := keyside.EncodeNullsReversed(nil, d, dir)
+			if err != nil {
+				return "error: " + err.Error()
+			}
+			// In both directions, the reversed encoding of NULL places it last
+			// when reading the column in its natural order, so it is the largest
+			// key when ascending and the smallest key when descending.
+			expectedCmp := 1
+			if dir == encoding.Descending {
+				expectedCmp = -1
+			}
+			if cmp := bytes.Compare(bNull, b); cmp != expectedCmp {
+				return fmt.Sprintf("NULL is not encoded in reversed position: \n%v\n%v", bNull, b)
+			}
+			newD, leftoverBytes, err := keyside.Decode(a, d.ResolvedType(), bNull, dir)
+			if err != nil {
+				return "error: " + err.Error()
+			}
+			if len(leftoverBytes) > 0 {
+				return "Leftover bytes"
+			}
+			if newD != tree.DNull {
+				return fmt.Sprintf("decoded %s instead of NULL", newD)
+			}
+			return ""
+		},
+		genColumnType().
+			SuchThat(hasKeyEncoding).
+			FlatMap(genDatumWithType, reflect.TypeOf((*tree.Datum)(nil)).Elem()),
+		genEncodingDirection(),
+	))
+
 	properties.TestingRun(t)
 }
 
@@ -179,6 +219,54 @@ func TestSkip(t *testing.T) {
 	properties.TestingRun(t)
 }
 
+// TestEncodeDecodeArrayDims tests that arrays with non-default dimensions
+// round trip through the key encoding, and that their encodings sort in the
+// same order as the arrays.
+func TestEncodeDecodeArrayDims(t *testing.T) {
+	ctx := eval.NewTestingEvalContext(cluster.MakeTestingClusterSettings())
+	// The arrays are listed in ascending order.
+	var arrays []tree.Datum
+	for _, s := range []string{
+		`{}`,
+		`{1,2}`,
+		`{1,2,3}`,
+		`[0:1]={1,2}`,
+		`[2:3]={1,2}`,
+		`{{1,NULL},{4,5}}`,
+		`{{1,2},{3,4}}`,
+		`{{1,2,3},{4,5,6}}`,
+		`{{{1},{2}},{{3},{4}}}`,
+	} {
+		arr, _, err := tree.ParseDArrayFromString(nil /* ParseContext */, s, types.Int)
+		require.NoError(t, err)
+		arrays = append(arrays, arr)
+	}
+	for _, dir := range []encoding.Direction{encoding.Ascending, encoding.Descending} {
+		var prev []byte
+		for i, d := range arrays {
+			b, err := keyside.Encode(nil, d, dir)
+			require.NoError(t, err)
+			a := &tree.DatumAlloc{}
+			decoded, rest, err := keyside.Decode(a, d.ResolvedType(), b, dir)
+			require.NoError(t, err)
+			require.Empty(t, rest)
+			require.Equal(t, 0, decoded.Compare(ctx, d), "%s decoded as %s", d, decoded)
+			rest, err = keyside.Skip(b)
+			require.NoError(t, err)
+			require.Empty(t, rest)
+			if i > 0 {
+				require.Equal(t, -1, arrays[i-1].Compare(ctx, d), "%s should sort before %s", arrays[i-1], d)
+				cmp := bytes.Compare(prev, b)
+				if dir == encoding.Descending {
+					cmp = -cmp
+				}
+				require.Equal(t, -1, cmp, "encoding of %s should sort before %s", arrays[i-1], d)
+			}
+			prev = b
+		}
+	}
+}
+
 // TestDecodeOutOfRangeTimestamp deliberately tests out of range timestamps
 // can still be decoded from disk. See #46973.
 func TestDecodeOutOfRangeTimestamp(t *testing.T) {
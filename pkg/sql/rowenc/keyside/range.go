@@ -0,0 +1,133 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package keyside
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/errors"
+)
+
+// The tags which precede the parts of an encoded range. They are chosen so
+// that ranges sort in the same order as tree.DRange.Compare: empty ranges
+// first, then by lower bound (where an infinite lower bound sorts first and an
+// inclusive lower bound sorts before an exclusive one with the same value),
+// then by upper bound (where an infinite upper bound sorts last and an
+// exclusive upper bound sorts before an inclusive one with the same value).
+const (
+	rangeKeyEmpty    = 0
+	rangeKeyNonEmpty = 1
+
+	rangeKeyLowerInfinite  = 0
+	rangeKeyLowerFinite    = 1
+	rangeKeyLowerInclusive = 0
+	rangeKeyLowerExclusive = 1
+
+	rangeKeyUpperFinite    = 0
+	rangeKeyUpperInfinite  = 1
+	rangeKeyUpperExclusive = 0
+	rangeKeyUpperInclusive = 1
+)
+
+// encodeRangeKey generates an ordered key encoding of a range. The parts of
+// the range are encoded in ascending order into a byte string, which is in
+// turn encoded in the given direction. This keeps the encoding of a range a
+// single value in the key, which can be skipped without knowing its type.
+func encodeRangeKey(b []byte, r *tree.DRange, dir encoding.Direction) ([]byte, error) {
+	var buf []byte
+	if r.Empty {
+		buf = encoding.EncodeVarintAscending(buf, rangeKeyEmpty)
+	} else {
+		var err error
+		buf = encoding.EncodeVarintAscending(buf, rangeKeyNonEmpty)
+		if r.Lower.IsInfinite() {
+			buf = encoding.EncodeVarintAscending(buf, rangeKeyLowerInfinite)
+		} else {
+			buf = encoding.EncodeVarintAscending(buf, rangeKeyLowerFinite)
+			if buf, err = Encode(buf, r.Lower.Val, encoding.Ascending); err != nil {
+				return nil, err
+			}
+			inclusive := int64(rangeKeyLowerExclusive)
+			if r.Lower.Inclusive {
+				inclusive = rangeKeyLowerInclusive
+			}
+			buf = encoding.EncodeVarintAscending(buf, inclusive)
+		}
+		if r.Upper.IsInfinite() {
+			buf = encoding.EncodeVarintAscending(buf, rangeKeyUpperInfinite)
+		} else {
+			buf = encoding.EncodeVarintAscending(buf, rangeKeyUpperFinite)
+			if buf, err = Encode(buf, r.Upper.Val, encoding.Ascending); err != nil {
+				return nil, err
+			}
+			inclusive := int64(rangeKeyUpperExclusive)
+			if r.Upper.Inclusive {
+				inclusive = rangeKeyUpperInclusive
+			}
+			buf = encoding.EncodeVarintAscending(buf, inclusive)
+		}
+	}
+	if dir == encoding.Ascending {
+		return encoding.EncodeBytesAscending(b, buf), nil
+	}
+	return encoding.EncodeBytesDescending(b, buf), nil
+}
+
+// decodeRangeKey decodes a range key generated by encodeRangeKey.
+func decodeRangeKey(
+	a *tree.DatumAlloc, t *types.T, key []byte, dir encoding.Direction,
+) (tree.Datum, []byte, error) {
+	var buf []byte
+	var err error
+	if dir == encoding.Ascending {
+		key, buf, err = encoding.DecodeBytesAscending(key, nil)
+	} else {
+		key, buf, err = encoding.DecodeBytesDescending(key, nil)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	var tag int64
+	if buf, tag, err = encoding.DecodeVarintAscending(buf); err != nil {
+		return nil, nil, err
+	}
+	if tag == rangeKeyEmpty {
+		return tree.NewEmptyDRange(t), key, nil
+	}
+	res := &tree.DRange{Typ: t}
+	for _, b := range []struct {
+		bound     *tree.RangeBound
+		finite    int64
+		inclusive int64
+	}{
+		{&res.Lower, rangeKeyLowerFinite, rangeKeyLowerInclusive},
+		{&res.Upper, rangeKeyUpperFinite, rangeKeyUpperInclusive},
+	} {
+		if buf, tag, err = encoding.DecodeVarintAscending(buf); err != nil {
+			return nil, nil, err
+		}
+		if tag != b.finite {
+			continue
+		}
+		if b.bound.Val, buf, err = Decode(a, t.RangeContents(), buf, encoding.Ascending); err != nil {
+			return nil, nil, err
+		}
+		if buf, tag, err = encoding.DecodeVarintAscending(buf); err != nil {
+			return nil, nil, err
+		}
+		b.bound.Inclusive = tag == b.inclusive
+	}
+	if len(buf) != 0 {
+		return nil, nil, errors.AssertionFailedf("invalid range encoding (%d trailing bytes)", len(buf))
+	}
+	return res, key, nil
+}
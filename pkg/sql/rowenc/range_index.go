@@ -0,0 +1,281 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package rowenc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"sort"
+
+	"github.com/cockroachdb/apd/v3"
+	"github.com/cockroachdb/cockroach/pkg/sql/inverted"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/errors"
+)
+
+// Inverted indexes on ranges and multiranges work by projecting the subtype of
+// the range onto the uint64 domain with an order-preserving (but not
+// necessarily injective) function, and covering the projection of each range
+// with a small number of cells of a binary partition of that domain. A cell
+// at depth d covers 2^(64-d) consecutive values, and is identified by its
+// first value and its depth. Two ranges can only overlap if one of the cells
+// of the first range is equal to, an ancestor of, or a descendant of one of
+// the cells of the second range.
+//
+// Each key is a byte string of the form <tag><start><depth>, which is in turn
+// encoded as a single bytes value. Since the start of a cell is encoded in
+// big-endian order, all of the descendants of a cell sort directly after it.
+const (
+	// rangeIndexMaxCells is the maximum number of cells used to cover a range.
+	// It is a trade-off between the number of index entries per range and the
+	// precision of the cover.
+	rangeIndexMaxCells = 4
+
+	rangeIndexTagEmpty = 0
+	rangeIndexTagCell  = 1
+	// rangeIndexTagEnd sorts after all cells, and is used as the end key of
+	// the spans of cells that end at the end of the domain.
+	rangeIndexTagEnd = 2
+)
+
+// rangeIndexCell is a cell of the binary partition of the uint64 domain.
+type rangeIndexCell struct {
+	start uint64
+	depth uint8
+}
+
+// size returns the number of values covered by the cell, or 0 if the cell is
+// the root of the partition, which covers the whole domain.
+func (c rangeIndexCell) size() uint64 {
+	return 1 << (64 - uint(c.depth))
+}
+
+// encode returns the encoding of the cell as an inverted index key.
+func (c rangeIndexCell) encode() []byte {
+	buf := make([]byte, 10)
+	buf[0] = rangeIndexTagCell
+	binary.BigEndian.PutUint64(buf[1:], c.start)
+	buf[9] = c.depth
+	return buf
+}
+
+// spanOfDescendants returns the span containing the key of the cell and of all
+// of its descendants.
+func (c rangeIndexCell) spanOfDescendants() inverted.Span {
+	span := inverted.Span{Start: encoding.EncodeBytesAscending(nil, c.encode())}
+	if end := c.start + c.size(); end == 0 {
+		// The cell extends to the end of the domain.
+		span.End = encoding.EncodeBytesAscending(nil, []byte{rangeIndexTagEnd})
+	} else {
+		span.End = encoding.EncodeBytesAscending(nil, rangeIndexCell{start: end}.encode())
+	}
+	return span
+}
+
+// coverRangeIndexInterval returns the cells that cover the closed interval
+// [lo, hi]. All cells have the same depth, which is the largest depth at which
+// at most rangeIndexMaxCells cells are needed.
+func coverRangeIndexInterval(lo, hi uint64) []rangeIndexCell {
+	for depth := 64; ; depth-- {
+		shift := uint(64 - depth)
+		first, last := lo>>shift, hi>>shift
+		if last-first >= rangeIndexMaxCells && depth > 0 {
+			continue
+		}
+		cells := make([]rangeIndexCell, 0, last-first+1)
+		for i := first; ; i++ {
+			cells = append(cells, rangeIndexCell{start: i << shift, depth: uint8(depth)})
+			if i == last {
+				return cells
+			}
+		}
+	}
+}
+
+// rangeIndexPoint projects a value of the subtype of a range onto the uint64
+// domain. The projection preserves the order of values, but different values
+// may have the same projection. Values of subtypes which cannot be projected
+// are not supported, and ok is false.
+func rangeIndexPoint(d tree.Datum) (_ uint64, ok bool) {
+	signed := func(i int64) uint64 {
+		return uint64(i) ^ (1 << 63)
+	}
+	float := func(f float64) uint64 {
+		if math.IsNaN(f) {
+			// NaN sorts after all other values.
+			return math.MaxUint64
+		}
+		bits := math.Float64bits(f)
+		if bits&(1<<63) != 0 {
+			return ^bits
+		}
+		return bits | (1 << 63)
+	}
+	switch t := tree.UnwrapDOidWrapper(d).(type) {
+	case *tree.DInt:
+		return signed(int64(*t)), true
+	case *tree.DDate:
+		return signed(t.UnixEpochDaysWithOrig()), true
+	case *tree.DTimestamp:
+		return signed(t.UnixMicro()), true
+	case *tree.DTimestampTZ:
+		return signed(t.UnixMicro()), true
+	case *tree.DFloat:
+		return float(float64(*t)), true
+	case *tree.DDecimal:
+		switch t.Form {
+		case apd.NaN, apd.NaNSignaling:
+			return math.MaxUint64, true
+		case apd.Infinite:
+			if t.Negative {
+				return 0, true
+			}
+			return math.MaxUint64, true
+		}
+		// Decimals which are too large for a float are rounded to infinity,
+		// which preserves their order.
+		f, _ := t.Float64()
+		return float(f), true
+	case *tree.DString:
+		var buf [8]byte
+		copy(buf[:], *t)
+		return binary.BigEndian.Uint64(buf[:]), true
+	}
+	return 0, false
+}
+
+// rangeIndexCells returns the cells which cover the non-empty range r. If the
+// subtype of the range cannot be projected onto the index domain, the root
+// cell is returned.
+func rangeIndexCells(r *tree.DRange) []rangeIndexCell {
+	lo, hi := uint64(0), uint64(math.MaxUint64)
+	if !r.Lower.IsInfinite() {
+		p, ok := rangeIndexPoint(r.Lower.Val)
+		if !ok {
+			return []rangeIndexCell{{}}
+		}
+		lo = p
+	}
+	if !r.Upper.IsInfinite() {
+		p, ok := rangeIndexPoint(r.Upper.Val)
+		if !ok {
+			return []rangeIndexCell{{}}
+		}
+		hi = p
+	}
+	return coverRangeIndexInterval(lo, hi)
+}
+
+// rangesOf returns the ranges of a range or multirange datum.
+func rangesOf(val tree.Datum) ([]*tree.DRange, error) {
+	switch t := tree.UnwrapDOidWrapper(val).(type) {
+	case *tree.DRange:
+		return []*tree.DRange{t}, nil
+	case *tree.DMultirange:
+		return t.Ranges, nil
+	}
+	return nil, errors.AssertionFailedf("expected range or multirange, found %s", val.ResolvedType().SQLStringForError())
+}
+
+// encodeRangeInvertedIndexTableKeys returns the inverted index keys for a
+// range or multirange. Empty ranges and multiranges produce a single key,
+// which is distinct from the keys of all non-empty ranges.
+func encodeRangeInvertedIndexTableKeys(val tree.Datum, inKey []byte) ([][]byte, error) {
+	ranges, err := rangesOf(val)
+	if err != nil {
+		return nil, err
+	}
+	var keys [][]byte
+	for _, r := range ranges {
+		if r.Empty {
+			continue
+		}
+		for _, c := range rangeIndexCells(r) {
+			keys = append(keys, c.encode())
+		}
+	}
+	if len(keys) == 0 {
+		keys = append(keys, []byte{rangeIndexTagEmpty})
+	}
+	// The ranges of a multirange may share cells, so deduplicate the keys.
+	sort.Slice(keys, func(i, j int) bool { return bytes.Compare(keys[i], keys[j]) < 0 })
+	outKeys := make([][]byte, 0, len(keys))
+	for i := range keys {
+		if i > 0 && bytes.Equal(keys[i], keys[i-1]) {
+			continue
+		}
+		outKey := make([]byte, len(inKey), len(inKey)+len(keys[i])+3)
+		copy(outKey, inKey)
+		outKeys = append(outKeys, encoding.EncodeBytesAscending(outKey, keys[i]))
+	}
+	return outKeys, nil
+}
+
+// EncodeRangeOverlapsInvertedIndexSpans returns the spans that must be scanned
+// in an inverted index on a range or multirange column to find the values that
+// could overlap with val, which is either a range, a multirange or a value of
+// the subtype of the range. If includeEmpty is true, the spans also include
+// empty ranges and multiranges, which is needed to evaluate a contained by
+// (<@) predicate.
+//
+// The spans are returned in an inverted.SpanExpression, which is never tight.
+// If val is an empty range or multirange and includeEmpty is false, a nil
+// expression is returned, since no values can overlap with it.
+func EncodeRangeOverlapsInvertedIndexSpans(
+	val tree.Datum, includeEmpty bool,
+) (inverted.Expression, error) {
+	var cells []rangeIndexCell
+	switch tree.UnwrapDOidWrapper(val).(type) {
+	case *tree.DRange, *tree.DMultirange:
+		ranges, err := rangesOf(val)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range ranges {
+			if !r.Empty {
+				cells = append(cells, rangeIndexCells(r)...)
+			}
+		}
+	default:
+		p, ok := rangeIndexPoint(val)
+		if !ok {
+			cells = []rangeIndexCell{{}}
+		} else {
+			cells = coverRangeIndexInterval(p, p)
+		}
+	}
+
+	var invertedExpr inverted.Expression
+	addSpan := func(span inverted.Span) {
+		spanExpr := inverted.ExprForSpan(span, false /* tight */)
+		if invertedExpr == nil {
+			invertedExpr = spanExpr
+		} else {
+			invertedExpr = inverted.Or(invertedExpr, spanExpr)
+		}
+	}
+	if includeEmpty {
+		addSpan(inverted.MakeSingleValSpan(encoding.EncodeBytesAscending(nil, []byte{rangeIndexTagEmpty})))
+	}
+	for _, c := range cells {
+		// Overlapping ranges must have a cell which is either a descendant of c
+		// or c itself, or which is an ancestor of c.
+		addSpan(c.spanOfDescendants())
+		for depth := uint8(0); depth < c.depth; depth++ {
+			shift := uint(64 - depth)
+			ancestor := rangeIndexCell{start: c.start >> shift << shift, depth: depth}
+			addSpan(inverted.MakeSingleValSpan(encoding.EncodeBytesAscending(nil, ancestor.encode())))
+		}
+	}
+	return invertedExpr, nil
+}
@@ -11,6 +11,7 @@
 package valueside
 
 import (
+	"github.com/cockroachdb/cockroach/pkg/geo/geometric"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
@@ -32,14 +33,17 @@ func encodeArray(d *tree.DArray, scratch []byte) ([]byte, error) {
 		return nil, err
 	}
 	header := arrayHeader{
-		hasNulls: d.HasNulls,
-		// TODO(justin): support multiple dimensions.
+		hasNulls:      d.HasNulls,
 		numDimensions: 1,
+		dims:          d.Dims,
 		elementType:   elementType,
 		length:        uint64(d.Len()),
 		// We don't encode the NULL bitmap in this function because we do it in lockstep with the
 		// main data.
 	}
+	if d.Dims != nil {
+		header.numDimensions = len(d.Dims)
+	}
 	scratch, err = encodeArrayHeader(header, scratch)
 	if err != nil {
 		return nil, err
@@ -92,6 +96,9 @@ func decodeArray(a *tree.DatumAlloc, arrayType *types.T, b []byte) (tree.Datum,
 			result.Array[i] = val
 		}
 	}
+	if err := result.SetDims(header.dims); err != nil {
+		return nil, b, err
+	}
 	return &result, b, nil
 }
 
@@ -105,6 +112,8 @@ type arrayHeader struct {
 	hasNulls bool
 	// numDimensions is the number of dimensions in the array.
 	numDimensions int
+	// dims, if set, are the non-default dimensions of the array.
+	dims []tree.ArrayDim
 	// elementType is the encoding type of the array elements.
 	elementType encoding.Type
 	// length is the total number of elements encoded.
@@ -141,7 +150,10 @@ func makeBitVec(src []byte, length int) (b, bitVec []byte) {
 	return src[nullBitmapNumBytes:], src[:nullBitmapNumBytes]
 }
 
-const hasNullFlag = 1 << 4
+const (
+	hasNullFlag = 1 << 4
+	hasDimsFlag = 1 << 5
+)
 
 // encodeArrayHeader is used by encodeArray to encode the header
 // at the beginning of the value encoding.
@@ -149,14 +161,24 @@ func encodeArrayHeader(h arrayHeader, buf []byte) ([]byte, error) {
 	// The header byte we append here is formatted as follows:
 	// * The low 4 bits encode the number of dimensions in the array.
 	// * The high 4 bits are flags, with the lowest representing whether the array
-	//   contains NULLs, and the rest reserved.
+	//   contains NULLs, the next representing whether the length and lower bound
+	//   of each dimension follow the total number of elements, and the rest
+	//   reserved. Arrays with a single dimension whose lower bound is 1 don't
+	//   encode their dimensions.
 	headerByte := h.numDimensions
 	if h.hasNulls {
 		headerByte = headerByte | hasNullFlag
 	}
+	if h.dims != nil {
+		headerByte = headerByte | hasDimsFlag
+	}
 	buf = append(buf, byte(headerByte))
 	buf = encoding.EncodeValueTag(buf, encoding.NoColumnID, h.elementType)
 	buf = encoding.EncodeNonsortingUvarint(buf, h.length)
+	for _, dim := range h.dims {
+		buf = encoding.EncodeNonsortingUvarint(buf, uint64(dim.Length))
+		buf = encoding.EncodeNonsortingStdlibVarint(buf, int64(dim.LowerBound))
+	}
 	return buf, nil
 }
 
@@ -167,6 +189,8 @@ func decodeArrayHeader(b []byte) (arrayHeader, []byte, error) {
 		return arrayHeader{}, b, errors.Errorf("buffer too small")
 	}
 	hasNulls := b[0]&hasNullFlag != 0
+	hasDims := b[0]&hasDimsFlag != 0
+	numDimensions := int(b[0] & 0x0f)
 	b = b[1:]
 	_, dataOffset, _, encType, err := encoding.DecodeValueTag(b)
 	if err != nil {
@@ -177,14 +201,33 @@ func decodeArrayHeader(b []byte) (arrayHeader, []byte, error) {
 	if err != nil {
 		return arrayHeader{}, b, err
 	}
+	var dims []tree.ArrayDim
+	if hasDims {
+		dims = make([]tree.ArrayDim, numDimensions)
+		for i := range dims {
+			var dimLength uint64
+			var lowerBound int64
+			b, _, dimLength, err = encoding.DecodeNonsortingUvarint(b)
+			if err != nil {
+				return arrayHeader{}, b, err
+			}
+			b, _, lowerBound, err = encoding.DecodeNonsortingStdlibVarint(b)
+			if err != nil {
+				return arrayHeader{}, b, err
+			}
+			dims[i] = tree.ArrayDim{Length: int(dimLength), LowerBound: int(lowerBound)}
+		}
+	} else {
+		numDimensions = 1
+	}
 	nullBitmap := []byte(nil)
 	if hasNulls {
 		b, nullBitmap = makeBitVec(b, int(length))
 	}
 	return arrayHeader{
-		hasNulls: hasNulls,
-		// TODO(justin): support multiple dimensions.
-		numDimensions: 1,
+		hasNulls:      hasNulls,
+		numDimensions: numDimensions,
+		dims:          dims,
 		elementType:   encType,
 		length:        length,
 		nullBitmap:    nullBitmap,
@@ -211,7 +254,8 @@ func DatumTypeToArrayElementEncodingType(t *types.T) (encoding.Type, error) {
 	case types.DecimalFamily:
 		return encoding.Decimal, nil
 	case types.BytesFamily, types.StringFamily, types.CollatedStringFamily,
-		types.EnumFamily, types.RefCursorFamily:
+		types.EnumFamily, types.RefCursorFamily, types.JsonpathFamily, types.GeometricFamily,
+		types.RangeFamily, types.MultirangeFamily:
 		return encoding.Bytes, nil
 	case types.TimestampFamily, types.TimestampTZFamily:
 		return encoding.Time, nil
@@ -323,6 +367,16 @@ func encodeArrayElement(b []byte, d tree.Datum) ([]byte, error) {
 	case *tree.DTSQuery:
 		encoded := tsearch.EncodeTSQueryPGBinary(nil, t.TSQuery)
 		return encoding.EncodeUntaggedBytesValue(b, encoded), nil
+	case *tree.DJsonpath:
+		return encoding.EncodeUntaggedBytesValue(b, []byte(t.Jsonpath.String())), nil
+	case *tree.DGeometric:
+		return encoding.EncodeUntaggedBytesValue(b, geometric.EncodeBinary(nil, t.Shape)), nil
+	case *tree.DRange, *tree.DMultirange:
+		encoded, err := encodeRangeOrMultirange(nil, t)
+		if err != nil {
+			return nil, err
+		}
+		return encoding.EncodeUntaggedBytesValue(b, encoded), nil
 	case *tree.DTSVector:
 		encoded, err := tsearch.EncodeTSVector(nil, t.TSVector)
 		if err != nil {
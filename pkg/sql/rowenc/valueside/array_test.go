@@ -109,6 +109,22 @@ func TestArrayEncoding(t *testing.T) {
 				HasNulls: true,
 			},
 			[]byte{17, 3, 9, 6, 1, 2, 4, 6, 8, 10, 12},
+		}, {
+			"two-dimensional int array",
+			tree.DArray{
+				ParamTyp: types.Int,
+				Array:    tree.Datums{tree.NewDInt(1), tree.NewDInt(2), tree.NewDInt(3), tree.NewDInt(4)},
+				Dims:     []tree.ArrayDim{{Length: 2, LowerBound: 1}, {Length: 2, LowerBound: 1}},
+			},
+			[]byte{34, 3, 4, 2, 2, 2, 2, 2, 4, 6, 8},
+		}, {
+			"int array with a custom lower bound",
+			tree.DArray{
+				ParamTyp: types.Int,
+				Array:    tree.Datums{tree.NewDInt(1), tree.NewDInt(2)},
+				Dims:     []tree.ArrayDim{{Length: 2, LowerBound: 0}},
+			},
+			[]byte{33, 3, 2, 2, 0, 2, 4},
 		},
 	}
 
@@ -12,6 +12,7 @@ package valueside
 
 import (
 	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/cockroachdb/cockroach/pkg/geo/geometric"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
@@ -212,6 +213,30 @@ func DecodeUntaggedDatum(
 			return nil, b, err
 		}
 		return tree.NewDTSQuery(v), b, nil
+	case types.JsonpathFamily:
+		b, data, err := encoding.DecodeUntaggedBytesValue(buf)
+		if err != nil {
+			return nil, b, err
+		}
+		d, err := tree.ParseDJsonpath(string(data))
+		return d, b, err
+	case types.GeometricFamily:
+		b, data, err := encoding.DecodeUntaggedBytesValue(buf)
+		if err != nil {
+			return nil, b, err
+		}
+		s, err := geometric.DecodeBinary(tree.GeometricKind(t), data)
+		if err != nil {
+			return nil, b, err
+		}
+		return tree.NewDGeometric(s), b, nil
+	case types.RangeFamily, types.MultirangeFamily:
+		b, data, err := encoding.DecodeUntaggedBytesValue(buf)
+		if err != nil {
+			return nil, b, err
+		}
+		d, err := decodeRangeOrMultirange(a, t, data)
+		return d, b, err
 	case types.TSVectorFamily:
 		b, data, err := encoding.DecodeUntaggedBytesValue(buf)
 		if err != nil {
@@ -11,6 +11,7 @@
 package valueside
 
 import (
+	"github.com/cockroachdb/cockroach/pkg/geo/geometric"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
@@ -89,6 +90,16 @@ func Encode(appendTo []byte, colID ColumnIDDelta, val tree.Datum, scratch []byte
 			return nil, err
 		}
 		return encoding.EncodeTSQueryValue(appendTo, uint32(colID), encoded), nil
+	case *tree.DJsonpath:
+		return encoding.EncodeBytesValue(appendTo, uint32(colID), []byte(t.Jsonpath.String())), nil
+	case *tree.DGeometric:
+		return encoding.EncodeBytesValue(appendTo, uint32(colID), geometric.EncodeBinary(scratch, t.Shape)), nil
+	case *tree.DRange, *tree.DMultirange:
+		encoded, err := encodeRangeOrMultirange(scratch, t)
+		if err != nil {
+			return nil, err
+		}
+		return encoding.EncodeBytesValue(appendTo, uint32(colID), encoded), nil
 	case *tree.DTSVector:
 		encoded, err := tsearch.EncodeTSVector(scratch, t.TSVector)
 		if err != nil {
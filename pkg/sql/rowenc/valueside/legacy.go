@@ -12,6 +12,7 @@ package valueside
 
 import (
 	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/cockroachdb/cockroach/pkg/geo/geometric"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/lex"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
@@ -159,6 +160,26 @@ func MarshalLegacy(colType *types.T, val tree.Datum) (roachpb.Value, error) {
 			r.SetBytes(data)
 			return r, nil
 		}
+	case types.JsonpathFamily:
+		if v, ok := val.(*tree.DJsonpath); ok {
+			r.SetString(v.Jsonpath.String())
+			return r, nil
+		}
+	case types.GeometricFamily:
+		if v, ok := val.(*tree.DGeometric); ok {
+			r.SetBytes(geometric.EncodeBinary(nil, v.Shape))
+			return r, nil
+		}
+	case types.RangeFamily, types.MultirangeFamily:
+		switch val.(type) {
+		case *tree.DRange, *tree.DMultirange:
+			data, err := encodeRangeOrMultirange(nil, val)
+			if err != nil {
+				return r, err
+			}
+			r.SetBytes(data)
+			return r, nil
+		}
 	case types.TSVectorFamily:
 		if v, ok := val.(*tree.DTSVector); ok {
 			data, err := tsearch.EncodeTSVector(nil, v.TSVector)
@@ -412,6 +433,28 @@ func UnmarshalLegacy(a *tree.DatumAlloc, typ *types.T, value roachpb.Value) (tre
 			return nil, err
 		}
 		return tree.NewDTSQuery(vec), nil
+	case types.JsonpathFamily:
+		v, err := value.GetBytes()
+		if err != nil {
+			return nil, err
+		}
+		return tree.ParseDJsonpath(string(v))
+	case types.GeometricFamily:
+		v, err := value.GetBytes()
+		if err != nil {
+			return nil, err
+		}
+		s, err := geometric.DecodeBinary(tree.GeometricKind(typ), v)
+		if err != nil {
+			return nil, err
+		}
+		return tree.NewDGeometric(s), nil
+	case types.RangeFamily, types.MultirangeFamily:
+		v, err := value.GetBytes()
+		if err != nil {
+			return nil, err
+		}
+		return decodeRangeOrMultirange(a, typ, v)
 	case types.TSVectorFamily:
 		v, err := value.GetBytes()
 		if err != nil {
@@ -0,0 +1,152 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package valueside
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/errors"
+)
+
+// The flags which describe the shape of an encoded range. They are the same as
+// the flags of the binary representation of ranges in Postgres.
+const (
+	rangeEmpty          = 0x01
+	rangeLowerInclusive = 0x02
+	rangeUpperInclusive = 0x04
+	rangeLowerInfinite  = 0x08
+	rangeUpperInfinite  = 0x10
+)
+
+// encodeRange produces the value encoding for a range: a byte of flags,
+// followed by the value encoding of each finite bound.
+func encodeRange(b []byte, r *tree.DRange) ([]byte, error) {
+	var flags byte
+	switch {
+	case r.Empty:
+		flags = rangeEmpty
+	default:
+		if r.Lower.Inclusive {
+			flags |= rangeLowerInclusive
+		}
+		if r.Upper.Inclusive {
+			flags |= rangeUpperInclusive
+		}
+		if r.Lower.IsInfinite() {
+			flags |= rangeLowerInfinite
+		}
+		if r.Upper.IsInfinite() {
+			flags |= rangeUpperInfinite
+		}
+	}
+	b = append(b, flags)
+	var err error
+	for _, bound := range [2]tree.RangeBound{r.Lower, r.Upper} {
+		if bound.IsInfinite() {
+			continue
+		}
+		if b, err = Encode(b, NoColumnID, bound.Val, nil /* scratch */); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// decodeRange decodes a range from its value encoding. It is the counterpart
+// of encodeRange().
+func decodeRange(a *tree.DatumAlloc, t *types.T, b []byte) (*tree.DRange, []byte, error) {
+	if len(b) == 0 {
+		return nil, nil, errors.AssertionFailedf("invalid range encoding (empty)")
+	}
+	flags := b[0]
+	b = b[1:]
+	if flags&rangeEmpty != 0 {
+		return tree.NewEmptyDRange(t), b, nil
+	}
+	res := &tree.DRange{Typ: t}
+	var err error
+	for _, bound := range []struct {
+		*tree.RangeBound
+		inclusive, infinite byte
+	}{
+		{&res.Lower, rangeLowerInclusive, rangeLowerInfinite},
+		{&res.Upper, rangeUpperInclusive, rangeUpperInfinite},
+	} {
+		if flags&bound.infinite != 0 {
+			continue
+		}
+		bound.Inclusive = flags&bound.inclusive != 0
+		if bound.Val, b, err = Decode(a, t.RangeContents(), b); err != nil {
+			return nil, nil, err
+		}
+	}
+	return res, b, nil
+}
+
+// encodeMultirange produces the value encoding for a multirange: the number
+// of ranges, followed by the value encoding of each range.
+func encodeMultirange(b []byte, mr *tree.DMultirange) ([]byte, error) {
+	b = encoding.EncodeNonsortingUvarint(b, uint64(len(mr.Ranges)))
+	var err error
+	for _, r := range mr.Ranges {
+		if b, err = encodeRange(b, r); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// decodeMultirange decodes a multirange from its value encoding. It is the
+// counterpart of encodeMultirange().
+func decodeMultirange(
+	a *tree.DatumAlloc, t *types.T, b []byte,
+) (*tree.DMultirange, []byte, error) {
+	b, _, n, err := encoding.DecodeNonsortingUvarint(b)
+	if err != nil {
+		return nil, nil, err
+	}
+	res := &tree.DMultirange{Typ: t, Ranges: make([]*tree.DRange, n)}
+	for i := range res.Ranges {
+		if res.Ranges[i], b, err = decodeRange(a, t.RangeContents(), b); err != nil {
+			return nil, nil, err
+		}
+	}
+	return res, b, nil
+}
+
+// encodeRangeOrMultirange produces the value encoding for a range or a
+// multirange.
+func encodeRangeOrMultirange(b []byte, d tree.Datum) ([]byte, error) {
+	if mr, ok := d.(*tree.DMultirange); ok {
+		return encodeMultirange(b, mr)
+	}
+	return encodeRange(b, d.(*tree.DRange))
+}
+
+// decodeRangeOrMultirange decodes a range or multirange of type t, which
+// must be the only contents of b.
+func decodeRangeOrMultirange(a *tree.DatumAlloc, t *types.T, b []byte) (tree.Datum, error) {
+	var d tree.Datum
+	var err error
+	if t.Family() == types.MultirangeFamily {
+		d, b, err = decodeMultirange(a, t, b)
+	} else {
+		d, b, err = decodeRange(a, t, b)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != 0 {
+		return nil, errors.AssertionFailedf("invalid %s encoding (%d trailing bytes)", t.Family(), len(b))
+	}
+	return d, nil
+}
@@ -40,6 +40,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/cockroachdb/errors"
 	"github.com/lib/pq/oid"
 )
@@ -297,11 +298,28 @@ func (b *builderState) CheckPrivilege(e scpb.Element, privilege privilege.Kind)
 	b.checkPrivilege(screl.GetDescID(e), privilege)
 }
 
+// HasPrivilege implements the scbuildstmt.PrivilegeChecker interface.
+func (b *builderState) HasPrivilege(e scpb.Element, privilege privilege.Kind) bool {
+	err := b.privilegeError(screl.GetDescID(e), privilege)
+	if err != nil && pgerror.GetPGCode(err) != pgcode.InsufficientPrivilege {
+		panic(err)
+	}
+	return err == nil
+}
+
 func (b *builderState) checkPrivilege(id catid.DescID, priv privilege.Kind) {
+	if err := b.privilegeError(id, priv); err != nil {
+		panic(err)
+	}
+}
+
+// privilegeError returns an error if the current user does not have the
+// specified privilege on the descriptor, or the USAGE privilege on its schema.
+func (b *builderState) privilegeError(id catid.DescID, priv privilege.Kind) error {
 	b.ensureDescriptor(id)
 	c := b.descCache[id]
 	if c.hasOwnership {
-		return
+		return nil
 	}
 	err, found := c.privileges[priv]
 	if !found {
@@ -311,18 +329,19 @@ func (b *builderState) checkPrivilege(id catid.DescID, priv privilege.Kind) {
 			scpb.ForEachSchemaParent(
 				b.QueryByID(id),
 				func(current scpb.Status, _ scpb.TargetStatus, e *scpb.SchemaParent) {
-					if current == scpb.Status_PUBLIC {
-						b.checkPrivilege(e.SchemaID, privilege.USAGE)
+					if err == nil && current == scpb.Status_PUBLIC {
+						err = b.privilegeError(e.SchemaID, privilege.USAGE)
 					}
 				},
 			)
+			if err != nil {
+				return err
+			}
 		}
 		err = b.auth.CheckPrivilege(b.ctx, c.desc, priv)
 		c.privileges[priv] = err
 	}
-	if err != nil {
-		panic(err)
-	}
+	return err
 }
 
 // CurrentUserHasAdminOrIsMemberOf implements the scbuildstmt.PrivilegeChecker interface.
@@ -607,6 +626,28 @@ func (b *builderState) ResolveTypeRef(ref tree.ResolvableTypeReference) scpb.Typ
 	return newTypeT(toType)
 }
 
+// isBuiltinTextSearchObjectName returns whether the given argument to a text
+// search builtin is a string literal naming a built-in configuration or
+// dictionary.
+func isBuiltinTextSearchObjectName(arg tree.Expr) bool {
+	for {
+		switch t := arg.(type) {
+		case *tree.AnnotateTypeExpr:
+			arg = t.Expr
+		case *tree.CastExpr:
+			arg = t.Expr
+		case *tree.ParenExpr:
+			arg = t.Expr
+		case *tree.StrVal:
+			return tsearch.IsBuiltinObject(t.RawString())
+		case *tree.DString:
+			return tsearch.IsBuiltinObject(string(*t))
+		default:
+			return false
+		}
+	}
+}
+
 func newTypeT(t *types.T) scpb.TypeT {
 	return scpb.TypeT{Type: t, ClosedTypeIDs: typedesc.GetTypeDescriptorClosure(t).Ordered()}
 }
@@ -624,6 +665,17 @@ func (b *builderState) WrapExpression(tableID catid.DescID, expr tree.Expr) *scp
 	if expr == nil {
 		return nil
 	}
+	// References to user-defined text search objects are only recorded by the
+	// legacy schema changer.
+	if err := schemaexpr.ForEachTextSearchObjectArg(expr, func(arg tree.Expr, _ bool) error {
+		if !isBuiltinTextSearchObjectName(arg) {
+			panic(scerrors.NotImplementedErrorf(expr,
+				"expressions that may use a user-defined text search object"))
+		}
+		return nil
+	}); err != nil {
+		panic(err)
+	}
 	// Collect type IDs.
 	var typeIDs catalog.DescriptorIDSet
 	{
@@ -952,6 +1004,12 @@ func (b *builderState) ResolveUserDefinedTypeType(
 	case descpb.TypeDescriptor_COMPOSITE:
 		b.ensureDescriptor(typ.GetID())
 		b.mustOwn(typ.GetID())
+	case descpb.TypeDescriptor_DOMAIN:
+		b.ensureDescriptor(typ.GetID())
+		b.mustOwn(typ.GetID())
+	case descpb.TypeDescriptor_RANGE:
+		b.ensureDescriptor(typ.GetID())
+		b.mustOwn(typ.GetID())
 	case descpb.TypeDescriptor_TABLE_IMPLICIT_RECORD_TYPE:
 		// Implicit record types are not directly modifiable.
 		panic(pgerror.Newf(pgcode.DependentObjectsStillExist,
@@ -1239,7 +1297,7 @@ func (b *builderState) ResolveRoutine(
 		panic(err)
 	}
 
-	paramTypes, err := routineObj.ParamTypes(b.ctx, b.cr)
+	paramTypes, err := routineObj.ParamTypes(b.ctx, b.cr, routineType)
 	if err != nil {
 		return nil
 	}
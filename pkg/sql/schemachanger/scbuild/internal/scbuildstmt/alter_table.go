@@ -132,6 +132,25 @@ func alterTableAddConstraintChecks(
 		return isV222Active(t, mode, activeVersion)
 	}
 
+	// Exclusion constraints are not supported in the declarative schema
+	// changer yet.
+	if _, ok := t.ConstraintDef.(*tree.ExclusionConstraintTableDef); ok {
+		return false
+	}
+
+	// Neither are deferrable constraints, whose deferrability is not part of
+	// the constraint elements.
+	switch d := t.ConstraintDef.(type) {
+	case *tree.ForeignKeyConstraintTableDef:
+		if d.Deferrability.Deferrable {
+			return false
+		}
+	case *tree.UniqueConstraintTableDef:
+		if d.Deferrability.Deferrable {
+			return false
+		}
+	}
+
 	// Start supporting all other ADD CONSTRAINTs from V23_1, including
 	// - ADD PRIMARY KEY NOT VALID
 	// - ADD UNIQUE [NOT VALID]
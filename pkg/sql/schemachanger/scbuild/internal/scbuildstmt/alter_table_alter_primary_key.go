@@ -194,9 +194,10 @@ func alterPKInPrimaryIndexAndItsTemp(
 				panic(fmt.Sprintf("table %v does not have a column named %v", tn.String(), col.Column))
 			}
 			ret = append(ret, indexColumnSpec{
-				columnID:  colID,
-				kind:      scpb.IndexColumn_KEY,
-				direction: indexColumnDirection(col.Direction),
+				columnID:   colID,
+				kind:       scpb.IndexColumn_KEY,
+				direction:  indexColumnDirection(col.Direction),
+				nullsOrder: indexColumnNullsOrder(b, col),
 			})
 			keyColIDsInIndex[colID] = true
 		}
@@ -245,6 +246,7 @@ func alterPKInPrimaryIndexAndItsTemp(
 				existingIndexCol.Kind = inColumn.kind
 				existingIndexCol.OrdinalInKind = ordinalInKind
 				existingIndexCol.Direction = inColumn.direction
+				existingIndexCol.NullsOrder = inColumn.nullsOrder
 				delete(uncoveredExistingIndexCols, existingIndexCol.ColumnID)
 			} else {
 				inIndexCol := &scpb.IndexColumn{
@@ -254,6 +256,7 @@ func alterPKInPrimaryIndexAndItsTemp(
 					OrdinalInKind: ordinalInKind,
 					Kind:          inColumn.kind,
 					Direction:     inColumn.direction,
+					NullsOrder:    inColumn.nullsOrder,
 				}
 				if isIndexFinal {
 					b.Add(inIndexCol)
@@ -372,7 +375,8 @@ func isNewPrimaryKeySameAsOldPrimaryKey(b BuildCtx, tbl *scpb.Table, t alterPrim
 		})
 		_, _, colElem := scpb.FindColumn(colElems)
 		if (oldPrimaryIndexKeyColumns[i].ColumnID != colElem.ColumnID) ||
-			oldPrimaryIndexKeyColumns[i].Direction != indexColumnDirection(col.Direction) {
+			oldPrimaryIndexKeyColumns[i].Direction != indexColumnDirection(col.Direction) ||
+			oldPrimaryIndexKeyColumns[i].NullsOrder != descpb.IndexColumnNullsOrder(col.Direction, col.NullsOrder) {
 			return false
 		}
 	}
@@ -689,9 +693,10 @@ func recreateAllSecondaryIndexes(
 				if ic.Kind == scpb.IndexColumn_KEY {
 					idxColIDs.Add(ic.ColumnID)
 					inColumns = append(inColumns, indexColumnSpec{
-						columnID:  ic.ColumnID,
-						kind:      scpb.IndexColumn_KEY,
-						direction: ic.Direction,
+						columnID:   ic.ColumnID,
+						kind:       scpb.IndexColumn_KEY,
+						direction:  ic.Direction,
+						nullsOrder: ic.NullsOrder,
 					})
 					if idx.IsInverted && ic.OrdinalInKind >= largestKeyOrdinal {
 						largestKeyOrdinal = ic.OrdinalInKind
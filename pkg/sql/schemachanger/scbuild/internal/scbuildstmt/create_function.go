@@ -28,6 +28,18 @@ func CreateFunction(b BuildCtx, n *tree.CreateRoutine) {
 	if n.Replace {
 		panic(scerrors.NotImplementedError(n))
 	}
+	// TODO(chengxiong): add elements for the security mode and the session
+	// variable settings of a routine.
+	for _, option := range n.Options {
+		switch t := option.(type) {
+		case tree.RoutineSecurity:
+			if t == tree.RoutineDefiner {
+				panic(scerrors.NotImplementedErrorf(n, "SECURITY DEFINER is not supported"))
+			}
+		case tree.RoutineSet:
+			panic(scerrors.NotImplementedErrorf(n, "SET options are not supported"))
+		}
+	}
 	b.IncrementSchemaChangeCreateCounter("function")
 
 	dbElts, scElts := b.ResolveTargetObject(n.Name.ToUnresolvedObjectName(), privilege.CREATE)
@@ -44,7 +56,7 @@ func CreateFunction(b BuildCtx, n *tree.CreateRoutine) {
 	existingFn := b.ResolveRoutine(
 		&tree.RoutineObj{
 			FuncName: n.Name,
-			Params:   n.Params,
+			Params:   n.InputParams(),
 		},
 		ResolveParams{
 			IsExistenceOptional: true,
@@ -60,6 +72,13 @@ func CreateFunction(b BuildCtx, n *tree.CreateRoutine) {
 		))
 	}
 
+	// Build the function body before the function element, since the return
+	// type of a routine with OUT parameters is determined while it is built.
+	refProvider := b.BuildReferenceProvider(n)
+	if refProvider.ReferencesTextSearchObjects() {
+		panic(scerrors.NotImplementedErrorf(n, "routines that may use a user-defined text search object are not supported"))
+	}
+
 	fnID := b.GenerateUniqueDescID()
 	fn := scpb.Function{
 		FunctionID:  fnID,
@@ -145,7 +164,6 @@ func CreateFunction(b BuildCtx, n *tree.CreateRoutine) {
 	for _, up := range ups {
 		b.Add(up)
 	}
-	refProvider := b.BuildReferenceProvider(n)
 	validateTypeReferences(b, refProvider, db.DatabaseID)
 	validateFunctionRelationReferences(b, refProvider, db.DatabaseID)
 	b.Add(b.WrapFunctionBody(fnID, fnBodyStr, lang, refProvider))
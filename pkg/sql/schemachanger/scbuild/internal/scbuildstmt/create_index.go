@@ -312,6 +312,13 @@ func processColNodeType(
 		panic(pgerror.New(pgcode.FeatureNotSupported,
 			"the last column in an inverted index cannot have the DESC option"))
 	}
+	// Disallow non-default NULLs orderings in inverted indexes.
+	if n.Inverted && descpb.IndexColumnNullsOrder(
+		columnNode.Direction, columnNode.NullsOrder,
+	) != catenumpb.IndexColumn_NULLS_DEFAULT {
+		panic(pgerror.New(pgcode.FeatureNotSupported,
+			"inverted indexes do not support non-default NULLS FIRST or NULLS LAST options"))
+	}
 	if n.Inverted && lastColIdx {
 		switch columnType.Type.Family() {
 		case types.ArrayFamily:
@@ -361,7 +368,18 @@ func processColNodeType(
 			}
 			invertedKind = catpb.InvertedIndexColumnKind_TRIGRAM
 			b.IncrementSchemaChangeIndexCounter("trigram_inverted")
-
+		case types.RangeFamily:
+			switch columnNode.OpClass {
+			case "range_ops", "":
+			default:
+				panic(newUndefinedOpclassError(columnNode.OpClass))
+			}
+		case types.MultirangeFamily:
+			switch columnNode.OpClass {
+			case "multirange_ops", "":
+			default:
+				panic(newUndefinedOpclassError(columnNode.OpClass))
+			}
 		}
 		relationElts := b.QueryByID(indexSpec.secondary.TableID)
 		scpb.ForEachIndexColumn(relationElts, func(current scpb.Status, target scpb.TargetStatus, e *scpb.IndexColumn) {
@@ -590,6 +608,7 @@ func addColumnsForSecondaryIndex(
 			OrdinalInKind: uint32(i),
 			Kind:          scpb.IndexColumn_KEY,
 			Direction:     indexColumnDirection(columnNode.Direction),
+			NullsOrder:    indexColumnNullsOrder(b, columnNode),
 			InvertedKind:  processColNodeType(b, n, idxSpec, string(colName), columnNode, columnTypeElem, i == lastColumnIdx),
 		})
 		keyColIDs.Add(colID)
@@ -212,6 +212,10 @@ type PrivilegeChecker interface {
 	// privilege for the element.
 	CheckPrivilege(e scpb.Element, privilege privilege.Kind)
 
+	// HasPrivilege returns true iff the current user has the specified
+	// privilege for the element.
+	HasPrivilege(e scpb.Element, privilege privilege.Kind) bool
+
 	// CurrentUserHasAdminOrIsMemberOf returns true iff the current user is (1)
 	// an admin or (2) has membership in the specified role.
 	CurrentUserHasAdminOrIsMemberOf(member username.SQLUsername) bool
@@ -394,4 +398,7 @@ type ReferenceProvider interface {
 	ReferencedTypes() catalog.DescriptorIDSet
 	// ReferencedRelationIDs Returns all referenced relation IDs.
 	ReferencedRelationIDs() catalog.DescriptorIDSet
+	// ReferencesTextSearchObjects returns whether the routine body may use a
+	// user-defined text search configuration or dictionary.
+	ReferencesTextSearchObjects() bool
 }
@@ -19,6 +19,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/errors"
 )
 
 func DropFunction(b BuildCtx, n *tree.DropRoutine) {
@@ -43,6 +44,7 @@ func DropFunction(b BuildCtx, n *tree.DropRoutine) {
 			continue
 		}
 		f.FuncName.ObjectNamePrefix = b.NamePrefix(fn)
+		checkDropRoutineKind(n, fn, elts)
 		if dropRestrictDescriptor(b, fn.FunctionID) {
 			toCheckBackRefs = append(toCheckBackRefs, fn.FunctionID)
 			_, _, fnName := scpb.FindFunctionName(elts)
@@ -65,3 +67,19 @@ func DropFunction(b BuildCtx, n *tree.DropRoutine) {
 		}
 	}
 }
+
+// checkDropRoutineKind panics if the statement drops an aggregate with DROP
+// FUNCTION or a non-aggregate function with DROP AGGREGATE.
+func checkDropRoutineKind(n *tree.DropRoutine, fn *scpb.Function, elts ElementResultSet) {
+	if n.Aggregate == fn.IsAggregate || n.Procedure {
+		return
+	}
+	_, _, fnName := scpb.FindFunctionName(elts)
+	if n.Aggregate {
+		panic(pgerror.Newf(pgcode.WrongObjectType, "function %s is not an aggregate", fnName.Name))
+	}
+	panic(errors.WithHint(
+		pgerror.Newf(pgcode.WrongObjectType, "%q is an aggregate function", fnName.Name),
+		"Use DROP AGGREGATE to drop aggregate functions.",
+	))
+}
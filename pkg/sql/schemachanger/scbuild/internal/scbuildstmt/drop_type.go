@@ -36,13 +36,23 @@ func DropType(b BuildCtx, n *tree.DropType) {
 		})
 		var typ scpb.Element
 		var typeID, arrayTypeID catid.DescID
-		if _, _, enum := scpb.FindEnumType(elts); enum != nil {
+		_, _, domain := scpb.FindDomainType(elts)
+		if n.Domain && domain == nil && !elts.IsEmpty() {
+			panic(pgerror.Newf(pgcode.WrongObjectType, "%q is not a domain", name.Object()))
+		}
+		if domain != nil {
+			typeID, arrayTypeID = domain.TypeID, domain.ArrayTypeID
+			typ = domain
+		} else if _, _, enum := scpb.FindEnumType(elts); enum != nil {
 			b.IncrementEnumCounter(sqltelemetry.EnumDrop)
 			typeID, arrayTypeID = enum.TypeID, enum.ArrayTypeID
 			typ = enum
 		} else if _, _, composite := scpb.FindCompositeType(elts); composite != nil {
 			typeID, arrayTypeID = composite.TypeID, composite.ArrayTypeID
 			typ = composite
+		} else if _, _, rng := scpb.FindRangeType(elts); rng != nil {
+			typeID, arrayTypeID = rng.TypeID, rng.ArrayTypeID
+			typ = rng
 		} else {
 			continue
 		}
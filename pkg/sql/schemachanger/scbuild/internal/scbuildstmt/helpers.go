@@ -14,6 +14,7 @@ import (
 	"fmt"
 	"sort"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catenumpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
@@ -95,7 +96,7 @@ func undroppedElements(b BuildCtx, id catid.DescID) ElementResultSet {
 			// target states by the decomposition logic.
 			switch e.(type) {
 			case *scpb.Database, *scpb.Schema, *scpb.Table, *scpb.Sequence, *scpb.View, *scpb.EnumType, *scpb.AliasType,
-				*scpb.CompositeType:
+				*scpb.CompositeType, *scpb.DomainType, *scpb.RangeType:
 				panic(errors.Wrapf(pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
 					"object state is %s instead of PUBLIC, cannot be targeted by DROP", current),
 					"%s", errMsgPrefix(b, id)))
@@ -130,7 +131,7 @@ func errMsgPrefix(b BuildCtx, id catid.DescID) string {
 			typ = "sequence"
 		case *scpb.View:
 			typ = "view"
-		case *scpb.EnumType, *scpb.AliasType, *scpb.CompositeType:
+		case *scpb.EnumType, *scpb.AliasType, *scpb.CompositeType, *scpb.DomainType, *scpb.RangeType:
 			typ = "type"
 		case *scpb.Namespace:
 			// Set the name either from the first encountered Namespace element, or
@@ -180,7 +181,7 @@ func dropCascadeDescriptor(b BuildCtx, id catid.DescID) {
 			if t.IsTemporary {
 				panic(scerrors.NotImplementedErrorf(nil, "dropping a temporary view"))
 			}
-		case *scpb.EnumType, *scpb.AliasType, *scpb.CompositeType:
+		case *scpb.EnumType, *scpb.AliasType, *scpb.CompositeType, *scpb.DomainType, *scpb.RangeType:
 			break
 		default:
 			return
@@ -200,6 +201,10 @@ func dropCascadeDescriptor(b BuildCtx, id catid.DescID) {
 			dropCascadeDescriptor(next, t.ArrayTypeID)
 		case *scpb.CompositeType:
 			dropCascadeDescriptor(next, t.ArrayTypeID)
+		case *scpb.DomainType:
+			dropCascadeDescriptor(next, t.ArrayTypeID)
+		case *scpb.RangeType:
+			dropCascadeDescriptor(next, t.ArrayTypeID)
 		case *scpb.SequenceOwner:
 			dropCascadeDescriptor(next, t.SequenceID)
 		}
@@ -222,6 +227,10 @@ func dropCascadeDescriptor(b BuildCtx, id catid.DescID) {
 			dropCascadeDescriptor(next, t.TypeID)
 		case *scpb.CompositeType:
 			dropCascadeDescriptor(next, t.TypeID)
+		case *scpb.DomainType:
+			dropCascadeDescriptor(next, t.TypeID)
+		case *scpb.RangeType:
+			dropCascadeDescriptor(next, t.TypeID)
 		case *scpb.FunctionBody:
 			dropCascadeDescriptor(next, t.FunctionID)
 		case *scpb.Column, *scpb.ColumnType, *scpb.SecondaryIndexPartial:
@@ -523,6 +532,19 @@ func indexColumnDirection(d tree.Direction) catenumpb.IndexColumn_Direction {
 	}
 }
 
+// indexColumnNullsOrder returns the placement of NULLs in the index column of
+// the given index element. Non-default placements are only supported once
+// all nodes can encode the corresponding index keys.
+func indexColumnNullsOrder(b BuildCtx, elem tree.IndexElem) catenumpb.IndexColumn_NullsOrder {
+	nullsOrder := descpb.IndexColumnNullsOrder(elem.Direction, elem.NullsOrder)
+	if nullsOrder != catenumpb.IndexColumn_NULLS_DEFAULT &&
+		!b.EvalCtx().Settings.Version.IsActive(b, clusterversion.V24_1) {
+		panic(pgerror.New(pgcode.FeatureNotSupported,
+			"non-default NULLS FIRST or NULLS LAST options in indexes are not supported until version 24.1"))
+	}
+	return nullsOrder
+}
+
 // indexSpec holds an index element and its children.
 type indexSpec struct {
 	primary   *scpb.PrimaryIndex
@@ -735,6 +757,7 @@ type indexColumnSpec struct {
 	columnID     catid.ColumnID
 	kind         scpb.IndexColumn_Kind
 	direction    catenumpb.IndexColumn_Direction
+	nullsOrder   catenumpb.IndexColumn_NullsOrder
 	implicit     bool
 	invertedKind catpb.InvertedIndexColumnKind
 }
@@ -744,6 +767,7 @@ func makeIndexColumnSpec(ic *scpb.IndexColumn) indexColumnSpec {
 		columnID:     ic.ColumnID,
 		kind:         ic.Kind,
 		direction:    ic.Direction,
+		nullsOrder:   ic.NullsOrder,
 		implicit:     ic.Implicit,
 		invertedKind: ic.InvertedKind,
 	}
@@ -835,6 +859,7 @@ func makeSwapIndexSpec(
 				OrdinalInKind: ordinalInKind,
 				Kind:          cs.kind,
 				Direction:     cs.direction,
+				NullsOrder:    cs.nullsOrder,
 				Implicit:      cs.implicit,
 				InvertedKind:  cs.invertedKind,
 			})
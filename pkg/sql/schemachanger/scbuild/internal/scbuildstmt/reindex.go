@@ -0,0 +1,284 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package scbuildstmt
+
+import (
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/errors"
+)
+
+// Reindex implements REINDEX.
+//
+// Each index is rebuilt online by swapping it out for a new index with the
+// same definition, which is backfilled and validated like any other new
+// index. The old index remains in use until the new one becomes public, at
+// which point the two are swapped atomically.
+func Reindex(b BuildCtx, n *tree.Reindex) {
+	if n.Concurrently {
+		b.EvalCtx().ClientNoticeSender.BufferClientNotice(b,
+			pgnotice.Newf("CONCURRENTLY is not required as all indexes are rebuilt concurrently"))
+	}
+	switch n.Target {
+	case tree.ReindexIndex:
+		reindexIndex(b, n)
+	case tree.ReindexTable:
+		tn := n.Table.ToTableName()
+		elts := b.ResolveTable(n.Table, ResolveParams{
+			RequiredPrivilege: privilege.CREATE,
+		})
+		_, _, tbl := scpb.FindTable(elts)
+		tn.ObjectNamePrefix = b.NamePrefix(tbl)
+		b.SetUnresolvedNameAnnotation(n.Table, &tn)
+		reindexTable(b, tbl.TableID)
+	case tree.ReindexSchema:
+		for _, tableID := range schemaTablesToReindex(b, n.Schema) {
+			reindexTable(b, tableID)
+		}
+	case tree.ReindexDatabase:
+		for _, tableID := range databaseTablesToReindex(b, n.Database) {
+			reindexTable(b, tableID)
+		}
+	default:
+		panic(errors.AssertionFailedf("unknown REINDEX target %d", n.Target))
+	}
+}
+
+// reindexIndex rebuilds the index named in a REINDEX INDEX statement.
+func reindexIndex(b BuildCtx, n *tree.Reindex) {
+	idxElts := b.ResolveIndexByName(&n.Index, ResolveParams{
+		RequiredPrivilege: privilege.CREATE,
+	})
+	var tableID catid.DescID
+	var indexID catid.IndexID
+	if _, _, pie := scpb.FindPrimaryIndex(idxElts); pie != nil {
+		tableID, indexID = pie.TableID, pie.IndexID
+	} else if _, _, sie := scpb.FindSecondaryIndex(idxElts); sie != nil {
+		tableID, indexID = sie.TableID, sie.IndexID
+	} else {
+		panic(errors.AssertionFailedf("programming error: cannot find index element for %q", n.Index.Index))
+	}
+	checkTableCanBeReindexed(b, tableID)
+	panicIfIndexIsReferenced(b, tableID, indexID)
+	out := makeIndexSpec(b, tableID, indexID)
+	var sourceIndexID catid.IndexID
+	if out.primary != nil {
+		sourceIndexID = out.primary.IndexID
+	} else {
+		sourceIndexID = mustRetrieveCurrentPrimaryIndexElement(b, tableID).IndexID
+	}
+	in := swapInReplacementIndex(b, out, sourceIndexID)
+	logReplacementIndex(b, in)
+	b.IncrementSchemaChangeAlterCounter("index", "reindex")
+}
+
+// reindexTable rebuilds the primary index and all the secondary indexes of a
+// table. As with ALTER PRIMARY KEY, the replacement secondary indexes are
+// backfilled from the replacement primary index.
+func reindexTable(b BuildCtx, tableID catid.DescID) {
+	checkTableCanBeReindexed(b, tableID)
+	publicTableElts := b.QueryByID(tableID).Filter(publicTargetFilter)
+	var indexIDs []catid.IndexID
+	scpb.ForEachSecondaryIndex(publicTableElts, func(_ scpb.Status, _ scpb.TargetStatus, e *scpb.SecondaryIndex) {
+		indexIDs = append(indexIDs, e.IndexID)
+	})
+	sort.Slice(indexIDs, func(i, j int) bool { return indexIDs[i] < indexIDs[j] })
+	primary := mustRetrieveCurrentPrimaryIndexElement(b, tableID)
+	panicIfIndexIsReferenced(b, tableID, primary.IndexID)
+	for _, indexID := range indexIDs {
+		panicIfIndexIsReferenced(b, tableID, indexID)
+	}
+	newPrimary := swapInReplacementIndex(b, makeIndexSpec(b, tableID, primary.IndexID), primary.IndexID)
+	logReplacementIndex(b, newPrimary)
+	for _, indexID := range indexIDs {
+		in := swapInReplacementIndex(b, makeIndexSpec(b, tableID, indexID), newPrimary.indexID())
+		logReplacementIndex(b, in)
+	}
+	b.IncrementSchemaChangeAlterCounter("table", "reindex")
+}
+
+// swapInReplacementIndex drops the index described by out and adds an
+// identical index in its place, backfilled from sourceIndexID. The
+// replacement index is returned.
+func swapInReplacementIndex(b BuildCtx, out indexSpec, sourceIndexID catid.IndexID) indexSpec {
+	columns := make([]*scpb.IndexColumn, len(out.columns))
+	copy(columns, out.columns)
+	sort.Slice(columns, func(i, j int) bool {
+		if columns[i].Kind != columns[j].Kind {
+			return columns[i].Kind < columns[j].Kind
+		}
+		return columns[i].OrdinalInKind < columns[j].OrdinalInKind
+	})
+	inColumns := make([]indexColumnSpec, len(columns))
+	for i, ic := range columns {
+		inColumns[i] = makeIndexColumnSpec(ic)
+	}
+	in, temp := makeSwapIndexSpec(b, out, sourceIndexID, inColumns, false /* inUseTentativeIDs */)
+	if in.secondary != nil {
+		in.secondary.RecreateSourceIndexID = out.indexID()
+	}
+	out.apply(b.Drop)
+	in.apply(b.Add)
+	temp.apply(b.AddTransient)
+	return in
+}
+
+// logReplacementIndex writes an event log entry for a replacement index and
+// marks the end of the portion of the statement which created it.
+func logReplacementIndex(b BuildCtx, in indexSpec) {
+	if in.primary != nil {
+		b.LogEventForExistingTarget(in.primary)
+	} else {
+		b.LogEventForExistingTarget(in.secondary)
+	}
+	b.IncrementSubWorkID()
+}
+
+// checkTableCanBeReindexed panics if the indexes of a table cannot be rebuilt
+// by REINDEX.
+func checkTableCanBeReindexed(b BuildCtx, tableID catid.DescID) {
+	tableElts := b.QueryByID(tableID)
+	_, target, tbl := scpb.FindTable(tableElts)
+	_, _, ns := scpb.FindNamespace(tableElts)
+	if ns == nil {
+		panic(errors.AssertionFailedf("programming error: Namespace element not found"))
+	}
+	if tbl == nil {
+		panic(pgerror.Newf(pgcode.WrongObjectType, "%q is not a table", ns.Name))
+	}
+	if target != scpb.ToPublic {
+		panic(pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+			"table %q is being dropped, try again later", ns.Name))
+	}
+	if ns.DatabaseID == keys.SystemDatabaseID {
+		panic(pgerror.Newf(pgcode.InsufficientPrivilege,
+			"cannot reindex system table %q", ns.Name))
+	}
+	panicIfSchemaIsLocked(tableElts)
+	if !tableElts.Filter(notReachedTargetYetFilter).IsEmpty() {
+		panic(pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+			"cannot reindex table %q while it is undergoing another schema change", ns.Name))
+	}
+	if _, _, rbr := scpb.FindTableLocalityRegionalByRow(tableElts); rbr != nil {
+		panic(unimplemented.Newf("reindex regional by row",
+			"REINDEX is not supported on REGIONAL BY ROW table %q", ns.Name))
+	}
+	if _, _, zc := scpb.FindIndexZoneConfig(tableElts); zc != nil {
+		panic(unimplemented.Newf("reindex subzone",
+			"REINDEX is not supported on table %q because it has index or partition zone configurations", ns.Name))
+	}
+}
+
+// panicIfIndexIsReferenced panics if a view or a function references the
+// given index by ID, since these references would be left dangling once the
+// index is replaced.
+func panicIfIndexIsReferenced(b BuildCtx, tableID catid.DescID, indexID catid.IndexID) {
+	indexName := mustRetrieveIndexNameElem(b, tableID, indexID).Name
+	b.BackReferences(tableID).Filter(publicTargetFilter).ForEachTarget(func(target scpb.TargetStatus, e scpb.Element) {
+		switch el := e.(type) {
+		case *scpb.FunctionBody:
+			for _, ref := range el.UsesTables {
+				if ref.TableID == tableID && ref.IndexID == indexID {
+					fnName := b.QueryByID(el.FunctionID).FilterFunctionName().MustGetOneElement().Name
+					panic(sqlerrors.NewDependentBlocksOpError("reindex", "index", indexName, "function", fnName))
+				}
+			}
+		case *scpb.View:
+			for _, ref := range el.ForwardReferences {
+				if ref.ToID == tableID && ref.IndexID == indexID {
+					viewName := b.QueryByID(el.ViewID).FilterNamespace().MustGetOneElement().Name
+					panic(sqlerrors.NewDependentBlocksOpError("reindex", "index", indexName, "view", viewName))
+				}
+			}
+		}
+	})
+}
+
+// schemaTablesToReindex returns the IDs of the tables in the schema named in
+// a REINDEX SCHEMA statement on which the user has the CREATE privilege.
+func schemaTablesToReindex(b BuildCtx, name tree.ObjectNamePrefix) []catid.DescID {
+	name.ExplicitSchema = true
+	if name.CatalogName == "" {
+		b.ResolveDatabasePrefix(&name)
+	}
+	_, _, db := scpb.FindDatabase(b.ResolveDatabase(name.CatalogName, ResolveParams{
+		RequiredPrivilege: privilege.CONNECT,
+	}))
+	var tableIDs []catid.DescID
+	var found bool
+	scpb.ForEachSchemaParent(undroppedBackrefs(b, db.DatabaseID), func(_ scpb.Status, _ scpb.TargetStatus, sp *scpb.SchemaParent) {
+		schemaElts := b.QueryByID(sp.SchemaID)
+		if _, _, ns := scpb.FindNamespace(schemaElts); ns == nil || ns.Name != string(name.SchemaName) {
+			return
+		}
+		found = true
+		tableIDs = append(tableIDs, schemaTablesToReindexByID(b, sp.SchemaID)...)
+	})
+	if !found {
+		panic(sqlerrors.NewUndefinedSchemaError(name.Schema()))
+	}
+	return tableIDs
+}
+
+// databaseTablesToReindex returns the IDs of the tables in the database named
+// in a REINDEX DATABASE statement on which the user has the CREATE privilege.
+func databaseTablesToReindex(b BuildCtx, name tree.Name) []catid.DescID {
+	_, _, db := scpb.FindDatabase(b.ResolveDatabase(name, ResolveParams{
+		RequiredPrivilege: privilege.CONNECT,
+	}))
+	if db.DatabaseID == keys.SystemDatabaseID {
+		panic(pgerror.Newf(pgcode.InsufficientPrivilege,
+			"cannot reindex system database %q", name))
+	}
+	var tableIDs []catid.DescID
+	scpb.ForEachSchemaParent(undroppedBackrefs(b, db.DatabaseID), func(_ scpb.Status, _ scpb.TargetStatus, sp *scpb.SchemaParent) {
+		if _, _, sc := scpb.FindSchema(b.QueryByID(sp.SchemaID)); sc != nil && sc.IsTemporary {
+			return
+		}
+		tableIDs = append(tableIDs, schemaTablesToReindexByID(b, sp.SchemaID)...)
+	})
+	return tableIDs
+}
+
+// schemaTablesToReindexByID returns the IDs of the tables in a schema on which
+// the user has the CREATE privilege. The other tables are skipped with a
+// notice.
+func schemaTablesToReindexByID(b BuildCtx, schemaID catid.DescID) []catid.DescID {
+	var tableIDs []catid.DescID
+	scpb.ForEachSchemaChild(undroppedBackrefs(b, schemaID), func(_ scpb.Status, _ scpb.TargetStatus, sc *scpb.SchemaChild) {
+		tableElts := b.QueryByID(sc.ChildObjectID)
+		_, _, tbl := scpb.FindTable(tableElts)
+		if tbl == nil {
+			return
+		}
+		if !b.HasPrivilege(tbl, privilege.CREATE) {
+			_, _, ns := scpb.FindNamespace(tableElts)
+			b.EvalCtx().ClientNoticeSender.BufferClientNotice(b, pgnotice.Newf(
+				"skipping table %q because user %s does not have %s privilege on it",
+				ns.Name, b.SessionData().User(), privilege.CREATE,
+			))
+			return
+		}
+		tableIDs = append(tableIDs, tbl.TableID)
+	})
+	sort.Slice(tableIDs, func(i, j int) bool { return tableIDs[i] < tableIDs[j] })
+	return tableIDs
+}
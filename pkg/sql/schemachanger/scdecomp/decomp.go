@@ -217,6 +217,16 @@ func (w *walkCtx) walkType(typ catalog.TypeDescriptor) {
 				Name:            comp.GetElementLabel(i),
 			})
 		}
+	} else if dom := typ.AsDomainTypeDescriptor(); dom != nil {
+		w.ev(descriptorStatus(typ), &scpb.DomainType{
+			TypeID:      dom.GetID(),
+			ArrayTypeID: dom.GetArrayTypeID(),
+		})
+	} else if rng := typ.AsRangeTypeDescriptor(); rng != nil {
+		w.ev(descriptorStatus(typ), &scpb.RangeType{
+			TypeID:      rng.GetID(),
+			ArrayTypeID: rng.GetArrayTypeID(),
+		})
 	} else {
 		panic(errors.AssertionFailedf("unsupported type kind %q", typ.GetKind()))
 	}
@@ -276,6 +286,22 @@ func GetSequenceOptions(
 }
 
 func (w *walkCtx) walkRelation(tbl catalog.TableDescriptor) {
+	if tbl.IsForeignTable() {
+		panic(
+			scerrors.NotImplementedErrorf(
+				nil, // n
+				"foreign tables are not supported by the declarative schema changer",
+			),
+		)
+	}
+	if len(tbl.GetTriggers()) > 0 {
+		panic(
+			scerrors.NotImplementedErrorf(
+				nil, // n
+				"tables with triggers are not supported by the declarative schema changer",
+			),
+		)
+	}
 	switch {
 	case tbl.IsSequence():
 		w.ev(descriptorStatus(tbl), &scpb.Sequence{
@@ -602,6 +628,7 @@ func (w *walkCtx) walkIndex(tbl catalog.TableDescriptor, idx catalog.Index) {
 				OrdinalInKind: uint32(i),
 				Kind:          scpb.IndexColumn_KEY,
 				Direction:     cpy.KeyColumnDirections[i],
+				NullsOrder:    cpy.KeyColumnNullsOrder(i),
 				Implicit:      i < idx.ImplicitPartitioningColumnCount(),
 				InvertedKind:  invertedKind,
 			})
@@ -810,10 +837,11 @@ func (w *walkCtx) walkForeignKeyConstraint(
 func (w *walkCtx) walkFunction(fnDesc catalog.FunctionDescriptor) {
 	typeT := newTypeT(fnDesc.GetReturnType().Type)
 	fn := &scpb.Function{
-		FunctionID: fnDesc.GetID(),
-		ReturnSet:  fnDesc.GetReturnType().ReturnSet,
-		ReturnType: *typeT,
-		Params:     make([]scpb.Function_Parameter, len(fnDesc.GetParams())),
+		FunctionID:  fnDesc.GetID(),
+		ReturnSet:   fnDesc.GetReturnType().ReturnSet,
+		ReturnType:  *typeT,
+		Params:      make([]scpb.Function_Parameter, len(fnDesc.GetParams())),
+		IsAggregate: fnDesc.IsAggregate(),
 	}
 	for i, param := range fnDesc.GetParams() {
 		typeT := newTypeT(param.Type)
@@ -864,6 +892,13 @@ func (w *walkCtx) walkFunction(fnDesc catalog.FunctionDescriptor) {
 		UsesTypeIDs: fnDesc.GetDependsOnTypes(),
 		// TODO(chengxiong): add UsesFunctionIDs when UDF usage is allowed.
 	}
+	if agg := fnDesc.FuncDesc().Aggregate; agg != nil {
+		for _, id := range []descpb.ID{agg.StateFuncID, agg.FinalFuncID, agg.CombineFuncID} {
+			if id != descpb.InvalidID {
+				fnBody.UsesFunctionIDs = append(fnBody.UsesFunctionIDs, id)
+			}
+		}
+	}
 	dedupeColIDs := func(colIDs []catid.ColumnID) []catid.ColumnID {
 		ret := catalog.MakeTableColSet()
 		for _, id := range colIDs {
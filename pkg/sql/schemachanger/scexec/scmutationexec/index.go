@@ -376,6 +376,17 @@ func (i *immediateVisitor) AddColumnToIndex(ctx context.Context, op scop.AddColu
 		}
 		(*s)[n-1] = op.Direction
 	}
+	insertIntoNullsOrders := func(s *[]catenumpb.IndexColumn_NullsOrder) {
+		if op.NullsOrder == catenumpb.IndexColumn_NULLS_DEFAULT && len(*s) < n {
+			// Missing entries are implicitly the default, so the list is only
+			// extended when needed.
+			return
+		}
+		for delta := n - len(*s); delta > 0; delta-- {
+			*s = append(*s, 0)
+		}
+		(*s)[n-1] = op.NullsOrder
+	}
 	insertIntoIDs := func(s *[]descpb.ColumnID) {
 		for delta := n - len(*s); delta > 0; delta-- {
 			*s = append(*s, 0)
@@ -387,6 +398,7 @@ func (i *immediateVisitor) AddColumnToIndex(ctx context.Context, op scop.AddColu
 		insertIntoIDs(&indexDesc.KeyColumnIDs)
 		insertIntoNames(&indexDesc.KeyColumnNames)
 		insertIntoDirections(&indexDesc.KeyColumnDirections)
+		insertIntoNullsOrders(&indexDesc.KeyColumnNullsOrders)
 	case scpb.IndexColumn_KEY_SUFFIX:
 		insertIntoIDs(&indexDesc.KeySuffixColumnIDs)
 	case scpb.IndexColumn_STORED:
@@ -451,6 +463,9 @@ func (i *immediateVisitor) RemoveColumnFromIndex(
 			idx.KeyColumnNames = idx.KeyColumnNames[:i]
 			idx.KeyColumnIDs = idx.KeyColumnIDs[:i]
 			idx.KeyColumnDirections = idx.KeyColumnDirections[:i]
+			if len(idx.KeyColumnNullsOrders) > i {
+				idx.KeyColumnNullsOrders = idx.KeyColumnNullsOrders[:i]
+			}
 			if idx.Type == descpb.IndexDescriptor_INVERTED && i == len(idx.KeyColumnIDs)-1 {
 				idx.InvertedColumnKinds = nil
 			}
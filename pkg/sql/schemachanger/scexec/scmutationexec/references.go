@@ -18,7 +18,6 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scop"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
-	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/iterutil"
 	"github.com/cockroachdb/errors"
 )
@@ -359,6 +358,19 @@ func (i *immediateVisitor) RemoveTableColumnBackReferencesInFunctions(
 	return nil
 }
 
+func (i *immediateVisitor) RemoveBackReferenceInFunctions(
+	ctx context.Context, op scop.RemoveBackReferenceInFunctions,
+) error {
+	for _, id := range op.FunctionIDs {
+		fnDesc, err := i.checkOutFunction(ctx, id)
+		if err != nil {
+			return err
+		}
+		fnDesc.RemoveReference(op.BackReferencedDescriptorID)
+	}
+	return nil
+}
+
 // Look through `seqID`'s dependedOnBy slice, find the back-reference to `tblID`,
 // and update it to either
 //   - upsert `colID` to ColumnIDs field of that back-reference, if `forwardRefs` contains `seqID`; or
@@ -560,17 +572,7 @@ func (i *immediateVisitor) SetObjectParentID(ctx context.Context, op scop.SetObj
 		t.ParentID = sc.GetParentID()
 		t.ParentSchemaID = sc.GetID()
 
-		ol := descpb.SchemaDescriptor_FunctionSignature{
-			ID:          obj.GetID(),
-			ArgTypes:    make([]*types.T, len(t.GetParams())),
-			ReturnType:  t.GetReturnType().Type,
-			ReturnSet:   t.GetReturnType().ReturnSet,
-			IsProcedure: t.IsProcedure(),
-		}
-		for i := range t.Params {
-			ol.ArgTypes[i] = t.Params[i].Type
-		}
-		sc.AddFunction(obj.GetName(), ol)
+		sc.AddFunction(obj.GetName(), t.ToSignature())
 	}
 	return nil
 }
@@ -574,6 +574,14 @@ type RemoveTableColumnBackReferencesInFunctions struct {
 	FunctionIDs            []descpb.ID
 }
 
+// RemoveBackReferenceInFunctions removes back-references to a descriptor from
+// referenced functions.
+type RemoveBackReferenceInFunctions struct {
+	immediateMutationOp
+	BackReferencedDescriptorID descpb.ID
+	FunctionIDs                []descpb.ID
+}
+
 // SetColumnName renames a column.
 type SetColumnName struct {
 	immediateMutationOp
@@ -729,6 +737,7 @@ type AddColumnToIndex struct {
 	IndexID      descpb.IndexID
 	Kind         scpb.IndexColumn_Kind
 	Direction    catenumpb.IndexColumn_Direction
+	NullsOrder   catenumpb.IndexColumn_NullsOrder
 	Ordinal      uint32
 	InvertedKind catpb.InvertedIndexColumnKind
 }
@@ -90,6 +90,7 @@ type ImmediateMutationVisitor interface {
 	AddTableConstraintBackReferencesInFunctions(context.Context, AddTableConstraintBackReferencesInFunctions) error
 	RemoveTableConstraintBackReferencesFromFunctions(context.Context, RemoveTableConstraintBackReferencesFromFunctions) error
 	RemoveTableColumnBackReferencesInFunctions(context.Context, RemoveTableColumnBackReferencesInFunctions) error
+	RemoveBackReferenceInFunctions(context.Context, RemoveBackReferenceInFunctions) error
 	SetColumnName(context.Context, SetColumnName) error
 	SetIndexName(context.Context, SetIndexName) error
 	SetConstraintName(context.Context, SetConstraintName) error
@@ -470,6 +471,11 @@ func (op RemoveTableColumnBackReferencesInFunctions) Visit(ctx context.Context,
 	return v.RemoveTableColumnBackReferencesInFunctions(ctx, op)
 }
 
+// Visit is part of the ImmediateMutationOp interface.
+func (op RemoveBackReferenceInFunctions) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.RemoveBackReferenceInFunctions(ctx, op)
+}
+
 // Visit is part of the ImmediateMutationOp interface.
 func (op SetColumnName) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
 	return v.SetColumnName(ctx, op)
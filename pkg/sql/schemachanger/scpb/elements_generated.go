@@ -792,6 +792,43 @@ func (c *ElementCollection[E]) FilterDatabaseRoleSetting() *ElementCollection[*D
 	return (*ElementCollection[*DatabaseRoleSetting])(ret)
 }
 
+func (e DomainType) element() {}
+
+// Element implements ElementGetter.
+func (e * ElementProto_DomainType) Element() Element {
+	return e.DomainType
+}
+
+// ForEachDomainType iterates over elements of type DomainType.
+// Deprecated
+func ForEachDomainType(
+	c *ElementCollection[Element], fn func(current Status, target TargetStatus, e *DomainType),
+) {
+  c.FilterDomainType().ForEach(fn)
+}
+
+// FindDomainType finds the first element of type DomainType.
+// Deprecated
+func FindDomainType(
+	c *ElementCollection[Element],
+) (current Status, target TargetStatus, element *DomainType) {
+	if tc := c.FilterDomainType(); !tc.IsEmpty() {
+		var e Element
+		current, target, e = tc.Get(0)
+		element = e.(*DomainType)
+	}
+	return current, target, element
+}
+
+// DomainTypeElements filters elements of type DomainType.
+func (c *ElementCollection[E]) FilterDomainType() *ElementCollection[*DomainType] {
+	ret := c.genericFilter(func(_ Status, _ TargetStatus, e Element) bool {
+		_, ok := e.(*DomainType)
+		return ok
+	})
+	return (*ElementCollection[*DomainType])(ret)
+}
+
 func (e EnumType) element() {}
 
 // Element implements ElementGetter.
@@ -1532,6 +1569,43 @@ func (c *ElementCollection[E]) FilterPrimaryIndex() *ElementCollection[*PrimaryI
 	return (*ElementCollection[*PrimaryIndex])(ret)
 }
 
+func (e RangeType) element() {}
+
+// Element implements ElementGetter.
+func (e * ElementProto_RangeType) Element() Element {
+	return e.RangeType
+}
+
+// ForEachRangeType iterates over elements of type RangeType.
+// Deprecated
+func ForEachRangeType(
+	c *ElementCollection[Element], fn func(current Status, target TargetStatus, e *RangeType),
+) {
+  c.FilterRangeType().ForEach(fn)
+}
+
+// FindRangeType finds the first element of type RangeType.
+// Deprecated
+func FindRangeType(
+	c *ElementCollection[Element],
+) (current Status, target TargetStatus, element *RangeType) {
+	if tc := c.FilterRangeType(); !tc.IsEmpty() {
+		var e Element
+		current, target, e = tc.Get(0)
+		element = e.(*RangeType)
+	}
+	return current, target, element
+}
+
+// RangeTypeElements filters elements of type RangeType.
+func (c *ElementCollection[E]) FilterRangeType() *ElementCollection[*RangeType] {
+	ret := c.genericFilter(func(_ Status, _ TargetStatus, e Element) bool {
+		_, ok := e.(*RangeType)
+		return ok
+	})
+	return (*ElementCollection[*RangeType])(ret)
+}
+
 func (e RowLevelTTL) element() {}
 
 // Element implements ElementGetter.
@@ -2504,6 +2578,8 @@ func (e* ElementProto) SetElement(element Element) {
 			e.ElementOneOf = &ElementProto_DatabaseRegionConfig{ DatabaseRegionConfig: t}
 		case *DatabaseRoleSetting:
 			e.ElementOneOf = &ElementProto_DatabaseRoleSetting{ DatabaseRoleSetting: t}
+		case *DomainType:
+			e.ElementOneOf = &ElementProto_DomainType{ DomainType: t}
 		case *EnumType:
 			e.ElementOneOf = &ElementProto_EnumType{ EnumType: t}
 		case *EnumTypeValue:
@@ -2544,6 +2620,8 @@ func (e* ElementProto) SetElement(element Element) {
 			e.ElementOneOf = &ElementProto_Owner{ Owner: t}
 		case *PrimaryIndex:
 			e.ElementOneOf = &ElementProto_PrimaryIndex{ PrimaryIndex: t}
+		case *RangeType:
+			e.ElementOneOf = &ElementProto_RangeType{ RangeType: t}
 		case *RowLevelTTL:
 			e.ElementOneOf = &ElementProto_RowLevelTTL{ RowLevelTTL: t}
 		case *Schema:
@@ -2621,6 +2699,7 @@ func GetElementOneOfProtos() []interface{} {
 	((*ElementProto_DatabaseData)(nil)),
 	((*ElementProto_DatabaseRegionConfig)(nil)),
 	((*ElementProto_DatabaseRoleSetting)(nil)),
+	((*ElementProto_DomainType)(nil)),
 	((*ElementProto_EnumType)(nil)),
 	((*ElementProto_EnumTypeValue)(nil)),
 	((*ElementProto_ForeignKeyConstraint)(nil)),
@@ -2641,6 +2720,7 @@ func GetElementOneOfProtos() []interface{} {
 	((*ElementProto_Namespace)(nil)),
 	((*ElementProto_Owner)(nil)),
 	((*ElementProto_PrimaryIndex)(nil)),
+	((*ElementProto_RangeType)(nil)),
 	((*ElementProto_RowLevelTTL)(nil)),
 	((*ElementProto_Schema)(nil)),
 	((*ElementProto_SchemaChild)(nil)),
@@ -2694,6 +2774,7 @@ func GetElementTypes() []interface{} {
 	((*DatabaseData)(nil)),
 	((*DatabaseRegionConfig)(nil)),
 	((*DatabaseRoleSetting)(nil)),
+	((*DomainType)(nil)),
 	((*EnumType)(nil)),
 	((*EnumTypeValue)(nil)),
 	((*ForeignKeyConstraint)(nil)),
@@ -2714,6 +2795,7 @@ func GetElementTypes() []interface{} {
 	((*Namespace)(nil)),
 	((*Owner)(nil)),
 	((*PrimaryIndex)(nil)),
+	((*RangeType)(nil)),
 	((*RowLevelTTL)(nil)),
 	((*Schema)(nil)),
 	((*SchemaChild)(nil)),
@@ -26,6 +26,7 @@ func init() {
 					IndexID:      column.IndexID,
 					Kind:         column.Kind,
 					Direction:    column.Direction,
+					NullsOrder:   column.NullsOrder,
 					Ordinal:      column.OrdinalInKind,
 					InvertedKind: column.InvertedKind,
 				}
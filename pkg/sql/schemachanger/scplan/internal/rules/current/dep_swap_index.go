@@ -126,8 +126,8 @@ func init() {
 }
 
 // This rule ensures that when secondary indexes are re-created after a primary
-// index key is changed, that the secondary indexes are swapped in an atomic
-// manner, so that queries are not impacted by missing indexes.
+// index key is changed or by REINDEX, that the secondary indexes are swapped in
+// an atomic manner, so that queries are not impacted by missing indexes.
 func init() {
 	// This ia strict version of the rule that will only work, when a node
 	// is generating a plan on the latest master / 23.1. The StrictRecreate flag
@@ -141,7 +141,7 @@ func ColumnInSwappedInPrimaryIndex(
 }
 
 // IsPotentialSecondaryIndexSwap determines if a secondary index recreate is
-// occurring because of a primary key alter.
+// occurring because of a primary key alter or a REINDEX.
 func IsPotentialSecondaryIndexSwap(indexIdVar rel.Var, tableIDVar rel.Var) rel.Clauses {
 	oldIndex := MkNodeVars("old-index")
 	newIndex := MkNodeVars("new-index")
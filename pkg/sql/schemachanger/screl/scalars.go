@@ -132,6 +132,8 @@ func VersionSupportsElementUse(el scpb.Element, version clusterversion.ClusterVe
 		return version.IsActive(clusterversion.V23_1)
 	case *scpb.SequenceOption:
 		return version.IsActive(clusterversion.V23_2)
+	case *scpb.DomainType, *scpb.RangeType:
+		return version.IsActive(clusterversion.V24_1)
 	default:
 		panic(errors.AssertionFailedf("unknown element %T", el))
 	}
@@ -58,12 +58,15 @@ func init() {
 			signature := name + fn.Signature(true)
 			overloads[i].Oid = signatureMustHaveHardcodedOID(signature)
 			tree.OidToBuiltinName[overloads[i].Oid] = name
-			if _, ok := CastBuiltinNames[name]; ok {
+			if _, ok := CastBuiltinNames[name]; ok && fn.Types.Length() == 1 {
 				retOid := fn.ReturnType(nil).Oid()
+				srcTyp := fn.Types.GetAt(0)
 				if _, ok := CastBuiltinOIDs[retOid]; !ok {
 					CastBuiltinOIDs[retOid] = make(map[types.Family]oid.Oid, len(overloads))
+					CastBuiltinOIDsBySource[retOid] = make(map[oid.Oid]oid.Oid, len(overloads))
 				}
-				CastBuiltinOIDs[retOid][fn.Types.GetAt(0).Family()] = overloads[i].Oid
+				CastBuiltinOIDs[retOid][srcTyp.Family()] = overloads[i].Oid
+				CastBuiltinOIDsBySource[retOid][srcTyp.Oid()] = overloads[i].Oid
 			}
 		}
 		fDef := tree.NewFunctionDefinition(name, props, overloads)
@@ -45,12 +45,14 @@ const (
 	CategoryEnum                = "Enum"
 	CategoryFullTextSearch      = "Full Text Search"
 	CategoryGenerator           = "Set-returning"
+	CategoryGeometric           = "Geometric"
 	CategoryTrigram             = "Trigrams"
 	CategoryFuzzyStringMatching = "Fuzzy String Matching"
 	CategoryIDGeneration        = "ID generation"
 	CategoryJSON                = "JSONB"
 	CategoryMultiRegion         = "Multi-region"
 	CategoryMultiTenancy        = "Multi-tenancy"
+	CategoryRange               = "Range"
 	CategorySequences           = "Sequence"
 	CategorySpatial             = "Spatial"
 	CategoryString              = "String and byte"
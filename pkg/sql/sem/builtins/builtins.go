@@ -271,14 +271,14 @@ var regularBuiltins = map[string]builtinDefinition{
 	// TODO(pmattis): What string functions should also support types.Bytes?
 
 	"lower": makeBuiltin(tree.FunctionProperties{Category: builtinconstants.CategoryString},
-		stringOverload1(
+		withRangeBoundOverloads(true /* lower */, stringOverload1(
 			func(_ context.Context, _ *eval.Context, s string) (tree.Datum, error) {
 				return tree.NewDString(strings.ToLower(s)), nil
 			},
 			types.String,
 			"Converts all characters in `val` to their lower-case equivalents.",
 			volatility.Immutable,
-		),
+		))...,
 	),
 
 	"unaccent": makeBuiltin(tree.FunctionProperties{Category: builtinconstants.CategoryString},
@@ -302,14 +302,14 @@ var regularBuiltins = map[string]builtinDefinition{
 	),
 
 	"upper": makeBuiltin(tree.FunctionProperties{Category: builtinconstants.CategoryString},
-		stringOverload1(
+		withRangeBoundOverloads(false /* lower */, stringOverload1(
 			func(_ context.Context, _ *eval.Context, s string) (tree.Datum, error) {
 				return tree.NewDString(strings.ToUpper(s)), nil
 			},
 			types.String,
 			"Converts all characters in `val` to their to their upper-case equivalents.",
 			volatility.Immutable,
-		),
+		))...,
 	),
 
 	"prettify_statement": makeBuiltin(tree.FunctionProperties{Category: builtinconstants.CategoryString},
@@ -3623,9 +3623,7 @@ value if you rely on the HLC for accuracy.`,
 				dimen := int64(tree.MustBeDInt(args[1]))
 				return arrayLength(arr, dimen), nil
 			},
-			Info: "Calculates the length of `input` on the provided `array_dimension`. However, " +
-				"because CockroachDB doesn't yet support multi-dimensional arrays, the only supported" +
-				" `array_dimension` is **1**.",
+			Info:       "Calculates the length of `input` on the provided `array_dimension`.",
 			Volatility: volatility.Immutable,
 		},
 	),
@@ -3643,6 +3641,74 @@ value if you rely on the HLC for accuracy.`,
 		},
 	),
 
+	"array_ndims": makeBuiltin(arrayProps(),
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "input", Typ: types.AnyArray}},
+			ReturnType: tree.FixedReturnType(types.Int),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				arr := tree.MustBeDArray(args[0])
+				if arr.NumDims() == 0 {
+					return tree.DNull, nil
+				}
+				return tree.NewDInt(tree.DInt(arr.NumDims())), nil
+			},
+			Info:       "Returns the number of dimensions of `input`.",
+			Volatility: volatility.Immutable,
+		},
+	),
+
+	"array_dims": makeBuiltin(arrayProps(),
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "input", Typ: types.AnyArray}},
+			ReturnType: tree.FixedReturnType(types.String),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				arr := tree.MustBeDArray(args[0])
+				return arrayDims(arr), nil
+			},
+			Info:       "Returns a text representation of the dimensions of `input`, such as `[1:2][1:3]`.",
+			Volatility: volatility.Immutable,
+		},
+	),
+
+	"crdb_internal.array_set": makeBuiltin(
+		tree.FunctionProperties{
+			Category:     builtinconstants.CategoryArray,
+			Undocumented: true,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "array", Typ: types.AnyArray},
+				{Name: "subscripts", Typ: types.IntArray},
+				{Name: "elem", Typ: types.Any},
+			},
+			ReturnType: tree.IdentityReturnType(0),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				if args[0] == tree.DNull {
+					return tree.DNull, nil
+				}
+				arr := tree.MustBeDArray(args[0])
+				subscriptsArr := tree.MustBeDArray(args[1])
+				subscripts := make([]int, subscriptsArr.Len())
+				for i, d := range subscriptsArr.Array {
+					if d == tree.DNull {
+						return nil, pgerror.New(pgcode.NullValueNotAllowed,
+							"array subscript in assignment must not be null")
+					}
+					s := int64(tree.MustBeDInt(d))
+					if s < math.MinInt32 || s > math.MaxInt32 {
+						return nil, pgerror.New(pgcode.ArraySubscript, "array subscript out of range")
+					}
+					subscripts[i] = int(s)
+				}
+				return arr.SetSubscript(subscripts, args[2])
+			},
+			Info: "This function is used internally to assign to an element of an array, " +
+				"as in UPDATE t SET a[2] = 1. Returns NULL if `array` is NULL.",
+			Volatility:        volatility.Immutable,
+			CalledOnNullInput: true,
+		},
+	),
+
 	"array_lower": makeBuiltin(arrayProps(),
 		tree.Overload{
 			Types:      tree.ParamTypes{{Name: "input", Typ: types.AnyArray}, {Name: "array_dimension", Typ: types.Int}},
@@ -3652,9 +3718,7 @@ value if you rely on the HLC for accuracy.`,
 				dimen := int64(tree.MustBeDInt(args[1]))
 				return arrayLower(arr, dimen), nil
 			},
-			Info: "Calculates the minimum value of `input` on the provided `array_dimension`. " +
-				"However, because CockroachDB doesn't yet support multi-dimensional arrays, the only " +
-				"supported `array_dimension` is **1**.",
+			Info:       "Returns the lower bound of `input` on the provided `array_dimension`.",
 			Volatility: volatility.Immutable,
 		},
 	),
@@ -3666,11 +3730,9 @@ value if you rely on the HLC for accuracy.`,
 			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
 				arr := tree.MustBeDArray(args[0])
 				dimen := int64(tree.MustBeDInt(args[1]))
-				return arrayLength(arr, dimen), nil
+				return arrayUpper(arr, dimen), nil
 			},
-			Info: "Calculates the maximum value of `input` on the provided `array_dimension`. " +
-				"However, because CockroachDB doesn't yet support multi-dimensional arrays, the only " +
-				"supported `array_dimension` is **1**.",
+			Info:       "Returns the upper bound of `input` on the provided `array_dimension`.",
 			Volatility: volatility.Immutable,
 		},
 	),
@@ -3748,8 +3810,13 @@ value if you rely on the HLC for accuracy.`,
 				if args[0] == tree.DNull {
 					return tree.DNull, nil
 				}
+				arr := tree.MustBeDArray(args[0])
+				if arr.NumDims() > 1 {
+					return nil, pgerror.New(pgcode.FeatureNotSupported,
+						"removing elements from multidimensional arrays is not supported")
+				}
 				result := tree.NewDArray(typ)
-				for _, e := range tree.MustBeDArray(args[0]).Array {
+				for _, e := range arr.Array {
 					cmp, err := e.CompareError(evalCtx, args[1])
 					if err != nil {
 						return nil, err
@@ -3776,8 +3843,9 @@ value if you rely on the HLC for accuracy.`,
 				if args[0] == tree.DNull {
 					return tree.DNull, nil
 				}
+				arr := tree.MustBeDArray(args[0])
 				result := tree.NewDArray(typ)
-				for _, e := range tree.MustBeDArray(args[0]).Array {
+				for _, e := range arr.Array {
 					cmp, err := e.CompareError(evalCtx, args[1])
 					if err != nil {
 						return nil, err
@@ -3792,6 +3860,9 @@ value if you rely on the HLC for accuracy.`,
 						}
 					}
 				}
+				if err := result.SetDims(arr.Dims); err != nil {
+					return nil, err
+				}
 				return result, nil
 			},
 			Info:              "Replace all occurrences of `toreplace` in `array` with `replacewith`.",
@@ -3860,7 +3931,6 @@ value if you rely on the HLC for accuracy.`,
 	"tsvector_concat":                makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
 	"ts_debug":                       makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
 	"ts_headline":                    makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"ts_lexize":                      makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
 	"websearch_to_tsquery":           makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
 	"array_to_tsvector":              makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
 	"get_current_ts_config":          makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
@@ -3956,14 +4026,6 @@ value if you rely on the HLC for accuracy.`,
 	// The behavior of both the JSON and JSONB data types in CockroachDB is
 	// similar to the behavior of the JSONB data type in Postgres.
 
-	"jsonb_path_exists":      makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 22513, Category: builtinconstants.CategoryJSON}),
-	"jsonb_path_exists_opr":  makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 22513, Category: builtinconstants.CategoryJSON}),
-	"jsonb_path_match":       makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 22513, Category: builtinconstants.CategoryJSON}),
-	"jsonb_path_match_opr":   makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 22513, Category: builtinconstants.CategoryJSON}),
-	"jsonb_path_query":       makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 22513, Category: builtinconstants.CategoryJSON}),
-	"jsonb_path_query_array": makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 22513, Category: builtinconstants.CategoryJSON}),
-	"jsonb_path_query_first": makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 22513, Category: builtinconstants.CategoryJSON}),
-
 	"json_remove_path": makeBuiltin(jsonProps(),
 		tree.Overload{
 			Types:      tree.ParamTypes{{Name: "val", Typ: types.Jsonb}, {Name: "path", Typ: types.StringArray}},
@@ -5454,6 +5516,35 @@ SELECT
 		},
 	),
 
+	"crdb_internal.check_domain_value": makeBuiltin(
+		tree.FunctionProperties{
+			Category:     builtinconstants.CategorySystemInfo,
+			Undocumented: true,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "value", Typ: types.Any},
+				{Name: "ok", Typ: types.Bool},
+				{Name: "errorCode", Typ: types.String},
+				{Name: "msg", Typ: types.String},
+			},
+			ReturnType: tree.IdentityReturnType(0),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				// Like CHECK constraints on tables, a constraint of a domain is only
+				// violated if it evaluates to false, not if it evaluates to NULL.
+				if args[1] != tree.DBoolFalse {
+					return args[0], nil
+				}
+				errCode := string(tree.MustBeDString(args[2]))
+				msg := string(tree.MustBeDString(args[3]))
+				return nil, pgerror.Newf(pgcode.MakeCode(errCode), "%s", msg)
+			},
+			Info:              "This function is used internally to enforce the constraints of domain types.",
+			Volatility:        volatility.Immutable,
+			CalledOnNullInput: true,
+		},
+	),
+
 	"crdb_internal.notice": makeBuiltin(
 		tree.FunctionProperties{
 			Category: builtinconstants.CategorySystemInfo,
@@ -6982,6 +7073,30 @@ Parameters:` + randgencfg.ConfigDoc,
 		},
 	),
 
+	"grouping": makeBuiltin(
+		tree.FunctionProperties{
+			Category: builtinconstants.CategoryComparison,
+		},
+		tree.Overload{
+			Types: tree.VariadicType{
+				VarType: types.Any,
+			},
+			ReturnType: tree.FixedReturnType(types.Int),
+			Fn: func(_ context.Context, _ *eval.Context, _ tree.Datums) (tree.Datum, error) {
+				// GROUPING is replaced with a constant by the optimizer, since its
+				// value depends only on the grouping set that produced the row.
+				return nil, errors.AssertionFailedf("grouping should have been replaced")
+			},
+			Info: "Returns a bit mask indicating which of the given GROUP BY " +
+				"expressions are not included in the grouping set of the current row. " +
+				"Bits are assigned with the rightmost argument corresponding to the " +
+				"least-significant bit; each bit is 0 if the corresponding expression " +
+				"is included in the grouping set, and 1 if it is not.",
+			Volatility:        volatility.Immutable,
+			CalledOnNullInput: true,
+		},
+	),
+
 	builtinconstants.GatewayRegionBuiltinName: makeBuiltin(
 		tree.FunctionProperties{
 			Category: builtinconstants.CategoryMultiRegion,
@@ -8593,25 +8708,221 @@ specified store on the node it's run from. One of 'mvccGC', 'merge', 'split',
 				if err != nil {
 					return nil, err
 				}
-				res := make(tree.Datums, len(resultTypes))
-				for i := 0; i < len(resultTypes); i++ {
-					if i < len(row) {
-						res[i], err = eval.PerformCastNoTruncate(ctx, evalCtx, row[i], resultTypes[i])
-						if err != nil {
-							return nil, err
-						}
-					} else {
-						res[i] = tree.DNull
-					}
+				if row == nil {
+					// There are no rows left to fetch.
+					return tree.DNull, nil
 				}
-				tup := tree.MakeDTuple(types.MakeTuple(resultTypes), res...)
-				return &tup, nil
+				return makePLpgSQLResultTuple(ctx, evalCtx, row, resultTypes)
 			},
 			Info:              "This function is used internally to implement the PLpgSQL FETCH and MOVE statements.",
 			Volatility:        volatility.Volatile,
 			CalledOnNullInput: true,
 		},
 	),
+	"crdb_internal.plpgsql_execute": makeBuiltin(tree.FunctionProperties{
+		Category:     builtinconstants.CategoryString,
+		Undocumented: true,
+	},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "query", Typ: types.String},
+				{Name: "params", Typ: types.Any},
+				{Name: "strict", Typ: types.Bool},
+				{Name: "resultTypes", Typ: types.Any},
+			},
+			ReturnType: tree.IdentityReturnType(3),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (_ tree.Datum, err error) {
+				if args[0] == tree.DNull {
+					return nil, pgerror.New(pgcode.NullValueNotAllowed, "query string argument of EXECUTE is null")
+				}
+				query := string(tree.MustBeDString(args[0]))
+				params := tree.MustBeDTuple(args[1]).D
+				strict := bool(tree.MustBeDBool(args[2]))
+				resultTypes := args[3].(tree.TypedExpr).ResolvedType().TupleContents()
+				qargs := make([]interface{}, len(params))
+				for i := range params {
+					qargs[i] = params[i]
+				}
+				it, err := evalCtx.Planner.QueryIteratorEx(
+					ctx, "plpgsql-execute", sessiondata.NoSessionDataOverride, query, qargs...,
+				)
+				if err != nil {
+					return nil, err
+				}
+				defer func() {
+					err = errors.CombineErrors(err, it.Close())
+				}()
+				// Only the first row is returned, but the query is always run to
+				// completion.
+				var row tree.Datums
+				var numRows int
+				var ok bool
+				for ok, err = it.Next(ctx); ok; ok, err = it.Next(ctx) {
+					if numRows == 0 {
+						row = append(tree.Datums(nil), it.Cur()...)
+					}
+					numRows++
+				}
+				if err != nil {
+					return nil, err
+				}
+				if strict {
+					if numRows == 0 {
+						return nil, pgerror.New(pgcode.NoDataFound, "query returned no rows")
+					} else if numRows > 1 {
+						return nil, pgerror.New(pgcode.TooManyRows, "query returned more than one row")
+					}
+				}
+				if row == nil {
+					return tree.DNull, nil
+				}
+				return makePLpgSQLResultTuple(ctx, evalCtx, row, resultTypes)
+			},
+			Info:              "This function is used internally to implement the PLpgSQL EXECUTE statement.",
+			Volatility:        volatility.Volatile,
+			CalledOnNullInput: true,
+		},
+	),
+	"crdb_internal.plpgsql_open_dynamic_cursor": makeBuiltin(tree.FunctionProperties{
+		Category:     builtinconstants.CategoryString,
+		Undocumented: true,
+	},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "name", Typ: types.RefCursor},
+				{Name: "scroll", Typ: types.Bool},
+				{Name: "query", Typ: types.String},
+				{Name: "params", Typ: types.Any},
+			},
+			ReturnType: tree.FixedReturnType(types.Int),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				if args[0] == tree.DNull {
+					return nil, errors.AssertionFailedf("expected non-null argument for plpgsql_open_dynamic_cursor")
+				}
+				if args[2] == tree.DNull {
+					return nil, pgerror.New(pgcode.NullValueNotAllowed, "query string argument of EXECUTE is null")
+				}
+				return tree.DNull, evalCtx.Planner.PLpgSQLOpenDynamicCursor(
+					ctx,
+					tree.Name(tree.MustBeDString(args[0])),
+					bool(tree.MustBeDBool(args[1])),
+					string(tree.MustBeDString(args[2])),
+					tree.MustBeDTuple(args[3]).D,
+				)
+			},
+			Info:              "This function is used internally to implement the PLpgSQL OPEN ... FOR EXECUTE statement.",
+			Volatility:        volatility.Volatile,
+			CalledOnNullInput: true,
+		},
+	),
+	"crdb_internal.plpgsql_return_query_execute": makeBuiltin(tree.FunctionProperties{
+		Category:     builtinconstants.CategoryString,
+		Undocumented: true,
+	},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "query", Typ: types.String},
+				{Name: "params", Typ: types.Any},
+				{Name: "resultType", Typ: types.Any},
+			},
+			ReturnType: tree.FixedReturnType(types.Int),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (_ tree.Datum, err error) {
+				if args[0] == tree.DNull {
+					return nil, pgerror.New(pgcode.NullValueNotAllowed, "query string argument of EXECUTE is null")
+				}
+				query := string(tree.MustBeDString(args[0]))
+				params := tree.MustBeDTuple(args[1]).D
+				// The result type is wrapped in a tuple with a single element, so
+				// that it is not lost for a NULL argument.
+				resultType := args[2].(tree.TypedExpr).ResolvedType().TupleContents()[0]
+				resultTypes := []*types.T{resultType}
+				if resultType.Family() == types.TupleFamily {
+					resultTypes = resultType.TupleContents()
+				}
+				qargs := make([]interface{}, len(params))
+				for i := range params {
+					qargs[i] = params[i]
+				}
+				it, err := evalCtx.Planner.QueryIteratorEx(
+					ctx, "plpgsql-return-query", sessiondata.NoSessionDataOverride, query, qargs...,
+				)
+				if err != nil {
+					return nil, err
+				}
+				defer func() {
+					err = errors.CombineErrors(err, it.Close())
+				}()
+				var ok bool
+				for ok, err = it.Next(ctx); ok; ok, err = it.Next(ctx) {
+					row := it.Cur()
+					if len(row) != len(resultTypes) {
+						return nil, errors.WithDetail(
+							pgerror.New(pgcode.DatatypeMismatch, "structure of query does not match function result type"),
+							"Number of returned columns does not match expected column count.",
+						)
+					}
+					res, err := makePLpgSQLResultTuple(ctx, evalCtx, row, resultTypes)
+					if err != nil {
+						return nil, err
+					}
+					if resultType.Family() != types.TupleFamily {
+						res = tree.MustBeDTuple(res).D[0]
+					}
+					if err = evalCtx.Planner.PLpgSQLReturnNext(ctx, res); err != nil {
+						return nil, err
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				return tree.DNull, nil
+			},
+			Info:              "This function is used internally to implement the PLpgSQL RETURN QUERY EXECUTE statement.",
+			Volatility:        volatility.Volatile,
+			CalledOnNullInput: true,
+		},
+	),
+}
+
+// makePLpgSQLResultTuple casts the given row to the given result types, and
+// returns the result as a tuple. Columns that are missing from the row are
+// NULL. It is used to assign the result of a query to PLpgSQL variables. If
+// there is a single composite result type, the entire row is assigned to it.
+func makePLpgSQLResultTuple(
+	ctx context.Context, evalCtx *eval.Context, row tree.Datums, resultTypes []*types.T,
+) (tree.Datum, error) {
+	if len(resultTypes) == 1 && resultTypes[0].Family() == types.TupleFamily &&
+		(len(row) != 1 || row[0].ResolvedType().Family() != types.TupleFamily) {
+		var inner tree.Datum
+		if types.IsRecordType(resultTypes[0]) {
+			typs := make([]*types.T, len(row))
+			for i := range row {
+				typs[i] = row[i].ResolvedType()
+			}
+			inner = tree.NewDTuple(types.MakeTuple(typs), row...)
+		} else {
+			var err error
+			inner, err = makePLpgSQLResultTuple(ctx, evalCtx, row, resultTypes[0].TupleContents())
+			if err != nil {
+				return nil, err
+			}
+		}
+		return tree.NewDTuple(types.MakeTuple(resultTypes), inner), nil
+	}
+	res := make(tree.Datums, len(resultTypes))
+	for i := 0; i < len(resultTypes); i++ {
+		if i < len(row) {
+			var err error
+			res[i], err = eval.PerformCastNoTruncate(ctx, evalCtx, row[i], resultTypes[i])
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			res[i] = tree.DNull
+		}
+	}
+	tup := tree.MakeDTuple(types.MakeTuple(resultTypes), res...)
+	return &tup, nil
 }
 
 var lengthImpls = func(incBitOverload bool) builtinDefinition {
@@ -10345,44 +10656,55 @@ func GenerateUniqueID(instanceID int32, timestamp uint64) tree.DInt {
 }
 
 func cardinality(arr *tree.DArray) tree.Datum {
-	if arr.ParamTyp.Family() != types.ArrayFamily {
-		return tree.NewDInt(tree.DInt(arr.Len()))
-	}
-	card := 0
-	for _, a := range arr.Array {
-		card += int(tree.MustBeDInt(cardinality(tree.MustBeDArray(a))))
+	return tree.NewDInt(tree.DInt(arr.Len()))
+}
+
+// arrayDim returns the dimension of arr numbered dim, starting at 1, or false
+// if there is no such dimension.
+func arrayDim(arr *tree.DArray, dim int64) (tree.ArrayDim, bool) {
+	dims := arr.Dimensions()
+	if dim < 1 || dim > int64(len(dims)) {
+		return tree.ArrayDim{}, false
 	}
-	return tree.NewDInt(tree.DInt(card))
+	return dims[dim-1], true
 }
 
 func arrayLength(arr *tree.DArray, dim int64) tree.Datum {
-	if arr.Len() == 0 || dim < 1 {
-		return tree.DNull
-	}
-	if dim == 1 {
-		return tree.NewDInt(tree.DInt(arr.Len()))
-	}
-	a, ok := tree.AsDArray(arr.Array[0])
+	d, ok := arrayDim(arr, dim)
 	if !ok {
 		return tree.DNull
 	}
-	return arrayLength(a, dim-1)
+	return tree.NewDInt(tree.DInt(d.Length))
 }
 
-var intOne = tree.NewDInt(tree.DInt(1))
-
 func arrayLower(arr *tree.DArray, dim int64) tree.Datum {
-	if arr.Len() == 0 || dim < 1 {
+	d, ok := arrayDim(arr, dim)
+	if !ok {
 		return tree.DNull
 	}
-	if dim == 1 {
-		return intOne
-	}
-	a, ok := tree.AsDArray(arr.Array[0])
+	return tree.NewDInt(tree.DInt(d.LowerBound))
+}
+
+func arrayUpper(arr *tree.DArray, dim int64) tree.Datum {
+	d, ok := arrayDim(arr, dim)
 	if !ok {
 		return tree.DNull
 	}
-	return arrayLower(a, dim-1)
+	return tree.NewDInt(tree.DInt(d.UpperBound()))
+}
+
+// arrayDims returns the text representation of the dimensions of arr, such
+// as [1:2][1:3], or NULL if the array is empty.
+func arrayDims(arr *tree.DArray) tree.Datum {
+	dims := arr.Dimensions()
+	if len(dims) == 0 {
+		return tree.DNull
+	}
+	var b strings.Builder
+	for _, d := range dims {
+		fmt.Fprintf(&b, "[%d:%d]", d.LowerBound, d.UpperBound())
+	}
+	return tree.NewDString(b.String())
 }
 
 func extractBuiltin() builtinDefinition {
@@ -11076,10 +11398,10 @@ func asJSONBuildObjectKey(
 			tree.FmtDataConversionConfig(dcc),
 		), nil
 	case *tree.DBitArray, *tree.DBool, *tree.DBox2D, *tree.DBytes, *tree.DDate,
-		*tree.DDecimal, *tree.DEnum, *tree.DFloat, *tree.DGeography,
-		*tree.DGeometry, *tree.DIPAddr, *tree.DInt, *tree.DInterval, *tree.DOid,
-		*tree.DOidWrapper, *tree.DPGLSN, *tree.DTime, *tree.DTimeTZ, *tree.DTimestamp,
-		*tree.DTSQuery, *tree.DTSVector, *tree.DUuid, *tree.DVoid:
+		*tree.DDecimal, *tree.DEnum, *tree.DFloat, *tree.DGeography, *tree.DGeometric,
+		*tree.DGeometry, *tree.DIPAddr, *tree.DInt, *tree.DInterval, *tree.DJsonpath,
+		*tree.DMultirange, *tree.DOid, *tree.DOidWrapper, *tree.DPGLSN, *tree.DRange,
+		*tree.DTime, *tree.DTimeTZ, *tree.DTimestamp, *tree.DTSQuery, *tree.DTSVector, *tree.DUuid, *tree.DVoid:
 		return tree.AsStringWithFlags(d, tree.FmtBareStrings), nil
 	default:
 		return "", errors.AssertionFailedf("unexpected type %T for key value", d)
@@ -2486,6 +2486,399 @@ var builtinOidsArray = []string{
 	2515: `crdb_internal.privilege_name(internal_key: string) -> string`,
 	2516: `crdb_internal.privilege_name(internal_key: string[]) -> string[]`,
 	2517: `jsonb_array_to_string_array(input: jsonb) -> string[]`,
+	2518: `grouping(anyelement...) -> int`,
+	2519: `pg_notify(channel: string, payload: string) -> void`,
+	2520: `crdb_internal.plpgsql_execute(query: string, params: anyelement, strict: bool, resultTypes: anyelement) -> anyelement`,
+	2521: `crdb_internal.plpgsql_open_dynamic_cursor(name: refcursor, scroll: bool, query: string, params: anyelement) -> int`,
+	2522: `crdb_internal.plpgsql_return_query_execute(query: string, params: anyelement, resultType: anyelement) -> int`,
+	2523: `jsonb_path_exists(target: jsonb, path: jsonpath) -> bool`,
+	2524: `jsonb_path_exists(target: jsonb, path: jsonpath, vars: jsonb) -> bool`,
+	2525: `jsonb_path_exists(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> bool`,
+	2526: `jsonb_path_exists_tz(target: jsonb, path: jsonpath) -> bool`,
+	2527: `jsonb_path_exists_tz(target: jsonb, path: jsonpath, vars: jsonb) -> bool`,
+	2528: `jsonb_path_exists_tz(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> bool`,
+	2529: `jsonb_path_match(target: jsonb, path: jsonpath) -> bool`,
+	2530: `jsonb_path_match(target: jsonb, path: jsonpath, vars: jsonb) -> bool`,
+	2531: `jsonb_path_match(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> bool`,
+	2532: `jsonb_path_match_tz(target: jsonb, path: jsonpath) -> bool`,
+	2533: `jsonb_path_match_tz(target: jsonb, path: jsonpath, vars: jsonb) -> bool`,
+	2534: `jsonb_path_match_tz(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> bool`,
+	2535: `jsonb_path_query(target: jsonb, path: jsonpath) -> jsonb`,
+	2536: `jsonb_path_query(target: jsonb, path: jsonpath, vars: jsonb) -> jsonb`,
+	2537: `jsonb_path_query(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> jsonb`,
+	2538: `jsonb_path_query_tz(target: jsonb, path: jsonpath) -> jsonb`,
+	2539: `jsonb_path_query_tz(target: jsonb, path: jsonpath, vars: jsonb) -> jsonb`,
+	2540: `jsonb_path_query_tz(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> jsonb`,
+	2541: `jsonb_path_query_array(target: jsonb, path: jsonpath) -> jsonb`,
+	2542: `jsonb_path_query_array(target: jsonb, path: jsonpath, vars: jsonb) -> jsonb`,
+	2543: `jsonb_path_query_array(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> jsonb`,
+	2544: `jsonb_path_query_array_tz(target: jsonb, path: jsonpath) -> jsonb`,
+	2545: `jsonb_path_query_array_tz(target: jsonb, path: jsonpath, vars: jsonb) -> jsonb`,
+	2546: `jsonb_path_query_array_tz(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> jsonb`,
+	2547: `jsonb_path_query_first(target: jsonb, path: jsonpath) -> jsonb`,
+	2548: `jsonb_path_query_first(target: jsonb, path: jsonpath, vars: jsonb) -> jsonb`,
+	2549: `jsonb_path_query_first(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> jsonb`,
+	2550: `jsonb_path_query_first_tz(target: jsonb, path: jsonpath) -> jsonb`,
+	2551: `jsonb_path_query_first_tz(target: jsonb, path: jsonpath, vars: jsonb) -> jsonb`,
+	2552: `jsonb_path_query_first_tz(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> jsonb`,
+	2553: `jsonb_path_exists_opr(target: jsonb, path: jsonpath) -> bool`,
+	2554: `jsonb_path_match_opr(target: jsonb, path: jsonpath) -> bool`,
+	2555: `jsonpathsend(jsonpath: jsonpath) -> bytes`,
+	2556: `jsonpathout(jsonpath: jsonpath) -> bytes`,
+	2557: `jsonpathrecv(input: anyelement) -> jsonpath`,
+	2558: `jsonpathin(input: anyelement) -> jsonpath`,
+	2559: `jsonpath(string: string) -> jsonpath`,
+	2560: `jsonpath(jsonpath: jsonpath) -> jsonpath`,
+	2561: `varchar(jsonpath: jsonpath) -> varchar`,
+	2562: `text(jsonpath: jsonpath) -> string`,
+	2563: `bpchar(jsonpath: jsonpath) -> char`,
+	2564: `name(jsonpath: jsonpath) -> name`,
+	2565: `char(jsonpath: jsonpath) -> "char"`,
+	2566: `crdb_internal.check_domain_value(value: anyelement, ok: bool, errorCode: string, msg: string) -> anyelement`,
+	2567: `array_ndims(input: anyelement[]) -> int`,
+	2568: `array_dims(input: anyelement[]) -> string`,
+	2569: `point_send(point: point) -> bytes`,
+	2570: `point_out(point: point) -> bytes`,
+	2571: `point_recv(input: anyelement) -> point`,
+	2572: `point_in(input: anyelement) -> point`,
+	2573: `lseg_send(lseg: lseg) -> bytes`,
+	2574: `lseg_out(lseg: lseg) -> bytes`,
+	2575: `lseg_recv(input: anyelement) -> lseg`,
+	2576: `lseg_in(input: anyelement) -> lseg`,
+	2577: `line_send(line: line) -> bytes`,
+	2578: `line_out(line: line) -> bytes`,
+	2579: `line_recv(input: anyelement) -> line`,
+	2580: `line_in(input: anyelement) -> line`,
+	2581: `box_send(box: box) -> bytes`,
+	2582: `box_out(box: box) -> bytes`,
+	2583: `box_recv(input: anyelement) -> box`,
+	2584: `box_in(input: anyelement) -> box`,
+	2585: `path_send(path: path) -> bytes`,
+	2586: `path_out(path: path) -> bytes`,
+	2587: `path_recv(input: anyelement) -> path`,
+	2588: `path_in(input: anyelement) -> path`,
+	2589: `polygon_send(polygon: polygon) -> bytes`,
+	2590: `polygon_out(polygon: polygon) -> bytes`,
+	2591: `polygon_recv(input: anyelement) -> polygon`,
+	2592: `polygon_in(input: anyelement) -> polygon`,
+	2593: `circle_send(circle: circle) -> bytes`,
+	2594: `circle_out(circle: circle) -> bytes`,
+	2595: `circle_recv(input: anyelement) -> circle`,
+	2596: `circle_in(input: anyelement) -> circle`,
+	2597: `point(string: string) -> point`,
+	2598: `point(point: point) -> point`,
+	2599: `point(box: box) -> point`,
+	2600: `point(circle: circle) -> point`,
+	2601: `point(lseg: lseg) -> point`,
+	2602: `point(polygon: polygon) -> point`,
+	2603: `point(geometry: geometry) -> point`,
+	2604: `lseg(string: string) -> lseg`,
+	2605: `lseg(lseg: lseg) -> lseg`,
+	2606: `lseg(box: box) -> lseg`,
+	2607: `line(string: string) -> line`,
+	2608: `line(line: line) -> line`,
+	2609: `box(string: string) -> box`,
+	2610: `box(box: box) -> box`,
+	2611: `box(circle: circle) -> box`,
+	2612: `box(point: point) -> box`,
+	2613: `box(polygon: polygon) -> box`,
+	2614: `path(string: string) -> path`,
+	2615: `path(path: path) -> path`,
+	2616: `path(polygon: polygon) -> path`,
+	2617: `path(geometry: geometry) -> path`,
+	2618: `polygon(string: string) -> polygon`,
+	2619: `polygon(polygon: polygon) -> polygon`,
+	2620: `polygon(box: box) -> polygon`,
+	2621: `polygon(circle: circle) -> polygon`,
+	2622: `polygon(path: path) -> polygon`,
+	2623: `polygon(geometry: geometry) -> polygon`,
+	2624: `circle(string: string) -> circle`,
+	2625: `circle(circle: circle) -> circle`,
+	2626: `circle(box: box) -> circle`,
+	2627: `circle(polygon: polygon) -> circle`,
+	2628: `geometry(point: point) -> geometry`,
+	2629: `geometry(path: path) -> geometry`,
+	2630: `geometry(polygon: polygon) -> geometry`,
+	2631: `varchar(point: point) -> varchar`,
+	2632: `text(point: point) -> string`,
+	2633: `bpchar(point: point) -> char`,
+	2634: `name(point: point) -> name`,
+	2635: `char(point: point) -> "char"`,
+	2636: `varchar(lseg: lseg) -> varchar`,
+	2637: `text(lseg: lseg) -> string`,
+	2638: `bpchar(lseg: lseg) -> char`,
+	2639: `name(lseg: lseg) -> name`,
+	2640: `char(lseg: lseg) -> "char"`,
+	2641: `varchar(line: line) -> varchar`,
+	2642: `text(line: line) -> string`,
+	2643: `bpchar(line: line) -> char`,
+	2644: `name(line: line) -> name`,
+	2645: `char(line: line) -> "char"`,
+	2646: `varchar(box: box) -> varchar`,
+	2647: `text(box: box) -> string`,
+	2648: `bpchar(box: box) -> char`,
+	2649: `name(box: box) -> name`,
+	2650: `char(box: box) -> "char"`,
+	2651: `varchar(path: path) -> varchar`,
+	2652: `text(path: path) -> string`,
+	2653: `bpchar(path: path) -> char`,
+	2654: `name(path: path) -> name`,
+	2655: `char(path: path) -> "char"`,
+	2656: `varchar(polygon: polygon) -> varchar`,
+	2657: `text(polygon: polygon) -> string`,
+	2658: `bpchar(polygon: polygon) -> char`,
+	2659: `name(polygon: polygon) -> name`,
+	2660: `char(polygon: polygon) -> "char"`,
+	2661: `varchar(circle: circle) -> varchar`,
+	2662: `text(circle: circle) -> string`,
+	2663: `bpchar(circle: circle) -> char`,
+	2664: `name(circle: circle) -> name`,
+	2665: `char(circle: circle) -> "char"`,
+	2666: `point(x: float, y: float) -> point`,
+	2667: `lseg(point1: point, point2: point) -> lseg`,
+	2668: `line(point1: point, point2: point) -> line`,
+	2669: `box(point1: point, point2: point) -> box`,
+	2670: `circle(center: point, radius: float) -> circle`,
+	2671: `polygon(npts: int, circle: circle) -> polygon`,
+	2672: `area(box: box) -> float`,
+	2673: `area(path: path) -> float`,
+	2674: `area(circle: circle) -> float`,
+	2675: `center(box: box) -> point`,
+	2676: `center(circle: circle) -> point`,
+	2677: `diameter(circle: circle) -> float`,
+	2678: `radius(circle: circle) -> float`,
+	2679: `height(box: box) -> float`,
+	2680: `width(box: box) -> float`,
+	2681: `npoints(path: path) -> int`,
+	2682: `npoints(polygon: polygon) -> int`,
+	2683: `isclosed(path: path) -> bool`,
+	2684: `isopen(path: path) -> bool`,
+	2685: `pclose(path: path) -> path`,
+	2686: `popen(path: path) -> path`,
+	2687: `geometric_distance(left: point, right: point) -> float`,
+	2688: `geometric_distance(left: point, right: lseg) -> float`,
+	2689: `geometric_distance(left: point, right: line) -> float`,
+	2690: `geometric_distance(left: point, right: box) -> float`,
+	2691: `geometric_distance(left: point, right: path) -> float`,
+	2692: `geometric_distance(left: point, right: polygon) -> float`,
+	2693: `geometric_distance(left: point, right: circle) -> float`,
+	2694: `geometric_distance(left: lseg, right: point) -> float`,
+	2695: `geometric_distance(left: lseg, right: lseg) -> float`,
+	2696: `geometric_distance(left: lseg, right: line) -> float`,
+	2697: `geometric_distance(left: lseg, right: box) -> float`,
+	2698: `geometric_distance(left: line, right: point) -> float`,
+	2699: `geometric_distance(left: line, right: lseg) -> float`,
+	2700: `geometric_distance(left: line, right: line) -> float`,
+	2701: `geometric_distance(left: box, right: point) -> float`,
+	2702: `geometric_distance(left: box, right: lseg) -> float`,
+	2703: `geometric_distance(left: box, right: box) -> float`,
+	2704: `geometric_distance(left: path, right: point) -> float`,
+	2705: `geometric_distance(left: path, right: path) -> float`,
+	2706: `geometric_distance(left: polygon, right: point) -> float`,
+	2707: `geometric_distance(left: polygon, right: polygon) -> float`,
+	2708: `geometric_distance(left: polygon, right: circle) -> float`,
+	2709: `geometric_distance(left: circle, right: point) -> float`,
+	2710: `geometric_distance(left: circle, right: polygon) -> float`,
+	2711: `geometric_distance(left: circle, right: circle) -> float`,
+	2712: `geometric_intersects(left: lseg, right: lseg) -> bool`,
+	2713: `geometric_intersects(left: lseg, right: line) -> bool`,
+	2714: `geometric_intersects(left: lseg, right: box) -> bool`,
+	2715: `geometric_intersects(left: line, right: lseg) -> bool`,
+	2716: `geometric_intersects(left: line, right: line) -> bool`,
+	2717: `geometric_intersects(left: line, right: box) -> bool`,
+	2718: `geometric_intersects(left: box, right: lseg) -> bool`,
+	2719: `geometric_intersects(left: box, right: line) -> bool`,
+	2720: `geometric_intersects(left: box, right: box) -> bool`,
+	2721: `geometric_intersects(left: path, right: path) -> bool`,
+	2722: `int4range_send(int4range: int4range) -> bytes`,
+	2723: `int4range_out(int4range: int4range) -> bytes`,
+	2724: `int4range_recv(input: anyelement) -> int4range`,
+	2725: `int4range_in(input: anyelement) -> int4range`,
+	2726: `int8range_send(int8range: int8range) -> bytes`,
+	2727: `int8range_out(int8range: int8range) -> bytes`,
+	2728: `int8range_recv(input: anyelement) -> int8range`,
+	2729: `int8range_in(input: anyelement) -> int8range`,
+	2730: `numrange_send(numrange: numrange) -> bytes`,
+	2731: `numrange_out(numrange: numrange) -> bytes`,
+	2732: `numrange_recv(input: anyelement) -> numrange`,
+	2733: `numrange_in(input: anyelement) -> numrange`,
+	2734: `tsrange_send(tsrange: tsrange) -> bytes`,
+	2735: `tsrange_out(tsrange: tsrange) -> bytes`,
+	2736: `tsrange_recv(input: anyelement) -> tsrange`,
+	2737: `tsrange_in(input: anyelement) -> tsrange`,
+	2738: `tstzrange_send(tstzrange: tstzrange) -> bytes`,
+	2739: `tstzrange_out(tstzrange: tstzrange) -> bytes`,
+	2740: `tstzrange_recv(input: anyelement) -> tstzrange`,
+	2741: `tstzrange_in(input: anyelement) -> tstzrange`,
+	2742: `daterange_send(daterange: daterange) -> bytes`,
+	2743: `daterange_out(daterange: daterange) -> bytes`,
+	2744: `daterange_recv(input: anyelement) -> daterange`,
+	2745: `daterange_in(input: anyelement) -> daterange`,
+	2746: `anyrange_send(anyrange: anyrange) -> bytes`,
+	2747: `anyrange_out(anyrange: anyrange) -> bytes`,
+	2748: `anyrange_recv(input: anyelement) -> anyrange`,
+	2749: `anyrange_in(input: anyelement) -> anyrange`,
+	2750: `int4multirange_send(int4multirange: int4multirange) -> bytes`,
+	2751: `int4multirange_out(int4multirange: int4multirange) -> bytes`,
+	2752: `int4multirange_recv(input: anyelement) -> int4multirange`,
+	2753: `int4multirange_in(input: anyelement) -> int4multirange`,
+	2754: `int8multirange_send(int8multirange: int8multirange) -> bytes`,
+	2755: `int8multirange_out(int8multirange: int8multirange) -> bytes`,
+	2756: `int8multirange_recv(input: anyelement) -> int8multirange`,
+	2757: `int8multirange_in(input: anyelement) -> int8multirange`,
+	2758: `nummultirange_send(nummultirange: nummultirange) -> bytes`,
+	2759: `nummultirange_out(nummultirange: nummultirange) -> bytes`,
+	2760: `nummultirange_recv(input: anyelement) -> nummultirange`,
+	2761: `nummultirange_in(input: anyelement) -> nummultirange`,
+	2762: `tsmultirange_send(tsmultirange: tsmultirange) -> bytes`,
+	2763: `tsmultirange_out(tsmultirange: tsmultirange) -> bytes`,
+	2764: `tsmultirange_recv(input: anyelement) -> tsmultirange`,
+	2765: `tsmultirange_in(input: anyelement) -> tsmultirange`,
+	2766: `tstzmultirange_send(tstzmultirange: tstzmultirange) -> bytes`,
+	2767: `tstzmultirange_out(tstzmultirange: tstzmultirange) -> bytes`,
+	2768: `tstzmultirange_recv(input: anyelement) -> tstzmultirange`,
+	2769: `tstzmultirange_in(input: anyelement) -> tstzmultirange`,
+	2770: `datemultirange_send(datemultirange: datemultirange) -> bytes`,
+	2771: `datemultirange_out(datemultirange: datemultirange) -> bytes`,
+	2772: `datemultirange_recv(input: anyelement) -> datemultirange`,
+	2773: `datemultirange_in(input: anyelement) -> datemultirange`,
+	2774: `anymultirange_send(anymultirange: anymultirange) -> bytes`,
+	2775: `anymultirange_out(anymultirange: anymultirange) -> bytes`,
+	2776: `anymultirange_recv(input: anyelement) -> anymultirange`,
+	2777: `anymultirange_in(input: anyelement) -> anymultirange`,
+	2778: `int4range(string: string) -> int4range`,
+	2779: `int4range(int4range: int4range) -> int4range`,
+	2780: `int8range(string: string) -> int8range`,
+	2781: `int8range(int8range: int8range) -> int8range`,
+	2782: `numrange(string: string) -> numrange`,
+	2783: `numrange(numrange: numrange) -> numrange`,
+	2784: `tsrange(string: string) -> tsrange`,
+	2785: `tsrange(tsrange: tsrange) -> tsrange`,
+	2786: `tstzrange(string: string) -> tstzrange`,
+	2787: `tstzrange(tstzrange: tstzrange) -> tstzrange`,
+	2788: `daterange(string: string) -> daterange`,
+	2789: `daterange(daterange: daterange) -> daterange`,
+	2790: `int4multirange(string: string) -> int4multirange`,
+	2791: `int4multirange(int4multirange: int4multirange) -> int4multirange`,
+	2792: `int8multirange(string: string) -> int8multirange`,
+	2793: `int8multirange(int8multirange: int8multirange) -> int8multirange`,
+	2794: `nummultirange(string: string) -> nummultirange`,
+	2795: `nummultirange(nummultirange: nummultirange) -> nummultirange`,
+	2796: `tsmultirange(string: string) -> tsmultirange`,
+	2797: `tsmultirange(tsmultirange: tsmultirange) -> tsmultirange`,
+	2798: `tstzmultirange(string: string) -> tstzmultirange`,
+	2799: `tstzmultirange(tstzmultirange: tstzmultirange) -> tstzmultirange`,
+	2800: `datemultirange(string: string) -> datemultirange`,
+	2801: `datemultirange(datemultirange: datemultirange) -> datemultirange`,
+	2802: `varchar(int4range: int4range) -> varchar`,
+	2803: `text(int4range: int4range) -> string`,
+	2804: `bpchar(int4range: int4range) -> char`,
+	2805: `name(int4range: int4range) -> name`,
+	2806: `char(int4range: int4range) -> "char"`,
+	2807: `varchar(int8range: int8range) -> varchar`,
+	2808: `text(int8range: int8range) -> string`,
+	2809: `bpchar(int8range: int8range) -> char`,
+	2810: `name(int8range: int8range) -> name`,
+	2811: `char(int8range: int8range) -> "char"`,
+	2812: `varchar(numrange: numrange) -> varchar`,
+	2813: `text(numrange: numrange) -> string`,
+	2814: `bpchar(numrange: numrange) -> char`,
+	2815: `name(numrange: numrange) -> name`,
+	2816: `char(numrange: numrange) -> "char"`,
+	2817: `varchar(tsrange: tsrange) -> varchar`,
+	2818: `text(tsrange: tsrange) -> string`,
+	2819: `bpchar(tsrange: tsrange) -> char`,
+	2820: `name(tsrange: tsrange) -> name`,
+	2821: `char(tsrange: tsrange) -> "char"`,
+	2822: `varchar(tstzrange: tstzrange) -> varchar`,
+	2823: `text(tstzrange: tstzrange) -> string`,
+	2824: `bpchar(tstzrange: tstzrange) -> char`,
+	2825: `name(tstzrange: tstzrange) -> name`,
+	2826: `char(tstzrange: tstzrange) -> "char"`,
+	2827: `varchar(daterange: daterange) -> varchar`,
+	2828: `text(daterange: daterange) -> string`,
+	2829: `bpchar(daterange: daterange) -> char`,
+	2830: `name(daterange: daterange) -> name`,
+	2831: `char(daterange: daterange) -> "char"`,
+	2832: `varchar(int4multirange: int4multirange) -> varchar`,
+	2833: `text(int4multirange: int4multirange) -> string`,
+	2834: `bpchar(int4multirange: int4multirange) -> char`,
+	2835: `name(int4multirange: int4multirange) -> name`,
+	2836: `char(int4multirange: int4multirange) -> "char"`,
+	2837: `varchar(int8multirange: int8multirange) -> varchar`,
+	2838: `text(int8multirange: int8multirange) -> string`,
+	2839: `bpchar(int8multirange: int8multirange) -> char`,
+	2840: `name(int8multirange: int8multirange) -> name`,
+	2841: `char(int8multirange: int8multirange) -> "char"`,
+	2842: `varchar(nummultirange: nummultirange) -> varchar`,
+	2843: `text(nummultirange: nummultirange) -> string`,
+	2844: `bpchar(nummultirange: nummultirange) -> char`,
+	2845: `name(nummultirange: nummultirange) -> name`,
+	2846: `char(nummultirange: nummultirange) -> "char"`,
+	2847: `varchar(tsmultirange: tsmultirange) -> varchar`,
+	2848: `text(tsmultirange: tsmultirange) -> string`,
+	2849: `bpchar(tsmultirange: tsmultirange) -> char`,
+	2850: `name(tsmultirange: tsmultirange) -> name`,
+	2851: `char(tsmultirange: tsmultirange) -> "char"`,
+	2852: `varchar(tstzmultirange: tstzmultirange) -> varchar`,
+	2853: `text(tstzmultirange: tstzmultirange) -> string`,
+	2854: `bpchar(tstzmultirange: tstzmultirange) -> char`,
+	2855: `name(tstzmultirange: tstzmultirange) -> name`,
+	2856: `char(tstzmultirange: tstzmultirange) -> "char"`,
+	2857: `varchar(datemultirange: datemultirange) -> varchar`,
+	2858: `text(datemultirange: datemultirange) -> string`,
+	2859: `bpchar(datemultirange: datemultirange) -> char`,
+	2860: `name(datemultirange: datemultirange) -> name`,
+	2861: `char(datemultirange: datemultirange) -> "char"`,
+	2862: `int4range(lower: int, upper: int) -> int4range`,
+	2863: `int4range(lower: int, upper: int, bounds: string) -> int4range`,
+	2864: `int4multirange(int4range...) -> int4multirange`,
+	2865: `int8range(lower: int, upper: int) -> int8range`,
+	2866: `int8range(lower: int, upper: int, bounds: string) -> int8range`,
+	2867: `int8multirange(int8range...) -> int8multirange`,
+	2868: `numrange(lower: decimal, upper: decimal) -> numrange`,
+	2869: `numrange(lower: decimal, upper: decimal, bounds: string) -> numrange`,
+	2870: `nummultirange(numrange...) -> nummultirange`,
+	2871: `tsrange(lower: timestamp, upper: timestamp) -> tsrange`,
+	2872: `tsrange(lower: timestamp, upper: timestamp, bounds: string) -> tsrange`,
+	2873: `tsmultirange(tsrange...) -> tsmultirange`,
+	2874: `tstzrange(lower: timestamptz, upper: timestamptz) -> tstzrange`,
+	2875: `tstzrange(lower: timestamptz, upper: timestamptz, bounds: string) -> tstzrange`,
+	2876: `tstzmultirange(tstzrange...) -> tstzmultirange`,
+	2877: `daterange(lower: date, upper: date) -> daterange`,
+	2878: `daterange(lower: date, upper: date, bounds: string) -> daterange`,
+	2879: `datemultirange(daterange...) -> datemultirange`,
+	2880: `lower(range: anyrange) -> anyelement`,
+	2881: `lower(multirange: anymultirange) -> anyelement`,
+	2882: `upper(range: anyrange) -> anyelement`,
+	2883: `upper(multirange: anymultirange) -> anyelement`,
+	2884: `isempty(range: anyrange) -> bool`,
+	2885: `isempty(multirange: anymultirange) -> bool`,
+	2886: `lower_inc(range: anyrange) -> bool`,
+	2887: `lower_inc(multirange: anymultirange) -> bool`,
+	2888: `upper_inc(range: anyrange) -> bool`,
+	2889: `upper_inc(multirange: anymultirange) -> bool`,
+	2890: `lower_inf(range: anyrange) -> bool`,
+	2891: `lower_inf(multirange: anymultirange) -> bool`,
+	2892: `upper_inf(range: anyrange) -> bool`,
+	2893: `upper_inf(multirange: anymultirange) -> bool`,
+	2894: `range_merge(left: anyrange, right: anyrange) -> anyelement`,
+	2895: `range_merge(multirange: anymultirange) -> anyelement`,
+	2896: `range_adjacent(left: anyrange, right: anyrange) -> bool`,
+	2897: `range_adjacent(left: anyrange, right: anymultirange) -> bool`,
+	2898: `range_adjacent(left: anymultirange, right: anyrange) -> bool`,
+	2899: `range_adjacent(left: anymultirange, right: anymultirange) -> bool`,
+	2900: `multirange(int4range: int4range) -> int4multirange`,
+	2901: `multirange(int8range: int8range) -> int8multirange`,
+	2902: `multirange(numrange: numrange) -> nummultirange`,
+	2903: `multirange(tsrange: tsrange) -> tsmultirange`,
+	2904: `multirange(tstzrange: tstzrange) -> tstzmultirange`,
+	2905: `multirange(daterange: daterange) -> datemultirange`,
+	2906: `crdb_internal.array_set(array: anyelement[], subscripts: int[], elem: anyelement) -> anyelement`,
+	2907: `trigger_send(trigger: trigger) -> bytes`,
+	2908: `trigger_recv(input: anyelement) -> trigger`,
+	2909: `trigger_out(trigger: trigger) -> bytes`,
+	2910: `trigger_in(input: anyelement) -> trigger`,
 }
 
 var builtinOidsBySignature map[string]oid.Oid
@@ -0,0 +1,283 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package builtins
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/geo/geometric"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
+)
+
+func init() {
+	for k, v := range geometricBuiltins {
+		v.props.Category = builtinconstants.CategoryGeometric
+		const enforceClass = true
+		registerBuiltin(k, v, tree.NormalClass, enforceClass)
+	}
+}
+
+// geometricOverload1 returns an overload of a function which takes a single
+// geometric value of the given type.
+func geometricOverload1(
+	typ *types.T, ret *types.T, info string, fn func(geometric.Shape) (tree.Datum, error),
+) tree.Overload {
+	return tree.Overload{
+		Types:      tree.ParamTypes{{Name: typ.String(), Typ: typ}},
+		ReturnType: tree.FixedReturnType(ret),
+		Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+			return fn(tree.MustBeDGeometric(args[0]).Shape)
+		},
+		Info:       info,
+		Volatility: volatility.Immutable,
+	}
+}
+
+// makeGeometricBinaryBuiltin returns the definition of a function which
+// implements a binary geometric operator, with an overload for every pair of
+// types the operator supports.
+func makeGeometricBinaryBuiltin(
+	op geometric.BinaryOp,
+	ret *types.T,
+	info string,
+	fn func(a, b geometric.Shape) (tree.Datum, bool),
+) builtinDefinition {
+	var overloads []tree.Overload
+	for left := geometric.PointKind; left <= geometric.CircleKind; left++ {
+		for right := geometric.PointKind; right <= geometric.CircleKind; right++ {
+			if !op.Supports(left, right) {
+				continue
+			}
+			leftTyp, rightTyp := tree.GeometricType(left), tree.GeometricType(right)
+			overloads = append(overloads, tree.Overload{
+				Types:      tree.ParamTypes{{Name: "left", Typ: leftTyp}, {Name: "right", Typ: rightTyp}},
+				ReturnType: tree.FixedReturnType(ret),
+				Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+					a, b := tree.MustBeDGeometric(args[0]).Shape, tree.MustBeDGeometric(args[1]).Shape
+					res, ok := fn(a, b)
+					if !ok {
+						return nil, errors.AssertionFailedf(
+							"operator not supported for %s and %s", a.Kind(), b.Kind())
+					}
+					return res, nil
+				},
+				Info:       info,
+				Volatility: volatility.Immutable,
+			})
+		}
+	}
+	return makeBuiltin(tree.FunctionProperties{}, overloads...)
+}
+
+// pathPoints returns the number of points in a path or polygon.
+func pathPoints(s geometric.Shape) (tree.Datum, error) {
+	switch t := s.(type) {
+	case geometric.Path:
+		return tree.NewDInt(tree.DInt(len(t.Points))), nil
+	case geometric.Polygon:
+		return tree.NewDInt(tree.DInt(len(t.Points))), nil
+	}
+	return nil, errors.AssertionFailedf("unexpected shape %s", s.Kind())
+}
+
+var geometricBuiltins = map[string]builtinDefinition{
+	"area": makeBuiltin(tree.FunctionProperties{},
+		geometricOverload1(types.Box, types.Float, "Returns the area of the box.",
+			func(s geometric.Shape) (tree.Datum, error) {
+				return tree.NewDFloat(tree.DFloat(s.(geometric.Box).Area())), nil
+			}),
+		geometricOverload1(types.Path, types.Float,
+			"Returns the area enclosed by the path, or NULL if the path is open.",
+			func(s geometric.Shape) (tree.Datum, error) {
+				area, ok := s.(geometric.Path).Area()
+				if !ok {
+					return tree.DNull, nil
+				}
+				return tree.NewDFloat(tree.DFloat(area)), nil
+			}),
+		geometricOverload1(types.Circle, types.Float, "Returns the area of the circle.",
+			func(s geometric.Shape) (tree.Datum, error) {
+				return tree.NewDFloat(tree.DFloat(s.(geometric.Circle).Area())), nil
+			}),
+	),
+	"center": makeBuiltin(tree.FunctionProperties{},
+		geometricOverload1(types.Box, types.Point, "Returns the center of the box.",
+			func(s geometric.Shape) (tree.Datum, error) {
+				return tree.NewDGeometric(s.(geometric.Box).Center()), nil
+			}),
+		geometricOverload1(types.Circle, types.Point, "Returns the center of the circle.",
+			func(s geometric.Shape) (tree.Datum, error) {
+				return tree.NewDGeometric(s.(geometric.Circle).Center), nil
+			}),
+	),
+	"diameter": makeBuiltin(tree.FunctionProperties{},
+		geometricOverload1(types.Circle, types.Float, "Returns the diameter of the circle.",
+			func(s geometric.Shape) (tree.Datum, error) {
+				return tree.NewDFloat(tree.DFloat(s.(geometric.Circle).Diameter())), nil
+			}),
+	),
+	"radius": makeBuiltin(tree.FunctionProperties{},
+		geometricOverload1(types.Circle, types.Float, "Returns the radius of the circle.",
+			func(s geometric.Shape) (tree.Datum, error) {
+				return tree.NewDFloat(tree.DFloat(s.(geometric.Circle).Radius)), nil
+			}),
+	),
+	"height": makeBuiltin(tree.FunctionProperties{},
+		geometricOverload1(types.Box, types.Float, "Returns the vertical size of the box.",
+			func(s geometric.Shape) (tree.Datum, error) {
+				return tree.NewDFloat(tree.DFloat(s.(geometric.Box).Height())), nil
+			}),
+	),
+	"width": makeBuiltin(tree.FunctionProperties{},
+		geometricOverload1(types.Box, types.Float, "Returns the horizontal size of the box.",
+			func(s geometric.Shape) (tree.Datum, error) {
+				return tree.NewDFloat(tree.DFloat(s.(geometric.Box).Width())), nil
+			}),
+	),
+	"npoints": makeBuiltin(tree.FunctionProperties{},
+		geometricOverload1(types.Path, types.Int, "Returns the number of points in the path.",
+			pathPoints),
+		geometricOverload1(types.Polygon, types.Int, "Returns the number of points in the polygon.",
+			pathPoints),
+	),
+	"isclosed": makeBuiltin(tree.FunctionProperties{},
+		geometricOverload1(types.Path, types.Bool, "Returns whether the path is closed.",
+			func(s geometric.Shape) (tree.Datum, error) {
+				return tree.MakeDBool(tree.DBool(s.(geometric.Path).Closed)), nil
+			}),
+	),
+	"isopen": makeBuiltin(tree.FunctionProperties{},
+		geometricOverload1(types.Path, types.Bool, "Returns whether the path is open.",
+			func(s geometric.Shape) (tree.Datum, error) {
+				return tree.MakeDBool(tree.DBool(!s.(geometric.Path).Closed)), nil
+			}),
+	),
+	"pclose": makeBuiltin(tree.FunctionProperties{},
+		geometricOverload1(types.Path, types.Path, "Converts the path to closed form.",
+			func(s geometric.Shape) (tree.Datum, error) {
+				p := s.(geometric.Path)
+				p.Closed = true
+				return tree.NewDGeometric(p), nil
+			}),
+	),
+	"popen": makeBuiltin(tree.FunctionProperties{},
+		geometricOverload1(types.Path, types.Path, "Converts the path to open form.",
+			func(s geometric.Shape) (tree.Datum, error) {
+				p := s.(geometric.Path)
+				p.Closed = false
+				return tree.NewDGeometric(p), nil
+			}),
+	),
+
+	// The following functions implement the <-> and ?# operators.
+	"geometric_distance": makeGeometricBinaryBuiltin(geometric.DistanceOp, types.Float,
+		"Returns the distance between the two values. Implements the <-> operator.",
+		func(a, b geometric.Shape) (tree.Datum, bool) {
+			d, ok := geometric.Distance(a, b)
+			return tree.NewDFloat(tree.DFloat(d)), ok
+		}),
+	"geometric_intersects": makeGeometricBinaryBuiltin(geometric.IntersectsOp, types.Bool,
+		"Returns whether the two values intersect. Implements the ?# operator.",
+		func(a, b geometric.Shape) (tree.Datum, bool) {
+			res, ok := geometric.Intersects(a, b)
+			return tree.MakeDBool(tree.DBool(res)), ok
+		}),
+}
+
+// geometricConstructors contains the overloads of the functions that build a
+// geometric value from its components, keyed by the type they return. These
+// functions have the same names as the casts to the geometric types, so the
+// overloads are added to the cast builtins.
+var geometricConstructors = map[oid.Oid][]tree.Overload{
+	oid.T_point: {{
+		Types:      tree.ParamTypes{{Name: "x", Typ: types.Float}, {Name: "y", Typ: types.Float}},
+		ReturnType: tree.FixedReturnType(types.Point),
+		Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+			x, y := tree.MustBeDFloat(args[0]), tree.MustBeDFloat(args[1])
+			return tree.NewDGeometric(geometric.Point{X: float64(x), Y: float64(y)}), nil
+		},
+		Info:       "Constructs a point from its coordinates.",
+		Volatility: volatility.Immutable,
+	}},
+	oid.T_lseg: {{
+		Types:      tree.ParamTypes{{Name: "point1", Typ: types.Point}, {Name: "point2", Typ: types.Point}},
+		ReturnType: tree.FixedReturnType(types.LSeg),
+		Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+			p1, p2 := tree.MustBeDGeometric(args[0]).Shape, tree.MustBeDGeometric(args[1]).Shape
+			return tree.NewDGeometric(geometric.LSeg{
+				P: [2]geometric.Point{p1.(geometric.Point), p2.(geometric.Point)},
+			}), nil
+		},
+		Info:       "Constructs a line segment from its endpoints.",
+		Volatility: volatility.Immutable,
+	}},
+	oid.T_line: {{
+		Types:      tree.ParamTypes{{Name: "point1", Typ: types.Point}, {Name: "point2", Typ: types.Point}},
+		ReturnType: tree.FixedReturnType(types.Line),
+		Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+			p1, p2 := tree.MustBeDGeometric(args[0]).Shape, tree.MustBeDGeometric(args[1]).Shape
+			l, err := geometric.MakeLine(p1.(geometric.Point), p2.(geometric.Point))
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDGeometric(l), nil
+		},
+		Info:       "Constructs the line that goes through the two points.",
+		Volatility: volatility.Immutable,
+	}},
+	oid.T_box: {{
+		Types:      tree.ParamTypes{{Name: "point1", Typ: types.Point}, {Name: "point2", Typ: types.Point}},
+		ReturnType: tree.FixedReturnType(types.Box),
+		Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+			p1, p2 := tree.MustBeDGeometric(args[0]).Shape, tree.MustBeDGeometric(args[1]).Shape
+			return tree.NewDGeometric(geometric.MakeBox(p1.(geometric.Point), p2.(geometric.Point))), nil
+		},
+		Info:       "Constructs the box that has the two points as opposite corners.",
+		Volatility: volatility.Immutable,
+	}},
+	oid.T_circle: {{
+		Types:      tree.ParamTypes{{Name: "center", Typ: types.Point}, {Name: "radius", Typ: types.Float}},
+		ReturnType: tree.FixedReturnType(types.Circle),
+		Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+			center := tree.MustBeDGeometric(args[0]).Shape.(geometric.Point)
+			radius := float64(tree.MustBeDFloat(args[1]))
+			if radius < 0 {
+				return nil, pgerror.New(pgcode.InvalidParameterValue, "circle radius cannot be negative")
+			}
+			return tree.NewDGeometric(geometric.Circle{Center: center, Radius: radius}), nil
+		},
+		Info:       "Constructs a circle from its center and radius.",
+		Volatility: volatility.Immutable,
+	}},
+	oid.T_polygon: {{
+		Types:      tree.ParamTypes{{Name: "npts", Typ: types.Int}, {Name: "circle", Typ: types.Circle}},
+		ReturnType: tree.FixedReturnType(types.Polygon),
+		Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+			n := int(tree.MustBeDInt(args[0]))
+			c := tree.MustBeDGeometric(args[1]).Shape.(geometric.Circle)
+			p, err := geometric.CircleToPolygon(c, n)
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDGeometric(p), nil
+		},
+		Info:       "Constructs a polygon with `npts` points that approximates the circle.",
+		Volatility: volatility.Immutable,
+	}},
+}
@@ -0,0 +1,294 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package builtins
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/jsonpath"
+)
+
+func init() {
+	for k, v := range jsonpathBuiltins {
+		v.props.Category = builtinconstants.CategoryJSON
+		// jsonb_path_query and jsonb_path_query_tz are of the Generator class,
+		// the rest are of the Normal class.
+		const enforceClass = false
+		registerBuiltin(k, v, tree.NormalClass, enforceClass)
+	}
+}
+
+// jsonpathArgs are the arguments shared by the jsonb_path_* functions.
+type jsonpathArgs struct {
+	target json.JSON
+	path   jsonpath.Jsonpath
+	opts   jsonpath.EvalOptions
+	// silent suppresses the errors that the SQL/JSON standard allows to be
+	// suppressed, such as missing keys and type mismatches.
+	silent bool
+}
+
+// makeJsonpathArgs unpacks the (target, path [, vars [, silent]]) arguments
+// of a jsonb_path_* function. If tz is true, the path is evaluated using the
+// session time zone.
+func makeJsonpathArgs(evalCtx *eval.Context, args tree.Datums, tz bool) jsonpathArgs {
+	a := jsonpathArgs{
+		target: tree.MustBeDJSON(args[0]).JSON,
+		path:   tree.MustBeDJsonpath(args[1]).Jsonpath,
+	}
+	if len(args) > 2 {
+		a.opts.Vars = tree.MustBeDJSON(args[2]).JSON
+	}
+	if len(args) > 3 {
+		a.silent = bool(tree.MustBeDBool(args[3]))
+	}
+	if tz {
+		a.opts.UseTZ = true
+		a.opts.Location = evalCtx.GetLocation()
+	}
+	return a
+}
+
+// eval returns the items produced by the path. In silent mode, the items
+// found before a suppressible error are returned without the error.
+func (a jsonpathArgs) eval() ([]json.JSON, error) {
+	res, err := jsonpath.Eval(a.path, a.target, a.opts)
+	if err != nil && !(a.silent && jsonpath.IsSuppressibleError(err)) {
+		return nil, err
+	}
+	return res, nil
+}
+
+// jsonpathParamTypes returns the parameter types of the overloads of a
+// jsonb_path_* function, which optionally take a vars object and a silent
+// flag.
+func jsonpathParamTypes() []tree.ParamTypes {
+	return []tree.ParamTypes{
+		{{Name: "target", Typ: types.Jsonb}, {Name: "path", Typ: types.Jsonpath}},
+		{
+			{Name: "target", Typ: types.Jsonb},
+			{Name: "path", Typ: types.Jsonpath},
+			{Name: "vars", Typ: types.Jsonb},
+		},
+		{
+			{Name: "target", Typ: types.Jsonb},
+			{Name: "path", Typ: types.Jsonpath},
+			{Name: "vars", Typ: types.Jsonb},
+			{Name: "silent", Typ: types.Bool},
+		},
+	}
+}
+
+// jsonpathVolatility returns the volatility of a jsonb_path_* function. The
+// _tz variants depend on the session time zone.
+func jsonpathVolatility(tz bool) volatility.V {
+	if tz {
+		return volatility.Stable
+	}
+	return volatility.Immutable
+}
+
+// jsonpathInfo returns the documentation of a jsonb_path_* function.
+func jsonpathInfo(info string, tz bool) string {
+	info += " If `vars` is specified, it is an object whose fields are the values " +
+		"of the variables referenced by `path`. If `silent` is true, the errors " +
+		"that the SQL/JSON standard allows to be suppressed are suppressed."
+	if tz {
+		info += " Comparisons of date and time values with and without time zones " +
+			"use the session time zone."
+	}
+	return info
+}
+
+// makeJsonpathBuiltin returns the definition of a scalar jsonb_path_*
+// function.
+func makeJsonpathBuiltin(
+	ret *types.T,
+	tz bool,
+	info string,
+	fn func(ctx context.Context, a jsonpathArgs) (tree.Datum, error),
+) builtinDefinition {
+	paramTypes := jsonpathParamTypes()
+	overloads := make([]tree.Overload, len(paramTypes))
+	for i := range paramTypes {
+		overloads[i] = tree.Overload{
+			Types:      paramTypes[i],
+			ReturnType: tree.FixedReturnType(ret),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return fn(ctx, makeJsonpathArgs(evalCtx, args, tz))
+			},
+			Info:       jsonpathInfo(info, tz),
+			Volatility: jsonpathVolatility(tz),
+		}
+	}
+	return makeBuiltin(tree.FunctionProperties{}, overloads...)
+}
+
+// makeJsonpathQueryBuiltin returns the definition of jsonb_path_query or
+// jsonb_path_query_tz.
+func makeJsonpathQueryBuiltin(tz bool) builtinDefinition {
+	paramTypes := jsonpathParamTypes()
+	overloads := make([]tree.Overload, len(paramTypes))
+	for i := range paramTypes {
+		overloads[i] = makeGeneratorOverload(
+			paramTypes[i],
+			types.Jsonb,
+			func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (eval.ValueGenerator, error) {
+				return &jsonpathQueryGenerator{args: makeJsonpathArgs(evalCtx, args, tz)}, nil
+			},
+			jsonpathInfo("Returns all JSON items returned by the JSON path for the specified JSON value.", tz),
+			jsonpathVolatility(tz),
+		)
+	}
+	return makeBuiltin(tree.FunctionProperties{ReturnLabels: []string{"jsonb_path_query"}}, overloads...)
+}
+
+func jsonpathExists(_ context.Context, a jsonpathArgs) (tree.Datum, error) {
+	exists, err := jsonpath.Exists(a.path, a.target, a.opts)
+	if err != nil {
+		if a.silent && jsonpath.IsSuppressibleError(err) {
+			return tree.DNull, nil
+		}
+		return nil, err
+	}
+	return tree.MakeDBool(tree.DBool(exists)), nil
+}
+
+func jsonpathMatch(_ context.Context, a jsonpathArgs) (tree.Datum, error) {
+	return eval.JsonpathMatch(a.target, a.path, a.opts, a.silent)
+}
+
+func jsonpathQueryArray(_ context.Context, a jsonpathArgs) (tree.Datum, error) {
+	res, err := a.eval()
+	if err != nil {
+		return nil, err
+	}
+	b := json.NewArrayBuilder(len(res))
+	for _, j := range res {
+		b.Add(j)
+	}
+	return tree.NewDJSON(b.Build()), nil
+}
+
+func jsonpathQueryFirst(_ context.Context, a jsonpathArgs) (tree.Datum, error) {
+	res, err := a.eval()
+	if err != nil {
+		return nil, err
+	}
+	if len(res) == 0 {
+		return tree.DNull, nil
+	}
+	return tree.NewDJSON(res[0]), nil
+}
+
+// jsonpathQueryGenerator is the generator for jsonb_path_query.
+type jsonpathQueryGenerator struct {
+	args  jsonpathArgs
+	items []json.JSON
+	buf   [1]tree.Datum
+}
+
+// ResolvedType implements the eval.ValueGenerator interface.
+func (g *jsonpathQueryGenerator) ResolvedType() *types.T {
+	return types.Jsonb
+}
+
+// Start implements the eval.ValueGenerator interface.
+func (g *jsonpathQueryGenerator) Start(_ context.Context, _ *kv.Txn) error {
+	var err error
+	g.items, err = g.args.eval()
+	return err
+}
+
+// Next implements the eval.ValueGenerator interface.
+func (g *jsonpathQueryGenerator) Next(_ context.Context) (bool, error) {
+	if len(g.items) == 0 {
+		return false, nil
+	}
+	g.buf[0] = tree.NewDJSON(g.items[0])
+	g.items = g.items[1:]
+	return true, nil
+}
+
+// Values implements the eval.ValueGenerator interface.
+func (g *jsonpathQueryGenerator) Values() (tree.Datums, error) {
+	return g.buf[:], nil
+}
+
+// Close implements the eval.ValueGenerator interface.
+func (g *jsonpathQueryGenerator) Close(_ context.Context) {}
+
+var jsonpathBuiltins = map[string]builtinDefinition{
+	"jsonb_path_exists": makeJsonpathBuiltin(types.Bool, false, /* tz */
+		"Returns whether the JSON path returns any item for the specified JSON value.",
+		jsonpathExists),
+	"jsonb_path_exists_tz": makeJsonpathBuiltin(types.Bool, true, /* tz */
+		"Returns whether the JSON path returns any item for the specified JSON value.",
+		jsonpathExists),
+	"jsonb_path_match": makeJsonpathBuiltin(types.Bool, false, /* tz */
+		"Returns the result of the JSON path predicate check for the specified JSON value.",
+		jsonpathMatch),
+	"jsonb_path_match_tz": makeJsonpathBuiltin(types.Bool, true, /* tz */
+		"Returns the result of the JSON path predicate check for the specified JSON value.",
+		jsonpathMatch),
+	"jsonb_path_query":    makeJsonpathQueryBuiltin(false /* tz */),
+	"jsonb_path_query_tz": makeJsonpathQueryBuiltin(true /* tz */),
+	"jsonb_path_query_array": makeJsonpathBuiltin(types.Jsonb, false, /* tz */
+		"Returns all JSON items returned by the JSON path for the specified JSON value, as a JSON array.",
+		jsonpathQueryArray),
+	"jsonb_path_query_array_tz": makeJsonpathBuiltin(types.Jsonb, true, /* tz */
+		"Returns all JSON items returned by the JSON path for the specified JSON value, as a JSON array.",
+		jsonpathQueryArray),
+	"jsonb_path_query_first": makeJsonpathBuiltin(types.Jsonb, false, /* tz */
+		"Returns the first JSON item returned by the JSON path for the specified JSON value.",
+		jsonpathQueryFirst),
+	"jsonb_path_query_first_tz": makeJsonpathBuiltin(types.Jsonb, true, /* tz */
+		"Returns the first JSON item returned by the JSON path for the specified JSON value.",
+		jsonpathQueryFirst),
+
+	// The following functions implement the @? and @@ operators, which
+	// suppress errors.
+	"jsonb_path_exists_opr": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "target", Typ: types.Jsonb}, {Name: "path", Typ: types.Jsonpath}},
+			ReturnType: tree.FixedReturnType(types.Bool),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				a := makeJsonpathArgs(evalCtx, args, false /* tz */)
+				a.silent = true
+				return jsonpathExists(ctx, a)
+			},
+			Info:       "Returns whether the JSON path returns any item for the specified JSON value. Implements the @? operator.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"jsonb_path_match_opr": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "target", Typ: types.Jsonb}, {Name: "path", Typ: types.Jsonpath}},
+			ReturnType: tree.FixedReturnType(types.Bool),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				a := makeJsonpathArgs(evalCtx, args, false /* tz */)
+				a.silent = true
+				return jsonpathMatch(ctx, a)
+			},
+			Info:       "Returns the result of the JSON path predicate check for the specified JSON value. Implements the @@ operator.",
+			Volatility: volatility.Immutable,
+		},
+	),
+}
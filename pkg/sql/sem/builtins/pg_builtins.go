@@ -87,6 +87,30 @@ var typeBuiltinsHaveUnderscore = map[oid.Oid]struct{}{
 	types.Timestamp.Oid():   {},
 	types.TimestampTZ.Oid(): {},
 	types.AnyTuple.Oid():    {},
+	types.Point.Oid():       {},
+	types.LSeg.Oid():        {},
+	types.Line.Oid():        {},
+	types.Box.Oid():         {},
+	types.Path.Oid():        {},
+	types.Polygon.Oid():     {},
+	types.Circle.Oid():      {},
+
+	types.Int4Range.Oid():      {},
+	types.Int8Range.Oid():      {},
+	types.NumRange.Oid():       {},
+	types.TSRange.Oid():        {},
+	types.TSTZRange.Oid():      {},
+	types.DateRange.Oid():      {},
+	types.AnyRange.Oid():       {},
+	types.Int4Multirange.Oid(): {},
+	types.Int8Multirange.Oid(): {},
+	types.NumMultirange.Oid():  {},
+	types.TSMultirange.Oid():   {},
+	types.TSTZMultirange.Oid(): {},
+	types.DateMultirange.Oid(): {},
+	types.AnyMultirange.Oid():  {},
+
+	types.Trigger.Oid(): {},
 }
 
 // PGIOBuiltinPrefix returns the string prefix to a type's IO functions. This
@@ -201,6 +225,15 @@ func init() {
 			},
 		)
 	}
+	// The functions that construct geometric values share their names with the
+	// casts to the geometric types.
+	for toOID, overloads := range geometricConstructors {
+		castBuiltins[toOID].overloads = append(castBuiltins[toOID].overloads, overloads...)
+	}
+	// Likewise for the functions that construct range and multirange values.
+	for toOID, overloads := range rangeConstructors {
+		castBuiltins[toOID].overloads = append(castBuiltins[toOID].overloads, overloads...)
+	}
 	for toOID, def := range castBuiltins {
 		n := cast.CastTypeName(types.OidToType[toOID])
 		CastBuiltinNames[n] = struct{}{}
@@ -233,6 +266,11 @@ var CastBuiltinNames = make(map[string]struct{})
 // in order to make type resolution non-ambiguous.
 var CastBuiltinOIDs = make(map[oid.Oid]map[types.Family]oid.Oid)
 
+// CastBuiltinOIDsBySource maps casts from tgt oid to src oid to OIDs. It is
+// used for the casts between types of the same family, such as the geometric
+// types, which CastBuiltinOIDs cannot distinguish.
+var CastBuiltinOIDsBySource = make(map[oid.Oid]map[oid.Oid]oid.Oid)
+
 func shouldMakeFromCastBuiltin(in *types.T) bool {
 	// Since type resolutions are based on families, prevent ambiguity where
 	// possible by using the "preferred" type for the family.
@@ -596,6 +634,34 @@ var pgBuiltins = map[string]builtinDefinition{
 		},
 	),
 
+	// See https://www.postgresql.org/docs/current/functions-info.html#FUNCTIONS-INFO-SESSION.
+	"pg_notify": makeBuiltin(tree.FunctionProperties{DistsqlBlocklist: true},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "channel", Typ: types.String},
+				{Name: "payload", Typ: types.String},
+			},
+			ReturnType: tree.FixedReturnType(types.Void),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				// As in Postgres, a NULL channel is reported as an empty channel name,
+				// and a NULL payload is treated as an empty payload.
+				var channel, payload string
+				if args[0] != tree.DNull {
+					channel = string(tree.MustBeDString(args[0]))
+				}
+				if args[1] != tree.DNull {
+					payload = string(tree.MustBeDString(args[1]))
+				}
+				return tree.DVoidDatum, evalCtx.Planner.QueueNotification(ctx, channel, payload)
+			},
+			Info: "Sends a notification with the given payload on the given channel to " +
+				"all sessions listening on it, when the current transaction commits. " +
+				"This is equivalent to NOTIFY.",
+			Volatility:        volatility.Volatile,
+			CalledOnNullInput: true,
+		},
+	),
+
 	// See https://www.postgresql.org/docs/9.3/static/catalog-pg-database.html.
 	"pg_encoding_to_char": makeBuiltin(defProps(),
 		tree.Overload{
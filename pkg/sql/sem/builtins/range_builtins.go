@@ -0,0 +1,356 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package builtins
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
+)
+
+func init() {
+	for k, v := range rangeBuiltins {
+		v.props.Category = builtinconstants.CategoryRange
+		const enforceClass = true
+		registerBuiltin(k, v, tree.NormalClass, enforceClass)
+	}
+}
+
+// builtinRangeTypes contains the built-in range types.
+var builtinRangeTypes = []*types.T{
+	types.Int4Range,
+	types.Int8Range,
+	types.NumRange,
+	types.TSRange,
+	types.TSTZRange,
+	types.DateRange,
+}
+
+// rangeOf returns the given range, or the smallest range containing all the
+// ranges of the given multirange.
+func rangeOf(d tree.Datum) *tree.DRange {
+	if r, ok := tree.AsDRange(d); ok {
+		return r
+	}
+	mr := tree.MustBeDMultirange(d)
+	if len(mr.Ranges) == 0 {
+		return tree.NewEmptyDRange(mr.Typ.RangeContents())
+	}
+	first, last := mr.Ranges[0], mr.Ranges[len(mr.Ranges)-1]
+	return &tree.DRange{Typ: first.Typ, Lower: first.Lower, Upper: last.Upper}
+}
+
+// asMultirange returns the given multirange, or the multirange containing only
+// the given range.
+func asMultirange(d tree.Datum) *tree.DMultirange {
+	if r, ok := tree.AsDRange(d); ok {
+		return tree.RangeToMultirange(r)
+	}
+	return tree.MustBeDMultirange(d)
+}
+
+// rangeSubtypeReturnType returns the subtype of the range or multirange given
+// as the first argument.
+func rangeSubtypeReturnType(args []tree.TypedExpr) *types.T {
+	if len(args) == 0 {
+		return tree.UnknownReturnType
+	}
+	t := args[0].ResolvedType()
+	if t.Family() == types.MultirangeFamily {
+		t = t.RangeContents()
+	}
+	if t.Family() != types.RangeFamily {
+		return types.Unknown
+	}
+	return t.RangeContents()
+}
+
+// rangeOverloads1 returns the overloads of a function which takes a single
+// range or multirange. Multiranges are treated as the smallest range
+// containing all of their ranges.
+func rangeOverloads1(
+	ret tree.ReturnTyper, info string, fn func(*tree.DRange) tree.Datum,
+) []tree.Overload {
+	var res []tree.Overload
+	for _, typ := range []*types.T{types.AnyRange, types.AnyMultirange} {
+		name := "range"
+		if typ.Family() == types.MultirangeFamily {
+			name = "multirange"
+		}
+		res = append(res, tree.Overload{
+			Types:      tree.ParamTypes{{Name: name, Typ: typ}},
+			ReturnType: ret,
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return fn(rangeOf(args[0])), nil
+			},
+			Info:       info,
+			Volatility: volatility.Immutable,
+		})
+	}
+	return res
+}
+
+// withRangeBoundOverloads returns the overloads of the lower or upper
+// function: the given string overload, followed by the overloads for ranges
+// and multiranges. The string overload is preferred, so that the function
+// can still be applied to untyped placeholders.
+func withRangeBoundOverloads(lower bool, stringOverload tree.Overload) []tree.Overload {
+	which := "upper"
+	if lower {
+		which = "lower"
+	}
+	stringOverload.PreferredOverload = true
+	return append([]tree.Overload{stringOverload}, rangeOverloads1(
+		rangeSubtypeReturnType,
+		fmt.Sprintf("Returns the %s bound of the range, or NULL if it is empty or infinite.", which),
+		func(r *tree.DRange) tree.Datum {
+			b := r.Upper
+			if lower {
+				b = r.Lower
+			}
+			if r.Empty || b.IsInfinite() {
+				return tree.DNull
+			}
+			return b.Val
+		},
+	)...)
+}
+
+var rangeBuiltins = map[string]builtinDefinition{
+	"isempty": makeBuiltin(tree.FunctionProperties{},
+		rangeOverloads1(tree.FixedReturnType(types.Bool), "Returns whether the range is empty.",
+			func(r *tree.DRange) tree.Datum {
+				return tree.MakeDBool(tree.DBool(r.Empty))
+			})...,
+	),
+	"lower_inc": makeBuiltin(tree.FunctionProperties{},
+		rangeOverloads1(tree.FixedReturnType(types.Bool), "Returns whether the lower bound is inclusive.",
+			func(r *tree.DRange) tree.Datum {
+				return tree.MakeDBool(tree.DBool(!r.Empty && r.Lower.Inclusive))
+			})...,
+	),
+	"upper_inc": makeBuiltin(tree.FunctionProperties{},
+		rangeOverloads1(tree.FixedReturnType(types.Bool), "Returns whether the upper bound is inclusive.",
+			func(r *tree.DRange) tree.Datum {
+				return tree.MakeDBool(tree.DBool(!r.Empty && r.Upper.Inclusive))
+			})...,
+	),
+	"lower_inf": makeBuiltin(tree.FunctionProperties{},
+		rangeOverloads1(tree.FixedReturnType(types.Bool), "Returns whether the lower bound is infinite.",
+			func(r *tree.DRange) tree.Datum {
+				return tree.MakeDBool(tree.DBool(!r.Empty && r.Lower.IsInfinite()))
+			})...,
+	),
+	"upper_inf": makeBuiltin(tree.FunctionProperties{},
+		rangeOverloads1(tree.FixedReturnType(types.Bool), "Returns whether the upper bound is infinite.",
+			func(r *tree.DRange) tree.Datum {
+				return tree.MakeDBool(tree.DBool(!r.Empty && r.Upper.IsInfinite()))
+			})...,
+	),
+	"range_merge": makeBuiltin(tree.FunctionProperties{},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "left", Typ: types.AnyRange}, {Name: "right", Typ: types.AnyRange}},
+			ReturnType: tree.IdentityReturnType(0),
+			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				left, right := tree.MustBeDRange(args[0]), tree.MustBeDRange(args[1])
+				res, err := left.Union(evalCtx, right, false /* strict */)
+				if err != nil {
+					return nil, err
+				}
+				return res, nil
+			},
+			Info:       "Returns the smallest range which includes both of the given ranges.",
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{{Name: "multirange", Typ: types.AnyMultirange}},
+			ReturnType: func(args []tree.TypedExpr) *types.T {
+				if len(args) == 0 {
+					return tree.UnknownReturnType
+				}
+				return args[0].ResolvedType().RangeContents()
+			},
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return rangeOf(args[0]), nil
+			},
+			Info:       "Returns the smallest range which includes the entire multirange.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"range_adjacent": makeRangeAdjacentBuiltin(),
+	"multirange":     makeMultirangeBuiltin(),
+}
+
+// makeRangeAdjacentBuiltin returns the definition of the function implementing
+// the -|- operator, which tests whether two ranges or multiranges are adjacent.
+func makeRangeAdjacentBuiltin() builtinDefinition {
+	var overloads []tree.Overload
+	for _, left := range []*types.T{types.AnyRange, types.AnyMultirange} {
+		for _, right := range []*types.T{types.AnyRange, types.AnyMultirange} {
+			overloads = append(overloads, tree.Overload{
+				Types:      tree.ParamTypes{{Name: "left", Typ: left}, {Name: "right", Typ: right}},
+				ReturnType: tree.FixedReturnType(types.Bool),
+				Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+					var res bool
+					var err error
+					if l, ok := tree.AsDRange(args[0]); ok {
+						if r, ok := tree.AsDRange(args[1]); ok {
+							res, err = l.Adjacent(evalCtx, r)
+						} else {
+							res, err = asMultirange(l).Adjacent(evalCtx, asMultirange(args[1]))
+						}
+					} else {
+						res, err = asMultirange(args[0]).Adjacent(evalCtx, asMultirange(args[1]))
+					}
+					if err != nil {
+						return nil, err
+					}
+					return tree.MakeDBool(tree.DBool(res)), nil
+				},
+				Info:       "Returns whether the two values are adjacent. Implements the -|- operator.",
+				Volatility: volatility.Immutable,
+			})
+		}
+	}
+	return makeBuiltin(tree.FunctionProperties{}, overloads...)
+}
+
+// makeMultirangeBuiltin returns the definition of the function which converts
+// a range into a multirange, with an overload for every built-in range type.
+func makeMultirangeBuiltin() builtinDefinition {
+	var overloads []tree.Overload
+	for _, typ := range builtinRangeTypes {
+		overloads = append(overloads, tree.Overload{
+			Types:      tree.ParamTypes{{Name: typ.String(), Typ: typ}},
+			ReturnType: tree.FixedReturnType(types.MultirangeOf(typ)),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return tree.RangeToMultirange(tree.MustBeDRange(args[0])), nil
+			},
+			Info:       "Returns the multirange containing only the given range.",
+			Volatility: volatility.Immutable,
+		})
+	}
+	return makeBuiltin(tree.FunctionProperties{}, overloads...)
+}
+
+// parseRangeBoundFlags parses the bounds argument of the range constructors,
+// which is one of "[]", "[)", "(]" or "()".
+func parseRangeBoundFlags(flags string) (lowerInc, upperInc bool, _ error) {
+	if len(flags) == 2 &&
+		(flags[0] == '[' || flags[0] == '(') && (flags[1] == ']' || flags[1] == ')') {
+		return flags[0] == '[', flags[1] == ']', nil
+	}
+	return false, false, errors.WithHint(
+		pgerror.New(pgcode.Syntax, "invalid range bound flags"),
+		`Valid values are "[]", "[)", "(]", and "()".`,
+	)
+}
+
+// makeRangeConstructors returns the overloads of the functions which build a
+// range of the given type from its bounds. A NULL bound is infinite.
+func makeRangeConstructors(typ *types.T) []tree.Overload {
+	fn := func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+		lower := tree.RangeBound{Inclusive: true}
+		var upper tree.RangeBound
+		if len(args) == 3 {
+			if args[2] == tree.DNull {
+				return nil, pgerror.New(pgcode.NullValueNotAllowed,
+					"range constructor flags argument must not be null")
+			}
+			var err error
+			lower.Inclusive, upper.Inclusive, err = parseRangeBoundFlags(string(tree.MustBeDString(args[2])))
+			if err != nil {
+				return nil, err
+			}
+		}
+		if args[0] != tree.DNull {
+			lower.Val = args[0]
+		}
+		if args[1] != tree.DNull {
+			upper.Val = args[1]
+		}
+		res, err := tree.NewDRange(evalCtx, typ, lower, upper)
+		if err != nil {
+			return nil, err
+		}
+		return res, nil
+	}
+	subtype := typ.RangeContents()
+	if subtype.Family() == types.IntFamily {
+		subtype = types.Int
+	}
+	return []tree.Overload{
+		{
+			Types:             tree.ParamTypes{{Name: "lower", Typ: subtype}, {Name: "upper", Typ: subtype}},
+			ReturnType:        tree.FixedReturnType(typ),
+			Fn:                fn,
+			Info:              "Constructs a range with an inclusive lower bound and an exclusive upper bound.",
+			Volatility:        volatility.Immutable,
+			CalledOnNullInput: true,
+		},
+		{
+			Types: tree.ParamTypes{
+				{Name: "lower", Typ: subtype}, {Name: "upper", Typ: subtype}, {Name: "bounds", Typ: types.String},
+			},
+			ReturnType: tree.FixedReturnType(typ),
+			Fn:         fn,
+			Info: "Constructs a range with the given bounds. `bounds` specifies whether each " +
+				"bound is inclusive, and is one of '[]', '[)', '(]' or '()'.",
+			Volatility:        volatility.Immutable,
+			CalledOnNullInput: true,
+		},
+	}
+}
+
+// makeMultirangeConstructor returns the overload of the function which builds
+// a multirange of the given type from any number of ranges.
+func makeMultirangeConstructor(typ *types.T) tree.Overload {
+	return tree.Overload{
+		Types:      tree.VariadicType{VarType: typ.RangeContents()},
+		ReturnType: tree.FixedReturnType(typ),
+		Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+			ranges := make([]*tree.DRange, len(args))
+			for i, arg := range args {
+				ranges[i] = tree.MustBeDRange(arg)
+			}
+			res, err := tree.NewDMultirange(evalCtx, typ, ranges)
+			if err != nil {
+				return nil, err
+			}
+			return res, nil
+		},
+		Info:       "Constructs a multirange containing the given ranges.",
+		Volatility: volatility.Immutable,
+	}
+}
+
+// rangeConstructors contains the overloads of the functions that build a
+// range or multirange value from its components, keyed by the type they
+// return. These functions have the same names as the casts to the range types,
+// so the overloads are added to the cast builtins.
+var rangeConstructors = func() map[oid.Oid][]tree.Overload {
+	res := make(map[oid.Oid][]tree.Overload)
+	for _, typ := range builtinRangeTypes {
+		res[typ.Oid()] = makeRangeConstructors(typ)
+		mrTyp := types.MultirangeOf(typ)
+		res[mrTyp.Oid()] = []tree.Overload{makeMultirangeConstructor(mrTyp)}
+	}
+	return res
+}()
@@ -92,13 +92,16 @@ var tsearchBuiltins = map[string]builtinDefinition{
 	),
 	// Full text search functions.
 	"to_tsvector": makeBuiltin(
-		tree.FunctionProperties{},
+		tree.FunctionProperties{TextSearchObjectArgument: true},
 		tree.Overload{
 			Types:      tree.ParamTypes{{Name: "config", Typ: types.String}, {Name: "text", Typ: types.String}},
 			ReturnType: tree.FixedReturnType(types.TSVector),
-			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
 				// Parse, stem, and stopword the input.
-				config := string(tree.MustBeDString(args[0]))
+				config, err := getTextSearchConfig(ctx, evalCtx, string(tree.MustBeDString(args[0])))
+				if err != nil {
+					return nil, err
+				}
 				document := string(tree.MustBeDString(args[1]))
 				vector, err := tsearch.DocumentToTSVector(config, document)
 				if err != nil {
@@ -113,8 +116,11 @@ var tsearchBuiltins = map[string]builtinDefinition{
 		tree.Overload{
 			Types:      tree.ParamTypes{{Name: "text", Typ: types.String}},
 			ReturnType: tree.FixedReturnType(types.TSVector),
-			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
-				config := tsearch.GetConfigKey(evalCtx.SessionData().DefaultTextSearchConfig)
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				config, err := getTextSearchConfig(ctx, evalCtx, evalCtx.SessionData().DefaultTextSearchConfig)
+				if err != nil {
+					return nil, err
+				}
 				document := string(tree.MustBeDString(args[0]))
 				vector, err := tsearch.DocumentToTSVector(config, document)
 				if err != nil {
@@ -128,12 +134,15 @@ var tsearchBuiltins = map[string]builtinDefinition{
 		},
 	),
 	"to_tsquery": makeBuiltin(
-		tree.FunctionProperties{},
+		tree.FunctionProperties{TextSearchObjectArgument: true},
 		tree.Overload{
 			Types:      tree.ParamTypes{{Name: "config", Typ: types.String}, {Name: "text", Typ: types.String}},
 			ReturnType: tree.FixedReturnType(types.TSQuery),
-			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
-				config := string(tree.MustBeDString(args[0]))
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				config, err := getTextSearchConfig(ctx, evalCtx, string(tree.MustBeDString(args[0])))
+				if err != nil {
+					return nil, err
+				}
 				input := string(tree.MustBeDString(args[1]))
 				query, err := tsearch.ToTSQuery(config, input)
 				if err != nil {
@@ -149,8 +158,11 @@ var tsearchBuiltins = map[string]builtinDefinition{
 		tree.Overload{
 			Types:      tree.ParamTypes{{Name: "text", Typ: types.String}},
 			ReturnType: tree.FixedReturnType(types.TSQuery),
-			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
-				config := tsearch.GetConfigKey(evalCtx.SessionData().DefaultTextSearchConfig)
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				config, err := getTextSearchConfig(ctx, evalCtx, evalCtx.SessionData().DefaultTextSearchConfig)
+				if err != nil {
+					return nil, err
+				}
 				input := string(tree.MustBeDString(args[0]))
 				query, err := tsearch.ToTSQuery(config, input)
 				if err != nil {
@@ -165,12 +177,15 @@ var tsearchBuiltins = map[string]builtinDefinition{
 		},
 	),
 	"plainto_tsquery": makeBuiltin(
-		tree.FunctionProperties{},
+		tree.FunctionProperties{TextSearchObjectArgument: true},
 		tree.Overload{
 			Types:      tree.ParamTypes{{Name: "config", Typ: types.String}, {Name: "text", Typ: types.String}},
 			ReturnType: tree.FixedReturnType(types.TSQuery),
-			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
-				config := string(tree.MustBeDString(args[0]))
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				config, err := getTextSearchConfig(ctx, evalCtx, string(tree.MustBeDString(args[0])))
+				if err != nil {
+					return nil, err
+				}
 				input := string(tree.MustBeDString(args[1]))
 				query, err := tsearch.PlainToTSQuery(config, input)
 				if err != nil {
@@ -185,8 +200,11 @@ var tsearchBuiltins = map[string]builtinDefinition{
 		tree.Overload{
 			Types:      tree.ParamTypes{{Name: "text", Typ: types.String}},
 			ReturnType: tree.FixedReturnType(types.TSQuery),
-			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
-				config := tsearch.GetConfigKey(evalCtx.SessionData().DefaultTextSearchConfig)
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				config, err := getTextSearchConfig(ctx, evalCtx, evalCtx.SessionData().DefaultTextSearchConfig)
+				if err != nil {
+					return nil, err
+				}
 				input := string(tree.MustBeDString(args[0]))
 				query, err := tsearch.PlainToTSQuery(config, input)
 				if err != nil {
@@ -200,12 +218,15 @@ var tsearchBuiltins = map[string]builtinDefinition{
 		},
 	),
 	"phraseto_tsquery": makeBuiltin(
-		tree.FunctionProperties{},
+		tree.FunctionProperties{TextSearchObjectArgument: true},
 		tree.Overload{
 			Types:      tree.ParamTypes{{Name: "config", Typ: types.String}, {Name: "text", Typ: types.String}},
 			ReturnType: tree.FixedReturnType(types.TSQuery),
-			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
-				config := string(tree.MustBeDString(args[0]))
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				config, err := getTextSearchConfig(ctx, evalCtx, string(tree.MustBeDString(args[0])))
+				if err != nil {
+					return nil, err
+				}
 				input := string(tree.MustBeDString(args[1]))
 				query, err := tsearch.PhraseToTSQuery(config, input)
 				if err != nil {
@@ -220,8 +241,11 @@ var tsearchBuiltins = map[string]builtinDefinition{
 		tree.Overload{
 			Types:      tree.ParamTypes{{Name: "text", Typ: types.String}},
 			ReturnType: tree.FixedReturnType(types.TSQuery),
-			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
-				config := tsearch.GetConfigKey(evalCtx.SessionData().DefaultTextSearchConfig)
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				config, err := getTextSearchConfig(ctx, evalCtx, evalCtx.SessionData().DefaultTextSearchConfig)
+				if err != nil {
+					return nil, err
+				}
 				input := string(tree.MustBeDString(args[0]))
 				query, err := tsearch.PhraseToTSQuery(config, input)
 				if err != nil {
@@ -333,6 +357,61 @@ var tsearchBuiltins = map[string]builtinDefinition{
 			Volatility: volatility.Immutable,
 		},
 	),
+	"ts_lexize": makeBuiltin(
+		tree.FunctionProperties{TextSearchObjectArgument: true},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "dict", Typ: types.String}, {Name: "token", Typ: types.String}},
+			ReturnType: tree.FixedReturnType(types.StringArray),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				dict, err := getTextSearchDictionary(ctx, evalCtx, string(tree.MustBeDString(args[0])))
+				if err != nil {
+					return nil, err
+				}
+				lexeme, recognized, stopWord := dict.Lexize(string(tree.MustBeDString(args[1])))
+				if !recognized {
+					return tree.DNull, nil
+				}
+				ret := tree.NewDArray(types.String)
+				if !stopWord {
+					if err := ret.Append(tree.NewDString(lexeme)); err != nil {
+						return nil, err
+					}
+				}
+				return ret, nil
+			},
+			Info: "Returns the lexemes the dictionary produces for the token: an empty array if the " +
+				"token is a stopword, or NULL if the dictionary doesn't recognize it.",
+			Volatility: volatility.Immutable,
+		},
+	),
+}
+
+// getTextSearchConfig returns the text search configuration of the given name.
+// Built-in configurations are resolved without the planner, so that they are
+// available in contexts that don't have one, such as distributed execution.
+func getTextSearchConfig(
+	ctx context.Context, evalCtx *eval.Context, name string,
+) (*tsearch.Config, error) {
+	config, err := tsearch.GetBuiltinConfig(name)
+	if err == nil || evalCtx.Planner == nil {
+		return config, err
+	}
+	return evalCtx.Planner.ResolveTextSearchConfig(ctx, name)
+}
+
+// getTextSearchDictionary returns the text search dictionary of the given
+// name, like getTextSearchConfig.
+func getTextSearchDictionary(
+	ctx context.Context, evalCtx *eval.Context, name string,
+) (*tsearch.Dictionary, error) {
+	if dict, ok := tsearch.GetBuiltinDictionary(name); ok {
+		return dict, nil
+	}
+	if evalCtx.Planner == nil {
+		return nil, pgerror.Newf(pgcode.UndefinedObject,
+			"text search dictionary %q does not exist", name)
+	}
+	return evalCtx.Planner.ResolveTextSearchDictionary(ctx, name)
 }
 
 func getWeights(arr *tree.DArray) ([]float32, error) {
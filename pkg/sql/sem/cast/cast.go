@@ -200,6 +200,14 @@ func OIDInCastMap(src, tgt oid.Oid) bool {
 // LookupCast returns a cast that describes the cast from src to tgt if it
 // exists. If it does not exist, ok=false is returned.
 func LookupCast(src, tgt *types.T) (Cast, bool) {
+	// Casts from and to domain types are the casts from and to their base
+	// types. Constraints of the target domain are checked separately.
+	if src.IsDomain() {
+		src = src.DomainBaseType()
+	}
+	if tgt.IsDomain() {
+		tgt = tgt.DomainBaseType()
+	}
 	srcFamily := src.Family()
 	tgtFamily := tgt.Family()
 
@@ -241,6 +249,30 @@ func LookupCast(src, tgt *types.T) (Cast, bool) {
 		}
 	}
 
+	// User-defined range types also have dynamic OIDs. Their casts to and from
+	// string types are stable because the text of their bounds may depend on
+	// session settings.
+	if srcFamily == types.RangeFamily && src.UserDefined() && tgtFamily == types.StringFamily {
+		return Cast{
+			MaxContext: ContextAssignment,
+			Volatility: volatility.Stable,
+		}, true
+	}
+	if tgtFamily == types.RangeFamily && tgt.UserDefined() {
+		switch srcFamily {
+		case types.StringFamily:
+			return Cast{
+				MaxContext: ContextExplicit,
+				Volatility: volatility.Stable,
+			}, true
+		case types.UnknownFamily:
+			return Cast{
+				MaxContext: ContextImplicit,
+				Volatility: volatility.Immutable,
+			}, true
+		}
+	}
+
 	// Casts from array types to string types are stable and allowed in
 	// assignment contexts.
 	if srcFamily == types.ArrayFamily && tgtFamily == types.StringFamily {
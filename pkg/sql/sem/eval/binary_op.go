@@ -29,6 +29,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/bitarray"
 	"github.com/cockroachdb/cockroach/pkg/util/duration"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/jsonpath"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
 	"github.com/cockroachdb/cockroach/pkg/util/trigram"
 	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
@@ -133,6 +134,12 @@ func (e *evaluator) EvalBitXorVarBitOp(
 	}, nil
 }
 
+func (e *evaluator) EvalCombineRangesOp(
+	ctx context.Context, op *tree.CombineRangesOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	return op.Op(e.ctx(), left, right)
+}
+
 func (e *evaluator) EvalCompareBox2DOp(
 	ctx context.Context, op *tree.CompareBox2DOp, left, right tree.Datum,
 ) (tree.Datum, error) {
@@ -142,6 +149,22 @@ func (e *evaluator) EvalCompareBox2DOp(
 	return tree.MakeDBool(tree.DBool(op.Op(left, right))), nil
 }
 
+func (e *evaluator) EvalCompareGeometricOp(
+	ctx context.Context, op *tree.CompareGeometricOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	return tree.MakeDBool(tree.DBool(op.Op(left, right))), nil
+}
+
+func (e *evaluator) EvalCompareRangeOp(
+	ctx context.Context, op *tree.CompareRangeOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	res, err := op.Op(e.ctx(), left, right)
+	if err != nil {
+		return nil, err
+	}
+	return tree.MakeDBool(tree.DBool(res)), nil
+}
+
 func (e *evaluator) EvalCompareScalarOp(
 	ctx context.Context, op *tree.CompareScalarOp, left, right tree.Datum,
 ) (tree.Datum, error) {
@@ -696,6 +719,15 @@ func (e *evaluator) EvalJSONSomeExistsOp(
 	return tree.JSONExistsAny(tree.MustBeDJSON(a), tree.MustBeDArray(b))
 }
 
+func (e *evaluator) EvalJSONPathMatchOp(
+	ctx context.Context, _ *tree.JSONPathMatchOp, a, b tree.Datum,
+) (tree.Datum, error) {
+	return JsonpathMatch(
+		tree.MustBeDJSON(a).JSON, tree.MustBeDJsonpath(b).Jsonpath,
+		jsonpath.EvalOptions{}, true, /* silent */
+	)
+}
+
 func (e *evaluator) EvalLShiftINetOp(
 	ctx context.Context, _ *tree.LShiftINetOp, left, right tree.Datum,
 ) (tree.Datum, error) {
@@ -20,6 +20,7 @@ import (
 	"github.com/cockroachdb/apd/v3"
 	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/cockroachdb/cockroach/pkg/geo/geometric"
 	"github.com/cockroachdb/cockroach/pkg/geo/geopb"
 	"github.com/cockroachdb/cockroach/pkg/sql/lex"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
@@ -101,6 +102,11 @@ func PerformAssignmentCast(
 func performCast(
 	ctx context.Context, evalCtx *Context, d tree.Datum, t *types.T, truncateWidth bool,
 ) (tree.Datum, error) {
+	// A cast to a domain type is a cast to its base type. The constraints of the
+	// domain are checked by the optimizer when it builds the cast.
+	if t.IsDomain() {
+		t = t.DomainBaseType()
+	}
 	d, err := performCastWithoutPrecisionTruncation(ctx, evalCtx, d, t, truncateWidth)
 	if err != nil {
 		return nil, err
@@ -453,7 +459,7 @@ func performCastWithoutPrecisionTruncation(
 				ts,
 				tree.FmtBareStrings,
 			)
-		case *tree.DTuple:
+		case *tree.DTuple, *tree.DRange, *tree.DMultirange:
 			s = tree.AsStringWithFlags(
 				d,
 				tree.FmtPgwireText,
@@ -498,6 +504,10 @@ func performCastWithoutPrecisionTruncation(
 			s = t.String()
 		case *tree.DJSON:
 			s = t.JSON.String()
+		case *tree.DJsonpath:
+			s = t.Jsonpath.String()
+		case *tree.DGeometric:
+			s = t.Shape.String()
 		case *tree.DTSQuery:
 			s = t.TSQuery.String()
 		case *tree.DTSVector:
@@ -714,6 +724,16 @@ func performCastWithoutPrecisionTruncation(
 				return nil, err
 			}
 			return &tree.DGeometry{Geometry: g}, nil
+		case *tree.DGeometric:
+			gt, err := geometric.ToGeomT(d.Shape)
+			if err != nil {
+				return nil, err
+			}
+			g, err := geo.MakeGeometryFromGeomT(gt)
+			if err != nil {
+				return nil, err
+			}
+			return &tree.DGeometry{Geometry: g}, nil
 		case *tree.DBytes:
 			g, err := geo.ParseGeometryFromEWKB(geopb.EWKB(*d))
 			if err != nil {
@@ -906,6 +926,54 @@ func performCastWithoutPrecisionTruncation(
 			}
 			return &tree.DTSQuery{TSQuery: q}, nil
 		}
+	case types.JsonpathFamily:
+		switch v := d.(type) {
+		case *tree.DString:
+			return tree.ParseDJsonpath(string(*v))
+		case *tree.DJsonpath:
+			return v, nil
+		}
+	case types.RangeFamily:
+		switch v := d.(type) {
+		case *tree.DString:
+			res, _, err := tree.ParseDRange(evalCtx, string(*v), t)
+			return res, err
+		case *tree.DRange:
+			if v.Typ.Oid() == t.Oid() {
+				return v, nil
+			}
+		}
+	case types.MultirangeFamily:
+		switch v := d.(type) {
+		case *tree.DString:
+			res, _, err := tree.ParseDMultirange(evalCtx, string(*v), t)
+			return res, err
+		case *tree.DMultirange:
+			if v.Typ.Oid() == t.Oid() {
+				return v, nil
+			}
+		}
+	case types.GeometricFamily:
+		switch v := d.(type) {
+		case *tree.DString:
+			return tree.ParseDGeometric(t, string(*v))
+		case *tree.DGeometric:
+			s, err := geometric.Convert(v.Shape, tree.GeometricKind(t))
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDGeometric(s), nil
+		case *tree.DGeometry:
+			gt, err := v.Geometry.AsGeomT()
+			if err != nil {
+				return nil, err
+			}
+			s, err := geometric.FromGeomT(gt, tree.GeometricKind(t))
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDGeometric(s), nil
+		}
 	case types.TSVectorFamily:
 		if !evalCtx.Settings.Version.IsActive(ctx, clusterversion.V23_1) {
 			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
@@ -944,6 +1012,11 @@ func performCastWithoutPrecisionTruncation(
 					return nil, err
 				}
 			}
+			if v.Dims != nil {
+				if err := dcast.SetDims(v.Dims); err != nil {
+					return nil, err
+				}
+			}
 			return dcast, nil
 		}
 	case types.OidFamily:
@@ -32,6 +32,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/mon"
 	"github.com/cockroachdb/cockroach/pkg/util/rangedesc"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/lib/pq/oid"
 )
 
@@ -426,6 +427,33 @@ type Planner interface {
 	// PLpgSQL FETCH statement.
 	PLpgSQLFetchCursor(ctx context.Context, cursor *tree.CursorStmt) (res tree.Datums, err error)
 
+	// PLpgSQLOpenDynamicCursor opens a cursor with the given name for the
+	// result of the given query, which is only known at execution time. The
+	// arguments are used for the placeholders in the query. It is used to
+	// implement the PLpgSQL OPEN ... FOR EXECUTE statement.
+	PLpgSQLOpenDynamicCursor(
+		ctx context.Context, cursorName tree.Name, scroll bool, query string, args tree.Datums,
+	) error
+
+	// PLpgSQLReturnNext adds the given value to the result of the set-returning
+	// PLpgSQL function that is currently being evaluated. It is used to
+	// implement the PLpgSQL RETURN NEXT and RETURN QUERY statements.
+	PLpgSQLReturnNext(ctx context.Context, val tree.Datum) error
+
+	// QueueNotification queues an asynchronous notification on the given
+	// channel, which is sent to the sessions listening on it when the current
+	// transaction commits. It is used to implement pg_notify.
+	QueueNotification(ctx context.Context, channel, payload string) error
+
+	// ResolveTextSearchConfig returns the text search configuration with the
+	// given, possibly qualified, name. It is used by the text search builtins
+	// for configurations that aren't built-in.
+	ResolveTextSearchConfig(ctx context.Context, name string) (*tsearch.Config, error)
+
+	// ResolveTextSearchDictionary returns the text search dictionary with the
+	// given, possibly qualified, name.
+	ResolveTextSearchDictionary(ctx context.Context, name string) (*tsearch.Dictionary, error)
+
 	// AutoCommit indicates whether the Planner has flagged the current statement
 	// as eligible for transaction auto-commit.
 	AutoCommit() bool
@@ -12,6 +12,7 @@ package eval
 
 import (
 	"context"
+	"math"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
@@ -64,6 +65,18 @@ func (e *evaluator) EvalAndExpr(ctx context.Context, expr *tree.AndExpr) (tree.D
 }
 
 func (e *evaluator) EvalArray(ctx context.Context, t *tree.Array) (tree.Datum, error) {
+	if len(t.Exprs) > 0 && tree.IsSubArrayConstructor(t.Exprs[0].(tree.TypedExpr).ResolvedType()) {
+		subArrays := make(tree.Datums, len(t.Exprs))
+		for i, ae := range t.Exprs {
+			d, err := ae.(tree.TypedExpr).Eval(ctx, e)
+			if err != nil {
+				return nil, err
+			}
+			subArrays[i] = d
+		}
+		return tree.NewMultiDimDArray(t.ResolvedType().ArrayContents(), subArrays)
+	}
+
 	array, err := arrayOfType(t.ResolvedType())
 	if err != nil {
 		return nil, err
@@ -288,6 +301,57 @@ func (e *evaluator) EvalIndexedVar(ctx context.Context, iv *tree.IndexedVar) (tr
 	return eivc.IndexedVarEval(ctx, iv.Idx, e)
 }
 
+// evalArraySubscripts evaluates the subscripts of an indirection into an
+// array, which may index into multiple dimensions or take a slice of the
+// array. As in Postgres, if any subscript is a slice then all of them are
+// treated as slices, where a subscript [n] is equivalent to [1:n].
+func (e *evaluator) evalArraySubscripts(
+	ctx context.Context, arr *tree.DArray, subscripts tree.ArraySubscripts, isSlice bool,
+) (tree.Datum, error) {
+	evalBound := func(bound tree.Expr, omitted int) (_ int, isNull bool, _ error) {
+		if bound == nil {
+			return omitted, false, nil
+		}
+		d, err := bound.(tree.TypedExpr).Eval(ctx, e)
+		if err != nil || d == tree.DNull {
+			return 0, d == tree.DNull, err
+		}
+		return int(tree.MustBeDInt(d)), false, nil
+	}
+	if !isSlice {
+		indexes := make([]int, len(subscripts))
+		for i, t := range subscripts {
+			idx, isNull, err := evalBound(t.Begin, 0 /* omitted */)
+			if err != nil || isNull {
+				return tree.DNull, err
+			}
+			indexes[i] = idx
+		}
+		return arr.Subscript(indexes), nil
+	}
+	lower := make([]int, len(subscripts))
+	upper := make([]int, len(subscripts))
+	for i, t := range subscripts {
+		var isNull bool
+		var err error
+		if t.Slice {
+			// Omitted bounds are clamped to the bounds of the array.
+			if lower[i], isNull, err = evalBound(t.Begin, math.MinInt); err != nil || isNull {
+				return tree.DNull, err
+			}
+			if upper[i], isNull, err = evalBound(t.End, math.MaxInt); err != nil || isNull {
+				return tree.DNull, err
+			}
+		} else {
+			lower[i] = 1
+			if upper[i], isNull, err = evalBound(t.Begin, 0 /* omitted */); err != nil || isNull {
+				return tree.DNull, err
+			}
+		}
+	}
+	return arr.Slice(lower, upper)
+}
+
 func (e *evaluator) EvalIndirectionExpr(
 	ctx context.Context, expr *tree.IndirectionExpr,
 ) (tree.Datum, error) {
@@ -303,12 +367,14 @@ func (e *evaluator) EvalIndirectionExpr(
 
 	switch d.ResolvedType().Family() {
 	case types.ArrayFamily:
-		for i, t := range expr.Indirection {
-			if t.Slice || i > 0 {
-				return nil, errors.AssertionFailedf("unsupported feature should have been rejected during planning")
-			}
-
-			beginDatum, err := t.Begin.(tree.TypedExpr).Eval(ctx, e)
+		arr := tree.MustBeDArray(d)
+		isSlice := false
+		for _, t := range expr.Indirection {
+			isSlice = isSlice || t.Slice
+		}
+		if !isSlice && len(expr.Indirection) == 1 && arr.Dims == nil {
+			// Fast path for indexing into a one-dimensional array.
+			beginDatum, err := expr.Indirection[0].Begin.(tree.TypedExpr).Eval(ctx, e)
 			if err != nil {
 				return nil, err
 			}
@@ -316,19 +382,18 @@ func (e *evaluator) EvalIndirectionExpr(
 				return tree.DNull, nil
 			}
 			subscriptIdx = int(tree.MustBeDInt(beginDatum))
-		}
-
-		// Index into the DArray, using 1-indexing.
-		arr := tree.MustBeDArray(d)
 
-		// VECTOR types use 0-indexing.
-		if arr.FirstIndex() == 0 {
-			subscriptIdx++
-		}
-		if subscriptIdx < 1 || subscriptIdx > arr.Len() {
-			return tree.DNull, nil
+			// Index into the DArray, using 1-indexing.
+			// VECTOR types use 0-indexing.
+			if arr.FirstIndex() == 0 {
+				subscriptIdx++
+			}
+			if subscriptIdx < 1 || subscriptIdx > arr.Len() {
+				return tree.DNull, nil
+			}
+			return arr.Array[subscriptIdx-1], nil
 		}
-		return arr.Array[subscriptIdx-1], nil
+		return e.evalArraySubscripts(ctx, arr, expr.Indirection, isSlice)
 	case types.JsonFamily:
 		j := tree.MustBeDJSON(d)
 		curr := j.JSON
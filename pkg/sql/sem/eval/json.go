@@ -18,6 +18,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/jsonpath"
 	"github.com/cockroachdb/errors"
 )
 
@@ -111,3 +112,27 @@ func PopulateRecordWithJSON(
 	}
 	return nil
 }
+
+// JsonpathMatch returns the result of the jsonpath predicate path applied to
+// target as a BOOL, or NULL if the result is unknown. It is used for the @@
+// operator and the jsonb_path_match family of functions. If silent is true,
+// errors which the SQL/JSON standard allows to be suppressed result in NULL.
+func JsonpathMatch(
+	target json.JSON, path jsonpath.Jsonpath, opts jsonpath.EvalOptions, silent bool,
+) (tree.Datum, error) {
+	res, err := jsonpath.Match(path, target, opts)
+	if err != nil {
+		if silent && jsonpath.IsSuppressibleError(err) {
+			return tree.DNull, nil
+		}
+		return nil, err
+	}
+	switch res.Type() {
+	case json.TrueJSONType:
+		return tree.DBoolTrue, nil
+	case json.FalseJSONType:
+		return tree.DBoolFalse, nil
+	default:
+		return tree.DNull, nil
+	}
+}
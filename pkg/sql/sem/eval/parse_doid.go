@@ -125,7 +125,8 @@ func ParseDOid(ctx context.Context, evalCtx *Context, s string, t *types.T) (*tr
 			}
 		}
 
-		paramTypes, err := fn.ParamTypes(ctx, evalCtx.Planner)
+		routineType := tree.BuiltinRoutine | tree.UDFRoutine | tree.ProcedureRoutine
+		paramTypes, err := fn.ParamTypes(ctx, evalCtx.Planner, routineType)
 		if err != nil {
 			return nil, err
 		}
@@ -133,7 +134,7 @@ func ParseDOid(ctx context.Context, evalCtx *Context, s string, t *types.T) (*tr
 			paramTypes,
 			fn.FuncName.Schema(),
 			&evalCtx.SessionData().SearchPath,
-			tree.BuiltinRoutine|tree.UDFRoutine|tree.ProcedureRoutine,
+			routineType,
 		)
 		if err != nil {
 			return nil, err
@@ -163,10 +163,36 @@ func (s *CursorDeclaration) WalkStmt(visitor StatementVisitor) {
 	visitor.Visit(s)
 }
 
+// AliasDeclaration declares a new name for a variable or parameter, e.g.
+// "amount ALIAS FOR $1;".
+type AliasDeclaration struct {
+	StatementImpl
+	Name   Variable
+	Target string
+}
+
+func (s *AliasDeclaration) Format(ctx *tree.FmtCtx) {
+	s.Name.Format(ctx)
+	ctx.WriteString(" ALIAS FOR ")
+	ctx.WriteString(s.Target)
+	ctx.WriteString(";\n")
+}
+
+func (s *AliasDeclaration) PlpgSQLStatementTag() string {
+	return "decl_alias_stmt"
+}
+
+func (s *AliasDeclaration) WalkStmt(visitor StatementVisitor) {
+	visitor.Visit(s)
+}
+
 // stmt_assign
 type Assignment struct {
 	Statement
-	Var   Variable
+	Var Variable
+	// Field, if set, is the field of the composite-typed variable Var that is
+	// assigned, as in "rec.field := expr".
+	Field tree.Name
 	Value Expr
 }
 
@@ -175,6 +201,10 @@ func (s *Assignment) PlpgSQLStatementTag() string {
 }
 
 func (s *Assignment) Format(ctx *tree.FmtCtx) {
+	if s.Field != "" {
+		ctx.WriteString(fmt.Sprintf("%s.%s := %s;\n", s.Var, s.Field, s.Value))
+		return
+	}
 	ctx.WriteString(fmt.Sprintf("%s := %s;\n", s.Var, s.Value))
 }
 
@@ -413,11 +443,26 @@ type ForInt struct {
 	Lower   Expr
 	Upper   Expr
 	Step    Expr
-	Reverse int
+	Reverse bool
 	Body    []Statement
 }
 
 func (s *ForInt) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString("FOR ")
+	s.Var.Format(ctx)
+	ctx.WriteString(" IN ")
+	if s.Reverse {
+		ctx.WriteString("REVERSE ")
+	}
+	s.Lower.Format(ctx)
+	ctx.WriteString("..")
+	s.Upper.Format(ctx)
+	if s.Step != nil {
+		ctx.WriteString(" BY ")
+		s.Step.Format(ctx)
+	}
+	ctx.WriteString(" LOOP\n")
+	formatLoopBody(ctx, s.Label, s.Body)
 }
 
 func (s *ForInt) PlpgSQLStatementTag() string {
@@ -431,14 +476,19 @@ func (s *ForInt) WalkStmt(visitor StatementVisitor) {
 	}
 }
 
+// ForQuery contains the fields shared by the FOR loops that iterate over the
+// rows of a query.
 type ForQuery struct {
 	StatementImpl
-	Label string
-	Var   Variable
-	Body  []Statement
+	Label  string
+	Target []Variable
+	Body   []Statement
 }
 
 func (s *ForQuery) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString("FOR ")
+	formatTargets(ctx, s.Target)
+	ctx.WriteString(" IN ")
 }
 
 func (s *ForQuery) PlpgSQLStatementTag() string {
@@ -454,10 +504,14 @@ func (s *ForQuery) WalkStmt(visitor StatementVisitor) {
 
 type ForSelect struct {
 	ForQuery
-	Query Expr
+	Query tree.Statement
 }
 
 func (s *ForSelect) Format(ctx *tree.FmtCtx) {
+	s.ForQuery.Format(ctx)
+	s.Query.Format(ctx)
+	ctx.WriteString(" LOOP\n")
+	formatLoopBody(ctx, s.Label, s.Body)
 }
 
 func (s *ForSelect) PlpgSQLStatementTag() string {
@@ -466,16 +520,22 @@ func (s *ForSelect) PlpgSQLStatementTag() string {
 
 func (s *ForSelect) WalkStmt(visitor StatementVisitor) {
 	visitor.Visit(s)
-	s.ForQuery.WalkStmt(visitor)
+	for _, stmt := range s.Body {
+		stmt.WalkStmt(visitor)
+	}
 }
 
+// ForCursor is a FOR loop over the rows of a bound cursor variable.
 type ForCursor struct {
 	ForQuery
-	CurVar   int // TODO(drewk): is this CursorVariable?
-	ArgQuery Expr
+	CurVar Variable
 }
 
 func (s *ForCursor) Format(ctx *tree.FmtCtx) {
+	s.ForQuery.Format(ctx)
+	s.CurVar.Format(ctx)
+	ctx.WriteString(" LOOP\n")
+	formatLoopBody(ctx, s.Label, s.Body)
 }
 
 func (s *ForCursor) PlpgSQLStatementTag() string {
@@ -484,7 +544,9 @@ func (s *ForCursor) PlpgSQLStatementTag() string {
 
 func (s *ForCursor) WalkStmt(visitor StatementVisitor) {
 	visitor.Visit(s)
-	s.ForQuery.WalkStmt(visitor)
+	for _, stmt := range s.Body {
+		stmt.WalkStmt(visitor)
+	}
 }
 
 type ForDynamic struct {
@@ -494,6 +556,12 @@ type ForDynamic struct {
 }
 
 func (s *ForDynamic) Format(ctx *tree.FmtCtx) {
+	s.ForQuery.Format(ctx)
+	ctx.WriteString("EXECUTE ")
+	s.Query.Format(ctx)
+	formatUsing(ctx, s.Params)
+	ctx.WriteString(" LOOP\n")
+	formatLoopBody(ctx, s.Label, s.Body)
 }
 
 func (s *ForDynamic) PlpgSQLStatementTag() string {
@@ -502,20 +570,34 @@ func (s *ForDynamic) PlpgSQLStatementTag() string {
 
 func (s *ForDynamic) WalkStmt(visitor StatementVisitor) {
 	visitor.Visit(s)
-	s.ForQuery.WalkStmt(visitor)
+	for _, stmt := range s.Body {
+		stmt.WalkStmt(visitor)
+	}
 }
 
 // stmt_foreach_a
 type ForEachArray struct {
 	StatementImpl
 	Label string
-	Var   *Variable
-	Slice int // TODO(drewk): not sure what this is
+	Var   Variable
+	// Slice is the number of array dimensions assigned to the loop variable on
+	// each iteration. Zero means that the loop iterates over the individual
+	// elements of the array.
+	Slice int
 	Expr  Expr
 	Body  []Statement
 }
 
 func (s *ForEachArray) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString("FOREACH ")
+	s.Var.Format(ctx)
+	if s.Slice != 0 {
+		ctx.WriteString(fmt.Sprintf(" SLICE %d", s.Slice))
+	}
+	ctx.WriteString(" IN ARRAY ")
+	s.Expr.Format(ctx)
+	ctx.WriteString(" LOOP\n")
+	formatLoopBody(ctx, s.Label, s.Body)
 }
 
 func (s *ForEachArray) PlpgSQLStatementTag() string {
@@ -530,6 +612,41 @@ func (s *ForEachArray) WalkStmt(visitor StatementVisitor) {
 	}
 }
 
+// formatLoopBody formats the body and the END LOOP clause of a loop
+// statement.
+func formatLoopBody(ctx *tree.FmtCtx, label string, body []Statement) {
+	for _, stmt := range body {
+		stmt.Format(ctx)
+	}
+	ctx.WriteString("END LOOP")
+	if label != "" {
+		ctx.WriteString(fmt.Sprintf(" %s", label))
+	}
+	ctx.WriteString(";\n")
+}
+
+// formatTargets formats a comma-separated list of target variables.
+func formatTargets(ctx *tree.FmtCtx, targets []Variable) {
+	for i := range targets {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		targets[i].Format(ctx)
+	}
+}
+
+// formatUsing formats the USING clause of a dynamic SQL command, if any.
+func formatUsing(ctx *tree.FmtCtx, params []Expr) {
+	for i := range params {
+		if i == 0 {
+			ctx.WriteString(" USING ")
+		} else {
+			ctx.WriteString(", ")
+		}
+		params[i].Format(ctx)
+	}
+}
+
 // stmt_exit
 type Exit struct {
 	StatementImpl
@@ -593,11 +710,13 @@ type Return struct {
 }
 
 func (s *Return) Format(ctx *tree.FmtCtx) {
-	ctx.WriteString("RETURN ")
-	if s.Expr == nil {
-		s.RetVar.Format(ctx)
-	} else {
+	ctx.WriteString("RETURN")
+	if s.Expr != nil {
+		ctx.WriteString(" ")
 		s.Expr.Format(ctx)
+	} else if s.RetVar != "" {
+		ctx.WriteString(" ")
+		s.RetVar.Format(ctx)
 	}
 	ctx.WriteString(";\n")
 }
@@ -617,6 +736,15 @@ type ReturnNext struct {
 }
 
 func (s *ReturnNext) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString("RETURN NEXT")
+	if s.Expr != nil {
+		ctx.WriteString(" ")
+		s.Expr.Format(ctx)
+	} else if s.RetVar != "" {
+		ctx.WriteString(" ")
+		s.RetVar.Format(ctx)
+	}
+	ctx.WriteString(";\n")
 }
 
 func (s *ReturnNext) PlpgSQLStatementTag() string {
@@ -627,14 +755,25 @@ func (s *ReturnNext) WalkStmt(visitor StatementVisitor) {
 	visitor.Visit(s)
 }
 
+// ReturnQuery is either a RETURN QUERY statement with a static query, or a
+// RETURN QUERY EXECUTE statement with a dynamic query string.
 type ReturnQuery struct {
 	StatementImpl
-	Query        Expr
+	Query        tree.Statement
 	DynamicQuery Expr
 	Params       []Expr
 }
 
 func (s *ReturnQuery) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString("RETURN QUERY ")
+	if s.Query != nil {
+		s.Query.Format(ctx)
+	} else {
+		ctx.WriteString("EXECUTE ")
+		s.DynamicQuery.Format(ctx)
+		formatUsing(ctx, s.Params)
+	}
+	ctx.WriteString(";\n")
 }
 
 func (s *ReturnQuery) PlpgSQLStatementTag() string {
@@ -758,29 +897,26 @@ func (s *Execute) WalkStmt(visitor StatementVisitor) {
 }
 
 // stmt_dynexecute
-// TODO(chengxiong): query should be a better expression type.
 type DynamicExecute struct {
 	StatementImpl
-	Query  string
-	Into   bool
-	Strict bool
-	Target Variable
+	Query  Expr
+	Strict bool // INTO STRICT flag
+	Target []Variable
 	Params []Expr
 }
 
 func (s *DynamicExecute) Format(ctx *tree.FmtCtx) {
-	// TODO(drewk): Pretty print the original command
-	ctx.WriteString("EXECUTE a dynamic command")
-	if s.Into {
-		ctx.WriteString(" WITH INTO")
+	ctx.WriteString("EXECUTE ")
+	s.Query.Format(ctx)
+	if s.Target != nil {
+		ctx.WriteString(" INTO ")
 		if s.Strict {
-			ctx.WriteString(" STRICT")
+			ctx.WriteString("STRICT ")
 		}
+		formatTargets(ctx, s.Target)
 	}
-	if s.Params != nil {
-		ctx.WriteString(" WITH USING")
-	}
-	ctx.WriteString("\n")
+	formatUsing(ctx, s.Params)
+	ctx.WriteString(";\n")
 }
 
 func (s *DynamicExecute) PlpgSQLStatementTag() string {
@@ -794,10 +930,21 @@ func (s *DynamicExecute) WalkStmt(visitor StatementVisitor) {
 // stmt_perform
 type Perform struct {
 	StatementImpl
-	Expr Expr
+	// Query is the SELECT statement that is executed in place of the PERFORM.
+	// Its results are discarded.
+	Query tree.Statement
 }
 
 func (s *Perform) Format(ctx *tree.FmtCtx) {
+	// The query is formatted in-place and then the leading SELECT keyword is
+	// replaced with PERFORM, so that the formatting flags are respected.
+	start := ctx.Len()
+	s.Query.Format(ctx)
+	query := strings.TrimPrefix(string(ctx.Bytes()[start:]), "SELECT ")
+	ctx.Truncate(start)
+	ctx.WriteString("PERFORM ")
+	ctx.WriteString(query)
+	ctx.WriteString(";\n")
 }
 
 func (s *Perform) PlpgSQLStatementTag() string {
@@ -882,6 +1029,10 @@ type Open struct {
 	CurVar Variable
 	Scroll tree.CursorScrollOption
 	Query  tree.Statement
+	// DynamicQuery and Params are set for OPEN ... FOR EXECUTE statements, in
+	// which case Query is nil.
+	DynamicQuery Expr
+	Params       []Expr
 }
 
 func (s *Open) Format(ctx *tree.FmtCtx) {
@@ -896,6 +1047,10 @@ func (s *Open) Format(ctx *tree.FmtCtx) {
 	if s.Query != nil {
 		ctx.WriteString(" FOR ")
 		s.Query.Format(ctx)
+	} else if s.DynamicQuery != nil {
+		ctx.WriteString(" FOR EXECUTE ")
+		s.DynamicQuery.Format(ctx)
+		formatUsing(ctx, s.Params)
 	}
 	ctx.WriteString(";\n")
 }
@@ -983,6 +1138,11 @@ type Commit struct {
 }
 
 func (s *Commit) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString("COMMIT")
+	if s.Chain {
+		ctx.WriteString(" AND CHAIN")
+	}
+	ctx.WriteString(";\n")
 }
 
 func (s *Commit) PlpgSQLStatementTag() string {
@@ -1000,6 +1160,11 @@ type Rollback struct {
 }
 
 func (s *Rollback) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString("ROLLBACK")
+	if s.Chain {
+		ctx.WriteString(" AND CHAIN")
+	}
+	ctx.WriteString(";\n")
 }
 
 func (s *Rollback) PlpgSQLStatementTag() string {
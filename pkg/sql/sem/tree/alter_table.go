@@ -175,6 +175,8 @@ func (node *AlterTable) HoistAddColumnConstraints(onHoistedFKConstraint func())
 					Name:     d.References.ConstraintName,
 					Actions:  d.References.Actions,
 					Match:    d.References.Match,
+
+					Deferrability: d.References.Deferrability,
 				}
 				constraint := &AlterTableAddConstraint{
 					ConstraintDef:      fk,
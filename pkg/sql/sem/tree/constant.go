@@ -542,6 +542,26 @@ var (
 		types.UUIDArray,
 		types.INet,
 		types.Jsonb,
+		types.Jsonpath,
+		types.Point,
+		types.LSeg,
+		types.Line,
+		types.Box,
+		types.Path,
+		types.Polygon,
+		types.Circle,
+		types.Int4Range,
+		types.Int8Range,
+		types.NumRange,
+		types.TSRange,
+		types.TSTZRange,
+		types.DateRange,
+		types.Int4Multirange,
+		types.Int8Multirange,
+		types.NumMultirange,
+		types.TSMultirange,
+		types.TSTZMultirange,
+		types.DateMultirange,
 		types.PGLSN,
 		types.PGLSNArray,
 		types.RefCursor,
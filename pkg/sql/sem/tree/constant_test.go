@@ -378,6 +378,32 @@ func mustParseDTSQuery(t *testing.T, s string) tree.Datum {
 	}
 	return d
 }
+func mustParseDJsonpath(t *testing.T, s string) tree.Datum {
+	d, err := tree.ParseDJsonpath(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+func mustParseDGeometricOfType(typ *types.T) func(t *testing.T, s string) tree.Datum {
+	return func(t *testing.T, s string) tree.Datum {
+		d, err := tree.ParseDGeometric(typ, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+}
+func mustParseDRangeOfType(typ *types.T) func(t *testing.T, s string) tree.Datum {
+	return func(t *testing.T, s string) tree.Datum {
+		evalContext := eval.MakeTestingEvalContext(cluster.MakeTestingClusterSettings())
+		d, _, err := tree.ParseAndRequireString(typ, s, &evalContext)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+}
 func mustParseDArrayOfType(typ *types.T) func(t *testing.T, s string) tree.Datum {
 	return func(t *testing.T, s string) tree.Datum {
 		evalContext := eval.MakeTestingEvalContext(cluster.MakeTestingClusterSettings())
@@ -403,6 +429,7 @@ var parseFuncs = map[*types.T]func(*testing.T, string) tree.Datum{
 	types.TimestampTZ:      mustParseDTimestampTZ,
 	types.Interval:         mustParseDInterval,
 	types.Jsonb:            mustParseDJSON,
+	types.Jsonpath:         mustParseDJsonpath,
 	types.Uuid:             mustParseDUuid,
 	types.Box2D:            mustParseDBox2D,
 	types.Geography:        mustParseDGeography,
@@ -413,6 +440,25 @@ var parseFuncs = map[*types.T]func(*testing.T, string) tree.Datum{
 	types.RefCursor:        mustParseDRefCursor,
 	types.TSQuery:          mustParseDTSQuery,
 	types.TSVector:         mustParseDTSVector,
+	types.Point:            mustParseDGeometricOfType(types.Point),
+	types.LSeg:             mustParseDGeometricOfType(types.LSeg),
+	types.Line:             mustParseDGeometricOfType(types.Line),
+	types.Box:              mustParseDGeometricOfType(types.Box),
+	types.Path:             mustParseDGeometricOfType(types.Path),
+	types.Polygon:          mustParseDGeometricOfType(types.Polygon),
+	types.Circle:           mustParseDGeometricOfType(types.Circle),
+	types.Int4Range:        mustParseDRangeOfType(types.Int4Range),
+	types.Int8Range:        mustParseDRangeOfType(types.Int8Range),
+	types.NumRange:         mustParseDRangeOfType(types.NumRange),
+	types.TSRange:          mustParseDRangeOfType(types.TSRange),
+	types.TSTZRange:        mustParseDRangeOfType(types.TSTZRange),
+	types.DateRange:        mustParseDRangeOfType(types.DateRange),
+	types.Int4Multirange:   mustParseDRangeOfType(types.Int4Multirange),
+	types.Int8Multirange:   mustParseDRangeOfType(types.Int8Multirange),
+	types.NumMultirange:    mustParseDRangeOfType(types.NumMultirange),
+	types.TSMultirange:     mustParseDRangeOfType(types.TSMultirange),
+	types.TSTZMultirange:   mustParseDRangeOfType(types.TSTZMultirange),
+	types.DateMultirange:   mustParseDRangeOfType(types.DateMultirange),
 	types.BytesArray:       mustParseDArrayOfType(types.Bytes),
 	types.DecimalArray:     mustParseDArrayOfType(types.Decimal),
 	types.FloatArray:       mustParseDArrayOfType(types.Float),
@@ -458,8 +504,8 @@ func TestStringConstantResolveAvailableTypes(t *testing.T) {
 		},
 		{
 			c: tree.NewStrVal("true"),
-			parseOptions: typeSet(types.String, types.Bytes, types.Bool, types.Jsonb, types.TSVector,
-				types.TSQuery, types.RefCursor),
+			parseOptions: typeSet(types.String, types.Bytes, types.Bool, types.Jsonb, types.Jsonpath,
+				types.TSVector, types.TSQuery, types.RefCursor),
 		},
 		{
 			c: tree.NewStrVal("2010-09-28"),
@@ -527,6 +573,7 @@ func TestStringConstantResolveAvailableTypes(t *testing.T) {
 				types.Decimal,
 				types.Interval,
 				types.Jsonb,
+				types.Jsonpath,
 				types.TSVector,
 				types.TSQuery,
 				types.RefCursor,
@@ -695,7 +742,8 @@ func TestStringConstantResolveAvailableTypes(t *testing.T) {
 						!strings.Contains(err.Error(), "parsing") &&
 						!strings.Contains(err.Error(), "out of range") &&
 						!strings.Contains(err.Error(), "exceeds supported") &&
-						!strings.Contains(err.Error(), "invalid input syntax") {
+						!strings.Contains(err.Error(), "invalid input syntax") &&
+						!strings.Contains(err.Error(), "malformed") {
 						// Parsing errors are permitted for this test, but the number of correctly
 						// parseable types will be verified. Any other error should throw a failure.
 						t.Errorf("%d: expected resolving %v as available type %s would either succeed"+
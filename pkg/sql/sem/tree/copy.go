@@ -20,7 +20,12 @@ type CopyFrom struct {
 	Table   TableName
 	Columns NameList
 	Stdin   bool
+	// File is the URI of the external storage file the rows are read from. It
+	// is only set if Stdin is false.
+	File    Expr
 	Options CopyOptions
+	// Where, if set, filters the rows that are inserted.
+	Where *Where
 }
 
 // CopyTo represents a COPY TO statement.
@@ -28,7 +33,10 @@ type CopyTo struct {
 	Table     TableName
 	Columns   NameList
 	Statement Statement
-	Options   CopyOptions
+	// File is the URI of the external storage file the rows are written to. If
+	// it is nil, the rows are sent to the client.
+	File    Expr
+	Options CopyOptions
 }
 
 // Format implements the NodeFormatter interface.
@@ -46,7 +54,12 @@ func (node *CopyTo) Format(ctx *FmtCtx) {
 			ctx.WriteString(")")
 		}
 	}
-	ctx.WriteString(" TO STDOUT")
+	ctx.WriteString(" TO ")
+	if node.File != nil {
+		ctx.FormatNode(node.File)
+	} else {
+		ctx.WriteString("STDOUT")
+	}
 	if !node.Options.IsDefault() {
 		ctx.WriteString(" WITH ")
 		ctx.FormatNode(&node.Options)
@@ -63,6 +76,19 @@ type CopyOptions struct {
 	Header      bool
 	Quote       *StrVal
 
+	// ForceQuote is the list of columns whose non-NULL values are always
+	// quoted by COPY TO in CSV format. ForceQuoteAll applies this to all
+	// columns.
+	ForceQuote    NameList
+	ForceQuoteAll bool
+	// ForceNotNull is the list of columns whose values are never matched
+	// against the NULL string by COPY FROM in CSV format.
+	ForceNotNull NameList
+	// ForceNull is the list of columns whose values are matched against the
+	// NULL string by COPY FROM in CSV format even if they are quoted.
+	ForceNull NameList
+	Encoding  *StrVal
+
 	// Additional flags are needed to keep track of whether explicit default
 	// values were already set.
 	HasFormat bool
@@ -83,11 +109,17 @@ func (node *CopyFrom) Format(ctx *FmtCtx) {
 	ctx.WriteString(" FROM ")
 	if node.Stdin {
 		ctx.WriteString("STDIN")
+	} else if node.File != nil {
+		ctx.FormatNode(node.File)
 	}
 	if !node.Options.IsDefault() {
 		ctx.WriteString(" WITH ")
 		ctx.FormatNode(&node.Options)
 	}
+	if node.Where != nil {
+		ctx.WriteByte(' ')
+		ctx.FormatNode(node.Where)
+	}
 }
 
 // Format implements the NodeFormatter interface
@@ -151,12 +183,41 @@ func (o *CopyOptions) Format(ctx *FmtCtx) {
 		ctx.WriteString("QUOTE ")
 		ctx.FormatNode(o.Quote)
 	}
+	if o.ForceQuoteAll {
+		maybeAddSep()
+		ctx.WriteString("FORCE_QUOTE *")
+	} else if len(o.ForceQuote) > 0 {
+		maybeAddSep()
+		ctx.WriteString("FORCE_QUOTE (")
+		ctx.FormatNode(&o.ForceQuote)
+		ctx.WriteString(")")
+	}
+	if len(o.ForceNotNull) > 0 {
+		maybeAddSep()
+		ctx.WriteString("FORCE_NOT_NULL (")
+		ctx.FormatNode(&o.ForceNotNull)
+		ctx.WriteString(")")
+	}
+	if len(o.ForceNull) > 0 {
+		maybeAddSep()
+		ctx.WriteString("FORCE_NULL (")
+		ctx.FormatNode(&o.ForceNull)
+		ctx.WriteString(")")
+	}
+	if o.Encoding != nil {
+		maybeAddSep()
+		ctx.WriteString("ENCODING ")
+		ctx.FormatNode(o.Encoding)
+	}
 	ctx.WriteString(")")
 }
 
 // IsDefault returns true if this struct has default value.
-func (o CopyOptions) IsDefault() bool {
-	return o == CopyOptions{}
+func (o *CopyOptions) IsDefault() bool {
+	return o.Destination == nil && o.CopyFormat == CopyFormatText && o.Delimiter == nil &&
+		o.Null == nil && o.Escape == nil && !o.Header && o.Quote == nil &&
+		!o.HasFormat && !o.HasHeader && len(o.ForceQuote) == 0 && !o.ForceQuoteAll &&
+		len(o.ForceNotNull) == 0 && len(o.ForceNull) == 0 && o.Encoding == nil
 }
 
 // CombineWith merges other options into this struct. An error is returned if
@@ -206,6 +267,31 @@ func (o *CopyOptions) CombineWith(other *CopyOptions) error {
 		}
 		o.Quote = other.Quote
 	}
+	if len(other.ForceQuote) > 0 || other.ForceQuoteAll {
+		if len(o.ForceQuote) > 0 || o.ForceQuoteAll {
+			return pgerror.Newf(pgcode.Syntax, "force_quote option specified multiple times")
+		}
+		o.ForceQuote = other.ForceQuote
+		o.ForceQuoteAll = other.ForceQuoteAll
+	}
+	if len(other.ForceNotNull) > 0 {
+		if len(o.ForceNotNull) > 0 {
+			return pgerror.Newf(pgcode.Syntax, "force_not_null option specified multiple times")
+		}
+		o.ForceNotNull = other.ForceNotNull
+	}
+	if len(other.ForceNull) > 0 {
+		if len(o.ForceNull) > 0 {
+			return pgerror.Newf(pgcode.Syntax, "force_null option specified multiple times")
+		}
+		o.ForceNull = other.ForceNull
+	}
+	if other.Encoding != nil {
+		if o.Encoding != nil {
+			return pgerror.Newf(pgcode.Syntax, "encoding option specified multiple times")
+		}
+		o.Encoding = other.Encoding
+	}
 	return nil
 }
 
@@ -112,15 +112,39 @@ func (node *CreateRoutine) Format(ctx *FmtCtx) {
 	}
 	ctx.FormatNode(&node.Name)
 	ctx.WriteByte('(')
-	ctx.FormatNode(node.Params)
+	// The columns of a function that returns TABLE are formatted as its return
+	// type rather than as parameters.
+	var params, tableCols RoutineParams
+	for i := range node.Params {
+		if node.Params[i].Class == RoutineParamTable {
+			tableCols = append(tableCols, node.Params[i])
+		} else {
+			params = append(params, node.Params[i])
+		}
+	}
+	ctx.FormatNode(params)
 	ctx.WriteString(")\n\t")
 	if !node.IsProcedure {
-		ctx.WriteString("RETURNS ")
-		if node.ReturnType.SetOf {
-			ctx.WriteString("SETOF ")
+		if len(tableCols) > 0 {
+			ctx.WriteString("RETURNS TABLE (")
+			for i := range tableCols {
+				if i > 0 {
+					ctx.WriteString(", ")
+				}
+				ctx.FormatNode(&tableCols[i].Name)
+				ctx.WriteByte(' ')
+				ctx.FormatTypeReference(tableCols[i].Type)
+			}
+			ctx.WriteString(")\n\t")
+		} else if node.ReturnType.Type != nil {
+			// The return type may be omitted if the function has OUT parameters.
+			ctx.WriteString("RETURNS ")
+			if node.ReturnType.SetOf {
+				ctx.WriteString("SETOF ")
+			}
+			ctx.FormatTypeReference(node.ReturnType.Type)
+			ctx.WriteString("\n\t")
 		}
-		ctx.FormatTypeReference(node.ReturnType.Type)
-		ctx.WriteString("\n\t")
 	}
 	var funcBody RoutineBodyStr
 	for _, option := range node.Options {
@@ -163,6 +187,74 @@ func (node *CreateRoutine) Format(ctx *FmtCtx) {
 	}
 }
 
+// InputParams returns the parameters of the routine for which arguments are
+// passed when it is invoked.
+func (node *CreateRoutine) InputParams() RoutineParams {
+	params := make(RoutineParams, 0, len(node.Params))
+	for i := range node.Params {
+		if node.Params[i].Class.IsInput(node.IsProcedure) {
+			params = append(params, node.Params[i])
+		}
+	}
+	return params
+}
+
+// CreateAggregate represents a CREATE AGGREGATE statement.
+type CreateAggregate struct {
+	Replace bool
+	Name    RoutineName
+	Params  RoutineParams
+	Options AggregateOptions
+}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateAggregate) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE ")
+	if node.Replace {
+		ctx.WriteString("OR REPLACE ")
+	}
+	ctx.WriteString("AGGREGATE ")
+	ctx.FormatNode(&node.Name)
+	ctx.WriteByte('(')
+	ctx.FormatNode(node.Params)
+	ctx.WriteString(") (")
+	ctx.FormatNode(node.Options)
+	ctx.WriteByte(')')
+}
+
+// AggregateOptions is a list of the options of a CREATE AGGREGATE statement.
+type AggregateOptions []AggregateOption
+
+// Format implements the NodeFormatter interface.
+func (node AggregateOptions) Format(ctx *FmtCtx) {
+	for i := range node {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(&node[i])
+	}
+}
+
+// AggregateOption is a "name = value" option of a CREATE AGGREGATE statement.
+// Options which name a function or a type, like SFUNC and STYPE, have a Type,
+// while INITCOND has a constant Value.
+type AggregateOption struct {
+	Name  Name
+	Type  ResolvableTypeReference
+	Value Expr
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AggregateOption) Format(ctx *FmtCtx) {
+	ctx.WriteString(strings.ToUpper(string(node.Name)))
+	ctx.WriteString(" = ")
+	if node.Type != nil {
+		ctx.FormatTypeReference(node.Type)
+	} else {
+		ctx.FormatNode(node.Value)
+	}
+}
+
 // RoutineBody represent a list of statements in a UDF body.
 type RoutineBody struct {
 	// Stmts is populated during parsing. Unlike BodyStatements, we don't need
@@ -195,6 +287,8 @@ func (RoutineVolatility) routineOption()        {}
 func (RoutineLeakproof) routineOption()         {}
 func (RoutineBodyStr) routineOption()           {}
 func (RoutineLanguage) routineOption()          {}
+func (RoutineSecurity) routineOption()          {}
+func (RoutineSet) routineOption()               {}
 
 // RoutineNullInputBehavior represent the UDF property on null parameters.
 type RoutineNullInputBehavior int
@@ -267,6 +361,71 @@ func (node RoutineLeakproof) Format(ctx *FmtCtx) {
 	ctx.WriteString("LEAKPROOF")
 }
 
+// RoutineSecurity indicates whose privileges are used while a routine
+// executes. The default is SECURITY INVOKER if no security option is provided.
+type RoutineSecurity int
+
+const (
+	// RoutineInvoker indicates that the routine executes with the privileges of
+	// the user that invokes it.
+	RoutineInvoker RoutineSecurity = iota
+	// RoutineDefiner indicates that the routine executes with the privileges of
+	// the user that owns it.
+	RoutineDefiner
+)
+
+// Format implements the NodeFormatter interface.
+func (node RoutineSecurity) Format(ctx *FmtCtx) {
+	switch node {
+	case RoutineInvoker:
+		ctx.WriteString("SECURITY INVOKER")
+	case RoutineDefiner:
+		ctx.WriteString("SECURITY DEFINER")
+	default:
+		panic(pgerror.New(pgcode.InvalidParameterValue, "unknown routine option"))
+	}
+}
+
+// RoutineSet sets a session variable to the given value while the routine
+// executes. If Reset is true, the setting of the variable is removed from the
+// routine instead, and if ResetAll is true, all settings are removed.
+type RoutineSet struct {
+	Name   string
+	Values Exprs
+	// FromCurrent is true if the variable is set to its value in the session
+	// that creates or alters the routine.
+	FromCurrent bool
+	Reset       bool
+	ResetAll    bool
+}
+
+// Format implements the NodeFormatter interface.
+func (node RoutineSet) Format(ctx *FmtCtx) {
+	if node.ResetAll {
+		ctx.WriteString("RESET ALL")
+		return
+	}
+	if node.Reset {
+		ctx.WriteString("RESET ")
+	} else {
+		ctx.WriteString("SET ")
+	}
+	ctx.WithFlags(ctx.flags & ^FmtAnonymize & ^FmtMarkRedactionNode, func() {
+		// Session var names never contain PII and should be distinguished
+		// for feature tracking purposes.
+		ctx.FormatNameP(&node.Name)
+	})
+	if node.Reset {
+		return
+	}
+	if node.FromCurrent {
+		ctx.WriteString(" FROM CURRENT")
+		return
+	}
+	ctx.WriteString(" = ")
+	ctx.FormatNode(&node.Values)
+}
+
 // RoutineLanguage indicates the language of the statements in the routine body.
 type RoutineLanguage string
 
@@ -384,18 +543,35 @@ const (
 	RoutineParamInOut
 	// RoutineParamVariadic args are variadic.
 	RoutineParamVariadic
+	// RoutineParamTable args are the output columns of a function declared with
+	// RETURNS TABLE. They are only used as output.
+	RoutineParamTable
 )
 
+// IsOutput returns true if the parameter class is used as output.
+func (c RoutineParamClass) IsOutput() bool {
+	return c == RoutineParamOut || c == RoutineParamInOut || c == RoutineParamTable
+}
+
+// IsInput returns true if an argument is passed for a parameter of the class
+// when a routine is invoked. Arguments are passed for all parameters of a
+// procedure, including its OUT parameters.
+func (c RoutineParamClass) IsInput(isProcedure bool) bool {
+	return isProcedure || (c != RoutineParamOut && c != RoutineParamTable)
+}
+
 // RoutineReturnType represent the return type of UDF.
 type RoutineReturnType struct {
 	Type  ResolvableTypeReference
 	SetOf bool
 }
 
-// DropRoutine represents a DROP FUNCTION or DROP PROCEDURE statement.
+// DropRoutine represents a DROP FUNCTION, DROP PROCEDURE or DROP AGGREGATE
+// statement.
 type DropRoutine struct {
 	IfExists     bool
 	Procedure    bool
+	Aggregate    bool
 	Routines     RoutineObjs
 	DropBehavior DropBehavior
 }
@@ -404,6 +580,8 @@ type DropRoutine struct {
 func (node *DropRoutine) Format(ctx *FmtCtx) {
 	if node.Procedure {
 		ctx.WriteString("DROP PROCEDURE ")
+	} else if node.Aggregate {
+		ctx.WriteString("DROP AGGREGATE ")
 	} else {
 		ctx.WriteString("DROP FUNCTION ")
 	}
@@ -447,22 +625,24 @@ func (node *RoutineObj) Format(ctx *FmtCtx) {
 	}
 }
 
-// ParamTypes returns a slice of parameter types of the routine.
+// ParamTypes returns a slice of parameter types of the routine. The OUT
+// parameters of a function are not part of its signature, so they are ignored
+// unless the routine may be a procedure.
 func (node RoutineObj) ParamTypes(
-	ctx context.Context, res TypeReferenceResolver,
+	ctx context.Context, res TypeReferenceResolver, routineType RoutineType,
 ) ([]*types.T, error) {
-	// TODO(chengxiong): handle INOUT, OUT and VARIADIC argument classes when we
-	// support them. This is because only IN and INOUT arg types need to be
-	// considered to match a overload.
 	var argTypes []*types.T
 	if node.Params != nil {
-		argTypes = make([]*types.T, len(node.Params))
-		for i, arg := range node.Params {
+		argTypes = make([]*types.T, 0, len(node.Params))
+		for _, arg := range node.Params {
+			if !arg.Class.IsInput(routineType&ProcedureRoutine != 0) {
+				continue
+			}
 			typ, err := ResolveType(ctx, arg.Type, res)
 			if err != nil {
 				return nil, err
 			}
-			argTypes[i] = typ
+			argTypes = append(argTypes, typ)
 		}
 	}
 	return argTypes, nil
@@ -484,18 +664,21 @@ func (node *AlterFunctionOptions) Format(ctx *FmtCtx) {
 	}
 }
 
-// AlterRoutineRename represents a ALTER FUNCTION...RENAME or
-// ALTER PROCEDURE...RENAME statement.
+// AlterRoutineRename represents a ALTER FUNCTION...RENAME,
+// ALTER PROCEDURE...RENAME or ALTER AGGREGATE...RENAME statement.
 type AlterRoutineRename struct {
 	Function  RoutineObj
 	NewName   Name
 	Procedure bool
+	Aggregate bool
 }
 
 // Format implements the NodeFormatter interface.
 func (node *AlterRoutineRename) Format(ctx *FmtCtx) {
 	if node.Procedure {
 		ctx.WriteString("ALTER PROCEDURE ")
+	} else if node.Aggregate {
+		ctx.WriteString("ALTER AGGREGATE ")
 	} else {
 		ctx.WriteString("ALTER FUNCTION ")
 	}
@@ -504,18 +687,21 @@ func (node *AlterRoutineRename) Format(ctx *FmtCtx) {
 	ctx.FormatNode(&node.NewName)
 }
 
-// AlterRoutineSetSchema represents a ALTER FUNCTION...SET SCHEMA or
-// ALTER PROCEDURE...SET SCHEMA statement.
+// AlterRoutineSetSchema represents a ALTER FUNCTION...SET SCHEMA,
+// ALTER PROCEDURE...SET SCHEMA or ALTER AGGREGATE...SET SCHEMA statement.
 type AlterRoutineSetSchema struct {
 	Function      RoutineObj
 	NewSchemaName Name
 	Procedure     bool
+	Aggregate     bool
 }
 
 // Format implements the NodeFormatter interface.
 func (node *AlterRoutineSetSchema) Format(ctx *FmtCtx) {
 	if node.Procedure {
 		ctx.WriteString("ALTER PROCEDURE ")
+	} else if node.Aggregate {
+		ctx.WriteString("ALTER AGGREGATE ")
 	} else {
 		ctx.WriteString("ALTER FUNCTION ")
 	}
@@ -524,18 +710,21 @@ func (node *AlterRoutineSetSchema) Format(ctx *FmtCtx) {
 	ctx.FormatNode(&node.NewSchemaName)
 }
 
-// AlterRoutineSetOwner represents the ALTER FUNCTION...OWNER TO or
-// ALTER PROCEDURE...OWNER TO statement.
+// AlterRoutineSetOwner represents the ALTER FUNCTION...OWNER TO,
+// ALTER PROCEDURE...OWNER TO or ALTER AGGREGATE...OWNER TO statement.
 type AlterRoutineSetOwner struct {
 	Function  RoutineObj
 	NewOwner  RoleSpec
 	Procedure bool
+	Aggregate bool
 }
 
 // Format implements the NodeFormatter interface.
 func (node *AlterRoutineSetOwner) Format(ctx *FmtCtx) {
 	if node.Procedure {
 		ctx.WriteString("ALTER PROCEDURE ")
+	} else if node.Aggregate {
+		ctx.WriteString("ALTER AGGREGATE ")
 	} else {
 		ctx.WriteString("ALTER FUNCTION ")
 	}
@@ -604,6 +793,8 @@ const (
 	TTLExpirationExpr               SchemaExprContext = "TTL EXPIRATION EXPRESSION"
 	TTLDefaultExpr                  SchemaExprContext = "TTL DEFAULT"
 	TTLUpdateExpr                   SchemaExprContext = "TTL UPDATE"
+	DomainCheckExpr                 SchemaExprContext = "DOMAIN CHECK"
+	DomainDefaultExpr               SchemaExprContext = "DOMAIN DEFAULT"
 )
 
 func ComputedColumnExprContext(isVirtual bool) SchemaExprContext {
@@ -616,7 +807,7 @@ func ComputedColumnExprContext(isVirtual bool) SchemaExprContext {
 // ValidateRoutineOptions checks whether there are conflicting or redundant
 // routine options in the given slice.
 func ValidateRoutineOptions(options RoutineOptions) error {
-	var hasLang, hasBody, hasLeakProof, hasVolatility, hasNullInputBehavior bool
+	var hasLang, hasBody, hasLeakProof, hasVolatility, hasNullInputBehavior, hasSecurity bool
 	conflictingErr := func(opt RoutineOption) error {
 		return errors.Wrapf(ErrConflictingRoutineOption, "%s", AsString(opt))
 	}
@@ -647,6 +838,13 @@ func ValidateRoutineOptions(options RoutineOptions) error {
 				return conflictingErr(option)
 			}
 			hasNullInputBehavior = true
+		case RoutineSecurity:
+			if hasSecurity {
+				return conflictingErr(option)
+			}
+			hasSecurity = true
+		case RoutineSet:
+			// A routine may set any number of session variables.
 		default:
 			return pgerror.Newf(pgcode.InvalidParameterValue, "unknown function option: ", AsString(option))
 		}
@@ -0,0 +1,184 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import "github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+
+// CreateTrigger represents a CREATE TRIGGER statement.
+type CreateTrigger struct {
+	Replace     bool
+	Name        Name
+	ActionTime  TriggerActionTime
+	Events      []*TriggerEvent
+	TableName   *UnresolvedObjectName
+	Transitions []*TriggerTransition
+	ForEach     TriggerForEach
+	When        Expr
+	FuncName    *UnresolvedName
+	FuncArgs    []string
+}
+
+var _ Statement = &CreateTrigger{}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateTrigger) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE ")
+	if node.Replace {
+		ctx.WriteString("OR REPLACE ")
+	}
+	ctx.WriteString("TRIGGER ")
+	ctx.FormatNode(&node.Name)
+	ctx.WriteByte(' ')
+	ctx.FormatNode(node.ActionTime)
+	ctx.WriteByte(' ')
+	for i := range node.Events {
+		if i > 0 {
+			ctx.WriteString(" OR ")
+		}
+		ctx.FormatNode(node.Events[i])
+	}
+	ctx.WriteString(" ON ")
+	ctx.FormatNode(node.TableName)
+	if len(node.Transitions) > 0 {
+		ctx.WriteString(" REFERENCING ")
+		for i := range node.Transitions {
+			if i > 0 {
+				ctx.WriteByte(' ')
+			}
+			ctx.FormatNode(node.Transitions[i])
+		}
+	}
+	ctx.WriteByte(' ')
+	ctx.FormatNode(node.ForEach)
+	if node.When != nil {
+		ctx.WriteString(" WHEN (")
+		ctx.FormatNode(node.When)
+		ctx.WriteByte(')')
+	}
+	ctx.WriteString(" EXECUTE FUNCTION ")
+	ctx.FormatNode(node.FuncName)
+	ctx.WriteByte('(')
+	for i := range node.FuncArgs {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		if ctx.flags.HasFlags(FmtHideConstants) {
+			ctx.WriteString("'_'")
+		} else {
+			lexbase.EncodeSQLStringWithFlags(&ctx.Buffer, node.FuncArgs[i], ctx.flags.EncodeFlags())
+		}
+	}
+	ctx.WriteByte(')')
+}
+
+// TriggerActionTime describes when a trigger fires relative to the event
+// that caused it.
+type TriggerActionTime uint8
+
+// TriggerActionTime values.
+const (
+	TriggerActionTimeBefore TriggerActionTime = iota
+	TriggerActionTimeAfter
+	TriggerActionTimeInsteadOf
+)
+
+var triggerActionTimeName = [...]string{
+	TriggerActionTimeBefore:    "BEFORE",
+	TriggerActionTimeAfter:     "AFTER",
+	TriggerActionTimeInsteadOf: "INSTEAD OF",
+}
+
+// String implements the fmt.Stringer interface.
+func (t TriggerActionTime) String() string {
+	return triggerActionTimeName[t]
+}
+
+// Format implements the NodeFormatter interface.
+func (t TriggerActionTime) Format(ctx *FmtCtx) {
+	ctx.WriteString(t.String())
+}
+
+// TriggerEventType describes the statement type that causes a trigger to
+// fire.
+type TriggerEventType uint8
+
+// TriggerEventType values.
+const (
+	TriggerEventInsert TriggerEventType = iota
+	TriggerEventUpdate
+	TriggerEventDelete
+	TriggerEventTruncate
+)
+
+var triggerEventTypeName = [...]string{
+	TriggerEventInsert:   "INSERT",
+	TriggerEventUpdate:   "UPDATE",
+	TriggerEventDelete:   "DELETE",
+	TriggerEventTruncate: "TRUNCATE",
+}
+
+// String implements the fmt.Stringer interface.
+func (t TriggerEventType) String() string {
+	return triggerEventTypeName[t]
+}
+
+// TriggerEvent represents one of the events that cause a trigger to fire.
+// Columns is only set for UPDATE OF events.
+type TriggerEvent struct {
+	EventType TriggerEventType
+	Columns   NameList
+}
+
+// Format implements the NodeFormatter interface.
+func (node *TriggerEvent) Format(ctx *FmtCtx) {
+	ctx.WriteString(node.EventType.String())
+	if len(node.Columns) > 0 {
+		ctx.WriteString(" OF ")
+		ctx.FormatNode(&node.Columns)
+	}
+}
+
+// TriggerTransition represents a transition relation declared in the
+// REFERENCING clause of a CREATE TRIGGER statement.
+type TriggerTransition struct {
+	Name  Name
+	IsNew bool
+}
+
+// Format implements the NodeFormatter interface.
+func (node *TriggerTransition) Format(ctx *FmtCtx) {
+	if node.IsNew {
+		ctx.WriteString("NEW")
+	} else {
+		ctx.WriteString("OLD")
+	}
+	ctx.WriteString(" TABLE AS ")
+	ctx.FormatNode(&node.Name)
+}
+
+// TriggerForEach describes whether a trigger fires once for each modified
+// row, or once for the whole statement.
+type TriggerForEach uint8
+
+// TriggerForEach values.
+const (
+	TriggerForEachStatement TriggerForEach = iota
+	TriggerForEachRow
+)
+
+// Format implements the NodeFormatter interface.
+func (t TriggerForEach) Format(ctx *FmtCtx) {
+	if t == TriggerForEachRow {
+		ctx.WriteString("FOR EACH ROW")
+	} else {
+		ctx.WriteString("FOR EACH STATEMENT")
+	}
+}
@@ -25,6 +25,7 @@ import (
 
 	"github.com/cockroachdb/apd/v3"
 	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/cockroachdb/cockroach/pkg/geo/geometric"
 	"github.com/cockroachdb/cockroach/pkg/sql/lex"
 	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
@@ -38,6 +39,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
 	"github.com/cockroachdb/cockroach/pkg/util/ipaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/jsonpath"
 	"github.com/cockroachdb/cockroach/pkg/util/stringencoding"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
 	"github.com/cockroachdb/cockroach/pkg/util/timetz"
@@ -4027,7 +4029,7 @@ func AsJSON(
 		// This is RFC3339Nano, but without the TZ fields.
 		return json.FromString(formatTime(t.UTC(), "2006-01-02T15:04:05.999999999")), nil
 	case *DDate, *DUuid, *DOid, *DInterval, *DBytes, *DIPAddr, *DTime, *DTimeTZ, *DBitArray, *DBox2D,
-		*DTSVector, *DTSQuery, *DPGLSN:
+		*DTSVector, *DTSQuery, *DPGLSN, *DJsonpath, *DGeometric, *DRange, *DMultirange:
 		return json.FromString(
 			AsStringWithFlags(t, FmtBareStrings, FmtDataConversionConfig(dcc), FmtLocation(loc)),
 		), nil
@@ -4140,6 +4142,294 @@ func (d *DJSON) Size() uintptr {
 	return unsafe.Sizeof(*d) + d.JSON.Size()
 }
 
+// DJsonpath is the jsonpath Datum.
+type DJsonpath struct {
+	jsonpath.Jsonpath
+}
+
+// NewDJsonpath is a helper routine to create a DJsonpath initialized from its
+// argument.
+func NewDJsonpath(j jsonpath.Jsonpath) *DJsonpath {
+	return &DJsonpath{Jsonpath: j}
+}
+
+// ParseDJsonpath takes a string of jsonpath and returns a DJsonpath value.
+func ParseDJsonpath(s string) (Datum, error) {
+	j, err := jsonpath.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	return NewDJsonpath(j), nil
+}
+
+// AsDJsonpath attempts to retrieve a DJsonpath from an Expr, returning a
+// DJsonpath and a flag signifying whether the assertion was successful. The
+// function should be used instead of direct type assertions wherever a
+// *DJsonpath wrapped by a *DOidWrapper is possible.
+func AsDJsonpath(e Expr) (*DJsonpath, bool) {
+	switch t := e.(type) {
+	case *DJsonpath:
+		return t, true
+	case *DOidWrapper:
+		return AsDJsonpath(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDJsonpath attempts to retrieve a DJsonpath from an Expr, panicking if
+// the assertion fails.
+func MustBeDJsonpath(e Expr) *DJsonpath {
+	v, ok := AsDJsonpath(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DJsonpath, found %T", e))
+	}
+	return v
+}
+
+// Format implements the NodeFormatter interface.
+func (d *DJsonpath) Format(ctx *FmtCtx) {
+	bareStrings := ctx.HasFlags(FmtFlags(lexbase.EncBareStrings))
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+	str := d.Jsonpath.String()
+	if !bareStrings {
+		str = strings.ReplaceAll(str, `'`, `''`)
+	}
+	ctx.WriteString(str)
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (d *DJsonpath) ResolvedType() *types.T {
+	return types.Jsonpath
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (d *DJsonpath) AmbiguousFormat() bool { return true }
+
+// Compare implements the Datum interface.
+func (d *DJsonpath) Compare(ctx CompareContext, other Datum) int {
+	res, err := d.CompareError(ctx, other)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// CompareError implements the Datum interface.
+func (d *DJsonpath) CompareError(ctx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := ctx.UnwrapDatum(other).(*DJsonpath)
+	if !ok {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	return strings.Compare(d.Jsonpath.String(), v.Jsonpath.String()), nil
+}
+
+// Prev implements the Datum interface.
+func (d *DJsonpath) Prev(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DJsonpath) Next(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMin implements the Datum interface.
+func (d *DJsonpath) IsMin(_ CompareContext) bool {
+	return false
+}
+
+// IsMax implements the Datum interface.
+func (d *DJsonpath) IsMax(_ CompareContext) bool {
+	return false
+}
+
+// Max implements the Datum interface.
+func (d *DJsonpath) Max(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DJsonpath) Min(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Size implements the Datum interface.
+func (d *DJsonpath) Size() uintptr {
+	return unsafe.Sizeof(*d) + uintptr(len(d.Jsonpath.String()))
+}
+
+// DGeometric is the Datum for the geometric types point, lseg, line, box,
+// path, polygon and circle. The type of the datum is determined by the kind
+// of its shape.
+type DGeometric struct {
+	geometric.Shape
+}
+
+// NewDGeometric is a helper routine to create a DGeometric initialized from
+// its argument.
+func NewDGeometric(s geometric.Shape) *DGeometric {
+	return &DGeometric{Shape: s}
+}
+
+// geometricKindTypes maps each geometric kind to the type of its values.
+var geometricKindTypes = [...]*types.T{
+	geometric.PointKind:   types.Point,
+	geometric.LSegKind:    types.LSeg,
+	geometric.LineKind:    types.Line,
+	geometric.BoxKind:     types.Box,
+	geometric.PathKind:    types.Path,
+	geometric.PolygonKind: types.Polygon,
+	geometric.CircleKind:  types.Circle,
+}
+
+// GeometricType returns the type of the shapes of the given kind.
+func GeometricType(k geometric.Kind) *types.T {
+	return geometricKindTypes[k]
+}
+
+// GeometricKind returns the kind of the shapes of the given geometric type.
+func GeometricKind(t *types.T) geometric.Kind {
+	switch t.Oid() {
+	case oid.T_point:
+		return geometric.PointKind
+	case oid.T_lseg:
+		return geometric.LSegKind
+	case oid.T_line:
+		return geometric.LineKind
+	case oid.T_box:
+		return geometric.BoxKind
+	case oid.T_path:
+		return geometric.PathKind
+	case oid.T_polygon:
+		return geometric.PolygonKind
+	case oid.T_circle:
+		return geometric.CircleKind
+	}
+	panic(errors.AssertionFailedf("unexpected geometric type %s", t.SQLStringForError()))
+}
+
+// ParseDGeometric takes the string representation of a value of the given
+// geometric type and returns a DGeometric value.
+func ParseDGeometric(t *types.T, s string) (*DGeometric, error) {
+	shape, err := geometric.Parse(GeometricKind(t), s)
+	if err != nil {
+		return nil, err
+	}
+	return NewDGeometric(shape), nil
+}
+
+// AsDGeometric attempts to retrieve a DGeometric from an Expr, returning a
+// DGeometric and a flag signifying whether the assertion was successful. The
+// function should be used instead of direct type assertions wherever a
+// *DGeometric wrapped by a *DOidWrapper is possible.
+func AsDGeometric(e Expr) (*DGeometric, bool) {
+	switch t := e.(type) {
+	case *DGeometric:
+		return t, true
+	case *DOidWrapper:
+		return AsDGeometric(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDGeometric attempts to retrieve a DGeometric from an Expr, panicking
+// if the assertion fails.
+func MustBeDGeometric(e Expr) *DGeometric {
+	v, ok := AsDGeometric(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DGeometric, found %T", e))
+	}
+	return v
+}
+
+// Format implements the NodeFormatter interface.
+func (d *DGeometric) Format(ctx *FmtCtx) {
+	bareStrings := ctx.HasFlags(FmtFlags(lexbase.EncBareStrings))
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+	ctx.Write(geometric.AppendText(ctx.scratch[:0], d.Shape))
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (d *DGeometric) ResolvedType() *types.T {
+	return GeometricType(d.Kind())
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (d *DGeometric) AmbiguousFormat() bool { return true }
+
+// Compare implements the Datum interface.
+func (d *DGeometric) Compare(ctx CompareContext, other Datum) int {
+	res, err := d.CompareError(ctx, other)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// CompareError implements the Datum interface. Geometric values have no
+// meaningful ordering, so this compares their coordinates in order to
+// provide a total order for operations like DISTINCT.
+func (d *DGeometric) CompareError(ctx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := ctx.UnwrapDatum(other).(*DGeometric)
+	if !ok || v.Kind() != d.Kind() {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	return geometric.Compare(d.Shape, v.Shape), nil
+}
+
+// Prev implements the Datum interface.
+func (d *DGeometric) Prev(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DGeometric) Next(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMin implements the Datum interface.
+func (d *DGeometric) IsMin(_ CompareContext) bool {
+	return false
+}
+
+// IsMax implements the Datum interface.
+func (d *DGeometric) IsMax(_ CompareContext) bool {
+	return false
+}
+
+// Max implements the Datum interface.
+func (d *DGeometric) Max(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DGeometric) Min(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Size implements the Datum interface.
+func (d *DGeometric) Size() uintptr {
+	return unsafe.Sizeof(*d) + geometric.Size(d.Shape)
+}
+
 // DTSQuery is the tsquery Datum.
 type DTSQuery struct {
 	tsearch.TSQuery
@@ -4877,10 +5167,35 @@ type DArray struct {
 	// This is used in expression serialization (FmtParsable).
 	HasNonNulls bool
 
+	// Dims describes the dimensions of the array when they differ from the
+	// default, which is a single dimension whose lower bound is FirstIndex.
+	// This is the case for multidimensional arrays and for arrays with custom
+	// lower bounds, such as '[0:1]={1,2}'. The elements of a multidimensional
+	// array are stored in Array in row-major order. Dims is nil for arrays
+	// with default dimensions and for empty arrays; use SetDims to set it.
+	Dims []ArrayDim
+
 	// customOid, if non-0, is the oid of this array datum.
 	customOid oid.Oid
 }
 
+// ArrayDim describes one dimension of a DArray.
+type ArrayDim struct {
+	// Length is the number of elements along the dimension.
+	Length int
+	// LowerBound is the subscript of the first element along the dimension.
+	LowerBound int
+}
+
+// UpperBound returns the subscript of the last element along the dimension.
+func (d ArrayDim) UpperBound() int {
+	return d.LowerBound + d.Length - 1
+}
+
+// MaxArrayDims is the maximum number of dimensions of an array, matching
+// the limit in Postgres.
+const MaxArrayDims = 6
+
 // NewDArray returns a DArray containing elements of the specified type.
 func NewDArray(paramTyp *types.T) *DArray {
 	return &DArray{ParamTyp: paramTyp}
@@ -4959,6 +5274,276 @@ func (d *DArray) FirstIndex() int {
 	return 1
 }
 
+// NumDims returns the number of dimensions of the array. Empty arrays have
+// no dimensions.
+func (d *DArray) NumDims() int {
+	if d.Dims != nil {
+		return len(d.Dims)
+	}
+	if d.Len() == 0 {
+		return 0
+	}
+	return 1
+}
+
+// Dimensions returns the dimensions of the array, whether or not they are
+// the default. Empty arrays have no dimensions.
+func (d *DArray) Dimensions() []ArrayDim {
+	if d.Dims != nil {
+		return d.Dims
+	}
+	if d.Len() == 0 {
+		return nil
+	}
+	return []ArrayDim{{Length: d.Len(), LowerBound: d.FirstIndex()}}
+}
+
+var errArrayTooManyDims = pgerror.Newf(pgcode.ProgramLimitExceeded,
+	"number of array dimensions exceeds the maximum allowed (%d)", MaxArrayDims)
+
+// SetDims sets the dimensions of the array, whose elements must already have
+// been appended in row-major order. Dimensions that are the default for the
+// array are not stored, so that equal arrays have equal representations. No
+// dimensions stand for the default dimensions.
+func (d *DArray) SetDims(dims []ArrayDim) error {
+	if len(dims) == 0 {
+		d.Dims = nil
+		return nil
+	}
+	if len(dims) > MaxArrayDims {
+		return errArrayTooManyDims
+	}
+	n := 1
+	for _, dim := range dims {
+		if dim.Length < 0 {
+			return errors.AssertionFailedf("invalid array dimension length %d", dim.Length)
+		}
+		if int64(dim.LowerBound)+int64(dim.Length) > math.MaxInt32 ||
+			dim.LowerBound < math.MinInt32 {
+			return pgerror.New(pgcode.ProgramLimitExceeded, "array upper bound is too large")
+		}
+		if n *= dim.Length; n > maxArrayLength {
+			return errors.WithStack(errArrayTooLongError)
+		}
+	}
+	if n != d.Len() {
+		return errors.AssertionFailedf(
+			"array with %d elements does not match dimensions %v", d.Len(), dims)
+	}
+	if n == 0 || (len(dims) == 1 && dims[0].LowerBound == d.FirstIndex()) {
+		d.Dims = nil
+		return nil
+	}
+	d.Dims = dims
+	return nil
+}
+
+// IsSubArrayConstructor returns whether an ARRAY constructor whose elements
+// have type elemTyp builds a multidimensional array out of sub-arrays, as in
+// ARRAY[ARRAY[1,2],ARRAY[3,4]], rather than a one-dimensional array.
+func IsSubArrayConstructor(elemTyp *types.T) bool {
+	// The VECTOR types are not arrays in this sense, so ARRAY constructors
+	// over them build arrays of vectors.
+	return elemTyp.Family() == types.ArrayFamily &&
+		elemTyp.Oid() != oid.T_int2vector && elemTyp.Oid() != oid.T_oidvector
+}
+
+// NewMultiDimDArray returns the array built by an ARRAY constructor whose
+// elements are the given sub-arrays. The result has one more dimension than
+// the sub-arrays, which must all have the same dimensions. As in Postgres,
+// NULL sub-arrays are ignored.
+func NewMultiDimDArray(paramTyp *types.T, subArrays Datums) (*DArray, error) {
+	res := NewDArray(paramTyp)
+	var subDims []ArrayDim
+	n := 0
+	for _, sub := range subArrays {
+		if sub == DNull {
+			continue
+		}
+		subArray := MustBeDArray(sub)
+		dims := subArray.Dimensions()
+		if n == 0 {
+			subDims = dims
+		} else if len(dims) != len(subDims) {
+			return nil, errNonHomogeneousArray
+		} else {
+			for i := range dims {
+				if dims[i] != subDims[i] {
+					return nil, errNonHomogeneousArray
+				}
+			}
+		}
+		for _, e := range subArray.Array {
+			if err := res.Append(e); err != nil {
+				return nil, err
+			}
+		}
+		n++
+	}
+	if len(subDims) == 0 {
+		// All sub-arrays were empty or NULL.
+		return res, nil
+	}
+	dims := make([]ArrayDim, 0, len(subDims)+1)
+	dims = append(dims, ArrayDim{Length: n, LowerBound: 1})
+	dims = append(dims, subDims...)
+	if err := res.SetDims(dims); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Subscript returns the element of the array at the given subscripts, one
+// for each dimension. As in Postgres, it returns NULL if the number of
+// subscripts does not match the number of dimensions, or if any subscript is
+// out of bounds.
+func (d *DArray) Subscript(subscripts []int) Datum {
+	dims := d.Dimensions()
+	if len(subscripts) != len(dims) {
+		return DNull
+	}
+	offset := 0
+	for i, dim := range dims {
+		idx := subscripts[i] - dim.LowerBound
+		if idx < 0 || idx >= dim.Length {
+			return DNull
+		}
+		offset = offset*dim.Length + idx
+	}
+	return d.Array[offset]
+}
+
+// SetSubscript returns a copy of the array in which the element at the given
+// subscripts, one for each dimension, is replaced by v. As in Postgres, an
+// empty array becomes an array with the single element v, a one-dimensional
+// array is extended with NULLs when the subscript is beyond its bounds, and
+// multidimensional arrays cannot be extended.
+func (d *DArray) SetSubscript(subscripts []int, v Datum) (*DArray, error) {
+	res := NewDArray(d.ParamTyp)
+	dims := d.Dimensions()
+	if len(dims) == 0 {
+		if err := res.Append(v); err != nil {
+			return nil, err
+		}
+		resDims := make([]ArrayDim, len(subscripts))
+		for i, s := range subscripts {
+			resDims[i] = ArrayDim{Length: 1, LowerBound: s}
+		}
+		if err := res.SetDims(resDims); err != nil {
+			return nil, err
+		}
+		return res, nil
+	}
+	if len(subscripts) != len(dims) {
+		return nil, pgerror.New(pgcode.ArraySubscript, "wrong number of array subscripts")
+	}
+
+	if len(dims) == 1 {
+		dim, s := dims[0], subscripts[0]
+		lo, hi := dim.LowerBound, dim.UpperBound()
+		if s < lo {
+			lo = s
+		}
+		if s > hi {
+			hi = s
+		}
+		if int64(hi)-int64(lo) >= maxArrayLength {
+			return nil, errors.WithStack(errArrayTooLongError)
+		}
+		for i := lo; i <= hi; i++ {
+			var e Datum = DNull
+			if i == s {
+				e = v
+			} else if i >= dim.LowerBound && i <= dim.UpperBound() {
+				e = d.Array[i-dim.LowerBound]
+			}
+			if err := res.Append(e); err != nil {
+				return nil, err
+			}
+		}
+		if err := res.SetDims([]ArrayDim{{Length: hi - lo + 1, LowerBound: lo}}); err != nil {
+			return nil, err
+		}
+		return res, nil
+	}
+
+	offset := 0
+	for i, dim := range dims {
+		idx := subscripts[i] - dim.LowerBound
+		if idx < 0 || idx >= dim.Length {
+			return nil, pgerror.New(pgcode.ArraySubscript, "array subscript out of range")
+		}
+		offset = offset*dim.Length + idx
+	}
+	for i, e := range d.Array {
+		if i == offset {
+			e = v
+		}
+		if err := res.Append(e); err != nil {
+			return nil, err
+		}
+	}
+	if err := res.SetDims(append([]ArrayDim(nil), dims...)); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Slice returns the sub-array between the given lower and upper subscripts,
+// inclusive, which are given for the leading dimensions of the array.
+// Dimensions without subscripts are included in full, and subscripts beyond
+// the bounds of the array are clamped to them. As in Postgres, the slice has
+// lower bounds of 1, and it is empty if any of its dimensions are.
+func (d *DArray) Slice(lower, upper []int) (*DArray, error) {
+	res := NewDArray(d.ParamTyp)
+	dims := d.Dimensions()
+	if len(lower) > len(dims) {
+		return res, nil
+	}
+	// sel holds the selected range of subscripts in each dimension.
+	sel := make([]ArrayDim, len(dims))
+	for i, dim := range dims {
+		lo, hi := dim.LowerBound, dim.UpperBound()
+		if i < len(lower) {
+			if lower[i] > lo {
+				lo = lower[i]
+			}
+			if upper[i] < hi {
+				hi = upper[i]
+			}
+		}
+		if lo > hi {
+			return res, nil
+		}
+		sel[i] = ArrayDim{Length: hi - lo + 1, LowerBound: lo}
+	}
+	var appendSelected func(level, offset int) error
+	appendSelected = func(level, offset int) error {
+		start := sel[level].LowerBound - dims[level].LowerBound
+		for i := 0; i < sel[level].Length; i++ {
+			idx := offset*dims[level].Length + start + i
+			if level == len(dims)-1 {
+				if err := res.Append(d.Array[idx]); err != nil {
+					return err
+				}
+			} else if err := appendSelected(level+1, idx); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := appendSelected(0 /* level */, 0 /* offset */); err != nil {
+		return nil, err
+	}
+	for i := range sel {
+		sel[i].LowerBound = 1
+	}
+	if err := res.SetDims(sel); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
 // Compare implements the Datum interface.
 func (d *DArray) Compare(ctx CompareContext, other Datum) int {
 	res, err := d.CompareError(ctx, other)
@@ -4978,6 +5563,16 @@ func (d *DArray) CompareError(ctx CompareContext, other Datum) (int, error) {
 	if !ok {
 		return 0, makeUnsupportedComparisonMessage(d, other)
 	}
+	// Arrays with non-default dimensions sort after all arrays with default
+	// dimensions, and are ordered by their dimensions before their elements.
+	// This differs from Postgres, which compares elements first, but matches
+	// the key encoding of arrays, which cannot place the dimensions after the
+	// elements without breaking the encoding of existing arrays.
+	if d.Dims != nil || v.Dims != nil {
+		if c := compareArrayDims(d.Dims, v.Dims); c != 0 {
+			return c, nil
+		}
+	}
 	n := d.Len()
 	if n > v.Len() {
 		n = v.Len()
@@ -5000,6 +5595,41 @@ func (d *DArray) CompareError(ctx CompareContext, other Datum) (int, error) {
 	return 0, nil
 }
 
+// compareArrayDims compares the non-default dimensions of two arrays, where
+// nil dimensions sort first.
+func compareArrayDims(a, b []ArrayDim) int {
+	if a == nil || b == nil {
+		if a != nil {
+			return 1
+		}
+		if b != nil {
+			return -1
+		}
+		return 0
+	}
+	if len(a) != len(b) {
+		if len(a) < len(b) {
+			return -1
+		}
+		return 1
+	}
+	for i := range a {
+		if a[i].Length != b[i].Length {
+			if a[i].Length < b[i].Length {
+				return -1
+			}
+			return 1
+		}
+		if a[i].LowerBound != b[i].LowerBound {
+			if a[i].LowerBound < b[i].LowerBound {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
 // Prev implements the Datum interface.
 func (d *DArray) Prev(ctx CompareContext) (Datum, bool) {
 	return nil, false
@@ -5007,6 +5637,10 @@ func (d *DArray) Prev(ctx CompareContext) (Datum, bool) {
 
 // Next implements the Datum interface.
 func (d *DArray) Next(ctx CompareContext) (Datum, bool) {
+	if d.Dims != nil {
+		// Appending an element would not produce a valid array.
+		return nil, false
+	}
 	a := DArray{ParamTyp: d.ParamTyp, Array: make(Datums, d.Len()+1)}
 	copy(a.Array, d.Array)
 	a.Array[len(a.Array)-1] = DNull
@@ -5043,7 +5677,19 @@ func (d *DArray) AmbiguousFormat() bool {
 		// a valid type. So an array of unknown type is (paradoxically) unambiguous.
 		return false
 	}
-	return !d.HasNonNulls
+	// Arrays with custom lower bounds are formatted as string literals.
+	return !d.HasNonNulls || d.hasCustomLowerBounds()
+}
+
+// hasCustomLowerBounds returns whether any dimension of the array has a lower
+// bound other than 1. Such arrays cannot be produced by ARRAY constructors.
+func (d *DArray) hasCustomLowerBounds() bool {
+	for _, dim := range d.Dims {
+		if dim.LowerBound != 1 {
+			return true
+		}
+	}
+	return false
 }
 
 // Format implements the NodeFormatter interface.
@@ -5061,14 +5707,47 @@ func (d *DArray) Format(ctx *FmtCtx) {
 		defer func() { ctx.flags = oldFlags }()
 	}
 
-	ctx.WriteString("ARRAY[")
-	comma := ""
-	for _, v := range d.Array {
-		ctx.WriteString(comma)
+	if d.hasCustomLowerBounds() {
+		s := AsStringWithFlags(d, FmtPgwireText,
+			FmtDataConversionConfig(ctx.dataConversionConfig), FmtLocation(ctx.location))
+		lexbase.EncodeSQLStringWithFlags(&ctx.Buffer, s, ctx.flags.EncodeFlags())
+		return
+	}
+	ctx.WriteString("ARRAY")
+	formatArrayDims(ctx, d.Dimensions(), d.Array, '[', ']', ",", func(v Datum) {
 		ctx.FormatNode(v)
-		comma = ","
+	})
+}
+
+// formatArrayDims formats the elements of an array with the given dimensions,
+// enclosing each dimension between open and close. The elements are separated
+// by delim, and each is formatted by formatElem.
+func formatArrayDims(
+	ctx *FmtCtx,
+	dims []ArrayDim,
+	elems Datums,
+	open, close byte,
+	delim string,
+	formatElem func(Datum),
+) {
+	ctx.WriteByte(open)
+	if len(dims) <= 1 {
+		for i, v := range elems {
+			if i > 0 {
+				ctx.WriteString(delim)
+			}
+			formatElem(v)
+		}
+	} else {
+		stride := len(elems) / dims[0].Length
+		for i := 0; i < dims[0].Length; i++ {
+			if i > 0 {
+				ctx.WriteString(delim)
+			}
+			formatArrayDims(ctx, dims[1:], elems[i*stride:(i+1)*stride], open, close, delim, formatElem)
+		}
 	}
-	ctx.WriteByte(']')
+	ctx.WriteByte(close)
 }
 
 const maxArrayLength = math.MaxInt32
@@ -5091,7 +5770,7 @@ func (d *DArray) Len() int {
 
 // Size implements the Datum interface.
 func (d *DArray) Size() uintptr {
-	sz := unsafe.Sizeof(*d)
+	sz := unsafe.Sizeof(*d) + uintptr(len(d.Dims))*unsafe.Sizeof(ArrayDim{})
 	for _, e := range d.Array {
 		dsz := e.Size()
 		sz += dsz
@@ -6141,12 +6820,18 @@ var baseDatumTypeSizes = map[types.Family]struct {
 	types.TSVectorFamily:       {unsafe.Sizeof(DTSVector{}), variableSize},
 	types.IntervalFamily:       {unsafe.Sizeof(DInterval{}), fixedSize},
 	types.JsonFamily:           {unsafe.Sizeof(DJSON{}), variableSize},
+	types.JsonpathFamily:       {unsafe.Sizeof(DJsonpath{}), variableSize},
+	types.GeometricFamily:      {unsafe.Sizeof(DGeometric{}), variableSize},
+	types.RangeFamily:          {unsafe.Sizeof(DRange{}), variableSize},
+	types.MultirangeFamily:     {unsafe.Sizeof(DMultirange{}), variableSize},
 	types.UuidFamily:           {unsafe.Sizeof(DUuid{}), fixedSize},
 	types.INetFamily:           {unsafe.Sizeof(DIPAddr{}), fixedSize},
 	types.OidFamily:            {unsafe.Sizeof(DOid{}.Oid), fixedSize},
 	types.EnumFamily:           {unsafe.Sizeof(DEnum{}), variableSize},
 
 	types.VoidFamily: {sz: unsafe.Sizeof(DVoid{}), variable: fixedSize},
+	// Postgres reports trigger as a 4-byte, pass-by-value pseudo-type.
+	types.TriggerFamily: {sz: unsafe.Sizeof(DOid{}.Oid), variable: fixedSize},
 	// TODO(jordan,justin): This seems suspicious.
 	types.ArrayFamily: {unsafe.Sizeof(DString("")), variableSize},
 
@@ -0,0 +1,1015 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import (
+	"bytes"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unsafe"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
+)
+
+// RangeBound is the lower or upper bound of a DRange.
+type RangeBound struct {
+	// Val is the value of the bound, or nil if the bound is infinite.
+	Val Datum
+	// Inclusive is true if Val is contained in the range. It is always false
+	// for infinite bounds.
+	Inclusive bool
+}
+
+// IsInfinite returns true if the bound is unbounded.
+func (b RangeBound) IsInfinite() bool {
+	return b.Val == nil
+}
+
+// DRange is the Datum for range types. Ranges are always stored in their
+// canonical form: empty ranges have no bounds, and ranges over discrete
+// subtypes (int4range, int8range and daterange) have an inclusive lower bound
+// and an exclusive upper bound.
+type DRange struct {
+	// Typ is the range type of the datum.
+	Typ *types.T
+	// Empty is true if the range contains no values, in which case Lower and
+	// Upper are unset.
+	Empty bool
+	Lower RangeBound
+	Upper RangeBound
+}
+
+// NewEmptyDRange returns an empty range of the given type.
+func NewEmptyDRange(typ *types.T) *DRange {
+	return &DRange{Typ: typ, Empty: true}
+}
+
+// NewDRange returns a range of the given type with the given bounds, in
+// canonical form. An error is returned if the lower bound is greater than the
+// upper bound.
+func NewDRange(ctx CompareContext, typ *types.T, lower, upper RangeBound) (*DRange, error) {
+	for _, b := range []*RangeBound{&lower, &upper} {
+		if b.IsInfinite() {
+			b.Inclusive = false
+			continue
+		}
+		var err error
+		if b.Val, err = AdjustValueToType(typ.RangeContents(), b.Val); err != nil {
+			return nil, err
+		}
+	}
+	if !lower.IsInfinite() && !upper.IsInfinite() {
+		c, err := lower.Val.CompareError(ctx, upper.Val)
+		if err != nil {
+			return nil, err
+		}
+		if c > 0 {
+			return nil, pgerror.New(pgcode.DataException,
+				"range lower bound must be less than or equal to range upper bound")
+		}
+		if c == 0 && !(lower.Inclusive && upper.Inclusive) {
+			return NewEmptyDRange(typ), nil
+		}
+	}
+	if rangeIsDiscrete(typ) {
+		if !lower.IsInfinite() && !lower.Inclusive {
+			next, ok, err := rangeBoundNext(typ, lower.Val)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				lower = RangeBound{Val: next, Inclusive: true}
+			}
+		}
+		if !upper.IsInfinite() && upper.Inclusive {
+			next, ok, err := rangeBoundNext(typ, upper.Val)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				upper = RangeBound{Val: next}
+			}
+		}
+		if !lower.IsInfinite() && !upper.IsInfinite() {
+			c, err := lower.Val.CompareError(ctx, upper.Val)
+			if err != nil {
+				return nil, err
+			}
+			if c == 0 && !(lower.Inclusive && upper.Inclusive) {
+				return NewEmptyDRange(typ), nil
+			}
+		}
+	}
+	return &DRange{Typ: typ, Lower: lower, Upper: upper}, nil
+}
+
+// rangeIsDiscrete returns true if ranges of the given type are canonicalized
+// to the [lower,upper) form.
+func rangeIsDiscrete(typ *types.T) bool {
+	switch typ.Oid() {
+	case oid.T_int4range, oid.T_int8range, oid.T_daterange:
+		return true
+	}
+	return false
+}
+
+// rangeBoundNext returns the value following the given bound value of a
+// discrete range type. ok is false if the value has no successor that should
+// be used for canonicalization, as is the case for infinite dates.
+func rangeBoundNext(typ *types.T, val Datum) (_ Datum, ok bool, _ error) {
+	switch t := val.(type) {
+	case *DInt:
+		max := int64(math.MaxInt64)
+		if typ.Oid() == oid.T_int4range {
+			max = math.MaxInt32
+		}
+		if int64(*t) >= max {
+			return nil, false, ErrIntOutOfRange
+		}
+		return NewDInt(*t + 1), true, nil
+	case *DDate:
+		if !t.IsFinite() {
+			return val, false, nil
+		}
+		d, err := t.AddDays(1)
+		if err != nil {
+			return nil, false, err
+		}
+		return NewDDate(d), true, nil
+	}
+	return val, false, nil
+}
+
+// rangeCompareContext is a CompareContext used to compare the bounds of
+// ranges outside of the evaluation of an expression, such as when parsing or
+// decoding ranges. Range bounds always share the same subtype, so no session
+// state is needed to compare them.
+type rangeCompareContext struct{}
+
+var _ CompareContext = rangeCompareContext{}
+
+// UnwrapDatum implements the CompareContext interface.
+func (rangeCompareContext) UnwrapDatum(d Datum) Datum {
+	return UnwrapDOidWrapper(d)
+}
+
+// GetLocation implements the CompareContext interface.
+func (rangeCompareContext) GetLocation() *time.Location {
+	return time.UTC
+}
+
+// GetRelativeParseTime implements the CompareContext interface.
+func (rangeCompareContext) GetRelativeParseTime() time.Time {
+	return timeutil.Now()
+}
+
+// MustGetPlaceholderValue implements the CompareContext interface.
+func (rangeCompareContext) MustGetPlaceholderValue(p *Placeholder) Datum {
+	panic(errors.AssertionFailedf("unexpected placeholder %s in range bound", p))
+}
+
+// RangeCompareContext returns a CompareContext that can be used to construct
+// ranges when no evaluation context is available.
+func RangeCompareContext() CompareContext {
+	return rangeCompareContext{}
+}
+
+// AsDRange attempts to retrieve a DRange from an Expr, returning a DRange and
+// a flag signifying whether the assertion was successful. The function should
+// be used instead of direct type assertions wherever a *DRange wrapped by a
+// *DOidWrapper is possible.
+func AsDRange(e Expr) (*DRange, bool) {
+	switch t := e.(type) {
+	case *DRange:
+		return t, true
+	case *DOidWrapper:
+		return AsDRange(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDRange attempts to retrieve a DRange from an Expr, panicking if the
+// assertion fails.
+func MustBeDRange(e Expr) *DRange {
+	v, ok := AsDRange(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DRange, found %T", e))
+	}
+	return v
+}
+
+// Format implements the NodeFormatter interface.
+func (d *DRange) Format(ctx *FmtCtx) {
+	formatRangeLiteral(ctx, func(buf *bytes.Buffer) {
+		d.appendText(ctx, buf)
+	})
+}
+
+// formatRangeLiteral writes the text produced by appendText to ctx, quoting
+// it as a string literal unless bare strings were requested.
+func formatRangeLiteral(ctx *FmtCtx, appendText func(buf *bytes.Buffer)) {
+	bareStrings := ctx.HasFlags(FmtFlags(lexbase.EncBareStrings))
+	if bareStrings {
+		appendText(&ctx.Buffer)
+		return
+	}
+	var buf bytes.Buffer
+	appendText(&buf)
+	ctx.WriteByte('\'')
+	ctx.WriteString(strings.ReplaceAll(buf.String(), `'`, `''`))
+	ctx.WriteByte('\'')
+}
+
+var rangeBoundQuoteSet asciiSet
+
+func init() {
+	var ok bool
+	rangeBoundQuoteSet, ok = makeASCIISet(" \t\v\f\r\n()[],\"\\")
+	if !ok {
+		panic("range bound asciiset")
+	}
+}
+
+// appendText appends the Postgres text representation of the range to buf.
+func (d *DRange) appendText(ctx *FmtCtx, buf *bytes.Buffer) {
+	if d.Empty {
+		buf.WriteString("empty")
+		return
+	}
+	if d.Lower.Inclusive {
+		buf.WriteByte('[')
+	} else {
+		buf.WriteByte('(')
+	}
+	appendRangeBound(ctx, buf, d.Lower)
+	buf.WriteByte(',')
+	appendRangeBound(ctx, buf, d.Upper)
+	if d.Upper.Inclusive {
+		buf.WriteByte(']')
+	} else {
+		buf.WriteByte(')')
+	}
+}
+
+// appendRangeBound appends the text of a bound value to buf, quoting it if it
+// contains characters that are significant in range literals. Infinite bounds
+// are written as nothing at all.
+func appendRangeBound(ctx *FmtCtx, buf *bytes.Buffer, b RangeBound) {
+	if b.IsInfinite() {
+		return
+	}
+	flags := FmtBareStrings
+	if ctx.HasFlags(fmtPgwireFormat) {
+		flags = FmtPgwireText
+	}
+	s := AsStringWithFlags(
+		b.Val, flags, FmtDataConversionConfig(ctx.dataConversionConfig), FmtLocation(ctx.location),
+	)
+	quote := s == "" || rangeBoundQuoteSet.in(s)
+	if quote {
+		buf.WriteByte('"')
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] == '"' || s[i] == '\\' {
+			buf.WriteByte(s[i])
+		}
+		buf.WriteByte(s[i])
+	}
+	if quote {
+		buf.WriteByte('"')
+	}
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (d *DRange) ResolvedType() *types.T {
+	return d.Typ
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (d *DRange) AmbiguousFormat() bool { return true }
+
+// IsComposite implements the CompositeDatum interface.
+func (d *DRange) IsComposite() bool {
+	for _, b := range [2]RangeBound{d.Lower, d.Upper} {
+		if cdatum, ok := b.Val.(CompositeDatum); ok && cdatum.IsComposite() {
+			return true
+		}
+	}
+	return false
+}
+
+// Compare implements the Datum interface.
+func (d *DRange) Compare(ctx CompareContext, other Datum) int {
+	res, err := d.CompareError(ctx, other)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// CompareError implements the Datum interface. Empty ranges sort before all
+// other ranges, which are ordered by their lower bounds and then by their
+// upper bounds.
+func (d *DRange) CompareError(ctx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := ctx.UnwrapDatum(other).(*DRange)
+	if !ok || v.Typ.Oid() != d.Typ.Oid() {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	return compareRanges(ctx, d, v)
+}
+
+func compareRanges(ctx CompareContext, a, b *DRange) (int, error) {
+	switch {
+	case a.Empty && b.Empty:
+		return 0, nil
+	case a.Empty:
+		return -1, nil
+	case b.Empty:
+		return 1, nil
+	}
+	c, err := compareRangeBounds(ctx, a.Lower, true /* isLower1 */, b.Lower, true /* isLower2 */)
+	if err != nil || c != 0 {
+		return c, err
+	}
+	return compareRangeBounds(ctx, a.Upper, false /* isLower1 */, b.Upper, false /* isLower2 */)
+}
+
+// compareRangeBounds compares two range bounds, each of which is either a
+// lower or an upper bound. It follows range_cmp_bounds in Postgres.
+func compareRangeBounds(
+	ctx CompareContext, b1 RangeBound, isLower1 bool, b2 RangeBound, isLower2 bool,
+) (int, error) {
+	// Infinite lower bounds sort before everything else, and infinite upper
+	// bounds sort after everything else.
+	infiniteCmp := func(isLower bool) int {
+		if isLower {
+			return -1
+		}
+		return 1
+	}
+	switch {
+	case b1.IsInfinite() && b2.IsInfinite():
+		if isLower1 == isLower2 {
+			return 0, nil
+		}
+		return infiniteCmp(isLower1), nil
+	case b1.IsInfinite():
+		return infiniteCmp(isLower1), nil
+	case b2.IsInfinite():
+		return -infiniteCmp(isLower2), nil
+	}
+	c, err := b1.Val.CompareError(ctx, b2.Val)
+	if err != nil || c != 0 {
+		return c, err
+	}
+	// The values are equal, so the inclusivity of the bounds decides. An
+	// exclusive lower bound is just after its value, and an exclusive upper
+	// bound is just before it.
+	switch {
+	case !b1.Inclusive && !b2.Inclusive:
+		if isLower1 == isLower2 {
+			return 0, nil
+		}
+		return -infiniteCmp(isLower1), nil
+	case !b1.Inclusive:
+		return -infiniteCmp(isLower1), nil
+	case !b2.Inclusive:
+		return infiniteCmp(isLower2), nil
+	}
+	return 0, nil
+}
+
+// boundsAdjacent returns true if the upper bound upper and the lower bound
+// lower touch without overlapping, such as the bounds of [1,2) and [2,3).
+func boundsAdjacent(ctx CompareContext, upper, lower RangeBound) (bool, error) {
+	if upper.IsInfinite() || lower.IsInfinite() {
+		return false, nil
+	}
+	c, err := upper.Val.CompareError(ctx, lower.Val)
+	if err != nil {
+		return false, err
+	}
+	return c == 0 && upper.Inclusive != lower.Inclusive, nil
+}
+
+// checkRangeTypes returns an error if the two ranges are of different types.
+func checkRangeTypes(a, b *DRange) error {
+	if a.Typ.Oid() != b.Typ.Oid() {
+		return makeUnsupportedComparisonMessage(a, b)
+	}
+	return nil
+}
+
+// ContainsElem returns true if the value is within the range.
+func (d *DRange) ContainsElem(ctx CompareContext, val Datum) (bool, error) {
+	if d.Empty {
+		return false, nil
+	}
+	elem := RangeBound{Val: val, Inclusive: true}
+	c, err := compareRangeBounds(ctx, d.Lower, true /* isLower1 */, elem, true /* isLower2 */)
+	if err != nil || c > 0 {
+		return false, err
+	}
+	c, err = compareRangeBounds(ctx, d.Upper, false /* isLower1 */, elem, false /* isLower2 */)
+	if err != nil || c < 0 {
+		return false, err
+	}
+	return true, nil
+}
+
+// ContainsRange returns true if every value of other is within the range.
+func (d *DRange) ContainsRange(ctx CompareContext, other *DRange) (bool, error) {
+	if err := checkRangeTypes(d, other); err != nil {
+		return false, err
+	}
+	if other.Empty {
+		return true, nil
+	}
+	if d.Empty {
+		return false, nil
+	}
+	c, err := compareRangeBounds(ctx, d.Lower, true /* isLower1 */, other.Lower, true /* isLower2 */)
+	if err != nil || c > 0 {
+		return false, err
+	}
+	c, err = compareRangeBounds(ctx, d.Upper, false /* isLower1 */, other.Upper, false /* isLower2 */)
+	if err != nil || c < 0 {
+		return false, err
+	}
+	return true, nil
+}
+
+// Overlaps returns true if the two ranges have a value in common.
+func (d *DRange) Overlaps(ctx CompareContext, other *DRange) (bool, error) {
+	if err := checkRangeTypes(d, other); err != nil {
+		return false, err
+	}
+	if d.Empty || other.Empty {
+		return false, nil
+	}
+	c, err := compareRangeBounds(ctx, d.Lower, true /* isLower1 */, other.Upper, false /* isLower2 */)
+	if err != nil || c > 0 {
+		return false, err
+	}
+	c, err = compareRangeBounds(ctx, other.Lower, true /* isLower1 */, d.Upper, false /* isLower2 */)
+	if err != nil || c > 0 {
+		return false, err
+	}
+	return true, nil
+}
+
+// Adjacent returns true if the two ranges touch without overlapping.
+func (d *DRange) Adjacent(ctx CompareContext, other *DRange) (bool, error) {
+	if err := checkRangeTypes(d, other); err != nil {
+		return false, err
+	}
+	if d.Empty || other.Empty {
+		return false, nil
+	}
+	if ok, err := boundsAdjacent(ctx, d.Upper, other.Lower); err != nil || ok {
+		return ok, err
+	}
+	return boundsAdjacent(ctx, other.Upper, d.Lower)
+}
+
+// Union returns the smallest range containing both ranges. An error is
+// returned if the ranges neither overlap nor are adjacent, unless strict is
+// false, in which case the gap between them is included in the result.
+func (d *DRange) Union(ctx CompareContext, other *DRange, strict bool) (*DRange, error) {
+	if err := checkRangeTypes(d, other); err != nil {
+		return nil, err
+	}
+	if d.Empty {
+		return other, nil
+	}
+	if other.Empty {
+		return d, nil
+	}
+	if strict {
+		overlaps, err := d.Overlaps(ctx, other)
+		if err != nil {
+			return nil, err
+		}
+		adjacent, err := d.Adjacent(ctx, other)
+		if err != nil {
+			return nil, err
+		}
+		if !overlaps && !adjacent {
+			return nil, pgerror.New(pgcode.DataException,
+				"result of range union would not be contiguous")
+		}
+	}
+	res := &DRange{Typ: d.Typ, Lower: d.Lower, Upper: d.Upper}
+	c, err := compareRangeBounds(ctx, other.Lower, true /* isLower1 */, d.Lower, true /* isLower2 */)
+	if err != nil {
+		return nil, err
+	}
+	if c < 0 {
+		res.Lower = other.Lower
+	}
+	c, err = compareRangeBounds(ctx, other.Upper, false /* isLower1 */, d.Upper, false /* isLower2 */)
+	if err != nil {
+		return nil, err
+	}
+	if c > 0 {
+		res.Upper = other.Upper
+	}
+	return res, nil
+}
+
+// Intersect returns the range of values contained in both ranges.
+func (d *DRange) Intersect(ctx CompareContext, other *DRange) (*DRange, error) {
+	overlaps, err := d.Overlaps(ctx, other)
+	if err != nil {
+		return nil, err
+	}
+	if !overlaps {
+		return NewEmptyDRange(d.Typ), nil
+	}
+	res := &DRange{Typ: d.Typ, Lower: d.Lower, Upper: d.Upper}
+	c, err := compareRangeBounds(ctx, other.Lower, true /* isLower1 */, d.Lower, true /* isLower2 */)
+	if err != nil {
+		return nil, err
+	}
+	if c > 0 {
+		res.Lower = other.Lower
+	}
+	c, err = compareRangeBounds(ctx, other.Upper, false /* isLower1 */, d.Upper, false /* isLower2 */)
+	if err != nil {
+		return nil, err
+	}
+	if c < 0 {
+		res.Upper = other.Upper
+	}
+	return res, nil
+}
+
+// Minus returns the values of the range that are not contained in other. An
+// error is returned if the result would consist of two disjoint ranges.
+func (d *DRange) Minus(ctx CompareContext, other *DRange) (*DRange, error) {
+	parts, err := d.minus(ctx, other)
+	if err != nil {
+		return nil, err
+	}
+	switch len(parts) {
+	case 0:
+		return NewEmptyDRange(d.Typ), nil
+	case 1:
+		return parts[0], nil
+	}
+	return nil, pgerror.New(pgcode.DataException,
+		"result of range difference would not be contiguous")
+}
+
+// minus returns the non-empty ranges, in order, whose union contains the
+// values of the range that are not contained in other.
+func (d *DRange) minus(ctx CompareContext, other *DRange) ([]*DRange, error) {
+	overlaps, err := d.Overlaps(ctx, other)
+	if err != nil {
+		return nil, err
+	}
+	if !overlaps {
+		if d.Empty {
+			return nil, nil
+		}
+		return []*DRange{d}, nil
+	}
+	var res []*DRange
+	// The part of d below other ends just before the lower bound of other.
+	c, err := compareRangeBounds(ctx, d.Lower, true /* isLower1 */, other.Lower, true /* isLower2 */)
+	if err != nil {
+		return nil, err
+	}
+	if c < 0 {
+		below, err := NewDRange(ctx, d.Typ, d.Lower, RangeBound{
+			Val: other.Lower.Val, Inclusive: !other.Lower.Inclusive,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !below.Empty {
+			res = append(res, below)
+		}
+	}
+	// The part of d above other starts just after the upper bound of other.
+	c, err = compareRangeBounds(ctx, d.Upper, false /* isLower1 */, other.Upper, false /* isLower2 */)
+	if err != nil {
+		return nil, err
+	}
+	if c > 0 {
+		above, err := NewDRange(ctx, d.Typ, RangeBound{
+			Val: other.Upper.Val, Inclusive: !other.Upper.Inclusive,
+		}, d.Upper)
+		if err != nil {
+			return nil, err
+		}
+		if !above.Empty {
+			res = append(res, above)
+		}
+	}
+	return res, nil
+}
+
+// Prev implements the Datum interface.
+func (d *DRange) Prev(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DRange) Next(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMin implements the Datum interface.
+func (d *DRange) IsMin(_ CompareContext) bool {
+	return d.Empty
+}
+
+// IsMax implements the Datum interface.
+func (d *DRange) IsMax(_ CompareContext) bool {
+	return false
+}
+
+// Max implements the Datum interface.
+func (d *DRange) Max(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DRange) Min(_ CompareContext) (Datum, bool) {
+	return NewEmptyDRange(d.Typ), true
+}
+
+// Size implements the Datum interface.
+func (d *DRange) Size() uintptr {
+	sz := unsafe.Sizeof(*d)
+	if !d.Lower.IsInfinite() {
+		sz += d.Lower.Val.Size()
+	}
+	if !d.Upper.IsInfinite() {
+		sz += d.Upper.Val.Size()
+	}
+	return sz
+}
+
+// DMultirange is the Datum for multirange types. The ranges of a multirange
+// are non-empty, sorted, and neither overlap nor are adjacent to each other.
+type DMultirange struct {
+	// Typ is the multirange type of the datum.
+	Typ    *types.T
+	Ranges []*DRange
+}
+
+// NewDMultirange returns a multirange of the given type containing the union
+// of the given ranges.
+func NewDMultirange(ctx CompareContext, typ *types.T, ranges []*DRange) (*DMultirange, error) {
+	res := &DMultirange{Typ: typ}
+	sorted := make([]*DRange, 0, len(ranges))
+	for _, r := range ranges {
+		if !r.Empty {
+			sorted = append(sorted, r)
+		}
+	}
+	var err error
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if err != nil {
+			return false
+		}
+		var c int
+		c, err = compareRanges(ctx, sorted[i], sorted[j])
+		return c < 0
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range sorted {
+		if n := len(res.Ranges); n > 0 {
+			last := res.Ranges[n-1]
+			merge, err := last.Overlaps(ctx, r)
+			if err != nil {
+				return nil, err
+			}
+			if !merge {
+				if merge, err = boundsAdjacent(ctx, last.Upper, r.Lower); err != nil {
+					return nil, err
+				}
+			}
+			if merge {
+				if res.Ranges[n-1], err = last.Union(ctx, r, false /* strict */); err != nil {
+					return nil, err
+				}
+				continue
+			}
+		}
+		res.Ranges = append(res.Ranges, r)
+	}
+	return res, nil
+}
+
+// AsDMultirange attempts to retrieve a DMultirange from an Expr, returning a
+// DMultirange and a flag signifying whether the assertion was successful. The
+// function should be used instead of direct type assertions wherever a
+// *DMultirange wrapped by a *DOidWrapper is possible.
+func AsDMultirange(e Expr) (*DMultirange, bool) {
+	switch t := e.(type) {
+	case *DMultirange:
+		return t, true
+	case *DOidWrapper:
+		return AsDMultirange(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDMultirange attempts to retrieve a DMultirange from an Expr,
+// panicking if the assertion fails.
+func MustBeDMultirange(e Expr) *DMultirange {
+	v, ok := AsDMultirange(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DMultirange, found %T", e))
+	}
+	return v
+}
+
+// RangeToMultirange returns the multirange containing only the given range.
+// User-defined ranges have no multirange type, so they are wrapped in a
+// multirange of type anymultirange.
+func RangeToMultirange(r *DRange) *DMultirange {
+	typ := types.MultirangeOf(r.Typ)
+	if typ == nil {
+		typ = types.AnyMultirange
+	}
+	res := &DMultirange{Typ: typ}
+	if !r.Empty {
+		res.Ranges = []*DRange{r}
+	}
+	return res
+}
+
+// Format implements the NodeFormatter interface.
+func (d *DMultirange) Format(ctx *FmtCtx) {
+	formatRangeLiteral(ctx, func(buf *bytes.Buffer) {
+		buf.WriteByte('{')
+		for i, r := range d.Ranges {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			r.appendText(ctx, buf)
+		}
+		buf.WriteByte('}')
+	})
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (d *DMultirange) ResolvedType() *types.T {
+	return d.Typ
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (d *DMultirange) AmbiguousFormat() bool { return true }
+
+// Compare implements the Datum interface.
+func (d *DMultirange) Compare(ctx CompareContext, other Datum) int {
+	res, err := d.CompareError(ctx, other)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// CompareError implements the Datum interface. Multiranges are compared range
+// by range, and a multirange sorts before any multirange it is a prefix of.
+func (d *DMultirange) CompareError(ctx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := ctx.UnwrapDatum(other).(*DMultirange)
+	if !ok || v.Typ.Oid() != d.Typ.Oid() {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	for i := 0; i < len(d.Ranges) && i < len(v.Ranges); i++ {
+		if c, err := compareRanges(ctx, d.Ranges[i], v.Ranges[i]); err != nil || c != 0 {
+			return c, err
+		}
+	}
+	switch {
+	case len(d.Ranges) < len(v.Ranges):
+		return -1, nil
+	case len(d.Ranges) > len(v.Ranges):
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// checkMultirangeTypes returns an error if the two multiranges are of
+// different types.
+func checkMultirangeTypes(a, b *DMultirange) error {
+	if a.Typ.Oid() != b.Typ.Oid() {
+		return makeUnsupportedComparisonMessage(a, b)
+	}
+	return nil
+}
+
+// ContainsElem returns true if the value is within one of the ranges of the
+// multirange.
+func (d *DMultirange) ContainsElem(ctx CompareContext, val Datum) (bool, error) {
+	for _, r := range d.Ranges {
+		if ok, err := r.ContainsElem(ctx, val); err != nil || ok {
+			return ok, err
+		}
+	}
+	return false, nil
+}
+
+// ContainsMultirange returns true if every value of other is within the
+// multirange.
+func (d *DMultirange) ContainsMultirange(ctx CompareContext, other *DMultirange) (bool, error) {
+	if err := checkMultirangeTypes(d, other); err != nil {
+		return false, err
+	}
+	// Both multiranges are sorted and their ranges are disjoint, so every range
+	// of other must be contained in a single range of d.
+	i := 0
+	for _, r := range other.Ranges {
+		for ; i < len(d.Ranges); i++ {
+			ok, err := d.Ranges[i].ContainsRange(ctx, r)
+			if err != nil {
+				return false, err
+			}
+			if ok {
+				break
+			}
+		}
+		if i == len(d.Ranges) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// Overlaps returns true if the two multiranges have a value in common.
+func (d *DMultirange) Overlaps(ctx CompareContext, other *DMultirange) (bool, error) {
+	if err := checkMultirangeTypes(d, other); err != nil {
+		return false, err
+	}
+	i, j := 0, 0
+	for i < len(d.Ranges) && j < len(other.Ranges) {
+		a, b := d.Ranges[i], other.Ranges[j]
+		ok, err := a.Overlaps(ctx, b)
+		if err != nil || ok {
+			return ok, err
+		}
+		// Advance past whichever range ends first.
+		c, err := compareRangeBounds(ctx, a.Upper, false /* isLower1 */, b.Upper, false /* isLower2 */)
+		if err != nil {
+			return false, err
+		}
+		if c < 0 {
+			i++
+		} else {
+			j++
+		}
+	}
+	return false, nil
+}
+
+// Adjacent returns true if the two multiranges touch without overlapping.
+func (d *DMultirange) Adjacent(ctx CompareContext, other *DMultirange) (bool, error) {
+	if err := checkMultirangeTypes(d, other); err != nil {
+		return false, err
+	}
+	if len(d.Ranges) == 0 || len(other.Ranges) == 0 {
+		return false, nil
+	}
+	dFirst, dLast := d.Ranges[0], d.Ranges[len(d.Ranges)-1]
+	oFirst, oLast := other.Ranges[0], other.Ranges[len(other.Ranges)-1]
+	if ok, err := dLast.Adjacent(ctx, oFirst); err != nil || ok {
+		return ok, err
+	}
+	return oLast.Adjacent(ctx, dFirst)
+}
+
+// Union returns the multirange containing the values of both multiranges.
+func (d *DMultirange) Union(ctx CompareContext, other *DMultirange) (*DMultirange, error) {
+	if err := checkMultirangeTypes(d, other); err != nil {
+		return nil, err
+	}
+	ranges := make([]*DRange, 0, len(d.Ranges)+len(other.Ranges))
+	ranges = append(ranges, d.Ranges...)
+	ranges = append(ranges, other.Ranges...)
+	return NewDMultirange(ctx, d.Typ, ranges)
+}
+
+// Intersect returns the multirange containing the values that are in both
+// multiranges.
+func (d *DMultirange) Intersect(ctx CompareContext, other *DMultirange) (*DMultirange, error) {
+	if err := checkMultirangeTypes(d, other); err != nil {
+		return nil, err
+	}
+	res := &DMultirange{Typ: d.Typ}
+	i, j := 0, 0
+	for i < len(d.Ranges) && j < len(other.Ranges) {
+		a, b := d.Ranges[i], other.Ranges[j]
+		r, err := a.Intersect(ctx, b)
+		if err != nil {
+			return nil, err
+		}
+		if !r.Empty {
+			res.Ranges = append(res.Ranges, r)
+		}
+		c, err := compareRangeBounds(ctx, a.Upper, false /* isLower1 */, b.Upper, false /* isLower2 */)
+		if err != nil {
+			return nil, err
+		}
+		if c < 0 {
+			i++
+		} else {
+			j++
+		}
+	}
+	return res, nil
+}
+
+// Minus returns the multirange containing the values of the multirange that
+// are not in other.
+func (d *DMultirange) Minus(ctx CompareContext, other *DMultirange) (*DMultirange, error) {
+	if err := checkMultirangeTypes(d, other); err != nil {
+		return nil, err
+	}
+	res := &DMultirange{Typ: d.Typ}
+	for _, r := range d.Ranges {
+		remaining := []*DRange{r}
+		for _, o := range other.Ranges {
+			var next []*DRange
+			for _, rem := range remaining {
+				parts, err := rem.minus(ctx, o)
+				if err != nil {
+					return nil, err
+				}
+				next = append(next, parts...)
+			}
+			remaining = next
+		}
+		res.Ranges = append(res.Ranges, remaining...)
+	}
+	return res, nil
+}
+
+// Prev implements the Datum interface.
+func (d *DMultirange) Prev(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DMultirange) Next(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMin implements the Datum interface.
+func (d *DMultirange) IsMin(_ CompareContext) bool {
+	return len(d.Ranges) == 0
+}
+
+// IsMax implements the Datum interface.
+func (d *DMultirange) IsMax(_ CompareContext) bool {
+	return false
+}
+
+// Max implements the Datum interface.
+func (d *DMultirange) Max(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DMultirange) Min(_ CompareContext) (Datum, bool) {
+	return &DMultirange{Typ: d.Typ}, true
+}
+
+// Size implements the Datum interface.
+func (d *DMultirange) Size() uintptr {
+	sz := unsafe.Sizeof(*d)
+	for _, r := range d.Ranges {
+		sz += r.Size()
+	}
+	return sz
+}
@@ -14,6 +14,7 @@ import (
 	"fmt"
 
 	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/cockroachdb/cockroach/pkg/geo/geometric"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/cast"
@@ -263,8 +264,16 @@ func (op *BinOp) preferred() bool {
 // argument is NULL, an array of one element is created.
 func AppendToMaybeNullArray(typ *types.T, left Datum, right Datum) (Datum, error) {
 	result := NewDArray(typ)
+	lowerBound := result.FirstIndex()
 	if left != DNull {
-		for _, e := range MustBeDArray(left).Array {
+		arr := MustBeDArray(left)
+		if arr.NumDims() > 1 {
+			return nil, errArrayNotOneDimensional
+		}
+		if dims := arr.Dimensions(); len(dims) == 1 {
+			lowerBound = dims[0].LowerBound
+		}
+		for _, e := range arr.Array {
 			if err := result.Append(e); err != nil {
 				return nil, err
 			}
@@ -273,6 +282,9 @@ func AppendToMaybeNullArray(typ *types.T, left Datum, right Datum) (Datum, error
 	if err := result.Append(right); err != nil {
 		return nil, err
 	}
+	if err := result.SetDims([]ArrayDim{{Length: result.Len(), LowerBound: lowerBound}}); err != nil {
+		return nil, err
+	}
 	return result, nil
 }
 
@@ -280,19 +292,33 @@ func AppendToMaybeNullArray(typ *types.T, left Datum, right Datum) (Datum, error
 // If the argument is NULL, an array of one element is created.
 func PrependToMaybeNullArray(typ *types.T, left Datum, right Datum) (Datum, error) {
 	result := NewDArray(typ)
+	lowerBound := result.FirstIndex()
 	if err := result.Append(left); err != nil {
 		return nil, err
 	}
 	if right != DNull {
-		for _, e := range MustBeDArray(right).Array {
+		arr := MustBeDArray(right)
+		if arr.NumDims() > 1 {
+			return nil, errArrayNotOneDimensional
+		}
+		if dims := arr.Dimensions(); len(dims) == 1 {
+			lowerBound = dims[0].LowerBound - 1
+		}
+		for _, e := range arr.Array {
 			if err := result.Append(e); err != nil {
 				return nil, err
 			}
 		}
 	}
+	if err := result.SetDims([]ArrayDim{{Length: result.Len(), LowerBound: lowerBound}}); err != nil {
+		return nil, err
+	}
 	return result, nil
 }
 
+var errArrayNotOneDimensional = pgerror.New(pgcode.DataException,
+	"argument must be empty or one-dimensional array")
+
 // TODO(justin): these might be improved by making arrays into an interface and
 // then introducing a ConcatenatedArray implementation which just references two
 // existing arrays. This would optimize the common case of appending an element
@@ -318,29 +344,79 @@ func initArrayElementConcatenation() {
 	}
 }
 
-// ConcatArrays concatenates two arrays.
+// ConcatArrays concatenates two arrays. As in Postgres, arrays with the same
+// number of dimensions are concatenated along their first dimension, and an
+// array with one dimension less than the other is added to it as a new
+// element of its first dimension.
 func ConcatArrays(typ *types.T, left Datum, right Datum) (Datum, error) {
 	if left == DNull && right == DNull {
 		return DNull, nil
 	}
 	result := NewDArray(typ)
+	var leftDims, rightDims []ArrayDim
 	if left != DNull {
-		for _, e := range MustBeDArray(left).Array {
+		leftArr := MustBeDArray(left)
+		leftDims = leftArr.Dimensions()
+		for _, e := range leftArr.Array {
 			if err := result.Append(e); err != nil {
 				return nil, err
 			}
 		}
 	}
 	if right != DNull {
-		for _, e := range MustBeDArray(right).Array {
+		rightArr := MustBeDArray(right)
+		rightDims = rightArr.Dimensions()
+		for _, e := range rightArr.Array {
 			if err := result.Append(e); err != nil {
 				return nil, err
 			}
 		}
 	}
+	dims, err := concatArrayDims(leftDims, rightDims)
+	if err != nil {
+		return nil, err
+	}
+	if err := result.SetDims(dims); err != nil {
+		return nil, err
+	}
 	return result, nil
 }
 
+// concatArrayDims returns the dimensions of the concatenation of arrays with
+// the given dimensions.
+func concatArrayDims(left, right []ArrayDim) ([]ArrayDim, error) {
+	if len(left) == 0 {
+		return right, nil
+	}
+	if len(right) == 0 {
+		return left, nil
+	}
+	var first ArrayDim
+	var rest, other []ArrayDim
+	switch len(left) - len(right) {
+	case 0:
+		first = ArrayDim{Length: left[0].Length + right[0].Length, LowerBound: left[0].LowerBound}
+		rest, other = left[1:], right[1:]
+	case 1:
+		first = ArrayDim{Length: left[0].Length + 1, LowerBound: left[0].LowerBound}
+		rest, other = left[1:], right
+	case -1:
+		first = ArrayDim{Length: right[0].Length + 1, LowerBound: right[0].LowerBound}
+		rest, other = right[1:], left
+	default:
+		return nil, errIncompatibleArrayConcat
+	}
+	for i := range rest {
+		if rest[i].Length != other[i].Length {
+			return nil, errIncompatibleArrayConcat
+		}
+	}
+	return append([]ArrayDim{first}, rest...), nil
+}
+
+var errIncompatibleArrayConcat = pgerror.New(pgcode.ArraySubscript,
+	"cannot concatenate incompatible arrays")
+
 // ArrayContains return true if the haystack contains all needles.
 func ArrayContains(ctx CompareContext, haystack *DArray, needles *DArray) (*DBool, error) {
 	if !haystack.ParamTyp.Equivalent(needles.ParamTyp) {
@@ -455,10 +531,81 @@ func initNonArrayToNonArrayConcatenation() {
 	}
 }
 
+// rangeTypes are the built-in range types, and multirangeTypes are the
+// multirange types corresponding to each of them.
+var (
+	rangeTypes = []*types.T{
+		types.Int4Range, types.Int8Range, types.NumRange,
+		types.TSRange, types.TSTZRange, types.DateRange,
+	}
+	multirangeTypes = []*types.T{
+		types.Int4Multirange, types.Int8Multirange, types.NumMultirange,
+		types.TSMultirange, types.TSTZMultirange, types.DateMultirange,
+	}
+)
+
+// initRangeOperators initializes the union (+), intersection (*) and
+// difference (-) operators of the built-in range and multirange types.
+func initRangeOperators() {
+	addRangeOp := func(
+		symbol treebin.BinaryOperatorSymbol,
+		rangeOp func(ctx CompareContext, left, right *DRange) (*DRange, error),
+		multirangeOp func(ctx CompareContext, left, right *DMultirange) (*DMultirange, error),
+	) {
+		for _, t := range rangeTypes {
+			addBinOp(symbol, &BinOp{
+				LeftType:   t,
+				RightType:  t,
+				ReturnType: t,
+				EvalOp: &CombineRangesOp{Op: func(ctx CompareContext, left, right Datum) (Datum, error) {
+					return rangeOp(ctx, MustBeDRange(left), MustBeDRange(right))
+				}},
+				Volatility: volatility.Immutable,
+			})
+		}
+		for _, t := range multirangeTypes {
+			addBinOp(symbol, &BinOp{
+				LeftType:   t,
+				RightType:  t,
+				ReturnType: t,
+				EvalOp: &CombineRangesOp{Op: func(ctx CompareContext, left, right Datum) (Datum, error) {
+					return multirangeOp(ctx, MustBeDMultirange(left), MustBeDMultirange(right))
+				}},
+				Volatility: volatility.Immutable,
+			})
+		}
+	}
+	addRangeOp(treebin.Plus,
+		func(ctx CompareContext, left, right *DRange) (*DRange, error) {
+			return left.Union(ctx, right, true /* strict */)
+		},
+		func(ctx CompareContext, left, right *DMultirange) (*DMultirange, error) {
+			return left.Union(ctx, right)
+		},
+	)
+	addRangeOp(treebin.Mult,
+		func(ctx CompareContext, left, right *DRange) (*DRange, error) {
+			return left.Intersect(ctx, right)
+		},
+		func(ctx CompareContext, left, right *DMultirange) (*DMultirange, error) {
+			return left.Intersect(ctx, right)
+		},
+	)
+	addRangeOp(treebin.Minus,
+		func(ctx CompareContext, left, right *DRange) (*DRange, error) {
+			return left.Minus(ctx, right)
+		},
+		func(ctx CompareContext, left, right *DMultirange) (*DMultirange, error) {
+			return left.Minus(ctx, right)
+		},
+	)
+}
+
 func init() {
 	initArrayElementConcatenation()
 	initArrayToArrayConcatenation()
 	initNonArrayToNonArrayConcatenation()
+	initRangeOperators()
 }
 
 func init() {
@@ -1414,7 +1561,10 @@ func cmpOpFixups(
 	}
 
 	// Array equality comparisons.
-	for _, t := range append(types.Scalar, types.AnyEnum) {
+	arrayElemTypes := append(append([]*types.T(nil), types.Scalar...), types.AnyEnum)
+	arrayElemTypes = append(arrayElemTypes, rangeTypes...)
+	arrayElemTypes = append(arrayElemTypes, multirangeTypes...)
+	for _, t := range arrayElemTypes {
 		appendCmpOp := func(sym treecmp.ComparisonOperatorSymbol, cmpOp *CmpOp) {
 			s, ok := cmpOps[sym]
 			if !ok {
@@ -1524,6 +1674,8 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 	treecmp.EQ: {overloads: []*CmpOp{
 		// Single-type comparisons.
 		makeEqFn(types.AnyEnum, types.AnyEnum, volatility.Immutable),
+		makeEqFn(types.AnyRange, types.AnyRange, volatility.Immutable),
+		makeEqFn(types.AnyMultirange, types.AnyMultirange, volatility.Immutable),
 		makeEqFn(types.Bool, types.Bool, volatility.Leakproof),
 		makeEqFn(types.Bytes, types.Bytes, volatility.Leakproof),
 		makeEqFn(types.Date, types.Date, volatility.Leakproof),
@@ -1585,6 +1737,8 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 	treecmp.LT: {overloads: []*CmpOp{
 		// Single-type comparisons.
 		makeLtFn(types.AnyEnum, types.AnyEnum, volatility.Immutable),
+		makeLtFn(types.AnyRange, types.AnyRange, volatility.Immutable),
+		makeLtFn(types.AnyMultirange, types.AnyMultirange, volatility.Immutable),
 		makeLtFn(types.Bool, types.Bool, volatility.Leakproof),
 		makeLtFn(types.Bytes, types.Bytes, volatility.Leakproof),
 		makeLtFn(types.Date, types.Date, volatility.Leakproof),
@@ -1644,6 +1798,8 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 	treecmp.LE: {overloads: []*CmpOp{
 		// Single-type comparisons.
 		makeLeFn(types.AnyEnum, types.AnyEnum, volatility.Immutable),
+		makeLeFn(types.AnyRange, types.AnyRange, volatility.Immutable),
+		makeLeFn(types.AnyMultirange, types.AnyMultirange, volatility.Immutable),
 		makeLeFn(types.Bool, types.Bool, volatility.Leakproof),
 		makeLeFn(types.Bytes, types.Bytes, volatility.Leakproof),
 		makeLeFn(types.Date, types.Date, volatility.Leakproof),
@@ -1723,6 +1879,8 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 		},
 		// Single-type comparisons.
 		makeIsFn(types.AnyEnum, types.AnyEnum, volatility.Immutable),
+		makeIsFn(types.AnyRange, types.AnyRange, volatility.Immutable),
+		makeIsFn(types.AnyMultirange, types.AnyMultirange, volatility.Immutable),
 		makeIsFn(types.Bool, types.Bool, volatility.Leakproof),
 		makeIsFn(types.Bytes, types.Bytes, volatility.Leakproof),
 		makeIsFn(types.Date, types.Date, volatility.Leakproof),
@@ -1793,6 +1951,8 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 
 	treecmp.In: {overloads: []*CmpOp{
 		makeEvalTupleIn(types.AnyEnum, volatility.Leakproof),
+		makeEvalTupleIn(types.AnyRange, volatility.Immutable),
+		makeEvalTupleIn(types.AnyMultirange, volatility.Immutable),
 		makeEvalTupleIn(types.Bool, volatility.Leakproof),
 		makeEvalTupleIn(types.Bytes, volatility.Leakproof),
 		makeEvalTupleIn(types.Date, volatility.Leakproof),
@@ -1897,7 +2057,7 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 		},
 	}},
 
-	treecmp.Contains: {overloads: []*CmpOp{
+	treecmp.Contains: {overloads: append([]*CmpOp{
 		{
 			LeftType:   types.AnyArray,
 			RightType:  types.AnyArray,
@@ -1910,9 +2070,23 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 			EvalOp:     &ContainsJsonbOp{},
 			Volatility: volatility.Immutable,
 		},
-	}},
+	}, append(append(makeGeometricComparisonOperators(
+		geometric.ContainsOp.Supports,
+		func(left, right geometric.Shape) bool {
+			res, _ := geometric.Contains(left, right)
+			return res
+		},
+	), makeRangeComparisonOperators(
+		func(ctx CompareContext, left, right *DRange) (bool, error) {
+			return left.ContainsRange(ctx, right)
+		},
+		func(ctx CompareContext, left, right *DMultirange) (bool, error) {
+			return left.ContainsMultirange(ctx, right)
+		},
+	)...), makeRangeContainsElemOperators(false /* elemOnLeft */)...)...),
+	},
 
-	treecmp.ContainedBy: {overloads: []*CmpOp{
+	treecmp.ContainedBy: {overloads: append([]*CmpOp{
 		{
 			LeftType:   types.AnyArray,
 			RightType:  types.AnyArray,
@@ -1925,7 +2099,23 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 			EvalOp:     &ContainedByJsonbOp{},
 			Volatility: volatility.Immutable,
 		},
-	}},
+	}, append(append(makeGeometricComparisonOperators(
+		func(left, right geometric.Kind) bool {
+			return geometric.ContainsOp.Supports(right, left)
+		},
+		func(left, right geometric.Shape) bool {
+			res, _ := geometric.Contains(right, left)
+			return res
+		},
+	), makeRangeComparisonOperators(
+		func(ctx CompareContext, left, right *DRange) (bool, error) {
+			return right.ContainsRange(ctx, left)
+		},
+		func(ctx CompareContext, left, right *DMultirange) (bool, error) {
+			return right.ContainsMultirange(ctx, left)
+		},
+	)...), makeRangeContainsElemOperators(true /* elemOnLeft */)...)...),
+	},
 	treecmp.Overlaps: {overloads: append([]*CmpOp{
 		{
 			LeftType:   types.AnyArray,
@@ -1939,11 +2129,24 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 			EvalOp:     &OverlapsINetOp{},
 			Volatility: volatility.Immutable,
 		},
-	}, makeBox2DComparisonOperators(
+	}, append(makeBox2DComparisonOperators(
 		func(lhs, rhs *geo.CartesianBoundingBox) bool {
 			return lhs.Intersects(rhs)
 		},
-	)...),
+	), append(makeGeometricComparisonOperators(
+		geometric.OverlapsOp.Supports,
+		func(left, right geometric.Shape) bool {
+			res, _ := geometric.Overlaps(left, right)
+			return res
+		},
+	), makeRangeComparisonOperators(
+		func(ctx CompareContext, left, right *DRange) (bool, error) {
+			return left.Overlaps(ctx, right)
+		},
+		func(ctx CompareContext, left, right *DMultirange) (bool, error) {
+			return left.Overlaps(ctx, right)
+		},
+	)...)...)...),
 	},
 	treecmp.TSMatches: {overloads: []*CmpOp{
 		{
@@ -1958,6 +2161,12 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 			EvalOp:     &TSMatchesVectorQueryOp{},
 			Volatility: volatility.Immutable,
 		},
+		{
+			LeftType:   types.Jsonb,
+			RightType:  types.Jsonpath,
+			EvalOp:     &JSONPathMatchOp{},
+			Volatility: volatility.Immutable,
+		},
 	}},
 })
 
@@ -2010,6 +2219,104 @@ func makeBox2DComparisonOperators(op func(lhs, rhs *geo.CartesianBoundingBox) bo
 	}
 }
 
+// makeGeometricComparisonOperators returns the overloads of a comparison
+// operator between geometric types, for every pair of types whose kinds are
+// accepted by supports.
+func makeGeometricComparisonOperators(
+	supports func(left, right geometric.Kind) bool, op func(left, right geometric.Shape) bool,
+) []*CmpOp {
+	var res []*CmpOp
+	for left, leftType := range geometricKindTypes {
+		for right, rightType := range geometricKindTypes {
+			if leftType == nil || rightType == nil ||
+				!supports(geometric.Kind(left), geometric.Kind(right)) {
+				continue
+			}
+			res = append(res, &CmpOp{
+				LeftType:  leftType,
+				RightType: rightType,
+				EvalOp: &CompareGeometricOp{Op: func(left, right Datum) bool {
+					return op(MustBeDGeometric(left).Shape, MustBeDGeometric(right).Shape)
+				}},
+				Volatility: volatility.Immutable,
+			})
+		}
+	}
+	return res
+}
+
+// makeRangeComparisonOperators returns the overloads of a comparison operator
+// between ranges and multiranges. rangeOp implements the operator between two
+// ranges and multirangeOp implements it between two multiranges; a range
+// compared with a multirange is treated as a multirange containing only that
+// range.
+func makeRangeComparisonOperators(
+	rangeOp func(ctx CompareContext, left, right *DRange) (bool, error),
+	multirangeOp func(ctx CompareContext, left, right *DMultirange) (bool, error),
+) []*CmpOp {
+	asMultirange := func(d Datum) *DMultirange {
+		if r, ok := AsDRange(d); ok {
+			return RangeToMultirange(r)
+		}
+		return MustBeDMultirange(d)
+	}
+	res := []*CmpOp{{
+		LeftType:  types.AnyRange,
+		RightType: types.AnyRange,
+		EvalOp: &CompareRangeOp{Op: func(ctx CompareContext, left, right Datum) (bool, error) {
+			return rangeOp(ctx, MustBeDRange(left), MustBeDRange(right))
+		}},
+		Volatility: volatility.Immutable,
+	}}
+	for _, operands := range [][2]*types.T{
+		{types.AnyMultirange, types.AnyMultirange},
+		{types.AnyMultirange, types.AnyRange},
+		{types.AnyRange, types.AnyMultirange},
+	} {
+		res = append(res, &CmpOp{
+			LeftType:  operands[0],
+			RightType: operands[1],
+			EvalOp: &CompareRangeOp{Op: func(ctx CompareContext, left, right Datum) (bool, error) {
+				return multirangeOp(ctx, asMultirange(left), asMultirange(right))
+			}},
+			Volatility: volatility.Immutable,
+		})
+	}
+	return res
+}
+
+// makeRangeContainsElemOperators returns the overloads of the operator testing
+// whether a built-in range or multirange contains a value of its subtype. The
+// value is the left operand if elemOnLeft is true, as for <@.
+func makeRangeContainsElemOperators(elemOnLeft bool) []*CmpOp {
+	var res []*CmpOp
+	for _, t := range append(append([]*types.T(nil), rangeTypes...), multirangeTypes...) {
+		elemType := t.RangeContents()
+		if t.Family() == types.MultirangeFamily {
+			elemType = elemType.RangeContents()
+		}
+		leftType, rightType := t, elemType
+		if elemOnLeft {
+			leftType, rightType = elemType, t
+		}
+		res = append(res, &CmpOp{
+			LeftType:  leftType,
+			RightType: rightType,
+			EvalOp: &CompareRangeOp{Op: func(ctx CompareContext, left, right Datum) (bool, error) {
+				if elemOnLeft {
+					left, right = right, left
+				}
+				if r, ok := AsDRange(left); ok {
+					return r.ContainsElem(ctx, right)
+				}
+				return MustBeDMultirange(left).ContainsElem(ctx, right)
+			}},
+			Volatility: volatility.Immutable,
+		})
+	}
+	return res
+}
+
 // This map contains the inverses for operators in the CmpOps map that have
 // inverses.
 var cmpOpsInverse map[treecmp.ComparisonOperatorSymbol]treecmp.ComparisonOperatorSymbol
@@ -32,6 +32,21 @@ type CompareBox2DOp struct {
 	Op func(left, right Datum) bool
 }
 
+// CompareGeometricOp is a BinaryEvalOp.
+type CompareGeometricOp struct {
+	Op func(left, right Datum) bool
+}
+
+// CompareRangeOp is a BinaryEvalOp.
+type CompareRangeOp struct {
+	Op func(ctx CompareContext, left, right Datum) (bool, error)
+}
+
+// CombineRangesOp is a BinaryEvalOp.
+type CombineRangesOp struct {
+	Op func(ctx CompareContext, left, right Datum) (Datum, error)
+}
+
 // InTupleOp is a BinaryEvalOp.
 type InTupleOp struct{}
 
@@ -376,6 +391,9 @@ type JSONFetchValPathOp struct{}
 // JSONFetchTextPathOp is a BinaryEvalOp.
 type JSONFetchTextPathOp struct{}
 
+// JSONPathMatchOp is a BinaryEvalOp.
+type JSONPathMatchOp struct{}
+
 // ContainsArrayOp is a BinaryEvalOp.
 type ContainsArrayOp struct{}
 
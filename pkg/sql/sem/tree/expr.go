@@ -421,6 +421,19 @@ func NewTypedIndirectionExpr(expr, index TypedExpr, typ *types.T) *IndirectionEx
 	return node
 }
 
+// NewTypedArraySubscriptExpr returns a new IndirectionExpr with the given
+// subscripts into an array, which is verified to be well-typed.
+func NewTypedArraySubscriptExpr(
+	expr TypedExpr, subscripts ArraySubscripts, typ *types.T,
+) *IndirectionExpr {
+	node := &IndirectionExpr{
+		Expr:        expr,
+		Indirection: subscripts,
+	}
+	node.typ = typ
+	return node
+}
+
 // NewTypedCollateExpr returns a new CollateExpr that is verified to be well-typed.
 func NewTypedCollateExpr(expr TypedExpr, locale string) *CollateExpr {
 	node := &CollateExpr{
@@ -1753,6 +1766,8 @@ func (node *DTimestamp) String() string       { return AsString(node) }
 func (node *DTimestampTZ) String() string     { return AsString(node) }
 func (node *DTuple) String() string           { return AsString(node) }
 func (node *DArray) String() string           { return AsString(node) }
+func (node *DRange) String() string           { return AsString(node) }
+func (node *DMultirange) String() string      { return AsString(node) }
 func (node *DOid) String() string             { return AsString(node) }
 func (node *DOidWrapper) String() string      { return AsString(node) }
 func (node *DVoid) String() string            { return AsString(node) }
@@ -1782,6 +1797,7 @@ func (node *TupleStar) String() string        { return AsString(node) }
 func (node *AnnotateTypeExpr) String() string { return AsString(node) }
 func (node *UnaryExpr) String() string        { return AsString(node) }
 func (node DefaultVal) String() string        { return AsString(node) }
+func (node *GroupingSet) String() string      { return AsString(node) }
 func (node PartitionMaxVal) String() string   { return AsString(node) }
 func (node PartitionMinVal) String() string   { return AsString(node) }
 func (node *Placeholder) String() string      { return AsString(node) }
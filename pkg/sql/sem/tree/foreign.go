@@ -0,0 +1,111 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+// ForeignOption is a single `key 'value'` entry of an OPTIONS clause of a
+// foreign server or foreign table.
+type ForeignOption struct {
+	Key   Name
+	Value string
+}
+
+// ForeignOptions is the list of options in an OPTIONS clause.
+type ForeignOptions []ForeignOption
+
+// Format implements the NodeFormatter interface.
+func (o *ForeignOptions) Format(ctx *FmtCtx) {
+	for i := range *o {
+		opt := &(*o)[i]
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(&opt.Key)
+		ctx.WriteByte(' ')
+		ctx.FormatNode(NewStrVal(opt.Value))
+	}
+}
+
+// CreateForeignServer represents a CREATE SERVER statement.
+type CreateForeignServer struct {
+	Name        Name
+	IfNotExists bool
+	Wrapper     Name
+	Options     ForeignOptions
+}
+
+var _ Statement = &CreateForeignServer{}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateForeignServer) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE SERVER ")
+	if node.IfNotExists {
+		ctx.WriteString("IF NOT EXISTS ")
+	}
+	ctx.FormatNode(&node.Name)
+	ctx.WriteString(" FOREIGN DATA WRAPPER ")
+	ctx.FormatNode(&node.Wrapper)
+	if len(node.Options) > 0 {
+		ctx.WriteString(" OPTIONS (")
+		ctx.FormatNode(&node.Options)
+		ctx.WriteString(")")
+	}
+}
+
+// DropForeignServer represents a DROP SERVER statement.
+type DropForeignServer struct {
+	Names        NameList
+	IfExists     bool
+	DropBehavior DropBehavior
+}
+
+var _ Statement = &DropForeignServer{}
+
+// Format implements the NodeFormatter interface.
+func (node *DropForeignServer) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP SERVER ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.FormatNode(&node.Names)
+	if node.DropBehavior != DropDefault {
+		ctx.WriteString(" ")
+		ctx.WriteString(node.DropBehavior.String())
+	}
+}
+
+// CreateForeignTable represents a CREATE FOREIGN TABLE statement.
+type CreateForeignTable struct {
+	Table       TableName
+	IfNotExists bool
+	Defs        TableDefs
+	Server      Name
+	Options     ForeignOptions
+}
+
+var _ Statement = &CreateForeignTable{}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateForeignTable) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE FOREIGN TABLE ")
+	if node.IfNotExists {
+		ctx.WriteString("IF NOT EXISTS ")
+	}
+	ctx.FormatNode(&node.Table)
+	ctx.WriteString(" (")
+	ctx.FormatNode(&node.Defs)
+	ctx.WriteString(") SERVER ")
+	ctx.FormatNode(&node.Server)
+	if len(node.Options) > 0 {
+		ctx.WriteString(" OPTIONS (")
+		ctx.FormatNode(&node.Options)
+		ctx.WriteString(")")
+	}
+}
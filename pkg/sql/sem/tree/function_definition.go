@@ -148,6 +148,11 @@ type FunctionProperties struct {
 	// since its definition is to pick out the JSON attributes within the input
 	// that match, by name, to the columns in the aliased record type.
 	ReturnsRecordType bool
+
+	// TextSearchObjectArgument is true if the first argument of the two-argument
+	// overloads of the builtin names a text search configuration or dictionary,
+	// which may be user-defined.
+	TextSearchObjectArgument bool
 }
 
 // ShouldDocument returns whether the built-in function should be included in
@@ -263,7 +268,13 @@ func (fd *ResolvedFunctionDefinition) MatchOverload(
 ) (QualifiedOverload, error) {
 	matched := func(ol QualifiedOverload, schema string) bool {
 		if ol.Type == UDFRoutine || ol.Type == ProcedureRoutine {
-			return schema == ol.Schema && (paramTypes == nil || ol.params().MatchIdentical(paramTypes))
+			params := ol.params()
+			if v, ok := params.(VariadicType); ok {
+				// The variadic parameter of a routine is identified by its array
+				// type.
+				params = v.declaredParams()
+			}
+			return schema == ol.Schema && (paramTypes == nil || params.MatchIdentical(paramTypes))
 		}
 		return schema == ol.Schema && (paramTypes == nil || ol.params().Match(paramTypes))
 	}
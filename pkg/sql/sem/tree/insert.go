@@ -21,12 +21,16 @@ package tree
 
 // Insert represents an INSERT statement.
 type Insert struct {
-	With       *With
-	Table      TableExpr
-	Columns    NameList
-	Rows       *Select
-	OnConflict *OnConflict
-	Returning  ReturningClause
+	With    *With
+	Table   TableExpr
+	Columns NameList
+	// ColumnIndirections, if non-nil, has one entry for each of the Columns,
+	// which designates the part of the column that is assigned to. See
+	// TargetColumns.
+	ColumnIndirections []TargetIndirection
+	Rows               *Select
+	OnConflict         *OnConflict
+	Returning          ReturningClause
 }
 
 // Format implements the NodeFormatter interface.
@@ -41,7 +45,7 @@ func (node *Insert) Format(ctx *FmtCtx) {
 	ctx.FormatNode(node.Table)
 	if node.Columns != nil {
 		ctx.WriteByte('(')
-		ctx.FormatNode(&node.Columns)
+		ctx.FormatNode(&TargetColumns{Names: node.Columns, Indirections: node.ColumnIndirections})
 		ctx.WriteByte(')')
 	}
 	if node.DefaultValues() {
@@ -0,0 +1,102 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+// Merge represents a MERGE statement.
+type Merge struct {
+	With   *With
+	Table  TableExpr
+	Source TableExpr
+	On     Expr
+	Whens  []*MergeWhen
+}
+
+// Format implements the NodeFormatter interface.
+func (node *Merge) Format(ctx *FmtCtx) {
+	ctx.FormatNode(node.With)
+	ctx.WriteString("MERGE INTO ")
+	ctx.FormatNode(node.Table)
+	ctx.WriteString(" USING ")
+	ctx.FormatNode(node.Source)
+	ctx.WriteString(" ON ")
+	ctx.FormatNode(node.On)
+	for _, w := range node.Whens {
+		ctx.WriteByte(' ')
+		ctx.FormatNode(w)
+	}
+}
+
+// MergeActionKind describes the action taken by a WHEN clause of a MERGE
+// statement.
+type MergeActionKind uint8
+
+// MergeActionKind values.
+const (
+	MergeActionDoNothing MergeActionKind = iota
+	MergeActionUpdate
+	MergeActionDelete
+	MergeActionInsert
+)
+
+// MergeWhen represents a single WHEN [NOT] MATCHED clause of a MERGE
+// statement.
+//
+// Exprs is only set for UPDATE actions. Columns and Values are only set for
+// INSERT actions; a nil Values denotes DEFAULT VALUES.
+type MergeWhen struct {
+	Matched bool
+	Cond    Expr
+	Kind    MergeActionKind
+	Exprs   UpdateExprs
+	Columns NameList
+	// ColumnIndirections, if non-nil, has one entry for each of the Columns,
+	// which designates the part of the column that is assigned to. See
+	// TargetColumns.
+	ColumnIndirections []TargetIndirection
+	Values             Exprs
+}
+
+// Format implements the NodeFormatter interface.
+func (node *MergeWhen) Format(ctx *FmtCtx) {
+	if node.Matched {
+		ctx.WriteString("WHEN MATCHED")
+	} else {
+		ctx.WriteString("WHEN NOT MATCHED")
+	}
+	if node.Cond != nil {
+		ctx.WriteString(" AND ")
+		ctx.FormatNode(node.Cond)
+	}
+	ctx.WriteString(" THEN ")
+	switch node.Kind {
+	case MergeActionDoNothing:
+		ctx.WriteString("DO NOTHING")
+	case MergeActionUpdate:
+		ctx.WriteString("UPDATE SET ")
+		ctx.FormatNode(&node.Exprs)
+	case MergeActionDelete:
+		ctx.WriteString("DELETE")
+	case MergeActionInsert:
+		ctx.WriteString("INSERT")
+		if len(node.Columns) > 0 {
+			ctx.WriteString(" (")
+			ctx.FormatNode(&TargetColumns{Names: node.Columns, Indirections: node.ColumnIndirections})
+			ctx.WriteByte(')')
+		}
+		if node.Values == nil {
+			ctx.WriteString(" DEFAULT VALUES")
+		} else {
+			ctx.WriteString(" VALUES (")
+			ctx.FormatNode(&node.Values)
+			ctx.WriteByte(')')
+		}
+	}
+}
@@ -257,6 +257,38 @@ type Overload struct {
 	// Language is the function language that was used to define the UDF.
 	// This is currently either SQL or PL/pgSQL.
 	Language RoutineLanguage
+	// ParamClasses contains the class of each parameter of a user-defined
+	// routine. It is only set if the routine has parameters that are not IN
+	// parameters, in which case RoutineParams is also set.
+	ParamClasses []RoutineParamClass
+	// RoutineParams contains all parameters of a user-defined routine, in the
+	// order of ParamClasses. Unlike Types, it includes the OUT parameters of a
+	// function, which are not passed as arguments, and the variadic parameter
+	// with its array type.
+	RoutineParams ParamTypes
+	// RoutineExecContext describes the changes to the session that are in
+	// effect while a user-defined routine executes. It is only set for SECURITY
+	// DEFINER routines and routines with SET options.
+	RoutineExecContext *RoutineExecContext
+	// Aggregate describes how a user-defined aggregate is computed. It is only
+	// set for overloads of user-defined aggregates.
+	Aggregate *UDFAggregate
+}
+
+// InputParamTypes returns the parameters of a user-defined routine for which
+// arguments are passed when it is invoked. The variadic parameter of the
+// routine, if any, is included with its array type.
+func (b *Overload) InputParamTypes() ParamTypes {
+	if b.ParamClasses == nil {
+		return b.Types.(ParamTypes)
+	}
+	params := make(ParamTypes, 0, len(b.RoutineParams))
+	for i := range b.RoutineParams {
+		if b.ParamClasses[i].IsInput(b.Type == ProcedureRoutine) {
+			params = append(params, b.RoutineParams[i])
+		}
+	}
+	return params
 }
 
 // params implements the overloadImpl interface.
@@ -548,7 +580,15 @@ func (v VariadicType) Match(types []*types.T) bool {
 }
 
 // MatchIdentical is part of the TypeList interface.
-func (VariadicType) MatchIdentical(types []*types.T) bool {
+func (v VariadicType) MatchIdentical(types []*types.T) bool {
+	if !v.MatchLen(len(types)) {
+		return false
+	}
+	for i := range types {
+		if !v.MatchAtIdentical(types[i], i) {
+			return false
+		}
+	}
 	return true
 }
 
@@ -561,9 +601,22 @@ func (v VariadicType) MatchAt(typ *types.T, i int) bool {
 }
 
 // MatchAtIdentical is part of the TypeList interface.
-// TODO(mgartner): This will be incorrect once we add support for variadic UDFs
-func (VariadicType) MatchAtIdentical(typ *types.T, i int) bool {
-	return true
+func (v VariadicType) MatchAtIdentical(typ *types.T, i int) bool {
+	if i < len(v.FixedTypes) {
+		return typ.Family() == types.UnknownFamily || v.FixedTypes[i].Identical(typ)
+	}
+	return typ.Family() == types.UnknownFamily || v.VarType.Identical(typ)
+}
+
+// declaredParams returns the parameters of a variadic user-defined routine as
+// they are declared, with the array type of the variadic parameter.
+func (v VariadicType) declaredParams() ParamTypes {
+	params := make(ParamTypes, len(v.FixedTypes)+1)
+	for i, typ := range v.FixedTypes {
+		params[i].Typ = typ
+	}
+	params[len(v.FixedTypes)].Typ = types.MakeArray(v.VarType)
+	return params
 }
 
 // MatchLen is part of the TypeList interface.
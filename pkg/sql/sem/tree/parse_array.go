@@ -12,6 +12,7 @@ package tree
 
 import (
 	"bytes"
+	"strconv"
 	"strings"
 	"unicode"
 	"unicode/utf8"
@@ -19,13 +20,15 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
-	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 )
 
 var enclosingError = pgerror.Newf(pgcode.InvalidTextRepresentation, "array must be enclosed in { and }")
 var extraTextError = pgerror.Newf(pgcode.InvalidTextRepresentation, "extra text after closing right brace")
-var nestedArraysNotSupportedError = unimplemented.NewWithIssueDetail(32552, "strcast", "nested arrays not supported")
 var malformedError = pgerror.Newf(pgcode.InvalidTextRepresentation, "malformed array")
+var nonRectangularError = pgerror.Newf(pgcode.InvalidTextRepresentation,
+	"multidimensional arrays must have sub-arrays with matching dimensions")
+var dimsMismatchError = pgerror.Newf(pgcode.InvalidTextRepresentation,
+	"specified array dimensions do not match array contents")
 
 func isQuoteChar(ch byte) bool {
 	return ch == '"'
@@ -123,6 +126,12 @@ type parseState struct {
 	dependsOnContext bool
 	result           *DArray
 	t                *types.T
+	// lengths holds the length of each nesting level of braces seen so far,
+	// or -1 for levels whose length is not yet known.
+	lengths []int
+	// numDims is the nesting level at which elements were found, or 0 if no
+	// elements have been found yet.
+	numDims int
 }
 
 func (p *parseState) advance() {
@@ -162,8 +171,6 @@ func (p *parseState) parseElement() error {
 	var err error
 	r := p.peek()
 	switch r {
-	case '{':
-		return nestedArraysNotSupportedError
 	case '"':
 		p.advance()
 		next, err = p.parseQuotedString()
@@ -194,6 +201,120 @@ func (p *parseState) parseElement() error {
 	return p.result.Append(d)
 }
 
+// parseSubArray parses a brace-enclosed array at the given nesting level,
+// which may contain either elements or further brace-enclosed sub-arrays.
+func (p *parseState) parseSubArray(level int) error {
+	if level >= MaxArrayDims {
+		return errArrayTooManyDims
+	}
+	if len(p.lengths) == level {
+		p.lengths = append(p.lengths, -1)
+	}
+	// Consume the opening brace.
+	p.advance()
+	p.eatWhitespace()
+	length := 0
+	if p.peek() != '}' {
+		for {
+			if p.peek() == '{' {
+				if p.numDims != 0 && level+1 >= p.numDims {
+					return malformedError
+				}
+				if err := p.parseSubArray(level + 1); err != nil {
+					return err
+				}
+			} else {
+				if p.numDims == 0 {
+					p.numDims = level + 1
+				} else if p.numDims != level+1 {
+					return malformedError
+				}
+				if err := p.parseElement(); err != nil {
+					return err
+				}
+			}
+			length++
+			p.eatWhitespace()
+			if string(p.peek()) != p.t.Delimiter() {
+				break
+			}
+			p.advance()
+			p.eatWhitespace()
+		}
+	}
+	if p.eof() {
+		return enclosingError
+	}
+	if p.peek() != '}' {
+		return malformedError
+	}
+	p.advance()
+	if p.lengths[level] == -1 {
+		p.lengths[level] = length
+	} else if p.lengths[level] != length {
+		return nonRectangularError
+	}
+	return nil
+}
+
+// parseDimsDecoration parses the optional dimensions that may precede an
+// array, such as the "[0:1][1:3]=" in "[0:1][1:3]={{1,2,3},{4,5,6}}". The
+// lower bound of a dimension defaults to 1 if it is omitted.
+func (p *parseState) parseDimsDecoration() ([]ArrayDim, error) {
+	var dims []ArrayDim
+	parseBound := func() (int, error) {
+		i := 0
+		for i < len(p.s) && (p.s[i] == '-' || p.s[i] == '+' || (p.s[i] >= '0' && p.s[i] <= '9')) {
+			i++
+		}
+		n, err := strconv.ParseInt(p.s[:i], 10, 32)
+		if err != nil {
+			return 0, malformedError
+		}
+		p.s = p.s[i:]
+		p.eatWhitespace()
+		return int(n), nil
+	}
+	for p.peek() == '[' {
+		if len(dims) == MaxArrayDims {
+			return nil, errArrayTooManyDims
+		}
+		p.advance()
+		p.eatWhitespace()
+		lower, upper := 1, 0
+		bound, err := parseBound()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() == ':' {
+			p.advance()
+			p.eatWhitespace()
+			lower = bound
+			if upper, err = parseBound(); err != nil {
+				return nil, err
+			}
+		} else {
+			upper = bound
+		}
+		if p.peek() != ']' {
+			return nil, malformedError
+		}
+		p.advance()
+		p.eatWhitespace()
+		if upper < lower-1 {
+			return nil, pgerror.Newf(pgcode.InvalidTextRepresentation,
+				"upper bound cannot be less than lower bound")
+		}
+		dims = append(dims, ArrayDim{Length: upper - lower + 1, LowerBound: lower})
+	}
+	if p.peek() != '=' {
+		return nil, malformedError
+	}
+	p.advance()
+	p.eatWhitespace()
+	return dims, nil
+}
+
 // ParseDArrayFromString parses the string-form of constructing arrays, handling
 // cases such as `'{1,2,3}'::INT[]`. The input type t is the type of the
 // parameter of the array to parse.
@@ -226,36 +347,47 @@ func doParseDArrayFromString(
 	}
 
 	parser.eatWhitespace()
-	if parser.peek() != '{' {
-		return nil, false, enclosingError
-	}
-	parser.advance()
-	parser.eatWhitespace()
-	if parser.peek() != '}' {
-		if err := parser.parseElement(); err != nil {
+	var decoratedDims []ArrayDim
+	if parser.peek() == '[' {
+		var err error
+		if decoratedDims, err = parser.parseDimsDecoration(); err != nil {
 			return nil, false, err
 		}
-		parser.eatWhitespace()
-		for string(parser.peek()) == t.Delimiter() {
-			parser.advance()
-			parser.eatWhitespace()
-			if err := parser.parseElement(); err != nil {
-				return nil, false, err
-			}
-		}
 	}
-	parser.eatWhitespace()
-	if parser.eof() {
+	if parser.peek() != '{' {
 		return nil, false, enclosingError
 	}
-	if parser.peek() != '}' {
-		return nil, false, malformedError
+	if err := parser.parseSubArray(0 /* level */); err != nil {
+		return nil, false, err
 	}
-	parser.advance()
 	parser.eatWhitespace()
 	if !parser.eof() {
 		return nil, false, extraTextError
 	}
 
+	// Determine the dimensions of the array from the nesting of the braces. An
+	// array without elements is empty, no matter how its braces are nested.
+	var dims []ArrayDim
+	if parser.numDims > 0 {
+		dims = make([]ArrayDim, parser.numDims)
+		for i := range dims {
+			dims[i] = ArrayDim{Length: parser.lengths[i], LowerBound: 1}
+		}
+	}
+	if decoratedDims != nil {
+		if len(decoratedDims) != len(dims) {
+			return nil, false, dimsMismatchError
+		}
+		for i := range dims {
+			if dims[i].Length != decoratedDims[i].Length {
+				return nil, false, dimsMismatchError
+			}
+		}
+		dims = decoratedDims
+	}
+	if err := parser.result.SetDims(dims); err != nil {
+		return nil, false, err
+	}
+
 	return parser.result, parser.dependsOnContext, nil
 }
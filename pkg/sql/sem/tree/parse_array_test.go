@@ -140,6 +140,47 @@ lo}`, types.String, Datums{NewDString(`hel`), NewDString(`lo`)}},
 	}
 }
 
+func TestParseMultiDimArray(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	testData := []struct {
+		str      string
+		expected string
+		numDims  int
+	}{
+		{`{{}}`, `{}`, 0},
+		{`{{1,2},{3,4}}`, `{{1,2},{3,4}}`, 2},
+		{` { { 1 , 2 } , { NULL , 4 } } `, `{{1,2},{NULL,4}}`, 2},
+		{`{{{1},{2}},{{3},{4}}}`, `{{{1},{2}},{{3},{4}}}`, 3},
+		{`[1:2][1:2]={{1,2},{3,4}}`, `{{1,2},{3,4}}`, 2},
+		{`[0:1]={1,2}`, `[0:1]={1,2}`, 1},
+		{`[2]={1,2}`, `{1,2}`, 1},
+		{`[-1:0][3:4]={{1,2},{3,4}}`, `[-1:0][3:4]={{1,2},{3,4}}`, 2},
+	}
+	for _, td := range testData {
+		t.Run(td.str, func(t *testing.T) {
+			actual, _, err := ParseDArrayFromString(nil /* ParseContext */, td.str, types.Int)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s := AsStringWithFlags(actual, FmtPgwireText); s != td.expected {
+				t.Fatalf("expected %s, got %s", td.expected, s)
+			}
+			if n := actual.NumDims(); n != td.numDims {
+				t.Fatalf("expected %d dimensions, got %d", td.numDims, n)
+			}
+			// The formatted array must parse back into an equal array.
+			roundTripped, _, err := ParseDArrayFromString(nil /* ParseContext */, td.expected, types.Int)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if roundTripped.Compare(noopUnwrapCompareContext{}, actual) != 0 {
+				t.Fatalf("expected %s, got %s", actual, roundTripped)
+			}
+		})
+	}
+}
+
 type noopUnwrapCompareContext struct {
 	CompareContext
 }
@@ -218,8 +259,13 @@ func TestParseArrayError(t *testing.T) {
 		{`{,}`, types.Int, `could not parse "{,}" as type int[]: malformed array`},
 		{`{}{}`, types.Int, `could not parse "{}{}" as type int[]: extra text after closing right brace`},
 		{`{} {}`, types.Int, `could not parse "{} {}" as type int[]: extra text after closing right brace`},
-		{`{{}}`, types.Int, `could not parse "{{}}" as type int[]: unimplemented: nested arrays not supported`},
-		{`{1, {1}}`, types.Int, `could not parse "{1, {1}}" as type int[]: unimplemented: nested arrays not supported`},
+		{`{1, {1}}`, types.Int, `could not parse "{1, {1}}" as type int[]: malformed array`},
+		{`{{1}, 1}`, types.Int, `could not parse "{{1}, 1}" as type int[]: malformed array`},
+		{`{{1,2},{3}}`, types.Int, `could not parse "{{1,2},{3}}" as type int[]: multidimensional arrays must have sub-arrays with matching dimensions`},
+		{`{{{{{{{1}}}}}}}`, types.Int, `could not parse "{{{{{{{1}}}}}}}" as type int[]: number of array dimensions exceeds the maximum allowed (6)`},
+		{`[1:3]={1,2}`, types.Int, `could not parse "[1:3]={1,2}" as type int[]: specified array dimensions do not match array contents`},
+		{`[1:2]{1,2}`, types.Int, `could not parse "[1:2]{1,2}" as type int[]: malformed array`},
+		{`[3:1]={}`, types.Int, `could not parse "[3:1]={}" as type int[]: upper bound cannot be less than lower bound`},
 		{`{hello}`, types.Int, `could not parse "{hello}" as type int[]: could not parse "hello" as type int: strconv.ParseInt: parsing "hello": invalid syntax`},
 		{`{"hello}`, types.String, `could not parse "{\"hello}" as type string[]: malformed array`},
 		// It might be unnecessary to disallow this, but Postgres does.
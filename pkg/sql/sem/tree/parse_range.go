@@ -0,0 +1,266 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+const rangeEmptyLiteral = "empty"
+
+// rangeParser splits the text representation of ranges and multiranges into
+// their parts, following range_parse and multirange_in in Postgres.
+type rangeParser struct {
+	s   string
+	pos int
+}
+
+// rangeBoundText is the unparsed text of a range bound.
+type rangeBoundText struct {
+	val       string
+	infinite  bool
+	inclusive bool
+}
+
+// rangeText is the unparsed text of a range.
+type rangeText struct {
+	empty        bool
+	lower, upper rangeBoundText
+}
+
+func (p *rangeParser) eof() bool {
+	return p.pos >= len(p.s)
+}
+
+func (p *rangeParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.s[p.pos]
+}
+
+func (p *rangeParser) skipWhitespace() {
+	for !p.eof() && unicode.IsSpace(rune(p.s[p.pos])) {
+		p.pos++
+	}
+}
+
+// atEmpty returns true if the "empty" key word is next in the input.
+func (p *rangeParser) atEmpty() bool {
+	return len(p.s)-p.pos >= len(rangeEmptyLiteral) &&
+		strings.EqualFold(p.s[p.pos:p.pos+len(rangeEmptyLiteral)], rangeEmptyLiteral)
+}
+
+// consumeEmpty consumes the "empty" key word, if it is next in the input.
+func (p *rangeParser) consumeEmpty() bool {
+	if p.atEmpty() {
+		p.pos += len(rangeEmptyLiteral)
+		return true
+	}
+	return false
+}
+
+// parseRange parses a range starting at the current position, stopping after
+// its closing parenthesis or bracket. If a parse error occurs, the detail of
+// the error is returned.
+func (p *rangeParser) parseRange() (r rangeText, detail string) {
+	if p.consumeEmpty() {
+		r.empty = true
+		return r, ""
+	}
+	switch p.peek() {
+	case '[':
+		r.lower.inclusive = true
+	case '(':
+	default:
+		return r, "Missing left parenthesis or bracket."
+	}
+	p.pos++
+	if r.lower, detail = p.parseBound(r.lower.inclusive); detail != "" {
+		return r, detail
+	}
+	if p.peek() != ',' {
+		return r, "Missing comma after lower bound."
+	}
+	p.pos++
+	if r.upper, detail = p.parseBound(false /* inclusive */); detail != "" {
+		return r, detail
+	}
+	switch p.peek() {
+	case ']':
+		r.upper.inclusive = true
+	case ')':
+	default:
+		return r, "Too many commas."
+	}
+	p.pos++
+	return r, ""
+}
+
+// parseBound parses a bound value, which is terminated by a comma or a closing
+// parenthesis or bracket outside of double quotes.
+func (p *rangeParser) parseBound(inclusive bool) (b rangeBoundText, detail string) {
+	b.inclusive = inclusive
+	switch p.peek() {
+	case ',', ')', ']':
+		b.infinite = true
+		b.inclusive = false
+		return b, ""
+	}
+	var sb strings.Builder
+	inQuote := false
+	for {
+		if p.eof() {
+			return b, "Unexpected end of input."
+		}
+		ch := p.s[p.pos]
+		if !inQuote && (ch == ',' || ch == ')' || ch == ']') {
+			break
+		}
+		p.pos++
+		switch {
+		case ch == '\\':
+			if p.eof() {
+				return b, "Unexpected end of input."
+			}
+			sb.WriteByte(p.s[p.pos])
+			p.pos++
+		case ch == '"' && !inQuote:
+			inQuote = true
+		case ch == '"' && p.peek() == '"':
+			sb.WriteByte('"')
+			p.pos++
+		case ch == '"':
+			inQuote = false
+		default:
+			sb.WriteByte(ch)
+		}
+	}
+	b.val = sb.String()
+	return b, ""
+}
+
+// makeRange parses the bounds of the given range text as values of the
+// subtype of the range type t and returns the resulting range.
+func makeRange(ctx ParseContext, r rangeText, t *types.T) (_ *DRange, dependsOnContext bool, _ error) {
+	if r.empty {
+		return NewEmptyDRange(t), false, nil
+	}
+	var bounds [2]RangeBound
+	for i, b := range [2]rangeBoundText{r.lower, r.upper} {
+		bounds[i].Inclusive = b.inclusive
+		if b.infinite {
+			continue
+		}
+		d, dep, err := ParseAndRequireString(t.RangeContents(), b.val, ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		dependsOnContext = dependsOnContext || dep
+		bounds[i].Val = d
+	}
+	res, err := NewDRange(RangeCompareContext(), t, bounds[0], bounds[1])
+	return res, dependsOnContext, err
+}
+
+func makeMalformedRangeError(s, detail string) error {
+	return errors.WithDetail(
+		pgerror.Newf(pgcode.InvalidTextRepresentation, "malformed range literal: %q", s), detail,
+	)
+}
+
+func makeMalformedMultirangeError(s, detail string) error {
+	return errors.WithDetail(
+		pgerror.Newf(pgcode.InvalidTextRepresentation, "malformed multirange literal: %q", s), detail,
+	)
+}
+
+// ParseDRange parses the string representation of a value of the range type
+// t, such as "[1,10)" or "empty".
+//
+// The dependsOnContext return value indicates if we had to consult the
+// ParseContext (either for the time or the local timezone).
+func ParseDRange(ctx ParseContext, s string, t *types.T) (_ *DRange, dependsOnContext bool, _ error) {
+	p := rangeParser{s: s}
+	p.skipWhitespace()
+	r, detail := p.parseRange()
+	if detail != "" {
+		return nil, false, makeMalformedRangeError(s, detail)
+	}
+	p.skipWhitespace()
+	if !p.eof() {
+		if r.empty {
+			return nil, false, makeMalformedRangeError(s, `Junk after "empty" key word.`)
+		}
+		return nil, false, makeMalformedRangeError(s, "Junk after right parenthesis or bracket.")
+	}
+	return makeRange(ctx, r, t)
+}
+
+// ParseDMultirange parses the string representation of a value of the
+// multirange type t, such as "{[1,3),[5,7)}".
+//
+// The dependsOnContext return value indicates if we had to consult the
+// ParseContext (either for the time or the local timezone).
+func ParseDMultirange(
+	ctx ParseContext, s string, t *types.T,
+) (_ *DMultirange, dependsOnContext bool, _ error) {
+	p := rangeParser{s: s}
+	p.skipWhitespace()
+	if p.peek() != '{' {
+		return nil, false, makeMalformedMultirangeError(s, "Missing left brace.")
+	}
+	p.pos++
+	p.skipWhitespace()
+	var ranges []*DRange
+	if p.peek() == '}' {
+		p.pos++
+	} else {
+		for {
+			p.skipWhitespace()
+			if c := p.peek(); c != '[' && c != '(' && !p.atEmpty() {
+				return nil, false, makeMalformedMultirangeError(s, "Expected range start.")
+			}
+			text, detail := p.parseRange()
+			if detail != "" {
+				return nil, false, makeMalformedMultirangeError(s, detail)
+			}
+			r, dep, err := makeRange(ctx, text, t.RangeContents())
+			if err != nil {
+				return nil, false, err
+			}
+			dependsOnContext = dependsOnContext || dep
+			ranges = append(ranges, r)
+			p.skipWhitespace()
+			if p.peek() == ',' {
+				p.pos++
+				continue
+			}
+			if p.peek() == '}' {
+				p.pos++
+				break
+			}
+			return nil, false, makeMalformedMultirangeError(s, "Expected comma or end of multirange.")
+		}
+	}
+	p.skipWhitespace()
+	if !p.eof() {
+		return nil, false, makeMalformedMultirangeError(s, "Junk after closing right brace.")
+	}
+	res, err := NewDMultirange(RangeCompareContext(), t, ranges)
+	return res, dependsOnContext, err
+}
@@ -0,0 +1,116 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree_test
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRange(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	testData := []struct {
+		str      string
+		typ      *types.T
+		expected string
+	}{
+		{`[1,5]`, types.Int4Range, `[1,6)`},
+		{`(1,5)`, types.Int8Range, `[2,5)`},
+		{`[3,3)`, types.Int4Range, `empty`},
+		{`(3,4)`, types.Int4Range, `empty`},
+		{` empty `, types.Int4Range, `empty`},
+		{`EMPTY`, types.Int8Range, `empty`},
+		{`(,)`, types.Int4Range, `(,)`},
+		{`[,5]`, types.Int4Range, `(,6)`},
+		{`["1","3")`, types.Int4Range, `[1,3)`},
+		{`[1.5,2.5]`, types.NumRange, `[1.5,2.5]`},
+		{`(1.5,)`, types.NumRange, `(1.5,)`},
+		{`[2024-01-01,2024-01-31]`, types.DateRange, `[2024-01-01,2024-02-01)`},
+	}
+	for _, td := range testData {
+		t.Run(td.str, func(t *testing.T) {
+			evalContext := eval.NewTestingEvalContext(cluster.MakeTestingClusterSettings())
+			actual, _, err := tree.ParseDRange(evalContext, td.str, td.typ)
+			require.NoError(t, err)
+			require.Equal(t, td.expected, tree.AsStringWithFlags(actual, tree.FmtBareStrings))
+		})
+	}
+}
+
+func TestParseMultirange(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	testData := []struct {
+		str      string
+		typ      *types.T
+		expected string
+	}{
+		{`{}`, types.Int4Multirange, `{}`},
+		{` { } `, types.Int4Multirange, `{}`},
+		{`{empty}`, types.Int4Multirange, `{}`},
+		{`{[1,3), [2,5), [7,9)}`, types.Int4Multirange, `{[1,5),[7,9)}`},
+		{`{[7,9),[1,3)}`, types.Int8Multirange, `{[1,3),[7,9)}`},
+		{`{[1,3),[3,5)}`, types.Int8Multirange, `{[1,5)}`},
+		{`{(,1.5],[2,)}`, types.NumMultirange, `{(,1.5],[2,)}`},
+	}
+	for _, td := range testData {
+		t.Run(td.str, func(t *testing.T) {
+			evalContext := eval.NewTestingEvalContext(cluster.MakeTestingClusterSettings())
+			actual, _, err := tree.ParseDMultirange(evalContext, td.str, td.typ)
+			require.NoError(t, err)
+			require.Equal(t, td.expected, tree.AsStringWithFlags(actual, tree.FmtBareStrings))
+		})
+	}
+}
+
+func TestParseRangeErrors(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	testData := []struct {
+		str           string
+		typ           *types.T
+		expectedError string
+	}{
+		{`1,2`, types.Int4Range, `malformed range literal: "1,2"`},
+		{`[1,2`, types.Int4Range, `malformed range literal: "[1,2"`},
+		{`[1,2,3)`, types.Int4Range, `malformed range literal: "[1,2,3)"`},
+		{`[1,2) x`, types.Int4Range, `malformed range literal: "[1,2) x"`},
+		{`empty x`, types.Int4Range, `malformed range literal: "empty x"`},
+		{`[5,1)`, types.Int4Range, `range lower bound must be less than or equal to range upper bound`},
+		{`[a,b)`, types.Int4Range, `could not parse "a" as type int`},
+		{`[1,2)`, types.Int4Multirange, `malformed multirange literal: "[1,2)"`},
+		{`{[1,2)`, types.Int4Multirange, `malformed multirange literal: "{[1,2)"`},
+		{`{1}`, types.Int4Multirange, `malformed multirange literal: "{1}"`},
+	}
+	for _, td := range testData {
+		t.Run(td.str, func(t *testing.T) {
+			evalContext := eval.NewTestingEvalContext(cluster.MakeTestingClusterSettings())
+			var err error
+			if td.typ.Family() == types.MultirangeFamily {
+				_, _, err = tree.ParseDMultirange(evalContext, td.str, td.typ)
+			} else {
+				_, _, err = tree.ParseDRange(evalContext, td.str, td.typ)
+			}
+			require.ErrorContains(t, err, td.expectedError)
+		})
+	}
+}
@@ -77,6 +77,12 @@ func ParseAndRequireString(
 		d, err = ParseDGeography(s)
 	case types.GeometryFamily:
 		d, err = ParseDGeometry(s)
+	case types.GeometricFamily:
+		d, err = ParseDGeometric(t, s)
+	case types.RangeFamily:
+		d, dependsOnContext, err = ParseDRange(ctx, s, t)
+	case types.MultirangeFamily:
+		d, dependsOnContext, err = ParseDMultirange(ctx, s, t)
 	case types.JsonFamily:
 		d, err = ParseDJSON(s)
 	case types.OidFamily:
@@ -106,6 +112,8 @@ func ParseAndRequireString(
 		if err == nil {
 			d = NewDEnum(e)
 		}
+	case types.JsonpathFamily:
+		d, err = ParseDJsonpath(s)
 	case types.TSQueryFamily:
 		d, err = ParseDTSQuery(s)
 	case types.TSVectorFamily:
@@ -126,8 +126,6 @@ func (d *DArray) pgwireFormat(ctx *FmtCtx) {
 	case oid.T_int2vector, oid.T_oidvector:
 		// vectors are serialized as a string of space-separated values.
 		sep := ""
-		// TODO(justin): add a test for nested arrays when #32552 is
-		// addressed.
 		for _, d := range d.Array {
 			ctx.WriteString(sep)
 			ctx.FormatNode(d)
@@ -139,10 +137,16 @@ func (d *DArray) pgwireFormat(ctx *FmtCtx) {
 	if ctx.HasFlags(FmtPGCatalog) {
 		ctx.WriteByte('\'')
 	}
-	ctx.WriteByte('{')
-	delimiter := ""
-	for _, v := range d.Array {
-		ctx.WriteString(delimiter)
+	dims := d.Dimensions()
+	if d.hasCustomLowerBounds() {
+		// Postgres only includes the dimensions when a lower bound is not 1,
+		// for example "[0:1][1:2]={{1,2},{3,4}}".
+		for _, dim := range dims {
+			ctx.Printf("[%d:%d]", dim.LowerBound, dim.UpperBound())
+		}
+		ctx.WriteByte('=')
+	}
+	formatArrayDims(ctx, dims, d.Array, '{', '}', d.ParamTyp.Delimiter(), func(v Datum) {
 		switch dv := UnwrapDOidWrapper(v).(type) {
 		case dNull:
 			ctx.WriteString("NULL")
@@ -169,9 +173,7 @@ func (d *DArray) pgwireFormat(ctx *FmtCtx) {
 			s := AsStringWithFlags(v, ctx.flags, FmtDataConversionConfig(ctx.dataConversionConfig), FmtLocation(ctx.location))
 			pgwireFormatStringInArray(ctx, s)
 		}
-		delimiter = d.ParamTyp.Delimiter()
-	}
-	ctx.WriteByte('}')
+	})
 	if ctx.HasFlags(FmtPGCatalog) {
 		ctx.WriteByte('\'')
 	}
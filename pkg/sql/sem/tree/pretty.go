@@ -974,7 +974,8 @@ func (node *Insert) doc(p *PrettyCfg) pretty.Doc {
 
 	into := p.Doc(node.Table)
 	if node.Columns != nil {
-		into = p.nestUnder(into, p.bracket("(", p.Doc(&node.Columns), ")"))
+		cols := &TargetColumns{Names: node.Columns, Indirections: node.ColumnIndirections}
+		into = p.nestUnder(into, p.bracket("(", p.Doc(cols), ")"))
 	}
 	items = append(items, p.row("INTO", into))
 
@@ -1238,7 +1239,7 @@ func (node *Order) doc(p *PrettyCfg) pretty.Doc {
 }
 
 func (node *UpdateExpr) doc(p *PrettyCfg) pretty.Doc {
-	d := p.Doc(&node.Names)
+	d := p.Doc(&TargetColumns{Names: node.Names, Indirections: node.Indirections})
 	if node.Tuple {
 		d = p.bracket("(", d, ")")
 	}
@@ -1821,6 +1822,9 @@ func (node *UniqueConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 	if node.PartitionByIndex != nil {
 		clauses = append(clauses, p.Doc(node.PartitionByIndex))
 	}
+	if deferrability := node.Deferrability.keywordDoc(); deferrability != pretty.Nil {
+		clauses = append(clauses, deferrability)
+	}
 	if node.Predicate != nil {
 		clauses = append(clauses, p.nestUnder(pretty.Keyword("WHERE"), p.Doc(node.Predicate)))
 	}
@@ -1851,6 +1855,7 @@ func (node *ForeignKeyConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 	//    REFERENCES tbl (...)
 	//    [MATCH ...]
 	//    [ACTIONS ...]
+	//    [DEFERRABLE ...]
 	//
 	// or (no constraint name):
 	//
@@ -1858,6 +1863,7 @@ func (node *ForeignKeyConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 	//    REFERENCES tbl [(...)]
 	//    [MATCH ...]
 	//    [ACTIONS ...]
+	//    [DEFERRABLE ...]
 	//
 	clauses := make([]pretty.Doc, 0, 4)
 	title := pretty.ConcatSpace(
@@ -1884,9 +1890,23 @@ func (node *ForeignKeyConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 		clauses = append(clauses, actions)
 	}
 
+	if deferrability := node.Deferrability.keywordDoc(); deferrability != pretty.Nil {
+		clauses = append(clauses, deferrability)
+	}
+
 	return p.nestUnder(title, pretty.Group(pretty.Stack(clauses...)))
 }
 
+func (node *ConstraintDeferrability) keywordDoc() pretty.Doc {
+	switch {
+	case node.InitiallyDeferred:
+		return pretty.Keyword("DEFERRABLE INITIALLY DEFERRED")
+	case node.Deferrable:
+		return pretty.Keyword("DEFERRABLE")
+	}
+	return pretty.Nil
+}
+
 func (p *PrettyCfg) maybePrependConstraintName(constraintName *Name, d pretty.Doc) pretty.Doc {
 	if *constraintName != "" {
 		return pretty.Fold(pretty.ConcatSpace,
@@ -2070,6 +2090,9 @@ func (node *ColumnTableDef) docRow(p *PrettyCfg) pretty.TableRow {
 		if ref := p.Doc(&node.References.Actions); ref != pretty.Nil {
 			fkDetails = append(fkDetails, ref)
 		}
+		if deferrability := node.References.Deferrability.keywordDoc(); deferrability != pretty.Nil {
+			fkDetails = append(fkDetails, deferrability)
+		}
 		fk := fkHead
 		if len(fkDetails) > 0 {
 			fk = p.nestUnder(fk, pretty.Group(pretty.Stack(fkDetails...)))
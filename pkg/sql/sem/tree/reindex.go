@@ -0,0 +1,76 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+// ReindexTarget is the kind of object whose indexes are rebuilt by a REINDEX
+// statement.
+type ReindexTarget int
+
+const (
+	// ReindexIndex rebuilds a single index.
+	ReindexIndex ReindexTarget = iota
+	// ReindexTable rebuilds all the indexes of a table.
+	ReindexTable
+	// ReindexSchema rebuilds the indexes of all tables in a schema.
+	ReindexSchema
+	// ReindexDatabase rebuilds the indexes of all tables in a database.
+	ReindexDatabase
+)
+
+var reindexTargetNames = [...]string{
+	ReindexIndex:    "INDEX",
+	ReindexTable:    "TABLE",
+	ReindexSchema:   "SCHEMA",
+	ReindexDatabase: "DATABASE",
+}
+
+func (t ReindexTarget) String() string {
+	return reindexTargetNames[t]
+}
+
+// Reindex represents a REINDEX statement.
+type Reindex struct {
+	Target ReindexTarget
+	// Concurrently is accepted for compatibility with PostgreSQL. Indexes are
+	// always rebuilt online.
+	Concurrently bool
+
+	// Index is set when Target is ReindexIndex.
+	Index TableIndexName
+	// Table is set when Target is ReindexTable.
+	Table *UnresolvedObjectName
+	// Schema is set when Target is ReindexSchema.
+	Schema ObjectNamePrefix
+	// Database is set when Target is ReindexDatabase.
+	Database Name
+}
+
+var _ Statement = &Reindex{}
+
+// Format implements the NodeFormatter interface.
+func (node *Reindex) Format(ctx *FmtCtx) {
+	ctx.WriteString("REINDEX ")
+	ctx.WriteString(node.Target.String())
+	ctx.WriteByte(' ')
+	if node.Concurrently {
+		ctx.WriteString("CONCURRENTLY ")
+	}
+	switch node.Target {
+	case ReindexIndex:
+		ctx.FormatNode(&node.Index)
+	case ReindexTable:
+		ctx.FormatNode(node.Table)
+	case ReindexSchema:
+		ctx.FormatNode(&node.Schema)
+	case ReindexDatabase:
+		ctx.FormatNode(&node.Database)
+	}
+}
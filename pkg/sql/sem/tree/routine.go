@@ -13,8 +13,10 @@ package tree
 import (
 	"context"
 
+	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/lib/pq/oid"
 )
 
 // RoutinePlanGenerator generates a plan for the execution of each statement
@@ -123,6 +125,11 @@ type RoutineExpr struct {
 	// Procedure is true if the routine is a procedure being invoked by CALL.
 	Procedure bool
 
+	// TxnOp indicates that the routine commits or rolls back the current
+	// transaction before it is evaluated. It is only set for the sub-routines
+	// of a PLpgSQL procedure that model COMMIT and ROLLBACK statements.
+	TxnOp StoredProcTxnOp
+
 	// BlockState holds the information needed to coordinate error-handling
 	// between the sub-routines that make up a PLpgSQL exception block.
 	BlockState *BlockState
@@ -130,6 +137,74 @@ type RoutineExpr struct {
 	// CursorDeclaration contains the information needed to open a SQL cursor with
 	// the result of the *first* body statement. It may be unset.
 	CursorDeclaration *RoutineOpenCursor
+
+	// ReturnNext is true if the rows returned by the first body statement are
+	// added to the result of the enclosing set-returning routine. It is used to
+	// implement the PLpgSQL RETURN NEXT and RETURN QUERY statements.
+	ReturnNext bool
+
+	// BufferedResult is true if the rows returned by the routine are not the
+	// result of its last statement, but are instead accumulated by the
+	// sub-routines that have ReturnNext set. It is only set for set-returning
+	// PLpgSQL functions.
+	BufferedResult bool
+
+	// ExecContext, if set, describes the changes to the session that are in
+	// effect while the routine executes.
+	ExecContext *RoutineExecContext
+}
+
+// RoutineExecContext describes the changes to the session that are in effect
+// while a routine executes. It is set for SECURITY DEFINER routines and for
+// routines with SET options.
+type RoutineExecContext struct {
+	// Definer is the user whose privileges are used while the routine executes.
+	// It is only set for SECURITY DEFINER routines.
+	Definer username.SQLUsername
+
+	// Config contains the session variables that are set while the routine
+	// executes, in the "name=value" form of pg_proc.proconfig.
+	Config []string
+}
+
+// UDFAggregate describes a user-defined aggregate, which is computed by
+// user-defined support functions.
+type UDFAggregate struct {
+	// StateFunc is the OID of the function which computes the next state from
+	// the current state and the arguments of an input row.
+	StateFunc oid.Oid
+	// StateType is the type of the state value.
+	StateType *types.T
+	// FinalFunc is the OID of the function which computes the result from the
+	// final state. It is zero if the final state is the result.
+	FinalFunc oid.Oid
+	// CombineFunc is the OID of the function which combines two partial
+	// states. It is zero if the aggregate cannot be computed in multiple
+	// stages.
+	CombineFunc oid.Oid
+	// InitCond is the string representation of the initial state. The initial
+	// state is NULL if it is nil.
+	InitCond *string
+}
+
+// UserDefinedAggregate contains the routines needed to evaluate a user-defined
+// aggregate. The routines are built without arguments; the arguments are
+// supplied by the aggregator when the routines are invoked.
+type UserDefinedAggregate struct {
+	// Name is the name of the aggregate.
+	Name string
+	// StateType is the type of the state value.
+	StateType *types.T
+	// InitCond is the initial state. It is DNull if the aggregate has no
+	// initial condition.
+	InitCond Datum
+	// StateFunc computes the next state from the current state and the
+	// arguments of an input row.
+	StateFunc *RoutineExpr
+	// FinalFunc, if set, computes the result from the final state.
+	FinalFunc *RoutineExpr
+	// CombineFunc, if set, combines two partial states.
+	CombineFunc *RoutineExpr
 }
 
 // NewTypedRoutineExpr returns a new RoutineExpr that is well-typed.
@@ -144,8 +219,12 @@ func NewTypedRoutineExpr(
 	generator bool,
 	tailCall bool,
 	procedure bool,
+	txnOp StoredProcTxnOp,
 	blockState *BlockState,
 	cursorDeclaration *RoutineOpenCursor,
+	returnNext bool,
+	bufferedResult bool,
+	execContext *RoutineExecContext,
 ) *RoutineExpr {
 	return &RoutineExpr{
 		Args:              args,
@@ -158,8 +237,12 @@ func NewTypedRoutineExpr(
 		Generator:         generator,
 		TailCall:          tailCall,
 		Procedure:         procedure,
+		TxnOp:             txnOp,
 		BlockState:        blockState,
 		CursorDeclaration: cursorDeclaration,
+		ReturnNext:        returnNext,
+		BufferedResult:    bufferedResult,
+		ExecContext:       execContext,
 	}
 }
 
@@ -188,6 +271,21 @@ func (node *RoutineExpr) Walk(v Visitor) Expr {
 	return node
 }
 
+// StoredProcTxnOp indicates whether a routine that is part of a stored
+// procedure ends the current transaction before it is evaluated.
+type StoredProcTxnOp uint8
+
+const (
+	// StoredProcTxnNoOp indicates that the routine does not end the
+	// transaction.
+	StoredProcTxnNoOp StoredProcTxnOp = iota
+	// StoredProcTxnCommit indicates that the routine commits the transaction.
+	StoredProcTxnCommit
+	// StoredProcTxnRollback indicates that the routine rolls back the
+	// transaction.
+	StoredProcTxnRollback
+)
+
 // RoutineExceptionHandler encapsulates the information needed to match and
 // handle errors for the exception block of a routine defined with PLpgSQL.
 type RoutineExceptionHandler struct {
@@ -0,0 +1,139 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+// CreateTextSearchConfig represents a CREATE TEXT SEARCH CONFIGURATION
+// statement.
+type CreateTextSearchConfig struct {
+	Name *UnresolvedObjectName
+	// Options contains either the PARSER or the COPY parameter.
+	Options StorageParams
+}
+
+var _ Statement = &CreateTextSearchConfig{}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateTextSearchConfig) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE TEXT SEARCH CONFIGURATION ")
+	ctx.FormatNode(node.Name)
+	ctx.WriteString(" (")
+	ctx.FormatNode(&node.Options)
+	ctx.WriteString(")")
+}
+
+// CreateTextSearchDictionary represents a CREATE TEXT SEARCH DICTIONARY
+// statement.
+type CreateTextSearchDictionary struct {
+	Name *UnresolvedObjectName
+	// Options contains the TEMPLATE parameter and the parameters of the
+	// template.
+	Options StorageParams
+}
+
+var _ Statement = &CreateTextSearchDictionary{}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateTextSearchDictionary) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE TEXT SEARCH DICTIONARY ")
+	ctx.FormatNode(node.Name)
+	ctx.WriteString(" (")
+	ctx.FormatNode(&node.Options)
+	ctx.WriteString(")")
+}
+
+// AlterTextSearchConfigCmd is the kind of change made by an ALTER TEXT SEARCH
+// CONFIGURATION statement.
+type AlterTextSearchConfigCmd int
+
+const (
+	// AlterTextSearchAddMapping is ADD MAPPING FOR ... WITH ...
+	AlterTextSearchAddMapping AlterTextSearchConfigCmd = iota
+	// AlterTextSearchAlterMapping is ALTER MAPPING FOR ... WITH ...
+	AlterTextSearchAlterMapping
+	// AlterTextSearchDropMapping is DROP MAPPING [IF EXISTS] FOR ...
+	AlterTextSearchDropMapping
+)
+
+// AlterTextSearchConfig represents an ALTER TEXT SEARCH CONFIGURATION
+// statement.
+type AlterTextSearchConfig struct {
+	Name       *UnresolvedObjectName
+	Cmd        AlterTextSearchConfigCmd
+	TokenTypes NameList
+	// Dictionaries is empty for DROP MAPPING.
+	Dictionaries []*UnresolvedObjectName
+	// IfExists is only used by DROP MAPPING.
+	IfExists bool
+}
+
+var _ Statement = &AlterTextSearchConfig{}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterTextSearchConfig) Format(ctx *FmtCtx) {
+	ctx.WriteString("ALTER TEXT SEARCH CONFIGURATION ")
+	ctx.FormatNode(node.Name)
+	switch node.Cmd {
+	case AlterTextSearchAddMapping:
+		ctx.WriteString(" ADD MAPPING FOR ")
+	case AlterTextSearchAlterMapping:
+		ctx.WriteString(" ALTER MAPPING FOR ")
+	case AlterTextSearchDropMapping:
+		ctx.WriteString(" DROP MAPPING ")
+		if node.IfExists {
+			ctx.WriteString("IF EXISTS ")
+		}
+		ctx.WriteString("FOR ")
+	}
+	ctx.FormatNode(&node.TokenTypes)
+	if node.Cmd != AlterTextSearchDropMapping {
+		ctx.WriteString(" WITH ")
+		for i := range node.Dictionaries {
+			if i > 0 {
+				ctx.WriteString(", ")
+			}
+			ctx.FormatNode(node.Dictionaries[i])
+		}
+	}
+}
+
+// DropTextSearch represents a DROP TEXT SEARCH CONFIGURATION or a DROP TEXT
+// SEARCH DICTIONARY statement.
+type DropTextSearch struct {
+	Names        []*UnresolvedObjectName
+	IfExists     bool
+	DropBehavior DropBehavior
+	// Dictionary is set for DROP TEXT SEARCH DICTIONARY.
+	Dictionary bool
+}
+
+var _ Statement = &DropTextSearch{}
+
+// Format implements the NodeFormatter interface.
+func (node *DropTextSearch) Format(ctx *FmtCtx) {
+	if node.Dictionary {
+		ctx.WriteString("DROP TEXT SEARCH DICTIONARY ")
+	} else {
+		ctx.WriteString("DROP TEXT SEARCH CONFIGURATION ")
+	}
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	for i := range node.Names {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(node.Names[i])
+	}
+	if node.DropBehavior != DropDefault {
+		ctx.WriteByte(' ')
+		ctx.WriteString(node.DropBehavior.String())
+	}
+}
@@ -23,7 +23,6 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/collatedstring"
 	"github.com/cockroachdb/cockroach/pkg/util/duration"
-	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/intsets"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
 	"github.com/cockroachdb/errors"
@@ -696,20 +695,32 @@ func (expr *IndirectionExpr) TypeCheck(
 
 	switch typ.Family() {
 	case types.ArrayFamily:
+		if len(expr.Indirection) > MaxArrayDims {
+			return nil, pgerror.Newf(pgcode.ProgramLimitExceeded,
+				"number of array dimensions (%d) exceeds the maximum allowed (%d)",
+				len(expr.Indirection), MaxArrayDims)
+		}
+		// As in Postgres, if any subscript is a slice, the result is an array.
+		// Otherwise, the result is an element of the array.
 		expr.typ = typ.ArrayContents()
-		for i, t := range expr.Indirection {
+		for _, t := range expr.Indirection {
 			if t.Slice {
-				return nil, unimplemented.NewWithIssuef(32551, "ARRAY slicing in %s", expr)
+				expr.typ = types.MakeArray(typ.ArrayContents())
 			}
-			if i > 0 {
-				return nil, unimplemented.NewWithIssueDetailf(32552, "ind", "multidimensional indexing: %s", expr)
+			if t.Begin != nil {
+				beginExpr, err := typeCheckAndRequire(ctx, semaCtx, t.Begin, types.Int, "ARRAY subscript")
+				if err != nil {
+					return nil, err
+				}
+				t.Begin = beginExpr
 			}
-
-			beginExpr, err := typeCheckAndRequire(ctx, semaCtx, t.Begin, types.Int, "ARRAY subscript")
-			if err != nil {
-				return nil, err
+			if t.End != nil {
+				endExpr, err := typeCheckAndRequire(ctx, semaCtx, t.End, types.Int, "ARRAY subscript")
+				if err != nil {
+					return nil, err
+				}
+				t.End = endExpr
 			}
-			t.Begin = beginExpr
 		}
 
 		if OnTypeCheckArraySubscript != nil {
@@ -985,6 +996,7 @@ var (
 	errInvalidDefaultUsage = pgerror.New(pgcode.Syntax, "DEFAULT can only appear in a VALUES list within INSERT or on the right side of a SET")
 	errInvalidMaxUsage     = pgerror.New(pgcode.Syntax, "MAXVALUE can only appear within a range partition expression")
 	errInvalidMinUsage     = pgerror.New(pgcode.Syntax, "MINVALUE can only appear within a range partition expression")
+	errInvalidGroupingSet  = pgerror.New(pgcode.Syntax, "ROLLUP, CUBE and GROUPING SETS can only appear within a GROUP BY clause")
 	errPrivateFunction     = pgerror.New(pgcode.ReservedName, "function reserved for internal use")
 )
 
@@ -1748,6 +1760,13 @@ func (expr PartitionMinVal) TypeCheck(
 	return nil, errInvalidMinUsage
 }
 
+// TypeCheck implements the Expr interface.
+func (expr *GroupingSet) TypeCheck(
+	_ context.Context, _ *SemaContext, desired *types.T,
+) (TypedExpr, error) {
+	return nil, errInvalidGroupingSet
+}
+
 // TypeCheck implements the Expr interface.
 func (expr PartitionMaxVal) TypeCheck(
 	_ context.Context, _ *SemaContext, desired *types.T,
@@ -1824,12 +1843,23 @@ func (expr *Array) TypeCheck(
 		return expr, nil
 	}
 
+	// The sub-arrays of a multidimensional ARRAY constructor, such as the inner
+	// arrays of ARRAY[[1,2],[3,4]], have the same type as the array itself.
+	if _, ok := expr.Exprs[0].(*Array); ok && desired.Family() == types.ArrayFamily {
+		desiredParam = desired
+	}
 	typedSubExprs, typ, err := typeCheckSameTypedExprs(ctx, semaCtx, desiredParam, expr.Exprs...)
 	if err != nil {
 		return nil, err
 	}
 
-	expr.typ = types.MakeArray(typ)
+	if IsSubArrayConstructor(typ) {
+		// As in Postgres, an ARRAY constructor over arrays builds an array with
+		// one more dimension, rather than an array of arrays.
+		expr.typ = typ
+	} else {
+		expr.typ = types.MakeArray(typ)
+	}
 	for i := range typedSubExprs {
 		expr.Exprs[i] = typedSubExprs[i]
 	}
@@ -2047,6 +2077,24 @@ func (d *DGeography) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (T
 	return d, nil
 }
 
+// TypeCheck implements the Expr interface. It is implemented as an idempotent
+// identity function for Datum.
+func (d *DGeometric) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
+	return d, nil
+}
+
+// TypeCheck implements the Expr interface. It is implemented as an idempotent
+// identity function for Datum.
+func (d *DMultirange) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
+	return d, nil
+}
+
+// TypeCheck implements the Expr interface. It is implemented as an idempotent
+// identity function for Datum.
+func (d *DRange) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
+	return d, nil
+}
+
 // TypeCheck implements the Expr interface. It is implemented as an idempotent
 // identity function for Datum.
 func (d *DGeometry) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
@@ -2059,6 +2107,12 @@ func (d *DJSON) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedE
 	return d, nil
 }
 
+// TypeCheck implements the Expr interface. It is implemented as an idempotent
+// identity function for Datum.
+func (d *DJsonpath) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
+	return d, nil
+}
+
 // TypeCheck implements the Expr interface. It is implemented as an idempotent
 // identity function for Datum.
 func (d *DTSQuery) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
@@ -12,8 +12,9 @@ package tree
 
 // Unlisten represents a UNLISTEN statement.
 type Unlisten struct {
-	ChannelName *UnresolvedObjectName
-	Star        bool
+	ChannelName Name
+	// Star is set for UNLISTEN *, which stops listening on all channels.
+	Star bool
 }
 
 var _ Statement = &Unlisten{}
@@ -23,8 +24,8 @@ func (node *Unlisten) Format(ctx *FmtCtx) {
 	ctx.WriteString("UNLISTEN ")
 	if node.Star {
 		ctx.WriteString("* ")
-	} else if node.ChannelName != nil {
-		ctx.FormatNode(node.ChannelName)
+	} else {
+		ctx.FormatNode(&node.ChannelName)
 	}
 }
 
@@ -77,7 +77,11 @@ func (node *UpdateExprs) Format(ctx *FmtCtx) {
 type UpdateExpr struct {
 	Tuple bool
 	Names NameList
-	Expr  Expr
+	// Indirections, if non-nil, has one entry for each of the Names, which
+	// designates the part of the column that is assigned to. See
+	// TargetColumns.
+	Indirections []TargetIndirection
+	Expr         Expr
 }
 
 // Format implements the NodeFormatter interface.
@@ -87,8 +91,55 @@ func (node *UpdateExpr) Format(ctx *FmtCtx) {
 		open, close = "(", ")"
 	}
 	ctx.WriteString(open)
-	ctx.FormatNode(&node.Names)
+	ctx.FormatNode(&TargetColumns{Names: node.Names, Indirections: node.Indirections})
 	ctx.WriteString(close)
 	ctx.WriteString(" = ")
 	ctx.FormatNode(node.Expr)
 }
+
+// TargetColumns is a list of target columns of an INSERT, or of an UPDATE
+// SET clause, along with the parts of the columns that are assigned to.
+// Indirections is nil if all of the columns are assigned in full. Otherwise
+// it has one entry for each of the Names, which is empty for the columns that
+// are assigned in full.
+type TargetColumns struct {
+	Names        NameList
+	Indirections []TargetIndirection
+}
+
+// Format implements the NodeFormatter interface.
+func (node *TargetColumns) Format(ctx *FmtCtx) {
+	for i := range node.Names {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(&node.Names[i])
+		if node.Indirections != nil {
+			ctx.FormatNode(node.Indirections[i])
+		}
+	}
+}
+
+// TargetIndirection designates the part of a target column that is assigned
+// to, as a sequence of field selections and array subscripts. For example, in
+// UPDATE t SET a.b[2] = 1, the target column a has the indirection .b[2].
+type TargetIndirection []TargetIndirectionElem
+
+// TargetIndirectionElem is a field selection or an array subscript in a
+// TargetIndirection. Exactly one of Field and Subscript is set.
+type TargetIndirectionElem struct {
+	Field     Name
+	Subscript *ArraySubscript
+}
+
+// Format implements the NodeFormatter interface.
+func (t TargetIndirection) Format(ctx *FmtCtx) {
+	for i := range t {
+		if t[i].Subscript != nil {
+			ctx.FormatNode(t[i].Subscript)
+		} else {
+			ctx.WriteByte('.')
+			ctx.FormatNode(&t[i].Field)
+		}
+	}
+}
@@ -0,0 +1,304 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/errors"
+)
+
+// SetConstraints implements SET CONSTRAINTS, which changes whether the checks
+// of DEFERRABLE constraints run at the end of each statement or when the
+// transaction commits.
+// See https://www.postgresql.org/docs/current/sql-set-constraints.html for
+// details.
+func (p *planner) SetConstraints(ctx context.Context, n *tree.SetConstraints) (planNode, error) {
+	if !p.extendedEvalCtx.deferredConstraints.enabled() {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			"SET CONSTRAINTS is only supported in client sessions")
+	}
+	node := &setConstraintsNode{all: n.Names == nil, deferred: n.Deferred}
+	for _, name := range n.Names {
+		constraints, err := p.resolveDeferrableConstraints(ctx, string(name))
+		if err != nil {
+			return nil, err
+		}
+		node.constraints = append(node.constraints, constraints...)
+	}
+	return node, nil
+}
+
+// resolveDeferrableConstraints returns the constraints with the given name
+// in the first schema of the search path that has any. As in Postgres, it is
+// an error if one of them is not deferrable.
+func (p *planner) resolveDeferrableConstraints(
+	ctx context.Context, name string,
+) ([]deferredConstraint, error) {
+	if p.CurrentDatabase() == "" {
+		return nil, pgerror.Newf(pgcode.UndefinedObject, "constraint %q does not exist", name)
+	}
+	db, err := p.Descriptors().ByNameWithLeased(p.txn).Get().Database(ctx, p.CurrentDatabase())
+	if err != nil {
+		return nil, err
+	}
+	iter := p.SessionData().SearchPath.IterWithoutImplicitPGSchemas()
+	for scName, ok := iter.Next(); ok; scName, ok = iter.Next() {
+		sc, err := p.Descriptors().ByNameWithLeased(p.txn).MaybeGet().Schema(ctx, db, scName)
+		if err != nil {
+			return nil, err
+		}
+		if sc == nil || sc.SchemaKind() == catalog.SchemaVirtual {
+			continue
+		}
+		objects, err := p.Descriptors().GetAllObjectsInSchema(ctx, p.txn, db, sc)
+		if err != nil {
+			return nil, err
+		}
+		var res []deferredConstraint
+		if err := objects.ForEachDescriptor(func(desc catalog.Descriptor) error {
+			tbl, ok := desc.(catalog.TableDescriptor)
+			if !ok {
+				return nil
+			}
+			c := catalog.FindConstraintByName(tbl, name)
+			if c == nil {
+				return nil
+			}
+			if deferrable, _ := constraintDeferrability(c); !deferrable {
+				return pgerror.Newf(pgcode.WrongObjectType, "constraint %q is not deferrable", name)
+			}
+			res = append(res, deferredConstraint{tableID: tbl.GetID(), name: name})
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+		if len(res) > 0 {
+			return res, nil
+		}
+	}
+	return nil, pgerror.Newf(pgcode.UndefinedObject, "constraint %q does not exist", name)
+}
+
+type setConstraintsNode struct {
+	all         bool
+	constraints []deferredConstraint
+	deferred    bool
+}
+
+func (n *setConstraintsNode) startExec(params runParams) error {
+	deferredConstraints := params.p.extendedEvalCtx.deferredConstraints
+	if n.all {
+		deferredConstraints.setAll(n.deferred)
+	} else {
+		for _, c := range n.constraints {
+			deferredConstraints.setNamed(c, n.deferred)
+		}
+	}
+	if n.deferred {
+		return nil
+	}
+	// Constraints that become IMMEDIATE are checked right away.
+	return params.p.validateDeferredConstraints(params.ctx, deferredConstraints.takeImmediate())
+}
+
+func (n *setConstraintsNode) Next(_ runParams) (bool, error) { return false, nil }
+func (n *setConstraintsNode) Values() tree.Datums            { return nil }
+func (n *setConstraintsNode) Close(_ context.Context)        {}
+
+// deferredConstraint identifies a DEFERRABLE constraint.
+type deferredConstraint struct {
+	tableID descpb.ID
+	name    string
+}
+
+// constraintMode is the mode of the deferrable constraints set by SET
+// CONSTRAINTS ALL.
+type constraintMode int
+
+const (
+	// constraintModeInitial means that every deferrable constraint uses the
+	// mode it was declared with.
+	constraintModeInitial constraintMode = iota
+	constraintModeDeferred
+	constraintModeImmediate
+)
+
+// txnDeferredConstraints tracks the DEFERRABLE constraints of the current
+// transaction. The checks of deferred constraints are skipped by the
+// statements that modify the constrained tables; instead, those constraints
+// are validated in full before the transaction commits, or when SET
+// CONSTRAINTS makes them IMMEDIATE.
+type txnDeferredConstraints struct {
+	// all is the mode set by the last SET CONSTRAINTS ALL.
+	all constraintMode
+
+	// named contains the constraints set to DEFERRED (true) or IMMEDIATE
+	// (false) by name since the last SET CONSTRAINTS ALL.
+	named map[deferredConstraint]bool
+
+	// pending are the deferred constraints whose checks were skipped, in the
+	// order in which they were first skipped.
+	pending []exec.CheckDeferral
+}
+
+// enabled returns whether checks can be deferred in this session. It is false
+// for internal executors, which may not own their transaction.
+func (d *txnDeferredConstraints) enabled() bool {
+	return d != nil
+}
+
+// isDeferred returns whether the given deferrable constraint is currently
+// deferred.
+func (d *txnDeferredConstraints) isDeferred(c *exec.CheckDeferral) bool {
+	if deferred, ok := d.named[deferredConstraint{tableID: descpb.ID(c.TableID), name: c.ConstraintName}]; ok {
+		return deferred
+	}
+	switch d.all {
+	case constraintModeDeferred:
+		return true
+	case constraintModeImmediate:
+		return false
+	default:
+		return c.InitiallyDeferred
+	}
+}
+
+// filterChecks records the checks of deferred constraints as pending and
+// returns the remaining ones, which must be run immediately.
+func (d *txnDeferredConstraints) filterChecks(checks []checkPlan) []checkPlan {
+	var res []checkPlan
+	for i := range checks {
+		if c := checks[i].deferral; c != nil && d.isDeferred(c) {
+			d.addPending(c)
+			if res == nil {
+				res = append(make([]checkPlan, 0, len(checks)), checks[:i]...)
+			}
+			continue
+		}
+		if res != nil {
+			res = append(res, checks[i])
+		}
+	}
+	if res == nil {
+		return checks
+	}
+	return res
+}
+
+func (d *txnDeferredConstraints) addPending(c *exec.CheckDeferral) {
+	for i := range d.pending {
+		if d.pending[i].TableID == c.TableID && d.pending[i].ConstraintName == c.ConstraintName {
+			return
+		}
+	}
+	d.pending = append(d.pending, *c)
+}
+
+func (d *txnDeferredConstraints) setAll(deferred bool) {
+	d.all = constraintModeImmediate
+	if deferred {
+		d.all = constraintModeDeferred
+	}
+	d.named = nil
+}
+
+func (d *txnDeferredConstraints) setNamed(c deferredConstraint, deferred bool) {
+	if d.named == nil {
+		d.named = make(map[deferredConstraint]bool)
+	}
+	d.named[c] = deferred
+}
+
+// takeImmediate removes and returns the pending constraints that are no longer
+// deferred.
+func (d *txnDeferredConstraints) takeImmediate() []exec.CheckDeferral {
+	var res []exec.CheckDeferral
+	pending := d.pending[:0]
+	for i := range d.pending {
+		if d.isDeferred(&d.pending[i]) {
+			pending = append(pending, d.pending[i])
+		} else {
+			res = append(res, d.pending[i])
+		}
+	}
+	d.pending = pending
+	return res
+}
+
+// takePending removes and returns all the pending constraints.
+func (d *txnDeferredConstraints) takePending() []exec.CheckDeferral {
+	res := d.pending
+	d.pending = nil
+	return res
+}
+
+// reset clears the state at the end of a transaction.
+func (d *txnDeferredConstraints) reset() {
+	*d = txnDeferredConstraints{}
+}
+
+// validateDeferredConstraints validates the given deferred constraints
+// against all the rows of their tables. Constraints that were dropped since
+// their checks were deferred are ignored.
+func (p *planner) validateDeferredConstraints(
+	ctx context.Context, constraints []exec.CheckDeferral,
+) error {
+	for i := range constraints {
+		tbl, err := p.Descriptors().ByIDWithLeased(p.Txn()).WithoutNonPublic().Get().Table(
+			ctx, descpb.ID(constraints[i].TableID),
+		)
+		if err != nil {
+			if errors.Is(err, catalog.ErrDescriptorDropped) {
+				continue
+			}
+			return err
+		}
+		c := catalog.FindConstraintByName(tbl, constraints[i].ConstraintName)
+		if c == nil {
+			continue
+		}
+		if fk := c.AsForeignKey(); fk != nil {
+			target, err := p.Descriptors().ByIDWithLeased(p.Txn()).WithoutNonPublic().Get().Table(
+				ctx, fk.GetReferencedTableID(),
+			)
+			if err != nil {
+				return err
+			}
+			if err := validateForeignKey(
+				ctx, p.InternalSQLTxn(), tbl, target, fk.ForeignKeyDesc(), 0, /* indexIDForValidation */
+			); err != nil {
+				return err
+			}
+		} else if uwi := c.AsUniqueWithoutIndex(); uwi != nil {
+			if err := validateUniqueConstraint(
+				ctx,
+				tbl,
+				uwi.GetName(),
+				uwi.CollectKeyColumnIDs().Ordered(),
+				uwi.GetPredicate(),
+				0, /* indexIDForValidation */
+				p.InternalSQLTxn(),
+				p.User(),
+				true, /* preExisting */
+			); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -144,8 +144,19 @@ func ShowCreateTable(
 			f.WriteString(fkCtx.String())
 		}
 	}
+	// The supporting indexes of exclusion constraints are created by the
+	// constraints, which share their names, so they are not shown separately.
+	exclusionIndexNames := make(map[string]struct{})
+	for _, c := range desc.UniqueConstraintsWithoutIndex() {
+		if c.IsExclusionConstraint() {
+			exclusionIndexNames[c.GetName()] = struct{}{}
+		}
+	}
 	for _, idx := range desc.PublicNonPrimaryIndexes() {
 		// Showing the primary index is handled above.
+		if _, ok := exclusionIndexNames[idx.GetName()]; ok && !idx.IsUnique() {
+			continue
+		}
 
 		// Build the PARTITION BY clause.
 		var partitionBuf bytes.Buffer
@@ -245,6 +256,12 @@ func (p *planner) ShowCreate(
 	if desc.IsSequence() {
 		return ShowCreateSequence(ctx, &tn, desc)
 	}
+	if desc.IsForeignTable() {
+		return ShowCreateForeignTable(
+			ctx, &p.RunParams(ctx).p.semaCtx, p.RunParams(ctx).p.SessionData(), &tn, desc,
+			displayOptions.RedactableValues,
+		)
+	}
 	lCtx := newInternalLookupCtx(allHydratedDescs, nil /* prefix */)
 	// Overwrite desc with hydrated descriptor.
 	var err error
@@ -505,6 +505,10 @@ func showForeignKeyConstraint(
 		buf.WriteString(" ON UPDATE ")
 		buf.WriteString(tree.ForeignKeyReferenceActionType[fk.OnUpdate].String())
 	}
+	buf.WriteString(tree.AsString(&tree.ConstraintDeferrability{
+		Deferrable:        fk.Deferrable,
+		InitiallyDeferred: fk.InitiallyDeferred,
+	}))
 	if fk.Validity != descpb.ConstraintValidity_Validated {
 		buf.WriteString(" NOT VALID")
 	}
@@ -540,6 +544,57 @@ func ShowCreateSequence(
 	return f.CloseAndGetString(), nil
 }
 
+// ShowCreateForeignTable returns a valid SQL representation of the CREATE
+// FOREIGN TABLE statement used to create the given foreign table. Secrets in
+// the external storage URI of the table are redacted.
+func ShowCreateForeignTable(
+	ctx context.Context,
+	semaCtx *tree.SemaContext,
+	sessionData *sessiondata.SessionData,
+	tn *tree.TableName,
+	desc catalog.TableDescriptor,
+	redactableValues bool,
+) (string, error) {
+	fmtFlags := tree.FmtSimple
+	if redactableValues {
+		fmtFlags |= tree.FmtMarkRedactionNode | tree.FmtOmitNameRedaction
+	}
+	f := tree.NewFmtCtx(fmtFlags)
+	f.WriteString("CREATE FOREIGN TABLE ")
+	f.FormatNode(tn)
+	f.WriteString(" (")
+	for i, col := range desc.AccessibleColumns() {
+		if i != 0 {
+			f.WriteString(",")
+		}
+		f.WriteString("\n\t")
+		colstr, err := schemaexpr.FormatColumnForDisplay(
+			ctx, desc, col, semaCtx, sessionData, redactableValues,
+		)
+		if err != nil {
+			return "", err
+		}
+		f.WriteString(colstr)
+	}
+	f.WriteString("\n) SERVER ")
+	foreign := desc.GetForeignTable()
+	f.FormatNameP(&foreign.ServerName)
+	if len(foreign.Options) > 0 {
+		options, err := redactForeignOptions(foreign.Options)
+		if err != nil {
+			return "", err
+		}
+		opts := make(tree.ForeignOptions, len(options))
+		for i, opt := range options {
+			opts[i] = tree.ForeignOption{Key: tree.Name(opt.Key), Value: opt.Value}
+		}
+		f.WriteString(" OPTIONS (")
+		f.FormatNode(&opts)
+		f.WriteString(")")
+	}
+	return f.CloseAndGetString(), nil
+}
+
 // showFamilyClause creates the FAMILY clauses for a CREATE statement, writing them
 // to tree.FmtCtx f
 func showFamilyClause(desc catalog.TableDescriptor, f *tree.FmtCtx) {
@@ -766,13 +821,24 @@ func showConstraintClause(
 			formatQuoteNames(&f.Buffer, c.GetName())
 			f.WriteString(" ")
 		}
-		f.WriteString("UNIQUE WITHOUT INDEX (")
-		colNames, err := catalog.ColumnNamesForIDs(desc, c.CollectKeyColumnIDs().Ordered())
-		if err != nil {
-			return err
+		if c.IsExclusionConstraint() {
+			if err := showExclusionElements(desc, c.UniqueWithoutIndexDesc(), f); err != nil {
+				return err
+			}
+		} else {
+			f.WriteString("UNIQUE WITHOUT INDEX (")
+			colNames, err := catalog.ColumnNamesForIDs(desc, c.CollectKeyColumnIDs().Ordered())
+			if err != nil {
+				return err
+			}
+			f.WriteString(strings.Join(colNames, ", "))
+			f.WriteString(")")
+			uwi := c.UniqueWithoutIndexDesc()
+			f.FormatNode(&tree.ConstraintDeferrability{
+				Deferrable:        uwi.Deferrable,
+				InitiallyDeferred: uwi.InitiallyDeferred,
+			})
 		}
-		f.WriteString(strings.Join(colNames, ", "))
-		f.WriteString(")")
 		if c.IsPartial() {
 			f.WriteString(" WHERE ")
 			pred, err := schemaexpr.FormatExprForDisplay(
@@ -790,3 +856,38 @@ func showConstraintClause(
 	f.WriteString("\n)")
 	return nil
 }
+
+// showExclusionElements writes the EXCLUDE clause of the given exclusion
+// constraint, excluding its predicate, to f.
+func showExclusionElements(
+	desc catalog.TableDescriptor, uc *descpb.UniqueWithoutIndexConstraint, f *tree.FmtCtx,
+) error {
+	f.WriteString("EXCLUDE USING ")
+	f.WriteString(uc.ExclusionMethod)
+	f.WriteString(" (")
+	for i, elem := range uc.ExclusionElements {
+		if i > 0 {
+			f.WriteString(", ")
+		}
+		colNames, err := catalog.ColumnNamesForIDs(desc, elem.ColumnIDs)
+		if err != nil {
+			return err
+		}
+		if len(colNames) > 1 {
+			f.WriteString("(")
+		}
+		for j, name := range colNames {
+			if j > 0 {
+				f.WriteString(", ")
+			}
+			f.FormatName(name)
+		}
+		if len(colNames) > 1 {
+			f.WriteString(")")
+		}
+		f.WriteString(" WITH ")
+		f.WriteString(elem.Operator)
+	}
+	f.WriteString(")")
+	return nil
+}
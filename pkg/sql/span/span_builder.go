@@ -16,14 +16,12 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
-	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catalogkeys"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catenumpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/fetchpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/inverted"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/constraint"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
-	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/keyside"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
@@ -89,6 +87,7 @@ func (s *Builder) SpanFromEncDatumsWithRange(
 	rangeColTyp *types.T,
 ) (_ roachpb.Span, containsNull, filterRow bool, err error) {
 	isDesc := s.keyAndPrefixCols[prefixLen].Direction == catenumpb.IndexColumn_DESC
+	nullsReversed := s.keyAndPrefixCols[prefixLen].NullsOrder == catenumpb.IndexColumn_NULLS_REVERSED
 	if isDesc {
 		startBound, endBound = endBound, startBound
 		startInclusive, endInclusive = endInclusive, startInclusive
@@ -151,8 +150,9 @@ func (s *Builder) SpanFromEncDatumsWithRange(
 		}
 	} else {
 		startKey, startContainsNull, err = makeKeyFromRow(values[:prefixLen])
-		// If we have an ascending index make sure not to include NULLs.
-		if !isDesc {
+		// If we have an ascending index, or a descending index with reversed
+		// NULLs, make sure not to include NULLs.
+		if !isDesc || nullsReversed {
 			startKey = encoding.EncodeNotNullAscending(startKey)
 		}
 	}
@@ -170,9 +170,12 @@ func (s *Builder) SpanFromEncDatumsWithRange(
 		}
 	} else {
 		endKey, endContainsNull, err = makeKeyFromRow(values[:prefixLen])
-		// If we have a descending index make sure not to include NULLs.
+		// If we have a descending index, or an ascending index with reversed
+		// NULLs, make sure not to include NULLs.
 		if isDesc {
 			endKey = encoding.EncodeNotNullDescending(endKey)
+		} else if nullsReversed {
+			endKey = encoding.EncodeNullDescending(endKey)
 		} else {
 			endKey = endKey.PrefixEnd()
 		}
@@ -268,9 +271,198 @@ func (s *Builder) UnconstrainedSpans() (roachpb.Spans, error) {
 // appendSpansFromConstraintSpan converts a constraint.Span to one or more
 // roachpb.Spans and appends them to the provided spans. It appends multiple
 // spans in the case that multiple, non-adjacent column families should be
-// scanned.
+// scanned, or that the span constrains an index column with reversed NULLs.
 func (s *Builder) appendSpansFromConstraintSpan(
 	appendTo roachpb.Spans, cs *constraint.Span, splitter Splitter,
+) (roachpb.Spans, error) {
+	if col := s.firstNullsReversedColumn(cs); col != -1 {
+		return s.appendSpansFromNullsReversedConstraintSpan(appendTo, cs, col, splitter)
+	}
+	return s.encodeAndAppendConstraintSpan(appendTo, cs, splitter)
+}
+
+// firstNullsReversedColumn returns the position of the first nullable index
+// column constrained by the given span whose NULLs are not in their default
+// position, or -1 if there is no such column.
+func (s *Builder) firstNullsReversedColumn(cs *constraint.Span) int {
+	n := cs.StartKey().Length()
+	if l := cs.EndKey().Length(); l > n {
+		n = l
+	}
+	for i := 0; i < n; i++ {
+		if col := &s.keyAndPrefixCols[i]; col.NullsOrder == catenumpb.IndexColumn_NULLS_REVERSED &&
+			!col.IsNonNullable {
+			return i
+		}
+	}
+	return -1
+}
+
+// appendSpansFromNullsReversedConstraintSpan converts a constraint.Span that
+// constrains the index column at position col, whose NULLs are not in their
+// default position, to roachpb.Spans and appends them to the provided spans.
+//
+// Constraints order NULLs before all other values of ascending columns and
+// after all other values of descending columns, as the default key encoding
+// does. With reversed NULLs, the keys that have a NULL in column col are
+// instead stored after the keys with the same prefix that have a non-NULL
+// value in the column when it is ascending, and before them when it is
+// descending. The span is therefore split into the keys that share the prefix
+// of its start key, the keys that share the prefix of its end key, and the keys
+// in between, which are not affected by the placement of NULLs. The former two
+// are further split into their NULL and non-NULL parts, which are appended in
+// the order in which they are stored.
+//
+// The optimizer does not build constraints on the index columns that follow a
+// second column with reversed NULLs, so column col is the only one that needs
+// special handling.
+func (s *Builder) appendSpansFromNullsReversedConstraintSpan(
+	appendTo roachpb.Spans, cs *constraint.Span, col int, splitter Splitter,
+) (roachpb.Spans, error) {
+	start, startBoundary := cs.StartKey(), cs.StartBoundary()
+	end, endBoundary := cs.EndKey(), cs.EndBoundary()
+	var pieces []constraint.Span
+	if col == 0 || (start.Length() > col && end.Length() > col && s.hasSamePrefix(start, end, col)) {
+		// All the keys of the span share the same prefix.
+		pieces = s.appendNullsReversedPrefixSpans(
+			pieces, start, startBoundary, end, endBoundary, col,
+		)
+	} else {
+		var startPieces, endPieces []constraint.Span
+		midStart, midStartBoundary := start, startBoundary
+		midEnd, midEndBoundary := end, endBoundary
+		if start.Length() > col {
+			prefix := start.CutBack(start.Length() - col)
+			startPieces = s.appendNullsReversedPrefixSpans(
+				nil, start, startBoundary, prefix, constraint.IncludeBoundary, col,
+			)
+			midStart, midStartBoundary = prefix, constraint.ExcludeBoundary
+		}
+		if end.Length() > col {
+			prefix := end.CutBack(end.Length() - col)
+			endPieces = s.appendNullsReversedPrefixSpans(
+				nil, prefix, constraint.IncludeBoundary, end, endBoundary, col,
+			)
+			midEnd, midEndBoundary = prefix, constraint.ExcludeBoundary
+		}
+		var mid constraint.Span
+		mid.Init(midStart, midStartBoundary, midEnd, midEndBoundary)
+		pieces = append(startPieces, mid)
+		pieces = append(pieces, endPieces...)
+	}
+
+	for i := range pieces {
+		spans, err := s.encodeAndAppendConstraintSpan(nil /* appendTo */, &pieces[i], splitter)
+		if err != nil {
+			return nil, err
+		}
+		for _, sp := range spans {
+			// Some of the pieces can be empty, for example the keys in between the
+			// prefixes of the start and end keys when they are adjacent.
+			if sp.Key.Compare(sp.EndKey) < 0 {
+				appendTo = append(appendTo, sp)
+			}
+		}
+	}
+	return appendTo, nil
+}
+
+// hasSamePrefix returns true if the given keys have the same values for the
+// first n columns. Both keys must have at least n columns.
+func (s *Builder) hasSamePrefix(a, b constraint.Key, n int) bool {
+	for i := 0; i < n; i++ {
+		if a.Value(i).Compare(s.evalCtx, b.Value(i)) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// appendNullsReversedPrefixSpans appends the spans that cover the keys between
+// lo and hi to pieces, in the order in which they are stored. Both keys must
+// have the same prefix of col columns, after which column col has reversed
+// NULLs. A key that does not include a value for column col is open on that
+// column, so it covers all of the keys with its prefix.
+func (s *Builder) appendNullsReversedPrefixSpans(
+	pieces []constraint.Span,
+	lo constraint.Key,
+	loBoundary constraint.SpanBoundary,
+	hi constraint.Key,
+	hiBoundary constraint.SpanBoundary,
+	col int,
+) []constraint.Span {
+	prefix := lo.CutBack(lo.Length() - col)
+	nullKey := prefix.Concat(constraint.MakeKey(tree.DNull))
+	loOpen, hiOpen := lo.Length() <= col, hi.Length() <= col
+	loNull := !loOpen && lo.Value(col) == tree.DNull
+	hiNull := !hiOpen && hi.Value(col) == tree.DNull
+
+	var nulls, values constraint.Span
+	if s.keyAndPrefixCols[col].Direction == catenumpb.IndexColumn_DESC {
+		// The constraint orders NULLs after all other values, but they are stored
+		// before them.
+		if hiOpen || hiNull {
+			nullStart, nullStartBoundary := nullKey, constraint.IncludeBoundary
+			if loNull {
+				nullStart, nullStartBoundary = lo, loBoundary
+			}
+			nullEnd, nullEndBoundary := nullKey, constraint.IncludeBoundary
+			if hiNull {
+				nullEnd, nullEndBoundary = hi, hiBoundary
+			}
+			nulls.Init(nullStart, nullStartBoundary, nullEnd, nullEndBoundary)
+			pieces = append(pieces, nulls)
+		}
+		if !loNull {
+			valStart, valStartBoundary := lo, loBoundary
+			if loOpen {
+				valStart, valStartBoundary = nullKey, constraint.ExcludeBoundary
+			}
+			valEnd, valEndBoundary := hi, hiBoundary
+			if hiOpen || hiNull {
+				valEnd, valEndBoundary = prefix, constraint.IncludeBoundary
+			}
+			values.Init(valStart, valStartBoundary, valEnd, valEndBoundary)
+			pieces = append(pieces, values)
+		}
+		return pieces
+	}
+
+	// The constraint orders NULLs before all other values, but they are stored
+	// after them.
+	if !hiNull {
+		valStart, valStartBoundary := lo, loBoundary
+		if loOpen || loNull {
+			valStart, valStartBoundary = prefix, constraint.IncludeBoundary
+		}
+		valEnd, valEndBoundary := hi, hiBoundary
+		if hiOpen {
+			valEnd, valEndBoundary = nullKey, constraint.ExcludeBoundary
+		}
+		values.Init(valStart, valStartBoundary, valEnd, valEndBoundary)
+		pieces = append(pieces, values)
+	}
+	if loOpen || loNull {
+		nullStart, nullStartBoundary := nullKey, constraint.IncludeBoundary
+		if loNull {
+			nullStart, nullStartBoundary = lo, loBoundary
+		}
+		nullEnd, nullEndBoundary := nullKey, constraint.IncludeBoundary
+		if hiNull {
+			nullEnd, nullEndBoundary = hi, hiBoundary
+		}
+		nulls.Init(nullStart, nullStartBoundary, nullEnd, nullEndBoundary)
+		pieces = append(pieces, nulls)
+	}
+	return pieces
+}
+
+// encodeAndAppendConstraintSpan encodes the boundaries of a constraint.Span
+// into one or more roachpb.Spans and appends them to the provided spans. The
+// span must not constrain index columns with reversed NULLs, unless it is one
+// of the pieces built by appendSpansFromNullsReversedConstraintSpan.
+func (s *Builder) encodeAndAppendConstraintSpan(
+	appendTo roachpb.Spans, cs *constraint.Span, splitter Splitter,
 ) (roachpb.Spans, error) {
 	var span roachpb.Span
 	var err error
@@ -327,14 +519,8 @@ func (s *Builder) encodeConstraintKey(
 			containsNull = true
 		}
 
-		dir, err := catalogkeys.IndexColumnEncodingDirection(
-			s.keyAndPrefixCols[i].Direction,
-		)
-		if err != nil {
-			return nil, false, err
-		}
-
-		key, err = keyside.Encode(key, val, dir)
+		var err error
+		key, err = rowenc.EncodeKeyColumn(key, val, &s.keyAndPrefixCols[i])
 		if err != nil {
 			return nil, false, err
 		}
@@ -13,18 +13,27 @@ package sql
 import (
 	"context"
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/clusterunique"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
 	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser/statements"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowcontainer"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/errors"
 )
@@ -35,17 +44,13 @@ func (p *planner) DeclareCursor(ctx context.Context, s *tree.DeclareCursor) (pla
 	if s.Binary {
 		return nil, unimplemented.NewWithIssue(77099, "DECLARE BINARY CURSOR")
 	}
-	if s.Scroll == tree.Scroll {
-		return nil, unimplemented.NewWithIssue(77102, "DECLARE SCROLL CURSOR")
-	}
 
 	return &delayedNode{
 		name: s.String(),
 		constructor: func(ctx context.Context, p *planner) (_ planNode, _ error) {
-			if p.extendedEvalCtx.TxnImplicit {
-				if s.Hold {
-					return nil, unimplemented.NewWithIssue(77101, "DECLARE CURSOR WITH HOLD can only be used in transaction blocks")
-				}
+			// WITH HOLD cursors are allowed outside of transaction blocks, since
+			// they are materialized when the implicit transaction commits.
+			if p.extendedEvalCtx.TxnImplicit && !s.Hold {
 				return nil, pgerror.Newf(pgcode.NoActiveSQLTransaction, "DECLARE CURSOR can only be used in transaction blocks")
 			}
 
@@ -106,6 +111,14 @@ func (p *planner) DeclareCursor(ctx context.Context, s *tree.DeclareCursor) (pla
 				statement:  statement,
 				created:    timeutil.Now(),
 				withHold:   s.Hold,
+				scroll:     s.Scroll == tree.Scroll,
+			}
+			if cursor.scroll {
+				// Scrollable cursors spool the rows they read so that they can
+				// move backward.
+				cursor.spool = newCursorSpool(
+					ctx, p.ExtendedEvalContext(), p.Mon(), rows.Types(), 0, /* offset */
+				)
 			}
 			if err := p.sqlCursors.addCursor(s.Name, cursor); err != nil {
 				// This case shouldn't happen because cursor names are scoped to a session,
@@ -131,7 +144,10 @@ func (p *planner) checkIfCursorExists(name tree.Name) error {
 	return nil
 }
 
-var errBackwardScan = pgerror.Newf(pgcode.ObjectNotInPrerequisiteState, "cursor can only scan forward")
+var errBackwardScan = errors.WithHint(
+	pgerror.Newf(pgcode.ObjectNotInPrerequisiteState, "cursor can only scan forward"),
+	"Declare it with SCROLL option to enable backward scan.",
+)
 
 // FetchCursor implements the FETCH and MOVE statements.
 // See https://www.postgresql.org/docs/current/sql-fetch.html for details.
@@ -148,7 +164,7 @@ func (p *planner) newFetchNode(s *tree.CursorStmt) (*fetchNode, error) {
 			pgcode.InvalidCursorName, "cursor %q does not exist", s.Name,
 		)
 	}
-	if s.Count < 0 || s.FetchType == tree.FetchBackwardAll {
+	if !cursor.scroll && (s.Count < 0 || s.FetchType == tree.FetchBackwardAll) {
 		return nil, errBackwardScan
 	}
 	node := &fetchNode{
@@ -165,9 +181,9 @@ func (p *planner) newFetchNode(s *tree.CursorStmt) (*fetchNode, error) {
 
 type fetchNode struct {
 	cursor *sqlCursor
-	// n is the number of rows requested.
+	// n is the number of rows requested. It is negative when moving backward.
 	n int64
-	// offset is the number of rows to read first, when in relative or absolute
+	// offset is the position to seek to first, when in relative or absolute
 	// mode.
 	offset    int64
 	fetchType tree.FetchType
@@ -194,8 +210,11 @@ func (f *fetchNode) startInternal() error {
 }
 
 func (f *fetchNode) nextInternal(ctx context.Context) (bool, error) {
-	if f.fetchType == tree.FetchAll {
+	switch f.fetchType {
+	case tree.FetchAll:
 		return f.cursor.Next(ctx)
+	case tree.FetchBackwardAll:
+		return f.cursor.seek(ctx, f.cursor.curRow-1)
 	}
 
 	if !f.seeked {
@@ -204,42 +223,27 @@ func (f *fetchNode) nextInternal(ctx context.Context) (bool, error) {
 		f.seeked = true
 		switch f.fetchType {
 		case tree.FetchFirst:
-			switch f.cursor.curRow {
-			case 0:
-				_, err := f.cursor.Next(ctx)
-				return true, err
-			case 1:
-				return true, nil
-			}
-			return false, errBackwardScan
+			return f.cursor.seek(ctx, 1)
 		case tree.FetchLast:
-			return false, errBackwardScan
+			return f.cursor.seekFromEnd(ctx, -1)
 		case tree.FetchAbsolute:
-			if f.cursor.curRow > f.offset {
-				return false, errBackwardScan
-			}
-			for f.cursor.curRow < f.offset {
-				more, err := f.cursor.Next(ctx)
-				if !more || err != nil {
-					return more, err
-				}
+			if f.offset < 0 {
+				return f.cursor.seekFromEnd(ctx, f.offset)
 			}
-			return true, nil
+			return f.cursor.seek(ctx, f.offset)
 		case tree.FetchRelative:
-			for i := int64(0); i < f.offset; i++ {
-				more, err := f.cursor.Next(ctx)
-				if !more || err != nil {
-					return more, err
-				}
-			}
-			return true, nil
+			return f.cursor.seek(ctx, f.cursor.curRow+f.offset)
 		}
 	}
-	if f.n <= 0 {
-		return false, nil
+	switch {
+	case f.n > 0:
+		f.n--
+		return f.cursor.Next(ctx)
+	case f.n < 0:
+		f.n++
+		return f.cursor.seek(ctx, f.cursor.curRow-1)
 	}
-	f.n--
-	return f.cursor.Next(ctx)
+	return false, nil
 }
 
 func (f *fetchNode) startExec(params runParams) error {
@@ -274,7 +278,7 @@ func (p *planner) CloseCursor(ctx context.Context, n *tree.CloseCursor) (planNod
 		name: n.String(),
 		constructor: func(ctx context.Context, p *planner) (planNode, error) {
 			if n.All {
-				return newZeroNode(nil /* columns */), p.sqlCursors.closeAll(false /* errorOnWithHold */)
+				return newZeroNode(nil /* columns */), p.sqlCursors.closeAll(false /* keepPersisted */)
 			}
 			return newZeroNode(nil /* columns */), p.sqlCursors.closeCursor(n.Name)
 		},
@@ -319,6 +323,73 @@ func (p *planner) PLpgSQLFetchCursor(
 	return res, err
 }
 
+// PLpgSQLOpenDynamicCursor implements the eval.Planner interface. As with the
+// PLpgSQL OPEN statement, the query is executed eagerly, and its result is
+// stored to be returned by the cursor.
+func (p *planner) PLpgSQLOpenDynamicCursor(
+	ctx context.Context, cursorName tree.Name, scroll bool, query string, args tree.Datums,
+) (err error) {
+	if cursorName == "" {
+		// Specifying the empty string as a cursor name conflicts with the
+		// "unnamed" portal, which always exists.
+		return pgerror.Newf(pgcode.DuplicateCursor, "cursor \"\" already in use")
+	}
+	stmt, err := parser.ParseOne(query)
+	if err != nil {
+		return err
+	}
+	if _, ok := stmt.AST.(*tree.Select); !ok {
+		return pgerror.Newf(
+			pgcode.InvalidCursorDefinition, "cannot open %s query as cursor", stmt.AST.StatementTag(),
+		)
+	}
+	qargs := make([]interface{}, len(args))
+	for i := range args {
+		qargs[i] = args[i]
+	}
+	rows, err := p.InternalSQLTxn().QueryIteratorEx(
+		ctx, "plpgsql-open-cursor", p.Txn(), sessiondata.NoSessionDataOverride, query, qargs...,
+	)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.CombineErrors(err, rows.Close())
+	}()
+	// Use context.Background(), since the cursor can outlive the context in
+	// which it was created.
+	cursorHelper := &plpgsqlCursorHelper{
+		ctx:        context.Background(),
+		cursorName: cursorName,
+		cursorSql:  query,
+		scroll:     scroll,
+		resultCols: rows.Types(),
+	}
+	cursorHelper.container.Init(
+		cursorHelper.ctx,
+		getTypesFromResultColumns(cursorHelper.resultCols),
+		p.ExtendedEvalContextCopy(),
+		"routine_open_cursor", /* opName */
+	)
+	for {
+		var ok bool
+		ok, err = rows.Next(ctx)
+		if err != nil || !ok {
+			break
+		}
+		if err = cursorHelper.container.AddRow(ctx, rows.Cur()); err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = cursorHelper.createCursor(p, nil /* blockState */)
+	}
+	if err != nil && !cursorHelper.addedCursor {
+		return errors.CombineErrors(err, cursorHelper.Close())
+	}
+	return err
+}
+
 type sqlCursor struct {
 	isql.Rows
 	// txn is the transaction object that the internal executor for this cursor
@@ -329,29 +400,298 @@ type sqlCursor struct {
 	readSeqNum enginepb.TxnSeq
 	statement  string
 	created    time.Time
-	curRow     int64
-	withHold   bool
+	// curRow is the 1-based position of the cursor in its result set. It is 0
+	// before the first row, and one past the number of rows after the last row.
+	curRow   int64
+	withHold bool
+	// scroll is set if the cursor was declared with the SCROLL option, which
+	// allows it to move backward.
+	scroll bool
 	// eagerExecution indicates that the cursor's query was executed eagerly and
 	// stored in a row container. If true, there is no need to set the transaction
 	// sequence number, since the query is no longer active.
 	eagerExecution bool
+	// persisted indicates that this is a WITH HOLD cursor whose results were
+	// materialized into spool when its transaction committed, so that it can
+	// be used for the rest of the session.
+	persisted bool
+	// exhausted is set once Rows has returned all of its rows.
+	exhausted bool
+	// spool, if set, buffers the rows read from Rows, so that they can be
+	// revisited. It is used by scrollable and persisted cursors.
+	spool *cursorSpool
+	// cur is the current row of a cursor that uses a spool.
+	cur tree.Datums
 }
 
 // Next implements the Rows interface.
 func (s *sqlCursor) Next(ctx context.Context) (bool, error) {
-	more, err := s.Rows.Next(ctx)
-	if err == nil {
-		s.curRow++
+	return s.seek(ctx, s.curRow+1)
+}
+
+// Cur implements the Rows interface.
+func (s *sqlCursor) Cur() tree.Datums {
+	if s.spool != nil {
+		return s.cur
+	}
+	return s.Rows.Cur()
+}
+
+// Close implements the Rows interface.
+func (s *sqlCursor) Close() error {
+	if s.spool != nil {
+		s.spool.close(context.Background())
+		s.spool = nil
+	}
+	return s.Rows.Close()
+}
+
+// seek positions the cursor on the row at the given 1-based position in its
+// result set, and returns whether there is a row at that position. Seeking to
+// position 0 or below positions the cursor before the first row, and seeking
+// past the last row positions it after the last row. Cursors that are not
+// scrollable can only move forward.
+func (s *sqlCursor) seek(ctx context.Context, pos int64) (bool, error) {
+	if pos < 0 {
+		pos = 0
+	}
+	if pos < s.curRow && !s.scroll {
+		return false, errBackwardScan
+	}
+	if s.spool == nil {
+		for s.curRow < pos {
+			if s.exhausted {
+				return false, nil
+			}
+			more, err := s.Rows.Next(ctx)
+			if err != nil {
+				return false, err
+			}
+			s.curRow++
+			if !more {
+				s.exhausted = true
+				return false, nil
+			}
+		}
+		return s.curRow > 0 && !s.exhausted, nil
+	}
+
+	if err := s.fillSpool(ctx, pos); err != nil {
+		return false, err
+	}
+	s.cur = nil
+	if pos == 0 {
+		s.curRow = 0
+		return false, nil
 	}
-	return more, err
+	if pos > s.spool.len() {
+		s.curRow = s.spool.len() + 1
+		return false, nil
+	}
+	row, err := s.spool.get(ctx, pos)
+	if err != nil {
+		return false, err
+	}
+	s.curRow, s.cur = pos, row
+	return true, nil
+}
+
+// seekFromEnd positions the cursor on the row at the given position counted
+// from the end of its result set, where -1 is the last row. It requires the
+// whole result set to be read, so it is only supported by scrollable cursors.
+func (s *sqlCursor) seekFromEnd(ctx context.Context, pos int64) (bool, error) {
+	if !s.scroll {
+		return false, errBackwardScan
+	}
+	if err := s.fillSpool(ctx, math.MaxInt64); err != nil {
+		return false, err
+	}
+	return s.seek(ctx, s.spool.len()+1+pos)
+}
+
+// fillSpool reads rows from Rows into the spool until the spool contains the
+// row at the given position, or until Rows is exhausted.
+func (s *sqlCursor) fillSpool(ctx context.Context, pos int64) error {
+	for s.spool.len() < pos && !s.exhausted {
+		more, err := s.Rows.Next(ctx)
+		if err != nil {
+			return err
+		}
+		if !more {
+			s.exhausted = true
+			break
+		}
+		if err := s.spool.add(ctx, s.Rows.Cur()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// persist materializes the remaining rows of a WITH HOLD cursor into a spool
+// that is accounted for by the given session-level monitor, so that the cursor
+// remains usable once its transaction commits. Scrollable cursors keep all of
+// their rows, while other cursors only keep the current row and the rows that
+// follow it.
+func (s *sqlCursor) persist(
+	ctx context.Context, evalCtx *extendedEvalContext, sessionMon *mon.BytesMonitor,
+) (retErr error) {
+	// Read the remaining rows at the sequence number that the cursor was
+	// declared with, just like FETCH does.
+	origTxnSeqNum := s.txn.GetReadSeqNum()
+	if err := s.txn.SetReadSeqNum(s.readSeqNum); err != nil {
+		return err
+	}
+	defer func() {
+		if err := s.txn.SetReadSeqNum(origTxnSeqNum); err != nil && retErr == nil {
+			retErr = err
+		}
+	}()
+
+	var first int64 = 1
+	if !s.scroll && s.curRow > 1 {
+		first = s.curRow
+	}
+	spool := newCursorSpool(ctx, evalCtx, sessionMon, s.Rows.Types(), first-1 /* offset */)
+	if err := func() error {
+		if s.spool != nil {
+			if err := s.fillSpool(ctx, math.MaxInt64); err != nil {
+				return err
+			}
+			for pos := first; pos <= s.spool.len(); pos++ {
+				row, err := s.spool.get(ctx, pos)
+				if err != nil {
+					return err
+				}
+				if err := spool.add(ctx, row); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		if s.curRow > 0 && !s.exhausted {
+			s.cur = s.Rows.Cur()
+			if err := spool.add(ctx, s.cur); err != nil {
+				return err
+			}
+		}
+		for !s.exhausted {
+			more, err := s.Rows.Next(ctx)
+			if err != nil {
+				return err
+			}
+			if !more {
+				s.exhausted = true
+				break
+			}
+			if err := spool.add(ctx, s.Rows.Cur()); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		spool.close(ctx)
+		return err
+	}
+
+	if s.spool != nil {
+		s.spool.close(ctx)
+	}
+	s.spool = spool
+	s.exhausted = true
+	s.persisted = true
+	s.eagerExecution = true
+	s.txn = nil
+	return s.Rows.Close()
+}
+
+// cursorSpool buffers the rows of a cursor's result set in a disk-backed row
+// container, allowing rows to be accessed by their position.
+type cursorSpool struct {
+	memMonitor  *mon.BytesMonitor
+	diskMonitor *mon.BytesMonitor
+	rows        *rowcontainer.DiskBackedIndexedRowContainer
+	scratch     rowenc.EncDatumRow
+	// offset is the number of rows of the result set that precede the first
+	// row stored in the spool.
+	offset int64
+}
+
+// newCursorSpool creates a cursorSpool for rows with the given columns, whose
+// memory usage is accounted for by the given monitor. The spool must be closed
+// once it is no longer needed.
+func newCursorSpool(
+	ctx context.Context,
+	evalCtx *extendedEvalContext,
+	parentMon *mon.BytesMonitor,
+	cols colinfo.ResultColumns,
+	offset int64,
+) *cursorSpool {
+	distSQLCfg := &evalCtx.DistSQLPlanner.distSQLSrv.ServerConfig
+	typs := make([]*types.T, len(cols))
+	for i := range cols {
+		typs[i] = cols[i].Typ
+	}
+	s := &cursorSpool{
+		memMonitor: execinfra.NewLimitedMonitorNoFlowCtx(
+			ctx, parentMon, distSQLCfg, evalCtx.SessionData(), "cursor-spool-limited",
+		),
+		diskMonitor: execinfra.NewMonitor(ctx, distSQLCfg.ParentDiskMonitor, "cursor-spool-disk"),
+		scratch:     make(rowenc.EncDatumRow, len(typs)),
+		offset:      offset,
+	}
+	s.rows = rowcontainer.NewDiskBackedIndexedRowContainer(
+		colinfo.NoOrdering, typs, &evalCtx.Context,
+		distSQLCfg.TempStorage, s.memMonitor, s.diskMonitor,
+	)
+	return s
+}
+
+// add appends a row to the spool.
+func (s *cursorSpool) add(ctx context.Context, row tree.Datums) error {
+	for i := range row {
+		s.scratch[i].Datum = row[i]
+	}
+	return s.rows.AddRow(ctx, s.scratch)
+}
+
+// len returns the position of the last row stored in the spool.
+func (s *cursorSpool) len() int64 {
+	return s.offset + int64(s.rows.Len())
+}
+
+// get returns the row at the given 1-based position of the result set, which
+// must be stored in the spool.
+func (s *cursorSpool) get(ctx context.Context, pos int64) (tree.Datums, error) {
+	if pos <= s.offset || pos > s.len() {
+		return nil, errors.AssertionFailedf("cursor row %d is not in the spool", pos)
+	}
+	indexedRow, err := s.rows.GetRow(ctx, int(pos-s.offset-1))
+	if err != nil {
+		return nil, err
+	}
+	datums, err := indexedRow.GetDatums(0, len(s.scratch))
+	if err != nil {
+		return nil, err
+	}
+	// Copy the row, since the container may reuse the returned datums.
+	row := make(tree.Datums, len(datums))
+	copy(row, datums)
+	return row, nil
+}
+
+func (s *cursorSpool) close(ctx context.Context) {
+	s.rows.Close(ctx)
+	s.memMonitor.Stop(ctx)
+	s.diskMonitor.Stop(ctx)
 }
 
 // sqlCursors contains a set of active cursors for a session.
 type sqlCursors interface {
-	// closeAll closes all cursors in the set. If any of the cursors were
-	// created WITH HOLD, and the errorOnWithHold flag is true, an error is
-	// returned.
-	closeAll(errorOnWithHold bool) error
+	// closeAll closes all cursors in the set. If keepPersisted is true, WITH
+	// HOLD cursors that were persisted by a committed transaction are left
+	// open.
+	closeAll(keepPersisted bool) error
 	// closeCursor closes the named cursor, returning an error if that cursor
 	// didn't exist in the set.
 	closeCursor(tree.Name) error
@@ -406,19 +746,41 @@ type cursorMap struct {
 	nameCounter int
 }
 
-func (c *cursorMap) closeAll(errorOnWithHold bool) error {
-	for n, c := range c.cursors {
-		if c.withHold && errorOnWithHold {
-			return unimplemented.NewWithIssuef(77101, "cursor %s WITH HOLD must be closed before committing", n)
+func (c *cursorMap) closeAll(keepPersisted bool) error {
+	for n, cursor := range c.cursors {
+		if cursor.persisted && keepPersisted {
+			continue
 		}
-		if err := c.Close(); err != nil {
+		delete(c.cursors, n)
+		if err := cursor.Close(); err != nil {
 			return err
 		}
 	}
-	c.cursors = nil
+	if len(c.cursors) == 0 {
+		c.cursors = nil
+	}
 	return nil
 }
 
+// persistHeldCursors persists all WITH HOLD cursors that were declared in the
+// current transaction, so that they outlive it. It must be called before the
+// transaction commits. The names of the persisted cursors are returned.
+func (c *cursorMap) persistHeldCursors(
+	ctx context.Context, evalCtx *extendedEvalContext, sessionMon *mon.BytesMonitor,
+) ([]tree.Name, error) {
+	var names []tree.Name
+	for n, cursor := range c.cursors {
+		if !cursor.withHold || cursor.persisted {
+			continue
+		}
+		if err := cursor.persist(ctx, evalCtx, sessionMon); err != nil {
+			return names, err
+		}
+		names = append(names, n)
+	}
+	return names, nil
+}
+
 func (c *cursorMap) closeCursor(s tree.Name) error {
 	cursor, ok := c.cursors[s]
 	if !ok {
@@ -465,8 +827,8 @@ type connExCursorAccessor struct {
 	ex *connExecutor
 }
 
-func (c connExCursorAccessor) closeAll(errorOnWithHold bool) error {
-	return c.ex.extraTxnState.sqlCursors.closeAll(errorOnWithHold)
+func (c connExCursorAccessor) closeAll(keepPersisted bool) error {
+	return c.ex.extraTxnState.sqlCursors.closeAll(keepPersisted)
 }
 
 func (c connExCursorAccessor) closeCursor(s tree.Name) error {
@@ -498,9 +860,12 @@ func (p *planner) checkNoConflictingCursors(stmt tree.Statement) error {
 	// We could improve this by matching the memo metadata's list of dependent
 	// schema objects in each open cursor with the objects being changed in the
 	// schema change.
-	if len(p.sqlCursors.list()) > 0 {
-		return unimplemented.NewWithIssue(74608, "cannot run schema change "+
-			"in a transaction with open DECLARE cursors")
+	for _, cursor := range p.sqlCursors.list() {
+		// Persisted WITH HOLD cursors no longer read from the database.
+		if !cursor.persisted {
+			return unimplemented.NewWithIssue(74608, "cannot run schema change "+
+				"in a transaction with open DECLARE cursors")
+		}
 	}
 	return nil
 }
@@ -0,0 +1,735 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/rangefeed"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/fetchpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsnutil"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgoutput"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgrepltree"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
+	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
+)
+
+// walSenderKeepaliveInterval is the interval at which keepalive messages are
+// sent to the client when no changes are streamed.
+const walSenderKeepaliveInterval = 10 * time.Second
+
+// walSenderConfirmInterval is the minimum interval between two updates of the
+// position of the replication slot confirmed by the client.
+const walSenderConfirmInterval = 10 * time.Second
+
+// walSender streams the changes of the tables of a set of publications to a
+// client of the streaming replication protocol, encoded as messages of the
+// pgoutput logical decoding plugin.
+//
+// The changes are read from a rangefeed over the primary indexes of the
+// published tables, so kv.rangefeed.enabled must be set. All the changes
+// committed within the same microsecond are sent as a single transaction,
+// whose LSN is derived from that microsecond (see lsnutil.HLCToLSN).
+//
+// The set of published tables is determined when streaming starts: tables
+// added to the publications afterwards are only streamed after the client
+// reconnects.
+//
+// The positions confirmed as flushed by the client are recorded in the
+// replication slot, which protects the changes after them from garbage
+// collection until they are confirmed.
+type walSender struct {
+	execCfg *ExecutorConfig
+	conn    pgwirebase.Conn
+	fmtCtx  *tree.FmtCtx
+
+	// slotJobID is the ID of the job of the replication slot.
+	slotJobID jobspb.JobID
+	// confirmedFlush is the last position confirmed by the client which was
+	// recorded in the replication slot, and pendingFlush is the last position
+	// confirmed since then.
+	confirmedFlush, pendingFlush hlc.Timestamp
+
+	// startLSN is the position requested by the client. Transactions
+	// committed before it are not sent.
+	startLSN lsn.LSN
+	// tables contains the published tables, by ID.
+	tables map[descpb.ID]*walSenderTable
+	// pending contains the changes which have not been sent yet since the
+	// rangefeed frontier has not passed their microsecond.
+	pending []walSenderEvent
+	// flushedMicros is the last microsecond whose changes have been sent.
+	flushedMicros int64
+	// xid is the counter used to number the transactions sent to the client.
+	xid uint32
+
+	buf  []byte
+	msgs [][]byte
+}
+
+// walSenderTable is a published table.
+type walSenderTable struct {
+	id         descpb.ID
+	schemaName string
+	// span is the span of the primary index of the table.
+	span roachpb.Span
+	// publishInsert, publishUpdate and publishDelete are the operations
+	// published by any of the requested publications.
+	publishInsert, publishUpdate, publishDelete bool
+
+	// desc is the version of the table used to decode changes. A Relation
+	// message is sent to the client before the first change of every version.
+	desc     catalog.TableDescriptor
+	relSent  bool
+	cols     []pgoutput.Column
+	fetcher  row.Fetcher
+	alloc    tree.DatumAlloc
+	provider row.KVProvider
+}
+
+// walSenderEvent is a change of a row received from the rangefeed, or an
+// advance of the frontier of the rangefeed if frontier is set.
+type walSenderEvent struct {
+	key       roachpb.Key
+	value     roachpb.Value
+	prevValue roachpb.Value
+	frontier  hlc.Timestamp
+}
+
+// runWalSender executes START_REPLICATION: it enters the Copy-both
+// subprotocol and streams changes until the client ends the stream or an
+// error occurs.
+func runWalSender(ctx context.Context, p *planner, cmd StartReplication) error {
+	if err := p.checkLogicalReplicationConnection(); err != nil {
+		return err
+	}
+	if err := p.checkReplicationSlotsSupported(ctx); err != nil {
+		return err
+	}
+	n := cmd.Stmt
+	if n.Kind != pgrepltree.LogicalReplication {
+		return unimplemented.NewWithIssueDetail(0, "physical replication",
+			"physical replication is not supported")
+	}
+	if n.Slot == "" {
+		return pgerror.New(pgcode.ObjectNotInPrerequisiteState,
+			"logical replication requires a replication slot")
+	}
+	var pubNames []string
+	for _, o := range n.Options {
+		value := replicationOptionValue(o)
+		switch o.Key {
+		case "proto_version":
+			if value != "1" {
+				return pgerror.Newf(pgcode.FeatureNotSupported,
+					"proto_version %q is not supported; only version 1 is available", value)
+			}
+		case "publication_names":
+			for _, name := range strings.Split(value, ",") {
+				name = strings.Trim(strings.TrimSpace(name), `"`)
+				if name != "" {
+					pubNames = append(pubNames, name)
+				}
+			}
+		case "binary", "streaming", "messages":
+			if value == "true" || value == "on" || value == "1" {
+				return unimplemented.NewWithIssueDetailf(0, "pgoutput "+string(o.Key),
+					"pgoutput option %q is not supported", o.Key)
+			}
+		case "origin":
+		default:
+			return pgerror.Newf(pgcode.InvalidParameterValue,
+				"unrecognized pgoutput option: %s", o.Key)
+		}
+	}
+	if len(pubNames) == 0 {
+		return pgerror.New(pgcode.InvalidParameterValue,
+			"publication_names parameter missing")
+	}
+
+	w := &walSender{
+		execCfg:  p.ExecCfg(),
+		conn:     cmd.Conn,
+		fmtCtx:   p.EvalContext().FmtCtx(tree.FmtPgwireText),
+		startLSN: n.LSN,
+		tables:   make(map[descpb.ID]*walSenderTable),
+	}
+	slot, err := w.loadPublishedTables(ctx, p.CurrentDatabase(), string(n.Slot), pubNames)
+	if err != nil {
+		return err
+	}
+	// Like in postgres, changes are streamed from the last position confirmed
+	// by the client, or from the position requested by the client if it is
+	// after it.
+	w.slotJobID = slot.jobID
+	w.confirmedFlush = slot.progress.ConfirmedFlush
+	w.pendingFlush = w.confirmedFlush
+	initialTS := slot.progress.ConfirmedFlush
+	if w.startLSN != 0 {
+		initialTS.Forward(lsnutil.LSNToHLC(w.startLSN).Prev())
+	}
+	w.flushedMicros = initialTS.WallTime/int64(time.Microsecond) - 1
+	return w.stream(ctx, initialTS)
+}
+
+// loadPublishedTables loads the replication slot and the tables published by
+// the given publications.
+func (w *walSender) loadPublishedTables(
+	ctx context.Context, dbName string, slotName string, pubNames []string,
+) (slot replicationSlot, _ error) {
+	err := w.execCfg.InternalDB.DescsTxn(ctx, func(ctx context.Context, txn descs.Txn) error {
+		// Reset the state in case of retries.
+		for id := range w.tables {
+			delete(w.tables, id)
+		}
+		dbDesc, err := txn.Descriptors().ByName(txn.KV()).Get().Database(ctx, dbName)
+		if err != nil {
+			return err
+		}
+		s, err := getReplicationSlot(ctx, txn, w.execCfg.JobRegistry, slotName)
+		if err != nil {
+			return err
+		}
+		if s == nil {
+			return pgerror.Newf(pgcode.UndefinedObject,
+				"replication slot %q does not exist", slotName)
+		}
+		if err := checkReplicationSlotDatabase(s, dbDesc.GetID()); err != nil {
+			return err
+		}
+		slot = *s
+		tables, err := txn.Descriptors().GetAllTablesInDatabase(ctx, txn.KV(), dbDesc)
+		if err != nil {
+			return err
+		}
+		for _, pubName := range pubNames {
+			pub, err := getPublication(ctx, txn, w.execCfg.Settings.Version, dbDesc.GetID(), pubName)
+			if err != nil {
+				return err
+			}
+			if pub == nil {
+				return pgerror.Newf(pgcode.UndefinedObject,
+					"publication %q does not exist", pubName)
+			}
+			if err := tables.ForEachDescriptor(func(desc catalog.Descriptor) error {
+				table, ok := desc.(catalog.TableDescriptor)
+				if !ok || !publicationIncludesTable(pub, table) {
+					return nil
+				}
+				t := w.tables[table.GetID()]
+				if t == nil {
+					if table.NumFamilies() > 1 {
+						return unimplemented.NewWithIssueDetailf(0, "logical replication column families",
+							"cannot publish table %q with multiple column families", table.GetName())
+					}
+					sc, err := txn.Descriptors().ByID(txn.KV()).Get().Schema(ctx, table.GetParentSchemaID())
+					if err != nil {
+						return err
+					}
+					t = &walSenderTable{
+						id:         table.GetID(),
+						schemaName: sc.GetName(),
+						span:       table.PrimaryIndexSpan(w.execCfg.Codec),
+					}
+					w.tables[table.GetID()] = t
+				}
+				t.publishInsert = t.publishInsert || pub.publishInsert
+				t.publishUpdate = t.publishUpdate || pub.publishUpdate
+				t.publishDelete = t.publishDelete || pub.publishDelete
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return slot, err
+}
+
+// stream enters the Copy-both subprotocol and streams the changes committed
+// after initialTS.
+func (w *walSender) stream(ctx context.Context, initialTS hlc.Timestamp) (retErr error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	if err := w.conn.BeginCopyBoth(ctx); err != nil {
+		return err
+	}
+
+	// The client messages are read by a separate goroutine. It exits once the
+	// client ends the stream with CopyDone, after which the connection can be
+	// handed back to the network routine.
+	statusUpdates := make(chan pgoutput.StandbyStatusUpdate, 1)
+	clientDone := make(chan error, 1)
+	go func() {
+		clientDone <- w.readClientMessages(statusUpdates)
+	}()
+	var clientEnded bool
+	defer func() {
+		if clientEnded {
+			return
+		}
+		// End the stream on our side and wait for the client to acknowledge it,
+		// so that the error can be sent to the client once the connection is
+		// back in the normal mode.
+		if err := w.conn.SendCopyDone(ctx); err != nil {
+			log.SqlExec.Warningf(ctx, "failed to end replication stream: %v", err)
+		}
+		<-clientDone
+	}()
+
+	events := make(chan walSenderEvent)
+	rangefeedErr := make(chan error, 1)
+	spans := make([]roachpb.Span, 0, len(w.tables))
+	for _, t := range w.tables {
+		spans = append(spans, t.span)
+	}
+	sendEvent := func(ctx context.Context, ev walSenderEvent) {
+		select {
+		case events <- ev:
+		case <-ctx.Done():
+		}
+	}
+	if len(spans) > 0 {
+		rf, err := w.execCfg.RangeFeedFactory.RangeFeed(
+			ctx, "logical-replication", spans, initialTS,
+			func(ctx context.Context, value *kvpb.RangeFeedValue) {
+				sendEvent(ctx, walSenderEvent{
+					key:       value.Key,
+					value:     value.Value,
+					prevValue: value.PrevValue,
+				})
+			},
+			rangefeed.WithDiff(true),
+			rangefeed.WithOnFrontierAdvance(func(ctx context.Context, ts hlc.Timestamp) {
+				sendEvent(ctx, walSenderEvent{frontier: ts})
+			}),
+			rangefeed.WithOnInternalError(func(ctx context.Context, err error) {
+				select {
+				case rangefeedErr <- err:
+				default:
+				}
+			}),
+			rangefeed.WithOnDeleteRange(func(ctx context.Context, value *kvpb.RangeFeedDeleteRange) {
+				// Range deletions are only used to remove the data of dropped
+				// tables and indexes, which are not published.
+			}),
+		)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			// Unblock the rangefeed callbacks before waiting for them.
+			cancel()
+			rf.Close()
+		}()
+	}
+
+	keepalive := time.NewTicker(walSenderKeepaliveInterval)
+	defer keepalive.Stop()
+	confirm := time.NewTicker(walSenderConfirmInterval)
+	defer confirm.Stop()
+	defer func() {
+		// Record the last position confirmed by the client, unless the slot was
+		// dropped.
+		if retErr == nil {
+			retErr = w.maybeAdvanceSlot(ctx)
+		}
+	}()
+	for {
+		select {
+		case ev := <-events:
+			if ev.frontier.IsEmpty() {
+				w.pending = append(w.pending, ev)
+				continue
+			}
+			if err := w.flush(ctx, ev.frontier); err != nil {
+				return err
+			}
+		case <-keepalive.C:
+			if len(spans) == 0 {
+				// Without a rangefeed, there are no changes to wait for.
+				w.flushedMicros = w.execCfg.Clock.Now().WallTime/int64(time.Microsecond) - 1
+			}
+			if err := w.sendKeepalive(ctx, false /* replyRequested */); err != nil {
+				return err
+			}
+		case u := <-statusUpdates:
+			if err := w.handleStatusUpdate(ctx, u); err != nil {
+				return err
+			}
+		case <-confirm.C:
+			if err := w.maybeAdvanceSlot(ctx); err != nil {
+				return err
+			}
+		case err := <-clientDone:
+			clientEnded = true
+			if err != nil {
+				return err
+			}
+			// The last status update of the client is sent before CopyDone.
+			select {
+			case u := <-statusUpdates:
+				w.pendingFlush.Forward(statusUpdateFlush(u))
+			default:
+			}
+			return w.conn.SendCopyDone(ctx)
+		case err := <-rangefeedErr:
+			return err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// handleStatusUpdate handles a standby status update of the client. The
+// position confirmed as flushed is recorded in the slot periodically, to limit
+// the writes to the job of the slot.
+func (w *walSender) handleStatusUpdate(ctx context.Context, u pgoutput.StandbyStatusUpdate) error {
+	w.pendingFlush.Forward(statusUpdateFlush(u))
+	if u.ReplyRequested {
+		return w.sendKeepalive(ctx, false /* replyRequested */)
+	}
+	return nil
+}
+
+// statusUpdateFlush returns the time up to which the changes were confirmed
+// as flushed by a standby status update, inclusive.
+func statusUpdateFlush(u pgoutput.StandbyStatusUpdate) hlc.Timestamp {
+	if u.FlushPosition == 0 {
+		return hlc.Timestamp{}
+	}
+	// The changes committed at or after the LSN are not confirmed.
+	return lsnutil.LSNToHLC(u.FlushPosition).Prev()
+}
+
+// maybeAdvanceSlot records the position confirmed by the client in the
+// replication slot, if it advanced.
+func (w *walSender) maybeAdvanceSlot(ctx context.Context) error {
+	if !w.confirmedFlush.Less(w.pendingFlush) {
+		return nil
+	}
+	if err := advanceReplicationSlot(ctx, w.execCfg, w.slotJobID, w.pendingFlush); err != nil {
+		return err
+	}
+	w.confirmedFlush = w.pendingFlush
+	return nil
+}
+
+// readClientMessages reads the messages sent by the client until it ends the
+// stream. It must not return before the client sends CopyDone or the
+// connection is broken, as the connection is not usable by the network
+// routine until then.
+func (w *walSender) readClientMessages(
+	statusUpdates chan pgoutput.StandbyStatusUpdate,
+) (retErr error) {
+	readBuf := pgwirebase.MakeReadBuffer(
+		pgwirebase.ReadBufferOptionWithClusterSettings(&w.execCfg.Settings.SV),
+	)
+	for {
+		typ, _, err := readBuf.ReadTypedMsg(w.conn.Rd())
+		if err != nil {
+			if pgwirebase.IsMessageTooBigError(err) {
+				if _, err := readBuf.SlurpBytes(w.conn.Rd(), pgwirebase.GetMessageTooBigSize(err)); err != nil {
+					return err
+				}
+				if retErr == nil {
+					retErr = pgwirebase.NewProtocolViolationErrorf("message too big")
+				}
+				continue
+			}
+			return err
+		}
+		switch typ {
+		case pgwirebase.ClientMsgCopyData:
+			if !pgoutput.IsStandbyStatusUpdate(readBuf.Msg) {
+				// Hot standby feedback messages are not applicable.
+				continue
+			}
+			u, err := pgoutput.ParseStandbyStatusUpdate(readBuf.Msg)
+			if err != nil {
+				if retErr == nil {
+					retErr = pgwirebase.NewProtocolViolationErrorf("%v", err)
+				}
+				continue
+			}
+			// Only the latest position matters, so an update that was not
+			// processed yet is replaced.
+			select {
+			case <-statusUpdates:
+			default:
+			}
+			statusUpdates <- u
+		case pgwirebase.ClientMsgCopyDone:
+			return retErr
+		case pgwirebase.ClientMsgCopyFail:
+			return pgerror.Newf(pgcode.QueryCanceled,
+				"replication stream failed: %s", string(readBuf.Msg))
+		case pgwirebase.ClientMsgFlush, pgwirebase.ClientMsgSync:
+			// Like in the Copy-in subprotocol, Flush and Sync are ignored.
+		default:
+			if retErr == nil {
+				retErr = pgwirebase.NewUnrecognizedMsgTypeErr(typ)
+			}
+		}
+	}
+}
+
+// flush sends the pending changes of all the microseconds before the given
+// frontier of the rangefeed, one transaction per microsecond.
+func (w *walSender) flush(ctx context.Context, frontier hlc.Timestamp) error {
+	frontierMicros := frontier.WallTime / int64(time.Microsecond)
+	if frontierMicros-1 <= w.flushedMicros {
+		return nil
+	}
+	micros := func(ev *walSenderEvent) int64 {
+		return ev.value.Timestamp.WallTime / int64(time.Microsecond)
+	}
+	sort.SliceStable(w.pending, func(i, j int) bool {
+		if c := w.pending[i].value.Timestamp.Compare(w.pending[j].value.Timestamp); c != 0 {
+			return c < 0
+		}
+		return w.pending[i].key.Compare(w.pending[j].key) < 0
+	})
+	i := 0
+	for i < len(w.pending) && micros(&w.pending[i]) < frontierMicros {
+		j := i + 1
+		for j < len(w.pending) && micros(&w.pending[j]) == micros(&w.pending[i]) {
+			j++
+		}
+		// Changes at or before the last sent microsecond are redeliveries of the
+		// rangefeed.
+		if m := micros(&w.pending[i]); m > w.flushedMicros {
+			if err := w.sendTransaction(ctx, m, w.pending[i:j]); err != nil {
+				return err
+			}
+		}
+		i = j
+	}
+	w.pending = append(w.pending[:0], w.pending[i:]...)
+	w.flushedMicros = frontierMicros - 1
+	return nil
+}
+
+// sendTransaction sends the changes committed in the given microsecond.
+func (w *walSender) sendTransaction(
+	ctx context.Context, micros int64, events []walSenderEvent,
+) error {
+	commitTS := hlc.Timestamp{WallTime: micros * int64(time.Microsecond)}
+	commitLSN := lsnutil.HLCToLSN(commitTS)
+	if commitLSN < w.startLSN {
+		return nil
+	}
+	w.msgs = w.msgs[:0]
+	for i := range events {
+		ev := &events[i]
+		if i > 0 && ev.key.Equal(events[i-1].key) &&
+			ev.value.Timestamp == events[i-1].value.Timestamp {
+			continue
+		}
+		if err := w.appendChange(ctx, ev); err != nil {
+			return err
+		}
+	}
+	// Like pgoutput, don't send transactions without published changes.
+	if len(w.msgs) == 0 {
+		return nil
+	}
+	commitTime := timeutil.Unix(0, commitTS.WallTime)
+	w.xid++
+	if err := w.sendXLogData(ctx, commitLSN, pgoutput.AppendBegin(nil, commitLSN, commitTime, w.xid)); err != nil {
+		return err
+	}
+	for _, msg := range w.msgs {
+		if err := w.sendXLogData(ctx, commitLSN, msg); err != nil {
+			return err
+		}
+	}
+	return w.sendXLogData(
+		ctx, commitLSN, pgoutput.AppendCommit(nil, commitLSN, lsnutil.EndLSN(commitLSN), commitTime),
+	)
+}
+
+// appendChange decodes a change and appends the corresponding messages to
+// w.msgs.
+func (w *walSender) appendChange(ctx context.Context, ev *walSenderEvent) error {
+	_, tableID, err := w.execCfg.Codec.DecodeTablePrefix(ev.key)
+	if err != nil {
+		return err
+	}
+	t := w.tables[descpb.ID(tableID)]
+	if t == nil {
+		return nil
+	}
+	if err := w.maybeUpdateTable(ctx, t, ev.value.Timestamp); err != nil {
+		return err
+	}
+	t.provider.KVs = append(t.provider.KVs[:0], roachpb.KeyValue{Key: ev.key, Value: ev.value})
+	if err := t.fetcher.ConsumeKVProvider(ctx, &t.provider); err != nil {
+		return err
+	}
+	datums, err := t.fetcher.NextRowDecoded(ctx)
+	if err != nil {
+		return err
+	}
+	if datums == nil {
+		return errors.AssertionFailedf("no row decoded from %s", ev.key)
+	}
+	deleted := t.fetcher.RowIsDeleted()
+	var op byte
+	switch {
+	case deleted:
+		if !t.publishDelete {
+			return nil
+		}
+		op = 'D'
+	case ev.prevValue.IsPresent():
+		if !t.publishUpdate {
+			return nil
+		}
+		op = 'U'
+	default:
+		if !t.publishInsert {
+			return nil
+		}
+		op = 'I'
+	}
+
+	vals := make([]pgoutput.Value, len(datums))
+	for i, d := range datums {
+		if d == tree.DNull {
+			vals[i].Null = true
+			continue
+		}
+		w.fmtCtx.Buffer.Reset()
+		w.fmtCtx.FormatNode(d)
+		vals[i].Text = append([]byte(nil), w.fmtCtx.Buffer.Bytes()...)
+	}
+	w.fmtCtx.Buffer.Reset()
+
+	relID := oid.Oid(t.id)
+	if !t.relSent {
+		w.msgs = append(w.msgs, pgoutput.AppendRelation(nil, relID, t.schemaName, t.desc.GetName(), t.cols))
+		t.relSent = true
+	}
+	switch op {
+	case 'I':
+		w.msgs = append(w.msgs, pgoutput.AppendInsert(nil, relID, vals))
+	case 'U':
+		w.msgs = append(w.msgs, pgoutput.AppendUpdate(nil, relID, vals))
+	case 'D':
+		w.msgs = append(w.msgs, pgoutput.AppendDelete(nil, relID, t.cols, vals))
+	}
+	return nil
+}
+
+// maybeUpdateTable initializes the decoding state of the table for the
+// version of the table at the given timestamp, if it differs from the
+// current one.
+func (w *walSender) maybeUpdateTable(
+	ctx context.Context, t *walSenderTable, ts hlc.Timestamp,
+) error {
+	leased, err := w.execCfg.LeaseManager.Acquire(ctx, ts, t.id)
+	if err != nil {
+		return err
+	}
+	version := leased.Underlying().GetVersion()
+	leased.Release(ctx)
+	if t.desc != nil && t.desc.GetVersion() == version {
+		return nil
+	}
+
+	var desc catalog.TableDescriptor
+	if err := w.execCfg.InternalDB.DescsTxn(ctx, func(ctx context.Context, txn descs.Txn) (err error) {
+		if err := txn.KV().SetFixedTimestamp(ctx, ts); err != nil {
+			return err
+		}
+		desc, err = txn.Descriptors().ByID(txn.KV()).WithoutNonPublic().Get().Table(ctx, t.id)
+		return err
+	}); err != nil {
+		return err
+	}
+	if desc.NumFamilies() > 1 {
+		return unimplemented.NewWithIssueDetailf(0, "logical replication column families",
+			"cannot publish table %q with multiple column families", desc.GetName())
+	}
+
+	keyCols := desc.GetPrimaryIndex().CollectKeyColumnIDs()
+	var colIDs descpb.ColumnIDs
+	t.cols = t.cols[:0]
+	for _, col := range desc.PublicColumns() {
+		// Virtual columns are not stored, and inaccessible columns are
+		// internal to the table.
+		if col.IsVirtual() || col.IsInaccessible() {
+			continue
+		}
+		colIDs = append(colIDs, col.GetID())
+		t.cols = append(t.cols, pgoutput.Column{
+			Name:    col.GetName(),
+			TypeOID: col.GetType().Oid(),
+			TypeMod: col.GetType().TypeModifier(),
+			Key:     keyCols.Contains(col.GetID()),
+		})
+	}
+	var spec fetchpb.IndexFetchSpec
+	if err := rowenc.InitIndexFetchSpec(
+		&spec, w.execCfg.Codec, desc, desc.GetPrimaryIndex(), colIDs,
+	); err != nil {
+		return err
+	}
+	if err := t.fetcher.Init(ctx, row.FetcherInitArgs{
+		WillUseKVProvider: true,
+		Alloc:             &t.alloc,
+		Spec:              &spec,
+	}); err != nil {
+		return err
+	}
+	t.desc = desc
+	t.relSent = false
+	return nil
+}
+
+// sendXLogData sends a pgoutput message wrapped in a XLogData message.
+func (w *walSender) sendXLogData(ctx context.Context, walStart lsn.LSN, msg []byte) error {
+	w.buf = pgoutput.AppendXLogData(w.buf[:0], walStart, walStart, timeutil.Now())
+	w.buf = append(w.buf, msg...)
+	return w.conn.SendCopyData(ctx, w.buf)
+}
+
+// sendKeepalive sends a Primary keepalive message with the position up to
+// which all the changes have been sent.
+func (w *walSender) sendKeepalive(ctx context.Context, replyRequested bool) error {
+	walEnd := lsnutil.EndLSN(lsnutil.HLCToLSN(hlc.Timestamp{
+		WallTime: w.flushedMicros * int64(time.Microsecond),
+	}))
+	w.buf = pgoutput.AppendPrimaryKeepalive(w.buf[:0], walEnd, timeutil.Now(), replyRequested)
+	return w.conn.SendCopyData(ctx, w.buf)
+}
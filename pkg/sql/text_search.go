@@ -0,0 +1,1036 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemadesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
+	"github.com/cockroachdb/errors"
+)
+
+// Text search dictionaries and configurations are stored in the descriptor of
+// the schema that contains them. The built-in ones live in pg_catalog and are
+// provided by the tsearch package.
+
+type createTextSearchDictionaryNode struct {
+	n      *tree.CreateTextSearchDictionary
+	scDesc *schemadesc.Mutable
+	dict   descpb.SchemaDescriptor_TextSearchDictionary
+}
+
+// CreateTextSearchDictionary creates a text search dictionary.
+// Privileges: CREATE on the schema.
+//
+//	notes: postgres requires CREATE on the schema.
+func (p *planner) CreateTextSearchDictionary(
+	ctx context.Context, n *tree.CreateTextSearchDictionary,
+) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE TEXT SEARCH DICTIONARY",
+	); err != nil {
+		return nil, err
+	}
+	if err := p.checkTextSearchObjectsSupported(ctx); err != nil {
+		return nil, err
+	}
+
+	scDesc, err := p.getSchemaForCreateTextSearch(ctx, n.Name)
+	if err != nil {
+		return nil, err
+	}
+	name := n.Name.Object()
+	if scDesc.GetTextSearchDictionary(name) != nil {
+		return nil, pgerror.Newf(pgcode.DuplicateObject,
+			"text search dictionary %q already exists", name)
+	}
+
+	var template string
+	var options []tsearch.DictionaryOption
+	for _, option := range n.Options {
+		key := string(option.Key)
+		value, err := p.textSearchParamValue(ctx, key, option.Value)
+		if err != nil {
+			return nil, err
+		}
+		if key == "template" {
+			template = value
+			continue
+		}
+		options = append(options, tsearch.DictionaryOption{Name: key, Value: value})
+	}
+	if template == "" {
+		return nil, pgerror.New(pgcode.InvalidObjectDefinition,
+			"text search template is required")
+	}
+	d, err := tsearch.NewDictionary(name, template, options)
+	if err != nil {
+		return nil, err
+	}
+
+	dict := descpb.SchemaDescriptor_TextSearchDictionary{
+		Name:       name,
+		OwnerProto: p.User().EncodeProto(),
+		Template:   d.Template.String(),
+	}
+	for _, opt := range d.Options() {
+		dict.Options = append(dict.Options, descpb.SchemaDescriptor_TextSearchDictionary_Option{
+			Name:  opt.Name,
+			Value: opt.Value,
+		})
+	}
+	return &createTextSearchDictionaryNode{n: n, scDesc: scDesc, dict: dict}, nil
+}
+
+func (n *createTextSearchDictionaryNode) startExec(params runParams) error {
+	n.scDesc.TextSearchDictionaries = append(n.scDesc.TextSearchDictionaries, n.dict)
+	return params.p.writeSchemaDescChange(
+		params.ctx,
+		n.scDesc,
+		tree.AsStringWithFQNames(n.n, params.Ann()),
+	)
+}
+
+func (n *createTextSearchDictionaryNode) Next(runParams) (bool, error) { return false, nil }
+func (n *createTextSearchDictionaryNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *createTextSearchDictionaryNode) Close(context.Context)        {}
+
+type createTextSearchConfigNode struct {
+	n      *tree.CreateTextSearchConfig
+	scDesc *schemadesc.Mutable
+	config descpb.SchemaDescriptor_TextSearchConfiguration
+}
+
+// CreateTextSearchConfig creates a text search configuration, either empty
+// with the default parser or as a copy of an existing configuration.
+// Privileges: CREATE on the schema.
+//
+//	notes: postgres requires CREATE on the schema.
+func (p *planner) CreateTextSearchConfig(
+	ctx context.Context, n *tree.CreateTextSearchConfig,
+) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE TEXT SEARCH CONFIGURATION",
+	); err != nil {
+		return nil, err
+	}
+	if err := p.checkTextSearchObjectsSupported(ctx); err != nil {
+		return nil, err
+	}
+
+	scDesc, err := p.getSchemaForCreateTextSearch(ctx, n.Name)
+	if err != nil {
+		return nil, err
+	}
+	name := n.Name.Object()
+	if scDesc.GetTextSearchConfiguration(name) != nil {
+		return nil, pgerror.Newf(pgcode.DuplicateObject,
+			"text search configuration %q already exists", name)
+	}
+
+	var parserName, source string
+	for _, option := range n.Options {
+		key := string(option.Key)
+		switch key {
+		case "parser":
+			if _, ok := option.Value.(tree.DefaultVal); ok {
+				parserName = "default"
+				continue
+			}
+			value, err := p.textSearchParamValue(ctx, key, option.Value)
+			if err != nil {
+				return nil, err
+			}
+			if tsearch.GetConfigKey(value) != "default" {
+				return nil, pgerror.Newf(pgcode.UndefinedObject,
+					"text search parser %q does not exist", value)
+			}
+			parserName = value
+		case "copy":
+			value, err := p.textSearchParamValue(ctx, key, option.Value)
+			if err != nil {
+				return nil, err
+			}
+			source = value
+		default:
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+				"text search configuration parameter %q not recognized", key)
+		}
+	}
+	if parserName != "" && source != "" {
+		return nil, pgerror.New(pgcode.Syntax, "cannot specify both PARSER and COPY options")
+	}
+	if parserName == "" && source == "" {
+		return nil, pgerror.New(pgcode.InvalidObjectDefinition, "text search parser is required")
+	}
+
+	config := descpb.SchemaDescriptor_TextSearchConfiguration{
+		Name:       name,
+		OwnerProto: p.User().EncodeProto(),
+	}
+	if source != "" {
+		un, err := parser.ParseTableName(source)
+		if err != nil {
+			return nil, err
+		}
+		srcSchemaID, err := p.resolveTextSearchObject(ctx, un, false /* dictionary */)
+		if err != nil {
+			return nil, err
+		}
+		if srcSchemaID == descpb.InvalidID {
+			if un.HasExplicitSchema() && un.Schema() != catconstants.PgCatalogName {
+				return nil, pgerror.Newf(pgcode.UndefinedObject,
+					"text search configuration %q does not exist", source)
+			}
+			builtin, err := tsearch.GetBuiltinConfig(un.Object())
+			if err != nil {
+				return nil, err
+			}
+			for _, typ := range tsearch.TokenTypes() {
+				dicts := builtin.Mappings[typ]
+				if len(dicts) == 0 {
+					continue
+				}
+				m := descpb.SchemaDescriptor_TextSearchConfiguration_Mapping{TokenType: typ.String()}
+				for _, d := range dicts {
+					m.Dictionaries = append(m.Dictionaries, descpb.SchemaDescriptor_TextSearchDictionaryRef{Name: d.Name})
+				}
+				config.Mappings = append(config.Mappings, m)
+			}
+		} else {
+			srcDesc, err := p.Descriptors().ByIDWithLeased(p.txn).WithoutNonPublic().Get().Schema(ctx, srcSchemaID)
+			if err != nil {
+				return nil, err
+			}
+			for _, m := range srcDesc.GetTextSearchConfiguration(un.Object()).Mappings {
+				config.Mappings = append(config.Mappings, descpb.SchemaDescriptor_TextSearchConfiguration_Mapping{
+					TokenType:    m.TokenType,
+					Dictionaries: append([]descpb.SchemaDescriptor_TextSearchDictionaryRef(nil), m.Dictionaries...),
+				})
+			}
+		}
+	}
+	return &createTextSearchConfigNode{n: n, scDesc: scDesc, config: config}, nil
+}
+
+func (n *createTextSearchConfigNode) startExec(params runParams) error {
+	n.scDesc.TextSearchConfigurations = append(n.scDesc.TextSearchConfigurations, n.config)
+	return params.p.writeSchemaDescChange(
+		params.ctx,
+		n.scDesc,
+		tree.AsStringWithFQNames(n.n, params.Ann()),
+	)
+}
+
+func (n *createTextSearchConfigNode) Next(runParams) (bool, error) { return false, nil }
+func (n *createTextSearchConfigNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *createTextSearchConfigNode) Close(context.Context)        {}
+
+type alterTextSearchConfigNode struct {
+	n      *tree.AlterTextSearchConfig
+	scDesc *schemadesc.Mutable
+}
+
+// AlterTextSearchConfig changes the dictionaries a text search configuration
+// uses for some token types.
+// Privileges: ownership of the configuration.
+//
+//	notes: postgres requires ownership of the configuration.
+func (p *planner) AlterTextSearchConfig(
+	ctx context.Context, n *tree.AlterTextSearchConfig,
+) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"ALTER TEXT SEARCH CONFIGURATION",
+	); err != nil {
+		return nil, err
+	}
+
+	scDesc, err := p.getSchemaForTextSearchObject(ctx, n.Name, false /* dictionary */)
+	if err != nil {
+		return nil, err
+	}
+	name := n.Name.Object()
+	if scDesc == nil {
+		return nil, pgerror.Newf(pgcode.UndefinedObject,
+			"text search configuration %q does not exist", name)
+	}
+	config := scDesc.GetTextSearchConfiguration(name)
+	if err := p.checkTextSearchOwnership(
+		ctx, config.OwnerProto.Decode(), "text search configuration", name,
+	); err != nil {
+		return nil, err
+	}
+
+	var dicts []descpb.SchemaDescriptor_TextSearchDictionaryRef
+	for _, un := range n.Dictionaries {
+		schemaID, err := p.resolveTextSearchObject(ctx, un, true /* dictionary */)
+		if err != nil {
+			return nil, err
+		}
+		if schemaID == descpb.InvalidID {
+			if _, ok := tsearch.GetBuiltinDictionary(un.Object()); !ok {
+				return nil, pgerror.Newf(pgcode.UndefinedObject,
+					"text search dictionary %q does not exist", un.Object())
+			}
+		}
+		dicts = append(dicts, descpb.SchemaDescriptor_TextSearchDictionaryRef{
+			SchemaID: schemaID,
+			Name:     un.Object(),
+		})
+	}
+
+	for _, tokenType := range n.TokenTypes {
+		typ, err := tsearch.TokenTypeFromString(string(tokenType))
+		if err != nil {
+			return nil, err
+		}
+		idx := -1
+		for i := range config.Mappings {
+			if config.Mappings[i].TokenType == typ.String() {
+				idx = i
+				break
+			}
+		}
+		switch n.Cmd {
+		case tree.AlterTextSearchAddMapping:
+			if idx != -1 {
+				return nil, pgerror.Newf(pgcode.DuplicateObject,
+					"mapping for token type %q already exists", typ)
+			}
+			config.Mappings = append(config.Mappings, descpb.SchemaDescriptor_TextSearchConfiguration_Mapping{
+				TokenType:    typ.String(),
+				Dictionaries: dicts,
+			})
+		case tree.AlterTextSearchAlterMapping:
+			if idx == -1 {
+				return nil, pgerror.Newf(pgcode.UndefinedObject,
+					"mapping for token type %q does not exist", typ)
+			}
+			config.Mappings[idx].Dictionaries = dicts
+		case tree.AlterTextSearchDropMapping:
+			if idx == -1 {
+				if n.IfExists {
+					p.BufferClientNotice(ctx, pgnotice.Newf(
+						"mapping for token type %q does not exist, skipping", typ))
+					continue
+				}
+				return nil, pgerror.Newf(pgcode.UndefinedObject,
+					"mapping for token type %q does not exist", typ)
+			}
+			config.Mappings = append(config.Mappings[:idx], config.Mappings[idx+1:]...)
+		}
+	}
+	sortTextSearchMappings(config)
+	return &alterTextSearchConfigNode{n: n, scDesc: scDesc}, nil
+}
+
+func (n *alterTextSearchConfigNode) startExec(params runParams) error {
+	return params.p.writeSchemaDescChange(
+		params.ctx,
+		n.scDesc,
+		tree.AsStringWithFQNames(n.n, params.Ann()),
+	)
+}
+
+func (n *alterTextSearchConfigNode) Next(runParams) (bool, error) { return false, nil }
+func (n *alterTextSearchConfigNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *alterTextSearchConfigNode) Close(context.Context)        {}
+
+type dropTextSearchNode struct {
+	n *tree.DropTextSearch
+	// scDescs are the schemas whose descriptors are modified, keyed by ID.
+	scDescs map[descpb.ID]*schemadesc.Mutable
+}
+
+// DropTextSearch drops text search configurations or dictionaries.
+// Privileges: ownership of the objects.
+//
+//	notes: postgres requires ownership of the objects.
+func (p *planner) DropTextSearch(ctx context.Context, n *tree.DropTextSearch) (planNode, error) {
+	kind := "text search configuration"
+	if n.Dictionary {
+		kind = "text search dictionary"
+	}
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"DROP "+strings.ToUpper(kind),
+	); err != nil {
+		return nil, err
+	}
+
+	scDescs := make(map[descpb.ID]*schemadesc.Mutable)
+	for _, un := range n.Names {
+		scDesc, err := p.getSchemaForTextSearchObject(ctx, un, n.Dictionary)
+		if err != nil {
+			return nil, err
+		}
+		name := un.Object()
+		if scDesc == nil {
+			if n.IfExists {
+				p.BufferClientNotice(ctx, pgnotice.Newf(
+					"%s %q does not exist, skipping", kind, name))
+				continue
+			}
+			return nil, pgerror.Newf(pgcode.UndefinedObject, "%s %q does not exist", kind, name)
+		}
+		// Reuse the descriptor if an earlier name referred to the same schema,
+		// so that all the changes are made to the same mutable descriptor.
+		if prev, ok := scDescs[scDesc.GetID()]; ok {
+			scDesc = prev
+		}
+		var owner username.SQLUsername
+		if n.Dictionary {
+			owner = scDesc.GetTextSearchDictionary(name).OwnerProto.Decode()
+		} else {
+			owner = scDesc.GetTextSearchConfiguration(name).OwnerProto.Decode()
+		}
+		if err := p.checkTextSearchOwnership(ctx, owner, kind, name); err != nil {
+			return nil, err
+		}
+		// Like dropping a type, dropping an object that tables or routines
+		// depend on is not supported even with CASCADE.
+		if err := p.checkTextSearchObjectNotInUse(ctx, scDesc, name, n.Dictionary, kind); err != nil {
+			return nil, err
+		}
+		if !n.Dictionary {
+			scDesc.RemoveTextSearchConfiguration(name)
+			scDescs[scDesc.GetID()] = scDesc
+			continue
+		}
+
+		// Configurations of the database that use the dictionary depend on it.
+		ref := descpb.SchemaDescriptor_TextSearchDictionaryRef{SchemaID: scDesc.GetID(), Name: name}
+		if err := p.removeTextSearchDictionaryRefs(
+			ctx, scDesc, ref, n.DropBehavior == tree.DropCascade, scDescs,
+		); err != nil {
+			return nil, err
+		}
+		scDesc.RemoveTextSearchDictionary(name)
+		scDescs[scDesc.GetID()] = scDesc
+	}
+	return &dropTextSearchNode{n: n, scDescs: scDescs}, nil
+}
+
+// removeTextSearchDictionaryRefs removes the references to the given
+// dictionary from the mappings of the configurations of the database, or
+// returns an error if there are any and cascade is false. The modified schema
+// descriptors are added to scDescs.
+func (p *planner) removeTextSearchDictionaryRefs(
+	ctx context.Context,
+	dictSchema *schemadesc.Mutable,
+	ref descpb.SchemaDescriptor_TextSearchDictionaryRef,
+	cascade bool,
+	scDescs map[descpb.ID]*schemadesc.Mutable,
+) error {
+	db, err := p.Descriptors().ByID(p.txn).Get().Database(ctx, dictSchema.GetParentID())
+	if err != nil {
+		return err
+	}
+	schemas, err := p.Descriptors().GetSchemasForDatabase(ctx, p.txn, db)
+	if err != nil {
+		return err
+	}
+	schemaIDs := make([]descpb.ID, 0, len(schemas))
+	for id := range schemas {
+		schemaIDs = append(schemaIDs, id)
+	}
+	sort.Slice(schemaIDs, func(i, j int) bool { return schemaIDs[i] < schemaIDs[j] })
+	for _, id := range schemaIDs {
+		var scDesc *schemadesc.Mutable
+		if id == dictSchema.GetID() {
+			scDesc = dictSchema
+		} else if prev, ok := scDescs[id]; ok {
+			scDesc = prev
+		} else {
+			sc, err := p.Descriptors().ByID(p.txn).Get().Schema(ctx, id)
+			if err != nil {
+				return err
+			}
+			if sc.SchemaKind() != catalog.SchemaUserDefined && sc.SchemaKind() != catalog.SchemaPublic {
+				continue
+			}
+			if !schemaUsesTextSearchDictionary(sc, ref) {
+				continue
+			}
+			if scDesc, err = p.Descriptors().MutableByID(p.txn).Schema(ctx, id); err != nil {
+				return err
+			}
+		}
+		for i := range scDesc.TextSearchConfigurations {
+			config := &scDesc.TextSearchConfigurations[i]
+			mappings := config.Mappings[:0]
+			for _, m := range config.Mappings {
+				dicts := m.Dictionaries[:0]
+				for _, r := range m.Dictionaries {
+					if r == ref {
+						if !cascade {
+							return pgerror.Newf(pgcode.DependentObjectsStillExist,
+								"cannot drop text search dictionary %q because text search configuration %q depends on it",
+								ref.Name, config.Name)
+						}
+						continue
+					}
+					dicts = append(dicts, r)
+				}
+				// Like postgres, a mapping left without dictionaries is removed.
+				if m.Dictionaries = dicts; len(dicts) > 0 {
+					mappings = append(mappings, m)
+				}
+			}
+			config.Mappings = mappings
+		}
+		scDescs[id] = scDesc
+	}
+	return nil
+}
+
+// schemaUsesTextSearchDictionary returns whether a configuration of the schema
+// uses the given dictionary.
+func schemaUsesTextSearchDictionary(
+	sc catalog.SchemaDescriptor, ref descpb.SchemaDescriptor_TextSearchDictionaryRef,
+) bool {
+	for _, c := range sc.SchemaDesc().TextSearchConfigurations {
+		for _, m := range c.Mappings {
+			for _, r := range m.Dictionaries {
+				if r == ref {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// checkTextSearchObjectNotInUse returns an error if a table, view or routine
+// of the database of the given schema refers to the text search configuration
+// or dictionary of the given name in it. The references are recorded on the
+// columns and check constraints of tables and on views and routines when they
+// are defined. A reference without a schema, which is recorded for a
+// non-constant argument of a text search builtin, may refer to any object of
+// its kind.
+func (p *planner) checkTextSearchObjectNotInUse(
+	ctx context.Context, sc catalog.SchemaDescriptor, name string, dictionary bool, kind string,
+) error {
+	db, err := p.Descriptors().ByID(p.txn).Get().Database(ctx, sc.GetParentID())
+	if err != nil {
+		return err
+	}
+	all, err := p.Descriptors().GetAllInDatabase(ctx, p.txn, db)
+	if err != nil {
+		return err
+	}
+	return all.ForEachDescriptor(func(desc catalog.Descriptor) error {
+		if desc.Dropped() {
+			return nil
+		}
+		var depKind string
+		var refs []descpb.TextSearchObjectRef
+		switch d := desc.(type) {
+		case catalog.TableDescriptor:
+			depKind = "table"
+			if d.IsView() {
+				depKind = "view"
+			}
+			refs = append(refs, d.TableDesc().DependsOnTextSearchObjects...)
+			for _, col := range d.AllColumns() {
+				refs = append(refs, col.ColumnDesc().UsesTextSearchObjects...)
+			}
+			for _, ck := range d.CheckConstraints() {
+				refs = append(refs, ck.CheckDesc().UsesTextSearchObjects...)
+			}
+		case catalog.FunctionDescriptor:
+			depKind = "function"
+			if d.IsProcedure() {
+				depKind = "procedure"
+			}
+			refs = d.FuncDesc().DependsOnTextSearchObjects
+		default:
+			return nil
+		}
+		for _, ref := range refs {
+			if ref.Dictionary != dictionary {
+				continue
+			}
+			if ref.SchemaID == sc.GetID() && ref.Name == name {
+				return pgerror.Newf(pgcode.DependentObjectsStillExist,
+					"cannot drop %s %q because %s %q depends on it", kind, name, depKind, desc.GetName())
+			}
+			if ref.SchemaID == descpb.InvalidID {
+				return errors.WithHintf(
+					pgerror.Newf(pgcode.DependentObjectsStillExist,
+						"cannot drop %s %q because %s %q may depend on it", kind, name, depKind, desc.GetName()),
+					"%s %q passes a %s that is not a constant to a text search function",
+					depKind, desc.GetName(), kind,
+				)
+			}
+		}
+		return nil
+	})
+}
+
+// resolveTextSearchDeps returns references to the user-defined text search
+// configurations and dictionaries that a view or routine passes to text search
+// builtins.
+func (p *planner) resolveTextSearchDeps(
+	ctx context.Context, deps opt.SchemaTextSearchDeps,
+) ([]descpb.TextSearchObjectRef, error) {
+	var refs []descpb.TextSearchObjectRef
+	for _, dep := range deps {
+		ref, ok, err := p.resolveTextSearchObjectRef(ctx, dep.Name, dep.Dictionary)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			refs = addTextSearchObjectRef(refs, ref)
+		}
+	}
+	return refs, nil
+}
+
+// textSearchObjectRefsInExpr returns references to the user-defined text
+// search configurations and dictionaries that the given serialized expression
+// of a table passes to text search builtins.
+func (p *planner) textSearchObjectRefsInExpr(
+	ctx context.Context, exprStr string,
+) ([]descpb.TextSearchObjectRef, error) {
+	expr, err := parser.ParseExpr(exprStr)
+	if err != nil {
+		return nil, err
+	}
+	var refs []descpb.TextSearchObjectRef
+	if err := schemaexpr.ForEachTextSearchObjectArg(expr, func(arg tree.Expr, dictionary bool) error {
+		var name string
+		// Arguments that refer to columns fail to type check, and are treated
+		// like any other argument that isn't a constant.
+		if typedArg, err := tree.TypeCheck(ctx, arg, p.SemaCtx(), types.String); err == nil &&
+			eval.IsConst(p.EvalContext(), typedArg) {
+			d, err := eval.Expr(ctx, p.EvalContext(), typedArg)
+			if err != nil {
+				return err
+			}
+			if s, ok := tree.AsDString(d); ok {
+				name = string(s)
+			}
+		}
+		ref, ok, err := p.resolveTextSearchObjectRef(ctx, name, dictionary)
+		if err != nil {
+			return err
+		}
+		if ok {
+			refs = addTextSearchObjectRef(refs, ref)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return refs, nil
+}
+
+// updateTextSearchRefsForColumn records the user-defined text search objects
+// used by the expressions of the given column.
+func (p *planner) updateTextSearchRefsForColumn(
+	ctx context.Context, col *descpb.ColumnDescriptor,
+) error {
+	col.UsesTextSearchObjects = nil
+	for _, expr := range []*string{col.DefaultExpr, col.OnUpdateExpr, col.ComputeExpr} {
+		if expr == nil {
+			continue
+		}
+		refs, err := p.textSearchObjectRefsInExpr(ctx, *expr)
+		if err != nil {
+			return err
+		}
+		for _, ref := range refs {
+			col.UsesTextSearchObjects = addTextSearchObjectRef(col.UsesTextSearchObjects, ref)
+		}
+	}
+	return nil
+}
+
+// updateTextSearchRefsForCheck records the user-defined text search objects
+// used by the expression of the given check constraint.
+func (p *planner) updateTextSearchRefsForCheck(
+	ctx context.Context, ck *descpb.TableDescriptor_CheckConstraint,
+) (err error) {
+	ck.UsesTextSearchObjects, err = p.textSearchObjectRefsInExpr(ctx, ck.Expr)
+	return err
+}
+
+// resolveTextSearchObjectRef returns a reference to the user-defined text
+// search object that the given name refers to with the current search path, or
+// false if it refers to a built-in object or to none. An empty name stands for
+// an argument that isn't a constant.
+func (p *planner) resolveTextSearchObjectRef(
+	ctx context.Context, name string, dictionary bool,
+) (descpb.TextSearchObjectRef, bool, error) {
+	if name == "" {
+		return descpb.TextSearchObjectRef{Dictionary: dictionary}, true, nil
+	}
+	un, err := parser.ParseTableName(name)
+	if err != nil {
+		// The builtins fail for names that can't be parsed.
+		return descpb.TextSearchObjectRef{}, false, nil //nolint:returnerrcheck
+	}
+	schemaID, err := p.resolveTextSearchObject(ctx, un, dictionary)
+	if err != nil || schemaID == descpb.InvalidID {
+		return descpb.TextSearchObjectRef{}, false, err
+	}
+	return descpb.TextSearchObjectRef{
+		SchemaID:   schemaID,
+		Name:       un.Object(),
+		Dictionary: dictionary,
+	}, true, nil
+}
+
+// addTextSearchObjectRef adds ref to refs unless it is already there.
+func addTextSearchObjectRef(
+	refs []descpb.TextSearchObjectRef, ref descpb.TextSearchObjectRef,
+) []descpb.TextSearchObjectRef {
+	for _, r := range refs {
+		if r == ref {
+			return refs
+		}
+	}
+	return append(refs, ref)
+}
+
+func (n *dropTextSearchNode) startExec(params runParams) error {
+	ids := make([]descpb.ID, 0, len(n.scDescs))
+	for id := range n.scDescs {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	for _, id := range ids {
+		if err := params.p.writeSchemaDescChange(
+			params.ctx,
+			n.scDescs[id],
+			tree.AsStringWithFQNames(n.n, params.Ann()),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *dropTextSearchNode) Next(runParams) (bool, error) { return false, nil }
+func (n *dropTextSearchNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *dropTextSearchNode) Close(context.Context)        {}
+
+// checkTextSearchObjectsSupported returns an error if user-defined text search
+// objects, which are stored in new fields of the schema descriptor, can't be
+// created yet because some nodes might not know about them.
+func (p *planner) checkTextSearchObjectsSupported(ctx context.Context) error {
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V24_1) {
+		return pgerror.New(pgcode.FeatureNotSupported,
+			"user-defined text search dictionaries and configurations are not supported until version 24.1")
+	}
+	return nil
+}
+
+// getSchemaForCreateTextSearch returns the mutable descriptor of the schema in
+// which a text search object of the given name is created, after checking that
+// the user can create objects in it.
+func (p *planner) getSchemaForCreateTextSearch(
+	ctx context.Context, un *tree.UnresolvedObjectName,
+) (*schemadesc.Mutable, error) {
+	db, sc, _, err := p.ResolveTargetObject(ctx, un)
+	if err != nil {
+		return nil, err
+	}
+	if db.GetID() == keys.SystemDatabaseID {
+		return nil, pgerror.New(pgcode.InsufficientPrivilege,
+			"cannot create text search objects in the system database")
+	}
+	switch sc.SchemaKind() {
+	case catalog.SchemaTemporary:
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			"cannot create text search objects in a temporary schema")
+	case catalog.SchemaVirtual:
+		return nil, pgerror.Newf(pgcode.InsufficientPrivilege,
+			"schema cannot be modified: %q", sc.GetName())
+	}
+	if err := p.canCreateOnSchema(
+		ctx, sc.GetID(), db.GetID(), p.User(), skipCheckPublicSchema,
+	); err != nil {
+		return nil, err
+	}
+	return p.Descriptors().MutableByID(p.txn).Schema(ctx, sc.GetID())
+}
+
+// getSchemaForTextSearchObject returns the mutable descriptor of the schema
+// containing the existing text search configuration or dictionary of the
+// given name, or nil if there is none. Built-in objects can't be modified.
+func (p *planner) getSchemaForTextSearchObject(
+	ctx context.Context, un *tree.UnresolvedObjectName, dictionary bool,
+) (*schemadesc.Mutable, error) {
+	schemaID, err := p.resolveTextSearchObject(ctx, un, dictionary)
+	if err != nil {
+		return nil, err
+	}
+	if schemaID == descpb.InvalidID {
+		var builtin bool
+		if dictionary {
+			_, builtin = tsearch.GetBuiltinDictionary(un.Object())
+		} else {
+			builtin = tsearch.ValidConfig(un.Object()) == nil
+		}
+		if builtin && (!un.HasExplicitSchema() || un.Schema() == catconstants.PgCatalogName) {
+			return nil, pgerror.Newf(pgcode.InsufficientPrivilege,
+				"%s is a built-in text search object and cannot be modified", un.Object())
+		}
+		return nil, nil
+	}
+	scDesc, err := p.Descriptors().MutableByID(p.txn).Schema(ctx, schemaID)
+	if err != nil {
+		return nil, err
+	}
+	// The object may already have been removed by an earlier change of the
+	// transaction.
+	if dictionary && scDesc.GetTextSearchDictionary(un.Object()) == nil ||
+		!dictionary && scDesc.GetTextSearchConfiguration(un.Object()) == nil {
+		return nil, nil
+	}
+	return scDesc, nil
+}
+
+// checkTextSearchOwnership checks that the current user owns the text search
+// object with the given owner.
+func (p *planner) checkTextSearchOwnership(
+	ctx context.Context, owner username.SQLUsername, kind string, name string,
+) error {
+	if owner == p.User() {
+		return nil
+	}
+	if hasAdmin, err := p.HasAdminRole(ctx); err != nil {
+		return err
+	} else if hasAdmin {
+		return nil
+	}
+	memberOf, err := p.MemberOfWithAdminOption(ctx, p.User())
+	if err != nil {
+		return err
+	}
+	if _, found := memberOf[owner]; !found {
+		return pgerror.Newf(pgcode.InsufficientPrivilege,
+			"must be owner of %s %s", kind, tree.Name(name))
+	}
+	return nil
+}
+
+// textSearchParamValue returns the value of a parameter of CREATE TEXT SEARCH
+// as a string. Like in postgres, values may be given as names, strings or
+// other constants.
+func (p *planner) textSearchParamValue(
+	ctx context.Context, key string, value tree.Expr,
+) (string, error) {
+	if value == nil {
+		return "", pgerror.Newf(pgcode.InvalidParameterValue,
+			"text search parameter %q requires a value", key)
+	}
+	if name, ok := value.(*tree.UnresolvedName); ok {
+		return tree.AsStringWithFlags(name, tree.FmtBareIdentifiers), nil
+	}
+	typedExpr, err := tree.TypeCheck(ctx, value, &p.semaCtx, types.Any)
+	if err != nil {
+		return "", err
+	}
+	d, err := eval.Expr(ctx, p.EvalContext(), typedExpr)
+	if err != nil {
+		return "", err
+	}
+	if s, ok := tree.AsDString(d); ok {
+		return string(s), nil
+	}
+	return tree.AsStringWithFlags(d, tree.FmtBareStrings), nil
+}
+
+// resolveTextSearchObject returns the ID of the schema of the current database
+// containing the text search configuration or dictionary with the given name,
+// following the search path for unqualified names. It returns InvalidID if the
+// object is a built-in one of pg_catalog, or if there is no such object.
+func (p *planner) resolveTextSearchObject(
+	ctx context.Context, un *tree.UnresolvedObjectName, dictionary bool,
+) (descpb.ID, error) {
+	name := un.Object()
+	if un.HasExplicitCatalog() && un.Catalog() != p.CurrentDatabase() {
+		return descpb.InvalidID, pgerror.Newf(pgcode.FeatureNotSupported,
+			"cross-database references to text search objects are not supported: %s", un)
+	}
+	var scNames []string
+	if un.HasExplicitSchema() {
+		scNames = []string{un.Schema()}
+	} else {
+		iter := p.SessionData().SearchPath.Iter()
+		for scName, ok := iter.Next(); ok; scName, ok = iter.Next() {
+			scNames = append(scNames, scName)
+		}
+	}
+	var db catalog.DatabaseDescriptor
+	if p.CurrentDatabase() != "" {
+		var err error
+		db, err = p.Descriptors().ByNameWithLeased(p.txn).MaybeGet().Database(ctx, p.CurrentDatabase())
+		if err != nil {
+			return descpb.InvalidID, err
+		}
+	}
+	for _, scName := range scNames {
+		if scName == catconstants.PgCatalogName {
+			if dictionary {
+				if _, ok := tsearch.GetBuiltinDictionary(name); ok {
+					return descpb.InvalidID, nil
+				}
+			} else if tsearch.ValidConfig(name) == nil {
+				return descpb.InvalidID, nil
+			}
+			continue
+		}
+		if db == nil {
+			continue
+		}
+		sc, err := p.Descriptors().ByNameWithLeased(p.txn).MaybeGet().Schema(ctx, db, scName)
+		if err != nil {
+			return descpb.InvalidID, err
+		}
+		if sc == nil {
+			continue
+		}
+		if dictionary && sc.GetTextSearchDictionary(name) != nil ||
+			!dictionary && sc.GetTextSearchConfiguration(name) != nil {
+			return sc.GetID(), nil
+		}
+	}
+	return descpb.InvalidID, nil
+}
+
+// sortTextSearchMappings sorts the mappings of a configuration by token type.
+func sortTextSearchMappings(config *descpb.SchemaDescriptor_TextSearchConfiguration) {
+	tokenType := func(i int) tsearch.TokenType {
+		typ, _ := tsearch.TokenTypeFromString(config.Mappings[i].TokenType)
+		return typ
+	}
+	sort.SliceStable(config.Mappings, func(i, j int) bool { return tokenType(i) < tokenType(j) })
+}
+
+// ResolveTextSearchConfig is part of the eval.Planner interface.
+func (p *planner) ResolveTextSearchConfig(ctx context.Context, name string) (*tsearch.Config, error) {
+	un, err := parser.ParseTableName(name)
+	if err != nil {
+		return nil, pgerror.Newf(pgcode.UndefinedObject,
+			"text search configuration %q does not exist", name)
+	}
+	schemaID, err := p.resolveTextSearchObject(ctx, un, false /* dictionary */)
+	if err != nil {
+		return nil, err
+	}
+	if schemaID == descpb.InvalidID {
+		if un.HasExplicitSchema() && un.Schema() != catconstants.PgCatalogName {
+			return nil, pgerror.Newf(pgcode.UndefinedObject,
+				"text search configuration %q does not exist", name)
+		}
+		return tsearch.GetBuiltinConfig(un.Object())
+	}
+	sc, err := p.Descriptors().ByIDWithLeased(p.txn).WithoutNonPublic().Get().Schema(ctx, schemaID)
+	if err != nil {
+		return nil, err
+	}
+	desc := sc.GetTextSearchConfiguration(un.Object())
+	config := &tsearch.Config{Name: desc.Name}
+	for _, m := range desc.Mappings {
+		typ, err := tsearch.TokenTypeFromString(m.TokenType)
+		if err != nil {
+			return nil, err
+		}
+		for _, ref := range m.Dictionaries {
+			d, err := p.getTextSearchDictionary(ctx, ref)
+			if err != nil {
+				return nil, err
+			}
+			config.Mappings[typ] = append(config.Mappings[typ], d)
+		}
+	}
+	return config, nil
+}
+
+// ResolveTextSearchDictionary is part of the eval.Planner interface.
+func (p *planner) ResolveTextSearchDictionary(
+	ctx context.Context, name string,
+) (*tsearch.Dictionary, error) {
+	un, err := parser.ParseTableName(name)
+	if err != nil {
+		return nil, pgerror.Newf(pgcode.UndefinedObject,
+			"text search dictionary %q does not exist", name)
+	}
+	schemaID, err := p.resolveTextSearchObject(ctx, un, true /* dictionary */)
+	if err != nil {
+		return nil, err
+	}
+	if schemaID == descpb.InvalidID && un.HasExplicitSchema() && un.Schema() != catconstants.PgCatalogName {
+		return nil, pgerror.Newf(pgcode.UndefinedObject,
+			"text search dictionary %q does not exist", name)
+	}
+	return p.getTextSearchDictionary(ctx, descpb.SchemaDescriptor_TextSearchDictionaryRef{
+		SchemaID: schemaID,
+		Name:     un.Object(),
+	})
+}
+
+// getTextSearchDictionary returns the dictionary a configuration mapping
+// refers to.
+func (p *planner) getTextSearchDictionary(
+	ctx context.Context, ref descpb.SchemaDescriptor_TextSearchDictionaryRef,
+) (*tsearch.Dictionary, error) {
+	if ref.SchemaID == descpb.InvalidID {
+		if d, ok := tsearch.GetBuiltinDictionary(ref.Name); ok {
+			return d, nil
+		}
+		return nil, pgerror.Newf(pgcode.UndefinedObject,
+			"text search dictionary %q does not exist", ref.Name)
+	}
+	sc, err := p.Descriptors().ByIDWithLeased(p.txn).WithoutNonPublic().Get().Schema(ctx, ref.SchemaID)
+	if err != nil {
+		return nil, err
+	}
+	desc := sc.GetTextSearchDictionary(ref.Name)
+	if desc == nil {
+		return nil, pgerror.Newf(pgcode.UndefinedObject,
+			"text search dictionary %q does not exist", ref.Name)
+	}
+	options := make([]tsearch.DictionaryOption, len(desc.Options))
+	for i, opt := range desc.Options {
+		options[i] = tsearch.DictionaryOption{Name: opt.Name, Value: opt.Value}
+	}
+	return tsearch.NewDictionary(desc.Name, desc.Template, options)
+}
@@ -485,6 +485,21 @@ func (t *typeSchemaChanger) exec(ctx context.Context) error {
 		}
 	}
 
+	// Validate the CHECK constraints being added to a domain. Every node now
+	// enforces them on writes, so only the values written before they were
+	// added remain to be validated.
+	if typeDesc.AsDomainTypeDescriptor() != nil {
+		published, err := t.validateDomainChecks(ctx)
+		if err != nil {
+			return err
+		}
+		if published {
+			if err := refreshTypeDescriptorLeases(ctx, leaseMgr, typeDesc); err != nil {
+				return err
+			}
+		}
+	}
+
 	// If the type is being dropped, remove the descriptor here only
 	// if the declarative schema changer is not in use.
 	if typeDesc.Dropped() && typeDesc.GetDeclarativeSchemaChangerState() == nil {
@@ -595,6 +610,78 @@ func (t *typeSchemaChanger) cleanupEnumValues(ctx context.Context) error {
 	return t.execCfg.InternalDB.DescsTxn(ctx, cleanup)
 }
 
+// validateDomainChecks validates the CHECK constraints that are being added to
+// a domain against the values stored in the columns of the domain type, and
+// marks them as validated. It returns whether any constraint was published.
+func (t *typeSchemaChanger) validateDomainChecks(ctx context.Context) (published bool, _ error) {
+	var validated []string
+	// The validation is done in a separate txn to the one that mutates the
+	// descriptor, as it can take arbitrarily long.
+	if err := t.execCfg.InternalDB.DescsTxn(ctx, func(ctx context.Context, txn descs.Txn) error {
+		validated = validated[:0]
+		typeDesc, err := txn.Descriptors().MutableByID(txn.KV()).Type(ctx, t.typeID)
+		if err != nil {
+			return err
+		}
+		for _, check := range typeDesc.Domain.Checks {
+			if check.Validity != descpb.ConstraintValidity_Validating {
+				continue
+			}
+			if err := validateDomainCheck(ctx, txn, typeDesc, check); err != nil {
+				return err
+			}
+			validated = append(validated, check.Name)
+		}
+		return nil
+	}); err != nil || len(validated) == 0 {
+		return false, err
+	}
+	if err := t.execCfg.InternalDB.DescsTxn(ctx, func(ctx context.Context, txn descs.Txn) error {
+		typeDesc, err := txn.Descriptors().MutableByID(txn.KV()).Type(ctx, t.typeID)
+		if err != nil {
+			return err
+		}
+		for i := range typeDesc.Domain.Checks {
+			check := &typeDesc.Domain.Checks[i]
+			for _, name := range validated {
+				if check.Name == name && check.Validity == descpb.ConstraintValidity_Validating {
+					check.Validity = descpb.ConstraintValidity_Validated
+				}
+			}
+		}
+		return txn.Descriptors().WriteDesc(ctx, true /* kvTrace */, typeDesc, txn.KV())
+	}); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// cleanupDomainChecks removes the CHECK constraints that were being added to a
+// domain if their validation failed.
+func (t *typeSchemaChanger) cleanupDomainChecks(ctx context.Context) error {
+	return t.execCfg.InternalDB.DescsTxn(ctx, func(ctx context.Context, txn descs.Txn) error {
+		typeDesc, err := txn.Descriptors().MutableByID(txn.KV()).Type(ctx, t.typeID)
+		if err != nil {
+			return err
+		}
+		if typeDesc.Domain == nil {
+			return nil
+		}
+		checks := typeDesc.Domain.Checks[:0]
+		for _, check := range typeDesc.Domain.Checks {
+			if check.Validity != descpb.ConstraintValidity_Validating {
+				checks = append(checks, check)
+			}
+		}
+		if len(checks) == len(typeDesc.Domain.Checks) {
+			// No cleanup required.
+			return nil
+		}
+		typeDesc.Domain.Checks = checks
+		return txn.Descriptors().WriteDesc(ctx, true /* kvTrace */, typeDesc, txn.KV())
+	})
+}
+
 // convertToSQLStringRepresentation takes an array of bytes (the physical
 // representation of an enum) and converts it into a string that can be used
 // in a SQL predicate.
@@ -1278,6 +1365,10 @@ func (t *typeChangeResumer) OnFailOrCancel(
 			return err
 		}
 
+		if err := tc.cleanupDomainChecks(ctx); err != nil {
+			return err
+		}
+
 		if fn := tc.execCfg.TypeSchemaChangerTestingKnobs.RunAfterOnFailOrCancel; fn != nil {
 			return fn()
 		}
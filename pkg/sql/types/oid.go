@@ -60,18 +60,24 @@ var (
 // Note that additional elements for the array Oid types are added in init().
 var OidToType = map[oid.Oid]*T{
 	oid.T_anyelement: Any,
+	oid.T_anyrange:   AnyRange,
 	oid.T_bit:        typeBit,
 	oid.T_bool:       Bool,
+	oid.T_box:        Box,
 	oid.T_bpchar:     typeBpChar,
 	oid.T_bytea:      Bytes,
 	oid.T_char:       QChar,
+	oid.T_circle:     Circle,
 	oid.T_date:       Date,
+	oid.T_daterange:  DateRange,
 	oid.T_float4:     Float4,
 	oid.T_float8:     Float,
 	oid.T_int2:       Int2,
 	oid.T_int2vector: Int2Vector,
 	oid.T_int4:       Int4,
+	oid.T_int4range:  Int4Range,
 	oid.T_int8:       Int,
+	oid.T_int8range:  Int8Range,
 	oid.T_inet:       INet,
 	oid.T_interval:   Interval,
 	// NOTE(sql-exp): Uncomment the line below if we support the JSON type.
@@ -79,11 +85,17 @@ var OidToType = map[oid.Oid]*T{
 	// existing tables.
 	// oid.T_json:      Json,
 	oid.T_jsonb:        Jsonb,
+	oid.T_line:         Line,
+	oid.T_lseg:         LSeg,
 	oid.T_name:         Name,
 	oid.T_numeric:      Decimal,
+	oid.T_numrange:     NumRange,
 	oid.T_oid:          Oid,
 	oid.T_oidvector:    OidVector,
+	oid.T_path:         Path,
 	oid.T_pg_lsn:       PGLSN,
+	oid.T_point:        Point,
+	oid.T_polygon:      Polygon,
 	oid.T_record:       AnyTuple,
 	oid.T_refcursor:    RefCursor,
 	oid.T_regclass:     RegClass,
@@ -98,16 +110,28 @@ var OidToType = map[oid.Oid]*T{
 	oid.T_timestamp:    Timestamp,
 	oid.T_timestamptz:  TimestampTZ,
 	oid.T_tsquery:      TSQuery,
+	oid.T_tsrange:      TSRange,
+	oid.T_tstzrange:    TSTZRange,
 	oid.T_tsvector:     TSVector,
 	oid.T_unknown:      Unknown,
 	oid.T_uuid:         Uuid,
 	oid.T_varbit:       VarBit,
 	oid.T_varchar:      VarChar,
 	oid.T_void:         Void,
+	oid.T_trigger:      Trigger,
 
 	oidext.T_geometry:  Geometry,
 	oidext.T_geography: Geography,
 	oidext.T_box2d:     Box2D,
+	oidext.T_jsonpath:  Jsonpath,
+
+	oidext.T_int4multirange: Int4Multirange,
+	oidext.T_int8multirange: Int8Multirange,
+	oidext.T_nummultirange:  NumMultirange,
+	oidext.T_tsmultirange:   TSMultirange,
+	oidext.T_tstzmultirange: TSTZMultirange,
+	oidext.T_datemultirange: DateMultirange,
+	oidext.T_anymultirange:  AnyMultirange,
 }
 
 // oidToArrayOid maps scalar type Oids to their corresponding array type Oid.
@@ -115,24 +139,35 @@ var oidToArrayOid = map[oid.Oid]oid.Oid{
 	oid.T_anyelement:   oid.T_anyarray,
 	oid.T_bit:          oid.T__bit,
 	oid.T_bool:         oid.T__bool,
+	oid.T_box:          oid.T__box,
 	oid.T_bpchar:       oid.T__bpchar,
 	oid.T_bytea:        oid.T__bytea,
 	oid.T_char:         oid.T__char,
+	oid.T_circle:       oid.T__circle,
 	oid.T_date:         oid.T__date,
+	oid.T_daterange:    oid.T__daterange,
 	oid.T_float4:       oid.T__float4,
 	oid.T_float8:       oid.T__float8,
 	oid.T_inet:         oid.T__inet,
 	oid.T_int2:         oid.T__int2,
 	oid.T_int2vector:   oid.T__int2vector,
 	oid.T_int4:         oid.T__int4,
+	oid.T_int4range:    oid.T__int4range,
 	oid.T_int8:         oid.T__int8,
+	oid.T_int8range:    oid.T__int8range,
 	oid.T_interval:     oid.T__interval,
 	oid.T_jsonb:        oid.T__jsonb,
+	oid.T_line:         oid.T__line,
+	oid.T_lseg:         oid.T__lseg,
 	oid.T_name:         oid.T__name,
 	oid.T_numeric:      oid.T__numeric,
+	oid.T_numrange:     oid.T__numrange,
 	oid.T_oid:          oid.T__oid,
 	oid.T_oidvector:    oid.T__oidvector,
+	oid.T_path:         oid.T__path,
 	oid.T_pg_lsn:       oid.T__pg_lsn,
+	oid.T_point:        oid.T__point,
+	oid.T_polygon:      oid.T__polygon,
 	oid.T_record:       oid.T__record,
 	oid.T_refcursor:    oid.T__refcursor,
 	oid.T_regclass:     oid.T__regclass,
@@ -147,6 +182,8 @@ var oidToArrayOid = map[oid.Oid]oid.Oid{
 	oid.T_timestamp:    oid.T__timestamp,
 	oid.T_timestamptz:  oid.T__timestamptz,
 	oid.T_tsquery:      oid.T__tsquery,
+	oid.T_tsrange:      oid.T__tsrange,
+	oid.T_tstzrange:    oid.T__tstzrange,
 	oid.T_tsvector:     oid.T__tsvector,
 	oid.T_uuid:         oid.T__uuid,
 	oid.T_varbit:       oid.T__varbit,
@@ -155,6 +192,14 @@ var oidToArrayOid = map[oid.Oid]oid.Oid{
 	oidext.T_geometry:  oidext.T__geometry,
 	oidext.T_geography: oidext.T__geography,
 	oidext.T_box2d:     oidext.T__box2d,
+	oidext.T_jsonpath:  oidext.T__jsonpath,
+
+	oidext.T_int4multirange: oidext.T__int4multirange,
+	oidext.T_int8multirange: oidext.T__int8multirange,
+	oidext.T_nummultirange:  oidext.T__nummultirange,
+	oidext.T_tsmultirange:   oidext.T__tsmultirange,
+	oidext.T_tstzmultirange: oidext.T__tstzmultirange,
+	oidext.T_datemultirange: oidext.T__datemultirange,
 }
 
 // familyToOid maps each type family to a default OID value that is used when
@@ -182,6 +227,10 @@ var familyToOid = map[Family]oid.Oid{
 	TimeFamily:           oid.T_time,
 	TimeTZFamily:         oid.T_timetz,
 	JsonFamily:           oid.T_jsonb,
+	JsonpathFamily:       oidext.T_jsonpath,
+	GeometricFamily:      oid.T_point,
+	RangeFamily:          oid.T_int8range,
+	MultirangeFamily:     oidext.T_int8multirange,
 	TSQueryFamily:        oid.T_tsquery,
 	TSVectorFamily:       oid.T_tsvector,
 	TupleFamily:          oid.T_record,
@@ -229,6 +278,11 @@ func CalcArrayOid(elemTyp *T) oid.Oid {
 	case EnumFamily:
 		return elemTyp.UserDefinedArrayOID()
 
+	case RangeFamily:
+		if elemTyp.UserDefined() {
+			return elemTyp.UserDefinedArrayOID()
+		}
+
 	case TupleFamily:
 		if elemTyp.UserDefined() {
 			if elemTyp.TypeMeta.ImplicitRecordType {
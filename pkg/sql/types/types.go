@@ -57,6 +57,7 @@ import (
 //	ArrayContents - array element type (T)
 //	TupleContents - slice of types of each tuple field ([]*T)
 //	TupleLabels   - slice of labels of each tuple field ([]string)
+//	RangeContents - range subtype or multirange range type (T)
 //
 // Some types are not currently allowed as the type of a column (e.g. nested
 // arrays). Other usages of the types package may have similar restrictions.
@@ -161,6 +162,24 @@ import (
 // When these types are themselves made into arrays, the Oids become T__int2vector and
 // T__oidvector, respectively.
 //
+// Range types
+// -----------
+//
+// | SQL type       | Family     | Oid              | RangeContents |
+// |----------------|------------|------------------|---------------|
+// | INT4RANGE      | RANGE      | T_int4range      | Int4          |
+// | INT8RANGE      | RANGE      | T_int8range      | Int           |
+// | NUMRANGE       | RANGE      | T_numrange       | Decimal       |
+// | TSRANGE        | RANGE      | T_tsrange        | Timestamp     |
+// | TSTZRANGE      | RANGE      | T_tstzrange      | TimestampTZ   |
+// | DATERANGE      | RANGE      | T_daterange      | Date          |
+// | INT4MULTIRANGE | MULTIRANGE | T_int4multirange | Int4Range     |
+// | INT8MULTIRANGE | MULTIRANGE | T_int8multirange | Int8Range     |
+// | NUMMULTIRANGE  | MULTIRANGE | T_nummultirange  | NumRange      |
+// | TSMULTIRANGE   | MULTIRANGE | T_tsmultirange   | TSRange       |
+// | TSTZMULTIRANGE | MULTIRANGE | T_tstzmultirange | TSTZRange     |
+// | DATEMULTIRANGE | MULTIRANGE | T_datemultirange | DateRange     |
+//
 // User defined types
 // ------------------
 //
@@ -170,6 +189,13 @@ import (
 // | Family        | EnumFamily                                 |
 // | Oid           | A unique OID generated upon enum creation  |
 //
+// * Ranges
+// | Field         | Description                                |
+// |---------------|--------------------------------------------|
+// | Family        | RangeFamily                                |
+// | Oid           | A unique OID generated upon range creation |
+// | RangeContents | The subtype of the range                   |
+//
 // See types.proto for the corresponding proto definition. Its automatic
 // type declaration is suppressed in the proto so that it is possible to
 // add additional fields to T without serializing them.
@@ -195,6 +221,9 @@ type UserDefinedTypeMetadata struct {
 	// EnumData is non-nil iff the metadata is for an ENUM type.
 	EnumData *EnumMetadata
 
+	// DomainData is non-nil iff the metadata is for a DOMAIN type.
+	DomainData *DomainMetadata
+
 	// Version is the descriptor version of the descriptor used to construct
 	// this version of the type metadata.
 	Version uint32
@@ -221,6 +250,28 @@ type EnumMetadata struct {
 	//  should occur, if at all.
 }
 
+// DomainMetadata is metadata about a DOMAIN needed for evaluation.
+type DomainMetadata struct {
+	// BaseType is the type that the domain is defined over.
+	BaseType *T
+	// NotNull is true if the domain does not allow NULL values.
+	NotNull bool
+	// DefaultExpr is the serialized default expression of the domain, or the
+	// empty string if the domain has no default.
+	DefaultExpr string
+	// Checks are the CHECK constraints of the domain.
+	Checks []DomainCheck
+}
+
+// DomainCheck is a CHECK constraint of a DOMAIN.
+type DomainCheck struct {
+	// Name is the name of the constraint.
+	Name string
+	// Expr is the serialized check expression, which refers to the value being
+	// checked as VALUE.
+	Expr string
+}
+
 func (e *EnumMetadata) debugString() string {
 	return fmt.Sprintf(
 		"PhysicalReps: %v; LogicalReps: %s",
@@ -415,6 +466,89 @@ var (
 	Json = &T{InternalType: InternalType{
 		Family: JsonFamily, Oid: oid.T_json, Locale: &emptyLocale}}
 
+	// Jsonpath is the type of a SQL/JSON path expression, which is used to
+	// query JSON values.
+	Jsonpath = &T{InternalType: InternalType{
+		Family: JsonpathFamily, Oid: oidext.T_jsonpath, Locale: &emptyLocale}}
+
+	// Point is the type of a point in a two-dimensional plane.
+	Point = &T{InternalType: InternalType{
+		Family: GeometricFamily, Oid: oid.T_point, Locale: &emptyLocale}}
+
+	// LSeg is the type of a finite line segment.
+	LSeg = &T{InternalType: InternalType{
+		Family: GeometricFamily, Oid: oid.T_lseg, Locale: &emptyLocale}}
+
+	// Line is the type of an infinite line, represented by the coefficients of
+	// the linear equation Ax + By + C = 0.
+	Line = &T{InternalType: InternalType{
+		Family: GeometricFamily, Oid: oid.T_line, Locale: &emptyLocale}}
+
+	// Box is the type of a rectangular box whose sides are parallel to the
+	// axes.
+	Box = &T{InternalType: InternalType{
+		Family: GeometricFamily, Oid: oid.T_box, Locale: &emptyLocale}}
+
+	// Path is the type of an open or closed sequence of connected points.
+	Path = &T{InternalType: InternalType{
+		Family: GeometricFamily, Oid: oid.T_path, Locale: &emptyLocale}}
+
+	// Polygon is the type of a closed sequence of points enclosing an area.
+	Polygon = &T{InternalType: InternalType{
+		Family: GeometricFamily, Oid: oid.T_polygon, Locale: &emptyLocale}}
+
+	// Circle is the type of a circle, represented by its center and radius.
+	Circle = &T{InternalType: InternalType{
+		Family: GeometricFamily, Oid: oid.T_circle, Locale: &emptyLocale}}
+
+	// Int4Range is the type of a range of Int4 values.
+	Int4Range = &T{InternalType: InternalType{
+		Family: RangeFamily, RangeContents: Int4, Oid: oid.T_int4range, Locale: &emptyLocale}}
+
+	// Int8Range is the type of a range of Int values.
+	Int8Range = &T{InternalType: InternalType{
+		Family: RangeFamily, RangeContents: Int, Oid: oid.T_int8range, Locale: &emptyLocale}}
+
+	// NumRange is the type of a range of Decimal values.
+	NumRange = &T{InternalType: InternalType{
+		Family: RangeFamily, RangeContents: Decimal, Oid: oid.T_numrange, Locale: &emptyLocale}}
+
+	// TSRange is the type of a range of Timestamp values.
+	TSRange = &T{InternalType: InternalType{
+		Family: RangeFamily, RangeContents: Timestamp, Oid: oid.T_tsrange, Locale: &emptyLocale}}
+
+	// TSTZRange is the type of a range of TimestampTZ values.
+	TSTZRange = &T{InternalType: InternalType{
+		Family: RangeFamily, RangeContents: TimestampTZ, Oid: oid.T_tstzrange, Locale: &emptyLocale}}
+
+	// DateRange is the type of a range of Date values.
+	DateRange = &T{InternalType: InternalType{
+		Family: RangeFamily, RangeContents: Date, Oid: oid.T_daterange, Locale: &emptyLocale}}
+
+	// Int4Multirange is the type of a multirange of Int4Range values.
+	Int4Multirange = &T{InternalType: InternalType{
+		Family: MultirangeFamily, RangeContents: Int4Range, Oid: oidext.T_int4multirange, Locale: &emptyLocale}}
+
+	// Int8Multirange is the type of a multirange of Int8Range values.
+	Int8Multirange = &T{InternalType: InternalType{
+		Family: MultirangeFamily, RangeContents: Int8Range, Oid: oidext.T_int8multirange, Locale: &emptyLocale}}
+
+	// NumMultirange is the type of a multirange of NumRange values.
+	NumMultirange = &T{InternalType: InternalType{
+		Family: MultirangeFamily, RangeContents: NumRange, Oid: oidext.T_nummultirange, Locale: &emptyLocale}}
+
+	// TSMultirange is the type of a multirange of TSRange values.
+	TSMultirange = &T{InternalType: InternalType{
+		Family: MultirangeFamily, RangeContents: TSRange, Oid: oidext.T_tsmultirange, Locale: &emptyLocale}}
+
+	// TSTZMultirange is the type of a multirange of TSTZRange values.
+	TSTZMultirange = &T{InternalType: InternalType{
+		Family: MultirangeFamily, RangeContents: TSTZRange, Oid: oidext.T_tstzmultirange, Locale: &emptyLocale}}
+
+	// DateMultirange is the type of a multirange of DateRange values.
+	DateMultirange = &T{InternalType: InternalType{
+		Family: MultirangeFamily, RangeContents: DateRange, Oid: oidext.T_datemultirange, Locale: &emptyLocale}}
+
 	// Uuid is the type of a universally unique identifier (UUID), which is a
 	// 128-bit quantity that is very unlikely to ever be generated again, and so
 	// can be relied on to be distinct from all other UUID values.
@@ -476,6 +610,15 @@ var (
 		},
 	}
 
+	// Trigger is the pseudo-type used as the return type of trigger functions.
+	Trigger = &T{
+		InternalType: InternalType{
+			Family: TriggerFamily,
+			Oid:    oid.T_trigger,
+			Locale: &emptyLocale,
+		},
+	}
+
 	// EncodedKey is a special type used internally for passing encoded key data.
 	// It behaves similarly to Bytes in most circumstances, except
 	// encoding/decoding. It is currently used to pass around inverted index keys,
@@ -567,6 +710,18 @@ var (
 	AnyEnum = &T{InternalType: InternalType{
 		Family: EnumFamily, Locale: &emptyLocale, Oid: oid.T_anyenum}}
 
+	// AnyRange is a special type used only during static analysis as a wildcard
+	// type that matches a range of any subtype. Execution-time values should
+	// never have this type.
+	AnyRange = &T{InternalType: InternalType{
+		Family: RangeFamily, RangeContents: Any, Oid: oid.T_anyrange, Locale: &emptyLocale}}
+
+	// AnyMultirange is a special type used only during static analysis as a
+	// wildcard type that matches a multirange of any range type. Execution-time
+	// values should never have this type.
+	AnyMultirange = &T{InternalType: InternalType{
+		Family: MultirangeFamily, RangeContents: AnyRange, Oid: oidext.T_anymultirange, Locale: &emptyLocale}}
+
 	// AnyTuple is a special type used only during static analysis as a wildcard
 	// type that matches a tuple with any number of fields of any type (including
 	// tuple types). Execution-time values should never have this type.
@@ -672,6 +827,11 @@ var (
 	JSONArrayForDecodingOnly = &T{InternalType: InternalType{
 		Family: ArrayFamily, ArrayContents: Json, Oid: oid.T__json, Locale: &emptyLocale}}
 
+	// JsonpathArray is the type of an array value having Jsonpath-typed
+	// elements.
+	JsonpathArray = &T{InternalType: InternalType{
+		Family: ArrayFamily, ArrayContents: Jsonpath, Oid: oidext.T__jsonpath, Locale: &emptyLocale}}
+
 	// Int2Vector is a type-alias for an array of Int2 values with a different
 	// OID (T_int2vector instead of T__int2). It is a special VECTOR type used
 	// by Postgres in system tables. Int2vectors are 0-indexed, unlike normal arrays.
@@ -1153,6 +1313,39 @@ func MakeEnum(typeOID, arrayTypeOID oid.Oid) *T {
 	}}
 }
 
+// MakeRange constructs a new instance of a user-defined RangeFamily type over
+// the given subtype, with the given type OIDs. Note that it does not hydrate
+// cached fields on the type.
+func MakeRange(typeOID, arrayTypeOID oid.Oid, subtype *T) *T {
+	return &T{InternalType: InternalType{
+		Family:        RangeFamily,
+		Oid:           typeOID,
+		Locale:        &emptyLocale,
+		RangeContents: subtype,
+		UDTMetadata: &PersistentUserDefinedTypeMetadata{
+			ArrayTypeOID: arrayTypeOID,
+		},
+	}}
+}
+
+// MultirangeOf returns the built-in multirange type having ranges of the given
+// built-in range type, or nil if there is no such type.
+func MultirangeOf(rangeTyp *T) *T {
+	return rangeToMultirange[rangeTyp.Oid()]
+}
+
+// rangeToMultirange maps the OID of each built-in range type to its
+// corresponding multirange type.
+var rangeToMultirange = map[oid.Oid]*T{
+	oid.T_int4range: Int4Multirange,
+	oid.T_int8range: Int8Multirange,
+	oid.T_numrange:  NumMultirange,
+	oid.T_tsrange:   TSMultirange,
+	oid.T_tstzrange: TSTZMultirange,
+	oid.T_daterange: DateMultirange,
+	oid.T_anyrange:  AnyMultirange,
+}
+
 // MakeArray constructs a new instance of an ArrayFamily type with the given
 // element type (which may itself be an ArrayFamily type).
 func MakeArray(typ *T) *T {
@@ -1216,6 +1409,19 @@ func NewCompositeType(typeOID, arrayTypeOID oid.Oid, contents []*T, labels []str
 	}}
 }
 
+// MakeDomain constructs a new instance of a domain type over the given base
+// type, with the given user-defined type OIDs. The domain type has the same
+// family and type modifiers as the base type. Note that it does not hydrate
+// cached fields on the type.
+func MakeDomain(typeOID, arrayTypeOID oid.Oid, baseType *T) *T {
+	internal := baseType.InternalType
+	internal.Oid = typeOID
+	internal.UDTMetadata = &PersistentUserDefinedTypeMetadata{
+		ArrayTypeOID: arrayTypeOID,
+	}
+	return &T{InternalType: internal}
+}
+
 // Family specifies a group of types that are compatible with one another. Types
 // in the same family can be compared, assigned, etc., but may differ from one
 // another in width, precision, locale, and other attributes. For example, it is
@@ -1374,8 +1580,9 @@ func (t *T) WithoutTypeModifiers() *T {
 			return t
 		}
 		return MakeTuple(newContents)
-	case EnumFamily:
-		// Enums have no type modifiers.
+	case EnumFamily, RangeFamily, MultirangeFamily:
+		// Enums have no type modifiers. Ranges and multiranges have no type
+		// modifiers, and their subtypes never do.
 		return t
 	}
 
@@ -1417,6 +1624,12 @@ func (t *T) ArrayContents() *T {
 	return t.InternalType.ArrayContents
 }
 
+// RangeContents returns the subtype of a RangeFamily type, or the range type of
+// a MultirangeFamily type. This is nil for all other types.
+func (t *T) RangeContents() *T {
+	return t.InternalType.RangeContents
+}
+
 // TupleContents returns a slice containing the type of each tuple field. This
 // is nil for non-TupleFamily types.
 func (t *T) TupleContents() []*T {
@@ -1460,6 +1673,33 @@ func (t *T) UserDefined() bool {
 	return IsOIDUserDefinedType(t.Oid())
 }
 
+// IsDomain returns whether or not t is a user defined DOMAIN type. Domains are
+// the only user defined types that are not enums, composite types, ranges, or
+// arrays of user defined types.
+func (t *T) IsDomain() bool {
+	if !t.UserDefined() {
+		return false
+	}
+	switch t.Family() {
+	case EnumFamily, TupleFamily, ArrayFamily, RangeFamily:
+		return false
+	}
+	return true
+}
+
+// DomainBaseType returns the type that the DOMAIN type t is defined over. If
+// the type metadata is not hydrated, it returns the canonical type of the
+// family of t with the type modifiers of t.
+func (t *T) DomainBaseType() *T {
+	if t.TypeMeta.DomainData != nil {
+		return t.TypeMeta.DomainData.BaseType
+	}
+	base := &T{InternalType: t.InternalType}
+	base.InternalType.Oid = familyToOid[t.Family()]
+	base.InternalType.UDTMetadata = nil
+	return base
+}
+
 // IsOIDUserDefinedType returns whether or not o corresponds to a user
 // defined type.
 func IsOIDUserDefinedType(o oid.Oid) bool {
@@ -1484,6 +1724,10 @@ var familyNames = map[Family]string{
 	IntFamily:            "int",
 	IntervalFamily:       "interval",
 	JsonFamily:           "jsonb",
+	JsonpathFamily:       "jsonpath",
+	GeometricFamily:      "geometric",
+	RangeFamily:          "range",
+	MultirangeFamily:     "multirange",
 	OidFamily:            "oid",
 	PGLSNFamily:          "pg_lsn",
 	RefCursorFamily:      "refcursor",
@@ -1498,6 +1742,7 @@ var familyNames = map[Family]string{
 	UnknownFamily:        "unknown",
 	UuidFamily:           "uuid",
 	VoidFamily:           "void",
+	TriggerFamily:        "trigger",
 	EncodedKeyFamily:     "encodedkey",
 }
 
@@ -1520,6 +1765,9 @@ func (f Family) Name() string {
 //
 // TODO(andyk): Should these be changed to be the same as SQLStandardName?
 func (t *T) Name() string {
+	if t.IsDomain() {
+		return t.domainName()
+	}
 	switch fam := t.Family(); fam {
 	case AnyFamily:
 		return "anyelement"
@@ -1561,9 +1809,19 @@ func (t *T) Name() string {
 			panic(errors.AssertionFailedf("programming error: unknown int width: %d", t.Width()))
 		}
 
-	case OidFamily:
+	case GeometricFamily, MultirangeFamily, OidFamily:
 		return t.SQLStandardName()
 
+	case RangeFamily:
+		if !t.UserDefined() {
+			return t.SQLStandardName()
+		}
+		// This can be nil during unit testing.
+		if t.TypeMeta.Name == nil {
+			return "unknown_range"
+		}
+		return t.TypeMeta.Name.Basename()
+
 	case StringFamily, CollatedStringFamily:
 		switch t.Oid() {
 		case oid.T_text:
@@ -1657,6 +1915,9 @@ func (t *T) TelemetryName() string {
 // This function is full of special cases. See backend/utils/adt/format_type.c
 // in Postgres.
 func (t *T) SQLStandardNameWithTypmod(haveTypmod bool, typmod int) string {
+	if t.IsDomain() {
+		return t.domainName()
+	}
 	var buf strings.Builder
 	switch t.Family() {
 	case AnyFamily:
@@ -1742,6 +2003,27 @@ func (t *T) SQLStandardNameWithTypmod(haveTypmod bool, typmod int) string {
 	case JsonFamily:
 		// Only binary JSON is currently supported.
 		return "jsonb"
+	case JsonpathFamily:
+		return "jsonpath"
+	case GeometricFamily:
+		switch t.Oid() {
+		case oid.T_point:
+			return "point"
+		case oid.T_lseg:
+			return "lseg"
+		case oid.T_line:
+			return "line"
+		case oid.T_box:
+			return "box"
+		case oid.T_path:
+			return "path"
+		case oid.T_polygon:
+			return "polygon"
+		case oid.T_circle:
+			return "circle"
+		default:
+			panic(errors.AssertionFailedf("unexpected Oid: %v", errors.Safe(t.Oid())))
+		}
 	case OidFamily:
 		switch t.Oid() {
 		case oid.T_oid:
@@ -1763,6 +2045,15 @@ func (t *T) SQLStandardNameWithTypmod(haveTypmod bool, typmod int) string {
 		}
 	case PGLSNFamily:
 		return "pg_lsn"
+	case RangeFamily, MultirangeFamily:
+		if t.UserDefined() {
+			return t.TypeMeta.Name.Basename()
+		}
+		name, ok := oidext.TypeName(t.Oid())
+		if !ok {
+			panic(errors.AssertionFailedf("unexpected Oid: %v", errors.Safe(t.Oid())))
+		}
+		return strings.ToLower(name)
 	case RefCursorFamily:
 		return "refcursor"
 	case StringFamily, CollatedStringFamily:
@@ -1838,6 +2129,8 @@ func (t *T) SQLStandardNameWithTypmod(haveTypmod bool, typmod int) string {
 		return "uuid"
 	case VoidFamily:
 		return "void"
+	case TriggerFamily:
+		return "trigger"
 	case EnumFamily:
 		return t.TypeMeta.Name.Basename()
 	default:
@@ -1855,6 +2148,10 @@ func (t *T) InformationSchemaName() string {
 	if t.Family() == ArrayFamily {
 		return "ARRAY"
 	}
+	// Domains report the name of their base type, like in Postgres.
+	if t.IsDomain() {
+		return t.DomainBaseType().InformationSchemaName()
+	}
 	// TypeMeta attributes are populated only when it is user defined type.
 	if t.TypeMeta.Name != nil {
 		return "USER-DEFINED"
@@ -1866,6 +2163,12 @@ func (t *T) InformationSchemaName() string {
 // reproduce the type via parsing the string as a type. It is used in error
 // messages and also to produce the output of SHOW CREATE.
 func (t *T) SQLString() string {
+	if t.IsDomain() {
+		if t.TypeMeta.Name == nil {
+			return fmt.Sprintf("@%d", t.Oid())
+		}
+		return t.TypeMeta.Name.FQName()
+	}
 	switch t.Family() {
 	case BitFamily:
 		o := t.Oid()
@@ -1967,6 +2270,13 @@ func (t *T) SQLString() string {
 			return fmt.Sprintf("@%d", t.Oid())
 		}
 		return t.TypeMeta.Name.FQName()
+	case RangeFamily:
+		if t.UserDefined() {
+			if t.TypeMeta.Name == nil {
+				return fmt.Sprintf("@%d", t.Oid())
+			}
+			return t.TypeMeta.Name.FQName()
+		}
 	}
 	return strings.ToUpper(t.Name())
 }
@@ -1985,6 +2295,11 @@ func (t *T) SQLStringForError() redact.RedactableString {
 			prefix = "RECORD"
 		case ArrayFamily:
 			prefix = "ARRAY"
+		case RangeFamily:
+			prefix = "RANGE"
+		}
+		if t.IsDomain() {
+			prefix = "DOMAIN"
 		}
 		return redact.Sprintf("USER DEFINED %s: %s", redact.Safe(prefix), t.SQLString())
 	}
@@ -1997,8 +2312,9 @@ func (t *T) SQLStringForError() redact.RedactableString {
 	case BoolFamily, IntFamily, FloatFamily, DecimalFamily, DateFamily, TimestampFamily,
 		IntervalFamily, StringFamily, BytesFamily, TimestampTZFamily, CollatedStringFamily, OidFamily,
 		UnknownFamily, UuidFamily, INetFamily, TimeFamily, JsonFamily, TimeTZFamily, BitFamily,
-		GeometryFamily, GeographyFamily, Box2DFamily, VoidFamily, EncodedKeyFamily, TSQueryFamily,
-		TSVectorFamily, AnyFamily, PGLSNFamily, RefCursorFamily:
+		GeometryFamily, GeographyFamily, Box2DFamily, VoidFamily, TriggerFamily, EncodedKeyFamily, TSQueryFamily,
+		TSVectorFamily, AnyFamily, PGLSNFamily, RefCursorFamily, JsonpathFamily,
+		GeometricFamily, RangeFamily, MultirangeFamily:
 		// These types do not contain other types, and do not require redaction.
 		return redact.Sprint(redact.SafeString(t.SQLString()))
 	}
@@ -2073,6 +2389,26 @@ func (t *T) Equivalent(other *T) bool {
 		if t.Oid() != other.Oid() {
 			return false
 		}
+
+	case GeometricFamily:
+		// Each geometric type has its own representation, so types in the
+		// family are only equivalent if they are the same type.
+		if t.Oid() != other.Oid() {
+			return false
+		}
+
+	case RangeFamily, MultirangeFamily:
+		// If one of the types is anyrange or anymultirange, then allow the
+		// comparison to go through -- they are used when matching overloads.
+		// Otherwise, ranges are only equivalent if they are the same type, since
+		// e.g. int4range and int8range have different canonical forms.
+		if t.Oid() == oid.T_anyrange || other.Oid() == oid.T_anyrange ||
+			t.Oid() == oidext.T_anymultirange || other.Oid() == oidext.T_anymultirange {
+			return true
+		}
+		if t.Oid() != other.Oid() {
+			return false
+		}
 	}
 
 	return true
@@ -2202,6 +2538,15 @@ func (t *InternalType) Identical(other *InternalType) bool {
 	} else if other.ArrayContents != nil {
 		return false
 	}
+	if t.RangeContents != nil && other.RangeContents != nil {
+		if !t.RangeContents.Identical(other.RangeContents) {
+			return false
+		}
+	} else if t.RangeContents != nil {
+		return false
+	} else if other.RangeContents != nil {
+		return false
+	}
 	if len(t.TupleContents) != len(other.TupleContents) {
 		return false
 	}
@@ -2655,6 +3000,8 @@ func (t *T) IsAmbiguous() bool {
 		return t.ArrayContents().IsAmbiguous()
 	case EnumFamily:
 		return t.Oid() == oid.T_anyenum
+	case RangeFamily, MultirangeFamily:
+		return t.RangeContents().IsAmbiguous()
 	}
 	return false
 }
@@ -2848,6 +3195,15 @@ func (t *T) stringTypeSQL() string {
 	return typName
 }
 
+// domainName returns the unqualified name of the DOMAIN type t.
+func (t *T) domainName() string {
+	// This can be nil during unit testing.
+	if t.TypeMeta.Name == nil {
+		return "unknown_domain"
+	}
+	return t.TypeMeta.Name.Basename()
+}
+
 // IsHydrated returns true if this is a user-defined type and the TypeMeta
 // is hydrated.
 func (t *T) IsHydrated() bool {
@@ -2954,16 +3310,10 @@ var unreservedTypeTokens = map[string]*T{
 // github issues. It is also possible, but not necessary, to include
 // PostgreSQL types that are already implemented in CockroachDB.
 var postgresPredefinedTypeIssues = map[string]int{
-	"box":           21286,
 	"cidr":          18846,
-	"circle":        21286,
-	"jsonpath":      22513,
-	"line":          21286,
-	"lseg":          21286,
 	"macaddr":       45813,
 	"macaddr8":      45813,
 	"money":         41578,
-	"path":          21286,
 	"txid_snapshot": -1,
 	"xml":           43355,
 }
@@ -2989,6 +3339,13 @@ func (t *T) Delimiter() string {
 	switch t.Family() {
 	case Geometry.Family(), Geography.Family():
 		return ":"
+	case GeometricFamily:
+		// Box values contain commas, so Postgres uses a different delimiter for
+		// box arrays.
+		if t.Oid() == oid.T_box {
+			return ";"
+		}
+		return ","
 	default:
 		return ","
 	}
@@ -346,6 +346,31 @@ func TestTypes(t *testing.T) {
 			Family: JsonFamily, Oid: oid.T_jsonb, Locale: &emptyLocale}}},
 		{Jsonb, MakeScalar(JsonFamily, oid.T_jsonb, 0, 0, emptyLocale)},
 
+		// JSONPATH
+		{Jsonpath, &T{InternalType: InternalType{
+			Family: JsonpathFamily, Oid: oidext.T_jsonpath, Locale: &emptyLocale}}},
+		{Jsonpath, MakeScalar(JsonpathFamily, oidext.T_jsonpath, 0, 0, emptyLocale)},
+
+		// GEOMETRIC
+		{Point, &T{InternalType: InternalType{
+			Family: GeometricFamily, Oid: oid.T_point, Locale: &emptyLocale}}},
+		{Point, MakeScalar(GeometricFamily, oid.T_point, 0, 0, emptyLocale)},
+		{Polygon, &T{InternalType: InternalType{
+			Family: GeometricFamily, Oid: oid.T_polygon, Locale: &emptyLocale}}},
+		{Polygon, MakeScalar(GeometricFamily, oid.T_polygon, 0, 0, emptyLocale)},
+
+		// RANGE
+		{Int4Range, &T{InternalType: InternalType{
+			Family: RangeFamily, RangeContents: Int4, Oid: oid.T_int4range, Locale: &emptyLocale}}},
+		{TSTZRange, &T{InternalType: InternalType{
+			Family: RangeFamily, RangeContents: TimestampTZ, Oid: oid.T_tstzrange, Locale: &emptyLocale}}},
+		{MakeArray(Int8Range), &T{InternalType: InternalType{
+			Family: ArrayFamily, ArrayContents: Int8Range, Oid: oid.T__int8range, Locale: &emptyLocale}}},
+
+		// MULTIRANGE
+		{DateMultirange, &T{InternalType: InternalType{
+			Family: MultirangeFamily, RangeContents: DateRange, Oid: oidext.T_datemultirange, Locale: &emptyLocale}}},
+
 		// OID
 		{Oid, &T{InternalType: InternalType{
 			Family: OidFamily, Oid: oid.T_oid, Locale: &emptyLocale}}},
@@ -622,6 +647,17 @@ func TestEquivalent(t *testing.T) {
 		{MakeEnum(15210, 15213), MakeEnum(15210, 15213), true},
 		{MakeEnum(15210, 15213), MakeEnum(15150, 15213), false},
 
+		// RANGE
+		{Int4Range, Int4Range, true},
+		{Int4Range, Int8Range, false},
+		{Int8Range, AnyRange, true},
+		{AnyRange, NumRange, true},
+		{MakeRange(115210, 115213, Float), AnyRange, true},
+		{MakeRange(115210, 115213, Float), MakeRange(115150, 115153, Float), false},
+		{Int8Range, Int8Multirange, false},
+		{Int8Multirange, AnyMultirange, true},
+		{Int8Multirange, Int4Multirange, false},
+
 		// UNKNOWN
 		{Unknown, &T{InternalType: InternalType{
 			Family: UnknownFamily, Oid: oid.T_unknown, Locale: &emptyLocale}}, true},
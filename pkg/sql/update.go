@@ -286,6 +286,9 @@ func (u *updateNode) processSourceRow(params runParams, sourceVals tree.Datums)
 	if err := enforceLocalColumnConstraints(u.run.updateValues, u.run.tu.ru.UpdateCols); err != nil {
 		return err
 	}
+	if err := validateColumnValues(params, u.run.updateValues); err != nil {
+		return err
+	}
 
 	// Run the CHECK constraints, if any. CheckHelper will either evaluate the
 	// constraints itself, or else inspect boolean columns from the input that
@@ -431,3 +434,14 @@ func enforceLocalColumnConstraints(row tree.Datums, cols []catalog.Column) error
 	}
 	return nil
 }
+
+// validateColumnValues asserts that the given row values can be written at the
+// active cluster version.
+func validateColumnValues(params runParams, row tree.Datums) error {
+	for _, d := range row {
+		if err := colinfo.ValidateColumnValue(params.ctx, params.ExecCfg().Settings.Version, d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
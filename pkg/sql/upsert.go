@@ -141,6 +141,9 @@ func (n *upsertNode) processSourceRow(params runParams, rowVals tree.Datums) err
 	if err := enforceLocalColumnConstraints(rowVals, n.run.insertCols); err != nil {
 		return err
 	}
+	if err := validateColumnValues(params, rowVals); err != nil {
+		return err
+	}
 
 	// Create a set of partial index IDs to not add or remove entries from.
 	var pm row.PartialIndexUpdateHelper
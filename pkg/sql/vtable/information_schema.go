@@ -793,7 +793,8 @@ CREATE TABLE information_schema.attributes (
 	is_derived_reference_attribute STRING
 )`
 
-// InformationSchemaDomainConstraints is an empty table in the information_schema that is not implemented yet
+// InformationSchemaDomainConstraints describes the schema of the
+// information_schema.domain_constraints table.
 const InformationSchemaDomainConstraints = `
 CREATE TABLE information_schema.domain_constraints (
 	constraint_catalog STRING,
@@ -878,7 +879,8 @@ CREATE TABLE information_schema.usage_privileges (
 	is_grantable STRING
 )`
 
-// InformationSchemaDomains is an empty table in the information_schema that is not implemented yet
+// InformationSchemaDomains describes the schema of the
+// information_schema.domains table.
 const InformationSchemaDomains = `
 CREATE TABLE information_schema.domains (
 	domain_catalog STRING,
@@ -379,6 +379,14 @@ var upgrades = []upgradebase.Upgrade{
 
 	newFirstUpgrade(toCV(clusterversion.V24_1Start)),
 
+	upgrade.NewTenantUpgrade(
+		"create system.publications table",
+		toCV(clusterversion.V24_1_AddSystemPublicationsTable),
+		upgrade.NoPrecondition,
+		systemPublicationsTableMigration,
+		upgrade.RestoreActionNotRequired("publications reference the IDs of the tables of the cluster and are not restored"),
+	),
+
 	// Note: when starting a new release version, the first upgrade (for
 	// Vxy_zStart) must be a newFirstUpgrade. Keep this comment at the bottom.
 }
@@ -128,6 +128,14 @@ const (
 	// Because of the context, they cannot be ambiguous with these other bytes.
 	ascendingNullWithinArrayKey  byte = 0x01
 	descendingNullWithinArrayKey byte = 0xFE
+	// Arrays whose dimensions are not the default (multidimensional arrays,
+	// or one-dimensional arrays with a lower bound other than 1) have their
+	// dimensions encoded between the array key marker and the elements. The
+	// dimensions are preceded by these bytes, which cannot start an element
+	// or terminator encoded in the same direction, so that such arrays sort
+	// after all arrays with default dimensions.
+	ascendingArrayKeyDims  byte = 0xFF
+	descendingArrayKeyDims byte = 0x00
 
 	// Defining different key markers, for the descending designation,
 	// for handling different JSON values.
@@ -1978,7 +1986,8 @@ func PeekLength(b []byte) (int, error) {
 		// ascendingNullWithinArrayKey and descendingNullWithinArrayKey also
 		// contain the same byte values as encodedNotNull and encodedNotNullDesc
 		// respectively, but they cannot be included explicitly in the case
-		// statement.
+		// statement. Similarly, ascendingArrayKeyDims and descendingArrayKeyDims
+		// share their values with encodedNullDesc and encodedNull.
 		return 1, nil
 	case bitArrayMarker, bitArrayDescMarker:
 		terminator := byte(bitArrayDataTerminator)
@@ -2242,6 +2251,17 @@ func prettyPrintFirstValue(dir Direction, b []byte) ([]byte, string, error) {
 		if err != nil {
 			return nil, "", err
 		}
+		if HasArrayKeyDims(buf, encDir) {
+			var lengths, lowerBounds []int64
+			buf, lengths, lowerBounds, err = DecodeArrayKeyDims(buf, encDir)
+			if err != nil {
+				return nil, "", err
+			}
+			for i := range lengths {
+				fmt.Fprintf(&build, "[%d:%d]", lowerBounds[i], lowerBounds[i]+lengths[i]-1)
+			}
+			build.WriteString("=")
+		}
 		build.WriteString("ARRAY[")
 		first := true
 		// Use the array key decoding logic, but instead of calling out
@@ -3592,6 +3612,77 @@ func EncodeArrayKeyTerminator(buf []byte, dir Direction) []byte {
 	}
 }
 
+// EncodeArrayKeyDims adds the dimensions of a key encoded array to buf
+// and returns the new buffer. It must be called immediately after
+// EncodeArrayKeyMarker, and only for arrays whose dimensions are not the
+// default. Each dimension is described by its length and lower bound.
+func EncodeArrayKeyDims(buf []byte, dir Direction, lengths, lowerBounds []int64) []byte {
+	switch dir {
+	case Ascending:
+		buf = append(buf, ascendingArrayKeyDims)
+		buf = EncodeVarintAscending(buf, int64(len(lengths)))
+		for i := range lengths {
+			buf = EncodeVarintAscending(buf, lengths[i])
+			buf = EncodeVarintAscending(buf, lowerBounds[i])
+		}
+		return buf
+	case Descending:
+		buf = append(buf, descendingArrayKeyDims)
+		buf = EncodeVarintDescending(buf, int64(len(lengths)))
+		for i := range lengths {
+			buf = EncodeVarintDescending(buf, lengths[i])
+			buf = EncodeVarintDescending(buf, lowerBounds[i])
+		}
+		return buf
+	default:
+		panic("invalid direction")
+	}
+}
+
+// HasArrayKeyDims returns whether buf, which must directly follow an array
+// key marker, starts with dimensions encoded by EncodeArrayKeyDims.
+func HasArrayKeyDims(buf []byte, dir Direction) bool {
+	expected := ascendingArrayKeyDims
+	if dir == Descending {
+		expected = descendingArrayKeyDims
+	}
+	return len(buf) > 0 && buf[0] == expected
+}
+
+// DecodeArrayKeyDims decodes the dimensions encoded by EncodeArrayKeyDims,
+// returning the lengths and lower bounds of the dimensions along with the
+// remaining bytes.
+func DecodeArrayKeyDims(
+	buf []byte, dir Direction,
+) (_ []byte, lengths, lowerBounds []int64, _ error) {
+	if !HasArrayKeyDims(buf, dir) {
+		return nil, nil, nil, errors.AssertionFailedf("array key does not contain dimensions")
+	}
+	buf = buf[1:]
+	decode := DecodeVarintAscending
+	if dir == Descending {
+		decode = DecodeVarintDescending
+	}
+	buf, numDims, err := decode(buf)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if numDims < 0 || numDims > int64(len(buf)) {
+		return nil, nil, nil, errors.Errorf("invalid number of array dimensions %d", numDims)
+	}
+	lengths = make([]int64, numDims)
+	lowerBounds = make([]int64, numDims)
+	for i := range lengths {
+		if buf, lengths[i], err = decode(buf); err != nil {
+			return nil, nil, nil, err
+		}
+		if buf, lowerBounds[i], err = decode(buf); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	return buf, lengths, lowerBounds, nil
+}
+
 // EncodeNullWithinArrayKey encodes NULL within a key encoded array.
 func EncodeNullWithinArrayKey(buf []byte, dir Direction) []byte {
 	switch dir {
@@ -0,0 +1,532 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package jsonpath
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/errors"
+)
+
+// datetimeType is the type of a datetime item produced by .datetime().
+type datetimeType int
+
+const (
+	dateType datetimeType = iota
+	timeType
+	timeTZType
+	timestampType
+	timestampTZType
+)
+
+// String returns the name of the type as reported by .type().
+func (t datetimeType) String() string {
+	switch t {
+	case dateType:
+		return "date"
+	case timeType:
+		return "time without time zone"
+	case timeTZType:
+		return "time with time zone"
+	case timestampType:
+		return "timestamp without time zone"
+	default:
+		return "timestamp with time zone"
+	}
+}
+
+// shortName returns the name of the type used in error messages.
+func (t datetimeType) shortName() string {
+	switch t {
+	case dateType:
+		return "date"
+	case timeType:
+		return "time"
+	case timeTZType:
+		return "timetz"
+	case timestampType:
+		return "timestamp"
+	default:
+		return "timestamptz"
+	}
+}
+
+func (t datetimeType) hasTZ() bool { return t == timeTZType || t == timestampTZType }
+
+func (t datetimeType) hasDate() bool {
+	return t == dateType || t == timestampType || t == timestampTZType
+}
+
+// datetime is a value produced by the .datetime() item method.
+type datetime struct {
+	typ datetimeType
+	// t holds the value. Values of types without a time zone are stored in
+	// UTC, and values of time types are stored on January 1 of year 0.
+	t time.Time
+}
+
+// String returns the ISO 8601 representation of the value, which is used when
+// the value is returned as JSON.
+func (d datetime) String() string {
+	switch d.typ {
+	case dateType:
+		return d.t.Format("2006-01-02")
+	case timeType:
+		return d.t.Format("15:04:05.999999")
+	case timeTZType:
+		return d.t.Format("15:04:05.999999-07:00")
+	case timestampType:
+		return d.t.Format("2006-01-02T15:04:05.999999")
+	default:
+		return d.t.Format("2006-01-02T15:04:05.999999-07:00")
+	}
+}
+
+// datetimeFields accumulates the fields parsed from a datetime string.
+type datetimeFields struct {
+	hasDate, hasTime, hasTZ bool
+
+	year, month, day     int
+	hour, minute, second int
+	nanos                int
+	// pm is set if a PM meridiem indicator was parsed; hour12 is set if the
+	// hour was parsed on a 12-hour clock.
+	pm, hour12 bool
+	// tzSign is -1 for negative time zone offsets and 1 otherwise.
+	tzSign, tzHour, tzMinute int
+}
+
+// toDatetime validates the fields and converts them to a datetime.
+func (f *datetimeFields) toDatetime() (datetime, bool) {
+	if f.hour12 {
+		if f.hour < 1 || f.hour > 12 {
+			return datetime{}, false
+		}
+		if f.hour == 12 {
+			f.hour = 0
+		}
+		if f.pm {
+			f.hour += 12
+		}
+	}
+	if f.hour > 23 || f.minute > 59 || f.second > 59 || f.tzMinute > 59 {
+		return datetime{}, false
+	}
+	loc := time.UTC
+	if f.hasTZ {
+		offset := f.tzSign * (f.tzHour*3600 + f.tzMinute*60)
+		loc = time.FixedZone("", offset)
+	}
+	year, month, day := 0, 1, 1
+	if f.hasDate {
+		year, month, day = f.year, f.month, f.day
+	}
+	t := time.Date(year, time.Month(month), day, f.hour, f.minute, f.second, f.nanos, loc)
+	if f.hasDate && (t.Year() != year || int(t.Month()) != month || t.Day() != day) {
+		return datetime{}, false
+	}
+	var typ datetimeType
+	switch {
+	case f.hasDate && f.hasTime && f.hasTZ:
+		typ = timestampTZType
+	case f.hasDate && f.hasTime:
+		typ = timestampType
+	case f.hasDate && f.hasTZ:
+		// A date with a time zone is treated as midnight in that zone.
+		typ = timestampTZType
+	case f.hasDate:
+		typ = dateType
+	case f.hasTZ:
+		typ = timeTZType
+	default:
+		typ = timeType
+	}
+	return datetime{typ: typ, t: t}, true
+}
+
+// datetimeScanner reads fields from a datetime string.
+type datetimeScanner struct {
+	s   string
+	pos int
+}
+
+func (s *datetimeScanner) done() bool { return s.pos >= len(s.s) }
+
+// digits reads between 1 and max decimal digits.
+func (s *datetimeScanner) digits(max int) (string, bool) {
+	start := s.pos
+	for s.pos < len(s.s) && s.pos-start < max && isDigit(s.s[s.pos]) {
+		s.pos++
+	}
+	return s.s[start:s.pos], s.pos > start
+}
+
+// number reads between 1 and max decimal digits as an integer.
+func (s *datetimeScanner) number(max int) (int, bool) {
+	d, ok := s.digits(max)
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.Atoi(d)
+	return n, err == nil
+}
+
+// fraction reads up to 9 digits of fractional seconds and returns them as
+// nanoseconds. Digits beyond microsecond precision are rounded.
+func (s *datetimeScanner) fraction() (int, bool) {
+	d, ok := s.digits(9)
+	if !ok {
+		return 0, false
+	}
+	d += strings.Repeat("0", 9-len(d))
+	n, err := strconv.Atoi(d)
+	if err != nil {
+		return 0, false
+	}
+	return (n + 500) / 1000 * 1000, true
+}
+
+func (s *datetimeScanner) byte(c byte) bool {
+	if s.pos < len(s.s) && s.s[s.pos] == c {
+		s.pos++
+		return true
+	}
+	return false
+}
+
+// tz reads a time zone offset of the form +HH[:MM].
+func (s *datetimeScanner) tz(f *datetimeFields) bool {
+	switch {
+	case s.byte('+'):
+		f.tzSign = 1
+	case s.byte('-'):
+		f.tzSign = -1
+	default:
+		return false
+	}
+	var ok bool
+	if f.tzHour, ok = s.number(2); !ok {
+		return false
+	}
+	if s.byte(':') {
+		if f.tzMinute, ok = s.number(2); !ok {
+			return false
+		}
+	}
+	f.hasTZ = true
+	return true
+}
+
+// parseISODatetime parses s using the ISO formats recognized by .datetime()
+// without a template: a date, a time or a timestamp, each optionally with a
+// time zone offset.
+func parseISODatetime(s string) (datetime, bool) {
+	sc := datetimeScanner{s: s}
+	var f datetimeFields
+	var ok bool
+	// Try to read a date first.
+	if f.year, ok = sc.number(9); ok && sc.byte('-') {
+		if f.month, ok = sc.number(2); !ok || !sc.byte('-') {
+			return datetime{}, false
+		}
+		if f.day, ok = sc.number(2); !ok {
+			return datetime{}, false
+		}
+		f.hasDate = true
+		if sc.done() {
+			return f.toDatetime()
+		}
+		if !sc.byte(' ') && !sc.byte('T') {
+			return datetime{}, false
+		}
+	} else {
+		sc.pos = 0
+	}
+	if f.hour, ok = sc.number(2); !ok || !sc.byte(':') {
+		return datetime{}, false
+	}
+	if f.minute, ok = sc.number(2); !ok || !sc.byte(':') {
+		return datetime{}, false
+	}
+	if f.second, ok = sc.number(2); !ok {
+		return datetime{}, false
+	}
+	if sc.byte('.') {
+		if f.nanos, ok = sc.fraction(); !ok {
+			return datetime{}, false
+		}
+	}
+	f.hasTime = true
+	if !sc.done() && (!sc.tz(&f) || !sc.done()) {
+		return datetime{}, false
+	}
+	return f.toDatetime()
+}
+
+// templatePatterns are the template patterns supported by .datetime(), in the
+// order in which they are matched.
+var templatePatterns = []string{
+	"YYYY", "HH24", "HH12", "TZH", "TZM", "A.M.", "P.M.",
+	"MM", "DD", "HH", "MI", "SS", "MS", "US", "AM", "PM",
+}
+
+// parseTemplateDatetime parses s using the given template, which supports a
+// subset of the template patterns of to_timestamp.
+func parseTemplateDatetime(s, template string) (datetime, error) {
+	sc := datetimeScanner{s: s}
+	var f datetimeFields
+	invalidValue := func(pattern string) error {
+		width := 2
+		switch pattern {
+		case "YYYY", "A.M.", "P.M.":
+			width = 4
+		case "MS", "TZH":
+			width = 3
+		case "US":
+			width = 6
+		}
+		rest := sc.s[sc.pos:]
+		if len(rest) > width {
+			rest = rest[:width]
+		}
+		return evalErrorf(pgcode.InvalidDatetimeFormat,
+			"invalid value \"%s\" for \"%s\"", rest, pattern)
+	}
+	for i := 0; i < len(template); {
+		if template[i] == '"' {
+			// Quoted text is matched literally.
+			end := strings.IndexByte(template[i+1:], '"')
+			if end < 0 {
+				end = len(template) - i - 1
+			}
+			lit := template[i+1 : i+1+end]
+			if !strings.HasPrefix(sc.s[sc.pos:], lit) {
+				return datetime{}, evalErrorf(pgcode.InvalidDatetimeFormat,
+					"unmatched format character \"%s\"", lit)
+			}
+			sc.pos += len(lit)
+			i += end + 2
+			continue
+		}
+		var pattern string
+		upper := strings.ToUpper(template[i:])
+		for _, p := range templatePatterns {
+			if strings.HasPrefix(upper, p) {
+				pattern = p
+				break
+			}
+		}
+		if pattern == "" {
+			// Any other character must match the input, except that separators
+			// match any separator.
+			c := template[i]
+			i++
+			if sc.done() {
+				continue
+			}
+			if sc.s[sc.pos] == c || (!isAlnum(c) && !isAlnum(sc.s[sc.pos])) {
+				sc.pos++
+				continue
+			}
+			if c == ' ' {
+				continue
+			}
+			return datetime{}, evalErrorf(pgcode.InvalidDatetimeFormat,
+				"unmatched format character \"%c\"", c)
+		}
+		i += len(pattern)
+		var ok bool
+		switch pattern {
+		case "YYYY":
+			f.year, ok = sc.number(9)
+			f.hasDate = true
+		case "MM":
+			f.month, ok = sc.number(2)
+			f.hasDate = true
+		case "DD":
+			f.day, ok = sc.number(2)
+			f.hasDate = true
+		case "HH24":
+			f.hour, ok = sc.number(2)
+			f.hasTime = true
+		case "HH", "HH12":
+			f.hour, ok = sc.number(2)
+			f.hour12, f.hasTime = true, true
+		case "MI":
+			f.minute, ok = sc.number(2)
+			f.hasTime = true
+		case "SS":
+			f.second, ok = sc.number(2)
+			f.hasTime = true
+		case "MS", "US":
+			max := 3
+			if pattern == "US" {
+				max = 6
+			}
+			var d string
+			d, ok = sc.digits(max)
+			if ok {
+				d += strings.Repeat("0", 9-len(d))
+				f.nanos, _ = strconv.Atoi(d)
+			}
+			f.hasTime = true
+		case "AM", "PM", "A.M.", "P.M.":
+			rest := strings.ToUpper(sc.s[sc.pos:])
+			if strings.HasPrefix(rest, "AM") || strings.HasPrefix(rest, "PM") {
+				f.pm = rest[0] == 'P'
+				sc.pos += 2
+				ok = true
+			} else if strings.HasPrefix(rest, "A.M.") || strings.HasPrefix(rest, "P.M.") {
+				f.pm = rest[0] == 'P'
+				sc.pos += 4
+				ok = true
+			}
+		case "TZH":
+			f.tzSign = 1
+			if sc.byte('-') {
+				f.tzSign = -1
+			} else {
+				sc.byte('+')
+			}
+			f.tzHour, ok = sc.number(2)
+			f.hasTZ = true
+		case "TZM":
+			f.tzMinute, ok = sc.number(2)
+			if f.tzSign == 0 {
+				f.tzSign = 1
+			}
+			f.hasTZ = true
+		}
+		if !ok {
+			return datetime{}, invalidValue(pattern)
+		}
+	}
+	if !sc.done() {
+		return datetime{}, evalErrorf(pgcode.InvalidDatetimeFormat,
+			"trailing characters remain in input string after datetime format")
+	}
+	if f.hasDate && f.year == 0 {
+		f.year = 1
+	}
+	if f.hasDate && f.month == 0 {
+		f.month = 1
+	}
+	if f.hasDate && f.day == 0 {
+		f.day = 1
+	}
+	if f.hasDate && f.hasTZ && !f.hasTime {
+		f.hasTime = true
+	}
+	d, ok := f.toDatetime()
+	if !ok {
+		return datetime{}, evalErrorf(pgcode.DatetimeFieldOverflow,
+			"date/time field value out of range: \"%s\"", s)
+	}
+	return d, nil
+}
+
+func isAlnum(c byte) bool {
+	return isDigit(c) || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// parseDatetime implements the .datetime() item method.
+func parseDatetime(s string, template *string) (datetime, error) {
+	if template != nil {
+		return parseTemplateDatetime(s, *template)
+	}
+	d, ok := parseISODatetime(s)
+	if !ok {
+		return datetime{}, errors.WithHint(
+			evalErrorf(pgcode.InvalidArgumentForSQLJSONDatetimeFunction,
+				"datetime format is not recognized: \"%s\"", s),
+			"Use a datetime template argument to specify the input data format.",
+		)
+	}
+	return d, nil
+}
+
+// castTZ converts a datetime without a time zone to one with a time zone by
+// interpreting it in the session time zone. It returns an error if time zone
+// usage is not allowed.
+func (e *evaluator) castTZ(d datetime, to datetimeType) (datetime, error) {
+	if !e.useTZ {
+		return datetime{}, errors.WithHint(
+			pgerror.Newf(pgcode.InvalidParameterValue,
+				"cannot convert value from %s to %s without time zone usage",
+				d.typ.shortName(), to.shortName()),
+			"Use *_tz() function for time zone support.",
+		)
+	}
+	loc := e.loc
+	if loc == nil {
+		loc = time.UTC
+	}
+	t := d.t
+	if to == timeTZType {
+		// Times are converted using the current offset of the session time zone.
+		_, offset := e.now().In(loc).Zone()
+		loc = time.FixedZone("", offset)
+	}
+	return datetime{
+		typ: to,
+		t:   time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc),
+	}, nil
+}
+
+// compareDatetimes compares two datetimes. ok is false if the values are not
+// comparable, such as a date and a time.
+func (e *evaluator) compareDatetimes(l, r datetime) (cmp int, ok bool, err error) {
+	if l.typ.hasDate() != r.typ.hasDate() {
+		return 0, false, nil
+	}
+	if l.typ == dateType && r.typ == timestampType {
+		l.typ = timestampType
+	} else if l.typ == timestampType && r.typ == dateType {
+		r.typ = timestampType
+	}
+	if l.typ.hasTZ() != r.typ.hasTZ() {
+		to := timestampTZType
+		if !l.typ.hasDate() {
+			to = timeTZType
+		}
+		if l.typ.hasTZ() {
+			r, err = e.castTZ(r, to)
+		} else {
+			l, err = e.castTZ(l, to)
+		}
+		if err != nil {
+			return 0, false, err
+		}
+	}
+	switch {
+	case l.t.Before(r.t):
+		return -1, true, nil
+	case l.t.After(r.t):
+		return 1, true, nil
+	}
+	if l.typ == timeTZType {
+		// Equal times with time zones are ordered by their offsets, with the
+		// larger offset first, as in Postgres.
+		_, lo := l.t.Zone()
+		_, ro := r.t.Zone()
+		switch {
+		case lo > ro:
+			return -1, true, nil
+		case lo < ro:
+			return 1, true, nil
+		}
+	}
+	return 0, true, nil
+}
@@ -0,0 +1,1057 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package jsonpath
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/apd/v3"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/errors"
+)
+
+// errEval marks the errors raised by the evaluation of a jsonpath that are
+// suppressed by silent evaluation, such as structural errors and errors of
+// item methods.
+var errEval = errors.New("jsonpath evaluation error")
+
+// evalErrorf returns a new error marked as a suppressible evaluation error.
+func evalErrorf(code pgcode.Code, format string, args ...interface{}) error {
+	return errors.Mark(pgerror.Newf(code, format, args...), errEval)
+}
+
+// IsSuppressibleError returns true if err was raised by the evaluation of a
+// jsonpath and should be suppressed when the silent argument of the jsonpath
+// functions is true.
+func IsSuppressibleError(err error) bool {
+	return errors.Is(err, errEval)
+}
+
+var (
+	// exactCtx is the decimal context used for operations with exact results.
+	exactCtx = decimalCtx.WithPrecision(0)
+	// decimalCtx is the decimal context used for division.
+	decimalCtx = &apd.Context{
+		Precision:   20,
+		Rounding:    apd.RoundHalfUp,
+		MaxExponent: 2000,
+		MinExponent: -2000,
+		Traps:       apd.DefaultTraps,
+	}
+	// highPrecisionCtx is the decimal context used for the modulo operation,
+	// which requires the integer part of the quotient to be exact.
+	highPrecisionCtx = decimalCtx.WithPrecision(2000)
+)
+
+// EvalOptions contains the optional arguments of Eval.
+type EvalOptions struct {
+	// Vars is an object containing the values of the variables referenced by
+	// the path. It may be nil if the path has no variables.
+	Vars json.JSON
+	// UseTZ allows comparisons between datetime values with and without time
+	// zones, which depend on the session time zone.
+	UseTZ bool
+	// Location is the session time zone. It defaults to UTC.
+	Location *time.Location
+}
+
+// item is a value produced during evaluation: either a JSON value or a
+// datetime produced by .datetime().
+type item struct {
+	j  json.JSON
+	dt *datetime
+}
+
+func jsonItem(j json.JSON) item { return item{j: j} }
+
+// itemKind is the type of an item as used by comparisons.
+type itemKind int
+
+const (
+	nullKind itemKind = iota
+	boolKind
+	numberKind
+	stringKind
+	datetimeKind
+	arrayKind
+	objectKind
+)
+
+func (v item) kind() itemKind {
+	if v.dt != nil {
+		return datetimeKind
+	}
+	switch v.j.Type() {
+	case json.NullJSONType:
+		return nullKind
+	case json.TrueJSONType, json.FalseJSONType:
+		return boolKind
+	case json.NumberJSONType:
+		return numberKind
+	case json.StringJSONType:
+		return stringKind
+	case json.ArrayJSONType:
+		return arrayKind
+	default:
+		return objectKind
+	}
+}
+
+func (v item) isArray() bool  { return v.dt == nil && v.j.Type() == json.ArrayJSONType }
+func (v item) isObject() bool { return v.dt == nil && v.j.Type() == json.ObjectJSONType }
+
+// toJSON converts the item to a JSON value. Datetimes are converted to
+// strings.
+func (v item) toJSON() json.JSON {
+	if v.dt != nil {
+		return json.FromString(v.dt.String())
+	}
+	return v.j
+}
+
+// decimal returns the numeric value of the item, if it is a number.
+func (v item) decimal() (*apd.Decimal, bool) {
+	if v.dt != nil {
+		return nil, false
+	}
+	return v.j.AsDecimal()
+}
+
+// text returns the value of the item, if it is a string.
+func (v item) text() (string, bool) {
+	if v.dt != nil || v.j.Type() != json.StringJSONType {
+		return "", false
+	}
+	s, err := v.j.AsText()
+	if err != nil || s == nil {
+		return "", false
+	}
+	return *s, true
+}
+
+// typeName returns the name of the type of the item, as returned by .type().
+func (v item) typeName() string {
+	if v.dt != nil {
+		return v.dt.typ.String()
+	}
+	switch v.j.Type() {
+	case json.TrueJSONType, json.FalseJSONType:
+		return "boolean"
+	default:
+		return v.j.Type().String()
+	}
+}
+
+// elements returns the elements of an array or the values of an object.
+func (v item) elements() ([]item, error) {
+	if v.isArray() {
+		arr, _ := v.j.AsArray()
+		res := make([]item, len(arr))
+		for i := range arr {
+			res[i] = jsonItem(arr[i])
+		}
+		return res, nil
+	}
+	it, err := v.j.ObjectIter()
+	if err != nil || it == nil {
+		return nil, err
+	}
+	var res []item
+	for it.Next() {
+		res = append(res, jsonItem(it.Value()))
+	}
+	return res, nil
+}
+
+// boolResult is the result of a predicate, which may be unknown.
+type boolResult int
+
+const (
+	boolFalse boolResult = iota
+	boolTrue
+	boolUnknown
+)
+
+func makeBoolResult(b bool) boolResult {
+	if b {
+		return boolTrue
+	}
+	return boolFalse
+}
+
+func (b boolResult) toJSON() json.JSON {
+	switch b {
+	case boolTrue:
+		return json.TrueJSONValue
+	case boolFalse:
+		return json.FalseJSONValue
+	default:
+		return json.NullJSONValue
+	}
+}
+
+// evaluator holds the state of the evaluation of a jsonpath.
+type evaluator struct {
+	root   json.JSON
+	vars   json.JSON
+	strict bool
+	// ignoreStructuralErrors is true in lax mode and while evaluating the
+	// items produced by the .** accessor.
+	ignoreStructuralErrors bool
+	// current is the item being tested by the innermost filter.
+	current item
+	// innermostArraySize is the size of the array being subscripted, which
+	// is referred to by last, or -1 outside of subscripts.
+	innermostArraySize int
+	// lastKeyValueID is the id assigned to the last object converted by
+	// .keyvalue().
+	lastKeyValueID int
+	useTZ          bool
+	loc            *time.Location
+}
+
+// Eval evaluates the jsonpath against target and returns the resulting
+// sequence of JSON values. If an error is returned, the values produced before
+// the error are returned as well.
+func Eval(j Jsonpath, target json.JSON, opts EvalOptions) ([]json.JSON, error) {
+	if opts.Vars != nil && opts.Vars.Type() != json.ObjectJSONType {
+		return nil, errors.WithDetail(
+			pgerror.New(pgcode.InvalidParameterValue, `"vars" argument is not an object`),
+			`Jsonpath parameters should be encoded as key-value pairs of "vars" object.`,
+		)
+	}
+	e := evaluator{
+		root:                   target,
+		vars:                   opts.Vars,
+		strict:                 j.Strict,
+		ignoreStructuralErrors: !j.Strict,
+		current:                jsonItem(target),
+		innermostArraySize:     -1,
+		lastKeyValueID:         -1,
+		useTZ:                  opts.UseTZ,
+		loc:                    opts.Location,
+	}
+	var found []item
+	err := e.execute(j.Path, jsonItem(target), e.autoUnwrap(), &found)
+	res := make([]json.JSON, len(found))
+	for i := range found {
+		res[i] = found[i].toJSON()
+	}
+	return res, err
+}
+
+// autoUnwrap returns true if arrays are automatically unwrapped, which is the
+// case in lax mode.
+func (e *evaluator) autoUnwrap() bool { return !e.strict }
+
+// autoWrap returns true if non-array items are automatically wrapped into
+// singleton arrays by array accessors, which is the case in lax mode.
+func (e *evaluator) autoWrap() bool { return !e.strict }
+
+func (e *evaluator) now() time.Time { return time.Now() }
+
+// execute evaluates p against v and appends the results to found. If unwrap is
+// true, accessors that cannot be applied to an array are applied to each of
+// its elements instead.
+func (e *evaluator) execute(p Path, v item, unwrap bool, found *[]item) error {
+	chain, ok := p.(Paths)
+	if !ok {
+		chain = Paths{p}
+	}
+	return e.executeChain(chain, v, unwrap, found)
+}
+
+// executeNext evaluates the rest of a chain against v, or appends v to found if
+// the chain is empty.
+func (e *evaluator) executeNext(next Paths, v item, found *[]item) error {
+	if len(next) == 0 {
+		*found = append(*found, v)
+		return nil
+	}
+	return e.executeChain(next, v, e.autoUnwrap(), found)
+}
+
+// executeUnwrapArray evaluates chain against each element of the array v.
+func (e *evaluator) executeUnwrapArray(chain Paths, v item, found *[]item) error {
+	elems, err := v.elements()
+	if err != nil {
+		return err
+	}
+	for _, el := range elems {
+		if err := e.executeChain(chain, el, false /* unwrap */, found); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *evaluator) executeChain(chain Paths, v item, unwrap bool, found *[]item) error {
+	next := chain[1:]
+	switch t := chain[0].(type) {
+	case Paths:
+		var res []item
+		if err := e.executeChain(t, v, unwrap, &res); err != nil {
+			return err
+		}
+		for _, r := range res {
+			if err := e.executeNext(next, r, found); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case Root:
+		return e.executeNext(next, jsonItem(e.root), found)
+
+	case Current:
+		return e.executeNext(next, e.current, found)
+
+	case Variable:
+		val, err := e.variable(string(t))
+		if err != nil {
+			return err
+		}
+		return e.executeNext(next, jsonItem(val), found)
+
+	case Scalar:
+		return e.executeNext(next, jsonItem(t.Value), found)
+
+	case Last:
+		if e.innermostArraySize < 0 {
+			return errors.AssertionFailedf("evaluating jsonpath LAST outside of array subscript")
+		}
+		return e.executeNext(next, jsonItem(json.FromInt(e.innermostArraySize-1)), found)
+
+	case Key:
+		if v.isObject() {
+			val, err := v.j.FetchValKey(string(t))
+			if err != nil {
+				return err
+			}
+			if val != nil {
+				return e.executeNext(next, jsonItem(val), found)
+			}
+			if !e.ignoreStructuralErrors {
+				return evalErrorf(pgcode.SQLJSONMemberNotFound,
+					"JSON object does not contain key \"%s\"", string(t))
+			}
+			return nil
+		}
+		if unwrap && v.isArray() {
+			return e.executeUnwrapArray(chain, v, found)
+		}
+		if !e.ignoreStructuralErrors {
+			return evalErrorf(pgcode.SQLJSONMemberNotFound,
+				"jsonpath member accessor can only be applied to an object")
+		}
+		return nil
+
+	case AnyKey:
+		if v.isObject() {
+			elems, err := v.elements()
+			if err != nil {
+				return err
+			}
+			for _, el := range elems {
+				if err := e.executeNext(next, el, found); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		if unwrap && v.isArray() {
+			return e.executeUnwrapArray(chain, v, found)
+		}
+		if !e.ignoreStructuralErrors {
+			return evalErrorf(pgcode.SQLJSONObjectNotFound,
+				"jsonpath wildcard member accessor can only be applied to an object")
+		}
+		return nil
+
+	case AnyArray:
+		if v.isArray() {
+			elems, err := v.elements()
+			if err != nil {
+				return err
+			}
+			for _, el := range elems {
+				if err := e.executeNext(next, el, found); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		if e.autoWrap() {
+			return e.executeNext(next, v, found)
+		}
+		if !e.ignoreStructuralErrors {
+			return evalErrorf(pgcode.SQLJSONArrayNotFound,
+				"jsonpath wildcard array accessor can only be applied to an array")
+		}
+		return nil
+
+	case ArrayList:
+		return e.executeArrayList(t, next, v, found)
+
+	case AnyPath:
+		if t.First == 0 {
+			saved := e.ignoreStructuralErrors
+			e.ignoreStructuralErrors = true
+			err := e.executeNext(next, v, found)
+			e.ignoreStructuralErrors = saved
+			if err != nil {
+				return err
+			}
+		}
+		if v.isArray() || v.isObject() {
+			return e.executeAny(next, v, found, 1, t.First, t.Last)
+		}
+		return nil
+
+	case Filter:
+		if unwrap && v.isArray() {
+			return e.executeUnwrapArray(chain, v, found)
+		}
+		res, err := e.executeNestedBool(t.Condition, v)
+		if err != nil {
+			return err
+		}
+		if res != boolTrue {
+			return nil
+		}
+		return e.executeNext(next, v, found)
+
+	case Method:
+		return e.executeMethod(t, chain, next, v, unwrap, found)
+
+	case Operation:
+		switch t.Type {
+		case OpAdd, OpSub, OpMul, OpDiv, OpMod:
+			res, err := e.executeBinaryArithmetic(t, v)
+			if err != nil {
+				return err
+			}
+			return e.executeNext(next, res, found)
+		case OpPlus, OpMinus:
+			return e.executeUnaryArithmetic(t, next, v, found)
+		}
+		res, err := e.executeBool(t, v)
+		if err != nil {
+			return err
+		}
+		return e.executeNext(next, jsonItem(res.toJSON()), found)
+
+	case Regex:
+		res, err := e.executeBool(t, v)
+		if err != nil {
+			return err
+		}
+		return e.executeNext(next, jsonItem(res.toJSON()), found)
+
+	default:
+		return errors.AssertionFailedf("unhandled jsonpath node %T", t)
+	}
+}
+
+// variable returns the value of the named variable.
+func (e *evaluator) variable(name string) (json.JSON, error) {
+	if e.vars != nil {
+		val, err := e.vars.FetchValKey(name)
+		if err != nil {
+			return nil, err
+		}
+		if val != nil {
+			return val, nil
+		}
+	}
+	return nil, pgerror.Newf(pgcode.UndefinedObject,
+		"could not find jsonpath variable \"%s\"", name)
+}
+
+// executeAny implements the .** accessor by evaluating next against the items
+// at levels first through last below v.
+func (e *evaluator) executeAny(
+	next Paths, v item, found *[]item, level, first, last uint32,
+) error {
+	if level > last {
+		return nil
+	}
+	elems, err := v.elements()
+	if err != nil {
+		return err
+	}
+	for _, el := range elems {
+		isContainer := el.isArray() || el.isObject()
+		if level >= first || (first == AnyPathLast && last == AnyPathLast && !isContainer) {
+			if len(next) > 0 {
+				saved := e.ignoreStructuralErrors
+				e.ignoreStructuralErrors = true
+				err := e.executeChain(next, el, e.autoUnwrap(), found)
+				e.ignoreStructuralErrors = saved
+				if err != nil {
+					return err
+				}
+			} else {
+				*found = append(*found, el)
+			}
+		}
+		if level < last && isContainer {
+			if err := e.executeAny(next, el, found, level+1, first, last); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// executeArrayList implements the [subscript, ...] accessor.
+func (e *evaluator) executeArrayList(a ArrayList, next Paths, v item, found *[]item) error {
+	if !v.isArray() && !e.autoWrap() {
+		if !e.ignoreStructuralErrors {
+			return evalErrorf(pgcode.SQLJSONArrayNotFound,
+				"jsonpath array accessor can only be applied to an array")
+		}
+		return nil
+	}
+	var elems []item
+	if v.isArray() {
+		var err error
+		if elems, err = v.elements(); err != nil {
+			return err
+		}
+	} else {
+		// Non-array items are treated as singleton arrays in lax mode.
+		elems = []item{v}
+	}
+	saved := e.innermostArraySize
+	e.innermostArraySize = len(elems)
+	defer func() { e.innermostArraySize = saved }()
+	for _, r := range a {
+		from, err := e.arrayIndex(r.Start, v)
+		if err != nil {
+			return err
+		}
+		to := from
+		if r.End != nil {
+			if to, err = e.arrayIndex(r.End, v); err != nil {
+				return err
+			}
+		}
+		if !e.ignoreStructuralErrors && (from < 0 || from > to || to >= len(elems)) {
+			return evalErrorf(pgcode.InvalidSQLJSONSubscript,
+				"jsonpath array subscript is out of bounds")
+		}
+		if from < 0 {
+			from = 0
+		}
+		if to >= len(elems) {
+			to = len(elems) - 1
+		}
+		for i := from; i <= to; i++ {
+			if err := e.executeNext(next, elems[i], found); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// arrayIndex evaluates an array subscript, which must produce a single number.
+// The number is truncated to an integer.
+func (e *evaluator) arrayIndex(p Path, v item) (int, error) {
+	var res []item
+	if err := e.execute(p, v, e.autoUnwrap(), &res); err != nil {
+		return 0, err
+	}
+	var d *apd.Decimal
+	var ok bool
+	if len(res) == 1 {
+		d, ok = res[0].decimal()
+	}
+	if !ok {
+		return 0, evalErrorf(pgcode.InvalidSQLJSONSubscript,
+			"jsonpath array subscript is not a single numeric value")
+	}
+	var truncated, frac apd.Decimal
+	d.Modf(&truncated, &frac)
+	i, err := truncated.Int64()
+	if err != nil || i < math.MinInt32 || i > math.MaxInt32 {
+		return 0, evalErrorf(pgcode.InvalidSQLJSONSubscript,
+			"jsonpath array subscript is out of integer range")
+	}
+	return int(i), nil
+}
+
+// executeMethod evaluates an item method.
+func (e *evaluator) executeMethod(
+	m Method, chain, next Paths, v item, unwrap bool, found *[]item,
+) error {
+	if m.Type == TypeMethod {
+		return e.executeNext(next, jsonItem(json.FromString(v.typeName())), found)
+	}
+	if m.Type == SizeMethod {
+		size := 1
+		if v.isArray() {
+			size = v.j.Len()
+		} else if !e.autoWrap() {
+			if !e.ignoreStructuralErrors {
+				return evalErrorf(pgcode.SQLJSONArrayNotFound,
+					"jsonpath item method .%s() can only be applied to an array", m.Type)
+			}
+			return nil
+		}
+		return e.executeNext(next, jsonItem(json.FromInt(size)), found)
+	}
+	// The remaining methods are applied to each element of an array in lax
+	// mode.
+	if unwrap && v.isArray() {
+		return e.executeUnwrapArray(chain, v, found)
+	}
+	switch m.Type {
+	case AbsMethod, FloorMethod, CeilingMethod:
+		d, ok := v.decimal()
+		if !ok {
+			return evalErrorf(pgcode.NonNumericSQLJSONItem,
+				"jsonpath item method .%s() can only be applied to a numeric value", m.Type)
+		}
+		var res apd.Decimal
+		var err error
+		switch m.Type {
+		case AbsMethod:
+			res.Abs(d)
+		case FloorMethod:
+			_, err = exactCtx.Floor(&res, d)
+		case CeilingMethod:
+			_, err = exactCtx.Ceil(&res, d)
+		}
+		if err != nil {
+			return err
+		}
+		if res.IsZero() {
+			res.Negative = false
+		}
+		return e.executeNext(next, jsonItem(json.FromDecimal(res)), found)
+
+	case DoubleMethod:
+		var f float64
+		if d, ok := v.decimal(); ok {
+			var err error
+			if f, err = d.Float64(); err != nil || math.IsInf(f, 0) || math.IsNaN(f) {
+				return evalErrorf(pgcode.NonNumericSQLJSONItem,
+					"numeric argument of jsonpath item method .%s() is out of range for type double precision", m.Type)
+			}
+		} else if s, ok := v.text(); ok {
+			var err error
+			f, err = strconv.ParseFloat(strings.TrimSpace(s), 64)
+			if err != nil {
+				return evalErrorf(pgcode.NonNumericSQLJSONItem,
+					"string argument of jsonpath item method .%s() is not a valid representation of a double precision number", m.Type)
+			}
+			if math.IsInf(f, 0) || math.IsNaN(f) {
+				return evalErrorf(pgcode.NonNumericSQLJSONItem,
+					"string argument of jsonpath item method .%s() is not a valid representation of a double precision number", m.Type)
+			}
+		} else {
+			return evalErrorf(pgcode.NonNumericSQLJSONItem,
+				"jsonpath item method .%s() can only be applied to a string or numeric value", m.Type)
+		}
+		// Like Postgres, convert the float back to a number using 15
+		// significant digits.
+		var res apd.Decimal
+		if _, _, err := res.SetString(strconv.FormatFloat(f, 'g', 15, 64)); err != nil {
+			return err
+		}
+		return e.executeNext(next, jsonItem(json.FromDecimal(res)), found)
+
+	case KeyValueMethod:
+		if !v.isObject() {
+			return evalErrorf(pgcode.SQLJSONObjectNotFound,
+				"jsonpath item method .%s() can only be applied to an object", m.Type)
+		}
+		it, err := v.j.ObjectIter()
+		if err != nil {
+			return err
+		}
+		// Unlike Postgres, which derives ids from the position of the object
+		// within the queried value, ids are assigned sequentially to the
+		// objects in the order in which they are converted.
+		e.lastKeyValueID++
+		id := json.FromInt(e.lastKeyValueID)
+		for it != nil && it.Next() {
+			b := json.NewObjectBuilder(3)
+			b.Add("id", id)
+			b.Add("key", json.FromString(it.Key()))
+			b.Add("value", it.Value())
+			if err := e.executeNext(next, jsonItem(b.Build()), found); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case DatetimeMethod:
+		s, ok := v.text()
+		if !ok {
+			return evalErrorf(pgcode.InvalidArgumentForSQLJSONDatetimeFunction,
+				"jsonpath item method .%s() can only be applied to a string", m.Type)
+		}
+		dt, err := parseDatetime(s, m.Template)
+		if err != nil {
+			return err
+		}
+		return e.executeNext(next, item{dt: &dt}, found)
+
+	default:
+		return errors.AssertionFailedf("unhandled jsonpath method %s", m.Type)
+	}
+}
+
+// executeUnwrapResult evaluates p and, in lax mode, unwraps any arrays in the
+// results.
+func (e *evaluator) executeUnwrapResult(p Path, v item, unwrap bool) ([]item, error) {
+	var res []item
+	err := e.execute(p, v, e.autoUnwrap(), &res)
+	if err != nil || !unwrap || !e.autoUnwrap() {
+		return res, err
+	}
+	var unwrapped []item
+	for _, r := range res {
+		if !r.isArray() {
+			unwrapped = append(unwrapped, r)
+			continue
+		}
+		elems, err := r.elements()
+		if err != nil {
+			return nil, err
+		}
+		unwrapped = append(unwrapped, elems...)
+	}
+	return unwrapped, nil
+}
+
+// executeBinaryArithmetic evaluates a binary arithmetic operation, whose
+// operands must both be single numbers.
+func (e *evaluator) executeBinaryArithmetic(op Operation, v item) (item, error) {
+	operand := func(p Path, side string) (*apd.Decimal, error) {
+		res, err := e.executeUnwrapResult(p, v, true /* unwrap */)
+		if err != nil {
+			return nil, err
+		}
+		if len(res) == 1 {
+			if d, ok := res[0].decimal(); ok {
+				return d, nil
+			}
+		}
+		return nil, evalErrorf(pgcode.SingletonSQLJSONItemRequired,
+			"%s operand of jsonpath operator %s is not a single numeric value", side, op.Type)
+	}
+	l, err := operand(op.Left, "left")
+	if err != nil {
+		return item{}, err
+	}
+	r, err := operand(op.Right, "right")
+	if err != nil {
+		return item{}, err
+	}
+	var res apd.Decimal
+	switch op.Type {
+	case OpAdd:
+		_, err = exactCtx.Add(&res, l, r)
+	case OpSub:
+		_, err = exactCtx.Sub(&res, l, r)
+	case OpMul:
+		_, err = exactCtx.Mul(&res, l, r)
+	case OpDiv, OpMod:
+		if r.IsZero() {
+			return item{}, evalErrorf(pgcode.DivisionByZero, "division by zero")
+		}
+		if op.Type == OpDiv {
+			_, err = decimalCtx.Quo(&res, l, r)
+		} else {
+			_, err = highPrecisionCtx.Rem(&res, l, r)
+		}
+	}
+	if err != nil {
+		return item{}, evalErrorf(pgcode.NumericValueOutOfRange, "%s", err.Error())
+	}
+	return jsonItem(json.FromDecimal(res)), nil
+}
+
+// executeUnaryArithmetic evaluates a unary arithmetic operation, which is
+// applied to each item produced by its operand.
+func (e *evaluator) executeUnaryArithmetic(
+	op Operation, next Paths, v item, found *[]item,
+) error {
+	res, err := e.executeUnwrapResult(op.Left, v, true /* unwrap */)
+	if err != nil {
+		return err
+	}
+	for _, r := range res {
+		d, ok := r.decimal()
+		if !ok {
+			return evalErrorf(pgcode.SQLJSONNumberNotFound,
+				"operand of unary jsonpath operator %s is not a numeric value", op.Type)
+		}
+		if op.Type == OpMinus {
+			var neg apd.Decimal
+			neg.Neg(d)
+			r = jsonItem(json.FromDecimal(neg))
+		}
+		if err := e.executeNext(next, r, found); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// executeNestedBool evaluates the predicate of a filter with v as the current
+// item.
+func (e *evaluator) executeNestedBool(p Path, v item) (boolResult, error) {
+	saved := e.current
+	e.current = v
+	defer func() { e.current = saved }()
+	return e.executeBool(p, v)
+}
+
+// executeBool evaluates a predicate.
+func (e *evaluator) executeBool(p Path, v item) (boolResult, error) {
+	switch t := p.(type) {
+	case Paths:
+		if len(t) == 1 {
+			return e.executeBool(t[0], v)
+		}
+	case Regex:
+		re := t.re
+		if re == nil {
+			var err error
+			if re, err = compileRegex(t.Pattern, t.Flags); err != nil {
+				return boolUnknown, err
+			}
+		}
+		return e.executePredicate(t.Left, nil, v, false, func(l, _ item) (boolResult, error) {
+			s, ok := l.text()
+			if !ok {
+				return boolUnknown, nil
+			}
+			return makeBoolResult(re.MatchString(s)), nil
+		})
+	case Operation:
+		switch t.Type {
+		case OpAnd:
+			l, err := e.executeBool(t.Left, v)
+			if err != nil || l == boolFalse {
+				return l, err
+			}
+			r, err := e.executeBool(t.Right, v)
+			if err != nil || r == boolTrue {
+				return l, err
+			}
+			return r, nil
+		case OpOr:
+			l, err := e.executeBool(t.Left, v)
+			if err != nil || l == boolTrue {
+				return l, err
+			}
+			r, err := e.executeBool(t.Right, v)
+			if err != nil || r == boolFalse {
+				return l, err
+			}
+			return r, nil
+		case OpNot:
+			r, err := e.executeBool(t.Left, v)
+			if err != nil || r == boolUnknown {
+				return r, err
+			}
+			return makeBoolResult(r == boolFalse), nil
+		case OpIsUnknown:
+			r, err := e.executeBool(t.Left, v)
+			if err != nil {
+				return r, err
+			}
+			return makeBoolResult(r == boolUnknown), nil
+		case OpExists:
+			res, err := e.executeUnwrapResult(t.Left, v, false /* unwrap */)
+			if err != nil && !IsSuppressibleError(err) {
+				return boolUnknown, err
+			}
+			if e.strict && err != nil {
+				// In strict mode, all items must be produced without errors.
+				return boolUnknown, nil
+			}
+			if len(res) > 0 {
+				return boolTrue, nil
+			}
+			if err != nil {
+				return boolUnknown, nil
+			}
+			return boolFalse, nil
+		case OpStartsWith:
+			return e.executePredicate(t.Left, t.Right, v, false, func(l, r item) (boolResult, error) {
+				ls, lok := l.text()
+				rs, rok := r.text()
+				if !lok || !rok {
+					return boolUnknown, nil
+				}
+				return makeBoolResult(strings.HasPrefix(ls, rs)), nil
+			})
+		case OpEqual, OpNotEqual, OpLess, OpLessOrEqual, OpGreater, OpGreaterOrEqual:
+			return e.executePredicate(t.Left, t.Right, v, true, func(l, r item) (boolResult, error) {
+				return e.compareItems(t.Type, l, r)
+			})
+		}
+	}
+	return boolUnknown, errors.AssertionFailedf("invalid jsonpath boolean expression %T", p)
+}
+
+// executePredicate evaluates a predicate by applying fn to each pair of items
+// produced by left and right, or to each item produced by left if right is
+// nil. In lax mode the predicate is true if fn is true for any pair, and in
+// strict mode it is unknown if fn is unknown for any pair. Evaluation errors of
+// the operands make the predicate unknown.
+func (e *evaluator) executePredicate(
+	left, right Path, v item, unwrapRight bool, fn func(l, r item) (boolResult, error),
+) (boolResult, error) {
+	lseq, err := e.executeUnwrapResult(left, v, true /* unwrap */)
+	if err != nil {
+		if IsSuppressibleError(err) {
+			return boolUnknown, nil
+		}
+		return boolUnknown, err
+	}
+	rseq := []item{{}}
+	if right != nil {
+		if rseq, err = e.executeUnwrapResult(right, v, unwrapRight); err != nil {
+			if IsSuppressibleError(err) {
+				return boolUnknown, nil
+			}
+			return boolUnknown, err
+		}
+	}
+	found, hadUnknown := false, false
+	for _, l := range lseq {
+		for _, r := range rseq {
+			res, err := fn(l, r)
+			if err != nil {
+				return boolUnknown, err
+			}
+			switch res {
+			case boolUnknown:
+				if e.strict {
+					return boolUnknown, nil
+				}
+				hadUnknown = true
+			case boolTrue:
+				if !e.strict {
+					return boolTrue, nil
+				}
+				found = true
+			}
+		}
+	}
+	if found {
+		return boolTrue, nil
+	}
+	if hadUnknown {
+		return boolUnknown, nil
+	}
+	return boolFalse, nil
+}
+
+// compareItems compares two scalar items. A comparison between null and an
+// item of another type is false (or true for !=), and other comparisons
+// between items of different types, or between arrays or objects, are
+// unknown.
+func (e *evaluator) compareItems(op OperationType, l, r item) (boolResult, error) {
+	lk, rk := l.kind(), r.kind()
+	if lk != rk {
+		if lk == nullKind || rk == nullKind {
+			return makeBoolResult(op == OpNotEqual), nil
+		}
+		return boolUnknown, nil
+	}
+	var cmp int
+	switch lk {
+	case nullKind:
+	case boolKind:
+		lb, rb := l.j.Type() == json.TrueJSONType, r.j.Type() == json.TrueJSONType
+		switch {
+		case lb == rb:
+		case rb:
+			cmp = -1
+		default:
+			cmp = 1
+		}
+	case numberKind:
+		ld, _ := l.decimal()
+		rd, _ := r.decimal()
+		cmp = ld.Cmp(rd)
+	case stringKind:
+		ls, _ := l.text()
+		rs, _ := r.text()
+		cmp = strings.Compare(ls, rs)
+	case datetimeKind:
+		c, ok, err := e.compareDatetimes(*l.dt, *r.dt)
+		if err != nil {
+			return boolUnknown, err
+		}
+		if !ok {
+			return boolUnknown, nil
+		}
+		cmp = c
+	default:
+		return boolUnknown, nil
+	}
+	switch op {
+	case OpEqual:
+		return makeBoolResult(cmp == 0), nil
+	case OpNotEqual:
+		return makeBoolResult(cmp != 0), nil
+	case OpLess:
+		return makeBoolResult(cmp < 0), nil
+	case OpLessOrEqual:
+		return makeBoolResult(cmp <= 0), nil
+	case OpGreater:
+		return makeBoolResult(cmp > 0), nil
+	default:
+		return makeBoolResult(cmp >= 0), nil
+	}
+}
+
+// Exists returns true if the jsonpath produces any items for target. It
+// implements jsonb_path_exists and the @? operator.
+func Exists(j Jsonpath, target json.JSON, opts EvalOptions) (bool, error) {
+	res, err := Eval(j, target, opts)
+	if err != nil && (j.Strict || len(res) == 0) {
+		return false, err
+	}
+	return len(res) > 0, nil
+}
+
+// Match returns the result of a jsonpath predicate for target, which may be
+// NULL. It implements jsonb_path_match and the @@ operator.
+func Match(j Jsonpath, target json.JSON, opts EvalOptions) (json.JSON, error) {
+	res, err := Eval(j, target, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(res) == 1 {
+		switch res[0].Type() {
+		case json.TrueJSONType, json.FalseJSONType, json.NullJSONType:
+			return res[0], nil
+		}
+	}
+	return nil, evalErrorf(pgcode.SingletonSQLJSONItemRequired,
+		"single boolean result is expected")
+}
@@ -0,0 +1,317 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package jsonpath
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEval(t *testing.T) {
+	tcs := []struct {
+		target   string
+		path     string
+		vars     string
+		expected []string
+		// expectedErr is set if the evaluation must fail.
+		expectedErr string
+	}{
+		// Accessors.
+		{target: `{"a": 12, "b": {"a": 13}}`, path: `$.a`, expected: []string{`12`}},
+		{target: `{"a": 12, "b": {"a": 13}}`, path: `$.b`, expected: []string{`{"a": 13}`}},
+		{target: `{"a": 12, "b": {"a": 13}}`, path: `$.*`, expected: []string{`12`, `{"a": 13}`}},
+		{target: `{"a": 12, "b": {"a": 13}}`, path: `lax $.*.a`, expected: []string{`13`}},
+		{target: `[12, {"a": 13}, {"b": 14}]`, path: `lax $[*].a`, expected: []string{`13`}},
+		{target: `[12, {"a": 13}, {"b": 14}]`, path: `lax $[*].*`, expected: []string{`13`, `14`}},
+		{target: `[12, {"a": 13}, {"b": 14}]`, path: `lax $[0 to 10].a`, expected: []string{`13`}},
+		{
+			target:   `[12, {"a": 13}, {"b": 14}, "ccc", true]`,
+			path:     `$[2.5 - 1 to $.size() - 2]`,
+			expected: []string{`{"a": 13}`, `{"b": 14}`, `"ccc"`},
+		},
+		{target: `1`, path: `lax $[0]`, expected: []string{`1`}},
+		{target: `1`, path: `lax $[*]`, expected: []string{`1`}},
+		{target: `[1,2,3]`, path: `lax $[*]`, expected: []string{`1`, `2`, `3`}},
+		{target: `1`, path: `lax $.a`},
+		{target: `[]`, path: `lax $.a`},
+		{target: `{}`, path: `lax $.a`},
+		{target: `[1]`, path: `$[0.9]`, expected: []string{`1`}},
+		{target: `[1]`, path: `$[1.2]`},
+		{target: `[]`, path: `$[last]`},
+		{target: `[1,2,3]`, path: `$[last]`, expected: []string{`3`}},
+		{target: `[1,2,3]`, path: `$[last - 1]`, expected: []string{`2`}},
+		{target: `[1,2,3]`, path: `$[last ? (@.type() == "number")]`, expected: []string{`3`}},
+		{target: `{"a": {"b": 1}}`, path: `lax $.**`, expected: []string{`{"a": {"b": 1}}`, `{"b": 1}`, `1`}},
+		{target: `{"a": {"b": 1}}`, path: `lax $.**{0}`, expected: []string{`{"a": {"b": 1}}`}},
+		{target: `{"a": {"b": 1}}`, path: `lax $.**{1 to last}`, expected: []string{`{"b": 1}`, `1`}},
+		{target: `{"a": {"b": 1}}`, path: `lax $.**{last}`, expected: []string{`1`}},
+		{target: `{"a": {"b": 1}}`, path: `lax $.**{3 to last}`},
+		{target: `{"a": {"b": 1}}`, path: `lax $.**{1}.b ? (@ > 0)`, expected: []string{`1`}},
+		{target: `{"a": {"c": {"b": 1}}}`, path: `lax $.**{1}.b ? (@ > 0)`},
+		{target: `{"a": {"c": {"b": 1}}}`, path: `lax $.**{2 to 3}.b ? (@ > 0)`, expected: []string{`1`}},
+
+		// Structural errors in strict mode.
+		{target: `1`, path: `strict $.a`, expectedErr: `jsonpath member accessor can only be applied to an object`},
+		{target: `1`, path: `strict $.*`, expectedErr: `jsonpath wildcard member accessor can only be applied to an object`},
+		{target: `{}`, path: `strict $.a`, expectedErr: `JSON object does not contain key "a"`},
+		{target: `1`, path: `strict $[1]`, expectedErr: `jsonpath array accessor can only be applied to an array`},
+		{target: `1`, path: `strict $[*]`, expectedErr: `jsonpath wildcard array accessor can only be applied to an array`},
+		{target: `[]`, path: `strict $[1]`, expectedErr: `jsonpath array subscript is out of bounds`},
+		{target: `[]`, path: `strict $["a"]`, expectedErr: `jsonpath array subscript is not a single numeric value`},
+		{target: `[1]`, path: `lax $[10000000000000000]`, expectedErr: `jsonpath array subscript is out of integer range`},
+		{target: `[1,2,3]`, path: `$[last ? (@.type() == "string")]`, expectedErr: `jsonpath array subscript is not a single numeric value`},
+
+		// Variables.
+		{target: `{"a": 10}`, path: `$ ? (@.a < $value)`, expectedErr: `could not find jsonpath variable "value"`},
+		{target: `{"a": 10}`, path: `$ ? (@.a < $value)`, vars: `1`, expectedErr: `"vars" argument is not an object`},
+		{target: `{"a": 10}`, path: `$ ? (@.a < $value)`, vars: `{"value": 13}`, expected: []string{`{"a": 10}`}},
+		{target: `{"a": 10}`, path: `$ ? (@.a < $value)`, vars: `{"value": 8}`},
+		{target: `[10,11,12,13,14,15]`, path: `$[*] ? (@ < $value)`, vars: `{"value": 13}`, expected: []string{`10`, `11`, `12`}},
+		{target: `[10,11,12,13,14,15]`, path: `$[0,1] ? (@ < $x.value)`, vars: `{"x": {"value": 13}}`, expected: []string{`10`, `11`}},
+
+		// Filters and comparisons.
+		{target: `[1,"1",2,"2",null]`, path: `$[*] ? (@ == "1")`, expected: []string{`"1"`}},
+		{target: `[1,"1",2,"2",null]`, path: `$[*] ? (@ == $value)`, vars: `{"value": null}`, expected: []string{`null`}},
+		{target: `[1, "2", null]`, path: `$[*] ? (@ != null)`, expected: []string{`1`, `"2"`}},
+		{target: `{}`, path: `$ ? (@ == @)`},
+		{target: `{"g": {"x": 2}}`, path: `$.g ? (exists (@.x))`, expected: []string{`{"x": 2}`}},
+		{target: `{"g": {"x": 2}}`, path: `$.g ? (exists (@.y))`},
+		{target: `{"g": [{"x": 2}, {"y": 3}]}`, path: `lax $.g ? (exists (@.x + "3"))`},
+		{
+			target:   `{"g": [{"x": 2}, {"y": 3}]}`,
+			path:     `lax $.g ? ((exists (@.x + "3")) is unknown)`,
+			expected: []string{`{"x": 2}`, `{"y": 3}`},
+		},
+		{target: `{"g": [{"x": 2}, {"y": 3}]}`, path: `strict $.g[*] ? ((exists (@.x)) is unknown)`, expected: []string{`{"y": 3}`}},
+		{target: `{"g": [{"x": 2}, {"y": 3}]}`, path: `strict $.g ? (exists (@[*].x))`},
+		{target: `[1,2,0,3]`, path: `$[*] ? (2 / @ > 0)`, expected: []string{`1`, `2`, `3`}},
+		{target: `[1,2,0,3]`, path: `$[*] ? ((2 / @ > 0) is unknown)`, expected: []string{`0`}},
+		{target: `["", "a", "abc", "abcabc"]`, path: `$[*] ? (@ starts with "abc")`, expected: []string{`"abc"`, `"abcabc"`}},
+		{target: `[null, 1, "abd", "abdabc"]`, path: `lax $[*] ? ((@ starts with "abc") is unknown)`, expected: []string{`null`, `1`}},
+		{
+			target:   `[null, 1, "abc", "abd", "aBdC", "abdacb", "babc"]`,
+			path:     `lax $[*] ? (@ like_regex "^ab.*c" flag "i")`,
+			expected: []string{`"abc"`, `"aBdC"`, `"abdacb"`},
+		},
+		{target: `["a\\b", "^a\\b$"]`, path: `lax $[*] ? (@ like_regex "a\\b" flag "q")`, expected: []string{`"a\\b"`, `"^a\\b$"`}},
+
+		// Predicates as expressions.
+		{target: `2`, path: `$ > 1`, expected: []string{`true`}},
+		{target: `2`, path: `$ <= 1`, expected: []string{`false`}},
+		{target: `2`, path: `$ == "2"`, expected: []string{`null`}},
+		{target: `[1, 2, 3]`, path: `($[*] > 2) ? (@ == true)`, expected: []string{`true`}},
+		{target: `[1, 2, 3]`, path: `strict ($[*].a > 3).type()`, expected: []string{`"null"`}},
+
+		// Arithmetic.
+		{target: `{"a": [2]}`, path: `lax $.a * 3`, expected: []string{`6`}},
+		{target: `{"a": [2, 3, 4]}`, path: `lax -$.a`, expected: []string{`-2`, `-3`, `-4`}},
+		{target: `{"a": 2.5}`, path: `-($.a * $.a).floor() % 4.3`, expected: []string{`-1.7`}},
+		{target: `{"a": 2}`, path: `($.a - 5).abs() + 10`, expected: []string{`13`}},
+		{target: `0`, path: `1 / $`, expectedErr: `division by zero`},
+		{target: `0`, path: `-(3 + 1 % $)`, expectedErr: `division by zero`},
+		{target: `1`, path: `$ + "2"`, expectedErr: `right operand of jsonpath operator + is not a single numeric value`},
+		{target: `[1, 2]`, path: `3 * $`, expectedErr: `right operand of jsonpath operator * is not a single numeric value`},
+		{target: `"a"`, path: `-$`, expectedErr: `operand of unary jsonpath operator - is not a numeric value`},
+		{target: `{"a": [1, 2]}`, path: `lax $.a * 3`, expectedErr: `left operand of jsonpath operator * is not a single numeric value`},
+
+		// Item methods.
+		{
+			target:   `[null,1,true,"a",[],{}]`,
+			path:     `$[*].type()`,
+			expected: []string{`"null"`, `"number"`, `"boolean"`, `"string"`, `"array"`, `"object"`},
+		},
+		{
+			target:   `[1,null,true,"11",[],[1],[1,2,3],{},{"a":1,"b":2}]`,
+			path:     `lax $[*].size()`,
+			expected: []string{`1`, `1`, `1`, `1`, `0`, `1`, `3`, `1`, `1`},
+		},
+		{target: `[1,[2]]`, path: `strict $[*].size()`, expectedErr: `jsonpath item method .size() can only be applied to an array`},
+		{target: `[0, 1, -2, -3.4, 5.6]`, path: `$[*].floor()`, expected: []string{`0`, `1`, `-2`, `-4`, `5`}},
+		{target: `[0, 1, -2, -3.4, 5.6]`, path: `$[*].ceiling().abs()`, expected: []string{`0`, `1`, `2`, `3`, `6`}},
+		{target: `"1.2"`, path: `$.ceiling()`, expectedErr: `jsonpath item method .ceiling() can only be applied to a numeric value`},
+		{target: `"1.23"`, path: `$.double()`, expected: []string{`1.23`}},
+		{target: `null`, path: `$.double()`, expectedErr: `jsonpath item method .double() can only be applied to a string or numeric value`},
+		{
+			target:      `"1.23aaa"`,
+			path:        `$.double()`,
+			expectedErr: `string argument of jsonpath item method .double() is not a valid representation of a double precision number`,
+		},
+		{
+			target:   `{"a": 1, "b": [1, 2]}`,
+			path:     `$.keyvalue()`,
+			expected: []string{`{"id": 0, "key": "a", "value": 1}`, `{"id": 0, "key": "b", "value": [1, 2]}`},
+		},
+		{
+			target:   `[{"a": 1}, {"b": 2}]`,
+			path:     `lax $.keyvalue()`,
+			expected: []string{`{"id": 0, "key": "a", "value": 1}`, `{"id": 1, "key": "b", "value": 2}`},
+		},
+		{target: `[{},1]`, path: `$[*].keyvalue()`, expectedErr: `jsonpath item method .keyvalue() can only be applied to an object`},
+
+		// Datetimes.
+		{target: `"2017-03-10"`, path: `$.datetime().type()`, expected: []string{`"date"`}},
+		{target: `"2017-03-10 12:34:56"`, path: `$.datetime()`, expected: []string{`"2017-03-10T12:34:56"`}},
+		{target: `"2017-03-10 12:34:56+3"`, path: `$.datetime()`, expected: []string{`"2017-03-10T12:34:56+03:00"`}},
+		{target: `"2017-03-10 12:34:56.789+3:10"`, path: `$.datetime()`, expected: []string{`"2017-03-10T12:34:56.789+03:10"`}},
+		{target: `"12:34:56"`, path: `$.datetime().type()`, expected: []string{`"time without time zone"`}},
+		{target: `"12:34:56+3"`, path: `$.datetime()`, expected: []string{`"12:34:56+03:00"`}},
+		{target: `"10-03-2017"`, path: `$.datetime("dd-mm-yyyy")`, expected: []string{`"2017-03-10"`}},
+		{
+			target:   `"10-03-2017 12:34 +05:20"`,
+			path:     `$.datetime("dd-mm-yyyy HH24:MI TZH:TZM")`,
+			expected: []string{`"2017-03-10T12:34:00+05:20"`},
+		},
+		{target: `"12:34 PM"`, path: `$.datetime("HH:MI AM")`, expected: []string{`"12:34:00"`}},
+		{target: `"bogus"`, path: `$.datetime()`, expectedErr: `datetime format is not recognized: "bogus"`},
+		{target: `1`, path: `$.datetime()`, expectedErr: `jsonpath item method .datetime() can only be applied to a string`},
+		{target: `"aaaa"`, path: `$.datetime("HH24")`, expectedErr: `invalid value "aa" for "HH24"`},
+		{
+			target:      `"10-03-2017 12:34"`,
+			path:        `$.datetime("dd-mm-yyyy")`,
+			expectedErr: `trailing characters remain in input string after datetime format`,
+		},
+		{
+			target:   `["2017-03-09", "2017-03-10", "2017-03-10 00:00:00", "2017-03-10 12:34:56"]`,
+			path:     `$[*].datetime() ? (@ == "10.03.2017".datetime("dd.mm.yyyy"))`,
+			expected: []string{`"2017-03-10"`, `"2017-03-10T00:00:00"`},
+		},
+		{
+			target:      `["2017-03-10", "2017-03-10 03:00:00+03"]`,
+			path:        `$[*].datetime() ? (@ == "10.03.2017".datetime("dd.mm.yyyy"))`,
+			expectedErr: `cannot convert value from date to timestamptz without time zone usage`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.path, func(t *testing.T) {
+			j, err := Parse(tc.path)
+			require.NoError(t, err)
+			target, err := json.ParseJSON(tc.target)
+			require.NoError(t, err)
+			var opts EvalOptions
+			if tc.vars != "" {
+				opts.Vars, err = json.ParseJSON(tc.vars)
+				require.NoError(t, err)
+			}
+			res, err := Eval(j, target, opts)
+			if tc.expectedErr != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			actual := make([]string, len(res))
+			for i := range res {
+				actual[i] = res[i].String()
+			}
+			if len(tc.expected) == 0 {
+				require.Empty(t, actual)
+			} else {
+				require.Equal(t, tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestEvalTZ(t *testing.T) {
+	j := MustParse(`$[*].datetime() ? (@ < "10.03.2017".datetime("dd.mm.yyyy"))`)
+	target, err := json.ParseJSON(
+		`["2017-03-09", "2017-03-10", "2017-03-10 01:02:03+04", "2017-03-10 03:00:00+03"]`,
+	)
+	require.NoError(t, err)
+
+	_, err = Eval(j, target, EvalOptions{})
+	require.Error(t, err)
+	require.False(t, IsSuppressibleError(err))
+
+	res, err := Eval(j, target, EvalOptions{UseTZ: true, Location: time.UTC})
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+	require.Equal(t, `"2017-03-09"`, res[0].String())
+	require.Equal(t, `"2017-03-10T01:02:03+04:00"`, res[1].String())
+}
+
+func TestExistsAndMatch(t *testing.T) {
+	for _, tc := range []struct {
+		target string
+		path   string
+		// exists and match are the expected results, where "error" means that
+		// a suppressible error is expected.
+		exists string
+		match  string
+	}{
+		{target: `{"a": 12}`, path: `$.a`, exists: `true`, match: `error`},
+		{target: `{"a": 12}`, path: `$.b`, exists: `false`, match: `error`},
+		{target: `{"a": 12}`, path: `$.b + 2`, exists: `error`, match: `error`},
+		{target: `{"a": 12}`, path: `strict $.b`, exists: `error`, match: `error`},
+		{target: `[{"a": 1}, {"a": 2}, 3]`, path: `lax $[*].a`, exists: `true`, match: `error`},
+		{target: `[{"a": 1}, {"a": 2}, 3]`, path: `strict $[*].a`, exists: `error`, match: `error`},
+		{target: `2`, path: `$ > 1`, exists: `true`, match: `true`},
+		{target: `2`, path: `$ <= 1`, exists: `true`, match: `false`},
+		{target: `2`, path: `$ == "2"`, exists: `true`, match: `null`},
+		{target: `[{"a": 1}, {"a": 2}, 3]`, path: `strict exists($[*].a)`, exists: `true`, match: `null`},
+		{target: `{}`, path: `$`, exists: `true`, match: `error`},
+	} {
+		t.Run(tc.path, func(t *testing.T) {
+			j, err := Parse(tc.path)
+			require.NoError(t, err)
+			target, err := json.ParseJSON(tc.target)
+			require.NoError(t, err)
+
+			exists, err := Exists(j, target, EvalOptions{})
+			if tc.exists == "error" {
+				require.True(t, IsSuppressibleError(err), "expected suppressible error, got %v", err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.exists == "true", exists)
+			}
+
+			match, err := Match(j, target, EvalOptions{})
+			if tc.match == "error" {
+				require.True(t, IsSuppressibleError(err), "expected suppressible error, got %v", err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.match, match.String())
+			}
+		})
+	}
+}
+
+func TestEvalErrorCodes(t *testing.T) {
+	for _, tc := range []struct {
+		target string
+		path   string
+		code   pgcode.Code
+	}{
+		{`{}`, `strict $.a`, pgcode.SQLJSONMemberNotFound},
+		{`1`, `strict $.*`, pgcode.SQLJSONObjectNotFound},
+		{`1`, `strict $[*]`, pgcode.SQLJSONArrayNotFound},
+		{`[]`, `strict $[0]`, pgcode.InvalidSQLJSONSubscript},
+		{`[1, 2]`, `$ + 1`, pgcode.SingletonSQLJSONItemRequired},
+		{`"a"`, `-$`, pgcode.SQLJSONNumberNotFound},
+		{`"a"`, `$.abs()`, pgcode.NonNumericSQLJSONItem},
+		{`1`, `$.datetime()`, pgcode.InvalidArgumentForSQLJSONDatetimeFunction},
+		{`1`, `$ / 0`, pgcode.DivisionByZero},
+		{`1`, `$x`, pgcode.UndefinedObject},
+	} {
+		t.Run(tc.path, func(t *testing.T) {
+			target, err := json.ParseJSON(tc.target)
+			require.NoError(t, err)
+			_, err = Eval(MustParse(tc.path), target, EvalOptions{})
+			require.Error(t, err)
+			require.Equal(t, tc.code, pgerror.GetPGCode(err))
+		})
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package jsonpath
+
+import "github.com/cockroachdb/cockroach/pkg/util/json"
+
+// maxIndexContainments is the maximum number of values returned by
+// RequiredContainments.
+const maxIndexContainments = 16
+
+// RequiredContainments returns a set of JSON values with the property that
+// whenever the @@ operator (or the @? operator, if exists is true) returns true
+// for a target and j, the target contains (@>) at least one of the values. It
+// is used to constrain JSON inverted indexes. ok is false if no such set can be
+// derived from j.
+//
+// The following forms of jsonpath are supported, where the chains of keys have
+// at least one key between them and the scalar may be on either side of ==:
+//
+//	[lax|strict] $.k1.k2 == scalar             (@@)
+//	[lax|strict] $.k1.k2 ? (@.k3.k4 == scalar) (@?)
+//
+// In lax mode, arrays are automatically unwrapped before each key accessor,
+// before a filter and before a comparison, so the target may contain the
+// value at each of these levels either directly or nested in arrays.
+func RequiredContainments(j Jsonpath, exists bool) (_ []json.JSON, ok bool) {
+	var steps []indexStep
+	var val json.JSON
+	numKeys := 0
+	addKeys := func(keys []string) {
+		for _, k := range keys {
+			steps = append(steps, indexStep{wrap: true}, indexStep{key: k})
+		}
+		numKeys += len(keys)
+	}
+	if exists {
+		chain, ok := j.Path.(Paths)
+		if !ok || len(chain) < 2 {
+			return nil, false
+		}
+		filter, ok := chain[len(chain)-1].(Filter)
+		if !ok {
+			return nil, false
+		}
+		keys, ok := keyChain(chain[:len(chain)-1], false /* current */)
+		if !ok {
+			return nil, false
+		}
+		addKeys(keys)
+		steps = append(steps, indexStep{wrap: true})
+		var currentKeys []string
+		if currentKeys, val, ok = equalityOperands(filter.Condition, true /* current */); !ok {
+			return nil, false
+		}
+		addKeys(currentKeys)
+	} else {
+		var keys []string
+		if keys, val, ok = equalityOperands(j.Path, false /* current */); !ok {
+			return nil, false
+		}
+		addKeys(keys)
+	}
+	steps = append(steps, indexStep{wrap: true})
+	if numKeys == 0 {
+		return nil, false
+	}
+
+	// Build the values from the innermost level outwards. In lax mode, n
+	// consecutive levels at which arrays are unwrapped allow the value to be
+	// nested in up to n arrays.
+	vals := []json.JSON{val}
+	for i := len(steps) - 1; i >= 0; i-- {
+		if !steps[i].wrap {
+			for k, v := range vals {
+				b := json.NewObjectBuilder(1)
+				b.Add(steps[i].key, v)
+				vals[k] = b.Build()
+			}
+			continue
+		}
+		n := 1
+		for i > 0 && steps[i-1].wrap {
+			n++
+			i--
+		}
+		if j.Strict {
+			continue
+		}
+		if len(vals)*(n+1) > maxIndexContainments {
+			return nil, false
+		}
+		for _, v := range vals {
+			for k := 0; k < n; k++ {
+				b := json.NewArrayBuilder(1)
+				b.Add(v)
+				v = b.Build()
+				vals = append(vals, v)
+			}
+		}
+	}
+	return vals, true
+}
+
+// indexStep is a level of the values returned by RequiredContainments: either
+// an object with a single key, or a level at which arrays are unwrapped in lax
+// mode.
+type indexStep struct {
+	key  string
+	wrap bool
+}
+
+// equalityOperands returns the keys and the scalar of a predicate of the form
+// start.k1.k2 == scalar or scalar == start.k1.k2, where start is @ if current
+// is true and $ otherwise.
+func equalityOperands(p Path, current bool) (keys []string, val json.JSON, ok bool) {
+	op, ok := p.(Operation)
+	if !ok || op.Type != OpEqual {
+		return nil, nil, false
+	}
+	if val, ok = scalarValue(op.Right); ok {
+		keys, ok = keyChain(op.Left, current)
+	} else if val, ok = scalarValue(op.Left); ok {
+		keys, ok = keyChain(op.Right, current)
+	}
+	return keys, val, ok
+}
+
+// keyChain returns the keys of an accessor chain of the form start.k1.k2,
+// where start is @ if current is true and $ otherwise.
+func keyChain(p Path, current bool) ([]string, bool) {
+	chain, ok := p.(Paths)
+	if !ok {
+		chain = Paths{p}
+	}
+	if len(chain) == 0 {
+		return nil, false
+	}
+	switch chain[0].(type) {
+	case Root:
+		ok = !current
+	case Current:
+		ok = current
+	default:
+		ok = false
+	}
+	if !ok {
+		return nil, false
+	}
+	keys := make([]string, 0, len(chain)-1)
+	for _, p := range chain[1:] {
+		k, ok := p.(Key)
+		if !ok {
+			return nil, false
+		}
+		keys = append(keys, string(k))
+	}
+	return keys, true
+}
+
+// scalarValue returns the value of a literal.
+func scalarValue(p Path) (json.JSON, bool) {
+	if chain, ok := p.(Paths); ok {
+		if len(chain) != 1 {
+			return nil, false
+		}
+		p = chain[0]
+	}
+	s, ok := p.(Scalar)
+	if !ok {
+		return nil, false
+	}
+	return s.Value, true
+}
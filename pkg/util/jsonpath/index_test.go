@@ -0,0 +1,122 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package jsonpath
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequiredContainments(t *testing.T) {
+	tcs := []struct {
+		path   string
+		exists bool
+		// expected is nil if no containments can be derived from the path.
+		expected []string
+	}{
+		{path: `strict $.a == 1`, expected: []string{`{"a": 1}`}},
+		{path: `strict 1 == $.a.b`, expected: []string{`{"a": {"b": 1}}`}},
+		{path: `strict $.a ? (@.b == "x")`, exists: true, expected: []string{`{"a": {"b": "x"}}`}},
+		{path: `strict $ ? (@.a == null)`, exists: true, expected: []string{`{"a": null}`}},
+		{
+			path:     `$.a == true`,
+			expected: []string{`{"a": true}`, `{"a": [true]}`, `[{"a": true}]`, `[{"a": [true]}]`},
+		},
+		{
+			path:   `$.a ? (@ == 1)`,
+			exists: true,
+			expected: []string{
+				`{"a": 1}`, `{"a": [1]}`, `{"a": [[1]]}`, `[{"a": 1}]`, `[{"a": [1]}]`, `[{"a": [[1]]}]`,
+			},
+		},
+
+		// Unsupported paths.
+		{path: `$.a == 1`, exists: true},
+		{path: `$.a ? (@.b == 1)`},
+		{path: `$ == 1`},
+		{path: `$ ? (@ == 1)`, exists: true},
+		{path: `$.a != 1`},
+		{path: `$.a == $.b`},
+		{path: `$.a[0] == 1`},
+		{path: `$.a.b.c.d == 1`},
+		{path: `$.a ? (@.b == 1 && @.c == 2)`, exists: true},
+		{path: `$.a ? (@.b == 1).c`, exists: true},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.path, func(t *testing.T) {
+			j := MustParse(tc.path)
+			res, ok := RequiredContainments(j, tc.exists)
+			if tc.expected == nil {
+				require.False(t, ok)
+				return
+			}
+			require.True(t, ok)
+			actual := make([]string, len(res))
+			for i := range res {
+				actual[i] = res[i].String()
+			}
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+// TestRequiredContainmentsTargets verifies that every target for which a path
+// matches contains one of the values returned by RequiredContainments.
+func TestRequiredContainmentsTargets(t *testing.T) {
+	targets := []string{
+		`1`, `[]`, `{}`, `{"a": 1}`, `{"a": 1.0}`, `{"a": [1]}`, `{"a": [[1]]}`, `[{"a": 1}]`,
+		`[[{"a": 1}]]`, `{"a": {"b": 1}}`, `{"a": [{"b": 1}]}`, `[{"a": [{"b": [1]}]}]`,
+		`{"a": {"b": [[1]]}}`, `{"a": [[{"b": 1}]]}`, `{"a": {"b": 2}, "c": 1}`,
+	}
+	paths := []struct {
+		path   string
+		exists bool
+	}{
+		{path: `$.a == 1`},
+		{path: `strict $.a == 1`},
+		{path: `$.a.b == 1`},
+		{path: `strict 1 == $.a.b`},
+		{path: `$.a ? (@ == 1)`, exists: true},
+		{path: `strict $.a ? (@ == 1)`, exists: true},
+		{path: `$ ? (@.a.b == 1)`, exists: true},
+		{path: `$.a ? (@.b == 1)`, exists: true},
+		{path: `strict $.a ? (@.b == 1)`, exists: true},
+	}
+	for _, p := range paths {
+		j := MustParse(p.path)
+		vals, ok := RequiredContainments(j, p.exists)
+		require.True(t, ok, p.path)
+		for _, target := range targets {
+			tj, err := json.ParseJSON(target)
+			require.NoError(t, err)
+			var matches bool
+			if p.exists {
+				matches, err = Exists(j, tj, EvalOptions{})
+			} else {
+				var res json.JSON
+				res, err = Match(j, tj, EvalOptions{})
+				matches = err == nil && res.Type() == json.TrueJSONType
+			}
+			if !matches {
+				continue
+			}
+			contained := false
+			for _, v := range vals {
+				c, err := json.Contains(tj, v)
+				require.NoError(t, err)
+				contained = contained || c
+			}
+			require.True(t, contained, "%s does not contain any containment of %s", target, p.path)
+		}
+	}
+}
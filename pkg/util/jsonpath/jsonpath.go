@@ -0,0 +1,478 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package jsonpath implements the SQL/JSON path language, which is used to
+// query JSON values. See
+// https://www.postgresql.org/docs/current/functions-json.html#FUNCTIONS-SQLJSON-PATH
+// for a description of the language.
+package jsonpath
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+)
+
+// Jsonpath is a parsed SQL/JSON path expression.
+type Jsonpath struct {
+	// Strict is true if the path is evaluated in strict mode, in which
+	// structural errors are raised. Otherwise the path is evaluated in lax mode,
+	// in which arrays are automatically unwrapped and structural errors are
+	// suppressed.
+	Strict bool
+	// Path is the root of the expression tree.
+	Path Path
+}
+
+// String returns the canonical text representation of the jsonpath, which
+// matches the output of Postgres.
+func (j Jsonpath) String() string {
+	var buf strings.Builder
+	if j.Strict {
+		buf.WriteString("strict ")
+	}
+	if j.Path != nil {
+		j.Path.format(&buf, false /* inKey */, true /* parens */)
+	}
+	return buf.String()
+}
+
+// Path is a node in a jsonpath expression tree.
+type Path interface {
+	// format writes the text representation of the node to buf. inKey is true
+	// if the node follows another accessor, in which case member accessors are
+	// prefixed with a dot. parens is true if the node must be surrounded by
+	// parentheses if it is an operation.
+	format(buf *strings.Builder, inKey bool, parens bool)
+}
+
+// Paths is a chain of accessors, such as $.a[*].b. The first element is a
+// primary expression that produces the initial items, and each following
+// element is applied to the items produced by the previous one.
+type Paths []Path
+
+// Root is the $ expression, which refers to the JSON value being queried.
+type Root struct{}
+
+// Current is the @ expression, which refers to the item being tested by the
+// innermost filter.
+type Current struct{}
+
+// Last is the last expression, which refers to the last index of the array
+// being subscripted.
+type Last struct{}
+
+// Variable is a $name expression, which refers to a value in the vars object
+// passed to the jsonpath functions.
+type Variable string
+
+// Scalar is a literal value: a number, string, boolean or null.
+type Scalar struct {
+	Value json.JSON
+}
+
+// Key is the .name member accessor.
+type Key string
+
+// AnyKey is the .* wildcard member accessor.
+type AnyKey struct{}
+
+// AnyArray is the [*] wildcard array element accessor.
+type AnyArray struct{}
+
+// AnyPathLast is the level used by an AnyPath to represent "last", which is
+// the deepest level of the JSON value.
+const AnyPathLast = math.MaxUint32
+
+// AnyPath is the .** recursive wildcard member accessor, which returns the
+// items at all levels between First and Last (inclusive) below the current
+// item.
+type AnyPath struct {
+	First, Last uint32
+}
+
+// ArrayIndexRange is a single subscript of an ArrayList. End is nil if the
+// subscript is a single index rather than a range.
+type ArrayIndexRange struct {
+	Start, End Path
+}
+
+// ArrayList is the [subscript, ...] array element accessor.
+type ArrayList []ArrayIndexRange
+
+// Filter is the ?(predicate) filter expression, which passes through the
+// items for which the predicate is true.
+type Filter struct {
+	Condition Path
+}
+
+// MethodType is the type of an item method.
+type MethodType int
+
+const (
+	// TypeMethod returns the type of the item as a string.
+	TypeMethod MethodType = iota
+	// SizeMethod returns the number of elements in an array.
+	SizeMethod
+	// DoubleMethod converts a number or string to a floating point number.
+	DoubleMethod
+	// CeilingMethod rounds a number up.
+	CeilingMethod
+	// FloorMethod rounds a number down.
+	FloorMethod
+	// AbsMethod returns the absolute value of a number.
+	AbsMethod
+	// KeyValueMethod converts an object to a sequence of key-value pairs.
+	KeyValueMethod
+	// DatetimeMethod converts a string to a datetime value.
+	DatetimeMethod
+)
+
+var methodNames = [...]string{
+	TypeMethod:     "type",
+	SizeMethod:     "size",
+	DoubleMethod:   "double",
+	CeilingMethod:  "ceiling",
+	FloorMethod:    "floor",
+	AbsMethod:      "abs",
+	KeyValueMethod: "keyvalue",
+	DatetimeMethod: "datetime",
+}
+
+func (m MethodType) String() string { return methodNames[m] }
+
+// Method is an item method call, such as .size(). Template is the optional
+// argument of .datetime().
+type Method struct {
+	Type     MethodType
+	Template *string
+}
+
+// OperationType is the type of an Operation.
+type OperationType int
+
+const (
+	// OpAnd is the && operator.
+	OpAnd OperationType = iota
+	// OpOr is the || operator.
+	OpOr
+	// OpNot is the ! operator.
+	OpNot
+	// OpIsUnknown is the (predicate) is unknown test.
+	OpIsUnknown
+	// OpExists is the exists (path) test.
+	OpExists
+	// OpEqual is the == operator.
+	OpEqual
+	// OpNotEqual is the != (or <>) operator.
+	OpNotEqual
+	// OpLess is the < operator.
+	OpLess
+	// OpLessOrEqual is the <= operator.
+	OpLessOrEqual
+	// OpGreater is the > operator.
+	OpGreater
+	// OpGreaterOrEqual is the >= operator.
+	OpGreaterOrEqual
+	// OpStartsWith is the starts with operator.
+	OpStartsWith
+	// OpAdd is the binary + operator.
+	OpAdd
+	// OpSub is the binary - operator.
+	OpSub
+	// OpMul is the * operator.
+	OpMul
+	// OpDiv is the / operator.
+	OpDiv
+	// OpMod is the % operator.
+	OpMod
+	// OpPlus is the unary + operator.
+	OpPlus
+	// OpMinus is the unary - operator.
+	OpMinus
+)
+
+var operationNames = [...]string{
+	OpAnd:            "&&",
+	OpOr:             "||",
+	OpNot:            "!",
+	OpIsUnknown:      "is unknown",
+	OpExists:         "exists",
+	OpEqual:          "==",
+	OpNotEqual:       "!=",
+	OpLess:           "<",
+	OpLessOrEqual:    "<=",
+	OpGreater:        ">",
+	OpGreaterOrEqual: ">=",
+	OpStartsWith:     "starts with",
+	OpAdd:            "+",
+	OpSub:            "-",
+	OpMul:            "*",
+	OpDiv:            "/",
+	OpMod:            "%",
+	OpPlus:           "+",
+	OpMinus:          "-",
+}
+
+func (o OperationType) String() string { return operationNames[o] }
+
+// isUnary returns true if the operation has a single operand, which is stored
+// in Left.
+func (o OperationType) isUnary() bool {
+	switch o {
+	case OpNot, OpIsUnknown, OpExists, OpPlus, OpMinus:
+		return true
+	}
+	return false
+}
+
+// isPredicate returns true if the operation produces a boolean result.
+func (o OperationType) isPredicate() bool {
+	switch o {
+	case OpAnd, OpOr, OpNot, OpIsUnknown, OpExists, OpEqual, OpNotEqual, OpLess,
+		OpLessOrEqual, OpGreater, OpGreaterOrEqual, OpStartsWith:
+		return true
+	}
+	return false
+}
+
+// priority returns the binding strength of the operation, which determines
+// where parentheses are needed when it is formatted.
+func (o OperationType) priority() int {
+	switch o {
+	case OpOr:
+		return 0
+	case OpAnd:
+		return 1
+	case OpEqual, OpNotEqual, OpLess, OpLessOrEqual, OpGreater, OpGreaterOrEqual, OpStartsWith:
+		return 2
+	case OpAdd, OpSub:
+		return 3
+	case OpMul, OpDiv, OpMod:
+		return 4
+	case OpPlus, OpMinus:
+		return 5
+	}
+	return 6
+}
+
+// Operation is a unary or binary operation. Right is nil for unary
+// operations.
+type Operation struct {
+	Type        OperationType
+	Left, Right Path
+}
+
+// Regex is the like_regex predicate.
+type Regex struct {
+	Left    Path
+	Pattern string
+	Flags   string
+
+	re *regexp.Regexp
+}
+
+// priority returns the binding strength of the node when it is an operand of
+// an operation.
+func priority(p Path) int {
+	switch t := p.(type) {
+	case Operation:
+		return t.Type.priority()
+	case Paths:
+		if len(t) == 1 {
+			return priority(t[0])
+		}
+	}
+	return 6
+}
+
+// isPredicate returns true if the node produces a boolean result.
+func isPredicate(p Path) bool {
+	switch t := p.(type) {
+	case Operation:
+		return t.Type.isPredicate()
+	case Regex:
+		return true
+	case Paths:
+		return len(t) == 1 && isPredicate(t[0])
+	}
+	return false
+}
+
+func (p Paths) format(buf *strings.Builder, inKey bool, parens bool) {
+	for i, elem := range p {
+		if s, ok := elem.(Scalar); ok && i == 0 && len(p) > 1 && s.Value.Type() == json.NumberJSONType {
+			// A number that is followed by accessors is parenthesized, so that
+			// the accessors are not lexed as part of the number.
+			buf.WriteByte('(')
+			s.format(buf, inKey, parens)
+			buf.WriteByte(')')
+		} else if i == 0 {
+			// An operation that is followed by accessors must always be
+			// parenthesized.
+			elem.format(buf, inKey, parens || len(p) > 1)
+		} else {
+			elem.format(buf, true /* inKey */, true /* parens */)
+		}
+	}
+}
+
+func (Root) format(buf *strings.Builder, _ bool, _ bool) { buf.WriteByte('$') }
+
+func (Current) format(buf *strings.Builder, _ bool, _ bool) { buf.WriteByte('@') }
+
+func (Last) format(buf *strings.Builder, _ bool, _ bool) { buf.WriteString("last") }
+
+func (v Variable) format(buf *strings.Builder, _ bool, _ bool) {
+	buf.WriteByte('$')
+	writeQuoted(buf, string(v))
+}
+
+func (s Scalar) format(buf *strings.Builder, _ bool, _ bool) {
+	if s.Value.Type() == json.NumberJSONType {
+		d, _ := s.Value.AsDecimal()
+		buf.WriteString(d.Text('f'))
+		return
+	}
+	buf.WriteString(s.Value.String())
+}
+
+func (k Key) format(buf *strings.Builder, inKey bool, _ bool) {
+	if inKey {
+		buf.WriteByte('.')
+	}
+	writeQuoted(buf, string(k))
+}
+
+func (AnyKey) format(buf *strings.Builder, inKey bool, _ bool) {
+	if inKey {
+		buf.WriteByte('.')
+	}
+	buf.WriteByte('*')
+}
+
+func (AnyArray) format(buf *strings.Builder, _ bool, _ bool) { buf.WriteString("[*]") }
+
+func (a AnyPath) format(buf *strings.Builder, inKey bool, _ bool) {
+	if inKey {
+		buf.WriteByte('.')
+	}
+	level := func(l uint32) string {
+		if l == AnyPathLast {
+			return "last"
+		}
+		return fmt.Sprint(l)
+	}
+	switch {
+	case a.First == 0 && a.Last == AnyPathLast:
+		buf.WriteString("**")
+	case a.First == a.Last:
+		fmt.Fprintf(buf, "**{%s}", level(a.First))
+	default:
+		fmt.Fprintf(buf, "**{%s to %s}", level(a.First), level(a.Last))
+	}
+}
+
+func (a ArrayList) format(buf *strings.Builder, _ bool, _ bool) {
+	buf.WriteByte('[')
+	for i, r := range a {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		r.Start.format(buf, false /* inKey */, false /* parens */)
+		if r.End != nil {
+			buf.WriteString(" to ")
+			r.End.format(buf, false /* inKey */, false /* parens */)
+		}
+	}
+	buf.WriteByte(']')
+}
+
+func (f Filter) format(buf *strings.Builder, _ bool, _ bool) {
+	buf.WriteString("?(")
+	f.Condition.format(buf, false /* inKey */, false /* parens */)
+	buf.WriteByte(')')
+}
+
+func (m Method) format(buf *strings.Builder, _ bool, _ bool) {
+	buf.WriteByte('.')
+	buf.WriteString(m.Type.String())
+	buf.WriteByte('(')
+	if m.Template != nil {
+		writeQuoted(buf, *m.Template)
+	}
+	buf.WriteByte(')')
+}
+
+func (o Operation) format(buf *strings.Builder, _ bool, parens bool) {
+	switch o.Type {
+	case OpNot:
+		buf.WriteString("!(")
+		o.Left.format(buf, false /* inKey */, false /* parens */)
+		buf.WriteByte(')')
+	case OpIsUnknown:
+		buf.WriteByte('(')
+		o.Left.format(buf, false /* inKey */, false /* parens */)
+		buf.WriteString(") is unknown")
+	case OpExists:
+		buf.WriteString("exists (")
+		o.Left.format(buf, false /* inKey */, false /* parens */)
+		buf.WriteByte(')')
+	case OpPlus, OpMinus:
+		if parens {
+			buf.WriteByte('(')
+		}
+		buf.WriteString(o.Type.String())
+		o.Left.format(buf, false /* inKey */, priority(o.Left) <= o.Type.priority())
+		if parens {
+			buf.WriteByte(')')
+		}
+	default:
+		if parens {
+			buf.WriteByte('(')
+		}
+		o.Left.format(buf, false /* inKey */, priority(o.Left) <= o.Type.priority())
+		buf.WriteByte(' ')
+		buf.WriteString(o.Type.String())
+		buf.WriteByte(' ')
+		o.Right.format(buf, false /* inKey */, priority(o.Right) <= o.Type.priority())
+		if parens {
+			buf.WriteByte(')')
+		}
+	}
+}
+
+func (r Regex) format(buf *strings.Builder, _ bool, parens bool) {
+	if parens {
+		buf.WriteByte('(')
+	}
+	r.Left.format(buf, false /* inKey */, priority(r.Left) <= priority(r))
+	buf.WriteString(" like_regex ")
+	writeQuoted(buf, r.Pattern)
+	if r.Flags != "" {
+		buf.WriteString(" flag ")
+		writeQuoted(buf, r.Flags)
+	}
+	if parens {
+		buf.WriteByte(')')
+	}
+}
+
+// writeQuoted writes s to buf as a double-quoted string, escaped in the same
+// way as a JSON string.
+func writeQuoted(buf *strings.Builder, s string) {
+	var b bytes.Buffer
+	json.FromString(s).Format(&b)
+	buf.Write(b.Bytes())
+}
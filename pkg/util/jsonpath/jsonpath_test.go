@@ -0,0 +1,161 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package jsonpath
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	for _, tc := range []struct {
+		input    string
+		expected string
+	}{
+		{`$`, `$`},
+		{`strict $`, `strict $`},
+		{`lax $`, `$`},
+		{`$.a`, `$."a"`},
+		{`$.a.v`, `$."a"."v"`},
+		{`$.a.*`, `$."a".*`},
+		{`$.*[*]`, `$.*[*]`},
+		{`$.a[*][*]`, `$."a"[*][*]`},
+		{`$[*][0].a.b`, `$[*][0]."a"."b"`},
+		{`$."a b"`, `$."a b"`},
+		{`$.a.**.b`, `$."a".**."b"`},
+		{`$.a.**{2}.b`, `$."a".**{2}."b"`},
+		{`$.a.**{2 to 2}.b`, `$."a".**{2}."b"`},
+		{`$.a.**{2 to 5}.b`, `$."a".**{2 to 5}."b"`},
+		{`$.a.**{5 to last}.b`, `$."a".**{5 to last}."b"`},
+		{`$.a.**{last}.b`, `$."a".**{last}."b"`},
+		{`$.a.**{last to 5}.b`, `$."a".**{last to 5}."b"`},
+
+		// Arithmetic.
+		{`$+1`, `($ + 1)`},
+		{`$-1`, `($ - 1)`},
+		{`$--+1`, `($ - -1)`},
+		{`$.a/+-1`, `($."a" / -1)`},
+		{`1 * 2 + 4 % -3 != false`, `(1 * 2 + 4 % -3 != false)`},
+		{`($).a.b`, `$."a"."b"`},
+		{`($.a.b).c.d`, `$."a"."b"."c"."d"`},
+		{`($.a.b + -$.x.y).c.d`, `($."a"."b" + -$."x"."y")."c"."d"`},
+		{`(-+$.a.b).c.d`, `(-(+$."a"."b"))."c"."d"`},
+		{`1 + ($.a.b + 2).c.d`, `(1 + ($."a"."b" + 2)."c"."d")`},
+		{`1 + ($.a.b > 2).c.d`, `(1 + ($."a"."b" > 2)."c"."d")`},
+		{`(($))`, `$`},
+		{
+			`((($ + 1)).a + ((2)).b ? ((((@ > 1)) || (exists(@.c)))))`,
+			`(($ + 1)."a" + (2)."b"?(@ > 1 || exists (@."c")))`,
+		},
+
+		// Strings and escapes.
+		{`"\b\f\r\n\t\v\"'\\"`, `"\b\f\r\n\t\u000b\"'\\"`},
+		{`"\x50g\u{53}\u{051}\u{00004C}"`, `"PgSQL"`},
+		{`$.foo\x50g\u{53}\u{051}\u{00004C}\t\"bar`, `$."fooPgSQL\t\"bar"`},
+		{`"\z"`, `"z"`},
+		{`"😀"`, `"😀"`},
+
+		// Filters and predicates.
+		{`$.g ? ($.a == 1)`, `$."g"?($."a" == 1)`},
+		{`$.g ? (@.a == 1 || @.a == 4 && @.b == 7)`, `$."g"?(@."a" == 1 || @."a" == 4 && @."b" == 7)`},
+		{
+			`$.g ? (@.a == 1 || !(@.x >= 123 || @.a == 4) && @.b == 7)`,
+			`$."g"?(@."a" == 1 || !(@."x" >= 123 || @."a" == 4) && @."b" == 7)`,
+		},
+		{`$.g ? (@.x >= @[*]?(@.a > "abc"))`, `$."g"?(@."x" >= @[*]?(@."a" > "abc"))`},
+		{`$.g ? ((@.x >= 123 || @.a == 4) is unknown)`, `$."g"?((@."x" >= 123 || @."a" == 4) is unknown)`},
+		{`$.g ? (exists (@.x ? (@ == 14)))`, `$."g"?(exists (@."x"?(@ == 14)))`},
+		{`$.g ? (+@.x >= +-(+@.a + 2))`, `$."g"?(+@."x" >= +(-(+@."a" + 2)))`},
+		{`$ ? (!exists(@.a))`, `$?(!(exists (@."a")))`},
+		{`$ < 1`, `($ < 1)`},
+		{`($ < 1) || $.a.b <= $x`, `($ < 1 || $."a"."b" <= $"x")`},
+		{`$ ? (@ starts with "abc")`, `$?(@ starts with "abc")`},
+		{`$ ? (@ starts with $var)`, `$?(@ starts with $"var")`},
+		{`$ ? (@ like_regex "pattern")`, `$?(@ like_regex "pattern")`},
+		{`$ ? (@ like_regex "pattern" flag "")`, `$?(@ like_regex "pattern")`},
+		{`$ ? (@ like_regex "pattern" flag "isim")`, `$?(@ like_regex "pattern" flag "ism")`},
+		{`$ ? (@ like_regex "pattern" flag "iq")`, `$?(@ like_regex "pattern" flag "iq")`},
+
+		// Variables and subscripts.
+		{`$a`, `$"a"`},
+		{`$a.b`, `$"a"."b"`},
+		{`$.g ? (@.zip == $zip)`, `$."g"?(@."zip" == $"zip")`},
+		{`$.a[1,2, 3 to 16]`, `$."a"[1,2,3 to 16]`},
+		{`$.a[$a + 1, ($b[*]) to -($[0] * 2)]`, `$."a"[$"a" + 1,$"b"[*] to -($[0] * 2)]`},
+		{`$.a[$.a.size() - 3]`, `$."a"[$."a".size() - 3]`},
+		{`"last"`, `"last"`},
+		{`$.last`, `$."last"`},
+		{`$[last]`, `$[last]`},
+		{`$[$[0] ? (last > 0)]`, `$[$[0]?(last > 0)]`},
+
+		// Methods.
+		{`null.type()`, `null.type()`},
+		{`1.type()`, `(1).type()`},
+		{`1.2.type()`, `(1.2).type()`},
+		{`"aaa".type()`, `"aaa".type()`},
+		{`true.type()`, `true.type()`},
+		{`$.double().floor().ceiling().abs()`, `$.double().floor().ceiling().abs()`},
+		{`$.keyvalue().key`, `$.keyvalue()."key"`},
+		{`$.a.type`, `$."a"."type"`},
+		{`$.datetime()`, `$.datetime()`},
+		{`$.datetime("datetime template")`, `$.datetime("datetime template")`},
+
+		// Numbers.
+		{`$ ? (@.a < +1)`, `$?(@."a" < 1)`},
+		{`$ ? (@.a < -.1)`, `$?(@."a" < -0.1)`},
+		{`$ ? (@.a < 1e1)`, `$?(@."a" < 10)`},
+		{`$ ? (@.a < 10.1e+1)`, `$?(@."a" < 101)`},
+		{`0.000e1`, `0.00`},
+		{`0.0010e-1`, `0.00010`},
+		{`.001e1`, `0.01`},
+		{`1.2.e`, `(1.2)."e"`},
+	} {
+		t.Run(tc.input, func(t *testing.T) {
+			j, err := Parse(tc.input)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, j.String())
+
+			// The output must parse to the same jsonpath.
+			j, err = Parse(tc.expected)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, j.String())
+		})
+	}
+}
+
+func TestParseError(t *testing.T) {
+	for _, tc := range []struct {
+		input    string
+		expected string
+	}{
+		{``, `syntax error at end of jsonpath input`},
+		{`$.a  +`, `syntax error at end of jsonpath input`},
+		{`$ ? (@ == 1`, `syntax error at end of jsonpath input`},
+		{`"abc`, `unexpected end of quoted string at end of jsonpath input`},
+		{`1a`, `syntax error at or near "1a" of jsonpath input`},
+		{`00`, `syntax error at or near "00" of jsonpath input`},
+		{`last`, `LAST is allowed only in array subscripts`},
+		{`$ ? (last > 0)`, `LAST is allowed only in array subscripts`},
+		{`@ + 1`, `@ is not allowed in root expressions`},
+		{`$ ? (@ like_regex "(invalid pattern")`, `invalid regular expression`},
+		{`$ ? (@ like_regex "pattern" flag "xsms")`, `XQuery "x" flag (expanded regular expressions) is not implemented`},
+		{`$ ? (@ like_regex "pattern" flag "a")`, `invalid input syntax for type jsonpath`},
+		{`"\u{110000}"`, `invalid Unicode escape value in jsonpath input`},
+	} {
+		t.Run(tc.input, func(t *testing.T) {
+			_, err := Parse(tc.input)
+			require.Error(t, err)
+			require.Contains(t, pgerror.FullError(err), tc.expected)
+		})
+	}
+}
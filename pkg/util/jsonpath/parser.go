@@ -0,0 +1,959 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package jsonpath
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cockroachdb/apd/v3"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/errors"
+)
+
+// tokenKind is the kind of a lexical token of a jsonpath.
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	// tokIdent is an unquoted identifier, which may be a keyword.
+	tokIdent
+	// tokString is a double-quoted string.
+	tokString
+	// tokNumber is a numeric literal.
+	tokNumber
+	// tokVariable is a $name or $"name" variable reference.
+	tokVariable
+	// tokOp is an operator or punctuation character.
+	tokOp
+)
+
+type token struct {
+	kind tokenKind
+	// raw is the text of the token in the input, used in error messages.
+	raw string
+	// val is the value of the token. For strings and variables it is the
+	// unescaped value; otherwise it is the same as raw.
+	val string
+}
+
+// operators lists the multi-character operators, which must be matched before
+// their single-character prefixes.
+var operators = []string{
+	"==", "!=", "<>", "<=", ">=", "&&", "||", "**",
+	"<", ">", "!", "=", "*", "+", "-", "/", "%", "(", ")", "[", "]", "{", "}",
+	",", ".", "?", "@", "$", ":", "#",
+}
+
+// isIdentChar returns true if c may be part of an unquoted identifier.
+func isIdentChar(c byte) bool {
+	switch c {
+	case '?', '%', '$', '.', '[', ']', '{', '}', '(', ')', '|', '&', '!', '=', '<',
+		'>', '@', '#', ',', '*', ':', '-', '+', '/', '\\', '"', ' ', '\t', '\n', '\r', '\f':
+		return false
+	}
+	return true
+}
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
+
+// lex splits the input into tokens.
+func lex(s string) ([]token, error) {
+	var toks []token
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f':
+			i++
+
+		case c == '"':
+			val, n, err := lexString(s[i:])
+			if err != nil {
+				return nil, err
+			}
+			toks = append(toks, token{kind: tokString, raw: s[i : i+n], val: val})
+			i += n
+
+		case c == '$' && i+1 < len(s) && s[i+1] == '"':
+			val, n, err := lexString(s[i+1:])
+			if err != nil {
+				return nil, err
+			}
+			toks = append(toks, token{kind: tokVariable, raw: s[i : i+1+n], val: val})
+			i += 1 + n
+
+		case c == '$' && i+1 < len(s) && isIdentChar(s[i+1]):
+			j := i + 1
+			for j < len(s) && isIdentChar(s[j]) {
+				j++
+			}
+			toks = append(toks, token{kind: tokVariable, raw: s[i:j], val: s[i+1 : j]})
+			i = j
+
+		case isDigit(c) || (c == '.' && i+1 < len(s) && isDigit(s[i+1])):
+			j := i
+			for j < len(s) && isDigit(s[j]) {
+				j++
+			}
+			if j < len(s) && s[j] == '.' && (j+1 >= len(s) || isDigit(s[j+1]) || j == i) {
+				j++
+				for j < len(s) && isDigit(s[j]) {
+					j++
+				}
+			}
+			if j < len(s) && (s[j] == 'e' || s[j] == 'E') {
+				k := j + 1
+				if k < len(s) && (s[k] == '+' || s[k] == '-') {
+					k++
+				}
+				if k < len(s) && isDigit(s[k]) {
+					for k < len(s) && isDigit(s[k]) {
+						k++
+					}
+					j = k
+				}
+			}
+			if c == '0' && i+1 < len(s) && isDigit(s[i+1]) {
+				// Integers may not have leading zeros.
+				return nil, syntaxErrorAt(s[i:j])
+			}
+			if j < len(s) && isIdentChar(s[j]) {
+				// Numbers may not be directly followed by identifier characters.
+				k := j
+				for k < len(s) && isIdentChar(s[k]) {
+					k++
+				}
+				return nil, syntaxErrorAt(s[i:k])
+			}
+			toks = append(toks, token{kind: tokNumber, raw: s[i:j], val: s[i:j]})
+			i = j
+
+		case isIdentChar(c) || c == '\\':
+			val, n, err := lexIdent(s[i:])
+			if err != nil {
+				return nil, err
+			}
+			toks = append(toks, token{kind: tokIdent, raw: s[i : i+n], val: val})
+			i += n
+
+		default:
+			var op string
+			for _, o := range operators {
+				if strings.HasPrefix(s[i:], o) {
+					op = o
+					break
+				}
+			}
+			if op == "" {
+				// The only remaining character is a lone '|' or '&'.
+				op = s[i : i+1]
+			}
+			toks = append(toks, token{kind: tokOp, raw: op, val: op})
+			i += len(op)
+		}
+	}
+	return append(toks, token{kind: tokEOF}), nil
+}
+
+// lexString lexes the double-quoted string at the start of s, returning its
+// unescaped value and the number of bytes consumed.
+func lexString(s string) (string, int, error) {
+	var b strings.Builder
+	for i := 1; i < len(s); {
+		c := s[i]
+		switch c {
+		case '"':
+			return b.String(), i + 1, nil
+		case '\\':
+			if i+1 >= len(s) {
+				return "", 0, errorAtEnd("unexpected end of quoted string")
+			}
+			n, err := lexEscape(s[i+1:], &b)
+			if err != nil {
+				return "", 0, err
+			}
+			i += 1 + n
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+	return "", 0, errorAtEnd("unexpected end of quoted string")
+}
+
+// lexIdent lexes the unquoted identifier at the start of s, which may contain
+// escape sequences, returning its unescaped value and the number of bytes
+// consumed.
+func lexIdent(s string) (string, int, error) {
+	var b strings.Builder
+	i := 0
+	for i < len(s) {
+		switch c := s[i]; {
+		case c == '\\':
+			if i+1 >= len(s) {
+				return "", 0, errorAtEnd("unexpected end after backslash")
+			}
+			n, err := lexEscape(s[i+1:], &b)
+			if err != nil {
+				return "", 0, err
+			}
+			i += 1 + n
+		case isIdentChar(c):
+			b.WriteByte(c)
+			i++
+		default:
+			return b.String(), i, nil
+		}
+	}
+	return b.String(), i, nil
+}
+
+// lexEscape decodes the backslash escape sequence whose first character
+// (following the backslash) is at the start of s, writing the result to b and
+// returning the number of bytes consumed.
+func lexEscape(s string, b *strings.Builder) (int, error) {
+	switch e := s[0]; e {
+	case 'b':
+		b.WriteByte('\b')
+	case 'f':
+		b.WriteByte('\f')
+	case 'n':
+		b.WriteByte('\n')
+	case 'r':
+		b.WriteByte('\r')
+	case 't':
+		b.WriteByte('\t')
+	case 'v':
+		b.WriteByte('\v')
+	case 'x':
+		if len(s) < 3 {
+			return 0, syntaxError("invalid hexadecimal character sequence")
+		}
+		v, err := strconv.ParseUint(s[1:3], 16, 8)
+		if err != nil || v == 0 {
+			return 0, syntaxError("invalid hexadecimal character sequence")
+		}
+		b.WriteByte(byte(v))
+		return 3, nil
+	case 'u':
+		r, n, err := lexUnicodeEscape(s[1:])
+		if err != nil {
+			return 0, err
+		}
+		// Combine UTF-16 surrogate pairs.
+		if r >= 0xD800 && r <= 0xDBFF {
+			if !strings.HasPrefix(s[1+n:], `\u`) {
+				return 0, syntaxError("invalid Unicode surrogate pair")
+			}
+			lo, m, err := lexUnicodeEscape(s[1+n+2:])
+			if err != nil {
+				return 0, err
+			}
+			if lo < 0xDC00 || lo > 0xDFFF {
+				return 0, syntaxError("invalid Unicode surrogate pair")
+			}
+			r = (r-0xD800)<<10 + (lo - 0xDC00) + 0x10000
+			n += 2 + m
+		} else if r >= 0xDC00 && r <= 0xDFFF || r == 0 || !utf8.ValidRune(r) {
+			return 0, syntaxError("invalid Unicode escape value")
+		}
+		b.WriteRune(r)
+		return 1 + n, nil
+	default:
+		b.WriteByte(e)
+	}
+	return 1, nil
+}
+
+// lexUnicodeEscape lexes the hex digits of a \uXXXX or \u{X...} escape at the
+// start of s, returning the code point and the number of bytes consumed.
+func lexUnicodeEscape(s string) (rune, int, error) {
+	var digits string
+	var n int
+	if strings.HasPrefix(s, "{") {
+		end := strings.IndexByte(s, '}')
+		if end < 2 || end > 7 {
+			return 0, 0, syntaxError("invalid Unicode escape sequence")
+		}
+		digits, n = s[1:end], end+1
+	} else {
+		if len(s) < 4 {
+			return 0, 0, syntaxError("invalid Unicode escape sequence")
+		}
+		digits, n = s[:4], 4
+	}
+	v, err := strconv.ParseUint(digits, 16, 32)
+	if err != nil {
+		return 0, 0, syntaxError("invalid Unicode escape sequence")
+	}
+	return rune(v), n, nil
+}
+
+func syntaxError(msg string) error {
+	return pgerror.Newf(pgcode.Syntax, "%s in jsonpath input", msg)
+}
+
+func errorAtEnd(msg string) error {
+	return pgerror.Newf(pgcode.Syntax, "%s at end of jsonpath input", msg)
+}
+
+func syntaxErrorAt(raw string) error {
+	return pgerror.Newf(pgcode.Syntax, `syntax error at or near "%s" of jsonpath input`, raw)
+}
+
+// Parse parses the text representation of a jsonpath.
+func Parse(s string) (Jsonpath, error) {
+	toks, err := lex(s)
+	if err != nil {
+		return Jsonpath{}, err
+	}
+	p := parser{toks: toks}
+	return p.parse()
+}
+
+// MustParse is like Parse, but panics on error. It is intended for tests.
+func MustParse(s string) Jsonpath {
+	j, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return j
+}
+
+type parser struct {
+	toks []token
+	pos  int
+	// filterDepth is the number of filters enclosing the current position,
+	// which is used to reject @ outside of filters.
+	filterDepth int
+	// subscriptDepth is the number of array subscripts enclosing the current
+	// position, which is used to reject last outside of subscripts.
+	subscriptDepth int
+}
+
+func (p *parser) peek() token { return p.toks[p.pos] }
+
+func (p *parser) next() token {
+	t := p.toks[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+// peekOp returns true if the next token is the given operator.
+func (p *parser) peekOp(op string) bool {
+	t := p.peek()
+	return t.kind == tokOp && t.val == op
+}
+
+// peekKeyword returns true if the next token is the given keyword.
+func (p *parser) peekKeyword(kw string) bool {
+	t := p.peek()
+	return t.kind == tokIdent && t.val == kw
+}
+
+// unexpected returns a syntax error for the given token.
+func (p *parser) unexpected(t token) error {
+	if t.kind == tokEOF {
+		return errorAtEnd("syntax error")
+	}
+	return syntaxErrorAt(t.raw)
+}
+
+func (p *parser) expectOp(op string) error {
+	if !p.peekOp(op) {
+		return p.unexpected(p.peek())
+	}
+	p.next()
+	return nil
+}
+
+func (p *parser) expectKeyword(kw string) error {
+	if !p.peekKeyword(kw) {
+		return p.unexpected(p.peek())
+	}
+	p.next()
+	return nil
+}
+
+func (p *parser) parse() (Jsonpath, error) {
+	var j Jsonpath
+	if p.peekKeyword("strict") {
+		j.Strict = true
+		p.next()
+	} else if p.peekKeyword("lax") {
+		p.next()
+	}
+	path, err := p.parseOr()
+	if err != nil {
+		return Jsonpath{}, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return Jsonpath{}, p.unexpected(t)
+	}
+	j.Path = path
+	return j, nil
+}
+
+// parseOr parses a sequence of predicates separated by ||.
+func (p *parser) parseOr() (Path, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekOp("||") {
+		op := p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		if !isPredicate(left) || !isPredicate(right) {
+			return nil, p.unexpected(op)
+		}
+		left = Operation{Type: OpOr, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+// parseAnd parses a sequence of predicates separated by &&.
+func (p *parser) parseAnd() (Path, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekOp("&&") {
+		op := p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		if !isPredicate(left) || !isPredicate(right) {
+			return nil, p.unexpected(op)
+		}
+		left = Operation{Type: OpAnd, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+// parseNot parses a negated predicate, which must be parenthesized or an
+// exists test, or a comparison.
+func (p *parser) parseNot() (Path, error) {
+	if !p.peekOp("!") {
+		return p.parseComparison()
+	}
+	op := p.next()
+	if !p.peekOp("(") && !p.peekKeyword("exists") {
+		return nil, p.unexpected(p.peek())
+	}
+	arg, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	if !isPredicate(arg) {
+		return nil, p.unexpected(op)
+	}
+	return Operation{Type: OpNot, Left: arg}, nil
+}
+
+var comparisonOps = map[string]OperationType{
+	"==": OpEqual,
+	"!=": OpNotEqual,
+	"<>": OpNotEqual,
+	"<":  OpLess,
+	"<=": OpLessOrEqual,
+	">":  OpGreater,
+	">=": OpGreaterOrEqual,
+}
+
+// parseComparison parses a comparison, like_regex or starts with predicate,
+// or an arithmetic expression.
+func (p *parser) parseComparison() (Path, error) {
+	left, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	t := p.peek()
+	cmpOp, isCmp := comparisonOps[t.val]
+	switch {
+	case t.kind == tokOp && isCmp:
+		p.next()
+		right, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		if isPredicate(left) || isPredicate(right) {
+			return nil, p.unexpected(t)
+		}
+		return Operation{Type: cmpOp, Left: left, Right: right}, nil
+
+	case t.kind == tokIdent && t.val == "like_regex":
+		p.next()
+		if isPredicate(left) {
+			return nil, p.unexpected(t)
+		}
+		pattern := p.next()
+		if pattern.kind != tokString {
+			return nil, p.unexpected(pattern)
+		}
+		var flags string
+		if p.peekKeyword("flag") {
+			p.next()
+			f := p.next()
+			if f.kind != tokString {
+				return nil, p.unexpected(f)
+			}
+			flags = f.val
+		}
+		return makeRegex(left, pattern.val, flags)
+
+	case t.kind == tokIdent && t.val == "starts":
+		p.next()
+		if err := p.expectKeyword("with"); err != nil {
+			return nil, err
+		}
+		if isPredicate(left) {
+			return nil, p.unexpected(t)
+		}
+		var right Path
+		switch initial := p.next(); initial.kind {
+		case tokString:
+			right = Scalar{Value: json.FromString(initial.val)}
+		case tokVariable:
+			right = Variable(initial.val)
+		default:
+			return nil, p.unexpected(initial)
+		}
+		return Operation{Type: OpStartsWith, Left: left, Right: right}, nil
+	}
+	return left, nil
+}
+
+// makeRegex validates the flags and pattern of a like_regex predicate.
+func makeRegex(left Path, pattern, flags string) (Path, error) {
+	var seen [5]bool
+	const order = "ismxq"
+	for i := 0; i < len(flags); i++ {
+		idx := strings.IndexByte(order, flags[i])
+		if idx < 0 {
+			return nil, errors.WithDetailf(
+				pgerror.New(pgcode.Syntax, "invalid input syntax for type jsonpath"),
+				"Unrecognized flag character %q in LIKE_REGEX predicate.", flags[i:i+1],
+			)
+		}
+		seen[idx] = true
+	}
+	var normalized strings.Builder
+	for i := range order {
+		if seen[i] {
+			normalized.WriteByte(order[i])
+		}
+	}
+	r := Regex{Left: left, Pattern: pattern, Flags: normalized.String()}
+	re, err := compileRegex(r.Pattern, r.Flags)
+	if err != nil {
+		return nil, err
+	}
+	r.re = re
+	return r, nil
+}
+
+// compileRegex compiles a like_regex pattern with the given normalized flags.
+func compileRegex(pattern, flags string) (*regexp.Regexp, error) {
+	var prefix string
+	for _, f := range flags {
+		switch f {
+		case 'i':
+			prefix += "i"
+		case 's':
+			prefix += "s"
+		case 'm':
+			prefix += "m"
+		case 'x':
+			return nil, pgerror.New(pgcode.FeatureNotSupported,
+				`XQuery "x" flag (expanded regular expressions) is not implemented`)
+		case 'q':
+			pattern = regexp.QuoteMeta(pattern)
+		}
+	}
+	if prefix != "" {
+		pattern = "(?" + prefix + ")" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, pgerror.Wrap(err, pgcode.InvalidRegularExpression, "invalid regular expression")
+	}
+	return re, nil
+}
+
+// parseAdditive parses a sequence of expressions separated by + or -.
+func (p *parser) parseAdditive() (Path, error) {
+	left, err := p.parseMultiplicative()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekOp("+") || p.peekOp("-") {
+		op := p.next()
+		right, err := p.parseMultiplicative()
+		if err != nil {
+			return nil, err
+		}
+		if isPredicate(left) || isPredicate(right) {
+			return nil, p.unexpected(op)
+		}
+		typ := OpAdd
+		if op.val == "-" {
+			typ = OpSub
+		}
+		left = Operation{Type: typ, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+// parseMultiplicative parses a sequence of expressions separated by *, / or %.
+func (p *parser) parseMultiplicative() (Path, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekOp("*") || p.peekOp("/") || p.peekOp("%") {
+		op := p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if isPredicate(left) || isPredicate(right) {
+			return nil, p.unexpected(op)
+		}
+		typ := OpMul
+		switch op.val {
+		case "/":
+			typ = OpDiv
+		case "%":
+			typ = OpMod
+		}
+		left = Operation{Type: typ, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+// parseUnary parses an expression with an optional unary + or -. Unary
+// operators applied to numeric literals are folded into the literal.
+func (p *parser) parseUnary() (Path, error) {
+	if !p.peekOp("+") && !p.peekOp("-") {
+		return p.parseAccessorExpr()
+	}
+	op := p.next()
+	arg, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	if isPredicate(arg) {
+		return nil, p.unexpected(op)
+	}
+	if chain, ok := arg.(Paths); ok && len(chain) == 1 {
+		if s, ok := chain[0].(Scalar); ok && s.Value.Type() == json.NumberJSONType {
+			if op.val == "+" {
+				return arg, nil
+			}
+			d, _ := s.Value.AsDecimal()
+			var neg apd.Decimal
+			neg.Neg(d)
+			return Paths{Scalar{Value: json.FromDecimal(neg)}}, nil
+		}
+	}
+	typ := OpPlus
+	if op.val == "-" {
+		typ = OpMinus
+	}
+	return Operation{Type: typ, Left: arg}, nil
+}
+
+// parsePrimary parses a parenthesized expression or predicate, an exists
+// test, or a primary item such as $ or a literal.
+func (p *parser) parsePrimary() (Path, error) {
+	t := p.next()
+	switch t.kind {
+	case tokOp:
+		switch t.val {
+		case "(":
+			inner, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectOp(")"); err != nil {
+				return nil, err
+			}
+			if p.peekKeyword("is") {
+				isTok := p.next()
+				if err := p.expectKeyword("unknown"); err != nil {
+					return nil, err
+				}
+				if !isPredicate(inner) {
+					return nil, p.unexpected(isTok)
+				}
+				return Operation{Type: OpIsUnknown, Left: inner}, nil
+			}
+			return inner, nil
+		case "$":
+			return Root{}, nil
+		case "@":
+			if p.filterDepth == 0 {
+				return nil, pgerror.New(pgcode.Syntax, "@ is not allowed in root expressions")
+			}
+			return Current{}, nil
+		}
+	case tokIdent:
+		switch t.val {
+		case "exists":
+			if err := p.expectOp("("); err != nil {
+				return nil, err
+			}
+			arg, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if isPredicate(arg) {
+				return nil, p.unexpected(t)
+			}
+			if err := p.expectOp(")"); err != nil {
+				return nil, err
+			}
+			return Operation{Type: OpExists, Left: arg}, nil
+		case "last":
+			if p.subscriptDepth == 0 {
+				return nil, pgerror.New(pgcode.Syntax, "LAST is allowed only in array subscripts")
+			}
+			return Last{}, nil
+		case "true":
+			return Scalar{Value: json.TrueJSONValue}, nil
+		case "false":
+			return Scalar{Value: json.FalseJSONValue}, nil
+		case "null":
+			return Scalar{Value: json.NullJSONValue}, nil
+		}
+	case tokString:
+		return Scalar{Value: json.FromString(t.val)}, nil
+	case tokNumber:
+		var d apd.Decimal
+		if _, _, err := d.SetString(t.val); err != nil {
+			return nil, p.unexpected(t)
+		}
+		return Scalar{Value: json.FromDecimal(d)}, nil
+	case tokVariable:
+		return Variable(t.val), nil
+	}
+	return nil, p.unexpected(t)
+}
+
+// parseAccessorExpr parses a primary expression followed by any number of
+// accessors.
+func (p *parser) parseAccessorExpr() (Path, error) {
+	parenthesized := p.peekOp("(")
+	primary, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	var chain Paths
+	switch t := primary.(type) {
+	case Paths:
+		chain = append(chain, t...)
+	case Operation:
+		if t.Type == OpIsUnknown || t.Type == OpExists || !parenthesized {
+			// These predicates cannot be followed by accessors.
+			return primary, nil
+		}
+		chain = Paths{primary}
+	default:
+		chain = Paths{primary}
+	}
+	n := len(chain)
+	for {
+		accessor, ok, err := p.parseAccessor()
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			break
+		}
+		chain = append(chain, accessor)
+	}
+	if len(chain) == n {
+		if _, ok := primary.(Operation); ok {
+			return primary, nil
+		}
+		if _, ok := primary.(Regex); ok {
+			return primary, nil
+		}
+	}
+	return chain, nil
+}
+
+var methodTypes = map[string]MethodType{
+	"type":     TypeMethod,
+	"size":     SizeMethod,
+	"double":   DoubleMethod,
+	"ceiling":  CeilingMethod,
+	"floor":    FloorMethod,
+	"abs":      AbsMethod,
+	"keyvalue": KeyValueMethod,
+	"datetime": DatetimeMethod,
+}
+
+// parseAccessor parses a single accessor, returning false if the next token
+// does not start an accessor.
+func (p *parser) parseAccessor() (Path, bool, error) {
+	switch {
+	case p.peekOp("."):
+		p.next()
+		t := p.next()
+		switch t.kind {
+		case tokOp:
+			switch t.val {
+			case "*":
+				return AnyKey{}, true, nil
+			case "**":
+				a, err := p.parseAnyPath()
+				return a, err == nil, err
+			}
+		case tokIdent:
+			if p.peekOp("(") {
+				typ, ok := methodTypes[t.val]
+				if !ok {
+					return nil, false, p.unexpected(p.peek())
+				}
+				p.next()
+				m := Method{Type: typ}
+				if typ == DatetimeMethod && p.peek().kind == tokString {
+					template := p.next().val
+					m.Template = &template
+				}
+				if err := p.expectOp(")"); err != nil {
+					return nil, false, err
+				}
+				return m, true, nil
+			}
+			return Key(t.val), true, nil
+		case tokString:
+			return Key(t.val), true, nil
+		}
+		return nil, false, p.unexpected(t)
+
+	case p.peekOp("["):
+		p.next()
+		if p.peekOp("*") {
+			p.next()
+			if err := p.expectOp("]"); err != nil {
+				return nil, false, err
+			}
+			return AnyArray{}, true, nil
+		}
+		p.subscriptDepth++
+		defer func() { p.subscriptDepth-- }()
+		var list ArrayList
+		for {
+			var r ArrayIndexRange
+			var err error
+			if r.Start, err = p.parseSubscript(); err != nil {
+				return nil, false, err
+			}
+			if p.peekKeyword("to") {
+				p.next()
+				if r.End, err = p.parseSubscript(); err != nil {
+					return nil, false, err
+				}
+			}
+			list = append(list, r)
+			if !p.peekOp(",") {
+				break
+			}
+			p.next()
+		}
+		if err := p.expectOp("]"); err != nil {
+			return nil, false, err
+		}
+		return list, true, nil
+
+	case p.peekOp("?"):
+		p.next()
+		if err := p.expectOp("("); err != nil {
+			return nil, false, err
+		}
+		p.filterDepth++
+		cond, err := p.parseOr()
+		p.filterDepth--
+		if err != nil {
+			return nil, false, err
+		}
+		if t := p.peek(); !p.peekOp(")") || !isPredicate(cond) {
+			return nil, false, p.unexpected(t)
+		}
+		p.next()
+		return Filter{Condition: cond}, true, nil
+	}
+	return nil, false, nil
+}
+
+// parseSubscript parses a single array subscript expression.
+func (p *parser) parseSubscript() (Path, error) {
+	t := p.peek()
+	e, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	if isPredicate(e) {
+		return nil, p.unexpected(t)
+	}
+	return e, nil
+}
+
+// parseAnyPath parses the optional level bounds of a .** accessor.
+func (p *parser) parseAnyPath() (AnyPath, error) {
+	a := AnyPath{First: 0, Last: AnyPathLast}
+	if !p.peekOp("{") {
+		return a, nil
+	}
+	p.next()
+	var err error
+	if a.First, err = p.parseAnyLevel(); err != nil {
+		return AnyPath{}, err
+	}
+	a.Last = a.First
+	if p.peekKeyword("to") {
+		p.next()
+		if a.Last, err = p.parseAnyLevel(); err != nil {
+			return AnyPath{}, err
+		}
+	}
+	if err := p.expectOp("}"); err != nil {
+		return AnyPath{}, err
+	}
+	return a, nil
+}
+
+// parseAnyLevel parses a level bound of a .** accessor, which is a
+// non-negative integer or last.
+func (p *parser) parseAnyLevel() (uint32, error) {
+	t := p.next()
+	if t.kind == tokIdent && t.val == "last" {
+		return AnyPathLast, nil
+	}
+	if t.kind == tokNumber {
+		if v, err := strconv.ParseUint(t.val, 10, 32); err == nil && v < AnyPathLast {
+			return uint32(v), nil
+		}
+	}
+	return 0, p.unexpected(t)
+}
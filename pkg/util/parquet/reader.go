@@ -0,0 +1,322 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package parquet
+
+import (
+	"github.com/apache/arrow/go/v11/parquet"
+	"github.com/apache/arrow/go/v11/parquet/file"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// Reader reads the rows of a parquet file, decoding a subset of its columns
+// into datums of the requested types. Columns are matched by name, so the file
+// may contain columns which are not read, in any order.
+//
+// The Reader reads one row group at a time. It supports scalar and array
+// columns; tuple columns are not supported.
+type Reader struct {
+	reader *file.Reader
+	// columns[i] is the index of the physical column in the file which is
+	// decoded into the i-th requested column.
+	columns  []int
+	typs     []*types.T
+	decoders []decoder
+
+	// rowGroup is the index of the next row group to read.
+	rowGroup int
+	// rows holds the rows of the current row group which have not been
+	// returned yet.
+	rows [][]tree.Datum
+}
+
+// NewReader returns a Reader which reads the columns with the given names from
+// the given parquet file, decoding them into datums of the given types.
+func NewReader(f parquet.ReaderAtSeeker, colNames []string, typs []*types.T) (*Reader, error) {
+	reader, err := file.NewParquetReader(f)
+	if err != nil {
+		return nil, err
+	}
+	r := &Reader{
+		reader:   reader,
+		columns:  make([]int, len(colNames)),
+		typs:     typs,
+		decoders: make([]decoder, len(colNames)),
+	}
+	sch := reader.MetaData().Schema
+	for i, name := range colNames {
+		r.columns[i] = -1
+		for j := 0; j < sch.NumColumns(); j++ {
+			col := sch.Column(j)
+			if path := col.ColumnPath(); len(path) == 0 || path[0] != name {
+				continue
+			}
+			if r.columns[i] != -1 || col.MaxDefinitionLevel() == 2 {
+				return nil, errors.CombineErrors(pgerror.Newf(pgcode.FeatureNotSupported,
+					"parquet column %q: nested columns are not supported", name), reader.Close())
+			}
+			r.columns[i] = j
+		}
+		if r.columns[i] == -1 {
+			return nil, errors.CombineErrors(pgerror.Newf(pgcode.UndefinedColumn,
+				"parquet file does not contain column %q", name), reader.Close())
+		}
+		typ := typs[i]
+		if typ.Family() == types.ArrayFamily {
+			typ = typ.ArrayContents()
+		}
+		if r.decoders[i], err = decoderFromFamilyAndType(typ.Oid(), typ.Family()); err != nil {
+			return nil, errors.CombineErrors(pgerror.Newf(pgcode.FeatureNotSupported,
+				"cannot read parquet column %q as %s", name, typs[i].SQLString()), reader.Close())
+		}
+	}
+	return r, nil
+}
+
+// Next returns the next row of the file, or nil once all the rows have been
+// read.
+func (r *Reader) Next() (tree.Datums, error) {
+	for len(r.rows) == 0 {
+		if r.rowGroup >= r.reader.NumRowGroups() {
+			return nil, nil
+		}
+		if err := r.readRowGroup(); err != nil {
+			return nil, err
+		}
+	}
+	row := r.rows[0]
+	r.rows = r.rows[1:]
+	return row, nil
+}
+
+// readRowGroup decodes the requested columns of the next row group.
+func (r *Reader) readRowGroup() error {
+	rgr := r.reader.RowGroup(r.rowGroup)
+	r.rowGroup++
+	numRows := rgr.NumRows()
+	rows := make([][]tree.Datum, numRows)
+	for i := range rows {
+		rows[i] = make([]tree.Datum, len(r.columns))
+	}
+	for i, colIdx := range r.columns {
+		col, err := rgr.Column(colIdx)
+		if err != nil {
+			return err
+		}
+		isArray := col.Descriptor().MaxRepetitionLevel() > 0
+		if isArray != (r.typs[i].Family() == types.ArrayFamily) {
+			return pgerror.Newf(pgcode.DatatypeMismatch,
+				"cannot read parquet column %q as %s", col.Descriptor().Name(), r.typs[i].SQLString())
+		}
+		datums, err := readColInRowGroup(col, r.decoders[i], numRows, isArray, false /* isTuple */)
+		if err != nil {
+			return errors.Wrapf(err, "reading parquet column %q as %s",
+				col.Descriptor().Name(), r.typs[i].SQLString())
+		}
+		for rowIdx, d := range datums {
+			if rows[rowIdx][i], err = hydrateDatum(d, r.typs[i]); err != nil {
+				return err
+			}
+		}
+	}
+	r.rows = rows
+	return nil
+}
+
+// hydrateDatum completes the datums produced by the decoders which do not
+// carry their type, such as enums and collated strings.
+func hydrateDatum(d tree.Datum, typ *types.T) (tree.Datum, error) {
+	switch t := d.(type) {
+	case *tree.DEnum:
+		e, err := tree.MakeDEnumFromLogicalRepresentation(typ, t.LogicalRep)
+		if err != nil {
+			return nil, err
+		}
+		return &e, nil
+	case *tree.DCollatedString:
+		return tree.NewDCollatedString(t.Contents, typ.Locale(), &tree.CollationEnvironment{})
+	case *tree.DArray:
+		t.ParamTyp = typ.ArrayContents()
+		for i, elem := range t.Array {
+			if elem == tree.DNull {
+				t.HasNulls = true
+				continue
+			}
+			t.HasNonNulls = true
+			var err error
+			if t.Array[i], err = hydrateDatum(elem, t.ParamTyp); err != nil {
+				return nil, err
+			}
+		}
+		return t, nil
+	default:
+		return d, nil
+	}
+}
+
+// Close closes the Reader.
+func (r *Reader) Close() error {
+	return r.reader.Close()
+}
+
+func readColInRowGroup(
+	col file.ColumnChunkReader, dec decoder, rowsInRowGroup int64, isArray bool, isTuple bool,
+) ([]tree.Datum, error) {
+	switch col.Type() {
+	case parquet.Types.Boolean:
+		colDatums, err := readRowGroup(col, make([]bool, 1), dec, rowsInRowGroup, isArray, isTuple)
+		if err != nil {
+			return nil, err
+		}
+		return colDatums, nil
+	case parquet.Types.Int32:
+		colDatums, err := readRowGroup(col, make([]int32, 1), dec, rowsInRowGroup, isArray, isTuple)
+		if err != nil {
+			return nil, err
+		}
+		return colDatums, nil
+	case parquet.Types.Int64:
+		colDatums, err := readRowGroup(col, make([]int64, 1), dec, rowsInRowGroup, isArray, isTuple)
+		if err != nil {
+			return nil, err
+		}
+		return colDatums, nil
+	case parquet.Types.Int96:
+		panic("unimplemented")
+	case parquet.Types.Float:
+		colDatums, err := readRowGroup(col, make([]float32, 1), dec, rowsInRowGroup, isArray, isTuple)
+		if err != nil {
+			return nil, err
+		}
+		return colDatums, nil
+	case parquet.Types.Double:
+		colDatums, err := readRowGroup(col, make([]float64, 1), dec, rowsInRowGroup, isArray, isTuple)
+		if err != nil {
+			return nil, err
+		}
+		return colDatums, nil
+	case parquet.Types.ByteArray:
+		colDatums, err := readRowGroup(col, make([]parquet.ByteArray, 1), dec, rowsInRowGroup, isArray, isTuple)
+		if err != nil {
+			return nil, err
+		}
+		return colDatums, nil
+	case parquet.Types.FixedLenByteArray:
+		colDatums, err := readRowGroup(col, make([]parquet.FixedLenByteArray, 1), dec, rowsInRowGroup, isArray, isTuple)
+		if err != nil {
+			return nil, err
+		}
+		return colDatums, nil
+	default:
+		return nil, errors.AssertionFailedf("unexpected type: %s", col.Type())
+	}
+}
+
+type batchReader[T parquetDatatypes] interface {
+	ReadBatch(batchSize int64, values []T, defLvls []int16, repLvls []int16) (total int64, valuesRead int, err error)
+}
+
+// readRowGroup reads all the datums in a row group for a physical column.
+func readRowGroup[T parquetDatatypes](
+	r file.ColumnChunkReader,
+	valueAlloc []T,
+	dec decoder,
+	expectedRowCount int64,
+	isArray bool,
+	isTuple bool,
+) (tree.Datums, error) {
+	br, ok := r.(batchReader[T])
+	if !ok {
+		return nil, errors.AssertionFailedf("expected batchReader for type %T, but found %T instead", valueAlloc, r)
+	}
+
+	result := make([]tree.Datum, 0)
+	defLevels := [1]int16{}
+	repLevels := [1]int16{}
+	// Required columns, which are not written by the Writer but are common in
+	// files written by other tools, have no definition levels.
+	required := r.Descriptor().MaxDefinitionLevel() == 0
+
+	for {
+		numRowsRead, _, err := br.ReadBatch(1, valueAlloc, defLevels[:], repLevels[:])
+		if err != nil {
+			return nil, err
+		}
+		if numRowsRead == 0 {
+			break
+		}
+
+		if isArray {
+			// Replevel 0 indicates the start of a new array.
+			if repLevels[0] == 0 {
+				// Replevel 0, Deflevel 0 represents a NULL array.
+				if defLevels[0] == 0 {
+					result = append(result, tree.DNull)
+					continue
+				}
+				arrDatum := &tree.DArray{}
+				arrDatum.Array = tree.Datums{}
+				result = append(result, arrDatum)
+				// Replevel 0, Deflevel 1 represents an array which is empty.
+				if defLevels[0] == 1 {
+					continue
+				}
+			}
+			currentArrayDatum := result[len(result)-1].(*tree.DArray)
+			// Deflevel 2 represents a null value in an array.
+			if defLevels[0] == 2 {
+				currentArrayDatum.Array = append(currentArrayDatum.Array, tree.DNull)
+				continue
+			}
+			// Deflevel 3 represents a non-null datum in an array.
+			d, err := decode(dec, valueAlloc[0])
+			if err != nil {
+				return nil, err
+			}
+			currentArrayDatum.Array = append(currentArrayDatum.Array, d)
+		} else if isTuple {
+			// Deflevel 0 represents a null tuple.
+			// Deflevel 1 represents a null value in a non null tuple.
+			// Deflevel 2 represents a non-null value in a non-null tuple.
+			switch defLevels[0] {
+			case 0:
+				result = append(result, dNullTuple)
+			case 1:
+				result = append(result, tree.DNull)
+			case 2:
+				d, err := decode(dec, valueAlloc[0])
+				if err != nil {
+					return nil, err
+				}
+				result = append(result, d)
+			}
+		} else {
+			// Deflevel 0 represents a null value
+			// Deflevel 1 represents a non-null value
+			d := tree.DNull
+			if required || defLevels[0] != 0 {
+				d, err = decode(dec, valueAlloc[0])
+				if err != nil {
+					return nil, err
+				}
+			}
+			result = append(result, d)
+		}
+	}
+	if int64(len(result)) != expectedRowCount {
+		return nil, errors.AssertionFailedf(
+			"expected to read %d rows in row group, found %d", expectedRowCount, int64(len(result)))
+	}
+	return result, nil
+}
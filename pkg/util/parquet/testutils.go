@@ -18,7 +18,6 @@ import (
 	"strings"
 	"testing"
 
-	"github.com/apache/arrow/go/v11/parquet"
 	"github.com/apache/arrow/go/v11/parquet/file"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -203,154 +202,6 @@ func makeDatumMeta(reader *file.Reader, readDatums [][]tree.Datum) ReadDatumsMet
 	}
 }
 
-func readColInRowGroup(
-	col file.ColumnChunkReader, dec decoder, rowsInRowGroup int64, isArray bool, isTuple bool,
-) ([]tree.Datum, error) {
-	switch col.Type() {
-	case parquet.Types.Boolean:
-		colDatums, err := readRowGroup(col, make([]bool, 1), dec, rowsInRowGroup, isArray, isTuple)
-		if err != nil {
-			return nil, err
-		}
-		return colDatums, nil
-	case parquet.Types.Int32:
-		colDatums, err := readRowGroup(col, make([]int32, 1), dec, rowsInRowGroup, isArray, isTuple)
-		if err != nil {
-			return nil, err
-		}
-		return colDatums, nil
-	case parquet.Types.Int64:
-		colDatums, err := readRowGroup(col, make([]int64, 1), dec, rowsInRowGroup, isArray, isTuple)
-		if err != nil {
-			return nil, err
-		}
-		return colDatums, nil
-	case parquet.Types.Int96:
-		panic("unimplemented")
-	case parquet.Types.Float:
-		colDatums, err := readRowGroup(col, make([]float32, 1), dec, rowsInRowGroup, isArray, isTuple)
-		if err != nil {
-			return nil, err
-		}
-		return colDatums, nil
-	case parquet.Types.Double:
-		colDatums, err := readRowGroup(col, make([]float64, 1), dec, rowsInRowGroup, isArray, isTuple)
-		if err != nil {
-			return nil, err
-		}
-		return colDatums, nil
-	case parquet.Types.ByteArray:
-		colDatums, err := readRowGroup(col, make([]parquet.ByteArray, 1), dec, rowsInRowGroup, isArray, isTuple)
-		if err != nil {
-			return nil, err
-		}
-		return colDatums, nil
-	case parquet.Types.FixedLenByteArray:
-		colDatums, err := readRowGroup(col, make([]parquet.FixedLenByteArray, 1), dec, rowsInRowGroup, isArray, isTuple)
-		if err != nil {
-			return nil, err
-		}
-		return colDatums, nil
-	default:
-		return nil, errors.AssertionFailedf("unexpected type: %s", col.Type())
-	}
-}
-
-type batchReader[T parquetDatatypes] interface {
-	ReadBatch(batchSize int64, values []T, defLvls []int16, repLvls []int16) (total int64, valuesRead int, err error)
-}
-
-// readRowGroup reads all the datums in a row group for a physical column.
-func readRowGroup[T parquetDatatypes](
-	r file.ColumnChunkReader,
-	valueAlloc []T,
-	dec decoder,
-	expectedRowCount int64,
-	isArray bool,
-	isTuple bool,
-) (tree.Datums, error) {
-	br, ok := r.(batchReader[T])
-	if !ok {
-		return nil, errors.AssertionFailedf("expected batchReader for type %T, but found %T instead", valueAlloc, r)
-	}
-
-	result := make([]tree.Datum, 0)
-	defLevels := [1]int16{}
-	repLevels := [1]int16{}
-
-	for {
-		numRowsRead, _, err := br.ReadBatch(1, valueAlloc, defLevels[:], repLevels[:])
-		if err != nil {
-			return nil, err
-		}
-		if numRowsRead == 0 {
-			break
-		}
-
-		if isArray {
-			// Replevel 0 indicates the start of a new array.
-			if repLevels[0] == 0 {
-				// Replevel 0, Deflevel 0 represents a NULL array.
-				if defLevels[0] == 0 {
-					result = append(result, tree.DNull)
-					continue
-				}
-				arrDatum := &tree.DArray{}
-				arrDatum.Array = tree.Datums{}
-				result = append(result, arrDatum)
-				// Replevel 0, Deflevel 1 represents an array which is empty.
-				if defLevels[0] == 1 {
-					continue
-				}
-			}
-			currentArrayDatum := result[len(result)-1].(*tree.DArray)
-			// Deflevel 2 represents a null value in an array.
-			if defLevels[0] == 2 {
-				currentArrayDatum.Array = append(currentArrayDatum.Array, tree.DNull)
-				continue
-			}
-			// Deflevel 3 represents a non-null datum in an array.
-			d, err := decode(dec, valueAlloc[0])
-			if err != nil {
-				return nil, err
-			}
-			currentArrayDatum.Array = append(currentArrayDatum.Array, d)
-		} else if isTuple {
-			// Deflevel 0 represents a null tuple.
-			// Deflevel 1 represents a null value in a non null tuple.
-			// Deflevel 2 represents a non-null value in a non-null tuple.
-			switch defLevels[0] {
-			case 0:
-				result = append(result, dNullTuple)
-			case 1:
-				result = append(result, tree.DNull)
-			case 2:
-				d, err := decode(dec, valueAlloc[0])
-				if err != nil {
-					return nil, err
-				}
-				result = append(result, d)
-			}
-		} else {
-			// Deflevel 0 represents a null value
-			// Deflevel 1 represents a non-null value
-			d := tree.DNull
-			if defLevels[0] != 0 {
-				d, err = decode(dec, valueAlloc[0])
-				if err != nil {
-					return nil, err
-				}
-			}
-			result = append(result, d)
-		}
-	}
-	if int64(len(result)) != expectedRowCount {
-		return nil, errors.AssertionFailedf(
-			"expected to read %d rows in row group, found %d", expectedRowCount, int64(len(result)))
-	}
-	return result, nil
-}
-
 func decodeValuesIntoDatumsHelper(
 	colDatums []tree.Datum, datumRows [][]tree.Datum, colIdx int, startingRowIdx int,
 ) {
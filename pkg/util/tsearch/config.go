@@ -10,22 +10,184 @@
 
 package tsearch
 
-import "strings"
+import (
+	"strings"
+	"unicode"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+)
 
 // ValidConfig returns an error if the input string is not a supported and valid
 // text search config.
 func ValidConfig(input string) error {
-	input = GetConfigKey(input)
-	_, err := getStemmer(input)
+	_, err := GetBuiltinConfig(input)
 	return err
 }
 
-// GetConfigKey returns a config that can be used as a key to look up stemmers
-// and stopwords from an input config value. This is simulating the more
-// advanced customizable dictionaries and configs that Postgres has, which
-// allows user-defined text search configurations: because of this, configs can
-// have schema prefixes. Because we don't (yet?) allow this, we just have to
-// trim off any `pg_catalog.` prefix if it exists.
+// GetConfigKey returns a config that can be used as a key to look up built-in
+// configurations and dictionaries from an input config value. Built-in text
+// search objects live in the pg_catalog schema, so we trim off any
+// `pg_catalog.` prefix if it exists.
 func GetConfigKey(config string) string {
 	return strings.TrimPrefix(config, "pg_catalog.")
 }
+
+// TokenType is the type of a token produced by the text search parser. The
+// dictionaries a configuration uses are chosen according to the type of each
+// token.
+type TokenType int
+
+// These are the token types produced by TSParse. They are a subset of the ones
+// produced by the default Postgres parser, and use the same names.
+const (
+	// AsciiWordToken is a word made only of ASCII letters.
+	AsciiWordToken TokenType = iota
+	// WordToken is a word made of letters, some of which are not ASCII.
+	WordToken
+	// NumWordToken is a word made of letters and digits.
+	NumWordToken
+	// UintToken is an unsigned integer.
+	UintToken
+
+	numTokenTypes
+)
+
+var tokenTypeNames = [numTokenTypes]string{
+	AsciiWordToken: "asciiword",
+	WordToken:      "word",
+	NumWordToken:   "numword",
+	UintToken:      "uint",
+}
+
+// String implements the fmt.Stringer interface.
+func (t TokenType) String() string {
+	return tokenTypeNames[t]
+}
+
+// TokenTypes returns all the token types, in order.
+func TokenTypes() []TokenType {
+	ret := make([]TokenType, numTokenTypes)
+	for i := range ret {
+		ret[i] = TokenType(i)
+	}
+	return ret
+}
+
+// TokenTypeFromString returns the token type of the given name.
+func TokenTypeFromString(name string) (TokenType, error) {
+	for i, n := range tokenTypeNames {
+		if n == name {
+			return TokenType(i), nil
+		}
+	}
+	return 0, pgerror.Newf(pgcode.InvalidParameterValue, "token type %q does not exist", name)
+}
+
+// getTokenType returns the type of a token produced by TSParse.
+func getTokenType(token string) TokenType {
+	var hasLetter, hasDigit, hasNonASCII bool
+	for _, r := range token {
+		if unicode.IsLetter(r) {
+			hasLetter = true
+			if r > unicode.MaxASCII {
+				hasNonASCII = true
+			}
+		} else {
+			hasDigit = true
+		}
+	}
+	switch {
+	case hasLetter && hasDigit:
+		return NumWordToken
+	case hasDigit:
+		return UintToken
+	case hasNonASCII:
+		return WordToken
+	default:
+		return AsciiWordToken
+	}
+}
+
+// Config is a text search configuration, which specifies the dictionaries used
+// to normalize each type of token.
+type Config struct {
+	Name string
+	// Mappings contains, for each token type, the dictionaries that are
+	// consulted in order until one of them recognizes a token. Tokens of a type
+	// without dictionaries are ignored.
+	Mappings [numTokenTypes][]*Dictionary
+}
+
+// Lexize normalizes the input token into a lexeme according to the
+// configuration. It returns true in the second parameter if the token should
+// be ignored, either because it's a stopword or because no dictionary
+// recognizes it.
+func (c *Config) Lexize(token string) (lexeme string, stopWord bool) {
+	for _, d := range c.Mappings[getTokenType(token)] {
+		lexeme, recognized, stopWord := d.Lexize(token)
+		if recognized {
+			return lexeme, stopWord
+		}
+	}
+	return "", true
+}
+
+// builtinConfigs are the configurations available in the pg_catalog schema:
+// simple, which lowercases all tokens, and one configuration per language that
+// has a stemmer, which stems words and removes the stopwords of the language.
+var builtinConfigs = makeBuiltinConfigs()
+
+func makeBuiltinConfigs() map[string]*Config {
+	simpleDict := builtinDictionaries["simple"]
+	simple := &Config{Name: "simple"}
+	for i := range simple.Mappings {
+		simple.Mappings[i] = []*Dictionary{simpleDict}
+	}
+	builtinConfigs := map[string]*Config{"simple": simple}
+	for _, lang := range snowballLanguages {
+		stemDict := []*Dictionary{builtinDictionaries[lang+"_stem"]}
+		c := &Config{Name: lang}
+		c.Mappings[AsciiWordToken] = stemDict
+		c.Mappings[WordToken] = stemDict
+		c.Mappings[NumWordToken] = []*Dictionary{simpleDict}
+		c.Mappings[UintToken] = []*Dictionary{simpleDict}
+		builtinConfigs[lang] = c
+	}
+	return builtinConfigs
+}
+
+// GetBuiltinConfig returns the built-in text search configuration of the given
+// name. The name may be qualified with pg_catalog.
+func GetBuiltinConfig(name string) (*Config, error) {
+	c, ok := builtinConfigs[GetConfigKey(name)]
+	if !ok {
+		return nil, pgerror.Newf(pgcode.UndefinedObject, "text search configuration %q does not exist", name)
+	}
+	return c, nil
+}
+
+// IsBuiltinObject returns whether the given name refers to a built-in text
+// search configuration or dictionary.
+func IsBuiltinObject(name string) bool {
+	key := GetConfigKey(name)
+	_, isConfig := builtinConfigs[key]
+	_, isDict := builtinDictionaries[key]
+	return isConfig || isDict
+}
+
+// BuiltinConfigNames returns the names of the built-in text search
+// configurations, in order.
+func BuiltinConfigNames() []string {
+	return append([]string{"simple"}, snowballLanguages...)
+}
+
+// BuiltinDictionaryNames returns the names of the built-in text search
+// dictionaries, in order.
+func BuiltinDictionaryNames() []string {
+	ret := []string{"simple"}
+	for _, lang := range snowballLanguages {
+		ret = append(ret, lang+"_stem")
+	}
+	return ret
+}
@@ -0,0 +1,293 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/blevesearch/snowballstem"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+)
+
+// DictionaryTemplate is the kind of a text search dictionary, which determines
+// how it transforms tokens into lexemes.
+type DictionaryTemplate int
+
+const (
+	// SimpleTemplate dictionaries lowercase tokens and check them against an
+	// optional stopword list.
+	SimpleTemplate DictionaryTemplate = iota
+	// SnowballTemplate dictionaries check tokens against an optional stopword
+	// list and then reduce them to their stem using a Snowball stemmer.
+	SnowballTemplate
+	// SynonymTemplate dictionaries replace tokens with their synonym.
+	SynonymTemplate
+)
+
+// String implements the fmt.Stringer interface.
+func (t DictionaryTemplate) String() string {
+	switch t {
+	case SimpleTemplate:
+		return "simple"
+	case SnowballTemplate:
+		return "snowball"
+	case SynonymTemplate:
+		return "synonym"
+	}
+	return "unknown"
+}
+
+// Dictionary is a text search dictionary, which is the construct that turns
+// tokens produced by the parser into normalized lexemes. It is modeled after
+// the dictionaries of Postgres:
+// https://www.postgresql.org/docs/current/textsearch-dictionaries.html
+type Dictionary struct {
+	Name     string
+	Template DictionaryTemplate
+	// Language is the language of the Snowball stemmer, for snowball
+	// dictionaries.
+	Language string
+	// StopWords is the name of the built-in stopword list used by simple and
+	// snowball dictionaries, if any.
+	StopWords string
+	// Accept controls whether a simple dictionary recognizes the tokens that
+	// are not stopwords. If it's false, those tokens are passed on to the next
+	// dictionary of the configuration.
+	Accept bool
+	// Synonyms maps lowercased words to their synonym, for synonym
+	// dictionaries.
+	Synonyms map[string]string
+
+	stopWords map[string]struct{}
+	stemmer   func(env *snowballstem.Env) bool
+}
+
+// DictionaryOption is a parameter of a text search dictionary, as specified in
+// CREATE TEXT SEARCH DICTIONARY.
+type DictionaryOption struct {
+	Name  string
+	Value string
+}
+
+// NewDictionary validates the options of a dictionary using the template of
+// the given name and returns the dictionary.
+func NewDictionary(name string, template string, options []DictionaryOption) (*Dictionary, error) {
+	d := &Dictionary{Name: name}
+	switch GetConfigKey(template) {
+	case "simple":
+		d.Template = SimpleTemplate
+		d.Accept = true
+	case "snowball":
+		d.Template = SnowballTemplate
+	case "synonym":
+		d.Template = SynonymTemplate
+	case "ispell", "thesaurus":
+		return nil, unimplemented.NewWithIssueDetailf(7821, template,
+			"text search template %q is not supported", template)
+	default:
+		return nil, pgerror.Newf(pgcode.UndefinedObject, "text search template %q does not exist", template)
+	}
+	for _, opt := range options {
+		switch key := strings.ToLower(opt.Name); {
+		case key == "stopwords" && d.Template != SynonymTemplate:
+			if _, ok := stopwordsMap[opt.Value]; !ok || opt.Value == "simple" {
+				return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+					"stopword list %q does not exist", opt.Value)
+			}
+			d.StopWords = opt.Value
+		case key == "accept" && d.Template == SimpleTemplate:
+			switch strings.ToLower(opt.Value) {
+			case "true", "on", "yes", "1":
+				d.Accept = true
+			case "false", "off", "no", "0":
+				d.Accept = false
+			default:
+				return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+					"accept requires a Boolean value")
+			}
+		case key == "language" && d.Template == SnowballTemplate:
+			d.Language = strings.ToLower(opt.Value)
+		case key == "synonyms" && d.Template == SynonymTemplate:
+			synonyms, err := ParseSynonyms(opt.Value)
+			if err != nil {
+				return nil, err
+			}
+			d.Synonyms = synonyms
+		default:
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+				"unrecognized %s dictionary parameter: %q", d.Template, opt.Name)
+		}
+	}
+	if err := d.init(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// init checks the required parameters of the dictionary and sets up its
+// stopword list and stemmer.
+func (d *Dictionary) init() error {
+	if d.StopWords != "" {
+		d.stopWords = stopwordsMap[d.StopWords]
+	}
+	switch d.Template {
+	case SnowballTemplate:
+		if d.Language == "" {
+			return pgerror.New(pgcode.InvalidParameterValue, "missing Language parameter")
+		}
+		if d.Language == "simple" {
+			return pgerror.Newf(pgcode.InvalidParameterValue,
+				"no Snowball stemmer available for language %q", d.Language)
+		}
+		stemmer, err := getStemmer(d.Language)
+		if err != nil {
+			return pgerror.Newf(pgcode.InvalidParameterValue,
+				"no Snowball stemmer available for language %q", d.Language)
+		}
+		d.stemmer = stemmer
+	case SynonymTemplate:
+		if d.Synonyms == nil {
+			return pgerror.New(pgcode.InvalidParameterValue, "missing Synonyms parameter")
+		}
+	}
+	return nil
+}
+
+// Options returns the options of the dictionary in the form accepted by
+// NewDictionary. The options are returned in a deterministic order.
+func (d *Dictionary) Options() []DictionaryOption {
+	var ret []DictionaryOption
+	switch d.Template {
+	case SimpleTemplate:
+		if d.StopWords != "" {
+			ret = append(ret, DictionaryOption{Name: "stopwords", Value: d.StopWords})
+		}
+		if !d.Accept {
+			ret = append(ret, DictionaryOption{Name: "accept", Value: "false"})
+		}
+	case SnowballTemplate:
+		ret = append(ret, DictionaryOption{Name: "language", Value: d.Language})
+		if d.StopWords != "" {
+			ret = append(ret, DictionaryOption{Name: "stopwords", Value: d.StopWords})
+		}
+	case SynonymTemplate:
+		ret = append(ret, DictionaryOption{Name: "synonyms", Value: FormatSynonyms(d.Synonyms)})
+	}
+	return ret
+}
+
+// ParseSynonyms parses the synonyms of a synonym dictionary. Unlike Postgres,
+// which reads them from a file in the installation's share directory, the
+// synonyms are given inline: each entry is made of a word followed by its
+// synonym, and entries are separated by commas or newlines.
+func ParseSynonyms(input string) (map[string]string, error) {
+	synonyms := make(map[string]string)
+	entries := strings.FieldsFunc(input, func(r rune) bool {
+		return r == ',' || r == '\n'
+	})
+	for _, entry := range entries {
+		fields := strings.Fields(entry)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+				"invalid synonym entry %q: expected a word and its synonym", strings.TrimSpace(entry))
+		}
+		synonyms[strings.ToLower(fields[0])] = fields[1]
+	}
+	return synonyms, nil
+}
+
+// FormatSynonyms is the inverse of ParseSynonyms.
+func FormatSynonyms(synonyms map[string]string) string {
+	words := make([]string, 0, len(synonyms))
+	for word := range synonyms {
+		words = append(words, word)
+	}
+	sort.Strings(words)
+	var buf strings.Builder
+	for i, word := range words {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(word)
+		buf.WriteByte(' ')
+		buf.WriteString(synonyms[word])
+	}
+	return buf.String()
+}
+
+// Lexize transforms the input token into a lexeme. It returns false in the
+// second parameter if the dictionary doesn't recognize the token, in which case
+// the token should be passed to the next dictionary, and true in the third
+// parameter if the token is a stopword, in which case it should be ignored.
+func (d *Dictionary) Lexize(token string) (lexeme string, recognized bool, stopWord bool) {
+	lower := strings.ToLower(token)
+	switch d.Template {
+	case SimpleTemplate:
+		if _, ok := d.stopWords[lower]; ok {
+			return "", true, true
+		}
+		if !d.Accept {
+			return "", false, false
+		}
+		return lower, true, false
+	case SnowballTemplate:
+		if _, ok := d.stopWords[lower]; ok {
+			return "", true, true
+		}
+		env := snowballstem.NewEnv(lower)
+		d.stemmer(env)
+		return env.Current(), true, false
+	case SynonymTemplate:
+		if synonym, ok := d.Synonyms[lower]; ok {
+			return synonym, true, false
+		}
+	}
+	return "", false, false
+}
+
+// builtinDictionaries are the dictionaries available in the pg_catalog schema:
+// simple, and a snowball dictionary named <language>_stem for each language
+// that has a stemmer.
+var builtinDictionaries = makeBuiltinDictionaries()
+
+func makeBuiltinDictionaries() map[string]*Dictionary {
+	builtinDictionaries := map[string]*Dictionary{
+		"simple": {Name: "simple", Template: SimpleTemplate, Accept: true},
+	}
+	for _, lang := range snowballLanguages {
+		d := &Dictionary{
+			Name:     lang + "_stem",
+			Template: SnowballTemplate,
+			Language: lang,
+		}
+		if _, ok := stopwordsMap[lang]; ok {
+			d.StopWords = lang
+		}
+		if err := d.init(); err != nil {
+			panic(err)
+		}
+		builtinDictionaries[d.Name] = d
+	}
+	return builtinDictionaries
+}
+
+// GetBuiltinDictionary returns the built-in dictionary of the given name. The
+// name may be qualified with pg_catalog.
+func GetBuiltinDictionary(name string) (*Dictionary, bool) {
+	d, ok := builtinDictionaries[GetConfigKey(name)]
+	return d, ok
+}
@@ -0,0 +1,118 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuiltinConfigs(t *testing.T) {
+	tcs := []struct {
+		config   string
+		input    string
+		expected string
+	}{
+		{"simple", "Die Häuser der Kinder", "'der':3 'die':1 'häuser':2 'kinder':4"},
+		{"german", "Die Häuser der Kinder", "'haus':2 'kind':4"},
+		{"pg_catalog.german", "Bücher und ein Buch", "'buch':1,4"},
+		{"french", "Les chaussures rouges", "'chaussur':2 'le':1 'roug':3"},
+		{"spanish", "Los zapatos y las camisetas", "'camiset':5 'zapat':2"},
+		{"english", "running shoes size 42 b2b", "'42':4 'b2b':5 'run':1 'shoe':2 'size':3"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.config+"/"+tc.input, func(t *testing.T) {
+			config, err := GetBuiltinConfig(tc.config)
+			require.NoError(t, err)
+			vector, err := DocumentToTSVector(config, tc.input)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, vector.String())
+		})
+	}
+
+	_, err := GetBuiltinConfig("klingon")
+	require.EqualError(t, err, `text search configuration "klingon" does not exist`)
+}
+
+func TestDictionaries(t *testing.T) {
+	synonyms, err := NewDictionary("shoe_syn", "synonym", []DictionaryOption{
+		{Name: "synonyms", Value: "sneakers shoe, Trainers shoe\nkicks shoe"},
+	})
+	require.NoError(t, err)
+	stem, ok := GetBuiltinDictionary("english_stem")
+	require.True(t, ok)
+
+	config := &Config{Name: "shop"}
+	config.Mappings[AsciiWordToken] = []*Dictionary{synonyms, stem}
+	vector, err := DocumentToTSVector(config, "The TRAINERS and running sneakers 42")
+	require.NoError(t, err)
+	require.Equal(t, "'run':4 'shoe':2,5", vector.String())
+
+	query, err := PlainToTSQuery(config, "kicks for running")
+	require.NoError(t, err)
+	require.Equal(t, "'shoe' & 'run'", query.String())
+
+	// A simple dictionary that doesn't accept words only removes stopwords.
+	stopwords, err := NewDictionary("stop", "pg_catalog.simple", []DictionaryOption{
+		{Name: "StopWords", Value: "english"}, {Name: "Accept", Value: "false"},
+	})
+	require.NoError(t, err)
+	config.Mappings[AsciiWordToken] = []*Dictionary{stopwords, builtinDictionaries["simple"]}
+	vector, err = DocumentToTSVector(config, "The Running Shoes")
+	require.NoError(t, err)
+	require.Equal(t, "'running':2 'shoes':3", vector.String())
+
+	require.Equal(t, []DictionaryOption{
+		{Name: "stopwords", Value: "english"}, {Name: "accept", Value: "false"},
+	}, stopwords.Options())
+	require.Equal(t, []DictionaryOption{
+		{Name: "synonyms", Value: "kicks shoe, sneakers shoe, trainers shoe"},
+	}, synonyms.Options())
+
+	for _, tc := range []struct {
+		template string
+		options  []DictionaryOption
+		expected string
+	}{
+		{"snowball", nil, "missing Language parameter"},
+		{"snowball", []DictionaryOption{{"language", "klingon"}}, `no Snowball stemmer available for language "klingon"`},
+		{"snowball", []DictionaryOption{{"language", "german"}, {"accept", "true"}}, `unrecognized snowball dictionary parameter: "accept"`},
+		{"simple", []DictionaryOption{{"stopwords", "klingon"}}, `stopword list "klingon" does not exist`},
+		{"simple", []DictionaryOption{{"accept", "maybe"}}, "accept requires a Boolean value"},
+		{"synonym", nil, "missing Synonyms parameter"},
+		{"synonym", []DictionaryOption{{"synonyms", "a b c"}}, `invalid synonym entry "a b c": expected a word and its synonym`},
+		{"ispell", nil, `unimplemented: text search template "ispell" is not supported`},
+		{"klingon", nil, `text search template "klingon" does not exist`},
+	} {
+		_, err := NewDictionary("d", tc.template, tc.options)
+		require.EqualError(t, err, tc.expected)
+	}
+}
+
+func TestTokenTypes(t *testing.T) {
+	for _, tc := range []struct {
+		token    string
+		expected TokenType
+	}{
+		{"hello", AsciiWordToken},
+		{"häuser", WordToken},
+		{"b2b", NumWordToken},
+		{"42", UintToken},
+	} {
+		require.Equal(t, tc.expected, getTokenType(tc.token), tc.token)
+		typ, err := TokenTypeFromString(tc.expected.String())
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, typ)
+	}
+	_, err := TokenTypeFromString("email")
+	require.EqualError(t, err, `token type "email" does not exist`)
+}
@@ -30,6 +30,12 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 )
 
+// snowballLanguages are the languages that have a Snowball stemmer.
+var snowballLanguages = []string{
+	"danish", "dutch", "english", "finnish", "french", "german", "hungarian", "italian",
+	"norwegian", "portuguese", "russian", "spanish", "swedish", "turkish",
+}
+
 func getStemmer(config string) (func(env *snowballstem.Env) bool, error) {
 	switch config {
 	case "simple":
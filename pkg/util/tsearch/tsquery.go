@@ -415,21 +415,21 @@ func (p *tsQueryParser) syntaxError() (*tsNode, error) {
 
 // ToTSQuery implements the to_tsquery builtin, which lexes an input, performs
 // stopwording and normalization on the tokens, and returns a parsed query.
-func ToTSQuery(config string, input string) (TSQuery, error) {
+func ToTSQuery(config *Config, input string) (TSQuery, error) {
 	return toTSQuery(config, invalid, input)
 }
 
 // PlainToTSQuery implements the plainto_tsquery builtin, which lexes an input,
 // performs stopwording and normalization on the tokens, and returns a parsed
 // query, interposing the & operator between each token.
-func PlainToTSQuery(config string, input string) (TSQuery, error) {
+func PlainToTSQuery(config *Config, input string) (TSQuery, error) {
 	return toTSQuery(config, and, input)
 }
 
 // PhraseToTSQuery implements the phraseto_tsquery builtin, which lexes an input,
 // performs stopwording and normalization on the tokens, and returns a parsed
 // query, interposing the <-> operator between each token.
-func PhraseToTSQuery(config string, input string) (TSQuery, error) {
+func PhraseToTSQuery(config *Config, input string) (TSQuery, error) {
 	return toTSQuery(config, followedby, input)
 }
 
@@ -437,7 +437,7 @@ func PhraseToTSQuery(config string, input string) (TSQuery, error) {
 // performs stopwording and normalization on the tokens, and returns a parsed
 // query. If the interpose operator is not invalid, it's interposed between each
 // token in the input.
-func toTSQuery(config string, interpose tsOperator, input string) (TSQuery, error) {
+func toTSQuery(config *Config, interpose tsOperator, input string) (TSQuery, error) {
 	vector, err := lexTSQuery(input)
 	if err != nil {
 		return TSQuery{}, err
@@ -499,10 +499,7 @@ func toTSQuery(config string, interpose tsOperator, input string) (TSQuery, erro
 				}
 				tokens = append(tokens, term)
 			}
-			lexeme, stopWord, err := TSLexize(config, lexemeTokens[j])
-			if err != nil {
-				return TSQuery{}, err
-			}
+			lexeme, stopWord := config.Lexize(lexemeTokens[j])
 			if stopWord {
 				foundStopwords = true
 			}
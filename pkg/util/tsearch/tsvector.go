@@ -18,7 +18,6 @@ import (
 	"unicode"
 	"unicode/utf8"
 
-	"github.com/blevesearch/snowballstem"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/errors"
@@ -401,40 +400,14 @@ func TSParse(input string) []string {
 	})
 }
 
-// TSLexize implements the "dictionary" construct that's exposed via ts_lexize.
-// It gets invoked once per input token to produce an output lexeme during
-// routines like to_tsvector and to_tsquery.
-// It can return true in the second parameter to indicate a stopword was found.
-func TSLexize(config string, token string) (lexeme string, stopWord bool, err error) {
-	stopwords, ok := stopwordsMap[config]
-	if !ok {
-		return "", false, pgerror.Newf(pgcode.UndefinedObject, "text search configuration %q does not exist", config)
-	}
-
-	lower := strings.ToLower(token)
-	if _, ok := stopwords[lower]; ok {
-		return "", true, nil
-	}
-	stemmer, err := getStemmer(config)
-	if err != nil {
-		return "", false, err
-	}
-	env := snowballstem.NewEnv(lower)
-	stemmer(env)
-	return env.Current(), false, nil
-}
-
 // DocumentToTSVector parses an input document into lexemes, removes stop words,
 // stems and normalizes the lexemes, and returns a TSVector annotated with
-// lexeme positions according to a text search configuration passed by name.
-func DocumentToTSVector(config string, input string) (TSVector, error) {
+// lexeme positions according to a text search configuration.
+func DocumentToTSVector(config *Config, input string) (TSVector, error) {
 	tokens := TSParse(input)
 	vector := make(TSVector, 0, len(tokens))
 	for i := range tokens {
-		lexeme, stopWord, err := TSLexize(config, tokens[i])
-		if err != nil {
-			return nil, err
-		}
+		lexeme, stopWord := config.Lexize(tokens[i])
 		if stopWord {
 			continue
 		}
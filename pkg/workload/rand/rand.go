@@ -431,6 +431,12 @@ func DatumToGoSQL(d tree.Datum) (interface{}, error) {
 		return geo.SpatialObjectToEWKT(d.Geometry.SpatialObject(), 2)
 	case *tree.DPGLSN:
 		return d.LSN.String(), nil
+	case *tree.DJsonpath:
+		return d.Jsonpath.String(), nil
+	case *tree.DGeometric:
+		return d.Shape.String(), nil
+	case *tree.DRange, *tree.DMultirange:
+		return tree.AsStringWithFlags(d, tree.FmtBareStrings), nil
 	case *tree.DTSQuery:
 		return d.String(), nil
 	case *tree.DTSVector: